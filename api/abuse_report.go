@@ -0,0 +1,185 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Report Abuse
+// @Description Report a message, file or user to the workspace admins. Only things the reporter can see can be reported. Anonymous reports hide the reporter from admins.
+// @ID createAbuseReport
+// @Tags reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body service.CreateAbuseReportRequest true "Report"
+// @Success 201 {object} service.AbuseReportResponse "Report submitted"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Report target not found"
+// @Failure 409 {object} map[string]string "Target already reported"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /reports [post]
+func (server *Server) createAbuseReport(ctx *gin.Context) {
+	var req service.CreateAbuseReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	report, err := server.abuseReportService.CreateReport(ctx, currentUser.ID, req)
+	if err != nil {
+		switch {
+		case strings.HasPrefix(err.Error(), "invalid report"):
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "already exists"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, report)
+}
+
+// @Summary List Abuse Reports
+// @Description List a workspace's abuse reports with the given status, oldest first (admin only)
+// @ID listAbuseReports
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param status query string false "Report status (default: open)" Enums(open, resolved, dismissed)
+// @Param limit query int false "Number of reports to return (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of reports to skip (default: 0)" minimum(0)
+// @Success 200 {array} service.AbuseReportResponse "Reports"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/reports [get]
+func (server *Server) listAbuseReports(ctx *gin.Context) {
+	var req service.ListAbuseReportsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	if req.Status == "" {
+		req.Status = service.AbuseReportStatusOpen
+	}
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	reports, err := server.abuseReportService.ListReports(ctx, workspaceID, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+// @Summary Resolve Abuse Report
+// @Description Mark an open report as resolved after acting on it (admin only)
+// @ID resolveAbuseReport
+// @Tags reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param report_id path int true "Report ID"
+// @Param request body service.CloseAbuseReportRequest false "Resolution note"
+// @Success 200 {object} service.AbuseReportResponse "Resolved report"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Report not found"
+// @Failure 409 {object} map[string]string "Report already closed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/reports/{report_id}/resolve [post]
+func (server *Server) resolveAbuseReport(ctx *gin.Context) {
+	server.closeAbuseReport(ctx, service.AbuseReportStatusResolved)
+}
+
+// @Summary Dismiss Abuse Report
+// @Description Dismiss an open report that needs no action (admin only)
+// @ID dismissAbuseReport
+// @Tags reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param report_id path int true "Report ID"
+// @Param request body service.CloseAbuseReportRequest false "Resolution note"
+// @Success 200 {object} service.AbuseReportResponse "Dismissed report"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Report not found"
+// @Failure 409 {object} map[string]string "Report already closed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/reports/{report_id}/dismiss [post]
+func (server *Server) dismissAbuseReport(ctx *gin.Context) {
+	server.closeAbuseReport(ctx, service.AbuseReportStatusDismissed)
+}
+
+// closeAbuseReport resolves or dismisses a report with an optional note
+func (server *Server) closeAbuseReport(ctx *gin.Context, status string) {
+	var req service.CloseAbuseReportRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+			return
+		}
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	reportID, err := strconv.ParseInt(ctx.Param("report_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid report ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	report, err := server.abuseReportService.CloseReport(ctx, workspaceID, reportID, currentUser.ID, status, req.Note)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "already closed"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
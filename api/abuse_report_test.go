@@ -0,0 +1,288 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAbuseReportAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	sender, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	message := db.GetMessageByIDRow{
+		ID:          21,
+		WorkspaceID: workspace.ID,
+		SenderID:    sender.ID,
+		ReceiverID:  sql.NullInt64{Int64: user.ID, Valid: true},
+		Content:     "buy cheap watches",
+		MessageType: "direct",
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"workspace_id": workspace.ID, "target_type": "message", "target_id": message.ID, "reason": "spam"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().
+					CreateAbuseReport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AbuseReport{
+						ID:          1,
+						WorkspaceID: workspace.ID,
+						ReporterID:  sql.NullInt64{Int64: user.ID, Valid: true},
+						TargetType:  "message",
+						TargetID:    message.ID,
+						Reason:      "spam",
+						Status:      service.AbuseReportStatusOpen,
+					}, nil)
+				store.EXPECT().ListWorkspaceAdminIDs(gomock.Any(), gomock.Any()).Times(1).Return([]int64{99}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response service.AbuseReportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, "message", response.TargetType)
+				require.Equal(t, service.AbuseReportStatusOpen, response.Status)
+			},
+		},
+		{
+			name: "MessageNotVisible",
+			body: gin.H{"workspace_id": workspace.ID, "target_type": "message", "target_id": message.ID, "reason": "spam"},
+			buildStubs: func(store *mockdb.MockStore) {
+				other := message
+				other.ReceiverID = sql.NullInt64{Int64: user.ID + 1, Valid: true}
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(other, nil)
+				store.EXPECT().CreateAbuseReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyReported",
+			body: gin.H{"workspace_id": workspace.ID, "target_type": "message", "target_id": message.ID, "reason": "spam"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().
+					CreateAbuseReport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AbuseReport{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotMember",
+			body: gin.H{"workspace_id": workspace.ID, "target_type": "user", "target_id": sender.ID, "reason": "harassment"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("", sql.ErrNoRows)
+				store.EXPECT().CreateAbuseReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidReason",
+			body: gin.H{"workspace_id": workspace.ID, "target_type": "user", "target_id": sender.ID, "reason": "boring"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAbuseReport(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/reports", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCloseAbuseReportAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+
+	report := db.AbuseReport{
+		ID:          4,
+		WorkspaceID: workspace.ID,
+		ReporterID:  sql.NullInt64{Int64: admin.ID + 1, Valid: true},
+		TargetType:  "user",
+		TargetID:    admin.ID + 2,
+		Reason:      "harassment",
+		Anonymous:   true,
+		Status:      service.AbuseReportStatusOpen,
+	}
+
+	testCases := []struct {
+		name          string
+		action        string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Resolve",
+			action: "resolve",
+			body:   gin.H{"note": "Warned the user"},
+			buildStubs: func(store *mockdb.MockStore) {
+				resolved := report
+				resolved.Status = service.AbuseReportStatusResolved
+				resolved.ResolutionNote = "Warned the user"
+				resolved.ResolvedBy = sql.NullInt64{Int64: admin.ID, Valid: true}
+				store.EXPECT().
+					ResolveAbuseReport(gomock.Any(), gomock.Eq(db.ResolveAbuseReportParams{
+						ID:             report.ID,
+						WorkspaceID:    workspace.ID,
+						Status:         service.AbuseReportStatusResolved,
+						ResolvedBy:     sql.NullInt64{Int64: admin.ID, Valid: true},
+						ResolutionNote: "Warned the user",
+					})).
+					Times(1).
+					Return(resolved, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.AbuseReportResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, service.AbuseReportStatusResolved, response.Status)
+				// Anonymous reporters stay hidden from admins
+				require.Nil(t, response.ReporterID)
+			},
+		},
+		{
+			name:   "DismissWithoutNote",
+			action: "dismiss",
+			buildStubs: func(store *mockdb.MockStore) {
+				dismissed := report
+				dismissed.Status = service.AbuseReportStatusDismissed
+				store.EXPECT().
+					ResolveAbuseReport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(dismissed, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "AlreadyClosed",
+			action: "dismiss",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResolveAbuseReport(gomock.Any(), gomock.Any()).Times(1).Return(db.AbuseReport{}, sql.ErrNoRows)
+				store.EXPECT().GetAbuseReport(gomock.Any(), gomock.Any()).Times(1).Return(report, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			action: "resolve",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ResolveAbuseReport(gomock.Any(), gomock.Any()).Times(1).Return(db.AbuseReport{}, sql.ErrNoRows)
+				store.EXPECT().GetAbuseReport(gomock.Any(), gomock.Any()).Times(1).Return(db.AbuseReport{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(admin.Role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body *bytes.Reader
+			if tc.body != nil {
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = bytes.NewReader(data)
+			} else {
+				body = bytes.NewReader(nil)
+			}
+
+			url := fmt.Sprintf("/workspaces/%d/reports/%d/%s", workspace.ID, report.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, body)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
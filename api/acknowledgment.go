@@ -0,0 +1,123 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Acknowledge Message
+// @Description Mark a message that asks for acknowledgment as read (requires access to the message). Acknowledging it again keeps the first acknowledgment. The author is sent a message_acknowledged event.
+// @ID acknowledgeMessage
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Success 200 {object} service.MessageAcknowledgmentStatus "Message acknowledged"
+// @Failure 400 {object} map[string]string "Invalid message ID or the user is the author"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Message or acknowledgment request not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/acknowledgment [post]
+func (server *Server) acknowledgeMessage(ctx *gin.Context) {
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	status, err := server.messageService.AcknowledgeMessage(ctx, messageID, currentUser.ID)
+	if err != nil {
+		if err.Error() == "authors don't acknowledge their own messages" {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		acknowledgmentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+// @Summary Get Message Acknowledgments
+// @Description List who has and hasn't acknowledged a message among the current members of its channel (only the author can see them)
+// @ID getMessageAcknowledgments
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Success 200 {object} service.MessageAcknowledgmentsResponse "Acknowledgments"
+// @Failure 400 {object} map[string]string "Invalid message ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Only the author can see acknowledgments"
+// @Failure 404 {object} map[string]string "Message or acknowledgment request not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/acknowledgments [get]
+func (server *Server) getMessageAcknowledgments(ctx *gin.Context) {
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	acknowledgments, err := server.messageService.GetAcknowledgments(ctx, messageID, currentUser.ID)
+	if err != nil {
+		acknowledgmentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, acknowledgments)
+}
+
+// @Summary Export Message Acknowledgments
+// @Description Download who has and hasn't acknowledged a message as CSV, one row per channel member with user_id, first_name, last_name, email, acknowledged and acknowledged_at (only the author can export them)
+// @ID exportMessageAcknowledgments
+// @Tags messages
+// @Security BearerAuth
+// @Produce text/csv
+// @Param message_id path int true "Message ID"
+// @Success 200 {file} file "Acknowledgments as CSV"
+// @Failure 400 {object} map[string]string "Invalid message ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Only the author can export acknowledgments"
+// @Failure 404 {object} map[string]string "Message or acknowledgment request not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/acknowledgments/export [get]
+func (server *Server) exportMessageAcknowledgments(ctx *gin.Context) {
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	export, err := server.messageService.ExportAcknowledgments(ctx, messageID, currentUser.ID)
+	if err != nil {
+		acknowledgmentErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"message-%d-acknowledgments.csv\"", messageID))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", export)
+}
+
+func acknowledgmentErrorResponse(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcknowledgeMessageAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	author, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	channel := randomChannel(workspace.ID, author.ID)
+	channel.IsPrivate = false
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	message := db.GetMessageByIDRow{
+		ID:          20,
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    author.ID,
+		Content:     "Office closed on Friday",
+		MessageType: "channel",
+		CreatedAt:   time.Now().Add(-time.Hour),
+	}
+	request := db.MessageAcknowledgmentRequest{
+		MessageID:   message.ID,
+		WorkspaceID: workspace.ID,
+		ChannelID:   channel.ID,
+		AuthorID:    author.ID,
+		RemindAt:    time.Now().Add(23 * time.Hour),
+	}
+	acknowledgedAt := time.Now().Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		messageID     string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			messageID: "20",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+				store.EXPECT().GetAcknowledgmentRequest(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(request, nil)
+
+				store.EXPECT().
+					AcknowledgeMessage(gomock.Any(), gomock.Eq(db.AcknowledgeMessageParams{MessageID: message.ID, UserID: user.ID})).
+					Times(1).
+					Return(db.MessageAcknowledgment{MessageID: message.ID, UserID: user.ID, AcknowledgedAt: acknowledgedAt}, nil)
+				store.EXPECT().
+					ListMessageAcknowledgmentStates(gomock.Any(), gomock.Eq(db.ListMessageAcknowledgmentStatesParams{
+						UserID:     user.ID,
+						MessageIds: []int64{message.ID},
+					})).
+					Times(1).
+					Return([]db.ListMessageAcknowledgmentStatesRow{
+						{MessageID: message.ID, RemindAt: request.RemindAt, AcknowledgedCount: 3, AcknowledgedAt: sql.NullTime{Time: acknowledgedAt, Valid: true}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var status service.MessageAcknowledgmentStatus
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+				require.True(t, status.Acknowledged)
+				require.Equal(t, int64(3), status.AcknowledgedCount)
+				require.WithinDuration(t, acknowledgedAt, *status.AcknowledgedAt, time.Second)
+			},
+		},
+		{
+			name:      "NoAcknowledgmentRequested",
+			messageID: "20",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+				store.EXPECT().GetAcknowledgmentRequest(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(db.MessageAcknowledgmentRequest{}, sql.ErrNoRows)
+				store.EXPECT().AcknowledgeMessage(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "MessageNotFound",
+			messageID: "20",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(db.GetMessageByIDRow{}, sql.ErrNoRows)
+				store.EXPECT().AcknowledgeMessage(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidID",
+			messageID: "abc",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/messages/%s/acknowledgment", tc.messageID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestExportMessageAcknowledgmentsAPI(t *testing.T) {
+	author, _ := randomUser(t)
+	member, _ := randomUser(t)
+	workspace := randomWorkspace(author.OrganizationID)
+	channel := randomChannel(workspace.ID, author.ID)
+	channel.IsPrivate = false
+
+	author.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	author.Role = "member"
+	member.WorkspaceID = author.WorkspaceID
+	member.Role = "member"
+
+	message := db.GetMessageByIDRow{
+		ID:          20,
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    author.ID,
+		Content:     "Office closed on Friday",
+		MessageType: "channel",
+	}
+
+	testCases := []struct {
+		name          string
+		user          db.User
+		buildStubs    func(store *mockdb.MockStore, user db.User)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			user: author,
+			buildStubs: func(store *mockdb.MockStore, user db.User) {
+				store.EXPECT().
+					ListAcknowledgmentRecipients(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return([]db.ListAcknowledgmentRecipientsRow{
+						{ID: member.ID, FirstName: "Grace", LastName: "Hopper", Email: "grace@example.com"},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename="message-20-acknowledgments.csv"`, recorder.Header().Get("Content-Disposition"))
+				require.Equal(t, "user_id,first_name,last_name,email,acknowledged,acknowledged_at\n"+
+					fmt.Sprintf("%d,Grace,Hopper,grace@example.com,false,\n", member.ID), recorder.Body.String())
+			},
+		},
+		{
+			name: "NotAuthor",
+			user: member,
+			buildStubs: func(store *mockdb.MockStore, user db.User) {
+				store.EXPECT().ListAcknowledgmentRecipients(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(tc.user.Email)).Times(1).Return(tc.user, nil)
+			store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+			store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+			store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+			store.EXPECT().
+				GetAcknowledgmentRequest(gomock.Any(), gomock.Eq(message.ID)).
+				Times(1).
+				Return(db.MessageAcknowledgmentRequest{MessageID: message.ID, ChannelID: channel.ID, AuthorID: author.ID}, nil)
+			tc.buildStubs(store, tc.user)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/messages/%d/acknowledgments/export", message.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
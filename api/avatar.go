@@ -0,0 +1,107 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Upload Avatar
+// @Description Upload an image as the current user's avatar. The image is cropped to a square and stored in several sizes.
+// @ID uploadAvatar
+// @Tags users
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Avatar image (JPEG, PNG or GIF)"
+// @Success 200 {object} service.AvatarResponse
+// @Failure 400 {object} map[string]string "Invalid image"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/avatar [post]
+func (server *Server) uploadAvatar(ctx *gin.Context) {
+	currentUser := getCurrentUser(ctx)
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid avatar: file is required")))
+		return
+	}
+
+	avatar, err := server.fileService.UploadAvatar(ctx, currentUser.ID, header)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, avatar)
+}
+
+// @Summary Delete Avatar
+// @Description Remove the current user's avatar so their initials are shown instead
+// @ID deleteAvatar
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} service.AvatarResponse
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/avatar [delete]
+func (server *Server) deleteAvatar(ctx *gin.Context) {
+	currentUser := getCurrentUser(ctx)
+
+	avatar, err := server.fileService.DeleteAvatar(ctx, currentUser.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, avatar)
+}
+
+// @Summary Get Avatar
+// @Description Serve an avatar image in one of the stored sizes
+// @ID getAvatar
+// @Tags users
+// @Produce image/png
+// @Param key path string true "Avatar key"
+// @Param size path int true "Size in pixels (32, 72, 192 or 512)"
+// @Success 200 {file} file "Avatar image"
+// @Failure 404 {object} map[string]string "Avatar not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /avatars/{key}/{size} [get]
+func (server *Server) getAvatar(ctx *gin.Context) {
+	size, err := strconv.Atoi(ctx.Param("size"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("avatar not found")))
+		return
+	}
+
+	file, err := server.fileService.OpenAvatar(ctx.Param("key"), size)
+	if err != nil {
+		if err.Error() == "avatar not found" {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+	defer file.Close()
+
+	// Each upload is stored under a new key, so the images never change
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	ctx.Header("Content-Type", "image/png")
+
+	if _, err := io.Copy(ctx.Writer, file); err != nil {
+		fmt.Printf("Error streaming avatar: %v\n", err)
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newAvatarTestServer(t *testing.T, store db.Store) *Server {
+	config := util.Config{
+		TokenSymmetricKey:   util.RandomString(32),
+		AccessTokenDuration: time.Minute,
+		FileStoragePath:     t.TempDir(),
+	}
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	return server
+}
+
+func newAvatarUploadRequest(t *testing.T, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "avatar.png")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	request, err := http.NewRequest(http.MethodPost, "/users/me/avatar", body)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func randomPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, height/2, color.RGBA{B: 255, A: 255})
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestUploadAvatarAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		content       []byte
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			content: randomPNG(t, 640, 480),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					UpdateUserAvatar(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.UpdateUserAvatarParams) (db.User, error) {
+						require.Equal(t, user.ID, arg.ID)
+						require.True(t, arg.AvatarKey.Valid)
+						updated := user
+						updated.AvatarKey = arg.AvatarKey
+						return updated, nil
+					})
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var avatar service.AvatarResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &avatar))
+				require.Len(t, avatar.AvatarURLs, len(service.AvatarSizes))
+				require.NotEmpty(t, avatar.Initials)
+
+				// Every size is served as a square PNG
+				getRecorder := httptest.NewRecorder()
+				request, err := http.NewRequest(http.MethodGet, avatar.AvatarURLs["72"], nil)
+				require.NoError(t, err)
+				server.router.ServeHTTP(getRecorder, request)
+				require.Equal(t, http.StatusOK, getRecorder.Code)
+				require.Equal(t, "image/png", getRecorder.Header().Get("Content-Type"))
+
+				config, err := png.DecodeConfig(getRecorder.Body)
+				require.NoError(t, err)
+				require.Equal(t, 72, config.Width)
+				require.Equal(t, 72, config.Height)
+			},
+		},
+		{
+			name:    "NotAnImage",
+			content: []byte("definitely not an image"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserAvatar(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalError",
+			content: randomPNG(t, 64, 64),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					UpdateUserAvatar(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.User{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newAvatarTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request := newAvatarUploadRequest(t, tc.content)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, server, recorder)
+		})
+	}
+}
+
+func TestDeleteAvatarAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.AvatarKey = sql.NullString{String: "0123456789abcdef0123456789abcdef", Valid: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		UpdateUserAvatar(gomock.Any(), gomock.Eq(db.UpdateUserAvatarParams{ID: user.ID})).
+		Times(1).
+		Return(user, nil)
+
+	server := newAvatarTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodDelete, "/users/me/avatar", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var avatar service.AvatarResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &avatar))
+	require.Empty(t, avatar.AvatarURLs)
+	require.NotEmpty(t, avatar.Initials)
+}
+
+func TestGetAvatarAPINotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newAvatarTestServer(t, mockdb.NewMockStore(ctrl))
+
+	for _, url := range []string{
+		"/avatars/0123456789abcdef0123456789abcdef/72",
+		"/avatars/0123456789abcdef0123456789abcdef/100",
+		"/avatars/..%2Fsecret/72",
+	} {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		server.router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusNotFound, recorder.Code, url)
+	}
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+type listBannersRequest struct {
+	IncludeExpired bool `form:"include_expired"`
+}
+
+// @Summary List Workspace Banners
+// @Description List the workspace's active announcement banners, most recent first. Users who can manage the workspace can include expired banners.
+// @ID listBanners
+// @Tags banners
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param include_expired query bool false "Also list expired banners (requires manage_workspace permission)"
+// @Success 200 {array} service.WorkspaceBannerResponse "Banners"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership or permission required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/banners [get]
+func (server *Server) listBanners(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	var req listBannersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	banners, err := server.bannerService.ListBanners(ctx, workspaceID, currentUser.ID, req.IncludeExpired)
+	if err != nil {
+		handleBannerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, banners)
+}
+
+// @Summary Create Workspace Banner
+// @Description Publish an announcement banner to every member of the workspace (requires manage_workspace permission). Members are sent a banner_updated WebSocket message.
+// @ID createBanner
+// @Tags banners
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.WorkspaceBannerRequest true "Banner"
+// @Success 201 {object} service.WorkspaceBannerResponse "Banner published"
+// @Failure 400 {object} map[string]string "Invalid request, text or expiry"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Permission required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/banners [post]
+func (server *Server) createBanner(ctx *gin.Context) {
+	var req service.WorkspaceBannerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	banner, err := server.bannerService.CreateBanner(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		handleBannerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, banner)
+}
+
+// @Summary Update Workspace Banner
+// @Description Replace the text, severity and expiry of an announcement banner (requires manage_workspace permission)
+// @ID updateBanner
+// @Tags banners
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param banner_id path int true "Banner ID"
+// @Param request body service.WorkspaceBannerRequest true "Banner"
+// @Success 200 {object} service.WorkspaceBannerResponse "Banner updated"
+// @Failure 400 {object} map[string]string "Invalid request, text or expiry"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Permission required"
+// @Failure 404 {object} map[string]string "Banner not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/banners/{banner_id} [put]
+func (server *Server) updateBanner(ctx *gin.Context) {
+	var req service.WorkspaceBannerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	bannerID, err := strconv.ParseInt(ctx.Param("banner_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid banner ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	banner, err := server.bannerService.UpdateBanner(ctx, workspaceID, bannerID, currentUser.ID, req)
+	if err != nil {
+		handleBannerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, banner)
+}
+
+// @Summary Delete Workspace Banner
+// @Description Take an announcement banner down (requires manage_workspace permission)
+// @ID deleteBanner
+// @Tags banners
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param banner_id path int true "Banner ID"
+// @Success 200 {object} map[string]string "Banner deleted"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Permission required"
+// @Failure 404 {object} map[string]string "Banner not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/banners/{banner_id} [delete]
+func (server *Server) deleteBanner(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	bannerID, err := strconv.ParseInt(ctx.Param("banner_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid banner ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	if err := server.bannerService.DeleteBanner(ctx, workspaceID, bannerID, currentUser.ID); err != nil {
+		handleBannerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "banner deleted"})
+}
+
+func handleBannerError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "invalid"):
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
@@ -0,0 +1,345 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBannerAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: "admin",
+			body: gin.H{"text": "  Office closed on Friday  ", "severity": "warning", "expires_at": expiresAt},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateWorkspaceBannerParams{
+					WorkspaceID: workspace.ID,
+					Text:        "Office closed on Friday",
+					Severity:    service.BannerSeverityWarning,
+					ExpiresAt:   sql.NullTime{Time: expiresAt, Valid: true},
+					CreatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+				}
+				store.EXPECT().
+					CreateWorkspaceBanner(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.WorkspaceBanner{
+						ID:          1,
+						WorkspaceID: workspace.ID,
+						Text:        arg.Text,
+						Severity:    arg.Severity,
+						ExpiresAt:   arg.ExpiresAt,
+						CreatedBy:   arg.CreatedBy,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var banner service.WorkspaceBannerResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &banner))
+				require.Equal(t, "Office closed on Friday", banner.Text)
+				require.Equal(t, service.BannerSeverityWarning, banner.Severity)
+				require.True(t, expiresAt.Equal(*banner.ExpiresAt))
+				require.Equal(t, user.ID, *banner.CreatedBy)
+			},
+		},
+		{
+			name: "DefaultSeverity",
+			role: "admin",
+			body: gin.H{"text": "Welcome!"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateWorkspaceBannerParams{
+					WorkspaceID: workspace.ID,
+					Text:        "Welcome!",
+					Severity:    service.BannerSeverityInfo,
+					CreatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+				}
+				store.EXPECT().
+					CreateWorkspaceBanner(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.WorkspaceBanner{ID: 1, WorkspaceID: workspace.ID, Text: arg.Text, Severity: arg.Severity}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidSeverity",
+			role: "admin",
+			body: gin.H{"text": "Welcome!", "severity": "loud"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWorkspaceBanner(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BlankText",
+			role: "admin",
+			body: gin.H{"text": "   "},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWorkspaceBanner(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ExpiryInPast",
+			role: "admin",
+			body: gin.H{"text": "Welcome!", "expires_at": time.Now().Add(-time.Hour)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWorkspaceBanner(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotAdmin",
+			role: "member",
+			body: gin.H{"text": "Welcome!"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserRolePermissions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				store.EXPECT().
+					CreateWorkspaceBanner(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			requestUser := user
+			requestUser.Role = tc.role
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(requestUser, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(tc.role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/banners", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteBannerAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "admin"
+
+	testCases := []struct {
+		name          string
+		rowsAffected  int64
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:         "OK",
+			rowsAffected: 1,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:         "NotFound",
+			rowsAffected: 0,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(user.Role, nil)
+			store.EXPECT().
+				DeleteWorkspaceBanner(gomock.Any(), gomock.Eq(db.DeleteWorkspaceBannerParams{ID: 5, WorkspaceID: workspace.ID})).
+				Times(1).
+				Return(tc.rowsAffected, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/banners/5", workspace.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetWorkspaceBannersAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	banner := db.WorkspaceBanner{
+		ID:          1,
+		WorkspaceID: workspace.ID,
+		Text:        "Maintenance tonight",
+		Severity:    service.BannerSeverityCritical,
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Member",
+			role: "member",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListActiveWorkspaceBanners(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.WorkspaceBanner{banner}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.WorkspaceResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, workspace.ID, response.ID)
+				require.Len(t, response.Banners, 1)
+				require.Equal(t, banner.Text, response.Banners[0].Text)
+				require.Equal(t, banner.Severity, response.Banners[0].Severity)
+			},
+		},
+		{
+			name: "NotMember",
+			role: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListActiveWorkspaceBanners(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.WorkspaceResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Empty(t, response.Banners)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetWorkspaceByID(gomock.Any(), gomock.Eq(workspace.ID)).
+				Times(1).
+				Return(workspace, nil)
+			if tc.role == "" {
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", sql.ErrNoRows)
+			} else {
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(tc.role, nil)
+			}
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d", workspace.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
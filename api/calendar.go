@@ -0,0 +1,166 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Get Calendar Integration
+// @Description Get the current user's calendar integration in a workspace with its upcoming busy blocks
+// @ID getCalendarIntegration
+// @Tags calendar
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.CalendarIntegrationResponse "Calendar integration"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Calendar integration not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/calendar [get]
+func (server *Server) getCalendarIntegration(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	integration, err := server.calendarService.GetCalendarIntegration(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
+
+// @Summary Set Calendar Integration
+// @Description Connect an ICS calendar feed for the current user. While the calendar shows a meeting the user's status is set to "In a meeting" until the meeting ends. Set enabled to false to opt out without removing the feed.
+// @ID setCalendarIntegration
+// @Tags calendar
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.SetCalendarIntegrationRequest true "Calendar feed"
+// @Success 200 {object} service.CalendarIntegrationResponse "Calendar integration"
+// @Failure 400 {object} map[string]string "Invalid request or calendar URL"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/calendar [put]
+func (server *Server) setCalendarIntegration(ctx *gin.Context) {
+	var req service.SetCalendarIntegrationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	integration, err := server.calendarService.SetCalendarIntegration(ctx, currentUser.ID, workspaceID, req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid calendar URL") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
+
+// @Summary Delete Calendar Integration
+// @Description Disconnect the current user's calendar in a workspace
+// @ID deleteCalendarIntegration
+// @Tags calendar
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} map[string]string "Calendar integration deleted"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Calendar integration not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/calendar [delete]
+func (server *Server) deleteCalendarIntegration(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	err = server.calendarService.DeleteCalendarIntegration(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Calendar integration deleted successfully"})
+}
+
+// @Summary Sync Calendar
+// @Description Fetch the current user's calendar feed now instead of waiting for the next background sync
+// @ID syncCalendar
+// @Tags calendar
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.CalendarIntegrationResponse "Synced calendar integration"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Calendar integration not found"
+// @Failure 502 {object} map[string]string "Calendar feed could not be fetched"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/calendar/sync [post]
+func (server *Server) syncCalendar(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	integration, err := server.calendarService.SyncCalendar(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "failed to fetch calendar"), strings.HasPrefix(err.Error(), "failed to read calendar"):
+			ctx.JSON(http.StatusBadGateway, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, integration)
+}
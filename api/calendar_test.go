@@ -0,0 +1,227 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCalendarIntegrationAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	// Make user a member of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"ics_url": "webcal://calendar.example.com/feed.ics",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user.Role, nil)
+
+				arg := db.UpsertCalendarIntegrationParams{
+					UserID:      user.ID,
+					WorkspaceID: workspace.ID,
+					IcsUrl:      "https://calendar.example.com/feed.ics",
+					Enabled:     true,
+				}
+				store.EXPECT().
+					UpsertCalendarIntegration(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CalendarIntegration{
+						ID:          1,
+						UserID:      user.ID,
+						WorkspaceID: workspace.ID,
+						Provider:    "ics",
+						IcsUrl:      arg.IcsUrl,
+						Enabled:     true,
+						CreatedAt:   time.Now(),
+						UpdatedAt:   time.Now(),
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.CalendarIntegrationResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, "https://calendar.example.com/feed.ics", response.ICSURL)
+				require.True(t, response.Enabled)
+			},
+		},
+		{
+			name: "OptOut",
+			body: gin.H{
+				"ics_url": "https://calendar.example.com/feed.ics",
+				"enabled": false,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user.Role, nil)
+
+				arg := db.UpsertCalendarIntegrationParams{
+					UserID:      user.ID,
+					WorkspaceID: workspace.ID,
+					IcsUrl:      "https://calendar.example.com/feed.ics",
+					Enabled:     false,
+				}
+				store.EXPECT().
+					UpsertCalendarIntegration(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CalendarIntegration{ID: 1, WorkspaceID: workspace.ID, IcsUrl: arg.IcsUrl}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidURL",
+			body: gin.H{
+				"ics_url": "ftp://calendar.example.com/feed.ics",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user.Role, nil)
+
+				store.EXPECT().
+					UpsertCalendarIntegration(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotWorkspaceMember",
+			body: gin.H{
+				"ics_url": "https://calendar.example.com/feed.ics",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", sql.ErrNoRows)
+
+				store.EXPECT().
+					UpsertCalendarIntegration(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/calendar", workspace.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteCalendarIntegrationAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	// Make user a member of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	testCases := []struct {
+		name         string
+		rowsAffected int64
+		expectedCode int
+	}{
+		{name: "OK", rowsAffected: 1, expectedCode: http.StatusOK},
+		{name: "NotFound", rowsAffected: 0, expectedCode: http.StatusNotFound},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(user.Role, nil)
+			store.EXPECT().
+				DeleteCalendarIntegration(gomock.Any(), gomock.Eq(db.DeleteCalendarIntegrationParams{
+					UserID:      user.ID,
+					WorkspaceID: workspace.ID,
+				})).
+				Times(1).
+				Return(tc.rowsAffected, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/calendar", workspace.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.expectedCode, recorder.Code)
+		})
+	}
+}
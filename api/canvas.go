@@ -0,0 +1,323 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Create Canvas
+// @Description Create a markdown canvas in a channel (requires channel membership). Channel members receive a canvas_updated WebSocket message.
+// @ID createCanvas
+// @Tags canvases
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Param request body service.CreateCanvasRequest true "Canvas title and content"
+// @Success 201 {object} service.CanvasResponse "Canvas created"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel membership required"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/channels/{channel_id}/canvases [post]
+func (server *Server) createCanvas(ctx *gin.Context) {
+	var req service.CreateCanvasRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, channelID, ok := parseCanvasChannelParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	canvas, err := server.canvasService.CreateCanvas(ctx, workspaceID, channelID, currentUser.ID, req)
+	if err != nil {
+		handleCanvasError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, canvas)
+}
+
+// @Summary List Channel Canvases
+// @Description List a channel's canvases, most recently edited first (requires access to the channel)
+// @ID listChannelCanvases
+// @Tags canvases
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Param limit query int false "Canvases per page (default: 20, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of canvases to skip" minimum(0)
+// @Success 200 {array} service.CanvasResponse "Canvases"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel membership required"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/channels/{channel_id}/canvases [get]
+func (server *Server) listChannelCanvases(ctx *gin.Context) {
+	var req service.ListCanvasesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, channelID, ok := parseCanvasChannelParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	canvases, err := server.canvasService.ListChannelCanvases(ctx, workspaceID, channelID, currentUser.ID, req)
+	if err != nil {
+		handleCanvasError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, canvases)
+}
+
+// @Summary Get Canvas
+// @Description Get a canvas and its current revision (requires access to its channel)
+// @ID getCanvas
+// @Tags canvases
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param canvas_id path int true "Canvas ID"
+// @Success 200 {object} service.CanvasResponse "Canvas"
+// @Failure 400 {object} map[string]string "Invalid workspace or canvas ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel membership required"
+// @Failure 404 {object} map[string]string "Canvas not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/canvases/{canvas_id} [get]
+func (server *Server) getCanvas(ctx *gin.Context) {
+	workspaceID, canvasID, ok := parseCanvasParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	canvas, err := server.canvasService.GetCanvas(ctx, workspaceID, canvasID, currentUser.ID)
+	if err != nil {
+		handleCanvasError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, canvas)
+}
+
+// @Summary Update Canvas
+// @Description Save an edit to a canvas (requires channel membership). base_revision must be the canvas's current revision; if someone else saved first the edit is rejected with 409 and the client should merge with the latest revision and retry. Channel members receive a canvas_updated WebSocket message.
+// @ID updateCanvas
+// @Tags canvases
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param canvas_id path int true "Canvas ID"
+// @Param request body service.UpdateCanvasRequest true "New title and content, and the revision they were based on"
+// @Success 200 {object} service.CanvasResponse "Canvas updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel membership required"
+// @Failure 404 {object} map[string]string "Canvas not found"
+// @Failure 409 {object} map[string]string "The canvas was changed since base_revision"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/canvases/{canvas_id} [put]
+func (server *Server) updateCanvas(ctx *gin.Context) {
+	var req service.UpdateCanvasRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, canvasID, ok := parseCanvasParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	canvas, err := server.canvasService.UpdateCanvas(ctx, workspaceID, canvasID, currentUser.ID, req)
+	if err != nil {
+		handleCanvasError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, canvas)
+}
+
+// @Summary Delete Canvas
+// @Description Delete a canvas and its revisions (requires being its creator, a channel admin, or the manage_channels permission)
+// @ID deleteCanvas
+// @Tags canvases
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param canvas_id path int true "Canvas ID"
+// @Success 200 {object} map[string]string "Canvas deleted"
+// @Failure 400 {object} map[string]string "Invalid workspace or canvas ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Not allowed to delete the canvas"
+// @Failure 404 {object} map[string]string "Canvas not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/canvases/{canvas_id} [delete]
+func (server *Server) deleteCanvas(ctx *gin.Context) {
+	workspaceID, canvasID, ok := parseCanvasParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	if err := server.canvasService.DeleteCanvas(ctx, workspaceID, canvasID, currentUser.ID); err != nil {
+		handleCanvasError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "canvas deleted"})
+}
+
+// @Summary List Canvas Revisions
+// @Description List a canvas's saved revisions, newest first (requires access to its channel)
+// @ID listCanvasRevisions
+// @Tags canvases
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param canvas_id path int true "Canvas ID"
+// @Param limit query int false "Revisions per page (default: 20, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of revisions to skip" minimum(0)
+// @Success 200 {array} service.CanvasRevisionResponse "Revisions"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel membership required"
+// @Failure 404 {object} map[string]string "Canvas not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/canvases/{canvas_id}/revisions [get]
+func (server *Server) listCanvasRevisions(ctx *gin.Context) {
+	var req service.ListCanvasesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, canvasID, ok := parseCanvasParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	revisions, err := server.canvasService.ListRevisions(ctx, workspaceID, canvasID, currentUser.ID, req)
+	if err != nil {
+		handleCanvasError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, revisions)
+}
+
+// @Summary Get Canvas Revision
+// @Description Get one saved revision of a canvas (requires access to its channel)
+// @ID getCanvasRevision
+// @Tags canvases
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param canvas_id path int true "Canvas ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} service.CanvasRevisionResponse "Revision"
+// @Failure 400 {object} map[string]string "Invalid workspace, canvas or revision"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel membership required"
+// @Failure 404 {object} map[string]string "Canvas or revision not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/canvases/{canvas_id}/revisions/{revision} [get]
+func (server *Server) getCanvasRevision(ctx *gin.Context) {
+	workspaceID, canvasID, ok := parseCanvasParams(ctx)
+	if !ok {
+		return
+	}
+
+	revision, err := strconv.ParseInt(ctx.Param("revision"), 10, 32)
+	if err != nil || revision < 1 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid revision")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	saved, err := server.canvasService.GetRevision(ctx, workspaceID, canvasID, int32(revision), currentUser.ID)
+	if err != nil {
+		handleCanvasError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, saved)
+}
+
+// parseCanvasChannelParams reads the workspace and channel IDs from the URL,
+// responding with an error if either is invalid
+func parseCanvasChannelParams(ctx *gin.Context) (workspaceID, channelID int64, ok bool) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return 0, 0, false
+	}
+
+	channelID, err = strconv.ParseInt(ctx.Param("channel_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
+		return 0, 0, false
+	}
+
+	return workspaceID, channelID, true
+}
+
+// parseCanvasParams reads the workspace and canvas IDs from the URL,
+// responding with an error if either is invalid
+func parseCanvasParams(ctx *gin.Context) (workspaceID, canvasID int64, ok bool) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return 0, 0, false
+	}
+
+	canvasID, err = strconv.ParseInt(ctx.Param("canvas_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid canvas ID")))
+		return 0, 0, false
+	}
+
+	return workspaceID, canvasID, true
+}
+
+func handleCanvasError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "canvas revision conflict"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateCanvasAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	channel := db.Channel{ID: 4, WorkspaceID: workspace.ID, Name: "general"}
+	canvas := db.Canvas{ID: 9, WorkspaceID: workspace.ID, ChannelID: channel.ID, Title: "Notes", Content: "old", Revision: 2}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"title": "Notes", "content": "new", "base_revision": 2},
+			buildStubs: func(store *mockdb.MockStore) {
+				updated := canvas
+				updated.Content, updated.Revision = "new", 3
+				store.EXPECT().
+					UpdateCanvasTx(gomock.Any(), gomock.Eq(db.UpdateCanvasParams{
+						ID:        canvas.ID,
+						Revision:  2,
+						Title:     "Notes",
+						Content:   "new",
+						UpdatedBy: sql.NullInt64{Int64: user.ID, Valid: true},
+					})).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.CanvasResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, int32(3), response.Revision)
+				require.Equal(t, "new", response.Content)
+			},
+		},
+		{
+			name: "StaleRevision",
+			body: gin.H{"title": "Notes", "content": "new", "base_revision": 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCanvasTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "MissingBaseRevision",
+			body: gin.H{"title": "Notes", "content": "new"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCanvas(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return("member", nil)
+			store.EXPECT().GetCanvas(gomock.Any(), gomock.Any()).AnyTimes().Return(canvas, nil)
+			store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).AnyTimes().Return(channel, nil)
+			store.EXPECT().IsChannelMember(gomock.Any(), gomock.Any()).AnyTimes().Return(true, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/canvases/%d", workspace.ID, canvas.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
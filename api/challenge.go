@@ -0,0 +1,104 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// challengeResponseHeader carries a client's solution to a CAPTCHA or proof-of-work challenge
+const challengeResponseHeader = "X-Challenge-Response"
+
+// Endpoints that can require a solved challenge, named in CHALLENGE_ENDPOINTS
+const (
+	challengeEndpointRegister           = "register"
+	challengeEndpointLogin              = "login"
+	challengeEndpointCreateOrganization = "create_organization"
+)
+
+var challengeEndpoints = []string{
+	challengeEndpointRegister,
+	challengeEndpointLogin,
+	challengeEndpointCreateOrganization,
+}
+
+// parseChallengeEndpoints returns the endpoints that require a challenge
+func parseChallengeEndpoints(value string) (map[string]bool, error) {
+	endpoints := make(map[string]bool)
+	for _, endpoint := range splitConfigList(value) {
+		known := false
+		for _, name := range challengeEndpoints {
+			known = known || name == endpoint
+		}
+		if !known {
+			return nil, fmt.Errorf("invalid challenge configuration: unknown endpoint %q", endpoint)
+		}
+		endpoints[endpoint] = true
+	}
+	return endpoints, nil
+}
+
+// requireChallenge middleware ensures the client solved a challenge when one is required
+func requireChallenge(verifier service.ChallengeVerifier, required bool) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		if verifier == nil || !required {
+			ctx.Next()
+			return
+		}
+
+		response := strings.TrimSpace(ctx.GetHeader(challengeResponseHeader))
+		if response == "" {
+			err := errors.New("challenge response is required")
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+
+		if err := verifier.Verify(ctx, response, ctx.ClientIP()); err != nil {
+			if errors.Is(err, service.ErrInvalidChallengeResponse) {
+				ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
+				return
+			}
+			// Let clients retry rather than locking them out while the provider is down
+			ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, errorResponse(ctx, err))
+			return
+		}
+
+		ctx.Next()
+	})
+}
+
+// @Summary Get Challenge
+// @Description Get what to solve before using an endpoint that requires a challenge: the site key of a CAPTCHA widget, or a proof-of-work challenge to find a counter for so that SHA-256("<challenge>:<counter>") starts with the given number of zero bits. Send the CAPTCHA token, or "<challenge>:<counter>", in the X-Challenge-Response header. Proof-of-work challenges can be used once.
+// @ID getChallenge
+// @Tags auth
+// @Produce json
+// @Success 200 {object} service.ChallengeResponse "Challenge"
+// @Failure 404 {object} map[string]string "Challenges are disabled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /challenge [get]
+func (server *Server) getChallenge(ctx *gin.Context) {
+	if server.challengeVerifier == nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("challenge not found")))
+		return
+	}
+
+	challenge, err := server.challengeVerifier.NewChallenge()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	challenge.Endpoints = []string{}
+	for _, endpoint := range challengeEndpoints {
+		if server.challengeEndpoints[endpoint] {
+			challenge.Endpoints = append(challenge.Endpoints, endpoint)
+		}
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, challenge)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"math/bits"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengeAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	server, err := NewServer(util.Config{
+		TokenSymmetricKey:      util.RandomString(32),
+		AccessTokenDuration:    time.Minute,
+		ChallengeProvider:      service.ChallengeProviderPoW,
+		ChallengeEndpoints:     "register",
+		ChallengePoWDifficulty: 8,
+		ChallengePoWTTL:        time.Minute,
+	}, store)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/challenge", nil)
+	require.NoError(t, err)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var challenge service.ChallengeResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &challenge))
+	require.Equal(t, service.ChallengeProviderPoW, challenge.Provider)
+	require.Equal(t, []string{"register"}, challenge.Endpoints)
+
+	var solution string
+	for counter := 0; solution == ""; counter++ {
+		response := challenge.Challenge + ":" + strconv.Itoa(counter)
+		hash := sha256.Sum256([]byte(response))
+		if bits.LeadingZeros8(hash[0]) >= challenge.Difficulty {
+			solution = response
+		}
+	}
+
+	// The empty body is only rejected once the challenge has been checked
+	testCases := []struct {
+		name         string
+		url          string
+		response     string
+		expectedCode int
+	}{
+		{name: "Missing", url: "/users", expectedCode: http.StatusForbidden},
+		{name: "Invalid", url: "/users", response: challenge.Challenge + ":0x", expectedCode: http.StatusForbidden},
+		{name: "Solved", url: "/users", response: solution, expectedCode: http.StatusBadRequest},
+		{name: "Reused", url: "/users", response: solution, expectedCode: http.StatusForbidden},
+		{name: "NotRequired", url: "/users/login", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, tc.url, strings.NewReader("{}"))
+			require.NoError(t, err)
+			if tc.response != "" {
+				request.Header.Set(challengeResponseHeader, tc.response)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.expectedCode, recorder.Code)
+		})
+	}
+}
+
+func TestChallengeDisabledAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/challenge", nil)
+	require.NoError(t, err)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+}
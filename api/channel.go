@@ -4,14 +4,16 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	db "github.com/heyrmi/goslack/db/sqlc"
 	"github.com/heyrmi/goslack/service"
-	"github.com/lib/pq"
 )
 
 // @Summary Create Channel
 // @Description Create a new channel in a workspace (requires workspace membership)
+// @ID createChannel
 // @Tags channels
 // @Security BearerAuth
 // @Accept json
@@ -30,13 +32,13 @@ func (server *Server) createChannel(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
 	var req service.CreateChannelRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -44,24 +46,22 @@ func (server *Server) createChannel(ctx *gin.Context) {
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
 		err := fmt.Errorf("user not found in context")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	channel, err := server.channelService.CreateChannel(ctx, user.ID, workspaceID, req)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				ctx.JSON(http.StatusConflict, errorResponse(err))
-				return
-			case "foreign_key_violation":
-				ctx.JSON(http.StatusBadRequest, errorResponse(err))
-				return
-			}
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+			return
+		case db.ForeignKeyViolation:
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -69,7 +69,8 @@ func (server *Server) createChannel(ctx *gin.Context) {
 }
 
 // @Summary Get Channel
-// @Description Retrieve channel information by ID (requires channel access)
+// @Description Retrieve channel information by ID, with the users typing in it (requires channel access)
+// @ID getChannel
 // @Tags channels
 // @Security BearerAuth
 // @Produce json
@@ -84,7 +85,7 @@ func (server *Server) createChannel(ctx *gin.Context) {
 func (server *Server) getChannel(ctx *gin.Context) {
 	var req getChannelRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -92,7 +93,7 @@ func (server *Server) getChannel(ctx *gin.Context) {
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
 		err := fmt.Errorf("user not found in context")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 	user := currentUser.(service.UserResponse)
@@ -100,28 +101,37 @@ func (server *Server) getChannel(ctx *gin.Context) {
 	// Check if user has access to this channel
 	err := server.channelService.CheckChannelAccess(ctx, user.ID, req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
 		return
 	}
 
 	channel, err := server.channelService.GetChannel(ctx, req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 		return
 	}
 
+	// Show who is typing as soon as the channel opens
+	if typing := server.hub.TypingUsers(channel.WorkspaceID, channel.ID); len(typing) > 0 {
+		channel.Typing = typing
+	}
+
 	ctx.JSON(http.StatusOK, channel)
 }
 
 // @Summary List Channels
 // @Description List channels in a workspace (requires workspace membership)
+// @ID listChannels
 // @Tags channels
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "Workspace ID"
 // @Param page_id query int false "Page ID (default: 1)" minimum(1)
 // @Param page_size query int false "Page size (default: 50, max: 50)" minimum(5) maximum(50)
+// @Param fields query string false "Comma-separated fields to return for each channel, e.g. id,name"
+// @Param If-None-Match header string false "ETag of a previously fetched list"
 // @Success 200 {array} service.ChannelResponse "List of channels"
+// @Success 304 "List has not changed"
 // @Failure 400 {object} map[string]string "Invalid request or pagination parameters"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Workspace membership required"
@@ -132,13 +142,13 @@ func (server *Server) listChannels(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
 	var req service.ListChannelsRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -154,7 +164,7 @@ func (server *Server) listChannels(ctx *gin.Context) {
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
 		err := fmt.Errorf("user not found in context")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 	user := currentUser.(service.UserResponse)
@@ -167,15 +177,21 @@ func (server *Server) listChannels(ctx *gin.Context) {
 		(req.PageID-1)*req.PageSize,
 	)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, channels)
+	selected, ok := filterFields(ctx, channels)
+	if !ok {
+		return
+	}
+
+	jsonWithETag(ctx, http.StatusOK, selected)
 }
 
 // @Summary Update Channel
 // @Description Update channel information (requires channel access)
+// @ID updateChannel
 // @Tags channels
 // @Security BearerAuth
 // @Accept json
@@ -192,13 +208,13 @@ func (server *Server) listChannels(ctx *gin.Context) {
 func (server *Server) updateChannel(ctx *gin.Context) {
 	var uriReq getChannelRequest
 	if err := ctx.ShouldBindUri(&uriReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	var req updateChannelRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -206,21 +222,23 @@ func (server *Server) updateChannel(ctx *gin.Context) {
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
 		err := fmt.Errorf("user not found in context")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	channel, err := server.channelService.UpdateChannel(ctx, user.ID, uriReq.ID, req.Name, req.IsPrivate)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				ctx.JSON(http.StatusConflict, errorResponse(err))
-				return
-			}
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+			return
+		}
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -228,7 +246,8 @@ func (server *Server) updateChannel(ctx *gin.Context) {
 }
 
 // @Summary Delete Channel
-// @Description Delete a channel (requires channel access)
+// @Description Delete a channel. Admins can restore it until the recovery window passes, then its content is purged (requires channel access)
+// @ID deleteChannel
 // @Tags channels
 // @Security BearerAuth
 // @Produce json
@@ -237,12 +256,14 @@ func (server *Server) updateChannel(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string "Invalid channel ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Channel access required"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Failure 409 {object} map[string]string "Channel has content under legal hold"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /channels/{id} [delete]
 func (server *Server) deleteChannel(ctx *gin.Context) {
 	var req getChannelRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -250,14 +271,26 @@ func (server *Server) deleteChannel(ctx *gin.Context) {
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
 		err := fmt.Errorf("user not found in context")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	err := server.channelService.DeleteChannel(ctx, user.ID, req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if strings.HasSuffix(err.Error(), "under legal hold") {
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+			return
+		}
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -266,6 +299,7 @@ func (server *Server) deleteChannel(ctx *gin.Context) {
 
 // @Summary Update User Role
 // @Description Update a user's role in their workspace (admin only, same workspace)
+// @ID updateUserRole
 // @Tags users
 // @Security BearerAuth
 // @Accept json
@@ -283,19 +317,30 @@ func (server *Server) updateUserRole(ctx *gin.Context) {
 	targetUserIDStr := ctx.Param("user_id")
 	targetUserID, err := strconv.ParseInt(targetUserIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
 	var req service.UpdateUserRoleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	// The middleware checked both users are in the current user's workspace
+	currentUser := getCurrentUser(ctx)
+	if err := server.checkAdminChange(ctx, *currentUser.WorkspaceID, targetUserID, req.Role == "admin"); err != nil {
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
 	user, err := server.userService.UpdateUserRole(ctx, targetUserID, req.Role)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultChannelStatsDays is how many days channel stats cover unless asked
+const defaultChannelStatsDays = 30
+
+type getChannelStatsRequest struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=365"`
+}
+
+// @Summary Get Channel Stats
+// @Description Get a channel's message counts by day, top posters, reply and thread ratios and last activity (requires channel access). Stats come from rollups refreshed nightly, so the most recent messages may not be counted yet; rolled_up_at tells when they were last refreshed. Days are UTC.
+// @ID getChannelStats
+// @Tags channels
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param days query int false "Number of days up to and including today (default: 30, max: 365)" minimum(1) maximum(365)
+// @Success 200 {object} service.ChannelStatsResponse "Channel stats"
+// @Failure 400 {object} map[string]string "Invalid channel ID or days"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /channels/{id}/stats [get]
+func (server *Server) getChannelStats(ctx *gin.Context) {
+	channelID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
+		return
+	}
+
+	var req getChannelStatsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+	if req.Days == 0 {
+		req.Days = defaultChannelStatsDays
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	if err := server.channelService.CheckChannelAccess(ctx, currentUser.ID, channelID); err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		return
+	}
+
+	stats, err := server.channelStatsService.GetChannelStats(ctx, channelID, req.Days)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChannelStatsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	channel := randomChannel(workspace.ID, user.ID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?days=7",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+				store.EXPECT().
+					ListChannelDailyStats(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListChannelDailyStatsParams) ([]db.ChannelDailyStat, error) {
+						require.Equal(t, channel.ID, arg.ChannelID)
+						require.Equal(t, 7*24*time.Hour, arg.ToDay.Sub(arg.FromDay))
+						return []db.ChannelDailyStat{{ChannelID: channel.ID, Day: arg.FromDay, MessageCount: 5, ReplyCount: 1}}, nil
+					})
+				store.EXPECT().ListChannelTopPosters(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListChannelTopPostersRow{}, nil)
+				store.EXPECT().GetChannelLastActivity(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(db.GetChannelLastActivityRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var stats service.ChannelStatsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &stats))
+				require.Len(t, stats.Days, 7)
+				require.Equal(t, int64(5), stats.MessageCount)
+				require.InDelta(t, 0.2, stats.ReplyRatio, 0.0001)
+				require.Nil(t, stats.LastActivityAt)
+			},
+		},
+		{
+			name:  "InvalidDays",
+			query: "?days=400",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListChannelDailyStats(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoAccess",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("", sql.ErrNoRows)
+				store.EXPECT().ListChannelDailyStats(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/channels/%d/stats%s", channel.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
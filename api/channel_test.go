@@ -64,16 +64,22 @@ func TestCreateChannelAPI(t *testing.T) {
 					Times(1).
 					Return(user.Role, nil)
 
-				arg := db.CreateChannelParams{
-					WorkspaceID: workspace.ID,
-					Name:        channel.Name,
-					IsPrivate:   channel.IsPrivate,
-					CreatedBy:   user.ID,
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				arg := db.CreateChannelTxParams{
+					WorkspaceID:   workspace.ID,
+					Name:          channel.Name,
+					IsPrivate:     channel.IsPrivate,
+					CreatedBy:     user.ID,
+					SystemMessage: fmt.Sprintf("%s %s created the channel", user.FirstName, user.LastName),
 				}
 				store.EXPECT().
-					CreateChannel(gomock.Any(), gomock.Eq(arg)).
+					CreateChannelTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
-					Return(channel, nil)
+					Return(db.CreateChannelTxResult{Channel: channel}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusCreated, recorder.Code)
@@ -154,9 +160,14 @@ func TestCreateChannelAPI(t *testing.T) {
 					Return(user.Role, nil)
 
 				store.EXPECT().
-					CreateChannel(gomock.Any(), gomock.Any()).
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CreateChannelTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(db.Channel{}, sql.ErrConnDone)
+					Return(db.CreateChannelTxResult{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
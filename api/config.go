@@ -0,0 +1,69 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+)
+
+// adminTokenHeader carries the operator token for /admin routes
+const adminTokenHeader = "X-Admin-Token"
+
+// requireAdminToken middleware ensures the request carries the operator token.
+// The routes don't exist when no token is configured.
+func requireAdminToken(expected string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		if expected == "" {
+			ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(ctx, errors.New("admin routes are disabled")))
+			return
+		}
+
+		token := ctx.GetHeader(adminTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, errors.New("invalid admin token")))
+			return
+		}
+
+		ctx.Next()
+	})
+}
+
+// ReloadTunables applies the tunable settings of a reloaded configuration.
+// Requests and WebSocket connections pick them up as they go; settings
+// read once per connection apply to new connections.
+func (server *Server) ReloadTunables(config util.Config) util.TunablesSnapshot {
+	return server.tunables.Update(config)
+}
+
+// @Summary Get Effective Configuration
+// @Description Get the reloadable settings the server currently runs with and when they were loaded. Send SIGHUP to the server to reload them from app.env, the environment and the secrets backend. Requires the X-Admin-Token header.
+// @ID getEffectiveConfig
+// @Tags system
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Success 200 {object} service.EffectiveConfigResponse "Effective configuration"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Admin routes are disabled"
+// @Router /admin/config [get]
+func (server *Server) getEffectiveConfig(ctx *gin.Context) {
+	snapshot := server.tunables.Snapshot()
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, service.EffectiveConfigResponse{
+		HTTPMaxBodySize:         snapshot.HTTPMaxBodySize,
+		FileMaxSize:             snapshot.FileMaxSize,
+		FileAllowedTypes:        splitConfigList(snapshot.FileAllowedTypes),
+		WSReadBufferSize:        snapshot.WSReadBufferSize,
+		WSWriteBufferSize:       snapshot.WSWriteBufferSize,
+		WSMaxConnectionsPerUser: snapshot.WSMaxConnectionsPerUser,
+		WSMaxMessageSize:        snapshot.WSMaxMessageSize,
+		WSPingInterval:          snapshot.WSPingInterval.String(),
+		WSPongTimeout:           snapshot.WSPongTimeout.String(),
+		WSDraftDebounce:         snapshot.WSDraftDebounce.String(),
+		LoadedAt:                snapshot.LoadedAt,
+	})
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEffectiveConfigAPI(t *testing.T) {
+	testCases := []struct {
+		name          string
+		adminToken    string
+		requestToken  string
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:         "OK",
+			adminToken:   "operator",
+			requestToken: "operator",
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.EffectiveConfigResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, int64(2048), response.HTTPMaxBodySize)
+				require.Equal(t, []string{"image/png", "text/plain"}, response.FileAllowedTypes)
+				require.Equal(t, "1m0s", response.WSPongTimeout)
+			},
+		},
+		{
+			name:         "InvalidToken",
+			adminToken:   "operator",
+			requestToken: "guess",
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:         "Disabled",
+			requestToken: "",
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := util.Config{
+				TokenSymmetricKey:   util.RandomString(32),
+				AccessTokenDuration: time.Minute,
+				AdminAPIToken:       tc.adminToken,
+				HTTPMaxBodySize:     2048,
+				FileAllowedTypes:    "image/png, text/plain",
+				WSPongTimeout:       time.Minute,
+			}
+			server, err := NewServer(config, mockdb.NewMockStore(ctrl))
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/admin/config", nil)
+			require.NoError(t, err)
+			request.Header.Set(adminTokenHeader, tc.requestToken)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDebugVarsAPI(t *testing.T) {
+	testCases := []struct {
+		name         string
+		adminToken   string
+		requestToken string
+		wantStatus   int
+	}{
+		{name: "OK", adminToken: "operator", requestToken: "operator", wantStatus: http.StatusOK},
+		{name: "NoToken", adminToken: "operator", requestToken: "", wantStatus: http.StatusUnauthorized},
+		{name: "InvalidToken", adminToken: "operator", requestToken: "guess", wantStatus: http.StatusUnauthorized},
+		{name: "Disabled", requestToken: "", wantStatus: http.StatusNotFound},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := util.Config{
+				TokenSymmetricKey:   util.RandomString(32),
+				AccessTokenDuration: time.Minute,
+				AdminAPIToken:       tc.adminToken,
+			}
+			server, err := NewServer(config, mockdb.NewMockStore(ctrl))
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/debug/vars", nil)
+			require.NoError(t, err)
+			if tc.requestToken != "" {
+				request.Header.Set(adminTokenHeader, tc.requestToken)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+			if tc.wantStatus == http.StatusOK {
+				require.Contains(t, recorder.Body.String(), "websocket_batching")
+			}
+		})
+	}
+}
+
+func TestReloadTunables(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).AnyTimes().Return(user, nil)
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return(user.Role, nil)
+
+	config := util.Config{
+		TokenSymmetricKey:   util.RandomString(32),
+		AccessTokenDuration: time.Minute,
+		HTTPMaxBodySize:     1 << 20,
+	}
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	// The body fails validation before anything is stored
+	send := func() int {
+		body := fmt.Sprintf(`{"content":42,"padding":%q}`, strings.Repeat("a", 100))
+		url := fmt.Sprintf("/workspace/%d/channels/1/messages", workspace.ID)
+		request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", gin.MIMEJSON)
+		addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+	require.Equal(t, http.StatusBadRequest, send())
+
+	// A lower limit applies to the next request, and to the hub and file service
+	config.HTTPMaxBodySize = 64
+	config.WSMaxConnectionsPerUser = 2
+	snapshot := server.ReloadTunables(config)
+	require.Equal(t, int64(64), snapshot.HTTPMaxBodySize)
+	require.Equal(t, 2, server.hub.tunables.Load().WSMaxConnectionsPerUser)
+	require.Equal(t, http.StatusRequestEntityTooLarge, send())
+}
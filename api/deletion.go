@@ -0,0 +1,154 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List Deleted Channels
+// @Description List a workspace's deleted channels that can still be restored, with when each will be purged (requires manage_channels permission)
+// @ID listDeletedChannels
+// @Tags channels
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.DeletedChannelResponse "Deleted channels"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_channels permission required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/deleted-channels [get]
+func (server *Server) listDeletedChannels(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	channels, err := server.deletionService.ListDeletedChannels(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channels)
+}
+
+// @Summary Restore Channel
+// @Description Restore a deleted channel with its members and messages during the recovery window (requires manage_channels permission)
+// @ID restoreChannel
+// @Tags channels
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Success 200 {object} service.ChannelResponse "Restored channel"
+// @Failure 400 {object} map[string]string "Invalid workspace or channel ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_channels permission required"
+// @Failure 404 {object} map[string]string "Deleted channel not found or already purged"
+// @Failure 409 {object} map[string]string "Channel name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/deleted-channels/{channel_id}/restore [post]
+func (server *Server) restoreChannel(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	channelID, err := strconv.ParseInt(ctx.Param("channel_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
+		return
+	}
+
+	channel, err := server.deletionService.RestoreChannel(ctx, workspaceID, channelID)
+	if err != nil {
+		handleDeletionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channel)
+}
+
+// @Summary List Deleted Workspaces
+// @Description List an organization's deleted workspaces that can still be restored, with when each will be purged (organization admin only)
+// @ID listDeletedWorkspaces
+// @Tags workspaces
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {array} service.DeletedWorkspaceResponse "Deleted workspaces"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/deleted-workspaces [get]
+func (server *Server) listDeletedWorkspaces(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	workspaces, err := server.deletionService.ListDeletedWorkspaces(ctx, organizationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workspaces)
+}
+
+// @Summary Restore Workspace
+// @Description Restore a deleted workspace with its members, channels and messages during the recovery window (organization admin only)
+// @ID restoreWorkspace
+// @Tags workspaces
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {object} service.WorkspaceResponse "Restored workspace"
+// @Failure 400 {object} map[string]string "Invalid organization or workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "Deleted workspace not found or already purged"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/deleted-workspaces/{workspace_id}/restore [post]
+func (server *Server) restoreWorkspace(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("workspace_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	workspace, err := server.deletionService.RestoreWorkspace(ctx, organizationID, workspaceID)
+	if err != nil {
+		handleDeletionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workspace)
+}
+
+func handleDeletionError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "already exists"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
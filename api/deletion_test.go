@@ -0,0 +1,215 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteChannelAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+	channel := randomChannel(workspace.ID, admin.ID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).Times(1).Return(admin, nil)
+	store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return("admin", nil)
+	store.EXPECT().ChannelHasActiveLegalHold(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(false, nil)
+	// The channel is kept for the recovery window rather than removed
+	store.EXPECT().
+		SoftDeleteChannel(gomock.Any(), gomock.Eq(db.SoftDeleteChannelParams{
+			ID:        channel.ID,
+			DeletedBy: sql.NullInt64{Int64: admin.ID, Valid: true},
+		})).
+		Times(1).
+		Return(int64(1), nil)
+	store.EXPECT().DeleteChannel(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/channels/%d", channel.ID)
+	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestRestoreChannelAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+	channel := randomChannel(workspace.ID, admin.ID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreChannel(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.RestoreChannelParams) (db.Channel, error) {
+						require.Equal(t, channel.ID, arg.ID)
+						require.Equal(t, workspace.ID, arg.WorkspaceID)
+						// Only deletions inside the recovery window qualify
+						require.WithinDuration(t, time.Now().Add(-720*time.Hour), arg.DeletedAfter, time.Minute)
+						return channel, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.ChannelResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, channel.ID, response.ID)
+				require.Equal(t, channel.Name, response.Name)
+			},
+		},
+		{
+			name: "Purged",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreChannel(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Channel{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NameTaken",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RestoreChannel(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Channel{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).Times(1).Return(admin, nil)
+			store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/deleted-channels/%d/restore", workspace.ID, channel.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListDeletedWorkspacesAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+	deletedAt := time.Now().Add(-time.Hour)
+	workspace.DeletedAt = sql.NullTime{Time: deletedAt, Valid: true}
+	workspace.DeletedBy = sql.NullInt64{Int64: admin.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		orgRole       string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			orgRole: service.OrganizationRoleOwner,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListDeletedWorkspaces(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.Workspace{workspace}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response []service.DeletedWorkspaceResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response, 1)
+				require.Equal(t, workspace.ID, response[0].ID)
+				require.NotNil(t, response[0].DeletedBy)
+				require.WithinDuration(t, deletedAt.Add(720*time.Hour), response[0].PurgeAt, time.Second)
+			},
+		},
+		{
+			name:    "NotOrganizationAdmin",
+			orgRole: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListDeletedWorkspaces(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).Times(1).Return(admin, nil)
+			orgRoleStub(store, admin, tc.orgRole)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/organizations/%d/deleted-workspaces", admin.OrganizationID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// recordLogin adds a login attempt to the login audit. Failing to record it
+// doesn't fail the login, so errors are only logged.
+func (server *Server) recordLogin(ctx context.Context, attempt service.LoginAttempt) *service.UserDeviceResponse {
+	device, err := server.deviceService.RecordLogin(ctx, attempt)
+	if err != nil {
+		fmt.Printf("Error recording login for %s: %v\n", attempt.Email, err)
+		return nil
+	}
+	return device
+}
+
+// @Summary List Devices
+// @Description List the devices the current user has signed in from, most recently seen first
+// @ID listDevices
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} service.UserDeviceResponse "Devices"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/devices [get]
+func (server *Server) listDevices(ctx *gin.Context) {
+	currentUser := getCurrentUser(ctx)
+
+	devices, err := server.deviceService.ListDevices(ctx, currentUser.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, devices)
+}
+
+// @Summary Update Device
+// @Description Name one of the current user's devices or change whether it's trusted. Trusted devices skip two-factor authentication for 30 days; trusting a device again extends it.
+// @ID updateDevice
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param device_id path int true "Device ID"
+// @Param request body service.UpdateUserDeviceRequest true "Device name and trust"
+// @Success 200 {object} service.UserDeviceResponse "Device updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "Device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/devices/{device_id} [put]
+func (server *Server) updateDevice(ctx *gin.Context) {
+	var req service.UpdateUserDeviceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	deviceID, err := strconv.ParseInt(ctx.Param("device_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid device ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	device, err := server.deviceService.UpdateDevice(ctx, currentUser.ID, deviceID, req)
+	if err != nil {
+		handleDeviceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, device)
+}
+
+// @Summary Revoke Device
+// @Description Forget one of the current user's devices, so it's no longer trusted and shows up as a new device the next time it's used to sign in
+// @ID revokeDevice
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param device_id path int true "Device ID"
+// @Success 200 {object} map[string]string "Device revoked"
+// @Failure 400 {object} map[string]string "Invalid device ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "Device not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/devices/{device_id} [delete]
+func (server *Server) revokeDevice(ctx *gin.Context) {
+	deviceID, err := strconv.ParseInt(ctx.Param("device_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid device ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	if err := server.deviceService.RevokeDevice(ctx, currentUser.ID, deviceID); err != nil {
+		handleDeviceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "device revoked"})
+}
+
+// @Summary List Login Events
+// @Description List the current user's successful and failed login attempts, most recent first
+// @ID listLoginEvents
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Number of events to return (1-100, default 50)"
+// @Param offset query int false "Number of events to skip"
+// @Success 200 {array} service.LoginEventResponse "Login events"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/me/login-events [get]
+func (server *Server) listLoginEvents(ctx *gin.Context) {
+	var req service.ListLoginEventsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	events, err := server.deviceService.ListLoginEvents(ctx, currentUser.ID, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, events)
+}
+
+func handleDeviceError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "invalid"):
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
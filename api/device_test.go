@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateDeviceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	device := db.UserDevice{ID: 3, UserID: user.ID, Name: "Laptop"}
+
+	testCases := []struct {
+		name          string
+		url           string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			url:  "/users/me/devices/3",
+			body: gin.H{"trusted": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserDevice(gomock.Any(), gomock.Eq(db.GetUserDeviceParams{ID: device.ID, UserID: user.ID})).
+					Times(1).
+					Return(device, nil)
+				store.EXPECT().
+					UpdateUserDevice(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpdateUserDeviceParams) (db.UserDevice, error) {
+						updated := device
+						updated.TrustedUntil = arg.TrustedUntil
+						return updated, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp service.UserDeviceResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, rsp.Trusted)
+				require.WithinDuration(t, time.Now().Add(service.TrustedDeviceDuration), *rsp.TrustedUntil, time.Minute)
+			},
+		},
+		{
+			name: "NotFound",
+			url:  "/users/me/devices/4",
+			body: gin.H{"name": "Phone"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserDevice(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserDevice{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidID",
+			url:  "/users/me/devices/abc",
+			body: gin.H{"name": "Phone"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserDevice(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
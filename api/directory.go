@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type searchDirectoryRequest struct {
+	Query  string `form:"q" binding:"max=100"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32  `form:"offset" binding:"omitempty,min=0"`
+}
+
+// @Summary Search Organization Directory
+// @Description List the people of the organization across all its workspaces, with their profile cards and presence. Matches name, handle and title; organization admins can also search by email. Emails and phone numbers are only shown to organization admins and to people in the same workspace.
+// @ID searchDirectory
+// @Tags organizations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param q query string false "Search text; empty lists everyone"
+// @Param limit query int false "Page size (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of people to skip" minimum(0)
+// @Success 200 {object} service.DirectoryResponse "People"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Not a member of the organization"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/directory [get]
+func (server *Server) searchDirectory(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	var req searchDirectoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	directory, err := server.directoryService.SearchDirectory(ctx, organizationID, currentUser, req.Query, req.Limit, req.Offset)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, directory)
+}
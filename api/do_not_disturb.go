@@ -0,0 +1,200 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Get Do Not Disturb
+// @Description Get the current user's Do Not Disturb state and whether urgent messages may break through it
+// @ID getDoNotDisturb
+// @Tags status
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.DoNotDisturbResponse "Do Not Disturb state"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/dnd [get]
+func (server *Server) getDoNotDisturb(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	dnd, err := server.doNotDisturbService.GetDoNotDisturb(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dnd)
+}
+
+// @Summary Set Do Not Disturb
+// @Description Turn Do Not Disturb on until dnd_until, or off when dnd_until is omitted, set a daily schedule in the user's timezone, and choose whether senders may notify anyway for urgent messages
+// @ID setDoNotDisturb
+// @Tags status
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.SetDoNotDisturbRequest true "Do Not Disturb settings"
+// @Success 200 {object} service.DoNotDisturbResponse "Do Not Disturb state"
+// @Failure 400 {object} map[string]string "Invalid request or schedule"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/dnd [put]
+func (server *Server) setDoNotDisturb(ctx *gin.Context) {
+	var req service.SetDoNotDisturbRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	dnd, err := server.doNotDisturbService.SetDoNotDisturb(ctx, currentUser.ID, workspaceID, req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "dnd_until") || strings.HasPrefix(err.Error(), "invalid") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dnd)
+}
+
+// @Summary Notify Anyway
+// @Description Confirm an urgent direct message to a recipient in Do Not Disturb so it notifies them anyway (only the sender, once per message, and only if the recipient allows it)
+// @ID notifyAnyway
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Success 200 {object} service.MessageResponse "Recipient notified"
+// @Failure 400 {object} map[string]string "Invalid message ID or message is not a direct message"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Not the sender or recipient does not allow urgent notifications"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 409 {object} map[string]string "Recipient is not in Do Not Disturb or was already notified"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/notify-anyway [post]
+func (server *Server) notifyAnyway(ctx *gin.Context) {
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	message, err := server.messageService.NotifyAnyway(ctx, messageID, currentUser.ID)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "only direct messages"):
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "recipient is not"), strings.HasSuffix(err.Error(), "already sent"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, message)
+}
+
+// @Summary Snooze Notifications
+// @Description Pause the current user's notifications for a while. The snooze turns Do Not Disturb on until it expires, shows as a z-z icon in the user's presence and clears itself.
+// @ID snoozeNotifications
+// @Tags status
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.SnoozeNotificationsRequest true "Snooze duration"
+// @Success 200 {object} service.DoNotDisturbResponse "Do Not Disturb state"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notifications/snooze [post]
+func (server *Server) snoozeNotifications(ctx *gin.Context) {
+	var req service.SnoozeNotificationsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	dnd, err := server.doNotDisturbService.Snooze(ctx, currentUser.ID, workspaceID, time.Duration(req.DurationMinutes)*time.Minute)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dnd)
+}
+
+// @Summary End Notification Snooze
+// @Description Resume the current user's notifications before their snooze expires. A Do Not Disturb schedule still applies.
+// @ID endNotificationSnooze
+// @Tags status
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.DoNotDisturbResponse "Do Not Disturb state"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notifications/snooze [delete]
+func (server *Server) endNotificationSnooze(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	dnd, err := server.doNotDisturbService.EndSnooze(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dnd)
+}
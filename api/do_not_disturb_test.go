@@ -0,0 +1,429 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDoNotDisturbAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	// Make user a member of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	dndUntil := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "KeepsOverrideSetting",
+			body: gin.H{
+				"dnd_until": dndUntil,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetDoNotDisturbRow{UserID: user.ID, WorkspaceID: workspace.ID, AllowUrgentOverride: false, ScheduleDays: []int32{}, Timezone: "UTC"}, nil)
+
+				arg := db.UpsertDoNotDisturbParams{
+					UserID:              user.ID,
+					WorkspaceID:         workspace.ID,
+					DndUntil:            sql.NullTime{Time: dndUntil, Valid: true},
+					AllowUrgentOverride: false,
+					ScheduleDays:        []int32{},
+				}
+				store.EXPECT().
+					UpsertDoNotDisturb(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.UpsertDoNotDisturbRow{
+						UserID:              user.ID,
+						WorkspaceID:         workspace.ID,
+						DndUntil:            arg.DndUntil,
+						AllowUrgentOverride: false,
+						ScheduleDays:        arg.ScheduleDays,
+						Timezone:            "UTC",
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.DoNotDisturbResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.True(t, response.Active)
+				require.False(t, response.AllowUrgentOverride)
+			},
+		},
+		{
+			name: "SetsSchedule",
+			body: gin.H{
+				"schedule": gin.H{"start": "22:00", "end": "08:00", "days": []string{"fri", "mon"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetDoNotDisturbRow{}, sql.ErrNoRows)
+
+				arg := db.UpsertDoNotDisturbParams{
+					UserID:              user.ID,
+					WorkspaceID:         workspace.ID,
+					AllowUrgentOverride: true,
+					ScheduleStartMinute: sql.NullInt32{Int32: 22 * 60, Valid: true},
+					ScheduleEndMinute:   sql.NullInt32{Int32: 8 * 60, Valid: true},
+					ScheduleDays:        []int32{1, 5},
+				}
+				store.EXPECT().
+					UpsertDoNotDisturb(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.UpsertDoNotDisturbRow{
+						UserID:              user.ID,
+						WorkspaceID:         workspace.ID,
+						AllowUrgentOverride: true,
+						ScheduleStartMinute: arg.ScheduleStartMinute,
+						ScheduleEndMinute:   arg.ScheduleEndMinute,
+						ScheduleDays:        arg.ScheduleDays,
+						Timezone:            "Europe/Berlin",
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.DoNotDisturbResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, &service.DoNotDisturbSchedule{Start: "22:00", End: "08:00", Days: []string{"mon", "fri"}}, response.Schedule)
+				require.Equal(t, "Europe/Berlin", response.Timezone)
+			},
+		},
+		{
+			name: "InvalidSchedule",
+			body: gin.H{
+				"schedule": gin.H{"start": "24:00", "end": "08:00"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UntilInPast",
+			body: gin.H{
+				"dnd_until": time.Now().Add(-time.Hour),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(user.Role, nil)
+			// Users without a status have no presence to update
+			store.EXPECT().
+				GetUserStatus(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return(db.UserStatus{}, sql.ErrNoRows)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/dnd", workspace.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestNotifyAnywayAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	receiver, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	message := randomDirectMessage(workspace.ID, user.ID, receiver.ID)
+	messageRow := db.GetMessageByIDRow{
+		ID:          message.ID,
+		WorkspaceID: message.WorkspaceID,
+		SenderID:    message.SenderID,
+		ReceiverID:  message.ReceiverID,
+		Content:     message.Content,
+		MessageType: message.MessageType,
+		CreatedAt:   message.CreatedAt,
+		SenderEmail: user.Email,
+	}
+	receivedRow := messageRow
+	receivedRow.SenderID = receiver.ID
+	receivedRow.ReceiverID = sql.NullInt64{Int64: user.ID, Valid: true}
+
+	activeDND := db.GetDoNotDisturbRow{
+		UserID:              receiver.ID,
+		WorkspaceID:         workspace.ID,
+		DndUntil:            sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		AllowUrgentOverride: true,
+		Timezone:            "UTC",
+	}
+
+	testCases := []struct {
+		name         string
+		buildStubs   func(store *mockdb.MockStore)
+		expectedCode int
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(messageRow, nil)
+				store.EXPECT().
+					GetDoNotDisturb(gomock.Any(), gomock.Eq(db.GetDoNotDisturbParams{
+						UserID:      receiver.ID,
+						WorkspaceID: workspace.ID,
+					})).
+					Times(1).
+					Return(activeDND, nil)
+				store.EXPECT().
+					CreateDNDOverride(gomock.Any(), gomock.Eq(db.CreateDNDOverrideParams{
+						MessageID:  message.ID,
+						SenderID:   user.ID,
+						ReceiverID: receiver.ID,
+					})).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "NotSender",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(receivedRow, nil)
+				store.EXPECT().GetDoNotDisturb(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "RecipientNotInDND",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(messageRow, nil)
+				store.EXPECT().GetDoNotDisturb(gomock.Any(), gomock.Any()).Times(1).Return(db.GetDoNotDisturbRow{}, sql.ErrNoRows)
+				store.EXPECT().CreateDNDOverride(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name: "OverrideNotAllowed",
+			buildStubs: func(store *mockdb.MockStore) {
+				blocked := activeDND
+				blocked.AllowUrgentOverride = false
+
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(messageRow, nil)
+				store.EXPECT().GetDoNotDisturb(gomock.Any(), gomock.Any()).Times(1).Return(blocked, nil)
+				store.EXPECT().CreateDNDOverride(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "AlreadyNotified",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(messageRow, nil)
+				store.EXPECT().GetDoNotDisturb(gomock.Any(), gomock.Any()).Times(1).Return(activeDND, nil)
+				store.EXPECT().CreateDNDOverride(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name: "MessageNotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(db.GetMessageByIDRow{}, sql.ErrNoRows)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/messages/%d/notify-anyway", message.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.expectedCode, recorder.Code)
+		})
+	}
+}
+
+func TestSnoozeNotificationsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"duration_minutes": 120,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetDoNotDisturbRow{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpsertDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg db.UpsertDoNotDisturbParams) (db.UpsertDoNotDisturbRow, error) {
+						require.WithinDuration(t, time.Now().Add(2*time.Hour), arg.DndUntil.Time, time.Minute)
+						return db.UpsertDoNotDisturbRow{
+							UserID:              arg.UserID,
+							WorkspaceID:         arg.WorkspaceID,
+							DndUntil:            arg.DndUntil,
+							AllowUrgentOverride: arg.AllowUrgentOverride,
+							ScheduleDays:        arg.ScheduleDays,
+							Timezone:            "UTC",
+						}, nil
+					})
+
+				// The snooze shows in the user's presence
+				store.EXPECT().
+					GetUserStatus(gomock.Any(), gomock.Eq(db.GetUserStatusParams{UserID: user.ID, WorkspaceID: workspace.ID})).
+					Times(1).
+					Return(db.UserStatus{UserID: user.ID, WorkspaceID: workspace.ID, Status: "online"}, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().
+					GetDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetDoNotDisturbRow{UserID: user.ID, DndUntil: sql.NullTime{Time: time.Now().Add(2 * time.Hour), Valid: true}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.DoNotDisturbResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.True(t, response.Active)
+			},
+		},
+		{
+			name: "DurationTooLong",
+			body: gin.H{
+				"duration_minutes": 10081,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(user.Role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/notifications/snooze", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
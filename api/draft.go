@@ -0,0 +1,133 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Drafts
+// @Description List the current user's unsent message drafts in a workspace, most recently changed first
+// @ID listDrafts
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.DraftResponse "Drafts"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/drafts [get]
+func (server *Server) listDrafts(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	drafts, err := server.draftService.ListDrafts(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, drafts)
+}
+
+// @Summary Save Draft
+// @Description Save the current user's draft for a channel or direct conversation, optionally in a thread. The draft is sent to the user's other WebSocket connections as a draft_updated event, except the one given by connection_id. Saving empty content deletes the draft. Clients connected over WebSocket can send draft_update messages instead, which are saved once the user stops typing.
+// @ID saveDraft
+// @Tags messages
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.SaveDraftRequest true "Draft"
+// @Success 200 {object} service.DraftResponse "Draft"
+// @Success 204 "Draft deleted"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace or channel membership required"
+// @Failure 404 {object} map[string]string "Channel or thread not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/drafts [put]
+func (server *Server) saveDraft(ctx *gin.Context) {
+	var req service.SaveDraftRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	draft, err := server.draftService.SaveDraft(ctx, currentUser.ID, workspaceID, req)
+	if err != nil {
+		switch {
+		case strings.HasPrefix(err.Error(), "invalid draft"), strings.HasSuffix(err.Error(), "not a member of the workspace"):
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	if draft == nil {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, draft)
+}
+
+// @Summary Delete Draft
+// @Description Delete one of the current user's drafts, e.g. once it's sent. The user's other WebSocket connections get a draft_deleted event, except the one given by connection_id.
+// @ID deleteDraft
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param draft_id path int true "Draft ID"
+// @Param connection_id query string false "WebSocket connection making the change"
+// @Success 200 {object} map[string]string "Draft deleted"
+// @Failure 400 {object} map[string]string "Invalid draft ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "Draft not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /drafts/{draft_id} [delete]
+func (server *Server) deleteDraft(ctx *gin.Context) {
+	draftID, err := strconv.ParseInt(ctx.Param("draft_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid draft ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	err = server.draftService.DeleteDraft(ctx, draftID, currentUser.ID, ctx.Query("connection_id"))
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Draft deleted successfully"})
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// emailTemplateErrorResponse responds with the status matching an email
+// template service error
+func emailTemplateErrorResponse(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "invalid email template"):
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
+
+// @Summary List Email Templates
+// @Description List the template the organization uses for each kind of email, with the variables each template can use (organization admin only)
+// @ID listEmailTemplates
+// @Tags email-templates
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {array} service.EmailTemplateResponse "Email templates"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/email-templates [get]
+func (server *Server) listEmailTemplates(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	templates, err := server.emailTemplateService.ListEmailTemplates(ctx, organizationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, templates)
+}
+
+// @Summary Get Email Template
+// @Description Get the template the organization uses for a kind of email (organization admin only)
+// @ID getEmailTemplate
+// @Tags email-templates
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param kind path string true "Kind of email, e.g. invitation or verification"
+// @Success 200 {object} service.EmailTemplateResponse "Email template"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "Email kind not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/email-templates/{kind} [get]
+func (server *Server) getEmailTemplate(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	template, err := server.emailTemplateService.GetEmailTemplate(ctx, organizationID, ctx.Param("kind"))
+	if err != nil {
+		emailTemplateErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// @Summary Update Email Template
+// @Description Replace the organization's template for a kind of email (organization admin only). Subjects and bodies are Go templates, e.g. {{.WorkspaceName}}; templates using unknown variables are rejected.
+// @ID updateEmailTemplate
+// @Tags email-templates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param kind path string true "Kind of email, e.g. invitation or verification"
+// @Param request body service.UpdateEmailTemplateRequest true "Subject and bodies"
+// @Success 200 {object} service.EmailTemplateResponse "Saved email template"
+// @Failure 400 {object} map[string]string "Invalid request or template"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "Email kind not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/email-templates/{kind} [put]
+func (server *Server) updateEmailTemplate(ctx *gin.Context) {
+	var req service.UpdateEmailTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	template, err := server.emailTemplateService.UpdateEmailTemplate(ctx, organizationID, currentUser.ID, ctx.Param("kind"), req)
+	if err != nil {
+		emailTemplateErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// @Summary Reset Email Template
+// @Description Go back to the built-in template for a kind of email (organization admin only)
+// @ID resetEmailTemplate
+// @Tags email-templates
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param kind path string true "Kind of email, e.g. invitation or verification"
+// @Success 200 {object} service.EmailTemplateResponse "Built-in email template"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "Email kind not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/email-templates/{kind} [delete]
+func (server *Server) resetEmailTemplate(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	template, err := server.emailTemplateService.ResetEmailTemplate(ctx, organizationID, ctx.Param("kind"))
+	if err != nil {
+		emailTemplateErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// @Summary Preview Email Template
+// @Description Render a kind of email with sample data and the organization's branding (organization admin only). Subject and bodies in the request are previewed in place of the saved ones; an empty request previews the saved template.
+// @ID previewEmailTemplate
+// @Tags email-templates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param kind path string true "Kind of email, e.g. invitation or verification"
+// @Param request body service.PreviewEmailTemplateRequest false "Unsaved subject and bodies"
+// @Success 200 {object} service.EmailPreviewResponse "Rendered email"
+// @Failure 400 {object} map[string]string "Invalid request or template"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "Email kind not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/email-templates/{kind}/preview [post]
+func (server *Server) previewEmailTemplate(ctx *gin.Context) {
+	var req service.PreviewEmailTemplateRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+			return
+		}
+	}
+
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	preview, err := server.emailTemplateService.PreviewEmailTemplate(ctx, organizationID, ctx.Param("kind"), req)
+	if err != nil {
+		emailTemplateErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preview)
+}
+
+// @Summary Get Email Branding
+// @Description Get the product name, logo, colour and footer of the organization's emails (organization admin only)
+// @ID getEmailBranding
+// @Tags email-templates
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} service.EmailBranding "Email branding"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/email-branding [get]
+func (server *Server) getEmailBranding(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	branding, err := server.emailTemplateService.GetEmailBranding(ctx, organizationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, branding)
+}
+
+// @Summary Update Email Branding
+// @Description Set the product name, HTTPS logo URL, primary colour (#rrggbb) and footer of the organization's emails (organization admin only). Empty fields fall back to the defaults.
+// @ID updateEmailBranding
+// @Tags email-templates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body service.UpdateEmailBrandingRequest true "Email branding"
+// @Success 200 {object} service.EmailBranding "Email branding"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/email-branding [put]
+func (server *Server) updateEmailBranding(ctx *gin.Context) {
+	var req service.UpdateEmailBrandingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	branding, err := server.emailTemplateService.UpdateEmailBranding(ctx, organizationID, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, branding)
+}
@@ -0,0 +1,324 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateEmailTemplateAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	validBody := gin.H{
+		"subject":   "Join {{.WorkspaceName}}",
+		"text_body": "{{.InviterName}} invited you: {{.Link}}",
+		"html_body": `<p>{{.InviterName}} invited you: <a href="{{.Link}}">join</a></p>`,
+	}
+
+	testCases := []struct {
+		name          string
+		kind          string
+		orgRole       string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			kind:    "invitation",
+			orgRole: service.OrganizationRoleAdmin,
+			body:    validBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertEmailTemplate(gomock.Any(), gomock.Eq(db.UpsertEmailTemplateParams{
+						OrganizationID: admin.OrganizationID,
+						Kind:           "invitation",
+						Subject:        "Join {{.WorkspaceName}}",
+						TextBody:       "{{.InviterName}} invited you: {{.Link}}",
+						HtmlBody:       `<p>{{.InviterName}} invited you: <a href="{{.Link}}">join</a></p>`,
+						UpdatedBy:      sql.NullInt64{Int64: admin.ID, Valid: true},
+					})).
+					Times(1).
+					Return(db.EmailTemplate{
+						ID:             1,
+						OrganizationID: admin.OrganizationID,
+						Kind:           "invitation",
+						Subject:        "Join {{.WorkspaceName}}",
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.EmailTemplateResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.True(t, response.Custom)
+				require.Contains(t, response.Variables, "WorkspaceName")
+				require.Contains(t, response.Variables, "Brand.LogoURL")
+			},
+		},
+		{
+			name:    "UnknownVariable",
+			kind:    "invitation",
+			orgRole: service.OrganizationRoleAdmin,
+			body: gin.H{
+				"subject":   "Join {{.Workspace}}",
+				"text_body": "Hi",
+				"html_body": "<p>Hi</p>",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "SyntaxError",
+			kind:    "verification",
+			orgRole: service.OrganizationRoleAdmin,
+			body: gin.H{
+				"subject":   "Verify",
+				"text_body": "{{.Link",
+				"html_body": "<p>Hi</p>",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "UnknownKind",
+			kind:    "newsletter",
+			orgRole: service.OrganizationRoleAdmin,
+			body:    validBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotOrganizationAdmin",
+			kind: "invitation",
+			body: validBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).Times(1).Return(admin, nil)
+			orgRoleStub(store, admin, tc.orgRole)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/organizations/%d/email-templates/%s", admin.OrganizationID, tc.kind)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestPreviewEmailTemplateAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          []byte
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "SavedTemplate",
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.EmailPreviewResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, "Welcome to Engineering on Acme Chat", response.Subject)
+				require.Contains(t, response.HTMLBody, "#0b5fff")
+			},
+		},
+		{
+			name: "DraftSubject",
+			body: []byte(`{"subject": "{{.InviterName}} wants you in {{.WorkspaceName}}"}`),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.EmailPreviewResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, "Ada Lovelace wants you in Engineering", response.Subject)
+				require.Contains(t, response.TextBody, "Acme Chat")
+			},
+		},
+		{
+			name: "DraftWithUnknownVariable",
+			body: []byte(`{"text_body": "{{.Password}}"}`),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).Times(1).Return(admin, nil)
+			orgRoleStub(store, admin, service.OrganizationRoleAdmin)
+			store.EXPECT().
+				GetEmailTemplate(gomock.Any(), gomock.Eq(db.GetEmailTemplateParams{OrganizationID: admin.OrganizationID, Kind: "invitation"})).
+				Times(1).
+				Return(db.EmailTemplate{
+					OrganizationID: admin.OrganizationID,
+					Kind:           "invitation",
+					Subject:        "Welcome to {{.WorkspaceName}} on {{.Brand.ProductName}}",
+					TextBody:       "{{.InviterName}} invited you to {{.Brand.ProductName}}",
+					HtmlBody:       `<a href="{{.Link}}" style="color: {{.Brand.PrimaryColor}}">Join</a>`,
+				}, nil)
+			store.EXPECT().
+				GetEmailBranding(gomock.Any(), gomock.Eq(admin.OrganizationID)).
+				AnyTimes().
+				Return(db.EmailBranding{OrganizationID: admin.OrganizationID, ProductName: "Acme Chat", PrimaryColor: "#0b5fff"}, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/organizations/%d/email-templates/invitation/preview", admin.OrganizationID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(tc.body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateEmailBrandingAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"product_name": "Acme Chat", "logo_url": "https://acme.example/logo.png", "primary_color": "#0b5fff"},
+			buildStubs: func(store *mockdb.MockStore) {
+				branding := db.EmailBranding{
+					OrganizationID: admin.OrganizationID,
+					ProductName:    "Acme Chat",
+					LogoUrl:        "https://acme.example/logo.png",
+					PrimaryColor:   "#0b5fff",
+				}
+				store.EXPECT().
+					UpsertEmailBranding(gomock.Any(), gomock.Eq(db.UpsertEmailBrandingParams{
+						OrganizationID: admin.OrganizationID,
+						ProductName:    "Acme Chat",
+						LogoUrl:        "https://acme.example/logo.png",
+						PrimaryColor:   "#0b5fff",
+					})).
+					Times(1).
+					Return(branding, nil)
+				store.EXPECT().GetEmailBranding(gomock.Any(), gomock.Eq(admin.OrganizationID)).Times(1).Return(branding, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.EmailBranding
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, "Acme Chat", response.ProductName)
+				require.Equal(t, "#0b5fff", response.PrimaryColor)
+			},
+		},
+		{
+			name: "InsecureLogo",
+			body: gin.H{"logo_url": "http://acme.example/logo.png"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailBranding(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidColor",
+			body: gin.H{"primary_color": "red;}"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailBranding(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).Times(1).Return(admin, nil)
+			orgRoleStub(store, admin, service.OrganizationRoleAdmin)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/organizations/%d/email-branding", admin.OrganizationID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
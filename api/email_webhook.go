@@ -0,0 +1,106 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// emailWebhookBody checks the webhook token and reads the request body,
+// responding and returning false when the request can't be handled
+func (server *Server) emailWebhookBody(ctx *gin.Context) ([]byte, bool) {
+	expected := server.config.EmailWebhookToken
+	if expected == "" {
+		err := errors.New("email webhooks are disabled")
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		return nil, false
+	}
+
+	token := ctx.Query("token")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		err := errors.New("invalid webhook token")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ctx, err))
+		return nil, false
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(ctx, err))
+			return nil, false
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		return nil, false
+	}
+
+	return body, true
+}
+
+// @Summary SendGrid Email Events
+// @Description Receives SendGrid event webhook deliveries. Addresses that hard bounced or reported an email as spam are no longer emailed.
+// @ID handleSendGridEmailEvents
+// @Tags email-webhooks
+// @Accept json
+// @Produce json
+// @Param token query string true "Webhook token (EMAIL_WEBHOOK_TOKEN)"
+// @Success 200 {object} map[string]int "Number of addresses suppressed"
+// @Failure 400 {object} map[string]string "Invalid events"
+// @Failure 401 {object} map[string]string "Invalid webhook token"
+// @Failure 404 {object} map[string]string "Email webhooks are disabled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /webhooks/email/sendgrid [post]
+func (server *Server) handleSendGridEmailEvents(ctx *gin.Context) {
+	body, ok := server.emailWebhookBody(ctx)
+	if !ok {
+		return
+	}
+
+	suppressed, err := server.emailService.HandleSendGridEvents(ctx, body)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidEmailFeedback) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"suppressed": suppressed})
+}
+
+// @Summary SES Email Notifications
+// @Description Receives Amazon SES bounce and complaint notifications through an SNS HTTPS subscription, which is confirmed automatically. Addresses that permanently bounced or complained are no longer emailed.
+// @ID handleSESEmailNotification
+// @Tags email-webhooks
+// @Accept json
+// @Produce json
+// @Param token query string true "Webhook token (EMAIL_WEBHOOK_TOKEN)"
+// @Success 200 {object} map[string]int "Number of addresses suppressed"
+// @Failure 400 {object} map[string]string "Invalid notification"
+// @Failure 401 {object} map[string]string "Invalid webhook token"
+// @Failure 404 {object} map[string]string "Email webhooks are disabled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /webhooks/email/ses [post]
+func (server *Server) handleSESEmailNotification(ctx *gin.Context) {
+	body, ok := server.emailWebhookBody(ctx)
+	if !ok {
+		return
+	}
+
+	suppressed, err := server.emailService.HandleSESNotification(ctx, body)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidEmailFeedback) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"suppressed": suppressed})
+}
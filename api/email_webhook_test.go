@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendGridEmailWebhookAPI(t *testing.T) {
+	events := `[{"email": "ada@example.com", "event": "bounce", "reason": "550 unknown user"}]`
+
+	testCases := []struct {
+		name          string
+		webhookToken  string
+		url           string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:         "OK",
+			webhookToken: "secret",
+			url:          "/webhooks/email/sendgrid?token=secret",
+			body:         events,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertEmailSuppression(gomock.Any(), gomock.Eq(db.UpsertEmailSuppressionParams{
+						Email:    "ada@example.com",
+						Reason:   "bounce",
+						Provider: "sendgrid",
+						Detail:   "550 unknown user",
+					})).
+					Times(1).
+					Return(db.EmailSuppression{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"suppressed": 1}`, recorder.Body.String())
+			},
+		},
+		{
+			name:         "WrongToken",
+			webhookToken: "secret",
+			url:          "/webhooks/email/sendgrid?token=guess",
+			body:         events,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailSuppression(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "Disabled",
+			url:  "/webhooks/email/sendgrid?token=",
+			body: events,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailSuppression(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:         "InvalidBody",
+			webhookToken: "secret",
+			url:          "/webhooks/email/sendgrid?token=secret",
+			body:         `{"event": "bounce"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailSuppression(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.config.EmailWebhookToken = tc.webhookToken
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSESEmailWebhookAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		UpsertEmailSuppression(gomock.Any(), gomock.Eq(db.UpsertEmailSuppressionParams{
+			Email:    "ada@example.com",
+			Reason:   "complaint",
+			Provider: "ses",
+		})).
+		Times(1).
+		Return(db.EmailSuppression{}, nil)
+
+	server := newTestServer(t, store)
+	server.config.EmailWebhookToken = "secret"
+	recorder := httptest.NewRecorder()
+
+	// SNS posts notifications as text/plain
+	body := `{"Type": "Notification", "Message": "{\"notificationType\": \"Complaint\", \"complaint\": {\"complainedRecipients\": [{\"emailAddress\": \"ada@example.com\"}]}}"}`
+	request, err := http.NewRequest(http.MethodPost, "/webhooks/email/ses?token=secret", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "text/plain; charset=UTF-8")
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.JSONEq(t, `{"suppressed": 1}`, recorder.Body.String())
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Create Custom Emoji
+// @Description Add a custom emoji to the workspace from a public PNG, GIF, JPEG or WebP image you uploaded. Members react with it as :name:.
+// @ID createCustomEmoji
+// @Tags emojis
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.CreateCustomEmojiRequest true "Emoji name and image file"
+// @Success 201 {object} service.CustomEmojiResponse "Custom emoji created"
+// @Failure 400 {object} map[string]string "Invalid name or image"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "File not found"
+// @Failure 409 {object} map[string]string "An emoji with this name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/emojis [post]
+func (server *Server) createCustomEmoji(ctx *gin.Context) {
+	var req service.CreateCustomEmojiRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	emoji, err := server.emojiService.CreateCustomEmoji(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		handleEmojiError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, emoji)
+}
+
+// @Summary List Custom Emojis
+// @Description List the workspace's custom emojis by name
+// @ID listCustomEmojis
+// @Tags emojis
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.CustomEmojiResponse "Custom emojis"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/emojis [get]
+func (server *Server) listCustomEmojis(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	emojis, err := server.emojiService.ListCustomEmojis(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, emojis)
+}
+
+// @Summary Delete Custom Emoji
+// @Description Remove a custom emoji from the workspace (its creator or a workspace admin). Existing reactions with it are kept, or move to replace_with: a single emoji or another custom emoji. A user who already reacted with the replacement keeps that one reaction.
+// @ID deleteCustomEmoji
+// @Tags emojis
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param emoji_id path int true "Custom emoji ID"
+// @Param replace_with query string false "Emoji the existing reactions move to"
+// @Success 200 {object} service.DeleteCustomEmojiResponse "Custom emoji deleted"
+// @Failure 400 {object} map[string]string "Invalid ID or replacement"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Only the creator or a workspace admin can delete the emoji"
+// @Failure 404 {object} map[string]string "Custom emoji not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/emojis/{emoji_id} [delete]
+func (server *Server) deleteCustomEmoji(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	emojiID, err := strconv.ParseInt(ctx.Param("emoji_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid emoji ID")))
+		return
+	}
+
+	var req service.DeleteCustomEmojiRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	response, err := server.emojiService.DeleteCustomEmoji(ctx, workspaceID, emojiID, currentUser.ID, req)
+	if err != nil {
+		handleEmojiError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+func handleEmojiError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "invalid"):
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "already exists"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
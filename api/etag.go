@@ -0,0 +1,61 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jsonWithETag responds with obj as JSON, tagged with a hash of its content.
+// Clients that send the same tag in If-None-Match get 304 Not Modified instead.
+func jsonWithETag(ctx *gin.Context, code int, obj interface{}) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	ctx.Header("Cache-Control", "private, no-cache")
+	if respondNotModified(ctx, `"`+hex.EncodeToString(sum[:16])+`"`) {
+		return
+	}
+
+	ctx.Data(code, "application/json; charset=utf-8", body)
+}
+
+// respondNotModified sets the ETag header and answers 304 Not Modified when the
+// request's If-None-Match header matches it. It reports whether it responded.
+func respondNotModified(ctx *gin.Context, etag string) bool {
+	ctx.Header("ETag", etag)
+
+	if !etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+
+	ctx.Status(http.StatusNotModified)
+	ctx.Writer.WriteHeaderNow()
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header matches an ETag, using the
+// weak comparison that If-None-Match calls for
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETagMatches(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ifNoneMatch string
+		etag        string
+		expected    bool
+	}{
+		{name: "Empty", ifNoneMatch: "", etag: `"abc"`, expected: false},
+		{name: "Exact", ifNoneMatch: `"abc"`, etag: `"abc"`, expected: true},
+		{name: "Weak", ifNoneMatch: `W/"abc"`, etag: `"abc"`, expected: true},
+		{name: "List", ifNoneMatch: `"xyz", "abc"`, etag: `"abc"`, expected: true},
+		{name: "Wildcard", ifNoneMatch: "*", etag: `"abc"`, expected: true},
+		{name: "Different", ifNoneMatch: `"xyz"`, etag: `"abc"`, expected: false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, etagMatches(tc.ifNoneMatch, tc.etag))
+		})
+	}
+}
+
+func TestListChannelsConditionalRequestAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	// Make user a member of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	channels := []db.Channel{
+		randomChannel(workspace.ID, user.ID),
+		randomChannel(workspace.ID, user.ID),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Times(3).
+		Return(user, nil)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+		Times(3).
+		Return(user.Role, nil)
+	store.EXPECT().
+		ListChannelsByWorkspace(gomock.Any(), gomock.Any()).
+		Times(3).
+		Return(channels, nil)
+
+	server := newTestServer(t, store)
+	url := fmt.Sprintf("/workspaces/%d/channels?page_id=1&page_size=10", workspace.ID)
+
+	send := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+		if ifNoneMatch != "" {
+			request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+
+		addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// The first request returns the list with its ETag
+	recorder := send("")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	requireBodyMatchChannels(t, recorder.Body, channels)
+	etag := recorder.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// Revalidating an unchanged list returns no body
+	recorder = send(etag)
+	require.Equal(t, http.StatusNotModified, recorder.Code)
+	require.Empty(t, recorder.Body.Bytes())
+	require.Equal(t, etag, recorder.Header().Get("ETag"))
+
+	// A stale ETag gets the full list again
+	recorder = send(`"stale"`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	requireBodyMatchChannels(t, recorder.Body, channels)
+}
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+type listExternalDMRequestsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending accepted declined closed"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32  `form:"offset" binding:"omitempty,min=0"`
+}
+
+// @Summary Get External DM Policy
+// @Description Get whether the organization's members can exchange direct messages with people in other organizations (organization admin only)
+// @ID getExternalDMPolicy
+// @Tags external-dms
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} service.ExternalDMPolicyResponse "External DM policy"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/external-dm-policy [get]
+func (server *Server) getExternalDMPolicy(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	policy, err := server.externalDMService.GetPolicy(ctx, organizationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// @Summary Update External DM Policy
+// @Description Allow the organization's members to exchange direct messages with anyone in other organizations, only with people whose email domain is allowed, or with nobody (organization admin only). Open conversations stop taking messages when the policy no longer allows them.
+// @ID updateExternalDMPolicy
+// @Tags external-dms
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body service.UpdateExternalDMPolicyRequest true "External DM policy"
+// @Success 200 {object} service.ExternalDMPolicyResponse "External DM policy"
+// @Failure 400 {object} map[string]string "Invalid request, policy or domain"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/external-dm-policy [put]
+func (server *Server) updateExternalDMPolicy(ctx *gin.Context) {
+	var req service.UpdateExternalDMPolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	policy, err := server.externalDMService.UpdatePolicy(ctx, organizationID, currentUser.ID, req)
+	if err != nil {
+		handleExternalDMError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// @Summary Send External DM Request
+// @Description Ask someone in another organization, found by email, for a direct conversation (requires workspace membership). The conversation is kept in this workspace and the recipient is sent an external_dm_request WebSocket message.
+// @ID sendExternalDMRequest
+// @Tags external-dms
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.CreateExternalDMRequestRequest true "Recipient and introduction"
+// @Success 201 {object} service.ExternalDMRequestResponse "External DM request"
+// @Failure 400 {object} map[string]string "Invalid request or recipient"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Not allowed by an organization's policy"
+// @Failure 404 {object} map[string]string "Recipient not found"
+// @Failure 409 {object} map[string]string "A request or conversation already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/external-dm-requests [post]
+func (server *Server) sendExternalDMRequest(ctx *gin.Context) {
+	var req service.CreateExternalDMRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	request, err := server.externalDMService.SendRequest(ctx, workspaceID, currentUser, req)
+	if err != nil {
+		handleExternalDMError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, request)
+}
+
+// @Summary List External DM Requests
+// @Description List the external DM requests the current user sent or received, newest first. Accepted requests are the user's conversations with people in other organizations.
+// @ID listExternalDMRequests
+// @Tags external-dms
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "Only list requests with this status" Enums(pending, accepted, declined, closed)
+// @Param limit query int false "Page size (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of requests to skip" minimum(0)
+// @Success 200 {array} service.ExternalDMRequestResponse "External DM requests"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /external-dm-requests [get]
+func (server *Server) listExternalDMRequests(ctx *gin.Context) {
+	var req listExternalDMRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	requests, err := server.externalDMService.ListRequests(ctx, currentUser.ID, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+}
+
+// @Summary Accept External DM Request
+// @Description Accept a request for a direct conversation from someone in another organization (recipient only). Both people are sent an external_dm_request_updated WebSocket message.
+// @ID acceptExternalDMRequest
+// @Tags external-dms
+// @Security BearerAuth
+// @Produce json
+// @Param request_id path int true "External DM request ID"
+// @Success 200 {object} service.ExternalDMRequestResponse "External DM request"
+// @Failure 400 {object} map[string]string "Invalid ID or request no longer pending"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Not the recipient, or not allowed by an organization's policy"
+// @Failure 404 {object} map[string]string "External DM request not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /external-dm-requests/{request_id}/accept [post]
+func (server *Server) acceptExternalDMRequest(ctx *gin.Context) {
+	server.respondToExternalDMRequest(ctx, server.externalDMService.AcceptRequest)
+}
+
+// @Summary Decline External DM Request
+// @Description Decline a request for a direct conversation from someone in another organization (recipient only)
+// @ID declineExternalDMRequest
+// @Tags external-dms
+// @Security BearerAuth
+// @Produce json
+// @Param request_id path int true "External DM request ID"
+// @Success 200 {object} service.ExternalDMRequestResponse "External DM request"
+// @Failure 400 {object} map[string]string "Invalid ID or request no longer pending"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Not the recipient"
+// @Failure 404 {object} map[string]string "External DM request not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /external-dm-requests/{request_id}/decline [post]
+func (server *Server) declineExternalDMRequest(ctx *gin.Context) {
+	server.respondToExternalDMRequest(ctx, server.externalDMService.DeclineRequest)
+}
+
+// @Summary Close External DM Request
+// @Description Withdraw a pending request or end a conversation with someone in another organization. Its messages are kept.
+// @ID closeExternalDMRequest
+// @Tags external-dms
+// @Security BearerAuth
+// @Produce json
+// @Param request_id path int true "External DM request ID"
+// @Success 200 {object} service.ExternalDMRequestResponse "External DM request"
+// @Failure 400 {object} map[string]string "Invalid ID or request already closed"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "External DM request not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /external-dm-requests/{request_id} [delete]
+func (server *Server) closeExternalDMRequest(ctx *gin.Context) {
+	server.respondToExternalDMRequest(ctx, server.externalDMService.CloseRequest)
+}
+
+func (server *Server) respondToExternalDMRequest(ctx *gin.Context, respond func(ctx context.Context, requestID, userID int64) (service.ExternalDMRequestResponse, error)) {
+	requestID, err := strconv.ParseInt(ctx.Param("request_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid external DM request ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	request, err := respond(ctx, requestID, currentUser.ID)
+	if err != nil {
+		handleExternalDMError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// @Summary List External DM Messages
+// @Description List the messages of a conversation with someone in another organization, newest first
+// @ID listExternalDMMessages
+// @Tags external-dms
+// @Security BearerAuth
+// @Produce json
+// @Param request_id path int true "External DM request ID"
+// @Param limit query int false "Number of messages to retrieve (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of messages to skip (default: 0)" minimum(0)
+// @Success 200 {object} object{messages=[]service.MessageResponse} "Messages"
+// @Failure 400 {object} map[string]string "Invalid request or conversation not accepted"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "External DM request not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /external-dm-requests/{request_id}/messages [get]
+func (server *Server) listExternalDMMessages(ctx *gin.Context) {
+	var req service.GetMessagesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	requestID, err := strconv.ParseInt(ctx.Param("request_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid external DM request ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	messages, err := server.externalDMService.ListMessages(ctx, requestID, currentUser.ID, req.Limit, req.Offset)
+	if err != nil {
+		handleExternalDMError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"messages": messages})
+}
+
+// @Summary Send External DM Message
+// @Description Send a text message in an accepted conversation with someone in another organization. Files and mentions aren't available in these conversations.
+// @ID sendExternalDMMessage
+// @Tags external-dms
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request_id path int true "External DM request ID"
+// @Param request body service.SendExternalDMRequest true "Message"
+// @Success 201 {object} service.MessageResponse "Message sent"
+// @Failure 400 {object} map[string]string "Invalid request or conversation not accepted"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Not allowed by an organization's policy"
+// @Failure 404 {object} map[string]string "External DM request not found"
+// @Failure 422 {object} map[string]string "Message blocked by content moderation"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /external-dm-requests/{request_id}/messages [post]
+func (server *Server) sendExternalDMMessage(ctx *gin.Context) {
+	var req service.SendExternalDMRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	requestID, err := strconv.ParseInt(ctx.Param("request_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid external DM request ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	message, err := server.externalDMService.SendMessage(ctx, requestID, currentUser.ID, req.Content)
+	if err != nil {
+		handleExternalDMError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, message)
+}
+
+func handleExternalDMError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "invalid"):
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "already exists"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	case err.Error() == "message blocked by content moderation":
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
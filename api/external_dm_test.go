@@ -0,0 +1,370 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendExternalDMRequestAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	recipient, _ := randomUser(t)
+	recipient.OrganizationID = user.OrganizationID + 1000
+	colleague, _ := randomUser(t)
+	colleague.OrganizationID = user.OrganizationID
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"email": recipient.Email, "message": "Hi, we met at the conference"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(recipient.Email)).
+					Times(1).
+					Return(recipient, nil)
+				store.EXPECT().
+					GetExternalDMPolicy(gomock.Any(), gomock.Eq(user.OrganizationID)).
+					Times(1).
+					Return(db.ExternalDmPolicy{}, sql.ErrNoRows)
+				// The recipient's organization only talks to example.com, where the sender's email is
+				store.EXPECT().
+					GetExternalDMPolicy(gomock.Any(), gomock.Eq(recipient.OrganizationID)).
+					Times(1).
+					Return(db.ExternalDmPolicy{
+						OrganizationID: recipient.OrganizationID,
+						Policy:         service.ExternalDMPolicyRestricted,
+						AllowedDomains: []string{"example.com"},
+					}, nil)
+				store.EXPECT().
+					CreateExternalDMRequest(gomock.Any(), gomock.Eq(db.CreateExternalDMRequestParams{
+						SenderID:    user.ID,
+						WorkspaceID: workspace.ID,
+						RecipientID: recipient.ID,
+						Message:     "Hi, we met at the conference",
+					})).
+					Times(1).
+					Return(db.ExternalDmRequest{
+						ID:          1,
+						SenderID:    user.ID,
+						WorkspaceID: workspace.ID,
+						RecipientID: recipient.ID,
+						Message:     "Hi, we met at the conference",
+						Status:      service.ExternalDMStatusPending,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var request service.ExternalDMRequestResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &request))
+				require.Equal(t, service.ExternalDMStatusPending, request.Status)
+				require.Equal(t, recipient.Email, request.Recipient.Email)
+				require.False(t, request.Incoming)
+			},
+		},
+		{
+			name: "SameOrganization",
+			body: gin.H{"email": colleague.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(colleague.Email)).
+					Times(1).
+					Return(colleague, nil)
+				store.EXPECT().
+					CreateExternalDMRequest(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "RecipientNotFound",
+			body: gin.H{"email": recipient.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(recipient.Email)).
+					Times(1).
+					Return(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "DisabledByRecipientOrganization",
+			body: gin.H{"email": recipient.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(recipient.Email)).
+					Times(1).
+					Return(recipient, nil)
+				store.EXPECT().
+					GetExternalDMPolicy(gomock.Any(), gomock.Eq(user.OrganizationID)).
+					Times(1).
+					Return(db.ExternalDmPolicy{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetExternalDMPolicy(gomock.Any(), gomock.Eq(recipient.OrganizationID)).
+					Times(1).
+					Return(db.ExternalDmPolicy{OrganizationID: recipient.OrganizationID, Policy: service.ExternalDMPolicyDisabled}, nil)
+				store.EXPECT().
+					CreateExternalDMRequest(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyExists",
+			body: gin.H{"email": recipient.Email},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(recipient.Email)).
+					Times(1).
+					Return(recipient, nil)
+				store.EXPECT().
+					GetExternalDMPolicy(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.ExternalDmPolicy{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateExternalDMRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ExternalDmRequest{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{"email": "not-an-email"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateExternalDMRequest(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(user.Role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/external-dm-requests", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSendExternalDMMessageAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	sender, _ := randomUser(t)
+	sender.OrganizationID = user.OrganizationID + 1000
+	workspace := randomWorkspace(sender.OrganizationID)
+
+	// The user was asked by someone in another organization, so the
+	// conversation is kept in the sender's workspace
+	request := db.GetExternalDMRequestRow{
+		ID:                      7,
+		SenderID:                sender.ID,
+		WorkspaceID:             workspace.ID,
+		RecipientID:             user.ID,
+		Status:                  service.ExternalDMStatusAccepted,
+		SenderEmail:             sender.Email,
+		SenderOrganizationID:    sender.OrganizationID,
+		RecipientEmail:          user.Email,
+		RecipientOrganizationID: user.OrganizationID,
+	}
+
+	testCases := []struct {
+		name          string
+		request       db.GetExternalDMRequestRow
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			request: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExternalDMPolicy(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.ExternalDmPolicy{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{}, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				expectDefaultWorkspaceSettings(store)
+
+				// Nobody in the sender's workspace can be mentioned
+				store.EXPECT().
+					ListWorkspaceMemberIDs(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				content := fmt.Sprintf("Thanks <@%d>!", sender.ID)
+				message := db.Message{
+					ID:          3,
+					WorkspaceID: workspace.ID,
+					SenderID:    user.ID,
+					ReceiverID:  sql.NullInt64{Int64: sender.ID, Valid: true},
+					Content:     content,
+					MessageType: "direct",
+					CreatedAt:   time.Now(),
+				}
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), EqDirectMessageTx(db.CreateDirectMessageParams{
+						WorkspaceID: workspace.ID,
+						SenderID:    user.ID,
+						ReceiverID:  sql.NullInt64{Int64: sender.ID, Valid: true},
+						Content:     content,
+						ContentType: "text",
+					})).
+					Times(1).
+					DoAndReturn(createMessageTx(message))
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "NotAccepted",
+			request: func() db.GetExternalDMRequestRow {
+				pending := request
+				pending.Status = service.ExternalDMStatusPending
+				return pending
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotParticipant",
+			request: func() db.GetExternalDMRequestRow {
+				other := request
+				other.RecipientID = user.ID + 1
+				return other
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "DisabledAfterAccepting",
+			request: request,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetExternalDMPolicy(gomock.Any(), gomock.Eq(sender.OrganizationID)).
+					Times(1).
+					Return(db.ExternalDmPolicy{OrganizationID: sender.OrganizationID, Policy: service.ExternalDMPolicyDisabled}, nil)
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetExternalDMRequest(gomock.Any(), gomock.Eq(request.ID)).
+				Times(1).
+				Return(tc.request, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"content": fmt.Sprintf("Thanks <@%d>!", sender.ID)})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/external-dm-requests/%d/messages", request.ID)
+			httpRequest, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, httpRequest, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, httpRequest)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Feature Flags
+// @Description List the features that can be rolled out per workspace and whether each is enabled, so clients can show or hide them
+// @ID listFeatureFlags
+// @Tags features
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.FeatureFlagResponse "Feature flags"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/features [get]
+func (server *Server) listFeatureFlags(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	flags, err := server.featureFlagService.ListFeatureFlags(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, flags)
+}
+
+// @Summary Set Feature Flag
+// @Description Turn a feature on or off for a workspace (admin only)
+// @ID setFeatureFlag
+// @Tags features
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param flag path string true "Feature flag"
+// @Param request body service.SetFeatureFlagRequest true "Feature state"
+// @Success 200 {object} service.FeatureFlagResponse "Feature flag"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Unknown feature flag"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/features/{flag} [put]
+func (server *Server) setFeatureFlag(ctx *gin.Context) {
+	var req service.SetFeatureFlagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	flag, err := server.featureFlagService.SetFeatureFlag(ctx, workspaceID, currentUser.ID, ctx.Param("flag"), *req.Enabled)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "unknown feature flag") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, flag)
+}
+
+// @Summary Reset Feature Flag
+// @Description Remove a workspace's override so the feature uses its default again (admin only)
+// @ID resetFeatureFlag
+// @Tags features
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param flag path string true "Feature flag"
+// @Success 200 {object} service.FeatureFlagResponse "Feature flag"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Unknown feature flag or no override"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/features/{flag} [delete]
+func (server *Server) resetFeatureFlag(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	flag, err := server.featureFlagService.ResetFeatureFlag(ctx, workspaceID, ctx.Param("flag"))
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "unknown feature flag") || strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, flag)
+}
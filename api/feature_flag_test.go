@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListFeatureFlagsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(user.Role, nil)
+	store.EXPECT().
+		ListFeatureFlags(gomock.Any(), gomock.Eq(workspace.ID)).
+		Times(1).
+		Return([]db.FeatureFlag{
+			{WorkspaceID: workspace.ID, Flag: service.FeatureHuddles, Enabled: true},
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/workspaces/%d/features", workspace.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var flags []service.FeatureFlagResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &flags))
+	require.Len(t, flags, len(service.FeatureFlags))
+
+	for _, flag := range flags {
+		switch flag.Flag {
+		case service.FeatureHuddles:
+			require.True(t, flag.Enabled)
+			require.True(t, flag.Overridden)
+		default:
+			require.Equal(t, flag.Default, flag.Enabled)
+			require.False(t, flag.Overridden)
+		}
+	}
+}
+
+func TestSetFeatureFlagAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		role          string
+		flag          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: "admin",
+			flag: service.FeatureHuddles,
+			body: gin.H{"enabled": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertFeatureFlagParams{
+					WorkspaceID: workspace.ID,
+					Flag:        service.FeatureHuddles,
+					Enabled:     true,
+					UpdatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+				}
+				store.EXPECT().
+					UpsertFeatureFlag(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.FeatureFlag{WorkspaceID: workspace.ID, Flag: arg.Flag, Enabled: true}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.FeatureFlagResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, service.FeatureHuddles, response.Flag)
+				require.True(t, response.Enabled)
+				require.True(t, response.Overridden)
+			},
+		},
+		{
+			name: "MissingEnabled",
+			role: "admin",
+			flag: service.FeatureHuddles,
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertFeatureFlag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownFlag",
+			role: "admin",
+			flag: "time_travel",
+			body: gin.H{"enabled": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertFeatureFlag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotAdmin",
+			role: "member",
+			flag: service.FeatureHuddles,
+			body: gin.H{"enabled": true},
+			buildStubs: func(store *mockdb.MockStore) {
+				// Members without a custom role hold no permissions
+				store.EXPECT().
+					GetUserRolePermissions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				store.EXPECT().
+					UpsertFeatureFlag(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			requestUser := user
+			requestUser.Role = tc.role
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(requestUser, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(tc.role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/features/%s", workspace.ID, tc.flag)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSearchRequiresFeatureAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(user.Role, nil)
+	store.EXPECT().
+		ListFeatureFlags(gomock.Any(), gomock.Eq(workspace.ID)).
+		Times(1).
+		Return([]db.FeatureFlag{
+			{WorkspaceID: workspace.ID, Flag: service.FeatureNewSearch, Enabled: false},
+		}, nil)
+	store.EXPECT().
+		SearchMessages(gomock.Any(), gomock.Any()).
+		Times(0)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/workspaces/%d/search?q=hello", workspace.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+}
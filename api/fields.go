@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fieldTree is a parsed fields= parameter. A nil subtree selects the whole value.
+type fieldTree map[string]fieldTree
+
+// parseFields parses a comma-separated list of dotted field paths such as
+// "id,content,sender.first_name"
+func parseFields(raw string) (fieldTree, error) {
+	tree := fieldTree{}
+
+	for _, path := range strings.Split(raw, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return nil, errors.New("invalid fields parameter: empty field")
+		}
+
+		node := tree
+		segments := strings.Split(path, ".")
+		for i, segment := range segments {
+			if segment == "" {
+				return nil, errors.New("invalid fields parameter: " + path)
+			}
+
+			subtree, exists := node[segment]
+			if i == len(segments)-1 {
+				// Selecting a whole value overrides narrower selections inside it
+				node[segment] = nil
+				break
+			}
+			if exists && subtree == nil {
+				// The whole value is already selected
+				break
+			}
+			if !exists {
+				subtree = fieldTree{}
+				node[segment] = subtree
+			}
+			node = subtree
+		}
+	}
+
+	return tree, nil
+}
+
+// prune keeps only the selected fields of a decoded JSON value. Lists are
+// pruned item by item.
+func (tree fieldTree) prune(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = tree.prune(item)
+		}
+		return items
+	case map[string]interface{}:
+		selected := make(map[string]interface{}, len(tree))
+		for key, subtree := range tree {
+			field, exists := v[key]
+			if !exists {
+				continue
+			}
+			if subtree == nil {
+				selected[key] = field
+			} else {
+				selected[key] = subtree.prune(field)
+			}
+		}
+		return selected
+	default:
+		return value
+	}
+}
+
+// filterFields applies the fields= query parameter to a response object, so
+// clients only receive the attributes they ask for. Without the parameter the
+// object is returned unchanged. It responds with 400 and reports false when the
+// parameter is invalid.
+func filterFields(ctx *gin.Context, obj interface{}) (interface{}, bool) {
+	raw, requested := ctx.GetQuery("fields")
+	if !requested {
+		return obj, true
+	}
+
+	tree, err := parseFields(raw)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		return nil, false
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return nil, false
+	}
+
+	// Decode numbers as json.Number so large IDs keep their precision
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return nil, false
+	}
+
+	return tree.prune(value), true
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFields(t *testing.T) {
+	testCases := []struct {
+		name     string
+		raw      string
+		expected fieldTree
+		wantErr  bool
+	}{
+		{
+			name:     "Flat",
+			raw:      "id,content",
+			expected: fieldTree{"id": nil, "content": nil},
+		},
+		{
+			name:     "Nested",
+			raw:      "id, sender.first_name,sender.email",
+			expected: fieldTree{"id": nil, "sender": fieldTree{"first_name": nil, "email": nil}},
+		},
+		{
+			name:     "WholeValueWins",
+			raw:      "sender.email,sender",
+			expected: fieldTree{"sender": nil},
+		},
+		{
+			name:     "WholeValueFirst",
+			raw:      "sender,sender.email",
+			expected: fieldTree{"sender": nil},
+		},
+		{name: "Empty", raw: "", wantErr: true},
+		{name: "EmptyField", raw: "id,,content", wantErr: true},
+		{name: "EmptySegment", raw: "sender..email", wantErr: true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tree, err := parseFields(tc.raw)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tree)
+		})
+	}
+}
+
+func TestPruneFields(t *testing.T) {
+	tree, err := parseFields("id,sender.first_name,missing")
+	require.NoError(t, err)
+
+	value := []interface{}{
+		map[string]interface{}{
+			"id":      "1",
+			"content": "hello",
+			"sender":  map[string]interface{}{"first_name": "Ada", "email": "ada@example.com"},
+		},
+		map[string]interface{}{
+			"id":     "2",
+			"sender": nil,
+		},
+	}
+
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"id":     "1",
+			"sender": map[string]interface{}{"first_name": "Ada"},
+		},
+		map[string]interface{}{
+			"id":     "2",
+			"sender": nil,
+		},
+	}, tree.prune(value))
+}
@@ -4,14 +4,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/publicid"
 	"github.com/heyrmi/goslack/service"
 )
 
 // @Summary Upload File
 // @Description Upload a file to a workspace, channel, or for direct messaging
+// @ID uploadFile
 // @Tags files
 // @Security BearerAuth
 // @Accept multipart/form-data
@@ -20,7 +25,8 @@ import (
 // @Param workspace_id formData int true "Workspace ID"
 // @Param channel_id formData int false "Channel ID (for channel files)"
 // @Param receiver_id formData int false "Receiver User ID (for direct message files)"
-// @Success 201 {object} map[string]interface{} "File uploaded successfully"
+// @Param is_voice_message formData bool false "Upload as a voice message. The audio must be an allowed type and its duration and waveform are returned"
+// @Success 201 {object} object{message=string,file=service.FileResponse} "File uploaded successfully"
 // @Failure 400 {object} map[string]string "Invalid request, file too large, or validation error"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Access denied - workspace/channel membership required"
@@ -31,72 +37,126 @@ func (server *Server) uploadFile(ctx *gin.Context) {
 	// Get current user
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("user not found in context")))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, fmt.Errorf("user not found in context")))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	// Parse multipart form
-	if err := ctx.Request.ParseMultipartForm(server.config.FileMaxSize); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("failed to parse multipart form: %w", err)))
+	if err := ctx.Request.ParseMultipartForm(server.tunables.Load().FileMaxSize); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("failed to parse multipart form: %w", err)))
 		return
 	}
 
 	// Parse form data
 	var req service.FileUploadRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
-	// Validate that user belongs to the workspace
-	if !server.userService.UserBelongsToWorkspace(user.ID, req.WorkspaceID) {
-		ctx.JSON(http.StatusForbidden, errorResponse(fmt.Errorf("access denied: user does not belong to workspace")))
+	if !server.checkUploadDestination(ctx, user.ID, req.WorkspaceID, req.ChannelID, req.ReceiverID) {
+		return
+	}
+
+	// Upload file
+	fileResponse, err := server.fileService.UploadFile(ctx, req, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"message": "File uploaded successfully",
+		"file":    fileResponse,
+	})
+}
+
+// @Summary Upload Inline Image
+// @Description Upload a pasted image sent as base64 or a data URL (data:image/png;base64,...). PNG, JPEG, GIF and WebP images are accepted and stored like multipart uploads, with deduplication and thumbnails.
+// @ID uploadInlineImage
+// @Tags files
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param image body service.InlineFileUploadRequest true "Pasted image"
+// @Success 201 {object} service.FileResponse "Image uploaded"
+// @Failure 400 {object} map[string]string "Invalid request, invalid base64 or image type not allowed"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied - workspace/channel membership required"
+// @Failure 413 {object} map[string]string "File too large"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /files/inline [post]
+func (server *Server) uploadInlineImage(ctx *gin.Context) {
+	var req service.InlineFileUploadRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	user := getCurrentUser(ctx)
+	if !server.checkUploadDestination(ctx, user.ID, req.WorkspaceID, req.ChannelID, req.ReceiverID) {
 		return
 	}
 
+	fileResponse, err := server.fileService.UploadInlineImage(ctx, req, user.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "exceeds maximum allowed size") {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(ctx, err))
+		} else {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, fileResponse)
+}
+
+// checkUploadDestination checks that the user can upload to the workspace
+// and the channel or direct message the file is for, responding with the
+// error when they can't
+func (server *Server) checkUploadDestination(ctx *gin.Context, userID, workspaceID int64, channelID, receiverID *int64) bool {
+	// Validate that user belongs to the workspace
+	if !server.userService.UserBelongsToWorkspace(ctx, userID, workspaceID) {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, fmt.Errorf("access denied: user does not belong to workspace")))
+		return false
+	}
+
 	// If channel_id is provided, validate user has access to the channel
-	if req.ChannelID != nil {
-		if !server.channelService.UserHasChannelAccess(user.ID, *req.ChannelID) {
-			ctx.JSON(http.StatusForbidden, errorResponse(fmt.Errorf("access denied: user does not have access to channel")))
-			return
+	if channelID != nil {
+		if !server.channelService.UserHasChannelAccess(ctx, userID, *channelID) {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, fmt.Errorf("access denied: user does not have access to channel")))
+			return false
 		}
 	}
 
 	// If receiver_id is provided, validate it's a direct message scenario
-	if req.ReceiverID != nil {
-		if req.ChannelID != nil {
-			ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("cannot specify both channel_id and receiver_id")))
-			return
+	if receiverID != nil {
+		if channelID != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("cannot specify both channel_id and receiver_id")))
+			return false
 		}
 
 		// Validate receiver belongs to the same workspace
-		if !server.userService.UserBelongsToWorkspace(*req.ReceiverID, req.WorkspaceID) {
-			ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("receiver does not belong to the workspace")))
-			return
+		if !server.userService.UserBelongsToWorkspace(ctx, *receiverID, workspaceID) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("receiver does not belong to the workspace")))
+			return false
 		}
 	}
 
-	// Upload file
-	fileResponse, err := server.fileService.UploadFile(req, user.ID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	ctx.JSON(http.StatusCreated, gin.H{
-		"message": "File uploaded successfully",
-		"file":    fileResponse,
-	})
+	return true
 }
 
 // @Summary Download File
-// @Description Download a file by ID (requires appropriate access permissions)
+// @Description Download a file by ID (requires appropriate access permissions). Images are stamped with the workspace name and the downloader's email when the workspace watermarks them.
+// @ID downloadFile
 // @Tags files
 // @Security BearerAuth
 // @Produce application/octet-stream
 // @Param id path int true "File ID"
+// @Param If-None-Match header string false "ETag of a previously downloaded copy"
 // @Success 200 {file} file "File content"
+// @Success 304 "File has not changed"
 // @Failure 400 {object} map[string]string "Invalid file ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 404 {object} map[string]string "File not found or access denied"
@@ -107,36 +167,71 @@ func (server *Server) downloadFile(ctx *gin.Context) {
 	fileIDStr := ctx.Param("id")
 	fileID, err := strconv.ParseInt(fileIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid file ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid file ID")))
 		return
 	}
 
 	// Get current user
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("user not found in context")))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, fmt.Errorf("user not found in context")))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	// Get file content with permission check
-	fileContent, fileInfo, err := server.fileService.GetFileContent(fileID, user.ID)
+	fileContent, fileInfo, err := server.fileService.GetFileContent(ctx, fileID, user.ID)
 	if err != nil {
 		if err.Error() == "file not found" || err.Error() == "access denied: you don't have permission to download this file" {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 		} else {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		}
 		return
 	}
 	defer fileContent.Close()
 
+	// The content hash identifies the file content, so clients can revalidate cheaply
+	etag := fileInfo.FileHash
+	fileSize := fileInfo.FileSize
+
+	// Workspaces can have images stamped with who downloaded them
+	watermarked, watermarkKey, err := server.fileService.OpenWatermarkedImage(ctx, fileInfo, user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+	if watermarked != nil {
+		defer watermarked.Close()
+		stat, err := watermarked.Stat()
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+			return
+		}
+		fileContent, fileSize = watermarked, stat.Size()
+		etag += "-" + watermarkKey
+	}
+
+	ctx.Header("Cache-Control", "must-revalidate")
+	if respondNotModified(ctx, `"`+etag+`"`) {
+		return
+	}
+
+	// Only downloads of the content are audited, not revalidations
+	if err := server.fileService.RecordDownload(ctx, service.FileDownload{
+		FileID:    fileID,
+		UserID:    user.ID,
+		IPAddress: ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
 	// Set appropriate headers
 	ctx.Header("Content-Description", "File Transfer")
 	ctx.Header("Content-Type", fileInfo.MimeType)
 	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileInfo.OriginalFilename))
-	ctx.Header("Content-Length", fmt.Sprintf("%d", fileInfo.FileSize))
-	ctx.Header("Cache-Control", "must-revalidate")
+	ctx.Header("Content-Length", fmt.Sprintf("%d", fileSize))
 
 	// Stream file content
 	if _, err := io.Copy(ctx.Writer, fileContent); err != nil {
@@ -145,14 +240,190 @@ func (server *Server) downloadFile(ctx *gin.Context) {
 	}
 }
 
+// @Summary Get Public File
+// @Description Fetch a file uploaded as public without signing in, so it can be embedded outside the workspace. Workspaces can turn public files off, or only let listed sites embed them. Supports range requests.
+// @ID getPublicFile
+// @Tags files
+// @Produce application/octet-stream
+// @Param key path string true "File public ID, as in the file's public_url"
+// @Param If-None-Match header string false "ETag of a previously fetched copy"
+// @Success 200 {file} file "File content"
+// @Success 206 {file} file "Requested range of the file"
+// @Success 304 "File has not changed"
+// @Failure 403 {object} map[string]string "The referring site can't embed files from this workspace"
+// @Failure 404 {object} map[string]string "File not found or not public"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /public/files/{key} [get]
+func (server *Server) getPublicFile(ctx *gin.Context) {
+	fileID, err := publicid.Decode(publicid.File, ctx.Param("key"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, fmt.Errorf("file not found")))
+		return
+	}
+
+	public, err := server.fileService.OpenPublicFile(ctx, fileID, server.embeddingHost(ctx))
+	if err != nil {
+		if err.Error() == "file not found" {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		} else if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		} else {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+	defer public.Content.Close()
+
+	info, err := public.Content.Stat()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	// Files can stop being served when the workspace turns public files off,
+	// so caches keep them for a while and then revalidate
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(server.config.PublicFileCacheMaxAge.Seconds())))
+	if public.RefererRestricted {
+		ctx.Writer.Header().Add("Vary", "Referer")
+	}
+	ctx.Header("ETag", `"`+public.File.FileHash+`"`)
+
+	// Media is shown in place, anything else is downloaded. Uploads are never
+	// sniffed or run as a page of this site.
+	disposition := "attachment"
+	if mediaType, _, _ := strings.Cut(public.File.MimeType, "/"); mediaType == "image" || mediaType == "video" || mediaType == "audio" {
+		disposition = "inline"
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("%s; filename=\"%s\"", disposition, public.File.OriginalFilename))
+	ctx.Header("Content-Type", public.File.MimeType)
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("Content-Security-Policy", "sandbox")
+
+	http.ServeContent(ctx.Writer, ctx.Request, "", info.ModTime(), public.Content)
+}
+
+// embeddingHost returns the host of the site a public file is embedded on,
+// taken from the Referer. It's empty when the file is opened directly, or
+// from this server or the web app, which can always show it.
+func (server *Server) embeddingHost(ctx *gin.Context) string {
+	referer, err := url.Parse(ctx.Request.Referer())
+	if err != nil || referer.Hostname() == "" {
+		return ""
+	}
+
+	host := referer.Hostname()
+	if strings.EqualFold(host, (&url.URL{Host: ctx.Request.Host}).Hostname()) {
+		return ""
+	}
+	if app, err := url.Parse(server.config.AppBaseURL); err == nil && strings.EqualFold(host, app.Hostname()) {
+		return ""
+	}
+	return host
+}
+
+// @Summary Get Video Playback Rendition
+// @Description Stream the web-friendly MP4 rendition made for an uploaded video (requires appropriate access permissions). Supports range requests for seeking.
+// @ID getVideoPlayback
+// @Tags files
+// @Security BearerAuth
+// @Produce video/mp4
+// @Param id path int true "File ID"
+// @Success 200 {file} file "MP4 video"
+// @Success 206 {file} file "Requested range of the video"
+// @Failure 400 {object} map[string]string "Invalid file ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "File not found, access denied, or video not processed yet"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /files/{id}/playback [get]
+func (server *Server) getVideoPlayback(ctx *gin.Context) {
+	server.serveVideoRendition(ctx, "playback")
+}
+
+// @Summary Get Video Poster
+// @Description Get the poster image made for an uploaded video (requires appropriate access permissions)
+// @ID getVideoPoster
+// @Tags files
+// @Security BearerAuth
+// @Produce image/jpeg
+// @Param id path int true "File ID"
+// @Success 200 {file} file "JPEG poster image"
+// @Failure 400 {object} map[string]string "Invalid file ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "File not found, access denied, or video not processed yet"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /files/{id}/poster [get]
+func (server *Server) getVideoPoster(ctx *gin.Context) {
+	server.serveVideoRendition(ctx, "poster")
+}
+
+// @Summary Get File Thumbnail
+// @Description Get the thumbnail of an uploaded image or the first page preview of a PDF or office document (requires appropriate access permissions)
+// @ID getFileThumbnail
+// @Tags files
+// @Security BearerAuth
+// @Produce image/png
+// @Param id path int true "File ID"
+// @Success 200 {file} file "Thumbnail image"
+// @Failure 400 {object} map[string]string "Invalid file ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "File not found, access denied, or no thumbnail yet"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /files/{id}/thumbnail [get]
+func (server *Server) getFileThumbnail(ctx *gin.Context) {
+	server.serveFileRendition(ctx, func(fileID, userID int64) (*os.File, string, error) {
+		return server.fileService.GetThumbnail(ctx, fileID, userID)
+	})
+}
+
+func (server *Server) serveVideoRendition(ctx *gin.Context, rendition string) {
+	server.serveFileRendition(ctx, func(fileID, userID int64) (*os.File, string, error) {
+		return server.fileService.GetVideoRendition(ctx, fileID, userID, rendition)
+	})
+}
+
+// serveFileRendition serves an image or video made from an upload, opened
+// with its MIME type for the current user
+func (server *Server) serveFileRendition(ctx *gin.Context, open func(fileID, userID int64) (*os.File, string, error)) {
+	fileID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid file ID")))
+		return
+	}
+
+	user := getCurrentUser(ctx)
+
+	content, mimeType, err := open(fileID, user.ID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") || strings.HasPrefix(err.Error(), "access denied") ||
+			err.Error() == "file upload not completed" {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		} else {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+	defer content.Close()
+
+	info, err := content.Stat()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.Header("Content-Type", mimeType)
+	ctx.Header("Cache-Control", "private, max-age=86400")
+	http.ServeContent(ctx.Writer, ctx.Request, "", info.ModTime(), content)
+}
+
 // @Summary Get File Metadata
 // @Description Retrieve file metadata by ID (requires appropriate access permissions)
+// @ID getFile
 // @Tags files
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "File ID"
 // @Param workspace_id query int true "Workspace ID"
-// @Success 200 {object} map[string]interface{} "File metadata"
+// @Success 200 {object} object{file=service.FileResponse} "File metadata"
 // @Failure 400 {object} map[string]string "Invalid file ID or workspace ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Access denied - workspace membership required"
@@ -164,44 +435,44 @@ func (server *Server) getFile(ctx *gin.Context) {
 	fileIDStr := ctx.Param("id")
 	fileID, err := strconv.ParseInt(fileIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid file ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid file ID")))
 		return
 	}
 
 	// Get workspace ID from query parameter
 	workspaceIDStr := ctx.Query("workspace_id")
 	if workspaceIDStr == "" {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("workspace_id is required")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("workspace_id is required")))
 		return
 	}
 
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid workspace_id")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid workspace_id")))
 		return
 	}
 
 	// Get current user
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("user not found in context")))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, fmt.Errorf("user not found in context")))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	// Validate that user belongs to the workspace
-	if !server.userService.UserBelongsToWorkspace(user.ID, workspaceID) {
-		ctx.JSON(http.StatusForbidden, errorResponse(fmt.Errorf("access denied: user does not belong to workspace")))
+	if !server.userService.UserBelongsToWorkspace(ctx, user.ID, workspaceID) {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, fmt.Errorf("access denied: user does not belong to workspace")))
 		return
 	}
 
 	// Get file with permission check
-	fileResponse, err := server.fileService.GetFile(fileID, user.ID, workspaceID)
+	fileResponse, err := server.fileService.GetFile(ctx, fileID, user.ID, workspaceID)
 	if err != nil {
 		if err.Error() == "file not found" || err.Error() == "access denied: you don't have permission to access this file" {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 		} else {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		}
 		return
 	}
@@ -213,13 +484,17 @@ func (server *Server) getFile(ctx *gin.Context) {
 
 // @Summary List Workspace Files
 // @Description List all files in a workspace (requires workspace membership)
+// @ID listWorkspaceFiles
 // @Tags files
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "Workspace ID"
 // @Param page query int false "Page number (default: 1)" minimum(1)
 // @Param limit query int false "Files per page (default: 20, max: 100)" minimum(1) maximum(100)
-// @Success 200 {object} map[string]interface{} "List of files with pagination"
+// @Param fields query string false "Comma-separated fields to return for each file, e.g. id,original_filename,file_size"
+// @Param If-None-Match header string false "ETag of a previously fetched list"
+// @Success 200 {object} object{files=[]service.FileResponse,pagination=object{page=int,limit=int,offset=int}} "List of files with pagination"
+// @Success 304 "List has not changed"
 // @Failure 400 {object} map[string]string "Invalid workspace ID or pagination parameters"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Workspace membership required"
@@ -230,21 +505,21 @@ func (server *Server) listWorkspaceFiles(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid workspace ID")))
 		return
 	}
 
 	// Get current user
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("user not found in context")))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, fmt.Errorf("user not found in context")))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	// Validate that user belongs to the workspace
-	if !server.userService.UserBelongsToWorkspace(user.ID, workspaceID) {
-		ctx.JSON(http.StatusForbidden, errorResponse(fmt.Errorf("access denied: user does not belong to workspace")))
+	if !server.userService.UserBelongsToWorkspace(ctx, user.ID, workspaceID) {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, fmt.Errorf("access denied: user does not belong to workspace")))
 		return
 	}
 
@@ -266,14 +541,19 @@ func (server *Server) listWorkspaceFiles(ctx *gin.Context) {
 	offset := (page - 1) * limit
 
 	// List files
-	files, err := server.fileService.ListWorkspaceFiles(workspaceID, int32(limit), int32(offset))
+	files, err := server.fileService.ListWorkspaceFiles(ctx, workspaceID, int32(limit), int32(offset))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"files": files,
+	selected, ok := filterFields(ctx, files)
+	if !ok {
+		return
+	}
+
+	jsonWithETag(ctx, http.StatusOK, gin.H{
+		"files": selected,
 		"pagination": gin.H{
 			"page":   page,
 			"limit":  limit,
@@ -283,12 +563,13 @@ func (server *Server) listWorkspaceFiles(ctx *gin.Context) {
 }
 
 // @Summary Delete File
-// @Description Delete a file (only file uploader can delete)
+// @Description Move a file to its workspace's trash, where it can be restored until it's purged (file uploader or members with manage_files permission)
+// @ID deleteFile
 // @Tags files
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "File ID"
-// @Success 200 {object} map[string]string "File deleted successfully"
+// @Success 200 {object} map[string]string "File moved to trash"
 // @Failure 400 {object} map[string]string "Invalid file ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Only file uploader can delete"
@@ -300,42 +581,157 @@ func (server *Server) deleteFile(ctx *gin.Context) {
 	fileIDStr := ctx.Param("id")
 	fileID, err := strconv.ParseInt(fileIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid file ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid file ID")))
 		return
 	}
 
 	// Get current user
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("user not found in context")))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, fmt.Errorf("user not found in context")))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	// Delete file
-	if err := server.fileService.DeleteFile(fileID, user.ID); err != nil {
+	if err := server.fileService.DeleteFile(ctx, fileID, user.ID); err != nil {
 		if err.Error() == "file not found" {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 		} else if err.Error() == "access denied: only the file uploader can delete this file" {
-			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
 		} else {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		}
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"message": "File deleted successfully",
+		"message": "File moved to trash",
 	})
 }
 
+// @Summary Get File Access Log
+// @Description List who downloaded a file, when and from where, most recent first (file uploader or members who can manage files)
+// @ID getFileAccessLog
+// @Tags files
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "File ID"
+// @Param limit query int false "Number of entries to return (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of entries to skip (default: 0)" minimum(0)
+// @Success 200 {array} service.FileAccessLogEntry "File downloads"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Only the file uploader or an admin can view the access log"
+// @Failure 404 {object} map[string]string "File not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /files/{id}/access-log [get]
+func (server *Server) getFileAccessLog(ctx *gin.Context) {
+	fileID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid file ID")))
+		return
+	}
+
+	var req service.ListFileAccessLogRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	currentUser := getCurrentUser(ctx)
+	entries, err := server.fileService.ListAccessLog(ctx, fileID, currentUser.ID, req.Limit, req.Offset)
+	if err != nil {
+		if err.Error() == "file not found" {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		} else if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		} else {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// @Summary Get File Settings
+// @Description Get which images downloaded from the workspace are stamped with the workspace name and the downloader's email, and whether its public files can be fetched without signing in (requires workspace admin)
+// @ID getFileSettings
+// @Tags files
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.FileSettingsResponse "File settings"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/settings/files [get]
+func (server *Server) getFileSettings(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid workspace ID")))
+		return
+	}
+
+	settings, err := server.fileService.GetFileSettings(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update File Settings
+// @Description Set which images downloaded from the workspace are watermarked: off, those shared in private channels, or all. Each downloader gets a copy stamped with the workspace name and their email. Also set whether public files can be fetched without signing in, and the sites allowed to embed them; an empty list allows any site. (requires workspace admin)
+// @ID updateFileSettings
+// @Tags files
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param settings body service.UpdateFileSettingsRequest true "Image watermark mode and public file options"
+// @Success 200 {object} service.FileSettingsResponse "File settings updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/settings/files [put]
+func (server *Server) updateFileSettings(ctx *gin.Context) {
+	var req service.UpdateFileSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid workspace ID")))
+		return
+	}
+
+	settings, err := server.fileService.UpdateFileSettings(ctx, workspaceID, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
 // @Summary Get File Statistics
 // @Description Get file statistics for a workspace (requires workspace membership)
+// @ID getFileStats
 // @Tags files
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "Workspace ID"
-// @Success 200 {object} map[string]interface{} "File statistics"
+// @Success 200 {object} object{stats=db.GetFileStatsRow} "File statistics"
 // @Failure 400 {object} map[string]string "Invalid workspace ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Workspace membership required"
@@ -346,28 +742,28 @@ func (server *Server) getFileStats(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("invalid workspace ID")))
 		return
 	}
 
 	// Get current user
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("user not found in context")))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, fmt.Errorf("user not found in context")))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	// Validate that user belongs to the workspace
-	if !server.userService.UserBelongsToWorkspace(user.ID, workspaceID) {
-		ctx.JSON(http.StatusForbidden, errorResponse(fmt.Errorf("access denied: user does not belong to workspace")))
+	if !server.userService.UserBelongsToWorkspace(ctx, user.ID, workspaceID) {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, fmt.Errorf("access denied: user does not belong to workspace")))
 		return
 	}
 
 	// Get file statistics
-	stats, err := server.fileService.GetFileStats(workspaceID)
+	stats, err := server.fileService.GetFileStats(ctx, workspaceID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -376,67 +772,70 @@ func (server *Server) getFileStats(ctx *gin.Context) {
 	})
 }
 
+type sendFileMessageRequest struct {
+	WorkspaceID int64   `json:"workspace_id" binding:"required" example:"1"`
+	ChannelID   *int64  `json:"channel_id" example:"2"`
+	ReceiverID  *int64  `json:"receiver_id"`
+	FileID      *int64  `json:"file_id"`
+	FileIDs     []int64 `json:"file_ids" example:"3,4"`
+	Content     string  `json:"content" example:"Check this out!"` // Optional text content with the files
+}
+
 // @Summary Send File Message
-// @Description Send a message with file attachment to channel or direct message
+// @Description Send a message with one or more file attachments to a channel or direct message. Files are given as file_id, file_ids or both; each has to be accessible to the sender.
+// @ID sendFileMessage
 // @Tags files
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Param message body map[string]interface{} true "File message details" example({"workspace_id": 1, "channel_id": 2, "file_id": 3, "content": "Check this out!"})
-// @Success 201 {object} map[string]interface{} "File message sent successfully"
-// @Failure 400 {object} map[string]string "Invalid request or file access denied"
+// @Param message body sendFileMessageRequest true "File message details"
+// @Success 201 {object} object{message=string,data=service.MessageResponse} "File message sent successfully"
+// @Failure 400 {object} map[string]string "Invalid request, unknown file or too many attachments"
 // @Failure 401 {object} map[string]string "Authentication required"
-// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 403 {object} map[string]string "Workspace membership or file access required"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /files/message [post]
 func (server *Server) sendFileMessage(ctx *gin.Context) {
-	var req struct {
-		WorkspaceID int64  `json:"workspace_id" binding:"required"`
-		ChannelID   *int64 `json:"channel_id"`
-		ReceiverID  *int64 `json:"receiver_id"`
-		FileID      int64  `json:"file_id" binding:"required"`
-		Content     string `json:"content"` // Optional text content with the file
-	}
-
+	var req sendFileMessageRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	// Get current user
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("user not found in context")))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, fmt.Errorf("user not found in context")))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	// Validate that user belongs to the workspace
-	if !server.userService.UserBelongsToWorkspace(user.ID, req.WorkspaceID) {
-		ctx.JSON(http.StatusForbidden, errorResponse(fmt.Errorf("access denied: user does not belong to workspace")))
+	if !server.userService.UserBelongsToWorkspace(ctx, user.ID, req.WorkspaceID) {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, fmt.Errorf("access denied: user does not belong to workspace")))
 		return
 	}
 
 	// Validate channel or receiver
 	if req.ChannelID == nil && req.ReceiverID == nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("either channel_id or receiver_id must be specified")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("either channel_id or receiver_id must be specified")))
 		return
 	}
 
 	if req.ChannelID != nil && req.ReceiverID != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("cannot specify both channel_id and receiver_id")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("cannot specify both channel_id and receiver_id")))
 		return
 	}
 
-	// Check file access
-	_, err := server.fileService.GetFile(req.FileID, user.ID, req.WorkspaceID)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("invalid file or access denied: %w", err)))
+	if req.FileID == nil && len(req.FileIDs) == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, fmt.Errorf("either file_id or file_ids must be specified")))
 		return
 	}
 
-	// Create file message using message service
+	// Create file message using message service, which checks access to
+	// every attached file
 	var messageResponse *service.MessageResponse
+	var err error
 
 	if req.ChannelID != nil {
 		// Channel message
@@ -445,9 +844,10 @@ func (server *Server) sendFileMessage(ctx *gin.Context) {
 			ChannelID:   *req.ChannelID,
 			Content:     req.Content,
 			ContentType: "file",
-			FileID:      &req.FileID,
+			FileID:      req.FileID,
+			FileIDs:     req.FileIDs,
 		}
-		messageResponse, err = server.messageService.CreateChannelMessage(messageReq, user.ID)
+		messageResponse, err = server.messageService.CreateChannelMessage(ctx, messageReq, user.ID)
 	} else {
 		// Direct message
 		messageReq := service.CreateDirectMessageRequest{
@@ -455,13 +855,18 @@ func (server *Server) sendFileMessage(ctx *gin.Context) {
 			ReceiverID:  *req.ReceiverID,
 			Content:     req.Content,
 			ContentType: "file",
-			FileID:      &req.FileID,
+			FileID:      req.FileID,
+			FileIDs:     req.FileIDs,
 		}
-		messageResponse, err = server.messageService.CreateDirectMessage(messageReq, user.ID)
+		messageResponse, err = server.messageService.CreateDirectMessage(ctx, messageReq, user.ID)
 	}
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Trashed Files
+// @Description List a workspace's deleted files that can still be restored, most recently deleted first, with when each will be purged. Members see the files they uploaded, members with manage_files permission see all of them.
+// @ID listTrashedFiles
+// @Tags files
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param limit query int false "Number of files to return (default: 20, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of files to skip (default: 0)" minimum(0)
+// @Success 200 {array} service.TrashedFileResponse "Trashed files"
+// @Failure 400 {object} map[string]string "Invalid workspace ID or pagination parameters"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/files/trash [get]
+func (server *Server) listTrashedFiles(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	var req service.ListTrashRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	currentUser := getCurrentUser(ctx)
+	files, err := server.fileService.ListTrash(ctx, workspaceID, currentUser.ID, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, files)
+}
+
+// @Summary Restore File
+// @Description Take a deleted file out of the trash before it's purged (file uploader or members with manage_files permission)
+// @ID restoreFile
+// @Tags files
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param file_id path int true "File ID"
+// @Success 200 {object} service.FileResponse "Restored file"
+// @Failure 400 {object} map[string]string "Invalid workspace or file ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Only the file uploader or members who can manage files can restore it"
+// @Failure 404 {object} map[string]string "Trashed file not found or already purged"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/files/trash/{file_id}/restore [post]
+func (server *Server) restoreFile(ctx *gin.Context) {
+	workspaceID, fileID, ok := parseTrashedFileParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+	file, err := server.fileService.RestoreFile(ctx, workspaceID, fileID, currentUser.ID)
+	if err != nil {
+		handleFileTrashError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, file)
+}
+
+// @Summary Delete File Permanently
+// @Description Delete a file in the trash and its content for good, without waiting for it to be purged (file uploader or members with manage_files permission)
+// @ID deleteFilePermanently
+// @Tags files
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param file_id path int true "File ID"
+// @Success 204 "File deleted permanently"
+// @Failure 400 {object} map[string]string "Invalid workspace or file ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Only the file uploader or members who can manage files can delete it"
+// @Failure 404 {object} map[string]string "Trashed file not found"
+// @Failure 409 {object} map[string]string "File is under legal hold"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/files/trash/{file_id} [delete]
+func (server *Server) deleteFilePermanently(ctx *gin.Context) {
+	workspaceID, fileID, ok := parseTrashedFileParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+	if err := server.fileService.DeleteFilePermanently(ctx, workspaceID, fileID, currentUser.ID); err != nil {
+		handleFileTrashError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// parseTrashedFileParams reads the workspace and file IDs of a trash route,
+// responding with a bad request when either is invalid
+func parseTrashedFileParams(ctx *gin.Context) (int64, int64, bool) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return 0, 0, false
+	}
+
+	fileID, err := strconv.ParseInt(ctx.Param("file_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid file ID")))
+		return 0, 0, false
+	}
+
+	return workspaceID, fileID, true
+}
+
+func handleFileTrashError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "under legal hold"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
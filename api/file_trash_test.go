@@ -0,0 +1,136 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTrashedFilesAPI(t *testing.T) {
+	member, _ := randomUser(t)
+	workspace := randomWorkspace(member.OrganizationID)
+	member.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	deletedAt := time.Now().Add(-time.Hour)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(member.Email)).Times(1).Return(member, nil)
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return("member", nil)
+	store.EXPECT().GetUserRolePermissions(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, sql.ErrNoRows)
+	store.EXPECT().
+		ListTrashedFiles(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ interface{}, arg db.ListTrashedFilesParams) ([]db.ListTrashedFilesRow, error) {
+			// Members only see the files they deleted from their own uploads
+			require.Equal(t, sql.NullInt64{Int64: member.ID, Valid: true}, arg.UploaderID)
+			require.Equal(t, int32(5), arg.Limit)
+			return []db.ListTrashedFilesRow{{
+				ID:               9,
+				WorkspaceID:      workspace.ID,
+				UploaderID:       member.ID,
+				OriginalFilename: "notes.txt",
+				DeletedAt:        sql.NullTime{Time: deletedAt, Valid: true},
+			}}, nil
+		})
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/workspaces/%d/files/trash?limit=5", workspace.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, member.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var files []service.TrashedFileResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &files))
+	require.Len(t, files, 1)
+	require.Equal(t, "notes.txt", files[0].OriginalFilename)
+	require.WithinDuration(t, deletedAt.Add(720*time.Hour), files[0].PurgeAt, time.Second)
+}
+
+func TestDeleteFilePermanentlyAPI(t *testing.T) {
+	member, _ := randomUser(t)
+	workspace := randomWorkspace(member.OrganizationID)
+	member.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	file := db.File{ID: 9, WorkspaceID: workspace.ID, UploaderID: member.ID, FilePath: filepath.Join(t.TempDir(), "gone.txt")}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTrashedFile(gomock.Any(), db.GetTrashedFileParams{ID: file.ID, WorkspaceID: workspace.ID}).Times(1).Return(file, nil)
+				store.EXPECT().HasActiveLegalHold(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+				store.EXPECT().PurgeFile(gomock.Any(), file.ID).Times(1).Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNoContent, recorder.Code)
+			},
+		},
+		{
+			name: "NotInTrash",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTrashedFile(gomock.Any(), gomock.Any()).Times(1).Return(db.File{}, sql.ErrNoRows)
+				store.EXPECT().PurgeFile(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "LegalHold",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTrashedFile(gomock.Any(), gomock.Any()).Times(1).Return(file, nil)
+				store.EXPECT().HasActiveLegalHold(gomock.Any(), gomock.Any()).Times(1).Return(true, nil)
+				store.EXPECT().PurgeFile(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(member.Email)).Times(1).Return(member, nil)
+			store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/files/trash/%d", workspace.ID, file.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, member.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
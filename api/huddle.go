@@ -0,0 +1,220 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Start Huddle
+// @Description Start an audio/video huddle in a channel or with another user (requires workspace membership). If the conversation already has a huddle the user joins it instead. Clients then exchange WebRTC offers, answers and ICE candidates over the WebSocket as huddle_offer, huddle_answer and huddle_ice_candidate messages with huddle_id and to_user_id.
+// @ID startHuddle
+// @Tags huddles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.StartHuddleRequest true "Channel or user to start the huddle with"
+// @Success 201 {object} service.HuddleResponse "Huddle started"
+// @Success 200 {object} service.HuddleResponse "Joined the conversation's huddle"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace or channel membership required"
+// @Failure 404 {object} map[string]string "Channel or user not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/huddles [post]
+func (server *Server) startHuddle(ctx *gin.Context) {
+	var req service.StartHuddleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	huddle, created, err := server.callService.StartHuddle(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		handleHuddleError(ctx, err)
+		return
+	}
+
+	if created {
+		ctx.JSON(http.StatusCreated, huddle)
+		return
+	}
+	ctx.JSON(http.StatusOK, huddle)
+}
+
+// @Summary Get Huddle
+// @Description Get an active huddle and its participants (requires access to the huddle's conversation)
+// @ID getHuddle
+// @Tags huddles
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param huddle_id path int true "Huddle ID"
+// @Success 200 {object} service.HuddleResponse "Huddle"
+// @Failure 400 {object} map[string]string "Invalid workspace or huddle ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access to the conversation required"
+// @Failure 404 {object} map[string]string "Huddle not found or ended"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/huddles/{huddle_id} [get]
+func (server *Server) getHuddle(ctx *gin.Context) {
+	workspaceID, huddleID, ok := parseHuddleParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	huddle, err := server.callService.GetHuddle(ctx, workspaceID, huddleID, currentUser.ID)
+	if err != nil {
+		handleHuddleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, huddle)
+}
+
+// @Summary Join Huddle
+// @Description Join an active huddle, leaving any other huddle the user is in (requires access to the huddle's conversation)
+// @ID joinHuddle
+// @Tags huddles
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param huddle_id path int true "Huddle ID"
+// @Success 200 {object} service.HuddleResponse "Joined huddle"
+// @Failure 400 {object} map[string]string "Invalid workspace or huddle ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access to the conversation required"
+// @Failure 404 {object} map[string]string "Huddle not found or ended"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/huddles/{huddle_id}/join [post]
+func (server *Server) joinHuddle(ctx *gin.Context) {
+	workspaceID, huddleID, ok := parseHuddleParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	huddle, err := server.callService.JoinHuddle(ctx, workspaceID, huddleID, currentUser.ID)
+	if err != nil {
+		handleHuddleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, huddle)
+}
+
+// @Summary Leave Huddle
+// @Description Leave a huddle. The huddle ends when its last participant leaves.
+// @ID leaveHuddle
+// @Tags huddles
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param huddle_id path int true "Huddle ID"
+// @Success 200 {object} map[string]string "Left huddle"
+// @Failure 400 {object} map[string]string "Invalid workspace or huddle ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "User is not in the huddle"
+// @Failure 404 {object} map[string]string "Huddle not found or ended"
+// @Router /workspaces/{id}/huddles/{huddle_id}/leave [post]
+func (server *Server) leaveHuddle(ctx *gin.Context) {
+	workspaceID, huddleID, ok := parseHuddleParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	if err := server.callService.LeaveHuddle(workspaceID, huddleID, currentUser.ID); err != nil {
+		handleHuddleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "left huddle"})
+}
+
+// @Summary Update Huddle Media
+// @Description Tell the other participants whether your microphone, camera and screen share are on
+// @ID updateHuddleMedia
+// @Tags huddles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param huddle_id path int true "Huddle ID"
+// @Param request body service.UpdateHuddleMediaRequest true "Media state"
+// @Success 200 {object} service.HuddleParticipantResponse "Updated participant"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "User is not in the huddle"
+// @Failure 404 {object} map[string]string "Huddle not found or ended"
+// @Router /workspaces/{id}/huddles/{huddle_id}/media [put]
+func (server *Server) updateHuddleMedia(ctx *gin.Context) {
+	var req service.UpdateHuddleMediaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, huddleID, ok := parseHuddleParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	participant, err := server.callService.UpdateMedia(workspaceID, huddleID, currentUser.ID, req)
+	if err != nil {
+		handleHuddleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, participant)
+}
+
+// parseHuddleParams reads the workspace and huddle IDs from the URL, responding
+// with an error if either is invalid
+func parseHuddleParams(ctx *gin.Context) (workspaceID, huddleID int64, ok bool) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return 0, 0, false
+	}
+
+	huddleID, err = strconv.ParseInt(ctx.Param("huddle_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid huddle ID")))
+		return 0, 0, false
+	}
+
+	return workspaceID, huddleID, true
+}
+
+func handleHuddleError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "invalid"):
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartHuddleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "DirectConversation",
+			body: gin.H{"user_id": other.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Eq(db.CheckUserWorkspaceRoleParams{
+						ID:          other.ID,
+						WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+					})).
+					Times(1).
+					Return("member", nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response service.HuddleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, workspace.ID, response.WorkspaceID)
+				require.ElementsMatch(t, []int64{user.ID, other.ID}, response.UserIDs)
+				require.Len(t, response.Participants, 1)
+				require.Equal(t, user.ID, response.Participants[0].UserID)
+			},
+		},
+		{
+			name: "ChannelAndUser",
+			body: gin.H{"user_id": other.ID, "channel_id": 3},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UserNotInWorkspace",
+			body: gin.H{"user_id": other.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			// requireWorkspaceMember checks the caller first
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Eq(db.CheckUserWorkspaceRoleParams{
+					ID:          user.ID,
+					WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+				})).
+				Times(1).
+				Return("member", nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/huddles", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
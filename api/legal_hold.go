@@ -0,0 +1,215 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Create Legal Hold
+// @Description Place a user or channel of the organization under legal hold (organization admin only). While the hold is active the held channel, workspaces holding the content, files uploaded by a held user and the organization can't be deleted.
+// @ID createLegalHold
+// @Tags legal-holds
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body service.CreateLegalHoldRequest true "Held user or channel and reason"
+// @Success 201 {object} service.LegalHoldResponse "Legal hold"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "User or channel not found"
+// @Failure 409 {object} map[string]string "Target already under legal hold"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/legal-holds [post]
+func (server *Server) createLegalHold(ctx *gin.Context) {
+	var req service.CreateLegalHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	hold, err := server.legalHoldService.CreateLegalHold(ctx, organizationID, currentUser.ID, req)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "already exists"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "invalid legal hold"):
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, hold)
+}
+
+// @Summary List Legal Holds
+// @Description List the organization's active legal holds, newest first (organization admin only)
+// @ID listLegalHolds
+// @Tags legal-holds
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param include_released query bool false "Include released holds"
+// @Param limit query int false "Number of holds to return (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of holds to skip (default: 0)" minimum(0)
+// @Success 200 {array} service.LegalHoldResponse "Legal holds"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/legal-holds [get]
+func (server *Server) listLegalHolds(ctx *gin.Context) {
+	var req service.ListLegalHoldsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	holds, err := server.legalHoldService.ListLegalHolds(ctx, organizationID, req.IncludeReleased, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, holds)
+}
+
+// @Summary Release Legal Hold
+// @Description Release an active legal hold (organization admin only). The content can be deleted again unless another hold covers it.
+// @ID releaseLegalHold
+// @Tags legal-holds
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param hold_id path int true "Legal hold ID"
+// @Success 200 {object} service.LegalHoldResponse "Released legal hold"
+// @Failure 400 {object} map[string]string "Invalid organization or hold ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "Legal hold not found"
+// @Failure 409 {object} map[string]string "Legal hold already released"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/legal-holds/{hold_id}/release [post]
+func (server *Server) releaseLegalHold(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	holdID, err := strconv.ParseInt(ctx.Param("hold_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid legal hold ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	hold, err := server.legalHoldService.ReleaseLegalHold(ctx, organizationID, holdID, currentUser.ID)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "already released"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, hold)
+}
+
+// @Summary Export Legal Hold
+// @Description Export a page of the messages covered by a legal hold, oldest first, for compliance review (organization admin only). A user hold covers the messages they sent or received, a channel hold every message in the channel. Deleted messages are included. Messages moved to the message archive before the hold was placed are exported separately with archived=true.
+// @ID exportLegalHold
+// @Tags legal-holds
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param hold_id path int true "Legal hold ID"
+// @Param limit query int false "Number of messages to return (default: 500, max: 1000)" minimum(1) maximum(1000)
+// @Param offset query int false "Number of messages to skip (default: 0)" minimum(0)
+// @Param archived query bool false "Export the held messages moved to the message archive instead, which is slower"
+// @Success 200 {object} service.LegalHoldExportResponse "Held messages"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "Legal hold not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/legal-holds/{hold_id}/export [get]
+func (server *Server) exportLegalHold(ctx *gin.Context) {
+	var req exportLegalHoldRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 500
+	}
+
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	holdID, err := strconv.ParseInt(ctx.Param("hold_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid legal hold ID")))
+		return
+	}
+
+	var export *service.LegalHoldExportResponse
+	if req.Archived {
+		export, err = server.legalHoldService.ExportArchivedLegalHold(ctx, organizationID, holdID, req.Limit, req.Offset)
+	} else {
+		export, err = server.legalHoldService.ExportLegalHold(ctx, organizationID, holdID, req.Limit, req.Offset)
+	}
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, export)
+}
+
+type exportLegalHoldRequest struct {
+	Limit    int32 `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Offset   int32 `form:"offset" binding:"omitempty,min=0"`
+	Archived bool  `form:"archived"`
+}
@@ -0,0 +1,369 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLegalHoldAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	target, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+	target.OrganizationID = admin.OrganizationID
+
+	channel := randomChannel(workspace.ID, admin.ID)
+
+	testCases := []struct {
+		name           string
+		organizationID int64
+		orgRole        string
+		body           gin.H
+		buildStubs     func(store *mockdb.MockStore)
+		checkResponse  func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:           "HoldUser",
+			organizationID: admin.OrganizationID,
+			orgRole:        service.OrganizationRoleAdmin,
+			body:           gin.H{"target_type": "user", "target_id": target.ID, "reason": "Pending litigation"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(target, nil)
+				store.EXPECT().
+					CreateLegalHold(gomock.Any(), gomock.Eq(db.CreateLegalHoldParams{
+						OrganizationID: admin.OrganizationID,
+						TargetType:     "user",
+						TargetID:       target.ID,
+						Reason:         "Pending litigation",
+						CreatedBy:      sql.NullInt64{Int64: admin.ID, Valid: true},
+					})).
+					Times(1).
+					Return(db.LegalHold{
+						ID:             1,
+						OrganizationID: admin.OrganizationID,
+						TargetType:     "user",
+						TargetID:       target.ID,
+						Reason:         "Pending litigation",
+						CreatedBy:      sql.NullInt64{Int64: admin.ID, Valid: true},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response service.LegalHoldResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.True(t, response.Active)
+				require.Equal(t, target.ID, response.TargetID)
+			},
+		},
+		{
+			name:           "HoldChannel",
+			organizationID: admin.OrganizationID,
+			orgRole:        service.OrganizationRoleAdmin,
+			body:           gin.H{"target_type": "channel", "target_id": channel.ID, "reason": "Audit"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(workspace, nil)
+				store.EXPECT().
+					CreateLegalHold(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LegalHold{ID: 2, OrganizationID: admin.OrganizationID, TargetType: "channel", TargetID: channel.ID}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:           "UserInOtherOrganization",
+			organizationID: admin.OrganizationID,
+			orgRole:        service.OrganizationRoleAdmin,
+			body:           gin.H{"target_type": "user", "target_id": target.ID, "reason": "Pending litigation"},
+			buildStubs: func(store *mockdb.MockStore) {
+				other := target
+				other.OrganizationID = admin.OrganizationID + 1
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(other, nil)
+				store.EXPECT().CreateLegalHold(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:           "AlreadyHeld",
+			organizationID: admin.OrganizationID,
+			orgRole:        service.OrganizationRoleAdmin,
+			body:           gin.H{"target_type": "user", "target_id": target.ID, "reason": "Pending litigation"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(target, nil)
+				store.EXPECT().
+					CreateLegalHold(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.LegalHold{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:           "NotOrganizationAdmin",
+			organizationID: admin.OrganizationID,
+			orgRole:        "",
+			body:           gin.H{"target_type": "user", "target_id": target.ID, "reason": "Pending litigation"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLegalHold(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:           "OtherOrganization",
+			organizationID: admin.OrganizationID + 1,
+			orgRole:        service.OrganizationRoleAdmin,
+			body:           gin.H{"target_type": "user", "target_id": target.ID, "reason": "Pending litigation"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLegalHold(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:           "MissingReason",
+			organizationID: admin.OrganizationID,
+			orgRole:        service.OrganizationRoleAdmin,
+			body:           gin.H{"target_type": "user", "target_id": target.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLegalHold(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := admin
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			if tc.organizationID == admin.OrganizationID {
+				orgRoleStub(store, admin, tc.orgRole)
+			}
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/organizations/%d/legal-holds", tc.organizationID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReleaseLegalHoldAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = "admin"
+
+	hold := db.LegalHold{
+		ID:             7,
+		OrganizationID: admin.OrganizationID,
+		TargetType:     "channel",
+		TargetID:       3,
+		Reason:         "Audit",
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				released := hold
+				released.ReleasedBy = sql.NullInt64{Int64: admin.ID, Valid: true}
+				released.ReleasedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().
+					ReleaseLegalHold(gomock.Any(), gomock.Eq(db.ReleaseLegalHoldParams{
+						ID:             hold.ID,
+						OrganizationID: admin.OrganizationID,
+						ReleasedBy:     sql.NullInt64{Int64: admin.ID, Valid: true},
+					})).
+					Times(1).
+					Return(released, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.LegalHoldResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.False(t, response.Active)
+			},
+		},
+		{
+			name: "AlreadyReleased",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReleaseLegalHold(gomock.Any(), gomock.Any()).Times(1).Return(db.LegalHold{}, sql.ErrNoRows)
+				store.EXPECT().GetLegalHold(gomock.Any(), gomock.Any()).Times(1).Return(hold, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReleaseLegalHold(gomock.Any(), gomock.Any()).Times(1).Return(db.LegalHold{}, sql.ErrNoRows)
+				store.EXPECT().GetLegalHold(gomock.Any(), gomock.Any()).Times(1).Return(db.LegalHold{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+				Times(1).
+				Return(admin, nil)
+			orgRoleStub(store, admin, service.OrganizationRoleAdmin)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/organizations/%d/legal-holds/%d/release", admin.OrganizationID, hold.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestExportLegalHoldAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.Role = "admin"
+
+	hold := db.LegalHold{
+		ID:             8,
+		OrganizationID: admin.OrganizationID,
+		TargetType:     "user",
+		TargetID:       admin.ID + 1,
+		Reason:         "Investigation",
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).Times(1).Return(admin, nil)
+	orgRoleStub(store, admin, service.OrganizationRoleAdmin)
+	store.EXPECT().
+		GetLegalHold(gomock.Any(), gomock.Eq(db.GetLegalHoldParams{ID: hold.ID, OrganizationID: admin.OrganizationID})).
+		Times(1).
+		Return(hold, nil)
+	store.EXPECT().
+		ListLegalHoldMessages(gomock.Any(), gomock.Eq(db.ListLegalHoldMessagesParams{
+			TargetType: "user",
+			TargetID:   hold.TargetID,
+			Limit:      500,
+			Offset:     0,
+		})).
+		Times(1).
+		Return([]db.ListLegalHoldMessagesRow{
+			{ID: 1, SenderID: hold.TargetID, Content: "kept", MessageType: "channel"},
+			{ID: 2, SenderID: hold.TargetID, Content: "deleted", MessageType: "channel", DeletedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/organizations/%d/legal-holds/%d/export", admin.OrganizationID, hold.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response service.LegalHoldExportResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Messages, 2)
+	// Deleted messages are part of the export
+	require.NotNil(t, response.Messages[1].DeletedAt)
+}
+
+func TestDeleteChannelUnderLegalHoldAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+	channel := randomChannel(workspace.ID, admin.ID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).Times(1).Return(admin, nil)
+	store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).AnyTimes().Return(channel, nil)
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return("admin", nil)
+	store.EXPECT().ChannelHasActiveLegalHold(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(true, nil)
+	store.EXPECT().SoftDeleteChannel(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/channels/%d", channel.ID)
+	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusConflict, recorder.Code)
+}
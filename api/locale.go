@@ -0,0 +1,42 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/i18n"
+)
+
+const localizerKey = "localizer"
+
+// newTranslator builds the translator from the built-in English messages and
+// the catalogs in the configured directory
+func newTranslator(catalogPath string) (*i18n.Translator, error) {
+	catalog := i18n.NewMapCatalog()
+	if catalogPath != "" {
+		if err := catalog.LoadDir(catalogPath); err != nil {
+			return nil, err
+		}
+	}
+	return i18n.NewTranslator(catalog), nil
+}
+
+// localeMiddleware picks the response language from the Accept-Language header
+func localeMiddleware(translator *i18n.Translator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		lang := translator.Match(ctx.GetHeader("Accept-Language"))
+
+		ctx.Set(localizerKey, translator.Localizer(lang))
+		ctx.Header("Content-Language", lang.String())
+		ctx.Header("Vary", "Accept-Language")
+		ctx.Next()
+	}
+}
+
+// getLocalizer returns the localizer for the request, or nil outside of the
+// locale middleware
+func getLocalizer(ctx *gin.Context) *i18n.Localizer {
+	localizer, exists := ctx.Get(localizerKey)
+	if !exists {
+		return nil
+	}
+	return localizer.(*i18n.Localizer)
+}
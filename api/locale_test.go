@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizedErrorResponse(t *testing.T) {
+	dir := t.TempDir()
+	catalog := `{"authorization header is not provided": "no se proporcionó el encabezado de autorización"}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "es.json"), []byte(catalog), 0o644))
+
+	testCases := []struct {
+		name             string
+		acceptLanguage   string
+		expectedLanguage string
+		expectedError    string
+	}{
+		{
+			name:             "Default",
+			expectedLanguage: "en",
+			expectedError:    "authorization header is not provided",
+		},
+		{
+			name:             "Spanish",
+			acceptLanguage:   "es-ES,es;q=0.9,en;q=0.5",
+			expectedLanguage: "es",
+			expectedError:    "no se proporcionó el encabezado de autorización",
+		},
+		{
+			name:             "Unsupported",
+			acceptLanguage:   "ja",
+			expectedLanguage: "en",
+			expectedError:    "authorization header is not provided",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := util.Config{
+				TokenSymmetricKey:   util.RandomString(32),
+				AccessTokenDuration: time.Minute,
+				I18NCatalogPath:     dir,
+			}
+			server, err := NewServer(config, mockdb.NewMockStore(ctrl))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/workspaces/%d/dnd", 1), nil)
+			require.NoError(t, err)
+			if tc.acceptLanguage != "" {
+				request.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			require.Equal(t, tc.expectedLanguage, recorder.Header().Get("Content-Language"))
+
+			var response map[string]string
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			require.Equal(t, tc.expectedError, response["error"])
+		})
+	}
+}
+
+func TestInvalidMessageCatalog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "es.json"), []byte("not json"), 0o644))
+
+	config := util.Config{
+		TokenSymmetricKey: util.RandomString(32),
+		I18NCatalogPath:   dir,
+	}
+	_, err := NewServer(config, mockdb.NewMockStore(ctrl))
+	require.Error(t, err)
+}
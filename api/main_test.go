@@ -1,11 +1,14 @@
 package api
 
 import (
+	"context"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
 	db "github.com/heyrmi/goslack/db/sqlc"
 	"github.com/heyrmi/goslack/util"
 	"github.com/stretchr/testify/require"
@@ -23,6 +26,17 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 	return server
 }
 
+// expectTx runs transactions against the mock store itself, so the queries
+// made inside them are expected like any others
+func expectTx(store *mockdb.MockStore) {
+	store.EXPECT().
+		ExecTx(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(ctx context.Context, fn func(db.Querier) error) error {
+			return fn(store)
+		})
+}
+
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
 	os.Exit(m.Run())
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// errMaintenance is returned for writes rejected during maintenance
+var errMaintenance = errors.New("service is under maintenance, try again later")
+
+// maintenanceMode middleware rejects writes while the server is in
+// maintenance. Reads, operator routes and the allowed routes keep working.
+func maintenanceMode(maintenanceService *service.MaintenanceService, allowedRoutes []string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(allowedRoutes))
+	for _, route := range allowedRoutes {
+		allowed[route] = true
+	}
+
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		switch ctx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			ctx.Next()
+			return
+		}
+
+		// Unknown routes still get a 404
+		route := ctx.FullPath()
+		if route == "" || allowed[route] || strings.HasPrefix(route, "/admin/") {
+			ctx.Next()
+			return
+		}
+
+		status := maintenanceService.Status()
+		if !status.Enabled {
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("Retry-After", strconv.Itoa(int(status.RetryAfterSeconds)))
+		response := errorResponse(ctx, errMaintenance)
+		response["maintenance"] = status
+		ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, response)
+	})
+}
+
+// @Summary Get Maintenance Status
+// @Description Get whether the server is in maintenance. Requires the X-Admin-Token header.
+// @ID getMaintenance
+// @Tags system
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Success 200 {object} service.MaintenanceResponse "Maintenance status"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Admin routes are disabled"
+// @Router /admin/maintenance [get]
+func (server *Server) getMaintenance(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, server.maintenanceService.Status())
+}
+
+// @Summary Update Maintenance Status
+// @Description Turn maintenance on or off. While it is on, reads keep working and writes are rejected with 503 and a Retry-After header, except for the routes in MAINTENANCE_ALLOWED_ROUTES and operator routes. WebSocket clients get a maintenance event when it starts and ends. Applies to this server only. Requires the X-Admin-Token header.
+// @ID updateMaintenance
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param request body service.UpdateMaintenanceRequest true "Maintenance settings"
+// @Success 200 {object} service.MaintenanceResponse "Maintenance status"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Admin routes are disabled"
+// @Router /admin/maintenance [put]
+func (server *Server) updateMaintenance(ctx *gin.Context) {
+	var req service.UpdateMaintenanceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.maintenanceService.UpdateMaintenance(req))
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).AnyTimes().Return(user, nil)
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return(user.Role, nil)
+
+	config := util.Config{
+		TokenSymmetricKey:        util.RandomString(32),
+		AccessTokenDuration:      time.Minute,
+		AdminAPIToken:            "operator",
+		MaintenanceRetryAfter:    time.Minute,
+		MaintenanceAllowedRoutes: "/workspace/:id/status",
+	}
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	send := func(method, url, body string) *httptest.ResponseRecorder {
+		request, err := http.NewRequest(method, url, bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", gin.MIMEJSON)
+		request.Header.Set(adminTokenHeader, "operator")
+		addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// The bodies fail validation, so writes that get through answer 400
+	messagesURL := fmt.Sprintf("/workspace/%d/channels/1/messages", workspace.ID)
+	statusURL := fmt.Sprintf("/workspace/%d/status", workspace.ID)
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPost, messagesURL, `{}`).Code)
+
+	recorder := send(http.MethodPut, "/admin/maintenance", `{"enabled":true,"message":"Upgrading"}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var status service.MaintenanceResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	require.True(t, status.Enabled)
+	require.Equal(t, "Upgrading", status.Message)
+
+	recorder = send(http.MethodPost, messagesURL, `{}`)
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	require.Equal(t, "60", recorder.Header().Get("Retry-After"))
+	require.Contains(t, recorder.Body.String(), errMaintenance.Error())
+
+	// Allowed routes and unknown routes are unaffected
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPut, statusURL, `{}`).Code)
+	require.Equal(t, http.StatusNotFound, send(http.MethodPost, "/no-such-route", `{}`).Code)
+
+	recorder = send(http.MethodGet, "/admin/maintenance", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), `"enabled":true`)
+
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPut, "/admin/maintenance", `{}`).Code)
+	require.Equal(t, http.StatusOK, send(http.MethodPut, "/admin/maintenance", `{"enabled":false}`).Code)
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPost, messagesURL, `{}`).Code)
+}
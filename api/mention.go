@@ -0,0 +1,86 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Mentions
+// @Description List the messages in a workspace mentioning the current user, newest first. Each mention comes with its channel name, permalink, read state and the messages leading up to it.
+// @ID listMentions
+// @Tags mentions
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param limit query int false "Number of mentions to retrieve (default: 20, max: 50)" minimum(1) maximum(50)
+// @Param offset query int false "Number of mentions to skip (default: 0)" minimum(0)
+// @Param unread_only query bool false "Only list mentions not yet marked as read"
+// @Success 200 {object} service.MentionsResponse "Mentions and the number of unread mentions"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/mentions [get]
+func (server *Server) listMentions(ctx *gin.Context) {
+	var req service.ListMentionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	mentions, err := server.messageService.GetUserMentions(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mentions)
+}
+
+// @Summary Mark Mention As Read
+// @Description Clear a mention of the current user from their unread mentions. Returns the number of unread mentions left in the message's workspace.
+// @ID markMentionAsRead
+// @Tags mentions
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "ID of the message with the mention"
+// @Success 200 {object} service.MentionReadResponse "Mention marked as read"
+// @Failure 400 {object} map[string]string "Invalid message ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "Mention not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /mentions/{id}/mark-read [post]
+func (server *Server) markMentionAsRead(ctx *gin.Context) {
+	messageID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	result, err := server.messageService.MarkMentionAsRead(ctx, messageID, currentUser.ID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
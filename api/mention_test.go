@@ -0,0 +1,109 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkMentionAsReadAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+
+	message := db.GetMessageByIDRow{
+		ID:          util.RandomInt(1, 1000),
+		WorkspaceID: util.RandomInt(1, 1000),
+		ChannelID:   sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true},
+		SenderID:    otherUser.ID,
+		Content:     fmt.Sprintf("Hi <@%d>", user.ID),
+		MessageType: "channel",
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+
+				readAt := time.Now()
+				store.EXPECT().
+					MarkMentionRead(gomock.Any(), gomock.Eq(db.MarkMentionReadParams{MessageID: message.ID, UserID: user.ID})).
+					Times(1).
+					Return(db.MessageMention{MessageID: message.ID, UserID: user.ID, ReadAt: sql.NullTime{Time: readAt, Valid: true}}, nil)
+				store.EXPECT().
+					CountUnreadMentions(gomock.Any(), gomock.Eq(db.CountUnreadMentionsParams{UserID: user.ID, WorkspaceID: message.WorkspaceID})).
+					Times(1).
+					Return(int64(4), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.MentionReadResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, message.ID, response.MessageID)
+				require.Equal(t, int64(4), response.UnreadCount)
+			},
+		},
+		{
+			name: "NotMentioned",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().MarkMentionRead(gomock.Any(), gomock.Any()).Times(1).Return(db.MessageMention{}, sql.ErrNoRows)
+				store.EXPECT().CountUnreadMentions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "MessageDeleted",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(db.GetMessageByIDRow{}, sql.ErrNoRows)
+				store.EXPECT().MarkMentionRead(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).AnyTimes().Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/mentions/%d/mark-read", message.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
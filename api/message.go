@@ -4,13 +4,16 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/heyrmi/goslack/service"
 )
 
 // @Summary Send Channel Message
-// @Description Send a message to a specific channel (requires workspace membership)
+// @Description Send a message to a specific channel (requires workspace membership). Workspace members mentioned as <@user_id> are listed in the response. With require_acknowledgment the channel's members are asked to acknowledge the message, and those who haven't are reminded once after acknowledgment_reminder_minutes, or the server's default delay.
+// @ID sendChannelMessage
 // @Tags messages
 // @Security BearerAuth
 // @Accept json
@@ -22,12 +25,13 @@ import (
 // @Failure 400 {object} map[string]string "Invalid request or IDs"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 422 {object} map[string]string "Message blocked by content moderation"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /workspace/{id}/channels/{channel_id}/messages [post]
 func (server *Server) sendChannelMessage(ctx *gin.Context) {
 	var req service.SendChannelMessageRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -35,7 +39,7 @@ func (server *Server) sendChannelMessage(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
 		return
 	}
 
@@ -43,7 +47,7 @@ func (server *Server) sendChannelMessage(ctx *gin.Context) {
 	channelIDStr := ctx.Param("channel_id")
 	channelID, err := strconv.ParseInt(channelIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid channel ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
 		return
 	}
 
@@ -51,9 +55,19 @@ func (server *Server) sendChannelMessage(ctx *gin.Context) {
 	currentUser := getCurrentUser(ctx)
 
 	// Send message
-	message, err := server.messageService.SendChannelMessage(ctx, workspaceID, channelID, currentUser.ID, req.Content)
+	var message *service.MessageResponse
+	if req.RequireAcknowledgment {
+		remindAfter := time.Duration(req.AcknowledgmentReminderMinutes) * time.Minute
+		message, err = server.messageService.SendMustReadMessage(ctx, workspaceID, channelID, currentUser.ID, req.Content, remindAfter)
+	} else {
+		message, err = server.messageService.SendChannelMessage(ctx, workspaceID, channelID, currentUser.ID, req.Content)
+	}
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if err.Error() == "message blocked by content moderation" {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -61,7 +75,8 @@ func (server *Server) sendChannelMessage(ctx *gin.Context) {
 }
 
 // @Summary Send Direct Message
-// @Description Send a direct message to another user (requires workspace membership)
+// @Description Send a direct message to another user (requires workspace membership). For urgent messages the response says whether the recipient is in Do Not Disturb and can be notified anyway.
+// @ID sendDirectMessage
 // @Tags messages
 // @Security BearerAuth
 // @Accept json
@@ -72,12 +87,13 @@ func (server *Server) sendChannelMessage(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string "Invalid request or workspace ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 422 {object} map[string]string "Message blocked by content moderation"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /workspace/{id}/messages/direct [post]
 func (server *Server) sendDirectMessage(ctx *gin.Context) {
 	var req service.SendDirectMessageRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -85,7 +101,7 @@ func (server *Server) sendDirectMessage(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
 		return
 	}
 
@@ -95,15 +111,30 @@ func (server *Server) sendDirectMessage(ctx *gin.Context) {
 	// Send message
 	message, err := server.messageService.SendDirectMessage(ctx, workspaceID, currentUser.ID, req.ReceiverID, req.Content)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if err.Error() == "message blocked by content moderation" {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
+	// Let the sender of an urgent message decide whether to notify a recipient in Do Not Disturb
+	if req.Urgent {
+		notice, err := server.doNotDisturbService.GetRecipientNotice(ctx, req.ReceiverID, workspaceID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+			return
+		}
+		message.RecipientDoNotDisturb = notice
+	}
+
 	ctx.JSON(http.StatusCreated, message)
 }
 
 // @Summary Get Channel Messages
-// @Description Retrieve messages from a specific channel (requires workspace membership)
+// @Description Retrieve messages from a specific channel (requires workspace membership). Deleted messages are returned as tombstones without content unless the workspace hides them. Once recent messages run out, history continues with archived messages, which are slower to fetch and marked archived. Messages that started a thread carry its reply count, last reply time and latest participants, and messages asking for acknowledgment carry whether the user acknowledged them.
+// @ID getChannelMessages
 // @Tags messages
 // @Security BearerAuth
 // @Produce json
@@ -111,16 +142,18 @@ func (server *Server) sendDirectMessage(ctx *gin.Context) {
 // @Param channel_id path int true "Channel ID"
 // @Param limit query int false "Number of messages to retrieve (default: 50, max: 100)" minimum(1) maximum(100)
 // @Param offset query int false "Number of messages to skip (default: 0)" minimum(0)
-// @Success 200 {object} map[string]interface{} "Channel messages"
+// @Param fields query string false "Comma-separated fields to return for each message, e.g. id,content,sender.first_name"
+// @Success 200 {object} object{messages=[]service.MessageResponse} "Channel messages"
 // @Failure 400 {object} map[string]string "Invalid request or IDs"
 // @Failure 401 {object} map[string]string "Authentication required"
-// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 403 {object} map[string]string "Workspace or private channel membership required"
+// @Failure 404 {object} map[string]string "Channel not found"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /workspace/{id}/channels/{channel_id}/messages [get]
 func (server *Server) getChannelMessages(ctx *gin.Context) {
 	var req service.GetMessagesRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -136,7 +169,7 @@ func (server *Server) getChannelMessages(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
 		return
 	}
 
@@ -144,7 +177,7 @@ func (server *Server) getChannelMessages(ctx *gin.Context) {
 	channelIDStr := ctx.Param("channel_id")
 	channelID, err := strconv.ParseInt(channelIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid channel ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
 		return
 	}
 
@@ -154,15 +187,28 @@ func (server *Server) getChannelMessages(ctx *gin.Context) {
 	// Get messages
 	messages, err := server.messageService.GetChannelMessages(ctx, workspaceID, channelID, currentUser.ID, req.Limit, req.Offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"messages": messages})
+	selected, ok := filterFields(ctx, messages)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"messages": selected})
 }
 
 // @Summary Get Direct Messages
-// @Description Retrieve direct messages with another user (requires workspace membership)
+// @Description Retrieve direct messages with another user (requires workspace membership). The response includes an out_of_office banner while the other user is out of office. Once recent messages run out, history continues with archived messages, which are slower to fetch and marked archived.
+// @ID getDirectMessages
 // @Tags messages
 // @Security BearerAuth
 // @Produce json
@@ -170,7 +216,8 @@ func (server *Server) getChannelMessages(ctx *gin.Context) {
 // @Param user_id path int true "Other User ID"
 // @Param limit query int false "Number of messages to retrieve (default: 50, max: 100)" minimum(1) maximum(100)
 // @Param offset query int false "Number of messages to skip (default: 0)" minimum(0)
-// @Success 200 {object} map[string]interface{} "Direct messages"
+// @Param fields query string false "Comma-separated fields to return for each message, e.g. id,content,sender.first_name"
+// @Success 200 {object} object{messages=[]service.MessageResponse,out_of_office=service.OutOfOfficeResponse} "Direct messages"
 // @Failure 400 {object} map[string]string "Invalid request or IDs"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Workspace membership required"
@@ -179,7 +226,7 @@ func (server *Server) getChannelMessages(ctx *gin.Context) {
 func (server *Server) getDirectMessages(ctx *gin.Context) {
 	var req service.GetMessagesRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -195,7 +242,7 @@ func (server *Server) getDirectMessages(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
 		return
 	}
 
@@ -203,7 +250,7 @@ func (server *Server) getDirectMessages(ctx *gin.Context) {
 	otherUserIDStr := ctx.Param("user_id")
 	otherUserID, err := strconv.ParseInt(otherUserIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid user ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid user ID")))
 		return
 	}
 
@@ -213,15 +260,193 @@ func (server *Server) getDirectMessages(ctx *gin.Context) {
 	// Get messages
 	messages, err := server.messageService.GetDirectMessages(ctx, workspaceID, currentUser.ID, otherUserID, req.Limit, req.Offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	// Show a banner while the other user is out of office
+	outOfOffice, err := server.outOfOfficeService.GetActiveOutOfOffice(ctx, otherUserID, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	selected, ok := filterFields(ctx, messages)
+	if !ok {
+		return
+	}
+
+	response := gin.H{"messages": selected}
+	if outOfOffice != nil {
+		response["out_of_office"] = outOfOffice
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// @Summary Get Archived Channel Messages
+// @Description Retrieve a page of a channel's messages that were moved to the message archive, newest first, optionally within a time range (requires workspace membership). Archives are fetched from archive storage, so this is slower than reading recent history. Channel history continues into the archive on its own once recent messages run out.
+// @ID getArchivedChannelMessages
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Param before query string false "Only messages sent before this time (RFC 3339)"
+// @Param after query string false "Only messages sent after this time (RFC 3339)"
+// @Param limit query int false "Number of messages to retrieve (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of messages to skip (default: 0)" minimum(0)
+// @Success 200 {object} object{messages=[]service.MessageResponse} "Archived channel messages"
+// @Failure 400 {object} map[string]string "Invalid request or IDs"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace or private channel membership required"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/channels/{channel_id}/messages/archived [get]
+func (server *Server) getArchivedChannelMessages(ctx *gin.Context) {
+	var req service.GetArchivedMessagesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	channelID, err := strconv.ParseInt(ctx.Param("channel_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	messages, err := server.messageService.GetArchivedChannelMessages(ctx, workspaceID, channelID, currentUser.ID, req)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"messages": messages})
 }
 
+// @Summary Get Archived Direct Messages
+// @Description Retrieve a page of the direct messages with another user that were moved to the message archive, newest first, optionally within a time range (requires workspace membership). Archives are fetched from archive storage, so this is slower than reading recent history.
+// @ID getArchivedDirectMessages
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param user_id path int true "Other User ID"
+// @Param before query string false "Only messages sent before this time (RFC 3339)"
+// @Param after query string false "Only messages sent after this time (RFC 3339)"
+// @Param limit query int false "Number of messages to retrieve (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of messages to skip (default: 0)" minimum(0)
+// @Success 200 {object} object{messages=[]service.MessageResponse} "Archived direct messages"
+// @Failure 400 {object} map[string]string "Invalid request or IDs"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/messages/direct/{user_id}/archived [get]
+func (server *Server) getArchivedDirectMessages(ctx *gin.Context) {
+	var req service.GetArchivedMessagesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	otherUserID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid user ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	messages, err := server.messageService.GetArchivedDirectMessages(ctx, workspaceID, currentUser.ID, otherUserID, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"messages": messages})
+}
+
+// @Summary Reply in Thread
+// @Description Reply in the thread of a message, in its channel or direct conversation (requires access to the message). A reply to a reply joins the same thread. The thread's reply count, last reply time and latest participants on its parent message are updated and sent as a thread_updated event.
+// @ID createThreadReply
+// @Tags messages
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Param reply body service.CreateThreadReplyRequest true "Reply content"
+// @Success 201 {object} service.MessageResponse "Reply sent successfully"
+// @Failure 400 {object} map[string]string "Invalid request or message ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 422 {object} map[string]string "Message blocked by content moderation"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/replies [post]
+func (server *Server) createThreadReply(ctx *gin.Context) {
+	var req service.CreateThreadReplyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	reply, err := server.messageService.CreateThreadReply(ctx, messageID, currentUser.ID, req)
+	if err != nil {
+		switch {
+		case err.Error() == "message not found":
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		case err.Error() == "message blocked by content moderation":
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, reply)
+}
+
 // @Summary Edit Message
-// @Description Edit a message (only message sender can edit)
+// @Description Edit a message (only message sender can edit, within the workspace's edit window unless they're a workspace admin)
+// @ID editMessage
 // @Tags messages
 // @Security BearerAuth
 // @Accept json
@@ -232,12 +457,13 @@ func (server *Server) getDirectMessages(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string "Invalid request or message ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Only message sender can edit"
+// @Failure 422 {object} map[string]string "Message blocked by content moderation"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /messages/{message_id} [put]
 func (server *Server) editMessage(ctx *gin.Context) {
 	var req service.EditMessageRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -245,7 +471,7 @@ func (server *Server) editMessage(ctx *gin.Context) {
 	messageIDStr := ctx.Param("message_id")
 	messageID, err := strconv.ParseInt(messageIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid message ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
 		return
 	}
 
@@ -255,7 +481,15 @@ func (server *Server) editMessage(ctx *gin.Context) {
 	// Edit message
 	message, err := server.messageService.EditMessage(ctx, messageID, currentUser.ID, req.Content)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if err.Error() == "message blocked by content moderation" {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(ctx, err))
+			return
+		}
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -263,7 +497,8 @@ func (server *Server) editMessage(ctx *gin.Context) {
 }
 
 // @Summary Delete Message
-// @Description Delete a message (only message sender can delete)
+// @Description Delete a message (only message sender can delete, within the workspace's delete window unless they're a workspace admin)
+// @ID deleteMessage
 // @Tags messages
 // @Security BearerAuth
 // @Produce json
@@ -279,7 +514,7 @@ func (server *Server) deleteMessage(ctx *gin.Context) {
 	messageIDStr := ctx.Param("message_id")
 	messageID, err := strconv.ParseInt(messageIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid message ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
 		return
 	}
 
@@ -289,19 +524,91 @@ func (server *Server) deleteMessage(ctx *gin.Context) {
 	// Delete message
 	err = server.messageService.DeleteMessage(ctx, messageID, currentUser.ID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Message deleted successfully"})
 }
 
+// @Summary Get Message Settings
+// @Description Get how long after sending members may edit and delete their messages, and whether deleted messages are hidden from history (requires workspace admin)
+// @ID getMessageSettings
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.MessageSettingsResponse "Message settings"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/settings/messages [get]
+func (server *Server) getMessageSettings(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	settings, err := server.messageService.GetMessageSettings(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update Message Settings
+// @Description Set how many minutes after sending members may edit and delete their messages, 0 allows it at any time, and whether deleted messages are hidden instead of shown as tombstones. Omitted windows use the server default. (requires workspace admin)
+// @ID updateMessageSettings
+// @Tags messages
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param settings body service.UpdateMessageSettingsRequest true "Edit and delete windows and tombstone visibility"
+// @Success 200 {object} service.MessageSettingsResponse "Message settings updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/settings/messages [put]
+func (server *Server) updateMessageSettings(ctx *gin.Context) {
+	var req service.UpdateMessageSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	settings, err := server.messageService.UpdateMessageSettings(ctx, workspaceID, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
 // @Summary Get Message
 // @Description Retrieve a specific message by ID
+// @ID getMessage
 // @Tags messages
 // @Security BearerAuth
 // @Produce json
 // @Param message_id path int true "Message ID"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,content,sender.first_name"
 // @Success 200 {object} service.MessageResponse "Message details"
 // @Failure 400 {object} map[string]string "Invalid message ID"
 // @Failure 401 {object} map[string]string "Authentication required"
@@ -313,7 +620,7 @@ func (server *Server) getMessage(ctx *gin.Context) {
 	messageIDStr := ctx.Param("message_id")
 	messageID, err := strconv.ParseInt(messageIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid message ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
 		return
 	}
 
@@ -323,11 +630,76 @@ func (server *Server) getMessage(ctx *gin.Context) {
 	// Get message
 	message, err := server.messageService.GetMessage(ctx, messageID, currentUser.ID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, message)
+	selected, ok := filterFields(ctx, message)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, selected)
+}
+
+// @Summary Get Message Context
+// @Description Retrieve a message with the surrounding messages of its channel, direct conversation or thread, so permalinks can be opened in place
+// @ID getMessageContext
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Param before query int false "Number of earlier messages to include (default: 25, max: 100)" minimum(0) maximum(100)
+// @Param after query int false "Number of later messages to include (default: 25, max: 100)" minimum(0) maximum(100)
+// @Success 200 {object} service.MessageContextResponse "Message with surrounding context"
+// @Failure 400 {object} map[string]string "Invalid message ID or parameters"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/context [get]
+func (server *Server) getMessageContext(ctx *gin.Context) {
+	var req service.MessageContextRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	// Get message ID from URL
+	messageIDStr := ctx.Param("message_id")
+	messageID, err := strconv.ParseInt(messageIDStr, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	// Set default values if not provided
+	before, after := int32(25), int32(25)
+	if req.Before != nil {
+		before = *req.Before
+	}
+	if req.After != nil {
+		after = *req.After
+	}
+
+	// Get current user
+	currentUser := getCurrentUser(ctx)
+
+	messageContext, err := server.messageService.GetMessageContext(ctx, messageID, currentUser.ID, before, after)
+	if err != nil {
+		if err.Error() == "message not found" {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, messageContext)
 }
 
 // Helper function to get current user from context
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"github.com/golang/mock/gomock"
 	mockdb "github.com/heyrmi/goslack/db/mock"
 	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
 	"github.com/heyrmi/goslack/token"
 	"github.com/heyrmi/goslack/util"
 	"github.com/stretchr/testify/require"
@@ -78,17 +80,133 @@ func TestSendChannelMessageAPI(t *testing.T) {
 				}
 
 				store.EXPECT().
-					CreateChannelMessage(gomock.Any(), gomock.Eq(arg)).
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
 					Times(1).
-					Return(message, nil)
+					Return([]db.ModerationWord{}, nil)
+
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), EqChannelMessageTx(arg)).
+					Times(1).
+					DoAndReturn(createMessageTx(message))
+
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "Mentions",
+			body: gin.H{
+				"content": fmt.Sprintf("<@%d> and <@%d>, take a look", user.ID+1, user.ID+2),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(user.Role, nil)
+
+				store.EXPECT().
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{}, nil)
 
 				store.EXPECT().
 					GetUser(gomock.Any(), gomock.Eq(user.ID)).
 					Times(1).
 					Return(user, nil)
+
+				// Only workspace members are mentioned
+				store.EXPECT().
+					ListWorkspaceMemberIDs(gomock.Any(), gomock.Eq(db.ListWorkspaceMemberIDsParams{
+						WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+						UserIds:     []int64{user.ID + 1, user.ID + 2},
+					})).
+					Times(1).
+					Return([]int64{user.ID + 2}, nil)
+
+				message := db.Message{
+					ID:          2,
+					WorkspaceID: workspace.ID,
+					ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+					SenderID:    user.ID,
+					Content:     fmt.Sprintf("<@%d> and <@%d>, take a look", user.ID+1, user.ID+2),
+					MessageType: "channel",
+					CreatedAt:   time.Now(),
+				}
+
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreateMessageTxParams) (db.CreateMessageTxResult, error) {
+						require.Equal(t, []int64{user.ID + 2}, arg.MentionedUserIDs)
+						return createMessageTx(message)(ctx, arg)
+					})
+
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response service.MessageResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, []int64{user.ID + 2}, response.Mentions)
+			},
+		},
+		{
+			name: "BlockedByModeration",
+			body: gin.H{
+				"content": "The launch code is secret",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(user.Role, nil)
+
+				store.EXPECT().
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{{WorkspaceID: workspace.ID, Word: "Secret", Action: "block"}}, nil)
+
+				store.EXPECT().
+					CreateModerationAuditEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ModerationAuditLog{}, nil)
+
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
 			},
 		},
 		{
@@ -147,6 +265,7 @@ func TestSendChannelMessageAPI(t *testing.T) {
 
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
+			expectDefaultWorkspaceSettings(store)
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
@@ -238,14 +357,211 @@ func TestSendDirectMessageAPI(t *testing.T) {
 				}
 
 				store.EXPECT().
-					CreateDirectMessage(gomock.Any(), gomock.Eq(arg)).
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{}, nil)
+
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), EqDirectMessageTx(arg)).
+					Times(1).
+					DoAndReturn(createMessageTx(message))
+
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				// Receiver is not out of office
+				store.EXPECT().
+					GetActiveOutOfOffice(gomock.Any(), gomock.Eq(db.GetActiveOutOfOfficeParams{
+						UserID:      receiver.ID,
+						WorkspaceID: workspace.ID,
+					})).
+					Times(1).
+					Return(db.OutOfOffice{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "OutOfOfficeAutoReply",
+			body: gin.H{
+				"receiver_id": receiver.ID,
+				"content":     "Hello there!",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(3).
+					Return("member", nil)
+
+				message := db.Message{
+					ID:          1,
+					WorkspaceID: workspace.ID,
+					SenderID:    user.ID,
+					ReceiverID:  sql.NullInt64{Int64: receiver.ID, Valid: true},
+					Content:     "Hello there!",
+					MessageType: "direct",
+					CreatedAt:   time.Now(),
+				}
+				store.EXPECT().
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{}, nil)
+
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), EqDirectMessageTx(db.CreateDirectMessageParams{
+						WorkspaceID: workspace.ID,
+						SenderID:    user.ID,
+						ReceiverID:  sql.NullInt64{Int64: receiver.ID, Valid: true},
+						Content:     "Hello there!",
+						ContentType: "text",
+					})).
+					Times(1).
+					DoAndReturn(createMessageTx(message))
+
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				outOfOffice := db.OutOfOffice{
+					UserID:      receiver.ID,
+					WorkspaceID: workspace.ID,
+					StartsAt:    time.Now().Add(-time.Hour),
+					EndsAt:      time.Now().Add(time.Hour),
+					Message:     "On vacation until Monday",
+				}
+				store.EXPECT().
+					GetActiveOutOfOffice(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(outOfOffice, nil)
+
+				store.EXPECT().
+					RecordOutOfOfficeReply(gomock.Any(), gomock.Eq(db.RecordOutOfOfficeReplyParams{
+						UserID:   receiver.ID,
+						SenderID: user.ID,
+					})).
+					Times(1).
+					Return(int64(1), nil)
+
+				reply := db.Message{
+					ID:          2,
+					WorkspaceID: workspace.ID,
+					SenderID:    receiver.ID,
+					ReceiverID:  sql.NullInt64{Int64: user.ID, Valid: true},
+					Content:     outOfOffice.Message,
+					MessageType: "direct",
+					CreatedAt:   time.Now(),
+				}
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), EqDirectMessageTx(db.CreateDirectMessageParams{
+						WorkspaceID: workspace.ID,
+						SenderID:    receiver.ID,
+						ReceiverID:  sql.NullInt64{Int64: user.ID, Valid: true},
+						Content:     outOfOffice.Message,
+						ContentType: "text",
+					})).
+					Times(1).
+					DoAndReturn(createMessageTx(reply))
+
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Eq(reply.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(receiver.ID)).
+					Times(1).
+					Return(receiver, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				// The sender's own message is returned, not the auto-reply
+				var response service.MessageResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, "Hello there!", response.Content)
+			},
+		},
+		{
+			name: "OutOfOfficeAlreadyReplied",
+			body: gin.H{
+				"receiver_id": receiver.ID,
+				"content":     "Hello again!",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(3).
+					Return("member", nil)
+
+				store.EXPECT().
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{}, nil)
+
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return(message, nil)
+					DoAndReturn(createMessageTx(db.Message{
+						ID:          3,
+						WorkspaceID: workspace.ID,
+						SenderID:    user.ID,
+						ReceiverID:  sql.NullInt64{Int64: receiver.ID, Valid: true},
+						Content:     "Hello again!",
+						MessageType: "direct",
+						CreatedAt:   time.Now(),
+					}))
+
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
 
 				store.EXPECT().
 					GetUser(gomock.Any(), gomock.Eq(user.ID)).
 					Times(1).
 					Return(user, nil)
+
+				store.EXPECT().
+					GetActiveOutOfOffice(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.OutOfOffice{UserID: receiver.ID, WorkspaceID: workspace.ID, Message: "Away"}, nil)
+
+				// An auto-reply was already sent today
+				store.EXPECT().
+					RecordOutOfOfficeReply(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusCreated, recorder.Code)
@@ -291,6 +607,7 @@ func TestSendDirectMessageAPI(t *testing.T) {
 
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
+			expectDefaultWorkspaceSettings(store)
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
@@ -314,6 +631,7 @@ func TestGetChannelMessagesAPI(t *testing.T) {
 	user, _ := randomUser(t)
 	workspace := randomWorkspace(user.OrganizationID)
 	channel := randomChannel(workspace.ID, user.ID)
+	lastReplyAt := time.Now().Add(-time.Minute)
 
 	// Make user a member of the workspace
 	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
@@ -367,14 +685,72 @@ func TestGetChannelMessagesAPI(t *testing.T) {
 					GetChannelMessages(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(messages, nil)
+
+				store.EXPECT().
+					ListReactionSummaries(gomock.Any(), gomock.Eq(db.ListReactionSummariesParams{
+						ViewerID:     user.ID,
+						ReactorLimit: 5,
+						MessageIds:   []int64{1},
+					})).
+					Times(1).
+					Return([]db.ListReactionSummariesRow{
+						{MessageID: 1, Emoji: ":+1:", Count: 3, Reacted: true, UserIds: []int64{user.ID, 2, 3}},
+					}, nil)
+
+				store.EXPECT().
+					ListMessageThreads(gomock.Any(), gomock.Eq([]int64{1})).
+					Times(1).
+					Return([]db.MessageThread{
+						{ThreadID: 1, ReplyCount: 4, LastReplyAt: sql.NullTime{Time: lastReplyAt, Valid: true}, ParticipantIds: []int64{2, user.ID}},
+					}, nil)
+				store.EXPECT().
+					ListThreadParticipants(gomock.Any(), gomock.Eq([]int64{2, user.ID})).
+					Times(1).
+					Return([]db.ListThreadParticipantsRow{
+						{ID: user.ID, FirstName: user.FirstName, LastName: user.LastName},
+						{ID: 2, FirstName: "Ada", LastName: "Lovelace", AvatarKey: sql.NullString{String: "ada", Valid: true}},
+					}, nil)
+
+				store.EXPECT().
+					ListMessageAcknowledgmentStates(gomock.Any(), gomock.Eq(db.ListMessageAcknowledgmentStatesParams{
+						UserID:     user.ID,
+						MessageIds: []int64{1},
+					})).
+					Times(1).
+					Return([]db.ListMessageAcknowledgmentStatesRow{
+						{MessageID: 1, RemindAt: lastReplyAt, AcknowledgedCount: 2},
+					}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Messages []service.MessageResponse `json:"messages"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Messages, 1)
+				require.Equal(t, []service.ReactionSummary{
+					{Emoji: ":+1:", Count: 3, UserIDs: []int64{user.ID, 2, 3}, Reacted: true},
+				}, response.Messages[0].Reactions)
+
+				// The thread's summary, latest participant first
+				message := response.Messages[0]
+				require.Equal(t, int32(4), message.ReplyCount)
+				require.WithinDuration(t, lastReplyAt, *message.LastReplyAt, time.Second)
+				require.Len(t, message.ReplyParticipants, 2)
+				require.Equal(t, "AL", message.ReplyParticipants[0].Initials)
+				require.Equal(t, "/avatars/ada/32", message.ReplyParticipants[0].AvatarURLs["32"])
+				require.Equal(t, user.ID, message.ReplyParticipants[1].ID)
+
+				// The user hasn't acknowledged the message yet
+				require.NotNil(t, message.Acknowledgment)
+				require.False(t, message.Acknowledgment.Acknowledged)
+				require.Equal(t, int64(2), message.Acknowledgment.AcknowledgedCount)
 			},
 		},
 		{
-			name:  "InvalidLimit",
-			query: "?limit=-1&offset=0", // Invalid limit
+			name:  "Tombstones",
+			query: "?limit=10&offset=0",
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
 			},
@@ -384,40 +760,597 @@ func TestGetChannelMessagesAPI(t *testing.T) {
 					Times(1).
 					Return(user, nil)
 
-				// Check workspace membership for middleware
-				roleArg := db.CheckUserWorkspaceRoleParams{
-					ID:          user.ID,
-					WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
-				}
 				store.EXPECT().
-					CheckUserWorkspaceRole(gomock.Any(), gomock.Eq(roleArg)).
-					Times(1). // Only middleware check since validation fails
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(2).
 					Return(user.Role, nil)
-			},
-			checkResponse: func(recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusBadRequest, recorder.Code)
-			},
-		},
-	}
-
-	for i := range testCases {
-		tc := testCases[i]
-
-		t.Run(tc.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
 
-			store := mockdb.NewMockStore(ctrl)
-			tc.buildStubs(store)
+				messages := []db.GetChannelMessagesRow{
+					{
+						ID:              1,
+						WorkspaceID:     workspace.ID,
+						ChannelID:       sql.NullInt64{Int64: channel.ID, Valid: true},
+						SenderID:        user.ID,
+						Content:         "Deleted content",
+						MessageType:     "channel",
+						DeletedAt:       sql.NullTime{Time: time.Now(), Valid: true},
+						DeletedBy:       sql.NullInt64{Int64: user.ID, Valid: true},
+						CreatedAt:       time.Now(),
+						SenderFirstName: user.FirstName,
+						SenderLastName:  user.LastName,
+						SenderEmail:     user.Email,
+					},
+				}
 
-			server := newTestServer(t, store)
-			recorder := httptest.NewRecorder()
+				store.EXPECT().
+					GetChannelMessages(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.GetChannelMessagesParams) ([]db.GetChannelMessagesRow, error) {
+						require.True(t, arg.IncludeDeleted)
+						return messages, nil
+					})
 
-			url := fmt.Sprintf("/workspace/%d/channels/%d/messages%s", workspace.ID, channel.ID, tc.query)
-			request, err := http.NewRequest(http.MethodGet, url, nil)
-			require.NoError(t, err)
+				// Tombstones have no reactions to load
+				store.EXPECT().
+					ListReactionSummaries(gomock.Any(), gomock.Any()).
+					Times(0)
 
-			tc.setupAuth(t, request, server.tokenMaker)
+				// Deleted messages keep their threads
+				store.EXPECT().
+					ListMessageThreads(gomock.Any(), gomock.Eq([]int64{1})).
+					Times(1).
+					Return([]db.MessageThread{}, nil)
+				store.EXPECT().
+					ListMessageAcknowledgmentStates(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListMessageAcknowledgmentStatesRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Messages []service.MessageResponse `json:"messages"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Messages, 1)
+				require.Empty(t, response.Messages[0].Content)
+				require.NotNil(t, response.Messages[0].DeletedAt)
+				require.Equal(t, user.ID, *response.Messages[0].DeletedBy)
+			},
+		},
+		{
+			name:  "SelectedFields",
+			query: "?fields=id,content,sender.first_name",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(user.Role, nil)
+
+				messages := []db.GetChannelMessagesRow{
+					{
+						ID:              1,
+						WorkspaceID:     workspace.ID,
+						ChannelID:       sql.NullInt64{Int64: channel.ID, Valid: true},
+						SenderID:        user.ID,
+						Content:         "Hello, world!",
+						MessageType:     "channel",
+						CreatedAt:       time.Now(),
+						SenderFirstName: user.FirstName,
+						SenderLastName:  user.LastName,
+						SenderEmail:     user.Email,
+					},
+				}
+
+				store.EXPECT().
+					GetChannelMessages(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(messages, nil)
+
+				store.EXPECT().
+					ListReactionSummaries(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListReactionSummariesRow{}, nil)
+
+				store.EXPECT().
+					ListMessageThreads(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.MessageThread{}, nil)
+
+				store.EXPECT().
+					ListMessageAcknowledgmentStates(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListMessageAcknowledgmentStatesRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Messages []map[string]interface{} `json:"messages"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Messages, 1)
+				require.Equal(t, map[string]interface{}{
+					"id":      float64(1),
+					"content": "Hello, world!",
+					"sender":  map[string]interface{}{"first_name": user.FirstName},
+				}, response.Messages[0])
+			},
+		},
+		{
+			name:  "InvalidFields",
+			query: "?fields=id,,content",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(user.Role, nil)
+
+				store.EXPECT().
+					GetChannelMessages(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.GetChannelMessagesRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "PrivateChannelNotMember",
+			query: "?limit=10&offset=0",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(user.Role, nil)
+
+				private := channel
+				private.IsPrivate = true
+				store.EXPECT().
+					GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).
+					Times(1).
+					Return(private, nil)
+
+				store.EXPECT().
+					IsChannelMember(gomock.Any(), gomock.Eq(db.IsChannelMemberParams{ChannelID: channel.ID, UserID: user.ID})).
+					Times(1).
+					Return(false, nil)
+
+				store.EXPECT().
+					GetChannelMessages(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidLimit",
+			query: "?limit=-1&offset=0", // Invalid limit
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				// Check workspace membership for middleware
+				roleArg := db.CheckUserWorkspaceRoleParams{
+					ID:          user.ID,
+					WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+				}
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Eq(roleArg)).
+					Times(1). // Only middleware check since validation fails
+					Return(user.Role, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectDefaultWorkspaceSettings(store)
+			expectNoMessageArchives(store)
+			store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).AnyTimes().Return(channel, nil)
+			store.EXPECT().IsChannelMember(gomock.Any(), gomock.Any()).AnyTimes().Return(true, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspace/%d/channels/%d/messages%s", workspace.ID, channel.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreateThreadReplyAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	channel := randomChannel(workspace.ID, user.ID)
+	channel.IsPrivate = false
+
+	// Make user a member of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	parent := db.GetMessageByIDRow{
+		ID:          10,
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    otherUser.ID,
+		Content:     "Who's around?",
+		MessageType: "channel",
+		CreatedAt:   time.Now().Add(-time.Hour),
+	}
+
+	testCases := []struct {
+		name          string
+		messageID     int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			messageID: parent.ID,
+			body:      gin.H{"content": "I am"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(parent.ID)).
+					Times(1).
+					Return(parent, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("member", nil)
+
+				store.EXPECT().
+					GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).
+					Times(1).
+					Return(channel, nil)
+
+				store.EXPECT().
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{}, nil)
+
+				arg := db.CreateThreadReplyParams{
+					WorkspaceID: workspace.ID,
+					ChannelID:   parent.ChannelID,
+					SenderID:    user.ID,
+					Content:     "I am",
+					ContentType: "text",
+					MessageType: "channel",
+					ThreadID:    sql.NullInt64{Int64: parent.ID, Valid: true},
+				}
+				reply := db.Message{
+					ID:          11,
+					WorkspaceID: workspace.ID,
+					ChannelID:   parent.ChannelID,
+					SenderID:    user.ID,
+					Content:     "I am",
+					MessageType: "channel",
+					ThreadID:    arg.ThreadID,
+					CreatedAt:   time.Now(),
+				}
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), EqThreadReplyTx(arg)).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreateMessageTxParams) (db.CreateMessageTxResult, error) {
+						result, err := createMessageTx(reply)(ctx, arg)
+						result.Thread = db.MessageThread{
+							ThreadID:       parent.ID,
+							ReplyCount:     1,
+							LastReplyAt:    sql.NullTime{Time: reply.CreatedAt, Valid: true},
+							ParticipantIds: []int64{user.ID},
+						}
+						return result, err
+					})
+
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Eq(reply.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				// The parent's new summary goes out to the channel
+				store.EXPECT().
+					ListThreadParticipants(gomock.Any(), gomock.Eq([]int64{user.ID})).
+					Times(1).
+					Return([]db.ListThreadParticipantsRow{{ID: user.ID, FirstName: user.FirstName, LastName: user.LastName}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response service.MessageResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, int64(11), response.ID)
+				require.NotNil(t, response.ThreadID)
+				require.Equal(t, parent.ID, *response.ThreadID)
+			},
+		},
+		{
+			name:      "ReplyToReply",
+			messageID: 12,
+			body:      gin.H{"content": "Me too"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				// A reply to a reply joins the thread of its parent
+				replied := parent
+				replied.ID = 12
+				replied.ThreadID = sql.NullInt64{Int64: parent.ID, Valid: true}
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(replied.ID)).
+					Times(1).
+					Return(replied, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("member", nil)
+
+				store.EXPECT().
+					GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).
+					Times(1).
+					Return(channel, nil)
+
+				store.EXPECT().
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{}, nil)
+
+				arg := db.CreateThreadReplyParams{
+					WorkspaceID: workspace.ID,
+					ChannelID:   parent.ChannelID,
+					SenderID:    user.ID,
+					Content:     "Me too",
+					ContentType: "text",
+					MessageType: "channel",
+					ThreadID:    sql.NullInt64{Int64: parent.ID, Valid: true},
+				}
+				reply := db.Message{
+					ID:          13,
+					WorkspaceID: workspace.ID,
+					ChannelID:   parent.ChannelID,
+					SenderID:    user.ID,
+					Content:     "Me too",
+					MessageType: "channel",
+					ThreadID:    arg.ThreadID,
+					CreatedAt:   time.Now(),
+				}
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), EqThreadReplyTx(arg)).
+					Times(1).
+					DoAndReturn(createMessageTx(reply))
+
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Eq(reply.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:      "DirectMessage",
+			messageID: 20,
+			body:      gin.H{"content": "Sure"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				direct := db.GetMessageByIDRow{
+					ID:          20,
+					WorkspaceID: workspace.ID,
+					SenderID:    otherUser.ID,
+					ReceiverID:  sql.NullInt64{Int64: user.ID, Valid: true},
+					Content:     "Lunch?",
+					MessageType: "direct",
+					CreatedAt:   time.Now().Add(-time.Hour),
+				}
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(direct.ID)).
+					Times(1).
+					Return(direct, nil)
+
+				store.EXPECT().
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{}, nil)
+
+				// The reply goes back to the person who sent the message
+				arg := db.CreateThreadReplyParams{
+					WorkspaceID: workspace.ID,
+					SenderID:    user.ID,
+					ReceiverID:  sql.NullInt64{Int64: otherUser.ID, Valid: true},
+					Content:     "Sure",
+					ContentType: "text",
+					MessageType: "direct",
+					ThreadID:    sql.NullInt64{Int64: direct.ID, Valid: true},
+				}
+				reply := db.Message{
+					ID:          21,
+					WorkspaceID: workspace.ID,
+					SenderID:    user.ID,
+					ReceiverID:  arg.ReceiverID,
+					Content:     "Sure",
+					MessageType: "direct",
+					ThreadID:    arg.ThreadID,
+					CreatedAt:   time.Now(),
+				}
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), EqThreadReplyTx(arg)).
+					Times(1).
+					DoAndReturn(createMessageTx(reply))
+
+				store.EXPECT().
+					MarkOutboxEventPublished(gomock.Any(), gomock.Eq(reply.ID)).
+					Times(1).
+					Return(nil)
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name:      "NotParticipant",
+			messageID: 30,
+			body:      gin.H{"content": "Hi"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(int64(30))).
+					Times(1).
+					Return(db.GetMessageByIDRow{
+						ID:          30,
+						WorkspaceID: workspace.ID,
+						SenderID:    otherUser.ID,
+						ReceiverID:  sql.NullInt64{Int64: util.RandomInt(2000, 3000), Valid: true},
+						MessageType: "direct",
+					}, nil)
+
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			messageID: 40,
+			body:      gin.H{"content": "Hi"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(int64(40))).
+					Times(1).
+					Return(db.GetMessageByIDRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "NoContent",
+			messageID: parent.ID,
+			body:      gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectDefaultWorkspaceSettings(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/messages/%d/replies", tc.messageID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
 		})
@@ -467,6 +1400,16 @@ func TestEditMessageAPI(t *testing.T) {
 				editedAt := time.Now()
 				updatedMessage.EditedAt = sql.NullTime{Time: editedAt, Valid: true}
 
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(db.GetMessageByIDRow{ID: message.ID, WorkspaceID: workspace.ID, SenderID: user.ID}, nil)
+
+				store.EXPECT().
+					ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return([]db.ModerationWord{}, nil)
+
 				store.EXPECT().
 					UpdateMessageContent(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -505,6 +1448,54 @@ func TestEditMessageAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:      "EditWindowPassed",
+			messageID: message.ID,
+			body: gin.H{
+				"content": "Updated content",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckMessageAuthor(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(user.ID, nil)
+
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(db.GetMessageByIDRow{ID: message.ID, WorkspaceID: workspace.ID, SenderID: user.ID, CreatedAt: time.Now().Add(-time.Hour)}, nil)
+
+				store.EXPECT().
+					GetWorkspaceSettings(gomock.Any(), gomock.Eq(workspace.ID)).
+					Times(1).
+					Return(db.WorkspaceSetting{WorkspaceID: workspace.ID, MessageEditWindowMinutes: sql.NullInt32{Int32: 15, Valid: true}}, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("member", nil)
+
+				store.EXPECT().
+					GetUserRolePermissions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+
+				store.EXPECT().
+					UpdateMessageContent(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -516,6 +1507,7 @@ func TestEditMessageAPI(t *testing.T) {
 
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
+			expectDefaultWorkspaceSettings(store)
 
 			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
@@ -535,6 +1527,189 @@ func TestEditMessageAPI(t *testing.T) {
 	}
 }
 
+func TestGetMessageContextAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	channel := randomChannel(workspace.ID, user.ID)
+	channel.IsPrivate = false
+	message := randomMessage(workspace.ID, channel.ID, user.ID)
+	directMessage := randomDirectMessage(workspace.ID, otherUser.ID, util.RandomInt(2000, 3000))
+
+	// Make user a member of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	toRow := func(message db.Message) db.GetMessageByIDRow {
+		return db.GetMessageByIDRow{
+			ID:          message.ID,
+			WorkspaceID: message.WorkspaceID,
+			ChannelID:   message.ChannelID,
+			SenderID:    message.SenderID,
+			ReceiverID:  message.ReceiverID,
+			Content:     message.Content,
+			MessageType: message.MessageType,
+			CreatedAt:   message.CreatedAt,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		messageID     int64
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			messageID: message.ID,
+			query:     "?before=2&after=1",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(toRow(message), nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("member", nil)
+
+				store.EXPECT().
+					GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).
+					Times(1).
+					Return(channel, nil)
+
+				store.EXPECT().
+					GetMessagesBefore(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.GetMessagesBeforeParams) ([]db.GetMessagesBeforeRow, error) {
+						require.Equal(t, channel.ID, arg.ChannelID.Int64)
+						require.False(t, arg.ThreadID.Valid)
+						require.Equal(t, int32(2), arg.Limit)
+						return []db.GetMessagesBeforeRow{{ID: message.ID - 1}, {ID: message.ID - 2}}, nil
+					})
+
+				store.EXPECT().
+					GetMessagesAfter(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.GetMessagesAfterRow{}, nil)
+
+				// Reactions for the whole page are loaded at once
+				store.EXPECT().
+					ListReactionSummaries(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListReactionSummariesParams) ([]db.ListReactionSummariesRow, error) {
+						require.ElementsMatch(t, []int64{message.ID, message.ID - 1, message.ID - 2}, arg.MessageIds)
+						return []db.ListReactionSummariesRow{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.MessageContextResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, message.ID, response.Message.ID)
+				require.Equal(t, fmt.Sprintf("/workspace/%d/messages/%d", workspace.ID, message.ID), response.Permalink)
+				require.Len(t, response.Before, 2)
+				// Earlier messages are returned oldest first
+				require.Equal(t, message.ID-2, response.Before[0].ID)
+				require.True(t, response.HasMoreBefore)
+				require.Empty(t, response.After)
+				require.False(t, response.HasMoreAfter)
+			},
+		},
+		{
+			name:      "NotFound",
+			messageID: message.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).
+					Times(1).
+					Return(db.GetMessageByIDRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "DirectMessageOfOthers",
+			messageID: directMessage.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Eq(directMessage.ID)).
+					Times(1).
+					Return(toRow(directMessage), nil)
+
+				store.EXPECT().
+					GetMessagesBefore(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidLimit",
+			messageID: message.ID,
+			query:     "?before=500",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetMessageByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+			expectDefaultWorkspaceSettings(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/messages/%d/context%s", tc.messageID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 // Helper functions for testing
 func randomMessage(workspaceID, channelID, senderID int64) db.Message {
 	return db.Message{
@@ -559,3 +1734,117 @@ func randomDirectMessage(workspaceID, senderID, receiverID int64) db.Message {
 		CreatedAt:   time.Now(),
 	}
 }
+
+type eqChannelMessageTxMatcher struct {
+	arg db.CreateChannelMessageParams
+}
+
+func (e eqChannelMessageTxMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateMessageTxParams)
+	if !ok || arg.ChannelMessage == nil {
+		return false
+	}
+	return gomock.Eq(e.arg).Matches(*arg.ChannelMessage)
+}
+
+func (e eqChannelMessageTxMatcher) String() string {
+	return fmt.Sprintf("creates channel message %v", e.arg)
+}
+
+func EqChannelMessageTx(arg db.CreateChannelMessageParams) gomock.Matcher {
+	return eqChannelMessageTxMatcher{arg}
+}
+
+type eqDirectMessageTxMatcher struct {
+	arg db.CreateDirectMessageParams
+}
+
+func (e eqDirectMessageTxMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateMessageTxParams)
+	if !ok || arg.DirectMessage == nil {
+		return false
+	}
+	return gomock.Eq(e.arg).Matches(*arg.DirectMessage)
+}
+
+func (e eqDirectMessageTxMatcher) String() string {
+	return fmt.Sprintf("creates direct message %v", e.arg)
+}
+
+func EqDirectMessageTx(arg db.CreateDirectMessageParams) gomock.Matcher {
+	return eqDirectMessageTxMatcher{arg}
+}
+
+type eqThreadReplyTxMatcher struct {
+	arg db.CreateThreadReplyParams
+}
+
+func (e eqThreadReplyTxMatcher) Matches(x interface{}) bool {
+	arg, ok := x.(db.CreateMessageTxParams)
+	if !ok || arg.ThreadReply == nil {
+		return false
+	}
+	return gomock.Eq(e.arg).Matches(*arg.ThreadReply)
+}
+
+func (e eqThreadReplyTxMatcher) String() string {
+	return fmt.Sprintf("creates thread reply %v", e.arg)
+}
+
+func EqThreadReplyTx(arg db.CreateThreadReplyParams) gomock.Matcher {
+	return eqThreadReplyTxMatcher{arg}
+}
+
+// createMessageTx stands in for the store's transaction, building the
+// message's outbox event the way it does
+func createMessageTx(message db.Message) func(context.Context, db.CreateMessageTxParams) (db.CreateMessageTxResult, error) {
+	return func(_ context.Context, arg db.CreateMessageTxParams) (db.CreateMessageTxResult, error) {
+		result := db.CreateMessageTxResult{
+			Message:          message,
+			MentionedUserIDs: arg.MentionedUserIDs,
+		}
+
+		event, err := arg.AfterCreate(result)
+		if err != nil {
+			return db.CreateMessageTxResult{}, err
+		}
+
+		result.Event = db.EventOutbox{
+			ID:           message.ID,
+			EventType:    event.EventType,
+			WorkspaceID:  event.WorkspaceID,
+			ChannelID:    event.ChannelID,
+			UserID:       event.UserID,
+			RecipientIds: event.RecipientIds,
+			Payload:      event.Payload,
+			CreatedAt:    message.CreatedAt,
+		}
+		return result, nil
+	}
+}
+
+// expectDefaultWorkspaceSettings lets the workspace settings be read any
+// number of times, finding none so the server's default message windows apply
+func expectDefaultWorkspaceSettings(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetWorkspaceSettings(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+}
+
+// expectNoMessageArchives lets history look for archived messages any number
+// of times, finding none
+func expectNoMessageArchives(store *mockdb.MockStore) {
+	store.EXPECT().
+		CountChannelMessages(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(int64(0), nil)
+	store.EXPECT().
+		CountDirectMessagesBetweenUsers(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return(int64(0), nil)
+	store.EXPECT().
+		ListMessageArchives(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return([]db.MessageArchive{}, nil)
+}
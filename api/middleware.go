@@ -26,28 +26,33 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 
 		if len(authorizationHeader) == 0 {
 			err := errors.New("authorization header is not provided")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, err))
 			return
 		}
 
 		fields := strings.Fields(authorizationHeader)
 		if len(fields) < 2 {
 			err := errors.New("invalid authorization header format")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, err))
 			return
 		}
 
 		authorizationType := strings.ToLower(fields[0])
 		if authorizationType != authorizationTypeBearer {
 			err := fmt.Errorf("unsupported authorization type %s", authorizationType)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, err))
 			return
 		}
 
 		accessToken := fields[1]
 		payload, err := tokenMaker.VerifyToken(accessToken)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, err))
+			return
+		}
+
+		if err := checkTokenScope(ctx, payload); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
 			return
 		}
 
@@ -64,28 +69,33 @@ func authWithUserMiddleware(tokenMaker token.Maker, userService *service.UserSer
 
 		if len(authorizationHeader) == 0 {
 			err := errors.New("authorization header is not provided")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, err))
 			return
 		}
 
 		fields := strings.Fields(authorizationHeader)
 		if len(fields) < 2 {
 			err := errors.New("invalid authorization header format")
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, err))
 			return
 		}
 
 		authorizationType := strings.ToLower(fields[0])
 		if authorizationType != authorizationTypeBearer {
 			err := fmt.Errorf("unsupported authorization type %s", authorizationType)
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, err))
 			return
 		}
 
 		accessToken := fields[1]
 		payload, err := tokenMaker.VerifyToken(accessToken)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, err))
+			return
+		}
+
+		if err := checkTokenScope(ctx, payload); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
 			return
 		}
 
@@ -94,7 +104,13 @@ func authWithUserMiddleware(tokenMaker token.Maker, userService *service.UserSer
 		// Get the payload and load the user
 		user, err := userService.GetUserByEmail(ctx, payload.Username)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, err))
+			return
+		}
+
+		// A token for a deleted user must not work for a new user who signs up with the same email
+		if payload.SubjectID != 0 && payload.SubjectID != user.ID {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(ctx, token.ErrInvalidToken))
 			return
 		}
 
@@ -103,6 +119,51 @@ func authWithUserMiddleware(tokenMaker token.Maker, userService *service.UserSer
 	})
 }
 
+// scopeAreas maps route path segments to the scope area that covers them
+var scopeAreas = map[string]string{
+	"workspaces":           "workspaces",
+	"workspace":            "workspaces",
+	"organizations":        "organizations",
+	"channels":             "channels",
+	"messages":             "messages",
+	"drafts":               "messages",
+	"mentions":             "messages",
+	"external-dm-requests": "messages",
+	"files":                "files",
+	"users":                "users",
+	"avatars":              "users",
+}
+
+// requiredScope returns the scope a request needs, e.g. "channels:read" for
+// GET /workspaces/:id/channels. The last segment of the route that names an
+// area decides it, so nested routes need the scope of what they act on.
+func requiredScope(ctx *gin.Context) string {
+	area := "workspaces"
+	segments := strings.Split(strings.Trim(ctx.FullPath(), "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if a, ok := scopeAreas[segments[i]]; ok {
+			area = a
+			break
+		}
+	}
+
+	switch ctx.Request.Method {
+	case http.MethodGet, http.MethodHead:
+		return area + ":read"
+	default:
+		return area + ":write"
+	}
+}
+
+// checkTokenScope ensures the token grants the scope the request needs
+func checkTokenScope(ctx *gin.Context, payload *token.Payload) error {
+	scope := requiredScope(ctx)
+	if !payload.HasScope(scope) {
+		return fmt.Errorf("access denied: token lacks scope %s", scope)
+	}
+	return nil
+}
+
 // requireWorkspaceMember middleware ensures the user is a member of the specified workspace
 func requireWorkspaceMember(userService *service.UserService) gin.HandlerFunc {
 	return gin.HandlerFunc(func(ctx *gin.Context) {
@@ -111,7 +172,7 @@ func requireWorkspaceMember(userService *service.UserService) gin.HandlerFunc {
 		workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 		if err != nil {
 			err := errors.New("invalid workspace ID")
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(ctx, err))
 			return
 		}
 
@@ -119,7 +180,7 @@ func requireWorkspaceMember(userService *service.UserService) gin.HandlerFunc {
 		currentUser, exists := ctx.Get(currentUserKey)
 		if !exists {
 			err := errors.New("user not found in context")
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
 			return
 		}
 		user := currentUser.(service.UserResponse)
@@ -127,13 +188,13 @@ func requireWorkspaceMember(userService *service.UserService) gin.HandlerFunc {
 		// Check if user is a member of the workspace
 		isMember, err := userService.IsWorkspaceMember(ctx, user.ID, workspaceID)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
 			return
 		}
 
 		if !isMember {
 			err := errors.New("access denied: user is not a member of this workspace")
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
 			return
 		}
 
@@ -149,7 +210,7 @@ func requireWorkspaceAdmin(userService *service.UserService) gin.HandlerFunc {
 		workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 		if err != nil {
 			err := errors.New("invalid workspace ID")
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(ctx, err))
 			return
 		}
 
@@ -157,7 +218,7 @@ func requireWorkspaceAdmin(userService *service.UserService) gin.HandlerFunc {
 		currentUser, exists := ctx.Get(currentUserKey)
 		if !exists {
 			err := errors.New("user not found in context")
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
 			return
 		}
 		user := currentUser.(service.UserResponse)
@@ -165,13 +226,91 @@ func requireWorkspaceAdmin(userService *service.UserService) gin.HandlerFunc {
 		// Check if user is an admin of the workspace
 		isAdmin, err := userService.IsWorkspaceAdmin(ctx, user.ID, workspaceID)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
 			return
 		}
 
 		if !isAdmin {
 			err := errors.New("access denied: user is not an admin of this workspace")
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+
+		ctx.Next()
+	})
+}
+
+// requireWorkspacePermission middleware ensures the user holds a permission in
+// the workspace, either as an admin or through their custom role
+func requireWorkspacePermission(userService *service.UserService, permission string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			err := errors.New("invalid workspace ID")
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+
+		currentUser, exists := ctx.Get(currentUserKey)
+		if !exists {
+			err := errors.New("user not found in context")
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
+			return
+		}
+		user := currentUser.(service.UserResponse)
+
+		allowed, err := userService.HasWorkspacePermission(ctx, user.ID, workspaceID, permission)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
+			return
+		}
+
+		if !allowed {
+			err := fmt.Errorf("access denied: %s permission required", permission)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+
+		ctx.Next()
+	})
+}
+
+// requireOrganizationAdmin middleware ensures the user is an owner or admin of the specified organization
+func requireOrganizationAdmin(organizationRoleService *service.OrganizationRoleService) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		// Get organization ID from URL parameter
+		organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			err := errors.New("invalid organization ID")
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+
+		// Get current user
+		currentUser, exists := ctx.Get(currentUserKey)
+		if !exists {
+			err := errors.New("user not found in context")
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
+			return
+		}
+		user := currentUser.(service.UserResponse)
+
+		if user.OrganizationID != organizationID {
+			err := errors.New("access denied: user is not an admin of this organization")
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+
+		// Check if user is an owner or admin of the organization
+		isAdmin, err := organizationRoleService.IsOrganizationAdmin(ctx, organizationID, user.ID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
+			return
+		}
+
+		if !isAdmin {
+			err := errors.New("access denied: user is not an admin of this organization")
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
 			return
 		}
 
@@ -179,7 +318,33 @@ func requireWorkspaceAdmin(userService *service.UserService) gin.HandlerFunc {
 	})
 }
 
-// requireSameWorkspaceForUserRole middleware ensures admin can only modify users in the same workspace
+// requireFeature middleware rejects requests to features that are turned off for the workspace
+func requireFeature(featureFlagService *service.FeatureFlagService, flag string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(ctx *gin.Context) {
+		workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			err := errors.New("invalid workspace ID")
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+
+		enabled, err := featureFlagService.IsEnabled(ctx, workspaceID, flag)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
+			return
+		}
+
+		if !enabled {
+			err := errors.New("access denied: feature is not enabled for this workspace")
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+
+		ctx.Next()
+	})
+}
+
+// requireSameWorkspaceForUserRole middleware ensures only members allowed to manage members can modify users in the same workspace
 func requireSameWorkspaceForUserRole(userService *service.UserService) gin.HandlerFunc {
 	return gin.HandlerFunc(func(ctx *gin.Context) {
 		// Get target user ID from URL parameter
@@ -187,7 +352,7 @@ func requireSameWorkspaceForUserRole(userService *service.UserService) gin.Handl
 		targetUserID, err := strconv.ParseInt(targetUserIDStr, 10, 64)
 		if err != nil {
 			err := errors.New("invalid user ID")
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(ctx, err))
 			return
 		}
 
@@ -195,7 +360,7 @@ func requireSameWorkspaceForUserRole(userService *service.UserService) gin.Handl
 		currentUser, exists := ctx.Get(currentUserKey)
 		if !exists {
 			err := errors.New("user not found in context")
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
 			return
 		}
 		user := currentUser.(service.UserResponse)
@@ -203,33 +368,33 @@ func requireSameWorkspaceForUserRole(userService *service.UserService) gin.Handl
 		// Get target user to check their workspace
 		targetUser, err := userService.GetUser(ctx, targetUserID)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(ctx, err))
 			return
 		}
 
 		// Check if both users are in the same workspace
 		if user.WorkspaceID == nil || targetUser.WorkspaceID == nil {
 			err := errors.New("users must be in a workspace")
-			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(ctx, err))
 			return
 		}
 
 		if *user.WorkspaceID != *targetUser.WorkspaceID {
 			err := errors.New("access denied: users are not in the same workspace")
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
 			return
 		}
 
-		// Check if current user is admin of the workspace
-		isAdmin, err := userService.IsWorkspaceAdmin(ctx, user.ID, *user.WorkspaceID)
+		// Check if current user can manage members of the workspace
+		allowed, err := userService.HasWorkspacePermission(ctx, user.ID, *user.WorkspaceID, service.PermissionManageMembers)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(ctx, err))
 			return
 		}
 
-		if !isAdmin {
-			err := errors.New("access denied: user is not an admin")
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+		if !allowed {
+			err := fmt.Errorf("access denied: %s permission required", service.PermissionManageMembers)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(ctx, err))
 			return
 		}
 
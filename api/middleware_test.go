@@ -0,0 +1,130 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenScopeMiddleware(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "admin"
+
+	// DELETE /workspaces/:id/banners/:banner_id needs workspaces:write
+	testCases := []struct {
+		name          string
+		claims        token.Claims
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "FullAccess",
+			claims: token.Claims{SubjectID: user.ID, Scopes: []string{token.ScopeAll}},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectBannerDelete(store, user, workspace)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "AreaWildcard",
+			claims: token.Claims{Type: token.TokenTypePersonal, Scopes: []string{"workspaces:*"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectBannerDelete(store, user, workspace)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ReadOnly",
+			claims: token.Claims{Type: token.TokenTypeBot, Scopes: []string{"workspaces:read"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "OtherArea",
+			claims: token.Claims{Type: token.TokenTypeBot, Scopes: []string{"messages:write"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "SubjectMismatch",
+			claims: token.Claims{SubjectID: user.ID + 1, Scopes: []string{token.ScopeAll}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/banners/5", workspace.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			claims := tc.claims
+			claims.Username = user.Email
+			accessToken, _, err := server.tokenMaker.CreateTokenWithClaims(claims, time.Minute)
+			require.NoError(t, err)
+
+			request.Header.Set(authorizationHeaderKey, fmt.Sprintf("%s %s", authorizationTypeBearer, accessToken))
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func expectBannerDelete(store *mockdb.MockStore, user db.User, workspace db.Workspace) {
+	store.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(user.Role, nil)
+	store.EXPECT().
+		DeleteWorkspaceBanner(gomock.Any(), gomock.Eq(db.DeleteWorkspaceBannerParams{ID: 5, WorkspaceID: workspace.ID})).
+		Times(1).
+		Return(int64(1), nil)
+}
@@ -0,0 +1,319 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Moderation Words
+// @Description List the words and phrases moderated in a workspace with the action taken on each (admin only)
+// @ID listModerationWords
+// @Tags moderation
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.ModerationWordResponse "Moderation words"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/moderation/words [get]
+func (server *Server) listModerationWords(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	words, err := server.moderationService.ListModerationWords(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, words)
+}
+
+// @Summary Add Moderation Word
+// @Description Add a word or phrase to a workspace's moderation list (admin only). Matching ignores case and only matches whole words. Messages containing a "block" word are rejected, "mask" words are replaced with asterisks and messages with a "flag" word are delivered and queued for review.
+// @ID addModerationWord
+// @Tags moderation
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.CreateModerationWordRequest true "Word and action"
+// @Success 201 {object} service.ModerationWordResponse "Moderation word"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 409 {object} map[string]string "Word already in the list"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/moderation/words [post]
+func (server *Server) addModerationWord(ctx *gin.Context) {
+	var req service.CreateModerationWordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	word, err := server.moderationService.AddModerationWord(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		switch {
+		case strings.HasPrefix(err.Error(), "invalid moderation word"):
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "already exists"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, word)
+}
+
+// @Summary Delete Moderation Word
+// @Description Remove a word from a workspace's moderation list (admin only)
+// @ID deleteModerationWord
+// @Tags moderation
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param word_id path int true "Moderation word ID"
+// @Success 200 {object} map[string]string "Moderation word deleted"
+// @Failure 400 {object} map[string]string "Invalid workspace or word ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Moderation word not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/moderation/words/{word_id} [delete]
+func (server *Server) deleteModerationWord(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	wordID, err := strconv.ParseInt(ctx.Param("word_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid moderation word ID")))
+		return
+	}
+
+	err = server.moderationService.DeleteModerationWord(ctx, workspaceID, wordID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Moderation word deleted successfully"})
+}
+
+// @Summary List Moderation Queue
+// @Description List flagged messages waiting for review, or already reviewed ones, oldest first (admin only)
+// @ID listModerationQueue
+// @Tags moderation
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param status query string false "Review status (default: pending)" Enums(pending, approved, removed)
+// @Param limit query int false "Number of items to return (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of items to skip (default: 0)" minimum(0)
+// @Success 200 {array} service.ModerationQueueItemResponse "Flagged messages"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/moderation/queue [get]
+func (server *Server) listModerationQueue(ctx *gin.Context) {
+	var req service.ListModerationQueueRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	if req.Status == "" {
+		req.Status = service.ModerationStatusPending
+	}
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	items, err := server.moderationService.ListModerationQueue(ctx, workspaceID, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, items)
+}
+
+// @Summary Review Flagged Message
+// @Description Approve a flagged message or remove it (admin only). Removed messages are deleted for everyone.
+// @ID reviewModerationItem
+// @Tags moderation
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param item_id path int true "Moderation queue item ID"
+// @Param request body service.ReviewModerationItemRequest true "Review decision"
+// @Success 200 {object} map[string]string "Flagged message reviewed"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 404 {object} map[string]string "Moderation item not found"
+// @Failure 409 {object} map[string]string "Moderation item already reviewed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/moderation/queue/{item_id}/review [post]
+func (server *Server) reviewModerationItem(ctx *gin.Context) {
+	var req service.ReviewModerationItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	itemID, err := strconv.ParseInt(ctx.Param("item_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid moderation item ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	err = server.moderationService.ReviewModerationItem(ctx, workspaceID, itemID, currentUser.ID, req.Decision)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "already reviewed"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Flagged message reviewed successfully"})
+}
+
+// @Summary Take Down Message
+// @Description Delete a message as a moderator of its workspace. The author is sent the reason in a direct message and the takedown is recorded in the moderation audit log. When a policy is given, the message's tombstone states that it violated it.
+// @ID takedownMessage
+// @Tags moderation
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Param request body service.TakedownMessageRequest true "Reason and violated policy"
+// @Success 200 {object} service.TakedownMessageResponse "Message taken down"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Moderator access required"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 409 {object} map[string]string "Message already deleted"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/takedown [post]
+func (server *Server) takedownMessage(ctx *gin.Context) {
+	var req service.TakedownMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	takedown, err := server.moderationService.TakedownMessage(ctx, messageID, currentUser.ID, req)
+	if err != nil {
+		switch {
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "already deleted"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, takedown)
+}
+
+// @Summary List Moderation Audit Log
+// @Description List moderation actions in a workspace, newest first (admin only). Automatic actions on new and edited messages have no actor.
+// @ID listModerationAuditLog
+// @Tags moderation
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param limit query int false "Number of entries to return (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of entries to skip (default: 0)" minimum(0)
+// @Success 200 {array} service.ModerationAuditEntryResponse "Moderation actions"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/moderation/audit [get]
+func (server *Server) listModerationAuditLog(ctx *gin.Context) {
+	var req service.GetMessagesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	entries, err := server.moderationService.ListModerationAuditLog(ctx, workspaceID, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entries)
+}
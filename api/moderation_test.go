@@ -0,0 +1,448 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddModerationWordAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "admin"
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"word": "  heck   no ", "action": "mask"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateModerationWordParams{
+					WorkspaceID: workspace.ID,
+					Word:        "heck no",
+					Action:      service.ModerationActionMask,
+					CreatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+				}
+				store.EXPECT().
+					CreateModerationWord(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ModerationWord{ID: 1, WorkspaceID: workspace.ID, Word: arg.Word, Action: arg.Action, CreatedBy: arg.CreatedBy}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response service.ModerationWordResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, "heck no", response.Word)
+				require.Equal(t, service.ModerationActionMask, response.Action)
+			},
+		},
+		{
+			name: "InvalidAction",
+			body: gin.H{"word": "heck", "action": "shout"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateModerationWord(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BlankWord",
+			body: gin.H{"word": "   ", "action": "block"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateModerationWord(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Duplicate",
+			body: gin.H{"word": "heck", "action": "block"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateModerationWord(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ModerationWord{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(user.Role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/moderation/words", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReviewModerationItemAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	author, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+	channel := randomChannel(workspace.ID, author.ID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+
+	message := db.GetMessageByIDRow{
+		ID:          7,
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    author.ID,
+		Content:     "Can I get a refund?",
+		MessageType: "channel",
+	}
+	item := db.ModerationQueue{
+		ID:           3,
+		WorkspaceID:  workspace.ID,
+		MessageID:    message.ID,
+		MatchedWords: []string{"refund"},
+		Status:       service.ModerationStatusPending,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Approve",
+			body: gin.H{"decision": "approve"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetModerationQueueItem(gomock.Any(), gomock.Any()).Times(1).Return(item, nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().SoftDeleteMessage(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					ResolveModerationQueueItem(gomock.Any(), gomock.Eq(db.ResolveModerationQueueItemParams{
+						ID:          item.ID,
+						WorkspaceID: workspace.ID,
+						Status:      service.ModerationStatusApproved,
+						ReviewedBy:  sql.NullInt64{Int64: admin.ID, Valid: true},
+					})).
+					Times(1).
+					Return(item, nil)
+				store.EXPECT().
+					CreateModerationAuditEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateModerationAuditEntryParams) (db.ModerationAuditLog, error) {
+						require.Equal(t, "approved", arg.Action)
+						require.Equal(t, admin.ID, arg.ActorID.Int64)
+						require.Equal(t, author.ID, arg.AuthorID.Int64)
+						return db.ModerationAuditLog{}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Remove",
+			body: gin.H{"decision": "remove"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetModerationQueueItem(gomock.Any(), gomock.Any()).Times(1).Return(item, nil)
+				// Once for the review and once by the message deletion
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(2).Return(message, nil)
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("admin", nil)
+				store.EXPECT().
+					SoftDeleteMessage(gomock.Any(), gomock.Eq(db.SoftDeleteMessageParams{ID: message.ID, DeletedBy: sql.NullInt64{Int64: admin.ID, Valid: true}})).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					ResolveModerationQueueItem(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ResolveModerationQueueItemParams) (db.ModerationQueue, error) {
+						require.Equal(t, service.ModerationStatusRemoved, arg.Status)
+						return item, nil
+					})
+				store.EXPECT().CreateModerationAuditEntry(gomock.Any(), gomock.Any()).Times(1).Return(db.ModerationAuditLog{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyReviewed",
+			body: gin.H{"decision": "remove"},
+			buildStubs: func(store *mockdb.MockStore) {
+				reviewed := item
+				reviewed.Status = service.ModerationStatusApproved
+				store.EXPECT().GetModerationQueueItem(gomock.Any(), gomock.Any()).Times(1).Return(reviewed, nil)
+				store.EXPECT().SoftDeleteMessage(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"decision": "approve"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetModerationQueueItem(gomock.Any(), gomock.Any()).Times(1).Return(db.ModerationQueue{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidDecision",
+			body: gin.H{"decision": "ignore"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetModerationQueueItem(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(admin.Role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/moderation/queue/%d/review", workspace.ID, item.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestTakedownMessageAPI(t *testing.T) {
+	moderator, _ := randomUser(t)
+	author, _ := randomUser(t)
+	workspace := randomWorkspace(moderator.OrganizationID)
+	channel := randomChannel(workspace.ID, author.ID)
+
+	moderator.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	message := db.GetMessageByIDRow{
+		ID:          9,
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    author.ID,
+		Content:     "Buy followers at my site",
+		MessageType: "channel",
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"reason": "Spam links", "policy": "the spam policy"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+				store.EXPECT().
+					TakedownMessage(gomock.Any(), gomock.Eq(db.TakedownMessageParams{
+						ID:             message.ID,
+						DeletedBy:      sql.NullInt64{Int64: moderator.ID, Valid: true},
+						DeletionNotice: sql.NullString{String: "Removed by a moderator for violating the spam policy", Valid: true},
+					})).
+					Times(1).
+					Return(int64(1), nil)
+				store.EXPECT().
+					CreateModerationAuditEntry(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateModerationAuditEntryParams) (db.ModerationAuditLog, error) {
+						require.Equal(t, "taken_down", arg.Action)
+						require.Equal(t, moderator.ID, arg.ActorID.Int64)
+						require.Equal(t, author.ID, arg.AuthorID.Int64)
+						require.Equal(t, "Spam links", arg.Reason.String)
+						return db.ModerationAuditLog{}, nil
+					})
+
+				// The author is told why in a direct message from the moderator
+				notification := db.Message{
+					ID:          10,
+					WorkspaceID: workspace.ID,
+					SenderID:    moderator.ID,
+					ReceiverID:  sql.NullInt64{Int64: author.ID, Valid: true},
+					MessageType: "direct",
+					ContentType: "system",
+					CreatedAt:   time.Now(),
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(moderator.ID)).Times(1).Return(moderator, nil)
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg db.CreateMessageTxParams) (db.CreateMessageTxResult, error) {
+						require.NotNil(t, arg.DirectMessage)
+						require.Equal(t, author.ID, arg.DirectMessage.ReceiverID.Int64)
+						require.Equal(t, "system", arg.DirectMessage.ContentType)
+						require.Contains(t, arg.DirectMessage.Content, "Spam links")
+						return createMessageTx(notification)(ctx, arg)
+					})
+				store.EXPECT().MarkOutboxEventPublished(gomock.Any(), gomock.Eq(notification.ID)).Times(1).Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.TakedownMessageResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, message.ID, response.MessageID)
+				require.Equal(t, "Removed by a moderator for violating the spam policy", response.DeletionNotice)
+				require.True(t, response.AuthorNotified)
+			},
+		},
+		{
+			name: "NotModerator",
+			body: gin.H{"reason": "Spam links"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+				store.EXPECT().GetUserRolePermissions(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, sql.ErrNoRows)
+				store.EXPECT().TakedownMessage(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyDeleted",
+			body: gin.H{"reason": "Spam links"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+				store.EXPECT().TakedownMessage(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+				store.EXPECT().CreateModerationAuditEntry(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"reason": "Spam links"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(db.GetMessageByIDRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "MissingReason",
+			body: gin.H{"policy": "the spam policy"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(moderator.Email)).
+				Times(1).
+				Return(moderator, nil)
+			tc.buildStubs(store)
+			expectDefaultWorkspaceSettings(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/messages/%d/takedown", message.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, moderator.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Notification Preferences
+// @Description Get the current user's workspace-wide notification preference and their channel overrides. Without a preference of their own users get mentions, email and push. Channel overrides that differ from the default admins picked for the channel are flagged with overrides_channel_default.
+// @ID listNotificationPreferences
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.NotificationPreferencesResponse "Notification preferences"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-preferences [get]
+func (server *Server) listNotificationPreferences(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	preferences, err := server.notificationPreferenceService.ListPreferences(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preferences)
+}
+
+// @Summary Update Notification Preference
+// @Description Change the current user's workspace-wide notification preference. Fields that are left out keep their current value.
+// @ID updateNotificationPreference
+// @Tags notifications
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.UpdateNotificationPreferenceRequest true "Notification preference"
+// @Success 200 {object} service.NotificationPreferenceResponse "Notification preference"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-preferences [put]
+func (server *Server) updateNotificationPreference(ctx *gin.Context) {
+	var req service.UpdateNotificationPreferenceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	preference, err := server.notificationPreferenceService.UpdateWorkspacePreference(ctx, currentUser.ID, workspaceID, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preference)
+}
+
+// @Summary Reset Notification Preference
+// @Description Remove the current user's workspace-wide notification preference so the defaults apply again. Channel overrides are kept.
+// @ID resetNotificationPreference
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} map[string]string "Notification preference reset"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Notification preference not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-preferences [delete]
+func (server *Server) resetNotificationPreference(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	err = server.notificationPreferenceService.ResetWorkspacePreference(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Notification preference reset successfully"})
+}
+
+// @Summary Get Channel Notification Preference
+// @Description Get the notification preference that applies to the current user in a channel: the channel's own, or else the workspace-wide one (marked as inherited)
+// @ID getChannelNotificationPreference
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Success 200 {object} service.NotificationPreferenceResponse "Notification preference"
+// @Failure 400 {object} map[string]string "Invalid workspace or channel ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace or channel membership required"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-preferences/channels/{channel_id} [get]
+func (server *Server) getChannelNotificationPreference(ctx *gin.Context) {
+	workspaceID, channelID, ok := parseNotificationChannelParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	preference, err := server.notificationPreferenceService.GetChannelPreference(ctx, currentUser.ID, workspaceID, channelID)
+	if err != nil {
+		notificationPreferenceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preference)
+}
+
+// @Summary Update Channel Notification Preference
+// @Description Override the current user's workspace-wide notification preference in a channel. Fields that are left out start from the preference that currently applies in the channel.
+// @ID updateChannelNotificationPreference
+// @Tags notifications
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Param request body service.UpdateNotificationPreferenceRequest true "Notification preference"
+// @Success 200 {object} service.NotificationPreferenceResponse "Notification preference"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace or channel membership required"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-preferences/channels/{channel_id} [put]
+func (server *Server) updateChannelNotificationPreference(ctx *gin.Context) {
+	var req service.UpdateNotificationPreferenceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, channelID, ok := parseNotificationChannelParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	preference, err := server.notificationPreferenceService.UpdateChannelPreference(ctx, currentUser.ID, workspaceID, channelID, req)
+	if err != nil {
+		notificationPreferenceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preference)
+}
+
+// @Summary Delete Channel Notification Preference
+// @Description Remove the current user's override in a channel so their workspace-wide notification preference applies again
+// @ID deleteChannelNotificationPreference
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Success 200 {object} map[string]string "Notification preference deleted"
+// @Failure 400 {object} map[string]string "Invalid workspace or channel ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace or channel membership required"
+// @Failure 404 {object} map[string]string "Channel or notification preference not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-preferences/channels/{channel_id} [delete]
+func (server *Server) deleteChannelNotificationPreference(ctx *gin.Context) {
+	workspaceID, channelID, ok := parseNotificationChannelParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	err := server.notificationPreferenceService.DeleteChannelPreference(ctx, currentUser.ID, workspaceID, channelID)
+	if err != nil {
+		notificationPreferenceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Notification preference deleted successfully"})
+}
+
+// @Summary List Channel Notification Defaults
+// @Description List the notification preferences admins picked for the workspace's channels. Members get a channel's default as their preference in it when they join.
+// @ID listChannelNotificationDefaults
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.ChannelNotificationDefaultResponse "Channel notification defaults"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-defaults [get]
+func (server *Server) listChannelNotificationDefaults(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	defaults, err := server.notificationPreferenceService.ListChannelDefaults(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, defaults)
+}
+
+// @Summary Set Channel Notification Default
+// @Description Pick the notification preference members get in a channel when they join it, such as every message in an announcements channel. Members already in the channel keep their preference until the default is applied. Requires the manage_channels permission.
+// @ID setChannelNotificationDefault
+// @Tags notifications
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Param request body service.SetChannelNotificationDefaultRequest true "Channel notification default"
+// @Success 200 {object} service.ChannelNotificationDefaultResponse "Channel notification default"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Permission to manage channels required"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-defaults/channels/{channel_id} [put]
+func (server *Server) setChannelNotificationDefault(ctx *gin.Context) {
+	var req service.SetChannelNotificationDefaultRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, channelID, ok := parseNotificationChannelParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	channelDefault, err := server.notificationPreferenceService.SetChannelDefault(ctx, currentUser.ID, workspaceID, channelID, req)
+	if err != nil {
+		notificationPreferenceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, channelDefault)
+}
+
+// @Summary Delete Channel Notification Default
+// @Description Stop seeding the preferences of members who join a channel. Preferences seeded earlier are kept. Requires the manage_channels permission.
+// @ID deleteChannelNotificationDefault
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Success 200 {object} map[string]string "Channel notification default deleted"
+// @Failure 400 {object} map[string]string "Invalid workspace or channel ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Permission to manage channels required"
+// @Failure 404 {object} map[string]string "Channel or channel notification default not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-defaults/channels/{channel_id} [delete]
+func (server *Server) deleteChannelNotificationDefault(ctx *gin.Context) {
+	workspaceID, channelID, ok := parseNotificationChannelParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	err := server.notificationPreferenceService.DeleteChannelDefault(ctx, currentUser.ID, workspaceID, channelID)
+	if err != nil {
+		notificationPreferenceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Channel notification default deleted successfully"})
+}
+
+// @Summary Apply Channel Notification Default
+// @Description Set every member's notification preference in a channel to the channel's default, replacing the overrides they picked themselves. Requires the manage_channels permission.
+// @ID applyChannelNotificationDefault
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Success 200 {object} service.ApplyChannelNotificationDefaultResponse "Number of members the default was applied to"
+// @Failure 400 {object} map[string]string "Invalid workspace or channel ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Permission to manage channels required"
+// @Failure 404 {object} map[string]string "Channel or channel notification default not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/notification-defaults/channels/{channel_id}/apply [post]
+func (server *Server) applyChannelNotificationDefault(ctx *gin.Context) {
+	workspaceID, channelID, ok := parseNotificationChannelParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	applied, err := server.notificationPreferenceService.ApplyChannelDefault(ctx, currentUser.ID, workspaceID, channelID)
+	if err != nil {
+		notificationPreferenceErrorResponse(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, applied)
+}
+
+// parseNotificationChannelParams reads the workspace and channel IDs from the
+// URL, responding with an error if either is invalid
+func parseNotificationChannelParams(ctx *gin.Context) (workspaceID, channelID int64, ok bool) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return 0, 0, false
+	}
+
+	channelID, err = strconv.ParseInt(ctx.Param("channel_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
+		return 0, 0, false
+	}
+
+	return workspaceID, channelID, true
+}
+
+// notificationPreferenceErrorResponse responds with the status matching a
+// notification preference service error
+func notificationPreferenceErrorResponse(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
@@ -0,0 +1,268 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateChannelNotificationPreferenceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	// Make user a member of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	channel := randomChannel(workspace.ID, user.ID)
+	channel.IsPrivate = false
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"level": "all",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+				store.EXPECT().
+					GetEffectiveNotificationPreference(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.NotificationPreference{}, sql.ErrNoRows)
+
+				arg := db.UpsertChannelNotificationPreferenceParams{
+					UserID:       user.ID,
+					WorkspaceID:  workspace.ID,
+					ChannelID:    channel.ID,
+					Level:        service.NotificationLevelAll,
+					EmailEnabled: true,
+					PushEnabled:  true,
+				}
+				store.EXPECT().
+					UpsertChannelNotificationPreference(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.NotificationPreference{
+						ID:           1,
+						UserID:       user.ID,
+						WorkspaceID:  workspace.ID,
+						ChannelID:    sql.NullInt64{Int64: channel.ID, Valid: true},
+						Level:        arg.Level,
+						EmailEnabled: true,
+						PushEnabled:  true,
+						UpdatedAt:    time.Now(),
+					}, nil)
+				store.EXPECT().
+					GetChannelNotificationDefault(gomock.Any(), gomock.Eq(channel.ID)).
+					Times(1).
+					Return(db.ChannelNotificationDefault{ChannelID: channel.ID, WorkspaceID: workspace.ID, Level: service.NotificationLevelMentions, EmailEnabled: true, PushEnabled: true}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.NotificationPreferenceResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, channel.ID, *response.ChannelID)
+				require.Equal(t, service.NotificationLevelAll, response.Level)
+				require.False(t, response.Inherited)
+				require.True(t, response.OverridesChannelDefault)
+			},
+		},
+		{
+			name: "InvalidLevel",
+			body: gin.H{
+				"level": "loud",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertChannelNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ChannelNotFound",
+			body: gin.H{
+				"push_enabled": false,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(db.Channel{}, sql.ErrNoRows)
+				store.EXPECT().UpsertChannelNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(user.Role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/notification-preferences/channels/%d", workspace.ID, channel.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSetChannelNotificationDefaultAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	channel := randomChannel(workspace.ID, user.ID)
+	channel.IsPrivate = false
+
+	testCases := []struct {
+		name          string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: "admin",
+			body: gin.H{"level": "all", "email_enabled": false},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+
+				arg := db.UpsertChannelNotificationDefaultParams{
+					ChannelID:    channel.ID,
+					WorkspaceID:  workspace.ID,
+					Level:        service.NotificationLevelAll,
+					EmailEnabled: false,
+					PushEnabled:  true,
+					UpdatedBy:    sql.NullInt64{Int64: user.ID, Valid: true},
+				}
+				store.EXPECT().
+					UpsertChannelNotificationDefault(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ChannelNotificationDefault{
+						ChannelID:   channel.ID,
+						WorkspaceID: workspace.ID,
+						Level:       arg.Level,
+						PushEnabled: true,
+						UpdatedBy:   arg.UpdatedBy,
+						UpdatedAt:   time.Now(),
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.ChannelNotificationDefaultResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, channel.ID, response.ChannelID)
+				require.Equal(t, service.NotificationLevelAll, response.Level)
+				require.False(t, response.EmailEnabled)
+				require.True(t, response.PushEnabled)
+			},
+		},
+		{
+			name: "MissingLevel",
+			role: "admin",
+			body: gin.H{"push_enabled": false},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertChannelNotificationDefault(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotAdmin",
+			role: "member",
+			body: gin.H{"level": "all"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserRolePermissions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				store.EXPECT().UpsertChannelNotificationDefault(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			requestUser := user
+			requestUser.Role = tc.role
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(requestUser, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(tc.role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/notification-defaults/channels/%d", workspace.ID, channel.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
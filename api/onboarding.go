@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// welcomeNewMember sends a user who just joined a workspace the workspace's
+// welcome DM. The user has joined either way, so failures are only logged.
+func (server *Server) welcomeNewMember(ctx context.Context, workspaceID, userID int64) {
+	if err := server.onboardingService.WelcomeMember(ctx, workspaceID, userID); err != nil {
+		fmt.Printf("Error welcoming user %d to workspace %d: %v\n", userID, workspaceID, err)
+	}
+}
+
+// @Summary Get Onboarding
+// @Description Get the current user's onboarding checklist and the channels suggested to them. Steps done since the checklist was last fetched are recorded as completed.
+// @ID getOnboarding
+// @Tags onboarding
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.OnboardingResponse "Onboarding checklist"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/onboarding [get]
+func (server *Server) getOnboarding(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	onboarding, err := server.onboardingService.GetOnboarding(ctx, workspaceID, currentUser)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, onboarding)
+}
+
+// @Summary Join Suggested Channels
+// @Description Join channels onboarding suggests, or all of them when no channel IDs are given
+// @ID joinSuggestedChannels
+// @Tags onboarding
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.JoinSuggestedChannelsRequest false "Channels to join"
+// @Success 200 {object} service.OnboardingResponse "Onboarding checklist"
+// @Failure 400 {object} map[string]string "Invalid request or channel not suggested"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Onboarding not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/onboarding/join-channels [post]
+func (server *Server) joinSuggestedChannels(ctx *gin.Context) {
+	var req service.JoinSuggestedChannelsRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+			return
+		}
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	onboarding, err := server.onboardingService.JoinSuggestedChannels(ctx, workspaceID, currentUser, req.ChannelIDs)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "is not suggested"):
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, onboarding)
+}
+
+// @Summary Get Onboarding Settings
+// @Description Get how the workspace onboards new members: the welcome DM, suggested channels and checklist steps (requires manage_workspace permission)
+// @ID getOnboardingSettings
+// @Tags onboarding
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.OnboardingSettingsResponse "Onboarding settings"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Permission required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/onboarding/settings [get]
+func (server *Server) getOnboardingSettings(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	settings, err := server.onboardingService.GetSettings(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update Onboarding Settings
+// @Description Customize how the workspace onboards new members (requires manage_workspace permission). The welcome DM is a Go template that can use {{.FirstName}}, {{.LastName}} and {{.WorkspaceName}}; only public channels can be suggested.
+// @ID updateOnboardingSettings
+// @Tags onboarding
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.UpdateOnboardingRequest true "Onboarding settings"
+// @Success 200 {object} service.OnboardingSettingsResponse "Onboarding settings"
+// @Failure 400 {object} map[string]string "Invalid request, step, channel or welcome message"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Permission required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/onboarding/settings [put]
+func (server *Server) updateOnboardingSettings(ctx *gin.Context) {
+	var req service.UpdateOnboardingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	settings, err := server.onboardingService.UpdateSettings(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
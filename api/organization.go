@@ -5,12 +5,13 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	db "github.com/heyrmi/goslack/db/sqlc"
 	"github.com/heyrmi/goslack/service"
-	"github.com/lib/pq"
 )
 
 // @Summary Create Organization
 // @Description Create a new organization
+// @ID createOrganization
 // @Tags organizations
 // @Accept json
 // @Produce json
@@ -23,20 +24,18 @@ import (
 func (server *Server) createOrganization(ctx *gin.Context) {
 	var req service.CreateOrganizationRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	organization, err := server.organizationService.CreateOrganization(ctx, req)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -45,6 +44,7 @@ func (server *Server) createOrganization(ctx *gin.Context) {
 
 // @Summary Get Organization
 // @Description Retrieve an organization by ID
+// @ID getOrganization
 // @Tags organizations
 // @Produce json
 // @Param id path int true "Organization ID"
@@ -56,13 +56,13 @@ func (server *Server) getOrganization(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
 	organization, err := server.organizationService.GetOrganization(ctx, id)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 		return
 	}
 
@@ -71,6 +71,7 @@ func (server *Server) getOrganization(ctx *gin.Context) {
 
 // @Summary Update Organization
 // @Description Update an organization's information (requires authentication)
+// @ID updateOrganization
 // @Tags organizations
 // @Security BearerAuth
 // @Accept json
@@ -86,19 +87,19 @@ func (server *Server) updateOrganization(ctx *gin.Context) {
 	idStr := ctx.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
 	var req service.CreateOrganizationRequest // Reusing the same struct since it only has name
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	organization, err := server.organizationService.UpdateOrganization(ctx, id, req.Name)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -107,6 +108,7 @@ func (server *Server) updateOrganization(ctx *gin.Context) {
 
 // @Summary List Organizations
 // @Description List all organizations with pagination
+// @ID listOrganizations
 // @Tags organizations
 // @Produce json
 // @Param page_id query int false "Page ID (default: 1)" minimum(1)
@@ -118,7 +120,7 @@ func (server *Server) updateOrganization(ctx *gin.Context) {
 func (server *Server) listOrganizations(ctx *gin.Context) {
 	var req listOrganizationsRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -132,41 +134,13 @@ func (server *Server) listOrganizations(ctx *gin.Context) {
 
 	organizations, err := server.organizationService.ListOrganizations(ctx, req.PageSize, (req.PageID-1)*req.PageSize)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, organizations)
 }
 
-// @Summary Delete Organization
-// @Description Delete an organization (requires authentication)
-// @Tags organizations
-// @Security BearerAuth
-// @Produce json
-// @Param id path int true "Organization ID"
-// @Success 200 {object} map[string]string "Organization deleted successfully"
-// @Failure 400 {object} map[string]string "Invalid organization ID"
-// @Failure 401 {object} map[string]string "Authentication required"
-// @Failure 500 {object} map[string]string "Internal server error"
-// @Router /organizations/{id} [delete]
-func (server *Server) deleteOrganization(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-
-	err = server.organizationService.DeleteOrganization(ctx, id)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-
-	ctx.JSON(http.StatusOK, gin.H{"message": "organization deleted successfully"})
-}
-
 type listOrganizationsRequest struct {
 	PageID   int32 `form:"page_id" binding:"omitempty,min=1"`
 	PageSize int32 `form:"page_size" binding:"omitempty,min=5,max=10"`
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Delete Organization
+// @Description Schedule the organization's deletion. Members lose access to its workspaces at once, and the deletion can be cancelled until its window passes, after which a background job removes the workspaces, their content and files (organization owner only)
+// @ID deleteOrganization
+// @Tags organizations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 202 {object} service.OrganizationDeletionResponse "Organization deletion scheduled"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization owner access required"
+// @Failure 404 {object} map[string]string "Organization not found"
+// @Failure 409 {object} map[string]string "Organization has content under legal hold or is already being deleted"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id} [delete]
+func (server *Server) deleteOrganization(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+	deletion, err := server.organizationDeletionService.RequestDeletion(ctx, organizationID, currentUser.ID)
+	if err != nil {
+		handleOrganizationDeletionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, deletion)
+}
+
+// @Summary Get Organization Deletion
+// @Description Report the organization's latest deletion: when it's due and how far the removal of its workspaces has got (organization admin only)
+// @ID getOrganizationDeletion
+// @Tags organizations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} service.OrganizationDeletionResponse "Organization deletion"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "Organization deletion not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/deletion [get]
+func (server *Server) getOrganizationDeletion(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	deletion, err := server.organizationDeletionService.GetDeletion(ctx, organizationID)
+	if err != nil {
+		handleOrganizationDeletionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deletion)
+}
+
+// @Summary Cancel Organization Deletion
+// @Description Cancel a scheduled organization deletion while it's still within its cancellation window, restoring members' access (organization owner only)
+// @ID cancelOrganizationDeletion
+// @Tags organizations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} service.OrganizationDeletionResponse "Organization deletion cancelled"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization owner access required"
+// @Failure 404 {object} map[string]string "Organization deletion not found"
+// @Failure 409 {object} map[string]string "Organization deletion has already started"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/deletion/cancel [post]
+func (server *Server) cancelOrganizationDeletion(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+	deletion, err := server.organizationDeletionService.CancelDeletion(ctx, organizationID, currentUser.ID)
+	if err != nil {
+		handleOrganizationDeletionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deletion)
+}
+
+func handleOrganizationDeletionError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "under legal hold"),
+		strings.HasSuffix(err.Error(), "already in progress"),
+		strings.HasSuffix(err.Error(), "can no longer be cancelled"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
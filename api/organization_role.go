@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Organization Roles
+// @Description List the organization's owners and admins. They administer every workspace in the organization (organization admin only).
+// @ID listOrganizationRoles
+// @Tags organization-roles
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {array} service.OrganizationRoleResponse "Organization owners and admins"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/roles [get]
+func (server *Server) listOrganizationRoles(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	roles, err := server.organizationRoleService.ListRoles(ctx, organizationID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, roles)
+}
+
+// @Summary Set Organization Role
+// @Description Make a user of the organization an owner or admin. Only owners can grant ownership or change an owner (organization admin only).
+// @ID setOrganizationRole
+// @Tags organization-roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param user_id path int true "User ID"
+// @Param request body service.SetOrganizationRoleRequest true "Organization role"
+// @Success 200 {object} service.OrganizationRoleResponse "Organization role"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin or owner access required"
+// @Failure 404 {object} map[string]string "User not found in organization"
+// @Failure 409 {object} map[string]string "Organization must keep at least one owner"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/roles/{user_id} [put]
+func (server *Server) setOrganizationRole(ctx *gin.Context) {
+	var req service.SetOrganizationRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	userID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid user ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	role, err := server.organizationRoleService.AssignRole(ctx, organizationID, currentUser.ID, userID, req.Role)
+	if err != nil {
+		handleOrganizationRoleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, role)
+}
+
+// @Summary Remove Organization Role
+// @Description Take away a user's organization role. They keep their role in their own workspace (organization admin only).
+// @ID removeOrganizationRole
+// @Tags organization-roles
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} map[string]string "Organization role removed"
+// @Failure 400 {object} map[string]string "Invalid organization or user ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin or owner access required"
+// @Failure 404 {object} map[string]string "Organization role not found"
+// @Failure 409 {object} map[string]string "Organization must keep at least one owner"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/roles/{user_id} [delete]
+func (server *Server) removeOrganizationRole(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	userID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid user ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	if err := server.organizationRoleService.RemoveRole(ctx, organizationID, currentUser.ID, userID); err != nil {
+		handleOrganizationRoleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "organization role removed successfully"})
+}
+
+func handleOrganizationRoleError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "invalid"):
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+	case strings.Contains(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "at least one owner"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
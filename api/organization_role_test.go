@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+// orgRoleStub expects a lookup of the user's organization role. An empty
+// role means the user holds none.
+func orgRoleStub(store *mockdb.MockStore, user db.User, role string) {
+	arg := db.GetOrganizationRoleParams{OrganizationID: user.OrganizationID, UserID: user.ID}
+	if role == "" {
+		store.EXPECT().GetOrganizationRole(gomock.Any(), gomock.Eq(arg)).Times(1).Return("", sql.ErrNoRows)
+		return
+	}
+	store.EXPECT().GetOrganizationRole(gomock.Any(), gomock.Eq(arg)).Times(1).Return(role, nil)
+}
+
+func TestSetOrganizationRoleAPI(t *testing.T) {
+	actor, _ := randomUser(t)
+	target, _ := randomUser(t)
+	target.OrganizationID = actor.OrganizationID
+
+	testCases := []struct {
+		name          string
+		actorRole     string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OwnerGrantsAdmin",
+			actorRole: service.OrganizationRoleOwner,
+			body:      gin.H{"role": "admin"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(target, nil)
+				orgRoleStub(store, actor, service.OrganizationRoleOwner)
+				orgRoleStub(store, target, "")
+				arg := db.UpsertOrganizationRoleParams{
+					OrganizationID: actor.OrganizationID,
+					UserID:         target.ID,
+					Role:           service.OrganizationRoleAdmin,
+					GrantedBy:      sql.NullInt64{Int64: actor.ID, Valid: true},
+				}
+				store.EXPECT().
+					UpsertOrganizationRole(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.OrganizationRole{
+						OrganizationID: arg.OrganizationID,
+						UserID:         arg.UserID,
+						Role:           arg.Role,
+						GrantedBy:      arg.GrantedBy,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.OrganizationRoleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, target.ID, response.UserID)
+				require.Equal(t, target.Email, response.Email)
+				require.Equal(t, service.OrganizationRoleAdmin, response.Role)
+			},
+		},
+		{
+			name:      "AdminCannotGrantOwner",
+			actorRole: service.OrganizationRoleAdmin,
+			body:      gin.H{"role": "owner"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(target, nil)
+				orgRoleStub(store, actor, service.OrganizationRoleAdmin)
+				orgRoleStub(store, target, "")
+				store.EXPECT().UpsertOrganizationRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "LastOwner",
+			actorRole: service.OrganizationRoleOwner,
+			body:      gin.H{"role": "admin"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(target, nil)
+				orgRoleStub(store, actor, service.OrganizationRoleOwner)
+				orgRoleStub(store, target, service.OrganizationRoleOwner)
+				store.EXPECT().
+					CountOrganizationOwners(gomock.Any(), gomock.Eq(actor.OrganizationID)).
+					Times(1).
+					Return(int64(1), nil)
+				store.EXPECT().UpsertOrganizationRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "UserInOtherOrganization",
+			actorRole: service.OrganizationRoleOwner,
+			body:      gin.H{"role": "admin"},
+			buildStubs: func(store *mockdb.MockStore) {
+				other := target
+				other.OrganizationID = actor.OrganizationID + 1
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(target.ID)).Times(1).Return(other, nil)
+				store.EXPECT().UpsertOrganizationRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidRole",
+			actorRole: service.OrganizationRoleOwner,
+			body:      gin.H{"role": "member"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertOrganizationRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "NotOrganizationAdmin",
+			actorRole: "",
+			body:      gin.H{"role": "admin"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertOrganizationRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(actor.Email)).
+				Times(1).
+				Return(actor, nil)
+			// requireOrganizationAdmin looks up the actor's role first
+			orgRoleStub(store, actor, tc.actorRole)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/organizations/%d/roles/%d", actor.OrganizationID, target.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRemoveOrganizationRoleAPI(t *testing.T) {
+	actor, _ := randomUser(t)
+	target, _ := randomUser(t)
+	target.OrganizationID = actor.OrganizationID
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				orgRoleStub(store, target, service.OrganizationRoleOwner)
+				store.EXPECT().
+					CountOrganizationOwners(gomock.Any(), gomock.Eq(actor.OrganizationID)).
+					Times(1).
+					Return(int64(2), nil)
+				store.EXPECT().
+					DeleteOrganizationRole(gomock.Any(), gomock.Eq(db.DeleteOrganizationRoleParams{
+						OrganizationID: actor.OrganizationID,
+						UserID:         target.ID,
+					})).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NoRole",
+			buildStubs: func(store *mockdb.MockStore) {
+				orgRoleStub(store, target, "")
+				store.EXPECT().DeleteOrganizationRole(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(actor.Email)).
+				Times(1).
+				Return(actor, nil)
+			// Once for requireOrganizationAdmin, once for the role change check
+			store.EXPECT().
+				GetOrganizationRole(gomock.Any(), gomock.Eq(db.GetOrganizationRoleParams{OrganizationID: actor.OrganizationID, UserID: actor.ID})).
+				Times(2).
+				Return(service.OrganizationRoleOwner, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/organizations/%d/roles/%d", actor.OrganizationID, target.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Get Out Of Office
+// @Description Get the current user's out-of-office window and auto-reply message in a workspace
+// @ID getOutOfOffice
+// @Tags status
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.OutOfOfficeResponse "Out-of-office window"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Out of office not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/out-of-office [get]
+func (server *Server) getOutOfOffice(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	outOfOffice, err := server.outOfOfficeService.GetOutOfOffice(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, outOfOffice)
+}
+
+// @Summary Set Out Of Office
+// @Description Set the current user's out-of-office window. Direct messages received during the window are answered with the auto-reply message, once per sender per day.
+// @ID setOutOfOffice
+// @Tags status
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.SetOutOfOfficeRequest true "Out-of-office window and auto-reply"
+// @Success 200 {object} service.OutOfOfficeResponse "Out-of-office window"
+// @Failure 400 {object} map[string]string "Invalid request or window"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/out-of-office [put]
+func (server *Server) setOutOfOffice(ctx *gin.Context) {
+	var req service.SetOutOfOfficeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	outOfOffice, err := server.outOfOfficeService.SetOutOfOffice(ctx, currentUser.ID, workspaceID, req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "out of office must") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, outOfOffice)
+}
+
+// @Summary Clear Out Of Office
+// @Description Remove the current user's out-of-office window in a workspace
+// @ID clearOutOfOffice
+// @Tags status
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} map[string]string "Out of office cleared"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Out of office not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/out-of-office [delete]
+func (server *Server) clearOutOfOffice(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	err = server.outOfOfficeService.ClearOutOfOffice(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Out of office cleared successfully"})
+}
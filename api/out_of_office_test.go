@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOutOfOfficeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	// Make user a member of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	startsAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	endsAt := startsAt.Add(72 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"starts_at": startsAt,
+				"ends_at":   endsAt,
+				"message":   "On vacation, back on Monday",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertOutOfOfficeParams{
+					UserID:      user.ID,
+					WorkspaceID: workspace.ID,
+					StartsAt:    startsAt,
+					EndsAt:      endsAt,
+					Message:     "On vacation, back on Monday",
+				}
+				store.EXPECT().
+					UpsertOutOfOffice(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.OutOfOffice{
+						UserID:      user.ID,
+						WorkspaceID: workspace.ID,
+						StartsAt:    startsAt,
+						EndsAt:      endsAt,
+						Message:     arg.Message,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.OutOfOfficeResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, "On vacation, back on Monday", response.Message)
+				require.False(t, response.Active)
+			},
+		},
+		{
+			name: "EndsBeforeStart",
+			body: gin.H{
+				"starts_at": endsAt,
+				"ends_at":   startsAt,
+				"message":   "On vacation",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertOutOfOffice(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingMessage",
+			body: gin.H{
+				"ends_at": endsAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertOutOfOffice(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(user.Role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/out-of-office", workspace.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetDirectMessagesOutOfOfficeBannerAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	// Make users members of the same workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	testCases := []struct {
+		name           string
+		outOfOffice    db.OutOfOffice
+		outOfOfficeErr error
+		expectBanner   bool
+	}{
+		{
+			name: "OutOfOffice",
+			outOfOffice: db.OutOfOffice{
+				UserID:      otherUser.ID,
+				WorkspaceID: workspace.ID,
+				StartsAt:    time.Now().Add(-time.Hour),
+				EndsAt:      time.Now().Add(time.Hour),
+				Message:     "Out sick today",
+			},
+			expectBanner: true,
+		},
+		{
+			name:           "NotOutOfOffice",
+			outOfOfficeErr: sql.ErrNoRows,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				AnyTimes().
+				Return("member", nil)
+			store.EXPECT().
+				GetDirectMessagesBetweenUsers(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return([]db.GetDirectMessagesBetweenUsersRow{}, nil)
+			expectNoMessageArchives(store)
+			store.EXPECT().
+				GetActiveOutOfOffice(gomock.Any(), gomock.Eq(db.GetActiveOutOfOfficeParams{
+					UserID:      otherUser.ID,
+					WorkspaceID: workspace.ID,
+				})).
+				Times(1).
+				Return(tc.outOfOffice, tc.outOfOfficeErr)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspace/%d/messages/direct/%d", workspace.ID, otherUser.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var response struct {
+				Messages    []service.MessageResponse    `json:"messages"`
+				OutOfOffice *service.OutOfOfficeResponse `json:"out_of_office"`
+			}
+			err = json.Unmarshal(recorder.Body.Bytes(), &response)
+			require.NoError(t, err)
+
+			if tc.expectBanner {
+				require.NotNil(t, response.OutOfOffice)
+				require.Equal(t, "Out sick today", response.OutOfOffice.Message)
+				require.True(t, response.OutOfOffice.Active)
+			} else {
+				require.Nil(t, response.OutOfOffice)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Pin Message
+// @Description Pin a channel message to the end of its channel's pins. Only channel members can pin, up to the workspace's pin limit. The channel receives a message_pinned WebSocket message.
+// @ID pinMessage
+// @Tags pins
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Success 201 {object} service.PinnedMessageResponse "Pinned message"
+// @Failure 400 {object} map[string]string "Invalid message ID or not a channel message"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel membership required"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 409 {object} map[string]string "Message already pinned"
+// @Failure 422 {object} map[string]string "Pin limit reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/pin [post]
+func (server *Server) pinMessage(ctx *gin.Context) {
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	pin, err := server.pinService.PinMessage(ctx, messageID, currentUser.ID)
+	if err != nil {
+		handlePinError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, pin)
+}
+
+// @Summary Unpin Message
+// @Description Remove a message from its channel's pins. Only channel members can unpin. The channel receives a message_unpinned WebSocket message.
+// @ID unpinMessage
+// @Tags pins
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Success 204 "Message unpinned"
+// @Failure 400 {object} map[string]string "Invalid message ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel membership required"
+// @Failure 404 {object} map[string]string "Message or pin not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/pin [delete]
+func (server *Server) unpinMessage(ctx *gin.Context) {
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	if err := server.pinService.UnpinMessage(ctx, messageID, currentUser.ID); err != nil {
+		handlePinError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary List Pinned Messages
+// @Description List a channel's pinned messages in their arranged order, with who pinned each and when
+// @ID listPinnedMessages
+// @Tags pins
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Success 200 {array} service.PinnedMessageResponse "Pinned messages"
+// @Failure 400 {object} map[string]string "Invalid channel ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /channels/{id}/pins [get]
+func (server *Server) listPinnedMessages(ctx *gin.Context) {
+	channelID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	pins, err := server.pinService.GetPinnedMessages(ctx, channelID, currentUser.ID)
+	if err != nil {
+		handlePinError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pins)
+}
+
+// @Summary Reorder Pinned Messages
+// @Description Arrange a channel's pinned messages. The request must list every pinned message of the channel once. Only channel members can reorder pins. The channel receives a pins_reordered WebSocket message.
+// @ID reorderPinnedMessages
+// @Tags pins
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Param request body service.ReorderPinsRequest true "Pinned message IDs in their new order"
+// @Success 200 {array} service.PinnedMessageResponse "Pinned messages in their new order"
+// @Failure 400 {object} map[string]string "Invalid request or order"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel membership required"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /channels/{id}/pins/order [put]
+func (server *Server) reorderPinnedMessages(ctx *gin.Context) {
+	var req service.ReorderPinsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	channelID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	pins, err := server.pinService.ReorderPins(ctx, channelID, currentUser.ID, req.MessageIDs)
+	if err != nil {
+		handlePinError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pins)
+}
+
+func handlePinError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "invalid"):
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasPrefix(err.Error(), "pin limit reached"):
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "already pinned"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
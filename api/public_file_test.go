@@ -0,0 +1,175 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/publicid"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPublicFileAPI(t *testing.T) {
+	content := randomPNG(t, 32, 32)
+	filePath := filepath.Join(t.TempDir(), "logo.png")
+	require.NoError(t, os.WriteFile(filePath, content, 0644))
+
+	file := db.File{
+		ID:               7,
+		WorkspaceID:      2,
+		OriginalFilename: "logo.png",
+		FilePath:         filePath,
+		MimeType:         "image/png",
+		FileHash:         "9f86d081",
+		IsPublic:         true,
+		UploadCompleted:  true,
+	}
+	restricted := db.WorkspaceSetting{ImageWatermark: "off", PublicFiles: true, PublicFileReferers: []string{"example.com"}}
+
+	testCases := []struct {
+		name          string
+		key           string // The file's public ID when empty
+		setupRequest  func(request *http.Request)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFile(gomock.Any(), file.ID).Times(1).Return(file, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), file.WorkspaceID).Times(1).Return(db.Workspace{ID: file.WorkspaceID}, nil)
+				store.EXPECT().GetWorkspaceSettings(gomock.Any(), file.WorkspaceID).Times(1).Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, content, recorder.Body.Bytes())
+				require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `inline; filename="logo.png"`, recorder.Header().Get("Content-Disposition"))
+				require.Equal(t, "public, max-age=3600", recorder.Header().Get("Cache-Control"))
+				require.Equal(t, `"9f86d081"`, recorder.Header().Get("ETag"))
+				require.Equal(t, "nosniff", recorder.Header().Get("X-Content-Type-Options"))
+				require.NotContains(t, recorder.Header().Values("Vary"), "Referer")
+			},
+		},
+		{
+			name: "NotModified",
+			setupRequest: func(request *http.Request) {
+				request.Header.Set("If-None-Match", `"9f86d081"`)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFile(gomock.Any(), file.ID).Times(1).Return(file, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), file.WorkspaceID).Times(1).Return(db.Workspace{ID: file.WorkspaceID}, nil)
+				store.EXPECT().GetWorkspaceSettings(gomock.Any(), file.WorkspaceID).Times(1).Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotModified, recorder.Code)
+				require.Empty(t, recorder.Body.Bytes())
+			},
+		},
+		{
+			name: "AllowedReferer",
+			setupRequest: func(request *http.Request) {
+				request.Header.Set("Referer", "https://blog.example.com/post")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFile(gomock.Any(), file.ID).Times(1).Return(file, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), file.WorkspaceID).Times(1).Return(db.Workspace{ID: file.WorkspaceID}, nil)
+				store.EXPECT().GetWorkspaceSettings(gomock.Any(), file.WorkspaceID).Times(1).Return(restricted, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Values("Vary"), "Referer")
+			},
+		},
+		{
+			name: "AppReferer",
+			setupRequest: func(request *http.Request) {
+				request.Header.Set("Referer", "http://localhost:3000/workspaces/2")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFile(gomock.Any(), file.ID).Times(1).Return(file, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), file.WorkspaceID).Times(1).Return(db.Workspace{ID: file.WorkspaceID}, nil)
+				store.EXPECT().GetWorkspaceSettings(gomock.Any(), file.WorkspaceID).Times(1).Return(restricted, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Hotlinked",
+			setupRequest: func(request *http.Request) {
+				request.Header.Set("Referer", "https://mirror.example.net/")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFile(gomock.Any(), file.ID).Times(1).Return(file, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), file.WorkspaceID).Times(1).Return(db.Workspace{ID: file.WorkspaceID}, nil)
+				store.EXPECT().GetWorkspaceSettings(gomock.Any(), file.WorkspaceID).Times(1).Return(restricted, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NumericID",
+			key:  "7",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFile(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotPublic",
+			buildStubs: func(store *mockdb.MockStore) {
+				private := file
+				private.IsPublic = false
+				store.EXPECT().GetFile(gomock.Any(), file.ID).Times(1).Return(private, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			config := util.Config{
+				TokenSymmetricKey:     util.RandomString(32),
+				AccessTokenDuration:   time.Minute,
+				AppBaseURL:            "http://localhost:3000",
+				PublicFileCacheMaxAge: time.Hour,
+			}
+			server, err := NewServer(config, store)
+			require.NoError(t, err)
+
+			// Public IDs are encoded with the key the server was created with
+			key := tc.key
+			if key == "" {
+				key = publicid.Encode(publicid.File, file.ID)
+			}
+
+			request, err := http.NewRequest(http.MethodGet, "/public/files/"+key, nil)
+			require.NoError(t, err)
+			if tc.setupRequest != nil {
+				tc.setupRequest(request)
+			}
+
+			recorder := httptest.NewRecorder()
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
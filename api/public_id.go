@@ -0,0 +1,79 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/publicid"
+)
+
+// publicIDParamKinds maps route parameters to the kind of ID they hold
+var publicIDParamKinds = map[string]publicid.Kind{
+	"workspace_id": publicid.Workspace,
+	"channel_id":   publicid.Channel,
+	"message_id":   publicid.Message,
+	"thread_id":    publicid.Message,
+	"user_id":      publicid.User,
+}
+
+// publicIDPathKinds maps the path segment in front of an :id parameter to the
+// kind of ID it holds, e.g. /channels/:id
+var publicIDPathKinds = map[string]publicid.Kind{
+	"workspace":  publicid.Workspace,
+	"workspaces": publicid.Workspace,
+	"channels":   publicid.Channel,
+	"messages":   publicid.Message,
+	"users":      publicid.User,
+}
+
+// publicIDMiddleware lets routes take public IDs such as ch_3NQ8T0XKZ6V1M in
+// place of numeric IDs. Public IDs in path parameters are replaced by the
+// numeric ID they stand for before the handler runs, so handlers keep parsing
+// numbers. A public ID of the wrong kind for its parameter is rejected.
+func publicIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		for i, param := range ctx.Params {
+			// Other parameters, such as emoji names, may contain underscores
+			if param.Key != "id" && !strings.HasSuffix(param.Key, "_id") {
+				continue
+			}
+			if !strings.Contains(param.Value, "_") {
+				continue
+			}
+
+			kind, id, err := publicid.Parse(param.Value)
+			if err != nil {
+				continue
+			}
+
+			if expected, ok := publicIDParamKind(ctx.FullPath(), param.Key); ok && expected != kind {
+				err := fmt.Errorf("invalid %s: expected a %s_ ID", param.Key, expected)
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(ctx, err))
+				return
+			}
+
+			ctx.Params[i].Value = strconv.FormatInt(id, 10)
+		}
+
+		ctx.Next()
+	}
+}
+
+// publicIDParamKind returns the kind of ID a route parameter holds, when known
+func publicIDParamKind(fullPath, key string) (publicid.Kind, bool) {
+	if kind, ok := publicIDParamKinds[key]; ok {
+		return kind, true
+	}
+
+	segments := strings.Split(fullPath, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] == ":"+key {
+			kind, ok := publicIDPathKinds[segments[i-1]]
+			return kind, ok
+		}
+	}
+	return "", false
+}
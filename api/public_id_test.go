@@ -0,0 +1,105 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/publicid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicIDRoutes(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+	channel := randomChannel(workspace.ID, user.ID)
+	channel.IsPrivate = false
+
+	testCases := []struct {
+		name          string
+		channelID     func() string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "PublicID",
+			channelID: func() string { return publicid.Encode(publicid.Channel, channel.ID) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).MinTimes(1).Return(channel, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return(user.Role, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response map[string]interface{}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, float64(channel.ID), response["id"])
+				require.Equal(t, publicid.Encode(publicid.Channel, channel.ID), response["public_id"])
+			},
+		},
+		{
+			name:      "NumericID",
+			channelID: func() string { return fmt.Sprint(channel.ID) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).MinTimes(1).Return(channel, nil)
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return(user.Role, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "WrongKind",
+			channelID: func() string { return publicid.Encode(publicid.Workspace, channel.ID) },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "NotAPublicID",
+			channelID: func() string { return "ch_123" },
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).AnyTimes().Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/channels/%s", tc.channelID())
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
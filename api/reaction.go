@@ -0,0 +1,253 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Add Reaction
+// @Description React to a message with a single Unicode emoji or a workspace custom emoji written as :name:, within the workspace's reaction limits. Rejected reactions return a code: invalid_emoji, unknown_custom_emoji, reaction_limit_reached or distinct_reaction_limit_reached. The conversation receives a reaction_added WebSocket message.
+// @ID addReaction
+// @Tags reactions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Param request body service.AddReactionRequest true "Emoji to react with"
+// @Success 201 {array} service.ReactionSummary "Updated reactions to the message"
+// @Failure 400 {object} service.ReactionValidationError "Invalid request or emoji"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 409 {object} map[string]string "Already reacted with this emoji"
+// @Failure 422 {object} service.ReactionValidationError "Reaction limit reached"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/reactions [post]
+func (server *Server) addReaction(ctx *gin.Context) {
+	var req service.AddReactionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	reactions, err := server.reactionService.AddReaction(ctx, messageID, currentUser.ID, req.Emoji)
+	if err != nil {
+		handleReactionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, reactions)
+}
+
+// @Summary Remove Reaction
+// @Description Remove your emoji reaction from a message. The conversation receives a reaction_removed WebSocket message.
+// @ID removeReaction
+// @Tags reactions
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Param emoji path string true "Emoji to remove"
+// @Success 200 {array} service.ReactionSummary "Updated reactions to the message"
+// @Failure 400 {object} map[string]string "Invalid message ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Message or reaction not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/reactions/{emoji} [delete]
+func (server *Server) removeReaction(ctx *gin.Context) {
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	reactions, err := server.reactionService.RemoveReaction(ctx, messageID, currentUser.ID, ctx.Param("emoji"))
+	if err != nil {
+		handleReactionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reactions)
+}
+
+// @Summary Get Reactions
+// @Description Get a message's reactions grouped by emoji, with the count, the first few users to react and whether you reacted
+// @ID getReactions
+// @Tags reactions
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Success 200 {array} service.ReactionSummary "Reactions to the message"
+// @Failure 400 {object} map[string]string "Invalid message ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/reactions [get]
+func (server *Server) getReactions(ctx *gin.Context) {
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	reactions, err := server.reactionService.GetReactions(ctx, messageID, currentUser.ID)
+	if err != nil {
+		handleReactionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reactions)
+}
+
+// @Summary List Reactors
+// @Description Page through the users who reacted to a message with an emoji, in the order they reacted
+// @ID listReactors
+// @Tags reactions
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Param emoji path string true "Emoji"
+// @Param limit query int false "Number of users to return (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of users to skip" minimum(0)
+// @Success 200 {array} service.ReactorResponse "Users who reacted"
+// @Failure 400 {object} map[string]string "Invalid message ID or parameters"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/reactions/{emoji}/users [get]
+func (server *Server) listReactors(ctx *gin.Context) {
+	var req service.ListReactorsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	reactors, err := server.reactionService.ListReactors(ctx, messageID, currentUser.ID, ctx.Param("emoji"), req)
+	if err != nil {
+		handleReactionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reactors)
+}
+
+// @Summary Get Reaction Settings
+// @Description Get the workspace's limits on reactions per user and different emojis per message (requires workspace admin)
+// @ID getReactionSettings
+// @Tags reactions
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.ReactionSettingsResponse "Reaction settings"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/settings/reactions [get]
+func (server *Server) getReactionSettings(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	settings, err := server.reactionService.GetReactionSettings(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update Reaction Settings
+// @Description Set the workspace's limits on reactions per user and different emojis per message. Omitted limits use the server default. (requires workspace admin)
+// @ID updateReactionSettings
+// @Tags reactions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param settings body service.UpdateReactionSettingsRequest true "Reaction limits"
+// @Success 200 {object} service.ReactionSettingsResponse "Reaction settings updated"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/settings/reactions [put]
+func (server *Server) updateReactionSettings(ctx *gin.Context) {
+	var req service.UpdateReactionSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	settings, err := server.reactionService.UpdateReactionSettings(ctx, workspaceID, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+func handleReactionError(ctx *gin.Context, err error) {
+	var validationErr *service.ReactionValidationError
+	if errors.As(err, &validationErr) {
+		status := http.StatusBadRequest
+		if validationErr.Limit > 0 {
+			status = http.StatusUnprocessableEntity
+		}
+		response := errorResponse(ctx, err)
+		response["code"] = validationErr.Code
+		if validationErr.Limit > 0 {
+			response["limit"] = validationErr.Limit
+		}
+		ctx.JSON(status, response)
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(err.Error(), "access denied"):
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "already exists"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
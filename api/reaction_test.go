@@ -0,0 +1,245 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddReactionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	channel := db.Channel{ID: 4, WorkspaceID: workspace.ID, Name: "general"}
+	message := db.GetMessageByIDRow{
+		ID:          20,
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    user.ID + 1,
+		MessageType: "channel",
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"emoji": "🎉"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetReactionCounts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetReactionCountsRow{}, nil)
+				store.EXPECT().
+					AddReaction(gomock.Any(), gomock.Eq(db.AddReactionParams{MessageID: message.ID, UserID: user.ID, Emoji: "🎉"})).
+					Times(1).
+					Return(db.MessageReaction{}, nil)
+				store.EXPECT().
+					ListReactionSummaries(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListReactionSummariesRow{
+						{MessageID: message.ID, Emoji: "🎉", Count: 2, Reacted: true, UserIds: []int64{user.ID + 1, user.ID}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response []service.ReactionSummary
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response, 1)
+				require.Equal(t, int64(2), response[0].Count)
+				require.True(t, response[0].Reacted)
+			},
+		},
+		{
+			name: "AlreadyReacted",
+			body: gin.H{"emoji": "🎉"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetReactionCounts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetReactionCountsRow{}, nil)
+				store.EXPECT().
+					AddReaction(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MessageReaction{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEmoji",
+			body: gin.H{"emoji": "tada"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AddReaction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var response map[string]interface{}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, service.ReactionErrorInvalidEmoji, response["code"])
+			},
+		},
+		{
+			name: "LimitReached",
+			body: gin.H{"emoji": "🎉"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetReactionCounts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetReactionCountsRow{DistinctEmojis: 50}, nil)
+				store.EXPECT().AddReaction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+
+				var response map[string]interface{}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, service.ReactionErrorDistinctLimit, response["code"])
+				require.Equal(t, float64(50), response["limit"])
+			},
+		},
+		{
+			name: "MissingEmoji",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AddReaction(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).AnyTimes().Return(message, nil)
+			store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return("member", nil)
+			store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).AnyTimes().Return(channel, nil)
+			store.EXPECT().GetWorkspaceSettings(gomock.Any(), gomock.Eq(workspace.ID)).AnyTimes().Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/messages/%d/reactions", message.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListReactorsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	message := db.GetMessageByIDRow{
+		ID:          20,
+		WorkspaceID: workspace.ID,
+		SenderID:    user.ID,
+		ReceiverID:  sql.NullInt64{Int64: user.ID + 1, Valid: true},
+		MessageType: "direct",
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?limit=2&offset=2",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().
+					ListReactors(gomock.Any(), gomock.Eq(db.ListReactorsParams{MessageID: message.ID, Emoji: "👍", Limit: 2, Offset: 2})).
+					Times(1).
+					Return([]db.ListReactorsRow{{UserID: user.ID + 1, FirstName: "Grace"}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response []service.ReactorResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response, 1)
+				require.Equal(t, "Grace", response[0].FirstName)
+			},
+		},
+		{
+			name:  "InvalidLimit",
+			query: "?limit=500",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(db.GetMessageByIDRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/messages/%d/reactions/%%F0%%9F%%91%%8D/users%s", message.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Mark Channel As Read
+// @Description Move the current user's read position in a channel up to a message
+// @ID markChannelAsRead
+// @Tags read-state
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param channel_id path int true "Channel ID"
+// @Param request body service.MarkChannelReadRequest true "Last read message"
+// @Success 200 {object} service.ChannelReadStateResponse "Updated read state"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Channel or message not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/channels/{channel_id}/read [post]
+func (server *Server) markChannelAsRead(ctx *gin.Context) {
+	var req service.MarkChannelReadRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	channelID, err := strconv.ParseInt(ctx.Param("channel_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	readState, err := server.readStateService.MarkChannelAsRead(ctx, workspaceID, channelID, currentUser.ID, req.MessageID)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, readState)
+}
+
+// @Summary Mark Direct Messages As Read
+// @Description Move the current user's read position in a direct message conversation up to a message
+// @ID markDirectMessagesAsRead
+// @Tags read-state
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param user_id path int true "Other user ID"
+// @Param request body service.MarkChannelReadRequest true "Last read message"
+// @Success 200 {object} service.DirectMessageReadStateResponse "Updated read state"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/messages/direct/{user_id}/read [post]
+func (server *Server) markDirectMessagesAsRead(ctx *gin.Context) {
+	var req service.MarkChannelReadRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	otherUserID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid user ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	readState, err := server.readStateService.MarkDirectMessagesAsRead(ctx, workspaceID, otherUserID, currentUser.ID, req.MessageID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, readState)
+}
+
+// @Summary Mark Thread As Read
+// @Description Move the current user's read position in a thread up to its root or one of its replies. Marking a thread as read also follows it, so its unread replies are counted.
+// @ID markThreadAsRead
+// @Tags read-state
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param message_id path int true "Thread root message ID"
+// @Param request body service.MarkChannelReadRequest true "Last read message"
+// @Success 200 {object} service.ThreadReadStateResponse "Updated read state"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied"
+// @Failure 404 {object} map[string]string "Thread or message not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/thread/read [post]
+func (server *Server) markThreadAsRead(ctx *gin.Context) {
+	var req service.MarkChannelReadRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	threadID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	readState, err := server.readStateService.MarkThreadAsRead(ctx, threadID, currentUser.ID, req.MessageID)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, readState)
+}
+
+// @Summary Unfollow Thread
+// @Description Stop counting a thread's unread replies for the current user. Marking the thread as read follows it again.
+// @ID unfollowThread
+// @Tags read-state
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Thread root message ID"
+// @Success 204 "Thread unfollowed"
+// @Failure 400 {object} map[string]string "Invalid message ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 404 {object} map[string]string "Thread not followed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /messages/{message_id}/thread/follow [delete]
+func (server *Server) unfollowThread(ctx *gin.Context) {
+	threadID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	if err := server.readStateService.UnfollowThread(ctx, threadID, currentUser.ID); err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// @Summary Get Unread Counts
+// @Description Get the current user's unread message counts per channel, direct message conversation and followed thread in a workspace. Only entries with unread messages are listed.
+// @ID getUnreadCounts
+// @Tags read-state
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.UnreadCountsResponse "Unread counts"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/unread [get]
+func (server *Server) getUnreadCounts(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	counts, err := server.readStateService.GetUnreadCounts(ctx, workspaceID, currentUser.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, counts)
+}
+
+// @Summary Mark Workspace As Read
+// @Description Mark every channel, direct message conversation and followed thread of the current user in a workspace as read in one step. The user's other connections receive a workspace_read event.
+// @ID markWorkspaceAsRead
+// @Tags read-state
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.WorkspaceReadResponse "Number of channels, conversations and threads marked as read"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/read-all [post]
+func (server *Server) markWorkspaceAsRead(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	result, err := server.readStateService.MarkWorkspaceAsRead(ctx, workspaceID, currentUser.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,299 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkWorkspaceAsReadAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	// Make user a member of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	testCases := []struct {
+		name          string
+		workspaceID   int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			workspaceID: workspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user.Role, nil)
+
+				arg := db.MarkWorkspaceReadTxParams{
+					UserID:      user.ID,
+					WorkspaceID: workspace.ID,
+				}
+				store.EXPECT().
+					MarkWorkspaceReadTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.MarkWorkspaceReadTxResult{ChannelsMarked: 3, ConversationsMarked: 2}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.WorkspaceReadResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, workspace.ID, response.WorkspaceID)
+				require.Equal(t, int64(3), response.ChannelsMarked)
+				require.Equal(t, int64(2), response.ConversationsMarked)
+			},
+		},
+		{
+			name:        "NotWorkspaceMember",
+			workspaceID: workspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return("", sql.ErrNoRows)
+
+				store.EXPECT().
+					MarkWorkspaceReadTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:        "InternalError",
+			workspaceID: workspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user.Role, nil)
+
+				store.EXPECT().
+					MarkWorkspaceReadTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MarkWorkspaceReadTxResult{}, errors.New("tx failed"))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/read-all", tc.workspaceID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestMarkThreadAsReadAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	workspaceID := util.RandomInt(1, 1000)
+
+	root := db.GetMessageByIDRow{
+		ID:          util.RandomInt(1, 1000),
+		WorkspaceID: workspaceID,
+		SenderID:    otherUser.ID,
+		ReceiverID:  sql.NullInt64{Int64: user.ID, Valid: true},
+		MessageType: "direct",
+	}
+	reply := root
+	reply.ID = root.ID + 1
+	reply.ThreadID = sql.NullInt64{Int64: root.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		root          db.GetMessageByIDRow
+		reply         db.GetMessageByIDRow
+		buildStubs    func(store *mockdb.MockStore, root, reply db.GetMessageByIDRow)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			root:  root,
+			reply: reply,
+			buildStubs: func(store *mockdb.MockStore, root, reply db.GetMessageByIDRow) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(root.ID)).Times(1).Return(root, nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(reply.ID)).Times(1).Return(reply, nil)
+
+				arg := db.MarkThreadReadParams{
+					UserID:            user.ID,
+					ThreadID:          root.ID,
+					LastReadMessageID: sql.NullInt64{Int64: reply.ID, Valid: true},
+				}
+				store.EXPECT().
+					MarkThreadRead(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ThreadReadState{UserID: user.ID, ThreadID: root.ID, LastReadMessageID: arg.LastReadMessageID, LastReadAt: time.Now()}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.ThreadReadStateResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, root.ID, response.ThreadID)
+				require.Equal(t, reply.ID, *response.LastReadMessageID)
+			},
+		},
+		{
+			name: "ReplyInAnotherThread",
+			root: root,
+			reply: func() db.GetMessageByIDRow {
+				other := reply
+				other.ThreadID = sql.NullInt64{Int64: root.ID + 100, Valid: true}
+				return other
+			}(),
+			buildStubs: func(store *mockdb.MockStore, root, reply db.GetMessageByIDRow) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(root.ID)).Times(1).Return(root, nil)
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(reply.ID)).Times(1).Return(reply, nil)
+				store.EXPECT().MarkThreadRead(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotInConversation",
+			root: func() db.GetMessageByIDRow {
+				other := root
+				other.ReceiverID = sql.NullInt64{Int64: otherUser.ID + 1, Valid: true}
+				return other
+			}(),
+			reply: reply,
+			buildStubs: func(store *mockdb.MockStore, root, reply db.GetMessageByIDRow) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(root.ID)).Times(1).Return(root, nil)
+				store.EXPECT().MarkThreadRead(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).AnyTimes().Return(user, nil)
+			tc.buildStubs(store, tc.root, tc.reply)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"message_id": tc.reply.ID})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/messages/%d/thread/read", tc.root.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetUnreadCountsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return(user.Role, nil)
+	store.EXPECT().
+		ListChannelUnreadCounts(gomock.Any(), gomock.Eq(db.ListChannelUnreadCountsParams{UserID: user.ID, WorkspaceID: workspace.ID})).
+		Times(1).
+		Return([]db.ListChannelUnreadCountsRow{{ChannelID: 4, UnreadCount: 3}}, nil)
+	store.EXPECT().
+		ListDirectMessageUnreadCounts(gomock.Any(), gomock.Eq(db.ListDirectMessageUnreadCountsParams{WorkspaceID: workspace.ID, UserID: user.ID})).
+		Times(1).
+		Return([]db.ListDirectMessageUnreadCountsRow{{OtherUserID: 9, LastReadMessageID: sql.NullInt64{Int64: 12, Valid: true}, UnreadCount: 2}}, nil)
+	store.EXPECT().
+		ListThreadUnreadCounts(gomock.Any(), gomock.Eq(db.ListThreadUnreadCountsParams{UserID: user.ID, WorkspaceID: workspace.ID})).
+		Times(1).
+		Return([]db.ListThreadUnreadCountsRow{{ThreadID: 30, UnreadCount: 1}}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/workspaces/%d/unread", workspace.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response service.UnreadCountsResponse
+	err = json.Unmarshal(recorder.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), response.TotalUnread)
+	require.Equal(t, []service.ChannelUnreadCount{{ChannelID: 4, UnreadCount: 3}}, response.Channels)
+	require.Equal(t, int64(12), *response.DirectMessages[0].LastReadMessageID)
+	require.Equal(t, int64(30), response.Threads[0].ThreadID)
+}
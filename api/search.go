@@ -0,0 +1,366 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Search Messages
+// @Description Search messages in a workspace. Free text can be combined with filters: from:me|email|id, in:#channel, before:YYYY-MM-DD, after:YYYY-MM-DD, on:YYYY-MM-DD, has:file, has:link, is:thread
+// @ID searchMessages
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param q query string true "Search query with optional filters"
+// @Param limit query int false "Number of results to retrieve (default: 20, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of results to skip (default: 0)" minimum(0)
+// @Success 200 {object} object{messages=[]service.MessageResponse,query=service.SearchQuery} "Matching messages and the parsed query"
+// @Failure 400 {object} map[string]string "Invalid query or filter"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/messages/search [get]
+func (server *Server) searchMessages(ctx *gin.Context) {
+	var req service.SearchMessagesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	// Set default values if not provided
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	// Get workspace ID from URL
+	workspaceIDStr := ctx.Param("id")
+	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	// Get current user
+	currentUser := getCurrentUser(ctx)
+
+	messages, query, err := server.searchService.SearchMessages(ctx, workspaceID, currentUser.ID, req.Query, req.Limit, req.Offset)
+	if err != nil {
+		// Bad queries and unknown from:/in: targets are client errors
+		var queryErr *service.SearchQueryError
+		var targetErr *service.SearchTargetError
+		if errors.As(err, &queryErr) || errors.As(err, &targetErr) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"messages": messages, "query": query})
+}
+
+// savedSearchLimitRequest holds the pagination parameters for running a saved search
+type savedSearchLimitRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// @Summary Create Saved Search
+// @Description Save a named search query for the current user in a workspace
+// @ID createSavedSearch
+// @Tags search
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.SavedSearchRequest true "Saved search name and query"
+// @Success 201 {object} service.SavedSearchResponse "Saved search created"
+// @Failure 400 {object} map[string]string "Invalid request or query"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 409 {object} map[string]string "Saved search name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/saved-searches [post]
+func (server *Server) createSavedSearch(ctx *gin.Context) {
+	var req service.SavedSearchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	savedSearch, err := server.searchService.CreateSavedSearch(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		var queryErr *service.SearchQueryError
+		if errors.As(err, &queryErr) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		if db.ErrorCode(err) == db.UniqueViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, errors.New("saved search name already exists")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, savedSearch)
+}
+
+// @Summary List Saved Searches
+// @Description List the current user's saved searches in a workspace
+// @ID listSavedSearches
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.SavedSearchResponse "Saved searches"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/saved-searches [get]
+func (server *Server) listSavedSearches(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	savedSearches, err := server.searchService.ListSavedSearches(ctx, workspaceID, currentUser.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, savedSearches)
+}
+
+// @Summary Update Saved Search
+// @Description Rename a saved search or change its query
+// @ID updateSavedSearch
+// @Tags search
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param search_id path int true "Saved search ID"
+// @Param request body service.SavedSearchRequest true "Saved search name and query"
+// @Success 200 {object} service.SavedSearchResponse "Saved search updated"
+// @Failure 400 {object} map[string]string "Invalid request or query"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Saved search not found"
+// @Failure 409 {object} map[string]string "Saved search name already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/saved-searches/{search_id} [put]
+func (server *Server) updateSavedSearch(ctx *gin.Context) {
+	var req service.SavedSearchRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, searchID, ok := parseSavedSearchParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	savedSearch, err := server.searchService.UpdateSavedSearch(ctx, workspaceID, currentUser.ID, searchID, req)
+	if err != nil {
+		var queryErr *service.SearchQueryError
+		if errors.As(err, &queryErr) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		if errors.Is(err, service.ErrSavedSearchNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		if db.ErrorCode(err) == db.UniqueViolation {
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, errors.New("saved search name already exists")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, savedSearch)
+}
+
+// @Summary Delete Saved Search
+// @Description Delete one of the current user's saved searches
+// @ID deleteSavedSearch
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param search_id path int true "Saved search ID"
+// @Success 200 {object} map[string]string "Saved search deleted"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Saved search not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/saved-searches/{search_id} [delete]
+func (server *Server) deleteSavedSearch(ctx *gin.Context) {
+	workspaceID, searchID, ok := parseSavedSearchParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	err := server.searchService.DeleteSavedSearch(ctx, workspaceID, currentUser.ID, searchID)
+	if err != nil {
+		if errors.Is(err, service.ErrSavedSearchNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "saved search deleted successfully"})
+}
+
+// @Summary Run Saved Search
+// @Description Execute a saved search and return the matching messages
+// @ID runSavedSearch
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param search_id path int true "Saved search ID"
+// @Param limit query int false "Number of results to retrieve (default: 20, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of results to skip (default: 0)" minimum(0)
+// @Success 200 {object} object{saved_search=service.SavedSearchResponse,messages=[]service.MessageResponse} "Saved search and matching messages"
+// @Failure 400 {object} map[string]string "Invalid ID or filter"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 404 {object} map[string]string "Saved search not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspace/{id}/saved-searches/{search_id}/results [get]
+func (server *Server) runSavedSearch(ctx *gin.Context) {
+	var req savedSearchLimitRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	// Set default values if not provided
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	workspaceID, searchID, ok := parseSavedSearchParams(ctx)
+	if !ok {
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	savedSearch, messages, err := server.searchService.RunSavedSearch(ctx, workspaceID, currentUser.ID, searchID, req.Limit, req.Offset)
+	if err != nil {
+		if errors.Is(err, service.ErrSavedSearchNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		// Channels or senders referenced by the query may have gone away
+		var targetErr *service.SearchTargetError
+		if errors.As(err, &targetErr) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"saved_search": savedSearch, "messages": messages})
+}
+
+// parseSavedSearchParams reads the workspace and saved search IDs from the URL,
+// writing a 400 response when either is invalid
+func parseSavedSearchParams(ctx *gin.Context) (int64, int64, bool) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return 0, 0, false
+	}
+
+	searchID, err := strconv.ParseInt(ctx.Param("search_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid saved search ID")))
+		return 0, 0, false
+	}
+
+	return workspaceID, searchID, true
+}
+
+// @Summary Search Workspace
+// @Description Search messages, files, channels and people in a workspace with a single query. Message filters (from:, in:, before:, after:, on:, has:, is:) only narrow the messages group. People match on name or handle; emails are only matched and shown to members who can manage members.
+// @ID searchWorkspace
+// @Tags search
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param q query string true "Search query with optional message filters"
+// @Param limit query int false "Number of results per group (default: 5, max: 50)" minimum(1) maximum(50)
+// @Success 200 {object} service.UnifiedSearchResponse "Grouped search results with per-group counts"
+// @Failure 400 {object} map[string]string "Invalid query or filter"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/search [get]
+func (server *Server) searchWorkspace(ctx *gin.Context) {
+	var req service.UnifiedSearchRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	// Set default values if not provided
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	results, err := server.searchService.Search(ctx, workspaceID, currentUser.ID, req.Query, req.Limit)
+	if err != nil {
+		// Bad queries and unknown from:/in: targets are client errors
+		var queryErr *service.SearchQueryError
+		var targetErr *service.SearchTargetError
+		if errors.As(err, &queryErr) || errors.As(err, &targetErr) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results)
+}
@@ -1,12 +1,18 @@
 package api
 
 import (
+	"context"
+	"expvar"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/docs"
+	"github.com/heyrmi/goslack/i18n"
+	"github.com/heyrmi/goslack/publicid"
 	"github.com/heyrmi/goslack/service"
 	"github.com/heyrmi/goslack/token"
 	"github.com/heyrmi/goslack/util"
@@ -16,85 +22,402 @@ import (
 
 // Server serves HTTP requests for our GoSlack service.
 type Server struct {
-	config                     util.Config
-	store                      db.Store
-	tokenMaker                 token.Maker
-	router                     *gin.Engine
-	userService                *service.UserService
-	organizationService        *service.OrganizationService
-	workspaceService           *service.WorkspaceService
-	workspaceInvitationService *service.WorkspaceInvitationService
-	channelService             *service.ChannelService
-	messageService             *service.MessageService
-	statusService              *service.StatusService
-	fileService                *service.FileService
-	hub                        *Hub // WebSocket hub
+	config                        util.Config
+	tunables                      *util.TunablesStore // Settings reloaded on SIGHUP
+	maintenanceService            *service.MaintenanceService
+	store                         db.Store
+	tokenMaker                    token.Maker
+	router                        *gin.Engine
+	userService                   *service.UserService
+	organizationService           *service.OrganizationService
+	workspaceService              *service.WorkspaceService
+	workspaceInvitationService    *service.WorkspaceInvitationService
+	workspaceAutoJoinService      *service.WorkspaceAutoJoinService
+	emailService                  *service.EmailService
+	emailTemplateService          *service.EmailTemplateService
+	emailVerificationService      *service.EmailVerificationService
+	channelService                *service.ChannelService
+	messageService                *service.MessageService
+	statusService                 *service.StatusService
+	fileService                   *service.FileService
+	videoProcessingService        *service.VideoProcessingService
+	documentPreviewService        *service.DocumentPreviewService
+	searchService                 *service.SearchService
+	readStateService              *service.ReadStateService
+	calendarService               *service.CalendarService
+	outOfOfficeService            *service.OutOfOfficeService
+	draftService                  *service.DraftService
+	doNotDisturbService           *service.DoNotDisturbService
+	notificationPreferenceService *service.NotificationPreferenceService
+	featureFlagService            *service.FeatureFlagService
+	translationService            *service.TranslationService
+	moderationService             *service.ModerationService
+	abuseReportService            *service.AbuseReportService
+	legalHoldService              *service.LegalHoldService
+	workspaceRoleService          *service.WorkspaceRoleService
+	organizationRoleService       *service.OrganizationRoleService
+	directoryService              *service.DirectoryService
+	onboardingService             *service.OnboardingService
+	deletionService               *service.DeletionService
+	workspaceTeardownService      *service.WorkspaceTeardownService
+	organizationDeletionService   *service.OrganizationDeletionService
+	cleanupService                *service.CleanupService
+	fileReconciliationService     *service.FileReconciliationService
+	partitionService              *service.PartitionService
+	archiveService                *service.ArchiveService
+	channelStatsService           *service.ChannelStatsService
+	callService                   *service.CallService
+	canvasService                 *service.CanvasService
+	reactionService               *service.ReactionService
+	pinService                    *service.PinService
+	emojiService                  *service.EmojiService
+	bannerService                 *service.BannerService
+	externalDMService             *service.ExternalDMService
+	deviceService                 *service.DeviceService
+	challengeVerifier             service.ChallengeVerifier
+	challengeEndpoints            map[string]bool // Endpoints that require a solved challenge
+	outboxRelay                   *service.OutboxRelay
+	workspaceEventService         *service.WorkspaceEventService
+	webhookService                *service.WebhookService
+	hub                           *Hub // WebSocket hub
+	translator                    *i18n.Translator
 }
 
 // NewServer creates a new HTTP server and set up routing.
 func NewServer(config util.Config, store db.Store) (*Server, error) {
-	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+	tokenMaker, err := newTokenMaker(config)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
 	}
 
+	// Public IDs fall back to the token key, so they stay stable as long as it does
+	publicIDKey := config.PublicIDKey
+	if publicIDKey == "" {
+		publicIDKey = config.TokenSymmetricKey
+	}
+	publicid.SetKey(publicIDKey)
+
+	translator, err := newTranslator(config.I18NCatalogPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load message catalogs: %w", err)
+	}
+
 	// Create WebSocket hub
 	hub := NewHub(config)
 
 	userService := service.NewUserService(store, tokenMaker, config)
 	organizationService := service.NewOrganizationService(store)
 	workspaceService := service.NewWorkspaceService(store, userService)
-	workspaceInvitationService := service.NewWorkspaceInvitationService(store)
-	channelService := service.NewChannelService(store, userService, workspaceService)
-	messageService := service.NewMessageService(store, userService, hub) // Pass hub to message service
-	statusService := service.NewStatusService(store, hub)                // Pass hub to status service
-	fileService := service.NewFileService(store, config)                 // Add file service
+	emailService, err := service.NewEmailService(store, config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create email service: %w", err)
+	}
+	emailTemplateService := service.NewEmailTemplateService(store, emailService)
+	workspaceInvitationService := service.NewWorkspaceInvitationService(store, emailService, config)
+	workspaceAutoJoinService := service.NewWorkspaceAutoJoinService(store)
+	emailVerificationService := service.NewEmailVerificationService(store, emailService, config)
+	channelService := service.NewChannelService(store, userService, workspaceService, hub)
+	messageService := service.NewMessageService(store, userService, hub, config) // Pass hub to message service
+	statusService := service.NewStatusService(store, hub, config)                // Pass hub to status service
+
+	videoTranscoder, err := service.NewVideoTranscoder(config)
+	if err != nil {
+		return nil, err
+	}
+	videoProcessingService := service.NewVideoProcessingService(store, hub, videoTranscoder, config)
+	fileService := service.NewFileService(store, config, videoProcessingService)
+	fileService.SetHub(hub)
+
+	documentConverter, err := service.NewDocumentConverter(config)
+	if err != nil {
+		return nil, err
+	}
+	documentPreviewService := service.NewDocumentPreviewService(store, hub, documentConverter, config)
+	fileService.SetDocumentPreviews(documentPreviewService)
+
+	searchService := service.NewSearchService(store, userService)
+	readStateService := service.NewReadStateService(store, userService, messageService, hub)
+	calendarService := service.NewCalendarService(store, statusService)
+	outOfOfficeService := service.NewOutOfOfficeService(store)
+	draftService := service.NewDraftService(store, userService, hub)
+	doNotDisturbService := service.NewDoNotDisturbService(store, statusService)
+	notificationPreferenceService := service.NewNotificationPreferenceService(store)
+	featureFlagService := service.NewFeatureFlagService(store, config)
+
+	translationProvider, err := service.NewTranslationProvider(config)
+	if err != nil {
+		return nil, err
+	}
+	translationService := service.NewTranslationService(store, messageService, featureFlagService, translationProvider)
+	moderationService := service.NewModerationService(store, messageService)
+	abuseReportService := service.NewAbuseReportService(store, userService, messageService, hub)
+	legalHoldService := service.NewLegalHoldService(store)
+	workspaceRoleService := service.NewWorkspaceRoleService(store, userService)
+	organizationRoleService := service.NewOrganizationRoleService(store)
+	directoryService := service.NewDirectoryService(store, organizationRoleService)
+	onboardingService := service.NewOnboardingService(store, messageService)
+	deletionService := service.NewDeletionService(store, config)
+	workspaceTeardownService := service.NewWorkspaceTeardownService(store, hub, config)
+	organizationDeletionService := service.NewOrganizationDeletionService(store, emailService, fileService, hub, config)
+	cleanupService := service.NewCleanupService(store, config)
+	fileReconciliationService := service.NewFileReconciliationService(store, fileService, config)
+	partitionService := service.NewPartitionService(store, config)
+	archiveStore, err := service.NewArchiveStore(config)
+	if err != nil {
+		return nil, err
+	}
+	archiveService := service.NewArchiveService(store, archiveStore, config)
+	channelStatsService := service.NewChannelStatsService(store, config)
+	callService := service.NewCallService(store, userService, hub, config)
+	canvasService := service.NewCanvasService(store, hub)
+	reactionService := service.NewReactionService(store, messageService, hub, config)
+	pinService := service.NewPinService(store, messageService, hub)
+	emojiService := service.NewEmojiService(store)
+	bannerService := service.NewBannerService(store, hub)
+	externalDMService := service.NewExternalDMService(store, messageService, hub)
+	deviceService := service.NewDeviceService(store)
+	challengeVerifier, err := service.NewChallengeVerifier(config)
+	if err != nil {
+		return nil, err
+	}
+	challengeEndpoints, err := parseChallengeEndpoints(config.ChallengeEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	outboxRelay := service.NewOutboxRelay(store, hub, config)
+	eventPublisher, err := service.NewEventPublisher(config)
+	if err != nil {
+		return nil, err
+	}
+	workspaceEventService := service.NewWorkspaceEventService(store, eventPublisher, config)
+	webhookService := service.NewWebhookService(store, config)
+
+	maintenanceService := service.NewMaintenanceService(hub, config)
+
+	// Limits the hub and file service read can be reloaded without a restart
+	tunables := util.NewTunablesStore(config)
+	hub.SetTunables(tunables)
+	fileService.SetTunables(tunables)
 
 	server := &Server{
-		config:                     config,
-		store:                      store,
-		tokenMaker:                 tokenMaker,
-		userService:                userService,
-		organizationService:        organizationService,
-		workspaceService:           workspaceService,
-		workspaceInvitationService: workspaceInvitationService,
-		channelService:             channelService,
-		messageService:             messageService,
-		statusService:              statusService,
-		fileService:                fileService,
-		hub:                        hub,
-	}
-
-	server.setupRouter()
+		config:                        config,
+		tunables:                      tunables,
+		maintenanceService:            maintenanceService,
+		store:                         store,
+		tokenMaker:                    tokenMaker,
+		userService:                   userService,
+		organizationService:           organizationService,
+		workspaceService:              workspaceService,
+		workspaceInvitationService:    workspaceInvitationService,
+		workspaceAutoJoinService:      workspaceAutoJoinService,
+		emailService:                  emailService,
+		emailTemplateService:          emailTemplateService,
+		emailVerificationService:      emailVerificationService,
+		channelService:                channelService,
+		messageService:                messageService,
+		statusService:                 statusService,
+		fileService:                   fileService,
+		videoProcessingService:        videoProcessingService,
+		documentPreviewService:        documentPreviewService,
+		searchService:                 searchService,
+		readStateService:              readStateService,
+		calendarService:               calendarService,
+		outOfOfficeService:            outOfOfficeService,
+		draftService:                  draftService,
+		doNotDisturbService:           doNotDisturbService,
+		notificationPreferenceService: notificationPreferenceService,
+		featureFlagService:            featureFlagService,
+		translationService:            translationService,
+		moderationService:             moderationService,
+		abuseReportService:            abuseReportService,
+		legalHoldService:              legalHoldService,
+		workspaceRoleService:          workspaceRoleService,
+		organizationRoleService:       organizationRoleService,
+		directoryService:              directoryService,
+		onboardingService:             onboardingService,
+		deletionService:               deletionService,
+		workspaceTeardownService:      workspaceTeardownService,
+		organizationDeletionService:   organizationDeletionService,
+		cleanupService:                cleanupService,
+		fileReconciliationService:     fileReconciliationService,
+		partitionService:              partitionService,
+		archiveService:                archiveService,
+		channelStatsService:           channelStatsService,
+		callService:                   callService,
+		canvasService:                 canvasService,
+		reactionService:               reactionService,
+		pinService:                    pinService,
+		emojiService:                  emojiService,
+		bannerService:                 bannerService,
+		externalDMService:             externalDMService,
+		deviceService:                 deviceService,
+		challengeVerifier:             challengeVerifier,
+		challengeEndpoints:            challengeEndpoints,
+		outboxRelay:                   outboxRelay,
+		workspaceEventService:         workspaceEventService,
+		webhookService:                webhookService,
+		hub:                           hub,
+		translator:                    translator,
+	}
+
+	// Let WebSocket connections drive presence
+	hub.SetPresenceHandler(statusService)
+
+	// Relay huddle signaling between WebSocket clients
+	hub.SetCallHandler(callService)
+
+	// Save drafts sent over WebSocket and sync them between devices
+	hub.SetDraftHandler(draftService)
+
+	// Screen new and edited messages against workspace moderation lists
+	messageService.SetModerator(moderationService)
+
+	// Continue history and legal hold exports into archived messages
+	messageService.SetArchive(archiveService)
+	legalHoldService.SetArchive(archiveService)
+
+	if err := validateTLSConfig(config); err != nil {
+		return nil, err
+	}
+
+	if err := server.setupRouter(); err != nil {
+		return nil, err
+	}
 	return server, nil
 }
 
-func (server *Server) setupRouter() {
-	router := gin.Default()
+// defaultCORSAllowedHeaders are the request headers allowed when none are configured
+var defaultCORSAllowedHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With", "If-None-Match", "Accept-Language", challengeResponseHeader}
 
-	// Configure CORS middleware
-	config := cors.Config{
-		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:5173", "http://localhost:8080"},
+// newCORSConfig builds the CORS configuration from the allowed origins in the
+// config. It returns nil when no origins are configured, so cross-origin
+// requests are not allowed. A "*" origin allows every origin without credentials.
+func newCORSConfig(config util.Config) (*cors.Config, error) {
+	origins := splitConfigList(config.CORSAllowedOrigins)
+	if len(origins) == 0 {
+		return nil, nil
+	}
+
+	headers := splitConfigList(config.CORSAllowedHeaders)
+	if len(headers) == 0 {
+		headers = defaultCORSAllowedHeaders
+	}
+
+	corsConfig := cors.Config{
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     headers,
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Content-Language"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}
-	router.Use(cors.New(config))
+
+	for _, origin := range origins {
+		if origin == "*" {
+			// Browsers reject credentials for a wildcard origin
+			corsConfig.AllowAllOrigins = true
+			corsConfig.AllowCredentials = false
+			break
+		}
+		if strings.Contains(origin, "*") {
+			corsConfig.AllowWildcard = true
+		}
+	}
+	if !corsConfig.AllowAllOrigins {
+		corsConfig.AllowOrigins = origins
+	}
+
+	if err := corsConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid CORS configuration: %w", err)
+	}
+
+	return &corsConfig, nil
+}
+
+// splitConfigList splits a comma-separated config value, dropping empty entries
+func splitConfigList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (server *Server) setupRouter() error {
+	router := gin.Default()
+
+	// Only trust X-Forwarded-For from the configured proxies, none by default
+	if err := router.SetTrustedProxies(splitConfigList(server.config.TrustedProxies)); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	// Tell browsers to keep using HTTPS once the server terminates TLS itself
+	if hstsEnabled(server.config) {
+		router.Use(hstsMiddleware(server.config.HSTSMaxAge))
+	}
+
+	// Answer in the client's language
+	router.Use(localeMiddleware(server.translator))
+
+	// Accept public IDs wherever routes take a numeric ID
+	router.Use(publicIDMiddleware())
+
+	// Reject oversized bodies and report binding failures field by field
+	registerValidation()
+	router.Use(requestBodyLimit(server.tunables))
+
+	// Configure CORS middleware
+	corsConfig, err := newCORSConfig(server.config)
+	if err != nil {
+		return err
+	}
+	if corsConfig != nil {
+		router.Use(cors.New(*corsConfig))
+	}
+
+	// Reject writes while the server is in maintenance
+	router.Use(maintenanceMode(server.maintenanceService, splitConfigList(server.config.MaintenanceAllowedRoutes)))
 
 	// Swagger documentation endpoint
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// OpenAPI 3 document the generated clients are built from
+	router.GET("/openapi.json", server.getOpenAPIDocument)
+
 	// API info endpoint
 	router.GET("/api/info", server.getAPIInfo)
 
+	// Operator routes (require ADMIN_API_TOKEN)
+	// Process metrics, including WebSocket batch sizes and the database pool
+	router.GET("/debug/vars", requireAdminToken(server.config.AdminAPIToken), gin.WrapH(expvar.Handler()))
+	router.GET("/admin/config", requireAdminToken(server.config.AdminAPIToken), server.getEffectiveConfig)
+	router.GET("/admin/maintenance", requireAdminToken(server.config.AdminAPIToken), server.getMaintenance)
+	router.PUT("/admin/maintenance", requireAdminToken(server.config.AdminAPIToken), server.updateMaintenance)
+
 	// Public routes (no authentication required)
-	router.POST("/organizations", server.createOrganization)
+	router.GET("/challenge", server.getChallenge)
+	router.POST("/organizations", requireChallenge(server.challengeVerifier, server.challengeEndpoints[challengeEndpointCreateOrganization]), server.createOrganization)
 	router.GET("/organizations/:id", server.getOrganization)
 	router.GET("/organizations", server.listOrganizations)
-	router.POST("/users", server.createUser)
-	router.POST("/users/login", server.loginUser)
+	router.POST("/users", requireChallenge(server.challengeVerifier, server.challengeEndpoints[challengeEndpointRegister]), server.createUser)
+	router.POST("/users/login", requireChallenge(server.challengeVerifier, server.challengeEndpoints[challengeEndpointLogin]), server.loginUser)
+	router.POST("/users/verify-email", server.verifyEmail)
+	router.POST("/webhooks/email/sendgrid", server.handleSendGridEmailEvents)
+	router.POST("/webhooks/email/ses", server.handleSESEmailNotification)
+	router.GET("/avatars/:key/:size", server.getAvatar)
+	router.GET("/public/files/:key", server.getPublicFile)
+	router.GET("/.well-known/jwks.json", server.getJWKS)
+
+	// Slack Web API compatibility methods, authenticated and answered the
+	// way Slack does
+	for _, method := range server.slackMethods() {
+		handlers := []gin.HandlerFunc{slackAuthMiddleware(server.tokenMaker, server.userService, method.scope), method.handler}
+		router.POST("/api/"+method.name, handlers...)
+		if !strings.HasSuffix(method.scope, ":write") {
+			router.GET("/api/"+method.name, handlers...)
+		}
+	}
 
 	// Protected routes (authentication required)
 	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
@@ -103,11 +426,23 @@ func (server *Server) setupRouter() {
 	authRoutes.PUT("/users/:id/password", server.changePassword)
 	authRoutes.GET("/users", server.listUsers)
 	authRoutes.PUT("/organizations/:id", server.updateOrganization)
-	authRoutes.DELETE("/organizations/:id", server.deleteOrganization)
 
 	// Protected routes with user context
 	authWithUserRoutes := router.Group("/").Use(authWithUserMiddleware(server.tokenMaker, server.userService))
 
+	// Email verification (any authenticated user)
+	authWithUserRoutes.POST("/users/verify-email/resend", server.resendVerificationEmail)
+
+	// Avatar routes (current user)
+	authWithUserRoutes.POST("/users/me/avatar", server.uploadAvatar)
+	authWithUserRoutes.DELETE("/users/me/avatar", server.deleteAvatar)
+
+	// Login audit and trusted devices
+	authWithUserRoutes.GET("/users/me/devices", server.listDevices)
+	authWithUserRoutes.PUT("/users/me/devices/:device_id", server.updateDevice)
+	authWithUserRoutes.DELETE("/users/me/devices/:device_id", server.revokeDevice)
+	authWithUserRoutes.GET("/users/me/login-events", server.listLoginEvents)
+
 	// WebSocket endpoint
 	authWithUserRoutes.GET("/ws", server.handleWebSocket)
 
@@ -116,28 +451,63 @@ func (server *Server) setupRouter() {
 	authWithUserRoutes.GET("/workspaces", server.listWorkspaces)
 	authWithUserRoutes.GET("/workspaces/:id", server.getWorkspace)
 
-	// Workspace admin routes (require admin of the workspace)
-	authWithUserRoutes.PUT("/workspaces/:id", requireWorkspaceAdmin(server.userService), server.updateWorkspace)
+	// Workspace settings routes (deleting a workspace requires an admin)
+	authWithUserRoutes.PUT("/workspaces/:id", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.updateWorkspace)
 	authWithUserRoutes.DELETE("/workspaces/:id", requireWorkspaceAdmin(server.userService), server.deleteWorkspace)
 
-	// Workspace invitation routes (require workspace admin)
-	authWithUserRoutes.POST("/workspaces/:id/invitations", requireWorkspaceAdmin(server.userService), server.inviteUserToWorkspace)
-	authWithUserRoutes.GET("/workspaces/:id/invitations", requireWorkspaceAdmin(server.userService), server.listWorkspaceInvitations)
+	// Workspace invitation routes (require invite_members permission)
+	authWithUserRoutes.POST("/workspaces/:id/invitations", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.inviteUserToWorkspace)
+	authWithUserRoutes.GET("/workspaces/:id/invitations", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.listWorkspaceInvitations)
+	authWithUserRoutes.POST("/workspaces/:id/invitations/bulk", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.bulkInviteUsersToWorkspace)
+	authWithUserRoutes.POST("/workspaces/:id/invitations/:invitation_id/resend", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.resendWorkspaceInvitation)
+
+	// Workspace join link routes (require invite_members permission)
+	authWithUserRoutes.POST("/workspaces/:id/join-links", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.createWorkspaceJoinLink)
+	authWithUserRoutes.GET("/workspaces/:id/join-links", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.listWorkspaceJoinLinks)
+	authWithUserRoutes.DELETE("/workspaces/:id/join-links/:link_id", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.revokeWorkspaceJoinLink)
 
 	// Join workspace route (any authenticated user)
 	authWithUserRoutes.POST("/workspaces/join", server.joinWorkspace)
+	authWithUserRoutes.POST("/workspaces/join-link/:token", server.joinWorkspaceByLink)
+
+	// Email-domain auto-join routes (any authenticated user with a verified email)
+	authWithUserRoutes.GET("/workspaces/discoverable", server.listDiscoverableWorkspaces)
+	authWithUserRoutes.POST("/workspaces/:id/auto-join", server.autoJoinWorkspace)
+
+	// Email-domain auto-join admin routes (require invite_members permission)
+	authWithUserRoutes.POST("/workspaces/:id/auto-join-domains", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.addAutoJoinDomain)
+	authWithUserRoutes.GET("/workspaces/:id/auto-join-domains", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.listAutoJoinDomains)
+	authWithUserRoutes.DELETE("/workspaces/:id/auto-join-domains/:domain_id", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.removeAutoJoinDomain)
+	authWithUserRoutes.GET("/workspaces/:id/join-requests", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.listJoinRequests)
+	authWithUserRoutes.POST("/workspaces/:id/join-requests/:request_id/review", requireWorkspacePermission(server.userService, service.PermissionInviteMembers), server.reviewJoinRequest)
 
 	// Workspace member management routes
 	authWithUserRoutes.GET("/workspaces/:id/members", requireWorkspaceMember(server.userService), server.listWorkspaceMembers)
-	authWithUserRoutes.DELETE("/workspaces/:id/members/:user_id", requireWorkspaceAdmin(server.userService), server.removeUserFromWorkspace)
-	authWithUserRoutes.PUT("/workspaces/:id/members/:user_id/role", requireWorkspaceAdmin(server.userService), server.updateWorkspaceMemberRole)
+	authWithUserRoutes.DELETE("/workspaces/:id/members/:user_id", requireWorkspacePermission(server.userService, service.PermissionManageMembers), server.removeUserFromWorkspace)
+	authWithUserRoutes.PUT("/workspaces/:id/members/:user_id/role", requireWorkspacePermission(server.userService, service.PermissionManageMembers), server.updateWorkspaceMemberRole)
+	authWithUserRoutes.DELETE("/workspaces/:id/members/:user_id/email-suppression", requireWorkspacePermission(server.userService, service.PermissionManageMembers), server.liftMemberEmailSuppression)
+
+	// Custom role and permission routes
+	authWithUserRoutes.GET("/permissions", server.listPermissions)
+	authWithUserRoutes.GET("/workspaces/:id/permissions/me", requireWorkspaceMember(server.userService), server.getMyWorkspacePermissions)
+	authWithUserRoutes.GET("/workspaces/:id/roles", requireWorkspaceMember(server.userService), server.listWorkspaceRoles)
+	authWithUserRoutes.POST("/workspaces/:id/roles", requireWorkspacePermission(server.userService, service.PermissionManageRoles), server.createWorkspaceRole)
+	authWithUserRoutes.PUT("/workspaces/:id/roles/:role_id", requireWorkspacePermission(server.userService, service.PermissionManageRoles), server.updateWorkspaceRole)
+	authWithUserRoutes.DELETE("/workspaces/:id/roles/:role_id", requireWorkspacePermission(server.userService, service.PermissionManageRoles), server.deleteWorkspaceRole)
+	authWithUserRoutes.PUT("/workspaces/:id/members/:user_id/custom-role", requireWorkspacePermission(server.userService, service.PermissionManageRoles), server.assignWorkspaceRole)
 
 	// Workspace member routes (require membership of the workspace)
 	authWithUserRoutes.POST("/workspaces/:id/channels", requireWorkspaceMember(server.userService), server.createChannel)
 	authWithUserRoutes.GET("/workspaces/:id/channels", requireWorkspaceMember(server.userService), server.listChannels)
 
+	// Deleted channel routes (require manage_channels permission)
+	authWithUserRoutes.GET("/workspaces/:id/deleted-channels", requireWorkspacePermission(server.userService, service.PermissionManageChannels), server.listDeletedChannels)
+	authWithUserRoutes.POST("/workspaces/:id/deleted-channels/:channel_id/restore", requireWorkspacePermission(server.userService, service.PermissionManageChannels), server.restoreChannel)
+
 	// Channel routes (with individual access checks)
 	authWithUserRoutes.GET("/channels/:id", server.getChannel)
+	authWithUserRoutes.GET("/channels/:id/typing", server.listTypingUsers)
+	authWithUserRoutes.GET("/channels/:id/stats", server.getChannelStats)
 	authWithUserRoutes.PUT("/channels/:id", server.updateChannel)
 	authWithUserRoutes.DELETE("/channels/:id", server.deleteChannel)
 
@@ -149,29 +519,231 @@ func (server *Server) setupRouter() {
 	authWithUserRoutes.POST("/workspace/:id/messages/direct", requireWorkspaceMember(server.userService), server.sendDirectMessage)
 	authWithUserRoutes.GET("/workspace/:id/channels/:channel_id/messages", requireWorkspaceMember(server.userService), server.getChannelMessages)
 	authWithUserRoutes.GET("/workspace/:id/messages/direct/:user_id", requireWorkspaceMember(server.userService), server.getDirectMessages)
+	authWithUserRoutes.GET("/workspace/:id/channels/:channel_id/messages/archived", requireWorkspaceMember(server.userService), server.getArchivedChannelMessages)
+	authWithUserRoutes.GET("/workspace/:id/messages/direct/:user_id/archived", requireWorkspaceMember(server.userService), server.getArchivedDirectMessages)
 	authWithUserRoutes.PUT("/messages/:message_id", server.editMessage)
 	authWithUserRoutes.DELETE("/messages/:message_id", server.deleteMessage)
 	authWithUserRoutes.GET("/messages/:message_id", server.getMessage)
+	authWithUserRoutes.GET("/messages/:message_id/context", server.getMessageContext)
+	authWithUserRoutes.POST("/messages/:message_id/replies", server.createThreadReply)
+	authWithUserRoutes.POST("/messages/:message_id/acknowledgment", server.acknowledgeMessage)
+	authWithUserRoutes.GET("/messages/:message_id/acknowledgments", server.getMessageAcknowledgments)
+	authWithUserRoutes.GET("/messages/:message_id/acknowledgments/export", server.exportMessageAcknowledgments)
+	authWithUserRoutes.POST("/messages/:message_id/notify-anyway", server.notifyAnyway)
+	authWithUserRoutes.POST("/messages/:message_id/translate", server.translateMessage)
+
+	// Draft routes
+	authWithUserRoutes.GET("/workspaces/:id/drafts", requireWorkspaceMember(server.userService), server.listDrafts)
+	authWithUserRoutes.PUT("/workspaces/:id/drafts", requireWorkspaceMember(server.userService), server.saveDraft)
+	authWithUserRoutes.DELETE("/drafts/:draft_id", server.deleteDraft)
+
+	// Reaction routes (access is checked per message)
+	authWithUserRoutes.POST("/messages/:message_id/reactions", server.addReaction)
+	authWithUserRoutes.GET("/messages/:message_id/reactions", server.getReactions)
+	authWithUserRoutes.DELETE("/messages/:message_id/reactions/:emoji", server.removeReaction)
+	authWithUserRoutes.GET("/messages/:message_id/reactions/:emoji/users", server.listReactors)
+	authWithUserRoutes.GET("/workspaces/:id/settings/messages", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.getMessageSettings)
+	authWithUserRoutes.PUT("/workspaces/:id/settings/messages", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.updateMessageSettings)
+	authWithUserRoutes.GET("/workspaces/:id/settings/reactions", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.getReactionSettings)
+	authWithUserRoutes.PUT("/workspaces/:id/settings/reactions", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.updateReactionSettings)
+
+	// Pin routes (access is checked per channel)
+	authWithUserRoutes.POST("/messages/:message_id/pin", server.pinMessage)
+	authWithUserRoutes.DELETE("/messages/:message_id/pin", server.unpinMessage)
+	authWithUserRoutes.GET("/channels/:id/pins", server.listPinnedMessages)
+	authWithUserRoutes.PUT("/channels/:id/pins/order", server.reorderPinnedMessages)
+
+	// Custom emoji routes (require workspace membership)
+	authWithUserRoutes.POST("/workspaces/:id/emojis", requireWorkspaceMember(server.userService), server.createCustomEmoji)
+	authWithUserRoutes.GET("/workspaces/:id/emojis", requireWorkspaceMember(server.userService), server.listCustomEmojis)
+	authWithUserRoutes.DELETE("/workspaces/:id/emojis/:emoji_id", requireWorkspaceMember(server.userService), server.deleteCustomEmoji)
+
+	// Workspace banner routes (members read them, workspace managers publish them)
+	authWithUserRoutes.GET("/workspaces/:id/banners", requireWorkspaceMember(server.userService), server.listBanners)
+	authWithUserRoutes.POST("/workspaces/:id/banners", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.createBanner)
+	authWithUserRoutes.PUT("/workspaces/:id/banners/:banner_id", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.updateBanner)
+	authWithUserRoutes.DELETE("/workspaces/:id/banners/:banner_id", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.deleteBanner)
+
+	// Read state routes
+	authWithUserRoutes.POST("/workspace/:id/channels/:channel_id/read", requireWorkspaceMember(server.userService), server.markChannelAsRead)
+	authWithUserRoutes.POST("/workspace/:id/messages/direct/:user_id/read", requireWorkspaceMember(server.userService), server.markDirectMessagesAsRead)
+	authWithUserRoutes.POST("/messages/:message_id/thread/read", server.markThreadAsRead)
+	authWithUserRoutes.DELETE("/messages/:message_id/thread/follow", server.unfollowThread)
+	authWithUserRoutes.POST("/workspaces/:id/read-all", requireWorkspaceMember(server.userService), server.markWorkspaceAsRead)
+	authWithUserRoutes.GET("/workspaces/:id/unread", requireWorkspaceMember(server.userService), server.getUnreadCounts)
+
+	// Mention routes
+	authWithUserRoutes.GET("/workspaces/:id/mentions", requireWorkspaceMember(server.userService), server.listMentions)
+	authWithUserRoutes.POST("/mentions/:id/mark-read", server.markMentionAsRead)
+
+	// Search routes
+	authWithUserRoutes.GET("/workspaces/:id/search", requireWorkspaceMember(server.userService), requireFeature(server.featureFlagService, service.FeatureNewSearch), server.searchWorkspace)
+	authWithUserRoutes.GET("/workspace/:id/messages/search", requireWorkspaceMember(server.userService), server.searchMessages)
+	authWithUserRoutes.POST("/workspace/:id/saved-searches", requireWorkspaceMember(server.userService), server.createSavedSearch)
+	authWithUserRoutes.GET("/workspace/:id/saved-searches", requireWorkspaceMember(server.userService), server.listSavedSearches)
+	authWithUserRoutes.PUT("/workspace/:id/saved-searches/:search_id", requireWorkspaceMember(server.userService), server.updateSavedSearch)
+	authWithUserRoutes.DELETE("/workspace/:id/saved-searches/:search_id", requireWorkspaceMember(server.userService), server.deleteSavedSearch)
+	authWithUserRoutes.GET("/workspace/:id/saved-searches/:search_id/results", requireWorkspaceMember(server.userService), server.runSavedSearch)
 
 	// Status routes
 	authWithUserRoutes.PUT("/workspace/:id/status", requireWorkspaceMember(server.userService), server.updateUserStatus)
 	authWithUserRoutes.GET("/workspace/:id/status/:user_id", requireWorkspaceMember(server.userService), server.getUserStatus)
 	authWithUserRoutes.GET("/workspace/:id/status", requireWorkspaceMember(server.userService), server.getWorkspaceUserStatuses)
+	authWithUserRoutes.GET("/workspaces/:id/settings/presence", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.getPresenceSettings)
+	authWithUserRoutes.PUT("/workspaces/:id/settings/presence", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.updatePresenceSettings)
 	authWithUserRoutes.POST("/workspace/:id/activity", requireWorkspaceMember(server.userService), server.updateUserActivity)
 
+	// Onboarding routes
+	authWithUserRoutes.GET("/workspaces/:id/onboarding", requireWorkspaceMember(server.userService), server.getOnboarding)
+	authWithUserRoutes.POST("/workspaces/:id/onboarding/join-channels", requireWorkspaceMember(server.userService), server.joinSuggestedChannels)
+	authWithUserRoutes.GET("/workspaces/:id/onboarding/settings", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.getOnboardingSettings)
+	authWithUserRoutes.PUT("/workspaces/:id/onboarding/settings", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.updateOnboardingSettings)
+
+	// Out-of-office routes
+	authWithUserRoutes.GET("/workspaces/:id/out-of-office", requireWorkspaceMember(server.userService), server.getOutOfOffice)
+	authWithUserRoutes.PUT("/workspaces/:id/out-of-office", requireWorkspaceMember(server.userService), server.setOutOfOffice)
+	authWithUserRoutes.DELETE("/workspaces/:id/out-of-office", requireWorkspaceMember(server.userService), server.clearOutOfOffice)
+
+	// Do Not Disturb routes
+	authWithUserRoutes.GET("/workspaces/:id/dnd", requireWorkspaceMember(server.userService), server.getDoNotDisturb)
+	authWithUserRoutes.PUT("/workspaces/:id/dnd", requireWorkspaceMember(server.userService), server.setDoNotDisturb)
+	authWithUserRoutes.POST("/workspaces/:id/notifications/snooze", requireWorkspaceMember(server.userService), server.snoozeNotifications)
+	authWithUserRoutes.DELETE("/workspaces/:id/notifications/snooze", requireWorkspaceMember(server.userService), server.endNotificationSnooze)
+
+	// Notification preference routes
+	authWithUserRoutes.GET("/workspaces/:id/notification-preferences", requireWorkspaceMember(server.userService), server.listNotificationPreferences)
+	authWithUserRoutes.PUT("/workspaces/:id/notification-preferences", requireWorkspaceMember(server.userService), server.updateNotificationPreference)
+	authWithUserRoutes.DELETE("/workspaces/:id/notification-preferences", requireWorkspaceMember(server.userService), server.resetNotificationPreference)
+	authWithUserRoutes.GET("/workspaces/:id/notification-preferences/channels/:channel_id", requireWorkspaceMember(server.userService), server.getChannelNotificationPreference)
+	authWithUserRoutes.PUT("/workspaces/:id/notification-preferences/channels/:channel_id", requireWorkspaceMember(server.userService), server.updateChannelNotificationPreference)
+	authWithUserRoutes.DELETE("/workspaces/:id/notification-preferences/channels/:channel_id", requireWorkspaceMember(server.userService), server.deleteChannelNotificationPreference)
+	authWithUserRoutes.GET("/workspaces/:id/notification-defaults", requireWorkspaceMember(server.userService), server.listChannelNotificationDefaults)
+	authWithUserRoutes.PUT("/workspaces/:id/notification-defaults/channels/:channel_id", requireWorkspacePermission(server.userService, service.PermissionManageChannels), server.setChannelNotificationDefault)
+	authWithUserRoutes.DELETE("/workspaces/:id/notification-defaults/channels/:channel_id", requireWorkspacePermission(server.userService, service.PermissionManageChannels), server.deleteChannelNotificationDefault)
+	authWithUserRoutes.POST("/workspaces/:id/notification-defaults/channels/:channel_id/apply", requireWorkspacePermission(server.userService, service.PermissionManageChannels), server.applyChannelNotificationDefault)
+
+	// Feature flag routes
+	authWithUserRoutes.GET("/workspaces/:id/features", requireWorkspaceMember(server.userService), server.listFeatureFlags)
+	authWithUserRoutes.PUT("/workspaces/:id/features/:flag", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.setFeatureFlag)
+	authWithUserRoutes.DELETE("/workspaces/:id/features/:flag", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.resetFeatureFlag)
+
+	// Content moderation routes (require moderate_content permission)
+	authWithUserRoutes.GET("/workspaces/:id/moderation/words", requireWorkspacePermission(server.userService, service.PermissionModerateContent), server.listModerationWords)
+	authWithUserRoutes.POST("/workspaces/:id/moderation/words", requireWorkspacePermission(server.userService, service.PermissionModerateContent), server.addModerationWord)
+	authWithUserRoutes.DELETE("/workspaces/:id/moderation/words/:word_id", requireWorkspacePermission(server.userService, service.PermissionModerateContent), server.deleteModerationWord)
+	authWithUserRoutes.GET("/workspaces/:id/moderation/queue", requireWorkspacePermission(server.userService, service.PermissionModerateContent), server.listModerationQueue)
+	authWithUserRoutes.POST("/workspaces/:id/moderation/queue/:item_id/review", requireWorkspacePermission(server.userService, service.PermissionModerateContent), server.reviewModerationItem)
+	authWithUserRoutes.GET("/workspaces/:id/moderation/audit", requireWorkspacePermission(server.userService, service.PermissionModerateContent), server.listModerationAuditLog)
+	// Takedowns check moderate_content in the message's workspace
+	authWithUserRoutes.POST("/messages/:message_id/takedown", server.takedownMessage)
+
+	// Workspace event stream routes (require read_events permission)
+	authWithUserRoutes.GET("/workspaces/:id/events", requireWorkspacePermission(server.userService, service.PermissionReadEvents), server.listWorkspaceEvents)
+
+	// Outgoing webhook routes (require manage_workspace permission)
+	authWithUserRoutes.GET("/workspaces/:id/webhooks", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.listWebhooks)
+	authWithUserRoutes.POST("/workspaces/:id/webhooks", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.createWebhook)
+	authWithUserRoutes.DELETE("/workspaces/:id/webhooks/:webhook_id", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.deleteWebhook)
+
+	// Abuse report routes
+	authWithUserRoutes.POST("/reports", server.createAbuseReport)
+	authWithUserRoutes.GET("/workspaces/:id/reports", requireWorkspacePermission(server.userService, service.PermissionModerateContent), server.listAbuseReports)
+	authWithUserRoutes.POST("/workspaces/:id/reports/:report_id/resolve", requireWorkspacePermission(server.userService, service.PermissionModerateContent), server.resolveAbuseReport)
+	authWithUserRoutes.POST("/workspaces/:id/reports/:report_id/dismiss", requireWorkspacePermission(server.userService, service.PermissionModerateContent), server.dismissAbuseReport)
+
+	// Organization role routes (require organization owner or admin)
+	authWithUserRoutes.GET("/organizations/:id/roles", requireOrganizationAdmin(server.organizationRoleService), server.listOrganizationRoles)
+	authWithUserRoutes.PUT("/organizations/:id/roles/:user_id", requireOrganizationAdmin(server.organizationRoleService), server.setOrganizationRole)
+	authWithUserRoutes.DELETE("/organizations/:id/roles/:user_id", requireOrganizationAdmin(server.organizationRoleService), server.removeOrganizationRole)
+
+	// Organization directory routes
+	authWithUserRoutes.GET("/organizations/:id/directory", server.searchDirectory)
+
+	// External DM routes: conversations with people in other organizations
+	authWithUserRoutes.GET("/organizations/:id/external-dm-policy", requireOrganizationAdmin(server.organizationRoleService), server.getExternalDMPolicy)
+	authWithUserRoutes.PUT("/organizations/:id/external-dm-policy", requireOrganizationAdmin(server.organizationRoleService), server.updateExternalDMPolicy)
+	authWithUserRoutes.POST("/workspaces/:id/external-dm-requests", requireWorkspaceMember(server.userService), server.sendExternalDMRequest)
+	authWithUserRoutes.GET("/external-dm-requests", server.listExternalDMRequests)
+	authWithUserRoutes.POST("/external-dm-requests/:request_id/accept", server.acceptExternalDMRequest)
+	authWithUserRoutes.POST("/external-dm-requests/:request_id/decline", server.declineExternalDMRequest)
+	authWithUserRoutes.DELETE("/external-dm-requests/:request_id", server.closeExternalDMRequest)
+	authWithUserRoutes.GET("/external-dm-requests/:request_id/messages", server.listExternalDMMessages)
+	authWithUserRoutes.POST("/external-dm-requests/:request_id/messages", server.sendExternalDMMessage)
+
+	// Deleted workspace routes (require organization admin)
+	authWithUserRoutes.GET("/organizations/:id/deleted-workspaces", requireOrganizationAdmin(server.organizationRoleService), server.listDeletedWorkspaces)
+	authWithUserRoutes.POST("/organizations/:id/deleted-workspaces/:workspace_id/restore", requireOrganizationAdmin(server.organizationRoleService), server.restoreWorkspace)
+
+	// Workspace teardown routes (require organization admin)
+	authWithUserRoutes.GET("/organizations/:id/workspace-teardowns", requireOrganizationAdmin(server.organizationRoleService), server.listWorkspaceTeardowns)
+	authWithUserRoutes.GET("/organizations/:id/workspace-teardowns/:workspace_id", requireOrganizationAdmin(server.organizationRoleService), server.getWorkspaceTeardown)
+
+	// Organization deletion routes (require organization admin, owners request and cancel)
+	authWithUserRoutes.DELETE("/organizations/:id", requireOrganizationAdmin(server.organizationRoleService), server.deleteOrganization)
+	authWithUserRoutes.GET("/organizations/:id/deletion", requireOrganizationAdmin(server.organizationRoleService), server.getOrganizationDeletion)
+	authWithUserRoutes.POST("/organizations/:id/deletion/cancel", requireOrganizationAdmin(server.organizationRoleService), server.cancelOrganizationDeletion)
+
+	// Email template and branding routes (require organization admin)
+	authWithUserRoutes.GET("/organizations/:id/email-templates", requireOrganizationAdmin(server.organizationRoleService), server.listEmailTemplates)
+	authWithUserRoutes.GET("/organizations/:id/email-templates/:kind", requireOrganizationAdmin(server.organizationRoleService), server.getEmailTemplate)
+	authWithUserRoutes.PUT("/organizations/:id/email-templates/:kind", requireOrganizationAdmin(server.organizationRoleService), server.updateEmailTemplate)
+	authWithUserRoutes.DELETE("/organizations/:id/email-templates/:kind", requireOrganizationAdmin(server.organizationRoleService), server.resetEmailTemplate)
+	authWithUserRoutes.POST("/organizations/:id/email-templates/:kind/preview", requireOrganizationAdmin(server.organizationRoleService), server.previewEmailTemplate)
+	authWithUserRoutes.GET("/organizations/:id/email-branding", requireOrganizationAdmin(server.organizationRoleService), server.getEmailBranding)
+	authWithUserRoutes.PUT("/organizations/:id/email-branding", requireOrganizationAdmin(server.organizationRoleService), server.updateEmailBranding)
+
+	// Legal hold routes (require organization admin)
+	authWithUserRoutes.POST("/organizations/:id/legal-holds", requireOrganizationAdmin(server.organizationRoleService), server.createLegalHold)
+	authWithUserRoutes.GET("/organizations/:id/legal-holds", requireOrganizationAdmin(server.organizationRoleService), server.listLegalHolds)
+	authWithUserRoutes.POST("/organizations/:id/legal-holds/:hold_id/release", requireOrganizationAdmin(server.organizationRoleService), server.releaseLegalHold)
+	authWithUserRoutes.GET("/organizations/:id/legal-holds/:hold_id/export", requireOrganizationAdmin(server.organizationRoleService), server.exportLegalHold)
+
+	// Calendar routes
+	authWithUserRoutes.GET("/workspaces/:id/calendar", requireWorkspaceMember(server.userService), server.getCalendarIntegration)
+	authWithUserRoutes.PUT("/workspaces/:id/calendar", requireWorkspaceMember(server.userService), server.setCalendarIntegration)
+	authWithUserRoutes.DELETE("/workspaces/:id/calendar", requireWorkspaceMember(server.userService), server.deleteCalendarIntegration)
+	authWithUserRoutes.POST("/workspaces/:id/calendar/sync", requireWorkspaceMember(server.userService), server.syncCalendar)
+
+	// Huddle routes (require workspace membership)
+	authWithUserRoutes.POST("/workspaces/:id/huddles", requireWorkspaceMember(server.userService), server.startHuddle)
+	authWithUserRoutes.GET("/workspaces/:id/huddles/:huddle_id", requireWorkspaceMember(server.userService), server.getHuddle)
+	authWithUserRoutes.POST("/workspaces/:id/huddles/:huddle_id/join", requireWorkspaceMember(server.userService), server.joinHuddle)
+	authWithUserRoutes.POST("/workspaces/:id/huddles/:huddle_id/leave", requireWorkspaceMember(server.userService), server.leaveHuddle)
+	authWithUserRoutes.PUT("/workspaces/:id/huddles/:huddle_id/media", requireWorkspaceMember(server.userService), server.updateHuddleMedia)
+
+	// Canvas routes (require workspace membership, channel access is checked per canvas)
+	authWithUserRoutes.POST("/workspaces/:id/channels/:channel_id/canvases", requireWorkspaceMember(server.userService), server.createCanvas)
+	authWithUserRoutes.GET("/workspaces/:id/channels/:channel_id/canvases", requireWorkspaceMember(server.userService), server.listChannelCanvases)
+	authWithUserRoutes.GET("/workspaces/:id/canvases/:canvas_id", requireWorkspaceMember(server.userService), server.getCanvas)
+	authWithUserRoutes.PUT("/workspaces/:id/canvases/:canvas_id", requireWorkspaceMember(server.userService), server.updateCanvas)
+	authWithUserRoutes.DELETE("/workspaces/:id/canvases/:canvas_id", requireWorkspaceMember(server.userService), server.deleteCanvas)
+	authWithUserRoutes.GET("/workspaces/:id/canvases/:canvas_id/revisions", requireWorkspaceMember(server.userService), server.listCanvasRevisions)
+	authWithUserRoutes.GET("/workspaces/:id/canvases/:canvas_id/revisions/:revision", requireWorkspaceMember(server.userService), server.getCanvasRevision)
+
 	// Typing indicator endpoint
 	authWithUserRoutes.POST("/workspaces/:id/channels/:channel_id/typing", requireWorkspaceMember(server.userService), server.handleTyping)
 
 	// File routes
 	authWithUserRoutes.POST("/files/upload", server.uploadFile)
+	authWithUserRoutes.POST("/files/inline", server.uploadInlineImage)
 	authWithUserRoutes.GET("/files/:id", server.getFile)
 	authWithUserRoutes.GET("/files/:id/download", server.downloadFile)
+	authWithUserRoutes.GET("/files/:id/access-log", server.getFileAccessLog)
+	authWithUserRoutes.GET("/files/:id/playback", server.getVideoPlayback)
+	authWithUserRoutes.GET("/files/:id/poster", server.getVideoPoster)
+	authWithUserRoutes.GET("/files/:id/thumbnail", server.getFileThumbnail)
 	authWithUserRoutes.DELETE("/files/:id", server.deleteFile)
 	authWithUserRoutes.GET("/workspaces/:id/files", requireWorkspaceMember(server.userService), server.listWorkspaceFiles)
 	authWithUserRoutes.GET("/workspaces/:id/files/stats", requireWorkspaceMember(server.userService), server.getFileStats)
+	authWithUserRoutes.GET("/workspaces/:id/files/trash", requireWorkspaceMember(server.userService), server.listTrashedFiles)
+	authWithUserRoutes.POST("/workspaces/:id/files/trash/:file_id/restore", requireWorkspaceMember(server.userService), server.restoreFile)
+	authWithUserRoutes.DELETE("/workspaces/:id/files/trash/:file_id", requireWorkspaceMember(server.userService), server.deleteFilePermanently)
+	authWithUserRoutes.GET("/workspaces/:id/settings/files", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.getFileSettings)
+	authWithUserRoutes.PUT("/workspaces/:id/settings/files", requireWorkspacePermission(server.userService, service.PermissionManageWorkspace), server.updateFileSettings)
 	authWithUserRoutes.POST("/files/message", server.sendFileMessage)
 
 	server.router = router
+	return nil
 }
 
 // Start runs the HTTP server on a specific address.
@@ -179,15 +751,80 @@ func (server *Server) Start(address string) error {
 	// Start the WebSocket hub in a separate goroutine
 	go server.hub.Run()
 
-	return server.router.Run(address)
+	// Start the inactivity monitor with the server's status service so that
+	// status changes it makes reach WebSocket clients
+	go server.statusService.StartInactivityMonitor(context.Background())
+
+	// Keep meeting statuses in step with connected calendars
+	go server.calendarService.StartCalendarSync(context.Background(), server.config.CalendarSyncInterval)
+
+	// Permanently remove deleted channels and workspaces once they can no
+	// longer be restored
+	go server.deletionService.StartPurgeJob(context.Background(), server.config.DeletionPurgeInterval)
+
+	// Clear out the data left behind by purged workspaces
+	go server.workspaceTeardownService.StartTeardownWorker(context.Background(), server.config.TeardownInterval)
+	go server.organizationDeletionService.StartDeletionWorker(context.Background(), server.config.OrganizationDeletionInterval)
+
+	// Remove deleted messages past their retention and abandoned uploads
+	go server.cleanupService.StartCleanupJob(context.Background(), server.config.CleanupInterval)
+
+	// Quarantine stored files nothing refers to and remake missing thumbnails
+	go server.fileReconciliationService.StartReconciliationJob(context.Background(), server.config.FileReconciliationInterval)
+
+	// Purge files that have been in the trash longer than the retention
+	go server.fileService.StartTrashPurgeJob(context.Background(), server.config.FileTrashPurgeInterval)
+
+	// Create message partitions ahead of time and drop expired ones
+	go server.partitionService.StartMaintenanceJob(context.Background(), server.config.PartitionMaintenanceInterval)
+
+	// Move old messages to the message archive
+	if server.archiveService.Enabled() {
+		go server.archiveService.StartArchivalJob(context.Background(), server.config.MessageArchiveInterval)
+	}
+
+	// Remind members who haven't acknowledged must-read messages
+	go server.messageService.StartAcknowledgmentReminderJob(context.Background(), server.config.AcknowledgmentReminderInterval)
+
+	// Roll up channel message stats every night
+	go server.channelStatsService.StartRollupJob(context.Background())
+
+	// Send queued emails, retrying failed attempts
+	go server.emailService.StartQueueWorker(context.Background(), server.config.EmailQueueInterval)
+
+	// Publish real-time events that were saved but never broadcast
+	go server.outboxRelay.StartRelay(context.Background(), server.config.OutboxRelayInterval)
+
+	// Push workspace events to the configured broker and drop expired ones
+	go server.workspaceEventService.StartPublisher(context.Background(), server.config.EventPublishInterval)
+
+	// POST file events to outgoing webhooks, retrying failed deliveries
+	go server.webhookService.StartDeliveryWorker(context.Background(), server.config.WebhookDeliveryInterval)
+
+	// Transcode uploaded videos for playback in browsers
+	if server.videoProcessingService.Enabled() {
+		go server.videoProcessingService.StartProcessingWorker(context.Background(), server.config.VideoProcessingInterval)
+	}
+
+	// Render first page previews of uploaded documents
+	if server.documentPreviewService.Enabled() {
+		go server.documentPreviewService.StartPreviewWorker(context.Background(), server.config.DocumentPreviewInterval)
+	}
+
+	return server.listenAndServe(address)
 }
 
-func errorResponse(err error) gin.H {
+// errorResponse formats an error in the request's language
+func errorResponse(ctx *gin.Context, err error) gin.H {
+	if localizer := getLocalizer(ctx); localizer != nil {
+		return gin.H{"error": localizer.Error(err)}
+	}
 	return gin.H{"error": err.Error()}
 }
 
 // @Summary Get API Information
 // @Description Get general information about the GoSlack API
+// @ID getAPIInfo
 // @Tags system
 // @Produce json
 // @Success 200 {object} map[string]interface{} "API information"
@@ -200,6 +837,7 @@ func (server *Server) getAPIInfo(ctx *gin.Context) {
 		"endpoints": gin.H{
 			"swagger_ui":  "/swagger/index.html",
 			"swagger_doc": "/swagger/doc.json",
+			"openapi_doc": "/openapi.json",
 		},
 		"features": []string{
 			"User Management",
@@ -209,9 +847,26 @@ func (server *Server) getAPIInfo(ctx *gin.Context) {
 			"Real-time Messaging",
 			"File Management",
 			"Status Management",
+			"Message Search",
+			"Saved Searches",
+			"Calendar Status",
+			"Out-of-Office Auto-Replies",
+			"Localized Messages",
+			"Workspace Feature Flags",
 			"WebSocket Support",
 		},
 	}
 
 	ctx.JSON(200, info)
 }
+
+// @Summary Get OpenAPI Document
+// @Description Get the OpenAPI 3 document of the API, which the Go and TypeScript clients are generated from
+// @ID getOpenAPIDocument
+// @Tags system
+// @Produce json
+// @Success 200 {object} map[string]interface{} "OpenAPI 3 document"
+// @Router /openapi.json [get]
+func (server *Server) getOpenAPIDocument(ctx *gin.Context) {
+	ctx.Data(200, "application/json; charset=utf-8", docs.OpenAPI)
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCORSConfiguration(t *testing.T) {
+	testCases := []struct {
+		name               string
+		allowedOrigins     string
+		origin             string
+		expectedCode       int
+		expectedAllowed    string
+		expectsCredentials bool
+	}{
+		{
+			name:               "AllowedOrigin",
+			allowedOrigins:     "https://app.example.com, https://admin.example.com",
+			origin:             "https://admin.example.com",
+			expectedCode:       http.StatusNoContent,
+			expectedAllowed:    "https://admin.example.com",
+			expectsCredentials: true,
+		},
+		{
+			name:           "DisallowedOrigin",
+			allowedOrigins: "https://app.example.com",
+			origin:         "https://evil.example.com",
+			expectedCode:   http.StatusForbidden,
+		},
+		{
+			name:               "WildcardSubdomain",
+			allowedOrigins:     "https://*.example.com",
+			origin:             "https://team.example.com",
+			expectedCode:       http.StatusNoContent,
+			expectedAllowed:    "https://team.example.com",
+			expectsCredentials: true,
+		},
+		{
+			name:            "AnyOrigin",
+			allowedOrigins:  "*",
+			origin:          "https://anywhere.example.org",
+			expectedCode:    http.StatusNoContent,
+			expectedAllowed: "*",
+		},
+		{
+			name:         "NoOriginsConfigured",
+			origin:       "https://app.example.com",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			config := util.Config{
+				TokenSymmetricKey:   util.RandomString(32),
+				AccessTokenDuration: time.Minute,
+				CORSAllowedOrigins:  tc.allowedOrigins,
+			}
+			server, err := NewServer(config, mockdb.NewMockStore(ctrl))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodOptions, "/api/info", nil)
+			require.NoError(t, err)
+			request.Header.Set("Origin", tc.origin)
+			request.Header.Set("Access-Control-Request-Method", http.MethodGet)
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.expectedCode, recorder.Code)
+			require.Equal(t, tc.expectedAllowed, recorder.Header().Get("Access-Control-Allow-Origin"))
+			require.Equal(t, tc.expectsCredentials, recorder.Header().Get("Access-Control-Allow-Credentials") == "true")
+		})
+	}
+}
+
+func TestInvalidServerConfiguration(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config util.Config
+	}{
+		{
+			name:   "InvalidTrustedProxy",
+			config: util.Config{TrustedProxies: "not-an-ip"},
+		},
+		{
+			name:   "InvalidOrigin",
+			config: util.Config{CORSAllowedOrigins: "app.example.com"},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc.config.TokenSymmetricKey = util.RandomString(32)
+			_, err := NewServer(tc.config, mockdb.NewMockStore(ctrl))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestOpenAPIDocumentAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newTestServer(t, mockdb.NewMockStore(ctrl))
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	require.NoError(t, err)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var document struct {
+		OpenAPI string                    `json:"openapi"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &document))
+	require.Equal(t, "3.0.3", document.OpenAPI)
+	require.Contains(t, document.Paths, "/api/info")
+	require.Contains(t, document.Paths["/openapi.json"], "get")
+}
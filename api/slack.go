@@ -0,0 +1,1115 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/publicid"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/token"
+)
+
+// A core subset of Slack's Web API is answered at /api/<method>, taking and
+// returning Slack's payload shapes, so integrations built for Slack work with
+// a new base URL and a goslack token. Like Slack, every call answers 200 with
+// "ok" and an error code on failure. Tokens act in their user's workspace.
+//
+// Slack-style IDs are built from public IDs: C… for channels, D… for direct
+// conversations (named after the other user), U… for users and T… for
+// workspaces. A message's ts is the time it was sent to the microsecond, as
+// Slack's are, which keeps ts values ordered and lets them be turned back
+// into messages. Should two messages of a conversation share a ts, methods
+// given it fail with ambiguous_ts rather than act on either.
+
+const (
+	// slackHistoryBatch is how many messages conversations.history reads at
+	// a time while it skips thread replies, tombstones and out of range messages
+	slackHistoryBatch = 100
+	// slackHistoryMaxBatches bounds the reads of one conversations.history
+	// call, which returns a cursor to carry on from when it runs out
+	slackHistoryMaxBatches = 10
+	// slackMaxPageSize caps the limit of paginated methods
+	slackMaxPageSize = 200
+	// slackDefaultPageSize is used when a paginated method has no limit
+	slackDefaultPageSize = 100
+)
+
+var (
+	// slackMentionPattern matches a Slack mention of a user, with an optional label
+	slackMentionPattern = regexp.MustCompile(`<@(U[0-9A-Za-z]+)(?:\|[^>]*)?>`)
+	// escapedMentionPattern matches a goslack mention once the text is escaped for Slack
+	escapedMentionPattern = regexp.MustCompile(`&lt;@(\d+)&gt;`)
+	// Slack escapes these characters in message text
+	slackUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+	slackEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// slackMethod is a Slack Web API method the compatibility layer answers
+type slackMethod struct {
+	name    string
+	scope   string // Token scope the method needs, empty for none
+	handler gin.HandlerFunc
+}
+
+// slackMethods lists the Slack Web API methods that are answered. Methods
+// that only read can be called with GET as well as POST.
+func (server *Server) slackMethods() []slackMethod {
+	return []slackMethod{
+		{"auth.test", "", server.slackAuthTest},
+		{"chat.postMessage", "messages:write", server.slackPostMessage},
+		{"chat.update", "messages:write", server.slackUpdateMessage},
+		{"chat.delete", "messages:write", server.slackDeleteMessage},
+		{"conversations.list", "channels:read", server.slackListConversations},
+		{"conversations.info", "channels:read", server.slackConversationInfo},
+		{"conversations.history", "messages:read", server.slackConversationHistory},
+		{"reactions.add", "messages:write", server.slackAddReaction},
+		{"reactions.remove", "messages:write", server.slackRemoveReaction},
+		{"users.info", "users:read", server.slackUserInfo},
+		{"users.list", "users:read", server.slackListUsers},
+	}
+}
+
+// slackAuthMiddleware authenticates a Slack method call with a bearer token,
+// or a token parameter as older Slack clients send, and answers failures the
+// way Slack does
+func slackAuthMiddleware(tokenMaker token.Maker, userService *service.UserService, scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		accessToken := ctx.PostForm("token")
+		if accessToken == "" {
+			accessToken = ctx.Query("token")
+		}
+		if fields := strings.Fields(ctx.GetHeader(authorizationHeaderKey)); len(fields) == 2 && strings.ToLower(fields[0]) == authorizationTypeBearer {
+			accessToken = fields[1]
+		}
+		if accessToken == "" {
+			abortSlack(ctx, "not_authed")
+			return
+		}
+
+		payload, err := tokenMaker.VerifyToken(accessToken)
+		if err != nil {
+			abortSlack(ctx, "invalid_auth")
+			return
+		}
+		if scope != "" && !payload.HasScope(scope) {
+			ctx.AbortWithStatusJSON(http.StatusOK, gin.H{"ok": false, "error": "missing_scope", "needed": scope})
+			return
+		}
+
+		user, err := userService.GetUserByEmail(ctx, payload.Username)
+		if err != nil || (payload.SubjectID != 0 && payload.SubjectID != user.ID) {
+			abortSlack(ctx, "invalid_auth")
+			return
+		}
+		if user.WorkspaceID == nil {
+			abortSlack(ctx, "team_access_not_granted")
+			return
+		}
+
+		ctx.Set(authorizationPayloadKey, payload)
+		ctx.Set(currentUserKey, user)
+		ctx.Next()
+	}
+}
+
+// slackConversation is a channel, or a direct conversation with another user
+type slackConversation struct {
+	channelID int64
+	userID    int64
+}
+
+// id returns the conversation's Slack-style ID
+func (c slackConversation) id() string {
+	if c.channelID != 0 {
+		return slackID("C", publicid.Channel, c.channelID)
+	}
+	return slackID("D", publicid.User, c.userID)
+}
+
+// contains reports whether a message belongs to the conversation as seen by a user
+func (c slackConversation) contains(message *service.MessageResponse, userID int64) bool {
+	if c.channelID != 0 {
+		return message.ChannelID != nil && *message.ChannelID == c.channelID
+	}
+	if message.ReceiverID == nil {
+		return false
+	}
+	return (message.SenderID == userID && *message.ReceiverID == c.userID) ||
+		(message.SenderID == c.userID && *message.ReceiverID == userID)
+}
+
+// parseSlackConversation reads a conversation ID. User IDs stand for the
+// direct conversation with that user, as in Slack.
+func parseSlackConversation(value string) (slackConversation, bool) {
+	if id, ok := parseSlackID("C", publicid.Channel, value); ok {
+		return slackConversation{channelID: id}, true
+	}
+	if id, ok := parseSlackID("D", publicid.User, value); ok {
+		return slackConversation{userID: id}, true
+	}
+	if id, ok := parseSlackID("U", publicid.User, value); ok {
+		return slackConversation{userID: id}, true
+	}
+	return slackConversation{}, false
+}
+
+// slackID returns the Slack-style ID of a record, its public ID under a
+// one letter prefix
+func slackID(prefix string, kind publicid.Kind, id int64) string {
+	return prefix + strings.TrimPrefix(publicid.Encode(kind, id), string(kind)+"_")
+}
+
+// parseSlackID returns the database ID behind a Slack-style ID
+func parseSlackID(prefix string, kind publicid.Kind, value string) (int64, bool) {
+	encoded, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return 0, false
+	}
+	id, err := publicid.Decode(kind, string(kind)+"_"+encoded)
+	return id, err == nil
+}
+
+// slackTimestamp is a position in a conversation, read from a ts value
+type slackTimestamp struct {
+	at time.Time
+}
+
+// slackTS returns the ts of a moment, such as when a message was sent. As in
+// Slack, it's the Unix time with six fractional digits, which hold the
+// microseconds the database keeps timestamps to.
+func slackTS(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/int(time.Microsecond))
+}
+
+// parseSlackTS reads a ts value. Values without a fraction stand for the
+// start of their second, and digits past the microseconds are ignored.
+func parseSlackTS(ts string) (slackTimestamp, bool) {
+	seconds, fraction, _ := strings.Cut(ts, ".")
+	unix, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil || unix < 0 {
+		return slackTimestamp{}, false
+	}
+	var micros int64
+	if fraction != "" {
+		if strings.Trim(fraction, "0123456789") != "" {
+			return slackTimestamp{}, false
+		}
+		fraction = (fraction + "000000")[:6]
+		if micros, err = strconv.ParseInt(fraction, 10, 64); err != nil {
+			return slackTimestamp{}, false
+		}
+	}
+	return slackTimestamp{at: time.Unix(unix, micros*int64(time.Microsecond))}, true
+}
+
+// compare orders the timestamp against when a message was sent
+func (t slackTimestamp) compare(message *service.MessageResponse) int {
+	return t.at.Compare(message.CreatedAt.Truncate(time.Microsecond))
+}
+
+// slackCursor returns the cursor of the next page after offset items
+func slackCursor(offset int32) string {
+	return base64.StdEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(int(offset))))
+}
+
+// parseSlackCursor returns the offset a cursor stands for
+func parseSlackCursor(cursor string) (int32, bool) {
+	if cursor == "" {
+		return 0, true
+	}
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, false
+	}
+	value, ok := strings.CutPrefix(string(decoded), "offset:")
+	if !ok {
+		return 0, false
+	}
+	offset, err := strconv.ParseInt(value, 10, 32)
+	return int32(offset), err == nil && offset >= 0
+}
+
+// slackPageSize returns the page size for a requested limit
+func slackPageSize(limit int32) int32 {
+	if limit <= 0 {
+		return slackDefaultPageSize
+	}
+	return min(limit, slackMaxPageSize)
+}
+
+// fromSlackText turns message text from a Slack client into goslack content,
+// with Slack mentions as <@user_id>
+func fromSlackText(text string) string {
+	text = slackMentionPattern.ReplaceAllStringFunc(text, func(mention string) string {
+		userID, ok := parseSlackID("U", publicid.User, slackMentionPattern.FindStringSubmatch(mention)[1])
+		if !ok {
+			return mention
+		}
+		return fmt.Sprintf("<@%d>", userID)
+	})
+	return slackUnescaper.Replace(text)
+}
+
+// toSlackText escapes message content the way Slack does and turns mentions
+// into Slack mentions
+func toSlackText(content string) string {
+	return escapedMentionPattern.ReplaceAllStringFunc(slackEscaper.Replace(content), func(mention string) string {
+		userID, err := strconv.ParseInt(escapedMentionPattern.FindStringSubmatch(mention)[1], 10, 64)
+		if err != nil {
+			return mention
+		}
+		return "<@" + slackID("U", publicid.User, userID) + ">"
+	})
+}
+
+// slackMessage is a message in Slack's shape
+type slackMessage struct {
+	Type      string          `json:"type"`
+	User      string          `json:"user"`
+	Text      string          `json:"text"`
+	TS        string          `json:"ts"`
+	Edited    *slackEdited    `json:"edited,omitempty"`
+	Reactions []slackReaction `json:"reactions,omitempty"`
+}
+
+// slackEdited tells who last edited a message and when
+type slackEdited struct {
+	User string `json:"user"`
+	TS   string `json:"ts"`
+}
+
+// slackReaction is an emoji reaction in Slack's shape
+type slackReaction struct {
+	Name  string   `json:"name"`
+	Count int64    `json:"count"`
+	Users []string `json:"users"`
+}
+
+// slackChannel is a conversation in Slack's shape
+type slackChannel struct {
+	ID         string `json:"id"`
+	Name       string `json:"name,omitempty"`
+	IsChannel  bool   `json:"is_channel"`
+	IsIM       bool   `json:"is_im"`
+	IsPrivate  bool   `json:"is_private"`
+	IsArchived bool   `json:"is_archived"`
+	IsMember   bool   `json:"is_member"`
+	Created    int64  `json:"created"`
+	Creator    string `json:"creator,omitempty"`
+	User       string `json:"user,omitempty"` // The other user of a direct conversation
+}
+
+// slackUser is a user in Slack's shape
+type slackUser struct {
+	ID       string           `json:"id"`
+	TeamID   string           `json:"team_id"`
+	Name     string           `json:"name"`
+	RealName string           `json:"real_name"`
+	Deleted  bool             `json:"deleted"`
+	IsAdmin  bool             `json:"is_admin"`
+	IsBot    bool             `json:"is_bot"`
+	TZ       string           `json:"tz,omitempty"`
+	Profile  slackUserProfile `json:"profile"`
+}
+
+// slackUserProfile is a user's profile in Slack's shape
+type slackUserProfile struct {
+	Email       string `json:"email"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	RealName    string `json:"real_name"`
+	DisplayName string `json:"display_name"`
+	Title       string `json:"title,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+	Image72     string `json:"image_72,omitempty"`
+	Image192    string `json:"image_192,omitempty"`
+}
+
+func toSlackMessage(message *service.MessageResponse) slackMessage {
+	sender := slackID("U", publicid.User, message.SenderID)
+	response := slackMessage{
+		Type: "message",
+		User: sender,
+		Text: toSlackText(message.Content),
+		TS:   slackTS(message.CreatedAt),
+	}
+	if message.EditedAt != nil {
+		response.Edited = &slackEdited{User: sender, TS: slackTS(*message.EditedAt)}
+	}
+	for _, reaction := range message.Reactions {
+		users := make([]string, len(reaction.UserIDs))
+		for i, userID := range reaction.UserIDs {
+			users[i] = slackID("U", publicid.User, userID)
+		}
+		response.Reactions = append(response.Reactions, slackReaction{Name: reaction.Emoji, Count: reaction.Count, Users: users})
+	}
+	return response
+}
+
+func toSlackChannel(channel service.ChannelResponse, isMember bool) slackChannel {
+	return slackChannel{
+		ID:        slackID("C", publicid.Channel, channel.ID),
+		Name:      channel.Name,
+		IsChannel: true,
+		IsPrivate: channel.IsPrivate,
+		IsMember:  isMember,
+		Created:   channel.CreatedAt.Unix(),
+		Creator:   slackID("U", publicid.User, channel.CreatedBy),
+	}
+}
+
+func toSlackUser(user service.UserResponse) slackUser {
+	realName := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	name := user.Handle
+	if name == "" {
+		name, _, _ = strings.Cut(user.Email, "@")
+	}
+
+	response := slackUser{
+		ID:       slackID("U", publicid.User, user.ID),
+		Name:     name,
+		RealName: realName,
+		IsAdmin:  user.Role == "admin",
+		TZ:       user.Timezone,
+		Profile: slackUserProfile{
+			Email:       user.Email,
+			FirstName:   user.FirstName,
+			LastName:    user.LastName,
+			RealName:    realName,
+			DisplayName: user.Handle,
+			Title:       user.Title,
+			Phone:       user.Phone,
+			Image72:     user.AvatarURLs["72"],
+			Image192:    user.AvatarURLs["192"],
+		},
+	}
+	if user.WorkspaceID != nil {
+		response.TeamID = slackID("T", publicid.Workspace, *user.WorkspaceID)
+	}
+	return response
+}
+
+// slackOK answers a Slack method call that succeeded
+func slackOK(ctx *gin.Context, response gin.H) {
+	if response == nil {
+		response = gin.H{}
+	}
+	response["ok"] = true
+	ctx.JSON(http.StatusOK, response)
+}
+
+// slackFail answers a Slack method call that failed with a Slack error code
+func slackFail(ctx *gin.Context, code string) {
+	ctx.JSON(http.StatusOK, gin.H{"ok": false, "error": code})
+}
+
+// abortSlack stops a Slack method call with a Slack error code
+func abortSlack(ctx *gin.Context, code string) {
+	ctx.AbortWithStatusJSON(http.StatusOK, gin.H{"ok": false, "error": code})
+}
+
+// slackErrorCode returns the Slack error code for a service error, given the
+// codes the method uses when something isn't found or isn't allowed
+func slackErrorCode(err error, notFound, denied string) string {
+	message := err.Error()
+	switch {
+	case errors.Is(err, service.ErrAmbiguousMessageTime):
+		return "ambiguous_ts"
+	case message == "message blocked by content moderation":
+		return "restricted_action"
+	case strings.HasSuffix(message, "not found"), strings.HasSuffix(message, "is not a member of the workspace"):
+		return notFound
+	case strings.HasPrefix(message, "access denied"), strings.HasPrefix(message, "only the message author"):
+		return denied
+	default:
+		return "internal_error"
+	}
+}
+
+// slackChannelError answers a failed call on a conversation
+func slackChannelError(ctx *gin.Context, err error) {
+	slackFail(ctx, slackErrorCode(err, "channel_not_found", "not_in_channel"))
+}
+
+// slackCaller returns the current user and the workspace their token acts in
+func slackCaller(ctx *gin.Context) (service.UserResponse, int64) {
+	user := getCurrentUser(ctx)
+	return user, *user.WorkspaceID
+}
+
+// openSlackConversation checks that a conversation is in the workspace and
+// that the user can use it
+func (server *Server) openSlackConversation(ctx *gin.Context, conversation slackConversation, userID, workspaceID int64) error {
+	if conversation.channelID != 0 {
+		channel, err := server.channelService.GetChannel(ctx, conversation.channelID)
+		if err != nil {
+			return err
+		}
+		if channel.WorkspaceID != workspaceID {
+			return errors.New("channel not found")
+		}
+		return server.channelService.CheckChannelAccess(ctx, userID, conversation.channelID)
+	}
+
+	if !server.userService.UserBelongsToWorkspace(ctx, conversation.userID, workspaceID) {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// findSlackMessage returns the message a ts stands for, if the user can see
+// it and it's in the conversation. A ts names a message by when it was sent,
+// so it's looked up in the conversation by that time.
+func (server *Server) findSlackMessage(ctx *gin.Context, conversation slackConversation, ts string, userID, workspaceID int64) (*service.MessageResponse, error) {
+	position, ok := parseSlackTS(ts)
+	if !ok {
+		return nil, errors.New("message not found")
+	}
+
+	var message *service.MessageResponse
+	var err error
+	if conversation.channelID != 0 {
+		message, err = server.messageService.GetChannelMessageAt(ctx, workspaceID, conversation.channelID, userID, position.at)
+	} else {
+		message, err = server.messageService.GetDirectMessageAt(ctx, workspaceID, userID, conversation.userID, position.at)
+	}
+	if err != nil {
+		// Messages that can't be seen are reported as missing
+		if strings.HasPrefix(err.Error(), "access denied") {
+			return nil, errors.New("message not found")
+		}
+		return nil, err
+	}
+	if !conversation.contains(message, userID) || message.DeletedAt != nil {
+		return nil, errors.New("message not found")
+	}
+	return message, nil
+}
+
+// @Summary Slack auth.test
+// @Description Slack-compatible auth.test: check the token and return the user and workspace it acts as
+// @ID slackAuthTest
+// @Tags slack
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/auth.test [post]
+func (server *Server) slackAuthTest(ctx *gin.Context) {
+	user, workspaceID := slackCaller(ctx)
+
+	workspace, err := server.workspaceService.GetWorkspace(ctx, workspaceID)
+	if err != nil {
+		slackFail(ctx, slackErrorCode(err, "team_not_found", "team_access_not_granted"))
+		return
+	}
+
+	slackOK(ctx, gin.H{
+		"url":     server.config.AppBaseURL,
+		"team":    workspace.Name,
+		"user":    toSlackUser(user).Name,
+		"team_id": slackID("T", publicid.Workspace, workspaceID),
+		"user_id": slackID("U", publicid.User, user.ID),
+	})
+}
+
+type slackPostMessageRequest struct {
+	Channel  string `form:"channel" json:"channel"`
+	Text     string `form:"text" json:"text"`
+	ThreadTS string `form:"thread_ts" json:"thread_ts"`
+}
+
+// @Summary Slack chat.postMessage
+// @Description Slack-compatible chat.postMessage: send a text message to a channel (C…), or to a user (U… or D…) as a direct message. Slack mentions in the text are turned into goslack mentions. Thread replies aren't supported.
+// @ID slackPostMessage
+// @Tags slack
+// @Security BearerAuth
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @Param request body slackPostMessageRequest true "Message"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/chat.postMessage [post]
+func (server *Server) slackPostMessage(ctx *gin.Context) {
+	var req slackPostMessageRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		slackFail(ctx, "invalid_arguments")
+		return
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		slackFail(ctx, "no_text")
+		return
+	}
+	if req.ThreadTS != "" {
+		slackFail(ctx, "thread_replies_not_supported")
+		return
+	}
+
+	conversation, ok := parseSlackConversation(req.Channel)
+	if !ok {
+		slackFail(ctx, "channel_not_found")
+		return
+	}
+
+	user, workspaceID := slackCaller(ctx)
+	if err := server.openSlackConversation(ctx, conversation, user.ID, workspaceID); err != nil {
+		slackChannelError(ctx, err)
+		return
+	}
+
+	content := fromSlackText(req.Text)
+	if len(content) > 4000 {
+		slackFail(ctx, "msg_too_long")
+		return
+	}
+
+	var message *service.MessageResponse
+	var err error
+	if conversation.channelID != 0 {
+		message, err = server.messageService.SendChannelMessage(ctx, workspaceID, conversation.channelID, user.ID, content)
+	} else {
+		message, err = server.messageService.SendDirectMessage(ctx, workspaceID, user.ID, conversation.userID, content)
+	}
+	if err != nil {
+		slackChannelError(ctx, err)
+		return
+	}
+
+	posted := toSlackMessage(message)
+	slackOK(ctx, gin.H{
+		"channel": conversation.id(),
+		"ts":      posted.TS,
+		"message": posted,
+	})
+}
+
+type slackUpdateMessageRequest struct {
+	Channel string `form:"channel" json:"channel"`
+	TS      string `form:"ts" json:"ts"`
+	Text    string `form:"text" json:"text"`
+}
+
+// @Summary Slack chat.update
+// @Description Slack-compatible chat.update: replace the text of a message you sent
+// @ID slackUpdateMessage
+// @Tags slack
+// @Security BearerAuth
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @Param request body slackUpdateMessageRequest true "Message update"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/chat.update [post]
+func (server *Server) slackUpdateMessage(ctx *gin.Context) {
+	var req slackUpdateMessageRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		slackFail(ctx, "invalid_arguments")
+		return
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		slackFail(ctx, "no_text")
+		return
+	}
+
+	conversation, ok := parseSlackConversation(req.Channel)
+	if !ok {
+		slackFail(ctx, "channel_not_found")
+		return
+	}
+
+	user, workspaceID := slackCaller(ctx)
+	message, err := server.findSlackMessage(ctx, conversation, req.TS, user.ID, workspaceID)
+	if err != nil {
+		slackFail(ctx, slackErrorCode(err, "message_not_found", "cant_update_message"))
+		return
+	}
+
+	content := fromSlackText(req.Text)
+	if len(content) > 4000 {
+		slackFail(ctx, "msg_too_long")
+		return
+	}
+
+	message, err = server.messageService.EditMessage(ctx, message.ID, user.ID, content)
+	if err != nil {
+		slackFail(ctx, slackErrorCode(err, "message_not_found", "cant_update_message"))
+		return
+	}
+
+	updated := toSlackMessage(message)
+	slackOK(ctx, gin.H{
+		"channel": conversation.id(),
+		"ts":      updated.TS,
+		"text":    updated.Text,
+		"message": updated,
+	})
+}
+
+type slackDeleteMessageRequest struct {
+	Channel string `form:"channel" json:"channel"`
+	TS      string `form:"ts" json:"ts"`
+}
+
+// @Summary Slack chat.delete
+// @Description Slack-compatible chat.delete: delete a message you sent, or anyone's with delete_any_message permission
+// @ID slackDeleteMessage
+// @Tags slack
+// @Security BearerAuth
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @Param request body slackDeleteMessageRequest true "Message to delete"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/chat.delete [post]
+func (server *Server) slackDeleteMessage(ctx *gin.Context) {
+	var req slackDeleteMessageRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		slackFail(ctx, "invalid_arguments")
+		return
+	}
+
+	conversation, ok := parseSlackConversation(req.Channel)
+	if !ok {
+		slackFail(ctx, "channel_not_found")
+		return
+	}
+
+	user, workspaceID := slackCaller(ctx)
+	message, err := server.findSlackMessage(ctx, conversation, req.TS, user.ID, workspaceID)
+	if err != nil {
+		slackFail(ctx, slackErrorCode(err, "message_not_found", "cant_delete_message"))
+		return
+	}
+
+	if err := server.messageService.DeleteMessage(ctx, message.ID, user.ID); err != nil {
+		slackFail(ctx, slackErrorCode(err, "message_not_found", "cant_delete_message"))
+		return
+	}
+
+	slackOK(ctx, gin.H{
+		"channel": conversation.id(),
+		"ts":      slackTS(message.CreatedAt),
+	})
+}
+
+type slackListConversationsRequest struct {
+	Cursor string `form:"cursor" json:"cursor"`
+	Limit  int32  `form:"limit" json:"limit"`
+	Types  string `form:"types" json:"types"`
+}
+
+// @Summary Slack conversations.list
+// @Description Slack-compatible conversations.list: list the workspace's public channels and the private ones you're a member of. Of the types, public_channel and private_channel are answered; direct conversations aren't listed.
+// @ID slackListConversations
+// @Tags slack
+// @Security BearerAuth
+// @Produce json
+// @Param cursor query string false "Cursor from response_metadata.next_cursor"
+// @Param limit query int false "Number of channels to return (default: 100, max: 200)"
+// @Param types query string false "Comma-separated conversation types (default: public_channel)"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/conversations.list [get]
+func (server *Server) slackListConversations(ctx *gin.Context) {
+	var req slackListConversationsRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		slackFail(ctx, "invalid_arguments")
+		return
+	}
+	offset, ok := parseSlackCursor(req.Cursor)
+	if !ok {
+		slackFail(ctx, "invalid_cursor")
+		return
+	}
+
+	types := map[string]bool{}
+	for _, conversationType := range splitConfigList(req.Types) {
+		types[conversationType] = true
+	}
+	if len(types) == 0 {
+		types["public_channel"] = true
+	}
+
+	user, workspaceID := slackCaller(ctx)
+	limit := slackPageSize(req.Limit)
+	channels := []slackChannel{}
+	nextCursor := ""
+
+	if types["public_channel"] || types["private_channel"] {
+		// Private channels are only listed for their members
+		page, err := server.channelService.ListChannelsForUser(ctx, user.ID, workspaceID, limit, offset)
+		if err != nil {
+			slackChannelError(ctx, err)
+			return
+		}
+		for _, channel := range page {
+			if (channel.IsPrivate && types["private_channel"]) || (!channel.IsPrivate && types["public_channel"]) {
+				channels = append(channels, toSlackChannel(channel.ChannelResponse, channel.IsMember))
+			}
+		}
+		if len(page) == int(limit) {
+			nextCursor = slackCursor(offset + limit)
+		}
+	}
+
+	slackOK(ctx, gin.H{
+		"channels":          channels,
+		"response_metadata": gin.H{"next_cursor": nextCursor},
+	})
+}
+
+type slackConversationRequest struct {
+	Channel string `form:"channel" json:"channel"`
+}
+
+// @Summary Slack conversations.info
+// @Description Slack-compatible conversations.info: describe a channel (C…) or direct conversation (D…)
+// @ID slackConversationInfo
+// @Tags slack
+// @Security BearerAuth
+// @Produce json
+// @Param channel query string true "Conversation ID"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/conversations.info [get]
+func (server *Server) slackConversationInfo(ctx *gin.Context) {
+	var req slackConversationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		slackFail(ctx, "invalid_arguments")
+		return
+	}
+	conversation, ok := parseSlackConversation(req.Channel)
+	if !ok {
+		slackFail(ctx, "channel_not_found")
+		return
+	}
+
+	user, workspaceID := slackCaller(ctx)
+	if err := server.openSlackConversation(ctx, conversation, user.ID, workspaceID); err != nil {
+		slackChannelError(ctx, err)
+		return
+	}
+
+	if conversation.channelID == 0 {
+		slackOK(ctx, gin.H{"channel": slackChannel{
+			ID:        conversation.id(),
+			IsIM:      true,
+			IsPrivate: true,
+			IsMember:  true,
+			User:      slackID("U", publicid.User, conversation.userID),
+		}})
+		return
+	}
+
+	channel, err := server.channelService.GetChannel(ctx, conversation.channelID)
+	if err != nil {
+		slackChannelError(ctx, err)
+		return
+	}
+	isMember, err := server.channelService.IsChannelMember(ctx, user.ID, channel.ID)
+	if err != nil {
+		slackChannelError(ctx, err)
+		return
+	}
+	// As in conversations.list, private channels are only shown to members
+	if channel.IsPrivate && !isMember {
+		slackFail(ctx, "channel_not_found")
+		return
+	}
+	slackOK(ctx, gin.H{"channel": toSlackChannel(channel, isMember)})
+}
+
+type slackHistoryRequest struct {
+	Channel   string `form:"channel" json:"channel"`
+	Cursor    string `form:"cursor" json:"cursor"`
+	Limit     int32  `form:"limit" json:"limit"`
+	Oldest    string `form:"oldest" json:"oldest"`
+	Latest    string `form:"latest" json:"latest"`
+	Inclusive bool   `form:"inclusive" json:"inclusive"`
+}
+
+// @Summary Slack conversations.history
+// @Description Slack-compatible conversations.history: read a conversation's messages, newest first, optionally between two ts values. Thread replies and deleted messages are left out, as in Slack.
+// @ID slackConversationHistory
+// @Tags slack
+// @Security BearerAuth
+// @Produce json
+// @Param channel query string true "Conversation ID"
+// @Param cursor query string false "Cursor from response_metadata.next_cursor"
+// @Param limit query int false "Number of messages to return (default: 100, max: 200)"
+// @Param oldest query string false "Only messages after this ts"
+// @Param latest query string false "Only messages before this ts"
+// @Param inclusive query bool false "Include messages at oldest and latest"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/conversations.history [get]
+func (server *Server) slackConversationHistory(ctx *gin.Context) {
+	var req slackHistoryRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		slackFail(ctx, "invalid_arguments")
+		return
+	}
+	conversation, ok := parseSlackConversation(req.Channel)
+	if !ok {
+		slackFail(ctx, "channel_not_found")
+		return
+	}
+	offset, ok := parseSlackCursor(req.Cursor)
+	if !ok {
+		slackFail(ctx, "invalid_cursor")
+		return
+	}
+
+	var oldest, latest *slackTimestamp
+	if req.Oldest != "" {
+		position, ok := parseSlackTS(req.Oldest)
+		if !ok {
+			slackFail(ctx, "invalid_ts_oldest")
+			return
+		}
+		oldest = &position
+	}
+	if req.Latest != "" {
+		position, ok := parseSlackTS(req.Latest)
+		if !ok {
+			slackFail(ctx, "invalid_ts_latest")
+			return
+		}
+		latest = &position
+	}
+
+	user, workspaceID := slackCaller(ctx)
+	if err := server.openSlackConversation(ctx, conversation, user.ID, workspaceID); err != nil {
+		slackChannelError(ctx, err)
+		return
+	}
+
+	// Read newest first until the page is full or the messages reach oldest
+	limit := int(slackPageSize(req.Limit))
+	messages := []slackMessage{}
+	nextCursor := ""
+	for batch := 0; ; batch++ {
+		if batch == slackHistoryMaxBatches {
+			nextCursor = slackCursor(offset)
+			break
+		}
+
+		var page []*service.MessageResponse
+		var err error
+		if conversation.channelID != 0 {
+			page, err = server.messageService.GetChannelMessages(ctx, workspaceID, conversation.channelID, user.ID, slackHistoryBatch, offset)
+		} else {
+			page, err = server.messageService.GetDirectMessages(ctx, workspaceID, user.ID, conversation.userID, slackHistoryBatch, offset)
+		}
+		if err != nil {
+			slackChannelError(ctx, err)
+			return
+		}
+
+		done := len(page) < slackHistoryBatch
+		for i, message := range page {
+			if oldest != nil {
+				if order := oldest.compare(message); order > 0 || (order == 0 && !req.Inclusive) {
+					done = true
+					break
+				}
+			}
+			if message.ThreadID != nil || message.DeletedAt != nil {
+				continue
+			}
+			if latest != nil {
+				if order := latest.compare(message); order < 0 || (order == 0 && !req.Inclusive) {
+					continue
+				}
+			}
+			if len(messages) == limit {
+				nextCursor = slackCursor(offset + int32(i))
+				done = true
+				break
+			}
+			messages = append(messages, toSlackMessage(message))
+		}
+		offset += int32(len(page))
+
+		if done {
+			break
+		}
+	}
+
+	slackOK(ctx, gin.H{
+		"messages":          messages,
+		"has_more":          nextCursor != "",
+		"response_metadata": gin.H{"next_cursor": nextCursor},
+	})
+}
+
+type slackReactionRequest struct {
+	Channel   string `form:"channel" json:"channel"`
+	Timestamp string `form:"timestamp" json:"timestamp"`
+	Name      string `form:"name" json:"name"`
+}
+
+// @Summary Slack reactions.add
+// @Description Slack-compatible reactions.add: react to a message with an emoji
+// @ID slackAddReaction
+// @Tags slack
+// @Security BearerAuth
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @Param request body slackReactionRequest true "Reaction"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/reactions.add [post]
+func (server *Server) slackAddReaction(ctx *gin.Context) {
+	server.slackReact(ctx, server.reactionService.AddReaction)
+}
+
+// @Summary Slack reactions.remove
+// @Description Slack-compatible reactions.remove: take back your emoji reaction to a message
+// @ID slackRemoveReaction
+// @Tags slack
+// @Security BearerAuth
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @Param request body slackReactionRequest true "Reaction"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/reactions.remove [post]
+func (server *Server) slackRemoveReaction(ctx *gin.Context) {
+	server.slackReact(ctx, server.reactionService.RemoveReaction)
+}
+
+// slackReact adds or removes a reaction to the message a Slack reaction
+// request points at
+func (server *Server) slackReact(ctx *gin.Context, react func(ctx context.Context, messageID, userID int64, emoji string) ([]service.ReactionSummary, error)) {
+	var req slackReactionRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		slackFail(ctx, "invalid_arguments")
+		return
+	}
+	if req.Name == "" {
+		slackFail(ctx, "invalid_name")
+		return
+	}
+	conversation, ok := parseSlackConversation(req.Channel)
+	if !ok {
+		slackFail(ctx, "channel_not_found")
+		return
+	}
+
+	user, workspaceID := slackCaller(ctx)
+	message, err := server.findSlackMessage(ctx, conversation, req.Timestamp, user.ID, workspaceID)
+	if err != nil {
+		slackFail(ctx, slackErrorCode(err, "message_not_found", "not_in_channel"))
+		return
+	}
+
+	// Slack wraps emoji names in colons in some places
+	if _, err := react(ctx, message.ID, user.ID, strings.Trim(req.Name, ":")); err != nil {
+		var validationErr *service.ReactionValidationError
+		switch {
+		case errors.As(err, &validationErr) && validationErr.Limit > 0:
+			slackFail(ctx, "too_many_reactions")
+		case errors.As(err, &validationErr):
+			slackFail(ctx, "invalid_name")
+		case err.Error() == "reaction already exists":
+			slackFail(ctx, "already_reacted")
+		case err.Error() == "reaction not found":
+			slackFail(ctx, "no_reaction")
+		default:
+			slackFail(ctx, slackErrorCode(err, "message_not_found", "not_in_channel"))
+		}
+		return
+	}
+
+	slackOK(ctx, nil)
+}
+
+type slackUserRequest struct {
+	User string `form:"user" json:"user"`
+}
+
+// @Summary Slack users.info
+// @Description Slack-compatible users.info: describe a member of the workspace
+// @ID slackUserInfo
+// @Tags slack
+// @Security BearerAuth
+// @Produce json
+// @Param user query string true "User ID"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/users.info [get]
+func (server *Server) slackUserInfo(ctx *gin.Context) {
+	var req slackUserRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		slackFail(ctx, "invalid_arguments")
+		return
+	}
+	userID, ok := parseSlackID("U", publicid.User, req.User)
+	if !ok {
+		slackFail(ctx, "user_not_found")
+		return
+	}
+
+	_, workspaceID := slackCaller(ctx)
+	user, err := server.userService.GetUser(ctx, userID)
+	if err != nil {
+		slackFail(ctx, slackErrorCode(err, "user_not_found", "user_not_found"))
+		return
+	}
+	// Only members of the token's workspace can be looked up
+	if user.WorkspaceID == nil || *user.WorkspaceID != workspaceID {
+		slackFail(ctx, "user_not_found")
+		return
+	}
+
+	slackOK(ctx, gin.H{"user": toSlackUser(user)})
+}
+
+type slackListUsersRequest struct {
+	Cursor string `form:"cursor" json:"cursor"`
+	Limit  int32  `form:"limit" json:"limit"`
+}
+
+// @Summary Slack users.list
+// @Description Slack-compatible users.list: list the members of the workspace
+// @ID slackListUsers
+// @Tags slack
+// @Security BearerAuth
+// @Produce json
+// @Param cursor query string false "Cursor from response_metadata.next_cursor"
+// @Param limit query int false "Number of users to return (default: 100, max: 200)"
+// @Success 200 {object} map[string]interface{} "Slack response"
+// @Router /api/users.list [get]
+func (server *Server) slackListUsers(ctx *gin.Context) {
+	var req slackListUsersRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		slackFail(ctx, "invalid_arguments")
+		return
+	}
+	offset, ok := parseSlackCursor(req.Cursor)
+	if !ok {
+		slackFail(ctx, "invalid_cursor")
+		return
+	}
+
+	_, workspaceID := slackCaller(ctx)
+	limit := slackPageSize(req.Limit)
+	members, err := server.workspaceInvitationService.ListWorkspaceMembers(ctx, workspaceID, limit, offset)
+	if err != nil {
+		slackFail(ctx, slackErrorCode(err, "team_not_found", "team_access_not_granted"))
+		return
+	}
+
+	users := make([]slackUser, len(members))
+	for i, member := range members {
+		users[i] = toSlackUser(member)
+	}
+	nextCursor := ""
+	if len(members) == int(limit) {
+		nextCursor = slackCursor(offset + limit)
+	}
+
+	slackOK(ctx, gin.H{
+		"members":           users,
+		"response_metadata": gin.H{"next_cursor": nextCursor},
+	})
+}
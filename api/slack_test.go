@@ -0,0 +1,622 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/publicid"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+// slackResponse is the envelope every Slack method answers with
+type slackResponse struct {
+	OK               bool           `json:"ok"`
+	Error            string         `json:"error"`
+	UserID           string         `json:"user_id"`
+	Team             string         `json:"team"`
+	Channel          string         `json:"channel"`
+	TS               string         `json:"ts"`
+	Message          slackMessage   `json:"message"`
+	Messages         []slackMessage `json:"messages"`
+	Channels         []slackChannel `json:"channels"`
+	HasMore          bool           `json:"has_more"`
+	ResponseMetadata struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"response_metadata"`
+}
+
+func requireSlackResponse(t *testing.T, recorder *httptest.ResponseRecorder) slackResponse {
+	// Slack methods answer 200 even when they fail
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response slackResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return response
+}
+
+func TestSlackIDs(t *testing.T) {
+	id := slackID("C", publicid.Channel, 42)
+	require.True(t, strings.HasPrefix(id, "C"))
+
+	channelID, ok := parseSlackID("C", publicid.Channel, id)
+	require.True(t, ok)
+	require.Equal(t, int64(42), channelID)
+
+	conversation, ok := parseSlackConversation(slackID("U", publicid.User, 7))
+	require.True(t, ok)
+	require.Equal(t, slackConversation{userID: 7}, conversation)
+	require.Equal(t, slackID("D", publicid.User, 7), conversation.id())
+
+	_, ok = parseSlackConversation("G" + strings.TrimPrefix(id, "C"))
+	require.False(t, ok)
+}
+
+func TestSlackTimestamps(t *testing.T) {
+	createdAt := time.Unix(1700000000, 123456789)
+	require.Equal(t, "1700000000.123456", slackTS(createdAt))
+
+	position, ok := parseSlackTS("1700000000.123456")
+	require.True(t, ok)
+	require.True(t, time.Unix(1700000000, 123456000).Equal(position.at))
+
+	// Message IDs don't show in the ts, however large they grow
+	message := &service.MessageResponse{ID: 12345678, CreatedAt: createdAt}
+	require.Zero(t, position.compare(message))
+
+	// A whole second comes before every message sent during it
+	position, ok = parseSlackTS("1700000000")
+	require.True(t, ok)
+	require.Negative(t, position.compare(message))
+
+	position, ok = parseSlackTS("1700000000.5")
+	require.True(t, ok)
+	require.True(t, time.Unix(1700000000, 500000000).Equal(position.at))
+
+	_, ok = parseSlackTS("yesterday")
+	require.False(t, ok)
+	_, ok = parseSlackTS("1700000000.-1")
+	require.False(t, ok)
+}
+
+func TestSlackText(t *testing.T) {
+	mention := slackID("U", publicid.User, 7)
+
+	require.Equal(t, "hi <@7> & <b>", fromSlackText(fmt.Sprintf("hi <@%s|ada> &amp; &lt;b&gt;", mention)))
+	require.Equal(t, fmt.Sprintf("hi <@%s> &amp; &lt;b&gt;", mention), toSlackText("hi <@7> & <b>"))
+}
+
+func TestSlackCursor(t *testing.T) {
+	offset, ok := parseSlackCursor(slackCursor(300))
+	require.True(t, ok)
+	require.Equal(t, int32(300), offset)
+
+	offset, ok = parseSlackCursor("")
+	require.True(t, ok)
+	require.Zero(t, offset)
+
+	_, ok = parseSlackCursor("not a cursor")
+	require.False(t, ok)
+}
+
+func TestSlackAuthTestAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	t.Run("NotAuthed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := newTestServer(t, mockdb.NewMockStore(ctrl))
+		recorder := httptest.NewRecorder()
+
+		request, err := http.NewRequest(http.MethodPost, "/api/auth.test", nil)
+		require.NoError(t, err)
+		server.router.ServeHTTP(recorder, request)
+
+		response := requireSlackResponse(t, recorder)
+		require.False(t, response.OK)
+		require.Equal(t, "not_authed", response.Error)
+	})
+
+	t.Run("TokenParameter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+		store.EXPECT().GetWorkspaceByID(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(workspace, nil)
+
+		server := newTestServer(t, store)
+		recorder := httptest.NewRecorder()
+
+		accessToken, _, err := server.tokenMaker.CreateToken(user.Email, time.Minute)
+		require.NoError(t, err)
+		form := url.Values{"token": {accessToken}}
+		request, err := http.NewRequest(http.MethodPost, "/api/auth.test", strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		server.router.ServeHTTP(recorder, request)
+
+		response := requireSlackResponse(t, recorder)
+		require.True(t, response.OK)
+		require.Equal(t, workspace.Name, response.Team)
+		require.Equal(t, slackID("U", publicid.User, user.ID), response.UserID)
+	})
+}
+
+func TestSlackPostMessageAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.Role = "member"
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	channel := randomChannel(workspace.ID, user.ID)
+	otherChannel := randomChannel(workspace.ID+1, user.ID)
+	otherChannel.ID = channel.ID + 1
+	mentioned := user.ID + 1
+
+	testCases := []struct {
+		name          string
+		body          func() map[string]any
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(response slackResponse)
+	}{
+		{
+			name: "OK",
+			body: func() map[string]any {
+				return map[string]any{
+					"channel": slackID("C", publicid.Channel, channel.ID),
+					"text":    fmt.Sprintf("<@%s> ship it", slackID("U", publicid.User, mentioned)),
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				content := fmt.Sprintf("<@%d> ship it", mentioned)
+				message := db.Message{
+					ID:          5,
+					WorkspaceID: workspace.ID,
+					ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+					SenderID:    user.ID,
+					Content:     content,
+					MessageType: "channel",
+					CreatedAt:   time.Unix(1700000000, 250000000),
+				}
+
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).AnyTimes().Return(channel, nil)
+				store.EXPECT().ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return([]db.ModerationWord{}, nil)
+				store.EXPECT().ListWorkspaceMemberIDs(gomock.Any(), gomock.Any()).Times(1).Return([]int64{mentioned}, nil)
+				store.EXPECT().
+					CreateMessageTx(gomock.Any(), EqChannelMessageTx(db.CreateChannelMessageParams{
+						WorkspaceID: workspace.ID,
+						ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+						SenderID:    user.ID,
+						Content:     content,
+						ContentType: "text",
+					})).
+					Times(1).
+					DoAndReturn(createMessageTx(message))
+				store.EXPECT().MarkOutboxEventPublished(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+			},
+			checkResponse: func(response slackResponse) {
+				require.True(t, response.OK)
+				require.Equal(t, slackID("C", publicid.Channel, channel.ID), response.Channel)
+				require.Equal(t, "1700000000.250000", response.TS)
+				require.Equal(t, response.TS, response.Message.TS)
+				require.Equal(t, slackID("U", publicid.User, user.ID), response.Message.User)
+				require.Equal(t, fmt.Sprintf("<@%s> ship it", slackID("U", publicid.User, mentioned)), response.Message.Text)
+			},
+		},
+		{
+			name: "ThreadReply",
+			body: func() map[string]any {
+				return map[string]any{
+					"channel":   slackID("C", publicid.Channel, channel.ID),
+					"text":      "in a thread",
+					"thread_ts": "1700000000.000001",
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateMessageTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(response slackResponse) {
+				require.False(t, response.OK)
+				require.Equal(t, "thread_replies_not_supported", response.Error)
+			},
+		},
+		{
+			name: "OtherWorkspace",
+			body: func() map[string]any {
+				return map[string]any{
+					"channel": slackID("C", publicid.Channel, otherChannel.ID),
+					"text":    "hello",
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(otherChannel.ID)).Times(1).Return(otherChannel, nil)
+				store.EXPECT().CreateMessageTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(response slackResponse) {
+				require.False(t, response.OK)
+				require.Equal(t, "channel_not_found", response.Error)
+			},
+		},
+		{
+			name: "NoText",
+			body: func() map[string]any {
+				return map[string]any{
+					"channel": slackID("C", publicid.Channel, channel.ID),
+					"text":    "  ",
+				}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateMessageTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(response slackResponse) {
+				require.False(t, response.OK)
+				require.Equal(t, "no_text", response.Error)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return(user.Role, nil)
+			expectDefaultWorkspaceSettings(store)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body())
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/api/chat.postMessage", strings.NewReader(string(data)))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(requireSlackResponse(t, recorder))
+		})
+	}
+}
+
+func TestSlackConversationHistoryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.Role = "member"
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	channel := randomChannel(workspace.ID, user.ID)
+	channel.IsPrivate = false
+
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	newMessage := func(id int64) db.GetChannelMessagesRow {
+		return db.GetChannelMessagesRow{
+			ID:          id,
+			WorkspaceID: workspace.ID,
+			ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+			SenderID:    user.ID,
+			Content:     fmt.Sprintf("message %d", id),
+			MessageType: "channel",
+			CreatedAt:   createdAt.Add(time.Duration(id) * time.Minute),
+		}
+	}
+	reply := newMessage(4)
+	reply.ThreadID = sql.NullInt64{Int64: 1, Valid: true}
+	deleted := newMessage(3)
+	deleted.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	messages := []db.GetChannelMessagesRow{newMessage(5), reply, deleted, newMessage(2), newMessage(1)}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return(user.Role, nil)
+	store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).AnyTimes().Return(channel, nil)
+	expectDefaultWorkspaceSettings(store)
+	expectNoMessageArchives(store)
+	store.EXPECT().
+		GetChannelMessages(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ interface{}, arg db.GetChannelMessagesParams) ([]db.GetChannelMessagesRow, error) {
+			require.Equal(t, int32(slackHistoryBatch), arg.Limit)
+			require.Zero(t, arg.Offset)
+			return messages, nil
+		})
+	store.EXPECT().ListReactionSummaries(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListReactionSummariesRow{}, nil)
+	store.EXPECT().ListMessageThreads(gomock.Any(), gomock.Any()).Times(1).Return([]db.MessageThread{}, nil)
+	store.EXPECT().ListMessageAcknowledgmentStates(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListMessageAcknowledgmentStatesRow{}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	query := url.Values{
+		"channel": {slackID("C", publicid.Channel, channel.ID)},
+		"limit":   {"2"},
+	}
+	request, err := http.NewRequest(http.MethodGet, "/api/conversations.history?"+query.Encode(), nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+
+	response := requireSlackResponse(t, recorder)
+	require.True(t, response.OK)
+	require.Len(t, response.Messages, 2)
+	require.Equal(t, "message 5", response.Messages[0].Text)
+	require.Equal(t, "message 2", response.Messages[1].Text)
+
+	// The next page starts at the first message left out
+	require.True(t, response.HasMore)
+	offset, ok := parseSlackCursor(response.ResponseMetadata.NextCursor)
+	require.True(t, ok)
+	require.Equal(t, int32(4), offset)
+}
+
+func TestSlackDeleteMessageAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	message := db.GetMessageByIDRow{
+		ID:          1234567,
+		WorkspaceID: workspace.ID,
+		SenderID:    user.ID,
+		ReceiverID:  sql.NullInt64{Int64: other.ID, Valid: true},
+		Content:     "hello",
+		MessageType: "direct",
+		CreatedAt:   createdAt,
+	}
+	lookup := db.ListDirectMessageIDsAtParams{
+		WorkspaceID: workspace.ID,
+		SenderID:    user.ID,
+		ReceiverID:  sql.NullInt64{Int64: other.ID, Valid: true},
+		CreatedAt:   createdAt,
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(response slackResponse)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListDirectMessageIDsAt(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListDirectMessageIDsAtParams) ([]int64, error) {
+						require.True(t, lookup.CreatedAt.Equal(arg.CreatedAt))
+						arg.CreatedAt = lookup.CreatedAt
+						require.Equal(t, lookup, arg)
+						return []int64{message.ID}, nil
+					})
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).AnyTimes().Return(message, nil)
+				store.EXPECT().GetWorkspaceSettings(gomock.Any(), gomock.Eq(workspace.ID)).AnyTimes().Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+				store.EXPECT().
+					SoftDeleteMessage(gomock.Any(), gomock.Eq(db.SoftDeleteMessageParams{ID: message.ID, DeletedBy: sql.NullInt64{Int64: user.ID, Valid: true}})).
+					Times(1).
+					Return(nil)
+			},
+			check: func(response slackResponse) {
+				require.True(t, response.OK)
+				require.Equal(t, slackTS(createdAt), response.TS)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListDirectMessageIDsAt(gomock.Any(), gomock.Any()).Times(1).Return([]int64{}, nil)
+				store.EXPECT().SoftDeleteMessage(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(response slackResponse) {
+				require.False(t, response.OK)
+				require.Equal(t, "message_not_found", response.Error)
+			},
+		},
+		{
+			name: "Ambiguous",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListDirectMessageIDsAt(gomock.Any(), gomock.Any()).Times(1).Return([]int64{message.ID, message.ID + 1}, nil)
+				store.EXPECT().SoftDeleteMessage(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(response slackResponse) {
+				require.False(t, response.OK)
+				require.Equal(t, "ambiguous_ts", response.Error)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(map[string]string{
+				"channel": slackID("D", publicid.User, other.ID),
+				"ts":      slackTS(createdAt),
+			})
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/api/chat.delete", strings.NewReader(string(data)))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.check(requireSlackResponse(t, recorder))
+		})
+	}
+}
+
+func TestSlackListConversationsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+	store.EXPECT().
+		ListChannelsForUser(gomock.Any(), gomock.Eq(db.ListChannelsForUserParams{
+			UserID:      user.ID,
+			WorkspaceID: workspace.ID,
+			Limit:       slackDefaultPageSize,
+		})).
+		Times(1).
+		Return([]db.ListChannelsForUserRow{
+			{ID: 1, WorkspaceID: workspace.ID, Name: "general", IsMember: true},
+			{ID: 2, WorkspaceID: workspace.ID, Name: "random"},
+			{ID: 3, WorkspaceID: workspace.ID, Name: "leadership", IsPrivate: true, IsMember: true},
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	query := url.Values{"types": {"public_channel,private_channel"}}
+	request, err := http.NewRequest(http.MethodGet, "/api/conversations.list?"+query.Encode(), nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+
+	response := requireSlackResponse(t, recorder)
+	require.True(t, response.OK)
+	require.Len(t, response.Channels, 3)
+	require.True(t, response.Channels[0].IsMember)
+	require.Equal(t, "random", response.Channels[1].Name)
+	require.False(t, response.Channels[1].IsMember)
+	require.True(t, response.Channels[2].IsPrivate)
+	require.True(t, response.Channels[2].IsMember)
+	require.Empty(t, response.ResponseMetadata.NextCursor)
+}
+
+func TestSlackConversationInfoAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.Role = "member"
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	channel := randomChannel(workspace.ID, user.ID)
+	channel.IsPrivate = true
+
+	testCases := []struct {
+		name     string
+		isMember bool
+		check    func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Member",
+			isMember: true,
+			check: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					OK      bool         `json:"ok"`
+					Channel slackChannel `json:"channel"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.True(t, response.OK)
+				require.True(t, response.Channel.IsPrivate)
+				require.True(t, response.Channel.IsMember)
+			},
+		},
+		{
+			name: "PrivateChannelOfOthers",
+			check: func(recorder *httptest.ResponseRecorder) {
+				response := requireSlackResponse(t, recorder)
+				require.False(t, response.OK)
+				require.Equal(t, "channel_not_found", response.Error)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return(user.Role, nil)
+			store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).AnyTimes().Return(channel, nil)
+			store.EXPECT().
+				IsChannelMember(gomock.Any(), gomock.Eq(db.IsChannelMemberParams{ChannelID: channel.ID, UserID: user.ID})).
+				Times(1).
+				Return(tc.isMember, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			query := url.Values{"channel": {slackID("C", publicid.Channel, channel.ID)}}
+			request, err := http.NewRequest(http.MethodGet, "/api/conversations.info?"+query.Encode(), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.check(recorder)
+		})
+	}
+}
+
+func TestSlackUserInfoAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.Role = "member"
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	stranger, _ := randomUser(t)
+	stranger.ID = user.ID + 1
+	stranger.WorkspaceID = sql.NullInt64{Int64: workspace.ID + 1, Valid: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(stranger.ID)).AnyTimes().Return(stranger, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := "/api/users.info?user=" + slackID("U", publicid.User, stranger.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+
+	response := requireSlackResponse(t, recorder)
+	require.False(t, response.OK)
+	require.Equal(t, "user_not_found", response.Error)
+}
@@ -4,13 +4,15 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/heyrmi/goslack/service"
 )
 
 // @Summary Update User Status
-// @Description Update user's online status in a workspace (requires workspace membership)
+// @Description Update user's online status, custom status and status emoji in a workspace (requires workspace membership). The custom status and emoji are cleared automatically at clear_after when it is set.
+// @ID updateUserStatus
 // @Tags status
 // @Security BearerAuth
 // @Accept json
@@ -26,7 +28,7 @@ import (
 func (server *Server) updateUserStatus(ctx *gin.Context) {
 	var req service.UpdateUserStatusRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -34,7 +36,7 @@ func (server *Server) updateUserStatus(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
 		return
 	}
 
@@ -42,9 +44,13 @@ func (server *Server) updateUserStatus(ctx *gin.Context) {
 	currentUser := getCurrentUser(ctx)
 
 	// Update status
-	status, err := server.statusService.SetUserStatus(ctx, currentUser.ID, workspaceID, req.Status, req.CustomStatus)
+	status, err := server.statusService.SetUserStatus(ctx, currentUser.ID, workspaceID, req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if strings.HasPrefix(err.Error(), "clear_after") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -53,6 +59,7 @@ func (server *Server) updateUserStatus(ctx *gin.Context) {
 
 // @Summary Get User Status
 // @Description Get a specific user's status in a workspace (requires workspace membership)
+// @ID getUserStatus
 // @Tags status
 // @Security BearerAuth
 // @Produce json
@@ -69,7 +76,7 @@ func (server *Server) getUserStatus(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
 		return
 	}
 
@@ -77,14 +84,14 @@ func (server *Server) getUserStatus(ctx *gin.Context) {
 	userIDStr := ctx.Param("user_id")
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid user ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid user ID")))
 		return
 	}
 
 	// Get status
 	status, err := server.statusService.GetUserStatus(ctx, userID, workspaceID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -93,13 +100,14 @@ func (server *Server) getUserStatus(ctx *gin.Context) {
 
 // @Summary Get Workspace User Statuses
 // @Description Get all user statuses in a workspace (requires workspace membership)
+// @ID getWorkspaceUserStatuses
 // @Tags status
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "Workspace ID"
 // @Param limit query int false "Number of statuses to retrieve (default: 50, max: 100)" minimum(1) maximum(100)
 // @Param offset query int false "Number of statuses to skip (default: 0)" minimum(0)
-// @Success 200 {object} map[string]interface{} "Workspace user statuses"
+// @Success 200 {object} object{statuses=[]service.UserStatusResponse} "Workspace user statuses"
 // @Failure 400 {object} map[string]string "Invalid request or workspace ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Workspace membership required"
@@ -108,7 +116,7 @@ func (server *Server) getUserStatus(ctx *gin.Context) {
 func (server *Server) getWorkspaceUserStatuses(ctx *gin.Context) {
 	var req service.GetMessagesRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -116,14 +124,14 @@ func (server *Server) getWorkspaceUserStatuses(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
 		return
 	}
 
 	// Get statuses
 	statuses, err := server.statusService.GetWorkspaceUserStatuses(ctx, workspaceID, req.Limit, req.Offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -132,6 +140,7 @@ func (server *Server) getWorkspaceUserStatuses(ctx *gin.Context) {
 
 // @Summary Update User Activity
 // @Description Update user's last activity timestamp (requires workspace membership)
+// @ID updateUserActivity
 // @Tags status
 // @Security BearerAuth
 // @Produce json
@@ -147,7 +156,7 @@ func (server *Server) updateUserActivity(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
 		return
 	}
 
@@ -157,9 +166,83 @@ func (server *Server) updateUserActivity(ctx *gin.Context) {
 	// Update activity
 	err = server.statusService.UpdateUserActivity(ctx, currentUser.ID, workspaceID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Activity updated successfully"})
 }
+
+// @Summary Get Presence Settings
+// @Description Get the inactivity thresholds after which users in the workspace are marked away and offline (requires workspace admin)
+// @ID getPresenceSettings
+// @Tags status
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.PresenceSettingsResponse "Presence settings"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/settings/presence [get]
+func (server *Server) getPresenceSettings(ctx *gin.Context) {
+	// Get workspace ID from URL
+	workspaceIDStr := ctx.Param("id")
+	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	settings, err := server.statusService.GetPresenceSettings(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// @Summary Update Presence Settings
+// @Description Set the inactivity thresholds after which users in the workspace are marked away and offline. Omitted thresholds use the server default. (requires workspace admin)
+// @ID updatePresenceSettings
+// @Tags status
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param settings body service.UpdatePresenceSettingsRequest true "Inactivity thresholds in minutes"
+// @Success 200 {object} service.PresenceSettingsResponse "Presence settings updated"
+// @Failure 400 {object} map[string]string "Invalid request or thresholds"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/settings/presence [put]
+func (server *Server) updatePresenceSettings(ctx *gin.Context) {
+	var req service.UpdatePresenceSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	// Get workspace ID from URL
+	workspaceIDStr := ctx.Param("id")
+	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	settings, err := server.statusService.UpdatePresenceSettings(ctx, workspaceID, req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "away threshold") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
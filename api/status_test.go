@@ -14,6 +14,7 @@ import (
 	"github.com/golang/mock/gomock"
 	mockdb "github.com/heyrmi/goslack/db/mock"
 	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
 	"github.com/heyrmi/goslack/token"
 	"github.com/heyrmi/goslack/util"
 	"github.com/stretchr/testify/require"
@@ -76,6 +77,11 @@ func TestUpdateUserStatusAPI(t *testing.T) {
 					GetUser(gomock.Any(), gomock.Eq(user.ID)).
 					Times(1).
 					Return(user, nil)
+
+				store.EXPECT().
+					GetDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetDoNotDisturbRow{}, sql.ErrNoRows)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -108,6 +114,36 @@ func TestUpdateUserStatusAPI(t *testing.T) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name: "ClearAfterInPast",
+			body: gin.H{
+				"status":        "busy",
+				"custom_status": "In a meeting",
+				"status_emoji":  ":calendar:",
+				"clear_after":   time.Now().Add(-time.Hour).Format(time.RFC3339),
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user.Role, nil)
+
+				store.EXPECT().
+					UpsertUserStatus(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "NoAuthorization",
 			body: gin.H{
@@ -204,9 +240,24 @@ func TestGetUserStatusAPI(t *testing.T) {
 					GetUser(gomock.Any(), gomock.Eq(user.ID)).
 					Times(1).
 					Return(user, nil)
+
+				// Snoozed users show with a z-z icon
+				store.EXPECT().
+					GetDoNotDisturb(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.GetDoNotDisturbRow{
+						UserID:   user.ID,
+						DndUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+						Timezone: "UTC",
+					}, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.UserStatusResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.NotNil(t, response.DNDUntil)
 			},
 		},
 		{
@@ -337,3 +388,124 @@ func randomUserStatus(userID, workspaceID int64) db.UserStatus {
 		UpdatedAt:    time.Now(),
 	}
 }
+
+func TestUpdatePresenceSettingsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	// Make user an admin of the workspace
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "admin"
+
+	testCases := []struct {
+		name          string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: "admin",
+			body: gin.H{
+				"away_after_minutes":    10,
+				"offline_after_minutes": 60,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertWorkspacePresenceSettingsParams{
+					WorkspaceID:         workspace.ID,
+					AwayAfterMinutes:    sql.NullInt32{Int32: 10, Valid: true},
+					OfflineAfterMinutes: sql.NullInt32{Int32: 60, Valid: true},
+				}
+				store.EXPECT().
+					UpsertWorkspacePresenceSettings(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.WorkspaceSetting{
+						WorkspaceID:         workspace.ID,
+						AwayAfterMinutes:    arg.AwayAfterMinutes,
+						OfflineAfterMinutes: arg.OfflineAfterMinutes,
+						UpdatedAt:           time.Now(),
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.PresenceSettingsResponse
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, int32(10), *response.EffectiveAwayAfterMinutes)
+				require.Equal(t, int32(60), response.EffectiveOfflineAfterMinutes)
+			},
+		},
+		{
+			name: "AwayNotShorterThanOffline",
+			role: "admin",
+			body: gin.H{
+				"away_after_minutes":    60,
+				"offline_after_minutes": 30,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertWorkspacePresenceSettings(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotAdmin",
+			role: "member",
+			body: gin.H{
+				"offline_after_minutes": 60,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				// Members without a custom role hold no permissions
+				store.EXPECT().
+					GetUserRolePermissions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				store.EXPECT().
+					UpsertWorkspacePresenceSettings(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(tc.role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/settings/presence", workspace.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/util"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// validateTLSConfig checks that TLS is configured either with a certificate and
+// key or with ACME hostnames, but not both
+func validateTLSConfig(config util.Config) error {
+	hasCert := config.TLSCertFile != "" || config.TLSKeyFile != ""
+	if hasCert && (config.TLSCertFile == "" || config.TLSKeyFile == "") {
+		return errors.New("invalid TLS configuration: both TLS_CERT_FILE and TLS_KEY_FILE are required")
+	}
+	if hasCert && len(splitConfigList(config.TLSAutocertHosts)) > 0 {
+		return errors.New("invalid TLS configuration: use either a certificate or TLS_AUTOCERT_HOSTS, not both")
+	}
+	return nil
+}
+
+// tlsEnabled reports whether the server terminates TLS itself
+func tlsEnabled(config util.Config) bool {
+	return config.TLSCertFile != "" || len(splitConfigList(config.TLSAutocertHosts)) > 0
+}
+
+// hstsMiddleware tells browsers to only use HTTPS for the configured duration
+func hstsMiddleware(maxAge time.Duration) gin.HandlerFunc {
+	value := fmt.Sprintf("max-age=%d; includeSubDomains", int64(maxAge.Seconds()))
+	return func(ctx *gin.Context) {
+		ctx.Header("Strict-Transport-Security", value)
+		ctx.Next()
+	}
+}
+
+// httpsRedirectHandler redirects plain HTTP requests to the HTTPS server
+// listening on httpsAddress
+func httpsRedirectHandler(httpsAddress string) http.Handler {
+	httpsPort := ""
+	if _, port, err := net.SplitHostPort(httpsAddress); err == nil && port != "443" {
+		httpsPort = port
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(r.Host); err == nil {
+			host = h
+		}
+		if httpsPort != "" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+	})
+}
+
+// listenAndServe serves the router on address, terminating TLS when a
+// certificate or ACME hostnames are configured
+func (server *Server) listenAndServe(address string) error {
+	if !tlsEnabled(server.config) {
+		return server.router.Run(address)
+	}
+
+	httpServer := &http.Server{
+		Addr:    address,
+		Handler: server.router,
+		TLSConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		},
+	}
+	redirectHandler := httpsRedirectHandler(address)
+
+	autocertHosts := splitConfigList(server.config.TLSAutocertHosts)
+	if len(autocertHosts) > 0 {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(autocertHosts...),
+			Cache:      autocert.DirCache(server.config.TLSAutocertCacheDir),
+			Email:      server.config.TLSAutocertEmail,
+		}
+		httpServer.TLSConfig = manager.TLSConfig()
+		httpServer.TLSConfig.MinVersion = tls.VersionTLS12
+
+		// The HTTP listener also answers ACME HTTP-01 challenges
+		redirectHandler = manager.HTTPHandler(redirectHandler)
+	}
+
+	if server.config.HTTPRedirectAddress != "" {
+		go func() {
+			log.Printf("Redirecting HTTP on %s to HTTPS", server.config.HTTPRedirectAddress)
+			err := http.ListenAndServe(server.config.HTTPRedirectAddress, redirectHandler)
+			if err != nil {
+				log.Printf("HTTP redirect server stopped: %v", err)
+			}
+		}()
+	}
+
+	log.Printf("Listening and serving HTTPS on %s", address)
+	if len(autocertHosts) > 0 {
+		return httpServer.ListenAndServeTLS("", "")
+	}
+	return httpServer.ListenAndServeTLS(server.config.TLSCertFile, server.config.TLSKeyFile)
+}
+
+// hstsEnabled reports whether responses carry a Strict-Transport-Security header
+func hstsEnabled(config util.Config) bool {
+	return tlsEnabled(config) && config.HSTSMaxAge > 0
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTLSConfig(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    util.Config
+		expectErr bool
+	}{
+		{name: "Disabled", config: util.Config{}},
+		{name: "Certificate", config: util.Config{TLSCertFile: "tls.crt", TLSKeyFile: "tls.key"}},
+		{name: "Autocert", config: util.Config{TLSAutocertHosts: "chat.example.com"}},
+		{name: "MissingKey", config: util.Config{TLSCertFile: "tls.crt"}, expectErr: true},
+		{name: "CertificateAndAutocert", config: util.Config{TLSCertFile: "tls.crt", TLSKeyFile: "tls.key", TLSAutocertHosts: "chat.example.com"}, expectErr: true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTLSConfig(tc.config)
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPSRedirectHandler(t *testing.T) {
+	testCases := []struct {
+		name         string
+		httpsAddress string
+		host         string
+		path         string
+		expected     string
+	}{
+		{
+			name:         "DefaultPort",
+			httpsAddress: "0.0.0.0:443",
+			host:         "chat.example.com",
+			path:         "/workspaces?page_id=1",
+			expected:     "https://chat.example.com/workspaces?page_id=1",
+		},
+		{
+			name:         "CustomPort",
+			httpsAddress: "0.0.0.0:8443",
+			host:         "chat.example.com:8080",
+			path:         "/api/info",
+			expected:     "https://chat.example.com:8443/api/info",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+			request.Host = tc.host
+
+			httpsRedirectHandler(tc.httpsAddress).ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusMovedPermanently, recorder.Code)
+			require.Equal(t, tc.expected, recorder.Header().Get("Location"))
+		})
+	}
+}
+
+func TestHSTSHeader(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   util.Config
+		expected string
+	}{
+		{
+			name:     "TLSEnabled",
+			config:   util.Config{TLSAutocertHosts: "chat.example.com", HSTSMaxAge: 24 * time.Hour},
+			expected: "max-age=86400; includeSubDomains",
+		},
+		{
+			name:   "TLSDisabled",
+			config: util.Config{HSTSMaxAge: 24 * time.Hour},
+		},
+		{
+			name:   "HSTSDisabled",
+			config: util.Config{TLSAutocertHosts: "chat.example.com"},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc.config.TokenSymmetricKey = util.RandomString(32)
+			server, err := NewServer(tc.config, mockdb.NewMockStore(ctrl))
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/api/info", nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Equal(t, tc.expected, recorder.Header().Get("Strict-Transport-Security"))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/token"
+	"github.com/heyrmi/goslack/util"
+)
+
+// newTokenMaker creates the token maker for the format set in the configuration
+func newTokenMaker(config util.Config) (token.Maker, error) {
+	switch config.TokenFormat {
+	case "", token.FormatPASETO:
+		// Tokens issued before the cutover may lack an audience and scopes, for
+		// as long as an access token lives
+		legacy := token.LegacyTokens{MaxAge: config.AccessTokenDuration}
+		if config.TokenLegacyCutover != "" {
+			cutover, err := time.Parse(time.RFC3339, config.TokenLegacyCutover)
+			if err != nil {
+				return nil, fmt.Errorf("invalid token configuration: TOKEN_LEGACY_CUTOVER must be an RFC 3339 time: %w", err)
+			}
+			legacy.Cutover = cutover
+		}
+		return token.NewPasetoMaker(config.TokenSymmetricKey, config.TokenAudience, legacy)
+	case token.FormatJWT:
+		var keyPEMs [][]byte
+		for _, path := range splitConfigList(config.JWTKeyFiles) {
+			keyPEM, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read JWT key: %w", err)
+			}
+			keyPEMs = append(keyPEMs, keyPEM)
+		}
+		return token.NewJWTMaker(keyPEMs, config.TokenAudience)
+	default:
+		return nil, fmt.Errorf("invalid token configuration: unknown format %q", config.TokenFormat)
+	}
+}
+
+// @Summary Get JSON Web Key Set
+// @Description Get the public keys access tokens are verified with, for gateways that validate tokens themselves. Keys being rotated in or out are included. Only available when the server issues JWTs.
+// @ID getJWKS
+// @Tags auth
+// @Produce json
+// @Success 200 {object} token.JSONWebKeySet "Public keys"
+// @Failure 404 {object} map[string]string "Server does not issue JWTs"
+// @Router /.well-known/jwks.json [get]
+func (server *Server) getJWKS(ctx *gin.Context) {
+	provider, ok := server.tokenMaker.(token.KeySetProvider)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, errors.New("key set not found")))
+		return
+	}
+
+	// Gateways cache the keys, so a new key should be listed for longer than this before it signs
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, provider.JWKS())
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Translate Message
+// @Description Machine-translate a message into another language. The source language is detected by the translation provider. Translations are cached until the message is edited. Requires a configured translation provider and the "translation" feature flag on the message's workspace.
+// @ID translateMessage
+// @Tags messages
+// @Security BearerAuth
+// @Produce json
+// @Param message_id path int true "Message ID"
+// @Param lang query string true "Target language as a BCP 47 tag, e.g. de or pt-BR"
+// @Success 200 {object} service.MessageTranslationResponse "Translated message"
+// @Failure 400 {object} map[string]string "Invalid message ID or language"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Access denied or translation not enabled for the workspace"
+// @Failure 404 {object} map[string]string "Message not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 502 {object} map[string]string "Translation provider failed"
+// @Failure 503 {object} map[string]string "Translation is not configured"
+// @Router /messages/{message_id}/translate [post]
+func (server *Server) translateMessage(ctx *gin.Context) {
+	var req service.TranslateMessageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	messageID, err := strconv.ParseInt(ctx.Param("message_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid message ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	translation, err := server.translationService.TranslateMessage(ctx, messageID, currentUser.ID, req.Lang)
+	if err != nil {
+		switch {
+		case strings.HasPrefix(err.Error(), "invalid language"):
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		case err.Error() == "message not found":
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		case err.Error() == "translation is not configured":
+			ctx.JSON(http.StatusServiceUnavailable, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "failed to translate message"):
+			ctx.JSON(http.StatusBadGateway, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, translation)
+}
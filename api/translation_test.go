@@ -0,0 +1,272 @@
+package api
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateMessageAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	otherUser, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	directMessage := randomDirectMessage(workspace.ID, user.ID, otherUser.ID)
+	message := db.GetMessageByIDRow{
+		ID:          directMessage.ID,
+		WorkspaceID: directMessage.WorkspaceID,
+		SenderID:    directMessage.SenderID,
+		ReceiverID:  directMessage.ReceiverID,
+		Content:     directMessage.Content,
+		MessageType: directMessage.MessageType,
+		CreatedAt:   directMessage.CreatedAt,
+	}
+
+	contentHash := sha256.Sum256([]byte(message.Content))
+	hash := hex.EncodeToString(contentHash[:])
+
+	enabled := []db.FeatureFlag{{WorkspaceID: workspace.ID, Flag: service.FeatureTranslation, Enabled: true}}
+
+	// Fake DeepL API
+	providerCalls := 0
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		providerCalls++
+		require.Equal(t, "DeepL-Auth-Key test-key", r.Header.Get("Authorization"))
+
+		var body struct {
+			Text       []string `json:"text"`
+			TargetLang string   `json:"target_lang"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body.TargetLang == "JA" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"translations": []map[string]string{
+				{"detected_source_language": "EN", "text": "übersetzt: " + body.Text[0]},
+			},
+		})
+	}))
+	defer provider.Close()
+
+	testCases := []struct {
+		name          string
+		lang          string
+		noProvider    bool
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int)
+	}{
+		{
+			name: "OK",
+			lang: "de",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().ListFeatureFlags(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(enabled, nil)
+				store.EXPECT().
+					GetMessageTranslation(gomock.Any(), gomock.Eq(db.GetMessageTranslationParams{MessageID: message.ID, TargetLanguage: "de"})).
+					Times(1).
+					Return(db.MessageTranslation{}, sql.ErrNoRows)
+
+				arg := db.UpsertMessageTranslationParams{
+					MessageID:      message.ID,
+					TargetLanguage: "de",
+					SourceLanguage: "en",
+					ContentHash:    hash,
+					TranslatedText: "übersetzt: " + message.Content,
+					Provider:       service.TranslationProviderDeepL,
+				}
+				store.EXPECT().
+					UpsertMessageTranslation(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.MessageTranslation{
+						MessageID:      arg.MessageID,
+						TargetLanguage: arg.TargetLanguage,
+						SourceLanguage: arg.SourceLanguage,
+						ContentHash:    arg.ContentHash,
+						TranslatedText: arg.TranslatedText,
+						Provider:       arg.Provider,
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, 1, providerCalls)
+
+				var response service.MessageTranslationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, message.ID, response.MessageID)
+				require.Equal(t, "en", response.SourceLanguage)
+				require.Equal(t, "de", response.TargetLanguage)
+				require.Equal(t, "übersetzt: "+message.Content, response.TranslatedText)
+				require.False(t, response.Cached)
+			},
+		},
+		{
+			name: "Cached",
+			lang: "de",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().ListFeatureFlags(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(enabled, nil)
+				store.EXPECT().
+					GetMessageTranslation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MessageTranslation{
+						MessageID:      message.ID,
+						TargetLanguage: "de",
+						SourceLanguage: "en",
+						ContentHash:    hash,
+						TranslatedText: "zwischengespeichert",
+						Provider:       service.TranslationProviderDeepL,
+					}, nil)
+				store.EXPECT().UpsertMessageTranslation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, 0, providerCalls)
+
+				var response service.MessageTranslationResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, "zwischengespeichert", response.TranslatedText)
+				require.True(t, response.Cached)
+			},
+		},
+		{
+			name: "StaleCache",
+			lang: "de",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().ListFeatureFlags(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(enabled, nil)
+				store.EXPECT().
+					GetMessageTranslation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MessageTranslation{MessageID: message.ID, TargetLanguage: "de", ContentHash: "edited"}, nil)
+				store.EXPECT().
+					UpsertMessageTranslation(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MessageTranslation{MessageID: message.ID, TargetLanguage: "de"}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, 1, providerCalls)
+			},
+		},
+		{
+			name: "NotEnabled",
+			lang: "de",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().ListFeatureFlags(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return([]db.FeatureFlag{}, nil)
+				store.EXPECT().GetMessageTranslation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Equal(t, 0, providerCalls)
+			},
+		},
+		{
+			name:       "NotConfigured",
+			lang:       "de",
+			noProvider: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int) {
+				require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+			},
+		},
+		{
+			name: "MissingLanguage",
+			lang: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidLanguage",
+			lang: "not-a-language!",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MessageNotFound",
+			lang: "de",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(db.GetMessageByIDRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "ProviderError",
+			lang: "ja",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetMessageByID(gomock.Any(), gomock.Eq(message.ID)).Times(1).Return(message, nil)
+				store.EXPECT().ListFeatureFlags(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(enabled, nil)
+				store.EXPECT().GetMessageTranslation(gomock.Any(), gomock.Any()).Times(1).Return(db.MessageTranslation{}, sql.ErrNoRows)
+				store.EXPECT().UpsertMessageTranslation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, providerCalls int) {
+				require.Equal(t, http.StatusBadGateway, recorder.Code)
+				require.Equal(t, 1, providerCalls)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			config := util.Config{
+				TokenSymmetricKey:   util.RandomString(32),
+				AccessTokenDuration: time.Minute,
+			}
+			if !tc.noProvider {
+				config.TranslationProvider = service.TranslationProviderDeepL
+				config.TranslationAPIKey = "test-key"
+				config.TranslationAPIURL = provider.URL
+			}
+			server, err := NewServer(config, store)
+			require.NoError(t, err)
+
+			providerCalls = 0
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/messages/%d/translate?lang=%s", message.ID, tc.lang)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder, providerCalls)
+		})
+	}
+}
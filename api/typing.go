@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 // @Summary Send Typing Indicator
 // @Description Send typing indicator to a channel (requires workspace membership)
+// @ID handleTyping
 // @Tags realtime
 // @Security BearerAuth
 // @Produce json
@@ -27,7 +29,7 @@ func (server *Server) handleTyping(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid workspace ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
 		return
 	}
 
@@ -35,28 +37,169 @@ func (server *Server) handleTyping(ctx *gin.Context) {
 	channelIDStr := ctx.Param("channel_id")
 	channelID, err := strconv.ParseInt(channelIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(errors.New("invalid channel ID")))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
 		return
 	}
 
 	// Get current user
 	currentUser := getCurrentUser(ctx)
 
-	// Broadcast typing indicator
-	wsMessage := &service.WSMessage{
-		Type: "user_typing",
-		Data: map[string]interface{}{
-			"user_id": currentUser.ID,
-			"user":    currentUser,
-			"typing":  true,
-		},
-		WorkspaceID: workspaceID,
-		ChannelID:   &channelID,
-		UserID:      currentUser.ID,
-		Timestamp:   time.Now(),
+	// Track and broadcast typing indicator
+	server.hub.SetTyping(workspaceID, channelID, currentUser, true)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Typing indicator sent"})
+}
+
+// @Summary List Typing Users
+// @Description List the users typing in a channel right now (requires channel access). Typing indicators expire unless they are renewed.
+// @ID listTypingUsers
+// @Tags realtime
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Channel ID"
+// @Success 200 {array} service.TypingUserResponse "Users typing in the channel"
+// @Failure 400 {object} map[string]string "Invalid channel ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Channel access required"
+// @Failure 404 {object} map[string]string "Channel not found"
+// @Router /channels/{id}/typing [get]
+func (server *Server) listTypingUsers(ctx *gin.Context) {
+	channelID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid channel ID")))
+		return
 	}
 
-	server.hub.BroadcastToChannel(workspaceID, channelID, wsMessage)
+	currentUser := getCurrentUser(ctx)
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Typing indicator sent"})
+	if err := server.channelService.CheckChannelAccess(ctx, currentUser.ID, channelID); err != nil {
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		return
+	}
+
+	channel, err := server.channelService.GetChannel(ctx, channelID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.hub.TypingUsers(channel.WorkspaceID, channel.ID))
+}
+
+// typingState is a user typing in a channel
+type typingState struct {
+	workspaceID int64
+	user        service.UserResponse
+	startedAt   time.Time
+	expiresAt   time.Time
+}
+
+// SetTyping records that a user started or stopped typing in a channel and
+// broadcasts the change. Starting again before the indicator expires renews it.
+func (h *Hub) SetTyping(workspaceID, channelID int64, user service.UserResponse, typing bool) {
+	now := time.Now()
+	expiresAt := now.Add(h.typingTTL)
+
+	h.typingMutex.Lock()
+	h.pruneTyping(channelID, now)
+	if typing {
+		if h.typing[channelID] == nil {
+			h.typing[channelID] = make(map[int64]*typingState)
+		}
+		if state, ok := h.typing[channelID][user.ID]; ok && state.workspaceID == workspaceID {
+			state.expiresAt = expiresAt
+		} else {
+			h.typing[channelID][user.ID] = &typingState{
+				workspaceID: workspaceID,
+				user:        user,
+				startedAt:   now,
+				expiresAt:   expiresAt,
+			}
+		}
+	} else if channelTyping, ok := h.typing[channelID]; ok {
+		delete(channelTyping, user.ID)
+		if len(channelTyping) == 0 {
+			delete(h.typing, channelID)
+		}
+	}
+	h.typingMutex.Unlock()
+
+	data := gin.H{"user_id": user.ID, "user": user, "typing": typing}
+	if typing {
+		data["expires_at"] = expiresAt
+	}
+	h.BroadcastToChannel(workspaceID, channelID, &service.WSMessage{
+		Type:   WSUserTyping,
+		Data:   data,
+		UserID: user.ID,
+	})
+}
+
+// TypingUsers lists the users typing in a channel of a workspace, the
+// longest typing first
+func (h *Hub) TypingUsers(workspaceID, channelID int64) []service.TypingUserResponse {
+	h.typingMutex.Lock()
+	defer h.typingMutex.Unlock()
+
+	h.pruneTyping(channelID, time.Now())
+
+	users := []service.TypingUserResponse{}
+	for _, state := range h.typing[channelID] {
+		if state.workspaceID != workspaceID {
+			continue
+		}
+		users = append(users, service.TypingUserResponse{
+			UserID:    state.user.ID,
+			User:      state.user,
+			StartedAt: state.startedAt,
+			ExpiresAt: state.expiresAt,
+		})
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].StartedAt.Equal(users[j].StartedAt) {
+			return users[i].UserID < users[j].UserID
+		}
+		return users[i].StartedAt.Before(users[j].StartedAt)
+	})
+	return users
+}
+
+// clearTyping stops a user's typing indicators in every channel, e.g. when
+// their last connection closes
+func (h *Hub) clearTyping(userID int64) {
+	type stopped struct {
+		workspaceID int64
+		channelID   int64
+		user        service.UserResponse
+	}
+
+	var channels []stopped
+	h.typingMutex.Lock()
+	for channelID, channelTyping := range h.typing {
+		if state, ok := channelTyping[userID]; ok {
+			channels = append(channels, stopped{state.workspaceID, channelID, state.user})
+		}
+	}
+	h.typingMutex.Unlock()
+
+	for _, channel := range channels {
+		h.SetTyping(channel.workspaceID, channel.channelID, channel.user, false)
+	}
+}
+
+// pruneTyping drops expired typing indicators of a channel. The caller holds
+// typingMutex.
+func (h *Hub) pruneTyping(channelID int64, now time.Time) {
+	channelTyping, ok := h.typing[channelID]
+	if !ok {
+		return
+	}
+	for userID, state := range channelTyping {
+		if !now.Before(state.expiresAt) {
+			delete(channelTyping, userID)
+		}
+	}
+	if len(channelTyping) == 0 {
+		delete(h.typing, channelID)
+	}
 }
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubTypingState(t *testing.T) {
+	hub := NewHub(util.Config{WSTypingTTL: 50 * time.Millisecond})
+
+	ada := service.UserResponse{ID: 1, FirstName: "Ada"}
+	grace := service.UserResponse{ID: 2, FirstName: "Grace"}
+
+	hub.SetTyping(10, 100, ada, true)
+	hub.SetTyping(10, 100, grace, true)
+
+	typing := hub.TypingUsers(10, 100)
+	require.Len(t, typing, 2)
+	require.Equal(t, ada.ID, typing[0].UserID)
+	require.Equal(t, grace.ID, typing[1].UserID)
+
+	// Another workspace can't see the channel's typers
+	require.Empty(t, hub.TypingUsers(20, 100))
+
+	hub.SetTyping(10, 100, grace, false)
+	typing = hub.TypingUsers(10, 100)
+	require.Len(t, typing, 1)
+	require.Equal(t, ada.ID, typing[0].UserID)
+
+	// Indicators expire unless they are renewed
+	time.Sleep(60 * time.Millisecond)
+	require.Empty(t, hub.TypingUsers(10, 100))
+
+	hub.SetTyping(10, 100, ada, true)
+	hub.clearTyping(ada.ID)
+	require.Empty(t, hub.TypingUsers(10, 100))
+}
+
+func TestListTypingUsersAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	channel := randomChannel(workspace.ID, user.ID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		role          string
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: "member",
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var typing []service.TypingUserResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &typing))
+				require.Len(t, typing, 1)
+				require.Equal(t, int64(42), typing[0].UserID)
+				require.Equal(t, "Grace", typing[0].User.FirstName)
+			},
+		},
+		{
+			name: "NoAccess",
+			role: "none",
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).AnyTimes().Return(channel, nil)
+			if tc.role == "none" {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("", sql.ErrNoRows)
+			} else {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return(tc.role, nil)
+			}
+
+			server := newTestServer(t, store)
+			server.hub.SetTyping(workspace.ID, channel.ID, service.UserResponse{ID: 42, FirstName: "Grace"}, true)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/channels/%d/typing", channel.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -1,16 +1,18 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	db "github.com/heyrmi/goslack/db/sqlc"
 	"github.com/heyrmi/goslack/service"
 	"github.com/heyrmi/goslack/token"
-	"github.com/lib/pq"
 )
 
 // @Summary Create User
-// @Description Register a new user in an organization
+// @Description Register a new user in an organization. An email with a link that verifies the address is sent to the user.
+// @ID createUser
 // @Tags users
 // @Accept json
 // @Produce json
@@ -23,31 +25,93 @@ import (
 func (server *Server) createUser(ctx *gin.Context) {
 	var req service.CreateUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	user, err := server.userService.CreateUser(ctx, req)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			case "foreign_key_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		case db.ForeignKeyViolation:
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
+	// Registration succeeds even if the email can't be sent, it can be resent later
+	if err := server.emailVerificationService.SendVerificationEmail(ctx, user.ID); err != nil {
+		fmt.Printf("Error sending verification email to user %d: %v\n", user.ID, err)
+	}
+
 	ctx.JSON(http.StatusOK, user)
 }
 
+// @Summary Verify Email
+// @Description Verify the email address a verification link was sent to. Links expire after 24 hours and stop working when the user changes their email.
+// @ID verifyEmail
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body service.VerifyEmailRequest true "Verification token from the email"
+// @Success 200 {object} map[string]string "Email verified"
+// @Failure 400 {object} map[string]string "Invalid or expired verification token"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/verify-email [post]
+func (server *Server) verifyEmail(ctx *gin.Context) {
+	var req service.VerifyEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	err := server.emailVerificationService.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		if err.Error() == "invalid or expired verification token" {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// @Summary Resend Verification Email
+// @Description Send the current user a new link that verifies their email address
+// @ID resendVerificationEmail
+// @Tags users
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} map[string]string "Verification email sent"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 409 {object} map[string]string "Email is already verified"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /users/verify-email/resend [post]
+func (server *Server) resendVerificationEmail(ctx *gin.Context) {
+	currentUser := getCurrentUser(ctx)
+
+	err := server.emailVerificationService.SendVerificationEmail(ctx, currentUser.ID)
+	if err != nil {
+		if err.Error() == "email is already verified" {
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Verification email sent successfully"})
+}
+
 // @Summary User Login
-// @Description Authenticate a user and receive access token
+// @Description Authenticate a user and receive access token. Every attempt is recorded in the login audit with the device it came from; device_trusted tells whether the device skips two-factor authentication.
+// @ID loginUser
 // @Tags users
 // @Accept json
 // @Produce json
@@ -59,21 +123,45 @@ func (server *Server) createUser(ctx *gin.Context) {
 func (server *Server) loginUser(ctx *gin.Context) {
 	var req service.LoginUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
-	user, err := server.userService.LoginUser(ctx, req)
+	attempt := service.LoginAttempt{
+		Email:     req.Email,
+		DeviceID:  req.DeviceID,
+		IPAddress: ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
+
+	rsp, err := server.userService.LoginUser(ctx, req)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		switch err.Error() {
+		case "user not found":
+			attempt.FailureReason = service.LoginFailureUnknownUser
+		case "incorrect password":
+			attempt.FailureReason = service.LoginFailureIncorrectPassword
+		}
+		if attempt.FailureReason != "" {
+			server.recordLogin(ctx, attempt)
+		}
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ctx, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, user)
+	attempt.UserID = rsp.User.ID
+	attempt.Success = true
+	if device := server.recordLogin(ctx, attempt); device != nil {
+		rsp.DeviceID = device.ID
+		rsp.DeviceTrusted = device.Trusted
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
 }
 
 // @Summary Get User
 // @Description Retrieve user information by ID (requires authentication)
+// @ID getUser
 // @Tags users
 // @Security BearerAuth
 // @Produce json
@@ -87,14 +175,14 @@ func (server *Server) loginUser(ctx *gin.Context) {
 func (server *Server) getUser(ctx *gin.Context) {
 	var req getUserRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	user, err := server.userService.GetUserByEmail(ctx, authPayload.Username)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 		return
 	}
 
@@ -104,12 +192,12 @@ func (server *Server) getUser(ctx *gin.Context) {
 		// For now, we allow users to view other users in the same organization
 		requestedUser, err := server.userService.GetUser(ctx, req.ID)
 		if err != nil {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 			return
 		}
 
 		if requestedUser.OrganizationID != user.OrganizationID {
-			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
 			return
 		}
 
@@ -121,7 +209,8 @@ func (server *Server) getUser(ctx *gin.Context) {
 }
 
 // @Summary Update User Profile
-// @Description Update user profile information (users can only update their own profile)
+// @Description Update user profile information (users can only update their own profile). Handle, title, pronouns, phone and timezone keep their current value when left out. Handles are lowercase letters, digits, dots, dashes and underscores, unique within the organization. The timezone is an IANA name such as "Europe/Berlin".
+// @ID updateUserProfile
 // @Tags users
 // @Security BearerAuth
 // @Accept json
@@ -133,37 +222,45 @@ func (server *Server) getUser(ctx *gin.Context) {
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Can only update own profile"
 // @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Handle is already taken"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /users/{id}/profile [put]
 func (server *Server) updateUserProfile(ctx *gin.Context) {
 	var uriReq getUserRequest
 	if err := ctx.ShouldBindUri(&uriReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	var req service.UpdateUserProfileRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	user, err := server.userService.GetUserByEmail(ctx, authPayload.Username)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 		return
 	}
 
 	// Users can only update their own profile
 	if user.ID != uriReq.ID {
-		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
 		return
 	}
 
 	updatedUser, err := server.userService.UpdateUserProfile(ctx, user.ID, req)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		switch err.Error() {
+		case "invalid handle", "invalid phone number", "invalid timezone":
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		case "handle is already taken":
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
 		return
 	}
 
@@ -172,6 +269,7 @@ func (server *Server) updateUserProfile(ctx *gin.Context) {
 
 // @Summary Change Password
 // @Description Change user password (users can only change their own password)
+// @ID changePassword
 // @Tags users
 // @Security BearerAuth
 // @Accept json
@@ -187,32 +285,32 @@ func (server *Server) updateUserProfile(ctx *gin.Context) {
 func (server *Server) changePassword(ctx *gin.Context) {
 	var uriReq getUserRequest
 	if err := ctx.ShouldBindUri(&uriReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	var req service.ChangePasswordRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	user, err := server.userService.GetUserByEmail(ctx, authPayload.Username)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 		return
 	}
 
 	// Users can only change their own password
 	if user.ID != uriReq.ID {
-		ctx.JSON(http.StatusForbidden, errorResponse(err))
+		ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
 		return
 	}
 
 	err = server.userService.ChangePassword(ctx, user.ID, req)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
@@ -221,6 +319,7 @@ func (server *Server) changePassword(ctx *gin.Context) {
 
 // @Summary List Users
 // @Description List users in the authenticated user's organization
+// @ID listUsers
 // @Tags users
 // @Security BearerAuth
 // @Produce json
@@ -235,7 +334,7 @@ func (server *Server) changePassword(ctx *gin.Context) {
 func (server *Server) listUsers(ctx *gin.Context) {
 	var req listUsersRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -250,13 +349,13 @@ func (server *Server) listUsers(ctx *gin.Context) {
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	user, err := server.userService.GetUserByEmail(ctx, authPayload.Username)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 		return
 	}
 
 	users, err := server.userService.ListUsers(ctx, user.OrganizationID, req.PageSize, (req.PageID-1)*req.PageSize)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
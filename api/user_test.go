@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	mockdb "github.com/heyrmi/goslack/db/mock"
 	db "github.com/heyrmi/goslack/db/sqlc"
@@ -49,12 +51,71 @@ func TestCreateUserAPI(t *testing.T) {
 					CreateUser(gomock.Any(), EqCreateUserParams(arg, password)).
 					Times(1).
 					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEmailVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateEmailVerificationParams) (db.EmailVerification, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, user.Email, arg.Email)
+						return db.EmailVerification{UserID: arg.UserID, Email: arg.Email, Token: arg.Token, ExpiresAt: arg.ExpiresAt}, nil
+					})
+				store.EXPECT().
+					GetEmailTemplate(gomock.Any(), gomock.Eq(db.GetEmailTemplateParams{OrganizationID: user.OrganizationID, Kind: "verification"})).
+					Times(1).
+					Return(db.EmailTemplate{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetEmailBranding(gomock.Any(), gomock.Eq(user.OrganizationID)).
+					Times(1).
+					Return(db.EmailBranding{}, sql.ErrNoRows)
+				store.EXPECT().
+					GetEmailSuppression(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(db.EmailSuppression{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateEmailDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateEmailDeliveryParams) (db.EmailDelivery, error) {
+						require.Equal(t, "verification", arg.Category)
+						require.Equal(t, user.Email, arg.ToAddress)
+						return db.EmailDelivery{ID: 1}, nil
+					})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchUser(t, recorder.Body, user)
 			},
 		},
+		{
+			name: "VerificationEmailFails",
+			body: service.CreateUserRequest{
+				OrganizationID: user.OrganizationID,
+				Email:          user.Email,
+				FirstName:      user.FirstName,
+				LastName:       user.LastName,
+				Password:       password,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateUser(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					CreateEmailVerification(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.EmailVerification{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
 		{
 			name: "InternalError",
 			body: service.CreateUserRequest{
@@ -139,6 +200,81 @@ func TestCreateUserAPI(t *testing.T) {
 	}
 }
 
+func TestVerifyEmailAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	token := util.RandomString(32)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"token": token},
+			buildStubs: func(store *mockdb.MockStore) {
+				verified := user
+				verified.EmailVerifiedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Eq(token)).
+					Times(1).
+					Return(db.VerifyEmailTxResult{User: verified}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidToken",
+			body: gin.H{"token": token},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					VerifyEmailTx(gomock.Any(), gomock.Eq(token)).
+					Times(1).
+					Return(db.VerifyEmailTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingToken",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().VerifyEmailTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/users/verify-email", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 func TestLoginUserAPI(t *testing.T) {
 	user, password := randomUser(t)
 
@@ -153,15 +289,43 @@ func TestLoginUserAPI(t *testing.T) {
 			body: service.LoginUserRequest{
 				Email:    user.Email,
 				Password: password,
+				DeviceID: "laptop-1",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
 					Times(1).
 					Return(user, nil)
+				store.EXPECT().
+					UpsertUserDevice(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.UpsertUserDeviceParams) (db.UserDevice, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Len(t, arg.Fingerprint, 64)
+						return db.UserDevice{
+							ID:           7,
+							UserID:       user.ID,
+							Fingerprint:  arg.Fingerprint,
+							TrustedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+						}, nil
+					})
+				store.EXPECT().
+					CreateLoginEvent(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateLoginEventParams) (db.LoginEvent, error) {
+						require.True(t, arg.Success)
+						require.Equal(t, sql.NullInt64{Int64: user.ID, Valid: true}, arg.UserID)
+						require.Equal(t, sql.NullInt64{Int64: 7, Valid: true}, arg.DeviceID)
+						return db.LoginEvent{ID: 1}, nil
+					})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp service.LoginUserResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, int64(7), rsp.DeviceID)
+				require.True(t, rsp.DeviceTrusted)
 			},
 		},
 		{
@@ -175,6 +339,15 @@ func TestLoginUserAPI(t *testing.T) {
 					GetUserByEmail(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.User{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateLoginEvent(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateLoginEventParams) (db.LoginEvent, error) {
+						require.False(t, arg.Success)
+						require.False(t, arg.UserID.Valid)
+						require.Equal(t, service.LoginFailureUnknownUser, arg.FailureReason)
+						return db.LoginEvent{ID: 1}, nil
+					})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -189,8 +362,18 @@ func TestLoginUserAPI(t *testing.T) {
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
-					Times(1).
+					Times(2).
 					Return(user, nil)
+				store.EXPECT().UpsertUserDevice(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().
+					CreateLoginEvent(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateLoginEventParams) (db.LoginEvent, error) {
+						require.False(t, arg.Success)
+						require.Equal(t, sql.NullInt64{Int64: user.ID, Valid: true}, arg.UserID)
+						require.Equal(t, service.LoginFailureIncorrectPassword, arg.FailureReason)
+						return db.LoginEvent{ID: 1}, nil
+					})
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -207,6 +390,7 @@ func TestLoginUserAPI(t *testing.T) {
 					GetUserByEmail(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(db.User{}, sql.ErrConnDone)
+				store.EXPECT().CreateLoginEvent(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -339,6 +523,128 @@ func TestGetUserAPI(t *testing.T) {
 	}
 }
 
+func TestUpdateUserProfileAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"first_name": "Ada",
+				"last_name":  "Lovelace",
+				"title":      " Engineer ",
+				"pronouns":   "she/her",
+				"phone":      "+1 (555) 010-0199",
+				"timezone":   "Europe/London",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateUserProfileParams{
+					ID:        user.ID,
+					FirstName: "Ada",
+					LastName:  "Lovelace",
+					Title:     sql.NullString{String: "Engineer", Valid: true},
+					Pronouns:  sql.NullString{String: "she/her", Valid: true},
+					Phone:     sql.NullString{String: "+1 (555) 010-0199", Valid: true},
+					Timezone:  sql.NullString{String: "Europe/London", Valid: true},
+				}
+				updated := user
+				updated.FirstName = "Ada"
+				updated.LastName = "Lovelace"
+				updated.Title = "Engineer"
+				updated.Pronouns = "she/her"
+				updated.Phone = "+1 (555) 010-0199"
+				updated.Timezone = "Europe/London"
+				store.EXPECT().
+					UpdateUserProfile(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got service.UserResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, "Engineer", got.Title)
+				require.Equal(t, "she/her", got.Pronouns)
+				require.Equal(t, "Europe/London", got.Timezone)
+			},
+		},
+		{
+			name: "OmittedDetailsKept",
+			body: gin.H{"first_name": "Ada", "last_name": "Lovelace"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateUserProfileParams{
+					ID:        user.ID,
+					FirstName: "Ada",
+					LastName:  "Lovelace",
+				}
+				store.EXPECT().
+					UpdateUserProfile(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidTimezone",
+			body: gin.H{"first_name": "Ada", "last_name": "Lovelace", "timezone": "Mars/Olympus_Mons"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPhone",
+			body: gin.H{"first_name": "Ada", "last_name": "Lovelace", "phone": "call me"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/users/%d/profile", user.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
 func randomUser(t *testing.T) (user db.User, password string) {
 	password = util.RandomString(6)
 	hashedPassword, err := util.HashPassword(password)
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/heyrmi/goslack/util"
+)
+
+// errValidationFailed is the top-level error of requests that failed validation,
+// the failing fields are listed in the details
+var errValidationFailed = errors.New("request validation failed")
+
+// FieldError describes why one field of a request was rejected, e.g.
+// {"field":"content","rule":"max","param":"4000"}
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
+
+var registerValidationOnce sync.Once
+
+// registerValidation makes the binding validator report fields by the name
+// clients send them as, taken from the json, form or uri tag
+func registerValidation() {
+	registerValidationOnce.Do(func() {
+		validate, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			for _, tag := range []string{"json", "form", "uri"} {
+				name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+				if name == "-" {
+					return ""
+				}
+				if name != "" {
+					return name
+				}
+			}
+			return field.Name
+		})
+	})
+}
+
+// bindingErrorResponse turns the error returned by ctx.ShouldBind* into a
+// response listing the fields that failed and the rule each one broke. Errors
+// that aren't about a particular field, such as malformed JSON, are returned
+// like any other error.
+func bindingErrorResponse(ctx *gin.Context, err error) gin.H {
+	var details []FieldError
+
+	var validationErrors validator.ValidationErrors
+	var typeError *json.UnmarshalTypeError
+	var maxBytesError *http.MaxBytesError
+	switch {
+	case errors.As(err, &validationErrors):
+		for _, fieldError := range validationErrors {
+			details = append(details, FieldError{
+				Field: fieldError.Field(),
+				Rule:  fieldError.Tag(),
+				Param: fieldError.Param(),
+			})
+		}
+	case errors.As(err, &typeError):
+		details = append(details, FieldError{
+			Field: typeError.Field,
+			Rule:  "type",
+			Param: typeError.Type.String(),
+		})
+	case errors.As(err, &maxBytesError):
+		details = append(details, FieldError{
+			Field: "body",
+			Rule:  "max_bytes",
+			Param: fmt.Sprint(maxBytesError.Limit),
+		})
+	case errors.Is(err, io.EOF):
+		return errorResponse(ctx, errors.New("request body is empty"))
+	default:
+		return errorResponse(ctx, err)
+	}
+
+	response := errorResponse(ctx, errValidationFailed)
+	response["details"] = details
+	return response
+}
+
+// requestBodyLimit rejects request bodies larger than the current
+// HTTP_MAX_BODY_SIZE. File uploads are sent as multipart forms and limited by
+// the file service instead.
+func requestBodyLimit(tunables *util.TunablesStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		maxBytes := tunables.Load().HTTPMaxBodySize
+		if maxBytes <= 0 || ctx.Request.Body == nil || ctx.ContentType() == binding.MIMEMultipartPOSTForm {
+			ctx.Next()
+			return
+		}
+
+		if ctx.Request.ContentLength > maxBytes {
+			response := errorResponse(ctx, errValidationFailed)
+			response["details"] = []FieldError{{Field: "body", Rule: "max_bytes", Param: fmt.Sprint(maxBytes)}}
+			ctx.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, response)
+			return
+		}
+
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBytes)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+type validationErrorBody struct {
+	Error   string       `json:"error"`
+	Details []FieldError `json:"details"`
+}
+
+func TestRequestValidationErrors(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "member"
+
+	testCases := []struct {
+		name          string
+		maxBodySize   int64
+		method        string
+		url           string
+		body          string
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "MissingContent",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/workspace/%d/channels/1/messages", workspace.ID),
+			body:   `{}`,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				body := decodeValidationError(t, recorder)
+				require.Equal(t, errValidationFailed.Error(), body.Error)
+				require.Equal(t, []FieldError{{Field: "content", Rule: "required"}}, body.Details)
+			},
+		},
+		{
+			name:   "ContentTooLong",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/workspace/%d/channels/1/messages", workspace.ID),
+			body:   fmt.Sprintf(`{"content":%q}`, strings.Repeat("a", 4001)),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				body := decodeValidationError(t, recorder)
+				require.Equal(t, []FieldError{{Field: "content", Rule: "max", Param: "4000"}}, body.Details)
+			},
+		},
+		{
+			name:   "CustomStatusTooLong",
+			method: http.MethodPut,
+			url:    fmt.Sprintf("/workspace/%d/status", workspace.ID),
+			body:   fmt.Sprintf(`{"status":"away","custom_status":%q}`, strings.Repeat("a", 101)),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				body := decodeValidationError(t, recorder)
+				require.Equal(t, []FieldError{{Field: "custom_status", Rule: "max", Param: "100"}}, body.Details)
+			},
+		},
+		{
+			name:   "WrongType",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/workspace/%d/channels/1/messages", workspace.ID),
+			body:   `{"content":42}`,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				body := decodeValidationError(t, recorder)
+				require.Equal(t, []FieldError{{Field: "content", Rule: "type", Param: "string"}}, body.Details)
+			},
+		},
+		{
+			name:   "MalformedJSON",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/workspace/%d/channels/1/messages", workspace.ID),
+			body:   `{"content":`,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				body := decodeValidationError(t, recorder)
+				require.Empty(t, body.Details)
+			},
+		},
+		{
+			name:        "BodyTooLarge",
+			maxBodySize: 64,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("/workspace/%d/channels/1/messages", workspace.ID),
+			body:        fmt.Sprintf(`{"content":%q}`, strings.Repeat("a", 100)),
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+				body := decodeValidationError(t, recorder)
+				require.Equal(t, []FieldError{{Field: "body", Rule: "max_bytes", Param: "64"}}, body.Details)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).AnyTimes().Return(user, nil)
+			store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return(user.Role, nil)
+
+			config := util.Config{
+				TokenSymmetricKey:   util.RandomString(32),
+				AccessTokenDuration: time.Minute,
+				HTTPMaxBodySize:     tc.maxBodySize,
+			}
+			server, err := NewServer(config, store)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(tc.method, tc.url, bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", gin.MIMEJSON)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func decodeValidationError(t *testing.T, recorder *httptest.ResponseRecorder) validationErrorBody {
+	var body validationErrorBody
+	err := json.Unmarshal(recorder.Body.Bytes(), &body)
+	require.NoError(t, err)
+	require.NotEmpty(t, body.Error)
+	return body
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Webhooks
+// @Description List a workspace's outgoing webhooks. Secrets are only shown when a webhook is created. Requires manage_workspace permission.
+// @ID listWebhooks
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.WebhookResponse "Outgoing webhooks"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_workspace permission required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/webhooks [get]
+func (server *Server) listWebhooks(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	webhooks, err := server.webhookService.ListWebhooks(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+// @Summary Create Webhook
+// @Description Subscribe a URL to a workspace's file events: file.uploaded, file.deleted, file.shared and file.scanned, which reports whether an upload's content passed the check against its declared type. Each event is POSTed as JSON with X-Goslack-Event, X-Goslack-Delivery and X-Goslack-Signature headers; the signature is sha256= followed by the hex HMAC-SHA256 of the body keyed with the webhook's secret, which is only returned here. The URL must resolve to a public address and redirects aren't followed. Deliveries that don't get a 2xx response are retried with a growing delay. Requires manage_workspace permission.
+// @ID createWebhook
+// @Tags webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.CreateWebhookRequest true "URL and event types"
+// @Success 201 {object} service.WebhookResponse "Outgoing webhook with its secret"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_workspace permission required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/webhooks [post]
+func (server *Server) createWebhook(ctx *gin.Context) {
+	var req service.CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	webhook, err := server.webhookService.CreateWebhook(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "webhook URL") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, webhook)
+}
+
+// @Summary Delete Webhook
+// @Description Remove an outgoing webhook; its pending deliveries are dropped. Requires manage_workspace permission.
+// @ID deleteWebhook
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Success 200 {object} map[string]string "Webhook deleted"
+// @Failure 400 {object} map[string]string "Invalid workspace or webhook ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_workspace permission required"
+// @Failure 404 {object} map[string]string "Webhook not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/webhooks/{webhook_id} [delete]
+func (server *Server) deleteWebhook(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	webhookID, err := strconv.ParseInt(ctx.Param("webhook_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid webhook ID")))
+		return
+	}
+
+	err = server.webhookService.DeleteWebhook(ctx, workspaceID, webhookID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhookAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"url": "https://93.184.215.14/files", "event_types": []string{"file.uploaded", "file.shared"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+				store.EXPECT().
+					CreateOutgoingWebhook(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateOutgoingWebhookParams) (db.OutgoingWebhook, error) {
+						require.Equal(t, workspace.ID, arg.WorkspaceID)
+						require.Equal(t, user.ID, arg.CreatedBy.Int64)
+						return db.OutgoingWebhook{
+							ID:          3,
+							WorkspaceID: arg.WorkspaceID,
+							Url:         arg.Url,
+							Secret:      arg.Secret,
+							EventTypes:  arg.EventTypes,
+							CreatedBy:   arg.CreatedBy,
+							CreatedAt:   time.Now(),
+						}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var webhook service.WebhookResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &webhook))
+				require.Equal(t, int64(3), webhook.ID)
+				require.Equal(t, []string{"file.uploaded", "file.shared"}, webhook.EventTypes)
+				require.Len(t, webhook.Secret, 64)
+			},
+		},
+		{
+			name: "UnknownEventType",
+			body: gin.H{"url": "https://hooks.example.com/files", "event_types": []string{"message.created"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+				store.EXPECT().CreateOutgoingWebhook(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotHTTP",
+			body: gin.H{"url": "ftp://hooks.example.com/files", "event_types": []string{"file.deleted"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+				store.EXPECT().CreateOutgoingWebhook(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "PrivateAddress",
+			body: gin.H{"url": "http://169.254.169.254/latest/meta-data", "event_types": []string{"file.deleted"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+				store.EXPECT().CreateOutgoingWebhook(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoPermission",
+			body: gin.H{"url": "https://hooks.example.com/files", "event_types": []string{"file.uploaded"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+				store.EXPECT().GetUserRolePermissions(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, sql.ErrNoRows)
+				store.EXPECT().CreateOutgoingWebhook(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/webhooks", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteWebhookAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		deleted       int64
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			deleted: 1,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "NotFound",
+			deleted: 0,
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+			store.EXPECT().
+				DeleteOutgoingWebhook(gomock.Any(), gomock.Eq(db.DeleteOutgoingWebhookParams{ID: 3, WorkspaceID: workspace.ID})).
+				Times(1).
+				Return(tc.deleted, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/webhooks/3", workspace.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -1,13 +1,16 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/heyrmi/goslack/service"
 	"github.com/heyrmi/goslack/util"
@@ -22,17 +25,43 @@ const (
 	WSUserTyping            = "user_typing"
 	WSUserJoinedChannel     = "user_joined_channel"
 	WSUserLeftChannel       = "user_left_channel"
+	WSChannelCreated        = "channel_created"
 	WSConnectionEstablished = "connection_established"
+	WSHuddleError           = "huddle_error"
+	WSDraftError            = "draft_error"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		// Allow connections from any origin for now
-		// In production, implement proper CORS checking
-		return true
-	},
+// newUpgrader creates an upgrader with the current buffer sizes
+func newUpgrader(tunables util.Tunables) *websocket.Upgrader {
+	return &websocket.Upgrader{
+		ReadBufferSize:  tunables.WSReadBufferSize,
+		WriteBufferSize: tunables.WSWriteBufferSize,
+		CheckOrigin: func(r *http.Request) bool {
+			// Allow connections from any origin for now
+			// In production, implement proper CORS checking
+			return true
+		},
+	}
+}
+
+// PresenceHandler is notified when a user's first WebSocket connection opens
+// and when their last one closes
+type PresenceHandler interface {
+	UserConnected(userID, workspaceID int64)
+	UserDisconnected(userID, workspaceID int64)
+}
+
+// CallHandler relays huddle signaling between clients and is notified when a
+// user's last WebSocket connection closes
+type CallHandler interface {
+	RelaySignal(fromUserID, toUserID int64, signalType string, signal service.HuddleSignal) error
+	UserDisconnected(userID int64)
+}
+
+// DraftHandler saves drafts sent over WebSocket and syncs them to the user's
+// other connections
+type DraftHandler interface {
+	SaveDraft(ctx context.Context, userID, workspaceID int64, req service.SaveDraftRequest) (*service.DraftResponse, error)
 }
 
 // Hub maintains the set of active clients and broadcasts messages to the clients
@@ -61,12 +90,47 @@ type Hub struct {
 	// Configuration
 	config util.Config
 
+	// Settings that can change while the server runs
+	tunables *util.TunablesStore
+
+	// How long events for a client are collected into one frame, 0 sends each
+	// event on its own
+	batchWindow time.Duration
+
+	// Presence handler notified about connection changes (optional)
+	presence PresenceHandler
+
+	// Call handler relaying huddle signaling (optional)
+	calls CallHandler
+
+	// Draft handler saving drafts sent by clients (optional)
+	drafts DraftHandler
+
+	// Users typing in each channel, and how long an indicator lasts unless
+	// it is renewed
+	typing      map[int64]map[int64]*typingState
+	typingTTL   time.Duration
+	typingMutex sync.Mutex
+
 	// Mutex for thread-safe operations
 	mutex sync.RWMutex
 }
 
 // NewHub creates a new WebSocket hub
 func NewHub(config util.Config) *Hub {
+	var batchWindow time.Duration
+	if config.WSBatchEvents {
+		batchWindow = config.WSBatchWindow
+		if batchWindow <= 0 {
+			batchWindow = 50 * time.Millisecond
+		}
+	}
+
+	typingTTL := config.WSTypingTTL
+	if typingTTL <= 0 {
+		typingTTL = 6 * time.Second
+	}
+
 	return &Hub{
 		broadcast:       make(chan *service.WSMessage),
 		register:        make(chan *Client),
@@ -76,9 +140,42 @@ func NewHub(config util.Config) *Hub {
 		channels:        make(map[int64]map[*Client]bool),
 		userConnections: make(map[int64][]*Client),
 		config:          config,
+		tunables:        util.NewTunablesStore(config),
+		batchWindow:     batchWindow,
+		typing:          make(map[int64]map[int64]*typingState),
+		typingTTL:       typingTTL,
 	}
 }
 
+// SetPresenceHandler registers the handler notified about connection changes
+func (h *Hub) SetPresenceHandler(presence PresenceHandler) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.presence = presence
+}
+
+// SetCallHandler registers the handler that relays huddle signaling
+func (h *Hub) SetCallHandler(calls CallHandler) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.calls = calls
+}
+
+// SetDraftHandler registers the handler that saves drafts sent by clients
+func (h *Hub) SetDraftHandler(drafts DraftHandler) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.drafts = drafts
+}
+
+// SetTunables shares the server's tunables with the hub, so reloaded
+// settings apply to WebSocket connections
+func (h *Hub) SetTunables(tunables *util.TunablesStore) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.tunables = tunables
+}
+
 // Client is a middleman between the websocket connection and the hub
 type Client struct {
 	hub *Hub
@@ -89,6 +186,10 @@ type Client struct {
 	// Buffered channel of outbound messages
 	send chan *service.WSMessage
 
+	// Identifies the connection to the client, e.g. so it isn't sent back
+	// its own draft updates
+	id string
+
 	// User information
 	userID      int64
 	workspaceID int64
@@ -96,6 +197,10 @@ type Client struct {
 
 	// Connection state
 	isActive bool
+
+	// Draft updates waiting for the user to stop typing, by conversation
+	pendingDrafts map[string]*pendingDraft
+	draftMutex    sync.Mutex
 }
 
 // Run starts the WebSocket hub
@@ -121,7 +226,7 @@ func (h *Hub) registerClient(client *Client) {
 
 	// Check connection limit per user
 	userConns := h.userConnections[client.userID]
-	if len(userConns) >= h.config.WSMaxConnectionsPerUser {
+	if len(userConns) >= h.tunables.Load().WSMaxConnectionsPerUser {
 		// Close the oldest connection
 		if len(userConns) > 0 {
 			oldestClient := userConns[0]
@@ -139,12 +244,18 @@ func (h *Hub) registerClient(client *Client) {
 	h.workspaces[client.workspaceID][client] = true
 
 	// Add to user connections tracking
+	firstConnection := len(h.userConnections[client.userID]) == 0
 	h.userConnections[client.userID] = append(h.userConnections[client.userID], client)
 
+	// Notify presence outside the hub loop, which may need to broadcast
+	if firstConnection && h.presence != nil {
+		go h.presence.UserConnected(client.userID, client.workspaceID)
+	}
+
 	// Send connection established message
 	connectionMsg := &service.WSMessage{
 		Type:        WSConnectionEstablished,
-		Data:        gin.H{"message": "WebSocket connection established", "user": client.user},
+		Data:        gin.H{"message": "WebSocket connection established", "user": client.user, "connection_id": client.id},
 		WorkspaceID: client.workspaceID,
 		UserID:      client.userID,
 		Timestamp:   time.Now(),
@@ -188,6 +299,15 @@ func (h *Hub) unregisterClient(client *Client) {
 		}
 		if len(h.userConnections[client.userID]) == 0 {
 			delete(h.userConnections, client.userID)
+
+			if h.presence != nil {
+				go h.presence.UserDisconnected(client.userID, client.workspaceID)
+			}
+			if h.calls != nil {
+				go h.calls.UserDisconnected(client.userID)
+			}
+			// Broadcasting waits for the hub loop, which is running this
+			go h.clearTyping(client.userID)
 		}
 
 		// Remove from channel mappings
@@ -264,6 +384,9 @@ func (h *Hub) BroadcastToUser(userID int64, message *service.WSMessage) {
 
 	if userConns, exists := h.userConnections[userID]; exists {
 		for _, client := range userConns {
+			if message.ExcludeConnectionID != "" && client.id == message.ExcludeConnectionID {
+				continue
+			}
 			select {
 			case client.send <- message:
 			default:
@@ -274,17 +397,70 @@ func (h *Hub) BroadcastToUser(userID int64, message *service.WSMessage) {
 	}
 }
 
+// BroadcastToAll sends a message to every connection, e.g. server-wide notices
+func (h *Hub) BroadcastToAll(message *service.WSMessage) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	message.Timestamp = time.Now()
+
+	for client := range h.clients {
+		select {
+		case client.send <- message:
+		default:
+			log.Printf("Warning: client send channel full for user %d", client.userID)
+		}
+	}
+}
+
+// sendToClient sends a message to one client, unless it has already been
+// unregistered and its send channel closed
+func (h *Hub) sendToClient(client *Client, message *service.WSMessage) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if !h.clients[client] {
+		return
+	}
+	select {
+	case client.send <- message:
+	default:
+		log.Printf("Warning: client send channel full for user %d", client.userID)
+	}
+}
+
+// DisconnectWorkspace closes every connection to a workspace, e.g. once it's
+// deleted. Each client unregisters itself when its read pump stops.
+func (h *Hub) DisconnectWorkspace(workspaceID int64) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "workspace deleted")
+	for client := range h.workspaces[workspaceID] {
+		client.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(time.Second))
+		client.conn.Close()
+	}
+
+	log.Printf("Closed connections to workspace: workspace_id=%d", workspaceID)
+}
+
 // readPump pumps messages from the websocket connection to the hub
 func (c *Client) readPump() {
 	defer func() {
+		c.flushDrafts()
 		c.hub.unregister <- c
 		c.conn.Close()
 	}()
 
-	c.conn.SetReadLimit(512)
-	c.conn.SetReadDeadline(time.Now().Add(c.hub.config.WSPongTimeout))
+	tunables := c.hub.tunables.Load()
+	readLimit := tunables.WSMaxMessageSize
+	if readLimit <= 0 {
+		readLimit = 16384
+	}
+	c.conn.SetReadLimit(readLimit)
+	c.conn.SetReadDeadline(time.Now().Add(tunables.WSPongTimeout))
 	c.conn.SetPongHandler(func(string) error {
-		c.conn.SetReadDeadline(time.Now().Add(c.hub.config.WSPongTimeout))
+		c.conn.SetReadDeadline(time.Now().Add(c.hub.tunables.Load().WSPongTimeout))
 		return nil
 	})
 
@@ -307,7 +483,7 @@ func (c *Client) readPump() {
 
 // writePump pumps messages from the hub to the websocket connection
 func (c *Client) writePump() {
-	ticker := time.NewTicker(c.hub.config.WSPingInterval)
+	ticker := time.NewTicker(c.hub.tunables.Load().WSPingInterval)
 	defer func() {
 		ticker.Stop()
 		c.conn.Close()
@@ -316,16 +492,20 @@ func (c *Client) writePump() {
 	for {
 		select {
 		case message, ok := <-c.send:
-			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
 			if !ok {
+				c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
 				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
 
-			if err := c.conn.WriteJSON(message); err != nil {
+			closed, err := c.writeEvents(message)
+			if err != nil {
 				log.Printf("WebSocket write error: %v", err)
 				return
 			}
+			if closed {
+				return
+			}
 
 		case <-ticker.C:
 			c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
@@ -357,37 +537,158 @@ func (c *Client) handleIncomingMessage(message map[string]interface{}) {
 		case c.send <- pongMsg:
 		default:
 		}
-	case "typing_start":
-		// Handle typing indicator start
+	case "typing_start", "typing_stop":
+		// Handle typing indicators
 		if channelID, ok := message["channel_id"].(float64); ok {
-			typingMsg := &service.WSMessage{
-				Type:        WSUserTyping,
-				Data:        gin.H{"user_id": c.userID, "user": c.user, "typing": true},
-				WorkspaceID: c.workspaceID,
-				ChannelID:   func() *int64 { id := int64(channelID); return &id }(),
-				UserID:      c.userID,
-				Timestamp:   time.Now(),
-			}
-			c.hub.BroadcastToChannel(c.workspaceID, int64(channelID), typingMsg)
+			c.hub.SetTyping(c.workspaceID, int64(channelID), c.user, messageType == "typing_start")
 		}
-	case "typing_stop":
-		// Handle typing indicator stop
-		if channelID, ok := message["channel_id"].(float64); ok {
-			typingMsg := &service.WSMessage{
-				Type:        WSUserTyping,
-				Data:        gin.H{"user_id": c.userID, "user": c.user, "typing": false},
-				WorkspaceID: c.workspaceID,
-				ChannelID:   func() *int64 { id := int64(channelID); return &id }(),
-				UserID:      c.userID,
-				Timestamp:   time.Now(),
-			}
-			c.hub.BroadcastToChannel(c.workspaceID, int64(channelID), typingMsg)
+	case service.WSHuddleOffer, service.WSHuddleAnswer, service.WSHuddleICECandidate:
+		// Relay WebRTC signaling to another participant of the huddle
+		c.relayHuddleSignal(messageType, message)
+	case "draft_update":
+		// Save the draft once the user stops typing
+		c.queueDraft(message)
+	}
+}
+
+// relayHuddleSignal forwards a huddle offer, answer or ICE candidate and
+// reports failures back to the sender
+func (c *Client) relayHuddleSignal(messageType string, message map[string]interface{}) {
+	c.hub.mutex.RLock()
+	calls := c.hub.calls
+	c.hub.mutex.RUnlock()
+	if calls == nil {
+		return
+	}
+
+	huddleID, _ := message["huddle_id"].(float64)
+	toUserID, _ := message["to_user_id"].(float64)
+	sdp, _ := message["sdp"].(string)
+
+	signal := service.HuddleSignal{
+		HuddleID:  int64(huddleID),
+		SDP:       sdp,
+		Candidate: message["candidate"],
+	}
+	if err := calls.RelaySignal(c.userID, int64(toUserID), messageType, signal); err != nil {
+		errorMsg := &service.WSMessage{
+			Type:        WSHuddleError,
+			Data:        gin.H{"huddle_id": signal.HuddleID, "error": err.Error()},
+			WorkspaceID: c.workspaceID,
+			UserID:      c.userID,
+			Timestamp:   time.Now(),
+		}
+		select {
+		case c.send <- errorMsg:
+		default:
 		}
 	}
 }
 
+// pendingDraft is the latest draft_update for a conversation, saved when its
+// timer fires
+type pendingDraft struct {
+	req   service.SaveDraftRequest
+	timer *time.Timer
+}
+
+// queueDraft saves a draft sent by the client once draft_update messages for
+// its conversation pause for the debounce window, so each keystroke isn't
+// written and synced on its own. Only the latest content is saved.
+func (c *Client) queueDraft(message map[string]interface{}) {
+	content, _ := message["content"].(string)
+	req := service.SaveDraftRequest{
+		ChannelID:    optionalID(message["channel_id"]),
+		ReceiverID:   optionalID(message["receiver_id"]),
+		ThreadID:     optionalID(message["thread_id"]),
+		Content:      content,
+		ConnectionID: c.id,
+	}
+	key := fmt.Sprintf("%v:%v:%v", message["channel_id"], message["receiver_id"], message["thread_id"])
+
+	c.draftMutex.Lock()
+	defer c.draftMutex.Unlock()
+
+	if pending, exists := c.pendingDrafts[key]; exists {
+		pending.req = req
+		pending.timer.Reset(c.hub.tunables.Load().WSDraftDebounce)
+		return
+	}
+
+	if c.pendingDrafts == nil {
+		c.pendingDrafts = make(map[string]*pendingDraft)
+	}
+	pending := &pendingDraft{req: req}
+	pending.timer = time.AfterFunc(c.hub.tunables.Load().WSDraftDebounce, func() {
+		c.saveDraft(key)
+	})
+	c.pendingDrafts[key] = pending
+}
+
+// saveDraft saves the pending draft for a conversation and reports failures
+// back to the client
+func (c *Client) saveDraft(key string) {
+	c.draftMutex.Lock()
+	pending := c.pendingDrafts[key]
+	delete(c.pendingDrafts, key)
+	c.draftMutex.Unlock()
+
+	if pending != nil {
+		c.writeDraft(pending.req)
+	}
+}
+
+// flushDrafts saves the drafts still waiting for their debounce window when
+// the connection closes, so the last changes aren't lost
+func (c *Client) flushDrafts() {
+	c.draftMutex.Lock()
+	var reqs []service.SaveDraftRequest
+	for key, pending := range c.pendingDrafts {
+		if pending.timer.Stop() {
+			reqs = append(reqs, pending.req)
+		}
+		delete(c.pendingDrafts, key)
+	}
+	c.draftMutex.Unlock()
+
+	for _, req := range reqs {
+		c.writeDraft(req)
+	}
+}
+
+// writeDraft saves a draft through the hub's draft handler
+func (c *Client) writeDraft(req service.SaveDraftRequest) {
+	c.hub.mutex.RLock()
+	drafts := c.hub.drafts
+	c.hub.mutex.RUnlock()
+	if drafts == nil {
+		return
+	}
+
+	if _, err := drafts.SaveDraft(context.Background(), c.userID, c.workspaceID, req); err != nil {
+		c.hub.sendToClient(c, &service.WSMessage{
+			Type:        WSDraftError,
+			Data:        gin.H{"channel_id": req.ChannelID, "receiver_id": req.ReceiverID, "thread_id": req.ThreadID, "error": err.Error()},
+			WorkspaceID: c.workspaceID,
+			UserID:      c.userID,
+			Timestamp:   time.Now(),
+		})
+	}
+}
+
+// optionalID reads an ID from an incoming message, nil when it's missing
+func optionalID(value interface{}) *int64 {
+	id, ok := value.(float64)
+	if !ok || id <= 0 {
+		return nil
+	}
+	result := int64(id)
+	return &result
+}
+
 // @Summary WebSocket Connection
 // @Description Establish WebSocket connection for real-time communication (requires authentication)
+// @ID handleWebSocket
 // @Tags realtime
 // @Security BearerAuth
 // @Produce json
@@ -400,7 +701,7 @@ func (server *Server) handleWebSocket(c *gin.Context) {
 	currentUser := getCurrentUser(c)
 
 	// Upgrade HTTP connection to WebSocket
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	conn, err := newUpgrader(server.tunables.Load()).Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade error: %v", err)
 		return
@@ -408,6 +709,7 @@ func (server *Server) handleWebSocket(c *gin.Context) {
 
 	// Create client
 	client := &Client{
+		id:          uuid.New().String(),
 		hub:         server.hub,
 		conn:        conn,
 		send:        make(chan *service.WSMessage, 256),
@@ -417,6 +719,16 @@ func (server *Server) handleWebSocket(c *gin.Context) {
 		isActive:    true,
 	}
 
+	// Tell clients connecting during maintenance that writes are unavailable
+	if status := server.maintenanceService.Status(); status.Enabled {
+		client.send <- &service.WSMessage{
+			Type:        service.WSMaintenance,
+			Data:        status,
+			WorkspaceID: client.workspaceID,
+			Timestamp:   time.Now(),
+		}
+	}
+
 	// Register client and start goroutines
 	client.hub.register <- client
 
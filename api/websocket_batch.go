@@ -0,0 +1,139 @@
+package api
+
+import (
+	"expvar"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/heyrmi/goslack/service"
+)
+
+// wsMaxBatchSize caps the events sent in one frame, so a burst can't hold
+// back delivery for longer than it takes to fill a batch
+const wsMaxBatchSize = 100
+
+// coalescedEventTypes are events where a newer one from the same user in the
+// same channel supersedes the older ones, so only the latest is sent
+var coalescedEventTypes = map[string]bool{
+	WSUserTyping:    true,
+	WSStatusChanged: true,
+}
+
+// wsBatchMetrics counts the frames sent with batching and how many events
+// they carried, published with the process metrics at /debug/vars
+var wsBatchMetrics = expvar.NewMap("websocket_batching")
+
+// wsBatchSizeBuckets are the upper bounds of the batch size histogram
+var wsBatchSizeBuckets = []int{1, 5, 10, 25, 50, wsMaxBatchSize}
+
+// writeEvents writes an event to the connection. With batching enabled the
+// events queued for the client within the batch window are sent together as
+// one JSON array frame, after coalescing superseded events. It reports
+// whether the hub closed the client's send channel meanwhile.
+func (c *Client) writeEvents(first *service.WSMessage) (bool, error) {
+	window := c.hub.batchWindow
+	if window <= 0 {
+		c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		return false, c.conn.WriteJSON(first)
+	}
+
+	batch := []*service.WSMessage{first}
+	closed := false
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+collect:
+	for len(batch) < wsMaxBatchSize {
+		select {
+		case message, ok := <-c.send:
+			if !ok {
+				closed = true
+				break collect
+			}
+			batch = append(batch, message)
+		case <-timer.C:
+			break collect
+		}
+	}
+
+	events := coalesceEvents(batch)
+	recordBatch(len(batch), len(events))
+
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	var err error
+	if len(events) == 1 {
+		err = c.conn.WriteJSON(events[0])
+	} else {
+		err = c.conn.WriteJSON(events)
+	}
+	if err != nil {
+		return closed, err
+	}
+
+	if closed {
+		c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+	}
+	return closed, nil
+}
+
+// coalesceEvents drops events superseded by a later one of the same type from
+// the same user in the same channel, keeping the order of the rest
+func coalesceEvents(batch []*service.WSMessage) []*service.WSMessage {
+	if len(batch) < 2 {
+		return batch
+	}
+
+	type eventKey struct {
+		eventType string
+		userID    int64
+		channelID int64
+	}
+
+	seen := make(map[eventKey]bool)
+	keep := make([]bool, len(batch))
+	kept := 0
+	for i := len(batch) - 1; i >= 0; i-- {
+		event := batch[i]
+		if coalescedEventTypes[event.Type] {
+			key := eventKey{eventType: event.Type, userID: event.UserID}
+			if event.ChannelID != nil {
+				key.channelID = *event.ChannelID
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		keep[i] = true
+		kept++
+	}
+
+	if kept == len(batch) {
+		return batch
+	}
+
+	events := make([]*service.WSMessage, 0, kept)
+	for i, event := range batch {
+		if keep[i] {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// recordBatch adds a sent frame to the batching metrics
+func recordBatch(queued, sent int) {
+	wsBatchMetrics.Add("frames", 1)
+	wsBatchMetrics.Add("events_queued", int64(queued))
+	wsBatchMetrics.Add("events_sent", int64(sent))
+	wsBatchMetrics.Add("events_coalesced", int64(queued-sent))
+
+	for _, bound := range wsBatchSizeBuckets {
+		if sent <= bound {
+			wsBatchMetrics.Add(fmt.Sprintf("batch_size_le_%d", bound), 1)
+			return
+		}
+	}
+}
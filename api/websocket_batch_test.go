@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalesceEvents(t *testing.T) {
+	channelID, otherChannelID := int64(4), int64(5)
+
+	batch := []*service.WSMessage{
+		{Type: WSUserTyping, UserID: 1, ChannelID: &channelID, Data: map[string]interface{}{"is_typing": true}},
+		{Type: "reaction_added", UserID: 1, ChannelID: &channelID},
+		{Type: WSUserTyping, UserID: 2, ChannelID: &channelID},
+		{Type: WSUserTyping, UserID: 1, ChannelID: &otherChannelID},
+		{Type: WSStatusChanged, UserID: 1},
+		{Type: "reaction_added", UserID: 1, ChannelID: &channelID},
+		{Type: WSUserTyping, UserID: 1, ChannelID: &channelID, Data: map[string]interface{}{"is_typing": false}},
+		{Type: WSStatusChanged, UserID: 1},
+	}
+
+	events := coalesceEvents(batch)
+
+	// Later typing and status events replace earlier ones from the same user in
+	// the same channel, everything else is kept in order
+	require.Equal(t, []*service.WSMessage{batch[1], batch[2], batch[3], batch[5], batch[6], batch[7]}, events)
+}
+
+func TestWebSocketBatching(t *testing.T) {
+	hub := NewHub(util.Config{WSPingInterval: time.Minute, WSBatchEvents: true, WSBatchWindow: 50 * time.Millisecond})
+	channelID := int64(4)
+
+	clientConns := make(chan *Client, 1)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		client := &Client{hub: hub, conn: conn, send: make(chan *service.WSMessage, 256)}
+		clientConns <- client
+		go client.writePump()
+	}))
+	defer testServer.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(testServer.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := <-clientConns
+	for _, message := range []*service.WSMessage{
+		{Type: WSUserTyping, UserID: 1, ChannelID: &channelID},
+		{Type: "reaction_added", UserID: 1, ChannelID: &channelID},
+		{Type: WSUserTyping, UserID: 1, ChannelID: &channelID},
+	} {
+		client.send <- message
+	}
+
+	// The burst arrives as one array frame without the superseded typing event
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, data, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var events []service.WSMessage
+	require.NoError(t, json.Unmarshal(data, &events))
+	require.Len(t, events, 2)
+	require.Equal(t, "reaction_added", events[0].Type)
+	require.Equal(t, WSUserTyping, events[1].Type)
+
+	// A lone event is still sent as a plain object
+	client.send <- &service.WSMessage{Type: "pong"}
+
+	_, data, err = conn.ReadMessage()
+	require.NoError(t, err)
+
+	var event service.WSMessage
+	require.NoError(t, json.Unmarshal(data, &event))
+	require.Equal(t, "pong", event.Type)
+
+	require.NotNil(t, wsBatchMetrics.Get("batch_size_le_5"))
+	require.NotNil(t, wsBatchMetrics.Get("events_coalesced"))
+
+	close(client.send)
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseNoStatusReceived))
+}
@@ -41,6 +41,8 @@ func TestWebSocketMessageFlow(t *testing.T) {
 	sender := randomWSUser()
 	receiver := randomWSUser()
 
+	expectDefaultWorkspaceSettings(store)
+
 	// Mock database calls for WebSocket connections
 	store.EXPECT().
 		GetUserByEmail(gomock.Any(), gomock.Eq(sender.Email)).
@@ -86,9 +88,19 @@ func TestWebSocketMessageFlow(t *testing.T) {
 	}
 
 	store.EXPECT().
-		CreateChannelMessage(gomock.Any(), gomock.Any()).
+		ListModerationWords(gomock.Any(), gomock.Eq(workspace.ID)).
 		Times(1).
-		Return(mockMessage, nil)
+		Return([]db.ModerationWord{}, nil)
+
+	store.EXPECT().
+		CreateMessageTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(createMessageTx(mockMessage))
+
+	store.EXPECT().
+		MarkOutboxEventPublished(gomock.Any(), gomock.Eq(mockMessage.ID)).
+		Times(1).
+		Return(nil)
 
 	// Mock GetUser for message response (to get sender info)
 	store.EXPECT().
@@ -266,6 +278,10 @@ func TestWebSocketStatusUpdates(t *testing.T) {
 			Role:           "member",
 			CreatedAt:      time.Now(),
 		}, nil)
+	store.EXPECT().
+		GetDoNotDisturb(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.GetDoNotDisturbRow{}, sql.ErrNoRows)
 
 	// Create access tokens
 	user1Token, _, err := server.tokenMaker.CreateToken(user1.Email, time.Minute)
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -369,6 +371,145 @@ func TestWebSocketConnectionLimit(t *testing.T) {
 	require.True(t, len(connections) == 3) // We created 3 connections
 }
 
+// presenceRecorder records presence notifications from the hub
+type presenceRecorder struct {
+	events chan string
+}
+
+func (p *presenceRecorder) UserConnected(userID, workspaceID int64) {
+	p.events <- fmt.Sprintf("connected:%d", userID)
+}
+
+func (p *presenceRecorder) UserDisconnected(userID, workspaceID int64) {
+	p.events <- fmt.Sprintf("disconnected:%d", userID)
+}
+
+func TestWebSocketPresenceNotifications(t *testing.T) {
+	hub := NewHub(util.Config{WSMaxConnectionsPerUser: 5})
+	presence := &presenceRecorder{events: make(chan string, 10)}
+	hub.SetPresenceHandler(presence)
+
+	user := randomWSUser()
+	workspace := randomWSWorkspace()
+
+	newClient := func() *Client {
+		return &Client{
+			hub:         hub,
+			send:        make(chan *service.WSMessage, 256),
+			userID:      user.ID,
+			workspaceID: workspace.ID,
+			user:        user,
+			isActive:    true,
+		}
+	}
+
+	expectEvent := func(expected string) {
+		select {
+		case event := <-presence.events:
+			require.Equal(t, expected, event)
+		case <-time.After(time.Second):
+			t.Fatalf("expected presence event %q", expected)
+		}
+	}
+
+	expectNoEvent := func() {
+		select {
+		case event := <-presence.events:
+			t.Fatalf("unexpected presence event %q", event)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	client1 := newClient()
+	client2 := newClient()
+
+	// Only the first connection brings the user online
+	hub.registerClient(client1)
+	expectEvent(fmt.Sprintf("connected:%d", user.ID))
+	hub.registerClient(client2)
+	expectNoEvent()
+
+	// Only the last connection closing takes the user offline
+	hub.unregisterClient(client1)
+	expectNoEvent()
+	hub.unregisterClient(client2)
+	expectEvent(fmt.Sprintf("disconnected:%d", user.ID))
+}
+
+// draftRecorder records the drafts saved through the hub
+type draftRecorder struct {
+	saved chan service.SaveDraftRequest
+}
+
+func (d *draftRecorder) SaveDraft(ctx context.Context, userID, workspaceID int64, req service.SaveDraftRequest) (*service.DraftResponse, error) {
+	d.saved <- req
+	return &service.DraftResponse{WorkspaceID: workspaceID, Content: req.Content}, nil
+}
+
+func TestWebSocketDraftSync(t *testing.T) {
+	hub := NewHub(util.Config{WSMaxConnectionsPerUser: 5, WSDraftDebounce: 50 * time.Millisecond})
+	drafts := &draftRecorder{saved: make(chan service.SaveDraftRequest, 10)}
+	hub.SetDraftHandler(drafts)
+
+	user := randomWSUser()
+	workspace := randomWSWorkspace()
+
+	newClient := func(id string) *Client {
+		return &Client{
+			id:          id,
+			hub:         hub,
+			send:        make(chan *service.WSMessage, 256),
+			userID:      user.ID,
+			workspaceID: workspace.ID,
+			user:        user,
+			isActive:    true,
+		}
+	}
+	laptop := newClient("laptop")
+	phone := newClient("phone")
+	hub.registerClient(laptop)
+	hub.registerClient(phone)
+	<-laptop.send
+	<-phone.send
+
+	// Rapid updates to one conversation are saved once, with the latest content
+	for _, content := range []string{"H", "He", "Hey"} {
+		laptop.handleIncomingMessage(map[string]interface{}{"type": "draft_update", "channel_id": float64(3), "content": content})
+	}
+
+	select {
+	case req := <-drafts.saved:
+		require.Equal(t, "Hey", req.Content)
+		require.Equal(t, int64(3), *req.ChannelID)
+		require.Equal(t, "laptop", req.ConnectionID)
+	case <-time.After(time.Second):
+		t.Fatal("expected the draft to be saved")
+	}
+	select {
+	case req := <-drafts.saved:
+		t.Fatalf("unexpected draft save %+v", req)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Draft events skip the connection that made the change
+	hub.BroadcastToUser(user.ID, &service.WSMessage{Type: service.WSDraftUpdated, ExcludeConnectionID: "laptop"})
+	require.Len(t, laptop.send, 0)
+	require.Len(t, phone.send, 1)
+	require.Equal(t, service.WSDraftUpdated, (<-phone.send).Type)
+
+	// Updates still waiting when the connection closes are saved right away
+	laptop.handleIncomingMessage(map[string]interface{}{"type": "draft_update", "receiver_id": float64(9), "content": "See you"})
+	laptop.flushDrafts()
+
+	select {
+	case req := <-drafts.saved:
+		require.Equal(t, "See you", req.Content)
+		require.Equal(t, int64(9), *req.ReceiverID)
+	default:
+		t.Fatal("expected the pending draft to be saved")
+	}
+}
+
 // Helper functions for WebSocket testing
 func randomWSUser() service.UserResponse {
 	return service.UserResponse{
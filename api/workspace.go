@@ -3,14 +3,16 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	db "github.com/heyrmi/goslack/db/sqlc"
 	"github.com/heyrmi/goslack/service"
-	"github.com/lib/pq"
 )
 
 // @Summary Create Workspace
 // @Description Create a new workspace in the user's organization
+// @ID createWorkspace
 // @Tags workspaces
 // @Security BearerAuth
 // @Accept json
@@ -25,7 +27,7 @@ import (
 func (server *Server) createWorkspace(ctx *gin.Context) {
 	var req service.CreateWorkspaceRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -33,24 +35,22 @@ func (server *Server) createWorkspace(ctx *gin.Context) {
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
 		err := fmt.Errorf("user not found in context")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 	user := currentUser.(service.UserResponse)
 
 	workspace, err := server.workspaceService.CreateWorkspace(ctx, user.ID, req)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				ctx.JSON(http.StatusConflict, errorResponse(err))
-				return
-			case "foreign_key_violation":
-				ctx.JSON(http.StatusBadRequest, errorResponse(err))
-				return
-			}
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+			return
+		case db.ForeignKeyViolation:
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -58,7 +58,8 @@ func (server *Server) createWorkspace(ctx *gin.Context) {
 }
 
 // @Summary Get Workspace
-// @Description Retrieve workspace information by ID
+// @Description Retrieve workspace information by ID. Members also get the workspace's active announcement banners.
+// @ID getWorkspace
 // @Tags workspaces
 // @Security BearerAuth
 // @Produce json
@@ -67,25 +68,42 @@ func (server *Server) createWorkspace(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string "Invalid workspace ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 404 {object} map[string]string "Workspace not found"
+// @Failure 500 {object} map[string]string "Internal server error"
 // @Router /workspaces/{id} [get]
 func (server *Server) getWorkspace(ctx *gin.Context) {
 	var req getWorkspaceRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	workspace, err := server.workspaceService.GetWorkspace(ctx, req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		return
+	}
+
+	// Announcements are meant for the workspace's own members
+	currentUser := getCurrentUser(ctx)
+	isMember, err := server.userService.IsWorkspaceMember(ctx, currentUser.ID, workspace.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
+	if isMember {
+		workspace.Banners, err = server.bannerService.ListActiveBanners(ctx, workspace.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+			return
+		}
+	}
 
 	ctx.JSON(http.StatusOK, workspace)
 }
 
 // @Summary List Workspaces
 // @Description List workspaces in the authenticated user's organization
+// @ID listWorkspaces
 // @Tags workspaces
 // @Security BearerAuth
 // @Produce json
@@ -99,7 +117,7 @@ func (server *Server) getWorkspace(ctx *gin.Context) {
 func (server *Server) listWorkspaces(ctx *gin.Context) {
 	var req listWorkspacesRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -115,7 +133,7 @@ func (server *Server) listWorkspaces(ctx *gin.Context) {
 	currentUser, exists := ctx.Get(currentUserKey)
 	if !exists {
 		err := fmt.Errorf("user not found in context")
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 	user := currentUser.(service.UserResponse)
@@ -127,7 +145,7 @@ func (server *Server) listWorkspaces(ctx *gin.Context) {
 		(req.PageID-1)*req.PageSize,
 	)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -136,6 +154,7 @@ func (server *Server) listWorkspaces(ctx *gin.Context) {
 
 // @Summary Update Workspace
 // @Description Update workspace information (requires workspace admin role)
+// @ID updateWorkspace
 // @Tags workspaces
 // @Security BearerAuth
 // @Accept json
@@ -152,26 +171,24 @@ func (server *Server) listWorkspaces(ctx *gin.Context) {
 func (server *Server) updateWorkspace(ctx *gin.Context) {
 	var uriReq getWorkspaceRequest
 	if err := ctx.ShouldBindUri(&uriReq); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	var req updateWorkspaceRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
 	workspace, err := server.workspaceService.UpdateWorkspace(ctx, uriReq.ID, req.Name)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				ctx.JSON(http.StatusConflict, errorResponse(err))
-				return
-			}
+		switch db.ErrorCode(err) {
+		case db.UniqueViolation:
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -179,7 +196,8 @@ func (server *Server) updateWorkspace(ctx *gin.Context) {
 }
 
 // @Summary Delete Workspace
-// @Description Delete a workspace (requires workspace admin role)
+// @Description Delete a workspace. Organization admins can restore it until the recovery window passes, then its content is purged (requires workspace admin role)
+// @ID deleteWorkspace
 // @Tags workspaces
 // @Security BearerAuth
 // @Produce json
@@ -188,21 +206,36 @@ func (server *Server) updateWorkspace(ctx *gin.Context) {
 // @Failure 400 {object} map[string]string "Invalid workspace ID"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 404 {object} map[string]string "Workspace not found"
+// @Failure 409 {object} map[string]string "Workspace has content under legal hold"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /workspaces/{id} [delete]
 func (server *Server) deleteWorkspace(ctx *gin.Context) {
 	var req getWorkspaceRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
-	err := server.workspaceService.DeleteWorkspace(ctx, req.ID)
+	currentUser := getCurrentUser(ctx)
+
+	err := server.workspaceService.DeleteWorkspace(ctx, currentUser.ID, req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		if strings.HasSuffix(err.Error(), "under legal hold") {
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+			return
+		}
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
+	// Members lose access right away, so drop their live connections too
+	server.hub.DisconnectWorkspace(req.ID)
+
 	ctx.JSON(http.StatusOK, gin.H{"message": "workspace deleted successfully"})
 }
 
@@ -0,0 +1,309 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Add Auto-Join Domain
+// @Description Let users of the organization whose verified email address is in a domain join the workspace without an invitation (requires workspace admin role). With requires_approval their requests wait for an admin.
+// @ID addAutoJoinDomain
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.CreateAutoJoinDomainRequest true "Email domain, e.g. acme.com"
+// @Success 201 {object} service.AutoJoinDomainResponse "Allowed domain"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 409 {object} map[string]string "Domain already allowed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/auto-join-domains [post]
+func (server *Server) addAutoJoinDomain(ctx *gin.Context) {
+	var req service.CreateAutoJoinDomainRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	domain, err := server.workspaceAutoJoinService.AddAutoJoinDomain(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		switch {
+		case err.Error() == "invalid domain":
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "already exists"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, domain)
+}
+
+// @Summary List Auto-Join Domains
+// @Description List the email domains allowed to join a workspace (requires workspace admin role)
+// @ID listAutoJoinDomains
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.AutoJoinDomainResponse "Allowed domains"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/auto-join-domains [get]
+func (server *Server) listAutoJoinDomains(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	domains, err := server.workspaceAutoJoinService.ListAutoJoinDomains(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domains)
+}
+
+// @Summary Remove Auto-Join Domain
+// @Description Stop an email domain from joining a workspace (requires workspace admin role). Pending join requests stay in the queue.
+// @ID removeAutoJoinDomain
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param domain_id path int true "Auto-join domain ID"
+// @Success 200 {object} map[string]string "Domain removed"
+// @Failure 400 {object} map[string]string "Invalid workspace or domain ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 404 {object} map[string]string "Auto-join domain not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/auto-join-domains/{domain_id} [delete]
+func (server *Server) removeAutoJoinDomain(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	domainID, err := strconv.ParseInt(ctx.Param("domain_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid auto-join domain ID")))
+		return
+	}
+
+	err = server.workspaceAutoJoinService.RemoveAutoJoinDomain(ctx, workspaceID, domainID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Auto-join domain removed successfully"})
+}
+
+// @Summary List Discoverable Workspaces
+// @Description List the workspaces of the current user's organization that allow the domain of their email address. The email address must be verified.
+// @ID listDiscoverableWorkspaces
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} service.DiscoverableWorkspaceResponse "Workspaces the user can join"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Email address is not verified"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/discoverable [get]
+func (server *Server) listDiscoverableWorkspaces(ctx *gin.Context) {
+	currentUser := getCurrentUser(ctx)
+
+	workspaces, err := server.workspaceAutoJoinService.ListDiscoverableWorkspaces(ctx, currentUser.ID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, workspaces)
+}
+
+// @Summary Auto-Join Workspace
+// @Description Join a workspace that allows the domain of the current user's verified email address. When the domain requires approval a join request is queued for the workspace admins instead.
+// @ID autoJoinWorkspace
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} service.AutoJoinResponse "Joined the workspace"
+// @Success 202 {object} service.AutoJoinResponse "Join request waiting for approval"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Email address not verified or domain not allowed"
+// @Failure 404 {object} map[string]string "Workspace not found"
+// @Failure 409 {object} map[string]string "User already in a workspace or request already pending"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/auto-join [post]
+func (server *Server) autoJoinWorkspace(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	result, err := server.workspaceAutoJoinService.AutoJoinWorkspace(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		switch {
+		case strings.HasPrefix(err.Error(), "access denied"):
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "user is already a member"), strings.HasSuffix(err.Error(), "already exists"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	if result.Status == service.AutoJoinStatusPending {
+		ctx.JSON(http.StatusAccepted, result)
+		return
+	}
+
+	server.welcomeNewMember(ctx, workspaceID, currentUser.ID)
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// @Summary List Join Requests
+// @Description List requests to join a workspace from users of allowed email domains, oldest first (requires workspace admin role)
+// @ID listJoinRequests
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param status query string false "Request status (default: pending)" Enums(pending, approved, rejected)
+// @Param limit query int false "Number of requests to return (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of requests to skip (default: 0)" minimum(0)
+// @Success 200 {array} service.JoinRequestResponse "Join requests"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/join-requests [get]
+func (server *Server) listJoinRequests(ctx *gin.Context) {
+	var req service.ListJoinRequestsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	if req.Status == "" {
+		req.Status = service.JoinRequestStatusPending
+	}
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	requests, err := server.workspaceAutoJoinService.ListJoinRequests(ctx, workspaceID, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+}
+
+// @Summary Review Join Request
+// @Description Approve a join request, adding the user to the workspace as a member, or reject it (requires workspace admin role)
+// @ID reviewJoinRequest
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request_id path int true "Join request ID"
+// @Param request body service.ReviewJoinRequestRequest true "Review decision"
+// @Success 200 {object} service.JoinRequestResponse "Reviewed join request"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 404 {object} map[string]string "Join request not found"
+// @Failure 409 {object} map[string]string "Join request already reviewed or user already in a workspace"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/join-requests/{request_id}/review [post]
+func (server *Server) reviewJoinRequest(ctx *gin.Context) {
+	var req service.ReviewJoinRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	requestID, err := strconv.ParseInt(ctx.Param("request_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid join request ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	request, err := server.workspaceAutoJoinService.ReviewJoinRequest(ctx, workspaceID, requestID, currentUser.ID, req.Decision)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "already reviewed"), strings.HasPrefix(err.Error(), "user is already a member"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	if request.Status == service.JoinRequestStatusApproved {
+		server.welcomeNewMember(ctx, workspaceID, request.UserID)
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
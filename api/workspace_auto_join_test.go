@@ -0,0 +1,446 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddAutoJoinDomainAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: `{"domain":" @Acme.COM ","requires_approval":true}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateWorkspaceAutoJoinDomainParams{
+					WorkspaceID:      workspace.ID,
+					Domain:           "acme.com",
+					RequiresApproval: true,
+					CreatedBy:        sql.NullInt64{Int64: admin.ID, Valid: true},
+				}
+				store.EXPECT().
+					CreateWorkspaceAutoJoinDomain(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.WorkspaceAutoJoinDomain{ID: 1, WorkspaceID: workspace.ID, Domain: "acme.com", RequiresApproval: true}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var domain service.AutoJoinDomainResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &domain))
+				require.Equal(t, "acme.com", domain.Domain)
+				require.True(t, domain.RequiresApproval)
+			},
+		},
+		{
+			name: "InvalidDomain",
+			body: `{"domain":"ada@acme.com"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWorkspaceAutoJoinDomain(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyExists",
+			body: `{"domain":"acme.com"}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWorkspaceAutoJoinDomain(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WorkspaceAutoJoinDomain{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return("admin", nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/auto-join-domains", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAutoJoinWorkspaceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	user.EmailVerifiedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	domain := user.Email[strings.LastIndexByte(user.Email, '@')+1:]
+
+	workspace := randomWorkspace(user.OrganizationID)
+	otherWorkspace := randomWorkspace(user.OrganizationID + 1)
+
+	joined := user
+	joined.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	joined.Role = "member"
+
+	testCases := []struct {
+		name          string
+		workspaceID   int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "Joined",
+			workspaceID: workspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(workspace, nil)
+				store.EXPECT().
+					GetWorkspaceAutoJoinDomain(gomock.Any(), gomock.Eq(db.GetWorkspaceAutoJoinDomainParams{WorkspaceID: workspace.ID, Domain: domain})).
+					Times(1).
+					Return(db.WorkspaceAutoJoinDomain{WorkspaceID: workspace.ID, Domain: domain}, nil)
+				store.EXPECT().
+					AddUserToWorkspace(gomock.Any(), gomock.Eq(db.AddUserToWorkspaceParams{
+						ID:          user.ID,
+						WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+						Role:        "member",
+					})).
+					Times(1).
+					Return(joined, nil)
+				store.EXPECT().CreateWorkspaceJoinRequest(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetWorkspaceOnboarding(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(db.WorkspaceOnboarding{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var result service.AutoJoinResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+				require.Equal(t, service.AutoJoinStatusJoined, result.Status)
+				require.NotNil(t, result.User)
+				require.Equal(t, workspace.ID, *result.User.WorkspaceID)
+			},
+		},
+		{
+			name:        "RequiresApproval",
+			workspaceID: workspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(workspace, nil)
+				store.EXPECT().
+					GetWorkspaceAutoJoinDomain(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WorkspaceAutoJoinDomain{WorkspaceID: workspace.ID, Domain: domain, RequiresApproval: true}, nil)
+				store.EXPECT().
+					CreateWorkspaceJoinRequest(gomock.Any(), gomock.Eq(db.CreateWorkspaceJoinRequestParams{WorkspaceID: workspace.ID, UserID: user.ID})).
+					Times(1).
+					Return(db.WorkspaceJoinRequest{ID: 1, WorkspaceID: workspace.ID, UserID: user.ID, Status: "pending"}, nil)
+				store.EXPECT().AddUserToWorkspace(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var result service.AutoJoinResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+				require.Equal(t, service.AutoJoinStatusPending, result.Status)
+				require.NotNil(t, result.Request)
+				require.Nil(t, result.User)
+			},
+		},
+		{
+			name:        "RequestAlreadyPending",
+			workspaceID: workspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(workspace, nil)
+				store.EXPECT().
+					GetWorkspaceAutoJoinDomain(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WorkspaceAutoJoinDomain{WorkspaceID: workspace.ID, Domain: domain, RequiresApproval: true}, nil)
+				store.EXPECT().
+					CreateWorkspaceJoinRequest(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WorkspaceJoinRequest{}, &pgconn.PgError{Code: db.UniqueViolation})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "EmailNotVerified",
+			workspaceID: workspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				unverified := user
+				unverified.EmailVerifiedAt = sql.NullTime{}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(unverified, nil)
+				store.EXPECT().GetWorkspaceAutoJoinDomain(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:        "DomainNotAllowed",
+			workspaceID: workspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(workspace, nil)
+				store.EXPECT().
+					GetWorkspaceAutoJoinDomain(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WorkspaceAutoJoinDomain{}, sql.ErrNoRows)
+				store.EXPECT().AddUserToWorkspace(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:        "OtherOrganization",
+			workspaceID: otherWorkspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Eq(otherWorkspace.ID)).Times(1).Return(otherWorkspace, nil)
+				store.EXPECT().GetWorkspaceAutoJoinDomain(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "AlreadyInWorkspace",
+			workspaceID: workspace.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(joined, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/auto-join", tc.workspaceID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReviewJoinRequestAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+
+	requester, _ := randomUser(t)
+	requester.OrganizationID = admin.OrganizationID
+
+	request := db.WorkspaceJoinRequest{
+		ID:          1,
+		WorkspaceID: workspace.ID,
+		UserID:      requester.ID,
+		Status:      "pending",
+		CreatedAt:   time.Now(),
+	}
+	reviewed := func(status string) db.WorkspaceJoinRequest {
+		r := request
+		r.Status = status
+		r.ReviewedBy = sql.NullInt64{Int64: admin.ID, Valid: true}
+		r.ReviewedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		return r
+	}
+
+	testCases := []struct {
+		name          string
+		decision      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Approve",
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWorkspaceJoinRequest(gomock.Any(), gomock.Any()).Times(1).Return(request, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(requester.ID)).Times(1).Return(requester, nil)
+				store.EXPECT().
+					ApproveWorkspaceJoinRequestTx(gomock.Any(), gomock.Eq(db.ApproveWorkspaceJoinRequestTxParams{
+						ID:          request.ID,
+						WorkspaceID: workspace.ID,
+						ReviewerID:  admin.ID,
+					})).
+					Times(1).
+					Return(db.ApproveWorkspaceJoinRequestTxResult{Request: reviewed("approved")}, nil)
+				store.EXPECT().GetWorkspaceOnboarding(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(db.WorkspaceOnboarding{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got service.JoinRequestResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, service.JoinRequestStatusApproved, got.Status)
+				require.NotNil(t, got.ReviewedBy)
+			},
+		},
+		{
+			name:     "Reject",
+			decision: "reject",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWorkspaceJoinRequest(gomock.Any(), gomock.Any()).Times(1).Return(request, nil)
+				store.EXPECT().
+					ReviewWorkspaceJoinRequest(gomock.Any(), gomock.Eq(db.ReviewWorkspaceJoinRequestParams{
+						ID:          request.ID,
+						WorkspaceID: workspace.ID,
+						Status:      "rejected",
+						ReviewedBy:  sql.NullInt64{Int64: admin.ID, Valid: true},
+					})).
+					Times(1).
+					Return(reviewed("rejected"), nil)
+				store.EXPECT().ApproveWorkspaceJoinRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "UserJoinedAnotherWorkspace",
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				moved := requester
+				moved.WorkspaceID = sql.NullInt64{Int64: workspace.ID + 1, Valid: true}
+				store.EXPECT().GetWorkspaceJoinRequest(gomock.Any(), gomock.Any()).Times(1).Return(request, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(requester.ID)).Times(1).Return(moved, nil)
+				store.EXPECT().ApproveWorkspaceJoinRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "AlreadyReviewed",
+			decision: "approve",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWorkspaceJoinRequest(gomock.Any(), gomock.Any()).Times(1).Return(reviewed("rejected"), nil)
+				store.EXPECT().ApproveWorkspaceJoinRequestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			decision: "reject",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWorkspaceJoinRequest(gomock.Any(), gomock.Any()).Times(1).Return(db.WorkspaceJoinRequest{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidDecision",
+			decision: "maybe",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWorkspaceJoinRequest(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return("admin", nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body := fmt.Sprintf(`{"decision":%q}`, tc.decision)
+			url := fmt.Sprintf("/workspaces/%d/join-requests/%d/review", workspace.ID, request.ID)
+			req, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(body))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Workspace Events
+// @Description Read a workspace's event stream for data pipelines: message.created, message.edited, member.joined and file.uploaded events, oldest first. Start with since=0 and pass next_cursor back as since to read the events that follow; the most recent few seconds are held back so no event is skipped. Requires read_events permission.
+// @ID listWorkspaceEvents
+// @Tags workspaces
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param since query int false "Return events after this event ID (default: 0)" minimum(0)
+// @Param limit query int false "Number of events to return (default: 100, max: 1000)" minimum(1) maximum(1000)
+// @Success 200 {object} service.WorkspaceEventsResponse "Workspace events"
+// @Failure 400 {object} map[string]string "Invalid workspace ID or query parameters"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "read_events permission required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/events [get]
+func (server *Server) listWorkspaceEvents(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	var req service.ListWorkspaceEventsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	events, err := server.workspaceEventService.ListEvents(ctx, workspaceID, req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListWorkspaceEventsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?since=41&limit=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+				store.EXPECT().
+					ListWorkspaceEvents(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ListWorkspaceEventsParams) ([]db.WorkspaceEvent, error) {
+						require.Equal(t, workspace.ID, arg.WorkspaceID)
+						require.Equal(t, int64(41), arg.AfterID)
+						return []db.WorkspaceEvent{{
+							ID:          42,
+							WorkspaceID: workspace.ID,
+							EventType:   service.WorkspaceEventMemberJoined,
+							ActorID:     sql.NullInt64{Int64: user.ID, Valid: true},
+							Data:        json.RawMessage(fmt.Sprintf(`{"user_id":%d,"role":"member"}`, user.ID)),
+							CreatedAt:   time.Now().Add(-time.Minute),
+						}}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.WorkspaceEventsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Events, 1)
+				require.Equal(t, service.WorkspaceEventMemberJoined, response.Events[0].Type)
+				require.Equal(t, int64(42), response.NextCursor)
+				require.False(t, response.HasMore)
+			},
+		},
+		{
+			name:  "NoPermission",
+			query: "?since=0",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+				store.EXPECT().GetUserRolePermissions(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, sql.ErrNoRows)
+				store.EXPECT().ListWorkspaceEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "LimitTooLarge",
+			query: "?limit=5000",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("admin", nil)
+				store.EXPECT().ListWorkspaceEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).Times(1).Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/events%s", workspace.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
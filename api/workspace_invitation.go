@@ -1,15 +1,19 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/heyrmi/goslack/service"
 )
 
 // @Summary Invite User to Workspace
-// @Description Invite a user to join a workspace (requires workspace admin role)
+// @Description Invite a user to join a workspace (requires workspace admin role). An email with the invitation link is sent to the invitee, email_status tells whether it went out.
+// @ID inviteUserToWorkspace
 // @Tags workspace-invitations
 // @Security BearerAuth
 // @Accept json
@@ -26,7 +30,7 @@ import (
 func (server *Server) inviteUserToWorkspace(ctx *gin.Context) {
 	var req service.InviteUserRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -34,7 +38,7 @@ func (server *Server) inviteUserToWorkspace(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
@@ -44,18 +48,142 @@ func (server *Server) inviteUserToWorkspace(ctx *gin.Context) {
 	invitation, err := server.workspaceInvitationService.InviteUser(ctx, workspaceID, currentUser.ID, req)
 	if err != nil {
 		if err.Error() == "user is already a member of this workspace" {
-			ctx.JSON(http.StatusConflict, errorResponse(err))
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, invitation)
 }
 
+// maxInvitationCSVSize bounds CSV uploads to bulk invitations
+const maxInvitationCSVSize = 1 << 20 // 1MB
+
+// @Summary Bulk Invite Users to Workspace
+// @Description Invite up to 500 users at once (requires workspace admin role). Send a JSON list of emails and roles, a text/csv body, or a multipart form with a CSV "file". CSV rows are "email,role" with an optional header row, the role defaults to member. Each row is validated on its own and the report gives its status: invited, already_member, already_invited, duplicate, invalid_email or invalid_role. The invitations are created together and their emails are sent in the background.
+// @ID bulkInviteUsersToWorkspace
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Accept json,text/csv,multipart/form-data
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param invitations body service.BulkInviteRequest false "Emails and roles to invite"
+// @Param file formData file false "CSV of emails and roles"
+// @Success 200 {object} service.BulkInviteResponse "Per-email report"
+// @Failure 400 {object} map[string]string "Invalid request or CSV"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/invitations/bulk [post]
+func (server *Server) bulkInviteUsersToWorkspace(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	var entries []service.BulkInviteEntry
+	switch ctx.ContentType() {
+	case "text/csv":
+		entries, err = service.ParseInvitationCSV(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxInvitationCSVSize))
+	case "multipart/form-data":
+		entries, err = parseInvitationCSVUpload(ctx)
+	default:
+		var req service.BulkInviteRequest
+		err = ctx.ShouldBindJSON(&req)
+		entries = req.Invitations
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	report, err := server.workspaceInvitationService.BulkInviteUsers(ctx, workspaceID, currentUser.ID, entries)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid bulk invitation") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// parseInvitationCSVUpload reads bulk invitation rows from the "file" field of a multipart form
+func parseInvitationCSVUpload(ctx *gin.Context) ([]service.BulkInviteEntry, error) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxInvitationCSVSize)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, errors.New("invalid CSV: missing file")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	defer file.Close()
+
+	return service.ParseInvitationCSV(file)
+}
+
+// @Summary Resend Workspace Invitation
+// @Description Send the email of a pending invitation again (requires workspace admin role). Emails for an invitation must be a few minutes apart.
+// @ID resendWorkspaceInvitation
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param invitation_id path int true "Invitation ID"
+// @Success 200 {object} service.WorkspaceInvitationResponse "Invitation with the new email status"
+// @Failure 400 {object} map[string]string "Invalid workspace or invitation ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 404 {object} map[string]string "Invitation not found"
+// @Failure 409 {object} map[string]string "Invitation is no longer pending"
+// @Failure 429 {object} map[string]string "Invitation email was sent recently"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/invitations/{invitation_id}/resend [post]
+func (server *Server) resendWorkspaceInvitation(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	invitationID, err := strconv.ParseInt(ctx.Param("invitation_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid invitation ID")))
+		return
+	}
+
+	invitation, err := server.workspaceInvitationService.ResendInvitation(ctx, workspaceID, invitationID)
+	if err != nil {
+		switch {
+		case strings.HasSuffix(err.Error(), "not found"):
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		case strings.HasSuffix(err.Error(), "no longer pending"):
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		case strings.HasPrefix(err.Error(), "invitation email was sent recently"):
+			ctx.JSON(http.StatusTooManyRequests, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, invitation)
+}
+
 // @Summary Join Workspace
 // @Description Join a workspace using invitation code
+// @ID joinWorkspace
 // @Tags workspace-invitations
 // @Security BearerAuth
 // @Accept json
@@ -70,7 +198,7 @@ func (server *Server) inviteUserToWorkspace(ctx *gin.Context) {
 func (server *Server) joinWorkspace(ctx *gin.Context) {
 	var req service.JoinWorkspaceRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -81,22 +209,25 @@ func (server *Server) joinWorkspace(ctx *gin.Context) {
 	if err != nil {
 		switch err.Error() {
 		case "invalid or expired invitation code":
-			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 			return
 		case "invitation is not for this user", "user is already a member of another workspace":
-			ctx.JSON(http.StatusConflict, errorResponse(err))
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
 			return
 		default:
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 			return
 		}
 	}
 
+	server.welcomeNewMember(ctx, *user.WorkspaceID, user.ID)
+
 	ctx.JSON(http.StatusOK, user)
 }
 
 // @Summary List Workspace Invitations
 // @Description List invitations for a workspace (requires workspace admin role)
+// @ID listWorkspaceInvitations
 // @Tags workspace-invitations
 // @Security BearerAuth
 // @Produce json
@@ -114,7 +245,7 @@ func (server *Server) listWorkspaceInvitations(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
@@ -138,7 +269,7 @@ func (server *Server) listWorkspaceInvitations(ctx *gin.Context) {
 
 	invitations, err := server.workspaceInvitationService.ListWorkspaceInvitations(ctx, workspaceID, pageSize, offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -146,14 +277,18 @@ func (server *Server) listWorkspaceInvitations(ctx *gin.Context) {
 }
 
 // @Summary List Workspace Members
-// @Description List members of a workspace (requires workspace membership)
+// @Description List members of a workspace (requires workspace membership). Members with the manage_members permission also see whose emails are suppressed after a bounce or spam complaint.
+// @ID listWorkspaceMembers
 // @Tags workspace-members
 // @Security BearerAuth
 // @Produce json
 // @Param id path int true "Workspace ID"
 // @Param page_id query int false "Page ID (default: 1)"
 // @Param page_size query int false "Page size (default: 10, max: 50)"
+// @Param fields query string false "Comma-separated fields to return for each member, e.g. id,first_name,last_name"
+// @Param If-None-Match header string false "ETag of a previously fetched list"
 // @Success 200 {array} service.UserResponse "List of workspace members"
+// @Success 304 "List has not changed"
 // @Failure 400 {object} map[string]string "Invalid request"
 // @Failure 401 {object} map[string]string "Authentication required"
 // @Failure 403 {object} map[string]string "Workspace membership required"
@@ -164,7 +299,7 @@ func (server *Server) listWorkspaceMembers(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
@@ -188,15 +323,35 @@ func (server *Server) listWorkspaceMembers(ctx *gin.Context) {
 
 	members, err := server.workspaceInvitationService.ListWorkspaceMembers(ctx, workspaceID, pageSize, offset)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, members)
+	// Admins also see which members no longer get emails
+	currentUser := getCurrentUser(ctx)
+	canManage, err := server.userService.HasWorkspacePermission(ctx, currentUser.ID, workspaceID, service.PermissionManageMembers)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+	if canManage {
+		if err := server.workspaceInvitationService.AddEmailSuppressions(ctx, members); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+			return
+		}
+	}
+
+	selected, ok := filterFields(ctx, members)
+	if !ok {
+		return
+	}
+
+	jsonWithETag(ctx, http.StatusOK, selected)
 }
 
 // @Summary Remove User from Workspace
 // @Description Remove a user from workspace (requires workspace admin role)
+// @ID removeUserFromWorkspace
 // @Tags workspace-members
 // @Security BearerAuth
 // @Produce json
@@ -214,7 +369,7 @@ func (server *Server) removeUserFromWorkspace(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
@@ -222,25 +377,77 @@ func (server *Server) removeUserFromWorkspace(ctx *gin.Context) {
 	userIDStr := ctx.Param("user_id")
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		return
+	}
+
+	if err := server.checkAdminChange(ctx, workspaceID, userID, false); err != nil {
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
 	user, err := server.workspaceInvitationService.RemoveUserFromWorkspace(ctx, userID, workspaceID)
 	if err != nil {
 		if err.Error() == "user not found in workspace" {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, user)
 }
 
+// @Summary Lift Member Email Suppression
+// @Description Let emails be sent again to a member whose address bounced or complained (requires manage_members permission)
+// @ID liftMemberEmailSuppression
+// @Tags workspace-members
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param user_id path int true "User ID"
+// @Success 204 "Suppression lifted"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_members permission required"
+// @Failure 404 {object} map[string]string "User not found in workspace or not suppressed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/members/{user_id}/email-suppression [delete]
+func (server *Server) liftMemberEmailSuppression(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		return
+	}
+
+	userID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		return
+	}
+
+	err = server.workspaceInvitationService.LiftMemberEmailSuppression(ctx, workspaceID, userID)
+	if err != nil {
+		switch err.Error() {
+		case "user not found in workspace", "email address is not suppressed":
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 // @Summary Update Workspace Member Role
 // @Description Update a user's role in workspace (requires workspace admin role)
+// @ID updateWorkspaceMemberRole
 // @Tags workspace-members
 // @Security BearerAuth
 // @Accept json
@@ -258,7 +465,7 @@ func (server *Server) removeUserFromWorkspace(ctx *gin.Context) {
 func (server *Server) updateWorkspaceMemberRole(ctx *gin.Context) {
 	var req service.UpdateUserRoleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
 		return
 	}
 
@@ -266,7 +473,7 @@ func (server *Server) updateWorkspaceMemberRole(ctx *gin.Context) {
 	workspaceIDStr := ctx.Param("id")
 	workspaceID, err := strconv.ParseInt(workspaceIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
 		return
 	}
 
@@ -274,17 +481,26 @@ func (server *Server) updateWorkspaceMemberRole(ctx *gin.Context) {
 	userIDStr := ctx.Param("user_id")
 	userID, err := strconv.ParseInt(userIDStr, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		return
+	}
+
+	if err := server.checkAdminChange(ctx, workspaceID, userID, req.Role == "admin"); err != nil {
+		if strings.HasPrefix(err.Error(), "access denied") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
 	user, err := server.workspaceInvitationService.UpdateWorkspaceMemberRole(ctx, userID, workspaceID, req.Role)
 	if err != nil {
 		if err.Error() == "user not found in workspace" {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
 			return
 		}
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
 		return
 	}
 
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkInviteUsersAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+
+	csvUpload := func() (io.Reader, string) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("file", "invites.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("email,role\nada@example.com,admin\nbob@example.com,member\n"))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+		return &body, writer.FormDataContentType()
+	}
+
+	testCases := []struct {
+		name          string
+		body          func() (io.Reader, string)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "JSON",
+			body: func() (io.Reader, string) {
+				return strings.NewReader(`{"invitations":[{"email":"ada@example.com","role":"admin"},{"email":"nope"}]}`), "application/json"
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUsersByEmails(gomock.Any(), gomock.Eq([]string{"ada@example.com"})).Times(1).Return(nil, nil)
+				store.EXPECT().ListPendingInvitationEmails(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				store.EXPECT().
+					CreateWorkspaceInvitationsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.WorkspaceInvitation{{ID: 1, WorkspaceID: workspace.ID, InviteeEmail: "ada@example.com", Role: "admin", Status: "pending"}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var report service.BulkInviteResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+				require.Equal(t, 1, report.Invited)
+				require.Equal(t, 1, report.Skipped)
+				require.Equal(t, service.BulkInviteStatusInvalidEmail, report.Results[1].Status)
+			},
+		},
+		{
+			name: "CSVBody",
+			body: func() (io.Reader, string) {
+				return strings.NewReader("ada@example.com\nbob@example.com,admin\n"), "text/csv"
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUsersByEmails(gomock.Any(), gomock.Eq([]string{"ada@example.com", "bob@example.com"})).Times(1).Return(nil, nil)
+				store.EXPECT().ListPendingInvitationEmails(gomock.Any(), gomock.Any()).Times(1).Return([]string{"bob@example.com"}, nil)
+				store.EXPECT().
+					CreateWorkspaceInvitationsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.WorkspaceInvitation{{ID: 1, WorkspaceID: workspace.ID, InviteeEmail: "ada@example.com", Role: "member", Status: "pending"}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var report service.BulkInviteResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+				require.Equal(t, 1, report.Invited)
+				require.Equal(t, service.BulkInviteStatusAlreadyInvited, report.Results[1].Status)
+			},
+		},
+		{
+			name: "CSVUpload",
+			body: csvUpload,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListUsersByEmails(gomock.Any(), gomock.Eq([]string{"ada@example.com", "bob@example.com"})).Times(1).Return(nil, nil)
+				store.EXPECT().ListPendingInvitationEmails(gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
+				store.EXPECT().
+					CreateWorkspaceInvitationsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.WorkspaceInvitation{
+						{ID: 1, WorkspaceID: workspace.ID, InviteeEmail: "ada@example.com", Role: "admin", Status: "pending"},
+						{ID: 2, WorkspaceID: workspace.ID, InviteeEmail: "bob@example.com", Role: "member", Status: "pending"},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var report service.BulkInviteResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+				require.Equal(t, 2, report.Invited)
+			},
+		},
+		{
+			name: "EmptyList",
+			body: func() (io.Reader, string) {
+				return strings.NewReader(`{"invitations":[]}`), "application/json"
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWorkspaceInvitationsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "EmptyCSV",
+			body: func() (io.Reader, string) {
+				return strings.NewReader("email,role\n"), "text/csv"
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWorkspaceInvitationsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return("admin", nil)
+			tc.buildStubs(store)
+
+			// Invitation emails are sent in the background
+			store.EXPECT().GetWorkspace(gomock.Any(), gomock.Any()).AnyTimes().Return(workspace, nil)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().Return(admin, nil)
+			store.EXPECT().GetEmailTemplate(gomock.Any(), gomock.Any()).AnyTimes().Return(db.EmailTemplate{}, sql.ErrNoRows)
+			store.EXPECT().GetEmailBranding(gomock.Any(), gomock.Any()).AnyTimes().Return(db.EmailBranding{}, sql.ErrNoRows)
+			store.EXPECT().GetEmailSuppression(gomock.Any(), gomock.Any()).AnyTimes().Return(db.EmailSuppression{}, sql.ErrNoRows)
+			store.EXPECT().CreateEmailDelivery(gomock.Any(), gomock.Any()).AnyTimes().Return(db.EmailDelivery{}, nil)
+			store.EXPECT().UpdateWorkspaceInvitationEmailStatus(gomock.Any(), gomock.Any()).AnyTimes().Return(db.WorkspaceInvitation{}, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			body, contentType := tc.body()
+			url := fmt.Sprintf("/workspaces/%d/invitations/bulk", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", contentType)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
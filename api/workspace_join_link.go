@@ -0,0 +1,172 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary Create Workspace Join Link
+// @Description Create a shareable link that adds anyone in the organization who opens it to the workspace as a member (requires workspace admin role). Links can expire and be limited to a number of uses.
+// @ID createWorkspaceJoinLink
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.CreateJoinLinkRequest false "Optional expiry and use limit"
+// @Success 201 {object} service.JoinLinkResponse "Join link"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/join-links [post]
+func (server *Server) createWorkspaceJoinLink(ctx *gin.Context) {
+	var req service.CreateJoinLinkRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+			return
+		}
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	link, err := server.workspaceInvitationService.CreateJoinLink(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid join link") {
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, link)
+}
+
+// @Summary List Workspace Join Links
+// @Description List a workspace's join links, newest first, including revoked and expired ones (requires workspace admin role)
+// @ID listWorkspaceJoinLinks
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param limit query int false "Number of links to return (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of links to skip (default: 0)" minimum(0)
+// @Success 200 {array} service.JoinLinkResponse "Join links"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/join-links [get]
+func (server *Server) listWorkspaceJoinLinks(ctx *gin.Context) {
+	var req service.GetMessagesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	links, err := server.workspaceInvitationService.ListJoinLinks(ctx, workspaceID, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, links)
+}
+
+// @Summary Revoke Workspace Join Link
+// @Description Stop a join link from being used (requires workspace admin role). Members who already joined through it stay in the workspace.
+// @ID revokeWorkspaceJoinLink
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param link_id path int true "Join link ID"
+// @Success 200 {object} service.JoinLinkResponse "Revoked join link"
+// @Failure 400 {object} map[string]string "Invalid workspace or join link ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace admin access required"
+// @Failure 404 {object} map[string]string "Join link not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/join-links/{link_id} [delete]
+func (server *Server) revokeWorkspaceJoinLink(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	linkID, err := strconv.ParseInt(ctx.Param("link_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid join link ID")))
+		return
+	}
+
+	link, err := server.workspaceInvitationService.RevokeJoinLink(ctx, workspaceID, linkID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, link)
+}
+
+// @Summary Join Workspace by Link
+// @Description Join the workspace of a shareable join link as a member. Only users of the workspace's organization who aren't in a workspace yet can join.
+// @ID joinWorkspaceByLink
+// @Tags workspace-invitations
+// @Security BearerAuth
+// @Produce json
+// @Param token path string true "Join link token"
+// @Success 200 {object} service.UserResponse "Updated user information"
+// @Failure 400 {object} map[string]string "Invalid or expired join link"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 409 {object} map[string]string "User already in a workspace"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/join-link/{token} [post]
+func (server *Server) joinWorkspaceByLink(ctx *gin.Context) {
+	currentUser := getCurrentUser(ctx)
+
+	user, err := server.workspaceInvitationService.JoinWorkspaceByLink(ctx, currentUser.ID, ctx.Param("token"))
+	if err != nil {
+		switch err.Error() {
+		case "invalid or expired join link":
+			ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+		case "user is already a member of this workspace", "user is already a member of another workspace":
+			ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		}
+		return
+	}
+
+	server.welcomeNewMember(ctx, *user.WorkspaceID, user.ID)
+
+	ctx.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,289 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWorkspaceJoinLinkAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	workspace := randomWorkspace(admin.OrganizationID)
+
+	admin.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	admin.Role = "admin"
+
+	testCases := []struct {
+		name          string
+		body          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: `{"max_uses":10}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWorkspaceJoinLink(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg db.CreateWorkspaceJoinLinkParams) (db.WorkspaceJoinLink, error) {
+						require.Equal(t, workspace.ID, arg.WorkspaceID)
+						require.Equal(t, admin.ID, arg.CreatedBy.Int64)
+						require.Equal(t, sql.NullInt32{Int32: 10, Valid: true}, arg.MaxUses)
+						require.False(t, arg.ExpiresAt.Valid)
+						require.NotEmpty(t, arg.Token)
+						return db.WorkspaceJoinLink{
+							ID:          1,
+							WorkspaceID: arg.WorkspaceID,
+							Token:       arg.Token,
+							CreatedBy:   arg.CreatedBy,
+							MaxUses:     arg.MaxUses,
+							CreatedAt:   time.Now(),
+						}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var link service.JoinLinkResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &link))
+				require.True(t, link.Active)
+				require.NotNil(t, link.MaxUses)
+				require.True(t, strings.HasSuffix(link.URL, "/join-link/"+link.Token))
+			},
+		},
+		{
+			name: "NoBody",
+			body: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWorkspaceJoinLink(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WorkspaceJoinLink{ID: 1, WorkspaceID: workspace.ID, Token: "token"}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "ExpiryInThePast",
+			body: fmt.Sprintf(`{"expires_at":%q}`, time.Now().Add(-time.Hour).Format(time.RFC3339)),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWorkspaceJoinLink(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidMaxUses",
+			body: `{"max_uses":0}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWorkspaceJoinLink(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(admin.Email)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return("admin", nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/%d/join-links", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestJoinWorkspaceByLinkAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+	otherWorkspace := randomWorkspace(user.OrganizationID + 1)
+
+	token := util.RandomString(32)
+	link := db.WorkspaceJoinLink{
+		ID:          1,
+		WorkspaceID: workspace.ID,
+		Token:       token,
+		UseCount:    1,
+		CreatedAt:   time.Now(),
+	}
+
+	joined := user
+	joined.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWorkspaceJoinLinkByToken(gomock.Any(), gomock.Eq(token)).Times(1).Return(link, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(workspace, nil)
+				store.EXPECT().
+					JoinWorkspaceByLinkTx(gomock.Any(), gomock.Eq(db.JoinWorkspaceByLinkTxParams{Token: token, UserID: user.ID})).
+					Times(1).
+					Return(db.JoinWorkspaceByLinkTxResult{Link: link, User: joined}, nil)
+				store.EXPECT().GetWorkspaceOnboarding(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(db.WorkspaceOnboarding{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got service.UserResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.NotNil(t, got.WorkspaceID)
+				require.Equal(t, workspace.ID, *got.WorkspaceID)
+			},
+		},
+		{
+			name: "UnknownToken",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWorkspaceJoinLinkByToken(gomock.Any(), gomock.Any()).Times(1).Return(db.WorkspaceJoinLink{}, sql.ErrNoRows)
+				store.EXPECT().JoinWorkspaceByLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Expired",
+			buildStubs: func(store *mockdb.MockStore) {
+				expired := link
+				expired.ExpiresAt = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+				store.EXPECT().GetWorkspaceJoinLinkByToken(gomock.Any(), gomock.Any()).Times(1).Return(expired, nil)
+				store.EXPECT().JoinWorkspaceByLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UsedUp",
+			buildStubs: func(store *mockdb.MockStore) {
+				usedUp := link
+				usedUp.MaxUses = sql.NullInt32{Int32: 1, Valid: true}
+				store.EXPECT().GetWorkspaceJoinLinkByToken(gomock.Any(), gomock.Any()).Times(1).Return(usedUp, nil)
+				store.EXPECT().JoinWorkspaceByLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Revoked",
+			buildStubs: func(store *mockdb.MockStore) {
+				revoked := link
+				revoked.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().GetWorkspaceJoinLinkByToken(gomock.Any(), gomock.Any()).Times(1).Return(revoked, nil)
+				store.EXPECT().JoinWorkspaceByLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "OtherOrganization",
+			buildStubs: func(store *mockdb.MockStore) {
+				other := link
+				other.WorkspaceID = otherWorkspace.ID
+				store.EXPECT().GetWorkspaceJoinLinkByToken(gomock.Any(), gomock.Any()).Times(1).Return(other, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Eq(otherWorkspace.ID)).Times(1).Return(otherWorkspace, nil)
+				store.EXPECT().JoinWorkspaceByLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyMember",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWorkspaceJoinLinkByToken(gomock.Any(), gomock.Any()).Times(1).Return(link, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(joined, nil)
+				store.EXPECT().JoinWorkspaceByLinkTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "UsedUpConcurrently",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWorkspaceJoinLinkByToken(gomock.Any(), gomock.Any()).Times(1).Return(link, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.ID)).Times(1).Return(user, nil)
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Eq(workspace.ID)).Times(1).Return(workspace, nil)
+				store.EXPECT().JoinWorkspaceByLinkTx(gomock.Any(), gomock.Any()).Times(1).Return(db.JoinWorkspaceByLinkTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/workspaces/join-link/%s", token)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
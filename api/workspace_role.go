@@ -0,0 +1,293 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Permissions
+// @Description List every permission a custom workspace role can grant
+// @ID listPermissions
+// @Tags workspace-roles
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} service.PermissionResponse "Permission catalog"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Router /permissions [get]
+func (server *Server) listPermissions(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, service.Permissions())
+}
+
+// @Summary Get My Workspace Permissions
+// @Description List the permissions the current user holds in a workspace (requires workspace membership)
+// @ID getMyWorkspacePermissions
+// @Tags workspace-roles
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {object} map[string][]string "Permissions held"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/permissions/me [get]
+func (server *Server) getMyWorkspacePermissions(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	permissions, err := server.userService.GetWorkspacePermissions(ctx, currentUser.ID, workspaceID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found in workspace") {
+			ctx.JSON(http.StatusForbidden, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"permissions": permissions})
+}
+
+// @Summary List Workspace Roles
+// @Description List a workspace's custom roles and how many members hold each (requires workspace membership)
+// @ID listWorkspaceRoles
+// @Tags workspace-roles
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Success 200 {array} service.WorkspaceRoleResponse "Custom roles"
+// @Failure 400 {object} map[string]string "Invalid workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Workspace membership required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/roles [get]
+func (server *Server) listWorkspaceRoles(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	roles, err := server.workspaceRoleService.ListRoles(ctx, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, roles)
+}
+
+// @Summary Create Workspace Role
+// @Description Create a custom role granting a set of permissions (requires manage_roles permission)
+// @ID createWorkspaceRole
+// @Tags workspace-roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param request body service.WorkspaceRoleRequest true "Role name, description and permissions"
+// @Success 201 {object} service.WorkspaceRoleResponse "Created role"
+// @Failure 400 {object} map[string]string "Invalid request or unknown permission"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_roles permission required"
+// @Failure 409 {object} map[string]string "Role already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/roles [post]
+func (server *Server) createWorkspaceRole(ctx *gin.Context) {
+	var req service.WorkspaceRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	currentUser := getCurrentUser(ctx)
+
+	role, err := server.workspaceRoleService.CreateRole(ctx, workspaceID, currentUser.ID, req)
+	if err != nil {
+		handleWorkspaceRoleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, role)
+}
+
+// @Summary Update Workspace Role
+// @Description Replace a custom role's name, description and permissions (requires manage_roles permission)
+// @ID updateWorkspaceRole
+// @Tags workspace-roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param role_id path int true "Role ID"
+// @Param request body service.WorkspaceRoleRequest true "Role name, description and permissions"
+// @Success 200 {object} service.WorkspaceRoleResponse "Updated role"
+// @Failure 400 {object} map[string]string "Invalid request or unknown permission"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_roles permission required"
+// @Failure 404 {object} map[string]string "Role not found"
+// @Failure 409 {object} map[string]string "Role already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/roles/{role_id} [put]
+func (server *Server) updateWorkspaceRole(ctx *gin.Context) {
+	var req service.WorkspaceRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	roleID, err := strconv.ParseInt(ctx.Param("role_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid role ID")))
+		return
+	}
+
+	role, err := server.workspaceRoleService.UpdateRole(ctx, workspaceID, roleID, req)
+	if err != nil {
+		handleWorkspaceRoleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, role)
+}
+
+// @Summary Delete Workspace Role
+// @Description Delete a custom role. Members who held it keep only their built-in role's permissions (requires manage_roles permission).
+// @ID deleteWorkspaceRole
+// @Tags workspace-roles
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param role_id path int true "Role ID"
+// @Success 200 {object} map[string]string "Role deleted"
+// @Failure 400 {object} map[string]string "Invalid workspace or role ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_roles permission required"
+// @Failure 404 {object} map[string]string "Role not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/roles/{role_id} [delete]
+func (server *Server) deleteWorkspaceRole(ctx *gin.Context) {
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	roleID, err := strconv.ParseInt(ctx.Param("role_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid role ID")))
+		return
+	}
+
+	if err := server.workspaceRoleService.DeleteRole(ctx, workspaceID, roleID); err != nil {
+		handleWorkspaceRoleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "role deleted successfully"})
+}
+
+// @Summary Assign Workspace Role
+// @Description Give a workspace member a custom role, or remove it with a null role_id (requires manage_roles permission)
+// @ID assignWorkspaceRole
+// @Tags workspace-roles
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Workspace ID"
+// @Param user_id path int true "User ID"
+// @Param request body service.AssignWorkspaceRoleRequest true "Custom role ID"
+// @Success 200 {object} service.UserResponse "Updated member"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "manage_roles permission required"
+// @Failure 404 {object} map[string]string "Role or member not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /workspaces/{id}/members/{user_id}/custom-role [put]
+func (server *Server) assignWorkspaceRole(ctx *gin.Context) {
+	var req service.AssignWorkspaceRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	userID, err := strconv.ParseInt(ctx.Param("user_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid user ID")))
+		return
+	}
+
+	user, err := server.workspaceRoleService.AssignRole(ctx, workspaceID, userID, req.RoleID)
+	if err != nil {
+		handleWorkspaceRoleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+func handleWorkspaceRoleError(ctx *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "invalid"):
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "not found") || strings.HasSuffix(err.Error(), "not found in workspace"):
+		ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+	case strings.HasSuffix(err.Error(), "already exists"):
+		ctx.JSON(http.StatusConflict, errorResponse(ctx, err))
+	default:
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+	}
+}
+
+// checkAdminChange stops members who can manage members, but aren't admins
+// themselves, from granting the admin role or changing an admin
+func (server *Server) checkAdminChange(ctx *gin.Context, workspaceID, targetUserID int64, grantsAdmin bool) error {
+	currentUser := getCurrentUser(ctx)
+
+	isAdmin, err := server.userService.IsWorkspaceAdmin(ctx, currentUser.ID, workspaceID)
+	if err != nil || isAdmin {
+		return err
+	}
+
+	if grantsAdmin {
+		return errors.New("access denied: only admins can grant the admin role")
+	}
+
+	targetIsAdmin, err := server.userService.IsWorkspaceAdmin(ctx, targetUserID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if targetIsAdmin {
+		return errors.New("access denied: only admins can change or remove an admin")
+	}
+
+	return nil
+}
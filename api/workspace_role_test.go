@@ -0,0 +1,305 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWorkspaceRoleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+
+	testCases := []struct {
+		name          string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: "admin",
+			body: gin.H{
+				"name":        " Moderator ",
+				"permissions": []string{service.PermissionModerateContent, service.PermissionDeleteAnyMessage, service.PermissionModerateContent},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateWorkspaceRoleParams{
+					WorkspaceID: workspace.ID,
+					Name:        "Moderator",
+					Permissions: []string{service.PermissionDeleteAnyMessage, service.PermissionModerateContent},
+					CreatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+				}
+				store.EXPECT().
+					CreateWorkspaceRole(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.WorkspaceRole{
+						ID:          1,
+						WorkspaceID: workspace.ID,
+						Name:        arg.Name,
+						Permissions: arg.Permissions,
+						CreatedBy:   arg.CreatedBy,
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var response service.WorkspaceRoleResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, "Moderator", response.Name)
+				require.Equal(t, []string{service.PermissionDeleteAnyMessage, service.PermissionModerateContent}, response.Permissions)
+			},
+		},
+		{
+			name: "UnknownPermission",
+			role: "admin",
+			body: gin.H{"name": "Moderator", "permissions": []string{"time_travel"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BuiltInName",
+			role: "admin",
+			body: gin.H{"name": "Admin"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MemberWithManageRoles",
+			role: "member",
+			body: gin.H{"name": "Helper"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserRolePermissions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]string{service.PermissionManageRoles}, nil)
+				store.EXPECT().
+					CreateWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WorkspaceRole{ID: 2, WorkspaceID: workspace.ID, Name: "Helper", Permissions: []string{}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "MemberWithoutPermission",
+			role: "member",
+			body: gin.H{"name": "Helper"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserRolePermissions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]string{service.PermissionManageChannels}, nil)
+				store.EXPECT().
+					CreateWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			requestUser := user
+			requestUser.Role = tc.role
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(requestUser, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(tc.role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/roles", workspace.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAssignWorkspaceRoleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	workspace := randomWorkspace(user.OrganizationID)
+
+	user.WorkspaceID = sql.NullInt64{Int64: workspace.ID, Valid: true}
+	user.Role = "admin"
+
+	member, _ := randomUser(t)
+	member.WorkspaceID = user.WorkspaceID
+	member.Role = "member"
+
+	roleID := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"role_id": roleID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWorkspaceRole(gomock.Any(), gomock.Eq(db.GetWorkspaceRoleParams{ID: roleID, WorkspaceID: workspace.ID})).
+					Times(1).
+					Return(db.WorkspaceRole{ID: roleID, WorkspaceID: workspace.ID, Name: "Moderator"}, nil)
+
+				assigned := member
+				assigned.CustomRoleID = sql.NullInt64{Int64: roleID, Valid: true}
+				store.EXPECT().
+					SetUserCustomRole(gomock.Any(), gomock.Eq(db.SetUserCustomRoleParams{
+						ID:           member.ID,
+						WorkspaceID:  member.WorkspaceID,
+						CustomRoleID: assigned.CustomRoleID,
+					})).
+					Times(1).
+					Return(assigned, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.UserResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.NotNil(t, response.CustomRoleID)
+				require.Equal(t, roleID, *response.CustomRoleID)
+			},
+		},
+		{
+			name: "Unassign",
+			body: gin.H{"role_id": nil},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					SetUserCustomRole(gomock.Any(), gomock.Eq(db.SetUserCustomRoleParams{
+						ID:          member.ID,
+						WorkspaceID: member.WorkspaceID,
+					})).
+					Times(1).
+					Return(member, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response service.UserResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Nil(t, response.CustomRoleID)
+			},
+		},
+		{
+			name: "RoleNotFound",
+			body: gin.H{"role_id": roleID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetWorkspaceRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.WorkspaceRole{}, sql.ErrNoRows)
+				store.EXPECT().
+					SetUserCustomRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "MemberNotInWorkspace",
+			body: gin.H{"role_id": nil},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetUserCustomRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return(user.Role, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/workspaces/%d/members/%d/custom-role", workspace.ID, member.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/heyrmi/goslack/service"
+)
+
+// @Summary List Workspace Teardowns
+// @Description List the organization's purged workspaces and how far the cleanup of their data has got, newest first (organization admin only)
+// @ID listWorkspaceTeardowns
+// @Tags workspaces
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param limit query int false "Number of teardowns to return (default: 50, max: 100)" minimum(1) maximum(100)
+// @Param offset query int false "Number of teardowns to skip (default: 0)" minimum(0)
+// @Success 200 {array} service.WorkspaceTeardownResponse "Workspace teardowns"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/workspace-teardowns [get]
+func (server *Server) listWorkspaceTeardowns(ctx *gin.Context) {
+	var req service.ListWorkspaceTeardownsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, bindingErrorResponse(ctx, err))
+		return
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	teardowns, err := server.workspaceTeardownService.ListTeardowns(ctx, organizationID, req.Limit, req.Offset)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, teardowns)
+}
+
+// @Summary Get Workspace Teardown
+// @Description Report the progress of a purged workspace's cleanup (organization admin only)
+// @ID getWorkspaceTeardown
+// @Tags workspaces
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param workspace_id path int true "Workspace ID"
+// @Success 200 {object} service.WorkspaceTeardownResponse "Workspace teardown"
+// @Failure 400 {object} map[string]string "Invalid organization or workspace ID"
+// @Failure 401 {object} map[string]string "Authentication required"
+// @Failure 403 {object} map[string]string "Organization admin access required"
+// @Failure 404 {object} map[string]string "Workspace teardown not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /organizations/{id}/workspace-teardowns/{workspace_id} [get]
+func (server *Server) getWorkspaceTeardown(ctx *gin.Context) {
+	organizationID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid organization ID")))
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(ctx.Param("workspace_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ctx, errors.New("invalid workspace ID")))
+		return
+	}
+
+	teardown, err := server.workspaceTeardownService.GetTeardown(ctx, organizationID, workspaceID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			ctx.JSON(http.StatusNotFound, errorResponse(ctx, err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(ctx, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, teardown)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -51,6 +52,15 @@ func TestCreateWorkspaceAPI(t *testing.T) {
 					Times(1).
 					Return(user, nil)
 
+				expectTx(store)
+				store.EXPECT().
+					EnsureOrganizationOwner(gomock.Any(), gomock.Eq(db.EnsureOrganizationOwnerParams{
+						OrganizationID: user.OrganizationID,
+						UserID:         user.ID,
+					})).
+					Times(1).
+					Return(nil)
+
 				arg := db.CreateWorkspaceParams{
 					OrganizationID: user.OrganizationID,
 					Name:           workspace.Name,
@@ -114,6 +124,12 @@ func TestCreateWorkspaceAPI(t *testing.T) {
 					Times(1).
 					Return(user, nil)
 
+				expectTx(store)
+				store.EXPECT().
+					EnsureOrganizationOwner(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+
 				store.EXPECT().
 					CreateWorkspace(gomock.Any(), gomock.Any()).
 					Times(1).
@@ -123,6 +139,55 @@ func TestCreateWorkspaceAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "AssignAdminError",
+			body: gin.H{
+				"name": workspace.Name,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Email, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Times(1).
+					Return(user, nil)
+
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+
+				// The workspace is rolled back with the transaction rather
+				// than deleted afterwards
+				store.EXPECT().
+					ExecTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, fn func(db.Querier) error) error {
+						err := fn(store)
+						require.Error(t, err)
+						return err
+					})
+				store.EXPECT().
+					EnsureOrganizationOwner(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateWorkspace(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(workspace, nil)
+				store.EXPECT().
+					UpdateUserWorkspace(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.User{}, sql.ErrConnDone)
+				store.EXPECT().
+					DeleteWorkspace(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name: "InvalidJSON",
 			body: gin.H{
@@ -0,0 +1,225 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/lib/pq"
+)
+
+// Create writes a backup archive of the database and the file store to w.
+// Tables and the list of files are read from one snapshot, so the backup is
+// consistent while the server keeps running. Every stored file is checked
+// against the hash recorded at upload.
+func Create(ctx context.Context, conn *sql.DB, w io.Writer) (*Manifest, error) {
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	manifest := &Manifest{
+		FormatVersion: formatVersion,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	manifest.SchemaVersion, err = schemaVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := listTables(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, table := range tables {
+		info, err := dumpTable(ctx, tx, tarWriter, table)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Tables = append(manifest.Tables, info)
+	}
+
+	files, err := db.New(tx).ListStoredFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stored files: %w", err)
+	}
+
+	for _, file := range files {
+		info, err := addFile(tarWriter, file.FilePath, file.ID, file.FileHash)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, info)
+
+		if file.ThumbnailPath.Valid {
+			info, err := addFile(tarWriter, file.ThumbnailPath.String, file.ID, "")
+			if err != nil {
+				return nil, err
+			}
+			manifest.Files = append(manifest.Files, info)
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeEntry(tarWriter, manifestName, data); err != nil {
+		return nil, err
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// dumpTable writes the rows of a table as JSON lines in primary key order. The
+// dump is spooled to a temporary file because tar needs its size up front.
+func dumpTable(ctx context.Context, tx *sql.Tx, tarWriter *tar.Writer, table table) (TableInfo, error) {
+	query := fmt.Sprintf("SELECT row_to_json(t)::text FROM %s t", pq.QuoteIdentifier(table.name))
+	if len(table.primaryKey) > 0 {
+		columns := make([]string, len(table.primaryKey))
+		for i, column := range table.primaryKey {
+			columns[i] = "t." + pq.QuoteIdentifier(column)
+		}
+		query += " ORDER BY " + strings.Join(columns, ", ")
+	}
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return TableInfo{}, fmt.Errorf("failed to dump table %s: %w", table.name, err)
+	}
+	defer rows.Close()
+
+	spool, err := os.CreateTemp("", "goslack-backup-*.jsonl")
+	if err != nil {
+		return TableInfo{}, err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	info := TableInfo{Name: table.name}
+	hasher := sha256.New()
+	out := io.MultiWriter(spool, hasher)
+
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return TableInfo{}, fmt.Errorf("failed to dump table %s: %w", table.name, err)
+		}
+		if _, err := io.WriteString(out, row+"\n"); err != nil {
+			return TableInfo{}, err
+		}
+		info.Rows++
+	}
+	if err := rows.Err(); err != nil {
+		return TableInfo{}, fmt.Errorf("failed to dump table %s: %w", table.name, err)
+	}
+	info.SHA256 = hex.EncodeToString(hasher.Sum(nil))
+
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return TableInfo{}, err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return TableInfo{}, err
+	}
+
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name:    tableEntryName(table.name),
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return TableInfo{}, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if _, err := io.Copy(tarWriter, spool); err != nil {
+		return TableInfo{}, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return info, nil
+}
+
+// addFile copies a stored file into the archive. When expectedHash is set the
+// file must still match it, so corrupted files are caught before they are
+// backed up.
+func addFile(tarWriter *tar.Writer, path string, fileID int64, expectedHash string) (FileInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return FileInfo{}, fmt.Errorf("failed to open stored file %d: %w", fileID, err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return FileInfo{}, err
+	}
+
+	info := FileInfo{
+		Name:   filepath.Base(path),
+		FileID: fileID,
+		Size:   stat.Size(),
+	}
+
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name:    fileEntryName(info.Name),
+		Mode:    0644,
+		Size:    info.Size,
+		ModTime: stat.ModTime(),
+	})
+	if err != nil {
+		return FileInfo{}, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tarWriter, hasher), file); err != nil {
+		return FileInfo{}, fmt.Errorf("failed to back up stored file %d: %w", fileID, err)
+	}
+	info.SHA256 = hex.EncodeToString(hasher.Sum(nil))
+
+	if expectedHash != "" && info.SHA256 != expectedHash {
+		return FileInfo{}, fmt.Errorf("stored file %d does not match its hash", fileID)
+	}
+
+	return info, nil
+}
+
+// writeEntry writes an in-memory entry to the archive
+func writeEntry(tarWriter *tar.Writer, name string, data []byte) error {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if _, err := tarWriter.Write(data); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Package backup creates, verifies and restores logical backups of the
+// database together with the file store.
+package backup
+
+import (
+	"time"
+)
+
+// formatVersion is the version of the archive layout
+const formatVersion = 1
+
+// Archive entry names
+const (
+	manifestName = "manifest.json"
+	tablesDir    = "tables/"
+	filesDir     = "files/"
+)
+
+// Manifest describes the contents of a backup archive. It is the last entry
+// of the archive, so it can cover the checksums of every other entry.
+type Manifest struct {
+	FormatVersion int         `json:"format_version"`
+	CreatedAt     time.Time   `json:"created_at"`
+	SchemaVersion int64       `json:"schema_version"`
+	Tables        []TableInfo `json:"tables"` // In restore order
+	Files         []FileInfo  `json:"files"`
+}
+
+// TableInfo describes the dump of a table
+type TableInfo struct {
+	Name   string `json:"name"`
+	Rows   int64  `json:"rows"`
+	SHA256 string `json:"sha256"`
+}
+
+// FileInfo describes a file from the file store
+type FileInfo struct {
+	Name   string `json:"name"`
+	FileID int64  `json:"file_id"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// tableEntryName returns the archive entry of a table dump
+func tableEntryName(table string) string {
+	return tablesDir + table + ".jsonl"
+}
+
+// fileEntryName returns the archive entry of a stored file
+func fileEntryName(name string) string {
+	return filesDir + name
+}
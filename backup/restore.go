@@ -0,0 +1,280 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// restoreBatchSize is the number of rows inserted per statement
+const restoreBatchSize = 500
+
+// Restore loads a backup archive into a fresh instance: an empty database
+// migrated to the backup's schema version and the file store at storagePath.
+// The archive is verified before anything is written, and the database is
+// restored in one transaction.
+func Restore(ctx context.Context, conn *sql.DB, storagePath, archivePath string) (*Manifest, error) {
+	manifest, err := verifyFile(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	version, err := schemaVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if version != manifest.SchemaVersion {
+		return nil, fmt.Errorf("backup is from schema version %d but the database is at version %d, migrate to the same version first", manifest.SchemaVersion, version)
+	}
+
+	if err := checkEmpty(ctx, tx, manifest.Tables); err != nil {
+		return nil, err
+	}
+
+	// Consistency triggers check rows against the current state of other tables,
+	// which does not hold for history such as channels whose creator has left
+	for _, table := range manifest.Tables {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s DISABLE TRIGGER USER", pq.QuoteIdentifier(table.Name))); err != nil {
+			return nil, fmt.Errorf("failed to disable triggers on %s: %w", table.Name, err)
+		}
+	}
+
+	if err := os.MkdirAll(storagePath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	written, err := restoreEntries(ctx, tx, storagePath, archivePath)
+	if err != nil {
+		removeFiles(written)
+		return nil, err
+	}
+
+	for _, table := range manifest.Tables {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ENABLE TRIGGER USER", pq.QuoteIdentifier(table.Name))); err != nil {
+			removeFiles(written)
+			return nil, fmt.Errorf("failed to enable triggers on %s: %w", table.Name, err)
+		}
+	}
+
+	if err := resetSequences(ctx, tx); err != nil {
+		removeFiles(written)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		removeFiles(written)
+		return nil, fmt.Errorf("failed to commit restore: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// verifyFile verifies the backup archive at path
+func verifyFile(path string) (*Manifest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Verify(file)
+}
+
+// checkEmpty makes sure a restore does not mix with existing data
+func checkEmpty(ctx context.Context, tx *sql.Tx, tables []TableInfo) error {
+	for _, table := range tables {
+		var hasRows bool
+		query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s)", pq.QuoteIdentifier(table.Name))
+		if err := tx.QueryRowContext(ctx, query).Scan(&hasRows); err != nil {
+			return fmt.Errorf("failed to check table %s: %w", table.Name, err)
+		}
+		if hasRows {
+			return fmt.Errorf("database is not empty: table %s has rows, restore into a fresh instance", table.Name)
+		}
+	}
+	return nil
+}
+
+// restoreEntries loads the table dumps and writes the stored files. It returns
+// the paths of the files it wrote so they can be removed if the restore fails.
+func restoreEntries(ctx context.Context, tx *sql.Tx, storagePath, archivePath string) ([]string, error) {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	var written []string
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return written, nil
+		}
+		if err != nil {
+			return written, fmt.Errorf("invalid backup archive: %w", err)
+		}
+
+		switch {
+		case strings.HasPrefix(header.Name, tablesDir):
+			name := strings.TrimSuffix(strings.TrimPrefix(header.Name, tablesDir), ".jsonl")
+			if err := loadTable(ctx, tx, name, tarReader, storagePath); err != nil {
+				return written, err
+			}
+		case strings.HasPrefix(header.Name, filesDir):
+			path := filepath.Join(storagePath, strings.TrimPrefix(header.Name, filesDir))
+			if err := writeFile(path, tarReader); err != nil {
+				return written, err
+			}
+			written = append(written, path)
+		}
+	}
+}
+
+// loadTable inserts the rows of a table dump in batches
+func loadTable(ctx context.Context, tx *sql.Tx, name string, r io.Reader, storagePath string) error {
+	query := fmt.Sprintf("INSERT INTO %[1]s SELECT * FROM json_populate_recordset(NULL::%[1]s, $1)", pq.QuoteIdentifier(name))
+
+	var batch [][]byte
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		rows := append([]byte("["), bytes.Join(batch, []byte(","))...)
+		rows = append(rows, ']')
+		if _, err := tx.ExecContext(ctx, query, string(rows)); err != nil {
+			return fmt.Errorf("failed to restore table %s: %w", name, err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		row := append([]byte(nil), scanner.Bytes()...)
+		if name == "files" {
+			var err error
+			if row, err = relocateFile(row, storagePath); err != nil {
+				return fmt.Errorf("failed to restore table %s: %w", name, err)
+			}
+		}
+
+		batch = append(batch, row)
+		if len(batch) == restoreBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read table %s: %w", name, err)
+	}
+
+	return flush()
+}
+
+// relocateFile points a files row at the restored file store, which can be in a
+// different place than on the backed up instance
+func relocateFile(row []byte, storagePath string) ([]byte, error) {
+	var file map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(row))
+	decoder.UseNumber()
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	if path, ok := file["file_path"].(string); ok {
+		file["file_path"] = filepath.Join(storagePath, filepath.Base(path))
+	}
+	if path, ok := file["thumbnail_path"].(string); ok {
+		file["thumbnail_path"] = filepath.Join(storagePath, filepath.Base(path))
+	}
+
+	return json.Marshal(file)
+}
+
+// writeFile writes a stored file without replacing an existing one
+func writeFile(path string, r io.Reader) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("file store is not empty: %s already exists", filepath.Base(path))
+		}
+		return fmt.Errorf("failed to restore file: %w", err)
+	}
+
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to restore file: %w", err)
+	}
+	return file.Close()
+}
+
+// resetSequences moves every serial column's sequence past the restored rows
+func resetSequences(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT table_name, column_name FROM information_schema.columns
+		WHERE table_schema = 'public' AND column_default LIKE 'nextval(%'`)
+	if err != nil {
+		return fmt.Errorf("failed to list sequences: %w", err)
+	}
+
+	type serialColumn struct{ table, column string }
+	var columns []serialColumn
+	for rows.Next() {
+		var column serialColumn
+		if err := rows.Scan(&column.table, &column.column); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to list sequences: %w", err)
+		}
+		columns = append(columns, column)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list sequences: %w", err)
+	}
+
+	for _, column := range columns {
+		query := fmt.Sprintf(
+			"SELECT setval(pg_get_serial_sequence($1, $2), COALESCE((SELECT MAX(%s) FROM %s), 0) + 1, false)",
+			pq.QuoteIdentifier(column.column), pq.QuoteIdentifier(column.table),
+		)
+		if _, err := tx.ExecContext(ctx, query, pq.QuoteIdentifier(column.table), column.column); err != nil {
+			return fmt.Errorf("failed to reset sequence of %s.%s: %w", column.table, column.column, err)
+		}
+	}
+
+	return nil
+}
+
+func removeFiles(paths []string) {
+	for _, path := range paths {
+		os.Remove(path)
+	}
+}
@@ -0,0 +1,158 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// table is a table of the public schema with its primary key columns
+type table struct {
+	name       string
+	primaryKey []string
+}
+
+// listTables returns the application tables ordered so that every table comes
+// after the tables it references. Partitioned tables are dumped and loaded
+// through their parent, since the partitions of the restored database differ.
+func listTables(ctx context.Context, tx *sql.Tx) ([]table, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT c.relname FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+			AND c.relname <> 'schema_migrations'`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	names, err := scanStrings(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		SELECT child.relname, parent.relname FROM pg_constraint con
+		JOIN pg_class child ON child.oid = con.conrelid
+		JOIN pg_class parent ON parent.oid = con.confrelid
+		JOIN pg_namespace n ON n.oid = child.relnamespace
+		WHERE con.contype = 'f' AND n.nspname = 'public'`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+	references := make(map[string][]string)
+	for rows.Next() {
+		var child, parent string
+		if err := rows.Scan(&child, &parent); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+		}
+		references[child] = append(references[child], parent)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list foreign keys: %w", err)
+	}
+
+	rows, err = tx.QueryContext(ctx, `
+		SELECT c.relname, a.attname FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indisprimary AND n.nspname = 'public'
+		ORDER BY c.relname, array_position(i.indkey::int2[], a.attnum)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list primary keys: %w", err)
+	}
+	primaryKeys := make(map[string][]string)
+	for rows.Next() {
+		var name, column string
+		if err := rows.Scan(&name, &column); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to list primary keys: %w", err)
+		}
+		primaryKeys[name] = append(primaryKeys[name], column)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list primary keys: %w", err)
+	}
+
+	ordered, err := sortTables(names, references)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make([]table, len(ordered))
+	for i, name := range ordered {
+		tables[i] = table{name: name, primaryKey: primaryKeys[name]}
+	}
+	return tables, nil
+}
+
+// sortTables orders tables so that referenced tables come first. Ties are
+// broken by name so backups of the same schema list tables in the same order.
+// Self references, such as thread replies, are handled by dumping rows in
+// primary key order.
+func sortTables(names []string, references map[string][]string) ([]string, error) {
+	remaining := make(map[string]bool, len(names))
+	for _, name := range names {
+		remaining[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for len(remaining) > 0 {
+		var ready []string
+		for name := range remaining {
+			isReady := true
+			for _, parent := range references[name] {
+				if parent != name && remaining[parent] {
+					isReady = false
+					break
+				}
+			}
+			if isReady {
+				ready = append(ready, name)
+			}
+		}
+
+		if len(ready) == 0 {
+			return nil, fmt.Errorf("cannot order tables with circular foreign keys: %d tables left", len(remaining))
+		}
+
+		sort.Strings(ready)
+		for _, name := range ready {
+			delete(remaining, name)
+		}
+		sorted = append(sorted, ready...)
+	}
+
+	return sorted, nil
+}
+
+// schemaVersion returns the migration version of the database
+func schemaVersion(ctx context.Context, tx *sql.Tx) (int64, error) {
+	var version int64
+	var dirty bool
+	err := tx.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations").Scan(&version, &dirty)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+	if dirty {
+		return 0, fmt.Errorf("schema version %d is dirty, fix the failed migration first", version)
+	}
+	return version, nil
+}
+
+func scanStrings(rows *sql.Rows) ([]string, error) {
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
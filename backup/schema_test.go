@@ -0,0 +1,63 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortTables(t *testing.T) {
+	testCases := []struct {
+		name          string
+		names         []string
+		references    map[string][]string
+		expected      []string
+		expectedError bool
+	}{
+		{
+			name:  "ParentsFirst",
+			names: []string{"messages", "channels", "users", "workspaces"},
+			references: map[string][]string{
+				"messages": {"channels", "users"},
+				"channels": {"workspaces", "users"},
+				"users":    {"workspaces"},
+			},
+			expected: []string{"workspaces", "users", "channels", "messages"},
+		},
+		{
+			name:  "SelfReference",
+			names: []string{"messages", "channels"},
+			references: map[string][]string{
+				"messages": {"messages", "channels"},
+			},
+			expected: []string{"channels", "messages"},
+		},
+		{
+			name:       "Independent",
+			names:      []string{"b", "c", "a"},
+			references: map[string][]string{},
+			expected:   []string{"a", "b", "c"},
+		},
+		{
+			name:  "Cycle",
+			names: []string{"a", "b"},
+			references: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sorted, err := sortTables(tc.names, tc.references)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, sorted)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// entryDigest is the size and checksum of an archive entry
+type entryDigest struct {
+	size   int64
+	sha256 string
+}
+
+// Verify reads a backup archive and checks every table dump and stored file
+// against the checksums in its manifest
+func Verify(r io.Reader) (*Manifest, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	digests := make(map[string]entryDigest)
+	var manifest *Manifest
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup archive: %w", err)
+		}
+
+		if header.Name == manifestName {
+			manifest = &Manifest{}
+			if err := json.NewDecoder(tarReader).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("invalid backup manifest: %w", err)
+			}
+			continue
+		}
+
+		hasher := sha256.New()
+		size, err := io.Copy(hasher, tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup archive: %w", err)
+		}
+		digests[header.Name] = entryDigest{size: size, sha256: hex.EncodeToString(hasher.Sum(nil))}
+	}
+
+	if manifest == nil {
+		return nil, errors.New("invalid backup archive: manifest is missing")
+	}
+	if manifest.FormatVersion != formatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+
+	for _, table := range manifest.Tables {
+		digest, ok := digests[tableEntryName(table.Name)]
+		if !ok {
+			return nil, fmt.Errorf("backup is missing table %s", table.Name)
+		}
+		if digest.sha256 != table.SHA256 {
+			return nil, fmt.Errorf("table %s does not match the manifest", table.Name)
+		}
+		delete(digests, tableEntryName(table.Name))
+	}
+
+	for _, file := range manifest.Files {
+		if strings.ContainsAny(file.Name, `/\`) || file.Name == ".." || file.Name == "." {
+			return nil, fmt.Errorf("invalid file name %q in manifest", file.Name)
+		}
+
+		digest, ok := digests[fileEntryName(file.Name)]
+		if !ok {
+			return nil, fmt.Errorf("backup is missing file %s", file.Name)
+		}
+		if digest.size != file.Size || digest.sha256 != file.SHA256 {
+			return nil, fmt.Errorf("file %s does not match the manifest", file.Name)
+		}
+		delete(digests, fileEntryName(file.Name))
+	}
+
+	for name := range digests {
+		return nil, fmt.Errorf("backup contains %s, which is not in the manifest", name)
+	}
+
+	return manifest, nil
+}
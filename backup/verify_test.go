@@ -0,0 +1,147 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// buildArchive writes a backup archive with the given entries and manifest
+func buildArchive(t *testing.T, entries map[string][]byte, manifest Manifest) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for name, data := range entries {
+		require.NoError(t, writeEntry(tarWriter, name, data))
+	}
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, writeEntry(tarWriter, manifestName, data))
+
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestVerify(t *testing.T) {
+	users := []byte(`{"id":1,"email":"ada@example.com"}` + "\n")
+	file := []byte("hello world")
+
+	validEntries := func() map[string][]byte {
+		return map[string][]byte{
+			tableEntryName("users"):  users,
+			fileEntryName("abc.txt"): file,
+		}
+	}
+	validManifest := func() Manifest {
+		return Manifest{
+			FormatVersion: formatVersion,
+			CreatedAt:     time.Now().UTC(),
+			SchemaVersion: 13,
+			Tables:        []TableInfo{{Name: "users", Rows: 1, SHA256: checksum(users)}},
+			Files:         []FileInfo{{Name: "abc.txt", FileID: 1, Size: int64(len(file)), SHA256: checksum(file)}},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		buildArchive  func(t *testing.T) []byte
+		expectedError string
+	}{
+		{
+			name: "OK",
+			buildArchive: func(t *testing.T) []byte {
+				return buildArchive(t, validEntries(), validManifest())
+			},
+		},
+		{
+			name: "TamperedTable",
+			buildArchive: func(t *testing.T) []byte {
+				entries := validEntries()
+				entries[tableEntryName("users")] = []byte(`{"id":1,"email":"eve@example.com"}` + "\n")
+				return buildArchive(t, entries, validManifest())
+			},
+			expectedError: "table users does not match the manifest",
+		},
+		{
+			name: "TamperedFile",
+			buildArchive: func(t *testing.T) []byte {
+				entries := validEntries()
+				entries[fileEntryName("abc.txt")] = []byte("hello there")
+				return buildArchive(t, entries, validManifest())
+			},
+			expectedError: "file abc.txt does not match the manifest",
+		},
+		{
+			name: "MissingFile",
+			buildArchive: func(t *testing.T) []byte {
+				entries := validEntries()
+				delete(entries, fileEntryName("abc.txt"))
+				return buildArchive(t, entries, validManifest())
+			},
+			expectedError: "backup is missing file abc.txt",
+		},
+		{
+			name: "UnlistedEntry",
+			buildArchive: func(t *testing.T) []byte {
+				entries := validEntries()
+				entries[tableEntryName("sessions")] = []byte("{}\n")
+				return buildArchive(t, entries, validManifest())
+			},
+			expectedError: "backup contains tables/sessions.jsonl, which is not in the manifest",
+		},
+		{
+			name: "UnsafeFileName",
+			buildArchive: func(t *testing.T) []byte {
+				manifest := validManifest()
+				manifest.Files[0].Name = "../abc.txt"
+				return buildArchive(t, validEntries(), manifest)
+			},
+			expectedError: `invalid file name "../abc.txt" in manifest`,
+		},
+		{
+			name: "UnsupportedFormat",
+			buildArchive: func(t *testing.T) []byte {
+				manifest := validManifest()
+				manifest.FormatVersion = formatVersion + 1
+				return buildArchive(t, validEntries(), manifest)
+			},
+			expectedError: "unsupported backup format version 2",
+		},
+		{
+			name: "NotAnArchive",
+			buildArchive: func(t *testing.T) []byte {
+				return []byte("not a backup")
+			},
+			expectedError: "invalid backup archive",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			manifest, err := Verify(bytes.NewReader(tc.buildArchive(t)))
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(13), manifest.SchemaVersion)
+			require.Len(t, manifest.Tables, 1)
+			require.Len(t, manifest.Files, 1)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Package client is the Go client of the GoSlack API. Its types and methods
+// are generated from docs/openapi.json into client.gen.go by `go generate`;
+// this file holds the transport they share.
+//
+//	c := client.New("https://goslack.example.com", client.WithToken(accessToken))
+//	channel, err := c.GetChannel(ctx, channelID)
+//
+// Failed requests return an *APIError with the status code and the error the
+// server reported.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client sends requests to a GoSlack server
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends requests with an HTTP client other than
+// http.DefaultClient, for timeouts or a custom transport
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken authenticates requests with an access token or personal access
+// token, sent as a bearer token
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// New creates a client for the server at baseURL, such as
+// https://goslack.example.com
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// APIError is a response with an error status
+type APIError struct {
+	StatusCode int
+	// Message is the error the server reported, if it sent one
+	Message string
+	Body    []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("goslack: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("goslack: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotModified reports whether err is a 304 response to a conditional
+// request made with an If-None-Match parameter
+func IsNotModified(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotModified
+}
+
+// File is a file to upload in a multipart form
+type File struct {
+	Name    string
+	Content io.Reader
+}
+
+// request is a request being built by a generated method
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	// body is sent as JSON
+	body any
+	// form is sent as multipart/form-data when it has fields
+	form      []formField
+	formFiles []formFile
+}
+
+type formField struct {
+	name  string
+	value string
+}
+
+type formFile struct {
+	name string
+	file File
+}
+
+func newRequest(method, path string) *request {
+	return &request{
+		method: method,
+		path:   path,
+		query:  url.Values{},
+		header: http.Header{},
+	}
+}
+
+// add sets a query, header or form parameter
+func (r *request) add(in, name, value string) {
+	switch in {
+	case "query":
+		r.query.Add(name, value)
+	case "header":
+		r.header.Add(name, value)
+	case "formData":
+		r.form = append(r.form, formField{name: name, value: value})
+	}
+}
+
+// addFormFile adds a file to the multipart form
+func (r *request) addFormFile(name string, file File) {
+	r.formFiles = append(r.formFiles, formFile{name: name, file: file})
+}
+
+// encodeBody returns the request's body and its content type
+func (r *request) encodeBody() (io.Reader, string, error) {
+	if len(r.form) > 0 || len(r.formFiles) > 0 {
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		for _, field := range r.form {
+			if err := writer.WriteField(field.name, field.value); err != nil {
+				return nil, "", err
+			}
+		}
+		for _, upload := range r.formFiles {
+			part, err := writer.CreateFormFile(upload.name, upload.file.Name)
+			if err != nil {
+				return nil, "", err
+			}
+			if _, err := io.Copy(part, upload.file.Content); err != nil {
+				return nil, "", fmt.Errorf("cannot read %s: %w", upload.file.Name, err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			return nil, "", err
+		}
+		return &buf, writer.FormDataContentType(), nil
+	}
+
+	if r.body == nil {
+		return nil, "", nil
+	}
+	data, err := json.Marshal(r.body)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot encode request body: %w", err)
+	}
+	return bytes.NewReader(data), "application/json", nil
+}
+
+// send sends a request and returns its response if it succeeded
+func (c *Client) send(ctx context.Context, r *request) (*http.Response, error) {
+	body, contentType, err := r.encodeBody()
+	if err != nil {
+		return nil, err
+	}
+
+	target := c.baseURL + r.path
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range r.header {
+		req.Header[name] = values
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	apiErr.Body, _ = io.ReadAll(resp.Body)
+	var payload struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(apiErr.Body, &payload) == nil {
+		apiErr.Message = payload.Error
+	}
+	return nil, apiErr
+}
+
+// do sends a request and decodes its JSON response into out, unless out is
+// nil or the response has no content
+func (c *Client) do(ctx context.Context, r *request, out any) error {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("cannot decode response: %w", err)
+	}
+	return nil
+}
+
+// stream sends a request and returns its response body, for file content.
+// The caller closes it.
+func (c *Client) stream(ctx context.Context, r *request) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
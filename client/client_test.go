@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.Equal(t, "/channels/7", r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("page"))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name":"general"}`, string(body))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":7,"name":"general"}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL+"/", WithToken("secret"))
+	req := newRequest(http.MethodPut, "/channels/7")
+	req.add("query", "page", "2")
+	req.body = map[string]string{"name": "general"}
+
+	var out struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	require.NoError(t, c.do(context.Background(), req, &out))
+	require.Equal(t, int64(7), out.ID)
+	require.Equal(t, "general", out.Name)
+}
+
+func TestClientAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":"not a workspace member"}`))
+	}))
+	defer server.Close()
+
+	c := New(server.URL)
+	err := c.do(context.Background(), newRequest(http.MethodGet, "/channels/7"), nil)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	require.Equal(t, "not a workspace member", apiErr.Message)
+	require.False(t, IsNotModified(err))
+
+	req := newRequest(http.MethodGet, "/channels/7")
+	req.add("header", "If-None-Match", `"abc"`)
+	err = c.do(context.Background(), req, nil)
+	require.True(t, IsNotModified(err))
+}
+
+func TestClientMultipartForm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		require.Equal(t, "3", r.FormValue("workspace_id"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer file.Close()
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.Equal(t, "notes.txt", header.Filename)
+		require.Equal(t, "hello", string(content))
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	req := newRequest(http.MethodPost, "/files/upload")
+	req.add("formData", "workspace_id", "3")
+	req.addFormFile("file", File{Name: "notes.txt", Content: strings.NewReader("hello")})
+
+	var out map[string]any
+	require.NoError(t, New(server.URL).do(context.Background(), req, &out))
+	require.Nil(t, out)
+}
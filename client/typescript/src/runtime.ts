@@ -0,0 +1,129 @@
+// The request runtime the generated GoSlackClient extends. Unlike client.ts,
+// this file is written by hand.
+
+/** Options of a GoSlackClient */
+export interface ClientOptions {
+  /** Base URL of the server, such as https://goslack.example.com */
+  baseUrl: string;
+  /** Access token or personal access token, sent as a bearer token */
+  token?: string;
+  /** fetch implementation, for runtimes without a global one */
+  fetch?: typeof fetch;
+}
+
+/** ApiError is a response with an error status */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    message: string,
+    readonly body: unknown,
+  ) {
+    super(message);
+    this.name = "ApiError";
+  }
+
+  /** Whether the error is a 304 response to an If-None-Match request */
+  get notModified(): boolean {
+    return this.status === 304;
+  }
+}
+
+type Value = string | number | boolean | null | undefined;
+
+/** A request built by a generated method */
+export interface RequestOptions {
+  method: string;
+  path: string;
+  query?: Record<string, Value | Value[]>;
+  headers?: Record<string, Value>;
+  /** Sent as JSON */
+  body?: unknown;
+  /** Sent as multipart/form-data */
+  form?: Record<string, Value | Blob>;
+  responseType: "json" | "blob" | "none";
+}
+
+/** BaseClient sends the requests of the generated methods */
+export class BaseClient {
+  private readonly baseUrl: string;
+  private readonly fetchImpl: typeof fetch;
+  private token?: string;
+
+  constructor(options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/+$/, "");
+    this.fetchImpl = options.fetch ?? globalThis.fetch.bind(globalThis);
+    this.token = options.token;
+  }
+
+  /** Sets the token later requests are authenticated with, such as after logging in */
+  setToken(token: string | undefined): void {
+    this.token = token;
+  }
+
+  protected async request<T>(options: RequestOptions): Promise<T> {
+    const url = new URL(this.baseUrl + options.path);
+    for (const [name, value] of Object.entries(options.query ?? {})) {
+      for (const item of Array.isArray(value) ? value : [value]) {
+        if (item !== undefined && item !== null) {
+          url.searchParams.append(name, String(item));
+        }
+      }
+    }
+
+    const headers = new Headers();
+    for (const [name, value] of Object.entries(options.headers ?? {})) {
+      if (value !== undefined && value !== null) {
+        headers.set(name, String(value));
+      }
+    }
+    if (this.token) {
+      headers.set("Authorization", `Bearer ${this.token}`);
+    }
+
+    let body: BodyInit | undefined;
+    if (options.form) {
+      const form = new FormData();
+      for (const [name, value] of Object.entries(options.form)) {
+        if (value instanceof Blob) {
+          form.append(name, value);
+        } else if (value !== undefined && value !== null) {
+          form.append(name, String(value));
+        }
+      }
+      body = form;
+    } else if (options.body !== undefined) {
+      headers.set("Content-Type", "application/json");
+      body = JSON.stringify(options.body);
+    }
+
+    const response = await this.fetchImpl(url.toString(), {
+      method: options.method,
+      headers,
+      body,
+    });
+
+    if (!response.ok) {
+      const text = await response.text();
+      let payload: unknown = text;
+      let message = `${response.status} ${response.statusText}`;
+      try {
+        payload = JSON.parse(text);
+        const error = (payload as { error?: unknown }).error;
+        if (typeof error === "string") {
+          message = error;
+        }
+      } catch {
+        // Not JSON, such as a proxy's error page
+      }
+      throw new ApiError(response.status, message, payload);
+    }
+
+    if (options.responseType === "blob") {
+      return (await response.blob()) as T;
+    }
+    if (options.responseType === "none" || response.status === 204) {
+      return undefined as T;
+    }
+    return (await response.json()) as T;
+  }
+}
@@ -0,0 +1,59 @@
+// Command openapi-gen converts the Swagger document swag writes into an
+// OpenAPI 3 document and generates the Go and TypeScript clients from it. It
+// runs as part of `go generate`, after swag.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/heyrmi/goslack/openapi"
+)
+
+func main() {
+	input := flag.String("in", "docs/swagger.json", "Swagger 2.0 document to convert")
+	output := flag.String("out", "docs/openapi.json", "OpenAPI 3 document to write")
+	goOutput := flag.String("go", "", "Go client file to write")
+	goPackage := flag.String("go-package", "client", "package of the Go client")
+	tsOutput := flag.String("ts", "", "TypeScript client file to write")
+	flag.Parse()
+
+	data, err := os.ReadFile(*input)
+	if err != nil {
+		log.Fatal("cannot read Swagger document:", err)
+	}
+
+	doc, err := openapi.Convert(data)
+	if err != nil {
+		log.Fatal("cannot convert Swagger document:", err)
+	}
+
+	spec, err := doc.Marshal()
+	if err != nil {
+		log.Fatal(err)
+	}
+	writeFile(*output, spec)
+
+	if *goOutput != "" {
+		source, err := openapi.GenerateGo(doc, *goPackage)
+		if err != nil {
+			log.Fatal("cannot generate Go client:", err)
+		}
+		writeFile(*goOutput, source)
+	}
+
+	if *tsOutput != "" {
+		source, err := openapi.GenerateTypeScript(doc)
+		if err != nil {
+			log.Fatal("cannot generate TypeScript client:", err)
+		}
+		writeFile(*tsOutput, source)
+	}
+}
+
+func writeFile(path string, data []byte) {
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Fatal("cannot write generated file:", err)
+	}
+}
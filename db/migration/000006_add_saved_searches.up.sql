@@ -0,0 +1,13 @@
+-- Named search queries saved per user and workspace
+CREATE TABLE saved_searches (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    query TEXT NOT NULL CHECK (LENGTH(query) <= 500),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    UNIQUE(user_id, workspace_id, name)
+);
+
+CREATE INDEX idx_saved_searches_user_workspace ON saved_searches (user_id, workspace_id);
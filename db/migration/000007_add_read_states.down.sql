@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS direct_message_read_states;
+DROP TABLE IF EXISTS channel_read_states;
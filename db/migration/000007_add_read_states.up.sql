@@ -0,0 +1,21 @@
+-- Last read position per user and channel
+CREATE TABLE channel_read_states (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel_id BIGINT NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    last_read_message_id BIGINT REFERENCES messages(id) ON DELETE SET NULL,
+    last_read_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (user_id, channel_id)
+);
+
+-- Last read position per user and direct message conversation
+CREATE TABLE direct_message_read_states (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    other_user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_message_id BIGINT REFERENCES messages(id) ON DELETE SET NULL,
+    last_read_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (user_id, workspace_id, other_user_id)
+);
+
+CREATE INDEX idx_channel_read_states_channel ON channel_read_states (channel_id);
+CREATE INDEX idx_direct_message_read_states_other_user ON direct_message_read_states (other_user_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_user_status_clear_after;
+ALTER TABLE user_status DROP COLUMN IF EXISTS clear_after;
+ALTER TABLE user_status DROP COLUMN IF EXISTS status_emoji;
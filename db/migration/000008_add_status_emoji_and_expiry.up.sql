@@ -0,0 +1,5 @@
+-- Emoji shown next to the custom status and the time the custom status clears itself
+ALTER TABLE user_status ADD COLUMN status_emoji VARCHAR(64);
+ALTER TABLE user_status ADD COLUMN clear_after TIMESTAMPTZ;
+
+CREATE INDEX idx_user_status_clear_after ON user_status (clear_after) WHERE clear_after IS NOT NULL;
@@ -0,0 +1,8 @@
+-- Per-workspace settings. NULL values fall back to the server defaults.
+CREATE TABLE workspace_settings (
+    workspace_id BIGINT PRIMARY KEY REFERENCES workspaces(id) ON DELETE CASCADE,
+    away_after_minutes INTEGER CHECK (away_after_minutes > 0),
+    offline_after_minutes INTEGER CHECK (offline_after_minutes > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    CHECK (away_after_minutes IS NULL OR offline_after_minutes IS NULL OR away_after_minutes < offline_after_minutes)
+);
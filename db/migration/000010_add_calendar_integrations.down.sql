@@ -0,0 +1,3 @@
+ALTER TABLE user_status DROP COLUMN IF EXISTS set_by_calendar;
+DROP TABLE IF EXISTS calendar_busy_blocks;
+DROP TABLE IF EXISTS calendar_integrations;
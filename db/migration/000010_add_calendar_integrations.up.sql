@@ -0,0 +1,28 @@
+-- Per-user calendar feeds used to set an automatic "In a meeting" status
+CREATE TABLE calendar_integrations (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL DEFAULT 'ics' CHECK (provider IN ('ics')),
+    ics_url TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    last_synced_at TIMESTAMPTZ,
+    last_sync_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    UNIQUE (user_id, workspace_id)
+);
+
+-- Busy blocks ingested from a calendar feed
+CREATE TABLE calendar_busy_blocks (
+    id BIGSERIAL PRIMARY KEY,
+    integration_id BIGINT NOT NULL REFERENCES calendar_integrations(id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX idx_calendar_busy_blocks_integration ON calendar_busy_blocks (integration_id, starts_at, ends_at);
+
+-- Marks statuses set from a calendar so they can be reverted when the event ends
+ALTER TABLE user_status ADD COLUMN set_by_calendar BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,21 @@
+-- Out-of-office windows with the auto-reply sent to direct messages
+CREATE TABLE out_of_office (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    message TEXT NOT NULL CHECK (LENGTH(message) <= 1000),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (user_id, workspace_id),
+    CHECK (starts_at < ends_at)
+);
+
+-- Auto-replies already sent, so each sender gets at most one per day
+CREATE TABLE out_of_office_replies (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sender_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reply_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (user_id, sender_id, reply_date)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dnd_overrides;
+DROP TABLE IF EXISTS do_not_disturb;
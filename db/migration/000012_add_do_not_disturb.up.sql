@@ -0,0 +1,16 @@
+-- Do Not Disturb state and whether urgent messages may break through it
+CREATE TABLE do_not_disturb (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    dnd_until TIMESTAMPTZ,
+    allow_urgent_override BOOLEAN NOT NULL DEFAULT true,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+-- Urgent notifications that broke through Do Not Disturb, one per message
+CREATE TABLE dnd_overrides (
+    message_id BIGINT PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    sender_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    receiver_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
@@ -0,0 +1,9 @@
+-- Per-workspace feature flag overrides. Flags without a row use their default.
+CREATE TABLE feature_flags (
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    flag VARCHAR(100) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (workspace_id, flag)
+);
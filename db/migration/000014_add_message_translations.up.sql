@@ -0,0 +1,12 @@
+-- Cached machine translations of messages. content_hash is the SHA-256 of the
+-- translated content so edited messages are translated again.
+CREATE TABLE message_translations (
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    target_language VARCHAR(35) NOT NULL,
+    source_language VARCHAR(35) NOT NULL,
+    content_hash VARCHAR(64) NOT NULL,
+    translated_text TEXT NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (message_id, target_language)
+);
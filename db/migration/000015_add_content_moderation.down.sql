@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS moderation_audit_log;
+DROP TABLE IF EXISTS moderation_queue;
+DROP TABLE IF EXISTS moderation_words;
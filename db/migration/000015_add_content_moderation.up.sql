@@ -0,0 +1,42 @@
+-- Per-workspace moderated words and phrases with the action taken when a
+-- message contains them
+CREATE TABLE moderation_words (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    word VARCHAR(100) NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('block', 'mask', 'flag')),
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX idx_moderation_words_workspace_word ON moderation_words(workspace_id, lower(word));
+
+-- Messages flagged for review by workspace admins
+CREATE TABLE moderation_queue (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    matched_words TEXT[] NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'removed')),
+    reviewed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+-- A message is in the queue at most once while it waits for review
+CREATE UNIQUE INDEX idx_moderation_queue_pending_message ON moderation_queue(message_id) WHERE status = 'pending';
+CREATE INDEX idx_moderation_queue_workspace_status ON moderation_queue(workspace_id, status, created_at);
+
+-- Moderation actions, both automatic (actor_id is NULL) and by admins
+CREATE TABLE moderation_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    message_id BIGINT REFERENCES messages(id) ON DELETE SET NULL,
+    author_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    actor_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('blocked', 'masked', 'flagged', 'approved', 'removed')),
+    matched_words TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_moderation_audit_log_workspace ON moderation_audit_log(workspace_id, created_at DESC);
@@ -0,0 +1,22 @@
+-- Reports of abusive messages, files or users, reviewed by workspace admins.
+-- Anonymous reports keep the reporter so duplicates can be detected, but it is
+-- not shown to admins.
+CREATE TABLE abuse_reports (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    reporter_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    target_type VARCHAR(10) NOT NULL CHECK (target_type IN ('message', 'file', 'user')),
+    target_id BIGINT NOT NULL,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('spam', 'harassment', 'hate_speech', 'inappropriate', 'other')),
+    details TEXT NOT NULL DEFAULT '',
+    anonymous BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved', 'dismissed')),
+    resolved_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    resolution_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+-- A member can have one open report per target
+CREATE UNIQUE INDEX idx_abuse_reports_open_reporter_target ON abuse_reports(reporter_id, target_type, target_id) WHERE status = 'open';
+CREATE INDEX idx_abuse_reports_workspace_status ON abuse_reports(workspace_id, status, created_at);
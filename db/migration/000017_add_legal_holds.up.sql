@@ -0,0 +1,18 @@
+-- Legal holds preserve a user's or channel's content for compliance. While a
+-- hold is active the held content can't be deleted. Released holds are kept as
+-- a record of the hold.
+CREATE TABLE legal_holds (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    target_type VARCHAR(10) NOT NULL CHECK (target_type IN ('user', 'channel')),
+    target_id BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    released_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    released_at TIMESTAMPTZ
+);
+
+-- A user or channel can only be under one active hold
+CREATE UNIQUE INDEX idx_legal_holds_active_target ON legal_holds(target_type, target_id) WHERE released_at IS NULL;
+CREATE INDEX idx_legal_holds_organization ON legal_holds(organization_id, created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE workspace_invitations
+DROP COLUMN IF EXISTS email_error,
+DROP COLUMN IF EXISTS email_last_attempt_at,
+DROP COLUMN IF EXISTS email_attempts,
+DROP COLUMN IF EXISTS email_status;
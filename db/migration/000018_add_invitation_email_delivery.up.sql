@@ -0,0 +1,7 @@
+-- Track delivery of invitation emails. Invitations created before emails were
+-- sent stay 'pending' until they are resent.
+ALTER TABLE workspace_invitations
+ADD COLUMN email_status TEXT NOT NULL DEFAULT 'pending' CHECK (email_status IN ('pending', 'sent', 'failed')),
+ADD COLUMN email_attempts INT NOT NULL DEFAULT 0,
+ADD COLUMN email_last_attempt_at TIMESTAMPTZ,
+ADD COLUMN email_error TEXT NOT NULL DEFAULT '';
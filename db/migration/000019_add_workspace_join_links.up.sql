@@ -0,0 +1,15 @@
+-- Shareable links that let anyone in the organization join a workspace as a
+-- member. Links can expire, be limited to a number of uses and be revoked.
+CREATE TABLE workspace_join_links (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMPTZ,
+    max_uses INT CHECK (max_uses > 0),
+    use_count INT NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_workspace_join_links_workspace ON workspace_join_links(workspace_id, created_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS workspace_join_requests;
+DROP TABLE IF EXISTS workspace_auto_join_domains;
+DROP TABLE IF EXISTS email_verifications;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
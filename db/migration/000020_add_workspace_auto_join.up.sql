@@ -0,0 +1,43 @@
+-- Users confirm they own their email address before it can be trusted, e.g.
+-- to join workspaces that allow their email domain
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+CREATE TABLE email_verifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    token VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_email_verifications_user ON email_verifications(user_id, created_at);
+
+-- Email domains whose verified users can join a workspace without an
+-- invitation, either directly or after an admin approves their request
+CREATE TABLE workspace_auto_join_domains (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    domain VARCHAR(255) NOT NULL,
+    requires_approval BOOLEAN NOT NULL DEFAULT false,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    UNIQUE (workspace_id, domain)
+);
+
+CREATE INDEX idx_workspace_auto_join_domains_domain ON workspace_auto_join_domains(domain);
+
+CREATE TABLE workspace_join_requests (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewed_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+-- A user has at most one open request per workspace
+CREATE UNIQUE INDEX idx_workspace_join_requests_pending ON workspace_join_requests(workspace_id, user_id) WHERE status = 'pending';
+CREATE INDEX idx_workspace_join_requests_workspace ON workspace_join_requests(workspace_id, status, created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS phone,
+    DROP COLUMN IF EXISTS pronouns,
+    DROP COLUMN IF EXISTS title;
@@ -0,0 +1,6 @@
+-- Optional profile details and the IANA time zone used for the user's local time
+ALTER TABLE users
+    ADD COLUMN title VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN pronouns VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN phone VARCHAR(32) NOT NULL DEFAULT '',
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,3 @@
+-- Random key of the user's current avatar images in the file store, NULL
+-- shows the user's initials instead
+ALTER TABLE users ADD COLUMN avatar_key VARCHAR(64);
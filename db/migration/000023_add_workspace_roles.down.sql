@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_custom_role;
+ALTER TABLE users DROP COLUMN IF EXISTS custom_role_id;
+DROP TABLE IF EXISTS workspace_roles;
@@ -0,0 +1,17 @@
+-- Workspace-defined roles that grant members a set of permissions on top of
+-- the built-in "member" role. Workspace admins always hold every permission.
+CREATE TABLE workspace_roles (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    UNIQUE (workspace_id, name)
+);
+
+ALTER TABLE users ADD COLUMN custom_role_id BIGINT REFERENCES workspace_roles(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_users_custom_role ON users(custom_role_id);
@@ -0,0 +1,22 @@
+-- Organization owners and admins administer every workspace in their
+-- organization, whether or not they're a member of it. These assignments are
+-- separate from a user's role in their own workspace.
+CREATE TABLE organization_roles (
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'admin')),
+    granted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_roles_user ON organization_roles(user_id);
+
+-- Workspace admins used to administer their organization. Keep every
+-- organization administrable by making its earliest workspace admin the owner.
+INSERT INTO organization_roles (organization_id, user_id, role)
+SELECT DISTINCT ON (organization_id) organization_id, id, 'owner'
+FROM users
+WHERE role = 'admin'
+ORDER BY organization_id, created_at, id;
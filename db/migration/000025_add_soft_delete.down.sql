@@ -0,0 +1,17 @@
+-- Deleted channels and workspaces can't be represented without the columns
+DELETE FROM channels WHERE deleted_at IS NOT NULL;
+DELETE FROM workspaces WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS channels_workspace_id_name_idx;
+CREATE UNIQUE INDEX channels_workspace_id_name_idx ON channels(workspace_id, name);
+
+DROP INDEX IF EXISTS idx_workspaces_deleted_at;
+DROP INDEX IF EXISTS idx_channels_deleted_at;
+
+ALTER TABLE workspaces
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE channels
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
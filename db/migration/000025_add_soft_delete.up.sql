@@ -0,0 +1,16 @@
+-- Deleted channels and workspaces are kept for a recovery window before
+-- their content is purged for good
+ALTER TABLE channels
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE workspaces
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_channels_deleted_at ON channels(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_workspaces_deleted_at ON workspaces(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- A deleted channel's name can be reused while it waits to be purged
+DROP INDEX channels_workspace_id_name_idx;
+CREATE UNIQUE INDEX channels_workspace_id_name_idx ON channels(workspace_id, name) WHERE deleted_at IS NULL;
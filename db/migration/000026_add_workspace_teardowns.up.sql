@@ -0,0 +1,19 @@
+-- Purged workspaces are torn down in the background. Rows are kept after the
+-- workspace is gone so organization admins can follow the progress.
+CREATE TABLE workspace_teardowns (
+    workspace_id BIGINT PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    workspace_name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed')),
+    step VARCHAR(50) NOT NULL DEFAULT '',
+    rows_deleted BIGINT NOT NULL DEFAULT 0,
+    files_deleted BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_workspace_teardowns_organization ON workspace_teardowns(organization_id, created_at DESC);
+CREATE INDEX idx_workspace_teardowns_unfinished ON workspace_teardowns(created_at) WHERE status <> 'completed';
@@ -0,0 +1,4 @@
+ALTER TABLE files
+    DROP COLUMN IF EXISTS waveform,
+    DROP COLUMN IF EXISTS duration_ms,
+    DROP COLUMN IF EXISTS is_voice_message;
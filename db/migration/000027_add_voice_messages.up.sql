@@ -0,0 +1,6 @@
+-- Audio uploads recorded as voice messages keep the duration and waveform
+-- read from the audio so clients can render a player without downloading it
+ALTER TABLE files
+    ADD COLUMN is_voice_message BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN duration_ms INTEGER CHECK (duration_ms >= 0),
+    ADD COLUMN waveform INTEGER[];
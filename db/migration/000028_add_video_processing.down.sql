@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_files_pending_processing;
+
+ALTER TABLE files
+    DROP COLUMN IF EXISTS poster_path,
+    DROP COLUMN IF EXISTS transcoded_path,
+    DROP COLUMN IF EXISTS processing_status;
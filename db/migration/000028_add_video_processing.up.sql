@@ -0,0 +1,9 @@
+-- Uploaded videos are transcoded in the background into a web-friendly MP4
+-- rendition with a poster image. processing_status is NULL for files that
+-- need no processing.
+ALTER TABLE files
+    ADD COLUMN processing_status VARCHAR(20) CHECK (processing_status IN ('pending', 'processing', 'completed', 'failed')),
+    ADD COLUMN transcoded_path TEXT,
+    ADD COLUMN poster_path TEXT;
+
+CREATE INDEX idx_files_pending_processing ON files(created_at) WHERE processing_status = 'pending';
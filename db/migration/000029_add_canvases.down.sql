@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS canvas_revisions;
+DROP TABLE IF EXISTS canvases;
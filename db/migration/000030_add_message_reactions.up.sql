@@ -0,0 +1,12 @@
+-- Emoji reactions to messages, one row per user and emoji
+CREATE TABLE message_reactions (
+    id BIGSERIAL PRIMARY KEY,
+    message_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    UNIQUE (message_id, user_id, emoji)
+);
+
+-- Reaction summaries and reactor lists group by emoji in reaction order
+CREATE INDEX idx_message_reactions_message_emoji ON message_reactions(message_id, emoji, created_at, id);
@@ -0,0 +1,5 @@
+ALTER TABLE workspace_settings
+    DROP COLUMN IF EXISTS max_distinct_reactions,
+    DROP COLUMN IF EXISTS max_reactions_per_user;
+
+DROP TABLE IF EXISTS custom_emojis;
@@ -0,0 +1,15 @@
+-- Workspace custom emojis, used in reactions as :name:
+CREATE TABLE custom_emojis (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    name VARCHAR(62) NOT NULL,
+    file_id BIGINT NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    UNIQUE (workspace_id, name)
+);
+
+-- Reaction limits, NULL values fall back to the server defaults
+ALTER TABLE workspace_settings
+    ADD COLUMN max_reactions_per_user INTEGER CHECK (max_reactions_per_user > 0),
+    ADD COLUMN max_distinct_reactions INTEGER CHECK (max_distinct_reactions > 0);
@@ -0,0 +1,18 @@
+-- Real-time events written in the same transaction as the change they
+-- announce, so they survive a crash between commit and broadcast
+CREATE TABLE event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    workspace_id BIGINT NOT NULL,
+    channel_id BIGINT,
+    user_id BIGINT NOT NULL,
+    -- Users the event is sent to; empty sends it to the channel, or the
+    -- whole workspace without a channel
+    recipient_ids BIGINT[] NOT NULL DEFAULT '{}',
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_event_outbox_unpublished ON event_outbox(created_at) WHERE published_at IS NULL;
+CREATE INDEX idx_event_outbox_published_at ON event_outbox(published_at);
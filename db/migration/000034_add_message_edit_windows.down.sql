@@ -0,0 +1,3 @@
+ALTER TABLE workspace_settings
+    DROP COLUMN IF EXISTS message_delete_window_minutes,
+    DROP COLUMN IF EXISTS message_edit_window_minutes;
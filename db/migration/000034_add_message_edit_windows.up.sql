@@ -0,0 +1,5 @@
+-- How long after sending members may edit or delete their messages, NULL
+-- values fall back to the server defaults and 0 allows it at any time
+ALTER TABLE workspace_settings
+    ADD COLUMN message_edit_window_minutes INTEGER CHECK (message_edit_window_minutes >= 0),
+    ADD COLUMN message_delete_window_minutes INTEGER CHECK (message_delete_window_minutes >= 0);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_messages_channel_history;
+
+ALTER TABLE workspace_settings
+    DROP COLUMN IF EXISTS hide_deleted_messages;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS deleted_by;
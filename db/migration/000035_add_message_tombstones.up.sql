@@ -0,0 +1,10 @@
+-- Deleted messages stay in history as tombstones recording who deleted them,
+-- unless the workspace hides them
+ALTER TABLE messages
+    ADD COLUMN deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE workspace_settings
+    ADD COLUMN hide_deleted_messages BOOLEAN NOT NULL DEFAULT false;
+
+-- Channel history includes deleted messages, which the partial index leaves out
+CREATE INDEX idx_messages_channel_history ON messages (channel_id, created_at DESC);
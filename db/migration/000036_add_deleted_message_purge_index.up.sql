@@ -0,0 +1,2 @@
+-- Deleted messages are purged oldest first once their retention has passed
+CREATE INDEX idx_messages_deleted_at ON messages (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,11 @@
+-- Takedown entries can't be represented without the new action
+DELETE FROM moderation_audit_log WHERE action = 'taken_down';
+
+ALTER TABLE moderation_audit_log
+    DROP CONSTRAINT moderation_audit_log_action_check,
+    ADD CONSTRAINT moderation_audit_log_action_check
+        CHECK (action IN ('blocked', 'masked', 'flagged', 'approved', 'removed')),
+    DROP COLUMN IF EXISTS reason;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS deletion_notice;
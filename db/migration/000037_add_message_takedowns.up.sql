@@ -0,0 +1,11 @@
+-- Messages taken down by moderators can leave a notice in their tombstone,
+-- such as the policy they violated
+ALTER TABLE messages
+    ADD COLUMN deletion_notice TEXT;
+
+-- Takedowns are audited with the moderator's reason
+ALTER TABLE moderation_audit_log
+    ADD COLUMN reason TEXT,
+    DROP CONSTRAINT moderation_audit_log_action_check,
+    ADD CONSTRAINT moderation_audit_log_action_check
+        CHECK (action IN ('blocked', 'masked', 'flagged', 'approved', 'removed', 'taken_down'));
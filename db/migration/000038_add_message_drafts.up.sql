@@ -0,0 +1,19 @@
+-- Unsent messages, one per conversation or thread, synced between a user's devices
+CREATE TABLE message_drafts (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    channel_id BIGINT REFERENCES channels(id) ON DELETE CASCADE,
+    receiver_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    thread_id BIGINT REFERENCES messages(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    -- A draft is written either in a channel or to another user
+    CHECK ((channel_id IS NULL) <> (receiver_id IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_message_drafts_target ON message_drafts(
+    user_id, workspace_id, COALESCE(channel_id, 0), COALESCE(receiver_id, 0), COALESCE(thread_id, 0)
+);
+CREATE INDEX idx_message_drafts_user ON message_drafts(user_id, workspace_id, updated_at DESC);
@@ -0,0 +1,11 @@
+-- Threads a user follows and how far they have read in each. Only replies to
+-- followed threads count as unread.
+CREATE TABLE thread_read_states (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    thread_id BIGINT NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    last_read_message_id BIGINT REFERENCES messages(id) ON DELETE SET NULL,
+    last_read_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (user_id, thread_id)
+);
+
+CREATE INDEX idx_thread_read_states_thread ON thread_read_states (thread_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_message_mentions_unread;
+ALTER TABLE message_mentions DROP COLUMN IF EXISTS read_at;
@@ -0,0 +1,4 @@
+-- When the mentioned user cleared the mention from their mentions feed
+ALTER TABLE message_mentions ADD COLUMN read_at TIMESTAMPTZ;
+
+CREATE INDEX idx_message_mentions_unread ON message_mentions(user_id) WHERE read_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE workspace_settings DROP COLUMN IF EXISTS max_pins_per_channel;
+DROP TABLE IF EXISTS pinned_messages;
@@ -0,0 +1,13 @@
+-- Messages pinned to the top of their channel, in the order members arranged them
+CREATE TABLE pinned_messages (
+    message_id BIGINT PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    channel_id BIGINT NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    pinned_by BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    pinned_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_pinned_messages_channel ON pinned_messages (channel_id, position);
+
+-- Overrides the server's default limit on pins per channel
+ALTER TABLE workspace_settings ADD COLUMN max_pins_per_channel INT;
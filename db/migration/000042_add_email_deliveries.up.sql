@@ -0,0 +1,26 @@
+-- Outgoing emails, queued by requests and sent by a background worker that
+-- retries failed attempts. Rows also record how each email was delivered so
+-- failures can be looked into.
+CREATE TABLE email_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    -- What the email is about, e.g. "invitation", and the row it was sent for
+    category VARCHAR(50) NOT NULL DEFAULT '',
+    reference_id BIGINT,
+    to_address VARCHAR(320) NOT NULL,
+    subject TEXT NOT NULL,
+    text_body TEXT NOT NULL DEFAULT '',
+    html_body TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'sent', 'failed')),
+    provider VARCHAR(20) NOT NULL DEFAULT '',
+    provider_message_id VARCHAR(255) NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_email_deliveries_due ON email_deliveries(next_attempt_at) WHERE status IN ('pending', 'sending');
+CREATE INDEX idx_email_deliveries_to_address ON email_deliveries(lower(to_address));
+CREATE INDEX idx_email_deliveries_created_at ON email_deliveries(created_at);
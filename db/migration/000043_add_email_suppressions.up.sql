@@ -0,0 +1,12 @@
+-- Addresses that emails are no longer sent to, because the provider reported
+-- a hard bounce or the recipient marked an email as spam. Addresses are
+-- stored lowercased.
+CREATE TABLE email_suppressions (
+    email VARCHAR(320) PRIMARY KEY,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('bounce', 'complaint')),
+    provider VARCHAR(20) NOT NULL,
+    -- The provider's explanation, e.g. the bounce diagnostic
+    detail TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS email_brandings;
+DROP TABLE IF EXISTS email_templates;
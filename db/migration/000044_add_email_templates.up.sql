@@ -0,0 +1,24 @@
+-- Organization overrides of the built-in email templates. Subjects and bodies
+-- are Go templates filled in with the variables of the email's kind.
+CREATE TABLE email_templates (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    kind VARCHAR(50) NOT NULL,
+    subject TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    html_body TEXT NOT NULL,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    UNIQUE (organization_id, kind)
+);
+
+-- The product name, logo, colour and footer an organization's emails use
+CREATE TABLE email_brandings (
+    organization_id BIGINT PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    product_name VARCHAR(100) NOT NULL DEFAULT '',
+    logo_url TEXT NOT NULL DEFAULT '',
+    primary_color VARCHAR(7) NOT NULL DEFAULT '',
+    footer_text TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
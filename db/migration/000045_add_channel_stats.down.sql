@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS channel_daily_poster_stats;
+DROP TABLE IF EXISTS channel_daily_stats;
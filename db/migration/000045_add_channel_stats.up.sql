@@ -0,0 +1,28 @@
+-- Daily rollups of each channel's messages, rebuilt nightly by the channel
+-- stats job so stats don't aggregate the messages table on every request.
+-- Days are UTC and deleted messages aren't counted.
+CREATE TABLE channel_daily_stats (
+    channel_id BIGINT NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    -- Messages posted that day, thread replies included
+    message_count INTEGER NOT NULL DEFAULT 0,
+    -- Thread replies posted that day
+    reply_count INTEGER NOT NULL DEFAULT 0,
+    -- Messages posted that day that have replies
+    thread_count INTEGER NOT NULL DEFAULT 0,
+    last_message_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (channel_id, day)
+);
+
+-- Messages each user posted in a channel per day, for top posters
+CREATE TABLE channel_daily_poster_stats (
+    channel_id BIGINT NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (channel_id, day, user_id)
+);
+
+CREATE INDEX idx_channel_daily_stats_day ON channel_daily_stats (day);
+CREATE INDEX idx_channel_daily_poster_stats_day ON channel_daily_poster_stats (day);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_organization_name;
+DROP INDEX IF EXISTS idx_users_organization_handle;
+ALTER TABLE users DROP COLUMN IF EXISTS handle;
@@ -0,0 +1,6 @@
+-- Handles people can be found by in the organization directory, e.g.
+-- "ada.lovelace". They're optional but unique within an organization.
+ALTER TABLE users ADD COLUMN handle VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_users_organization_handle ON users (organization_id, handle) WHERE handle <> '';
+CREATE INDEX idx_users_organization_name ON users (organization_id, first_name, last_name);
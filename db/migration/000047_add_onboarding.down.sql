@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS onboarding_step_completions;
+DROP TABLE IF EXISTS workspace_onboarding;
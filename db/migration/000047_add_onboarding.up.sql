@@ -0,0 +1,26 @@
+-- Onboarding for new members of a workspace: a welcome DM from the
+-- workspace's onboarding bot, channels to suggest joining, and the steps of
+-- the profile checklist. Workspaces without a row have no onboarding.
+CREATE TABLE workspace_onboarding (
+    workspace_id BIGINT PRIMARY KEY REFERENCES workspaces(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    -- Go template of the welcome DM, e.g. "Welcome, {{.FirstName}}!"
+    welcome_message TEXT NOT NULL CHECK (LENGTH(welcome_message) <= 4000),
+    suggested_channel_ids BIGINT[] NOT NULL DEFAULT '{}',
+    -- Checklist steps in the order they are shown
+    steps TEXT[] NOT NULL DEFAULT '{}',
+    -- User the welcome DM is sent as, created with the first welcome
+    bot_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+-- Checklist steps members have completed. Steps stay completed even if the
+-- member later undoes them, e.g. removes their photo.
+CREATE TABLE onboarding_step_completions (
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    step VARCHAR(50) NOT NULL,
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (user_id, workspace_id, step)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE do_not_disturb
+    DROP COLUMN IF EXISTS schedule_days,
+    DROP COLUMN IF EXISTS schedule_end_minute,
+    DROP COLUMN IF EXISTS schedule_start_minute;
@@ -0,0 +1,8 @@
+-- Recurring Do Not Disturb, e.g. 22:00 to 08:00 on weekdays, in the user's
+-- own timezone. Times are minutes after local midnight; a schedule that ends
+-- before it starts runs past midnight. Days are 0 (Sunday) to 6 and name the
+-- day the schedule starts on; no days means every day.
+ALTER TABLE do_not_disturb
+    ADD COLUMN schedule_start_minute INTEGER CHECK (schedule_start_minute BETWEEN 0 AND 1439),
+    ADD COLUMN schedule_end_minute INTEGER CHECK (schedule_end_minute BETWEEN 0 AND 1439),
+    ADD COLUMN schedule_days INTEGER[] NOT NULL DEFAULT '{}' CHECK (schedule_days <@ ARRAY[0, 1, 2, 3, 4, 5, 6]);
@@ -0,0 +1,20 @@
+-- How a user wants to be notified in a workspace. The row without a channel
+-- is the user's workspace-wide default; channel rows override it.
+CREATE TABLE notification_preferences (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    channel_id BIGINT REFERENCES channels(id) ON DELETE CASCADE,
+    -- all messages, only mentions and direct messages, or nothing
+    level VARCHAR(20) NOT NULL DEFAULT 'mentions' CHECK (level IN ('all', 'mentions', 'nothing')),
+    email_enabled BOOLEAN NOT NULL DEFAULT true,
+    push_enabled BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+-- NULLs never conflict in a unique index, so a single index over channel_id
+-- would let the workspace-wide row be inserted again and again. Each kind of
+-- row gets its own partial index to upsert against instead.
+CREATE UNIQUE INDEX idx_notification_preferences_workspace ON notification_preferences (user_id, workspace_id) WHERE channel_id IS NULL;
+CREATE UNIQUE INDEX idx_notification_preferences_channel ON notification_preferences (user_id, channel_id) WHERE channel_id IS NOT NULL;
@@ -0,0 +1,15 @@
+-- Announcements shown to every member of a workspace until they expire or an
+-- admin removes them
+CREATE TABLE workspace_banners (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    text TEXT NOT NULL CHECK (LENGTH(text) BETWEEN 1 AND 500),
+    severity VARCHAR(20) NOT NULL DEFAULT 'info' CHECK (severity IN ('info', 'warning', 'critical')),
+    -- NULL keeps the banner up until it is deleted
+    expires_at TIMESTAMPTZ,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_workspace_banners_workspace ON workspace_banners (workspace_id);
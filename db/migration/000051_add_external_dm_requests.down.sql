@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS external_dm_requests;
+DROP TABLE IF EXISTS external_dm_policies;
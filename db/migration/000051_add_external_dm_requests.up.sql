@@ -0,0 +1,31 @@
+-- Whether an organization's members can exchange direct messages with people
+-- in other organizations. Organizations without a row are open.
+CREATE TABLE external_dm_policies (
+    organization_id BIGINT PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    -- open to everyone, restricted to people whose email domain is allowed, or disabled
+    policy VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (policy IN ('open', 'restricted', 'disabled')),
+    allowed_domains TEXT[] NOT NULL DEFAULT '{}',
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+-- A request to start a direct conversation with someone in another
+-- organization. Once accepted the request stands for the conversation, whose
+-- messages are kept in the requester's workspace.
+CREATE TABLE external_dm_requests (
+    id BIGSERIAL PRIMARY KEY,
+    sender_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    recipient_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message TEXT NOT NULL DEFAULT '' CHECK (LENGTH(message) <= 500),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'closed')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    responded_at TIMESTAMPTZ,
+    CHECK (sender_id <> recipient_id)
+);
+
+-- Two people have at most one open request or conversation, whoever asked
+CREATE UNIQUE INDEX idx_external_dm_requests_open ON external_dm_requests (LEAST(sender_id, recipient_id), GREATEST(sender_id, recipient_id))
+WHERE status IN ('pending', 'accepted');
+CREATE INDEX idx_external_dm_requests_recipient ON external_dm_requests (recipient_id);
+CREATE INDEX idx_external_dm_requests_sender ON external_dm_requests (sender_id);
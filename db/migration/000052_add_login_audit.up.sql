@@ -0,0 +1,33 @@
+-- Devices users have signed in from, told apart by a fingerprint of the
+-- client's device ID (or its user agent when it doesn't send one). Trusted
+-- devices skip two-factor authentication until trusted_until.
+CREATE TABLE user_devices (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(64) NOT NULL,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    last_ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    trusted_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    UNIQUE (user_id, fingerprint)
+);
+
+-- Every login attempt, successful or not. Attempts for unknown emails have no user.
+CREATE TABLE login_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    device_id BIGINT REFERENCES user_devices(id) ON DELETE SET NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL,
+    -- unknown_user or incorrect_password for failed attempts
+    failure_reason VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_login_events_user_created_at ON login_events (user_id, created_at DESC);
+CREATE INDEX idx_login_events_email_created_at ON login_events (email, created_at DESC);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trigger_update_message_search_vector ON messages;
+DROP FUNCTION IF EXISTS update_message_search_vector();
+ALTER TABLE messages DROP COLUMN IF EXISTS search_vector;
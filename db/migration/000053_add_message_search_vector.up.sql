@@ -0,0 +1,17 @@
+-- Message search matches against a stored tsvector instead of computing one
+-- per row at query time. The trigger keeps it in step with the content;
+-- 000054 fills it in for existing messages and indexes it.
+ALTER TABLE messages ADD COLUMN search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION update_message_search_vector()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := to_tsvector('english', NEW.content);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_update_message_search_vector
+    BEFORE INSERT OR UPDATE OF content ON messages
+    FOR EACH ROW
+    EXECUTE FUNCTION update_message_search_vector();
@@ -0,0 +1,8 @@
+-- Fill in the search vectors of messages written before 000053, then index
+-- them; building the index once afterwards is faster than updating it row
+-- by row during the backfill
+UPDATE messages
+SET search_vector = to_tsvector('english', content)
+WHERE search_vector IS NULL;
+
+CREATE INDEX idx_messages_search_vector ON messages USING GIN (search_vector);
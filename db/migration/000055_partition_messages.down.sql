@@ -0,0 +1,61 @@
+DROP TRIGGER IF EXISTS trigger_delete_message_dependents ON messages;
+DROP FUNCTION IF EXISTS drop_message_partition(TEXT);
+DROP FUNCTION IF EXISTS delete_deleted_message_dependents();
+DROP FUNCTION IF EXISTS delete_message_dependents(BIGINT[]);
+
+-- Messages written to the monthly partitions move back into the legacy table
+ALTER TABLE messages DETACH PARTITION messages_legacy;
+ALTER TABLE messages_legacy DROP CONSTRAINT messages_legacy_created_at_check;
+INSERT INTO messages_legacy SELECT * FROM messages;
+
+ALTER SEQUENCE messages_id_seq OWNED BY messages_legacy.id;
+DROP TABLE messages;
+DROP FUNCTION IF EXISTS create_message_partition(DATE);
+
+ALTER TABLE messages_legacy RENAME TO messages;
+
+DO $$
+DECLARE
+    primary_key TEXT;
+BEGIN
+    SELECT conname INTO primary_key FROM pg_constraint
+    WHERE conrelid = 'messages'::regclass AND contype = 'p';
+    EXECUTE format('ALTER TABLE messages DROP CONSTRAINT %I', primary_key);
+END;
+$$;
+ALTER TABLE messages ADD CONSTRAINT messages_pkey PRIMARY KEY (id);
+
+ALTER INDEX idx_messages_legacy_channel_created_at RENAME TO idx_messages_channel_created_at;
+ALTER INDEX idx_messages_legacy_direct_users RENAME TO idx_messages_direct_users;
+ALTER INDEX idx_messages_legacy_workspace_created_at RENAME TO idx_messages_workspace_created_at;
+ALTER INDEX idx_messages_legacy_thread_id RENAME TO idx_messages_thread_id;
+ALTER INDEX idx_messages_legacy_channel_history RENAME TO idx_messages_channel_history;
+ALTER INDEX idx_messages_legacy_deleted_at RENAME TO idx_messages_deleted_at;
+ALTER INDEX idx_messages_legacy_search_vector RENAME TO idx_messages_search_vector;
+
+DROP TRIGGER IF EXISTS trigger_check_message_type_consistency ON messages;
+CREATE TRIGGER trigger_check_message_type_consistency
+    BEFORE INSERT OR UPDATE ON messages
+    FOR EACH ROW
+    EXECUTE FUNCTION check_message_type_consistency();
+
+DROP TRIGGER IF EXISTS trigger_update_message_search_vector ON messages;
+CREATE TRIGGER trigger_update_message_search_vector
+    BEFORE INSERT OR UPDATE OF content ON messages
+    FOR EACH ROW
+    EXECUTE FUNCTION update_message_search_vector();
+
+ALTER TABLE messages ADD CONSTRAINT messages_thread_id_fkey FOREIGN KEY (thread_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE message_files ADD CONSTRAINT message_files_message_id_fkey FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE channel_read_states ADD CONSTRAINT channel_read_states_last_read_message_id_fkey FOREIGN KEY (last_read_message_id) REFERENCES messages(id) ON DELETE SET NULL;
+ALTER TABLE direct_message_read_states ADD CONSTRAINT direct_message_read_states_last_read_message_id_fkey FOREIGN KEY (last_read_message_id) REFERENCES messages(id) ON DELETE SET NULL;
+ALTER TABLE dnd_overrides ADD CONSTRAINT dnd_overrides_message_id_fkey FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE message_translations ADD CONSTRAINT message_translations_message_id_fkey FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE moderation_queue ADD CONSTRAINT moderation_queue_message_id_fkey FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE moderation_audit_log ADD CONSTRAINT moderation_audit_log_message_id_fkey FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE SET NULL;
+ALTER TABLE message_reactions ADD CONSTRAINT message_reactions_message_id_fkey FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE message_mentions ADD CONSTRAINT message_mentions_message_id_fkey FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE message_drafts ADD CONSTRAINT message_drafts_thread_id_fkey FOREIGN KEY (thread_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE thread_read_states ADD CONSTRAINT thread_read_states_thread_id_fkey FOREIGN KEY (thread_id) REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE thread_read_states ADD CONSTRAINT thread_read_states_last_read_message_id_fkey FOREIGN KEY (last_read_message_id) REFERENCES messages(id) ON DELETE SET NULL;
+ALTER TABLE pinned_messages ADD CONSTRAINT pinned_messages_message_id_fkey FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE;
@@ -0,0 +1,207 @@
+-- Messages are partitioned by month of created_at so that vacuum and index
+-- maintenance work on one month at a time and expired months can be dropped
+-- whole. The existing table becomes the messages_legacy partition holding
+-- everything up to the end of the current month, so no rows are copied.
+--
+-- A partitioned table's primary key has to include created_at, so other
+-- tables can no longer reference messages(id) with a foreign key. The
+-- cascades those keys did are done by delete_message_dependents instead.
+
+-- Foreign keys to messages
+ALTER TABLE messages DROP CONSTRAINT messages_thread_id_fkey;
+ALTER TABLE message_files DROP CONSTRAINT message_files_message_id_fkey;
+ALTER TABLE channel_read_states DROP CONSTRAINT channel_read_states_last_read_message_id_fkey;
+ALTER TABLE direct_message_read_states DROP CONSTRAINT direct_message_read_states_last_read_message_id_fkey;
+ALTER TABLE dnd_overrides DROP CONSTRAINT dnd_overrides_message_id_fkey;
+ALTER TABLE message_translations DROP CONSTRAINT message_translations_message_id_fkey;
+ALTER TABLE moderation_queue DROP CONSTRAINT moderation_queue_message_id_fkey;
+ALTER TABLE moderation_audit_log DROP CONSTRAINT moderation_audit_log_message_id_fkey;
+ALTER TABLE message_reactions DROP CONSTRAINT message_reactions_message_id_fkey;
+ALTER TABLE message_mentions DROP CONSTRAINT message_mentions_message_id_fkey;
+ALTER TABLE message_drafts DROP CONSTRAINT message_drafts_thread_id_fkey;
+ALTER TABLE thread_read_states DROP CONSTRAINT thread_read_states_thread_id_fkey;
+ALTER TABLE thread_read_states DROP CONSTRAINT thread_read_states_last_read_message_id_fkey;
+ALTER TABLE pinned_messages DROP CONSTRAINT pinned_messages_message_id_fkey;
+
+-- The partitioned table's triggers and indexes replace the old table's
+DROP TRIGGER trigger_check_message_type_consistency ON messages;
+DROP TRIGGER trigger_update_message_search_vector ON messages;
+
+-- Attaching gives the legacy table the (id, created_at) primary key
+ALTER TABLE messages DROP CONSTRAINT messages_pkey;
+ALTER TABLE messages RENAME TO messages_legacy;
+ALTER INDEX idx_messages_channel_created_at RENAME TO idx_messages_legacy_channel_created_at;
+ALTER INDEX idx_messages_direct_users RENAME TO idx_messages_legacy_direct_users;
+ALTER INDEX idx_messages_workspace_created_at RENAME TO idx_messages_legacy_workspace_created_at;
+ALTER INDEX idx_messages_thread_id RENAME TO idx_messages_legacy_thread_id;
+ALTER INDEX idx_messages_channel_history RENAME TO idx_messages_legacy_channel_history;
+ALTER INDEX idx_messages_deleted_at RENAME TO idx_messages_legacy_deleted_at;
+ALTER INDEX idx_messages_search_vector RENAME TO idx_messages_legacy_search_vector;
+
+CREATE TABLE messages (
+    id BIGINT NOT NULL DEFAULT nextval('messages_id_seq'),
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    channel_id BIGINT REFERENCES channels(id) ON DELETE CASCADE, -- NULL for direct messages
+    sender_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    receiver_id BIGINT REFERENCES users(id) ON DELETE CASCADE, -- NULL for channel messages
+    content TEXT NOT NULL CONSTRAINT messages_content_check CHECK (LENGTH(content) <= 4000),
+    message_type VARCHAR(20) NOT NULL CONSTRAINT messages_message_type_check CHECK (message_type IN ('channel', 'direct')),
+    thread_id BIGINT,
+    edited_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    content_type VARCHAR(20) NOT NULL DEFAULT 'text'
+        CONSTRAINT messages_content_type_check CHECK (content_type IN ('text', 'file', 'image', 'system')),
+    deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    deletion_notice TEXT,
+    search_vector TSVECTOR,
+    PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+ALTER SEQUENCE messages_id_seq OWNED BY messages.id;
+
+-- Creates the partition holding a month's messages unless it exists. It
+-- returns whether the partition was created.
+CREATE OR REPLACE FUNCTION create_message_partition(month DATE)
+RETURNS BOOLEAN AS $$
+DECLARE
+    starts_at TIMESTAMP := date_trunc('month', month::timestamp);
+    partition_name TEXT := 'messages_p' || to_char(starts_at, 'YYYYMM');
+BEGIN
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN false;
+    END IF;
+
+    EXECUTE format(
+        'CREATE TABLE %I PARTITION OF messages FOR VALUES FROM (%L) TO (%L)',
+        partition_name,
+        starts_at AT TIME ZONE 'UTC',
+        (starts_at + INTERVAL '1 month') AT TIME ZONE 'UTC'
+    );
+    RETURN true;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The legacy partition ends where the first monthly partition starts, at
+-- the start of next month in UTC
+DO $$
+DECLARE
+    cutover TIMESTAMP := date_trunc('month', now() AT TIME ZONE 'UTC') + INTERVAL '1 month';
+BEGIN
+    -- A valid check matching the partition bound spares the attach a scan
+    EXECUTE format(
+        'ALTER TABLE messages_legacy ADD CONSTRAINT messages_legacy_created_at_check CHECK (created_at < %L)',
+        cutover AT TIME ZONE 'UTC'
+    );
+    EXECUTE format(
+        'ALTER TABLE messages ATTACH PARTITION messages_legacy FOR VALUES FROM (MINVALUE) TO (%L)',
+        cutover AT TIME ZONE 'UTC'
+    );
+
+    -- Three months ahead; the maintenance job keeps it that way
+    FOR i IN 0..2 LOOP
+        PERFORM create_message_partition((cutover + i * INTERVAL '1 month')::date);
+    END LOOP;
+END;
+$$;
+
+-- Catches messages outside every partition, such as ones dated ahead of the
+-- maintenance job
+CREATE TABLE messages_default PARTITION OF messages DEFAULT;
+
+-- Partitioned indexes take over the legacy table's indexes without rebuilding them
+CREATE INDEX idx_messages_channel_created_at ON messages (channel_id, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_messages_direct_users ON messages (workspace_id, sender_id, receiver_id, created_at DESC) WHERE message_type = 'direct' AND deleted_at IS NULL;
+CREATE INDEX idx_messages_workspace_created_at ON messages (workspace_id, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX idx_messages_thread_id ON messages (thread_id) WHERE thread_id IS NOT NULL;
+CREATE INDEX idx_messages_channel_history ON messages (channel_id, created_at DESC);
+CREATE INDEX idx_messages_deleted_at ON messages (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_messages_search_vector ON messages USING GIN (search_vector);
+
+CREATE TRIGGER trigger_check_message_type_consistency
+    BEFORE INSERT OR UPDATE ON messages
+    FOR EACH ROW
+    EXECUTE FUNCTION check_message_type_consistency();
+
+CREATE TRIGGER trigger_update_message_search_vector
+    BEFORE INSERT OR UPDATE OF content ON messages
+    FOR EACH ROW
+    EXECUTE FUNCTION update_message_search_vector();
+
+-- Removes what the foreign keys to messages used to cascade to when
+-- messages are deleted: their replies, files, reactions and so on are
+-- deleted and read positions and audit entries pointing at them are cleared
+CREATE OR REPLACE FUNCTION delete_message_dependents(message_ids BIGINT[])
+RETURNS VOID AS $$
+BEGIN
+    IF cardinality(message_ids) = 0 THEN
+        RETURN;
+    END IF;
+
+    DELETE FROM messages WHERE thread_id = ANY(message_ids);
+    DELETE FROM message_files WHERE message_id = ANY(message_ids);
+    DELETE FROM dnd_overrides WHERE message_id = ANY(message_ids);
+    DELETE FROM message_translations WHERE message_id = ANY(message_ids);
+    DELETE FROM moderation_queue WHERE message_id = ANY(message_ids);
+    DELETE FROM message_reactions WHERE message_id = ANY(message_ids);
+    DELETE FROM message_mentions WHERE message_id = ANY(message_ids);
+    DELETE FROM message_drafts WHERE thread_id = ANY(message_ids);
+    DELETE FROM thread_read_states WHERE thread_id = ANY(message_ids);
+    DELETE FROM pinned_messages WHERE message_id = ANY(message_ids);
+
+    UPDATE channel_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE direct_message_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE thread_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE moderation_audit_log SET message_id = NULL WHERE message_id = ANY(message_ids);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION delete_deleted_message_dependents()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM delete_message_dependents(ARRAY(SELECT id FROM deleted_messages));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_delete_message_dependents
+    AFTER DELETE ON messages
+    REFERENCING OLD TABLE AS deleted_messages
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION delete_deleted_message_dependents();
+
+-- Drops a monthly partition past its retention along with what depends on
+-- its messages. A partition holding messages of a user or channel under an
+-- active legal hold is kept; it returns whether the partition was dropped.
+-- The dependents go first so the lock detaching takes is held only briefly.
+CREATE OR REPLACE FUNCTION drop_message_partition(partition_name TEXT)
+RETURNS BOOLEAN AS $$
+DECLARE
+    held BOOLEAN;
+    message_ids BIGINT[];
+BEGIN
+    IF partition_name !~ '^messages_p[0-9]{6}$' OR to_regclass(partition_name) IS NULL THEN
+        RETURN false;
+    END IF;
+
+    EXECUTE format(
+        'SELECT EXISTS (
+            SELECT 1 FROM %I m
+            JOIN legal_holds h ON h.released_at IS NULL AND (
+                (h.target_type = ''user'' AND h.target_id = m.sender_id)
+                OR (h.target_type = ''channel'' AND h.target_id = m.channel_id)
+            )
+        )',
+        partition_name
+    ) INTO held;
+    IF held THEN
+        RETURN false;
+    END IF;
+
+    EXECUTE format('SELECT ARRAY(SELECT id FROM %I)', partition_name) INTO message_ids;
+    PERFORM delete_message_dependents(message_ids);
+    EXECUTE format('ALTER TABLE messages DETACH PARTITION %I', partition_name);
+    EXECUTE format('DROP TABLE %I', partition_name);
+    RETURN true;
+END;
+$$ LANGUAGE plpgsql;
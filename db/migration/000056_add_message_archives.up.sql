@@ -0,0 +1,23 @@
+-- Old messages are moved out of the database into compressed objects in the
+-- archive store, one conversation and month at a time. Each archive records
+-- what it holds so history and exports can find the objects to fetch.
+-- Direct message archives record the pair of users, lower ID first.
+CREATE TABLE message_archives (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    channel_id BIGINT REFERENCES channels(id) ON DELETE CASCADE, -- NULL for direct messages
+    user_id BIGINT REFERENCES users(id) ON DELETE CASCADE, -- NULL for channel messages
+    other_user_id BIGINT REFERENCES users(id) ON DELETE CASCADE, -- NULL for channel messages
+    sender_ids BIGINT[] NOT NULL,
+    object_key TEXT NOT NULL UNIQUE,
+    first_message_at TIMESTAMPTZ NOT NULL,
+    last_message_at TIMESTAMPTZ NOT NULL,
+    message_count INT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    CHECK ((channel_id IS NULL) = (user_id IS NOT NULL AND other_user_id IS NOT NULL))
+);
+
+CREATE INDEX idx_message_archives_channel ON message_archives(channel_id, last_message_at DESC) WHERE channel_id IS NOT NULL;
+CREATE INDEX idx_message_archives_direct ON message_archives(workspace_id, user_id, other_user_id, last_message_at DESC) WHERE channel_id IS NULL;
+CREATE INDEX idx_message_archives_sender_ids ON message_archives USING GIN (sender_ids);
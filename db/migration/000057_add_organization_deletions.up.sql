@@ -0,0 +1,29 @@
+-- Organizations are deleted in stages. Requesting a deletion revokes access to
+-- the organization's workspaces at once; once the cancellation window has
+-- passed a background job tears the workspaces down and deletes the
+-- organization. Rows outlive the organization so the outcome stays on record.
+CREATE TABLE organization_deletions (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL,
+    organization_name VARCHAR(255) NOT NULL,
+    requested_by BIGINT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'cancelled')),
+    step VARCHAR(50) NOT NULL DEFAULT '',
+    workspaces_total INT NOT NULL DEFAULT 0,
+    workspaces_deleted INT NOT NULL DEFAULT 0,
+    rows_deleted BIGINT NOT NULL DEFAULT 0,
+    files_deleted BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    scheduled_for TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    cancelled_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+-- An organization has at most one deletion under way
+CREATE UNIQUE INDEX idx_organization_deletions_active ON organization_deletions(organization_id) WHERE status IN ('pending', 'running');
+CREATE INDEX idx_organization_deletions_due ON organization_deletions(scheduled_for) WHERE status IN ('pending', 'running');
+CREATE INDEX idx_organization_deletions_organization ON organization_deletions(organization_id, created_at DESC);
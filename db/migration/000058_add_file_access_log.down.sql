@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS file_access_log;
+
+ALTER TABLE files DROP COLUMN IF EXISTS download_count;
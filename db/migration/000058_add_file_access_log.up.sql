@@ -0,0 +1,15 @@
+ALTER TABLE files ADD COLUMN download_count BIGINT NOT NULL DEFAULT 0;
+
+-- Every download of a file's content, so security reviews can see who
+-- fetched what. Entries outlive the downloader's account but not the file.
+CREATE TABLE file_access_log (
+    id BIGSERIAL PRIMARY KEY,
+    file_id BIGINT NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_file_access_log_file_created_at ON file_access_log (file_id, created_at DESC);
+CREATE INDEX idx_file_access_log_user_id ON file_access_log (user_id);
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS trigger_record_message_file_webhook_event ON message_files;
+DROP TRIGGER IF EXISTS trigger_record_file_share_webhook_event ON file_shares;
+DROP FUNCTION IF EXISTS record_file_share_webhook_event();
+DROP TRIGGER IF EXISTS trigger_record_file_webhook_event ON files;
+DROP FUNCTION IF EXISTS record_file_webhook_event();
+DROP FUNCTION IF EXISTS file_webhook_data(files);
+DROP TRIGGER IF EXISTS trigger_queue_webhook_deliveries ON event_outbox;
+DROP FUNCTION IF EXISTS queue_webhook_deliveries();
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS outgoing_webhooks;
@@ -0,0 +1,150 @@
+-- Outgoing webhooks: a workspace subscribes a URL to event types and each
+-- matching event in the outbox is POSTed to it, signed with the webhook's
+-- secret
+CREATE TABLE outgoing_webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_outgoing_webhooks_workspace ON outgoing_webhooks(workspace_id);
+
+-- One delivery per webhook and event, sent by the delivery worker. Failed
+-- attempts are retried at next_attempt_at until the attempt limit.
+CREATE TABLE webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES outgoing_webhooks(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    workspace_id BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'delivered', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    UNIQUE(webhook_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status IN ('pending', 'sending');
+CREATE INDEX idx_webhook_deliveries_created_at ON webhook_deliveries(created_at);
+
+-- Queue a delivery to every webhook subscribed to a new outbox event
+CREATE OR REPLACE FUNCTION queue_webhook_deliveries()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, workspace_id, payload)
+    SELECT w.id, NEW.id, NEW.event_type, NEW.workspace_id, NEW.payload
+    FROM outgoing_webhooks w
+    WHERE w.workspace_id = NEW.workspace_id
+        AND NEW.event_type = ANY(w.event_types);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_queue_webhook_deliveries
+    AFTER INSERT ON event_outbox
+    FOR EACH ROW
+    EXECUTE FUNCTION queue_webhook_deliveries();
+
+-- File lifecycle events go to the outbox for webhooks only. They aren't
+-- broadcast to clients, so they're written already published. Payloads
+-- carry file metadata, never content. file.scanned comes from the file
+-- service, which checks upload content, rather than from a trigger.
+CREATE OR REPLACE FUNCTION file_webhook_data(f files)
+RETURNS JSONB AS $$
+    SELECT jsonb_build_object(
+        'file_id', f.id,
+        'uploader_id', f.uploader_id,
+        'filename', f.original_filename,
+        'mime_type', f.mime_type,
+        'file_size', f.file_size,
+        'file_hash', f.file_hash,
+        'is_public', f.is_public
+    );
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION record_file_webhook_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Uploads that never completed are cleaned up without an event
+    IF TG_OP = 'DELETE' THEN
+        IF OLD.upload_completed THEN
+            INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+            VALUES ('file.deleted', OLD.workspace_id, OLD.uploader_id, file_webhook_data(OLD), now());
+        END IF;
+        RETURN NULL;
+    END IF;
+
+    -- An upload completes once its content is stored
+    IF NEW.upload_completed AND (TG_OP = 'INSERT' OR NOT OLD.upload_completed) THEN
+        INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+        VALUES ('file.uploaded', NEW.workspace_id, NEW.uploader_id, file_webhook_data(NEW), now());
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND NEW.is_public AND NOT OLD.is_public THEN
+        INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+        VALUES ('file.shared', NEW.workspace_id, NEW.uploader_id,
+            file_webhook_data(NEW) || jsonb_build_object('shared_with', 'workspace'), now());
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_record_file_webhook_event
+    AFTER INSERT OR UPDATE OF upload_completed, is_public OR DELETE ON files
+    FOR EACH ROW
+    EXECUTE FUNCTION record_file_webhook_event();
+
+-- A file is shared when it's shared with a channel or user, or attached to a
+-- message
+CREATE OR REPLACE FUNCTION record_file_share_webhook_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    f files;
+    data JSONB;
+    actor_id BIGINT;
+BEGIN
+    SELECT * INTO f FROM files WHERE id = NEW.file_id;
+    IF NOT FOUND THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_TABLE_NAME = 'file_shares' THEN
+        actor_id := NEW.shared_by;
+        data := file_webhook_data(f) || jsonb_build_object(
+            'shared_by', NEW.shared_by,
+            'channel_id', NEW.channel_id,
+            'shared_with_user_id', NEW.shared_with_user_id
+        );
+    ELSE
+        SELECT m.sender_id INTO actor_id FROM messages m WHERE m.id = NEW.message_id;
+        actor_id := COALESCE(actor_id, f.uploader_id);
+        data := file_webhook_data(f) || jsonb_build_object(
+            'shared_by', actor_id,
+            'message_id', NEW.message_id
+        );
+    END IF;
+
+    INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+    VALUES ('file.shared', f.workspace_id, actor_id, data, now());
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_record_file_share_webhook_event
+    AFTER INSERT ON file_shares
+    FOR EACH ROW
+    EXECUTE FUNCTION record_file_share_webhook_event();
+
+CREATE TRIGGER trigger_record_message_file_webhook_event
+    AFTER INSERT ON message_files
+    FOR EACH ROW
+    EXECUTE FUNCTION record_file_share_webhook_event();
@@ -0,0 +1,2 @@
+ALTER TABLE workspace_settings
+    DROP COLUMN IF EXISTS image_watermark;
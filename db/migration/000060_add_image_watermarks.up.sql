@@ -0,0 +1,6 @@
+-- Images downloaded from the workspace can be stamped with the workspace
+-- name and the downloader's email: never, only those shared in private
+-- channels, or all of them
+ALTER TABLE workspace_settings
+    ADD COLUMN image_watermark VARCHAR(20) NOT NULL DEFAULT 'off'
+        CHECK (image_watermark IN ('off', 'private_channels', 'all'));
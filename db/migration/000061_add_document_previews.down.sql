@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_files_pending_preview;
+
+ALTER TABLE files DROP COLUMN IF EXISTS preview_status;
@@ -0,0 +1,7 @@
+-- PDFs and office documents get an image of their first page, rendered in
+-- the background and stored as the file's thumbnail. preview_status is NULL
+-- for files that get no preview.
+ALTER TABLE files
+    ADD COLUMN preview_status VARCHAR(20) CHECK (preview_status IN ('pending', 'processing', 'completed', 'failed'));
+
+CREATE INDEX idx_files_pending_preview ON files(created_at) WHERE preview_status = 'pending';
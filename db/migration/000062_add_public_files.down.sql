@@ -0,0 +1,3 @@
+ALTER TABLE workspace_settings
+    DROP COLUMN IF EXISTS public_file_referers,
+    DROP COLUMN IF EXISTS public_files;
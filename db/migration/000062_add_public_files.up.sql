@@ -0,0 +1,6 @@
+-- Files uploaded as public can be fetched without signing in, so they can be
+-- embedded outside the workspace. Workspaces can turn that off, or only allow
+-- embedding on the listed sites; an empty list allows any site.
+ALTER TABLE workspace_settings
+    ADD COLUMN public_files BOOLEAN NOT NULL DEFAULT true,
+    ADD COLUMN public_file_referers TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,40 @@
+-- Webhooks go back to seeing only permanent deletions
+CREATE OR REPLACE FUNCTION record_file_webhook_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Uploads that never completed are cleaned up without an event
+    IF TG_OP = 'DELETE' THEN
+        IF OLD.upload_completed THEN
+            INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+            VALUES ('file.deleted', OLD.workspace_id, OLD.uploader_id, file_webhook_data(OLD), now());
+        END IF;
+        RETURN NULL;
+    END IF;
+
+    -- An upload completes once its content is stored
+    IF NEW.upload_completed AND (TG_OP = 'INSERT' OR NOT OLD.upload_completed) THEN
+        INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+        VALUES ('file.uploaded', NEW.workspace_id, NEW.uploader_id, file_webhook_data(NEW), now());
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND NEW.is_public AND NOT OLD.is_public THEN
+        INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+        VALUES ('file.shared', NEW.workspace_id, NEW.uploader_id,
+            file_webhook_data(NEW) || jsonb_build_object('shared_with', 'workspace'), now());
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_record_file_webhook_event ON files;
+CREATE TRIGGER trigger_record_file_webhook_event
+    AFTER INSERT OR UPDATE OF upload_completed, is_public OR DELETE ON files
+    FOR EACH ROW
+    EXECUTE FUNCTION record_file_webhook_event();
+
+DROP INDEX IF EXISTS idx_files_deleted_at;
+
+ALTER TABLE files
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
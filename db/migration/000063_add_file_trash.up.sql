@@ -0,0 +1,50 @@
+-- Deleted files go to the trash, where they can be restored until they're
+-- purged along with their content
+ALTER TABLE files
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by BIGINT REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_files_deleted_at ON files(workspace_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Moving a file to the trash is a deletion for webhooks; purging it later is
+-- the permanent one
+CREATE OR REPLACE FUNCTION record_file_webhook_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Uploads that never completed are cleaned up without an event
+    IF TG_OP = 'DELETE' THEN
+        IF OLD.upload_completed THEN
+            INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+            VALUES ('file.deleted', OLD.workspace_id, COALESCE(OLD.deleted_by, OLD.uploader_id),
+                file_webhook_data(OLD) || jsonb_build_object('permanent', true), now());
+        END IF;
+        RETURN NULL;
+    END IF;
+
+    -- An upload completes once its content is stored
+    IF NEW.upload_completed AND (TG_OP = 'INSERT' OR NOT OLD.upload_completed) THEN
+        INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+        VALUES ('file.uploaded', NEW.workspace_id, NEW.uploader_id, file_webhook_data(NEW), now());
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL THEN
+        INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+        VALUES ('file.deleted', NEW.workspace_id, COALESCE(NEW.deleted_by, NEW.uploader_id),
+            file_webhook_data(NEW) || jsonb_build_object('permanent', false), now());
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND NEW.is_public AND NOT OLD.is_public THEN
+        INSERT INTO event_outbox (event_type, workspace_id, user_id, payload, published_at)
+        VALUES ('file.shared', NEW.workspace_id, NEW.uploader_id,
+            file_webhook_data(NEW) || jsonb_build_object('shared_with', 'workspace'), now());
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_record_file_webhook_event ON files;
+CREATE TRIGGER trigger_record_file_webhook_event
+    AFTER INSERT OR UPDATE OF upload_completed, deleted_at, is_public OR DELETE ON files
+    FOR EACH ROW
+    EXECUTE FUNCTION record_file_webhook_event();
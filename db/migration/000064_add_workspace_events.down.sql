@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trigger_record_file_event ON files;
+DROP FUNCTION IF EXISTS record_file_event();
+DROP TRIGGER IF EXISTS trigger_record_member_event ON users;
+DROP FUNCTION IF EXISTS record_member_event();
+DROP TRIGGER IF EXISTS trigger_record_message_event ON messages;
+DROP FUNCTION IF EXISTS record_message_event();
+DROP TABLE IF EXISTS workspace_events;
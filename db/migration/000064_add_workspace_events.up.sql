@@ -0,0 +1,97 @@
+-- A stream of normalized events per workspace for data pipelines to read with
+-- a cursor. Triggers write the events in the same transaction as the change,
+-- whichever code path made it.
+CREATE TABLE workspace_events (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    actor_id BIGINT,
+    channel_id BIGINT,
+    data JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    -- Set once the event has been sent to the configured publisher
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_workspace_events_workspace_id ON workspace_events(workspace_id, id);
+CREATE INDEX idx_workspace_events_unpublished ON workspace_events(id) WHERE published_at IS NULL;
+CREATE INDEX idx_workspace_events_created_at ON workspace_events(created_at);
+
+CREATE OR REPLACE FUNCTION record_message_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO workspace_events (workspace_id, event_type, actor_id, channel_id, data)
+        VALUES (NEW.workspace_id, 'message.created', NEW.sender_id, NEW.channel_id, jsonb_build_object(
+            'message_id', NEW.id,
+            'sender_id', NEW.sender_id,
+            'receiver_id', NEW.receiver_id,
+            'thread_id', NEW.thread_id,
+            'message_type', NEW.message_type,
+            'content_type', NEW.content_type,
+            'content', NEW.content,
+            'created_at', NEW.created_at
+        ));
+    ELSIF NEW.edited_at IS DISTINCT FROM OLD.edited_at AND NEW.edited_at IS NOT NULL THEN
+        INSERT INTO workspace_events (workspace_id, event_type, actor_id, channel_id, data)
+        VALUES (NEW.workspace_id, 'message.edited', NEW.sender_id, NEW.channel_id, jsonb_build_object(
+            'message_id', NEW.id,
+            'sender_id', NEW.sender_id,
+            'receiver_id', NEW.receiver_id,
+            'thread_id', NEW.thread_id,
+            'content', NEW.content,
+            'edited_at', NEW.edited_at
+        ));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_record_message_event
+    AFTER INSERT OR UPDATE OF edited_at ON messages
+    FOR EACH ROW
+    EXECUTE FUNCTION record_message_event();
+
+-- A user joins a workspace when their workspace is set
+CREATE OR REPLACE FUNCTION record_member_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.workspace_id IS NOT NULL AND (TG_OP = 'INSERT' OR NEW.workspace_id IS DISTINCT FROM OLD.workspace_id) THEN
+        INSERT INTO workspace_events (workspace_id, event_type, actor_id, data)
+        VALUES (NEW.workspace_id, 'member.joined', NEW.id, jsonb_build_object(
+            'user_id', NEW.id,
+            'role', NEW.role
+        ));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_record_member_event
+    AFTER INSERT OR UPDATE OF workspace_id ON users
+    FOR EACH ROW
+    EXECUTE FUNCTION record_member_event();
+
+-- A file is uploaded once its upload completes
+CREATE OR REPLACE FUNCTION record_file_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.upload_completed AND (TG_OP = 'INSERT' OR NOT OLD.upload_completed) THEN
+        INSERT INTO workspace_events (workspace_id, event_type, actor_id, data)
+        VALUES (NEW.workspace_id, 'file.uploaded', NEW.uploader_id, jsonb_build_object(
+            'file_id', NEW.id,
+            'uploader_id', NEW.uploader_id,
+            'filename', NEW.original_filename,
+            'mime_type', NEW.mime_type,
+            'file_size', NEW.file_size,
+            'is_public', NEW.is_public
+        ));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_record_file_event
+    AFTER INSERT OR UPDATE OF upload_completed ON files
+    FOR EACH ROW
+    EXECUTE FUNCTION record_file_event();
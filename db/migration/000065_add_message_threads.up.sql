@@ -0,0 +1,58 @@
+-- A summary of each thread kept on its parent message, so listings can show
+-- how many replies a thread has and who replied without reading the thread.
+-- Replies update it as they are posted and deleted.
+CREATE TABLE message_threads (
+    thread_id BIGINT PRIMARY KEY,
+    reply_count INTEGER NOT NULL DEFAULT 0,
+    last_reply_at TIMESTAMPTZ,
+    -- The most recent repliers, most recent first
+    participant_ids BIGINT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+INSERT INTO message_threads (thread_id, reply_count, last_reply_at, participant_ids)
+SELECT
+    r.thread_id,
+    COUNT(*),
+    MAX(r.created_at),
+    (
+        SELECT COALESCE(array_agg(p.sender_id ORDER BY p.last_reply_at DESC), '{}')
+        FROM (
+            SELECT sender_id, MAX(created_at) AS last_reply_at
+            FROM messages
+            WHERE thread_id = r.thread_id AND deleted_at IS NULL
+            GROUP BY sender_id
+            ORDER BY last_reply_at DESC
+            LIMIT 5
+        ) p
+    )
+FROM messages r
+WHERE r.thread_id IS NOT NULL AND r.deleted_at IS NULL
+GROUP BY r.thread_id;
+
+-- Parent messages take their thread summary with them
+CREATE OR REPLACE FUNCTION delete_message_dependents(message_ids BIGINT[])
+RETURNS VOID AS $$
+BEGIN
+    IF cardinality(message_ids) = 0 THEN
+        RETURN;
+    END IF;
+
+    DELETE FROM messages WHERE thread_id = ANY(message_ids);
+    DELETE FROM message_threads WHERE thread_id = ANY(message_ids);
+    DELETE FROM message_files WHERE message_id = ANY(message_ids);
+    DELETE FROM dnd_overrides WHERE message_id = ANY(message_ids);
+    DELETE FROM message_translations WHERE message_id = ANY(message_ids);
+    DELETE FROM moderation_queue WHERE message_id = ANY(message_ids);
+    DELETE FROM message_reactions WHERE message_id = ANY(message_ids);
+    DELETE FROM message_mentions WHERE message_id = ANY(message_ids);
+    DELETE FROM message_drafts WHERE thread_id = ANY(message_ids);
+    DELETE FROM thread_read_states WHERE thread_id = ANY(message_ids);
+    DELETE FROM pinned_messages WHERE message_id = ANY(message_ids);
+
+    UPDATE channel_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE direct_message_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE thread_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE moderation_audit_log SET message_id = NULL WHERE message_id = ANY(message_ids);
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trigger_seed_channel_notification_preference ON channel_members;
+DROP FUNCTION IF EXISTS seed_channel_notification_preference();
+DROP TABLE IF EXISTS channel_notification_defaults;
@@ -0,0 +1,34 @@
+-- The notification preference an admin picked for a channel, such as every
+-- message in #announcements. Members get it as their channel preference when
+-- they join, and admins can apply it to every member again later.
+CREATE TABLE channel_notification_defaults (
+    channel_id BIGINT PRIMARY KEY REFERENCES channels(id) ON DELETE CASCADE,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    level VARCHAR(20) NOT NULL CHECK (level IN ('all', 'mentions', 'nothing')),
+    email_enabled BOOLEAN NOT NULL DEFAULT true,
+    push_enabled BOOLEAN NOT NULL DEFAULT true,
+    updated_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_channel_notification_defaults_workspace ON channel_notification_defaults (workspace_id);
+
+-- Seed a joining member's channel preference from the channel's default. A
+-- preference they already have, from an earlier membership, is kept.
+CREATE OR REPLACE FUNCTION seed_channel_notification_preference()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO notification_preferences (user_id, workspace_id, channel_id, level, email_enabled, push_enabled)
+    SELECT NEW.user_id, d.workspace_id, d.channel_id, d.level, d.email_enabled, d.push_enabled
+    FROM channel_notification_defaults d
+    WHERE d.channel_id = NEW.channel_id
+    ON CONFLICT (user_id, channel_id) WHERE channel_id IS NOT NULL DO NOTHING;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_seed_channel_notification_preference
+    AFTER INSERT ON channel_members
+    FOR EACH ROW
+    EXECUTE FUNCTION seed_channel_notification_preference();
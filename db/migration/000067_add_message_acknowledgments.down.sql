@@ -0,0 +1,29 @@
+-- Restores the dependents cleanup from before acknowledgments
+CREATE OR REPLACE FUNCTION delete_message_dependents(message_ids BIGINT[])
+RETURNS VOID AS $$
+BEGIN
+    IF cardinality(message_ids) = 0 THEN
+        RETURN;
+    END IF;
+
+    DELETE FROM messages WHERE thread_id = ANY(message_ids);
+    DELETE FROM message_threads WHERE thread_id = ANY(message_ids);
+    DELETE FROM message_files WHERE message_id = ANY(message_ids);
+    DELETE FROM dnd_overrides WHERE message_id = ANY(message_ids);
+    DELETE FROM message_translations WHERE message_id = ANY(message_ids);
+    DELETE FROM moderation_queue WHERE message_id = ANY(message_ids);
+    DELETE FROM message_reactions WHERE message_id = ANY(message_ids);
+    DELETE FROM message_mentions WHERE message_id = ANY(message_ids);
+    DELETE FROM message_drafts WHERE thread_id = ANY(message_ids);
+    DELETE FROM thread_read_states WHERE thread_id = ANY(message_ids);
+    DELETE FROM pinned_messages WHERE message_id = ANY(message_ids);
+
+    UPDATE channel_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE direct_message_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE thread_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE moderation_audit_log SET message_id = NULL WHERE message_id = ANY(message_ids);
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS message_acknowledgments;
+DROP TABLE IF EXISTS message_acknowledgment_requests;
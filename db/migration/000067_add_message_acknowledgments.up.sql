@@ -0,0 +1,50 @@
+-- Channel messages that their readers are asked to acknowledge, such as an
+-- announcement everyone must read. Members who haven't acknowledged one by
+-- remind_at are reminded once.
+CREATE TABLE message_acknowledgment_requests (
+    message_id BIGINT PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    channel_id BIGINT NOT NULL REFERENCES channels(id) ON DELETE CASCADE,
+    author_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    remind_at TIMESTAMPTZ NOT NULL,
+    reminded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX idx_message_acknowledgment_requests_due ON message_acknowledgment_requests (remind_at) WHERE reminded_at IS NULL;
+
+CREATE TABLE message_acknowledgments (
+    message_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    acknowledged_at TIMESTAMPTZ NOT NULL DEFAULT (now()),
+    PRIMARY KEY (message_id, user_id)
+);
+
+-- Messages take their acknowledgments with them
+CREATE OR REPLACE FUNCTION delete_message_dependents(message_ids BIGINT[])
+RETURNS VOID AS $$
+BEGIN
+    IF cardinality(message_ids) = 0 THEN
+        RETURN;
+    END IF;
+
+    DELETE FROM messages WHERE thread_id = ANY(message_ids);
+    DELETE FROM message_threads WHERE thread_id = ANY(message_ids);
+    DELETE FROM message_acknowledgment_requests WHERE message_id = ANY(message_ids);
+    DELETE FROM message_acknowledgments WHERE message_id = ANY(message_ids);
+    DELETE FROM message_files WHERE message_id = ANY(message_ids);
+    DELETE FROM dnd_overrides WHERE message_id = ANY(message_ids);
+    DELETE FROM message_translations WHERE message_id = ANY(message_ids);
+    DELETE FROM moderation_queue WHERE message_id = ANY(message_ids);
+    DELETE FROM message_reactions WHERE message_id = ANY(message_ids);
+    DELETE FROM message_mentions WHERE message_id = ANY(message_ids);
+    DELETE FROM message_drafts WHERE thread_id = ANY(message_ids);
+    DELETE FROM thread_read_states WHERE thread_id = ANY(message_ids);
+    DELETE FROM pinned_messages WHERE message_id = ANY(message_ids);
+
+    UPDATE channel_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE direct_message_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE thread_read_states SET last_read_message_id = NULL WHERE last_read_message_id = ANY(message_ids);
+    UPDATE moderation_audit_log SET message_id = NULL WHERE message_id = ANY(message_ids);
+END;
+$$ LANGUAGE plpgsql;
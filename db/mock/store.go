@@ -37,6 +37,21 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitationTx mocks base method.
+func (m *MockStore) AcceptInvitationTx(arg0 context.Context, arg1 db.AcceptInvitationTxParams) (db.AcceptInvitationTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AcceptInvitationTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptInvitationTx indicates an expected call of AcceptInvitationTx.
+func (mr *MockStoreMockRecorder) AcceptInvitationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitationTx", reflect.TypeOf((*MockStore)(nil).AcceptInvitationTx), arg0, arg1)
+}
+
 // AcceptWorkspaceInvitation mocks base method.
 func (m *MockStore) AcceptWorkspaceInvitation(arg0 context.Context, arg1 db.AcceptWorkspaceInvitationParams) (db.WorkspaceInvitation, error) {
 	m.ctrl.T.Helper()
@@ -52,6 +67,21 @@ func (mr *MockStoreMockRecorder) AcceptWorkspaceInvitation(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).AcceptWorkspaceInvitation), arg0, arg1)
 }
 
+// AcknowledgeMessage mocks base method.
+func (m *MockStore) AcknowledgeMessage(arg0 context.Context, arg1 db.AcknowledgeMessageParams) (db.MessageAcknowledgment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeMessage", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageAcknowledgment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcknowledgeMessage indicates an expected call of AcknowledgeMessage.
+func (mr *MockStoreMockRecorder) AcknowledgeMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeMessage", reflect.TypeOf((*MockStore)(nil).AcknowledgeMessage), arg0, arg1)
+}
+
 // AddChannelMember mocks base method.
 func (m *MockStore) AddChannelMember(arg0 context.Context, arg1 db.AddChannelMemberParams) (db.ChannelMember, error) {
 	m.ctrl.T.Helper()
@@ -67,6 +97,21 @@ func (mr *MockStoreMockRecorder) AddChannelMember(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChannelMember", reflect.TypeOf((*MockStore)(nil).AddChannelMember), arg0, arg1)
 }
 
+// AddReaction mocks base method.
+func (m *MockStore) AddReaction(arg0 context.Context, arg1 db.AddReactionParams) (db.MessageReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockStoreMockRecorder) AddReaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*MockStore)(nil).AddReaction), arg0, arg1)
+}
+
 // AddUserToWorkspace mocks base method.
 func (m *MockStore) AddUserToWorkspace(arg0 context.Context, arg1 db.AddUserToWorkspaceParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -82,6 +127,81 @@ func (mr *MockStoreMockRecorder) AddUserToWorkspace(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToWorkspace", reflect.TypeOf((*MockStore)(nil).AddUserToWorkspace), arg0, arg1)
 }
 
+// ApplyChannelNotificationDefault mocks base method.
+func (m *MockStore) ApplyChannelNotificationDefault(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyChannelNotificationDefault", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyChannelNotificationDefault indicates an expected call of ApplyChannelNotificationDefault.
+func (mr *MockStoreMockRecorder) ApplyChannelNotificationDefault(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyChannelNotificationDefault", reflect.TypeOf((*MockStore)(nil).ApplyChannelNotificationDefault), arg0, arg1)
+}
+
+// ApproveWorkspaceJoinRequestTx mocks base method.
+func (m *MockStore) ApproveWorkspaceJoinRequestTx(arg0 context.Context, arg1 db.ApproveWorkspaceJoinRequestTxParams) (db.ApproveWorkspaceJoinRequestTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveWorkspaceJoinRequestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ApproveWorkspaceJoinRequestTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveWorkspaceJoinRequestTx indicates an expected call of ApproveWorkspaceJoinRequestTx.
+func (mr *MockStoreMockRecorder) ApproveWorkspaceJoinRequestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveWorkspaceJoinRequestTx", reflect.TypeOf((*MockStore)(nil).ApproveWorkspaceJoinRequestTx), arg0, arg1)
+}
+
+// ArchiveMessagesTx mocks base method.
+func (m *MockStore) ArchiveMessagesTx(arg0 context.Context, arg1 db.ArchiveMessagesTxParams) (db.MessageArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveMessagesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveMessagesTx indicates an expected call of ArchiveMessagesTx.
+func (mr *MockStoreMockRecorder) ArchiveMessagesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveMessagesTx", reflect.TypeOf((*MockStore)(nil).ArchiveMessagesTx), arg0, arg1)
+}
+
+// CancelOrganizationDeletion mocks base method.
+func (m *MockStore) CancelOrganizationDeletion(arg0 context.Context, arg1 db.CancelOrganizationDeletionParams) (db.OrganizationDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrganizationDeletion", arg0, arg1)
+	ret0, _ := ret[0].(db.OrganizationDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrganizationDeletion indicates an expected call of CancelOrganizationDeletion.
+func (mr *MockStoreMockRecorder) CancelOrganizationDeletion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrganizationDeletion", reflect.TypeOf((*MockStore)(nil).CancelOrganizationDeletion), arg0, arg1)
+}
+
+// ChannelHasActiveLegalHold mocks base method.
+func (m *MockStore) ChannelHasActiveLegalHold(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChannelHasActiveLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChannelHasActiveLegalHold indicates an expected call of ChannelHasActiveLegalHold.
+func (mr *MockStoreMockRecorder) ChannelHasActiveLegalHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChannelHasActiveLegalHold", reflect.TypeOf((*MockStore)(nil).ChannelHasActiveLegalHold), arg0, arg1)
+}
+
 // CheckChannelMembership mocks base method.
 func (m *MockStore) CheckChannelMembership(arg0 context.Context, arg1 db.CheckChannelMembershipParams) (string, error) {
 	m.ctrl.T.Helper()
@@ -157,1157 +277,6025 @@ func (mr *MockStoreMockRecorder) CheckUserWorkspaceRole(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserWorkspaceRole", reflect.TypeOf((*MockStore)(nil).CheckUserWorkspaceRole), arg0, arg1)
 }
 
-// CleanupIncompleteUploads mocks base method.
-func (m *MockStore) CleanupIncompleteUploads(arg0 context.Context) error {
+// ClaimDueAcknowledgmentReminders mocks base method.
+func (m *MockStore) ClaimDueAcknowledgmentReminders(arg0 context.Context, arg1 int32) ([]db.MessageAcknowledgmentRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CleanupIncompleteUploads", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ClaimDueAcknowledgmentReminders", arg0, arg1)
+	ret0, _ := ret[0].([]db.MessageAcknowledgmentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CleanupIncompleteUploads indicates an expected call of CleanupIncompleteUploads.
-func (mr *MockStoreMockRecorder) CleanupIncompleteUploads(arg0 interface{}) *gomock.Call {
+// ClaimDueAcknowledgmentReminders indicates an expected call of ClaimDueAcknowledgmentReminders.
+func (mr *MockStoreMockRecorder) ClaimDueAcknowledgmentReminders(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupIncompleteUploads", reflect.TypeOf((*MockStore)(nil).CleanupIncompleteUploads), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueAcknowledgmentReminders", reflect.TypeOf((*MockStore)(nil).ClaimDueAcknowledgmentReminders), arg0, arg1)
 }
 
-// CreateChannel mocks base method.
-func (m *MockStore) CreateChannel(arg0 context.Context, arg1 db.CreateChannelParams) (db.Channel, error) {
+// ClaimEmailDeliveries mocks base method.
+func (m *MockStore) ClaimEmailDeliveries(arg0 context.Context, arg1 db.ClaimEmailDeliveriesParams) ([]db.EmailDelivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateChannel", arg0, arg1)
-	ret0, _ := ret[0].(db.Channel)
+	ret := m.ctrl.Call(m, "ClaimEmailDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]db.EmailDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateChannel indicates an expected call of CreateChannel.
-func (mr *MockStoreMockRecorder) CreateChannel(arg0, arg1 interface{}) *gomock.Call {
+// ClaimEmailDeliveries indicates an expected call of ClaimEmailDeliveries.
+func (mr *MockStoreMockRecorder) ClaimEmailDeliveries(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockStore)(nil).CreateChannel), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEmailDeliveries", reflect.TypeOf((*MockStore)(nil).ClaimEmailDeliveries), arg0, arg1)
 }
 
-// CreateChannelMessage mocks base method.
-func (m *MockStore) CreateChannelMessage(arg0 context.Context, arg1 db.CreateChannelMessageParams) (db.Message, error) {
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockStore) ClaimWebhookDeliveries(arg0 context.Context, arg1 db.ClaimWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateChannelMessage", arg0, arg1)
-	ret0, _ := ret[0].(db.Message)
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]db.WebhookDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateChannelMessage indicates an expected call of CreateChannelMessage.
-func (mr *MockStoreMockRecorder) CreateChannelMessage(arg0, arg1 interface{}) *gomock.Call {
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockStoreMockRecorder) ClaimWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannelMessage", reflect.TypeOf((*MockStore)(nil).CreateChannelMessage), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).ClaimWebhookDeliveries), arg0, arg1)
 }
 
-// CreateDirectMessage mocks base method.
-func (m *MockStore) CreateDirectMessage(arg0 context.Context, arg1 db.CreateDirectMessageParams) (db.Message, error) {
+// CleanupIncompleteUploads mocks base method.
+func (m *MockStore) CleanupIncompleteUploads(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDirectMessage", arg0, arg1)
-	ret0, _ := ret[0].(db.Message)
+	ret := m.ctrl.Call(m, "CleanupIncompleteUploads", arg0)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateDirectMessage indicates an expected call of CreateDirectMessage.
-func (mr *MockStoreMockRecorder) CreateDirectMessage(arg0, arg1 interface{}) *gomock.Call {
+// CleanupIncompleteUploads indicates an expected call of CleanupIncompleteUploads.
+func (mr *MockStoreMockRecorder) CleanupIncompleteUploads(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirectMessage", reflect.TypeOf((*MockStore)(nil).CreateDirectMessage), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanupIncompleteUploads", reflect.TypeOf((*MockStore)(nil).CleanupIncompleteUploads), arg0)
 }
 
-// CreateFile mocks base method.
-func (m *MockStore) CreateFile(arg0 context.Context, arg1 db.CreateFileParams) (db.File, error) {
+// ClearExpiredCustomStatuses mocks base method.
+func (m *MockStore) ClearExpiredCustomStatuses(arg0 context.Context) ([]db.UserStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFile", arg0, arg1)
-	ret0, _ := ret[0].(db.File)
+	ret := m.ctrl.Call(m, "ClearExpiredCustomStatuses", arg0)
+	ret0, _ := ret[0].([]db.UserStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateFile indicates an expected call of CreateFile.
-func (mr *MockStoreMockRecorder) CreateFile(arg0, arg1 interface{}) *gomock.Call {
+// ClearExpiredCustomStatuses indicates an expected call of ClearExpiredCustomStatuses.
+func (mr *MockStoreMockRecorder) ClearExpiredCustomStatuses(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFile", reflect.TypeOf((*MockStore)(nil).CreateFile), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearExpiredCustomStatuses", reflect.TypeOf((*MockStore)(nil).ClearExpiredCustomStatuses), arg0)
 }
 
-// CreateFileShare mocks base method.
-func (m *MockStore) CreateFileShare(arg0 context.Context, arg1 db.CreateFileShareParams) (db.FileShare, error) {
+// ClearExpiredDoNotDisturb mocks base method.
+func (m *MockStore) ClearExpiredDoNotDisturb(arg0 context.Context) ([]db.UserStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFileShare", arg0, arg1)
-	ret0, _ := ret[0].(db.FileShare)
+	ret := m.ctrl.Call(m, "ClearExpiredDoNotDisturb", arg0)
+	ret0, _ := ret[0].([]db.UserStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateFileShare indicates an expected call of CreateFileShare.
-func (mr *MockStoreMockRecorder) CreateFileShare(arg0, arg1 interface{}) *gomock.Call {
+// ClearExpiredDoNotDisturb indicates an expected call of ClearExpiredDoNotDisturb.
+func (mr *MockStoreMockRecorder) ClearExpiredDoNotDisturb(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFileShare", reflect.TypeOf((*MockStore)(nil).CreateFileShare), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearExpiredDoNotDisturb", reflect.TypeOf((*MockStore)(nil).ClearExpiredDoNotDisturb), arg0)
 }
 
-// CreateMessageFile mocks base method.
-func (m *MockStore) CreateMessageFile(arg0 context.Context, arg1 db.CreateMessageFileParams) (db.MessageFile, error) {
+// CloseExternalDMRequest mocks base method.
+func (m *MockStore) CloseExternalDMRequest(arg0 context.Context, arg1 int64) (db.ExternalDmRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMessageFile", arg0, arg1)
-	ret0, _ := ret[0].(db.MessageFile)
+	ret := m.ctrl.Call(m, "CloseExternalDMRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.ExternalDmRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateMessageFile indicates an expected call of CreateMessageFile.
-func (mr *MockStoreMockRecorder) CreateMessageFile(arg0, arg1 interface{}) *gomock.Call {
+// CloseExternalDMRequest indicates an expected call of CloseExternalDMRequest.
+func (mr *MockStoreMockRecorder) CloseExternalDMRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageFile", reflect.TypeOf((*MockStore)(nil).CreateMessageFile), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseExternalDMRequest", reflect.TypeOf((*MockStore)(nil).CloseExternalDMRequest), arg0, arg1)
 }
 
-// CreateOrganization mocks base method.
-func (m *MockStore) CreateOrganization(arg0 context.Context, arg1 string) (db.Organization, error) {
+// CompleteOnboardingStep mocks base method.
+func (m *MockStore) CompleteOnboardingStep(arg0 context.Context, arg1 db.CompleteOnboardingStepParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1)
-	ret0, _ := ret[0].(db.Organization)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CompleteOnboardingStep", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateOrganization indicates an expected call of CreateOrganization.
-func (mr *MockStoreMockRecorder) CreateOrganization(arg0, arg1 interface{}) *gomock.Call {
+// CompleteOnboardingStep indicates an expected call of CompleteOnboardingStep.
+func (mr *MockStoreMockRecorder) CompleteOnboardingStep(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockStore)(nil).CreateOrganization), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOnboardingStep", reflect.TypeOf((*MockStore)(nil).CompleteOnboardingStep), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// CompleteOrganizationDeletion mocks base method.
+func (m *MockStore) CompleteOrganizationDeletion(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CompleteOrganizationDeletion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// CompleteOrganizationDeletion indicates an expected call of CompleteOrganizationDeletion.
+func (mr *MockStoreMockRecorder) CompleteOrganizationDeletion(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOrganizationDeletion", reflect.TypeOf((*MockStore)(nil).CompleteOrganizationDeletion), arg0, arg1)
 }
 
-// CreateWorkspace mocks base method.
-func (m *MockStore) CreateWorkspace(arg0 context.Context, arg1 db.CreateWorkspaceParams) (db.Workspace, error) {
+// CompleteWorkspaceTeardown mocks base method.
+func (m *MockStore) CompleteWorkspaceTeardown(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateWorkspace", arg0, arg1)
-	ret0, _ := ret[0].(db.Workspace)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "CompleteWorkspaceTeardown", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateWorkspace indicates an expected call of CreateWorkspace.
-func (mr *MockStoreMockRecorder) CreateWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// CompleteWorkspaceTeardown indicates an expected call of CompleteWorkspaceTeardown.
+func (mr *MockStoreMockRecorder) CompleteWorkspaceTeardown(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockStore)(nil).CreateWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteWorkspaceTeardown", reflect.TypeOf((*MockStore)(nil).CompleteWorkspaceTeardown), arg0, arg1)
 }
 
-// CreateWorkspaceInvitation mocks base method.
-func (m *MockStore) CreateWorkspaceInvitation(arg0 context.Context, arg1 db.CreateWorkspaceInvitationParams) (db.WorkspaceInvitation, error) {
+// CountChannelMessages mocks base method.
+func (m *MockStore) CountChannelMessages(arg0 context.Context, arg1 db.CountChannelMessagesParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateWorkspaceInvitation", arg0, arg1)
-	ret0, _ := ret[0].(db.WorkspaceInvitation)
+	ret := m.ctrl.Call(m, "CountChannelMessages", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateWorkspaceInvitation indicates an expected call of CreateWorkspaceInvitation.
-func (mr *MockStoreMockRecorder) CreateWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+// CountChannelMessages indicates an expected call of CountChannelMessages.
+func (mr *MockStoreMockRecorder) CountChannelMessages(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceInvitation), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChannelMessages", reflect.TypeOf((*MockStore)(nil).CountChannelMessages), arg0, arg1)
 }
 
-// DeclineWorkspaceInvitation mocks base method.
-func (m *MockStore) DeclineWorkspaceInvitation(arg0 context.Context, arg1 string) (db.WorkspaceInvitation, error) {
+// CountChannelPins mocks base method.
+func (m *MockStore) CountChannelPins(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeclineWorkspaceInvitation", arg0, arg1)
-	ret0, _ := ret[0].(db.WorkspaceInvitation)
+	ret := m.ctrl.Call(m, "CountChannelPins", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeclineWorkspaceInvitation indicates an expected call of DeclineWorkspaceInvitation.
-func (mr *MockStoreMockRecorder) DeclineWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+// CountChannelPins indicates an expected call of CountChannelPins.
+func (mr *MockStoreMockRecorder) CountChannelPins(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).DeclineWorkspaceInvitation), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChannelPins", reflect.TypeOf((*MockStore)(nil).CountChannelPins), arg0, arg1)
 }
 
-// DeleteChannel mocks base method.
-func (m *MockStore) DeleteChannel(arg0 context.Context, arg1 int64) error {
+// CountDirectMessagesBetweenUsers mocks base method.
+func (m *MockStore) CountDirectMessagesBetweenUsers(arg0 context.Context, arg1 db.CountDirectMessagesBetweenUsersParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteChannel", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountDirectMessagesBetweenUsers", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteChannel indicates an expected call of DeleteChannel.
-func (mr *MockStoreMockRecorder) DeleteChannel(arg0, arg1 interface{}) *gomock.Call {
+// CountDirectMessagesBetweenUsers indicates an expected call of CountDirectMessagesBetweenUsers.
+func (mr *MockStoreMockRecorder) CountDirectMessagesBetweenUsers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannel", reflect.TypeOf((*MockStore)(nil).DeleteChannel), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDirectMessagesBetweenUsers", reflect.TypeOf((*MockStore)(nil).CountDirectMessagesBetweenUsers), arg0, arg1)
 }
 
-// DeleteFile mocks base method.
-func (m *MockStore) DeleteFile(arg0 context.Context, arg1 db.DeleteFileParams) error {
+// CountOrganizationOwners mocks base method.
+func (m *MockStore) CountOrganizationOwners(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFile", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountOrganizationOwners", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteFile indicates an expected call of DeleteFile.
-func (mr *MockStoreMockRecorder) DeleteFile(arg0, arg1 interface{}) *gomock.Call {
+// CountOrganizationOwners indicates an expected call of CountOrganizationOwners.
+func (mr *MockStoreMockRecorder) CountOrganizationOwners(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockStore)(nil).DeleteFile), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrganizationOwners", reflect.TypeOf((*MockStore)(nil).CountOrganizationOwners), arg0, arg1)
 }
 
-// DeleteMessageFile mocks base method.
-func (m *MockStore) DeleteMessageFile(arg0 context.Context, arg1 db.DeleteMessageFileParams) error {
+// CountOrganizationWorkspaces mocks base method.
+func (m *MockStore) CountOrganizationWorkspaces(arg0 context.Context, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMessageFile", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountOrganizationWorkspaces", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteMessageFile indicates an expected call of DeleteMessageFile.
-func (mr *MockStoreMockRecorder) DeleteMessageFile(arg0, arg1 interface{}) *gomock.Call {
+// CountOrganizationWorkspaces indicates an expected call of CountOrganizationWorkspaces.
+func (mr *MockStoreMockRecorder) CountOrganizationWorkspaces(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageFile", reflect.TypeOf((*MockStore)(nil).DeleteMessageFile), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrganizationWorkspaces", reflect.TypeOf((*MockStore)(nil).CountOrganizationWorkspaces), arg0, arg1)
 }
 
-// DeleteOrganization mocks base method.
-func (m *MockStore) DeleteOrganization(arg0 context.Context, arg1 int64) error {
+// CountUnreadMentions mocks base method.
+func (m *MockStore) CountUnreadMentions(arg0 context.Context, arg1 db.CountUnreadMentionsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteOrganization", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CountUnreadMentions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteOrganization indicates an expected call of DeleteOrganization.
-func (mr *MockStoreMockRecorder) DeleteOrganization(arg0, arg1 interface{}) *gomock.Call {
+// CountUnreadMentions indicates an expected call of CountUnreadMentions.
+func (mr *MockStoreMockRecorder) CountUnreadMentions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockStore)(nil).DeleteOrganization), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadMentions", reflect.TypeOf((*MockStore)(nil).CountUnreadMentions), arg0, arg1)
 }
 
-// DeleteUser mocks base method.
-func (m *MockStore) DeleteUser(arg0 context.Context, arg1 int64) error {
+// CreateAbuseReport mocks base method.
+func (m *MockStore) CreateAbuseReport(arg0 context.Context, arg1 db.CreateAbuseReportParams) (db.AbuseReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateAbuseReport", arg0, arg1)
+	ret0, _ := ret[0].(db.AbuseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+// CreateAbuseReport indicates an expected call of CreateAbuseReport.
+func (mr *MockStoreMockRecorder) CreateAbuseReport(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAbuseReport", reflect.TypeOf((*MockStore)(nil).CreateAbuseReport), arg0, arg1)
 }
 
-// DeleteWorkspace mocks base method.
-func (m *MockStore) DeleteWorkspace(arg0 context.Context, arg1 int64) error {
+// CreateAcknowledgmentRequest mocks base method.
+func (m *MockStore) CreateAcknowledgmentRequest(arg0 context.Context, arg1 db.CreateAcknowledgmentRequestParams) (db.MessageAcknowledgmentRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteWorkspace", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateAcknowledgmentRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageAcknowledgmentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteWorkspace indicates an expected call of DeleteWorkspace.
-func (mr *MockStoreMockRecorder) DeleteWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// CreateAcknowledgmentRequest indicates an expected call of CreateAcknowledgmentRequest.
+func (mr *MockStoreMockRecorder) CreateAcknowledgmentRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspace", reflect.TypeOf((*MockStore)(nil).DeleteWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAcknowledgmentRequest", reflect.TypeOf((*MockStore)(nil).CreateAcknowledgmentRequest), arg0, arg1)
 }
 
-// DeleteWorkspaceInvitation mocks base method.
-func (m *MockStore) DeleteWorkspaceInvitation(arg0 context.Context, arg1 int64) error {
+// CreateCalendarBusyBlock mocks base method.
+func (m *MockStore) CreateCalendarBusyBlock(arg0 context.Context, arg1 db.CreateCalendarBusyBlockParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteWorkspaceInvitation", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateCalendarBusyBlock", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteWorkspaceInvitation indicates an expected call of DeleteWorkspaceInvitation.
-func (mr *MockStoreMockRecorder) DeleteWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+// CreateCalendarBusyBlock indicates an expected call of CreateCalendarBusyBlock.
+func (mr *MockStoreMockRecorder) CreateCalendarBusyBlock(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceInvitation), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCalendarBusyBlock", reflect.TypeOf((*MockStore)(nil).CreateCalendarBusyBlock), arg0, arg1)
 }
 
-// ExpireWorkspaceInvitation mocks base method.
-func (m *MockStore) ExpireWorkspaceInvitation(arg0 context.Context, arg1 int64) error {
+// CreateCanvas mocks base method.
+func (m *MockStore) CreateCanvas(arg0 context.Context, arg1 db.CreateCanvasParams) (db.Canvas, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExpireWorkspaceInvitation", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateCanvas", arg0, arg1)
+	ret0, _ := ret[0].(db.Canvas)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// ExpireWorkspaceInvitation indicates an expected call of ExpireWorkspaceInvitation.
-func (mr *MockStoreMockRecorder) ExpireWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+// CreateCanvas indicates an expected call of CreateCanvas.
+func (mr *MockStoreMockRecorder) CreateCanvas(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).ExpireWorkspaceInvitation), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCanvas", reflect.TypeOf((*MockStore)(nil).CreateCanvas), arg0, arg1)
 }
 
-// GetChannel mocks base method.
-func (m *MockStore) GetChannel(arg0 context.Context, arg1 int64) (db.Channel, error) {
+// CreateCanvasRevision mocks base method.
+func (m *MockStore) CreateCanvasRevision(arg0 context.Context, arg1 db.CreateCanvasRevisionParams) (db.CanvasRevision, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChannel", arg0, arg1)
-	ret0, _ := ret[0].(db.Channel)
+	ret := m.ctrl.Call(m, "CreateCanvasRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.CanvasRevision)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetChannel indicates an expected call of GetChannel.
-func (mr *MockStoreMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call {
+// CreateCanvasRevision indicates an expected call of CreateCanvasRevision.
+func (mr *MockStoreMockRecorder) CreateCanvasRevision(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockStore)(nil).GetChannel), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCanvasRevision", reflect.TypeOf((*MockStore)(nil).CreateCanvasRevision), arg0, arg1)
 }
 
-// GetChannelByID mocks base method.
-func (m *MockStore) GetChannelByID(arg0 context.Context, arg1 int64) (db.Channel, error) {
+// CreateCanvasTx mocks base method.
+func (m *MockStore) CreateCanvasTx(arg0 context.Context, arg1 db.CreateCanvasParams) (db.Canvas, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChannelByID", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateCanvasTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Canvas)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCanvasTx indicates an expected call of CreateCanvasTx.
+func (mr *MockStoreMockRecorder) CreateCanvasTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCanvasTx", reflect.TypeOf((*MockStore)(nil).CreateCanvasTx), arg0, arg1)
+}
+
+// CreateChannel mocks base method.
+func (m *MockStore) CreateChannel(arg0 context.Context, arg1 db.CreateChannelParams) (db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChannel", arg0, arg1)
 	ret0, _ := ret[0].(db.Channel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetChannelByID indicates an expected call of GetChannelByID.
-func (mr *MockStoreMockRecorder) GetChannelByID(arg0, arg1 interface{}) *gomock.Call {
+// CreateChannel indicates an expected call of CreateChannel.
+func (mr *MockStoreMockRecorder) CreateChannel(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelByID", reflect.TypeOf((*MockStore)(nil).GetChannelByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockStore)(nil).CreateChannel), arg0, arg1)
 }
 
-// GetChannelMembers mocks base method.
-func (m *MockStore) GetChannelMembers(arg0 context.Context, arg1 db.GetChannelMembersParams) ([]db.GetChannelMembersRow, error) {
+// CreateChannelMessage mocks base method.
+func (m *MockStore) CreateChannelMessage(arg0 context.Context, arg1 db.CreateChannelMessageParams) (db.Message, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChannelMembers", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetChannelMembersRow)
+	ret := m.ctrl.Call(m, "CreateChannelMessage", arg0, arg1)
+	ret0, _ := ret[0].(db.Message)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetChannelMembers indicates an expected call of GetChannelMembers.
-func (mr *MockStoreMockRecorder) GetChannelMembers(arg0, arg1 interface{}) *gomock.Call {
+// CreateChannelMessage indicates an expected call of CreateChannelMessage.
+func (mr *MockStoreMockRecorder) CreateChannelMessage(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMembers", reflect.TypeOf((*MockStore)(nil).GetChannelMembers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannelMessage", reflect.TypeOf((*MockStore)(nil).CreateChannelMessage), arg0, arg1)
 }
 
-// GetChannelMessages mocks base method.
-func (m *MockStore) GetChannelMessages(arg0 context.Context, arg1 db.GetChannelMessagesParams) ([]db.GetChannelMessagesRow, error) {
+// CreateChannelTx mocks base method.
+func (m *MockStore) CreateChannelTx(arg0 context.Context, arg1 db.CreateChannelTxParams) (db.CreateChannelTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChannelMessages", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetChannelMessagesRow)
+	ret := m.ctrl.Call(m, "CreateChannelTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateChannelTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetChannelMessages indicates an expected call of GetChannelMessages.
-func (mr *MockStoreMockRecorder) GetChannelMessages(arg0, arg1 interface{}) *gomock.Call {
+// CreateChannelTx indicates an expected call of CreateChannelTx.
+func (mr *MockStoreMockRecorder) CreateChannelTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMessages", reflect.TypeOf((*MockStore)(nil).GetChannelMessages), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannelTx", reflect.TypeOf((*MockStore)(nil).CreateChannelTx), arg0, arg1)
 }
 
-// GetChannelWithCreator mocks base method.
-func (m *MockStore) GetChannelWithCreator(arg0 context.Context, arg1 int64) (db.GetChannelWithCreatorRow, error) {
+// CreateCustomEmoji mocks base method.
+func (m *MockStore) CreateCustomEmoji(arg0 context.Context, arg1 db.CreateCustomEmojiParams) (db.CustomEmoji, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChannelWithCreator", arg0, arg1)
-	ret0, _ := ret[0].(db.GetChannelWithCreatorRow)
+	ret := m.ctrl.Call(m, "CreateCustomEmoji", arg0, arg1)
+	ret0, _ := ret[0].(db.CustomEmoji)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetChannelWithCreator indicates an expected call of GetChannelWithCreator.
-func (mr *MockStoreMockRecorder) GetChannelWithCreator(arg0, arg1 interface{}) *gomock.Call {
+// CreateCustomEmoji indicates an expected call of CreateCustomEmoji.
+func (mr *MockStoreMockRecorder) CreateCustomEmoji(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelWithCreator", reflect.TypeOf((*MockStore)(nil).GetChannelWithCreator), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomEmoji", reflect.TypeOf((*MockStore)(nil).CreateCustomEmoji), arg0, arg1)
 }
 
-// GetDirectMessagesBetweenUsers mocks base method.
-func (m *MockStore) GetDirectMessagesBetweenUsers(arg0 context.Context, arg1 db.GetDirectMessagesBetweenUsersParams) ([]db.GetDirectMessagesBetweenUsersRow, error) {
+// CreateDNDOverride mocks base method.
+func (m *MockStore) CreateDNDOverride(arg0 context.Context, arg1 db.CreateDNDOverrideParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDirectMessagesBetweenUsers", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetDirectMessagesBetweenUsersRow)
+	ret := m.ctrl.Call(m, "CreateDNDOverride", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDirectMessagesBetweenUsers indicates an expected call of GetDirectMessagesBetweenUsers.
-func (mr *MockStoreMockRecorder) GetDirectMessagesBetweenUsers(arg0, arg1 interface{}) *gomock.Call {
+// CreateDNDOverride indicates an expected call of CreateDNDOverride.
+func (mr *MockStoreMockRecorder) CreateDNDOverride(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectMessagesBetweenUsers", reflect.TypeOf((*MockStore)(nil).GetDirectMessagesBetweenUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDNDOverride", reflect.TypeOf((*MockStore)(nil).CreateDNDOverride), arg0, arg1)
 }
 
-// GetDuplicateFiles mocks base method.
-func (m *MockStore) GetDuplicateFiles(arg0 context.Context, arg1 int64) ([]db.GetDuplicateFilesRow, error) {
+// CreateDirectMessage mocks base method.
+func (m *MockStore) CreateDirectMessage(arg0 context.Context, arg1 db.CreateDirectMessageParams) (db.Message, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDuplicateFiles", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetDuplicateFilesRow)
+	ret := m.ctrl.Call(m, "CreateDirectMessage", arg0, arg1)
+	ret0, _ := ret[0].(db.Message)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDuplicateFiles indicates an expected call of GetDuplicateFiles.
-func (mr *MockStoreMockRecorder) GetDuplicateFiles(arg0, arg1 interface{}) *gomock.Call {
+// CreateDirectMessage indicates an expected call of CreateDirectMessage.
+func (mr *MockStoreMockRecorder) CreateDirectMessage(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicateFiles", reflect.TypeOf((*MockStore)(nil).GetDuplicateFiles), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDirectMessage", reflect.TypeOf((*MockStore)(nil).CreateDirectMessage), arg0, arg1)
 }
 
-// GetFile mocks base method.
-func (m *MockStore) GetFile(arg0 context.Context, arg1 int64) (db.File, error) {
+// CreateEmailDelivery mocks base method.
+func (m *MockStore) CreateEmailDelivery(arg0 context.Context, arg1 db.CreateEmailDeliveryParams) (db.EmailDelivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFile", arg0, arg1)
-	ret0, _ := ret[0].(db.File)
+	ret := m.ctrl.Call(m, "CreateEmailDelivery", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailDelivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFile indicates an expected call of GetFile.
-func (mr *MockStoreMockRecorder) GetFile(arg0, arg1 interface{}) *gomock.Call {
+// CreateEmailDelivery indicates an expected call of CreateEmailDelivery.
+func (mr *MockStoreMockRecorder) CreateEmailDelivery(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockStore)(nil).GetFile), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailDelivery", reflect.TypeOf((*MockStore)(nil).CreateEmailDelivery), arg0, arg1)
 }
 
-// GetFileByHash mocks base method.
-func (m *MockStore) GetFileByHash(arg0 context.Context, arg1 db.GetFileByHashParams) (db.File, error) {
+// CreateEmailVerification mocks base method.
+func (m *MockStore) CreateEmailVerification(arg0 context.Context, arg1 db.CreateEmailVerificationParams) (db.EmailVerification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileByHash", arg0, arg1)
-	ret0, _ := ret[0].(db.File)
+	ret := m.ctrl.Call(m, "CreateEmailVerification", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailVerification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFileByHash indicates an expected call of GetFileByHash.
-func (mr *MockStoreMockRecorder) GetFileByHash(arg0, arg1 interface{}) *gomock.Call {
+// CreateEmailVerification indicates an expected call of CreateEmailVerification.
+func (mr *MockStoreMockRecorder) CreateEmailVerification(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileByHash", reflect.TypeOf((*MockStore)(nil).GetFileByHash), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailVerification", reflect.TypeOf((*MockStore)(nil).CreateEmailVerification), arg0, arg1)
 }
 
-// GetFileMessages mocks base method.
-func (m *MockStore) GetFileMessages(arg0 context.Context, arg1 int64) ([]db.GetFileMessagesRow, error) {
+// CreateExternalDMRequest mocks base method.
+func (m *MockStore) CreateExternalDMRequest(arg0 context.Context, arg1 db.CreateExternalDMRequestParams) (db.ExternalDmRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileMessages", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetFileMessagesRow)
+	ret := m.ctrl.Call(m, "CreateExternalDMRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.ExternalDmRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFileMessages indicates an expected call of GetFileMessages.
-func (mr *MockStoreMockRecorder) GetFileMessages(arg0, arg1 interface{}) *gomock.Call {
+// CreateExternalDMRequest indicates an expected call of CreateExternalDMRequest.
+func (mr *MockStoreMockRecorder) CreateExternalDMRequest(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileMessages", reflect.TypeOf((*MockStore)(nil).GetFileMessages), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExternalDMRequest", reflect.TypeOf((*MockStore)(nil).CreateExternalDMRequest), arg0, arg1)
 }
 
-// GetFileShares mocks base method.
-func (m *MockStore) GetFileShares(arg0 context.Context, arg1 int64) ([]db.GetFileSharesRow, error) {
+// CreateFile mocks base method.
+func (m *MockStore) CreateFile(arg0 context.Context, arg1 db.CreateFileParams) (db.File, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileShares", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetFileSharesRow)
+	ret := m.ctrl.Call(m, "CreateFile", arg0, arg1)
+	ret0, _ := ret[0].(db.File)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFileShares indicates an expected call of GetFileShares.
-func (mr *MockStoreMockRecorder) GetFileShares(arg0, arg1 interface{}) *gomock.Call {
+// CreateFile indicates an expected call of CreateFile.
+func (mr *MockStoreMockRecorder) CreateFile(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileShares", reflect.TypeOf((*MockStore)(nil).GetFileShares), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFile", reflect.TypeOf((*MockStore)(nil).CreateFile), arg0, arg1)
 }
 
-// GetFileStats mocks base method.
-func (m *MockStore) GetFileStats(arg0 context.Context, arg1 int64) (db.GetFileStatsRow, error) {
+// CreateFileShare mocks base method.
+func (m *MockStore) CreateFileShare(arg0 context.Context, arg1 db.CreateFileShareParams) (db.FileShare, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileStats", arg0, arg1)
-	ret0, _ := ret[0].(db.GetFileStatsRow)
+	ret := m.ctrl.Call(m, "CreateFileShare", arg0, arg1)
+	ret0, _ := ret[0].(db.FileShare)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFileStats indicates an expected call of GetFileStats.
-func (mr *MockStoreMockRecorder) GetFileStats(arg0, arg1 interface{}) *gomock.Call {
+// CreateFileShare indicates an expected call of CreateFileShare.
+func (mr *MockStoreMockRecorder) CreateFileShare(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileStats", reflect.TypeOf((*MockStore)(nil).GetFileStats), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFileShare", reflect.TypeOf((*MockStore)(nil).CreateFileShare), arg0, arg1)
 }
 
-// GetFileWithPermissionCheck mocks base method.
-func (m *MockStore) GetFileWithPermissionCheck(arg0 context.Context, arg1 db.GetFileWithPermissionCheckParams) (db.GetFileWithPermissionCheckRow, error) {
+// CreateLegalHold mocks base method.
+func (m *MockStore) CreateLegalHold(arg0 context.Context, arg1 db.CreateLegalHoldParams) (db.LegalHold, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileWithPermissionCheck", arg0, arg1)
-	ret0, _ := ret[0].(db.GetFileWithPermissionCheckRow)
+	ret := m.ctrl.Call(m, "CreateLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(db.LegalHold)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFileWithPermissionCheck indicates an expected call of GetFileWithPermissionCheck.
-func (mr *MockStoreMockRecorder) GetFileWithPermissionCheck(arg0, arg1 interface{}) *gomock.Call {
+// CreateLegalHold indicates an expected call of CreateLegalHold.
+func (mr *MockStoreMockRecorder) CreateLegalHold(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileWithPermissionCheck", reflect.TypeOf((*MockStore)(nil).GetFileWithPermissionCheck), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLegalHold", reflect.TypeOf((*MockStore)(nil).CreateLegalHold), arg0, arg1)
 }
 
-// GetMessageByID mocks base method.
-func (m *MockStore) GetMessageByID(arg0 context.Context, arg1 int64) (db.GetMessageByIDRow, error) {
+// CreateLoginEvent mocks base method.
+func (m *MockStore) CreateLoginEvent(arg0 context.Context, arg1 db.CreateLoginEventParams) (db.LoginEvent, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMessageByID", arg0, arg1)
-	ret0, _ := ret[0].(db.GetMessageByIDRow)
+	ret := m.ctrl.Call(m, "CreateLoginEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginEvent)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMessageByID indicates an expected call of GetMessageByID.
-func (mr *MockStoreMockRecorder) GetMessageByID(arg0, arg1 interface{}) *gomock.Call {
+// CreateLoginEvent indicates an expected call of CreateLoginEvent.
+func (mr *MockStoreMockRecorder) CreateLoginEvent(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*MockStore)(nil).GetMessageByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoginEvent", reflect.TypeOf((*MockStore)(nil).CreateLoginEvent), arg0, arg1)
 }
 
-// GetMessageFiles mocks base method.
-func (m *MockStore) GetMessageFiles(arg0 context.Context, arg1 int64) ([]db.GetMessageFilesRow, error) {
+// CreateMessageArchive mocks base method.
+func (m *MockStore) CreateMessageArchive(arg0 context.Context, arg1 db.CreateMessageArchiveParams) (db.MessageArchive, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMessageFiles", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetMessageFilesRow)
+	ret := m.ctrl.Call(m, "CreateMessageArchive", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageArchive)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMessageFiles indicates an expected call of GetMessageFiles.
-func (mr *MockStoreMockRecorder) GetMessageFiles(arg0, arg1 interface{}) *gomock.Call {
+// CreateMessageArchive indicates an expected call of CreateMessageArchive.
+func (mr *MockStoreMockRecorder) CreateMessageArchive(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageFiles", reflect.TypeOf((*MockStore)(nil).GetMessageFiles), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageArchive", reflect.TypeOf((*MockStore)(nil).CreateMessageArchive), arg0, arg1)
 }
 
-// GetOnlineUsersInWorkspace mocks base method.
-func (m *MockStore) GetOnlineUsersInWorkspace(arg0 context.Context, arg1 int64) ([]db.GetOnlineUsersInWorkspaceRow, error) {
+// CreateMessageAt mocks base method.
+func (m *MockStore) CreateMessageAt(arg0 context.Context, arg1 db.CreateMessageAtParams) (db.Message, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOnlineUsersInWorkspace", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetOnlineUsersInWorkspaceRow)
+	ret := m.ctrl.Call(m, "CreateMessageAt", arg0, arg1)
+	ret0, _ := ret[0].(db.Message)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOnlineUsersInWorkspace indicates an expected call of GetOnlineUsersInWorkspace.
-func (mr *MockStoreMockRecorder) GetOnlineUsersInWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// CreateMessageAt indicates an expected call of CreateMessageAt.
+func (mr *MockStoreMockRecorder) CreateMessageAt(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOnlineUsersInWorkspace", reflect.TypeOf((*MockStore)(nil).GetOnlineUsersInWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageAt", reflect.TypeOf((*MockStore)(nil).CreateMessageAt), arg0, arg1)
 }
 
-// GetOrganization mocks base method.
-func (m *MockStore) GetOrganization(arg0 context.Context, arg1 int64) (db.Organization, error) {
+// CreateMessageFile mocks base method.
+func (m *MockStore) CreateMessageFile(arg0 context.Context, arg1 db.CreateMessageFileParams) (db.MessageFile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrganization", arg0, arg1)
-	ret0, _ := ret[0].(db.Organization)
+	ret := m.ctrl.Call(m, "CreateMessageFile", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrganization indicates an expected call of GetOrganization.
-func (mr *MockStoreMockRecorder) GetOrganization(arg0, arg1 interface{}) *gomock.Call {
+// CreateMessageFile indicates an expected call of CreateMessageFile.
+func (mr *MockStoreMockRecorder) CreateMessageFile(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockStore)(nil).GetOrganization), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageFile", reflect.TypeOf((*MockStore)(nil).CreateMessageFile), arg0, arg1)
 }
 
-// GetPendingInvitationsForUser mocks base method.
-func (m *MockStore) GetPendingInvitationsForUser(arg0 context.Context, arg1 string) ([]db.GetPendingInvitationsForUserRow, error) {
+// CreateMessageMentions mocks base method.
+func (m *MockStore) CreateMessageMentions(arg0 context.Context, arg1 db.CreateMessageMentionsParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPendingInvitationsForUser", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetPendingInvitationsForUserRow)
+	ret := m.ctrl.Call(m, "CreateMessageMentions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMessageMentions indicates an expected call of CreateMessageMentions.
+func (mr *MockStoreMockRecorder) CreateMessageMentions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageMentions", reflect.TypeOf((*MockStore)(nil).CreateMessageMentions), arg0, arg1)
+}
+
+// CreateMessagePartition mocks base method.
+func (m *MockStore) CreateMessagePartition(arg0 context.Context, arg1 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessagePartition", arg0, arg1)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPendingInvitationsForUser indicates an expected call of GetPendingInvitationsForUser.
-func (mr *MockStoreMockRecorder) GetPendingInvitationsForUser(arg0, arg1 interface{}) *gomock.Call {
+// CreateMessagePartition indicates an expected call of CreateMessagePartition.
+func (mr *MockStoreMockRecorder) CreateMessagePartition(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingInvitationsForUser", reflect.TypeOf((*MockStore)(nil).GetPendingInvitationsForUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessagePartition", reflect.TypeOf((*MockStore)(nil).CreateMessagePartition), arg0, arg1)
 }
 
-// GetRecentWorkspaceMessages mocks base method.
-func (m *MockStore) GetRecentWorkspaceMessages(arg0 context.Context, arg1 db.GetRecentWorkspaceMessagesParams) ([]db.GetRecentWorkspaceMessagesRow, error) {
+// CreateMessageReactions mocks base method.
+func (m *MockStore) CreateMessageReactions(arg0 context.Context, arg1 db.CreateMessageReactionsParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecentWorkspaceMessages", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetRecentWorkspaceMessagesRow)
+	ret := m.ctrl.Call(m, "CreateMessageReactions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRecentWorkspaceMessages indicates an expected call of GetRecentWorkspaceMessages.
-func (mr *MockStoreMockRecorder) GetRecentWorkspaceMessages(arg0, arg1 interface{}) *gomock.Call {
+// CreateMessageReactions indicates an expected call of CreateMessageReactions.
+func (mr *MockStoreMockRecorder) CreateMessageReactions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentWorkspaceMessages", reflect.TypeOf((*MockStore)(nil).GetRecentWorkspaceMessages), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageReactions", reflect.TypeOf((*MockStore)(nil).CreateMessageReactions), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 int64) (db.User, error) {
+// CreateMessageTx mocks base method.
+func (m *MockStore) CreateMessageTx(arg0 context.Context, arg1 db.CreateMessageTxParams) (db.CreateMessageTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "CreateMessageTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CreateMessageTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// CreateMessageTx indicates an expected call of CreateMessageTx.
+func (mr *MockStoreMockRecorder) CreateMessageTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageTx", reflect.TypeOf((*MockStore)(nil).CreateMessageTx), arg0, arg1)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+// CreateModerationAuditEntry mocks base method.
+func (m *MockStore) CreateModerationAuditEntry(arg0 context.Context, arg1 db.CreateModerationAuditEntryParams) (db.ModerationAuditLog, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "CreateModerationAuditEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.ModerationAuditLog)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+// CreateModerationAuditEntry indicates an expected call of CreateModerationAuditEntry.
+func (mr *MockStoreMockRecorder) CreateModerationAuditEntry(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModerationAuditEntry", reflect.TypeOf((*MockStore)(nil).CreateModerationAuditEntry), arg0, arg1)
 }
 
-// GetUserChannels mocks base method.
-func (m *MockStore) GetUserChannels(arg0 context.Context, arg1 db.GetUserChannelsParams) ([]db.Channel, error) {
+// CreateModerationQueueItem mocks base method.
+func (m *MockStore) CreateModerationQueueItem(arg0 context.Context, arg1 db.CreateModerationQueueItemParams) (db.ModerationQueue, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserChannels", arg0, arg1)
-	ret0, _ := ret[0].([]db.Channel)
+	ret := m.ctrl.Call(m, "CreateModerationQueueItem", arg0, arg1)
+	ret0, _ := ret[0].(db.ModerationQueue)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserChannels indicates an expected call of GetUserChannels.
-func (mr *MockStoreMockRecorder) GetUserChannels(arg0, arg1 interface{}) *gomock.Call {
+// CreateModerationQueueItem indicates an expected call of CreateModerationQueueItem.
+func (mr *MockStoreMockRecorder) CreateModerationQueueItem(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChannels", reflect.TypeOf((*MockStore)(nil).GetUserChannels), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModerationQueueItem", reflect.TypeOf((*MockStore)(nil).CreateModerationQueueItem), arg0, arg1)
 }
 
-// GetUserStatus mocks base method.
-func (m *MockStore) GetUserStatus(arg0 context.Context, arg1 db.GetUserStatusParams) (db.UserStatus, error) {
+// CreateModerationWord mocks base method.
+func (m *MockStore) CreateModerationWord(arg0 context.Context, arg1 db.CreateModerationWordParams) (db.ModerationWord, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserStatus", arg0, arg1)
-	ret0, _ := ret[0].(db.UserStatus)
+	ret := m.ctrl.Call(m, "CreateModerationWord", arg0, arg1)
+	ret0, _ := ret[0].(db.ModerationWord)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserStatus indicates an expected call of GetUserStatus.
-func (mr *MockStoreMockRecorder) GetUserStatus(arg0, arg1 interface{}) *gomock.Call {
+// CreateModerationWord indicates an expected call of CreateModerationWord.
+func (mr *MockStoreMockRecorder) CreateModerationWord(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStatus", reflect.TypeOf((*MockStore)(nil).GetUserStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModerationWord", reflect.TypeOf((*MockStore)(nil).CreateModerationWord), arg0, arg1)
 }
 
-// GetUsersByWorkspace mocks base method.
-func (m *MockStore) GetUsersByWorkspace(arg0 context.Context, arg1 db.GetUsersByWorkspaceParams) ([]db.User, error) {
+// CreateOrganization mocks base method.
+func (m *MockStore) CreateOrganization(arg0 context.Context, arg1 string) (db.Organization, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersByWorkspace", arg0, arg1)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1)
+	ret0, _ := ret[0].(db.Organization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUsersByWorkspace indicates an expected call of GetUsersByWorkspace.
-func (mr *MockStoreMockRecorder) GetUsersByWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockStoreMockRecorder) CreateOrganization(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByWorkspace", reflect.TypeOf((*MockStore)(nil).GetUsersByWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockStore)(nil).CreateOrganization), arg0, arg1)
 }
 
-// GetWorkspace mocks base method.
-func (m *MockStore) GetWorkspace(arg0 context.Context, arg1 int64) (db.Workspace, error) {
+// CreateOrganizationDeletion mocks base method.
+func (m *MockStore) CreateOrganizationDeletion(arg0 context.Context, arg1 db.CreateOrganizationDeletionParams) (db.OrganizationDeletion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWorkspace", arg0, arg1)
-	ret0, _ := ret[0].(db.Workspace)
+	ret := m.ctrl.Call(m, "CreateOrganizationDeletion", arg0, arg1)
+	ret0, _ := ret[0].(db.OrganizationDeletion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWorkspace indicates an expected call of GetWorkspace.
-func (mr *MockStoreMockRecorder) GetWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// CreateOrganizationDeletion indicates an expected call of CreateOrganizationDeletion.
+func (mr *MockStoreMockRecorder) CreateOrganizationDeletion(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspace", reflect.TypeOf((*MockStore)(nil).GetWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganizationDeletion", reflect.TypeOf((*MockStore)(nil).CreateOrganizationDeletion), arg0, arg1)
 }
 
-// GetWorkspaceByID mocks base method.
-func (m *MockStore) GetWorkspaceByID(arg0 context.Context, arg1 int64) (db.Workspace, error) {
+// CreateOutboxEvent mocks base method.
+func (m *MockStore) CreateOutboxEvent(arg0 context.Context, arg1 db.CreateOutboxEventParams) (db.EventOutbox, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWorkspaceByID", arg0, arg1)
-	ret0, _ := ret[0].(db.Workspace)
+	ret := m.ctrl.Call(m, "CreateOutboxEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.EventOutbox)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWorkspaceByID indicates an expected call of GetWorkspaceByID.
-func (mr *MockStoreMockRecorder) GetWorkspaceByID(arg0, arg1 interface{}) *gomock.Call {
+// CreateOutboxEvent indicates an expected call of CreateOutboxEvent.
+func (mr *MockStoreMockRecorder) CreateOutboxEvent(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceByID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutboxEvent", reflect.TypeOf((*MockStore)(nil).CreateOutboxEvent), arg0, arg1)
 }
 
-// GetWorkspaceInvitation mocks base method.
-func (m *MockStore) GetWorkspaceInvitation(arg0 context.Context, arg1 int64) (db.WorkspaceInvitation, error) {
+// CreateOutgoingWebhook mocks base method.
+func (m *MockStore) CreateOutgoingWebhook(arg0 context.Context, arg1 db.CreateOutgoingWebhookParams) (db.OutgoingWebhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWorkspaceInvitation", arg0, arg1)
-	ret0, _ := ret[0].(db.WorkspaceInvitation)
+	ret := m.ctrl.Call(m, "CreateOutgoingWebhook", arg0, arg1)
+	ret0, _ := ret[0].(db.OutgoingWebhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWorkspaceInvitation indicates an expected call of GetWorkspaceInvitation.
-func (mr *MockStoreMockRecorder) GetWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+// CreateOutgoingWebhook indicates an expected call of CreateOutgoingWebhook.
+func (mr *MockStoreMockRecorder) CreateOutgoingWebhook(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).GetWorkspaceInvitation), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOutgoingWebhook", reflect.TypeOf((*MockStore)(nil).CreateOutgoingWebhook), arg0, arg1)
 }
 
-// GetWorkspaceInvitationByCode mocks base method.
-func (m *MockStore) GetWorkspaceInvitationByCode(arg0 context.Context, arg1 string) (db.WorkspaceInvitation, error) {
+// CreateSavedSearch mocks base method.
+func (m *MockStore) CreateSavedSearch(arg0 context.Context, arg1 db.CreateSavedSearchParams) (db.SavedSearch, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWorkspaceInvitationByCode", arg0, arg1)
-	ret0, _ := ret[0].(db.WorkspaceInvitation)
+	ret := m.ctrl.Call(m, "CreateSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSavedSearch indicates an expected call of CreateSavedSearch.
+func (mr *MockStoreMockRecorder) CreateSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedSearch", reflect.TypeOf((*MockStore)(nil).CreateSavedSearch), arg0, arg1)
+}
+
+// CreateThreadReply mocks base method.
+func (m *MockStore) CreateThreadReply(arg0 context.Context, arg1 db.CreateThreadReplyParams) (db.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateThreadReply", arg0, arg1)
+	ret0, _ := ret[0].(db.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateThreadReply indicates an expected call of CreateThreadReply.
+func (mr *MockStoreMockRecorder) CreateThreadReply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateThreadReply", reflect.TypeOf((*MockStore)(nil).CreateThreadReply), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateWebhookEvent mocks base method.
+func (m *MockStore) CreateWebhookEvent(arg0 context.Context, arg1 db.CreateWebhookEventParams) (db.EventOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.EventOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookEvent indicates an expected call of CreateWebhookEvent.
+func (mr *MockStoreMockRecorder) CreateWebhookEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookEvent", reflect.TypeOf((*MockStore)(nil).CreateWebhookEvent), arg0, arg1)
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockStore) CreateWorkspace(arg0 context.Context, arg1 db.CreateWorkspaceParams) (db.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(db.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockStoreMockRecorder) CreateWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockStore)(nil).CreateWorkspace), arg0, arg1)
+}
+
+// CreateWorkspaceAutoJoinDomain mocks base method.
+func (m *MockStore) CreateWorkspaceAutoJoinDomain(arg0 context.Context, arg1 db.CreateWorkspaceAutoJoinDomainParams) (db.WorkspaceAutoJoinDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspaceAutoJoinDomain", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceAutoJoinDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspaceAutoJoinDomain indicates an expected call of CreateWorkspaceAutoJoinDomain.
+func (mr *MockStoreMockRecorder) CreateWorkspaceAutoJoinDomain(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceAutoJoinDomain", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceAutoJoinDomain), arg0, arg1)
+}
+
+// CreateWorkspaceBanner mocks base method.
+func (m *MockStore) CreateWorkspaceBanner(arg0 context.Context, arg1 db.CreateWorkspaceBannerParams) (db.WorkspaceBanner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspaceBanner", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceBanner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspaceBanner indicates an expected call of CreateWorkspaceBanner.
+func (mr *MockStoreMockRecorder) CreateWorkspaceBanner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceBanner", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceBanner), arg0, arg1)
+}
+
+// CreateWorkspaceInvitation mocks base method.
+func (m *MockStore) CreateWorkspaceInvitation(arg0 context.Context, arg1 db.CreateWorkspaceInvitationParams) (db.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspaceInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspaceInvitation indicates an expected call of CreateWorkspaceInvitation.
+func (mr *MockStoreMockRecorder) CreateWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceInvitation), arg0, arg1)
+}
+
+// CreateWorkspaceInvitationsTx mocks base method.
+func (m *MockStore) CreateWorkspaceInvitationsTx(arg0 context.Context, arg1 []db.CreateWorkspaceInvitationParams) ([]db.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspaceInvitationsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspaceInvitationsTx indicates an expected call of CreateWorkspaceInvitationsTx.
+func (mr *MockStoreMockRecorder) CreateWorkspaceInvitationsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceInvitationsTx", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceInvitationsTx), arg0, arg1)
+}
+
+// CreateWorkspaceJoinLink mocks base method.
+func (m *MockStore) CreateWorkspaceJoinLink(arg0 context.Context, arg1 db.CreateWorkspaceJoinLinkParams) (db.WorkspaceJoinLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspaceJoinLink", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceJoinLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspaceJoinLink indicates an expected call of CreateWorkspaceJoinLink.
+func (mr *MockStoreMockRecorder) CreateWorkspaceJoinLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceJoinLink", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceJoinLink), arg0, arg1)
+}
+
+// CreateWorkspaceJoinRequest mocks base method.
+func (m *MockStore) CreateWorkspaceJoinRequest(arg0 context.Context, arg1 db.CreateWorkspaceJoinRequestParams) (db.WorkspaceJoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspaceJoinRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceJoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspaceJoinRequest indicates an expected call of CreateWorkspaceJoinRequest.
+func (mr *MockStoreMockRecorder) CreateWorkspaceJoinRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceJoinRequest", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceJoinRequest), arg0, arg1)
+}
+
+// CreateWorkspaceRole mocks base method.
+func (m *MockStore) CreateWorkspaceRole(arg0 context.Context, arg1 db.CreateWorkspaceRoleParams) (db.WorkspaceRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspaceRole", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorkspaceRole indicates an expected call of CreateWorkspaceRole.
+func (mr *MockStoreMockRecorder) CreateWorkspaceRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceRole", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceRole), arg0, arg1)
+}
+
+// CreateWorkspaceTeardown mocks base method.
+func (m *MockStore) CreateWorkspaceTeardown(arg0 context.Context, arg1 db.CreateWorkspaceTeardownParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspaceTeardown", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWorkspaceTeardown indicates an expected call of CreateWorkspaceTeardown.
+func (mr *MockStoreMockRecorder) CreateWorkspaceTeardown(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspaceTeardown", reflect.TypeOf((*MockStore)(nil).CreateWorkspaceTeardown), arg0, arg1)
+}
+
+// CustomEmojiExists mocks base method.
+func (m *MockStore) CustomEmojiExists(arg0 context.Context, arg1 db.CustomEmojiExistsParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CustomEmojiExists", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CustomEmojiExists indicates an expected call of CustomEmojiExists.
+func (mr *MockStoreMockRecorder) CustomEmojiExists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CustomEmojiExists", reflect.TypeOf((*MockStore)(nil).CustomEmojiExists), arg0, arg1)
+}
+
+// DeclineWorkspaceInvitation mocks base method.
+func (m *MockStore) DeclineWorkspaceInvitation(arg0 context.Context, arg1 string) (db.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineWorkspaceInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineWorkspaceInvitation indicates an expected call of DeclineWorkspaceInvitation.
+func (mr *MockStoreMockRecorder) DeclineWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).DeclineWorkspaceInvitation), arg0, arg1)
+}
+
+// DeleteArchivedMessages mocks base method.
+func (m *MockStore) DeleteArchivedMessages(arg0 context.Context, arg1 []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArchivedMessages", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteArchivedMessages indicates an expected call of DeleteArchivedMessages.
+func (mr *MockStoreMockRecorder) DeleteArchivedMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArchivedMessages", reflect.TypeOf((*MockStore)(nil).DeleteArchivedMessages), arg0, arg1)
+}
+
+// DeleteCalendarBusyBlocks mocks base method.
+func (m *MockStore) DeleteCalendarBusyBlocks(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarBusyBlocks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCalendarBusyBlocks indicates an expected call of DeleteCalendarBusyBlocks.
+func (mr *MockStoreMockRecorder) DeleteCalendarBusyBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarBusyBlocks", reflect.TypeOf((*MockStore)(nil).DeleteCalendarBusyBlocks), arg0, arg1)
+}
+
+// DeleteCalendarIntegration mocks base method.
+func (m *MockStore) DeleteCalendarIntegration(arg0 context.Context, arg1 db.DeleteCalendarIntegrationParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarIntegration", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCalendarIntegration indicates an expected call of DeleteCalendarIntegration.
+func (mr *MockStoreMockRecorder) DeleteCalendarIntegration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarIntegration", reflect.TypeOf((*MockStore)(nil).DeleteCalendarIntegration), arg0, arg1)
+}
+
+// DeleteCanvas mocks base method.
+func (m *MockStore) DeleteCanvas(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCanvas", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCanvas indicates an expected call of DeleteCanvas.
+func (mr *MockStoreMockRecorder) DeleteCanvas(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCanvas", reflect.TypeOf((*MockStore)(nil).DeleteCanvas), arg0, arg1)
+}
+
+// DeleteChannel mocks base method.
+func (m *MockStore) DeleteChannel(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannel indicates an expected call of DeleteChannel.
+func (mr *MockStoreMockRecorder) DeleteChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannel", reflect.TypeOf((*MockStore)(nil).DeleteChannel), arg0, arg1)
+}
+
+// DeleteChannelDailyPosterStats mocks base method.
+func (m *MockStore) DeleteChannelDailyPosterStats(arg0 context.Context, arg1 db.DeleteChannelDailyPosterStatsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelDailyPosterStats", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannelDailyPosterStats indicates an expected call of DeleteChannelDailyPosterStats.
+func (mr *MockStoreMockRecorder) DeleteChannelDailyPosterStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelDailyPosterStats", reflect.TypeOf((*MockStore)(nil).DeleteChannelDailyPosterStats), arg0, arg1)
+}
+
+// DeleteChannelDailyStats mocks base method.
+func (m *MockStore) DeleteChannelDailyStats(arg0 context.Context, arg1 db.DeleteChannelDailyStatsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelDailyStats", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChannelDailyStats indicates an expected call of DeleteChannelDailyStats.
+func (mr *MockStoreMockRecorder) DeleteChannelDailyStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelDailyStats", reflect.TypeOf((*MockStore)(nil).DeleteChannelDailyStats), arg0, arg1)
+}
+
+// DeleteChannelNotificationDefault mocks base method.
+func (m *MockStore) DeleteChannelNotificationDefault(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelNotificationDefault", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChannelNotificationDefault indicates an expected call of DeleteChannelNotificationDefault.
+func (mr *MockStoreMockRecorder) DeleteChannelNotificationDefault(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelNotificationDefault", reflect.TypeOf((*MockStore)(nil).DeleteChannelNotificationDefault), arg0, arg1)
+}
+
+// DeleteChannelNotificationPreference mocks base method.
+func (m *MockStore) DeleteChannelNotificationPreference(arg0 context.Context, arg1 db.DeleteChannelNotificationPreferenceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChannelNotificationPreference indicates an expected call of DeleteChannelNotificationPreference.
+func (mr *MockStoreMockRecorder) DeleteChannelNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelNotificationPreference", reflect.TypeOf((*MockStore)(nil).DeleteChannelNotificationPreference), arg0, arg1)
+}
+
+// DeleteCustomEmoji mocks base method.
+func (m *MockStore) DeleteCustomEmoji(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomEmoji", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomEmoji indicates an expected call of DeleteCustomEmoji.
+func (mr *MockStoreMockRecorder) DeleteCustomEmoji(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomEmoji", reflect.TypeOf((*MockStore)(nil).DeleteCustomEmoji), arg0, arg1)
+}
+
+// DeleteCustomEmojiTx mocks base method.
+func (m *MockStore) DeleteCustomEmojiTx(arg0 context.Context, arg1 db.DeleteCustomEmojiTxParams) (db.DeleteCustomEmojiTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomEmojiTx", arg0, arg1)
+	ret0, _ := ret[0].(db.DeleteCustomEmojiTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCustomEmojiTx indicates an expected call of DeleteCustomEmojiTx.
+func (mr *MockStoreMockRecorder) DeleteCustomEmojiTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomEmojiTx", reflect.TypeOf((*MockStore)(nil).DeleteCustomEmojiTx), arg0, arg1)
+}
+
+// DeleteEmailSuppression mocks base method.
+func (m *MockStore) DeleteEmailSuppression(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailSuppression", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEmailSuppression indicates an expected call of DeleteEmailSuppression.
+func (mr *MockStoreMockRecorder) DeleteEmailSuppression(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailSuppression", reflect.TypeOf((*MockStore)(nil).DeleteEmailSuppression), arg0, arg1)
+}
+
+// DeleteEmailTemplate mocks base method.
+func (m *MockStore) DeleteEmailTemplate(arg0 context.Context, arg1 db.DeleteEmailTemplateParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailTemplate", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEmailTemplate indicates an expected call of DeleteEmailTemplate.
+func (mr *MockStoreMockRecorder) DeleteEmailTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailTemplate", reflect.TypeOf((*MockStore)(nil).DeleteEmailTemplate), arg0, arg1)
+}
+
+// DeleteFeatureFlag mocks base method.
+func (m *MockStore) DeleteFeatureFlag(arg0 context.Context, arg1 db.DeleteFeatureFlagParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeatureFlag", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFeatureFlag indicates an expected call of DeleteFeatureFlag.
+func (mr *MockStoreMockRecorder) DeleteFeatureFlag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeatureFlag", reflect.TypeOf((*MockStore)(nil).DeleteFeatureFlag), arg0, arg1)
+}
+
+// DeleteFile mocks base method.
+func (m *MockStore) DeleteFile(arg0 context.Context, arg1 db.DeleteFileParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockStoreMockRecorder) DeleteFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockStore)(nil).DeleteFile), arg0, arg1)
+}
+
+// DeleteFilesByIDs mocks base method.
+func (m *MockStore) DeleteFilesByIDs(arg0 context.Context, arg1 []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilesByIDs", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFilesByIDs indicates an expected call of DeleteFilesByIDs.
+func (mr *MockStoreMockRecorder) DeleteFilesByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilesByIDs", reflect.TypeOf((*MockStore)(nil).DeleteFilesByIDs), arg0, arg1)
+}
+
+// DeleteMessageDraft mocks base method.
+func (m *MockStore) DeleteMessageDraft(arg0 context.Context, arg1 db.DeleteMessageDraftParams) (db.MessageDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessageDraft", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessageDraft indicates an expected call of DeleteMessageDraft.
+func (mr *MockStoreMockRecorder) DeleteMessageDraft(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageDraft", reflect.TypeOf((*MockStore)(nil).DeleteMessageDraft), arg0, arg1)
+}
+
+// DeleteMessageDraftForTarget mocks base method.
+func (m *MockStore) DeleteMessageDraftForTarget(arg0 context.Context, arg1 db.DeleteMessageDraftForTargetParams) (db.MessageDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessageDraftForTarget", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessageDraftForTarget indicates an expected call of DeleteMessageDraftForTarget.
+func (mr *MockStoreMockRecorder) DeleteMessageDraftForTarget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageDraftForTarget", reflect.TypeOf((*MockStore)(nil).DeleteMessageDraftForTarget), arg0, arg1)
+}
+
+// DeleteMessageFile mocks base method.
+func (m *MockStore) DeleteMessageFile(arg0 context.Context, arg1 db.DeleteMessageFileParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessageFile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessageFile indicates an expected call of DeleteMessageFile.
+func (mr *MockStoreMockRecorder) DeleteMessageFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageFile", reflect.TypeOf((*MockStore)(nil).DeleteMessageFile), arg0, arg1)
+}
+
+// DeleteModerationWord mocks base method.
+func (m *MockStore) DeleteModerationWord(arg0 context.Context, arg1 db.DeleteModerationWordParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteModerationWord", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteModerationWord indicates an expected call of DeleteModerationWord.
+func (mr *MockStoreMockRecorder) DeleteModerationWord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteModerationWord", reflect.TypeOf((*MockStore)(nil).DeleteModerationWord), arg0, arg1)
+}
+
+// DeleteOldEmailDeliveries mocks base method.
+func (m *MockStore) DeleteOldEmailDeliveries(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldEmailDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOldEmailDeliveries indicates an expected call of DeleteOldEmailDeliveries.
+func (mr *MockStoreMockRecorder) DeleteOldEmailDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldEmailDeliveries", reflect.TypeOf((*MockStore)(nil).DeleteOldEmailDeliveries), arg0, arg1)
+}
+
+// DeleteOldWebhookDeliveries mocks base method.
+func (m *MockStore) DeleteOldWebhookDeliveries(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOldWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOldWebhookDeliveries indicates an expected call of DeleteOldWebhookDeliveries.
+func (mr *MockStoreMockRecorder) DeleteOldWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOldWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).DeleteOldWebhookDeliveries), arg0, arg1)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockStore) DeleteOrganization(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockStoreMockRecorder) DeleteOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockStore)(nil).DeleteOrganization), arg0, arg1)
+}
+
+// DeleteOrganizationRole mocks base method.
+func (m *MockStore) DeleteOrganizationRole(arg0 context.Context, arg1 db.DeleteOrganizationRoleParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganizationRole", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOrganizationRole indicates an expected call of DeleteOrganizationRole.
+func (mr *MockStoreMockRecorder) DeleteOrganizationRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganizationRole", reflect.TypeOf((*MockStore)(nil).DeleteOrganizationRole), arg0, arg1)
+}
+
+// DeleteOutOfOffice mocks base method.
+func (m *MockStore) DeleteOutOfOffice(arg0 context.Context, arg1 db.DeleteOutOfOfficeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutOfOffice", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOutOfOffice indicates an expected call of DeleteOutOfOffice.
+func (mr *MockStoreMockRecorder) DeleteOutOfOffice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutOfOffice", reflect.TypeOf((*MockStore)(nil).DeleteOutOfOffice), arg0, arg1)
+}
+
+// DeleteOutgoingWebhook mocks base method.
+func (m *MockStore) DeleteOutgoingWebhook(arg0 context.Context, arg1 db.DeleteOutgoingWebhookParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOutgoingWebhook", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOutgoingWebhook indicates an expected call of DeleteOutgoingWebhook.
+func (mr *MockStoreMockRecorder) DeleteOutgoingWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOutgoingWebhook", reflect.TypeOf((*MockStore)(nil).DeleteOutgoingWebhook), arg0, arg1)
+}
+
+// DeletePublishedOutboxEvents mocks base method.
+func (m *MockStore) DeletePublishedOutboxEvents(arg0 context.Context, arg1 sql.NullTime) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublishedOutboxEvents", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublishedOutboxEvents indicates an expected call of DeletePublishedOutboxEvents.
+func (mr *MockStoreMockRecorder) DeletePublishedOutboxEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublishedOutboxEvents", reflect.TypeOf((*MockStore)(nil).DeletePublishedOutboxEvents), arg0, arg1)
+}
+
+// DeleteReactionsByID mocks base method.
+func (m *MockStore) DeleteReactionsByID(arg0 context.Context, arg1 []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReactionsByID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReactionsByID indicates an expected call of DeleteReactionsByID.
+func (mr *MockStoreMockRecorder) DeleteReactionsByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReactionsByID", reflect.TypeOf((*MockStore)(nil).DeleteReactionsByID), arg0, arg1)
+}
+
+// DeleteSavedSearch mocks base method.
+func (m *MockStore) DeleteSavedSearch(arg0 context.Context, arg1 db.DeleteSavedSearchParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedSearch indicates an expected call of DeleteSavedSearch.
+func (mr *MockStoreMockRecorder) DeleteSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedSearch", reflect.TypeOf((*MockStore)(nil).DeleteSavedSearch), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStore) DeleteUser(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStoreMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), arg0, arg1)
+}
+
+// DeleteUserDevice mocks base method.
+func (m *MockStore) DeleteUserDevice(arg0 context.Context, arg1 db.DeleteUserDeviceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserDevice", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserDevice indicates an expected call of DeleteUserDevice.
+func (mr *MockStoreMockRecorder) DeleteUserDevice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserDevice", reflect.TypeOf((*MockStore)(nil).DeleteUserDevice), arg0, arg1)
+}
+
+// DeleteWorkspace mocks base method.
+func (m *MockStore) DeleteWorkspace(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspace indicates an expected call of DeleteWorkspace.
+func (mr *MockStoreMockRecorder) DeleteWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspace", reflect.TypeOf((*MockStore)(nil).DeleteWorkspace), arg0, arg1)
+}
+
+// DeleteWorkspaceAutoJoinDomain mocks base method.
+func (m *MockStore) DeleteWorkspaceAutoJoinDomain(arg0 context.Context, arg1 db.DeleteWorkspaceAutoJoinDomainParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceAutoJoinDomain", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkspaceAutoJoinDomain indicates an expected call of DeleteWorkspaceAutoJoinDomain.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceAutoJoinDomain(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceAutoJoinDomain", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceAutoJoinDomain), arg0, arg1)
+}
+
+// DeleteWorkspaceBanner mocks base method.
+func (m *MockStore) DeleteWorkspaceBanner(arg0 context.Context, arg1 db.DeleteWorkspaceBannerParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceBanner", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkspaceBanner indicates an expected call of DeleteWorkspaceBanner.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceBanner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceBanner", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceBanner), arg0, arg1)
+}
+
+// DeleteWorkspaceEvents mocks base method.
+func (m *MockStore) DeleteWorkspaceEvents(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceEvents", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkspaceEvents indicates an expected call of DeleteWorkspaceEvents.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceEvents", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceEvents), arg0, arg1)
+}
+
+// DeleteWorkspaceInvitation mocks base method.
+func (m *MockStore) DeleteWorkspaceInvitation(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceInvitation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspaceInvitation indicates an expected call of DeleteWorkspaceInvitation.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceInvitation), arg0, arg1)
+}
+
+// DeleteWorkspaceInvitationsBatch mocks base method.
+func (m *MockStore) DeleteWorkspaceInvitationsBatch(arg0 context.Context, arg1 db.DeleteWorkspaceInvitationsBatchParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceInvitationsBatch", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkspaceInvitationsBatch indicates an expected call of DeleteWorkspaceInvitationsBatch.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceInvitationsBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceInvitationsBatch", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceInvitationsBatch), arg0, arg1)
+}
+
+// DeleteWorkspaceMessagesBatch mocks base method.
+func (m *MockStore) DeleteWorkspaceMessagesBatch(arg0 context.Context, arg1 db.DeleteWorkspaceMessagesBatchParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceMessagesBatch", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkspaceMessagesBatch indicates an expected call of DeleteWorkspaceMessagesBatch.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceMessagesBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceMessagesBatch", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceMessagesBatch), arg0, arg1)
+}
+
+// DeleteWorkspaceNotificationPreference mocks base method.
+func (m *MockStore) DeleteWorkspaceNotificationPreference(arg0 context.Context, arg1 db.DeleteWorkspaceNotificationPreferenceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkspaceNotificationPreference indicates an expected call of DeleteWorkspaceNotificationPreference.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceNotificationPreference", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceNotificationPreference), arg0, arg1)
+}
+
+// DeleteWorkspacePreferences mocks base method.
+func (m *MockStore) DeleteWorkspacePreferences(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspacePreferences", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkspacePreferences indicates an expected call of DeleteWorkspacePreferences.
+func (mr *MockStoreMockRecorder) DeleteWorkspacePreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspacePreferences", reflect.TypeOf((*MockStore)(nil).DeleteWorkspacePreferences), arg0, arg1)
+}
+
+// DeleteWorkspaceRole mocks base method.
+func (m *MockStore) DeleteWorkspaceRole(arg0 context.Context, arg1 db.DeleteWorkspaceRoleParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceRole", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkspaceRole indicates an expected call of DeleteWorkspaceRole.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceRole", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceRole), arg0, arg1)
+}
+
+// DeleteWorkspaceStatuses mocks base method.
+func (m *MockStore) DeleteWorkspaceStatuses(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceStatuses", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteWorkspaceStatuses indicates an expected call of DeleteWorkspaceStatuses.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceStatuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceStatuses", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceStatuses), arg0, arg1)
+}
+
+// DeleteWorkspaceTx mocks base method.
+func (m *MockStore) DeleteWorkspaceTx(arg0 context.Context, arg1 db.SoftDeleteWorkspaceParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspaceTx indicates an expected call of DeleteWorkspaceTx.
+func (mr *MockStoreMockRecorder) DeleteWorkspaceTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceTx", reflect.TypeOf((*MockStore)(nil).DeleteWorkspaceTx), arg0, arg1)
+}
+
+// DisableStatementTimeout mocks base method.
+func (m *MockStore) DisableStatementTimeout(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableStatementTimeout", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableStatementTimeout indicates an expected call of DisableStatementTimeout.
+func (mr *MockStoreMockRecorder) DisableStatementTimeout(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableStatementTimeout", reflect.TypeOf((*MockStore)(nil).DisableStatementTimeout), arg0)
+}
+
+// DropMessagePartition mocks base method.
+func (m *MockStore) DropMessagePartition(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropMessagePartition", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DropMessagePartition indicates an expected call of DropMessagePartition.
+func (mr *MockStoreMockRecorder) DropMessagePartition(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropMessagePartition", reflect.TypeOf((*MockStore)(nil).DropMessagePartition), arg0, arg1)
+}
+
+// DropMessagePartitionTx mocks base method.
+func (m *MockStore) DropMessagePartitionTx(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DropMessagePartitionTx", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DropMessagePartitionTx indicates an expected call of DropMessagePartitionTx.
+func (mr *MockStoreMockRecorder) DropMessagePartitionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropMessagePartitionTx", reflect.TypeOf((*MockStore)(nil).DropMessagePartitionTx), arg0, arg1)
+}
+
+// EnsureOrganizationOwner mocks base method.
+func (m *MockStore) EnsureOrganizationOwner(arg0 context.Context, arg1 db.EnsureOrganizationOwnerParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureOrganizationOwner", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureOrganizationOwner indicates an expected call of EnsureOrganizationOwner.
+func (mr *MockStoreMockRecorder) EnsureOrganizationOwner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureOrganizationOwner", reflect.TypeOf((*MockStore)(nil).EnsureOrganizationOwner), arg0, arg1)
+}
+
+// ExecTx mocks base method.
+func (m *MockStore) ExecTx(arg0 context.Context, arg1 func(db.Querier) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecTx indicates an expected call of ExecTx.
+func (mr *MockStoreMockRecorder) ExecTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTx", reflect.TypeOf((*MockStore)(nil).ExecTx), arg0, arg1)
+}
+
+// ExpireWorkspaceInvitation mocks base method.
+func (m *MockStore) ExpireWorkspaceInvitation(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireWorkspaceInvitation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireWorkspaceInvitation indicates an expected call of ExpireWorkspaceInvitation.
+func (mr *MockStoreMockRecorder) ExpireWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).ExpireWorkspaceInvitation), arg0, arg1)
+}
+
+// FailEmailDelivery mocks base method.
+func (m *MockStore) FailEmailDelivery(arg0 context.Context, arg1 db.FailEmailDeliveryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailEmailDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailEmailDelivery indicates an expected call of FailEmailDelivery.
+func (mr *MockStoreMockRecorder) FailEmailDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailEmailDelivery", reflect.TypeOf((*MockStore)(nil).FailEmailDelivery), arg0, arg1)
+}
+
+// FailWebhookDelivery mocks base method.
+func (m *MockStore) FailWebhookDelivery(arg0 context.Context, arg1 db.FailWebhookDeliveryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailWebhookDelivery indicates an expected call of FailWebhookDelivery.
+func (mr *MockStoreMockRecorder) FailWebhookDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailWebhookDelivery", reflect.TypeOf((*MockStore)(nil).FailWebhookDelivery), arg0, arg1)
+}
+
+// GetAbuseReport mocks base method.
+func (m *MockStore) GetAbuseReport(arg0 context.Context, arg1 db.GetAbuseReportParams) (db.AbuseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAbuseReport", arg0, arg1)
+	ret0, _ := ret[0].(db.AbuseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAbuseReport indicates an expected call of GetAbuseReport.
+func (mr *MockStoreMockRecorder) GetAbuseReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAbuseReport", reflect.TypeOf((*MockStore)(nil).GetAbuseReport), arg0, arg1)
+}
+
+// GetAcknowledgmentRequest mocks base method.
+func (m *MockStore) GetAcknowledgmentRequest(arg0 context.Context, arg1 int64) (db.MessageAcknowledgmentRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAcknowledgmentRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageAcknowledgmentRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAcknowledgmentRequest indicates an expected call of GetAcknowledgmentRequest.
+func (mr *MockStoreMockRecorder) GetAcknowledgmentRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAcknowledgmentRequest", reflect.TypeOf((*MockStore)(nil).GetAcknowledgmentRequest), arg0, arg1)
+}
+
+// GetActiveCalendarBusyBlocks mocks base method.
+func (m *MockStore) GetActiveCalendarBusyBlocks(arg0 context.Context) ([]db.GetActiveCalendarBusyBlocksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveCalendarBusyBlocks", arg0)
+	ret0, _ := ret[0].([]db.GetActiveCalendarBusyBlocksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveCalendarBusyBlocks indicates an expected call of GetActiveCalendarBusyBlocks.
+func (mr *MockStoreMockRecorder) GetActiveCalendarBusyBlocks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveCalendarBusyBlocks", reflect.TypeOf((*MockStore)(nil).GetActiveCalendarBusyBlocks), arg0)
+}
+
+// GetActiveOutOfOffice mocks base method.
+func (m *MockStore) GetActiveOutOfOffice(arg0 context.Context, arg1 db.GetActiveOutOfOfficeParams) (db.OutOfOffice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveOutOfOffice", arg0, arg1)
+	ret0, _ := ret[0].(db.OutOfOffice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveOutOfOffice indicates an expected call of GetActiveOutOfOffice.
+func (mr *MockStoreMockRecorder) GetActiveOutOfOffice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveOutOfOffice", reflect.TypeOf((*MockStore)(nil).GetActiveOutOfOffice), arg0, arg1)
+}
+
+// GetCalendarIntegration mocks base method.
+func (m *MockStore) GetCalendarIntegration(arg0 context.Context, arg1 db.GetCalendarIntegrationParams) (db.CalendarIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarIntegration", arg0, arg1)
+	ret0, _ := ret[0].(db.CalendarIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarIntegration indicates an expected call of GetCalendarIntegration.
+func (mr *MockStoreMockRecorder) GetCalendarIntegration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarIntegration", reflect.TypeOf((*MockStore)(nil).GetCalendarIntegration), arg0, arg1)
+}
+
+// GetCanvas mocks base method.
+func (m *MockStore) GetCanvas(arg0 context.Context, arg1 db.GetCanvasParams) (db.Canvas, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCanvas", arg0, arg1)
+	ret0, _ := ret[0].(db.Canvas)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCanvas indicates an expected call of GetCanvas.
+func (mr *MockStoreMockRecorder) GetCanvas(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCanvas", reflect.TypeOf((*MockStore)(nil).GetCanvas), arg0, arg1)
+}
+
+// GetCanvasRevision mocks base method.
+func (m *MockStore) GetCanvasRevision(arg0 context.Context, arg1 db.GetCanvasRevisionParams) (db.CanvasRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCanvasRevision", arg0, arg1)
+	ret0, _ := ret[0].(db.CanvasRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCanvasRevision indicates an expected call of GetCanvasRevision.
+func (mr *MockStoreMockRecorder) GetCanvasRevision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCanvasRevision", reflect.TypeOf((*MockStore)(nil).GetCanvasRevision), arg0, arg1)
+}
+
+// GetChannel mocks base method.
+func (m *MockStore) GetChannel(arg0 context.Context, arg1 int64) (db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannel", arg0, arg1)
+	ret0, _ := ret[0].(db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannel indicates an expected call of GetChannel.
+func (mr *MockStoreMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockStore)(nil).GetChannel), arg0, arg1)
+}
+
+// GetChannelByID mocks base method.
+func (m *MockStore) GetChannelByID(arg0 context.Context, arg1 int64) (db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelByID", arg0, arg1)
+	ret0, _ := ret[0].(db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelByID indicates an expected call of GetChannelByID.
+func (mr *MockStoreMockRecorder) GetChannelByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelByID", reflect.TypeOf((*MockStore)(nil).GetChannelByID), arg0, arg1)
+}
+
+// GetChannelByName mocks base method.
+func (m *MockStore) GetChannelByName(arg0 context.Context, arg1 db.GetChannelByNameParams) (db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelByName", arg0, arg1)
+	ret0, _ := ret[0].(db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelByName indicates an expected call of GetChannelByName.
+func (mr *MockStoreMockRecorder) GetChannelByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelByName", reflect.TypeOf((*MockStore)(nil).GetChannelByName), arg0, arg1)
+}
+
+// GetChannelLastActivity mocks base method.
+func (m *MockStore) GetChannelLastActivity(arg0 context.Context, arg1 int64) (db.GetChannelLastActivityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelLastActivity", arg0, arg1)
+	ret0, _ := ret[0].(db.GetChannelLastActivityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelLastActivity indicates an expected call of GetChannelLastActivity.
+func (mr *MockStoreMockRecorder) GetChannelLastActivity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelLastActivity", reflect.TypeOf((*MockStore)(nil).GetChannelLastActivity), arg0, arg1)
+}
+
+// GetChannelMembers mocks base method.
+func (m *MockStore) GetChannelMembers(arg0 context.Context, arg1 db.GetChannelMembersParams) ([]db.GetChannelMembersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMembers", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetChannelMembersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelMembers indicates an expected call of GetChannelMembers.
+func (mr *MockStoreMockRecorder) GetChannelMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMembers", reflect.TypeOf((*MockStore)(nil).GetChannelMembers), arg0, arg1)
+}
+
+// GetChannelMessages mocks base method.
+func (m *MockStore) GetChannelMessages(arg0 context.Context, arg1 db.GetChannelMessagesParams) ([]db.GetChannelMessagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMessages", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetChannelMessagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelMessages indicates an expected call of GetChannelMessages.
+func (mr *MockStoreMockRecorder) GetChannelMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMessages", reflect.TypeOf((*MockStore)(nil).GetChannelMessages), arg0, arg1)
+}
+
+// GetChannelNotificationDefault mocks base method.
+func (m *MockStore) GetChannelNotificationDefault(arg0 context.Context, arg1 int64) (db.ChannelNotificationDefault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelNotificationDefault", arg0, arg1)
+	ret0, _ := ret[0].(db.ChannelNotificationDefault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelNotificationDefault indicates an expected call of GetChannelNotificationDefault.
+func (mr *MockStoreMockRecorder) GetChannelNotificationDefault(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelNotificationDefault", reflect.TypeOf((*MockStore)(nil).GetChannelNotificationDefault), arg0, arg1)
+}
+
+// GetChannelReadState mocks base method.
+func (m *MockStore) GetChannelReadState(arg0 context.Context, arg1 db.GetChannelReadStateParams) (db.ChannelReadState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelReadState", arg0, arg1)
+	ret0, _ := ret[0].(db.ChannelReadState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelReadState indicates an expected call of GetChannelReadState.
+func (mr *MockStoreMockRecorder) GetChannelReadState(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelReadState", reflect.TypeOf((*MockStore)(nil).GetChannelReadState), arg0, arg1)
+}
+
+// GetChannelWithCreator mocks base method.
+func (m *MockStore) GetChannelWithCreator(arg0 context.Context, arg1 int64) (db.GetChannelWithCreatorRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelWithCreator", arg0, arg1)
+	ret0, _ := ret[0].(db.GetChannelWithCreatorRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelWithCreator indicates an expected call of GetChannelWithCreator.
+func (mr *MockStoreMockRecorder) GetChannelWithCreator(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelWithCreator", reflect.TypeOf((*MockStore)(nil).GetChannelWithCreator), arg0, arg1)
+}
+
+// GetCustomEmoji mocks base method.
+func (m *MockStore) GetCustomEmoji(arg0 context.Context, arg1 db.GetCustomEmojiParams) (db.CustomEmoji, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomEmoji", arg0, arg1)
+	ret0, _ := ret[0].(db.CustomEmoji)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomEmoji indicates an expected call of GetCustomEmoji.
+func (mr *MockStoreMockRecorder) GetCustomEmoji(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomEmoji", reflect.TypeOf((*MockStore)(nil).GetCustomEmoji), arg0, arg1)
+}
+
+// GetDirectMessagesBetweenUsers mocks base method.
+func (m *MockStore) GetDirectMessagesBetweenUsers(arg0 context.Context, arg1 db.GetDirectMessagesBetweenUsersParams) ([]db.GetDirectMessagesBetweenUsersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectMessagesBetweenUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetDirectMessagesBetweenUsersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectMessagesBetweenUsers indicates an expected call of GetDirectMessagesBetweenUsers.
+func (mr *MockStoreMockRecorder) GetDirectMessagesBetweenUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectMessagesBetweenUsers", reflect.TypeOf((*MockStore)(nil).GetDirectMessagesBetweenUsers), arg0, arg1)
+}
+
+// GetDoNotDisturb mocks base method.
+func (m *MockStore) GetDoNotDisturb(arg0 context.Context, arg1 db.GetDoNotDisturbParams) (db.GetDoNotDisturbRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDoNotDisturb", arg0, arg1)
+	ret0, _ := ret[0].(db.GetDoNotDisturbRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDoNotDisturb indicates an expected call of GetDoNotDisturb.
+func (mr *MockStoreMockRecorder) GetDoNotDisturb(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDoNotDisturb", reflect.TypeOf((*MockStore)(nil).GetDoNotDisturb), arg0, arg1)
+}
+
+// GetDuplicateFiles mocks base method.
+func (m *MockStore) GetDuplicateFiles(arg0 context.Context, arg1 int64) ([]db.GetDuplicateFilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicateFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetDuplicateFilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicateFiles indicates an expected call of GetDuplicateFiles.
+func (mr *MockStoreMockRecorder) GetDuplicateFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicateFiles", reflect.TypeOf((*MockStore)(nil).GetDuplicateFiles), arg0, arg1)
+}
+
+// GetEarliestChannelMessageTime mocks base method.
+func (m *MockStore) GetEarliestChannelMessageTime(arg0 context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEarliestChannelMessageTime", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEarliestChannelMessageTime indicates an expected call of GetEarliestChannelMessageTime.
+func (mr *MockStoreMockRecorder) GetEarliestChannelMessageTime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEarliestChannelMessageTime", reflect.TypeOf((*MockStore)(nil).GetEarliestChannelMessageTime), arg0)
+}
+
+// GetEffectiveNotificationPreference mocks base method.
+func (m *MockStore) GetEffectiveNotificationPreference(arg0 context.Context, arg1 db.GetEffectiveNotificationPreferenceParams) (db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectiveNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectiveNotificationPreference indicates an expected call of GetEffectiveNotificationPreference.
+func (mr *MockStoreMockRecorder) GetEffectiveNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectiveNotificationPreference", reflect.TypeOf((*MockStore)(nil).GetEffectiveNotificationPreference), arg0, arg1)
+}
+
+// GetEmailBranding mocks base method.
+func (m *MockStore) GetEmailBranding(arg0 context.Context, arg1 int64) (db.EmailBranding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailBranding", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailBranding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailBranding indicates an expected call of GetEmailBranding.
+func (mr *MockStoreMockRecorder) GetEmailBranding(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailBranding", reflect.TypeOf((*MockStore)(nil).GetEmailBranding), arg0, arg1)
+}
+
+// GetEmailSuppression mocks base method.
+func (m *MockStore) GetEmailSuppression(arg0 context.Context, arg1 string) (db.EmailSuppression, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailSuppression", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailSuppression)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailSuppression indicates an expected call of GetEmailSuppression.
+func (mr *MockStoreMockRecorder) GetEmailSuppression(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailSuppression", reflect.TypeOf((*MockStore)(nil).GetEmailSuppression), arg0, arg1)
+}
+
+// GetEmailTemplate mocks base method.
+func (m *MockStore) GetEmailTemplate(arg0 context.Context, arg1 db.GetEmailTemplateParams) (db.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailTemplate indicates an expected call of GetEmailTemplate.
+func (mr *MockStoreMockRecorder) GetEmailTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailTemplate", reflect.TypeOf((*MockStore)(nil).GetEmailTemplate), arg0, arg1)
+}
+
+// GetExternalDMPolicy mocks base method.
+func (m *MockStore) GetExternalDMPolicy(arg0 context.Context, arg1 int64) (db.ExternalDmPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalDMPolicy", arg0, arg1)
+	ret0, _ := ret[0].(db.ExternalDmPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExternalDMPolicy indicates an expected call of GetExternalDMPolicy.
+func (mr *MockStoreMockRecorder) GetExternalDMPolicy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalDMPolicy", reflect.TypeOf((*MockStore)(nil).GetExternalDMPolicy), arg0, arg1)
+}
+
+// GetExternalDMRequest mocks base method.
+func (m *MockStore) GetExternalDMRequest(arg0 context.Context, arg1 int64) (db.GetExternalDMRequestRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalDMRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.GetExternalDMRequestRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExternalDMRequest indicates an expected call of GetExternalDMRequest.
+func (mr *MockStoreMockRecorder) GetExternalDMRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalDMRequest", reflect.TypeOf((*MockStore)(nil).GetExternalDMRequest), arg0, arg1)
+}
+
+// GetFile mocks base method.
+func (m *MockStore) GetFile(arg0 context.Context, arg1 int64) (db.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFile", arg0, arg1)
+	ret0, _ := ret[0].(db.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockStoreMockRecorder) GetFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockStore)(nil).GetFile), arg0, arg1)
+}
+
+// GetFileByHash mocks base method.
+func (m *MockStore) GetFileByHash(arg0 context.Context, arg1 db.GetFileByHashParams) (db.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileByHash", arg0, arg1)
+	ret0, _ := ret[0].(db.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileByHash indicates an expected call of GetFileByHash.
+func (mr *MockStoreMockRecorder) GetFileByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileByHash", reflect.TypeOf((*MockStore)(nil).GetFileByHash), arg0, arg1)
+}
+
+// GetFileMessages mocks base method.
+func (m *MockStore) GetFileMessages(arg0 context.Context, arg1 int64) ([]db.GetFileMessagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileMessages", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetFileMessagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileMessages indicates an expected call of GetFileMessages.
+func (mr *MockStoreMockRecorder) GetFileMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileMessages", reflect.TypeOf((*MockStore)(nil).GetFileMessages), arg0, arg1)
+}
+
+// GetFileShares mocks base method.
+func (m *MockStore) GetFileShares(arg0 context.Context, arg1 int64) ([]db.GetFileSharesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileShares", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetFileSharesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileShares indicates an expected call of GetFileShares.
+func (mr *MockStoreMockRecorder) GetFileShares(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileShares", reflect.TypeOf((*MockStore)(nil).GetFileShares), arg0, arg1)
+}
+
+// GetFileStats mocks base method.
+func (m *MockStore) GetFileStats(arg0 context.Context, arg1 int64) (db.GetFileStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileStats", arg0, arg1)
+	ret0, _ := ret[0].(db.GetFileStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileStats indicates an expected call of GetFileStats.
+func (mr *MockStoreMockRecorder) GetFileStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileStats", reflect.TypeOf((*MockStore)(nil).GetFileStats), arg0, arg1)
+}
+
+// GetFileWithPermissionCheck mocks base method.
+func (m *MockStore) GetFileWithPermissionCheck(arg0 context.Context, arg1 db.GetFileWithPermissionCheckParams) (db.GetFileWithPermissionCheckRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileWithPermissionCheck", arg0, arg1)
+	ret0, _ := ret[0].(db.GetFileWithPermissionCheckRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileWithPermissionCheck indicates an expected call of GetFileWithPermissionCheck.
+func (mr *MockStoreMockRecorder) GetFileWithPermissionCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileWithPermissionCheck", reflect.TypeOf((*MockStore)(nil).GetFileWithPermissionCheck), arg0, arg1)
+}
+
+// GetLatestChannelStatsDay mocks base method.
+func (m *MockStore) GetLatestChannelStatsDay(arg0 context.Context) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestChannelStatsDay", arg0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestChannelStatsDay indicates an expected call of GetLatestChannelStatsDay.
+func (mr *MockStoreMockRecorder) GetLatestChannelStatsDay(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestChannelStatsDay", reflect.TypeOf((*MockStore)(nil).GetLatestChannelStatsDay), arg0)
+}
+
+// GetLegalHold mocks base method.
+func (m *MockStore) GetLegalHold(arg0 context.Context, arg1 db.GetLegalHoldParams) (db.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(db.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLegalHold indicates an expected call of GetLegalHold.
+func (mr *MockStoreMockRecorder) GetLegalHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLegalHold", reflect.TypeOf((*MockStore)(nil).GetLegalHold), arg0, arg1)
+}
+
+// GetMessageByID mocks base method.
+func (m *MockStore) GetMessageByID(arg0 context.Context, arg1 int64) (db.GetMessageByIDRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageByID", arg0, arg1)
+	ret0, _ := ret[0].(db.GetMessageByIDRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageByID indicates an expected call of GetMessageByID.
+func (mr *MockStoreMockRecorder) GetMessageByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*MockStore)(nil).GetMessageByID), arg0, arg1)
+}
+
+// GetMessageFiles mocks base method.
+func (m *MockStore) GetMessageFiles(arg0 context.Context, arg1 int64) ([]db.GetMessageFilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetMessageFilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageFiles indicates an expected call of GetMessageFiles.
+func (mr *MockStoreMockRecorder) GetMessageFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageFiles", reflect.TypeOf((*MockStore)(nil).GetMessageFiles), arg0, arg1)
+}
+
+// GetMessageTranslation mocks base method.
+func (m *MockStore) GetMessageTranslation(arg0 context.Context, arg1 db.GetMessageTranslationParams) (db.MessageTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageTranslation", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageTranslation indicates an expected call of GetMessageTranslation.
+func (mr *MockStoreMockRecorder) GetMessageTranslation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageTranslation", reflect.TypeOf((*MockStore)(nil).GetMessageTranslation), arg0, arg1)
+}
+
+// GetMessagesAfter mocks base method.
+func (m *MockStore) GetMessagesAfter(arg0 context.Context, arg1 db.GetMessagesAfterParams) ([]db.GetMessagesAfterRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagesAfter", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetMessagesAfterRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessagesAfter indicates an expected call of GetMessagesAfter.
+func (mr *MockStoreMockRecorder) GetMessagesAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesAfter", reflect.TypeOf((*MockStore)(nil).GetMessagesAfter), arg0, arg1)
+}
+
+// GetMessagesBefore mocks base method.
+func (m *MockStore) GetMessagesBefore(arg0 context.Context, arg1 db.GetMessagesBeforeParams) ([]db.GetMessagesBeforeRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagesBefore", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetMessagesBeforeRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessagesBefore indicates an expected call of GetMessagesBefore.
+func (mr *MockStoreMockRecorder) GetMessagesBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesBefore", reflect.TypeOf((*MockStore)(nil).GetMessagesBefore), arg0, arg1)
+}
+
+// GetModerationQueueItem mocks base method.
+func (m *MockStore) GetModerationQueueItem(arg0 context.Context, arg1 db.GetModerationQueueItemParams) (db.ModerationQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationQueueItem", arg0, arg1)
+	ret0, _ := ret[0].(db.ModerationQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetModerationQueueItem indicates an expected call of GetModerationQueueItem.
+func (mr *MockStoreMockRecorder) GetModerationQueueItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueueItem", reflect.TypeOf((*MockStore)(nil).GetModerationQueueItem), arg0, arg1)
+}
+
+// GetOnboardingBotTx mocks base method.
+func (m *MockStore) GetOnboardingBotTx(arg0 context.Context, arg1 db.GetOnboardingBotTxParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOnboardingBotTx", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOnboardingBotTx indicates an expected call of GetOnboardingBotTx.
+func (mr *MockStoreMockRecorder) GetOnboardingBotTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOnboardingBotTx", reflect.TypeOf((*MockStore)(nil).GetOnboardingBotTx), arg0, arg1)
+}
+
+// GetOnlineUsersInWorkspace mocks base method.
+func (m *MockStore) GetOnlineUsersInWorkspace(arg0 context.Context, arg1 int64) ([]db.GetOnlineUsersInWorkspaceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOnlineUsersInWorkspace", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetOnlineUsersInWorkspaceRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOnlineUsersInWorkspace indicates an expected call of GetOnlineUsersInWorkspace.
+func (mr *MockStoreMockRecorder) GetOnlineUsersInWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOnlineUsersInWorkspace", reflect.TypeOf((*MockStore)(nil).GetOnlineUsersInWorkspace), arg0, arg1)
+}
+
+// GetOrganization mocks base method.
+func (m *MockStore) GetOrganization(arg0 context.Context, arg1 int64) (db.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganization", arg0, arg1)
+	ret0, _ := ret[0].(db.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganization indicates an expected call of GetOrganization.
+func (mr *MockStoreMockRecorder) GetOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockStore)(nil).GetOrganization), arg0, arg1)
+}
+
+// GetOrganizationDeletion mocks base method.
+func (m *MockStore) GetOrganizationDeletion(arg0 context.Context, arg1 int64) (db.OrganizationDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationDeletion", arg0, arg1)
+	ret0, _ := ret[0].(db.OrganizationDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationDeletion indicates an expected call of GetOrganizationDeletion.
+func (mr *MockStoreMockRecorder) GetOrganizationDeletion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationDeletion", reflect.TypeOf((*MockStore)(nil).GetOrganizationDeletion), arg0, arg1)
+}
+
+// GetOrganizationRole mocks base method.
+func (m *MockStore) GetOrganizationRole(arg0 context.Context, arg1 db.GetOrganizationRoleParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationRole", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationRole indicates an expected call of GetOrganizationRole.
+func (mr *MockStoreMockRecorder) GetOrganizationRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationRole", reflect.TypeOf((*MockStore)(nil).GetOrganizationRole), arg0, arg1)
+}
+
+// GetOrganizationTeardownProgress mocks base method.
+func (m *MockStore) GetOrganizationTeardownProgress(arg0 context.Context, arg1 db.GetOrganizationTeardownProgressParams) (db.GetOrganizationTeardownProgressRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationTeardownProgress", arg0, arg1)
+	ret0, _ := ret[0].(db.GetOrganizationTeardownProgressRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationTeardownProgress indicates an expected call of GetOrganizationTeardownProgress.
+func (mr *MockStoreMockRecorder) GetOrganizationTeardownProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationTeardownProgress", reflect.TypeOf((*MockStore)(nil).GetOrganizationTeardownProgress), arg0, arg1)
+}
+
+// GetOutOfOffice mocks base method.
+func (m *MockStore) GetOutOfOffice(arg0 context.Context, arg1 db.GetOutOfOfficeParams) (db.OutOfOffice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutOfOffice", arg0, arg1)
+	ret0, _ := ret[0].(db.OutOfOffice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutOfOffice indicates an expected call of GetOutOfOffice.
+func (mr *MockStoreMockRecorder) GetOutOfOffice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutOfOffice", reflect.TypeOf((*MockStore)(nil).GetOutOfOffice), arg0, arg1)
+}
+
+// GetOutgoingWebhook mocks base method.
+func (m *MockStore) GetOutgoingWebhook(arg0 context.Context, arg1 int64) (db.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutgoingWebhook", arg0, arg1)
+	ret0, _ := ret[0].(db.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutgoingWebhook indicates an expected call of GetOutgoingWebhook.
+func (mr *MockStoreMockRecorder) GetOutgoingWebhook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhook", reflect.TypeOf((*MockStore)(nil).GetOutgoingWebhook), arg0, arg1)
+}
+
+// GetPendingInvitationsForUser mocks base method.
+func (m *MockStore) GetPendingInvitationsForUser(arg0 context.Context, arg1 string) ([]db.GetPendingInvitationsForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingInvitationsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetPendingInvitationsForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingInvitationsForUser indicates an expected call of GetPendingInvitationsForUser.
+func (mr *MockStoreMockRecorder) GetPendingInvitationsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingInvitationsForUser", reflect.TypeOf((*MockStore)(nil).GetPendingInvitationsForUser), arg0, arg1)
+}
+
+// GetReactionCounts mocks base method.
+func (m *MockStore) GetReactionCounts(arg0 context.Context, arg1 db.GetReactionCountsParams) (db.GetReactionCountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactionCounts", arg0, arg1)
+	ret0, _ := ret[0].(db.GetReactionCountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactionCounts indicates an expected call of GetReactionCounts.
+func (mr *MockStoreMockRecorder) GetReactionCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactionCounts", reflect.TypeOf((*MockStore)(nil).GetReactionCounts), arg0, arg1)
+}
+
+// GetRecentWorkspaceMessages mocks base method.
+func (m *MockStore) GetRecentWorkspaceMessages(arg0 context.Context, arg1 db.GetRecentWorkspaceMessagesParams) ([]db.GetRecentWorkspaceMessagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentWorkspaceMessages", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetRecentWorkspaceMessagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentWorkspaceMessages indicates an expected call of GetRecentWorkspaceMessages.
+func (mr *MockStoreMockRecorder) GetRecentWorkspaceMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentWorkspaceMessages", reflect.TypeOf((*MockStore)(nil).GetRecentWorkspaceMessages), arg0, arg1)
+}
+
+// GetSavedSearch mocks base method.
+func (m *MockStore) GetSavedSearch(arg0 context.Context, arg1 db.GetSavedSearchParams) (db.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedSearch indicates an expected call of GetSavedSearch.
+func (mr *MockStoreMockRecorder) GetSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedSearch", reflect.TypeOf((*MockStore)(nil).GetSavedSearch), arg0, arg1)
+}
+
+// GetTrashedFile mocks base method.
+func (m *MockStore) GetTrashedFile(arg0 context.Context, arg1 db.GetTrashedFileParams) (db.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedFile", arg0, arg1)
+	ret0, _ := ret[0].(db.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashedFile indicates an expected call of GetTrashedFile.
+func (mr *MockStoreMockRecorder) GetTrashedFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedFile", reflect.TypeOf((*MockStore)(nil).GetTrashedFile), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 int64) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+}
+
+// GetUserChannels mocks base method.
+func (m *MockStore) GetUserChannels(arg0 context.Context, arg1 db.GetUserChannelsParams) ([]db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserChannels", arg0, arg1)
+	ret0, _ := ret[0].([]db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserChannels indicates an expected call of GetUserChannels.
+func (mr *MockStoreMockRecorder) GetUserChannels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChannels", reflect.TypeOf((*MockStore)(nil).GetUserChannels), arg0, arg1)
+}
+
+// GetUserDevice mocks base method.
+func (m *MockStore) GetUserDevice(arg0 context.Context, arg1 db.GetUserDeviceParams) (db.UserDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDevice", arg0, arg1)
+	ret0, _ := ret[0].(db.UserDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDevice indicates an expected call of GetUserDevice.
+func (mr *MockStoreMockRecorder) GetUserDevice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDevice", reflect.TypeOf((*MockStore)(nil).GetUserDevice), arg0, arg1)
+}
+
+// GetUserRolePermissions mocks base method.
+func (m *MockStore) GetUserRolePermissions(arg0 context.Context, arg1 db.GetUserRolePermissionsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRolePermissions", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRolePermissions indicates an expected call of GetUserRolePermissions.
+func (mr *MockStoreMockRecorder) GetUserRolePermissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRolePermissions", reflect.TypeOf((*MockStore)(nil).GetUserRolePermissions), arg0, arg1)
+}
+
+// GetUserStatus mocks base method.
+func (m *MockStore) GetUserStatus(arg0 context.Context, arg1 db.GetUserStatusParams) (db.UserStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.UserStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStatus indicates an expected call of GetUserStatus.
+func (mr *MockStoreMockRecorder) GetUserStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStatus", reflect.TypeOf((*MockStore)(nil).GetUserStatus), arg0, arg1)
+}
+
+// GetUsersByWorkspace mocks base method.
+func (m *MockStore) GetUsersByWorkspace(arg0 context.Context, arg1 db.GetUsersByWorkspaceParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByWorkspace", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByWorkspace indicates an expected call of GetUsersByWorkspace.
+func (mr *MockStoreMockRecorder) GetUsersByWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByWorkspace", reflect.TypeOf((*MockStore)(nil).GetUsersByWorkspace), arg0, arg1)
+}
+
+// GetWorkspace mocks base method.
+func (m *MockStore) GetWorkspace(arg0 context.Context, arg1 int64) (db.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(db.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspace indicates an expected call of GetWorkspace.
+func (mr *MockStoreMockRecorder) GetWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspace", reflect.TypeOf((*MockStore)(nil).GetWorkspace), arg0, arg1)
+}
+
+// GetWorkspaceAutoJoinDomain mocks base method.
+func (m *MockStore) GetWorkspaceAutoJoinDomain(arg0 context.Context, arg1 db.GetWorkspaceAutoJoinDomainParams) (db.WorkspaceAutoJoinDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceAutoJoinDomain", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceAutoJoinDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceAutoJoinDomain indicates an expected call of GetWorkspaceAutoJoinDomain.
+func (mr *MockStoreMockRecorder) GetWorkspaceAutoJoinDomain(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceAutoJoinDomain", reflect.TypeOf((*MockStore)(nil).GetWorkspaceAutoJoinDomain), arg0, arg1)
+}
+
+// GetWorkspaceBanner mocks base method.
+func (m *MockStore) GetWorkspaceBanner(arg0 context.Context, arg1 db.GetWorkspaceBannerParams) (db.WorkspaceBanner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceBanner", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceBanner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceBanner indicates an expected call of GetWorkspaceBanner.
+func (mr *MockStoreMockRecorder) GetWorkspaceBanner(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceBanner", reflect.TypeOf((*MockStore)(nil).GetWorkspaceBanner), arg0, arg1)
+}
+
+// GetWorkspaceByID mocks base method.
+func (m *MockStore) GetWorkspaceByID(arg0 context.Context, arg1 int64) (db.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceByID", arg0, arg1)
+	ret0, _ := ret[0].(db.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceByID indicates an expected call of GetWorkspaceByID.
+func (mr *MockStoreMockRecorder) GetWorkspaceByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceByID), arg0, arg1)
+}
+
+// GetWorkspaceInvitation mocks base method.
+func (m *MockStore) GetWorkspaceInvitation(arg0 context.Context, arg1 int64) (db.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceInvitation indicates an expected call of GetWorkspaceInvitation.
+func (mr *MockStoreMockRecorder) GetWorkspaceInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceInvitation", reflect.TypeOf((*MockStore)(nil).GetWorkspaceInvitation), arg0, arg1)
+}
+
+// GetWorkspaceInvitationByCode mocks base method.
+func (m *MockStore) GetWorkspaceInvitationByCode(arg0 context.Context, arg1 string) (db.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceInvitationByCode", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceInvitationByCode indicates an expected call of GetWorkspaceInvitationByCode.
+func (mr *MockStoreMockRecorder) GetWorkspaceInvitationByCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceInvitationByCode", reflect.TypeOf((*MockStore)(nil).GetWorkspaceInvitationByCode), arg0, arg1)
+}
+
+// GetWorkspaceJoinLinkByToken mocks base method.
+func (m *MockStore) GetWorkspaceJoinLinkByToken(arg0 context.Context, arg1 string) (db.WorkspaceJoinLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceJoinLinkByToken", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceJoinLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceJoinLinkByToken indicates an expected call of GetWorkspaceJoinLinkByToken.
+func (mr *MockStoreMockRecorder) GetWorkspaceJoinLinkByToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceJoinLinkByToken", reflect.TypeOf((*MockStore)(nil).GetWorkspaceJoinLinkByToken), arg0, arg1)
+}
+
+// GetWorkspaceJoinRequest mocks base method.
+func (m *MockStore) GetWorkspaceJoinRequest(arg0 context.Context, arg1 db.GetWorkspaceJoinRequestParams) (db.WorkspaceJoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceJoinRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceJoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceJoinRequest indicates an expected call of GetWorkspaceJoinRequest.
+func (mr *MockStoreMockRecorder) GetWorkspaceJoinRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceJoinRequest", reflect.TypeOf((*MockStore)(nil).GetWorkspaceJoinRequest), arg0, arg1)
+}
+
+// GetWorkspaceMemberCount mocks base method.
+func (m *MockStore) GetWorkspaceMemberCount(arg0 context.Context, arg1 sql.NullInt64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceMemberCount", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceMemberCount indicates an expected call of GetWorkspaceMemberCount.
+func (mr *MockStoreMockRecorder) GetWorkspaceMemberCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceMemberCount", reflect.TypeOf((*MockStore)(nil).GetWorkspaceMemberCount), arg0, arg1)
+}
+
+// GetWorkspaceOnboarding mocks base method.
+func (m *MockStore) GetWorkspaceOnboarding(arg0 context.Context, arg1 int64) (db.WorkspaceOnboarding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceOnboarding", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceOnboarding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceOnboarding indicates an expected call of GetWorkspaceOnboarding.
+func (mr *MockStoreMockRecorder) GetWorkspaceOnboarding(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceOnboarding", reflect.TypeOf((*MockStore)(nil).GetWorkspaceOnboarding), arg0, arg1)
+}
+
+// GetWorkspaceOnboardingForUpdate mocks base method.
+func (m *MockStore) GetWorkspaceOnboardingForUpdate(arg0 context.Context, arg1 int64) (db.WorkspaceOnboarding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceOnboardingForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceOnboarding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceOnboardingForUpdate indicates an expected call of GetWorkspaceOnboardingForUpdate.
+func (mr *MockStoreMockRecorder) GetWorkspaceOnboardingForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceOnboardingForUpdate", reflect.TypeOf((*MockStore)(nil).GetWorkspaceOnboardingForUpdate), arg0, arg1)
+}
+
+// GetWorkspaceRole mocks base method.
+func (m *MockStore) GetWorkspaceRole(arg0 context.Context, arg1 db.GetWorkspaceRoleParams) (db.WorkspaceRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceRole", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceRole indicates an expected call of GetWorkspaceRole.
+func (mr *MockStoreMockRecorder) GetWorkspaceRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceRole", reflect.TypeOf((*MockStore)(nil).GetWorkspaceRole), arg0, arg1)
+}
+
+// GetWorkspaceSettings mocks base method.
+func (m *MockStore) GetWorkspaceSettings(arg0 context.Context, arg1 int64) (db.WorkspaceSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceSettings", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceSettings indicates an expected call of GetWorkspaceSettings.
+func (mr *MockStoreMockRecorder) GetWorkspaceSettings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceSettings", reflect.TypeOf((*MockStore)(nil).GetWorkspaceSettings), arg0, arg1)
+}
+
+// GetWorkspaceTeardown mocks base method.
+func (m *MockStore) GetWorkspaceTeardown(arg0 context.Context, arg1 db.GetWorkspaceTeardownParams) (db.WorkspaceTeardown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceTeardown", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceTeardown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceTeardown indicates an expected call of GetWorkspaceTeardown.
+func (mr *MockStoreMockRecorder) GetWorkspaceTeardown(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceTeardown", reflect.TypeOf((*MockStore)(nil).GetWorkspaceTeardown), arg0, arg1)
+}
+
+// GetWorkspaceUserStatuses mocks base method.
+func (m *MockStore) GetWorkspaceUserStatuses(arg0 context.Context, arg1 db.GetWorkspaceUserStatusesParams) ([]db.GetWorkspaceUserStatusesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceUserStatuses", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetWorkspaceUserStatusesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceUserStatuses indicates an expected call of GetWorkspaceUserStatuses.
+func (mr *MockStoreMockRecorder) GetWorkspaceUserStatuses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceUserStatuses", reflect.TypeOf((*MockStore)(nil).GetWorkspaceUserStatuses), arg0, arg1)
+}
+
+// GetWorkspaceWithUserCount mocks base method.
+func (m *MockStore) GetWorkspaceWithUserCount(arg0 context.Context, arg1 int64) (db.GetWorkspaceWithUserCountRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceWithUserCount", arg0, arg1)
+	ret0, _ := ret[0].(db.GetWorkspaceWithUserCountRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceWithUserCount indicates an expected call of GetWorkspaceWithUserCount.
+func (mr *MockStoreMockRecorder) GetWorkspaceWithUserCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceWithUserCount", reflect.TypeOf((*MockStore)(nil).GetWorkspaceWithUserCount), arg0, arg1)
+}
+
+// HasActiveLegalHold mocks base method.
+func (m *MockStore) HasActiveLegalHold(arg0 context.Context, arg1 db.HasActiveLegalHoldParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasActiveLegalHold indicates an expected call of HasActiveLegalHold.
+func (mr *MockStoreMockRecorder) HasActiveLegalHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveLegalHold", reflect.TypeOf((*MockStore)(nil).HasActiveLegalHold), arg0, arg1)
+}
+
+// HasUserPostedInWorkspace mocks base method.
+func (m *MockStore) HasUserPostedInWorkspace(arg0 context.Context, arg1 db.HasUserPostedInWorkspaceParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasUserPostedInWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasUserPostedInWorkspace indicates an expected call of HasUserPostedInWorkspace.
+func (mr *MockStoreMockRecorder) HasUserPostedInWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasUserPostedInWorkspace", reflect.TypeOf((*MockStore)(nil).HasUserPostedInWorkspace), arg0, arg1)
+}
+
+// IsChannelMember mocks base method.
+func (m *MockStore) IsChannelMember(arg0 context.Context, arg1 db.IsChannelMemberParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsChannelMember", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsChannelMember indicates an expected call of IsChannelMember.
+func (mr *MockStoreMockRecorder) IsChannelMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsChannelMember", reflect.TypeOf((*MockStore)(nil).IsChannelMember), arg0, arg1)
+}
+
+// IsFileInPrivateChannel mocks base method.
+func (m *MockStore) IsFileInPrivateChannel(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFileInPrivateChannel", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFileInPrivateChannel indicates an expected call of IsFileInPrivateChannel.
+func (mr *MockStoreMockRecorder) IsFileInPrivateChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFileInPrivateChannel", reflect.TypeOf((*MockStore)(nil).IsFileInPrivateChannel), arg0, arg1)
+}
+
+// JoinWorkspaceByLinkTx mocks base method.
+func (m *MockStore) JoinWorkspaceByLinkTx(arg0 context.Context, arg1 db.JoinWorkspaceByLinkTxParams) (db.JoinWorkspaceByLinkTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinWorkspaceByLinkTx", arg0, arg1)
+	ret0, _ := ret[0].(db.JoinWorkspaceByLinkTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinWorkspaceByLinkTx indicates an expected call of JoinWorkspaceByLinkTx.
+func (mr *MockStoreMockRecorder) JoinWorkspaceByLinkTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinWorkspaceByLinkTx", reflect.TypeOf((*MockStore)(nil).JoinWorkspaceByLinkTx), arg0, arg1)
+}
+
+// ListAbuseReports mocks base method.
+func (m *MockStore) ListAbuseReports(arg0 context.Context, arg1 db.ListAbuseReportsParams) ([]db.AbuseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAbuseReports", arg0, arg1)
+	ret0, _ := ret[0].([]db.AbuseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAbuseReports indicates an expected call of ListAbuseReports.
+func (mr *MockStoreMockRecorder) ListAbuseReports(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAbuseReports", reflect.TypeOf((*MockStore)(nil).ListAbuseReports), arg0, arg1)
+}
+
+// ListAcknowledgmentRecipients mocks base method.
+func (m *MockStore) ListAcknowledgmentRecipients(arg0 context.Context, arg1 int64) ([]db.ListAcknowledgmentRecipientsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAcknowledgmentRecipients", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListAcknowledgmentRecipientsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAcknowledgmentRecipients indicates an expected call of ListAcknowledgmentRecipients.
+func (mr *MockStoreMockRecorder) ListAcknowledgmentRecipients(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAcknowledgmentRecipients", reflect.TypeOf((*MockStore)(nil).ListAcknowledgmentRecipients), arg0, arg1)
+}
+
+// ListActiveWorkspaceBanners mocks base method.
+func (m *MockStore) ListActiveWorkspaceBanners(arg0 context.Context, arg1 int64) ([]db.WorkspaceBanner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveWorkspaceBanners", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceBanner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveWorkspaceBanners indicates an expected call of ListActiveWorkspaceBanners.
+func (mr *MockStoreMockRecorder) ListActiveWorkspaceBanners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveWorkspaceBanners", reflect.TypeOf((*MockStore)(nil).ListActiveWorkspaceBanners), arg0, arg1)
+}
+
+// ListArchivableConversations mocks base method.
+func (m *MockStore) ListArchivableConversations(arg0 context.Context, arg1 db.ListArchivableConversationsParams) ([]db.ListArchivableConversationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArchivableConversations", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListArchivableConversationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArchivableConversations indicates an expected call of ListArchivableConversations.
+func (mr *MockStoreMockRecorder) ListArchivableConversations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArchivableConversations", reflect.TypeOf((*MockStore)(nil).ListArchivableConversations), arg0, arg1)
+}
+
+// ListAutoJoinWorkspaces mocks base method.
+func (m *MockStore) ListAutoJoinWorkspaces(arg0 context.Context, arg1 db.ListAutoJoinWorkspacesParams) ([]db.ListAutoJoinWorkspacesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAutoJoinWorkspaces", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListAutoJoinWorkspacesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAutoJoinWorkspaces indicates an expected call of ListAutoJoinWorkspaces.
+func (mr *MockStoreMockRecorder) ListAutoJoinWorkspaces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAutoJoinWorkspaces", reflect.TypeOf((*MockStore)(nil).ListAutoJoinWorkspaces), arg0, arg1)
+}
+
+// ListCanvasRevisions mocks base method.
+func (m *MockStore) ListCanvasRevisions(arg0 context.Context, arg1 db.ListCanvasRevisionsParams) ([]db.CanvasRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCanvasRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]db.CanvasRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCanvasRevisions indicates an expected call of ListCanvasRevisions.
+func (mr *MockStoreMockRecorder) ListCanvasRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCanvasRevisions", reflect.TypeOf((*MockStore)(nil).ListCanvasRevisions), arg0, arg1)
+}
+
+// ListChannelCanvases mocks base method.
+func (m *MockStore) ListChannelCanvases(arg0 context.Context, arg1 db.ListChannelCanvasesParams) ([]db.Canvas, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelCanvases", arg0, arg1)
+	ret0, _ := ret[0].([]db.Canvas)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelCanvases indicates an expected call of ListChannelCanvases.
+func (mr *MockStoreMockRecorder) ListChannelCanvases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelCanvases", reflect.TypeOf((*MockStore)(nil).ListChannelCanvases), arg0, arg1)
+}
+
+// ListChannelDailyStats mocks base method.
+func (m *MockStore) ListChannelDailyStats(arg0 context.Context, arg1 db.ListChannelDailyStatsParams) ([]db.ChannelDailyStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelDailyStats", arg0, arg1)
+	ret0, _ := ret[0].([]db.ChannelDailyStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelDailyStats indicates an expected call of ListChannelDailyStats.
+func (mr *MockStoreMockRecorder) ListChannelDailyStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelDailyStats", reflect.TypeOf((*MockStore)(nil).ListChannelDailyStats), arg0, arg1)
+}
+
+// ListChannelMessageIDsAt mocks base method.
+func (m *MockStore) ListChannelMessageIDsAt(arg0 context.Context, arg1 db.ListChannelMessageIDsAtParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelMessageIDsAt", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelMessageIDsAt indicates an expected call of ListChannelMessageIDsAt.
+func (mr *MockStoreMockRecorder) ListChannelMessageIDsAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelMessageIDsAt", reflect.TypeOf((*MockStore)(nil).ListChannelMessageIDsAt), arg0, arg1)
+}
+
+// ListChannelNotificationDefaults mocks base method.
+func (m *MockStore) ListChannelNotificationDefaults(arg0 context.Context, arg1 int64) ([]db.ChannelNotificationDefault, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelNotificationDefaults", arg0, arg1)
+	ret0, _ := ret[0].([]db.ChannelNotificationDefault)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelNotificationDefaults indicates an expected call of ListChannelNotificationDefaults.
+func (mr *MockStoreMockRecorder) ListChannelNotificationDefaults(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelNotificationDefaults", reflect.TypeOf((*MockStore)(nil).ListChannelNotificationDefaults), arg0, arg1)
+}
+
+// ListChannelPinIDs mocks base method.
+func (m *MockStore) ListChannelPinIDs(arg0 context.Context, arg1 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelPinIDs", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelPinIDs indicates an expected call of ListChannelPinIDs.
+func (mr *MockStoreMockRecorder) ListChannelPinIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelPinIDs", reflect.TypeOf((*MockStore)(nil).ListChannelPinIDs), arg0, arg1)
+}
+
+// ListChannelTopPosters mocks base method.
+func (m *MockStore) ListChannelTopPosters(arg0 context.Context, arg1 db.ListChannelTopPostersParams) ([]db.ListChannelTopPostersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelTopPosters", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListChannelTopPostersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelTopPosters indicates an expected call of ListChannelTopPosters.
+func (mr *MockStoreMockRecorder) ListChannelTopPosters(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelTopPosters", reflect.TypeOf((*MockStore)(nil).ListChannelTopPosters), arg0, arg1)
+}
+
+// ListChannelUnreadCounts mocks base method.
+func (m *MockStore) ListChannelUnreadCounts(arg0 context.Context, arg1 db.ListChannelUnreadCountsParams) ([]db.ListChannelUnreadCountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelUnreadCounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListChannelUnreadCountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelUnreadCounts indicates an expected call of ListChannelUnreadCounts.
+func (mr *MockStoreMockRecorder) ListChannelUnreadCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelUnreadCounts", reflect.TypeOf((*MockStore)(nil).ListChannelUnreadCounts), arg0, arg1)
+}
+
+// ListChannelsByWorkspace mocks base method.
+func (m *MockStore) ListChannelsByWorkspace(arg0 context.Context, arg1 db.ListChannelsByWorkspaceParams) ([]db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelsByWorkspace", arg0, arg1)
+	ret0, _ := ret[0].([]db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelsByWorkspace indicates an expected call of ListChannelsByWorkspace.
+func (mr *MockStoreMockRecorder) ListChannelsByWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelsByWorkspace", reflect.TypeOf((*MockStore)(nil).ListChannelsByWorkspace), arg0, arg1)
+}
+
+// ListChannelsForUser mocks base method.
+func (m *MockStore) ListChannelsForUser(arg0 context.Context, arg1 db.ListChannelsForUserParams) ([]db.ListChannelsForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListChannelsForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChannelsForUser indicates an expected call of ListChannelsForUser.
+func (mr *MockStoreMockRecorder) ListChannelsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelsForUser", reflect.TypeOf((*MockStore)(nil).ListChannelsForUser), arg0, arg1)
+}
+
+// ListCustomEmojis mocks base method.
+func (m *MockStore) ListCustomEmojis(arg0 context.Context, arg1 int64) ([]db.CustomEmoji, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomEmojis", arg0, arg1)
+	ret0, _ := ret[0].([]db.CustomEmoji)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomEmojis indicates an expected call of ListCustomEmojis.
+func (mr *MockStoreMockRecorder) ListCustomEmojis(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomEmojis", reflect.TypeOf((*MockStore)(nil).ListCustomEmojis), arg0, arg1)
+}
+
+// ListDeletedChannels mocks base method.
+func (m *MockStore) ListDeletedChannels(arg0 context.Context, arg1 db.ListDeletedChannelsParams) ([]db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedChannels", arg0, arg1)
+	ret0, _ := ret[0].([]db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedChannels indicates an expected call of ListDeletedChannels.
+func (mr *MockStoreMockRecorder) ListDeletedChannels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedChannels", reflect.TypeOf((*MockStore)(nil).ListDeletedChannels), arg0, arg1)
+}
+
+// ListDeletedWorkspaces mocks base method.
+func (m *MockStore) ListDeletedWorkspaces(arg0 context.Context, arg1 db.ListDeletedWorkspacesParams) ([]db.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedWorkspaces", arg0, arg1)
+	ret0, _ := ret[0].([]db.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedWorkspaces indicates an expected call of ListDeletedWorkspaces.
+func (mr *MockStoreMockRecorder) ListDeletedWorkspaces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedWorkspaces", reflect.TypeOf((*MockStore)(nil).ListDeletedWorkspaces), arg0, arg1)
+}
+
+// ListDirectMessageIDsAt mocks base method.
+func (m *MockStore) ListDirectMessageIDsAt(arg0 context.Context, arg1 db.ListDirectMessageIDsAtParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDirectMessageIDsAt", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDirectMessageIDsAt indicates an expected call of ListDirectMessageIDsAt.
+func (mr *MockStoreMockRecorder) ListDirectMessageIDsAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDirectMessageIDsAt", reflect.TypeOf((*MockStore)(nil).ListDirectMessageIDsAt), arg0, arg1)
+}
+
+// ListDirectMessageUnreadCounts mocks base method.
+func (m *MockStore) ListDirectMessageUnreadCounts(arg0 context.Context, arg1 db.ListDirectMessageUnreadCountsParams) ([]db.ListDirectMessageUnreadCountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDirectMessageUnreadCounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListDirectMessageUnreadCountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDirectMessageUnreadCounts indicates an expected call of ListDirectMessageUnreadCounts.
+func (mr *MockStoreMockRecorder) ListDirectMessageUnreadCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDirectMessageUnreadCounts", reflect.TypeOf((*MockStore)(nil).ListDirectMessageUnreadCounts), arg0, arg1)
+}
+
+// ListDueOrganizationDeletions mocks base method.
+func (m *MockStore) ListDueOrganizationDeletions(arg0 context.Context, arg1 db.ListDueOrganizationDeletionsParams) ([]db.OrganizationDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueOrganizationDeletions", arg0, arg1)
+	ret0, _ := ret[0].([]db.OrganizationDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueOrganizationDeletions indicates an expected call of ListDueOrganizationDeletions.
+func (mr *MockStoreMockRecorder) ListDueOrganizationDeletions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueOrganizationDeletions", reflect.TypeOf((*MockStore)(nil).ListDueOrganizationDeletions), arg0, arg1)
+}
+
+// ListEmailDeliveriesByAddress mocks base method.
+func (m *MockStore) ListEmailDeliveriesByAddress(arg0 context.Context, arg1 db.ListEmailDeliveriesByAddressParams) ([]db.EmailDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmailDeliveriesByAddress", arg0, arg1)
+	ret0, _ := ret[0].([]db.EmailDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmailDeliveriesByAddress indicates an expected call of ListEmailDeliveriesByAddress.
+func (mr *MockStoreMockRecorder) ListEmailDeliveriesByAddress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmailDeliveriesByAddress", reflect.TypeOf((*MockStore)(nil).ListEmailDeliveriesByAddress), arg0, arg1)
+}
+
+// ListEmailSuppressionsByEmails mocks base method.
+func (m *MockStore) ListEmailSuppressionsByEmails(arg0 context.Context, arg1 []string) ([]db.EmailSuppression, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmailSuppressionsByEmails", arg0, arg1)
+	ret0, _ := ret[0].([]db.EmailSuppression)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmailSuppressionsByEmails indicates an expected call of ListEmailSuppressionsByEmails.
+func (mr *MockStoreMockRecorder) ListEmailSuppressionsByEmails(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmailSuppressionsByEmails", reflect.TypeOf((*MockStore)(nil).ListEmailSuppressionsByEmails), arg0, arg1)
+}
+
+// ListEmailTemplates mocks base method.
+func (m *MockStore) ListEmailTemplates(arg0 context.Context, arg1 int64) ([]db.EmailTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmailTemplates", arg0, arg1)
+	ret0, _ := ret[0].([]db.EmailTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmailTemplates indicates an expected call of ListEmailTemplates.
+func (mr *MockStoreMockRecorder) ListEmailTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmailTemplates", reflect.TypeOf((*MockStore)(nil).ListEmailTemplates), arg0, arg1)
+}
+
+// ListEnabledCalendarIntegrations mocks base method.
+func (m *MockStore) ListEnabledCalendarIntegrations(arg0 context.Context) ([]db.CalendarIntegration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEnabledCalendarIntegrations", arg0)
+	ret0, _ := ret[0].([]db.CalendarIntegration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEnabledCalendarIntegrations indicates an expected call of ListEnabledCalendarIntegrations.
+func (mr *MockStoreMockRecorder) ListEnabledCalendarIntegrations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnabledCalendarIntegrations", reflect.TypeOf((*MockStore)(nil).ListEnabledCalendarIntegrations), arg0)
+}
+
+// ListExternalDMRequests mocks base method.
+func (m *MockStore) ListExternalDMRequests(arg0 context.Context, arg1 db.ListExternalDMRequestsParams) ([]db.ListExternalDMRequestsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExternalDMRequests", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListExternalDMRequestsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExternalDMRequests indicates an expected call of ListExternalDMRequests.
+func (mr *MockStoreMockRecorder) ListExternalDMRequests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExternalDMRequests", reflect.TypeOf((*MockStore)(nil).ListExternalDMRequests), arg0, arg1)
+}
+
+// ListFeatureFlags mocks base method.
+func (m *MockStore) ListFeatureFlags(arg0 context.Context, arg1 int64) ([]db.FeatureFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeatureFlags", arg0, arg1)
+	ret0, _ := ret[0].([]db.FeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeatureFlags indicates an expected call of ListFeatureFlags.
+func (mr *MockStoreMockRecorder) ListFeatureFlags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeatureFlags", reflect.TypeOf((*MockStore)(nil).ListFeatureFlags), arg0, arg1)
+}
+
+// ListFileAccessLog mocks base method.
+func (m *MockStore) ListFileAccessLog(arg0 context.Context, arg1 db.ListFileAccessLogParams) ([]db.ListFileAccessLogRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFileAccessLog", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListFileAccessLogRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFileAccessLog indicates an expected call of ListFileAccessLog.
+func (mr *MockStoreMockRecorder) ListFileAccessLog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileAccessLog", reflect.TypeOf((*MockStore)(nil).ListFileAccessLog), arg0, arg1)
+}
+
+// ListFilesForReconciliation mocks base method.
+func (m *MockStore) ListFilesForReconciliation(arg0 context.Context, arg1 db.ListFilesForReconciliationParams) ([]db.ListFilesForReconciliationRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFilesForReconciliation", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListFilesForReconciliationRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFilesForReconciliation indicates an expected call of ListFilesForReconciliation.
+func (mr *MockStoreMockRecorder) ListFilesForReconciliation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFilesForReconciliation", reflect.TypeOf((*MockStore)(nil).ListFilesForReconciliation), arg0, arg1)
+}
+
+// ListLegalHoldMessageArchives mocks base method.
+func (m *MockStore) ListLegalHoldMessageArchives(arg0 context.Context, arg1 db.ListLegalHoldMessageArchivesParams) ([]db.MessageArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLegalHoldMessageArchives", arg0, arg1)
+	ret0, _ := ret[0].([]db.MessageArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLegalHoldMessageArchives indicates an expected call of ListLegalHoldMessageArchives.
+func (mr *MockStoreMockRecorder) ListLegalHoldMessageArchives(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLegalHoldMessageArchives", reflect.TypeOf((*MockStore)(nil).ListLegalHoldMessageArchives), arg0, arg1)
+}
+
+// ListLegalHoldMessages mocks base method.
+func (m *MockStore) ListLegalHoldMessages(arg0 context.Context, arg1 db.ListLegalHoldMessagesParams) ([]db.ListLegalHoldMessagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLegalHoldMessages", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListLegalHoldMessagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLegalHoldMessages indicates an expected call of ListLegalHoldMessages.
+func (mr *MockStoreMockRecorder) ListLegalHoldMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLegalHoldMessages", reflect.TypeOf((*MockStore)(nil).ListLegalHoldMessages), arg0, arg1)
+}
+
+// ListLegalHolds mocks base method.
+func (m *MockStore) ListLegalHolds(arg0 context.Context, arg1 db.ListLegalHoldsParams) ([]db.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLegalHolds", arg0, arg1)
+	ret0, _ := ret[0].([]db.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLegalHolds indicates an expected call of ListLegalHolds.
+func (mr *MockStoreMockRecorder) ListLegalHolds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLegalHolds", reflect.TypeOf((*MockStore)(nil).ListLegalHolds), arg0, arg1)
+}
+
+// ListLoginEvents mocks base method.
+func (m *MockStore) ListLoginEvents(arg0 context.Context, arg1 db.ListLoginEventsParams) ([]db.ListLoginEventsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoginEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListLoginEventsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoginEvents indicates an expected call of ListLoginEvents.
+func (mr *MockStoreMockRecorder) ListLoginEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoginEvents", reflect.TypeOf((*MockStore)(nil).ListLoginEvents), arg0, arg1)
+}
+
+// ListMessageAcknowledgmentStates mocks base method.
+func (m *MockStore) ListMessageAcknowledgmentStates(arg0 context.Context, arg1 db.ListMessageAcknowledgmentStatesParams) ([]db.ListMessageAcknowledgmentStatesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessageAcknowledgmentStates", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMessageAcknowledgmentStatesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessageAcknowledgmentStates indicates an expected call of ListMessageAcknowledgmentStates.
+func (mr *MockStoreMockRecorder) ListMessageAcknowledgmentStates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessageAcknowledgmentStates", reflect.TypeOf((*MockStore)(nil).ListMessageAcknowledgmentStates), arg0, arg1)
+}
+
+// ListMessageArchives mocks base method.
+func (m *MockStore) ListMessageArchives(arg0 context.Context, arg1 db.ListMessageArchivesParams) ([]db.MessageArchive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessageArchives", arg0, arg1)
+	ret0, _ := ret[0].([]db.MessageArchive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessageArchives indicates an expected call of ListMessageArchives.
+func (mr *MockStoreMockRecorder) ListMessageArchives(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessageArchives", reflect.TypeOf((*MockStore)(nil).ListMessageArchives), arg0, arg1)
+}
+
+// ListMessageDrafts mocks base method.
+func (m *MockStore) ListMessageDrafts(arg0 context.Context, arg1 db.ListMessageDraftsParams) ([]db.MessageDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessageDrafts", arg0, arg1)
+	ret0, _ := ret[0].([]db.MessageDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessageDrafts indicates an expected call of ListMessageDrafts.
+func (mr *MockStoreMockRecorder) ListMessageDrafts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessageDrafts", reflect.TypeOf((*MockStore)(nil).ListMessageDrafts), arg0, arg1)
+}
+
+// ListMessageMentions mocks base method.
+func (m *MockStore) ListMessageMentions(arg0 context.Context, arg1 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessageMentions", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessageMentions indicates an expected call of ListMessageMentions.
+func (mr *MockStoreMockRecorder) ListMessageMentions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessageMentions", reflect.TypeOf((*MockStore)(nil).ListMessageMentions), arg0, arg1)
+}
+
+// ListMessagePartitions mocks base method.
+func (m *MockStore) ListMessagePartitions(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessagePartitions", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessagePartitions indicates an expected call of ListMessagePartitions.
+func (mr *MockStoreMockRecorder) ListMessagePartitions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessagePartitions", reflect.TypeOf((*MockStore)(nil).ListMessagePartitions), arg0)
+}
+
+// ListMessageThreads mocks base method.
+func (m *MockStore) ListMessageThreads(arg0 context.Context, arg1 []int64) ([]db.MessageThread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessageThreads", arg0, arg1)
+	ret0, _ := ret[0].([]db.MessageThread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessageThreads indicates an expected call of ListMessageThreads.
+func (mr *MockStoreMockRecorder) ListMessageThreads(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessageThreads", reflect.TypeOf((*MockStore)(nil).ListMessageThreads), arg0, arg1)
+}
+
+// ListMessagesToArchive mocks base method.
+func (m *MockStore) ListMessagesToArchive(arg0 context.Context, arg1 db.ListMessagesToArchiveParams) ([]db.ListMessagesToArchiveRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessagesToArchive", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMessagesToArchiveRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessagesToArchive indicates an expected call of ListMessagesToArchive.
+func (mr *MockStoreMockRecorder) ListMessagesToArchive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessagesToArchive", reflect.TypeOf((*MockStore)(nil).ListMessagesToArchive), arg0, arg1)
+}
+
+// ListModerationAuditLog mocks base method.
+func (m *MockStore) ListModerationAuditLog(arg0 context.Context, arg1 db.ListModerationAuditLogParams) ([]db.ModerationAuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModerationAuditLog", arg0, arg1)
+	ret0, _ := ret[0].([]db.ModerationAuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationAuditLog indicates an expected call of ListModerationAuditLog.
+func (mr *MockStoreMockRecorder) ListModerationAuditLog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationAuditLog", reflect.TypeOf((*MockStore)(nil).ListModerationAuditLog), arg0, arg1)
+}
+
+// ListModerationQueue mocks base method.
+func (m *MockStore) ListModerationQueue(arg0 context.Context, arg1 db.ListModerationQueueParams) ([]db.ListModerationQueueRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModerationQueue", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListModerationQueueRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationQueue indicates an expected call of ListModerationQueue.
+func (mr *MockStoreMockRecorder) ListModerationQueue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationQueue", reflect.TypeOf((*MockStore)(nil).ListModerationQueue), arg0, arg1)
+}
+
+// ListModerationWords mocks base method.
+func (m *MockStore) ListModerationWords(arg0 context.Context, arg1 int64) ([]db.ModerationWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModerationWords", arg0, arg1)
+	ret0, _ := ret[0].([]db.ModerationWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationWords indicates an expected call of ListModerationWords.
+func (mr *MockStoreMockRecorder) ListModerationWords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationWords", reflect.TypeOf((*MockStore)(nil).ListModerationWords), arg0, arg1)
+}
+
+// ListNotificationPreferences mocks base method.
+func (m *MockStore) ListNotificationPreferences(arg0 context.Context, arg1 db.ListNotificationPreferencesParams) ([]db.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationPreferences", arg0, arg1)
+	ret0, _ := ret[0].([]db.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationPreferences indicates an expected call of ListNotificationPreferences.
+func (mr *MockStoreMockRecorder) ListNotificationPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationPreferences", reflect.TypeOf((*MockStore)(nil).ListNotificationPreferences), arg0, arg1)
+}
+
+// ListOnboardingChannels mocks base method.
+func (m *MockStore) ListOnboardingChannels(arg0 context.Context, arg1 db.ListOnboardingChannelsParams) ([]db.ListOnboardingChannelsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOnboardingChannels", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListOnboardingChannelsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOnboardingChannels indicates an expected call of ListOnboardingChannels.
+func (mr *MockStoreMockRecorder) ListOnboardingChannels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOnboardingChannels", reflect.TypeOf((*MockStore)(nil).ListOnboardingChannels), arg0, arg1)
+}
+
+// ListOnboardingStepCompletions mocks base method.
+func (m *MockStore) ListOnboardingStepCompletions(arg0 context.Context, arg1 db.ListOnboardingStepCompletionsParams) ([]db.OnboardingStepCompletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOnboardingStepCompletions", arg0, arg1)
+	ret0, _ := ret[0].([]db.OnboardingStepCompletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOnboardingStepCompletions indicates an expected call of ListOnboardingStepCompletions.
+func (mr *MockStoreMockRecorder) ListOnboardingStepCompletions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOnboardingStepCompletions", reflect.TypeOf((*MockStore)(nil).ListOnboardingStepCompletions), arg0, arg1)
+}
+
+// ListOrganizationAvatarKeys mocks base method.
+func (m *MockStore) ListOrganizationAvatarKeys(arg0 context.Context, arg1 int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizationAvatarKeys", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizationAvatarKeys indicates an expected call of ListOrganizationAvatarKeys.
+func (mr *MockStoreMockRecorder) ListOrganizationAvatarKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationAvatarKeys", reflect.TypeOf((*MockStore)(nil).ListOrganizationAvatarKeys), arg0, arg1)
+}
+
+// ListOrganizationRoles mocks base method.
+func (m *MockStore) ListOrganizationRoles(arg0 context.Context, arg1 int64) ([]db.ListOrganizationRolesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizationRoles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListOrganizationRolesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizationRoles indicates an expected call of ListOrganizationRoles.
+func (mr *MockStoreMockRecorder) ListOrganizationRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizationRoles", reflect.TypeOf((*MockStore)(nil).ListOrganizationRoles), arg0, arg1)
+}
+
+// ListOrganizations mocks base method.
+func (m *MockStore) ListOrganizations(arg0 context.Context, arg1 db.ListOrganizationsParams) ([]db.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrganizations", arg0, arg1)
+	ret0, _ := ret[0].([]db.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrganizations indicates an expected call of ListOrganizations.
+func (mr *MockStoreMockRecorder) ListOrganizations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockStore)(nil).ListOrganizations), arg0, arg1)
+}
+
+// ListOutgoingWebhooks mocks base method.
+func (m *MockStore) ListOutgoingWebhooks(arg0 context.Context, arg1 int64) ([]db.OutgoingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOutgoingWebhooks", arg0, arg1)
+	ret0, _ := ret[0].([]db.OutgoingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutgoingWebhooks indicates an expected call of ListOutgoingWebhooks.
+func (mr *MockStoreMockRecorder) ListOutgoingWebhooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutgoingWebhooks", reflect.TypeOf((*MockStore)(nil).ListOutgoingWebhooks), arg0, arg1)
+}
+
+// ListPendingFilePreviews mocks base method.
+func (m *MockStore) ListPendingFilePreviews(arg0 context.Context, arg1 int32) ([]db.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingFilePreviews", arg0, arg1)
+	ret0, _ := ret[0].([]db.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingFilePreviews indicates an expected call of ListPendingFilePreviews.
+func (mr *MockStoreMockRecorder) ListPendingFilePreviews(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingFilePreviews", reflect.TypeOf((*MockStore)(nil).ListPendingFilePreviews), arg0, arg1)
+}
+
+// ListPendingInvitationEmails mocks base method.
+func (m *MockStore) ListPendingInvitationEmails(arg0 context.Context, arg1 db.ListPendingInvitationEmailsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingInvitationEmails", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingInvitationEmails indicates an expected call of ListPendingInvitationEmails.
+func (mr *MockStoreMockRecorder) ListPendingInvitationEmails(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingInvitationEmails", reflect.TypeOf((*MockStore)(nil).ListPendingInvitationEmails), arg0, arg1)
+}
+
+// ListPendingVideoFiles mocks base method.
+func (m *MockStore) ListPendingVideoFiles(arg0 context.Context, arg1 int32) ([]db.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingVideoFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingVideoFiles indicates an expected call of ListPendingVideoFiles.
+func (mr *MockStoreMockRecorder) ListPendingVideoFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingVideoFiles", reflect.TypeOf((*MockStore)(nil).ListPendingVideoFiles), arg0, arg1)
+}
+
+// ListPinnedMessages mocks base method.
+func (m *MockStore) ListPinnedMessages(arg0 context.Context, arg1 int64) ([]db.ListPinnedMessagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPinnedMessages", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPinnedMessagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPinnedMessages indicates an expected call of ListPinnedMessages.
+func (mr *MockStoreMockRecorder) ListPinnedMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPinnedMessages", reflect.TypeOf((*MockStore)(nil).ListPinnedMessages), arg0, arg1)
+}
+
+// ListPublicChannelsByWorkspace mocks base method.
+func (m *MockStore) ListPublicChannelsByWorkspace(arg0 context.Context, arg1 db.ListPublicChannelsByWorkspaceParams) ([]db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPublicChannelsByWorkspace", arg0, arg1)
+	ret0, _ := ret[0].([]db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPublicChannelsByWorkspace indicates an expected call of ListPublicChannelsByWorkspace.
+func (mr *MockStoreMockRecorder) ListPublicChannelsByWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublicChannelsByWorkspace", reflect.TypeOf((*MockStore)(nil).ListPublicChannelsByWorkspace), arg0, arg1)
+}
+
+// ListPurgeableChannels mocks base method.
+func (m *MockStore) ListPurgeableChannels(arg0 context.Context, arg1 db.ListPurgeableChannelsParams) ([]db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurgeableChannels", arg0, arg1)
+	ret0, _ := ret[0].([]db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurgeableChannels indicates an expected call of ListPurgeableChannels.
+func (mr *MockStoreMockRecorder) ListPurgeableChannels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurgeableChannels", reflect.TypeOf((*MockStore)(nil).ListPurgeableChannels), arg0, arg1)
+}
+
+// ListPurgeableFiles mocks base method.
+func (m *MockStore) ListPurgeableFiles(arg0 context.Context, arg1 db.ListPurgeableFilesParams) ([]db.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurgeableFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurgeableFiles indicates an expected call of ListPurgeableFiles.
+func (mr *MockStoreMockRecorder) ListPurgeableFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurgeableFiles", reflect.TypeOf((*MockStore)(nil).ListPurgeableFiles), arg0, arg1)
+}
+
+// ListPurgeableWorkspaces mocks base method.
+func (m *MockStore) ListPurgeableWorkspaces(arg0 context.Context, arg1 db.ListPurgeableWorkspacesParams) ([]db.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurgeableWorkspaces", arg0, arg1)
+	ret0, _ := ret[0].([]db.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurgeableWorkspaces indicates an expected call of ListPurgeableWorkspaces.
+func (mr *MockStoreMockRecorder) ListPurgeableWorkspaces(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurgeableWorkspaces", reflect.TypeOf((*MockStore)(nil).ListPurgeableWorkspaces), arg0, arg1)
+}
+
+// ListReactionSummaries mocks base method.
+func (m *MockStore) ListReactionSummaries(arg0 context.Context, arg1 db.ListReactionSummariesParams) ([]db.ListReactionSummariesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReactionSummaries", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListReactionSummariesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReactionSummaries indicates an expected call of ListReactionSummaries.
+func (mr *MockStoreMockRecorder) ListReactionSummaries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReactionSummaries", reflect.TypeOf((*MockStore)(nil).ListReactionSummaries), arg0, arg1)
+}
+
+// ListReactors mocks base method.
+func (m *MockStore) ListReactors(arg0 context.Context, arg1 db.ListReactorsParams) ([]db.ListReactorsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReactors", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListReactorsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReactors indicates an expected call of ListReactors.
+func (mr *MockStoreMockRecorder) ListReactors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReactors", reflect.TypeOf((*MockStore)(nil).ListReactors), arg0, arg1)
+}
+
+// ListSavedSearches mocks base method.
+func (m *MockStore) ListSavedSearches(arg0 context.Context, arg1 db.ListSavedSearchesParams) ([]db.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSavedSearches", arg0, arg1)
+	ret0, _ := ret[0].([]db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSavedSearches indicates an expected call of ListSavedSearches.
+func (mr *MockStoreMockRecorder) ListSavedSearches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavedSearches", reflect.TypeOf((*MockStore)(nil).ListSavedSearches), arg0, arg1)
+}
+
+// ListStoredFiles mocks base method.
+func (m *MockStore) ListStoredFiles(arg0 context.Context) ([]db.ListStoredFilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStoredFiles", arg0)
+	ret0, _ := ret[0].([]db.ListStoredFilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStoredFiles indicates an expected call of ListStoredFiles.
+func (mr *MockStoreMockRecorder) ListStoredFiles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStoredFiles", reflect.TypeOf((*MockStore)(nil).ListStoredFiles), arg0)
+}
+
+// ListThreadParticipants mocks base method.
+func (m *MockStore) ListThreadParticipants(arg0 context.Context, arg1 []int64) ([]db.ListThreadParticipantsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListThreadParticipants", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListThreadParticipantsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListThreadParticipants indicates an expected call of ListThreadParticipants.
+func (mr *MockStoreMockRecorder) ListThreadParticipants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListThreadParticipants", reflect.TypeOf((*MockStore)(nil).ListThreadParticipants), arg0, arg1)
+}
+
+// ListThreadUnreadCounts mocks base method.
+func (m *MockStore) ListThreadUnreadCounts(arg0 context.Context, arg1 db.ListThreadUnreadCountsParams) ([]db.ListThreadUnreadCountsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListThreadUnreadCounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListThreadUnreadCountsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListThreadUnreadCounts indicates an expected call of ListThreadUnreadCounts.
+func (mr *MockStoreMockRecorder) ListThreadUnreadCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListThreadUnreadCounts", reflect.TypeOf((*MockStore)(nil).ListThreadUnreadCounts), arg0, arg1)
+}
+
+// ListTrashedFiles mocks base method.
+func (m *MockStore) ListTrashedFiles(arg0 context.Context, arg1 db.ListTrashedFilesParams) ([]db.ListTrashedFilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrashedFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTrashedFilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrashedFiles indicates an expected call of ListTrashedFiles.
+func (mr *MockStoreMockRecorder) ListTrashedFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashedFiles", reflect.TypeOf((*MockStore)(nil).ListTrashedFiles), arg0, arg1)
+}
+
+// ListUnfinishedWorkspaceTeardowns mocks base method.
+func (m *MockStore) ListUnfinishedWorkspaceTeardowns(arg0 context.Context, arg1 int32) ([]db.WorkspaceTeardown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnfinishedWorkspaceTeardowns", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceTeardown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnfinishedWorkspaceTeardowns indicates an expected call of ListUnfinishedWorkspaceTeardowns.
+func (mr *MockStoreMockRecorder) ListUnfinishedWorkspaceTeardowns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnfinishedWorkspaceTeardowns", reflect.TypeOf((*MockStore)(nil).ListUnfinishedWorkspaceTeardowns), arg0, arg1)
+}
+
+// ListUnpublishedOutboxEvents mocks base method.
+func (m *MockStore) ListUnpublishedOutboxEvents(arg0 context.Context, arg1 db.ListUnpublishedOutboxEventsParams) ([]db.EventOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnpublishedOutboxEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.EventOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnpublishedOutboxEvents indicates an expected call of ListUnpublishedOutboxEvents.
+func (mr *MockStoreMockRecorder) ListUnpublishedOutboxEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnpublishedOutboxEvents", reflect.TypeOf((*MockStore)(nil).ListUnpublishedOutboxEvents), arg0, arg1)
+}
+
+// ListUnpublishedWorkspaceEvents mocks base method.
+func (m *MockStore) ListUnpublishedWorkspaceEvents(arg0 context.Context, arg1 db.ListUnpublishedWorkspaceEventsParams) ([]db.WorkspaceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnpublishedWorkspaceEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnpublishedWorkspaceEvents indicates an expected call of ListUnpublishedWorkspaceEvents.
+func (mr *MockStoreMockRecorder) ListUnpublishedWorkspaceEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnpublishedWorkspaceEvents", reflect.TypeOf((*MockStore)(nil).ListUnpublishedWorkspaceEvents), arg0, arg1)
+}
+
+// ListUpcomingCalendarBusyBlocks mocks base method.
+func (m *MockStore) ListUpcomingCalendarBusyBlocks(arg0 context.Context, arg1 db.ListUpcomingCalendarBusyBlocksParams) ([]db.CalendarBusyBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUpcomingCalendarBusyBlocks", arg0, arg1)
+	ret0, _ := ret[0].([]db.CalendarBusyBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUpcomingCalendarBusyBlocks indicates an expected call of ListUpcomingCalendarBusyBlocks.
+func (mr *MockStoreMockRecorder) ListUpcomingCalendarBusyBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUpcomingCalendarBusyBlocks", reflect.TypeOf((*MockStore)(nil).ListUpcomingCalendarBusyBlocks), arg0, arg1)
+}
+
+// ListUserDevices mocks base method.
+func (m *MockStore) ListUserDevices(arg0 context.Context, arg1 int64) ([]db.UserDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserDevices", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserDevices indicates an expected call of ListUserDevices.
+func (mr *MockStoreMockRecorder) ListUserDevices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserDevices", reflect.TypeOf((*MockStore)(nil).ListUserDevices), arg0, arg1)
+}
+
+// ListUserFiles mocks base method.
+func (m *MockStore) ListUserFiles(arg0 context.Context, arg1 db.ListUserFilesParams) ([]db.ListUserFilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserFilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserFiles indicates an expected call of ListUserFiles.
+func (mr *MockStoreMockRecorder) ListUserFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserFiles", reflect.TypeOf((*MockStore)(nil).ListUserFiles), arg0, arg1)
+}
+
+// ListUserMentions mocks base method.
+func (m *MockStore) ListUserMentions(arg0 context.Context, arg1 db.ListUserMentionsParams) ([]db.ListUserMentionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserMentions", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserMentionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserMentions indicates an expected call of ListUserMentions.
+func (mr *MockStoreMockRecorder) ListUserMentions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserMentions", reflect.TypeOf((*MockStore)(nil).ListUserMentions), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
+}
+
+// ListUsersByEmails mocks base method.
+func (m *MockStore) ListUsersByEmails(arg0 context.Context, arg1 []string) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersByEmails", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersByEmails indicates an expected call of ListUsersByEmails.
+func (mr *MockStoreMockRecorder) ListUsersByEmails(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersByEmails", reflect.TypeOf((*MockStore)(nil).ListUsersByEmails), arg0, arg1)
+}
+
+// ListWorkspaceAdminIDs mocks base method.
+func (m *MockStore) ListWorkspaceAdminIDs(arg0 context.Context, arg1 sql.NullInt64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceAdminIDs", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceAdminIDs indicates an expected call of ListWorkspaceAdminIDs.
+func (mr *MockStoreMockRecorder) ListWorkspaceAdminIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceAdminIDs", reflect.TypeOf((*MockStore)(nil).ListWorkspaceAdminIDs), arg0, arg1)
+}
+
+// ListWorkspaceAutoJoinDomains mocks base method.
+func (m *MockStore) ListWorkspaceAutoJoinDomains(arg0 context.Context, arg1 int64) ([]db.WorkspaceAutoJoinDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceAutoJoinDomains", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceAutoJoinDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceAutoJoinDomains indicates an expected call of ListWorkspaceAutoJoinDomains.
+func (mr *MockStoreMockRecorder) ListWorkspaceAutoJoinDomains(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceAutoJoinDomains", reflect.TypeOf((*MockStore)(nil).ListWorkspaceAutoJoinDomains), arg0, arg1)
+}
+
+// ListWorkspaceBanners mocks base method.
+func (m *MockStore) ListWorkspaceBanners(arg0 context.Context, arg1 int64) ([]db.WorkspaceBanner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceBanners", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceBanner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceBanners indicates an expected call of ListWorkspaceBanners.
+func (mr *MockStoreMockRecorder) ListWorkspaceBanners(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceBanners", reflect.TypeOf((*MockStore)(nil).ListWorkspaceBanners), arg0, arg1)
+}
+
+// ListWorkspaceDoNotDisturb mocks base method.
+func (m *MockStore) ListWorkspaceDoNotDisturb(arg0 context.Context, arg1 int64) ([]db.ListWorkspaceDoNotDisturbRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceDoNotDisturb", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWorkspaceDoNotDisturbRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceDoNotDisturb indicates an expected call of ListWorkspaceDoNotDisturb.
+func (mr *MockStoreMockRecorder) ListWorkspaceDoNotDisturb(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceDoNotDisturb", reflect.TypeOf((*MockStore)(nil).ListWorkspaceDoNotDisturb), arg0, arg1)
+}
+
+// ListWorkspaceEvents mocks base method.
+func (m *MockStore) ListWorkspaceEvents(arg0 context.Context, arg1 db.ListWorkspaceEventsParams) ([]db.WorkspaceEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceEvents indicates an expected call of ListWorkspaceEvents.
+func (mr *MockStoreMockRecorder) ListWorkspaceEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceEvents", reflect.TypeOf((*MockStore)(nil).ListWorkspaceEvents), arg0, arg1)
+}
+
+// ListWorkspaceFiles mocks base method.
+func (m *MockStore) ListWorkspaceFiles(arg0 context.Context, arg1 db.ListWorkspaceFilesParams) ([]db.ListWorkspaceFilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWorkspaceFilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceFiles indicates an expected call of ListWorkspaceFiles.
+func (mr *MockStoreMockRecorder) ListWorkspaceFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceFiles", reflect.TypeOf((*MockStore)(nil).ListWorkspaceFiles), arg0, arg1)
+}
+
+// ListWorkspaceFilesForTeardown mocks base method.
+func (m *MockStore) ListWorkspaceFilesForTeardown(arg0 context.Context, arg1 db.ListWorkspaceFilesForTeardownParams) ([]db.ListWorkspaceFilesForTeardownRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceFilesForTeardown", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWorkspaceFilesForTeardownRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceFilesForTeardown indicates an expected call of ListWorkspaceFilesForTeardown.
+func (mr *MockStoreMockRecorder) ListWorkspaceFilesForTeardown(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceFilesForTeardown", reflect.TypeOf((*MockStore)(nil).ListWorkspaceFilesForTeardown), arg0, arg1)
+}
+
+// ListWorkspaceInvitations mocks base method.
+func (m *MockStore) ListWorkspaceInvitations(arg0 context.Context, arg1 db.ListWorkspaceInvitationsParams) ([]db.WorkspaceInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceInvitations", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceInvitations indicates an expected call of ListWorkspaceInvitations.
+func (mr *MockStoreMockRecorder) ListWorkspaceInvitations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceInvitations", reflect.TypeOf((*MockStore)(nil).ListWorkspaceInvitations), arg0, arg1)
+}
+
+// ListWorkspaceJoinLinks mocks base method.
+func (m *MockStore) ListWorkspaceJoinLinks(arg0 context.Context, arg1 db.ListWorkspaceJoinLinksParams) ([]db.WorkspaceJoinLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceJoinLinks", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceJoinLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceJoinLinks indicates an expected call of ListWorkspaceJoinLinks.
+func (mr *MockStoreMockRecorder) ListWorkspaceJoinLinks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceJoinLinks", reflect.TypeOf((*MockStore)(nil).ListWorkspaceJoinLinks), arg0, arg1)
+}
+
+// ListWorkspaceJoinRequests mocks base method.
+func (m *MockStore) ListWorkspaceJoinRequests(arg0 context.Context, arg1 db.ListWorkspaceJoinRequestsParams) ([]db.ListWorkspaceJoinRequestsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceJoinRequests", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWorkspaceJoinRequestsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceJoinRequests indicates an expected call of ListWorkspaceJoinRequests.
+func (mr *MockStoreMockRecorder) ListWorkspaceJoinRequests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceJoinRequests", reflect.TypeOf((*MockStore)(nil).ListWorkspaceJoinRequests), arg0, arg1)
+}
+
+// ListWorkspaceMemberIDs mocks base method.
+func (m *MockStore) ListWorkspaceMemberIDs(arg0 context.Context, arg1 db.ListWorkspaceMemberIDsParams) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceMemberIDs", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceMemberIDs indicates an expected call of ListWorkspaceMemberIDs.
+func (mr *MockStoreMockRecorder) ListWorkspaceMemberIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceMemberIDs", reflect.TypeOf((*MockStore)(nil).ListWorkspaceMemberIDs), arg0, arg1)
+}
+
+// ListWorkspaceMembers mocks base method.
+func (m *MockStore) ListWorkspaceMembers(arg0 context.Context, arg1 db.ListWorkspaceMembersParams) ([]db.ListWorkspaceMembersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceMembers", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWorkspaceMembersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceMembers indicates an expected call of ListWorkspaceMembers.
+func (mr *MockStoreMockRecorder) ListWorkspaceMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceMembers", reflect.TypeOf((*MockStore)(nil).ListWorkspaceMembers), arg0, arg1)
+}
+
+// ListWorkspaceReactionsByEmoji mocks base method.
+func (m *MockStore) ListWorkspaceReactionsByEmoji(arg0 context.Context, arg1 db.ListWorkspaceReactionsByEmojiParams) ([]db.MessageReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceReactionsByEmoji", arg0, arg1)
+	ret0, _ := ret[0].([]db.MessageReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceReactionsByEmoji indicates an expected call of ListWorkspaceReactionsByEmoji.
+func (mr *MockStoreMockRecorder) ListWorkspaceReactionsByEmoji(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceReactionsByEmoji", reflect.TypeOf((*MockStore)(nil).ListWorkspaceReactionsByEmoji), arg0, arg1)
+}
+
+// ListWorkspaceRoles mocks base method.
+func (m *MockStore) ListWorkspaceRoles(arg0 context.Context, arg1 int64) ([]db.ListWorkspaceRolesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceRoles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWorkspaceRolesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceRoles indicates an expected call of ListWorkspaceRoles.
+func (mr *MockStoreMockRecorder) ListWorkspaceRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceRoles", reflect.TypeOf((*MockStore)(nil).ListWorkspaceRoles), arg0, arg1)
+}
+
+// ListWorkspaceTeardowns mocks base method.
+func (m *MockStore) ListWorkspaceTeardowns(arg0 context.Context, arg1 db.ListWorkspaceTeardownsParams) ([]db.WorkspaceTeardown, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceTeardowns", arg0, arg1)
+	ret0, _ := ret[0].([]db.WorkspaceTeardown)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceTeardowns indicates an expected call of ListWorkspaceTeardowns.
+func (mr *MockStoreMockRecorder) ListWorkspaceTeardowns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceTeardowns", reflect.TypeOf((*MockStore)(nil).ListWorkspaceTeardowns), arg0, arg1)
+}
+
+// ListWorkspacesByOrganization mocks base method.
+func (m *MockStore) ListWorkspacesByOrganization(arg0 context.Context, arg1 db.ListWorkspacesByOrganizationParams) ([]db.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspacesByOrganization", arg0, arg1)
+	ret0, _ := ret[0].([]db.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspacesByOrganization indicates an expected call of ListWorkspacesByOrganization.
+func (mr *MockStoreMockRecorder) ListWorkspacesByOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspacesByOrganization", reflect.TypeOf((*MockStore)(nil).ListWorkspacesByOrganization), arg0, arg1)
+}
+
+// MarkAllChannelsRead mocks base method.
+func (m *MockStore) MarkAllChannelsRead(arg0 context.Context, arg1 db.MarkAllChannelsReadParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllChannelsRead", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllChannelsRead indicates an expected call of MarkAllChannelsRead.
+func (mr *MockStoreMockRecorder) MarkAllChannelsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllChannelsRead", reflect.TypeOf((*MockStore)(nil).MarkAllChannelsRead), arg0, arg1)
+}
+
+// MarkAllDirectMessagesRead mocks base method.
+func (m *MockStore) MarkAllDirectMessagesRead(arg0 context.Context, arg1 db.MarkAllDirectMessagesReadParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllDirectMessagesRead", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllDirectMessagesRead indicates an expected call of MarkAllDirectMessagesRead.
+func (mr *MockStoreMockRecorder) MarkAllDirectMessagesRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllDirectMessagesRead", reflect.TypeOf((*MockStore)(nil).MarkAllDirectMessagesRead), arg0, arg1)
+}
+
+// MarkAllThreadsRead mocks base method.
+func (m *MockStore) MarkAllThreadsRead(arg0 context.Context, arg1 db.MarkAllThreadsReadParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllThreadsRead", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllThreadsRead indicates an expected call of MarkAllThreadsRead.
+func (mr *MockStoreMockRecorder) MarkAllThreadsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllThreadsRead", reflect.TypeOf((*MockStore)(nil).MarkAllThreadsRead), arg0, arg1)
+}
+
+// MarkChannelRead mocks base method.
+func (m *MockStore) MarkChannelRead(arg0 context.Context, arg1 db.MarkChannelReadParams) (db.ChannelReadState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkChannelRead", arg0, arg1)
+	ret0, _ := ret[0].(db.ChannelReadState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkChannelRead indicates an expected call of MarkChannelRead.
+func (mr *MockStoreMockRecorder) MarkChannelRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkChannelRead", reflect.TypeOf((*MockStore)(nil).MarkChannelRead), arg0, arg1)
+}
+
+// MarkDirectMessagesRead mocks base method.
+func (m *MockStore) MarkDirectMessagesRead(arg0 context.Context, arg1 db.MarkDirectMessagesReadParams) (db.DirectMessageReadState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDirectMessagesRead", arg0, arg1)
+	ret0, _ := ret[0].(db.DirectMessageReadState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDirectMessagesRead indicates an expected call of MarkDirectMessagesRead.
+func (mr *MockStoreMockRecorder) MarkDirectMessagesRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDirectMessagesRead", reflect.TypeOf((*MockStore)(nil).MarkDirectMessagesRead), arg0, arg1)
+}
+
+// MarkEmailDeliverySent mocks base method.
+func (m *MockStore) MarkEmailDeliverySent(arg0 context.Context, arg1 db.MarkEmailDeliverySentParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailDeliverySent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailDeliverySent indicates an expected call of MarkEmailDeliverySent.
+func (mr *MockStoreMockRecorder) MarkEmailDeliverySent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailDeliverySent", reflect.TypeOf((*MockStore)(nil).MarkEmailDeliverySent), arg0, arg1)
+}
+
+// MarkMentionRead mocks base method.
+func (m *MockStore) MarkMentionRead(arg0 context.Context, arg1 db.MarkMentionReadParams) (db.MessageMention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMentionRead", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageMention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMentionRead indicates an expected call of MarkMentionRead.
+func (mr *MockStoreMockRecorder) MarkMentionRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMentionRead", reflect.TypeOf((*MockStore)(nil).MarkMentionRead), arg0, arg1)
+}
+
+// MarkOutboxEventPublished mocks base method.
+func (m *MockStore) MarkOutboxEventPublished(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxEventPublished", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxEventPublished indicates an expected call of MarkOutboxEventPublished.
+func (mr *MockStoreMockRecorder) MarkOutboxEventPublished(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxEventPublished", reflect.TypeOf((*MockStore)(nil).MarkOutboxEventPublished), arg0, arg1)
+}
+
+// MarkThreadRead mocks base method.
+func (m *MockStore) MarkThreadRead(arg0 context.Context, arg1 db.MarkThreadReadParams) (db.ThreadReadState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkThreadRead", arg0, arg1)
+	ret0, _ := ret[0].(db.ThreadReadState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkThreadRead indicates an expected call of MarkThreadRead.
+func (mr *MockStoreMockRecorder) MarkThreadRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkThreadRead", reflect.TypeOf((*MockStore)(nil).MarkThreadRead), arg0, arg1)
+}
+
+// MarkUserEmailVerified mocks base method.
+func (m *MockStore) MarkUserEmailVerified(arg0 context.Context, arg1 db.MarkUserEmailVerifiedParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUserEmailVerified", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkUserEmailVerified indicates an expected call of MarkUserEmailVerified.
+func (mr *MockStoreMockRecorder) MarkUserEmailVerified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserEmailVerified", reflect.TypeOf((*MockStore)(nil).MarkUserEmailVerified), arg0, arg1)
+}
+
+// MarkWebhookDelivered mocks base method.
+func (m *MockStore) MarkWebhookDelivered(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWebhookDelivered", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWebhookDelivered indicates an expected call of MarkWebhookDelivered.
+func (mr *MockStoreMockRecorder) MarkWebhookDelivered(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWebhookDelivered", reflect.TypeOf((*MockStore)(nil).MarkWebhookDelivered), arg0, arg1)
+}
+
+// MarkWorkspaceEventsPublished mocks base method.
+func (m *MockStore) MarkWorkspaceEventsPublished(arg0 context.Context, arg1 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWorkspaceEventsPublished", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWorkspaceEventsPublished indicates an expected call of MarkWorkspaceEventsPublished.
+func (mr *MockStoreMockRecorder) MarkWorkspaceEventsPublished(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWorkspaceEventsPublished", reflect.TypeOf((*MockStore)(nil).MarkWorkspaceEventsPublished), arg0, arg1)
+}
+
+// MarkWorkspaceReadTx mocks base method.
+func (m *MockStore) MarkWorkspaceReadTx(arg0 context.Context, arg1 db.MarkWorkspaceReadTxParams) (db.MarkWorkspaceReadTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWorkspaceReadTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MarkWorkspaceReadTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkWorkspaceReadTx indicates an expected call of MarkWorkspaceReadTx.
+func (mr *MockStoreMockRecorder) MarkWorkspaceReadTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWorkspaceReadTx", reflect.TypeOf((*MockStore)(nil).MarkWorkspaceReadTx), arg0, arg1)
+}
+
+// OrganizationHasActiveLegalHold mocks base method.
+func (m *MockStore) OrganizationHasActiveLegalHold(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OrganizationHasActiveLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OrganizationHasActiveLegalHold indicates an expected call of OrganizationHasActiveLegalHold.
+func (mr *MockStoreMockRecorder) OrganizationHasActiveLegalHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrganizationHasActiveLegalHold", reflect.TypeOf((*MockStore)(nil).OrganizationHasActiveLegalHold), arg0, arg1)
+}
+
+// PinMessage mocks base method.
+func (m *MockStore) PinMessage(arg0 context.Context, arg1 db.PinMessageParams) (db.PinnedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinMessage", arg0, arg1)
+	ret0, _ := ret[0].(db.PinnedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PinMessage indicates an expected call of PinMessage.
+func (mr *MockStoreMockRecorder) PinMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinMessage", reflect.TypeOf((*MockStore)(nil).PinMessage), arg0, arg1)
+}
+
+// PurgeDeletedMessages mocks base method.
+func (m *MockStore) PurgeDeletedMessages(arg0 context.Context, arg1 db.PurgeDeletedMessagesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedMessages", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedMessages indicates an expected call of PurgeDeletedMessages.
+func (mr *MockStoreMockRecorder) PurgeDeletedMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedMessages", reflect.TypeOf((*MockStore)(nil).PurgeDeletedMessages), arg0, arg1)
+}
+
+// PurgeFile mocks base method.
+func (m *MockStore) PurgeFile(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeFile", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeFile indicates an expected call of PurgeFile.
+func (mr *MockStoreMockRecorder) PurgeFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeFile", reflect.TypeOf((*MockStore)(nil).PurgeFile), arg0, arg1)
+}
+
+// QueueOrganizationWorkspaceTeardowns mocks base method.
+func (m *MockStore) QueueOrganizationWorkspaceTeardowns(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueOrganizationWorkspaceTeardowns", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueueOrganizationWorkspaceTeardowns indicates an expected call of QueueOrganizationWorkspaceTeardowns.
+func (mr *MockStoreMockRecorder) QueueOrganizationWorkspaceTeardowns(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueOrganizationWorkspaceTeardowns", reflect.TypeOf((*MockStore)(nil).QueueOrganizationWorkspaceTeardowns), arg0, arg1)
+}
+
+// RecordFileDownload mocks base method.
+func (m *MockStore) RecordFileDownload(arg0 context.Context, arg1 db.RecordFileDownloadParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFileDownload", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFileDownload indicates an expected call of RecordFileDownload.
+func (mr *MockStoreMockRecorder) RecordFileDownload(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFileDownload", reflect.TypeOf((*MockStore)(nil).RecordFileDownload), arg0, arg1)
+}
+
+// RecordOrganizationDeletionError mocks base method.
+func (m *MockStore) RecordOrganizationDeletionError(arg0 context.Context, arg1 db.RecordOrganizationDeletionErrorParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOrganizationDeletionError", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordOrganizationDeletionError indicates an expected call of RecordOrganizationDeletionError.
+func (mr *MockStoreMockRecorder) RecordOrganizationDeletionError(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOrganizationDeletionError", reflect.TypeOf((*MockStore)(nil).RecordOrganizationDeletionError), arg0, arg1)
+}
+
+// RecordOrganizationDeletionProgress mocks base method.
+func (m *MockStore) RecordOrganizationDeletionProgress(arg0 context.Context, arg1 db.RecordOrganizationDeletionProgressParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOrganizationDeletionProgress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordOrganizationDeletionProgress indicates an expected call of RecordOrganizationDeletionProgress.
+func (mr *MockStoreMockRecorder) RecordOrganizationDeletionProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOrganizationDeletionProgress", reflect.TypeOf((*MockStore)(nil).RecordOrganizationDeletionProgress), arg0, arg1)
+}
+
+// RecordOutOfOfficeReply mocks base method.
+func (m *MockStore) RecordOutOfOfficeReply(arg0 context.Context, arg1 db.RecordOutOfOfficeReplyParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordOutOfOfficeReply", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordOutOfOfficeReply indicates an expected call of RecordOutOfOfficeReply.
+func (mr *MockStoreMockRecorder) RecordOutOfOfficeReply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordOutOfOfficeReply", reflect.TypeOf((*MockStore)(nil).RecordOutOfOfficeReply), arg0, arg1)
+}
+
+// RecordThreadReply mocks base method.
+func (m *MockStore) RecordThreadReply(arg0 context.Context, arg1 db.RecordThreadReplyParams) (db.MessageThread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordThreadReply", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageThread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordThreadReply indicates an expected call of RecordThreadReply.
+func (mr *MockStoreMockRecorder) RecordThreadReply(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordThreadReply", reflect.TypeOf((*MockStore)(nil).RecordThreadReply), arg0, arg1)
+}
+
+// RecordWorkspaceTeardownError mocks base method.
+func (m *MockStore) RecordWorkspaceTeardownError(arg0 context.Context, arg1 db.RecordWorkspaceTeardownErrorParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWorkspaceTeardownError", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordWorkspaceTeardownError indicates an expected call of RecordWorkspaceTeardownError.
+func (mr *MockStoreMockRecorder) RecordWorkspaceTeardownError(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWorkspaceTeardownError", reflect.TypeOf((*MockStore)(nil).RecordWorkspaceTeardownError), arg0, arg1)
+}
+
+// RecordWorkspaceTeardownProgress mocks base method.
+func (m *MockStore) RecordWorkspaceTeardownProgress(arg0 context.Context, arg1 db.RecordWorkspaceTeardownProgressParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordWorkspaceTeardownProgress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordWorkspaceTeardownProgress indicates an expected call of RecordWorkspaceTeardownProgress.
+func (mr *MockStoreMockRecorder) RecordWorkspaceTeardownProgress(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordWorkspaceTeardownProgress", reflect.TypeOf((*MockStore)(nil).RecordWorkspaceTeardownProgress), arg0, arg1)
+}
+
+// RefreshThreadSummary mocks base method.
+func (m *MockStore) RefreshThreadSummary(arg0 context.Context, arg1 int64) (db.MessageThread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshThreadSummary", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageThread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshThreadSummary indicates an expected call of RefreshThreadSummary.
+func (mr *MockStoreMockRecorder) RefreshThreadSummary(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshThreadSummary", reflect.TypeOf((*MockStore)(nil).RefreshThreadSummary), arg0, arg1)
+}
+
+// ReleaseLegalHold mocks base method.
+func (m *MockStore) ReleaseLegalHold(arg0 context.Context, arg1 db.ReleaseLegalHoldParams) (db.LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(db.LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseLegalHold indicates an expected call of ReleaseLegalHold.
+func (mr *MockStoreMockRecorder) ReleaseLegalHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLegalHold", reflect.TypeOf((*MockStore)(nil).ReleaseLegalHold), arg0, arg1)
+}
+
+// RemoveChannelMember mocks base method.
+func (m *MockStore) RemoveChannelMember(arg0 context.Context, arg1 db.RemoveChannelMemberParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveChannelMember", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveChannelMember indicates an expected call of RemoveChannelMember.
+func (mr *MockStoreMockRecorder) RemoveChannelMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveChannelMember", reflect.TypeOf((*MockStore)(nil).RemoveChannelMember), arg0, arg1)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockStore) RemoveReaction(arg0 context.Context, arg1 db.RemoveReactionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockStoreMockRecorder) RemoveReaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockStore)(nil).RemoveReaction), arg0, arg1)
+}
+
+// RemoveUserFromWorkspace mocks base method.
+func (m *MockStore) RemoveUserFromWorkspace(arg0 context.Context, arg1 db.RemoveUserFromWorkspaceParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveUserFromWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveUserFromWorkspace indicates an expected call of RemoveUserFromWorkspace.
+func (mr *MockStoreMockRecorder) RemoveUserFromWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromWorkspace", reflect.TypeOf((*MockStore)(nil).RemoveUserFromWorkspace), arg0, arg1)
+}
+
+// ReorderPinnedMessages mocks base method.
+func (m *MockStore) ReorderPinnedMessages(arg0 context.Context, arg1 db.ReorderPinnedMessagesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderPinnedMessages", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderPinnedMessages indicates an expected call of ReorderPinnedMessages.
+func (mr *MockStoreMockRecorder) ReorderPinnedMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderPinnedMessages", reflect.TypeOf((*MockStore)(nil).ReorderPinnedMessages), arg0, arg1)
+}
+
+// ReplaceCalendarBusyBlocksTx mocks base method.
+func (m *MockStore) ReplaceCalendarBusyBlocksTx(arg0 context.Context, arg1 db.ReplaceCalendarBusyBlocksTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceCalendarBusyBlocksTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceCalendarBusyBlocksTx indicates an expected call of ReplaceCalendarBusyBlocksTx.
+func (mr *MockStoreMockRecorder) ReplaceCalendarBusyBlocksTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCalendarBusyBlocksTx", reflect.TypeOf((*MockStore)(nil).ReplaceCalendarBusyBlocksTx), arg0, arg1)
+}
+
+// RequeueInterruptedFilePreviews mocks base method.
+func (m *MockStore) RequeueInterruptedFilePreviews(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueInterruptedFilePreviews", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueInterruptedFilePreviews indicates an expected call of RequeueInterruptedFilePreviews.
+func (mr *MockStoreMockRecorder) RequeueInterruptedFilePreviews(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueInterruptedFilePreviews", reflect.TypeOf((*MockStore)(nil).RequeueInterruptedFilePreviews), arg0)
+}
+
+// RequeueInterruptedFileProcessing mocks base method.
+func (m *MockStore) RequeueInterruptedFileProcessing(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueInterruptedFileProcessing", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueInterruptedFileProcessing indicates an expected call of RequeueInterruptedFileProcessing.
+func (mr *MockStoreMockRecorder) RequeueInterruptedFileProcessing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueInterruptedFileProcessing", reflect.TypeOf((*MockStore)(nil).RequeueInterruptedFileProcessing), arg0)
+}
+
+// ResolveAbuseReport mocks base method.
+func (m *MockStore) ResolveAbuseReport(arg0 context.Context, arg1 db.ResolveAbuseReportParams) (db.AbuseReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAbuseReport", arg0, arg1)
+	ret0, _ := ret[0].(db.AbuseReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveAbuseReport indicates an expected call of ResolveAbuseReport.
+func (mr *MockStoreMockRecorder) ResolveAbuseReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAbuseReport", reflect.TypeOf((*MockStore)(nil).ResolveAbuseReport), arg0, arg1)
+}
+
+// ResolveModerationQueueItem mocks base method.
+func (m *MockStore) ResolveModerationQueueItem(arg0 context.Context, arg1 db.ResolveModerationQueueItemParams) (db.ModerationQueue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveModerationQueueItem", arg0, arg1)
+	ret0, _ := ret[0].(db.ModerationQueue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveModerationQueueItem indicates an expected call of ResolveModerationQueueItem.
+func (mr *MockStoreMockRecorder) ResolveModerationQueueItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveModerationQueueItem", reflect.TypeOf((*MockStore)(nil).ResolveModerationQueueItem), arg0, arg1)
+}
+
+// RespondExternalDMRequest mocks base method.
+func (m *MockStore) RespondExternalDMRequest(arg0 context.Context, arg1 db.RespondExternalDMRequestParams) (db.ExternalDmRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RespondExternalDMRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.ExternalDmRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RespondExternalDMRequest indicates an expected call of RespondExternalDMRequest.
+func (mr *MockStoreMockRecorder) RespondExternalDMRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RespondExternalDMRequest", reflect.TypeOf((*MockStore)(nil).RespondExternalDMRequest), arg0, arg1)
+}
+
+// RestoreChannel mocks base method.
+func (m *MockStore) RestoreChannel(arg0 context.Context, arg1 db.RestoreChannelParams) (db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreChannel", arg0, arg1)
+	ret0, _ := ret[0].(db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreChannel indicates an expected call of RestoreChannel.
+func (mr *MockStoreMockRecorder) RestoreChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreChannel", reflect.TypeOf((*MockStore)(nil).RestoreChannel), arg0, arg1)
+}
+
+// RestoreFile mocks base method.
+func (m *MockStore) RestoreFile(arg0 context.Context, arg1 db.RestoreFileParams) (db.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFile", arg0, arg1)
+	ret0, _ := ret[0].(db.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreFile indicates an expected call of RestoreFile.
+func (mr *MockStoreMockRecorder) RestoreFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFile", reflect.TypeOf((*MockStore)(nil).RestoreFile), arg0, arg1)
+}
+
+// RestoreWorkspace mocks base method.
+func (m *MockStore) RestoreWorkspace(arg0 context.Context, arg1 db.RestoreWorkspaceParams) (db.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(db.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreWorkspace indicates an expected call of RestoreWorkspace.
+func (mr *MockStoreMockRecorder) RestoreWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreWorkspace", reflect.TypeOf((*MockStore)(nil).RestoreWorkspace), arg0, arg1)
+}
+
+// RetryEmailDelivery mocks base method.
+func (m *MockStore) RetryEmailDelivery(arg0 context.Context, arg1 db.RetryEmailDeliveryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryEmailDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryEmailDelivery indicates an expected call of RetryEmailDelivery.
+func (mr *MockStoreMockRecorder) RetryEmailDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryEmailDelivery", reflect.TypeOf((*MockStore)(nil).RetryEmailDelivery), arg0, arg1)
+}
+
+// RetryWebhookDelivery mocks base method.
+func (m *MockStore) RetryWebhookDelivery(arg0 context.Context, arg1 db.RetryWebhookDeliveryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryWebhookDelivery indicates an expected call of RetryWebhookDelivery.
+func (mr *MockStoreMockRecorder) RetryWebhookDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryWebhookDelivery", reflect.TypeOf((*MockStore)(nil).RetryWebhookDelivery), arg0, arg1)
+}
+
+// ReviewWorkspaceJoinRequest mocks base method.
+func (m *MockStore) ReviewWorkspaceJoinRequest(arg0 context.Context, arg1 db.ReviewWorkspaceJoinRequestParams) (db.WorkspaceJoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewWorkspaceJoinRequest", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceJoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewWorkspaceJoinRequest indicates an expected call of ReviewWorkspaceJoinRequest.
+func (mr *MockStoreMockRecorder) ReviewWorkspaceJoinRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewWorkspaceJoinRequest", reflect.TypeOf((*MockStore)(nil).ReviewWorkspaceJoinRequest), arg0, arg1)
+}
+
+// RevokeWorkspaceJoinLink mocks base method.
+func (m *MockStore) RevokeWorkspaceJoinLink(arg0 context.Context, arg1 db.RevokeWorkspaceJoinLinkParams) (db.WorkspaceJoinLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeWorkspaceJoinLink", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceJoinLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeWorkspaceJoinLink indicates an expected call of RevokeWorkspaceJoinLink.
+func (mr *MockStoreMockRecorder) RevokeWorkspaceJoinLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeWorkspaceJoinLink", reflect.TypeOf((*MockStore)(nil).RevokeWorkspaceJoinLink), arg0, arg1)
+}
+
+// RollupChannelDailyPosterStats mocks base method.
+func (m *MockStore) RollupChannelDailyPosterStats(arg0 context.Context, arg1 db.RollupChannelDailyPosterStatsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupChannelDailyPosterStats", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollupChannelDailyPosterStats indicates an expected call of RollupChannelDailyPosterStats.
+func (mr *MockStoreMockRecorder) RollupChannelDailyPosterStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupChannelDailyPosterStats", reflect.TypeOf((*MockStore)(nil).RollupChannelDailyPosterStats), arg0, arg1)
+}
+
+// RollupChannelDailyStats mocks base method.
+func (m *MockStore) RollupChannelDailyStats(arg0 context.Context, arg1 db.RollupChannelDailyStatsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupChannelDailyStats", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollupChannelDailyStats indicates an expected call of RollupChannelDailyStats.
+func (mr *MockStoreMockRecorder) RollupChannelDailyStats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupChannelDailyStats", reflect.TypeOf((*MockStore)(nil).RollupChannelDailyStats), arg0, arg1)
+}
+
+// RollupChannelStatsTx mocks base method.
+func (m *MockStore) RollupChannelStatsTx(arg0 context.Context, arg1 db.RollupChannelStatsTxParams) (db.RollupChannelStatsTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollupChannelStatsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RollupChannelStatsTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollupChannelStatsTx indicates an expected call of RollupChannelStatsTx.
+func (mr *MockStoreMockRecorder) RollupChannelStatsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollupChannelStatsTx", reflect.TypeOf((*MockStore)(nil).RollupChannelStatsTx), arg0, arg1)
+}
+
+// SearchChannels mocks base method.
+func (m *MockStore) SearchChannels(arg0 context.Context, arg1 db.SearchChannelsParams) ([]db.SearchChannelsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchChannels", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchChannelsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchChannels indicates an expected call of SearchChannels.
+func (mr *MockStoreMockRecorder) SearchChannels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchChannels", reflect.TypeOf((*MockStore)(nil).SearchChannels), arg0, arg1)
+}
+
+// SearchFiles mocks base method.
+func (m *MockStore) SearchFiles(arg0 context.Context, arg1 db.SearchFilesParams) ([]db.SearchFilesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFiles", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchFilesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFiles indicates an expected call of SearchFiles.
+func (mr *MockStoreMockRecorder) SearchFiles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFiles", reflect.TypeOf((*MockStore)(nil).SearchFiles), arg0, arg1)
+}
+
+// SearchMessages mocks base method.
+func (m *MockStore) SearchMessages(arg0 context.Context, arg1 db.SearchMessagesParams) ([]db.SearchMessagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessages", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchMessagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMessages indicates an expected call of SearchMessages.
+func (mr *MockStoreMockRecorder) SearchMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessages", reflect.TypeOf((*MockStore)(nil).SearchMessages), arg0, arg1)
+}
+
+// SearchOrganizationDirectory mocks base method.
+func (m *MockStore) SearchOrganizationDirectory(arg0 context.Context, arg1 db.SearchOrganizationDirectoryParams) ([]db.SearchOrganizationDirectoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchOrganizationDirectory", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchOrganizationDirectoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchOrganizationDirectory indicates an expected call of SearchOrganizationDirectory.
+func (mr *MockStoreMockRecorder) SearchOrganizationDirectory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchOrganizationDirectory", reflect.TypeOf((*MockStore)(nil).SearchOrganizationDirectory), arg0, arg1)
+}
+
+// SearchWorkspaceUsers mocks base method.
+func (m *MockStore) SearchWorkspaceUsers(arg0 context.Context, arg1 db.SearchWorkspaceUsersParams) ([]db.SearchWorkspaceUsersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchWorkspaceUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchWorkspaceUsersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchWorkspaceUsers indicates an expected call of SearchWorkspaceUsers.
+func (mr *MockStoreMockRecorder) SearchWorkspaceUsers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchWorkspaceUsers", reflect.TypeOf((*MockStore)(nil).SearchWorkspaceUsers), arg0, arg1)
+}
+
+// SetCalendarBusyStatus mocks base method.
+func (m *MockStore) SetCalendarBusyStatus(arg0 context.Context, arg1 db.SetCalendarBusyStatusParams) (db.UserStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCalendarBusyStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.UserStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCalendarBusyStatus indicates an expected call of SetCalendarBusyStatus.
+func (mr *MockStoreMockRecorder) SetCalendarBusyStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCalendarBusyStatus", reflect.TypeOf((*MockStore)(nil).SetCalendarBusyStatus), arg0, arg1)
+}
+
+// SetIdleUsersAway mocks base method.
+func (m *MockStore) SetIdleUsersAway(arg0 context.Context, arg1 sql.NullInt32) ([]db.UserStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIdleUsersAway", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIdleUsersAway indicates an expected call of SetIdleUsersAway.
+func (mr *MockStoreMockRecorder) SetIdleUsersAway(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIdleUsersAway", reflect.TypeOf((*MockStore)(nil).SetIdleUsersAway), arg0, arg1)
+}
+
+// SetIdleUsersOffline mocks base method.
+func (m *MockStore) SetIdleUsersOffline(arg0 context.Context, arg1 int32) ([]db.UserStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIdleUsersOffline", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetIdleUsersOffline indicates an expected call of SetIdleUsersOffline.
+func (mr *MockStoreMockRecorder) SetIdleUsersOffline(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIdleUsersOffline", reflect.TypeOf((*MockStore)(nil).SetIdleUsersOffline), arg0, arg1)
+}
+
+// SetUserCustomRole mocks base method.
+func (m *MockStore) SetUserCustomRole(arg0 context.Context, arg1 db.SetUserCustomRoleParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserCustomRole", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserCustomRole indicates an expected call of SetUserCustomRole.
+func (mr *MockStoreMockRecorder) SetUserCustomRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserCustomRole", reflect.TypeOf((*MockStore)(nil).SetUserCustomRole), arg0, arg1)
+}
+
+// SetUserPresenceOffline mocks base method.
+func (m *MockStore) SetUserPresenceOffline(arg0 context.Context, arg1 db.SetUserPresenceOfflineParams) (db.UserStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserPresenceOffline", arg0, arg1)
+	ret0, _ := ret[0].(db.UserStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserPresenceOffline indicates an expected call of SetUserPresenceOffline.
+func (mr *MockStoreMockRecorder) SetUserPresenceOffline(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserPresenceOffline", reflect.TypeOf((*MockStore)(nil).SetUserPresenceOffline), arg0, arg1)
+}
+
+// SetUserPresenceOnline mocks base method.
+func (m *MockStore) SetUserPresenceOnline(arg0 context.Context, arg1 db.SetUserPresenceOnlineParams) (db.UserStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserPresenceOnline", arg0, arg1)
+	ret0, _ := ret[0].(db.UserStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserPresenceOnline indicates an expected call of SetUserPresenceOnline.
+func (mr *MockStoreMockRecorder) SetUserPresenceOnline(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserPresenceOnline", reflect.TypeOf((*MockStore)(nil).SetUserPresenceOnline), arg0, arg1)
+}
+
+// SetUsersOfflineAfterInactivity mocks base method.
+func (m *MockStore) SetUsersOfflineAfterInactivity(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUsersOfflineAfterInactivity", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUsersOfflineAfterInactivity indicates an expected call of SetUsersOfflineAfterInactivity.
+func (mr *MockStoreMockRecorder) SetUsersOfflineAfterInactivity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUsersOfflineAfterInactivity", reflect.TypeOf((*MockStore)(nil).SetUsersOfflineAfterInactivity), arg0, arg1)
+}
+
+// SetWorkspaceInvitationEmailResult mocks base method.
+func (m *MockStore) SetWorkspaceInvitationEmailResult(arg0 context.Context, arg1 db.SetWorkspaceInvitationEmailResultParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWorkspaceInvitationEmailResult", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWorkspaceInvitationEmailResult indicates an expected call of SetWorkspaceInvitationEmailResult.
+func (mr *MockStoreMockRecorder) SetWorkspaceInvitationEmailResult(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkspaceInvitationEmailResult", reflect.TypeOf((*MockStore)(nil).SetWorkspaceInvitationEmailResult), arg0, arg1)
+}
+
+// SetWorkspaceOnboardingBot mocks base method.
+func (m *MockStore) SetWorkspaceOnboardingBot(arg0 context.Context, arg1 db.SetWorkspaceOnboardingBotParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWorkspaceOnboardingBot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWorkspaceOnboardingBot indicates an expected call of SetWorkspaceOnboardingBot.
+func (mr *MockStoreMockRecorder) SetWorkspaceOnboardingBot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkspaceOnboardingBot", reflect.TypeOf((*MockStore)(nil).SetWorkspaceOnboardingBot), arg0, arg1)
+}
+
+// SoftDeleteChannel mocks base method.
+func (m *MockStore) SoftDeleteChannel(arg0 context.Context, arg1 db.SoftDeleteChannelParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteChannel", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteChannel indicates an expected call of SoftDeleteChannel.
+func (mr *MockStoreMockRecorder) SoftDeleteChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteChannel", reflect.TypeOf((*MockStore)(nil).SoftDeleteChannel), arg0, arg1)
+}
+
+// SoftDeleteMessage mocks base method.
+func (m *MockStore) SoftDeleteMessage(arg0 context.Context, arg1 db.SoftDeleteMessageParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteMessage indicates an expected call of SoftDeleteMessage.
+func (mr *MockStoreMockRecorder) SoftDeleteMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteMessage", reflect.TypeOf((*MockStore)(nil).SoftDeleteMessage), arg0, arg1)
+}
+
+// SoftDeleteWorkspace mocks base method.
+func (m *MockStore) SoftDeleteWorkspace(arg0 context.Context, arg1 db.SoftDeleteWorkspaceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteWorkspace indicates an expected call of SoftDeleteWorkspace.
+func (mr *MockStoreMockRecorder) SoftDeleteWorkspace(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteWorkspace", reflect.TypeOf((*MockStore)(nil).SoftDeleteWorkspace), arg0, arg1)
+}
+
+// StartOrganizationDeletion mocks base method.
+func (m *MockStore) StartOrganizationDeletion(arg0 context.Context, arg1 int64) (db.OrganizationDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOrganizationDeletion", arg0, arg1)
+	ret0, _ := ret[0].(db.OrganizationDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOrganizationDeletion indicates an expected call of StartOrganizationDeletion.
+func (mr *MockStoreMockRecorder) StartOrganizationDeletion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOrganizationDeletion", reflect.TypeOf((*MockStore)(nil).StartOrganizationDeletion), arg0, arg1)
+}
+
+// TakedownMessage mocks base method.
+func (m *MockStore) TakedownMessage(arg0 context.Context, arg1 db.TakedownMessageParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakedownMessage", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakedownMessage indicates an expected call of TakedownMessage.
+func (mr *MockStoreMockRecorder) TakedownMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakedownMessage", reflect.TypeOf((*MockStore)(nil).TakedownMessage), arg0, arg1)
+}
+
+// TrashFile mocks base method.
+func (m *MockStore) TrashFile(arg0 context.Context, arg1 db.TrashFileParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashFile", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrashFile indicates an expected call of TrashFile.
+func (mr *MockStoreMockRecorder) TrashFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashFile", reflect.TypeOf((*MockStore)(nil).TrashFile), arg0, arg1)
+}
+
+// UnfollowThread mocks base method.
+func (m *MockStore) UnfollowThread(arg0 context.Context, arg1 db.UnfollowThreadParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowThread", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnfollowThread indicates an expected call of UnfollowThread.
+func (mr *MockStoreMockRecorder) UnfollowThread(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowThread", reflect.TypeOf((*MockStore)(nil).UnfollowThread), arg0, arg1)
+}
+
+// UnpinMessage mocks base method.
+func (m *MockStore) UnpinMessage(arg0 context.Context, arg1 int64) (db.PinnedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinMessage", arg0, arg1)
+	ret0, _ := ret[0].(db.PinnedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnpinMessage indicates an expected call of UnpinMessage.
+func (mr *MockStoreMockRecorder) UnpinMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinMessage", reflect.TypeOf((*MockStore)(nil).UnpinMessage), arg0, arg1)
+}
+
+// UpdateCalendarIntegrationSync mocks base method.
+func (m *MockStore) UpdateCalendarIntegrationSync(arg0 context.Context, arg1 db.UpdateCalendarIntegrationSyncParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCalendarIntegrationSync", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCalendarIntegrationSync indicates an expected call of UpdateCalendarIntegrationSync.
+func (mr *MockStoreMockRecorder) UpdateCalendarIntegrationSync(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCalendarIntegrationSync", reflect.TypeOf((*MockStore)(nil).UpdateCalendarIntegrationSync), arg0, arg1)
+}
+
+// UpdateCanvas mocks base method.
+func (m *MockStore) UpdateCanvas(arg0 context.Context, arg1 db.UpdateCanvasParams) (db.Canvas, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCanvas", arg0, arg1)
+	ret0, _ := ret[0].(db.Canvas)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCanvas indicates an expected call of UpdateCanvas.
+func (mr *MockStoreMockRecorder) UpdateCanvas(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCanvas", reflect.TypeOf((*MockStore)(nil).UpdateCanvas), arg0, arg1)
+}
+
+// UpdateCanvasTx mocks base method.
+func (m *MockStore) UpdateCanvasTx(arg0 context.Context, arg1 db.UpdateCanvasParams) (db.Canvas, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCanvasTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Canvas)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCanvasTx indicates an expected call of UpdateCanvasTx.
+func (mr *MockStoreMockRecorder) UpdateCanvasTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCanvasTx", reflect.TypeOf((*MockStore)(nil).UpdateCanvasTx), arg0, arg1)
+}
+
+// UpdateChannel mocks base method.
+func (m *MockStore) UpdateChannel(arg0 context.Context, arg1 db.UpdateChannelParams) (db.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannel", arg0, arg1)
+	ret0, _ := ret[0].(db.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChannel indicates an expected call of UpdateChannel.
+func (mr *MockStoreMockRecorder) UpdateChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannel", reflect.TypeOf((*MockStore)(nil).UpdateChannel), arg0, arg1)
+}
+
+// UpdateFilePreview mocks base method.
+func (m *MockStore) UpdateFilePreview(arg0 context.Context, arg1 db.UpdateFilePreviewParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilePreview", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFilePreview indicates an expected call of UpdateFilePreview.
+func (mr *MockStoreMockRecorder) UpdateFilePreview(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilePreview", reflect.TypeOf((*MockStore)(nil).UpdateFilePreview), arg0, arg1)
+}
+
+// UpdateFileProcessing mocks base method.
+func (m *MockStore) UpdateFileProcessing(arg0 context.Context, arg1 db.UpdateFileProcessingParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileProcessing", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileProcessing indicates an expected call of UpdateFileProcessing.
+func (mr *MockStoreMockRecorder) UpdateFileProcessing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileProcessing", reflect.TypeOf((*MockStore)(nil).UpdateFileProcessing), arg0, arg1)
+}
+
+// UpdateFileThumbnail mocks base method.
+func (m *MockStore) UpdateFileThumbnail(arg0 context.Context, arg1 db.UpdateFileThumbnailParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileThumbnail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileThumbnail indicates an expected call of UpdateFileThumbnail.
+func (mr *MockStoreMockRecorder) UpdateFileThumbnail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileThumbnail", reflect.TypeOf((*MockStore)(nil).UpdateFileThumbnail), arg0, arg1)
+}
+
+// UpdateFileUploadStatus mocks base method.
+func (m *MockStore) UpdateFileUploadStatus(arg0 context.Context, arg1 db.UpdateFileUploadStatusParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileUploadStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileUploadStatus indicates an expected call of UpdateFileUploadStatus.
+func (mr *MockStoreMockRecorder) UpdateFileUploadStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileUploadStatus", reflect.TypeOf((*MockStore)(nil).UpdateFileUploadStatus), arg0, arg1)
+}
+
+// UpdateFileVoiceMetadata mocks base method.
+func (m *MockStore) UpdateFileVoiceMetadata(arg0 context.Context, arg1 db.UpdateFileVoiceMetadataParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileVoiceMetadata", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFileVoiceMetadata indicates an expected call of UpdateFileVoiceMetadata.
+func (mr *MockStoreMockRecorder) UpdateFileVoiceMetadata(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileVoiceMetadata", reflect.TypeOf((*MockStore)(nil).UpdateFileVoiceMetadata), arg0, arg1)
+}
+
+// UpdateLastActivity mocks base method.
+func (m *MockStore) UpdateLastActivity(arg0 context.Context, arg1 db.UpdateLastActivityParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastActivity", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastActivity indicates an expected call of UpdateLastActivity.
+func (mr *MockStoreMockRecorder) UpdateLastActivity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastActivity", reflect.TypeOf((*MockStore)(nil).UpdateLastActivity), arg0, arg1)
+}
+
+// UpdateMessageContent mocks base method.
+func (m *MockStore) UpdateMessageContent(arg0 context.Context, arg1 db.UpdateMessageContentParams) (db.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMessageContent", arg0, arg1)
+	ret0, _ := ret[0].(db.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMessageContent indicates an expected call of UpdateMessageContent.
+func (mr *MockStoreMockRecorder) UpdateMessageContent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessageContent", reflect.TypeOf((*MockStore)(nil).UpdateMessageContent), arg0, arg1)
+}
+
+// UpdateOrganization mocks base method.
+func (m *MockStore) UpdateOrganization(arg0 context.Context, arg1 db.UpdateOrganizationParams) (db.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrganization", arg0, arg1)
+	ret0, _ := ret[0].(db.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrganization indicates an expected call of UpdateOrganization.
+func (mr *MockStoreMockRecorder) UpdateOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockStore)(nil).UpdateOrganization), arg0, arg1)
+}
+
+// UpdateSavedSearch mocks base method.
+func (m *MockStore) UpdateSavedSearch(arg0 context.Context, arg1 db.UpdateSavedSearchParams) (db.SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedSearch", arg0, arg1)
+	ret0, _ := ret[0].(db.SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSavedSearch indicates an expected call of UpdateSavedSearch.
+func (mr *MockStoreMockRecorder) UpdateSavedSearch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedSearch", reflect.TypeOf((*MockStore)(nil).UpdateSavedSearch), arg0, arg1)
+}
+
+// UpdateUserAvatar mocks base method.
+func (m *MockStore) UpdateUserAvatar(arg0 context.Context, arg1 db.UpdateUserAvatarParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserAvatar", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserAvatar indicates an expected call of UpdateUserAvatar.
+func (mr *MockStoreMockRecorder) UpdateUserAvatar(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAvatar", reflect.TypeOf((*MockStore)(nil).UpdateUserAvatar), arg0, arg1)
+}
+
+// UpdateUserDevice mocks base method.
+func (m *MockStore) UpdateUserDevice(arg0 context.Context, arg1 db.UpdateUserDeviceParams) (db.UserDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserDevice", arg0, arg1)
+	ret0, _ := ret[0].(db.UserDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserDevice indicates an expected call of UpdateUserDevice.
+func (mr *MockStoreMockRecorder) UpdateUserDevice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserDevice", reflect.TypeOf((*MockStore)(nil).UpdateUserDevice), arg0, arg1)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockStore) UpdateUserPassword(arg0 context.Context, arg1 db.UpdateUserPasswordParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockStoreMockRecorder) UpdateUserPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), arg0, arg1)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockStore) UpdateUserProfile(arg0 context.Context, arg1 db.UpdateUserProfileParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockStoreMockRecorder) UpdateUserProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockStore)(nil).UpdateUserProfile), arg0, arg1)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockStore) UpdateUserRole(arg0 context.Context, arg1 db.UpdateUserRoleParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockStoreMockRecorder) UpdateUserRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockStore)(nil).UpdateUserRole), arg0, arg1)
+}
+
+// UpdateUserWorkspace mocks base method.
+func (m *MockStore) UpdateUserWorkspace(arg0 context.Context, arg1 db.UpdateUserWorkspaceParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWorkspaceInvitationByCode indicates an expected call of GetWorkspaceInvitationByCode.
-func (mr *MockStoreMockRecorder) GetWorkspaceInvitationByCode(arg0, arg1 interface{}) *gomock.Call {
+// UpdateUserWorkspace indicates an expected call of UpdateUserWorkspace.
+func (mr *MockStoreMockRecorder) UpdateUserWorkspace(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceInvitationByCode", reflect.TypeOf((*MockStore)(nil).GetWorkspaceInvitationByCode), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserWorkspace", reflect.TypeOf((*MockStore)(nil).UpdateUserWorkspace), arg0, arg1)
 }
 
-// GetWorkspaceMemberCount mocks base method.
-func (m *MockStore) GetWorkspaceMemberCount(arg0 context.Context, arg1 sql.NullInt64) (int64, error) {
+// UpdateWorkspace mocks base method.
+func (m *MockStore) UpdateWorkspace(arg0 context.Context, arg1 db.UpdateWorkspaceParams) (db.Workspace, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWorkspaceMemberCount", arg0, arg1)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "UpdateWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(db.Workspace)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWorkspaceMemberCount indicates an expected call of GetWorkspaceMemberCount.
-func (mr *MockStoreMockRecorder) GetWorkspaceMemberCount(arg0, arg1 interface{}) *gomock.Call {
+// UpdateWorkspace indicates an expected call of UpdateWorkspace.
+func (mr *MockStoreMockRecorder) UpdateWorkspace(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceMemberCount", reflect.TypeOf((*MockStore)(nil).GetWorkspaceMemberCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockStore)(nil).UpdateWorkspace), arg0, arg1)
 }
 
-// GetWorkspaceUserStatuses mocks base method.
-func (m *MockStore) GetWorkspaceUserStatuses(arg0 context.Context, arg1 db.GetWorkspaceUserStatusesParams) ([]db.GetWorkspaceUserStatusesRow, error) {
+// UpdateWorkspaceBanner mocks base method.
+func (m *MockStore) UpdateWorkspaceBanner(arg0 context.Context, arg1 db.UpdateWorkspaceBannerParams) (db.WorkspaceBanner, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWorkspaceUserStatuses", arg0, arg1)
-	ret0, _ := ret[0].([]db.GetWorkspaceUserStatusesRow)
+	ret := m.ctrl.Call(m, "UpdateWorkspaceBanner", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceBanner)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWorkspaceUserStatuses indicates an expected call of GetWorkspaceUserStatuses.
-func (mr *MockStoreMockRecorder) GetWorkspaceUserStatuses(arg0, arg1 interface{}) *gomock.Call {
+// UpdateWorkspaceBanner indicates an expected call of UpdateWorkspaceBanner.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceBanner(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceUserStatuses", reflect.TypeOf((*MockStore)(nil).GetWorkspaceUserStatuses), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceBanner", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceBanner), arg0, arg1)
 }
 
-// GetWorkspaceWithUserCount mocks base method.
-func (m *MockStore) GetWorkspaceWithUserCount(arg0 context.Context, arg1 int64) (db.GetWorkspaceWithUserCountRow, error) {
+// UpdateWorkspaceInvitationEmailStatus mocks base method.
+func (m *MockStore) UpdateWorkspaceInvitationEmailStatus(arg0 context.Context, arg1 db.UpdateWorkspaceInvitationEmailStatusParams) (db.WorkspaceInvitation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetWorkspaceWithUserCount", arg0, arg1)
-	ret0, _ := ret[0].(db.GetWorkspaceWithUserCountRow)
+	ret := m.ctrl.Call(m, "UpdateWorkspaceInvitationEmailStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceInvitation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetWorkspaceWithUserCount indicates an expected call of GetWorkspaceWithUserCount.
-func (mr *MockStoreMockRecorder) GetWorkspaceWithUserCount(arg0, arg1 interface{}) *gomock.Call {
+// UpdateWorkspaceInvitationEmailStatus indicates an expected call of UpdateWorkspaceInvitationEmailStatus.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceInvitationEmailStatus(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceWithUserCount", reflect.TypeOf((*MockStore)(nil).GetWorkspaceWithUserCount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceInvitationEmailStatus", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceInvitationEmailStatus), arg0, arg1)
 }
 
-// IsChannelMember mocks base method.
-func (m *MockStore) IsChannelMember(arg0 context.Context, arg1 db.IsChannelMemberParams) (bool, error) {
+// UpdateWorkspaceMemberRole mocks base method.
+func (m *MockStore) UpdateWorkspaceMemberRole(arg0 context.Context, arg1 db.UpdateWorkspaceMemberRoleParams) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsChannelMember", arg0, arg1)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "UpdateWorkspaceMemberRole", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsChannelMember indicates an expected call of IsChannelMember.
-func (mr *MockStoreMockRecorder) IsChannelMember(arg0, arg1 interface{}) *gomock.Call {
+// UpdateWorkspaceMemberRole indicates an expected call of UpdateWorkspaceMemberRole.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceMemberRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsChannelMember", reflect.TypeOf((*MockStore)(nil).IsChannelMember), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceMemberRole", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceMemberRole), arg0, arg1)
 }
 
-// ListChannelsByWorkspace mocks base method.
-func (m *MockStore) ListChannelsByWorkspace(arg0 context.Context, arg1 db.ListChannelsByWorkspaceParams) ([]db.Channel, error) {
+// UpdateWorkspaceRole mocks base method.
+func (m *MockStore) UpdateWorkspaceRole(arg0 context.Context, arg1 db.UpdateWorkspaceRoleParams) (db.WorkspaceRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListChannelsByWorkspace", arg0, arg1)
-	ret0, _ := ret[0].([]db.Channel)
+	ret := m.ctrl.Call(m, "UpdateWorkspaceRole", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceRole)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListChannelsByWorkspace indicates an expected call of ListChannelsByWorkspace.
-func (mr *MockStoreMockRecorder) ListChannelsByWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// UpdateWorkspaceRole indicates an expected call of UpdateWorkspaceRole.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelsByWorkspace", reflect.TypeOf((*MockStore)(nil).ListChannelsByWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceRole", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceRole), arg0, arg1)
 }
 
-// ListOrganizations mocks base method.
-func (m *MockStore) ListOrganizations(arg0 context.Context, arg1 db.ListOrganizationsParams) ([]db.Organization, error) {
+// UpsertCalendarIntegration mocks base method.
+func (m *MockStore) UpsertCalendarIntegration(arg0 context.Context, arg1 db.UpsertCalendarIntegrationParams) (db.CalendarIntegration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListOrganizations", arg0, arg1)
-	ret0, _ := ret[0].([]db.Organization)
+	ret := m.ctrl.Call(m, "UpsertCalendarIntegration", arg0, arg1)
+	ret0, _ := ret[0].(db.CalendarIntegration)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListOrganizations indicates an expected call of ListOrganizations.
-func (mr *MockStoreMockRecorder) ListOrganizations(arg0, arg1 interface{}) *gomock.Call {
+// UpsertCalendarIntegration indicates an expected call of UpsertCalendarIntegration.
+func (mr *MockStoreMockRecorder) UpsertCalendarIntegration(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrganizations", reflect.TypeOf((*MockStore)(nil).ListOrganizations), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCalendarIntegration", reflect.TypeOf((*MockStore)(nil).UpsertCalendarIntegration), arg0, arg1)
 }
 
-// ListPublicChannelsByWorkspace mocks base method.
-func (m *MockStore) ListPublicChannelsByWorkspace(arg0 context.Context, arg1 db.ListPublicChannelsByWorkspaceParams) ([]db.Channel, error) {
+// UpsertChannelNotificationDefault mocks base method.
+func (m *MockStore) UpsertChannelNotificationDefault(arg0 context.Context, arg1 db.UpsertChannelNotificationDefaultParams) (db.ChannelNotificationDefault, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPublicChannelsByWorkspace", arg0, arg1)
-	ret0, _ := ret[0].([]db.Channel)
+	ret := m.ctrl.Call(m, "UpsertChannelNotificationDefault", arg0, arg1)
+	ret0, _ := ret[0].(db.ChannelNotificationDefault)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPublicChannelsByWorkspace indicates an expected call of ListPublicChannelsByWorkspace.
-func (mr *MockStoreMockRecorder) ListPublicChannelsByWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// UpsertChannelNotificationDefault indicates an expected call of UpsertChannelNotificationDefault.
+func (mr *MockStoreMockRecorder) UpsertChannelNotificationDefault(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPublicChannelsByWorkspace", reflect.TypeOf((*MockStore)(nil).ListPublicChannelsByWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertChannelNotificationDefault", reflect.TypeOf((*MockStore)(nil).UpsertChannelNotificationDefault), arg0, arg1)
 }
 
-// ListUserFiles mocks base method.
-func (m *MockStore) ListUserFiles(arg0 context.Context, arg1 db.ListUserFilesParams) ([]db.ListUserFilesRow, error) {
+// UpsertChannelNotificationPreference mocks base method.
+func (m *MockStore) UpsertChannelNotificationPreference(arg0 context.Context, arg1 db.UpsertChannelNotificationPreferenceParams) (db.NotificationPreference, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserFiles", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListUserFilesRow)
+	ret := m.ctrl.Call(m, "UpsertChannelNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationPreference)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUserFiles indicates an expected call of ListUserFiles.
-func (mr *MockStoreMockRecorder) ListUserFiles(arg0, arg1 interface{}) *gomock.Call {
+// UpsertChannelNotificationPreference indicates an expected call of UpsertChannelNotificationPreference.
+func (mr *MockStoreMockRecorder) UpsertChannelNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserFiles", reflect.TypeOf((*MockStore)(nil).ListUserFiles), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertChannelNotificationPreference", reflect.TypeOf((*MockStore)(nil).UpsertChannelNotificationPreference), arg0, arg1)
 }
 
-// ListUsers mocks base method.
-func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
+// UpsertDoNotDisturb mocks base method.
+func (m *MockStore) UpsertDoNotDisturb(arg0 context.Context, arg1 db.UpsertDoNotDisturbParams) (db.UpsertDoNotDisturbRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "UpsertDoNotDisturb", arg0, arg1)
+	ret0, _ := ret[0].(db.UpsertDoNotDisturbRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+// UpsertDoNotDisturb indicates an expected call of UpsertDoNotDisturb.
+func (mr *MockStoreMockRecorder) UpsertDoNotDisturb(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertDoNotDisturb", reflect.TypeOf((*MockStore)(nil).UpsertDoNotDisturb), arg0, arg1)
 }
 
-// ListWorkspaceFiles mocks base method.
-func (m *MockStore) ListWorkspaceFiles(arg0 context.Context, arg1 db.ListWorkspaceFilesParams) ([]db.ListWorkspaceFilesRow, error) {
+// UpsertEmailBranding mocks base method.
+func (m *MockStore) UpsertEmailBranding(arg0 context.Context, arg1 db.UpsertEmailBrandingParams) (db.EmailBranding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWorkspaceFiles", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListWorkspaceFilesRow)
+	ret := m.ctrl.Call(m, "UpsertEmailBranding", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailBranding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListWorkspaceFiles indicates an expected call of ListWorkspaceFiles.
-func (mr *MockStoreMockRecorder) ListWorkspaceFiles(arg0, arg1 interface{}) *gomock.Call {
+// UpsertEmailBranding indicates an expected call of UpsertEmailBranding.
+func (mr *MockStoreMockRecorder) UpsertEmailBranding(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceFiles", reflect.TypeOf((*MockStore)(nil).ListWorkspaceFiles), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEmailBranding", reflect.TypeOf((*MockStore)(nil).UpsertEmailBranding), arg0, arg1)
 }
 
-// ListWorkspaceInvitations mocks base method.
-func (m *MockStore) ListWorkspaceInvitations(arg0 context.Context, arg1 db.ListWorkspaceInvitationsParams) ([]db.WorkspaceInvitation, error) {
+// UpsertEmailSuppression mocks base method.
+func (m *MockStore) UpsertEmailSuppression(arg0 context.Context, arg1 db.UpsertEmailSuppressionParams) (db.EmailSuppression, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWorkspaceInvitations", arg0, arg1)
-	ret0, _ := ret[0].([]db.WorkspaceInvitation)
+	ret := m.ctrl.Call(m, "UpsertEmailSuppression", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailSuppression)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListWorkspaceInvitations indicates an expected call of ListWorkspaceInvitations.
-func (mr *MockStoreMockRecorder) ListWorkspaceInvitations(arg0, arg1 interface{}) *gomock.Call {
+// UpsertEmailSuppression indicates an expected call of UpsertEmailSuppression.
+func (mr *MockStoreMockRecorder) UpsertEmailSuppression(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceInvitations", reflect.TypeOf((*MockStore)(nil).ListWorkspaceInvitations), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEmailSuppression", reflect.TypeOf((*MockStore)(nil).UpsertEmailSuppression), arg0, arg1)
 }
 
-// ListWorkspaceMembers mocks base method.
-func (m *MockStore) ListWorkspaceMembers(arg0 context.Context, arg1 db.ListWorkspaceMembersParams) ([]db.ListWorkspaceMembersRow, error) {
+// UpsertEmailTemplate mocks base method.
+func (m *MockStore) UpsertEmailTemplate(arg0 context.Context, arg1 db.UpsertEmailTemplateParams) (db.EmailTemplate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWorkspaceMembers", arg0, arg1)
-	ret0, _ := ret[0].([]db.ListWorkspaceMembersRow)
+	ret := m.ctrl.Call(m, "UpsertEmailTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailTemplate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListWorkspaceMembers indicates an expected call of ListWorkspaceMembers.
-func (mr *MockStoreMockRecorder) ListWorkspaceMembers(arg0, arg1 interface{}) *gomock.Call {
+// UpsertEmailTemplate indicates an expected call of UpsertEmailTemplate.
+func (mr *MockStoreMockRecorder) UpsertEmailTemplate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceMembers", reflect.TypeOf((*MockStore)(nil).ListWorkspaceMembers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertEmailTemplate", reflect.TypeOf((*MockStore)(nil).UpsertEmailTemplate), arg0, arg1)
 }
 
-// ListWorkspacesByOrganization mocks base method.
-func (m *MockStore) ListWorkspacesByOrganization(arg0 context.Context, arg1 db.ListWorkspacesByOrganizationParams) ([]db.Workspace, error) {
+// UpsertExternalDMPolicy mocks base method.
+func (m *MockStore) UpsertExternalDMPolicy(arg0 context.Context, arg1 db.UpsertExternalDMPolicyParams) (db.ExternalDmPolicy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListWorkspacesByOrganization", arg0, arg1)
-	ret0, _ := ret[0].([]db.Workspace)
+	ret := m.ctrl.Call(m, "UpsertExternalDMPolicy", arg0, arg1)
+	ret0, _ := ret[0].(db.ExternalDmPolicy)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListWorkspacesByOrganization indicates an expected call of ListWorkspacesByOrganization.
-func (mr *MockStoreMockRecorder) ListWorkspacesByOrganization(arg0, arg1 interface{}) *gomock.Call {
+// UpsertExternalDMPolicy indicates an expected call of UpsertExternalDMPolicy.
+func (mr *MockStoreMockRecorder) UpsertExternalDMPolicy(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspacesByOrganization", reflect.TypeOf((*MockStore)(nil).ListWorkspacesByOrganization), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExternalDMPolicy", reflect.TypeOf((*MockStore)(nil).UpsertExternalDMPolicy), arg0, arg1)
 }
 
-// RemoveChannelMember mocks base method.
-func (m *MockStore) RemoveChannelMember(arg0 context.Context, arg1 db.RemoveChannelMemberParams) error {
+// UpsertFeatureFlag mocks base method.
+func (m *MockStore) UpsertFeatureFlag(arg0 context.Context, arg1 db.UpsertFeatureFlagParams) (db.FeatureFlag, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveChannelMember", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpsertFeatureFlag", arg0, arg1)
+	ret0, _ := ret[0].(db.FeatureFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RemoveChannelMember indicates an expected call of RemoveChannelMember.
-func (mr *MockStoreMockRecorder) RemoveChannelMember(arg0, arg1 interface{}) *gomock.Call {
+// UpsertFeatureFlag indicates an expected call of UpsertFeatureFlag.
+func (mr *MockStoreMockRecorder) UpsertFeatureFlag(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveChannelMember", reflect.TypeOf((*MockStore)(nil).RemoveChannelMember), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertFeatureFlag", reflect.TypeOf((*MockStore)(nil).UpsertFeatureFlag), arg0, arg1)
 }
 
-// RemoveUserFromWorkspace mocks base method.
-func (m *MockStore) RemoveUserFromWorkspace(arg0 context.Context, arg1 db.RemoveUserFromWorkspaceParams) (db.User, error) {
+// UpsertMessageDraft mocks base method.
+func (m *MockStore) UpsertMessageDraft(arg0 context.Context, arg1 db.UpsertMessageDraftParams) (db.MessageDraft, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveUserFromWorkspace", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "UpsertMessageDraft", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageDraft)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RemoveUserFromWorkspace indicates an expected call of RemoveUserFromWorkspace.
-func (mr *MockStoreMockRecorder) RemoveUserFromWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// UpsertMessageDraft indicates an expected call of UpsertMessageDraft.
+func (mr *MockStoreMockRecorder) UpsertMessageDraft(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveUserFromWorkspace", reflect.TypeOf((*MockStore)(nil).RemoveUserFromWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMessageDraft", reflect.TypeOf((*MockStore)(nil).UpsertMessageDraft), arg0, arg1)
 }
 
-// SetUsersOfflineAfterInactivity mocks base method.
-func (m *MockStore) SetUsersOfflineAfterInactivity(arg0 context.Context, arg1 time.Time) error {
+// UpsertMessageTranslation mocks base method.
+func (m *MockStore) UpsertMessageTranslation(arg0 context.Context, arg1 db.UpsertMessageTranslationParams) (db.MessageTranslation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetUsersOfflineAfterInactivity", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpsertMessageTranslation", arg0, arg1)
+	ret0, _ := ret[0].(db.MessageTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetUsersOfflineAfterInactivity indicates an expected call of SetUsersOfflineAfterInactivity.
-func (mr *MockStoreMockRecorder) SetUsersOfflineAfterInactivity(arg0, arg1 interface{}) *gomock.Call {
+// UpsertMessageTranslation indicates an expected call of UpsertMessageTranslation.
+func (mr *MockStoreMockRecorder) UpsertMessageTranslation(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUsersOfflineAfterInactivity", reflect.TypeOf((*MockStore)(nil).SetUsersOfflineAfterInactivity), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMessageTranslation", reflect.TypeOf((*MockStore)(nil).UpsertMessageTranslation), arg0, arg1)
 }
 
-// SoftDeleteMessage mocks base method.
-func (m *MockStore) SoftDeleteMessage(arg0 context.Context, arg1 int64) error {
+// UpsertOrganizationRole mocks base method.
+func (m *MockStore) UpsertOrganizationRole(arg0 context.Context, arg1 db.UpsertOrganizationRoleParams) (db.OrganizationRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SoftDeleteMessage", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpsertOrganizationRole", arg0, arg1)
+	ret0, _ := ret[0].(db.OrganizationRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SoftDeleteMessage indicates an expected call of SoftDeleteMessage.
-func (mr *MockStoreMockRecorder) SoftDeleteMessage(arg0, arg1 interface{}) *gomock.Call {
+// UpsertOrganizationRole indicates an expected call of UpsertOrganizationRole.
+func (mr *MockStoreMockRecorder) UpsertOrganizationRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteMessage", reflect.TypeOf((*MockStore)(nil).SoftDeleteMessage), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOrganizationRole", reflect.TypeOf((*MockStore)(nil).UpsertOrganizationRole), arg0, arg1)
 }
 
-// UpdateChannel mocks base method.
-func (m *MockStore) UpdateChannel(arg0 context.Context, arg1 db.UpdateChannelParams) (db.Channel, error) {
+// UpsertOutOfOffice mocks base method.
+func (m *MockStore) UpsertOutOfOffice(arg0 context.Context, arg1 db.UpsertOutOfOfficeParams) (db.OutOfOffice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateChannel", arg0, arg1)
-	ret0, _ := ret[0].(db.Channel)
+	ret := m.ctrl.Call(m, "UpsertOutOfOffice", arg0, arg1)
+	ret0, _ := ret[0].(db.OutOfOffice)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateChannel indicates an expected call of UpdateChannel.
-func (mr *MockStoreMockRecorder) UpdateChannel(arg0, arg1 interface{}) *gomock.Call {
+// UpsertOutOfOffice indicates an expected call of UpsertOutOfOffice.
+func (mr *MockStoreMockRecorder) UpsertOutOfOffice(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannel", reflect.TypeOf((*MockStore)(nil).UpdateChannel), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOutOfOffice", reflect.TypeOf((*MockStore)(nil).UpsertOutOfOffice), arg0, arg1)
 }
 
-// UpdateFileThumbnail mocks base method.
-func (m *MockStore) UpdateFileThumbnail(arg0 context.Context, arg1 db.UpdateFileThumbnailParams) error {
+// UpsertUserDevice mocks base method.
+func (m *MockStore) UpsertUserDevice(arg0 context.Context, arg1 db.UpsertUserDeviceParams) (db.UserDevice, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFileThumbnail", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpsertUserDevice", arg0, arg1)
+	ret0, _ := ret[0].(db.UserDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateFileThumbnail indicates an expected call of UpdateFileThumbnail.
-func (mr *MockStoreMockRecorder) UpdateFileThumbnail(arg0, arg1 interface{}) *gomock.Call {
+// UpsertUserDevice indicates an expected call of UpsertUserDevice.
+func (mr *MockStoreMockRecorder) UpsertUserDevice(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileThumbnail", reflect.TypeOf((*MockStore)(nil).UpdateFileThumbnail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserDevice", reflect.TypeOf((*MockStore)(nil).UpsertUserDevice), arg0, arg1)
 }
 
-// UpdateFileUploadStatus mocks base method.
-func (m *MockStore) UpdateFileUploadStatus(arg0 context.Context, arg1 db.UpdateFileUploadStatusParams) error {
+// UpsertUserStatus mocks base method.
+func (m *MockStore) UpsertUserStatus(arg0 context.Context, arg1 db.UpsertUserStatusParams) (db.UserStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFileUploadStatus", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpsertUserStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.UserStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateFileUploadStatus indicates an expected call of UpdateFileUploadStatus.
-func (mr *MockStoreMockRecorder) UpdateFileUploadStatus(arg0, arg1 interface{}) *gomock.Call {
+// UpsertUserStatus indicates an expected call of UpsertUserStatus.
+func (mr *MockStoreMockRecorder) UpsertUserStatus(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileUploadStatus", reflect.TypeOf((*MockStore)(nil).UpdateFileUploadStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserStatus", reflect.TypeOf((*MockStore)(nil).UpsertUserStatus), arg0, arg1)
 }
 
-// UpdateLastActivity mocks base method.
-func (m *MockStore) UpdateLastActivity(arg0 context.Context, arg1 db.UpdateLastActivityParams) error {
+// UpsertWorkspaceFileSettings mocks base method.
+func (m *MockStore) UpsertWorkspaceFileSettings(arg0 context.Context, arg1 db.UpsertWorkspaceFileSettingsParams) (db.WorkspaceSetting, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateLastActivity", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpsertWorkspaceFileSettings", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateLastActivity indicates an expected call of UpdateLastActivity.
-func (mr *MockStoreMockRecorder) UpdateLastActivity(arg0, arg1 interface{}) *gomock.Call {
+// UpsertWorkspaceFileSettings indicates an expected call of UpsertWorkspaceFileSettings.
+func (mr *MockStoreMockRecorder) UpsertWorkspaceFileSettings(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastActivity", reflect.TypeOf((*MockStore)(nil).UpdateLastActivity), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWorkspaceFileSettings", reflect.TypeOf((*MockStore)(nil).UpsertWorkspaceFileSettings), arg0, arg1)
 }
 
-// UpdateMessageContent mocks base method.
-func (m *MockStore) UpdateMessageContent(arg0 context.Context, arg1 db.UpdateMessageContentParams) (db.Message, error) {
+// UpsertWorkspaceMessageSettings mocks base method.
+func (m *MockStore) UpsertWorkspaceMessageSettings(arg0 context.Context, arg1 db.UpsertWorkspaceMessageSettingsParams) (db.WorkspaceSetting, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateMessageContent", arg0, arg1)
-	ret0, _ := ret[0].(db.Message)
+	ret := m.ctrl.Call(m, "UpsertWorkspaceMessageSettings", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceSetting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateMessageContent indicates an expected call of UpdateMessageContent.
-func (mr *MockStoreMockRecorder) UpdateMessageContent(arg0, arg1 interface{}) *gomock.Call {
+// UpsertWorkspaceMessageSettings indicates an expected call of UpsertWorkspaceMessageSettings.
+func (mr *MockStoreMockRecorder) UpsertWorkspaceMessageSettings(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessageContent", reflect.TypeOf((*MockStore)(nil).UpdateMessageContent), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWorkspaceMessageSettings", reflect.TypeOf((*MockStore)(nil).UpsertWorkspaceMessageSettings), arg0, arg1)
 }
 
-// UpdateOrganization mocks base method.
-func (m *MockStore) UpdateOrganization(arg0 context.Context, arg1 db.UpdateOrganizationParams) (db.Organization, error) {
+// UpsertWorkspaceNotificationPreference mocks base method.
+func (m *MockStore) UpsertWorkspaceNotificationPreference(arg0 context.Context, arg1 db.UpsertWorkspaceNotificationPreferenceParams) (db.NotificationPreference, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateOrganization", arg0, arg1)
-	ret0, _ := ret[0].(db.Organization)
+	ret := m.ctrl.Call(m, "UpsertWorkspaceNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(db.NotificationPreference)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateOrganization indicates an expected call of UpdateOrganization.
-func (mr *MockStoreMockRecorder) UpdateOrganization(arg0, arg1 interface{}) *gomock.Call {
+// UpsertWorkspaceNotificationPreference indicates an expected call of UpsertWorkspaceNotificationPreference.
+func (mr *MockStoreMockRecorder) UpsertWorkspaceNotificationPreference(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockStore)(nil).UpdateOrganization), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWorkspaceNotificationPreference", reflect.TypeOf((*MockStore)(nil).UpsertWorkspaceNotificationPreference), arg0, arg1)
 }
 
-// UpdateUserPassword mocks base method.
-func (m *MockStore) UpdateUserPassword(arg0 context.Context, arg1 db.UpdateUserPasswordParams) (db.User, error) {
+// UpsertWorkspaceOnboarding mocks base method.
+func (m *MockStore) UpsertWorkspaceOnboarding(arg0 context.Context, arg1 db.UpsertWorkspaceOnboardingParams) (db.WorkspaceOnboarding, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "UpsertWorkspaceOnboarding", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceOnboarding)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUserPassword indicates an expected call of UpdateUserPassword.
-func (mr *MockStoreMockRecorder) UpdateUserPassword(arg0, arg1 interface{}) *gomock.Call {
+// UpsertWorkspaceOnboarding indicates an expected call of UpsertWorkspaceOnboarding.
+func (mr *MockStoreMockRecorder) UpsertWorkspaceOnboarding(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStore)(nil).UpdateUserPassword), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWorkspaceOnboarding", reflect.TypeOf((*MockStore)(nil).UpsertWorkspaceOnboarding), arg0, arg1)
 }
 
-// UpdateUserProfile mocks base method.
-func (m *MockStore) UpdateUserProfile(arg0 context.Context, arg1 db.UpdateUserProfileParams) (db.User, error) {
+// UpsertWorkspacePresenceSettings mocks base method.
+func (m *MockStore) UpsertWorkspacePresenceSettings(arg0 context.Context, arg1 db.UpsertWorkspacePresenceSettingsParams) (db.WorkspaceSetting, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserProfile", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "UpsertWorkspacePresenceSettings", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceSetting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUserProfile indicates an expected call of UpdateUserProfile.
-func (mr *MockStoreMockRecorder) UpdateUserProfile(arg0, arg1 interface{}) *gomock.Call {
+// UpsertWorkspacePresenceSettings indicates an expected call of UpsertWorkspacePresenceSettings.
+func (mr *MockStoreMockRecorder) UpsertWorkspacePresenceSettings(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockStore)(nil).UpdateUserProfile), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWorkspacePresenceSettings", reflect.TypeOf((*MockStore)(nil).UpsertWorkspacePresenceSettings), arg0, arg1)
 }
 
-// UpdateUserRole mocks base method.
-func (m *MockStore) UpdateUserRole(arg0 context.Context, arg1 db.UpdateUserRoleParams) (db.User, error) {
+// UpsertWorkspaceReactionSettings mocks base method.
+func (m *MockStore) UpsertWorkspaceReactionSettings(arg0 context.Context, arg1 db.UpsertWorkspaceReactionSettingsParams) (db.WorkspaceSetting, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserRole", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "UpsertWorkspaceReactionSettings", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceSetting)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUserRole indicates an expected call of UpdateUserRole.
-func (mr *MockStoreMockRecorder) UpdateUserRole(arg0, arg1 interface{}) *gomock.Call {
+// UpsertWorkspaceReactionSettings indicates an expected call of UpsertWorkspaceReactionSettings.
+func (mr *MockStoreMockRecorder) UpsertWorkspaceReactionSettings(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockStore)(nil).UpdateUserRole), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWorkspaceReactionSettings", reflect.TypeOf((*MockStore)(nil).UpsertWorkspaceReactionSettings), arg0, arg1)
 }
 
-// UpdateUserWorkspace mocks base method.
-func (m *MockStore) UpdateUserWorkspace(arg0 context.Context, arg1 db.UpdateUserWorkspaceParams) (db.User, error) {
+// UseEmailVerification mocks base method.
+func (m *MockStore) UseEmailVerification(arg0 context.Context, arg1 string) (db.EmailVerification, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserWorkspace", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "UseEmailVerification", arg0, arg1)
+	ret0, _ := ret[0].(db.EmailVerification)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUserWorkspace indicates an expected call of UpdateUserWorkspace.
-func (mr *MockStoreMockRecorder) UpdateUserWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// UseEmailVerification indicates an expected call of UseEmailVerification.
+func (mr *MockStoreMockRecorder) UseEmailVerification(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserWorkspace", reflect.TypeOf((*MockStore)(nil).UpdateUserWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseEmailVerification", reflect.TypeOf((*MockStore)(nil).UseEmailVerification), arg0, arg1)
 }
 
-// UpdateWorkspace mocks base method.
-func (m *MockStore) UpdateWorkspace(arg0 context.Context, arg1 db.UpdateWorkspaceParams) (db.Workspace, error) {
+// UseWorkspaceJoinLink mocks base method.
+func (m *MockStore) UseWorkspaceJoinLink(arg0 context.Context, arg1 string) (db.WorkspaceJoinLink, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateWorkspace", arg0, arg1)
-	ret0, _ := ret[0].(db.Workspace)
+	ret := m.ctrl.Call(m, "UseWorkspaceJoinLink", arg0, arg1)
+	ret0, _ := ret[0].(db.WorkspaceJoinLink)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateWorkspace indicates an expected call of UpdateWorkspace.
-func (mr *MockStoreMockRecorder) UpdateWorkspace(arg0, arg1 interface{}) *gomock.Call {
+// UseWorkspaceJoinLink indicates an expected call of UseWorkspaceJoinLink.
+func (mr *MockStoreMockRecorder) UseWorkspaceJoinLink(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockStore)(nil).UpdateWorkspace), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseWorkspaceJoinLink", reflect.TypeOf((*MockStore)(nil).UseWorkspaceJoinLink), arg0, arg1)
 }
 
-// UpdateWorkspaceMemberRole mocks base method.
-func (m *MockStore) UpdateWorkspaceMemberRole(arg0 context.Context, arg1 db.UpdateWorkspaceMemberRoleParams) (db.User, error) {
+// VerifyEmailTx mocks base method.
+func (m *MockStore) VerifyEmailTx(arg0 context.Context, arg1 string) (db.VerifyEmailTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateWorkspaceMemberRole", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "VerifyEmailTx", arg0, arg1)
+	ret0, _ := ret[0].(db.VerifyEmailTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateWorkspaceMemberRole indicates an expected call of UpdateWorkspaceMemberRole.
-func (mr *MockStoreMockRecorder) UpdateWorkspaceMemberRole(arg0, arg1 interface{}) *gomock.Call {
+// VerifyEmailTx indicates an expected call of VerifyEmailTx.
+func (mr *MockStoreMockRecorder) VerifyEmailTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceMemberRole", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceMemberRole), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmailTx", reflect.TypeOf((*MockStore)(nil).VerifyEmailTx), arg0, arg1)
 }
 
-// UpsertUserStatus mocks base method.
-func (m *MockStore) UpsertUserStatus(arg0 context.Context, arg1 db.UpsertUserStatusParams) (db.UserStatus, error) {
+// WorkspaceHasActiveLegalHold mocks base method.
+func (m *MockStore) WorkspaceHasActiveLegalHold(arg0 context.Context, arg1 int64) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpsertUserStatus", arg0, arg1)
-	ret0, _ := ret[0].(db.UserStatus)
+	ret := m.ctrl.Call(m, "WorkspaceHasActiveLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpsertUserStatus indicates an expected call of UpsertUserStatus.
-func (mr *MockStoreMockRecorder) UpsertUserStatus(arg0, arg1 interface{}) *gomock.Call {
+// WorkspaceHasActiveLegalHold indicates an expected call of WorkspaceHasActiveLegalHold.
+func (mr *MockStoreMockRecorder) WorkspaceHasActiveLegalHold(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserStatus", reflect.TypeOf((*MockStore)(nil).UpsertUserStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkspaceHasActiveLegalHold", reflect.TypeOf((*MockStore)(nil).WorkspaceHasActiveLegalHold), arg0, arg1)
 }
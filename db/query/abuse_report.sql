@@ -0,0 +1,29 @@
+-- name: CreateAbuseReport :one
+INSERT INTO abuse_reports (
+    workspace_id,
+    reporter_id,
+    target_type,
+    target_id,
+    reason,
+    details,
+    anonymous
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: ListAbuseReports :many
+SELECT * FROM abuse_reports
+WHERE workspace_id = $1 AND status = $2
+ORDER BY created_at, id
+LIMIT $3 OFFSET $4;
+
+-- name: ResolveAbuseReport :one
+UPDATE abuse_reports
+SET status = $3, resolved_by = $4, resolution_note = $5, resolved_at = now()
+WHERE id = $1 AND workspace_id = $2 AND status = 'open'
+RETURNING *;
+
+-- name: GetAbuseReport :one
+SELECT * FROM abuse_reports
+WHERE id = $1 AND workspace_id = $2;
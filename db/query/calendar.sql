@@ -0,0 +1,68 @@
+-- name: UpsertCalendarIntegration :one
+INSERT INTO calendar_integrations (
+    user_id,
+    workspace_id,
+    ics_url,
+    enabled,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, now()
+)
+ON CONFLICT (user_id, workspace_id) DO UPDATE SET
+    ics_url = EXCLUDED.ics_url,
+    enabled = EXCLUDED.enabled,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetCalendarIntegration :one
+SELECT * FROM calendar_integrations
+WHERE user_id = $1 AND workspace_id = $2;
+
+-- name: DeleteCalendarIntegration :execrows
+DELETE FROM calendar_integrations
+WHERE user_id = $1 AND workspace_id = $2;
+
+-- name: ListEnabledCalendarIntegrations :many
+SELECT * FROM calendar_integrations
+WHERE enabled = true
+ORDER BY id;
+
+-- name: UpdateCalendarIntegrationSync :exec
+UPDATE calendar_integrations
+SET 
+    last_synced_at = now(),
+    last_sync_error = $2
+WHERE id = $1;
+
+-- name: DeleteCalendarBusyBlocks :exec
+DELETE FROM calendar_busy_blocks
+WHERE integration_id = $1;
+
+-- name: CreateCalendarBusyBlock :exec
+INSERT INTO calendar_busy_blocks (
+    integration_id,
+    starts_at,
+    ends_at
+) VALUES (
+    $1, $2, $3
+);
+
+-- name: ListUpcomingCalendarBusyBlocks :many
+SELECT * FROM calendar_busy_blocks
+WHERE integration_id = $1 AND ends_at > now()
+ORDER BY starts_at
+LIMIT $2;
+
+-- name: GetActiveCalendarBusyBlocks :many
+-- Returns the end of the current busy period for every user with an enabled
+-- calendar who is in a meeting right now
+SELECT 
+    ci.user_id,
+    ci.workspace_id,
+    MAX(cb.ends_at)::timestamptz AS ends_at
+FROM calendar_integrations ci
+JOIN calendar_busy_blocks cb ON cb.integration_id = ci.id
+WHERE ci.enabled = true
+    AND cb.starts_at <= now()
+    AND cb.ends_at > now()
+GROUP BY ci.user_id, ci.workspace_id;
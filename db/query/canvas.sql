@@ -0,0 +1,55 @@
+-- name: CreateCanvas :one
+INSERT INTO canvases (
+    workspace_id,
+    channel_id,
+    title,
+    content,
+    created_by,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $5
+)
+RETURNING *;
+
+-- name: GetCanvas :one
+SELECT * FROM canvases
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: ListChannelCanvases :many
+SELECT * FROM canvases
+WHERE channel_id = $1
+ORDER BY updated_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateCanvas :one
+-- Only applies when the canvas is still at the revision the edit was based on
+UPDATE canvases
+SET title = $3, content = $4, revision = revision + 1, updated_by = $5, updated_at = now()
+WHERE id = $1 AND revision = $2
+RETURNING *;
+
+-- name: DeleteCanvas :exec
+DELETE FROM canvases
+WHERE id = $1;
+
+-- name: CreateCanvasRevision :one
+INSERT INTO canvas_revisions (
+    canvas_id,
+    revision,
+    title,
+    content,
+    edited_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListCanvasRevisions :many
+SELECT * FROM canvas_revisions
+WHERE canvas_id = $1
+ORDER BY revision DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetCanvasRevision :one
+SELECT * FROM canvas_revisions
+WHERE canvas_id = $1 AND revision = $2;
@@ -11,22 +11,37 @@ RETURNING *;
 
 -- name: GetChannel :one
 SELECT * FROM channels
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
 
 -- name: GetChannelByID :one
 SELECT * FROM channels
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
 
 -- name: ListChannelsByWorkspace :many
 SELECT * FROM channels
-WHERE workspace_id = $1
+WHERE workspace_id = $1 AND deleted_at IS NULL
 ORDER BY created_at ASC
 LIMIT $2
 OFFSET $3;
 
+-- name: ListChannelsForUser :many
+-- Channels of the workspace the user can see, the public ones and the private
+-- ones they're a member of, and whether they have joined them
+SELECT
+    c.*,
+    (cm.user_id IS NOT NULL)::boolean AS is_member
+FROM channels c
+LEFT JOIN channel_members cm ON cm.channel_id = c.id AND cm.user_id = $1
+WHERE c.workspace_id = $2
+    AND c.deleted_at IS NULL
+    AND (c.is_private = false OR cm.user_id IS NOT NULL)
+ORDER BY c.created_at ASC
+LIMIT $3
+OFFSET $4;
+
 -- name: ListPublicChannelsByWorkspace :many
 SELECT * FROM channels
-WHERE workspace_id = $1 AND is_private = false
+WHERE workspace_id = $1 AND is_private = false AND deleted_at IS NULL
 ORDER BY created_at ASC
 LIMIT $2
 OFFSET $3;
@@ -36,13 +51,43 @@ UPDATE channels
 SET 
     name = $2,
     is_private = $3
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: DeleteChannel :exec
 DELETE FROM channels
 WHERE id = $1;
 
+-- name: SoftDeleteChannel :execrows
+UPDATE channels
+SET
+    deleted_at = now(),
+    deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreChannel :one
+-- Only channels still inside the recovery window can be restored
+UPDATE channels
+SET
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE id = sqlc.arg('id')
+    AND workspace_id = sqlc.arg('workspace_id')
+    AND deleted_at > sqlc.arg('deleted_after')::timestamptz
+RETURNING *;
+
+-- name: ListDeletedChannels :many
+SELECT * FROM channels
+WHERE workspace_id = sqlc.arg('workspace_id')
+    AND deleted_at > sqlc.arg('deleted_after')::timestamptz
+ORDER BY deleted_at DESC;
+
+-- name: ListPurgeableChannels :many
+SELECT * FROM channels
+WHERE deleted_at <= sqlc.arg('deleted_before')::timestamptz
+ORDER BY deleted_at ASC
+LIMIT sqlc.arg('limit');
+
 -- name: GetChannelWithCreator :one
 SELECT 
     c.*,
@@ -51,5 +96,23 @@ SELECT
     u.email as creator_email
 FROM channels c
 JOIN users u ON c.created_by = u.id
-WHERE c.id = $1
+WHERE c.id = $1 AND c.deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetChannelByName :one
+SELECT * FROM channels
+WHERE workspace_id = $1 AND name = $2 AND deleted_at IS NULL
 LIMIT 1;
+
+-- name: SearchChannels :many
+-- Private channels only match when the user is a member
+SELECT c.*, COUNT(*) OVER() as total_count
+FROM channels c
+WHERE c.workspace_id = sqlc.arg('workspace_id')
+    AND c.deleted_at IS NULL
+    AND c.name ILIKE '%' || sqlc.arg('query')::text || '%'
+    AND (c.is_private = false OR EXISTS (
+        SELECT 1 FROM channel_members cm WHERE cm.channel_id = c.id AND cm.user_id = sqlc.arg('user_id')
+    ))
+ORDER BY c.name ASC
+LIMIT sqlc.arg('limit');
@@ -35,7 +35,7 @@ SELECT
     c.*
 FROM channels c
 JOIN channel_members cm ON c.id = cm.channel_id
-WHERE cm.user_id = $1 AND c.workspace_id = $2
+WHERE cm.user_id = $1 AND c.workspace_id = $2 AND c.deleted_at IS NULL
 ORDER BY c.created_at ASC;
 
 -- name: IsChannelMember :one
@@ -0,0 +1,45 @@
+-- name: UpsertChannelNotificationDefault :one
+INSERT INTO channel_notification_defaults (
+    channel_id,
+    workspace_id,
+    level,
+    email_enabled,
+    push_enabled,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (channel_id) DO UPDATE SET
+    level = EXCLUDED.level,
+    email_enabled = EXCLUDED.email_enabled,
+    push_enabled = EXCLUDED.push_enabled,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetChannelNotificationDefault :one
+SELECT * FROM channel_notification_defaults
+WHERE channel_id = $1;
+
+-- name: ListChannelNotificationDefaults :many
+SELECT * FROM channel_notification_defaults
+WHERE workspace_id = $1
+ORDER BY channel_id;
+
+-- name: DeleteChannelNotificationDefault :execrows
+DELETE FROM channel_notification_defaults
+WHERE channel_id = $1;
+
+-- name: ApplyChannelNotificationDefault :execrows
+-- Sets every member's channel preference to the channel's default,
+-- replacing the ones they picked themselves
+INSERT INTO notification_preferences (user_id, workspace_id, channel_id, level, email_enabled, push_enabled)
+SELECT cm.user_id, d.workspace_id, d.channel_id, d.level, d.email_enabled, d.push_enabled
+FROM channel_notification_defaults d
+JOIN channel_members cm ON cm.channel_id = d.channel_id
+WHERE d.channel_id = $1
+ON CONFLICT (user_id, channel_id) WHERE channel_id IS NOT NULL DO UPDATE SET
+    level = EXCLUDED.level,
+    email_enabled = EXCLUDED.email_enabled,
+    push_enabled = EXCLUDED.push_enabled,
+    updated_at = now();
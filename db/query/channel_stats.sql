@@ -0,0 +1,93 @@
+-- name: DeleteChannelDailyStats :exec
+DELETE FROM channel_daily_stats
+WHERE day >= sqlc.arg('from_day')::date AND day < sqlc.arg('to_day')::date;
+
+-- name: DeleteChannelDailyPosterStats :exec
+DELETE FROM channel_daily_poster_stats
+WHERE day >= sqlc.arg('from_day')::date AND day < sqlc.arg('to_day')::date;
+
+-- name: RollupChannelDailyStats :execrows
+INSERT INTO channel_daily_stats (
+    channel_id,
+    day,
+    message_count,
+    reply_count,
+    thread_count,
+    last_message_at
+)
+SELECT
+    m.channel_id,
+    (m.created_at AT TIME ZONE 'UTC')::date AS day,
+    count(*),
+    count(*) FILTER (WHERE m.thread_id IS NOT NULL),
+    count(*) FILTER (WHERE m.thread_id IS NULL AND EXISTS (
+        SELECT 1 FROM messages r WHERE r.thread_id = m.id AND r.deleted_at IS NULL
+    )),
+    max(m.created_at)
+FROM messages m
+WHERE m.channel_id IS NOT NULL
+    AND m.deleted_at IS NULL
+    AND m.created_at >= sqlc.arg('from_day')::date::timestamp AT TIME ZONE 'UTC'
+    AND m.created_at < sqlc.arg('to_day')::date::timestamp AT TIME ZONE 'UTC'
+GROUP BY m.channel_id, day;
+
+-- name: RollupChannelDailyPosterStats :execrows
+INSERT INTO channel_daily_poster_stats (
+    channel_id,
+    day,
+    user_id,
+    message_count
+)
+SELECT
+    m.channel_id,
+    (m.created_at AT TIME ZONE 'UTC')::date AS day,
+    m.sender_id,
+    count(*)
+FROM messages m
+WHERE m.channel_id IS NOT NULL
+    AND m.deleted_at IS NULL
+    AND m.created_at >= sqlc.arg('from_day')::date::timestamp AT TIME ZONE 'UTC'
+    AND m.created_at < sqlc.arg('to_day')::date::timestamp AT TIME ZONE 'UTC'
+GROUP BY m.channel_id, day, m.sender_id;
+
+-- name: GetLatestChannelStatsDay :one
+-- The last day the rollup has stats for
+SELECT day FROM channel_daily_stats
+ORDER BY day DESC
+LIMIT 1;
+
+-- name: GetEarliestChannelMessageTime :one
+-- When the first channel message still counted was posted
+SELECT created_at FROM messages
+WHERE channel_id IS NOT NULL AND deleted_at IS NULL
+ORDER BY created_at
+LIMIT 1;
+
+-- name: ListChannelDailyStats :many
+SELECT * FROM channel_daily_stats
+WHERE channel_id = sqlc.arg('channel_id')
+    AND day >= sqlc.arg('from_day')::date
+    AND day < sqlc.arg('to_day')::date
+ORDER BY day;
+
+-- name: ListChannelTopPosters :many
+SELECT
+    s.user_id,
+    u.first_name,
+    u.last_name,
+    sum(s.message_count)::bigint AS message_count
+FROM channel_daily_poster_stats s
+JOIN users u ON u.id = s.user_id
+WHERE s.channel_id = sqlc.arg('channel_id')
+    AND s.day >= sqlc.arg('from_day')::date
+    AND s.day < sqlc.arg('to_day')::date
+GROUP BY s.user_id, u.first_name, u.last_name
+ORDER BY message_count DESC, s.user_id
+LIMIT sqlc.arg('limit');
+
+-- name: GetChannelLastActivity :one
+-- When the channel last had a message and when that day was rolled up
+SELECT last_message_at, updated_at FROM channel_daily_stats
+WHERE channel_id = sqlc.arg('channel_id')
+ORDER BY day DESC
+LIMIT 1;
@@ -0,0 +1,29 @@
+-- name: CreateCustomEmoji :one
+INSERT INTO custom_emojis (
+    workspace_id,
+    name,
+    file_id,
+    created_by
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetCustomEmoji :one
+SELECT * FROM custom_emojis
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: CustomEmojiExists :one
+SELECT EXISTS (
+    SELECT 1 FROM custom_emojis
+    WHERE workspace_id = $1 AND name = $2
+);
+
+-- name: ListCustomEmojis :many
+SELECT * FROM custom_emojis
+WHERE workspace_id = $1
+ORDER BY name;
+
+-- name: DeleteCustomEmoji :exec
+DELETE FROM custom_emojis
+WHERE id = $1;
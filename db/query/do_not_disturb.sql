@@ -0,0 +1,60 @@
+-- name: UpsertDoNotDisturb :one
+INSERT INTO do_not_disturb (
+    user_id,
+    workspace_id,
+    dnd_until,
+    allow_urgent_override,
+    schedule_start_minute,
+    schedule_end_minute,
+    schedule_days,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, now()
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    workspace_id = EXCLUDED.workspace_id,
+    dnd_until = EXCLUDED.dnd_until,
+    allow_urgent_override = EXCLUDED.allow_urgent_override,
+    schedule_start_minute = EXCLUDED.schedule_start_minute,
+    schedule_end_minute = EXCLUDED.schedule_end_minute,
+    schedule_days = EXCLUDED.schedule_days,
+    updated_at = now()
+RETURNING *, (SELECT timezone FROM users WHERE users.id = do_not_disturb.user_id)::text AS timezone;
+
+-- name: GetDoNotDisturb :one
+-- Whether Do Not Disturb is on depends on the user's timezone, so it comes along
+SELECT d.*, u.timezone
+FROM do_not_disturb d
+JOIN users u ON u.id = d.user_id
+WHERE d.user_id = $1 AND d.workspace_id = $2;
+
+-- name: CreateDNDOverride :execrows
+-- Affects no rows when the message already broke through Do Not Disturb
+INSERT INTO dnd_overrides (
+    message_id,
+    sender_id,
+    receiver_id
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT DO NOTHING;
+
+-- name: ListWorkspaceDoNotDisturb :many
+-- Do Not Disturb settings of everyone in a workspace who has them, for presence lists
+SELECT d.*, u.timezone
+FROM do_not_disturb d
+JOIN users u ON u.id = d.user_id
+WHERE d.workspace_id = $1;
+
+-- name: ClearExpiredDoNotDisturb :many
+-- Ends Do Not Disturb that was on until a time that has passed, such as a
+-- snooze, and returns the statuses of the users whose presence changes
+WITH cleared AS (
+    UPDATE do_not_disturb
+    SET dnd_until = NULL, updated_at = now()
+    WHERE dnd_until IS NOT NULL AND dnd_until <= now()
+    RETURNING user_id, workspace_id
+)
+SELECT us.*
+FROM user_status us
+JOIN cleared c ON c.user_id = us.user_id AND c.workspace_id = us.workspace_id;
@@ -0,0 +1,70 @@
+-- name: CreateEmailDelivery :one
+INSERT INTO email_deliveries (
+    category,
+    reference_id,
+    to_address,
+    subject,
+    text_body,
+    html_body
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ClaimEmailDeliveries :many
+-- Takes due emails for sending. Emails stuck in sending since before
+-- stale_before, because a worker stopped mid-send, are taken again.
+UPDATE email_deliveries
+SET
+    status = 'sending',
+    attempts = attempts + 1,
+    updated_at = now()
+WHERE id IN (
+    SELECT id FROM email_deliveries
+    WHERE (status = 'pending' AND next_attempt_at <= now())
+        OR (status = 'sending' AND updated_at < sqlc.arg('stale_before'))
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkEmailDeliverySent :exec
+UPDATE email_deliveries
+SET
+    status = 'sent',
+    provider = $2,
+    provider_message_id = $3,
+    last_error = '',
+    sent_at = now(),
+    updated_at = now()
+WHERE id = $1;
+
+-- name: RetryEmailDelivery :exec
+UPDATE email_deliveries
+SET
+    status = 'pending',
+    provider = $2,
+    last_error = $3,
+    next_attempt_at = $4,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: FailEmailDelivery :exec
+UPDATE email_deliveries
+SET
+    status = 'failed',
+    provider = $2,
+    last_error = $3,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: ListEmailDeliveriesByAddress :many
+SELECT * FROM email_deliveries
+WHERE lower(to_address) = lower(sqlc.arg('to_address'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit');
+
+-- name: DeleteOldEmailDeliveries :execrows
+DELETE FROM email_deliveries
+WHERE created_at < $1 AND status IN ('sent', 'failed');
@@ -0,0 +1,27 @@
+-- name: UpsertEmailSuppression :one
+INSERT INTO email_suppressions (
+    email,
+    reason,
+    provider,
+    detail
+) VALUES (
+    lower(sqlc.arg('email')), sqlc.arg('reason'), sqlc.arg('provider'), sqlc.arg('detail')
+)
+ON CONFLICT (email) DO UPDATE SET
+    reason = EXCLUDED.reason,
+    provider = EXCLUDED.provider,
+    detail = EXCLUDED.detail,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetEmailSuppression :one
+SELECT * FROM email_suppressions
+WHERE email = lower(sqlc.arg('email'));
+
+-- name: ListEmailSuppressionsByEmails :many
+SELECT * FROM email_suppressions
+WHERE email = ANY(sqlc.arg('emails')::text[]);
+
+-- name: DeleteEmailSuppression :execrows
+DELETE FROM email_suppressions
+WHERE email = lower(sqlc.arg('email'));
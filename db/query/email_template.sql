@@ -0,0 +1,53 @@
+-- name: GetEmailTemplate :one
+SELECT * FROM email_templates
+WHERE organization_id = $1 AND kind = $2;
+
+-- name: ListEmailTemplates :many
+SELECT * FROM email_templates
+WHERE organization_id = $1
+ORDER BY kind;
+
+-- name: UpsertEmailTemplate :one
+INSERT INTO email_templates (
+    organization_id,
+    kind,
+    subject,
+    text_body,
+    html_body,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (organization_id, kind) DO UPDATE SET
+    subject = EXCLUDED.subject,
+    text_body = EXCLUDED.text_body,
+    html_body = EXCLUDED.html_body,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteEmailTemplate :execrows
+DELETE FROM email_templates
+WHERE organization_id = $1 AND kind = $2;
+
+-- name: GetEmailBranding :one
+SELECT * FROM email_brandings
+WHERE organization_id = $1;
+
+-- name: UpsertEmailBranding :one
+INSERT INTO email_brandings (
+    organization_id,
+    product_name,
+    logo_url,
+    primary_color,
+    footer_text
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (organization_id) DO UPDATE SET
+    product_name = EXCLUDED.product_name,
+    logo_url = EXCLUDED.logo_url,
+    primary_color = EXCLUDED.primary_color,
+    footer_text = EXCLUDED.footer_text,
+    updated_at = now()
+RETURNING *;
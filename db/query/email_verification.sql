@@ -0,0 +1,26 @@
+-- name: CreateEmailVerification :one
+INSERT INTO email_verifications (
+    user_id,
+    email,
+    token,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: UseEmailVerification :one
+-- Marks a verification that is still valid as used, no row is returned otherwise
+UPDATE email_verifications
+SET used_at = now()
+WHERE token = $1
+    AND used_at IS NULL
+    AND expires_at > now()
+RETURNING *;
+
+-- name: MarkUserEmailVerified :one
+-- Only verifies the address the verification was sent to
+UPDATE users
+SET email_verified_at = now()
+WHERE id = $1 AND email = $2
+RETURNING *;
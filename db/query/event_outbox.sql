@@ -0,0 +1,27 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO event_outbox (
+    event_type,
+    workspace_id,
+    channel_id,
+    user_id,
+    recipient_ids,
+    payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM event_outbox
+WHERE published_at < $1;
+
+-- name: ListUnpublishedOutboxEvents :many
+SELECT * FROM event_outbox
+WHERE published_at IS NULL AND created_at < $1
+ORDER BY id
+LIMIT $2;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE event_outbox
+SET published_at = now()
+WHERE id = $1 AND published_at IS NULL;
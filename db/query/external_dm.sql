@@ -0,0 +1,76 @@
+-- name: GetExternalDMPolicy :one
+SELECT * FROM external_dm_policies
+WHERE organization_id = $1;
+
+-- name: UpsertExternalDMPolicy :one
+INSERT INTO external_dm_policies (
+    organization_id,
+    policy,
+    allowed_domains,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (organization_id) DO UPDATE SET
+    policy = EXCLUDED.policy,
+    allowed_domains = EXCLUDED.allowed_domains,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: CreateExternalDMRequest :one
+-- Returns no row when the two people already have an open request or
+-- conversation
+INSERT INTO external_dm_requests (
+    sender_id,
+    workspace_id,
+    recipient_id,
+    message
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (LEAST(sender_id, recipient_id), GREATEST(sender_id, recipient_id)) WHERE status IN ('pending', 'accepted')
+DO NOTHING
+RETURNING *;
+
+-- name: GetExternalDMRequest :one
+SELECT r.id, r.sender_id, r.workspace_id, r.recipient_id, r.message, r.status, r.created_at, r.responded_at,
+    s.first_name AS sender_first_name, s.last_name AS sender_last_name, s.email AS sender_email,
+    s.organization_id AS sender_organization_id,
+    u.first_name AS recipient_first_name, u.last_name AS recipient_last_name, u.email AS recipient_email,
+    u.organization_id AS recipient_organization_id
+FROM external_dm_requests r
+JOIN users s ON s.id = r.sender_id
+JOIN users u ON u.id = r.recipient_id
+WHERE r.id = $1;
+
+-- name: ListExternalDMRequests :many
+-- Requests the user sent or received, newest first, optionally only those
+-- with a status
+SELECT r.id, r.sender_id, r.workspace_id, r.recipient_id, r.message, r.status, r.created_at, r.responded_at,
+    s.first_name AS sender_first_name, s.last_name AS sender_last_name, s.email AS sender_email,
+    s.organization_id AS sender_organization_id,
+    u.first_name AS recipient_first_name, u.last_name AS recipient_last_name, u.email AS recipient_email,
+    u.organization_id AS recipient_organization_id
+FROM external_dm_requests r
+JOIN users s ON s.id = r.sender_id
+JOIN users u ON u.id = r.recipient_id
+WHERE (r.sender_id = sqlc.arg('user_id') OR r.recipient_id = sqlc.arg('user_id'))
+  AND (sqlc.arg('status')::text = '' OR r.status = sqlc.arg('status')::text)
+ORDER BY r.created_at DESC, r.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: RespondExternalDMRequest :one
+-- Accepts or declines a request that is still pending
+UPDATE external_dm_requests
+SET status = $2, responded_at = now()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: CloseExternalDMRequest :one
+-- Withdraws a pending request or ends an accepted conversation
+UPDATE external_dm_requests
+SET status = 'closed', responded_at = COALESCE(responded_at, now())
+WHERE id = $1 AND status IN ('pending', 'accepted')
+RETURNING *;
@@ -0,0 +1,24 @@
+-- name: ListFeatureFlags :many
+SELECT * FROM feature_flags
+WHERE workspace_id = $1
+ORDER BY flag;
+
+-- name: UpsertFeatureFlag :one
+INSERT INTO feature_flags (
+    workspace_id,
+    flag,
+    enabled,
+    updated_by,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, now()
+)
+ON CONFLICT (workspace_id, flag) DO UPDATE SET
+    enabled = EXCLUDED.enabled,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteFeatureFlag :execrows
+DELETE FROM feature_flags
+WHERE workspace_id = $1 AND flag = $2;
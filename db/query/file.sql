@@ -9,11 +9,11 @@ INSERT INTO files (
 
 -- name: GetFile :one
 SELECT * FROM files
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
 
 -- name: GetFileByHash :one
 SELECT * FROM files
-WHERE file_hash = $1 AND workspace_id = $2 AND upload_completed = true
+WHERE file_hash = $1 AND workspace_id = $2 AND upload_completed = true AND deleted_at IS NULL
 LIMIT 1;
 
 -- name: UpdateFileUploadStatus :exec
@@ -26,11 +26,50 @@ UPDATE files
 SET thumbnail_path = $2, updated_at = now()
 WHERE id = $1;
 
+-- name: UpdateFileVoiceMetadata :exec
+UPDATE files
+SET is_voice_message = true, duration_ms = $2, waveform = $3, updated_at = now()
+WHERE id = $1;
+
+-- name: UpdateFileProcessing :exec
+UPDATE files
+SET processing_status = $2, transcoded_path = $3, poster_path = $4, updated_at = now()
+WHERE id = $1;
+
+-- name: ListPendingVideoFiles :many
+SELECT * FROM files
+WHERE processing_status = 'pending' AND deleted_at IS NULL
+ORDER BY created_at
+LIMIT $1;
+
+-- name: RequeueInterruptedFileProcessing :exec
+-- Files left mid-transcode by a restart are picked up again
+UPDATE files
+SET processing_status = 'pending', updated_at = now()
+WHERE processing_status = 'processing';
+
+-- name: UpdateFilePreview :exec
+UPDATE files
+SET preview_status = $2, thumbnail_path = $3, updated_at = now()
+WHERE id = $1;
+
+-- name: ListPendingFilePreviews :many
+SELECT * FROM files
+WHERE preview_status = 'pending' AND deleted_at IS NULL
+ORDER BY created_at
+LIMIT $1;
+
+-- name: RequeueInterruptedFilePreviews :exec
+-- Documents left mid-render by a restart are picked up again
+UPDATE files
+SET preview_status = 'pending', updated_at = now()
+WHERE preview_status = 'processing';
+
 -- name: ListWorkspaceFiles :many
 SELECT f.*, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
 FROM files f
 JOIN users u ON f.uploader_id = u.id
-WHERE f.workspace_id = $1 AND f.upload_completed = true
+WHERE f.workspace_id = $1 AND f.upload_completed = true AND f.deleted_at IS NULL
 ORDER BY f.created_at DESC
 LIMIT $2 OFFSET $3;
 
@@ -38,7 +77,7 @@ LIMIT $2 OFFSET $3;
 SELECT f.*, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
 FROM files f
 JOIN users u ON f.uploader_id = u.id
-WHERE f.uploader_id = $1 AND f.workspace_id = $2 AND f.upload_completed = true
+WHERE f.uploader_id = $1 AND f.workspace_id = $2 AND f.upload_completed = true AND f.deleted_at IS NULL
 ORDER BY f.created_at DESC
 LIMIT $3 OFFSET $4;
 
@@ -46,11 +85,56 @@ LIMIT $3 OFFSET $4;
 DELETE FROM files
 WHERE id = $1 AND uploader_id = $2;
 
+-- name: TrashFile :execrows
+UPDATE files
+SET
+    deleted_at = now(),
+    deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreFile :one
+-- Only files still inside the trash retention can be restored
+UPDATE files
+SET
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE id = sqlc.arg('id')
+    AND workspace_id = sqlc.arg('workspace_id')
+    AND deleted_at > sqlc.arg('deleted_after')::timestamptz
+RETURNING *;
+
+-- name: GetTrashedFile :one
+SELECT * FROM files
+WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL
+LIMIT 1;
+
+-- name: ListTrashedFiles :many
+-- Files in the trash, narrowed to one uploader's when an uploader is given
+SELECT f.*, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
+FROM files f
+JOIN users u ON f.uploader_id = u.id
+WHERE f.workspace_id = sqlc.arg('workspace_id')
+    AND f.deleted_at > sqlc.arg('deleted_after')::timestamptz
+    AND (sqlc.narg('uploader_id')::bigint IS NULL OR f.uploader_id = sqlc.narg('uploader_id'))
+ORDER BY f.deleted_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListPurgeableFiles :many
+SELECT * FROM files
+WHERE deleted_at <= sqlc.arg('deleted_before')::timestamptz
+ORDER BY deleted_at ASC
+LIMIT sqlc.arg('limit');
+
+-- name: PurgeFile :execrows
+-- Only files in the trash are purged
+DELETE FROM files
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
 -- name: GetFileWithPermissionCheck :one
 SELECT f.*, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
 FROM files f
 JOIN users u ON f.uploader_id = u.id
-WHERE f.id = $1 AND f.workspace_id = $2 AND f.upload_completed = true
+WHERE f.id = $1 AND f.workspace_id = $2 AND f.upload_completed = true AND f.deleted_at IS NULL
 LIMIT 1;
 
 -- name: CreateMessageFile :one
@@ -63,8 +147,8 @@ SELECT f.*, u.first_name as uploader_first_name, u.last_name as uploader_last_na
 FROM message_files mf
 JOIN files f ON mf.file_id = f.id
 JOIN users u ON f.uploader_id = u.id
-WHERE mf.message_id = $1
-ORDER BY mf.created_at ASC;
+WHERE mf.message_id = $1 AND f.deleted_at IS NULL
+ORDER BY mf.created_at ASC, mf.id ASC;
 
 -- name: GetFileMessages :many
 SELECT m.*, u.first_name as sender_first_name, u.last_name as sender_last_name, u.email as sender_email
@@ -93,6 +177,7 @@ ORDER BY fs.created_at DESC;
 -- name: CheckFileAccess :one
 -- Check if user has access to file through direct ownership, channel membership, or direct share
 SELECT CASE 
+    WHEN f.deleted_at IS NOT NULL THEN false
     WHEN f.uploader_id = $2 THEN true
     WHEN f.is_public = true THEN true
     WHEN EXISTS (
@@ -118,17 +203,64 @@ SELECT
     COUNT(*) FILTER (WHERE mime_type LIKE 'image/%') as image_count,
     COUNT(*) FILTER (WHERE mime_type = 'application/pdf') as pdf_count
 FROM files 
-WHERE workspace_id = $1 AND upload_completed = true;
+WHERE workspace_id = $1 AND upload_completed = true AND deleted_at IS NULL;
 
--- name: CleanupIncompleteUploads :exec
+-- name: CleanupIncompleteUploads :execrows
 DELETE FROM files 
 WHERE upload_completed = false 
 AND created_at < now() - INTERVAL '1 hour';
 
+-- name: ListFilesForReconciliation :many
+-- Pages through every file with the paths it keeps in the file store
+SELECT id, file_path, mime_type, upload_completed, thumbnail_path, transcoded_path, poster_path, processing_status, preview_status
+FROM files
+WHERE id > sqlc.arg('after_id')
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
 -- name: GetDuplicateFiles :many
 SELECT file_hash, COUNT(*) as count, ARRAY_AGG(id) as file_ids, SUM(file_size) as total_size
 FROM files 
-WHERE workspace_id = $1 AND upload_completed = true
+WHERE workspace_id = $1 AND upload_completed = true AND deleted_at IS NULL
 GROUP BY file_hash 
 HAVING COUNT(*) > 1
 ORDER BY count DESC;
+
+-- name: SearchFiles :many
+-- Only files the user can access through ownership, public visibility or a share are returned
+SELECT f.*, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email,
+    COUNT(*) OVER() as total_count
+FROM files f
+JOIN users u ON f.uploader_id = u.id
+WHERE f.workspace_id = sqlc.arg('workspace_id')
+    AND f.upload_completed = true
+    AND f.deleted_at IS NULL
+    AND f.original_filename ILIKE '%' || sqlc.arg('query')::text || '%'
+    AND (
+        f.uploader_id = sqlc.arg('user_id')
+        OR f.is_public = true
+        OR EXISTS (
+            SELECT 1 FROM file_shares fs
+            LEFT JOIN channel_members cm ON fs.channel_id = cm.channel_id
+            WHERE fs.file_id = f.id
+            AND (fs.shared_with_user_id = sqlc.arg('user_id') OR cm.user_id = sqlc.arg('user_id'))
+            AND (fs.expires_at IS NULL OR fs.expires_at > now())
+        )
+    )
+ORDER BY f.created_at DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListStoredFiles :many
+-- Lists the files whose content is in the file store, for backups
+SELECT id, stored_filename, file_path, file_hash, thumbnail_path FROM files
+WHERE upload_completed = true
+ORDER BY id;
+
+-- name: IsFileInPrivateChannel :one
+-- Whether the file is attached to a message in a private channel
+SELECT EXISTS (
+    SELECT 1 FROM message_files mf
+    JOIN messages m ON m.id = mf.message_id
+    JOIN channels c ON c.id = m.channel_id
+    WHERE mf.file_id = $1 AND c.is_private = true
+);
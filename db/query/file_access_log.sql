@@ -0,0 +1,27 @@
+-- name: RecordFileDownload :exec
+-- Logs a download and adds it to the file's download count
+WITH logged AS (
+    INSERT INTO file_access_log (
+        file_id,
+        user_id,
+        ip_address,
+        user_agent
+    ) VALUES (
+        $1, $2, $3, $4
+    )
+)
+UPDATE files
+SET download_count = download_count + 1
+WHERE id = $1;
+
+-- name: ListFileAccessLog :many
+-- A file's downloads, most recent first, with who downloaded it
+SELECT l.*,
+    COALESCE(u.first_name, '')::text AS first_name,
+    COALESCE(u.last_name, '')::text AS last_name,
+    COALESCE(u.email, '')::text AS email
+FROM file_access_log l
+LEFT JOIN users u ON u.id = l.user_id
+WHERE l.file_id = $1
+ORDER BY l.created_at DESC, l.id DESC
+LIMIT $2 OFFSET $3;
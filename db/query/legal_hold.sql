@@ -0,0 +1,87 @@
+-- name: CreateLegalHold :one
+INSERT INTO legal_holds (
+    organization_id,
+    target_type,
+    target_id,
+    reason,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetLegalHold :one
+SELECT * FROM legal_holds
+WHERE id = $1 AND organization_id = $2;
+
+-- name: ListLegalHolds :many
+SELECT * FROM legal_holds
+WHERE organization_id = sqlc.arg('organization_id')
+    AND (NOT sqlc.arg('active_only')::bool OR released_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ReleaseLegalHold :one
+UPDATE legal_holds
+SET released_by = $3, released_at = now()
+WHERE id = $1 AND organization_id = $2 AND released_at IS NULL
+RETURNING *;
+
+-- name: HasActiveLegalHold :one
+SELECT EXISTS (
+    SELECT 1 FROM legal_holds
+    WHERE target_type = $1 AND target_id = $2 AND released_at IS NULL
+);
+
+-- name: WorkspaceHasActiveLegalHold :one
+-- A workspace holds content under legal hold if one of its channels is held, or
+-- a held user is a member or has messages or files in it
+SELECT EXISTS (
+    SELECT 1 FROM legal_holds h
+    WHERE h.released_at IS NULL AND (
+        (h.target_type = 'channel' AND EXISTS (
+            SELECT 1 FROM channels c WHERE c.id = h.target_id AND c.workspace_id = $1
+        ))
+        OR (h.target_type = 'user' AND (
+            EXISTS (SELECT 1 FROM users u WHERE u.id = h.target_id AND u.workspace_id = $1)
+            OR EXISTS (
+                SELECT 1 FROM messages m
+                WHERE m.workspace_id = $1 AND (m.sender_id = h.target_id OR m.receiver_id = h.target_id)
+            )
+            OR EXISTS (SELECT 1 FROM files f WHERE f.workspace_id = $1 AND f.uploader_id = h.target_id)
+        ))
+    )
+);
+
+-- name: OrganizationHasActiveLegalHold :one
+SELECT EXISTS (
+    SELECT 1 FROM legal_holds
+    WHERE organization_id = $1 AND released_at IS NULL
+);
+
+-- name: ListLegalHoldMessages :many
+-- Exports held messages, including deleted ones, oldest first
+SELECT 
+    m.*,
+    u.first_name as sender_first_name,
+    u.last_name as sender_last_name,
+    u.email as sender_email
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+WHERE (sqlc.arg('target_type')::text = 'channel' AND m.channel_id = sqlc.arg('target_id')::bigint)
+    OR (sqlc.arg('target_type')::text = 'user' AND (m.sender_id = sqlc.arg('target_id')::bigint OR m.receiver_id = sqlc.arg('target_id')::bigint))
+ORDER BY m.created_at, m.id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ChannelHasActiveLegalHold :one
+-- A channel holds content under legal hold if it is held itself or a held user
+-- posted in it
+SELECT EXISTS (
+    SELECT 1 FROM legal_holds h
+    WHERE h.released_at IS NULL AND (
+        (h.target_type = 'channel' AND h.target_id = $1)
+        OR (h.target_type = 'user' AND EXISTS (
+            SELECT 1 FROM messages m WHERE m.channel_id = $1 AND m.sender_id = h.target_id
+        ))
+    )
+);
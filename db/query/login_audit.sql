@@ -0,0 +1,59 @@
+-- name: UpsertUserDevice :one
+-- Records a login from a device, adding the device the first time it's seen
+INSERT INTO user_devices (
+    user_id,
+    fingerprint,
+    user_agent,
+    last_ip_address
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (user_id, fingerprint) DO UPDATE
+SET user_agent = EXCLUDED.user_agent,
+    last_ip_address = EXCLUDED.last_ip_address,
+    last_seen_at = now()
+RETURNING *;
+
+-- name: GetUserDevice :one
+SELECT * FROM user_devices
+WHERE id = $1 AND user_id = $2;
+
+-- name: ListUserDevices :many
+SELECT * FROM user_devices
+WHERE user_id = $1
+ORDER BY last_seen_at DESC, id DESC;
+
+-- name: UpdateUserDevice :one
+UPDATE user_devices
+SET name = $3,
+    trusted_until = $4
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteUserDevice :execrows
+DELETE FROM user_devices
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateLoginEvent :one
+INSERT INTO login_events (
+    user_id,
+    email,
+    device_id,
+    fingerprint,
+    ip_address,
+    user_agent,
+    success,
+    failure_reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: ListLoginEvents :many
+-- A user's login attempts, most recent first, with the name they gave the device
+SELECT le.*, COALESCE(d.name, '')::text AS device_name
+FROM login_events le
+LEFT JOIN user_devices d ON d.id = le.device_id
+WHERE le.user_id = $1
+ORDER BY le.created_at DESC, le.id DESC
+LIMIT $2 OFFSET $3;
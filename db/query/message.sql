@@ -24,6 +24,23 @@ INSERT INTO messages (
 )
 RETURNING *;
 
+-- name: CreateMessageAt :one
+-- Creates a message with its original timestamp, for importing or seeding history
+INSERT INTO messages (
+    workspace_id,
+    channel_id,
+    sender_id,
+    receiver_id,
+    content,
+    content_type,
+    message_type,
+    thread_id,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
 -- name: GetChannelMessages :many
 SELECT 
     m.*,
@@ -32,12 +49,24 @@ SELECT
     u.email as sender_email
 FROM messages m
 JOIN users u ON m.sender_id = u.id
-WHERE m.channel_id = $1 
-    AND m.workspace_id = $2 
-    AND m.deleted_at IS NULL
+WHERE m.channel_id = sqlc.arg('channel_id')
+    AND m.workspace_id = sqlc.arg('workspace_id')
+    AND (m.deleted_at IS NULL OR sqlc.arg('include_deleted')::boolean)
 ORDER BY m.created_at DESC
-LIMIT $3
-OFFSET $4;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListChannelMessageIDsAt :many
+-- The channel messages posted at an instant, the way Slack ts values name
+-- messages. Two at most are returned, enough to tell whether the instant
+-- names a single message.
+SELECT id FROM messages
+WHERE channel_id = sqlc.arg('channel_id')
+    AND workspace_id = sqlc.arg('workspace_id')
+    AND created_at = sqlc.arg('created_at')
+    AND deleted_at IS NULL
+ORDER BY id
+LIMIT 2;
 
 -- name: GetDirectMessagesBetweenUsers :many
 SELECT 
@@ -58,6 +87,37 @@ ORDER BY m.created_at DESC
 LIMIT $4
 OFFSET $5;
 
+-- name: ListDirectMessageIDsAt :many
+-- The direct messages between two users sent at an instant, as in
+-- ListChannelMessageIDsAt
+SELECT id FROM messages
+WHERE workspace_id = $1
+    AND message_type = 'direct'
+    AND created_at = $4
+    AND deleted_at IS NULL
+    AND (
+        (sender_id = $2 AND receiver_id = $3) OR
+        (sender_id = $3 AND receiver_id = $2)
+    )
+ORDER BY id
+LIMIT 2;
+
+-- name: CountChannelMessages :one
+SELECT COUNT(*) FROM messages
+WHERE channel_id = sqlc.arg('channel_id')
+    AND workspace_id = sqlc.arg('workspace_id')
+    AND (deleted_at IS NULL OR sqlc.arg('include_deleted')::boolean);
+
+-- name: CountDirectMessagesBetweenUsers :one
+SELECT COUNT(*) FROM messages
+WHERE workspace_id = $1
+    AND message_type = 'direct'
+    AND deleted_at IS NULL
+    AND (
+        (sender_id = $2 AND receiver_id = $3) OR
+        (sender_id = $3 AND receiver_id = $2)
+    );
+
 -- name: GetMessageByID :one
 SELECT 
     m.*,
@@ -76,11 +136,37 @@ SET
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
+-- name: PurgeDeletedMessages :execrows
+-- Permanently removes a batch of messages deleted before the cutoff. Messages
+-- with replies keep their tombstone so the thread stays intact, and messages
+-- of held users or channels are kept while the legal hold is active.
+DELETE FROM messages
+WHERE id IN (
+    SELECT m.id FROM messages m
+    WHERE m.deleted_at < sqlc.arg('deleted_before')
+        AND NOT EXISTS (SELECT 1 FROM messages r WHERE r.thread_id = m.id)
+        AND NOT EXISTS (
+            SELECT 1 FROM legal_holds h
+            WHERE h.released_at IS NULL AND (
+                (h.target_type = 'user' AND h.target_id = m.sender_id)
+                OR (h.target_type = 'channel' AND h.target_id = m.channel_id)
+            )
+        )
+    ORDER BY m.deleted_at
+    LIMIT sqlc.arg('limit')
+);
+
 -- name: SoftDeleteMessage :exec
 UPDATE messages
-SET deleted_at = now()
+SET deleted_at = now(), deleted_by = $2
 WHERE id = $1;
 
+-- name: TakedownMessage :execrows
+-- Deletes a message on behalf of a moderator, leaving a notice in its tombstone
+UPDATE messages
+SET deleted_at = now(), deleted_by = $2, deletion_notice = $3
+WHERE id = $1 AND deleted_at IS NULL;
+
 -- name: GetRecentWorkspaceMessages :many
 SELECT 
     m.*,
@@ -97,4 +183,98 @@ OFFSET $3;
 
 -- name: CheckMessageAuthor :one
 SELECT sender_id FROM messages
-WHERE id = $1 AND deleted_at IS NULL;
\ No newline at end of file
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: SearchMessages :many
+-- Channel messages only match in public channels and private channels the
+-- user is a member of, direct messages only when the user took part
+SELECT 
+    m.*,
+    u.first_name as sender_first_name,
+    u.last_name as sender_last_name,
+    u.email as sender_email,
+    COUNT(*) OVER() as total_count
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+WHERE m.workspace_id = sqlc.arg('workspace_id')
+    AND m.deleted_at IS NULL
+    AND (
+        (m.message_type = 'channel' AND EXISTS (
+            SELECT 1 FROM channels c
+            WHERE c.id = m.channel_id
+                AND c.deleted_at IS NULL
+                AND (c.is_private = false OR EXISTS (
+                    SELECT 1 FROM channel_members cm WHERE cm.channel_id = c.id AND cm.user_id = sqlc.arg('user_id')
+                ))
+        ))
+        OR (m.message_type = 'direct' AND (m.sender_id = sqlc.arg('user_id') OR m.receiver_id = sqlc.arg('user_id')))
+    )
+    AND (sqlc.narg('query')::text IS NULL OR m.search_vector @@ plainto_tsquery('english', sqlc.narg('query')::text))
+    AND (sqlc.narg('sender_id')::bigint IS NULL OR m.sender_id = sqlc.narg('sender_id')::bigint)
+    AND (sqlc.narg('channel_id')::bigint IS NULL OR m.channel_id = sqlc.narg('channel_id')::bigint)
+    AND (sqlc.narg('after')::timestamptz IS NULL OR m.created_at >= sqlc.narg('after')::timestamptz)
+    AND (sqlc.narg('before')::timestamptz IS NULL OR m.created_at < sqlc.narg('before')::timestamptz)
+    AND (NOT sqlc.arg('has_file')::boolean OR EXISTS (
+        SELECT 1 FROM message_files mf WHERE mf.message_id = m.id
+    ))
+    AND (NOT sqlc.arg('has_link')::boolean OR m.content ~* 'https?://')
+    AND (NOT sqlc.arg('is_thread')::boolean OR m.thread_id IS NOT NULL OR EXISTS (
+        SELECT 1 FROM messages r WHERE r.thread_id = m.id AND r.deleted_at IS NULL
+    ))
+ORDER BY m.created_at DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: GetMessagesBefore :many
+-- Messages preceding an anchor message in the same conversation, newest first.
+-- The conversation is a thread when thread_id is set, otherwise a channel or a direct
+-- message pair. Thread replies are left out of channel and direct message context.
+-- Deleted messages are included as tombstones when include_deleted is set.
+SELECT 
+    m.*,
+    u.first_name as sender_first_name,
+    u.last_name as sender_last_name,
+    u.email as sender_email
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+WHERE m.workspace_id = sqlc.arg('workspace_id')
+    AND (m.created_at, m.id) < (sqlc.arg('created_at')::timestamptz, sqlc.arg('message_id')::bigint)
+    AND (
+        (sqlc.narg('thread_id')::bigint IS NOT NULL AND (m.thread_id = sqlc.narg('thread_id')::bigint OR m.id = sqlc.narg('thread_id')::bigint))
+        OR (sqlc.narg('thread_id')::bigint IS NULL AND m.thread_id IS NULL AND (
+            (sqlc.narg('channel_id')::bigint IS NOT NULL AND m.channel_id = sqlc.narg('channel_id')::bigint)
+            OR (sqlc.narg('channel_id')::bigint IS NULL AND m.message_type = 'direct' AND (
+                (m.sender_id = sqlc.arg('user_a')::bigint AND m.receiver_id = sqlc.arg('user_b')::bigint) OR
+                (m.sender_id = sqlc.arg('user_b')::bigint AND m.receiver_id = sqlc.arg('user_a')::bigint)
+            ))
+        ))
+    )
+    AND (m.deleted_at IS NULL OR sqlc.arg('include_deleted')::boolean)
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: GetMessagesAfter :many
+-- Messages following an anchor message in the same conversation, oldest first.
+-- Scoping works the same way as GetMessagesBefore.
+SELECT 
+    m.*,
+    u.first_name as sender_first_name,
+    u.last_name as sender_last_name,
+    u.email as sender_email
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+WHERE m.workspace_id = sqlc.arg('workspace_id')
+    AND (m.created_at, m.id) > (sqlc.arg('created_at')::timestamptz, sqlc.arg('message_id')::bigint)
+    AND (
+        (sqlc.narg('thread_id')::bigint IS NOT NULL AND (m.thread_id = sqlc.narg('thread_id')::bigint OR m.id = sqlc.narg('thread_id')::bigint))
+        OR (sqlc.narg('thread_id')::bigint IS NULL AND m.thread_id IS NULL AND (
+            (sqlc.narg('channel_id')::bigint IS NOT NULL AND m.channel_id = sqlc.narg('channel_id')::bigint)
+            OR (sqlc.narg('channel_id')::bigint IS NULL AND m.message_type = 'direct' AND (
+                (m.sender_id = sqlc.arg('user_a')::bigint AND m.receiver_id = sqlc.arg('user_b')::bigint) OR
+                (m.sender_id = sqlc.arg('user_b')::bigint AND m.receiver_id = sqlc.arg('user_a')::bigint)
+            ))
+        ))
+    )
+    AND (m.deleted_at IS NULL OR sqlc.arg('include_deleted')::boolean)
+ORDER BY m.created_at ASC, m.id ASC
+LIMIT sqlc.arg('limit');
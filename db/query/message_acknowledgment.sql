@@ -0,0 +1,69 @@
+-- name: CreateAcknowledgmentRequest :one
+INSERT INTO message_acknowledgment_requests (
+    message_id,
+    workspace_id,
+    channel_id,
+    author_id,
+    remind_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetAcknowledgmentRequest :one
+SELECT * FROM message_acknowledgment_requests
+WHERE message_id = $1;
+
+-- name: AcknowledgeMessage :one
+-- Acknowledging again keeps the time of the first acknowledgment
+INSERT INTO message_acknowledgments (
+    message_id,
+    user_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (message_id, user_id) DO UPDATE SET
+    acknowledged_at = message_acknowledgments.acknowledged_at
+RETURNING *;
+
+-- name: ListMessageAcknowledgmentStates :many
+-- The messages among the listed ones that ask for acknowledgment, with how
+-- many people acknowledged each and when the user did
+SELECT
+    r.message_id,
+    r.remind_at,
+    (SELECT COUNT(*) FROM message_acknowledgments c WHERE c.message_id = r.message_id)::bigint AS acknowledged_count,
+    a.acknowledged_at
+FROM message_acknowledgment_requests r
+LEFT JOIN message_acknowledgments a ON a.message_id = r.message_id AND a.user_id = sqlc.arg('user_id')
+WHERE r.message_id = ANY(sqlc.arg('message_ids')::bigint[]);
+
+-- name: ListAcknowledgmentRecipients :many
+-- The members of the message's channel other than its author, with when they
+-- acknowledged it. Those who haven't come last.
+SELECT
+    u.id,
+    u.first_name,
+    u.last_name,
+    u.email,
+    a.acknowledged_at
+FROM message_acknowledgment_requests r
+JOIN channel_members cm ON cm.channel_id = r.channel_id
+JOIN users u ON u.id = cm.user_id
+LEFT JOIN message_acknowledgments a ON a.message_id = r.message_id AND a.user_id = cm.user_id
+WHERE r.message_id = $1 AND cm.user_id <> r.author_id
+ORDER BY a.acknowledged_at NULLS LAST, u.first_name, u.last_name, u.id;
+
+-- name: ClaimDueAcknowledgmentReminders :many
+-- Marks the requests whose reminder is due as reminded and returns them, so
+-- only one server sends each reminder
+UPDATE message_acknowledgment_requests
+SET reminded_at = now()
+WHERE message_id IN (
+    SELECT message_id FROM message_acknowledgment_requests
+    WHERE reminded_at IS NULL AND remind_at <= now()
+    ORDER BY remind_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
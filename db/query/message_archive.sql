@@ -0,0 +1,127 @@
+-- name: ListArchivableConversations :many
+-- Lists the conversations and UTC months holding messages that can be
+-- archived, oldest month first. A message can be archived once it is older
+-- than the cutoff, unless it is deleted, its thread has replies from after
+-- the cutoff, it still has replies of its own (they are archived first) or
+-- it is under an active legal hold.
+SELECT
+    m.workspace_id,
+    m.channel_id,
+    (CASE WHEN m.channel_id IS NULL THEN LEAST(m.sender_id, m.receiver_id) END)::bigint AS user_id,
+    (CASE WHEN m.channel_id IS NULL THEN GREATEST(m.sender_id, m.receiver_id) END)::bigint AS other_user_id,
+    (date_trunc('month', m.created_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC')::timestamptz AS month
+FROM messages m
+WHERE m.created_at < sqlc.arg('cutoff')
+    AND m.deleted_at IS NULL
+    AND NOT EXISTS (SELECT 1 FROM messages r WHERE r.thread_id = m.id)
+    AND NOT EXISTS (
+        SELECT 1 FROM messages r WHERE r.thread_id = m.thread_id AND r.created_at >= sqlc.arg('cutoff')
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM legal_holds h
+        WHERE h.released_at IS NULL AND (
+            (h.target_type = 'channel' AND h.target_id = m.channel_id)
+            OR (h.target_type = 'user' AND h.target_id IN (m.sender_id, m.receiver_id))
+        )
+    )
+GROUP BY 1, 2, 3, 4, 5
+ORDER BY month, m.workspace_id
+LIMIT sqlc.arg('limit');
+
+-- name: ListMessagesToArchive :many
+-- Lists a conversation's archivable messages created between starts_at and
+-- ends_at, oldest first, with their reactions
+SELECT
+    m.id,
+    m.channel_id,
+    m.sender_id,
+    m.receiver_id,
+    m.content,
+    m.content_type,
+    m.message_type,
+    m.thread_id,
+    m.edited_at,
+    m.created_at,
+    u.first_name AS sender_first_name,
+    u.last_name AS sender_last_name,
+    u.email AS sender_email,
+    COALESCE((
+        SELECT json_agg(json_build_object('emoji', r.emoji, 'user_id', r.user_id) ORDER BY r.created_at)
+        FROM message_reactions r WHERE r.message_id = m.id
+    ), '[]')::json AS reactions
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+WHERE m.workspace_id = sqlc.arg('workspace_id')
+    AND (
+        m.channel_id = sqlc.narg('channel_id')
+        OR (m.channel_id IS NULL
+            AND LEAST(m.sender_id, m.receiver_id) = sqlc.narg('user_id')
+            AND GREATEST(m.sender_id, m.receiver_id) = sqlc.narg('other_user_id'))
+    )
+    AND m.created_at >= sqlc.arg('starts_at')
+    AND m.created_at < sqlc.arg('ends_at')
+    AND m.created_at < sqlc.arg('cutoff')
+    AND m.deleted_at IS NULL
+    AND NOT EXISTS (SELECT 1 FROM messages r WHERE r.thread_id = m.id)
+    AND NOT EXISTS (
+        SELECT 1 FROM messages r WHERE r.thread_id = m.thread_id AND r.created_at >= sqlc.arg('cutoff')
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM legal_holds h
+        WHERE h.released_at IS NULL AND (
+            (h.target_type = 'channel' AND h.target_id = m.channel_id)
+            OR (h.target_type = 'user' AND h.target_id IN (m.sender_id, m.receiver_id))
+        )
+    )
+ORDER BY m.created_at, m.id
+LIMIT sqlc.arg('limit');
+
+-- name: CreateMessageArchive :one
+INSERT INTO message_archives (
+    workspace_id,
+    channel_id,
+    user_id,
+    other_user_id,
+    sender_ids,
+    object_key,
+    first_message_at,
+    last_message_at,
+    message_count,
+    size_bytes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+RETURNING *;
+
+-- name: DeleteArchivedMessages :execrows
+-- Deletes messages once they are archived; the trigger on messages removes
+-- their reactions and other dependents
+DELETE FROM messages
+WHERE id = ANY(sqlc.arg('ids')::bigint[])
+    AND deleted_at IS NULL;
+
+-- name: ListMessageArchives :many
+-- Lists a conversation's archives overlapping the optional time range,
+-- newest first
+SELECT * FROM message_archives
+WHERE workspace_id = sqlc.arg('workspace_id')
+    AND (
+        channel_id = sqlc.narg('channel_id')
+        OR (channel_id IS NULL AND user_id = sqlc.narg('user_id') AND other_user_id = sqlc.narg('other_user_id'))
+    )
+    AND (sqlc.narg('before')::timestamptz IS NULL OR first_message_at < sqlc.narg('before'))
+    AND (sqlc.narg('after')::timestamptz IS NULL OR last_message_at > sqlc.narg('after'))
+ORDER BY last_message_at DESC, id DESC;
+
+-- name: ListLegalHoldMessageArchives :many
+-- Lists the archives that may hold messages covered by a legal hold, oldest
+-- first: a channel's archives, or for a user the archives of their direct
+-- messages and of channels they posted in
+SELECT * FROM message_archives
+WHERE (sqlc.arg('target_type')::text = 'channel' AND channel_id = sqlc.arg('target_id')::bigint)
+    OR (sqlc.arg('target_type')::text = 'user' AND (
+        user_id = sqlc.arg('target_id')::bigint
+        OR other_user_id = sqlc.arg('target_id')::bigint
+        OR sender_ids @> ARRAY[sqlc.arg('target_id')::bigint]
+    ))
+ORDER BY first_message_at, id;
@@ -0,0 +1,34 @@
+-- name: UpsertMessageDraft :one
+INSERT INTO message_drafts (
+    user_id,
+    workspace_id,
+    channel_id,
+    receiver_id,
+    thread_id,
+    content
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (user_id, workspace_id, COALESCE(channel_id, 0), COALESCE(receiver_id, 0), COALESCE(thread_id, 0)) DO UPDATE SET
+    content = EXCLUDED.content,
+    updated_at = now()
+RETURNING *;
+
+-- name: ListMessageDrafts :many
+SELECT * FROM message_drafts
+WHERE user_id = $1 AND workspace_id = $2
+ORDER BY updated_at DESC;
+
+-- name: DeleteMessageDraft :one
+DELETE FROM message_drafts
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteMessageDraftForTarget :one
+DELETE FROM message_drafts
+WHERE user_id = sqlc.arg(user_id)
+    AND workspace_id = sqlc.arg(workspace_id)
+    AND COALESCE(channel_id, 0) = COALESCE(sqlc.narg(channel_id)::bigint, 0)
+    AND COALESCE(receiver_id, 0) = COALESCE(sqlc.narg(receiver_id)::bigint, 0)
+    AND COALESCE(thread_id, 0) = COALESCE(sqlc.narg(thread_id)::bigint, 0)
+RETURNING *;
@@ -0,0 +1,53 @@
+-- name: CreateMessageMentions :exec
+INSERT INTO message_mentions (message_id, user_id)
+SELECT sqlc.arg('message_id'), unnest(sqlc.arg('user_ids')::bigint[])
+ON CONFLICT DO NOTHING;
+
+-- name: ListMessageMentions :many
+SELECT user_id FROM message_mentions
+WHERE message_id = $1
+ORDER BY user_id;
+
+-- name: ListUserMentions :many
+-- Messages in the workspace mentioning the user, newest first. Mentions in
+-- private channels the user is no longer a member of are left out.
+SELECT
+    m.*,
+    u.first_name as sender_first_name,
+    u.last_name as sender_last_name,
+    u.email as sender_email,
+    c.name as channel_name,
+    mm.read_at
+FROM message_mentions mm
+JOIN messages m ON m.id = mm.message_id
+JOIN users u ON m.sender_id = u.id
+LEFT JOIN channels c ON c.id = m.channel_id
+WHERE mm.user_id = sqlc.arg('user_id')
+    AND m.workspace_id = sqlc.arg('workspace_id')
+    AND m.deleted_at IS NULL
+    AND (m.channel_id IS NULL OR (c.deleted_at IS NULL AND (c.is_private = false OR EXISTS (
+        SELECT 1 FROM channel_members cm WHERE cm.channel_id = c.id AND cm.user_id = mm.user_id
+    ))))
+    AND (mm.read_at IS NULL OR NOT sqlc.arg('unread_only')::boolean)
+ORDER BY mm.message_id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountUnreadMentions :one
+-- Counts the mentions ListUserMentions would list as unread
+SELECT COUNT(*) FROM message_mentions mm
+JOIN messages m ON m.id = mm.message_id
+LEFT JOIN channels c ON c.id = m.channel_id
+WHERE mm.user_id = sqlc.arg('user_id')
+    AND m.workspace_id = sqlc.arg('workspace_id')
+    AND m.deleted_at IS NULL
+    AND mm.read_at IS NULL
+    AND (m.channel_id IS NULL OR (c.deleted_at IS NULL AND (c.is_private = false OR EXISTS (
+        SELECT 1 FROM channel_members cm WHERE cm.channel_id = c.id AND cm.user_id = mm.user_id
+    ))));
+
+-- name: MarkMentionRead :one
+-- Keeps the time the mention was first read
+UPDATE message_mentions
+SET read_at = COALESCE(read_at, now())
+WHERE message_id = $1 AND user_id = $2
+RETURNING *;
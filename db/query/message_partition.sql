@@ -0,0 +1,22 @@
+-- name: CreateMessagePartition :one
+-- Creates the partition for the month of the date unless it exists
+SELECT create_message_partition(sqlc.arg('month')::date)::boolean AS created;
+
+-- name: ListMessagePartitions :many
+-- Lists the monthly message partitions, oldest first. The legacy and default
+-- partitions aren't monthly.
+SELECT c.relname::text AS name
+FROM pg_inherits i
+JOIN pg_class c ON c.oid = i.inhrelid
+WHERE i.inhparent = 'messages'::regclass
+    AND c.relname ~ '^messages_p[0-9]{6}$'
+ORDER BY c.relname;
+
+-- name: DropMessagePartition :one
+-- Drops a monthly partition and what depends on its messages, unless it
+-- holds messages under an active legal hold
+SELECT drop_message_partition(sqlc.arg('name')::text)::boolean AS dropped;
+
+-- name: DisableStatementTimeout :exec
+-- Lifts the statement timeout for the rest of the transaction
+SET LOCAL statement_timeout = 0;
@@ -0,0 +1,75 @@
+-- name: CreateThreadReply :one
+-- Creates a reply in the conversation of the thread's parent message
+INSERT INTO messages (
+    workspace_id,
+    channel_id,
+    sender_id,
+    receiver_id,
+    content,
+    content_type,
+    message_type,
+    thread_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: RecordThreadReply :one
+-- Counts a new reply in its thread's summary and moves its sender to the
+-- front of the thread's five most recent participants
+INSERT INTO message_threads (
+    thread_id,
+    reply_count,
+    last_reply_at,
+    participant_ids
+) VALUES (
+    sqlc.arg('thread_id'), 1, sqlc.arg('replied_at'), ARRAY[sqlc.arg('sender_id')::bigint]
+)
+ON CONFLICT (thread_id) DO UPDATE SET
+    reply_count = message_threads.reply_count + 1,
+    last_reply_at = GREATEST(message_threads.last_reply_at, EXCLUDED.last_reply_at),
+    participant_ids = (ARRAY[sqlc.arg('sender_id')::bigint] || array_remove(message_threads.participant_ids, sqlc.arg('sender_id')::bigint))[1:5],
+    updated_at = now()
+RETURNING *;
+
+-- name: RefreshThreadSummary :one
+-- Recounts a thread's summary from its replies, after a reply was deleted
+INSERT INTO message_threads (
+    thread_id,
+    reply_count,
+    last_reply_at,
+    participant_ids
+)
+SELECT
+    sqlc.arg('thread_id')::bigint,
+    COUNT(*)::integer,
+    MAX(created_at),
+    (
+        SELECT COALESCE(array_agg(p.sender_id ORDER BY p.last_reply_at DESC), '{}')::bigint[]
+        FROM (
+            SELECT sender_id, MAX(created_at) AS last_reply_at
+            FROM messages
+            WHERE thread_id = sqlc.arg('thread_id')::bigint AND deleted_at IS NULL
+            GROUP BY sender_id
+            ORDER BY last_reply_at DESC
+            LIMIT 5
+        ) p
+    )
+FROM messages
+WHERE thread_id = sqlc.arg('thread_id')::bigint AND deleted_at IS NULL
+ON CONFLICT (thread_id) DO UPDATE SET
+    reply_count = EXCLUDED.reply_count,
+    last_reply_at = EXCLUDED.last_reply_at,
+    participant_ids = EXCLUDED.participant_ids,
+    updated_at = now()
+RETURNING *;
+
+-- name: ListMessageThreads :many
+SELECT * FROM message_threads
+WHERE thread_id = ANY(sqlc.arg('thread_ids')::bigint[])
+    AND reply_count > 0;
+
+-- name: ListThreadParticipants :many
+-- The names and avatars shown for the participants of threads
+SELECT id, first_name, last_name, avatar_key FROM users
+WHERE id = ANY(sqlc.arg('user_ids')::bigint[]);
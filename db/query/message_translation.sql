@@ -0,0 +1,23 @@
+-- name: GetMessageTranslation :one
+SELECT * FROM message_translations
+WHERE message_id = $1 AND target_language = $2;
+
+-- name: UpsertMessageTranslation :one
+INSERT INTO message_translations (
+    message_id,
+    target_language,
+    source_language,
+    content_hash,
+    translated_text,
+    provider,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, now()
+)
+ON CONFLICT (message_id, target_language) DO UPDATE SET
+    source_language = EXCLUDED.source_language,
+    content_hash = EXCLUDED.content_hash,
+    translated_text = EXCLUDED.translated_text,
+    provider = EXCLUDED.provider,
+    created_at = now()
+RETURNING *;
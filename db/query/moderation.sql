@@ -0,0 +1,78 @@
+-- name: ListModerationWords :many
+SELECT * FROM moderation_words
+WHERE workspace_id = $1
+ORDER BY lower(word);
+
+-- name: CreateModerationWord :one
+INSERT INTO moderation_words (
+    workspace_id,
+    word,
+    action,
+    created_by
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: DeleteModerationWord :execrows
+DELETE FROM moderation_words
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: CreateModerationQueueItem :one
+INSERT INTO moderation_queue (
+    workspace_id,
+    message_id,
+    matched_words
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (message_id) WHERE status = 'pending' DO UPDATE SET
+    matched_words = EXCLUDED.matched_words
+RETURNING *;
+
+-- name: GetModerationQueueItem :one
+SELECT * FROM moderation_queue
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: ListModerationQueue :many
+SELECT
+    q.*,
+    m.content,
+    m.sender_id,
+    m.channel_id,
+    m.deleted_at AS message_deleted_at,
+    u.first_name AS sender_first_name,
+    u.last_name AS sender_last_name,
+    u.email AS sender_email
+FROM moderation_queue q
+JOIN messages m ON q.message_id = m.id
+JOIN users u ON m.sender_id = u.id
+WHERE q.workspace_id = $1 AND q.status = $2
+ORDER BY q.created_at
+LIMIT $3 OFFSET $4;
+
+-- name: ResolveModerationQueueItem :one
+UPDATE moderation_queue
+SET status = $3, reviewed_by = $4, reviewed_at = now()
+WHERE id = $1 AND workspace_id = $2 AND status = 'pending'
+RETURNING *;
+
+-- name: CreateModerationAuditEntry :one
+INSERT INTO moderation_audit_log (
+    workspace_id,
+    message_id,
+    author_id,
+    actor_id,
+    action,
+    matched_words,
+    reason
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: ListModerationAuditLog :many
+SELECT * FROM moderation_audit_log
+WHERE workspace_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
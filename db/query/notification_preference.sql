@@ -0,0 +1,56 @@
+-- name: UpsertWorkspaceNotificationPreference :one
+INSERT INTO notification_preferences (
+    user_id,
+    workspace_id,
+    level,
+    email_enabled,
+    push_enabled
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (user_id, workspace_id) WHERE channel_id IS NULL DO UPDATE SET
+    level = EXCLUDED.level,
+    email_enabled = EXCLUDED.email_enabled,
+    push_enabled = EXCLUDED.push_enabled,
+    updated_at = now()
+RETURNING *;
+
+-- name: UpsertChannelNotificationPreference :one
+INSERT INTO notification_preferences (
+    user_id,
+    workspace_id,
+    channel_id,
+    level,
+    email_enabled,
+    push_enabled
+) VALUES (
+    sqlc.arg('user_id'), sqlc.arg('workspace_id'), sqlc.arg('channel_id')::bigint, sqlc.arg('level'), sqlc.arg('email_enabled'), sqlc.arg('push_enabled')
+)
+ON CONFLICT (user_id, channel_id) WHERE channel_id IS NOT NULL DO UPDATE SET
+    level = EXCLUDED.level,
+    email_enabled = EXCLUDED.email_enabled,
+    push_enabled = EXCLUDED.push_enabled,
+    updated_at = now()
+RETURNING *;
+
+-- name: ListNotificationPreferences :many
+-- The workspace-wide preference comes first
+SELECT * FROM notification_preferences
+WHERE user_id = $1 AND workspace_id = $2
+ORDER BY channel_id NULLS FIRST;
+
+-- name: GetEffectiveNotificationPreference :one
+-- The channel's own preference, or the workspace-wide one when it has none
+SELECT * FROM notification_preferences
+WHERE user_id = sqlc.arg('user_id') AND workspace_id = sqlc.arg('workspace_id')
+    AND (channel_id IS NULL OR channel_id = sqlc.arg('channel_id')::bigint)
+ORDER BY channel_id NULLS LAST
+LIMIT 1;
+
+-- name: DeleteWorkspaceNotificationPreference :execrows
+DELETE FROM notification_preferences
+WHERE user_id = $1 AND workspace_id = $2 AND channel_id IS NULL;
+
+-- name: DeleteChannelNotificationPreference :execrows
+DELETE FROM notification_preferences
+WHERE user_id = sqlc.arg('user_id') AND channel_id = sqlc.arg('channel_id')::bigint;
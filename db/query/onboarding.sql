@@ -0,0 +1,70 @@
+-- name: GetWorkspaceOnboarding :one
+SELECT * FROM workspace_onboarding
+WHERE workspace_id = $1;
+
+-- name: GetWorkspaceOnboardingForUpdate :one
+SELECT * FROM workspace_onboarding
+WHERE workspace_id = $1
+FOR UPDATE;
+
+-- name: UpsertWorkspaceOnboarding :one
+INSERT INTO workspace_onboarding (
+    workspace_id,
+    enabled,
+    welcome_message,
+    suggested_channel_ids,
+    steps,
+    updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    enabled = EXCLUDED.enabled,
+    welcome_message = EXCLUDED.welcome_message,
+    suggested_channel_ids = EXCLUDED.suggested_channel_ids,
+    steps = EXCLUDED.steps,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING *;
+
+-- name: SetWorkspaceOnboardingBot :exec
+UPDATE workspace_onboarding
+SET bot_user_id = $2
+WHERE workspace_id = $1;
+
+-- name: ListOnboardingChannels :many
+-- Public channels of the workspace among the given ones, and whether the user
+-- has joined them
+SELECT
+    c.*,
+    EXISTS(
+        SELECT 1 FROM channel_members cm
+        WHERE cm.channel_id = c.id AND cm.user_id = sqlc.arg('user_id')
+    )::boolean AS is_member
+FROM channels c
+WHERE c.workspace_id = sqlc.arg('workspace_id')
+    AND c.id = ANY(sqlc.arg('channel_ids')::bigint[])
+    AND c.is_private = false
+    AND c.deleted_at IS NULL
+ORDER BY c.name ASC;
+
+-- name: HasUserPostedInWorkspace :one
+SELECT EXISTS(
+    SELECT 1 FROM messages
+    WHERE workspace_id = $1 AND sender_id = $2 AND deleted_at IS NULL
+);
+
+-- name: CompleteOnboardingStep :exec
+INSERT INTO onboarding_step_completions (
+    user_id,
+    workspace_id,
+    step
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id, workspace_id, step) DO NOTHING;
+
+-- name: ListOnboardingStepCompletions :many
+SELECT * FROM onboarding_step_completions
+WHERE user_id = $1 AND workspace_id = $2
+ORDER BY completed_at ASC;
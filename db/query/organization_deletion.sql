@@ -0,0 +1,98 @@
+-- name: CreateOrganizationDeletion :one
+INSERT INTO organization_deletions (
+    organization_id,
+    organization_name,
+    requested_by,
+    scheduled_for
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetOrganizationDeletion :one
+-- The organization's most recent deletion, under way or not
+SELECT * FROM organization_deletions
+WHERE organization_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: CancelOrganizationDeletion :one
+-- Only deletions still in their cancellation window can be cancelled
+UPDATE organization_deletions
+SET
+    status = 'cancelled',
+    cancelled_at = now(),
+    cancelled_by = $2,
+    updated_at = now()
+WHERE organization_id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: ListDueOrganizationDeletions :many
+SELECT * FROM organization_deletions
+WHERE status IN ('pending', 'running') AND scheduled_for <= $1
+ORDER BY scheduled_for ASC
+LIMIT $2;
+
+-- name: StartOrganizationDeletion :one
+-- Claims a due deletion, so a cancellation can no longer win against it
+UPDATE organization_deletions
+SET
+    status = 'running',
+    started_at = COALESCE(started_at, now()),
+    updated_at = now()
+WHERE id = $1 AND status IN ('pending', 'running')
+RETURNING *;
+
+-- name: RecordOrganizationDeletionProgress :exec
+UPDATE organization_deletions
+SET
+    step = sqlc.arg('step'),
+    workspaces_total = sqlc.arg('workspaces_total'),
+    workspaces_deleted = sqlc.arg('workspaces_deleted'),
+    rows_deleted = sqlc.arg('rows_deleted'),
+    files_deleted = sqlc.arg('files_deleted'),
+    last_error = NULL,
+    updated_at = now()
+WHERE id = sqlc.arg('id');
+
+-- name: RecordOrganizationDeletionError :exec
+UPDATE organization_deletions
+SET
+    last_error = $2,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: CompleteOrganizationDeletion :exec
+UPDATE organization_deletions
+SET
+    status = 'completed',
+    step = '',
+    completed_at = now(),
+    updated_at = now()
+WHERE id = $1;
+
+-- name: QueueOrganizationWorkspaceTeardowns :execrows
+-- Hands every workspace of the organization, deleted or not, to the teardown job
+INSERT INTO workspace_teardowns (workspace_id, organization_id, workspace_name)
+SELECT id, organization_id, name FROM workspaces
+WHERE organization_id = $1
+ON CONFLICT (workspace_id) DO NOTHING;
+
+-- name: GetOrganizationTeardownProgress :one
+-- Sums up the teardowns of the workspaces queued since the deletion was requested
+SELECT
+    COUNT(*)::int AS workspaces_total,
+    (COUNT(*) FILTER (WHERE status = 'completed'))::int AS workspaces_deleted,
+    COALESCE(SUM(rows_deleted), 0)::bigint AS rows_deleted,
+    COALESCE(SUM(files_deleted), 0)::bigint AS files_deleted
+FROM workspace_teardowns
+WHERE organization_id = $1 AND created_at >= sqlc.arg('since');
+
+-- name: CountOrganizationWorkspaces :one
+-- Counts the organization's workspaces that haven't been torn down, deleted ones included
+SELECT COUNT(*) FROM workspaces
+WHERE organization_id = $1;
+
+-- name: ListOrganizationAvatarKeys :many
+SELECT avatar_key::text FROM users
+WHERE organization_id = $1 AND avatar_key IS NOT NULL;
@@ -0,0 +1,44 @@
+-- name: UpsertOrganizationRole :one
+INSERT INTO organization_roles (
+    organization_id,
+    user_id,
+    role,
+    granted_by
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (organization_id, user_id) DO UPDATE
+SET role = EXCLUDED.role, granted_by = EXCLUDED.granted_by, updated_at = now()
+RETURNING *;
+
+-- name: EnsureOrganizationOwner :exec
+-- Makes the user the organization's owner if it has no owner yet
+INSERT INTO organization_roles (organization_id, user_id, role)
+SELECT $1, $2, 'owner'
+WHERE NOT EXISTS (
+    SELECT 1 FROM organization_roles
+    WHERE organization_id = $1 AND role = 'owner'
+)
+ON CONFLICT (organization_id, user_id) DO UPDATE
+SET role = 'owner', updated_at = now();
+
+-- name: GetOrganizationRole :one
+SELECT role FROM organization_roles
+WHERE organization_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: ListOrganizationRoles :many
+SELECT r.organization_id, r.user_id, r.role, r.granted_by, r.created_at, r.updated_at,
+    u.email, u.first_name, u.last_name
+FROM organization_roles r
+JOIN users u ON u.id = r.user_id
+WHERE r.organization_id = $1
+ORDER BY r.role DESC, r.created_at ASC;
+
+-- name: DeleteOrganizationRole :execrows
+DELETE FROM organization_roles
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: CountOrganizationOwners :one
+SELECT COUNT(*) FROM organization_roles
+WHERE organization_id = $1 AND role = 'owner';
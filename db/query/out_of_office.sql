@@ -0,0 +1,42 @@
+-- name: UpsertOutOfOffice :one
+INSERT INTO out_of_office (
+    user_id,
+    workspace_id,
+    starts_at,
+    ends_at,
+    message,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, now()
+)
+ON CONFLICT (user_id, workspace_id) DO UPDATE SET
+    starts_at = EXCLUDED.starts_at,
+    ends_at = EXCLUDED.ends_at,
+    message = EXCLUDED.message,
+    updated_at = now()
+RETURNING *;
+
+-- name: GetOutOfOffice :one
+SELECT * FROM out_of_office
+WHERE user_id = $1 AND workspace_id = $2;
+
+-- name: GetActiveOutOfOffice :one
+SELECT * FROM out_of_office
+WHERE user_id = $1 AND workspace_id = $2
+    AND starts_at <= now() AND ends_at > now();
+
+-- name: DeleteOutOfOffice :execrows
+DELETE FROM out_of_office
+WHERE user_id = $1 AND workspace_id = $2;
+
+-- name: RecordOutOfOfficeReply :execrows
+-- Affects no rows when the sender already got an auto-reply today, in the
+-- time zone of the user who is out of office
+INSERT INTO out_of_office_replies (
+    user_id,
+    sender_id,
+    reply_date
+) VALUES (
+    $1, $2, (now() AT TIME ZONE (SELECT timezone FROM users WHERE id = $1))::date
+)
+ON CONFLICT DO NOTHING;
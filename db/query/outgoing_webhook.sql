@@ -0,0 +1,86 @@
+-- name: CreateOutgoingWebhook :one
+INSERT INTO outgoing_webhooks (
+    workspace_id,
+    url,
+    secret,
+    event_types,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetOutgoingWebhook :one
+SELECT * FROM outgoing_webhooks
+WHERE id = $1;
+
+-- name: ListOutgoingWebhooks :many
+SELECT * FROM outgoing_webhooks
+WHERE workspace_id = $1
+ORDER BY id;
+
+-- name: DeleteOutgoingWebhook :execrows
+DELETE FROM outgoing_webhooks
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: CreateWebhookEvent :one
+-- Writes an event for webhooks only. It's written already published, so the
+-- outbox relay doesn't broadcast it to clients.
+INSERT INTO event_outbox (
+    event_type,
+    workspace_id,
+    user_id,
+    payload,
+    published_at
+) VALUES (
+    $1, $2, $3, $4, now()
+)
+RETURNING *;
+
+-- name: ClaimWebhookDeliveries :many
+-- Takes due deliveries for sending. Deliveries stuck in sending since before
+-- stale_before, because a worker stopped mid-send, are taken again.
+UPDATE webhook_deliveries
+SET
+    status = 'sending',
+    attempts = attempts + 1,
+    updated_at = now()
+WHERE id IN (
+    SELECT id FROM webhook_deliveries
+    WHERE (status = 'pending' AND next_attempt_at <= now())
+        OR (status = 'sending' AND updated_at < sqlc.arg('stale_before'))
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkWebhookDelivered :exec
+UPDATE webhook_deliveries
+SET
+    status = 'delivered',
+    last_error = '',
+    delivered_at = now(),
+    updated_at = now()
+WHERE id = $1;
+
+-- name: RetryWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET
+    status = 'pending',
+    last_error = $2,
+    next_attempt_at = $3,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: FailWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET
+    status = 'failed',
+    last_error = $2,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: DeleteOldWebhookDeliveries :execrows
+DELETE FROM webhook_deliveries
+WHERE created_at < $1 AND status IN ('delivered', 'failed');
@@ -0,0 +1,57 @@
+-- name: PinMessage :one
+-- New pins go to the end of the channel's list. Pinning a message that is
+-- already pinned returns no rows.
+INSERT INTO pinned_messages (
+    message_id,
+    channel_id,
+    pinned_by,
+    position
+) VALUES (
+    sqlc.arg('message_id'),
+    sqlc.arg('channel_id'),
+    sqlc.arg('pinned_by'),
+    (SELECT COALESCE(MAX(p.position), 0) + 1 FROM pinned_messages p WHERE p.channel_id = sqlc.arg('channel_id'))
+)
+ON CONFLICT (message_id) DO NOTHING
+RETURNING *;
+
+-- name: UnpinMessage :one
+DELETE FROM pinned_messages
+WHERE message_id = $1
+RETURNING *;
+
+-- name: CountChannelPins :one
+-- Pins of deleted messages count towards neither the limit nor the order
+SELECT COUNT(*) FROM pinned_messages p
+JOIN messages m ON m.id = p.message_id
+WHERE p.channel_id = $1 AND m.deleted_at IS NULL;
+
+-- name: ListChannelPinIDs :many
+SELECT p.message_id FROM pinned_messages p
+JOIN messages m ON m.id = p.message_id
+WHERE p.channel_id = $1 AND m.deleted_at IS NULL
+ORDER BY p.position, p.message_id;
+
+-- name: ListPinnedMessages :many
+-- Pinned messages of a channel in their arranged order, with who pinned them.
+-- Deleted messages keep their pin but aren't listed.
+SELECT
+    m.*,
+    u.first_name as sender_first_name,
+    u.last_name as sender_last_name,
+    u.email as sender_email,
+    p.pinned_by,
+    p.pinned_at,
+    p.position
+FROM pinned_messages p
+JOIN messages m ON m.id = p.message_id
+JOIN users u ON m.sender_id = u.id
+WHERE p.channel_id = $1 AND m.deleted_at IS NULL
+ORDER BY p.position, p.message_id;
+
+-- name: ReorderPinnedMessages :execrows
+-- Positions the channel's pins in the order of the given message IDs
+UPDATE pinned_messages p
+SET position = o.position
+FROM unnest(sqlc.arg('message_ids')::bigint[]) WITH ORDINALITY AS o(message_id, position)
+WHERE p.channel_id = sqlc.arg('channel_id') AND p.message_id = o.message_id;
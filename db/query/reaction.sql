@@ -0,0 +1,72 @@
+-- name: AddReaction :one
+INSERT INTO message_reactions (
+    message_id,
+    user_id,
+    emoji
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: RemoveReaction :execrows
+DELETE FROM message_reactions
+WHERE message_id = $1 AND user_id = $2 AND emoji = $3;
+
+-- name: GetReactionCounts :one
+-- Counts what a new reaction is checked against: the user's reactions to the
+-- message, the distinct emojis on it and whether the emoji is one of them
+SELECT
+    COUNT(*) FILTER (WHERE user_id = sqlc.arg('user_id')) AS user_reactions,
+    COUNT(DISTINCT emoji) AS distinct_emojis,
+    COALESCE(BOOL_OR(emoji = sqlc.arg('emoji')), false)::boolean AS emoji_used
+FROM message_reactions
+WHERE message_id = sqlc.arg('message_id');
+
+-- name: ListReactionSummaries :many
+-- Summarizes the reactions to a page of messages: each emoji's count, its
+-- first reactors and whether the viewer is one of them, in the order the
+-- emojis were first used
+SELECT
+    message_id,
+    emoji,
+    COUNT(*) AS count,
+    BOOL_OR(user_id = sqlc.arg('viewer_id')) AS reacted,
+    (ARRAY_AGG(user_id ORDER BY created_at, id))[1:sqlc.arg('reactor_limit')::int]::bigint[] AS user_ids
+FROM message_reactions
+WHERE message_id = ANY(sqlc.arg('message_ids')::bigint[])
+GROUP BY message_id, emoji
+ORDER BY message_id, MIN(created_at), emoji;
+
+-- name: ListReactors :many
+SELECT r.user_id, r.created_at, u.first_name, u.last_name
+FROM message_reactions r
+JOIN users u ON u.id = r.user_id
+WHERE r.message_id = $1 AND r.emoji = $2
+ORDER BY r.created_at, r.id
+LIMIT $3 OFFSET $4;
+
+-- name: CreateMessageReactions :execrows
+-- Adds many reactions in one statement from parallel arrays, skipping any
+-- the user already has on the message
+INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+SELECT * FROM unnest(
+    sqlc.arg('message_ids')::bigint[],
+    sqlc.arg('user_ids')::bigint[],
+    sqlc.arg('emojis')::text[],
+    sqlc.arg('created_ats')::timestamptz[]
+)
+ON CONFLICT DO NOTHING;
+
+-- name: ListWorkspaceReactionsByEmoji :many
+-- Pages through the reactions with an emoji on a workspace's messages
+SELECT r.* FROM message_reactions r
+JOIN messages m ON m.id = r.message_id
+WHERE m.workspace_id = sqlc.arg('workspace_id')
+    AND r.emoji = sqlc.arg('emoji')
+    AND r.id > sqlc.arg('after_id')
+ORDER BY r.id
+LIMIT sqlc.arg('limit');
+
+-- name: DeleteReactionsByID :execrows
+DELETE FROM message_reactions
+WHERE id = ANY(sqlc.arg('ids')::bigint[]);
@@ -0,0 +1,173 @@
+-- name: MarkChannelRead :one
+-- The read position only moves forward
+INSERT INTO channel_read_states (
+    user_id,
+    channel_id,
+    last_read_message_id,
+    last_read_at
+) VALUES (
+    $1, $2, $3, now()
+)
+ON CONFLICT (user_id, channel_id) DO UPDATE
+SET
+    last_read_message_id = GREATEST(channel_read_states.last_read_message_id, EXCLUDED.last_read_message_id),
+    last_read_at = now()
+RETURNING *;
+
+-- name: GetChannelReadState :one
+SELECT * FROM channel_read_states
+WHERE user_id = $1 AND channel_id = $2;
+
+-- name: MarkAllChannelsRead :execrows
+-- Marks every channel the user can read in the workspace as read up to its latest message
+INSERT INTO channel_read_states (user_id, channel_id, last_read_message_id, last_read_at)
+SELECT sqlc.arg('user_id')::bigint, c.id, latest.message_id, now()
+FROM channels c
+JOIN LATERAL (
+    SELECT MAX(m.id) as message_id FROM messages m
+    WHERE m.channel_id = c.id AND m.deleted_at IS NULL
+) latest ON latest.message_id IS NOT NULL
+WHERE c.workspace_id = sqlc.arg('workspace_id')
+    AND (c.is_private = false OR EXISTS (
+        SELECT 1 FROM channel_members cm WHERE cm.channel_id = c.id AND cm.user_id = sqlc.arg('user_id')::bigint
+    ))
+ON CONFLICT (user_id, channel_id) DO UPDATE
+SET
+    last_read_message_id = GREATEST(channel_read_states.last_read_message_id, EXCLUDED.last_read_message_id),
+    last_read_at = now();
+
+-- name: MarkAllDirectMessagesRead :execrows
+-- Marks every direct message conversation of the user in the workspace as read up to its latest message
+INSERT INTO direct_message_read_states (user_id, workspace_id, other_user_id, last_read_message_id, last_read_at)
+SELECT
+    sqlc.arg('user_id')::bigint,
+    sqlc.arg('workspace_id')::bigint,
+    conversations.other_user_id,
+    MAX(conversations.id),
+    now()
+FROM (
+    SELECT
+        m.id,
+        CASE WHEN m.sender_id = sqlc.arg('user_id')::bigint THEN m.receiver_id ELSE m.sender_id END as other_user_id
+    FROM messages m
+    WHERE m.workspace_id = sqlc.arg('workspace_id')::bigint
+        AND m.message_type = 'direct'
+        AND m.deleted_at IS NULL
+        AND (m.sender_id = sqlc.arg('user_id')::bigint OR m.receiver_id = sqlc.arg('user_id')::bigint)
+) conversations
+GROUP BY conversations.other_user_id
+ON CONFLICT (user_id, workspace_id, other_user_id) DO UPDATE
+SET
+    last_read_message_id = GREATEST(direct_message_read_states.last_read_message_id, EXCLUDED.last_read_message_id),
+    last_read_at = now();
+
+-- name: MarkDirectMessagesRead :one
+-- The read position only moves forward
+INSERT INTO direct_message_read_states (
+    user_id,
+    workspace_id,
+    other_user_id,
+    last_read_message_id,
+    last_read_at
+) VALUES (
+    $1, $2, $3, $4, now()
+)
+ON CONFLICT (user_id, workspace_id, other_user_id) DO UPDATE
+SET
+    last_read_message_id = GREATEST(direct_message_read_states.last_read_message_id, EXCLUDED.last_read_message_id),
+    last_read_at = now()
+RETURNING *;
+
+-- name: MarkThreadRead :one
+-- Follows the thread if the user didn't already. The read position only moves forward.
+INSERT INTO thread_read_states (
+    user_id,
+    thread_id,
+    last_read_message_id,
+    last_read_at
+) VALUES (
+    $1, $2, $3, now()
+)
+ON CONFLICT (user_id, thread_id) DO UPDATE
+SET
+    last_read_message_id = GREATEST(thread_read_states.last_read_message_id, EXCLUDED.last_read_message_id),
+    last_read_at = now()
+RETURNING *;
+
+-- name: UnfollowThread :execrows
+DELETE FROM thread_read_states
+WHERE user_id = $1 AND thread_id = $2;
+
+-- name: MarkAllThreadsRead :execrows
+-- Marks every thread the user follows in the workspace as read up to its latest reply
+UPDATE thread_read_states t
+SET
+    last_read_message_id = latest.message_id,
+    last_read_at = now()
+FROM messages root
+CROSS JOIN LATERAL (
+    SELECT MAX(r.id) as message_id FROM messages r
+    WHERE r.thread_id = root.id AND r.deleted_at IS NULL
+) latest
+WHERE t.user_id = $1
+    AND root.id = t.thread_id
+    AND root.workspace_id = $2
+    AND latest.message_id > COALESCE(t.last_read_message_id, 0);
+
+-- name: ListChannelUnreadCounts :many
+-- Counts messages from others after the user's read position in each channel
+-- they belong to in the workspace. Thread replies are counted per thread.
+SELECT
+    c.id as channel_id,
+    rs.last_read_message_id,
+    COUNT(m.id) as unread_count
+FROM channels c
+JOIN channel_members cm ON cm.channel_id = c.id AND cm.user_id = sqlc.arg('user_id')::bigint
+LEFT JOIN channel_read_states rs ON rs.channel_id = c.id AND rs.user_id = cm.user_id
+JOIN messages m ON m.channel_id = c.id
+    AND m.id > COALESCE(rs.last_read_message_id, 0)
+    AND m.thread_id IS NULL
+    AND m.deleted_at IS NULL
+    AND m.sender_id <> cm.user_id
+WHERE c.workspace_id = sqlc.arg('workspace_id')
+    AND c.deleted_at IS NULL
+GROUP BY c.id, rs.last_read_message_id
+ORDER BY c.id;
+
+-- name: ListDirectMessageUnreadCounts :many
+-- Counts direct messages received after the user's read position in each
+-- conversation in the workspace, most recently active first
+SELECT
+    m.sender_id as other_user_id,
+    rs.last_read_message_id,
+    COUNT(m.id) as unread_count
+FROM messages m
+LEFT JOIN direct_message_read_states rs ON rs.user_id = m.receiver_id
+    AND rs.workspace_id = m.workspace_id
+    AND rs.other_user_id = m.sender_id
+WHERE m.workspace_id = sqlc.arg('workspace_id')
+    AND m.receiver_id = sqlc.arg('user_id')::bigint
+    AND m.message_type = 'direct'
+    AND m.sender_id <> m.receiver_id
+    AND m.thread_id IS NULL
+    AND m.deleted_at IS NULL
+    AND m.id > COALESCE(rs.last_read_message_id, 0)
+GROUP BY m.sender_id, rs.last_read_message_id
+ORDER BY MAX(m.id) DESC;
+
+-- name: ListThreadUnreadCounts :many
+-- Counts replies from others after the user's read position in each thread
+-- they follow in the workspace, most recently active first
+SELECT
+    t.thread_id,
+    t.last_read_message_id,
+    COUNT(r.id) as unread_count
+FROM thread_read_states t
+JOIN messages root ON root.id = t.thread_id
+JOIN messages r ON r.thread_id = t.thread_id
+    AND r.id > COALESCE(t.last_read_message_id, 0)
+    AND r.deleted_at IS NULL
+    AND r.sender_id <> t.user_id
+WHERE t.user_id = $1 AND root.workspace_id = $2
+GROUP BY t.thread_id, t.last_read_message_id
+ORDER BY MAX(r.id) DESC;
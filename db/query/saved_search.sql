@@ -0,0 +1,33 @@
+-- name: CreateSavedSearch :one
+INSERT INTO saved_searches (
+    user_id,
+    workspace_id,
+    name,
+    query
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetSavedSearch :one
+SELECT * FROM saved_searches
+WHERE id = $1 AND user_id = $2 AND workspace_id = $3
+LIMIT 1;
+
+-- name: ListSavedSearches :many
+SELECT * FROM saved_searches
+WHERE user_id = $1 AND workspace_id = $2
+ORDER BY name ASC;
+
+-- name: UpdateSavedSearch :one
+UPDATE saved_searches
+SET
+    name = $4,
+    query = $5,
+    updated_at = now()
+WHERE id = $1 AND user_id = $2 AND workspace_id = $3
+RETURNING *;
+
+-- name: DeleteSavedSearch :exec
+DELETE FROM saved_searches
+WHERE id = $1 AND user_id = $2 AND workspace_id = $3;
@@ -34,10 +34,22 @@ WHERE id = $1
 RETURNING *;
 
 -- name: UpdateUserProfile :one
+-- Profile details that aren't given keep their current value
 UPDATE users
 SET
-    first_name = $2,
-    last_name = $3
+    first_name = sqlc.arg('first_name'),
+    last_name = sqlc.arg('last_name'),
+    title = COALESCE(sqlc.narg('title'), title),
+    pronouns = COALESCE(sqlc.narg('pronouns'), pronouns),
+    phone = COALESCE(sqlc.narg('phone'), phone),
+    timezone = COALESCE(sqlc.narg('timezone'), timezone),
+    handle = COALESCE(sqlc.narg('handle'), handle)
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: UpdateUserAvatar :one
+UPDATE users
+SET avatar_key = $2
 WHERE id = $1
 RETURNING *;
 
@@ -55,7 +67,8 @@ RETURNING *;
 UPDATE users
 SET 
     workspace_id = $2,
-    role = $3
+    role = $3,
+    custom_role_id = NULL
 WHERE id = $1
 RETURNING *;
 
@@ -67,6 +80,88 @@ LIMIT $2
 OFFSET $3;
 
 -- name: CheckUserWorkspaceRole :one
-SELECT role FROM users
-WHERE id = $1 AND workspace_id = $2
+-- Organization owners and admins act as admins of every workspace in their
+-- organization. Nobody has a role in a deleted workspace, or in one whose
+-- organization is being deleted.
+SELECT (CASE WHEN o.role IS NULL THEN u.role ELSE 'admin' END)::varchar AS role
+FROM users u
+LEFT JOIN organization_roles o ON o.user_id = u.id
+    AND o.organization_id = (SELECT w.organization_id FROM workspaces w WHERE w.id = $2)
+WHERE u.id = $1 AND (u.workspace_id = $2 OR o.role IS NOT NULL)
+    AND NOT EXISTS (SELECT 1 FROM workspaces dw WHERE dw.id = $2 AND dw.deleted_at IS NOT NULL)
+    AND NOT EXISTS (
+        SELECT 1 FROM workspaces ow
+        JOIN organization_deletions od ON od.organization_id = ow.organization_id
+        WHERE ow.id = $2 AND od.status IN ('pending', 'running')
+    )
 LIMIT 1;
+
+-- name: SearchWorkspaceUsers :many
+-- Lists the people of a workspace whose name or handle matches the query,
+-- with their public profile only. Emails are matched and returned only when
+-- include_email is set.
+SELECT
+    u.id,
+    u.organization_id,
+    u.first_name,
+    u.last_name,
+    u.workspace_id,
+    u.role,
+    u.handle,
+    u.title,
+    u.pronouns,
+    u.timezone,
+    u.avatar_key,
+    u.created_at,
+    (CASE WHEN sqlc.arg('include_email')::boolean THEN u.email ELSE '' END)::text AS email,
+    COUNT(*) OVER() as total_count
+FROM users u
+WHERE u.workspace_id = sqlc.arg('workspace_id')
+    AND (
+        (u.first_name || ' ' || u.last_name) ILIKE '%' || sqlc.arg('query')::text || '%'
+        OR u.handle ILIKE '%' || sqlc.arg('query')::text || '%'
+        OR (sqlc.arg('include_email')::boolean AND u.email ILIKE '%' || sqlc.arg('query')::text || '%')
+    )
+ORDER BY u.first_name ASC, u.last_name ASC
+LIMIT sqlc.arg('limit');
+
+-- name: SearchOrganizationDirectory :many
+-- Lists an organization's people across all its workspaces, with their
+-- workspace and presence. People match on name, handle or title, and on
+-- email when search_email is set; an empty query matches everyone.
+SELECT
+    u.*,
+    COALESCE(w.name, '')::text AS workspace_name,
+    COALESCE(us.status, 'offline')::text AS status,
+    us.custom_status,
+    us.status_emoji,
+    COUNT(*) OVER() AS total_count
+FROM users u
+LEFT JOIN workspaces w ON w.id = u.workspace_id
+LEFT JOIN user_status us ON us.user_id = u.id AND us.workspace_id = u.workspace_id
+WHERE u.organization_id = sqlc.arg('organization_id')
+    AND (
+        sqlc.arg('query')::text = ''
+        OR (u.first_name || ' ' || u.last_name) ILIKE '%' || sqlc.arg('query')::text || '%'
+        OR u.handle ILIKE '%' || sqlc.arg('query')::text || '%'
+        OR u.title ILIKE '%' || sqlc.arg('query')::text || '%'
+        OR (sqlc.arg('search_email')::boolean AND u.email ILIKE '%' || sqlc.arg('query')::text || '%')
+    )
+ORDER BY u.first_name ASC, u.last_name ASC, u.id ASC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListWorkspaceAdminIDs :many
+SELECT id FROM users
+WHERE workspace_id = $1 AND role = 'admin'
+ORDER BY id;
+
+-- name: ListUsersByEmails :many
+SELECT * FROM users
+WHERE lower(email) = ANY(sqlc.arg('emails')::text[]);
+
+-- name: ListWorkspaceMemberIDs :many
+-- Keeps the users that belong to the workspace, e.g. to check who a message mentions
+SELECT id FROM users
+WHERE workspace_id = sqlc.arg('workspace_id') AND id = ANY(sqlc.arg('user_ids')::bigint[])
+ORDER BY id;
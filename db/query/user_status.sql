@@ -4,13 +4,18 @@ INSERT INTO user_status (
     workspace_id,
     status,
     custom_status,
+    status_emoji,
+    clear_after,
     updated_at
 ) VALUES (
-    $1, $2, $3, $4, now()
+    $1, $2, $3, $4, $5, $6, now()
 )
 ON CONFLICT (user_id) DO UPDATE SET
     status = EXCLUDED.status,
     custom_status = EXCLUDED.custom_status,
+    status_emoji = EXCLUDED.status_emoji,
+    clear_after = EXCLUDED.clear_after,
+    set_by_calendar = false,
     updated_at = now()
 RETURNING *;
 
@@ -56,4 +61,93 @@ FROM user_status us
 JOIN users u ON us.user_id = u.id
 WHERE us.workspace_id = $1 
     AND us.status IN ('online', 'away', 'busy')
-ORDER BY us.updated_at DESC;
\ No newline at end of file
+ORDER BY us.updated_at DESC;
+-- name: ClearExpiredCustomStatuses :many
+-- Statuses set from a calendar also return from busy to online
+UPDATE user_status
+SET 
+    status = CASE WHEN set_by_calendar AND status = 'busy' THEN 'online' ELSE status END,
+    custom_status = NULL,
+    status_emoji = NULL,
+    clear_after = NULL,
+    set_by_calendar = false,
+    updated_at = now()
+WHERE clear_after IS NOT NULL AND clear_after <= now()
+RETURNING *;
+
+-- name: SetCalendarBusyStatus :one
+-- Sets a meeting status until the event ends. Custom statuses the user chose
+-- themselves are left alone.
+UPDATE user_status
+SET 
+    status = CASE WHEN status = 'offline' THEN status ELSE 'busy' END,
+    custom_status = $3,
+    status_emoji = $4,
+    clear_after = $5,
+    set_by_calendar = true,
+    updated_at = now()
+WHERE user_id = $1 AND workspace_id = $2
+    AND (set_by_calendar OR custom_status IS NULL)
+    AND (clear_after IS DISTINCT FROM $5 OR NOT set_by_calendar)
+RETURNING *;
+
+-- name: SetUserPresenceOnline :one
+-- Brings a user online without touching an explicitly chosen away/busy status or custom status
+INSERT INTO user_status (
+    user_id,
+    workspace_id,
+    status,
+    updated_at
+) VALUES (
+    $1, $2, 'online', now()
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    workspace_id = EXCLUDED.workspace_id,
+    status = CASE WHEN user_status.status = 'offline' THEN 'online' ELSE user_status.status END,
+    last_activity_at = now(),
+    last_seen_at = now(),
+    updated_at = now()
+RETURNING *;
+
+-- name: SetUserPresenceOffline :one
+-- Takes a user offline while keeping their custom status
+UPDATE user_status
+SET 
+    status = 'offline',
+    last_seen_at = now(),
+    updated_at = now()
+WHERE user_id = $1 AND workspace_id = $2
+RETURNING *;
+
+-- name: SetIdleUsersAway :many
+-- Marks online users away once they have been inactive longer than their
+-- workspace's away threshold. Workspaces without a threshold fall back to the
+-- default, and no one is marked away when neither is set.
+UPDATE user_status us
+SET 
+    status = 'away',
+    updated_at = now()
+WHERE us.status = 'online'
+    AND COALESCE(
+        (SELECT ws.away_after_minutes FROM workspace_settings ws WHERE ws.workspace_id = us.workspace_id),
+        sqlc.narg('default_away_minutes')::int
+    ) IS NOT NULL
+    AND us.last_activity_at < now() - make_interval(mins => COALESCE(
+        (SELECT ws.away_after_minutes FROM workspace_settings ws WHERE ws.workspace_id = us.workspace_id),
+        sqlc.narg('default_away_minutes')::int
+    ))
+RETURNING us.*;
+
+-- name: SetIdleUsersOffline :many
+-- Marks users offline once they have been inactive longer than their
+-- workspace's offline threshold, falling back to the default
+UPDATE user_status us
+SET 
+    status = 'offline',
+    updated_at = now()
+WHERE us.status != 'offline'
+    AND us.last_activity_at < now() - make_interval(mins => COALESCE(
+        (SELECT ws.offline_after_minutes FROM workspace_settings ws WHERE ws.workspace_id = us.workspace_id),
+        sqlc.arg('default_offline_minutes')::int
+    ))
+RETURNING us.*;
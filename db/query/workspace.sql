@@ -9,15 +9,15 @@ RETURNING *;
 
 -- name: GetWorkspace :one
 SELECT * FROM workspaces
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
 
 -- name: GetWorkspaceByID :one
 SELECT * FROM workspaces
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
 
 -- name: ListWorkspacesByOrganization :many
 SELECT * FROM workspaces
-WHERE organization_id = $1
+WHERE organization_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $2
 OFFSET $3;
@@ -25,20 +25,52 @@ OFFSET $3;
 -- name: UpdateWorkspace :one
 UPDATE workspaces
 SET name = $2
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: DeleteWorkspace :exec
 DELETE FROM workspaces
 WHERE id = $1;
 
+-- name: SoftDeleteWorkspace :execrows
+UPDATE workspaces
+SET
+    deleted_at = now(),
+    deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreWorkspace :one
+-- Only workspaces still inside the recovery window can be restored
+UPDATE workspaces
+SET
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE id = sqlc.arg('id')
+    AND organization_id = sqlc.arg('organization_id')
+    AND deleted_at > sqlc.arg('deleted_after')::timestamptz
+RETURNING *;
+
+-- name: ListDeletedWorkspaces :many
+SELECT * FROM workspaces
+WHERE organization_id = sqlc.arg('organization_id')
+    AND deleted_at > sqlc.arg('deleted_after')::timestamptz
+ORDER BY deleted_at DESC;
+
+-- name: ListPurgeableWorkspaces :many
+-- Workspaces already handed to the teardown job are skipped
+SELECT * FROM workspaces
+WHERE deleted_at <= sqlc.arg('deleted_before')::timestamptz
+    AND NOT EXISTS (SELECT 1 FROM workspace_teardowns t WHERE t.workspace_id = workspaces.id)
+ORDER BY deleted_at ASC
+LIMIT sqlc.arg('limit');
+
 -- name: GetWorkspaceWithUserCount :one
 SELECT 
     w.*,
     COUNT(u.id) as user_count
 FROM workspaces w
 LEFT JOIN users u ON w.id = u.workspace_id
-WHERE w.id = $1
+WHERE w.id = $1 AND w.deleted_at IS NULL
 GROUP BY w.id, w.organization_id, w.name, w.created_at
 LIMIT 1;
 
@@ -46,7 +78,8 @@ LIMIT 1;
 UPDATE users
 SET 
     workspace_id = $2,
-    role = $3
+    role = $3,
+    custom_role_id = NULL
 WHERE users.id = $1 AND users.organization_id = (
     SELECT workspaces.organization_id FROM workspaces WHERE workspaces.id = $2
 )
@@ -56,7 +89,8 @@ RETURNING *;
 UPDATE users
 SET 
     workspace_id = NULL,
-    role = 'member'
+    role = 'member',
+    custom_role_id = NULL
 WHERE users.id = $1 AND users.workspace_id = $2
 RETURNING *;
 
@@ -67,7 +101,8 @@ WHERE users.id = $1 AND users.workspace_id = $2
 RETURNING *;
 
 -- name: ListWorkspaceMembers :many
-SELECT u.id, u.organization_id, u.email, u.first_name, u.last_name, u.role, u.created_at, u.workspace_id
+SELECT u.id, u.organization_id, u.email, u.first_name, u.last_name, u.role, u.created_at, u.workspace_id,
+    u.title, u.pronouns, u.phone, u.timezone, u.avatar_key, u.custom_role_id, u.handle
 FROM users u
 WHERE u.workspace_id = $1
 ORDER BY u.role DESC, u.created_at ASC
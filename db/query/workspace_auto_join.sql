@@ -0,0 +1,78 @@
+-- name: CreateWorkspaceAutoJoinDomain :one
+INSERT INTO workspace_auto_join_domains (
+    workspace_id,
+    domain,
+    requires_approval,
+    created_by
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: ListWorkspaceAutoJoinDomains :many
+SELECT * FROM workspace_auto_join_domains
+WHERE workspace_id = $1
+ORDER BY domain;
+
+-- name: DeleteWorkspaceAutoJoinDomain :execrows
+DELETE FROM workspace_auto_join_domains
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: GetWorkspaceAutoJoinDomain :one
+SELECT * FROM workspace_auto_join_domains
+WHERE workspace_id = $1 AND domain = $2
+LIMIT 1;
+
+-- name: ListAutoJoinWorkspaces :many
+-- Workspaces of an organization that allow an email domain, with whether the
+-- user already asked to join them
+SELECT
+    w.*,
+    d.requires_approval,
+    EXISTS (
+        SELECT 1 FROM workspace_join_requests r
+        WHERE r.workspace_id = w.id
+            AND r.user_id = sqlc.arg('user_id')
+            AND r.status = 'pending'
+    )::boolean AS request_pending
+FROM workspaces w
+JOIN workspace_auto_join_domains d ON d.workspace_id = w.id
+WHERE w.organization_id = sqlc.arg('organization_id')
+    AND w.deleted_at IS NULL
+    AND d.domain = sqlc.arg('domain')
+ORDER BY w.name, w.id;
+
+-- name: CreateWorkspaceJoinRequest :one
+INSERT INTO workspace_join_requests (
+    workspace_id,
+    user_id
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: ListWorkspaceJoinRequests :many
+SELECT
+    r.*,
+    u.email AS user_email,
+    u.first_name AS user_first_name,
+    u.last_name AS user_last_name
+FROM workspace_join_requests r
+JOIN users u ON u.id = r.user_id
+WHERE r.workspace_id = $1 AND r.status = $2
+ORDER BY r.created_at ASC, r.id ASC
+LIMIT $3 OFFSET $4;
+
+-- name: GetWorkspaceJoinRequest :one
+SELECT * FROM workspace_join_requests
+WHERE id = $1 AND workspace_id = $2
+LIMIT 1;
+
+-- name: ReviewWorkspaceJoinRequest :one
+UPDATE workspace_join_requests
+SET
+    status = $3,
+    reviewed_by = $4,
+    reviewed_at = now()
+WHERE id = $1 AND workspace_id = $2 AND status = 'pending'
+RETURNING *;
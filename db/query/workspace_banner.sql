@@ -0,0 +1,40 @@
+-- name: CreateWorkspaceBanner :one
+INSERT INTO workspace_banners (
+    workspace_id,
+    text,
+    severity,
+    expires_at,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetWorkspaceBanner :one
+SELECT * FROM workspace_banners
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: ListWorkspaceBanners :many
+SELECT * FROM workspace_banners
+WHERE workspace_id = $1
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListActiveWorkspaceBanners :many
+-- Banners that have not expired yet, most recent first
+SELECT * FROM workspace_banners
+WHERE workspace_id = $1
+  AND (expires_at IS NULL OR expires_at > now())
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateWorkspaceBanner :one
+UPDATE workspace_banners
+SET text = $3,
+    severity = $4,
+    expires_at = $5,
+    updated_at = now()
+WHERE id = $1 AND workspace_id = $2
+RETURNING *;
+
+-- name: DeleteWorkspaceBanner :execrows
+DELETE FROM workspace_banners
+WHERE id = $1 AND workspace_id = $2;
@@ -0,0 +1,25 @@
+-- name: DeleteWorkspaceEvents :execrows
+-- Removes events older than the retention
+DELETE FROM workspace_events
+WHERE created_at < $1;
+
+-- name: ListUnpublishedWorkspaceEvents :many
+SELECT * FROM workspace_events
+WHERE published_at IS NULL AND created_at < $1
+ORDER BY id
+LIMIT $2;
+
+-- name: ListWorkspaceEvents :many
+-- Events after the cursor. Recent events are held back so events written by
+-- transactions still in flight can't be committed behind the cursor.
+SELECT * FROM workspace_events
+WHERE workspace_id = sqlc.arg('workspace_id')
+    AND id > sqlc.arg('after_id')
+    AND created_at < sqlc.arg('created_before')
+ORDER BY id
+LIMIT sqlc.arg('limit');
+
+-- name: MarkWorkspaceEventsPublished :exec
+UPDATE workspace_events
+SET published_at = now()
+WHERE id = ANY(sqlc.arg('ids')::bigint[]);
@@ -59,3 +59,29 @@ JOIN workspaces w ON wi.workspace_id = w.id
 JOIN users u ON wi.inviter_id = u.id
 WHERE wi.invitee_email = $1 AND wi.status = 'pending' AND wi.expires_at > NOW()
 ORDER BY wi.created_at DESC;
+
+-- name: UpdateWorkspaceInvitationEmailStatus :one
+UPDATE workspace_invitations
+SET 
+    email_status = $2,
+    email_error = $3,
+    email_attempts = email_attempts + 1,
+    email_last_attempt_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListPendingInvitationEmails :many
+SELECT DISTINCT lower(invitee_email)::text AS email
+FROM workspace_invitations
+WHERE workspace_id = sqlc.arg('workspace_id')
+    AND status = 'pending'
+    AND expires_at > NOW()
+    AND lower(invitee_email) = ANY(sqlc.arg('emails')::text[]);
+
+-- name: SetWorkspaceInvitationEmailResult :exec
+-- Records the outcome of an invitation email sent by the email queue
+UPDATE workspace_invitations
+SET
+    email_status = $2,
+    email_error = $3
+WHERE id = $1;
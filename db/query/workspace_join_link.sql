@@ -0,0 +1,37 @@
+-- name: CreateWorkspaceJoinLink :one
+INSERT INTO workspace_join_links (
+    workspace_id,
+    token,
+    created_by,
+    expires_at,
+    max_uses
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetWorkspaceJoinLinkByToken :one
+SELECT * FROM workspace_join_links
+WHERE token = $1 LIMIT 1;
+
+-- name: ListWorkspaceJoinLinks :many
+SELECT * FROM workspace_join_links
+WHERE workspace_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: RevokeWorkspaceJoinLink :one
+UPDATE workspace_join_links
+SET revoked_at = now()
+WHERE id = $1 AND workspace_id = $2 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: UseWorkspaceJoinLink :one
+-- Counts a use of a link that is still valid, no row is returned otherwise
+UPDATE workspace_join_links
+SET use_count = use_count + 1
+WHERE token = $1
+    AND revoked_at IS NULL
+    AND (expires_at IS NULL OR expires_at > now())
+    AND (max_uses IS NULL OR use_count < max_uses)
+RETURNING *;
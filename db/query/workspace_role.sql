@@ -0,0 +1,50 @@
+-- name: CreateWorkspaceRole :one
+INSERT INTO workspace_roles (
+    workspace_id,
+    name,
+    description,
+    permissions,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetWorkspaceRole :one
+SELECT * FROM workspace_roles
+WHERE id = $1 AND workspace_id = $2
+LIMIT 1;
+
+-- name: ListWorkspaceRoles :many
+SELECT r.*, (SELECT COUNT(*) FROM users u WHERE u.custom_role_id = r.id)::bigint AS member_count
+FROM workspace_roles r
+WHERE r.workspace_id = $1
+ORDER BY r.name;
+
+-- name: UpdateWorkspaceRole :one
+UPDATE workspace_roles
+SET
+    name = $3,
+    description = $4,
+    permissions = $5,
+    updated_at = now()
+WHERE id = $1 AND workspace_id = $2
+RETURNING *;
+
+-- name: DeleteWorkspaceRole :execrows
+DELETE FROM workspace_roles
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: SetUserCustomRole :one
+UPDATE users
+SET custom_role_id = $3
+WHERE users.id = $1 AND users.workspace_id = $2
+RETURNING *;
+
+-- name: GetUserRolePermissions :one
+-- Permissions granted by a user's custom role in the workspace they belong to
+SELECT r.permissions
+FROM users u
+JOIN workspace_roles r ON r.id = u.custom_role_id AND r.workspace_id = u.workspace_id
+WHERE u.id = $1 AND u.workspace_id = $2
+LIMIT 1;
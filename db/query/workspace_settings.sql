@@ -0,0 +1,69 @@
+-- name: GetWorkspaceSettings :one
+SELECT * FROM workspace_settings
+WHERE workspace_id = $1;
+
+-- name: UpsertWorkspacePresenceSettings :one
+INSERT INTO workspace_settings (
+    workspace_id,
+    away_after_minutes,
+    offline_after_minutes,
+    updated_at
+) VALUES (
+    $1, $2, $3, now()
+)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    away_after_minutes = EXCLUDED.away_after_minutes,
+    offline_after_minutes = EXCLUDED.offline_after_minutes,
+    updated_at = now()
+RETURNING *;
+
+-- name: UpsertWorkspaceReactionSettings :one
+INSERT INTO workspace_settings (
+    workspace_id,
+    max_reactions_per_user,
+    max_distinct_reactions,
+    updated_at
+) VALUES (
+    $1, $2, $3, now()
+)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    max_reactions_per_user = EXCLUDED.max_reactions_per_user,
+    max_distinct_reactions = EXCLUDED.max_distinct_reactions,
+    updated_at = now()
+RETURNING *;
+
+-- name: UpsertWorkspaceMessageSettings :one
+INSERT INTO workspace_settings (
+    workspace_id,
+    message_edit_window_minutes,
+    message_delete_window_minutes,
+    hide_deleted_messages,
+    max_pins_per_channel,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, now()
+)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    message_edit_window_minutes = EXCLUDED.message_edit_window_minutes,
+    message_delete_window_minutes = EXCLUDED.message_delete_window_minutes,
+    hide_deleted_messages = EXCLUDED.hide_deleted_messages,
+    max_pins_per_channel = EXCLUDED.max_pins_per_channel,
+    updated_at = now()
+RETURNING *;
+
+-- name: UpsertWorkspaceFileSettings :one
+INSERT INTO workspace_settings (
+    workspace_id,
+    image_watermark,
+    public_files,
+    public_file_referers,
+    updated_at
+) VALUES (
+    $1, $2, $3, $4, now()
+)
+ON CONFLICT (workspace_id) DO UPDATE SET
+    image_watermark = EXCLUDED.image_watermark,
+    public_files = EXCLUDED.public_files,
+    public_file_referers = EXCLUDED.public_file_referers,
+    updated_at = now()
+RETURNING *;
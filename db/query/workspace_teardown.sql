@@ -0,0 +1,106 @@
+-- name: CreateWorkspaceTeardown :exec
+INSERT INTO workspace_teardowns (
+    workspace_id,
+    organization_id,
+    workspace_name
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (workspace_id) DO NOTHING;
+
+-- name: GetWorkspaceTeardown :one
+SELECT * FROM workspace_teardowns
+WHERE workspace_id = $1 AND organization_id = $2
+LIMIT 1;
+
+-- name: ListWorkspaceTeardowns :many
+SELECT * FROM workspace_teardowns
+WHERE organization_id = $1
+ORDER BY created_at DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ListUnfinishedWorkspaceTeardowns :many
+SELECT * FROM workspace_teardowns
+WHERE status <> 'completed'
+ORDER BY created_at ASC
+LIMIT $1;
+
+-- name: RecordWorkspaceTeardownProgress :exec
+UPDATE workspace_teardowns
+SET
+    status = 'running',
+    step = sqlc.arg('step'),
+    rows_deleted = rows_deleted + sqlc.arg('rows_deleted')::bigint,
+    files_deleted = files_deleted + sqlc.arg('files_deleted')::bigint,
+    last_error = NULL,
+    started_at = COALESCE(started_at, now()),
+    updated_at = now()
+WHERE workspace_id = sqlc.arg('workspace_id');
+
+-- name: RecordWorkspaceTeardownError :exec
+UPDATE workspace_teardowns
+SET
+    last_error = $2,
+    updated_at = now()
+WHERE workspace_id = $1;
+
+-- name: CompleteWorkspaceTeardown :exec
+UPDATE workspace_teardowns
+SET
+    status = 'completed',
+    step = '',
+    completed_at = now(),
+    updated_at = now()
+WHERE workspace_id = $1;
+
+-- name: ListWorkspaceFilesForTeardown :many
+SELECT id, file_path, thumbnail_path, transcoded_path, poster_path FROM files
+WHERE workspace_id = $1
+ORDER BY id
+LIMIT $2;
+
+-- name: DeleteFilesByIDs :execrows
+DELETE FROM files
+WHERE id = ANY(sqlc.arg('ids')::bigint[]);
+
+-- name: DeleteWorkspaceMessagesBatch :execrows
+DELETE FROM messages
+WHERE id IN (
+    SELECT id FROM messages
+    WHERE workspace_id = $1
+    LIMIT $2
+);
+
+-- name: DeleteWorkspaceInvitationsBatch :execrows
+DELETE FROM workspace_invitations
+WHERE id IN (
+    SELECT id FROM workspace_invitations
+    WHERE workspace_id = $1
+    LIMIT $2
+);
+
+-- name: DeleteWorkspaceStatuses :execrows
+DELETE FROM user_status
+WHERE workspace_id = $1;
+
+-- name: DeleteWorkspacePreferences :one
+-- Per-user settings are bounded by the member count, so they go in one statement
+WITH saved AS (
+    DELETE FROM saved_searches WHERE saved_searches.workspace_id = $1 RETURNING 1
+), dnd AS (
+    DELETE FROM do_not_disturb WHERE do_not_disturb.workspace_id = $1 RETURNING 1
+), ooo AS (
+    DELETE FROM out_of_office WHERE out_of_office.workspace_id = $1 RETURNING 1
+), calendars AS (
+    DELETE FROM calendar_integrations WHERE calendar_integrations.workspace_id = $1 RETURNING 1
+), read_states AS (
+    DELETE FROM direct_message_read_states WHERE direct_message_read_states.workspace_id = $1 RETURNING 1
+)
+SELECT (
+    (SELECT COUNT(*) FROM saved) +
+    (SELECT COUNT(*) FROM dnd) +
+    (SELECT COUNT(*) FROM ooo) +
+    (SELECT COUNT(*) FROM calendars) +
+    (SELECT COUNT(*) FROM read_states)
+)::bigint AS deleted;
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbuseReports(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	message := createRandomChannelMessage(t, workspace, channel, user)
+
+	arg := CreateAbuseReportParams{
+		WorkspaceID: workspace.ID,
+		ReporterID:  sql.NullInt64{Int64: user.ID, Valid: true},
+		TargetType:  "message",
+		TargetID:    message.ID,
+		Reason:      "spam",
+		Details:     "Posted the same link everywhere",
+		Anonymous:   true,
+	}
+
+	report, err := testQueries.CreateAbuseReport(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "open", report.Status)
+	require.True(t, report.Anonymous)
+
+	// Only one open report per reporter and target
+	_, err = testQueries.CreateAbuseReport(context.Background(), arg)
+	require.Error(t, err)
+
+	reports, err := testQueries.ListAbuseReports(context.Background(), ListAbuseReportsParams{
+		WorkspaceID: workspace.ID,
+		Status:      "open",
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	resolved, err := testQueries.ResolveAbuseReport(context.Background(), ResolveAbuseReportParams{
+		ID:             report.ID,
+		WorkspaceID:    workspace.ID,
+		Status:         "resolved",
+		ResolvedBy:     sql.NullInt64{Int64: user.ID, Valid: true},
+		ResolutionNote: "Removed the message",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "resolved", resolved.Status)
+	require.True(t, resolved.ResolvedAt.Valid)
+
+	// Closed reports can't be closed again
+	_, err = testQueries.ResolveAbuseReport(context.Background(), ResolveAbuseReportParams{
+		ID:          report.ID,
+		WorkspaceID: workspace.ID,
+		Status:      "dismissed",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Reporting again is allowed once the earlier report is closed
+	_, err = testQueries.CreateAbuseReport(context.Background(), arg)
+	require.NoError(t, err)
+}
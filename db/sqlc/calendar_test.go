@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomCalendarIntegration(t *testing.T, user User, workspaceID int64) CalendarIntegration {
+	arg := UpsertCalendarIntegrationParams{
+		UserID:      user.ID,
+		WorkspaceID: workspaceID,
+		IcsUrl:      "https://calendar.example.com/" + util.RandomString(12) + ".ics",
+		Enabled:     true,
+	}
+
+	integration, err := testQueries.UpsertCalendarIntegration(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, integration)
+
+	require.Equal(t, arg.UserID, integration.UserID)
+	require.Equal(t, arg.WorkspaceID, integration.WorkspaceID)
+	require.Equal(t, arg.IcsUrl, integration.IcsUrl)
+	require.Equal(t, "ics", integration.Provider)
+	require.True(t, integration.Enabled)
+	require.False(t, integration.LastSyncedAt.Valid)
+
+	return integration
+}
+
+func TestUpsertCalendarIntegration(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	integration := createRandomCalendarIntegration(t, user, workspace.ID)
+
+	// Upserting again updates the existing integration
+	updated, err := testQueries.UpsertCalendarIntegration(context.Background(), UpsertCalendarIntegrationParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		IcsUrl:      integration.IcsUrl,
+		Enabled:     false,
+	})
+	require.NoError(t, err)
+	require.Equal(t, integration.ID, updated.ID)
+	require.False(t, updated.Enabled)
+
+	rows, err := testQueries.DeleteCalendarIntegration(context.Background(), DeleteCalendarIntegrationParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetCalendarIntegration(context.Background(), GetCalendarIntegrationParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestReplaceCalendarBusyBlocksTx(t *testing.T) {
+	store := NewStore(testDB)
+	workspace, user := createTestWorkspaceAndUser(t)
+	integration := createRandomCalendarIntegration(t, user, workspace.ID)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	first := []CalendarBusyBlockParams{
+		{StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+		{StartsAt: now.Add(3 * time.Hour), EndsAt: now.Add(4 * time.Hour)},
+	}
+	err := store.ReplaceCalendarBusyBlocksTx(context.Background(), ReplaceCalendarBusyBlocksTxParams{
+		IntegrationID: integration.ID,
+		Blocks:        first,
+	})
+	require.NoError(t, err)
+
+	// A second sync replaces the previous blocks
+	second := []CalendarBusyBlockParams{
+		{StartsAt: now.Add(-10 * time.Minute), EndsAt: now.Add(20 * time.Minute)},
+	}
+	err = store.ReplaceCalendarBusyBlocksTx(context.Background(), ReplaceCalendarBusyBlocksTxParams{
+		IntegrationID: integration.ID,
+		Blocks:        second,
+	})
+	require.NoError(t, err)
+
+	blocks, err := testQueries.ListUpcomingCalendarBusyBlocks(context.Background(), ListUpcomingCalendarBusyBlocksParams{
+		IntegrationID: integration.ID,
+		Limit:         10,
+	})
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	require.WithinDuration(t, second[0].EndsAt, blocks[0].EndsAt, time.Second)
+
+	synced, err := testQueries.GetCalendarIntegration(context.Background(), GetCalendarIntegrationParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, synced.LastSyncedAt.Valid)
+	require.False(t, synced.LastSyncError.Valid)
+
+	active, err := testQueries.GetActiveCalendarBusyBlocks(context.Background())
+	require.NoError(t, err)
+	found := false
+	for _, row := range active {
+		if row.UserID == user.ID {
+			found = true
+			require.WithinDuration(t, second[0].EndsAt, row.EndsAt, time.Second)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestCalendarBusyStatus(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	_, err := testQueries.UpsertUserStatus(context.Background(), UpsertUserStatusParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		Status:      "online",
+	})
+	require.NoError(t, err)
+
+	arg := SetCalendarBusyStatusParams{
+		UserID:       user.ID,
+		WorkspaceID:  workspace.ID,
+		CustomStatus: sql.NullString{String: "In a meeting", Valid: true},
+		StatusEmoji:  sql.NullString{String: "📅", Valid: true},
+		ClearAfter:   sql.NullTime{Time: time.Now().Add(-time.Second), Valid: true},
+	}
+	userStatus, err := testQueries.SetCalendarBusyStatus(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "busy", userStatus.Status)
+	require.True(t, userStatus.SetByCalendar)
+
+	// The same meeting is not applied twice
+	_, err = testQueries.SetCalendarBusyStatus(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	// Once the meeting has ended the user is back online
+	_, err = testQueries.ClearExpiredCustomStatuses(context.Background())
+	require.NoError(t, err)
+
+	userStatus, err = testQueries.GetUserStatus(context.Background(), GetUserStatusParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "online", userStatus.Status)
+	require.False(t, userStatus.CustomStatus.Valid)
+	require.False(t, userStatus.SetByCalendar)
+
+	// A custom status chosen by the user is left alone
+	_, err = testQueries.UpsertUserStatus(context.Background(), UpsertUserStatusParams{
+		UserID:       user.ID,
+		WorkspaceID:  workspace.ID,
+		Status:       "online",
+		CustomStatus: sql.NullString{String: "Commuting", Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.SetCalendarBusyStatus(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanvasRevisions(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	store := NewStore(testDB)
+	editor := sql.NullInt64{Int64: user.ID, Valid: true}
+
+	canvas, err := store.CreateCanvasTx(context.Background(), CreateCanvasParams{
+		WorkspaceID: workspace.ID,
+		ChannelID:   channel.ID,
+		Title:       "Launch plan",
+		Content:     "# Launch plan",
+		CreatedBy:   editor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), canvas.Revision)
+	require.Equal(t, editor, canvas.UpdatedBy)
+
+	updated, err := store.UpdateCanvasTx(context.Background(), UpdateCanvasParams{
+		ID:        canvas.ID,
+		Revision:  1,
+		Title:     canvas.Title,
+		Content:   "# Launch plan\n\n- Ship it",
+		UpdatedBy: editor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), updated.Revision)
+
+	// An edit based on an old revision is rejected and records nothing
+	_, err = store.UpdateCanvasTx(context.Background(), UpdateCanvasParams{
+		ID:        canvas.ID,
+		Revision:  1,
+		Title:     canvas.Title,
+		Content:   "# Stale",
+		UpdatedBy: editor,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	revisions, err := testQueries.ListCanvasRevisions(context.Background(), ListCanvasRevisionsParams{
+		CanvasID: canvas.ID,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, int32(2), revisions[0].Revision)
+	require.Equal(t, updated.Content, revisions[0].Content)
+
+	first, err := testQueries.GetCanvasRevision(context.Background(), GetCanvasRevisionParams{CanvasID: canvas.ID, Revision: 1})
+	require.NoError(t, err)
+	require.Equal(t, "# Launch plan", first.Content)
+
+	// Canvases are only found in their own workspace
+	_, err = testQueries.GetCanvas(context.Background(), GetCanvasParams{ID: canvas.ID, WorkspaceID: workspace.ID + 1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	canvases, err := testQueries.ListChannelCanvases(context.Background(), ListChannelCanvasesParams{ChannelID: channel.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, canvases, 1)
+
+	require.NoError(t, testQueries.DeleteCanvas(context.Background(), canvas.ID))
+	_, err = testQueries.GetCanvasRevision(context.Background(), GetCanvasRevisionParams{CanvasID: canvas.ID, Revision: 1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
@@ -19,7 +20,7 @@ INSERT INTO channels (
 ) VALUES (
     $1, $2, $3, $4
 )
-RETURNING id, workspace_id, name, is_private, created_by, created_at
+RETURNING id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by
 `
 
 type CreateChannelParams struct {
@@ -44,6 +45,8 @@ func (q *Queries) CreateChannel(ctx context.Context, arg CreateChannelParams) (C
 		&i.IsPrivate,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -59,8 +62,8 @@ func (q *Queries) DeleteChannel(ctx context.Context, id int64) error {
 }
 
 const getChannel = `-- name: GetChannel :one
-SELECT id, workspace_id, name, is_private, created_by, created_at FROM channels
-WHERE id = $1 LIMIT 1
+SELECT id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by FROM channels
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 func (q *Queries) GetChannel(ctx context.Context, id int64) (Channel, error) {
@@ -73,13 +76,15 @@ func (q *Queries) GetChannel(ctx context.Context, id int64) (Channel, error) {
 		&i.IsPrivate,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const getChannelByID = `-- name: GetChannelByID :one
-SELECT id, workspace_id, name, is_private, created_by, created_at FROM channels
-WHERE id = $1 LIMIT 1
+SELECT id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by FROM channels
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 func (q *Queries) GetChannelByID(ctx context.Context, id int64) (Channel, error) {
@@ -92,32 +97,63 @@ func (q *Queries) GetChannelByID(ctx context.Context, id int64) (Channel, error)
 		&i.IsPrivate,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
+const getChannelByName = `-- name: GetChannelByName :one
+SELECT id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by FROM channels
+WHERE workspace_id = $1 AND name = $2 AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetChannelByNameParams struct {
+	WorkspaceID int64  `json:"workspace_id"`
+	Name        string `json:"name"`
+}
+
+func (q *Queries) GetChannelByName(ctx context.Context, arg GetChannelByNameParams) (Channel, error) {
+	row := q.db.QueryRowContext(ctx, getChannelByName, arg.WorkspaceID, arg.Name)
+	var i Channel
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Name,
+		&i.IsPrivate,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const getChannelWithCreator = `-- name: GetChannelWithCreator :one
 SELECT 
-    c.id, c.workspace_id, c.name, c.is_private, c.created_by, c.created_at,
+    c.id, c.workspace_id, c.name, c.is_private, c.created_by, c.created_at, c.deleted_at, c.deleted_by,
     u.first_name as creator_first_name,
     u.last_name as creator_last_name,
     u.email as creator_email
 FROM channels c
 JOIN users u ON c.created_by = u.id
-WHERE c.id = $1
+WHERE c.id = $1 AND c.deleted_at IS NULL
 LIMIT 1
 `
 
 type GetChannelWithCreatorRow struct {
-	ID               int64     `json:"id"`
-	WorkspaceID      int64     `json:"workspace_id"`
-	Name             string    `json:"name"`
-	IsPrivate        bool      `json:"is_private"`
-	CreatedBy        int64     `json:"created_by"`
-	CreatedAt        time.Time `json:"created_at"`
-	CreatorFirstName string    `json:"creator_first_name"`
-	CreatorLastName  string    `json:"creator_last_name"`
-	CreatorEmail     string    `json:"creator_email"`
+	ID               int64         `json:"id"`
+	WorkspaceID      int64         `json:"workspace_id"`
+	Name             string        `json:"name"`
+	IsPrivate        bool          `json:"is_private"`
+	CreatedBy        int64         `json:"created_by"`
+	CreatedAt        time.Time     `json:"created_at"`
+	DeletedAt        sql.NullTime  `json:"deleted_at"`
+	DeletedBy        sql.NullInt64 `json:"deleted_by"`
+	CreatorFirstName string        `json:"creator_first_name"`
+	CreatorLastName  string        `json:"creator_last_name"`
+	CreatorEmail     string        `json:"creator_email"`
 }
 
 func (q *Queries) GetChannelWithCreator(ctx context.Context, id int64) (GetChannelWithCreatorRow, error) {
@@ -130,6 +166,8 @@ func (q *Queries) GetChannelWithCreator(ctx context.Context, id int64) (GetChann
 		&i.IsPrivate,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 		&i.CreatorFirstName,
 		&i.CreatorLastName,
 		&i.CreatorEmail,
@@ -138,8 +176,8 @@ func (q *Queries) GetChannelWithCreator(ctx context.Context, id int64) (GetChann
 }
 
 const listChannelsByWorkspace = `-- name: ListChannelsByWorkspace :many
-SELECT id, workspace_id, name, is_private, created_by, created_at FROM channels
-WHERE workspace_id = $1
+SELECT id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by FROM channels
+WHERE workspace_id = $1 AND deleted_at IS NULL
 ORDER BY created_at ASC
 LIMIT $2
 OFFSET $3
@@ -167,6 +205,125 @@ func (q *Queries) ListChannelsByWorkspace(ctx context.Context, arg ListChannelsB
 			&i.IsPrivate,
 			&i.CreatedBy,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChannelsForUser = `-- name: ListChannelsForUser :many
+SELECT
+    c.id, c.workspace_id, c.name, c.is_private, c.created_by, c.created_at, c.deleted_at, c.deleted_by,
+    (cm.user_id IS NOT NULL)::boolean AS is_member
+FROM channels c
+LEFT JOIN channel_members cm ON cm.channel_id = c.id AND cm.user_id = $1
+WHERE c.workspace_id = $2
+    AND c.deleted_at IS NULL
+    AND (c.is_private = false OR cm.user_id IS NOT NULL)
+ORDER BY c.created_at ASC
+LIMIT $3
+OFFSET $4
+`
+
+type ListChannelsForUserParams struct {
+	UserID      int64 `json:"user_id"`
+	WorkspaceID int64 `json:"workspace_id"`
+	Limit       int32 `json:"limit"`
+	Offset      int32 `json:"offset"`
+}
+
+type ListChannelsForUserRow struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Name        string        `json:"name"`
+	IsPrivate   bool          `json:"is_private"`
+	CreatedBy   int64         `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	DeletedBy   sql.NullInt64 `json:"deleted_by"`
+	IsMember    bool          `json:"is_member"`
+}
+
+// Channels of the workspace the user can see, the public ones and the private
+// ones they're a member of, and whether they have joined them
+func (q *Queries) ListChannelsForUser(ctx context.Context, arg ListChannelsForUserParams) ([]ListChannelsForUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChannelsForUser,
+		arg.UserID,
+		arg.WorkspaceID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListChannelsForUserRow{}
+	for rows.Next() {
+		var i ListChannelsForUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Name,
+			&i.IsPrivate,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.IsMember,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeletedChannels = `-- name: ListDeletedChannels :many
+SELECT id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by FROM channels
+WHERE workspace_id = $1
+    AND deleted_at > $2::timestamptz
+ORDER BY deleted_at DESC
+`
+
+type ListDeletedChannelsParams struct {
+	WorkspaceID  int64     `json:"workspace_id"`
+	DeletedAfter time.Time `json:"deleted_after"`
+}
+
+func (q *Queries) ListDeletedChannels(ctx context.Context, arg ListDeletedChannelsParams) ([]Channel, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedChannels, arg.WorkspaceID, arg.DeletedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Channel{}
+	for rows.Next() {
+		var i Channel
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Name,
+			&i.IsPrivate,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -182,8 +339,8 @@ func (q *Queries) ListChannelsByWorkspace(ctx context.Context, arg ListChannelsB
 }
 
 const listPublicChannelsByWorkspace = `-- name: ListPublicChannelsByWorkspace :many
-SELECT id, workspace_id, name, is_private, created_by, created_at FROM channels
-WHERE workspace_id = $1 AND is_private = false
+SELECT id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by FROM channels
+WHERE workspace_id = $1 AND is_private = false AND deleted_at IS NULL
 ORDER BY created_at ASC
 LIMIT $2
 OFFSET $3
@@ -211,6 +368,157 @@ func (q *Queries) ListPublicChannelsByWorkspace(ctx context.Context, arg ListPub
 			&i.IsPrivate,
 			&i.CreatedBy,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableChannels = `-- name: ListPurgeableChannels :many
+SELECT id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by FROM channels
+WHERE deleted_at <= $1::timestamptz
+ORDER BY deleted_at ASC
+LIMIT $2
+`
+
+type ListPurgeableChannelsParams struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Limit         int32     `json:"limit"`
+}
+
+func (q *Queries) ListPurgeableChannels(ctx context.Context, arg ListPurgeableChannelsParams) ([]Channel, error) {
+	rows, err := q.db.QueryContext(ctx, listPurgeableChannels, arg.DeletedBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Channel{}
+	for rows.Next() {
+		var i Channel
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Name,
+			&i.IsPrivate,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreChannel = `-- name: RestoreChannel :one
+UPDATE channels
+SET
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE id = $1
+    AND workspace_id = $2
+    AND deleted_at > $3::timestamptz
+RETURNING id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by
+`
+
+type RestoreChannelParams struct {
+	ID           int64     `json:"id"`
+	WorkspaceID  int64     `json:"workspace_id"`
+	DeletedAfter time.Time `json:"deleted_after"`
+}
+
+// Only channels still inside the recovery window can be restored
+func (q *Queries) RestoreChannel(ctx context.Context, arg RestoreChannelParams) (Channel, error) {
+	row := q.db.QueryRowContext(ctx, restoreChannel, arg.ID, arg.WorkspaceID, arg.DeletedAfter)
+	var i Channel
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.Name,
+		&i.IsPrivate,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
+const searchChannels = `-- name: SearchChannels :many
+SELECT c.id, c.workspace_id, c.name, c.is_private, c.created_by, c.created_at, c.deleted_at, c.deleted_by, COUNT(*) OVER() as total_count
+FROM channels c
+WHERE c.workspace_id = $1
+    AND c.deleted_at IS NULL
+    AND c.name ILIKE '%' || $2::text || '%'
+    AND (c.is_private = false OR EXISTS (
+        SELECT 1 FROM channel_members cm WHERE cm.channel_id = c.id AND cm.user_id = $3
+    ))
+ORDER BY c.name ASC
+LIMIT $4
+`
+
+type SearchChannelsParams struct {
+	WorkspaceID int64  `json:"workspace_id"`
+	Query       string `json:"query"`
+	UserID      int64  `json:"user_id"`
+	Limit       int32  `json:"limit"`
+}
+
+type SearchChannelsRow struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Name        string        `json:"name"`
+	IsPrivate   bool          `json:"is_private"`
+	CreatedBy   int64         `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	DeletedBy   sql.NullInt64 `json:"deleted_by"`
+	TotalCount  int64         `json:"total_count"`
+}
+
+// Private channels only match when the user is a member
+func (q *Queries) SearchChannels(ctx context.Context, arg SearchChannelsParams) ([]SearchChannelsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchChannels,
+		arg.WorkspaceID,
+		arg.Query,
+		arg.UserID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchChannelsRow{}
+	for rows.Next() {
+		var i SearchChannelsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Name,
+			&i.IsPrivate,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.TotalCount,
 		); err != nil {
 			return nil, err
 		}
@@ -225,13 +533,34 @@ func (q *Queries) ListPublicChannelsByWorkspace(ctx context.Context, arg ListPub
 	return items, nil
 }
 
+const softDeleteChannel = `-- name: SoftDeleteChannel :execrows
+UPDATE channels
+SET
+    deleted_at = now(),
+    deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type SoftDeleteChannelParams struct {
+	ID        int64         `json:"id"`
+	DeletedBy sql.NullInt64 `json:"deleted_by"`
+}
+
+func (q *Queries) SoftDeleteChannel(ctx context.Context, arg SoftDeleteChannelParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, softDeleteChannel, arg.ID, arg.DeletedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateChannel = `-- name: UpdateChannel :one
 UPDATE channels
 SET 
     name = $2,
     is_private = $3
-WHERE id = $1
-RETURNING id, workspace_id, name, is_private, created_by, created_at
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, workspace_id, name, is_private, created_by, created_at, deleted_at, deleted_by
 `
 
 type UpdateChannelParams struct {
@@ -250,6 +579,8 @@ func (q *Queries) UpdateChannel(ctx context.Context, arg UpdateChannelParams) (C
 		&i.IsPrivate,
 		&i.CreatedBy,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -132,10 +132,10 @@ func (q *Queries) GetChannelMembers(ctx context.Context, arg GetChannelMembersPa
 
 const getUserChannels = `-- name: GetUserChannels :many
 SELECT 
-    c.id, c.workspace_id, c.name, c.is_private, c.created_by, c.created_at
+    c.id, c.workspace_id, c.name, c.is_private, c.created_by, c.created_at, c.deleted_at, c.deleted_by
 FROM channels c
 JOIN channel_members cm ON c.id = cm.channel_id
-WHERE cm.user_id = $1 AND c.workspace_id = $2
+WHERE cm.user_id = $1 AND c.workspace_id = $2 AND c.deleted_at IS NULL
 ORDER BY c.created_at ASC
 `
 
@@ -160,6 +160,8 @@ func (q *Queries) GetUserChannels(ctx context.Context, arg GetUserChannelsParams
 			&i.IsPrivate,
 			&i.CreatedBy,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
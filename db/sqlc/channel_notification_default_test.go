@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelNotificationDefault(t *testing.T) {
+	workspace, admin := createTestWorkspaceAndUser(t)
+	member := createRandomUserForOrganization(t, workspace.OrganizationID)
+	joiner := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, admin)
+
+	// Someone in the channel before the default keeps their preference
+	createRandomChannelMember(t, channel, member, admin)
+	_, err := testQueries.UpsertChannelNotificationPreference(context.Background(), UpsertChannelNotificationPreferenceParams{
+		UserID:       member.ID,
+		WorkspaceID:  workspace.ID,
+		ChannelID:    channel.ID,
+		Level:        "nothing",
+		EmailEnabled: false,
+		PushEnabled:  false,
+	})
+	require.NoError(t, err)
+
+	channelDefault, err := testQueries.UpsertChannelNotificationDefault(context.Background(), UpsertChannelNotificationDefaultParams{
+		ChannelID:    channel.ID,
+		WorkspaceID:  workspace.ID,
+		Level:        "all",
+		EmailEnabled: false,
+		PushEnabled:  true,
+		UpdatedBy:    sql.NullInt64{Int64: admin.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "all", channelDefault.Level)
+
+	// Joining seeds the channel preference from the default
+	createRandomChannelMember(t, channel, joiner, admin)
+	seeded, err := testQueries.GetEffectiveNotificationPreference(context.Background(), GetEffectiveNotificationPreferenceParams{
+		UserID:      joiner.ID,
+		WorkspaceID: workspace.ID,
+		ChannelID:   channel.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, channel.ID, seeded.ChannelID.Int64)
+	require.Equal(t, "all", seeded.Level)
+	require.False(t, seeded.EmailEnabled)
+
+	existing, err := testQueries.GetEffectiveNotificationPreference(context.Background(), GetEffectiveNotificationPreferenceParams{
+		UserID:      member.ID,
+		WorkspaceID: workspace.ID,
+		ChannelID:   channel.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "nothing", existing.Level)
+
+	defaults, err := testQueries.ListChannelNotificationDefaults(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, defaults, 1)
+
+	// Applying the default again replaces every member's preference
+	applied, err := testQueries.ApplyChannelNotificationDefault(context.Background(), channel.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), applied)
+
+	existing, err = testQueries.GetEffectiveNotificationPreference(context.Background(), GetEffectiveNotificationPreferenceParams{
+		UserID:      member.ID,
+		WorkspaceID: workspace.ID,
+		ChannelID:   channel.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "all", existing.Level)
+	require.True(t, existing.PushEnabled)
+
+	rows, err := testQueries.DeleteChannelNotificationDefault(context.Background(), channel.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetChannelNotificationDefault(context.Background(), channel.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
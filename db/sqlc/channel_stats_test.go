@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupChannelStatsTx(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	otherUser := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, user)
+	store := NewStore(testDB)
+
+	day := time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC)
+	createMessage := func(sender User, threadID int64, createdAt time.Time) Message {
+		message, err := testQueries.CreateMessageAt(context.Background(), CreateMessageAtParams{
+			WorkspaceID: workspace.ID,
+			ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+			SenderID:    sender.ID,
+			Content:     util.RandomString(20),
+			ContentType: "text",
+			MessageType: "channel",
+			ThreadID:    sql.NullInt64{Int64: threadID, Valid: threadID != 0},
+			CreatedAt:   createdAt,
+		})
+		require.NoError(t, err)
+		return message
+	}
+
+	parent := createMessage(user, 0, day.Add(9*time.Hour))
+	createMessage(otherUser, parent.ID, day.Add(10*time.Hour))
+	last := createMessage(user, 0, day.Add(11*time.Hour))
+
+	// A deleted message isn't counted
+	deleted := createMessage(otherUser, 0, day.Add(12*time.Hour))
+	err := testQueries.SoftDeleteMessage(context.Background(), SoftDeleteMessageParams{ID: deleted.ID, DeletedBy: sql.NullInt64{Int64: otherUser.ID, Valid: true}})
+	require.NoError(t, err)
+
+	nextDay := day.AddDate(0, 0, 1)
+	_, err = store.RollupChannelStatsTx(context.Background(), RollupChannelStatsTxParams{FromDay: day, ToDay: nextDay})
+	require.NoError(t, err)
+
+	// Rolling up again replaces the day rather than adding to it
+	_, err = store.RollupChannelStatsTx(context.Background(), RollupChannelStatsTxParams{FromDay: day, ToDay: nextDay})
+	require.NoError(t, err)
+
+	stats, err := testQueries.ListChannelDailyStats(context.Background(), ListChannelDailyStatsParams{
+		ChannelID: channel.ID,
+		FromDay:   day,
+		ToDay:     nextDay,
+	})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, int32(3), stats[0].MessageCount)
+	require.Equal(t, int32(1), stats[0].ReplyCount)
+	require.Equal(t, int32(1), stats[0].ThreadCount)
+	require.WithinDuration(t, last.CreatedAt, stats[0].LastMessageAt, time.Millisecond)
+
+	posters, err := testQueries.ListChannelTopPosters(context.Background(), ListChannelTopPostersParams{
+		ChannelID: channel.ID,
+		FromDay:   day,
+		ToDay:     nextDay,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, posters, 2)
+	require.Equal(t, user.ID, posters[0].UserID)
+	require.Equal(t, int64(2), posters[0].MessageCount)
+	require.Equal(t, otherUser.ID, posters[1].UserID)
+	require.Equal(t, int64(1), posters[1].MessageCount)
+
+	activity, err := testQueries.GetChannelLastActivity(context.Background(), channel.ID)
+	require.NoError(t, err)
+	require.WithinDuration(t, last.CreatedAt, activity.LastMessageAt, time.Millisecond)
+}
@@ -121,6 +121,51 @@ func TestDeleteChannel(t *testing.T) {
 	require.Empty(t, channel2)
 }
 
+func TestSoftDeleteAndRestoreChannel(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+
+	rows, err := testQueries.SoftDeleteChannel(context.Background(), SoftDeleteChannelParams{
+		ID:        channel.ID,
+		DeletedBy: sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	// Deleted channels are hidden from lookups but can still be listed
+	_, err = testQueries.GetChannelByID(context.Background(), channel.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	deleted, err := testQueries.ListDeletedChannels(context.Background(), ListDeletedChannelsParams{
+		WorkspaceID:  workspace.ID,
+		DeletedAfter: time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	require.True(t, deleted[0].DeletedAt.Valid)
+	require.Equal(t, user.ID, deleted[0].DeletedBy.Int64)
+
+	// Outside the recovery window the channel can't be restored
+	_, err = testQueries.RestoreChannel(context.Background(), RestoreChannelParams{
+		ID:           channel.ID,
+		WorkspaceID:  workspace.ID,
+		DeletedAfter: time.Now().Add(time.Minute),
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	restored, err := testQueries.RestoreChannel(context.Background(), RestoreChannelParams{
+		ID:           channel.ID,
+		WorkspaceID:  workspace.ID,
+		DeletedAfter: time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, channel.Name, restored.Name)
+	require.False(t, restored.DeletedAt.Valid)
+
+	_, err = testQueries.GetChannelByID(context.Background(), channel.ID)
+	require.NoError(t, err)
+}
+
 func TestListChannelsByWorkspace(t *testing.T) {
 	workspace, user := createTestWorkspaceAndUser(t)
 
@@ -378,3 +423,56 @@ func TestChannelCascadeDelete(t *testing.T) {
 	require.EqualError(t, err, sql.ErrNoRows.Error())
 	require.Empty(t, deletedChannel)
 }
+
+func TestSearchChannels(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	// Membership makes the channel visible even when it is private
+	createRandomChannelMember(t, channel, user, user)
+
+	channels, err := testQueries.SearchChannels(context.Background(), SearchChannelsParams{
+		WorkspaceID: workspace.ID,
+		Query:       channel.Name[2:8],
+		UserID:      user.ID,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, channels)
+	require.Equal(t, channel.ID, channels[0].ID)
+	require.Equal(t, int64(len(channels)), channels[0].TotalCount)
+}
+
+func TestCreateChannelTx(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	store := NewStore(testDB)
+
+	arg := CreateChannelTxParams{
+		WorkspaceID:   workspace.ID,
+		Name:          util.RandomString(10),
+		IsPrivate:     true,
+		CreatedBy:     user.ID,
+		SystemMessage: "created the channel",
+	}
+
+	result, err := store.CreateChannelTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Name, result.Channel.Name)
+	require.Equal(t, result.Channel.ID, result.Member.ChannelID)
+	require.Equal(t, user.ID, result.Member.UserID)
+	require.Equal(t, "admin", result.Member.Role)
+	require.Equal(t, result.Channel.ID, result.Message.ChannelID.Int64)
+	require.Equal(t, "system", result.Message.ContentType)
+	require.Equal(t, arg.SystemMessage, result.Message.Content)
+
+	// Nothing is created when the channel name is taken
+	_, err = store.CreateChannelTx(context.Background(), arg)
+	require.Error(t, err)
+
+	messages, err := testQueries.GetChannelMessages(context.Background(), GetChannelMessagesParams{
+		ChannelID:   sql.NullInt64{Int64: result.Channel.ID, Valid: true},
+		WorkspaceID: workspace.ID,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+}
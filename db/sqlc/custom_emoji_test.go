@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomEmojis(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	file := createRandomFileForWorkspace(t, workspace.ID, user.ID)
+
+	emoji, err := testQueries.CreateCustomEmoji(context.Background(), CreateCustomEmojiParams{
+		WorkspaceID: workspace.ID,
+		Name:        "party-parrot",
+		FileID:      file.ID,
+		CreatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// Names are unique within a workspace
+	_, err = testQueries.CreateCustomEmoji(context.Background(), CreateCustomEmojiParams{
+		WorkspaceID: workspace.ID,
+		Name:        "party-parrot",
+		FileID:      file.ID,
+	})
+	require.Error(t, err)
+
+	exists, err := testQueries.CustomEmojiExists(context.Background(), CustomEmojiExistsParams{WorkspaceID: workspace.ID, Name: "party-parrot"})
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	exists, err = testQueries.CustomEmojiExists(context.Background(), CustomEmojiExistsParams{WorkspaceID: workspace.ID + 1, Name: "party-parrot"})
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	emojis, err := testQueries.ListCustomEmojis(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, emojis, 1)
+	require.Equal(t, emoji, emojis[0])
+
+	require.NoError(t, testQueries.DeleteCustomEmoji(context.Background(), emoji.ID))
+	_, err = testQueries.GetCustomEmoji(context.Background(), GetCustomEmojiParams{ID: emoji.ID, WorkspaceID: workspace.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoNotDisturb(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	arg := UpsertDoNotDisturbParams{
+		UserID:              user.ID,
+		WorkspaceID:         workspace.ID,
+		DndUntil:            sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		AllowUrgentOverride: true,
+		ScheduleDays:        []int32{},
+	}
+	dnd, err := testQueries.UpsertDoNotDisturb(context.Background(), arg)
+	require.NoError(t, err)
+	require.True(t, dnd.AllowUrgentOverride)
+
+	got, err := testQueries.GetDoNotDisturb(context.Background(), GetDoNotDisturbParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, got.UserID)
+	require.Equal(t, user.Timezone, got.Timezone)
+	require.False(t, got.ScheduleStartMinute.Valid)
+	require.Empty(t, got.ScheduleDays)
+
+	// Turning Do Not Disturb off keeps the schedule
+	arg.DndUntil = sql.NullTime{}
+	arg.ScheduleStartMinute = sql.NullInt32{Int32: 22 * 60, Valid: true}
+	arg.ScheduleEndMinute = sql.NullInt32{Int32: 8 * 60, Valid: true}
+	arg.ScheduleDays = []int32{1, 2, 3, 4, 5}
+	dnd, err = testQueries.UpsertDoNotDisturb(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, dnd.DndUntil.Valid)
+	require.Equal(t, int32(22*60), dnd.ScheduleStartMinute.Int32)
+	require.Equal(t, arg.ScheduleDays, dnd.ScheduleDays)
+	require.Equal(t, user.Timezone, dnd.Timezone)
+}
+
+func TestClearExpiredDoNotDisturb(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	snoozed := createRandomUserForOrganization(t, workspace.OrganizationID)
+
+	for _, u := range []User{user, snoozed} {
+		_, err := testQueries.UpsertUserStatus(context.Background(), UpsertUserStatusParams{
+			UserID:      u.ID,
+			WorkspaceID: workspace.ID,
+			Status:      "online",
+		})
+		require.NoError(t, err)
+	}
+
+	// One snooze has expired, the other hasn't
+	_, err := testQueries.UpsertDoNotDisturb(context.Background(), UpsertDoNotDisturbParams{
+		UserID:              user.ID,
+		WorkspaceID:         workspace.ID,
+		DndUntil:            sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+		AllowUrgentOverride: true,
+		ScheduleDays:        []int32{},
+	})
+	require.NoError(t, err)
+	_, err = testQueries.UpsertDoNotDisturb(context.Background(), UpsertDoNotDisturbParams{
+		UserID:              snoozed.ID,
+		WorkspaceID:         workspace.ID,
+		DndUntil:            sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		AllowUrgentOverride: true,
+		ScheduleDays:        []int32{},
+	})
+	require.NoError(t, err)
+
+	statuses, err := testQueries.ClearExpiredDoNotDisturb(context.Background())
+	require.NoError(t, err)
+
+	var clearedUserIDs []int64
+	for _, status := range statuses {
+		clearedUserIDs = append(clearedUserIDs, status.UserID)
+	}
+	require.Contains(t, clearedUserIDs, user.ID)
+	require.NotContains(t, clearedUserIDs, snoozed.ID)
+
+	dnds, err := testQueries.ListWorkspaceDoNotDisturb(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, dnds, 2)
+	for _, dnd := range dnds {
+		require.Equal(t, dnd.UserID == snoozed.ID, dnd.DndUntil.Valid)
+	}
+}
+
+func TestCreateDNDOverride(t *testing.T) {
+	workspace, sender := createTestWorkspaceAndUser(t)
+	receiver := createRandomUserForOrganization(t, workspace.OrganizationID)
+	message := createRandomDirectMessage(t, workspace, sender, receiver)
+
+	arg := CreateDNDOverrideParams{
+		MessageID:  message.ID,
+		SenderID:   sender.ID,
+		ReceiverID: receiver.ID,
+	}
+	rows, err := testQueries.CreateDNDOverride(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	// A message breaks through only once
+	rows, err = testQueries.CreateDNDOverride(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailDeliveryQueue(t *testing.T) {
+	address := util.RandomEmail()
+
+	delivery, err := testQueries.CreateEmailDelivery(context.Background(), CreateEmailDeliveryParams{
+		Category:    "invitation",
+		ReferenceID: sql.NullInt64{Int64: 7, Valid: true},
+		ToAddress:   address,
+		Subject:     "Hi",
+		TextBody:    "Hello",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", delivery.Status)
+	require.Zero(t, delivery.Attempts)
+
+	claimed := claimEmailDelivery(t, delivery.ID)
+	require.NotNil(t, claimed)
+	require.Equal(t, "sending", claimed.Status)
+	require.Equal(t, int32(1), claimed.Attempts)
+
+	// A claimed email isn't handed to another worker
+	require.Nil(t, claimEmailDelivery(t, delivery.ID))
+
+	err = testQueries.RetryEmailDelivery(context.Background(), RetryEmailDeliveryParams{
+		ID:            delivery.ID,
+		Provider:      "smtp",
+		LastError:     "connection refused",
+		NextAttemptAt: time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+
+	claimed = claimEmailDelivery(t, delivery.ID)
+	require.NotNil(t, claimed)
+	require.Equal(t, int32(2), claimed.Attempts)
+	require.Equal(t, "connection refused", claimed.LastError)
+
+	err = testQueries.MarkEmailDeliverySent(context.Background(), MarkEmailDeliverySentParams{
+		ID:                delivery.ID,
+		Provider:          "smtp",
+		ProviderMessageID: "<abc@goslack.test>",
+	})
+	require.NoError(t, err)
+
+	deliveries, err := testQueries.ListEmailDeliveriesByAddress(context.Background(), ListEmailDeliveriesByAddressParams{
+		ToAddress: address,
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, "sent", deliveries[0].Status)
+	require.Empty(t, deliveries[0].LastError)
+	require.True(t, deliveries[0].SentAt.Valid)
+}
+
+// claimEmailDelivery claims due emails and returns the one with the given ID,
+// if it was claimed
+func claimEmailDelivery(t *testing.T, id int64) *EmailDelivery {
+	deliveries, err := testQueries.ClaimEmailDeliveries(context.Background(), ClaimEmailDeliveriesParams{
+		StaleBefore: time.Now().Add(-time.Hour),
+		Limit:       1000,
+	})
+	require.NoError(t, err)
+
+	for _, delivery := range deliveries {
+		if delivery.ID == id {
+			return &delivery
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailSuppression(t *testing.T) {
+	address := util.RandomEmail()
+
+	suppression, err := testQueries.UpsertEmailSuppression(context.Background(), UpsertEmailSuppressionParams{
+		Email:    strings.ToUpper(address),
+		Reason:   "bounce",
+		Provider: "ses",
+		Detail:   "550 user unknown",
+	})
+	require.NoError(t, err)
+	require.Equal(t, strings.ToLower(address), suppression.Email)
+	require.Equal(t, "bounce", suppression.Reason)
+
+	// A later complaint replaces the bounce
+	suppression, err = testQueries.UpsertEmailSuppression(context.Background(), UpsertEmailSuppressionParams{
+		Email:    address,
+		Reason:   "complaint",
+		Provider: "ses",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "complaint", suppression.Reason)
+	require.Empty(t, suppression.Detail)
+
+	found, err := testQueries.GetEmailSuppression(context.Background(), strings.ToUpper(address))
+	require.NoError(t, err)
+	require.Equal(t, suppression.Email, found.Email)
+
+	listed, err := testQueries.ListEmailSuppressionsByEmails(context.Background(), []string{strings.ToLower(address), util.RandomEmail()})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	deleted, err := testQueries.DeleteEmailSuppression(context.Background(), address)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = testQueries.GetEmailSuppression(context.Background(), address)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTemplate(t *testing.T) {
+	organization := createRandomOrganization(t)
+	admin := createRandomUserForOrganization(t, organization.ID)
+
+	arg := UpsertEmailTemplateParams{
+		OrganizationID: organization.ID,
+		Kind:           "invitation",
+		Subject:        "Join {{.WorkspaceName}}",
+		TextBody:       "{{.Link}}",
+		HtmlBody:       `<a href="{{.Link}}">Join</a>`,
+		UpdatedBy:      sql.NullInt64{Int64: admin.ID, Valid: true},
+	}
+	template, err := testQueries.UpsertEmailTemplate(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Subject, template.Subject)
+
+	// Saving again replaces the template
+	arg.Subject = "You're invited to {{.WorkspaceName}}"
+	updated, err := testQueries.UpsertEmailTemplate(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, template.ID, updated.ID)
+	require.Equal(t, arg.Subject, updated.Subject)
+
+	found, err := testQueries.GetEmailTemplate(context.Background(), GetEmailTemplateParams{OrganizationID: organization.ID, Kind: "invitation"})
+	require.NoError(t, err)
+	require.Equal(t, arg.Subject, found.Subject)
+
+	templates, err := testQueries.ListEmailTemplates(context.Background(), organization.ID)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+
+	deleted, err := testQueries.DeleteEmailTemplate(context.Background(), DeleteEmailTemplateParams{OrganizationID: organization.ID, Kind: "invitation"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = testQueries.GetEmailTemplate(context.Background(), GetEmailTemplateParams{OrganizationID: organization.ID, Kind: "invitation"})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestEmailBranding(t *testing.T) {
+	organization := createRandomOrganization(t)
+
+	_, err := testQueries.GetEmailBranding(context.Background(), organization.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	branding, err := testQueries.UpsertEmailBranding(context.Background(), UpsertEmailBrandingParams{
+		OrganizationID: organization.ID,
+		ProductName:    "Acme Chat",
+		PrimaryColor:   "#0b5fff",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Acme Chat", branding.ProductName)
+
+	branding, err = testQueries.UpsertEmailBranding(context.Background(), UpsertEmailBrandingParams{
+		OrganizationID: organization.ID,
+		ProductName:    "Acme",
+		LogoUrl:        "https://acme.example/logo.png",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Acme", branding.ProductName)
+	require.Empty(t, branding.PrimaryColor)
+
+	found, err := testQueries.GetEmailBranding(context.Background(), organization.ID)
+	require.NoError(t, err)
+	require.Equal(t, branding.LogoUrl, found.LogoUrl)
+}
@@ -0,0 +1,24 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PostgreSQL error codes the application handles
+const (
+	ForeignKeyViolation = "23503"
+	UniqueViolation     = "23505"
+	QueryCanceled       = "57014" // Also raised when a statement exceeds DB_STATEMENT_TIMEOUT
+)
+
+// ErrorCode returns the SQLSTATE code of a PostgreSQL error, or an empty
+// string when err didn't come from the database
+func ErrorCode(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
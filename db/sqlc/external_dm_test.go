@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalDMRequests(t *testing.T) {
+	workspace, sender := createTestWorkspaceAndUser(t)
+	recipient := createRandomUserForOrganization(t, createRandomOrganization(t).ID)
+
+	request, err := testQueries.CreateExternalDMRequest(context.Background(), CreateExternalDMRequestParams{
+		SenderID:    sender.ID,
+		WorkspaceID: workspace.ID,
+		RecipientID: recipient.ID,
+		Message:     "Hello from across the way",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", request.Status)
+
+	// Asking back while the first request is open adds nothing
+	_, err = testQueries.CreateExternalDMRequest(context.Background(), CreateExternalDMRequestParams{
+		SenderID:    recipient.ID,
+		WorkspaceID: workspace.ID,
+		RecipientID: sender.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	accepted, err := testQueries.RespondExternalDMRequest(context.Background(), RespondExternalDMRequestParams{ID: request.ID, Status: "accepted"})
+	require.NoError(t, err)
+	require.True(t, accepted.RespondedAt.Valid)
+
+	_, err = testQueries.RespondExternalDMRequest(context.Background(), RespondExternalDMRequestParams{ID: request.ID, Status: "declined"})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	row, err := testQueries.GetExternalDMRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, sender.Email, row.SenderEmail)
+	require.Equal(t, recipient.OrganizationID, row.RecipientOrganizationID)
+
+	requests, err := testQueries.ListExternalDMRequests(context.Background(), ListExternalDMRequestsParams{
+		UserID: recipient.ID,
+		Status: "accepted",
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+
+	closed, err := testQueries.CloseExternalDMRequest(context.Background(), request.ID)
+	require.NoError(t, err)
+	require.Equal(t, "closed", closed.Status)
+	require.Equal(t, accepted.RespondedAt.Time, closed.RespondedAt.Time)
+
+	// Once closed the two can ask each other again
+	_, err = testQueries.CreateExternalDMRequest(context.Background(), CreateExternalDMRequestParams{
+		SenderID:    recipient.ID,
+		WorkspaceID: workspace.ID,
+		RecipientID: sender.ID,
+	})
+	require.NoError(t, err)
+}
+
+func TestUpsertExternalDMPolicy(t *testing.T) {
+	organization := createRandomOrganization(t)
+
+	_, err := testQueries.GetExternalDMPolicy(context.Background(), organization.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	policy, err := testQueries.UpsertExternalDMPolicy(context.Background(), UpsertExternalDMPolicyParams{
+		OrganizationID: organization.ID,
+		Policy:         "restricted",
+		AllowedDomains: []string{"partner.com"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"partner.com"}, policy.AllowedDomains)
+
+	policy, err = testQueries.UpsertExternalDMPolicy(context.Background(), UpsertExternalDMPolicyParams{
+		OrganizationID: organization.ID,
+		Policy:         "disabled",
+		AllowedDomains: []string{},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "disabled", policy.Policy)
+	require.Empty(t, policy.AllowedDomains)
+}
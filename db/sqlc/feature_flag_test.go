@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlags(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	flag, err := testQueries.UpsertFeatureFlag(context.Background(), UpsertFeatureFlagParams{
+		WorkspaceID: workspace.ID,
+		Flag:        "huddles",
+		Enabled:     true,
+		UpdatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.True(t, flag.Enabled)
+
+	// Upserting again updates the override
+	flag, err = testQueries.UpsertFeatureFlag(context.Background(), UpsertFeatureFlagParams{
+		WorkspaceID: workspace.ID,
+		Flag:        "huddles",
+		Enabled:     false,
+		UpdatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.False(t, flag.Enabled)
+
+	flags, err := testQueries.ListFeatureFlags(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+	require.Equal(t, "huddles", flags[0].Flag)
+
+	rows, err := testQueries.DeleteFeatureFlag(context.Background(), DeleteFeatureFlagParams{WorkspaceID: workspace.ID, Flag: "huddles"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	rows, err = testQueries.DeleteFeatureFlag(context.Background(), DeleteFeatureFlagParams{WorkspaceID: workspace.ID, Flag: "huddles"})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
@@ -9,10 +9,13 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 const checkFileAccess = `-- name: CheckFileAccess :one
 SELECT CASE 
+    WHEN f.deleted_at IS NOT NULL THEN false
     WHEN f.uploader_id = $2 THEN true
     WHEN f.is_public = true THEN true
     WHEN EXISTS (
@@ -45,15 +48,18 @@ func (q *Queries) CheckFileAccess(ctx context.Context, arg CheckFileAccessParams
 	return has_access, err
 }
 
-const cleanupIncompleteUploads = `-- name: CleanupIncompleteUploads :exec
+const cleanupIncompleteUploads = `-- name: CleanupIncompleteUploads :execrows
 DELETE FROM files 
 WHERE upload_completed = false 
 AND created_at < now() - INTERVAL '1 hour'
 `
 
-func (q *Queries) CleanupIncompleteUploads(ctx context.Context) error {
-	_, err := q.db.ExecContext(ctx, cleanupIncompleteUploads)
-	return err
+func (q *Queries) CleanupIncompleteUploads(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cleanupIncompleteUploads)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const createFile = `-- name: CreateFile :one
@@ -63,7 +69,7 @@ INSERT INTO files (
     upload_completed, thumbnail_path
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
-) RETURNING id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at
+) RETURNING id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at, is_voice_message, duration_ms, waveform, processing_status, transcoded_path, poster_path, download_count, preview_status, deleted_at, deleted_by
 `
 
 type CreateFileParams struct {
@@ -110,6 +116,16 @@ func (q *Queries) CreateFile(ctx context.Context, arg CreateFileParams) (File, e
 		&i.ThumbnailPath,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsVoiceMessage,
+		&i.DurationMs,
+		pq.Array(&i.Waveform),
+		&i.ProcessingStatus,
+		&i.TranscodedPath,
+		&i.PosterPath,
+		&i.DownloadCount,
+		&i.PreviewStatus,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -208,7 +224,7 @@ func (q *Queries) DeleteMessageFile(ctx context.Context, arg DeleteMessageFilePa
 const getDuplicateFiles = `-- name: GetDuplicateFiles :many
 SELECT file_hash, COUNT(*) as count, ARRAY_AGG(id) as file_ids, SUM(file_size) as total_size
 FROM files 
-WHERE workspace_id = $1 AND upload_completed = true
+WHERE workspace_id = $1 AND upload_completed = true AND deleted_at IS NULL
 GROUP BY file_hash 
 HAVING COUNT(*) > 1
 ORDER BY count DESC
@@ -250,8 +266,8 @@ func (q *Queries) GetDuplicateFiles(ctx context.Context, workspaceID int64) ([]G
 }
 
 const getFile = `-- name: GetFile :one
-SELECT id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at FROM files
-WHERE id = $1 LIMIT 1
+SELECT id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at, is_voice_message, duration_ms, waveform, processing_status, transcoded_path, poster_path, download_count, preview_status, deleted_at, deleted_by FROM files
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 func (q *Queries) GetFile(ctx context.Context, id int64) (File, error) {
@@ -272,13 +288,23 @@ func (q *Queries) GetFile(ctx context.Context, id int64) (File, error) {
 		&i.ThumbnailPath,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsVoiceMessage,
+		&i.DurationMs,
+		pq.Array(&i.Waveform),
+		&i.ProcessingStatus,
+		&i.TranscodedPath,
+		&i.PosterPath,
+		&i.DownloadCount,
+		&i.PreviewStatus,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const getFileByHash = `-- name: GetFileByHash :one
-SELECT id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at FROM files
-WHERE file_hash = $1 AND workspace_id = $2 AND upload_completed = true
+SELECT id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at, is_voice_message, duration_ms, waveform, processing_status, transcoded_path, poster_path, download_count, preview_status, deleted_at, deleted_by FROM files
+WHERE file_hash = $1 AND workspace_id = $2 AND upload_completed = true AND deleted_at IS NULL
 LIMIT 1
 `
 
@@ -305,12 +331,22 @@ func (q *Queries) GetFileByHash(ctx context.Context, arg GetFileByHashParams) (F
 		&i.ThumbnailPath,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsVoiceMessage,
+		&i.DurationMs,
+		pq.Array(&i.Waveform),
+		&i.ProcessingStatus,
+		&i.TranscodedPath,
+		&i.PosterPath,
+		&i.DownloadCount,
+		&i.PreviewStatus,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const getFileMessages = `-- name: GetFileMessages :many
-SELECT m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type, u.first_name as sender_first_name, u.last_name as sender_last_name, u.email as sender_email
+SELECT m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type, m.deleted_by, m.deletion_notice, m.search_vector, u.first_name as sender_first_name, u.last_name as sender_last_name, u.email as sender_email
 FROM message_files mf
 JOIN messages m ON mf.message_id = m.id
 JOIN users u ON m.sender_id = u.id
@@ -319,21 +355,24 @@ ORDER BY m.created_at DESC
 `
 
 type GetFileMessagesRow struct {
-	ID              int64         `json:"id"`
-	WorkspaceID     int64         `json:"workspace_id"`
-	ChannelID       sql.NullInt64 `json:"channel_id"`
-	SenderID        int64         `json:"sender_id"`
-	ReceiverID      sql.NullInt64 `json:"receiver_id"`
-	Content         string        `json:"content"`
-	MessageType     string        `json:"message_type"`
-	ThreadID        sql.NullInt64 `json:"thread_id"`
-	EditedAt        sql.NullTime  `json:"edited_at"`
-	DeletedAt       sql.NullTime  `json:"deleted_at"`
-	CreatedAt       time.Time     `json:"created_at"`
-	ContentType     string        `json:"content_type"`
-	SenderFirstName string        `json:"sender_first_name"`
-	SenderLastName  string        `json:"sender_last_name"`
-	SenderEmail     string        `json:"sender_email"`
+	ID              int64          `json:"id"`
+	WorkspaceID     int64          `json:"workspace_id"`
+	ChannelID       sql.NullInt64  `json:"channel_id"`
+	SenderID        int64          `json:"sender_id"`
+	ReceiverID      sql.NullInt64  `json:"receiver_id"`
+	Content         string         `json:"content"`
+	MessageType     string         `json:"message_type"`
+	ThreadID        sql.NullInt64  `json:"thread_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ContentType     string         `json:"content_type"`
+	DeletedBy       sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice  sql.NullString `json:"deletion_notice"`
+	SearchVector    interface{}    `json:"search_vector"`
+	SenderFirstName string         `json:"sender_first_name"`
+	SenderLastName  string         `json:"sender_last_name"`
+	SenderEmail     string         `json:"sender_email"`
 }
 
 func (q *Queries) GetFileMessages(ctx context.Context, fileID int64) ([]GetFileMessagesRow, error) {
@@ -358,6 +397,9 @@ func (q *Queries) GetFileMessages(ctx context.Context, fileID int64) ([]GetFileM
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.ContentType,
+			&i.DeletedBy,
+			&i.DeletionNotice,
+			&i.SearchVector,
 			&i.SenderFirstName,
 			&i.SenderLastName,
 			&i.SenderEmail,
@@ -439,7 +481,7 @@ SELECT
     COUNT(*) FILTER (WHERE mime_type LIKE 'image/%') as image_count,
     COUNT(*) FILTER (WHERE mime_type = 'application/pdf') as pdf_count
 FROM files 
-WHERE workspace_id = $1 AND upload_completed = true
+WHERE workspace_id = $1 AND upload_completed = true AND deleted_at IS NULL
 `
 
 type GetFileStatsRow struct {
@@ -462,10 +504,10 @@ func (q *Queries) GetFileStats(ctx context.Context, workspaceID int64) (GetFileS
 }
 
 const getFileWithPermissionCheck = `-- name: GetFileWithPermissionCheck :one
-SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
+SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, f.is_voice_message, f.duration_ms, f.waveform, f.processing_status, f.transcoded_path, f.poster_path, f.download_count, f.preview_status, f.deleted_at, f.deleted_by, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
 FROM files f
 JOIN users u ON f.uploader_id = u.id
-WHERE f.id = $1 AND f.workspace_id = $2 AND f.upload_completed = true
+WHERE f.id = $1 AND f.workspace_id = $2 AND f.upload_completed = true AND f.deleted_at IS NULL
 LIMIT 1
 `
 
@@ -489,6 +531,16 @@ type GetFileWithPermissionCheckRow struct {
 	ThumbnailPath     sql.NullString `json:"thumbnail_path"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
+	IsVoiceMessage    bool           `json:"is_voice_message"`
+	DurationMs        sql.NullInt32  `json:"duration_ms"`
+	Waveform          []int32        `json:"waveform"`
+	ProcessingStatus  sql.NullString `json:"processing_status"`
+	TranscodedPath    sql.NullString `json:"transcoded_path"`
+	PosterPath        sql.NullString `json:"poster_path"`
+	DownloadCount     int64          `json:"download_count"`
+	PreviewStatus     sql.NullString `json:"preview_status"`
+	DeletedAt         sql.NullTime   `json:"deleted_at"`
+	DeletedBy         sql.NullInt64  `json:"deleted_by"`
 	UploaderFirstName string         `json:"uploader_first_name"`
 	UploaderLastName  string         `json:"uploader_last_name"`
 	UploaderEmail     string         `json:"uploader_email"`
@@ -512,6 +564,16 @@ func (q *Queries) GetFileWithPermissionCheck(ctx context.Context, arg GetFileWit
 		&i.ThumbnailPath,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsVoiceMessage,
+		&i.DurationMs,
+		pq.Array(&i.Waveform),
+		&i.ProcessingStatus,
+		&i.TranscodedPath,
+		&i.PosterPath,
+		&i.DownloadCount,
+		&i.PreviewStatus,
+		&i.DeletedAt,
+		&i.DeletedBy,
 		&i.UploaderFirstName,
 		&i.UploaderLastName,
 		&i.UploaderEmail,
@@ -520,12 +582,12 @@ func (q *Queries) GetFileWithPermissionCheck(ctx context.Context, arg GetFileWit
 }
 
 const getMessageFiles = `-- name: GetMessageFiles :many
-SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
+SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, f.is_voice_message, f.duration_ms, f.waveform, f.processing_status, f.transcoded_path, f.poster_path, f.download_count, f.preview_status, f.deleted_at, f.deleted_by, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
 FROM message_files mf
 JOIN files f ON mf.file_id = f.id
 JOIN users u ON f.uploader_id = u.id
-WHERE mf.message_id = $1
-ORDER BY mf.created_at ASC
+WHERE mf.message_id = $1 AND f.deleted_at IS NULL
+ORDER BY mf.created_at ASC, mf.id ASC
 `
 
 type GetMessageFilesRow struct {
@@ -543,6 +605,16 @@ type GetMessageFilesRow struct {
 	ThumbnailPath     sql.NullString `json:"thumbnail_path"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
+	IsVoiceMessage    bool           `json:"is_voice_message"`
+	DurationMs        sql.NullInt32  `json:"duration_ms"`
+	Waveform          []int32        `json:"waveform"`
+	ProcessingStatus  sql.NullString `json:"processing_status"`
+	TranscodedPath    sql.NullString `json:"transcoded_path"`
+	PosterPath        sql.NullString `json:"poster_path"`
+	DownloadCount     int64          `json:"download_count"`
+	PreviewStatus     sql.NullString `json:"preview_status"`
+	DeletedAt         sql.NullTime   `json:"deleted_at"`
+	DeletedBy         sql.NullInt64  `json:"deleted_by"`
 	UploaderFirstName string         `json:"uploader_first_name"`
 	UploaderLastName  string         `json:"uploader_last_name"`
 	UploaderEmail     string         `json:"uploader_email"`
@@ -572,6 +644,456 @@ func (q *Queries) GetMessageFiles(ctx context.Context, messageID int64) ([]GetMe
 			&i.ThumbnailPath,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsVoiceMessage,
+			&i.DurationMs,
+			pq.Array(&i.Waveform),
+			&i.ProcessingStatus,
+			&i.TranscodedPath,
+			&i.PosterPath,
+			&i.DownloadCount,
+			&i.PreviewStatus,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.UploaderFirstName,
+			&i.UploaderLastName,
+			&i.UploaderEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTrashedFile = `-- name: GetTrashedFile :one
+SELECT id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at, is_voice_message, duration_ms, waveform, processing_status, transcoded_path, poster_path, download_count, preview_status, deleted_at, deleted_by FROM files
+WHERE id = $1 AND workspace_id = $2 AND deleted_at IS NOT NULL
+LIMIT 1
+`
+
+type GetTrashedFileParams struct {
+	ID          int64 `json:"id"`
+	WorkspaceID int64 `json:"workspace_id"`
+}
+
+func (q *Queries) GetTrashedFile(ctx context.Context, arg GetTrashedFileParams) (File, error) {
+	row := q.db.QueryRowContext(ctx, getTrashedFile, arg.ID, arg.WorkspaceID)
+	var i File
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.UploaderID,
+		&i.OriginalFilename,
+		&i.StoredFilename,
+		&i.FilePath,
+		&i.FileSize,
+		&i.MimeType,
+		&i.FileHash,
+		&i.IsPublic,
+		&i.UploadCompleted,
+		&i.ThumbnailPath,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsVoiceMessage,
+		&i.DurationMs,
+		pq.Array(&i.Waveform),
+		&i.ProcessingStatus,
+		&i.TranscodedPath,
+		&i.PosterPath,
+		&i.DownloadCount,
+		&i.PreviewStatus,
+		&i.DeletedAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
+const isFileInPrivateChannel = `-- name: IsFileInPrivateChannel :one
+SELECT EXISTS (
+    SELECT 1 FROM message_files mf
+    JOIN messages m ON m.id = mf.message_id
+    JOIN channels c ON c.id = m.channel_id
+    WHERE mf.file_id = $1 AND c.is_private = true
+)
+`
+
+// Whether the file is attached to a message in a private channel
+func (q *Queries) IsFileInPrivateChannel(ctx context.Context, fileID int64) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isFileInPrivateChannel, fileID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listFilesForReconciliation = `-- name: ListFilesForReconciliation :many
+SELECT id, file_path, mime_type, upload_completed, thumbnail_path, transcoded_path, poster_path, processing_status, preview_status
+FROM files
+WHERE id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListFilesForReconciliationParams struct {
+	AfterID int64 `json:"after_id"`
+	Limit   int32 `json:"limit"`
+}
+
+type ListFilesForReconciliationRow struct {
+	ID               int64          `json:"id"`
+	FilePath         string         `json:"file_path"`
+	MimeType         string         `json:"mime_type"`
+	UploadCompleted  bool           `json:"upload_completed"`
+	ThumbnailPath    sql.NullString `json:"thumbnail_path"`
+	TranscodedPath   sql.NullString `json:"transcoded_path"`
+	PosterPath       sql.NullString `json:"poster_path"`
+	ProcessingStatus sql.NullString `json:"processing_status"`
+	PreviewStatus    sql.NullString `json:"preview_status"`
+}
+
+// Pages through every file with the paths it keeps in the file store
+func (q *Queries) ListFilesForReconciliation(ctx context.Context, arg ListFilesForReconciliationParams) ([]ListFilesForReconciliationRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFilesForReconciliation, arg.AfterID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListFilesForReconciliationRow{}
+	for rows.Next() {
+		var i ListFilesForReconciliationRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FilePath,
+			&i.MimeType,
+			&i.UploadCompleted,
+			&i.ThumbnailPath,
+			&i.TranscodedPath,
+			&i.PosterPath,
+			&i.ProcessingStatus,
+			&i.PreviewStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingFilePreviews = `-- name: ListPendingFilePreviews :many
+SELECT id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at, is_voice_message, duration_ms, waveform, processing_status, transcoded_path, poster_path, download_count, preview_status, deleted_at, deleted_by FROM files
+WHERE preview_status = 'pending' AND deleted_at IS NULL
+ORDER BY created_at
+LIMIT $1
+`
+
+func (q *Queries) ListPendingFilePreviews(ctx context.Context, limit int32) ([]File, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingFilePreviews, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []File{}
+	for rows.Next() {
+		var i File
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.UploaderID,
+			&i.OriginalFilename,
+			&i.StoredFilename,
+			&i.FilePath,
+			&i.FileSize,
+			&i.MimeType,
+			&i.FileHash,
+			&i.IsPublic,
+			&i.UploadCompleted,
+			&i.ThumbnailPath,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsVoiceMessage,
+			&i.DurationMs,
+			pq.Array(&i.Waveform),
+			&i.ProcessingStatus,
+			&i.TranscodedPath,
+			&i.PosterPath,
+			&i.DownloadCount,
+			&i.PreviewStatus,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingVideoFiles = `-- name: ListPendingVideoFiles :many
+SELECT id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at, is_voice_message, duration_ms, waveform, processing_status, transcoded_path, poster_path, download_count, preview_status, deleted_at, deleted_by FROM files
+WHERE processing_status = 'pending' AND deleted_at IS NULL
+ORDER BY created_at
+LIMIT $1
+`
+
+func (q *Queries) ListPendingVideoFiles(ctx context.Context, limit int32) ([]File, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingVideoFiles, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []File{}
+	for rows.Next() {
+		var i File
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.UploaderID,
+			&i.OriginalFilename,
+			&i.StoredFilename,
+			&i.FilePath,
+			&i.FileSize,
+			&i.MimeType,
+			&i.FileHash,
+			&i.IsPublic,
+			&i.UploadCompleted,
+			&i.ThumbnailPath,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsVoiceMessage,
+			&i.DurationMs,
+			pq.Array(&i.Waveform),
+			&i.ProcessingStatus,
+			&i.TranscodedPath,
+			&i.PosterPath,
+			&i.DownloadCount,
+			&i.PreviewStatus,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableFiles = `-- name: ListPurgeableFiles :many
+SELECT id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at, is_voice_message, duration_ms, waveform, processing_status, transcoded_path, poster_path, download_count, preview_status, deleted_at, deleted_by FROM files
+WHERE deleted_at <= $1::timestamptz
+ORDER BY deleted_at ASC
+LIMIT $2
+`
+
+type ListPurgeableFilesParams struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Limit         int32     `json:"limit"`
+}
+
+func (q *Queries) ListPurgeableFiles(ctx context.Context, arg ListPurgeableFilesParams) ([]File, error) {
+	rows, err := q.db.QueryContext(ctx, listPurgeableFiles, arg.DeletedBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []File{}
+	for rows.Next() {
+		var i File
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.UploaderID,
+			&i.OriginalFilename,
+			&i.StoredFilename,
+			&i.FilePath,
+			&i.FileSize,
+			&i.MimeType,
+			&i.FileHash,
+			&i.IsPublic,
+			&i.UploadCompleted,
+			&i.ThumbnailPath,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsVoiceMessage,
+			&i.DurationMs,
+			pq.Array(&i.Waveform),
+			&i.ProcessingStatus,
+			&i.TranscodedPath,
+			&i.PosterPath,
+			&i.DownloadCount,
+			&i.PreviewStatus,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStoredFiles = `-- name: ListStoredFiles :many
+SELECT id, stored_filename, file_path, file_hash, thumbnail_path FROM files
+WHERE upload_completed = true
+ORDER BY id
+`
+
+type ListStoredFilesRow struct {
+	ID             int64          `json:"id"`
+	StoredFilename string         `json:"stored_filename"`
+	FilePath       string         `json:"file_path"`
+	FileHash       string         `json:"file_hash"`
+	ThumbnailPath  sql.NullString `json:"thumbnail_path"`
+}
+
+// Lists the files whose content is in the file store, for backups
+func (q *Queries) ListStoredFiles(ctx context.Context) ([]ListStoredFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStoredFiles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStoredFilesRow{}
+	for rows.Next() {
+		var i ListStoredFilesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StoredFilename,
+			&i.FilePath,
+			&i.FileHash,
+			&i.ThumbnailPath,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTrashedFiles = `-- name: ListTrashedFiles :many
+SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, f.is_voice_message, f.duration_ms, f.waveform, f.processing_status, f.transcoded_path, f.poster_path, f.download_count, f.preview_status, f.deleted_at, f.deleted_by, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
+FROM files f
+JOIN users u ON f.uploader_id = u.id
+WHERE f.workspace_id = $1
+    AND f.deleted_at > $2::timestamptz
+    AND ($3::bigint IS NULL OR f.uploader_id = $3)
+ORDER BY f.deleted_at DESC
+LIMIT $4 OFFSET $5
+`
+
+type ListTrashedFilesParams struct {
+	WorkspaceID  int64         `json:"workspace_id"`
+	DeletedAfter time.Time     `json:"deleted_after"`
+	UploaderID   sql.NullInt64 `json:"uploader_id"`
+	Limit        int32         `json:"limit"`
+	Offset       int32         `json:"offset"`
+}
+
+type ListTrashedFilesRow struct {
+	ID                int64          `json:"id"`
+	WorkspaceID       int64          `json:"workspace_id"`
+	UploaderID        int64          `json:"uploader_id"`
+	OriginalFilename  string         `json:"original_filename"`
+	StoredFilename    string         `json:"stored_filename"`
+	FilePath          string         `json:"file_path"`
+	FileSize          int64          `json:"file_size"`
+	MimeType          string         `json:"mime_type"`
+	FileHash          string         `json:"file_hash"`
+	IsPublic          bool           `json:"is_public"`
+	UploadCompleted   bool           `json:"upload_completed"`
+	ThumbnailPath     sql.NullString `json:"thumbnail_path"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	IsVoiceMessage    bool           `json:"is_voice_message"`
+	DurationMs        sql.NullInt32  `json:"duration_ms"`
+	Waveform          []int32        `json:"waveform"`
+	ProcessingStatus  sql.NullString `json:"processing_status"`
+	TranscodedPath    sql.NullString `json:"transcoded_path"`
+	PosterPath        sql.NullString `json:"poster_path"`
+	DownloadCount     int64          `json:"download_count"`
+	PreviewStatus     sql.NullString `json:"preview_status"`
+	DeletedAt         sql.NullTime   `json:"deleted_at"`
+	DeletedBy         sql.NullInt64  `json:"deleted_by"`
+	UploaderFirstName string         `json:"uploader_first_name"`
+	UploaderLastName  string         `json:"uploader_last_name"`
+	UploaderEmail     string         `json:"uploader_email"`
+}
+
+// Files in the trash, narrowed to one uploader's when an uploader is given
+func (q *Queries) ListTrashedFiles(ctx context.Context, arg ListTrashedFilesParams) ([]ListTrashedFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTrashedFiles,
+		arg.WorkspaceID,
+		arg.DeletedAfter,
+		arg.UploaderID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTrashedFilesRow{}
+	for rows.Next() {
+		var i ListTrashedFilesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.UploaderID,
+			&i.OriginalFilename,
+			&i.StoredFilename,
+			&i.FilePath,
+			&i.FileSize,
+			&i.MimeType,
+			&i.FileHash,
+			&i.IsPublic,
+			&i.UploadCompleted,
+			&i.ThumbnailPath,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsVoiceMessage,
+			&i.DurationMs,
+			pq.Array(&i.Waveform),
+			&i.ProcessingStatus,
+			&i.TranscodedPath,
+			&i.PosterPath,
+			&i.DownloadCount,
+			&i.PreviewStatus,
+			&i.DeletedAt,
+			&i.DeletedBy,
 			&i.UploaderFirstName,
 			&i.UploaderLastName,
 			&i.UploaderEmail,
@@ -590,10 +1112,10 @@ func (q *Queries) GetMessageFiles(ctx context.Context, messageID int64) ([]GetMe
 }
 
 const listUserFiles = `-- name: ListUserFiles :many
-SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
+SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, f.is_voice_message, f.duration_ms, f.waveform, f.processing_status, f.transcoded_path, f.poster_path, f.download_count, f.preview_status, f.deleted_at, f.deleted_by, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
 FROM files f
 JOIN users u ON f.uploader_id = u.id
-WHERE f.uploader_id = $1 AND f.workspace_id = $2 AND f.upload_completed = true
+WHERE f.uploader_id = $1 AND f.workspace_id = $2 AND f.upload_completed = true AND f.deleted_at IS NULL
 ORDER BY f.created_at DESC
 LIMIT $3 OFFSET $4
 `
@@ -620,6 +1142,16 @@ type ListUserFilesRow struct {
 	ThumbnailPath     sql.NullString `json:"thumbnail_path"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
+	IsVoiceMessage    bool           `json:"is_voice_message"`
+	DurationMs        sql.NullInt32  `json:"duration_ms"`
+	Waveform          []int32        `json:"waveform"`
+	ProcessingStatus  sql.NullString `json:"processing_status"`
+	TranscodedPath    sql.NullString `json:"transcoded_path"`
+	PosterPath        sql.NullString `json:"poster_path"`
+	DownloadCount     int64          `json:"download_count"`
+	PreviewStatus     sql.NullString `json:"preview_status"`
+	DeletedAt         sql.NullTime   `json:"deleted_at"`
+	DeletedBy         sql.NullInt64  `json:"deleted_by"`
 	UploaderFirstName string         `json:"uploader_first_name"`
 	UploaderLastName  string         `json:"uploader_last_name"`
 	UploaderEmail     string         `json:"uploader_email"`
@@ -654,6 +1186,16 @@ func (q *Queries) ListUserFiles(ctx context.Context, arg ListUserFilesParams) ([
 			&i.ThumbnailPath,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsVoiceMessage,
+			&i.DurationMs,
+			pq.Array(&i.Waveform),
+			&i.ProcessingStatus,
+			&i.TranscodedPath,
+			&i.PosterPath,
+			&i.DownloadCount,
+			&i.PreviewStatus,
+			&i.DeletedAt,
+			&i.DeletedBy,
 			&i.UploaderFirstName,
 			&i.UploaderLastName,
 			&i.UploaderEmail,
@@ -672,10 +1214,10 @@ func (q *Queries) ListUserFiles(ctx context.Context, arg ListUserFilesParams) ([
 }
 
 const listWorkspaceFiles = `-- name: ListWorkspaceFiles :many
-SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
+SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, f.is_voice_message, f.duration_ms, f.waveform, f.processing_status, f.transcoded_path, f.poster_path, f.download_count, f.preview_status, f.deleted_at, f.deleted_by, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email
 FROM files f
 JOIN users u ON f.uploader_id = u.id
-WHERE f.workspace_id = $1 AND f.upload_completed = true
+WHERE f.workspace_id = $1 AND f.upload_completed = true AND f.deleted_at IS NULL
 ORDER BY f.created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -701,6 +1243,16 @@ type ListWorkspaceFilesRow struct {
 	ThumbnailPath     sql.NullString `json:"thumbnail_path"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
+	IsVoiceMessage    bool           `json:"is_voice_message"`
+	DurationMs        sql.NullInt32  `json:"duration_ms"`
+	Waveform          []int32        `json:"waveform"`
+	ProcessingStatus  sql.NullString `json:"processing_status"`
+	TranscodedPath    sql.NullString `json:"transcoded_path"`
+	PosterPath        sql.NullString `json:"poster_path"`
+	DownloadCount     int64          `json:"download_count"`
+	PreviewStatus     sql.NullString `json:"preview_status"`
+	DeletedAt         sql.NullTime   `json:"deleted_at"`
+	DeletedBy         sql.NullInt64  `json:"deleted_by"`
 	UploaderFirstName string         `json:"uploader_first_name"`
 	UploaderLastName  string         `json:"uploader_last_name"`
 	UploaderEmail     string         `json:"uploader_email"`
@@ -730,9 +1282,227 @@ func (q *Queries) ListWorkspaceFiles(ctx context.Context, arg ListWorkspaceFiles
 			&i.ThumbnailPath,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsVoiceMessage,
+			&i.DurationMs,
+			pq.Array(&i.Waveform),
+			&i.ProcessingStatus,
+			&i.TranscodedPath,
+			&i.PosterPath,
+			&i.DownloadCount,
+			&i.PreviewStatus,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.UploaderFirstName,
+			&i.UploaderLastName,
+			&i.UploaderEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeFile = `-- name: PurgeFile :execrows
+DELETE FROM files
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+// Only files in the trash are purged
+func (q *Queries) PurgeFile(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeFile, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const requeueInterruptedFilePreviews = `-- name: RequeueInterruptedFilePreviews :exec
+UPDATE files
+SET preview_status = 'pending', updated_at = now()
+WHERE preview_status = 'processing'
+`
+
+// Documents left mid-render by a restart are picked up again
+func (q *Queries) RequeueInterruptedFilePreviews(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, requeueInterruptedFilePreviews)
+	return err
+}
+
+const requeueInterruptedFileProcessing = `-- name: RequeueInterruptedFileProcessing :exec
+UPDATE files
+SET processing_status = 'pending', updated_at = now()
+WHERE processing_status = 'processing'
+`
+
+// Files left mid-transcode by a restart are picked up again
+func (q *Queries) RequeueInterruptedFileProcessing(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, requeueInterruptedFileProcessing)
+	return err
+}
+
+const restoreFile = `-- name: RestoreFile :one
+UPDATE files
+SET
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE id = $1
+    AND workspace_id = $2
+    AND deleted_at > $3::timestamptz
+RETURNING id, workspace_id, uploader_id, original_filename, stored_filename, file_path, file_size, mime_type, file_hash, is_public, upload_completed, thumbnail_path, created_at, updated_at, is_voice_message, duration_ms, waveform, processing_status, transcoded_path, poster_path, download_count, preview_status, deleted_at, deleted_by
+`
+
+type RestoreFileParams struct {
+	ID           int64     `json:"id"`
+	WorkspaceID  int64     `json:"workspace_id"`
+	DeletedAfter time.Time `json:"deleted_after"`
+}
+
+// Only files still inside the trash retention can be restored
+func (q *Queries) RestoreFile(ctx context.Context, arg RestoreFileParams) (File, error) {
+	row := q.db.QueryRowContext(ctx, restoreFile, arg.ID, arg.WorkspaceID, arg.DeletedAfter)
+	var i File
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.UploaderID,
+		&i.OriginalFilename,
+		&i.StoredFilename,
+		&i.FilePath,
+		&i.FileSize,
+		&i.MimeType,
+		&i.FileHash,
+		&i.IsPublic,
+		&i.UploadCompleted,
+		&i.ThumbnailPath,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsVoiceMessage,
+		&i.DurationMs,
+		pq.Array(&i.Waveform),
+		&i.ProcessingStatus,
+		&i.TranscodedPath,
+		&i.PosterPath,
+		&i.DownloadCount,
+		&i.PreviewStatus,
+		&i.DeletedAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
+const searchFiles = `-- name: SearchFiles :many
+SELECT f.id, f.workspace_id, f.uploader_id, f.original_filename, f.stored_filename, f.file_path, f.file_size, f.mime_type, f.file_hash, f.is_public, f.upload_completed, f.thumbnail_path, f.created_at, f.updated_at, f.is_voice_message, f.duration_ms, f.waveform, f.processing_status, f.transcoded_path, f.poster_path, f.download_count, f.preview_status, f.deleted_at, f.deleted_by, u.first_name as uploader_first_name, u.last_name as uploader_last_name, u.email as uploader_email,
+    COUNT(*) OVER() as total_count
+FROM files f
+JOIN users u ON f.uploader_id = u.id
+WHERE f.workspace_id = $1
+    AND f.upload_completed = true
+    AND f.deleted_at IS NULL
+    AND f.original_filename ILIKE '%' || $2::text || '%'
+    AND (
+        f.uploader_id = $3
+        OR f.is_public = true
+        OR EXISTS (
+            SELECT 1 FROM file_shares fs
+            LEFT JOIN channel_members cm ON fs.channel_id = cm.channel_id
+            WHERE fs.file_id = f.id
+            AND (fs.shared_with_user_id = $3 OR cm.user_id = $3)
+            AND (fs.expires_at IS NULL OR fs.expires_at > now())
+        )
+    )
+ORDER BY f.created_at DESC
+LIMIT $4
+`
+
+type SearchFilesParams struct {
+	WorkspaceID int64  `json:"workspace_id"`
+	Query       string `json:"query"`
+	UserID      int64  `json:"user_id"`
+	Limit       int32  `json:"limit"`
+}
+
+type SearchFilesRow struct {
+	ID                int64          `json:"id"`
+	WorkspaceID       int64          `json:"workspace_id"`
+	UploaderID        int64          `json:"uploader_id"`
+	OriginalFilename  string         `json:"original_filename"`
+	StoredFilename    string         `json:"stored_filename"`
+	FilePath          string         `json:"file_path"`
+	FileSize          int64          `json:"file_size"`
+	MimeType          string         `json:"mime_type"`
+	FileHash          string         `json:"file_hash"`
+	IsPublic          bool           `json:"is_public"`
+	UploadCompleted   bool           `json:"upload_completed"`
+	ThumbnailPath     sql.NullString `json:"thumbnail_path"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	IsVoiceMessage    bool           `json:"is_voice_message"`
+	DurationMs        sql.NullInt32  `json:"duration_ms"`
+	Waveform          []int32        `json:"waveform"`
+	ProcessingStatus  sql.NullString `json:"processing_status"`
+	TranscodedPath    sql.NullString `json:"transcoded_path"`
+	PosterPath        sql.NullString `json:"poster_path"`
+	DownloadCount     int64          `json:"download_count"`
+	PreviewStatus     sql.NullString `json:"preview_status"`
+	DeletedAt         sql.NullTime   `json:"deleted_at"`
+	DeletedBy         sql.NullInt64  `json:"deleted_by"`
+	UploaderFirstName string         `json:"uploader_first_name"`
+	UploaderLastName  string         `json:"uploader_last_name"`
+	UploaderEmail     string         `json:"uploader_email"`
+	TotalCount        int64          `json:"total_count"`
+}
+
+// Only files the user can access through ownership, public visibility or a share are returned
+func (q *Queries) SearchFiles(ctx context.Context, arg SearchFilesParams) ([]SearchFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchFiles,
+		arg.WorkspaceID,
+		arg.Query,
+		arg.UserID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchFilesRow{}
+	for rows.Next() {
+		var i SearchFilesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.UploaderID,
+			&i.OriginalFilename,
+			&i.StoredFilename,
+			&i.FilePath,
+			&i.FileSize,
+			&i.MimeType,
+			&i.FileHash,
+			&i.IsPublic,
+			&i.UploadCompleted,
+			&i.ThumbnailPath,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsVoiceMessage,
+			&i.DurationMs,
+			pq.Array(&i.Waveform),
+			&i.ProcessingStatus,
+			&i.TranscodedPath,
+			&i.PosterPath,
+			&i.DownloadCount,
+			&i.PreviewStatus,
+			&i.DeletedAt,
+			&i.DeletedBy,
 			&i.UploaderFirstName,
 			&i.UploaderLastName,
 			&i.UploaderEmail,
+			&i.TotalCount,
 		); err != nil {
 			return nil, err
 		}
@@ -747,6 +1517,67 @@ func (q *Queries) ListWorkspaceFiles(ctx context.Context, arg ListWorkspaceFiles
 	return items, nil
 }
 
+const trashFile = `-- name: TrashFile :execrows
+UPDATE files
+SET
+    deleted_at = now(),
+    deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type TrashFileParams struct {
+	ID        int64         `json:"id"`
+	DeletedBy sql.NullInt64 `json:"deleted_by"`
+}
+
+func (q *Queries) TrashFile(ctx context.Context, arg TrashFileParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashFile, arg.ID, arg.DeletedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateFilePreview = `-- name: UpdateFilePreview :exec
+UPDATE files
+SET preview_status = $2, thumbnail_path = $3, updated_at = now()
+WHERE id = $1
+`
+
+type UpdateFilePreviewParams struct {
+	ID            int64          `json:"id"`
+	PreviewStatus sql.NullString `json:"preview_status"`
+	ThumbnailPath sql.NullString `json:"thumbnail_path"`
+}
+
+func (q *Queries) UpdateFilePreview(ctx context.Context, arg UpdateFilePreviewParams) error {
+	_, err := q.db.ExecContext(ctx, updateFilePreview, arg.ID, arg.PreviewStatus, arg.ThumbnailPath)
+	return err
+}
+
+const updateFileProcessing = `-- name: UpdateFileProcessing :exec
+UPDATE files
+SET processing_status = $2, transcoded_path = $3, poster_path = $4, updated_at = now()
+WHERE id = $1
+`
+
+type UpdateFileProcessingParams struct {
+	ID               int64          `json:"id"`
+	ProcessingStatus sql.NullString `json:"processing_status"`
+	TranscodedPath   sql.NullString `json:"transcoded_path"`
+	PosterPath       sql.NullString `json:"poster_path"`
+}
+
+func (q *Queries) UpdateFileProcessing(ctx context.Context, arg UpdateFileProcessingParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileProcessing,
+		arg.ID,
+		arg.ProcessingStatus,
+		arg.TranscodedPath,
+		arg.PosterPath,
+	)
+	return err
+}
+
 const updateFileThumbnail = `-- name: UpdateFileThumbnail :exec
 UPDATE files
 SET thumbnail_path = $2, updated_at = now()
@@ -778,3 +1609,20 @@ func (q *Queries) UpdateFileUploadStatus(ctx context.Context, arg UpdateFileUplo
 	_, err := q.db.ExecContext(ctx, updateFileUploadStatus, arg.ID, arg.UploadCompleted)
 	return err
 }
+
+const updateFileVoiceMetadata = `-- name: UpdateFileVoiceMetadata :exec
+UPDATE files
+SET is_voice_message = true, duration_ms = $2, waveform = $3, updated_at = now()
+WHERE id = $1
+`
+
+type UpdateFileVoiceMetadataParams struct {
+	ID         int64         `json:"id"`
+	DurationMs sql.NullInt32 `json:"duration_ms"`
+	Waveform   []int32       `json:"waveform"`
+}
+
+func (q *Queries) UpdateFileVoiceMetadata(ctx context.Context, arg UpdateFileVoiceMetadataParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileVoiceMetadata, arg.ID, arg.DurationMs, pq.Array(arg.Waveform))
+	return err
+}
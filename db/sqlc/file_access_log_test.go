@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordFileDownload(t *testing.T) {
+	file := createRandomFile(t)
+	downloader := createRandomUser(t)
+
+	for _, ip := range []string{"203.0.113.9", "198.51.100.4"} {
+		err := testQueries.RecordFileDownload(context.Background(), RecordFileDownloadParams{
+			FileID:    file.ID,
+			UserID:    sql.NullInt64{Int64: downloader.ID, Valid: true},
+			IpAddress: ip,
+			UserAgent: "curl/8.5.0",
+		})
+		require.NoError(t, err)
+	}
+
+	updated, err := testQueries.GetFile(context.Background(), file.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated.DownloadCount)
+
+	entries, err := testQueries.ListFileAccessLog(context.Background(), ListFileAccessLogParams{
+		FileID: file.ID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// Most recent first
+	require.Equal(t, "198.51.100.4", entries[0].IpAddress)
+	require.Equal(t, "203.0.113.9", entries[1].IpAddress)
+	for _, entry := range entries {
+		require.Equal(t, downloader.ID, entry.UserID.Int64)
+		require.Equal(t, downloader.Email, entry.Email)
+		require.Equal(t, downloader.FirstName, entry.FirstName)
+	}
+}
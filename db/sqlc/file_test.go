@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -209,6 +210,77 @@ func TestDeleteFile(t *testing.T) {
 	require.Empty(t, file2)
 }
 
+func TestTrashAndRestoreFile(t *testing.T) {
+	file := createRandomFile(t)
+
+	rows, err := testQueries.TrashFile(context.Background(), TrashFileParams{
+		ID:        file.ID,
+		DeletedBy: sql.NullInt64{Int64: file.UploaderID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	// Trashed files are hidden from lookups but can still be listed
+	_, err = testQueries.GetFile(context.Background(), file.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	trashed, err := testQueries.ListTrashedFiles(context.Background(), ListTrashedFilesParams{
+		WorkspaceID:  file.WorkspaceID,
+		DeletedAfter: time.Now().Add(-time.Hour),
+		UploaderID:   sql.NullInt64{Int64: file.UploaderID, Valid: true},
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	require.Equal(t, file.ID, trashed[0].ID)
+	require.Equal(t, file.UploaderID, trashed[0].DeletedBy.Int64)
+
+	// Outside the retention the file can't be restored
+	_, err = testQueries.RestoreFile(context.Background(), RestoreFileParams{
+		ID:           file.ID,
+		WorkspaceID:  file.WorkspaceID,
+		DeletedAfter: time.Now().Add(time.Minute),
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	restored, err := testQueries.RestoreFile(context.Background(), RestoreFileParams{
+		ID:           file.ID,
+		WorkspaceID:  file.WorkspaceID,
+		DeletedAfter: time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.False(t, restored.DeletedAt.Valid)
+
+	_, err = testQueries.GetFile(context.Background(), file.ID)
+	require.NoError(t, err)
+}
+
+func TestPurgeFile(t *testing.T) {
+	file := createRandomFile(t)
+
+	// Files outside the trash aren't purged
+	rows, err := testQueries.PurgeFile(context.Background(), file.ID)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	_, err = testQueries.TrashFile(context.Background(), TrashFileParams{ID: file.ID})
+	require.NoError(t, err)
+
+	purgeable, err := testQueries.ListPurgeableFiles(context.Background(), ListPurgeableFilesParams{
+		DeletedBefore: time.Now().Add(time.Minute),
+		Limit:         1000,
+	})
+	require.NoError(t, err)
+	require.True(t, slices.ContainsFunc(purgeable, func(f File) bool { return f.ID == file.ID }))
+
+	rows, err = testQueries.PurgeFile(context.Background(), file.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = testQueries.GetTrashedFile(context.Background(), GetTrashedFileParams{ID: file.ID, WorkspaceID: file.WorkspaceID})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
 func TestGetFileWithPermissionCheck(t *testing.T) {
 	file1 := createRandomFile(t)
 
@@ -345,7 +417,7 @@ func TestCleanupIncompleteUploads(t *testing.T) {
 	require.NoError(t, err)
 
 	// Run cleanup (this would normally clean files older than 1 hour)
-	err = testQueries.CleanupIncompleteUploads(context.Background())
+	_, err = testQueries.CleanupIncompleteUploads(context.Background())
 	require.NoError(t, err)
 
 	// File should still exist since it was just created
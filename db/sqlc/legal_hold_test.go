@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegalHolds(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	createRandomChannelMessage(t, workspace, channel, user)
+
+	arg := CreateLegalHoldParams{
+		OrganizationID: workspace.OrganizationID,
+		TargetType:     "user",
+		TargetID:       user.ID,
+		Reason:         "Pending litigation",
+		CreatedBy:      sql.NullInt64{Int64: user.ID, Valid: true},
+	}
+
+	hold, err := testQueries.CreateLegalHold(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, hold.ReleasedAt.Valid)
+
+	// Only one active hold per target
+	_, err = testQueries.CreateLegalHold(context.Background(), arg)
+	require.Error(t, err)
+
+	held, err := testQueries.HasActiveLegalHold(context.Background(), HasActiveLegalHoldParams{TargetType: "user", TargetID: user.ID})
+	require.NoError(t, err)
+	require.True(t, held)
+
+	// The held user's messages keep the channel, workspace and organization from being deleted
+	held, err = testQueries.ChannelHasActiveLegalHold(context.Background(), channel.ID)
+	require.NoError(t, err)
+	require.True(t, held)
+
+	held, err = testQueries.WorkspaceHasActiveLegalHold(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.True(t, held)
+
+	held, err = testQueries.OrganizationHasActiveLegalHold(context.Background(), workspace.OrganizationID)
+	require.NoError(t, err)
+	require.True(t, held)
+
+	messages, err := testQueries.ListLegalHoldMessages(context.Background(), ListLegalHoldMessagesParams{
+		TargetType: "user",
+		TargetID:   user.ID,
+		Limit:      10,
+	})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	released, err := testQueries.ReleaseLegalHold(context.Background(), ReleaseLegalHoldParams{
+		ID:             hold.ID,
+		OrganizationID: workspace.OrganizationID,
+		ReleasedBy:     sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.True(t, released.ReleasedAt.Valid)
+
+	held, err = testQueries.ChannelHasActiveLegalHold(context.Background(), channel.ID)
+	require.NoError(t, err)
+	require.False(t, held)
+
+	active, err := testQueries.ListLegalHolds(context.Background(), ListLegalHoldsParams{
+		OrganizationID: workspace.OrganizationID,
+		ActiveOnly:     true,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, active)
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAudit(t *testing.T) {
+	user := createRandomUser(t)
+
+	device, err := testQueries.UpsertUserDevice(context.Background(), UpsertUserDeviceParams{
+		UserID:        user.ID,
+		Fingerprint:   "fingerprint-1",
+		UserAgent:     "Firefox",
+		LastIpAddress: "10.0.0.1",
+	})
+	require.NoError(t, err)
+	require.False(t, device.TrustedUntil.Valid)
+
+	// Signing in from the same device again updates it
+	again, err := testQueries.UpsertUserDevice(context.Background(), UpsertUserDeviceParams{
+		UserID:        user.ID,
+		Fingerprint:   "fingerprint-1",
+		UserAgent:     "Firefox 2",
+		LastIpAddress: "10.0.0.2",
+	})
+	require.NoError(t, err)
+	require.Equal(t, device.ID, again.ID)
+	require.Equal(t, "10.0.0.2", again.LastIpAddress)
+
+	trustedUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	updated, err := testQueries.UpdateUserDevice(context.Background(), UpdateUserDeviceParams{
+		ID:           device.ID,
+		UserID:       user.ID,
+		Name:         "Work laptop",
+		TrustedUntil: sql.NullTime{Time: trustedUntil, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Work laptop", updated.Name)
+	require.WithinDuration(t, trustedUntil, updated.TrustedUntil.Time, time.Second)
+
+	_, err = testQueries.CreateLoginEvent(context.Background(), CreateLoginEventParams{
+		UserID:        sql.NullInt64{Int64: user.ID, Valid: true},
+		Email:         user.Email,
+		Fingerprint:   "fingerprint-2",
+		Success:       false,
+		FailureReason: "incorrect_password",
+	})
+	require.NoError(t, err)
+	_, err = testQueries.CreateLoginEvent(context.Background(), CreateLoginEventParams{
+		UserID:      sql.NullInt64{Int64: user.ID, Valid: true},
+		Email:       user.Email,
+		DeviceID:    sql.NullInt64{Int64: device.ID, Valid: true},
+		Fingerprint: "fingerprint-1",
+		Success:     true,
+	})
+	require.NoError(t, err)
+
+	events, err := testQueries.ListLoginEvents(context.Background(), ListLoginEventsParams{
+		UserID: sql.NullInt64{Int64: user.ID, Valid: true},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.True(t, events[0].Success)
+	require.Equal(t, "Work laptop", events[0].DeviceName)
+	require.Empty(t, events[1].DeviceName)
+
+	// Devices can only be revoked by their own user
+	rows, err := testQueries.DeleteUserDevice(context.Background(), DeleteUserDeviceParams{ID: device.ID, UserID: user.ID + 1})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testQueries.DeleteUserDevice(context.Background(), DeleteUserDeviceParams{ID: device.ID, UserID: user.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	devices, err := testQueries.ListUserDevices(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, devices)
+
+	// Revoking a device keeps its logins in the audit
+	events, err = testQueries.ListLoginEvents(context.Background(), ListLoginEventsParams{
+		UserID: sql.NullInt64{Int64: user.ID, Valid: true},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.False(t, events[0].DeviceID.Valid)
+}
@@ -23,6 +23,50 @@ func (q *Queries) CheckMessageAuthor(ctx context.Context, id int64) (int64, erro
 	return sender_id, err
 }
 
+const countChannelMessages = `-- name: CountChannelMessages :one
+SELECT COUNT(*) FROM messages
+WHERE channel_id = $1
+    AND workspace_id = $2
+    AND (deleted_at IS NULL OR $3::boolean)
+`
+
+type CountChannelMessagesParams struct {
+	ChannelID      sql.NullInt64 `json:"channel_id"`
+	WorkspaceID    int64         `json:"workspace_id"`
+	IncludeDeleted bool          `json:"include_deleted"`
+}
+
+func (q *Queries) CountChannelMessages(ctx context.Context, arg CountChannelMessagesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countChannelMessages, arg.ChannelID, arg.WorkspaceID, arg.IncludeDeleted)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countDirectMessagesBetweenUsers = `-- name: CountDirectMessagesBetweenUsers :one
+SELECT COUNT(*) FROM messages
+WHERE workspace_id = $1
+    AND message_type = 'direct'
+    AND deleted_at IS NULL
+    AND (
+        (sender_id = $2 AND receiver_id = $3) OR
+        (sender_id = $3 AND receiver_id = $2)
+    )
+`
+
+type CountDirectMessagesBetweenUsersParams struct {
+	WorkspaceID int64         `json:"workspace_id"`
+	SenderID    int64         `json:"sender_id"`
+	ReceiverID  sql.NullInt64 `json:"receiver_id"`
+}
+
+func (q *Queries) CountDirectMessagesBetweenUsers(ctx context.Context, arg CountDirectMessagesBetweenUsersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countDirectMessagesBetweenUsers, arg.WorkspaceID, arg.SenderID, arg.ReceiverID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createChannelMessage = `-- name: CreateChannelMessage :one
 INSERT INTO messages (
     workspace_id,
@@ -34,7 +78,7 @@ INSERT INTO messages (
 ) VALUES (
     $1, $2, $3, $4, $5, 'channel'
 )
-RETURNING id, workspace_id, channel_id, sender_id, receiver_id, content, message_type, thread_id, edited_at, deleted_at, created_at, content_type
+RETURNING id, workspace_id, channel_id, sender_id, receiver_id, content, message_type, thread_id, edited_at, deleted_at, created_at, content_type, deleted_by, deletion_notice, search_vector
 `
 
 type CreateChannelMessageParams struct {
@@ -67,6 +111,9 @@ func (q *Queries) CreateChannelMessage(ctx context.Context, arg CreateChannelMes
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.ContentType,
+		&i.DeletedBy,
+		&i.DeletionNotice,
+		&i.SearchVector,
 	)
 	return i, err
 }
@@ -82,7 +129,7 @@ INSERT INTO messages (
 ) VALUES (
     $1, $2, $3, $4, $5, 'direct'
 )
-RETURNING id, workspace_id, channel_id, sender_id, receiver_id, content, message_type, thread_id, edited_at, deleted_at, created_at, content_type
+RETURNING id, workspace_id, channel_id, sender_id, receiver_id, content, message_type, thread_id, edited_at, deleted_at, created_at, content_type, deleted_by, deletion_notice, search_vector
 `
 
 type CreateDirectMessageParams struct {
@@ -115,55 +162,126 @@ func (q *Queries) CreateDirectMessage(ctx context.Context, arg CreateDirectMessa
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.ContentType,
+		&i.DeletedBy,
+		&i.DeletionNotice,
+		&i.SearchVector,
+	)
+	return i, err
+}
+
+const createMessageAt = `-- name: CreateMessageAt :one
+INSERT INTO messages (
+    workspace_id,
+    channel_id,
+    sender_id,
+    receiver_id,
+    content,
+    content_type,
+    message_type,
+    thread_id,
+    created_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, workspace_id, channel_id, sender_id, receiver_id, content, message_type, thread_id, edited_at, deleted_at, created_at, content_type, deleted_by, deletion_notice, search_vector
+`
+
+type CreateMessageAtParams struct {
+	WorkspaceID int64         `json:"workspace_id"`
+	ChannelID   sql.NullInt64 `json:"channel_id"`
+	SenderID    int64         `json:"sender_id"`
+	ReceiverID  sql.NullInt64 `json:"receiver_id"`
+	Content     string        `json:"content"`
+	ContentType string        `json:"content_type"`
+	MessageType string        `json:"message_type"`
+	ThreadID    sql.NullInt64 `json:"thread_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// Creates a message with its original timestamp, for importing or seeding history
+func (q *Queries) CreateMessageAt(ctx context.Context, arg CreateMessageAtParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, createMessageAt,
+		arg.WorkspaceID,
+		arg.ChannelID,
+		arg.SenderID,
+		arg.ReceiverID,
+		arg.Content,
+		arg.ContentType,
+		arg.MessageType,
+		arg.ThreadID,
+		arg.CreatedAt,
+	)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ChannelID,
+		&i.SenderID,
+		&i.ReceiverID,
+		&i.Content,
+		&i.MessageType,
+		&i.ThreadID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.CreatedAt,
+		&i.ContentType,
+		&i.DeletedBy,
+		&i.DeletionNotice,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
 const getChannelMessages = `-- name: GetChannelMessages :many
 SELECT 
-    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type,
+    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type, m.deleted_by, m.deletion_notice, m.search_vector,
     u.first_name as sender_first_name,
     u.last_name as sender_last_name,
     u.email as sender_email
 FROM messages m
 JOIN users u ON m.sender_id = u.id
-WHERE m.channel_id = $1 
-    AND m.workspace_id = $2 
-    AND m.deleted_at IS NULL
+WHERE m.channel_id = $1
+    AND m.workspace_id = $2
+    AND (m.deleted_at IS NULL OR $3::boolean)
 ORDER BY m.created_at DESC
-LIMIT $3
-OFFSET $4
+LIMIT $4
+OFFSET $5
 `
 
 type GetChannelMessagesParams struct {
-	ChannelID   sql.NullInt64 `json:"channel_id"`
-	WorkspaceID int64         `json:"workspace_id"`
-	Limit       int32         `json:"limit"`
-	Offset      int32         `json:"offset"`
+	ChannelID      sql.NullInt64 `json:"channel_id"`
+	WorkspaceID    int64         `json:"workspace_id"`
+	IncludeDeleted bool          `json:"include_deleted"`
+	Limit          int32         `json:"limit"`
+	Offset         int32         `json:"offset"`
 }
 
 type GetChannelMessagesRow struct {
-	ID              int64         `json:"id"`
-	WorkspaceID     int64         `json:"workspace_id"`
-	ChannelID       sql.NullInt64 `json:"channel_id"`
-	SenderID        int64         `json:"sender_id"`
-	ReceiverID      sql.NullInt64 `json:"receiver_id"`
-	Content         string        `json:"content"`
-	MessageType     string        `json:"message_type"`
-	ThreadID        sql.NullInt64 `json:"thread_id"`
-	EditedAt        sql.NullTime  `json:"edited_at"`
-	DeletedAt       sql.NullTime  `json:"deleted_at"`
-	CreatedAt       time.Time     `json:"created_at"`
-	ContentType     string        `json:"content_type"`
-	SenderFirstName string        `json:"sender_first_name"`
-	SenderLastName  string        `json:"sender_last_name"`
-	SenderEmail     string        `json:"sender_email"`
+	ID              int64          `json:"id"`
+	WorkspaceID     int64          `json:"workspace_id"`
+	ChannelID       sql.NullInt64  `json:"channel_id"`
+	SenderID        int64          `json:"sender_id"`
+	ReceiverID      sql.NullInt64  `json:"receiver_id"`
+	Content         string         `json:"content"`
+	MessageType     string         `json:"message_type"`
+	ThreadID        sql.NullInt64  `json:"thread_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ContentType     string         `json:"content_type"`
+	DeletedBy       sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice  sql.NullString `json:"deletion_notice"`
+	SearchVector    interface{}    `json:"search_vector"`
+	SenderFirstName string         `json:"sender_first_name"`
+	SenderLastName  string         `json:"sender_last_name"`
+	SenderEmail     string         `json:"sender_email"`
 }
 
 func (q *Queries) GetChannelMessages(ctx context.Context, arg GetChannelMessagesParams) ([]GetChannelMessagesRow, error) {
 	rows, err := q.db.QueryContext(ctx, getChannelMessages,
 		arg.ChannelID,
 		arg.WorkspaceID,
+		arg.IncludeDeleted,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -187,6 +305,9 @@ func (q *Queries) GetChannelMessages(ctx context.Context, arg GetChannelMessages
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.ContentType,
+			&i.DeletedBy,
+			&i.DeletionNotice,
+			&i.SearchVector,
 			&i.SenderFirstName,
 			&i.SenderLastName,
 			&i.SenderEmail,
@@ -206,7 +327,7 @@ func (q *Queries) GetChannelMessages(ctx context.Context, arg GetChannelMessages
 
 const getDirectMessagesBetweenUsers = `-- name: GetDirectMessagesBetweenUsers :many
 SELECT 
-    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type,
+    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type, m.deleted_by, m.deletion_notice, m.search_vector,
     u.first_name as sender_first_name,
     u.last_name as sender_last_name,
     u.email as sender_email
@@ -233,21 +354,24 @@ type GetDirectMessagesBetweenUsersParams struct {
 }
 
 type GetDirectMessagesBetweenUsersRow struct {
-	ID              int64         `json:"id"`
-	WorkspaceID     int64         `json:"workspace_id"`
-	ChannelID       sql.NullInt64 `json:"channel_id"`
-	SenderID        int64         `json:"sender_id"`
-	ReceiverID      sql.NullInt64 `json:"receiver_id"`
-	Content         string        `json:"content"`
-	MessageType     string        `json:"message_type"`
-	ThreadID        sql.NullInt64 `json:"thread_id"`
-	EditedAt        sql.NullTime  `json:"edited_at"`
-	DeletedAt       sql.NullTime  `json:"deleted_at"`
-	CreatedAt       time.Time     `json:"created_at"`
-	ContentType     string        `json:"content_type"`
-	SenderFirstName string        `json:"sender_first_name"`
-	SenderLastName  string        `json:"sender_last_name"`
-	SenderEmail     string        `json:"sender_email"`
+	ID              int64          `json:"id"`
+	WorkspaceID     int64          `json:"workspace_id"`
+	ChannelID       sql.NullInt64  `json:"channel_id"`
+	SenderID        int64          `json:"sender_id"`
+	ReceiverID      sql.NullInt64  `json:"receiver_id"`
+	Content         string         `json:"content"`
+	MessageType     string         `json:"message_type"`
+	ThreadID        sql.NullInt64  `json:"thread_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ContentType     string         `json:"content_type"`
+	DeletedBy       sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice  sql.NullString `json:"deletion_notice"`
+	SearchVector    interface{}    `json:"search_vector"`
+	SenderFirstName string         `json:"sender_first_name"`
+	SenderLastName  string         `json:"sender_last_name"`
+	SenderEmail     string         `json:"sender_email"`
 }
 
 func (q *Queries) GetDirectMessagesBetweenUsers(ctx context.Context, arg GetDirectMessagesBetweenUsersParams) ([]GetDirectMessagesBetweenUsersRow, error) {
@@ -278,6 +402,9 @@ func (q *Queries) GetDirectMessagesBetweenUsers(ctx context.Context, arg GetDire
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.ContentType,
+			&i.DeletedBy,
+			&i.DeletionNotice,
+			&i.SearchVector,
 			&i.SenderFirstName,
 			&i.SenderLastName,
 			&i.SenderEmail,
@@ -297,7 +424,7 @@ func (q *Queries) GetDirectMessagesBetweenUsers(ctx context.Context, arg GetDire
 
 const getMessageByID = `-- name: GetMessageByID :one
 SELECT 
-    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type,
+    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type, m.deleted_by, m.deletion_notice, m.search_vector,
     u.first_name as sender_first_name,
     u.last_name as sender_last_name,
     u.email as sender_email
@@ -307,21 +434,24 @@ WHERE m.id = $1 AND m.deleted_at IS NULL
 `
 
 type GetMessageByIDRow struct {
-	ID              int64         `json:"id"`
-	WorkspaceID     int64         `json:"workspace_id"`
-	ChannelID       sql.NullInt64 `json:"channel_id"`
-	SenderID        int64         `json:"sender_id"`
-	ReceiverID      sql.NullInt64 `json:"receiver_id"`
-	Content         string        `json:"content"`
-	MessageType     string        `json:"message_type"`
-	ThreadID        sql.NullInt64 `json:"thread_id"`
-	EditedAt        sql.NullTime  `json:"edited_at"`
-	DeletedAt       sql.NullTime  `json:"deleted_at"`
-	CreatedAt       time.Time     `json:"created_at"`
-	ContentType     string        `json:"content_type"`
-	SenderFirstName string        `json:"sender_first_name"`
-	SenderLastName  string        `json:"sender_last_name"`
-	SenderEmail     string        `json:"sender_email"`
+	ID              int64          `json:"id"`
+	WorkspaceID     int64          `json:"workspace_id"`
+	ChannelID       sql.NullInt64  `json:"channel_id"`
+	SenderID        int64          `json:"sender_id"`
+	ReceiverID      sql.NullInt64  `json:"receiver_id"`
+	Content         string         `json:"content"`
+	MessageType     string         `json:"message_type"`
+	ThreadID        sql.NullInt64  `json:"thread_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ContentType     string         `json:"content_type"`
+	DeletedBy       sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice  sql.NullString `json:"deletion_notice"`
+	SearchVector    interface{}    `json:"search_vector"`
+	SenderFirstName string         `json:"sender_first_name"`
+	SenderLastName  string         `json:"sender_last_name"`
+	SenderEmail     string         `json:"sender_email"`
 }
 
 func (q *Queries) GetMessageByID(ctx context.Context, id int64) (GetMessageByIDRow, error) {
@@ -340,6 +470,9 @@ func (q *Queries) GetMessageByID(ctx context.Context, id int64) (GetMessageByIDR
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.ContentType,
+		&i.DeletedBy,
+		&i.DeletionNotice,
+		&i.SearchVector,
 		&i.SenderFirstName,
 		&i.SenderLastName,
 		&i.SenderEmail,
@@ -347,9 +480,235 @@ func (q *Queries) GetMessageByID(ctx context.Context, id int64) (GetMessageByIDR
 	return i, err
 }
 
+const getMessagesAfter = `-- name: GetMessagesAfter :many
+SELECT 
+    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type, m.deleted_by, m.deletion_notice, m.search_vector,
+    u.first_name as sender_first_name,
+    u.last_name as sender_last_name,
+    u.email as sender_email
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+WHERE m.workspace_id = $1
+    AND (m.created_at, m.id) > ($2::timestamptz, $3::bigint)
+    AND (
+        ($4::bigint IS NOT NULL AND (m.thread_id = $4::bigint OR m.id = $4::bigint))
+        OR ($4::bigint IS NULL AND m.thread_id IS NULL AND (
+            ($5::bigint IS NOT NULL AND m.channel_id = $5::bigint)
+            OR ($5::bigint IS NULL AND m.message_type = 'direct' AND (
+                (m.sender_id = $6::bigint AND m.receiver_id = $7::bigint) OR
+                (m.sender_id = $7::bigint AND m.receiver_id = $6::bigint)
+            ))
+        ))
+    )
+    AND (m.deleted_at IS NULL OR $8::boolean)
+ORDER BY m.created_at ASC, m.id ASC
+LIMIT $9
+`
+
+type GetMessagesAfterParams struct {
+	WorkspaceID    int64         `json:"workspace_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+	MessageID      int64         `json:"message_id"`
+	ThreadID       sql.NullInt64 `json:"thread_id"`
+	ChannelID      sql.NullInt64 `json:"channel_id"`
+	UserA          int64         `json:"user_a"`
+	UserB          int64         `json:"user_b"`
+	IncludeDeleted bool          `json:"include_deleted"`
+	Limit          int32         `json:"limit"`
+}
+
+type GetMessagesAfterRow struct {
+	ID              int64          `json:"id"`
+	WorkspaceID     int64          `json:"workspace_id"`
+	ChannelID       sql.NullInt64  `json:"channel_id"`
+	SenderID        int64          `json:"sender_id"`
+	ReceiverID      sql.NullInt64  `json:"receiver_id"`
+	Content         string         `json:"content"`
+	MessageType     string         `json:"message_type"`
+	ThreadID        sql.NullInt64  `json:"thread_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ContentType     string         `json:"content_type"`
+	DeletedBy       sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice  sql.NullString `json:"deletion_notice"`
+	SearchVector    interface{}    `json:"search_vector"`
+	SenderFirstName string         `json:"sender_first_name"`
+	SenderLastName  string         `json:"sender_last_name"`
+	SenderEmail     string         `json:"sender_email"`
+}
+
+// Messages following an anchor message in the same conversation, oldest first.
+// Scoping works the same way as GetMessagesBefore.
+func (q *Queries) GetMessagesAfter(ctx context.Context, arg GetMessagesAfterParams) ([]GetMessagesAfterRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMessagesAfter,
+		arg.WorkspaceID,
+		arg.CreatedAt,
+		arg.MessageID,
+		arg.ThreadID,
+		arg.ChannelID,
+		arg.UserA,
+		arg.UserB,
+		arg.IncludeDeleted,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMessagesAfterRow{}
+	for rows.Next() {
+		var i GetMessagesAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChannelID,
+			&i.SenderID,
+			&i.ReceiverID,
+			&i.Content,
+			&i.MessageType,
+			&i.ThreadID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.CreatedAt,
+			&i.ContentType,
+			&i.DeletedBy,
+			&i.DeletionNotice,
+			&i.SearchVector,
+			&i.SenderFirstName,
+			&i.SenderLastName,
+			&i.SenderEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessagesBefore = `-- name: GetMessagesBefore :many
+SELECT 
+    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type, m.deleted_by, m.deletion_notice, m.search_vector,
+    u.first_name as sender_first_name,
+    u.last_name as sender_last_name,
+    u.email as sender_email
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+WHERE m.workspace_id = $1
+    AND (m.created_at, m.id) < ($2::timestamptz, $3::bigint)
+    AND (
+        ($4::bigint IS NOT NULL AND (m.thread_id = $4::bigint OR m.id = $4::bigint))
+        OR ($4::bigint IS NULL AND m.thread_id IS NULL AND (
+            ($5::bigint IS NOT NULL AND m.channel_id = $5::bigint)
+            OR ($5::bigint IS NULL AND m.message_type = 'direct' AND (
+                (m.sender_id = $6::bigint AND m.receiver_id = $7::bigint) OR
+                (m.sender_id = $7::bigint AND m.receiver_id = $6::bigint)
+            ))
+        ))
+    )
+    AND (m.deleted_at IS NULL OR $8::boolean)
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT $9
+`
+
+type GetMessagesBeforeParams struct {
+	WorkspaceID    int64         `json:"workspace_id"`
+	CreatedAt      time.Time     `json:"created_at"`
+	MessageID      int64         `json:"message_id"`
+	ThreadID       sql.NullInt64 `json:"thread_id"`
+	ChannelID      sql.NullInt64 `json:"channel_id"`
+	UserA          int64         `json:"user_a"`
+	UserB          int64         `json:"user_b"`
+	IncludeDeleted bool          `json:"include_deleted"`
+	Limit          int32         `json:"limit"`
+}
+
+type GetMessagesBeforeRow struct {
+	ID              int64          `json:"id"`
+	WorkspaceID     int64          `json:"workspace_id"`
+	ChannelID       sql.NullInt64  `json:"channel_id"`
+	SenderID        int64          `json:"sender_id"`
+	ReceiverID      sql.NullInt64  `json:"receiver_id"`
+	Content         string         `json:"content"`
+	MessageType     string         `json:"message_type"`
+	ThreadID        sql.NullInt64  `json:"thread_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ContentType     string         `json:"content_type"`
+	DeletedBy       sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice  sql.NullString `json:"deletion_notice"`
+	SearchVector    interface{}    `json:"search_vector"`
+	SenderFirstName string         `json:"sender_first_name"`
+	SenderLastName  string         `json:"sender_last_name"`
+	SenderEmail     string         `json:"sender_email"`
+}
+
+// Messages preceding an anchor message in the same conversation, newest first.
+// The conversation is a thread when thread_id is set, otherwise a channel or a direct
+// message pair. Thread replies are left out of channel and direct message context.
+// Deleted messages are included as tombstones when include_deleted is set.
+func (q *Queries) GetMessagesBefore(ctx context.Context, arg GetMessagesBeforeParams) ([]GetMessagesBeforeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMessagesBefore,
+		arg.WorkspaceID,
+		arg.CreatedAt,
+		arg.MessageID,
+		arg.ThreadID,
+		arg.ChannelID,
+		arg.UserA,
+		arg.UserB,
+		arg.IncludeDeleted,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMessagesBeforeRow{}
+	for rows.Next() {
+		var i GetMessagesBeforeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChannelID,
+			&i.SenderID,
+			&i.ReceiverID,
+			&i.Content,
+			&i.MessageType,
+			&i.ThreadID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.CreatedAt,
+			&i.ContentType,
+			&i.DeletedBy,
+			&i.DeletionNotice,
+			&i.SearchVector,
+			&i.SenderFirstName,
+			&i.SenderLastName,
+			&i.SenderEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRecentWorkspaceMessages = `-- name: GetRecentWorkspaceMessages :many
 SELECT 
-    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type,
+    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type, m.deleted_by, m.deletion_notice, m.search_vector,
     u.first_name as sender_first_name,
     u.last_name as sender_last_name,
     u.email as sender_email
@@ -369,21 +728,24 @@ type GetRecentWorkspaceMessagesParams struct {
 }
 
 type GetRecentWorkspaceMessagesRow struct {
-	ID              int64         `json:"id"`
-	WorkspaceID     int64         `json:"workspace_id"`
-	ChannelID       sql.NullInt64 `json:"channel_id"`
-	SenderID        int64         `json:"sender_id"`
-	ReceiverID      sql.NullInt64 `json:"receiver_id"`
-	Content         string        `json:"content"`
-	MessageType     string        `json:"message_type"`
-	ThreadID        sql.NullInt64 `json:"thread_id"`
-	EditedAt        sql.NullTime  `json:"edited_at"`
-	DeletedAt       sql.NullTime  `json:"deleted_at"`
-	CreatedAt       time.Time     `json:"created_at"`
-	ContentType     string        `json:"content_type"`
-	SenderFirstName string        `json:"sender_first_name"`
-	SenderLastName  string        `json:"sender_last_name"`
-	SenderEmail     string        `json:"sender_email"`
+	ID              int64          `json:"id"`
+	WorkspaceID     int64          `json:"workspace_id"`
+	ChannelID       sql.NullInt64  `json:"channel_id"`
+	SenderID        int64          `json:"sender_id"`
+	ReceiverID      sql.NullInt64  `json:"receiver_id"`
+	Content         string         `json:"content"`
+	MessageType     string         `json:"message_type"`
+	ThreadID        sql.NullInt64  `json:"thread_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ContentType     string         `json:"content_type"`
+	DeletedBy       sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice  sql.NullString `json:"deletion_notice"`
+	SearchVector    interface{}    `json:"search_vector"`
+	SenderFirstName string         `json:"sender_first_name"`
+	SenderLastName  string         `json:"sender_last_name"`
+	SenderEmail     string         `json:"sender_email"`
 }
 
 func (q *Queries) GetRecentWorkspaceMessages(ctx context.Context, arg GetRecentWorkspaceMessagesParams) ([]GetRecentWorkspaceMessagesRow, error) {
@@ -408,9 +770,273 @@ func (q *Queries) GetRecentWorkspaceMessages(ctx context.Context, arg GetRecentW
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.ContentType,
+			&i.DeletedBy,
+			&i.DeletionNotice,
+			&i.SearchVector,
+			&i.SenderFirstName,
+			&i.SenderLastName,
+			&i.SenderEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChannelMessageIDsAt = `-- name: ListChannelMessageIDsAt :many
+SELECT id FROM messages
+WHERE channel_id = $1
+    AND workspace_id = $2
+    AND created_at = $3
+    AND deleted_at IS NULL
+ORDER BY id
+LIMIT 2
+`
+
+type ListChannelMessageIDsAtParams struct {
+	ChannelID   sql.NullInt64 `json:"channel_id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// The channel messages posted at an instant, the way Slack ts values name
+// messages. Two at most are returned, enough to tell whether the instant
+// names a single message.
+func (q *Queries) ListChannelMessageIDsAt(ctx context.Context, arg ListChannelMessageIDsAtParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listChannelMessageIDsAt, arg.ChannelID, arg.WorkspaceID, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDirectMessageIDsAt = `-- name: ListDirectMessageIDsAt :many
+SELECT id FROM messages
+WHERE workspace_id = $1
+    AND message_type = 'direct'
+    AND created_at = $4
+    AND deleted_at IS NULL
+    AND (
+        (sender_id = $2 AND receiver_id = $3) OR
+        (sender_id = $3 AND receiver_id = $2)
+    )
+ORDER BY id
+LIMIT 2
+`
+
+type ListDirectMessageIDsAtParams struct {
+	WorkspaceID int64         `json:"workspace_id"`
+	SenderID    int64         `json:"sender_id"`
+	ReceiverID  sql.NullInt64 `json:"receiver_id"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// The direct messages between two users sent at an instant, as in
+// ListChannelMessageIDsAt
+func (q *Queries) ListDirectMessageIDsAt(ctx context.Context, arg ListDirectMessageIDsAtParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listDirectMessageIDsAt,
+		arg.WorkspaceID,
+		arg.SenderID,
+		arg.ReceiverID,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedMessages = `-- name: PurgeDeletedMessages :execrows
+DELETE FROM messages
+WHERE id IN (
+    SELECT m.id FROM messages m
+    WHERE m.deleted_at < $1
+        AND NOT EXISTS (SELECT 1 FROM messages r WHERE r.thread_id = m.id)
+        AND NOT EXISTS (
+            SELECT 1 FROM legal_holds h
+            WHERE h.released_at IS NULL AND (
+                (h.target_type = 'user' AND h.target_id = m.sender_id)
+                OR (h.target_type = 'channel' AND h.target_id = m.channel_id)
+            )
+        )
+    ORDER BY m.deleted_at
+    LIMIT $2
+)
+`
+
+type PurgeDeletedMessagesParams struct {
+	DeletedBefore sql.NullTime `json:"deleted_before"`
+	Limit         int32        `json:"limit"`
+}
+
+// Permanently removes a batch of messages deleted before the cutoff. Messages
+// with replies keep their tombstone so the thread stays intact, and messages
+// of held users or channels are kept while the legal hold is active.
+func (q *Queries) PurgeDeletedMessages(ctx context.Context, arg PurgeDeletedMessagesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedMessages, arg.DeletedBefore, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchMessages = `-- name: SearchMessages :many
+SELECT 
+    m.id, m.workspace_id, m.channel_id, m.sender_id, m.receiver_id, m.content, m.message_type, m.thread_id, m.edited_at, m.deleted_at, m.created_at, m.content_type, m.deleted_by, m.deletion_notice, m.search_vector,
+    u.first_name as sender_first_name,
+    u.last_name as sender_last_name,
+    u.email as sender_email,
+    COUNT(*) OVER() as total_count
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+WHERE m.workspace_id = $1
+    AND m.deleted_at IS NULL
+    AND (
+        (m.message_type = 'channel' AND EXISTS (
+            SELECT 1 FROM channels c
+            WHERE c.id = m.channel_id
+                AND c.deleted_at IS NULL
+                AND (c.is_private = false OR EXISTS (
+                    SELECT 1 FROM channel_members cm WHERE cm.channel_id = c.id AND cm.user_id = $2
+                ))
+        ))
+        OR (m.message_type = 'direct' AND (m.sender_id = $2 OR m.receiver_id = $2))
+    )
+    AND ($3::text IS NULL OR m.search_vector @@ plainto_tsquery('english', $3::text))
+    AND ($4::bigint IS NULL OR m.sender_id = $4::bigint)
+    AND ($5::bigint IS NULL OR m.channel_id = $5::bigint)
+    AND ($6::timestamptz IS NULL OR m.created_at >= $6::timestamptz)
+    AND ($7::timestamptz IS NULL OR m.created_at < $7::timestamptz)
+    AND (NOT $8::boolean OR EXISTS (
+        SELECT 1 FROM message_files mf WHERE mf.message_id = m.id
+    ))
+    AND (NOT $9::boolean OR m.content ~* 'https?://')
+    AND (NOT $10::boolean OR m.thread_id IS NOT NULL OR EXISTS (
+        SELECT 1 FROM messages r WHERE r.thread_id = m.id AND r.deleted_at IS NULL
+    ))
+ORDER BY m.created_at DESC
+LIMIT $12
+OFFSET $11
+`
+
+type SearchMessagesParams struct {
+	WorkspaceID int64          `json:"workspace_id"`
+	UserID      int64          `json:"user_id"`
+	Query       sql.NullString `json:"query"`
+	SenderID    sql.NullInt64  `json:"sender_id"`
+	ChannelID   sql.NullInt64  `json:"channel_id"`
+	After       sql.NullTime   `json:"after"`
+	Before      sql.NullTime   `json:"before"`
+	HasFile     bool           `json:"has_file"`
+	HasLink     bool           `json:"has_link"`
+	IsThread    bool           `json:"is_thread"`
+	Offset      int32          `json:"offset"`
+	Limit       int32          `json:"limit"`
+}
+
+type SearchMessagesRow struct {
+	ID              int64          `json:"id"`
+	WorkspaceID     int64          `json:"workspace_id"`
+	ChannelID       sql.NullInt64  `json:"channel_id"`
+	SenderID        int64          `json:"sender_id"`
+	ReceiverID      sql.NullInt64  `json:"receiver_id"`
+	Content         string         `json:"content"`
+	MessageType     string         `json:"message_type"`
+	ThreadID        sql.NullInt64  `json:"thread_id"`
+	EditedAt        sql.NullTime   `json:"edited_at"`
+	DeletedAt       sql.NullTime   `json:"deleted_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	ContentType     string         `json:"content_type"`
+	DeletedBy       sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice  sql.NullString `json:"deletion_notice"`
+	SearchVector    interface{}    `json:"search_vector"`
+	SenderFirstName string         `json:"sender_first_name"`
+	SenderLastName  string         `json:"sender_last_name"`
+	SenderEmail     string         `json:"sender_email"`
+	TotalCount      int64          `json:"total_count"`
+}
+
+// Channel messages only match in public channels and private channels the
+// user is a member of, direct messages only when the user took part
+func (q *Queries) SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchMessages,
+		arg.WorkspaceID,
+		arg.UserID,
+		arg.Query,
+		arg.SenderID,
+		arg.ChannelID,
+		arg.After,
+		arg.Before,
+		arg.HasFile,
+		arg.HasLink,
+		arg.IsThread,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchMessagesRow{}
+	for rows.Next() {
+		var i SearchMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ChannelID,
+			&i.SenderID,
+			&i.ReceiverID,
+			&i.Content,
+			&i.MessageType,
+			&i.ThreadID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.CreatedAt,
+			&i.ContentType,
+			&i.DeletedBy,
+			&i.DeletionNotice,
+			&i.SearchVector,
 			&i.SenderFirstName,
 			&i.SenderLastName,
 			&i.SenderEmail,
+			&i.TotalCount,
 		); err != nil {
 			return nil, err
 		}
@@ -427,22 +1053,48 @@ func (q *Queries) GetRecentWorkspaceMessages(ctx context.Context, arg GetRecentW
 
 const softDeleteMessage = `-- name: SoftDeleteMessage :exec
 UPDATE messages
-SET deleted_at = now()
+SET deleted_at = now(), deleted_by = $2
 WHERE id = $1
 `
 
-func (q *Queries) SoftDeleteMessage(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, softDeleteMessage, id)
+type SoftDeleteMessageParams struct {
+	ID        int64         `json:"id"`
+	DeletedBy sql.NullInt64 `json:"deleted_by"`
+}
+
+func (q *Queries) SoftDeleteMessage(ctx context.Context, arg SoftDeleteMessageParams) error {
+	_, err := q.db.ExecContext(ctx, softDeleteMessage, arg.ID, arg.DeletedBy)
 	return err
 }
 
+const takedownMessage = `-- name: TakedownMessage :execrows
+UPDATE messages
+SET deleted_at = now(), deleted_by = $2, deletion_notice = $3
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type TakedownMessageParams struct {
+	ID             int64          `json:"id"`
+	DeletedBy      sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice sql.NullString `json:"deletion_notice"`
+}
+
+// Deletes a message on behalf of a moderator, leaving a notice in its tombstone
+func (q *Queries) TakedownMessage(ctx context.Context, arg TakedownMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, takedownMessage, arg.ID, arg.DeletedBy, arg.DeletionNotice)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateMessageContent = `-- name: UpdateMessageContent :one
 UPDATE messages
 SET 
     content = $2,
     edited_at = now()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, workspace_id, channel_id, sender_id, receiver_id, content, message_type, thread_id, edited_at, deleted_at, created_at, content_type
+RETURNING id, workspace_id, channel_id, sender_id, receiver_id, content, message_type, thread_id, edited_at, deleted_at, created_at, content_type, deleted_by, deletion_notice, search_vector
 `
 
 type UpdateMessageContentParams struct {
@@ -466,6 +1118,9 @@ func (q *Queries) UpdateMessageContent(ctx context.Context, arg UpdateMessageCon
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.ContentType,
+		&i.DeletedBy,
+		&i.DeletionNotice,
+		&i.SearchVector,
 	)
 	return i, err
 }
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageAcknowledgments(t *testing.T) {
+	workspace, author := createTestWorkspaceAndUser(t)
+	reader := createRandomUserForOrganization(t, workspace.OrganizationID)
+	laggard := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, author)
+	for _, user := range []User{author, reader, laggard} {
+		createRandomChannelMember(t, channel, user, author)
+	}
+	store := NewStore(testDB)
+
+	result, err := store.CreateMessageTx(context.Background(), CreateMessageTxParams{
+		ChannelMessage: &CreateChannelMessageParams{
+			WorkspaceID: workspace.ID,
+			ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+			SenderID:    author.ID,
+			Content:     util.RandomString(20),
+			ContentType: "text",
+		},
+		AcknowledgeBy: sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+	})
+	require.NoError(t, err)
+	messageID := result.Message.ID
+	require.Equal(t, messageID, result.AcknowledgmentRequest.MessageID)
+	require.Equal(t, channel.ID, result.AcknowledgmentRequest.ChannelID)
+	require.False(t, result.AcknowledgmentRequest.RemindedAt.Valid)
+
+	// Acknowledging again keeps the first time
+	first, err := testQueries.AcknowledgeMessage(context.Background(), AcknowledgeMessageParams{MessageID: messageID, UserID: reader.ID})
+	require.NoError(t, err)
+	again, err := testQueries.AcknowledgeMessage(context.Background(), AcknowledgeMessageParams{MessageID: messageID, UserID: reader.ID})
+	require.NoError(t, err)
+	require.Equal(t, first.AcknowledgedAt, again.AcknowledgedAt)
+
+	states, err := testQueries.ListMessageAcknowledgmentStates(context.Background(), ListMessageAcknowledgmentStatesParams{
+		UserID:     laggard.ID,
+		MessageIds: []int64{messageID},
+	})
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	require.Equal(t, int64(1), states[0].AcknowledgedCount)
+	require.False(t, states[0].AcknowledgedAt.Valid)
+
+	// The author isn't asked, and those who haven't acknowledged come last
+	recipients, err := testQueries.ListAcknowledgmentRecipients(context.Background(), messageID)
+	require.NoError(t, err)
+	require.Len(t, recipients, 2)
+	require.Equal(t, reader.ID, recipients[0].ID)
+	require.True(t, recipients[0].AcknowledgedAt.Valid)
+	require.Equal(t, laggard.ID, recipients[1].ID)
+	require.False(t, recipients[1].AcknowledgedAt.Valid)
+
+	// The due reminder is claimed once
+	claimed, err := testQueries.ClaimDueAcknowledgmentReminders(context.Background(), 1000)
+	require.NoError(t, err)
+	require.Contains(t, acknowledgmentRequestIDs(claimed), messageID)
+
+	claimed, err = testQueries.ClaimDueAcknowledgmentReminders(context.Background(), 1000)
+	require.NoError(t, err)
+	require.NotContains(t, acknowledgmentRequestIDs(claimed), messageID)
+
+	request, err := testQueries.GetAcknowledgmentRequest(context.Background(), messageID)
+	require.NoError(t, err)
+	require.True(t, request.RemindedAt.Valid)
+}
+
+func acknowledgmentRequestIDs(requests []MessageAcknowledgmentRequest) []int64 {
+	ids := make([]int64, len(requests))
+	for i, request := range requests {
+		ids[i] = request.MessageID
+	}
+	return ids
+}
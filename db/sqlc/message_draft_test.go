@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageDrafts(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	receiver := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, user)
+
+	channelArg := UpsertMessageDraftParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		Content:     "Draft for the channel",
+	}
+	channelDraft, err := testQueries.UpsertMessageDraft(context.Background(), channelArg)
+	require.NoError(t, err)
+
+	// Saving again for the same conversation replaces the draft
+	channelArg.Content = "Edited draft for the channel"
+	updated, err := testQueries.UpsertMessageDraft(context.Background(), channelArg)
+	require.NoError(t, err)
+	require.Equal(t, channelDraft.ID, updated.ID)
+	require.Equal(t, channelArg.Content, updated.Content)
+
+	directDraft, err := testQueries.UpsertMessageDraft(context.Background(), UpsertMessageDraftParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		ReceiverID:  sql.NullInt64{Int64: receiver.ID, Valid: true},
+		Content:     "Draft for a direct message",
+	})
+	require.NoError(t, err)
+
+	drafts, err := testQueries.ListMessageDrafts(context.Background(), ListMessageDraftsParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, drafts, 2)
+	require.Equal(t, directDraft.ID, drafts[0].ID)
+
+	deleted, err := testQueries.DeleteMessageDraftForTarget(context.Background(), DeleteMessageDraftForTargetParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		ChannelID:   channelArg.ChannelID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, channelDraft.ID, deleted.ID)
+
+	// Only the owner can delete a draft
+	_, err = testQueries.DeleteMessageDraft(context.Background(), DeleteMessageDraftParams{
+		ID:     directDraft.ID,
+		UserID: receiver.ID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueries.DeleteMessageDraft(context.Background(), DeleteMessageDraftParams{
+		ID:     directDraft.ID,
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+}
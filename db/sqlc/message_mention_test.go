@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUserMentions(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	mentioned := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, user)
+	createRandomChannelMember(t, channel, mentioned, user)
+
+	message1 := createRandomChannelMessage(t, workspace, channel, user)
+	message2 := createRandomChannelMessage(t, workspace, channel, user)
+	for _, message := range []Message{message1, message2} {
+		err := testQueries.CreateMessageMentions(context.Background(), CreateMessageMentionsParams{
+			MessageID: message.ID,
+			UserIds:   []int64{mentioned.ID},
+		})
+		require.NoError(t, err)
+	}
+
+	mentions, err := testQueries.ListUserMentions(context.Background(), ListUserMentionsParams{
+		UserID:      mentioned.ID,
+		WorkspaceID: workspace.ID,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, mentions, 2)
+	require.Equal(t, message2.ID, mentions[0].ID)
+	require.Equal(t, channel.Name, mentions[0].ChannelName.String)
+	require.False(t, mentions[0].ReadAt.Valid)
+
+	mention, err := testQueries.MarkMentionRead(context.Background(), MarkMentionReadParams{
+		MessageID: message2.ID,
+		UserID:    mentioned.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, mention.ReadAt.Valid)
+
+	// Marking it again keeps the time it was first read
+	again, err := testQueries.MarkMentionRead(context.Background(), MarkMentionReadParams{
+		MessageID: message2.ID,
+		UserID:    mentioned.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, mention.ReadAt.Time, again.ReadAt.Time)
+
+	unread, err := testQueries.CountUnreadMentions(context.Background(), CountUnreadMentionsParams{
+		UserID:      mentioned.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), unread)
+
+	mentions, err = testQueries.ListUserMentions(context.Background(), ListUserMentionsParams{
+		UserID:      mentioned.ID,
+		WorkspaceID: workspace.ID,
+		UnreadOnly:  true,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, mentions, 1)
+	require.Equal(t, message1.ID, mentions[0].ID)
+}
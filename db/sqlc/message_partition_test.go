@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessagePartitions(t *testing.T) {
+	month := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	created, err := testQueries.CreateMessagePartition(context.Background(), month)
+	require.NoError(t, err)
+	require.True(t, created)
+
+	// Creating a month's partition again is a no-op
+	created, err = testQueries.CreateMessagePartition(context.Background(), month.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	require.False(t, created)
+
+	partitions, err := testQueries.ListMessagePartitions(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, partitions, "messages_p209901")
+	require.NotContains(t, partitions, "messages_legacy")
+	require.NotContains(t, partitions, "messages_default")
+
+	// Only monthly partitions can be dropped
+	dropped, err := testQueries.DropMessagePartition(context.Background(), "messages_legacy")
+	require.NoError(t, err)
+	require.False(t, dropped)
+
+	// The maintenance job drops partitions without the pool's statement timeout
+	store := NewStore(testDB)
+	dropped, err = store.DropMessagePartitionTx(context.Background(), "messages_p209901")
+	require.NoError(t, err)
+	require.True(t, dropped)
+
+	partitions, err = testQueries.ListMessagePartitions(context.Background())
+	require.NoError(t, err)
+	require.NotContains(t, partitions, "messages_p209901")
+}
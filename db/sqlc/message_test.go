@@ -79,6 +79,38 @@ func TestCreateDirectMessage(t *testing.T) {
 	createRandomDirectMessage(t, workspace, user1, user2)
 }
 
+func TestCreateMessageAt(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	createdAt := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+
+	parent, err := testQueries.CreateMessageAt(context.Background(), CreateMessageAtParams{
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    user.ID,
+		Content:     util.RandomString(50),
+		ContentType: "text",
+		MessageType: "channel",
+		CreatedAt:   createdAt,
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, createdAt, parent.CreatedAt, time.Second)
+	require.False(t, parent.ThreadID.Valid)
+
+	reply, err := testQueries.CreateMessageAt(context.Background(), CreateMessageAtParams{
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    user.ID,
+		Content:     util.RandomString(50),
+		ContentType: "text",
+		MessageType: "channel",
+		ThreadID:    sql.NullInt64{Int64: parent.ID, Valid: true},
+		CreatedAt:   createdAt.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, parent.ID, reply.ThreadID.Int64)
+}
+
 func TestGetChannelMessages(t *testing.T) {
 	workspace, user := createTestWorkspaceAndUser(t)
 	channel := createRandomChannel(t, workspace, user)
@@ -198,6 +230,61 @@ func TestGetDirectMessagesBetweenUsers(t *testing.T) {
 	require.True(t, messageIDs[message2.ID])
 }
 
+func TestListMessageIDsAt(t *testing.T) {
+	workspace, user1 := createTestWorkspaceAndUser(t)
+	user2 := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, user1)
+
+	channelMessage := createRandomChannelMessage(t, workspace, channel, user1)
+	directMessage := createRandomDirectMessage(t, workspace, user2, user1)
+
+	ids, err := testQueries.ListChannelMessageIDsAt(context.Background(), ListChannelMessageIDsAtParams{
+		ChannelID:   channelMessage.ChannelID,
+		WorkspaceID: workspace.ID,
+		CreatedAt:   channelMessage.CreatedAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{channelMessage.ID}, ids)
+
+	// Either side of the conversation finds the message
+	ids, err = testQueries.ListDirectMessageIDsAt(context.Background(), ListDirectMessageIDsAtParams{
+		WorkspaceID: workspace.ID,
+		SenderID:    user1.ID,
+		ReceiverID:  sql.NullInt64{Int64: user2.ID, Valid: true},
+		CreatedAt:   directMessage.CreatedAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{directMessage.ID}, ids)
+
+	ids, err = testQueries.ListChannelMessageIDsAt(context.Background(), ListChannelMessageIDsAtParams{
+		ChannelID:   channelMessage.ChannelID,
+		WorkspaceID: workspace.ID,
+		CreatedAt:   channelMessage.CreatedAt.Add(time.Microsecond),
+	})
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	// Messages sent in the same instant are all found
+	sameInstant, err := testQueries.CreateMessageAt(context.Background(), CreateMessageAtParams{
+		WorkspaceID: workspace.ID,
+		ChannelID:   channelMessage.ChannelID,
+		SenderID:    user2.ID,
+		Content:     util.RandomString(20),
+		ContentType: "text",
+		MessageType: "channel",
+		CreatedAt:   channelMessage.CreatedAt,
+	})
+	require.NoError(t, err)
+
+	ids, err = testQueries.ListChannelMessageIDsAt(context.Background(), ListChannelMessageIDsAtParams{
+		ChannelID:   channelMessage.ChannelID,
+		WorkspaceID: workspace.ID,
+		CreatedAt:   channelMessage.CreatedAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int64{channelMessage.ID, sameInstant.ID}, ids)
+}
+
 func TestGetMessageByID(t *testing.T) {
 	workspace, user := createTestWorkspaceAndUser(t)
 	channel := createRandomChannel(t, workspace, user)
@@ -249,13 +336,60 @@ func TestUpdateMessageContent(t *testing.T) {
 	require.Equal(t, message.MessageType, updatedMessage.MessageType)
 }
 
+func TestPurgeDeletedMessages(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	message := createRandomChannelMessage(t, workspace, channel, user)
+	parent := createRandomChannelMessage(t, workspace, channel, user)
+
+	_, err := testQueries.CreateMessageAt(context.Background(), CreateMessageAtParams{
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    user.ID,
+		Content:     util.RandomString(50),
+		ContentType: "text",
+		MessageType: "channel",
+		ThreadID:    sql.NullInt64{Int64: parent.ID, Valid: true},
+		CreatedAt:   time.Now(),
+	})
+	require.NoError(t, err)
+
+	for _, id := range []int64{message.ID, parent.ID} {
+		err := testQueries.SoftDeleteMessage(context.Background(), SoftDeleteMessageParams{ID: id})
+		require.NoError(t, err)
+	}
+
+	purged, err := testQueries.PurgeDeletedMessages(context.Background(), PurgeDeletedMessagesParams{
+		DeletedBefore: sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true},
+		Limit:         1000,
+	})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, purged, int64(1))
+
+	// The thread parent keeps its tombstone while it has a reply
+	result, err := testQueries.GetChannelMessages(context.Background(), GetChannelMessagesParams{
+		ChannelID:      sql.NullInt64{Int64: channel.ID, Valid: true},
+		WorkspaceID:    workspace.ID,
+		IncludeDeleted: true,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	for _, row := range result {
+		require.NotEqual(t, message.ID, row.ID)
+	}
+}
+
 func TestSoftDeleteMessage(t *testing.T) {
 	workspace, user := createTestWorkspaceAndUser(t)
 	channel := createRandomChannel(t, workspace, user)
 	message := createRandomChannelMessage(t, workspace, channel, user)
 
 	// Soft delete the message
-	err := testQueries.SoftDeleteMessage(context.Background(), message.ID)
+	err := testQueries.SoftDeleteMessage(context.Background(), SoftDeleteMessageParams{
+		ID:        message.ID,
+		DeletedBy: sql.NullInt64{Int64: user.ID, Valid: true},
+	})
 	require.NoError(t, err)
 
 	// Message should not appear in channel messages (due to WHERE deleted_at IS NULL)
@@ -270,12 +404,55 @@ func TestSoftDeleteMessage(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, result, 0) // Should be empty because message is soft deleted
 
+	// unless tombstones are included
+	arg.IncludeDeleted = true
+	result, err = testQueries.GetChannelMessages(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.True(t, result[0].DeletedAt.Valid)
+	require.Equal(t, user.ID, result[0].DeletedBy.Int64)
+
 	// But GetMessageByID should still return error (due to WHERE deleted_at IS NULL)
 	_, err = testQueries.GetMessageByID(context.Background(), message.ID)
 	require.Error(t, err)
 	require.Equal(t, sql.ErrNoRows, err)
 }
 
+func TestTakedownMessage(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	moderator := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, user)
+	message := createRandomChannelMessage(t, workspace, channel, user)
+
+	arg := TakedownMessageParams{
+		ID:             message.ID,
+		DeletedBy:      sql.NullInt64{Int64: moderator.ID, Valid: true},
+		DeletionNotice: sql.NullString{String: "Removed by a moderator for violating the spam policy", Valid: true},
+	}
+	rows, err := testQueries.TakedownMessage(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	// The tombstone keeps who took the message down and the notice
+	result, err := testQueries.GetChannelMessages(context.Background(), GetChannelMessagesParams{
+		ChannelID:      sql.NullInt64{Int64: channel.ID, Valid: true},
+		WorkspaceID:    workspace.ID,
+		IncludeDeleted: true,
+		Limit:          10,
+		Offset:         0,
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.True(t, result[0].DeletedAt.Valid)
+	require.Equal(t, moderator.ID, result[0].DeletedBy.Int64)
+	require.Equal(t, arg.DeletionNotice, result[0].DeletionNotice)
+
+	// Deleted messages can't be taken down again
+	rows, err = testQueries.TakedownMessage(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
+
 func TestGetRecentWorkspaceMessages(t *testing.T) {
 	workspace, user1 := createTestWorkspaceAndUser(t)
 	user2 := createRandomUserForOrganization(t, workspace.OrganizationID)
@@ -403,3 +580,109 @@ func TestDirectMessageBetweenSameUser(t *testing.T) {
 	_, err := testQueries.CreateDirectMessage(context.Background(), arg)
 	require.Error(t, err) // Should fail due to trigger constraint
 }
+
+func TestSearchMessages(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+
+	arg := CreateChannelMessageParams{
+		WorkspaceID: workspace.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		SenderID:    user.ID,
+		Content:     "deployment notes at https://example.com/" + util.RandomString(8),
+		ContentType: "text",
+	}
+	message, err := testQueries.CreateChannelMessage(context.Background(), arg)
+	require.NoError(t, err)
+
+	createRandomChannelMessage(t, workspace, channel, user)
+
+	results, err := testQueries.SearchMessages(context.Background(), SearchMessagesParams{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+		Query:       sql.NullString{String: "deployment", Valid: true},
+		SenderID:    sql.NullInt64{Int64: user.ID, Valid: true},
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		HasLink:     true,
+		Limit:       10,
+		Offset:      0,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, message.ID, results[0].ID)
+
+	// Date range excluding the message returns nothing
+	results, err = testQueries.SearchMessages(context.Background(), SearchMessagesParams{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+		Query:       sql.NullString{String: "deployment", Valid: true},
+		Before:      sql.NullTime{Time: message.CreatedAt.Add(-time.Hour), Valid: true},
+		Limit:       10,
+		Offset:      0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// has:file requires an attachment
+	results, err = testQueries.SearchMessages(context.Background(), SearchMessagesParams{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+		Query:       sql.NullString{String: "deployment", Valid: true},
+		HasFile:     true,
+		Limit:       10,
+		Offset:      0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// Edits are searchable by their new content only
+	_, err = testQueries.UpdateMessageContent(context.Background(), UpdateMessageContentParams{
+		ID:      message.ID,
+		Content: "release checklist",
+	})
+	require.NoError(t, err)
+
+	for query, found := range map[string]bool{"deployment": false, "checklists": true} {
+		results, err = testQueries.SearchMessages(context.Background(), SearchMessagesParams{
+			WorkspaceID: workspace.ID,
+			UserID:      user.ID,
+			Query:       sql.NullString{String: query, Valid: true},
+			ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+			Limit:       10,
+			Offset:      0,
+		})
+		require.NoError(t, err)
+		require.Equal(t, found, len(results) == 1, query)
+	}
+}
+
+func TestGetMessagesAroundMessage(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+
+	var messages []Message
+	for i := 0; i < 5; i++ {
+		messages = append(messages, createRandomChannelMessage(t, workspace, channel, user))
+	}
+	anchor := messages[2]
+
+	arg := GetMessagesBeforeParams{
+		WorkspaceID: workspace.ID,
+		CreatedAt:   anchor.CreatedAt,
+		MessageID:   anchor.ID,
+		ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+		Limit:       10,
+	}
+
+	before, err := testQueries.GetMessagesBefore(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, before, 2)
+	require.Equal(t, messages[1].ID, before[0].ID)
+	require.Equal(t, messages[0].ID, before[1].ID)
+
+	after, err := testQueries.GetMessagesAfter(context.Background(), GetMessagesAfterParams(arg))
+	require.NoError(t, err)
+	require.Len(t, after, 2)
+	require.Equal(t, messages[3].ID, after[0].ID)
+	require.Equal(t, messages[4].ID, after[1].ID)
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomThreadReply(t *testing.T, store Store, parent Message, sender User) CreateMessageTxResult {
+	result, err := store.CreateMessageTx(context.Background(), CreateMessageTxParams{
+		ThreadReply: &CreateThreadReplyParams{
+			WorkspaceID: parent.WorkspaceID,
+			ChannelID:   parent.ChannelID,
+			SenderID:    sender.ID,
+			Content:     util.RandomString(20),
+			ContentType: "text",
+			MessageType: parent.MessageType,
+			ThreadID:    sql.NullInt64{Int64: parent.ID, Valid: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, parent.ID, result.Message.ThreadID.Int64)
+	require.Equal(t, parent.ChannelID, result.Message.ChannelID)
+	require.Equal(t, parent.ID, result.Thread.ThreadID)
+	return result
+}
+
+func TestCreateThreadReplyTx(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	other := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, user)
+	parent := createRandomChannelMessage(t, workspace, channel, user)
+	store := NewStore(testDB)
+
+	first := createRandomThreadReply(t, store, parent, user)
+	require.Equal(t, int32(1), first.Thread.ReplyCount)
+	require.Equal(t, []int64{user.ID}, first.Thread.ParticipantIds)
+
+	second := createRandomThreadReply(t, store, parent, other)
+	last := createRandomThreadReply(t, store, parent, user)
+	require.Equal(t, int32(3), last.Thread.ReplyCount)
+	require.WithinDuration(t, last.Message.CreatedAt, last.Thread.LastReplyAt.Time, 0)
+	// The most recent replier comes first, once
+	require.Equal(t, []int64{user.ID, other.ID}, last.Thread.ParticipantIds)
+
+	threads, err := testQueries.ListMessageThreads(context.Background(), []int64{parent.ID, first.Message.ID})
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+	require.Equal(t, last.Thread.ReplyCount, threads[0].ReplyCount)
+
+	participants, err := testQueries.ListThreadParticipants(context.Background(), threads[0].ParticipantIds)
+	require.NoError(t, err)
+	require.Len(t, participants, 2)
+
+	// Deleted replies drop out of the summary once it's refreshed
+	err = testQueries.SoftDeleteMessage(context.Background(), SoftDeleteMessageParams{
+		ID:        last.Message.ID,
+		DeletedBy: sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	thread, err := testQueries.RefreshThreadSummary(context.Background(), parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), thread.ReplyCount)
+	require.Equal(t, []int64{other.ID, user.ID}, thread.ParticipantIds)
+
+	// A thread whose replies are all deleted is no longer listed
+	for _, id := range []int64{first.Message.ID, second.Message.ID} {
+		require.NoError(t, testQueries.SoftDeleteMessage(context.Background(), SoftDeleteMessageParams{ID: id}))
+	}
+	thread, err = testQueries.RefreshThreadSummary(context.Background(), parent.ID)
+	require.NoError(t, err)
+	require.Zero(t, thread.ReplyCount)
+	require.Empty(t, thread.ParticipantIds)
+
+	threads, err = testQueries.ListMessageThreads(context.Background(), []int64{parent.ID})
+	require.NoError(t, err)
+	require.Empty(t, threads)
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageTranslations(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	message := createRandomChannelMessage(t, workspace, channel, user)
+
+	_, err := testQueries.GetMessageTranslation(context.Background(), GetMessageTranslationParams{
+		MessageID:      message.ID,
+		TargetLanguage: "de",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg := UpsertMessageTranslationParams{
+		MessageID:      message.ID,
+		TargetLanguage: "de",
+		SourceLanguage: "en",
+		ContentHash:    "first",
+		TranslatedText: "Hallo",
+		Provider:       "deepl",
+	}
+	translation, err := testQueries.UpsertMessageTranslation(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, "Hallo", translation.TranslatedText)
+
+	// Translating an edited message replaces the cached translation
+	arg.ContentHash = "second"
+	arg.TranslatedText = "Hallo Welt"
+	_, err = testQueries.UpsertMessageTranslation(context.Background(), arg)
+	require.NoError(t, err)
+
+	translation, err = testQueries.GetMessageTranslation(context.Background(), GetMessageTranslationParams{
+		MessageID:      message.ID,
+		TargetLanguage: "de",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "second", translation.ContentHash)
+	require.Equal(t, "Hallo Welt", translation.TranslatedText)
+	require.Equal(t, "en", translation.SourceLanguage)
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMessageTx(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	receiver := createRandomUserForOrganization(t, workspace.OrganizationID)
+	file := createRandomFileForWorkspace(t, workspace.ID, user.ID)
+	store := NewStore(testDB)
+
+	arg := CreateDirectMessageParams{
+		WorkspaceID: workspace.ID,
+		SenderID:    user.ID,
+		ReceiverID:  sql.NullInt64{Int64: receiver.ID, Valid: true},
+		Content:     "Hello",
+		ContentType: "text",
+	}
+
+	result, err := store.CreateMessageTx(context.Background(), CreateMessageTxParams{
+		DirectMessage:    &arg,
+		FileIDs:          []int64{file.ID},
+		MentionedUserIDs: []int64{receiver.ID},
+		AfterCreate: func(result CreateMessageTxResult) (CreateOutboxEventParams, error) {
+			// The event is built from the message with its files
+			require.Len(t, result.Files, 1)
+
+			return CreateOutboxEventParams{
+				EventType:    "message_sent",
+				WorkspaceID:  result.Message.WorkspaceID,
+				UserID:       result.Message.SenderID,
+				RecipientIds: []int64{user.ID, receiver.ID},
+				Payload:      json.RawMessage(`{"id":1}`),
+			}, nil
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Hello", result.Message.Content)
+	require.Equal(t, file.ID, result.Files[0].ID)
+	require.Equal(t, []int64{receiver.ID}, result.MentionedUserIDs)
+	require.Equal(t, []int64{user.ID, receiver.ID}, result.Event.RecipientIds)
+	require.False(t, result.Event.PublishedAt.Valid)
+
+	events, err := testQueries.ListUnpublishedOutboxEvents(context.Background(), ListUnpublishedOutboxEventsParams{
+		CreatedAt: time.Now().Add(time.Minute),
+		Limit:     1000,
+	})
+	require.NoError(t, err)
+	require.Contains(t, outboxEventIDs(events), result.Event.ID)
+
+	require.NoError(t, testQueries.MarkOutboxEventPublished(context.Background(), result.Event.ID))
+
+	events, err = testQueries.ListUnpublishedOutboxEvents(context.Background(), ListUnpublishedOutboxEventsParams{
+		CreatedAt: time.Now().Add(time.Minute),
+		Limit:     1000,
+	})
+	require.NoError(t, err)
+	require.NotContains(t, outboxEventIDs(events), result.Event.ID)
+
+	deleted, err := testQueries.DeletePublishedOutboxEvents(context.Background(), sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+
+	mentions, err := testQueries.ListMessageMentions(context.Background(), result.Message.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int64{receiver.ID}, mentions)
+
+	// A message whose file can't be linked isn't stored
+	arg.Content = "Never sent"
+	_, err = store.CreateMessageTx(context.Background(), CreateMessageTxParams{
+		DirectMessage: &arg,
+		FileIDs:       []int64{file.ID + 1000000},
+	})
+	require.Error(t, err)
+
+	// Neither is a message whose event can't be built
+	_, err = store.CreateMessageTx(context.Background(), CreateMessageTxParams{
+		DirectMessage: &arg,
+		AfterCreate: func(result CreateMessageTxResult) (CreateOutboxEventParams, error) {
+			return CreateOutboxEventParams{}, errors.New("encoding failed")
+		},
+	})
+	require.EqualError(t, err, "encoding failed")
+
+	messages, err := testQueries.GetDirectMessagesBetweenUsers(context.Background(), GetDirectMessagesBetweenUsersParams{
+		WorkspaceID: workspace.ID,
+		SenderID:    user.ID,
+		ReceiverID:  sql.NullInt64{Int64: receiver.ID, Valid: true},
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+}
+
+func outboxEventIDs(events []EventOutbox) []int64 {
+	ids := make([]int64, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	return ids
+}
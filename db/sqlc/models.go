@@ -6,16 +6,94 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type AbuseReport struct {
+	ID             int64         `json:"id"`
+	WorkspaceID    int64         `json:"workspace_id"`
+	ReporterID     sql.NullInt64 `json:"reporter_id"`
+	TargetType     string        `json:"target_type"`
+	TargetID       int64         `json:"target_id"`
+	Reason         string        `json:"reason"`
+	Details        string        `json:"details"`
+	Anonymous      bool          `json:"anonymous"`
+	Status         string        `json:"status"`
+	ResolvedBy     sql.NullInt64 `json:"resolved_by"`
+	ResolvedAt     sql.NullTime  `json:"resolved_at"`
+	ResolutionNote string        `json:"resolution_note"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type CalendarBusyBlock struct {
+	ID            int64     `json:"id"`
+	IntegrationID int64     `json:"integration_id"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+}
+
+type CalendarIntegration struct {
+	ID            int64          `json:"id"`
+	UserID        int64          `json:"user_id"`
+	WorkspaceID   int64          `json:"workspace_id"`
+	Provider      string         `json:"provider"`
+	IcsUrl        string         `json:"ics_url"`
+	Enabled       bool           `json:"enabled"`
+	LastSyncedAt  sql.NullTime   `json:"last_synced_at"`
+	LastSyncError sql.NullString `json:"last_sync_error"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+type Canvas struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	ChannelID   int64         `json:"channel_id"`
+	Title       string        `json:"title"`
+	Content     string        `json:"content"`
+	Revision    int32         `json:"revision"`
+	CreatedBy   sql.NullInt64 `json:"created_by"`
+	UpdatedBy   sql.NullInt64 `json:"updated_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type CanvasRevision struct {
+	CanvasID  int64         `json:"canvas_id"`
+	Revision  int32         `json:"revision"`
+	Title     string        `json:"title"`
+	Content   string        `json:"content"`
+	EditedBy  sql.NullInt64 `json:"edited_by"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
 type Channel struct {
-	ID          int64     `json:"id"`
-	WorkspaceID int64     `json:"workspace_id"`
-	Name        string    `json:"name"`
-	IsPrivate   bool      `json:"is_private"`
-	CreatedBy   int64     `json:"created_by"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Name        string        `json:"name"`
+	IsPrivate   bool          `json:"is_private"`
+	CreatedBy   int64         `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	DeletedBy   sql.NullInt64 `json:"deleted_by"`
+}
+
+type ChannelDailyPosterStat struct {
+	ChannelID    int64     `json:"channel_id"`
+	Day          time.Time `json:"day"`
+	UserID       int64     `json:"user_id"`
+	MessageCount int32     `json:"message_count"`
+}
+
+type ChannelDailyStat struct {
+	ChannelID     int64     `json:"channel_id"`
+	Day           time.Time `json:"day"`
+	MessageCount  int32     `json:"message_count"`
+	ReplyCount    int32     `json:"reply_count"`
+	ThreadCount   int32     `json:"thread_count"`
+	LastMessageAt time.Time `json:"last_message_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 type ChannelMember struct {
@@ -27,6 +105,157 @@ type ChannelMember struct {
 	JoinedAt  time.Time `json:"joined_at"`
 }
 
+type ChannelNotificationDefault struct {
+	ChannelID    int64         `json:"channel_id"`
+	WorkspaceID  int64         `json:"workspace_id"`
+	Level        string        `json:"level"`
+	EmailEnabled bool          `json:"email_enabled"`
+	PushEnabled  bool          `json:"push_enabled"`
+	UpdatedBy    sql.NullInt64 `json:"updated_by"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+type ChannelReadState struct {
+	UserID            int64         `json:"user_id"`
+	ChannelID         int64         `json:"channel_id"`
+	LastReadMessageID sql.NullInt64 `json:"last_read_message_id"`
+	LastReadAt        time.Time     `json:"last_read_at"`
+}
+
+type CustomEmoji struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Name        string        `json:"name"`
+	FileID      int64         `json:"file_id"`
+	CreatedBy   sql.NullInt64 `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type DirectMessageReadState struct {
+	UserID            int64         `json:"user_id"`
+	WorkspaceID       int64         `json:"workspace_id"`
+	OtherUserID       int64         `json:"other_user_id"`
+	LastReadMessageID sql.NullInt64 `json:"last_read_message_id"`
+	LastReadAt        time.Time     `json:"last_read_at"`
+}
+
+type DndOverride struct {
+	MessageID  int64     `json:"message_id"`
+	SenderID   int64     `json:"sender_id"`
+	ReceiverID int64     `json:"receiver_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type DoNotDisturb struct {
+	UserID              int64         `json:"user_id"`
+	WorkspaceID         int64         `json:"workspace_id"`
+	DndUntil            sql.NullTime  `json:"dnd_until"`
+	AllowUrgentOverride bool          `json:"allow_urgent_override"`
+	UpdatedAt           time.Time     `json:"updated_at"`
+	ScheduleStartMinute sql.NullInt32 `json:"schedule_start_minute"`
+	ScheduleEndMinute   sql.NullInt32 `json:"schedule_end_minute"`
+	ScheduleDays        []int32       `json:"schedule_days"`
+}
+
+type EmailBranding struct {
+	OrganizationID int64     `json:"organization_id"`
+	ProductName    string    `json:"product_name"`
+	LogoUrl        string    `json:"logo_url"`
+	PrimaryColor   string    `json:"primary_color"`
+	FooterText     string    `json:"footer_text"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type EmailDelivery struct {
+	ID                int64         `json:"id"`
+	Category          string        `json:"category"`
+	ReferenceID       sql.NullInt64 `json:"reference_id"`
+	ToAddress         string        `json:"to_address"`
+	Subject           string        `json:"subject"`
+	TextBody          string        `json:"text_body"`
+	HtmlBody          string        `json:"html_body"`
+	Status            string        `json:"status"`
+	Provider          string        `json:"provider"`
+	ProviderMessageID string        `json:"provider_message_id"`
+	Attempts          int32         `json:"attempts"`
+	LastError         string        `json:"last_error"`
+	NextAttemptAt     time.Time     `json:"next_attempt_at"`
+	SentAt            sql.NullTime  `json:"sent_at"`
+	CreatedAt         time.Time     `json:"created_at"`
+	UpdatedAt         time.Time     `json:"updated_at"`
+}
+
+type EmailSuppression struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	Provider  string    `json:"provider"`
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type EmailTemplate struct {
+	ID             int64         `json:"id"`
+	OrganizationID int64         `json:"organization_id"`
+	Kind           string        `json:"kind"`
+	Subject        string        `json:"subject"`
+	TextBody       string        `json:"text_body"`
+	HtmlBody       string        `json:"html_body"`
+	UpdatedBy      sql.NullInt64 `json:"updated_by"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+type EmailVerification struct {
+	ID        int64        `json:"id"`
+	UserID    int64        `json:"user_id"`
+	Email     string       `json:"email"`
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type EventOutbox struct {
+	ID           int64           `json:"id"`
+	EventType    string          `json:"event_type"`
+	WorkspaceID  int64           `json:"workspace_id"`
+	ChannelID    sql.NullInt64   `json:"channel_id"`
+	UserID       int64           `json:"user_id"`
+	RecipientIds []int64         `json:"recipient_ids"`
+	Payload      json.RawMessage `json:"payload"`
+	CreatedAt    time.Time       `json:"created_at"`
+	PublishedAt  sql.NullTime    `json:"published_at"`
+}
+
+type ExternalDmPolicy struct {
+	OrganizationID int64         `json:"organization_id"`
+	Policy         string        `json:"policy"`
+	AllowedDomains []string      `json:"allowed_domains"`
+	UpdatedBy      sql.NullInt64 `json:"updated_by"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+type ExternalDmRequest struct {
+	ID          int64        `json:"id"`
+	SenderID    int64        `json:"sender_id"`
+	WorkspaceID int64        `json:"workspace_id"`
+	RecipientID int64        `json:"recipient_id"`
+	Message     string       `json:"message"`
+	Status      string       `json:"status"`
+	CreatedAt   time.Time    `json:"created_at"`
+	RespondedAt sql.NullTime `json:"responded_at"`
+}
+
+type FeatureFlag struct {
+	WorkspaceID int64         `json:"workspace_id"`
+	Flag        string        `json:"flag"`
+	Enabled     bool          `json:"enabled"`
+	UpdatedBy   sql.NullInt64 `json:"updated_by"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
 type File struct {
 	ID               int64          `json:"id"`
 	WorkspaceID      int64          `json:"workspace_id"`
@@ -42,6 +271,25 @@ type File struct {
 	ThumbnailPath    sql.NullString `json:"thumbnail_path"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
+	IsVoiceMessage   bool           `json:"is_voice_message"`
+	DurationMs       sql.NullInt32  `json:"duration_ms"`
+	Waveform         []int32        `json:"waveform"`
+	ProcessingStatus sql.NullString `json:"processing_status"`
+	TranscodedPath   sql.NullString `json:"transcoded_path"`
+	PosterPath       sql.NullString `json:"poster_path"`
+	DownloadCount    int64          `json:"download_count"`
+	PreviewStatus    sql.NullString `json:"preview_status"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
+	DeletedBy        sql.NullInt64  `json:"deleted_by"`
+}
+
+type FileAccessLog struct {
+	ID        int64         `json:"id"`
+	FileID    int64         `json:"file_id"`
+	UserID    sql.NullInt64 `json:"user_id"`
+	IpAddress string        `json:"ip_address"`
+	UserAgent string        `json:"user_agent"`
+	CreatedAt time.Time     `json:"created_at"`
 }
 
 type FileShare struct {
@@ -55,19 +303,90 @@ type FileShare struct {
 	CreatedAt        time.Time     `json:"created_at"`
 }
 
+type LegalHold struct {
+	ID             int64         `json:"id"`
+	OrganizationID int64         `json:"organization_id"`
+	TargetType     string        `json:"target_type"`
+	TargetID       int64         `json:"target_id"`
+	Reason         string        `json:"reason"`
+	CreatedBy      sql.NullInt64 `json:"created_by"`
+	CreatedAt      time.Time     `json:"created_at"`
+	ReleasedBy     sql.NullInt64 `json:"released_by"`
+	ReleasedAt     sql.NullTime  `json:"released_at"`
+}
+
+type LoginEvent struct {
+	ID            int64         `json:"id"`
+	UserID        sql.NullInt64 `json:"user_id"`
+	Email         string        `json:"email"`
+	DeviceID      sql.NullInt64 `json:"device_id"`
+	Fingerprint   string        `json:"fingerprint"`
+	IpAddress     string        `json:"ip_address"`
+	UserAgent     string        `json:"user_agent"`
+	Success       bool          `json:"success"`
+	FailureReason string        `json:"failure_reason"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
 type Message struct {
+	ID             int64          `json:"id"`
+	WorkspaceID    int64          `json:"workspace_id"`
+	ChannelID      sql.NullInt64  `json:"channel_id"`
+	SenderID       int64          `json:"sender_id"`
+	ReceiverID     sql.NullInt64  `json:"receiver_id"`
+	Content        string         `json:"content"`
+	MessageType    string         `json:"message_type"`
+	ThreadID       sql.NullInt64  `json:"thread_id"`
+	EditedAt       sql.NullTime   `json:"edited_at"`
+	DeletedAt      sql.NullTime   `json:"deleted_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ContentType    string         `json:"content_type"`
+	DeletedBy      sql.NullInt64  `json:"deleted_by"`
+	DeletionNotice sql.NullString `json:"deletion_notice"`
+	SearchVector   interface{}    `json:"search_vector"`
+}
+
+type MessageAcknowledgment struct {
+	MessageID      int64     `json:"message_id"`
+	UserID         int64     `json:"user_id"`
+	AcknowledgedAt time.Time `json:"acknowledged_at"`
+}
+
+type MessageAcknowledgmentRequest struct {
+	MessageID   int64        `json:"message_id"`
+	WorkspaceID int64        `json:"workspace_id"`
+	ChannelID   int64        `json:"channel_id"`
+	AuthorID    int64        `json:"author_id"`
+	RemindAt    time.Time    `json:"remind_at"`
+	RemindedAt  sql.NullTime `json:"reminded_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type MessageArchive struct {
+	ID             int64         `json:"id"`
+	WorkspaceID    int64         `json:"workspace_id"`
+	ChannelID      sql.NullInt64 `json:"channel_id"`
+	UserID         sql.NullInt64 `json:"user_id"`
+	OtherUserID    sql.NullInt64 `json:"other_user_id"`
+	SenderIds      []int64       `json:"sender_ids"`
+	ObjectKey      string        `json:"object_key"`
+	FirstMessageAt time.Time     `json:"first_message_at"`
+	LastMessageAt  time.Time     `json:"last_message_at"`
+	MessageCount   int32         `json:"message_count"`
+	SizeBytes      int64         `json:"size_bytes"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+type MessageDraft struct {
 	ID          int64         `json:"id"`
+	UserID      int64         `json:"user_id"`
 	WorkspaceID int64         `json:"workspace_id"`
 	ChannelID   sql.NullInt64 `json:"channel_id"`
-	SenderID    int64         `json:"sender_id"`
 	ReceiverID  sql.NullInt64 `json:"receiver_id"`
-	Content     string        `json:"content"`
-	MessageType string        `json:"message_type"`
 	ThreadID    sql.NullInt64 `json:"thread_id"`
-	EditedAt    sql.NullTime  `json:"edited_at"`
-	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	Content     string        `json:"content"`
 	CreatedAt   time.Time     `json:"created_at"`
-	ContentType string        `json:"content_type"`
+	UpdatedAt   time.Time     `json:"updated_at"`
 }
 
 type MessageFile struct {
@@ -77,23 +396,209 @@ type MessageFile struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type MessageMention struct {
+	MessageID int64        `json:"message_id"`
+	UserID    int64        `json:"user_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	ReadAt    sql.NullTime `json:"read_at"`
+}
+
+type MessageReaction struct {
+	ID        int64     `json:"id"`
+	MessageID int64     `json:"message_id"`
+	UserID    int64     `json:"user_id"`
+	Emoji     string    `json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MessageThread struct {
+	ThreadID       int64        `json:"thread_id"`
+	ReplyCount     int32        `json:"reply_count"`
+	LastReplyAt    sql.NullTime `json:"last_reply_at"`
+	ParticipantIds []int64      `json:"participant_ids"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+}
+
+type MessageTranslation struct {
+	MessageID      int64     `json:"message_id"`
+	TargetLanguage string    `json:"target_language"`
+	SourceLanguage string    `json:"source_language"`
+	ContentHash    string    `json:"content_hash"`
+	TranslatedText string    `json:"translated_text"`
+	Provider       string    `json:"provider"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ModerationAuditLog struct {
+	ID           int64          `json:"id"`
+	WorkspaceID  int64          `json:"workspace_id"`
+	MessageID    sql.NullInt64  `json:"message_id"`
+	AuthorID     sql.NullInt64  `json:"author_id"`
+	ActorID      sql.NullInt64  `json:"actor_id"`
+	Action       string         `json:"action"`
+	MatchedWords []string       `json:"matched_words"`
+	CreatedAt    time.Time      `json:"created_at"`
+	Reason       sql.NullString `json:"reason"`
+}
+
+type ModerationQueue struct {
+	ID           int64         `json:"id"`
+	WorkspaceID  int64         `json:"workspace_id"`
+	MessageID    int64         `json:"message_id"`
+	MatchedWords []string      `json:"matched_words"`
+	Status       string        `json:"status"`
+	ReviewedBy   sql.NullInt64 `json:"reviewed_by"`
+	ReviewedAt   sql.NullTime  `json:"reviewed_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type ModerationWord struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Word        string        `json:"word"`
+	Action      string        `json:"action"`
+	CreatedBy   sql.NullInt64 `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type NotificationPreference struct {
+	ID           int64         `json:"id"`
+	UserID       int64         `json:"user_id"`
+	WorkspaceID  int64         `json:"workspace_id"`
+	ChannelID    sql.NullInt64 `json:"channel_id"`
+	Level        string        `json:"level"`
+	EmailEnabled bool          `json:"email_enabled"`
+	PushEnabled  bool          `json:"push_enabled"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+type OnboardingStepCompletion struct {
+	UserID      int64     `json:"user_id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	Step        string    `json:"step"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
 type Organization struct {
 	ID        int64     `json:"id"`
 	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type OrganizationDeletion struct {
+	ID                int64          `json:"id"`
+	OrganizationID    int64          `json:"organization_id"`
+	OrganizationName  string         `json:"organization_name"`
+	RequestedBy       sql.NullInt64  `json:"requested_by"`
+	Status            string         `json:"status"`
+	Step              string         `json:"step"`
+	WorkspacesTotal   int32          `json:"workspaces_total"`
+	WorkspacesDeleted int32          `json:"workspaces_deleted"`
+	RowsDeleted       int64          `json:"rows_deleted"`
+	FilesDeleted      int64          `json:"files_deleted"`
+	LastError         sql.NullString `json:"last_error"`
+	ScheduledFor      time.Time      `json:"scheduled_for"`
+	StartedAt         sql.NullTime   `json:"started_at"`
+	CompletedAt       sql.NullTime   `json:"completed_at"`
+	CancelledAt       sql.NullTime   `json:"cancelled_at"`
+	CancelledBy       sql.NullInt64  `json:"cancelled_by"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+type OrganizationRole struct {
+	OrganizationID int64         `json:"organization_id"`
+	UserID         int64         `json:"user_id"`
+	Role           string        `json:"role"`
+	GrantedBy      sql.NullInt64 `json:"granted_by"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+type OutOfOffice struct {
+	UserID      int64     `json:"user_id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Message     string    `json:"message"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type OutOfOfficeReply struct {
+	UserID    int64     `json:"user_id"`
+	SenderID  int64     `json:"sender_id"`
+	ReplyDate time.Time `json:"reply_date"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OutgoingWebhook struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Url         string        `json:"url"`
+	Secret      string        `json:"secret"`
+	EventTypes  []string      `json:"event_types"`
+	CreatedBy   sql.NullInt64 `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type PinnedMessage struct {
+	MessageID int64     `json:"message_id"`
+	ChannelID int64     `json:"channel_id"`
+	PinnedBy  int64     `json:"pinned_by"`
+	Position  int32     `json:"position"`
+	PinnedAt  time.Time `json:"pinned_at"`
+}
+
+type SavedSearch struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Query       string    `json:"query"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ThreadReadState struct {
+	UserID            int64         `json:"user_id"`
+	ThreadID          int64         `json:"thread_id"`
+	LastReadMessageID sql.NullInt64 `json:"last_read_message_id"`
+	LastReadAt        time.Time     `json:"last_read_at"`
+}
+
 type User struct {
-	ID                int64         `json:"id"`
-	OrganizationID    int64         `json:"organization_id"`
-	Email             string        `json:"email"`
-	FirstName         string        `json:"first_name"`
-	LastName          string        `json:"last_name"`
-	HashedPassword    string        `json:"hashed_password"`
-	PasswordChangedAt time.Time     `json:"password_changed_at"`
-	CreatedAt         time.Time     `json:"created_at"`
-	WorkspaceID       sql.NullInt64 `json:"workspace_id"`
-	Role              string        `json:"role"`
+	ID                int64          `json:"id"`
+	OrganizationID    int64          `json:"organization_id"`
+	Email             string         `json:"email"`
+	FirstName         string         `json:"first_name"`
+	LastName          string         `json:"last_name"`
+	HashedPassword    string         `json:"hashed_password"`
+	PasswordChangedAt time.Time      `json:"password_changed_at"`
+	CreatedAt         time.Time      `json:"created_at"`
+	WorkspaceID       sql.NullInt64  `json:"workspace_id"`
+	Role              string         `json:"role"`
+	EmailVerifiedAt   sql.NullTime   `json:"email_verified_at"`
+	Title             string         `json:"title"`
+	Pronouns          string         `json:"pronouns"`
+	Phone             string         `json:"phone"`
+	Timezone          string         `json:"timezone"`
+	AvatarKey         sql.NullString `json:"avatar_key"`
+	CustomRoleID      sql.NullInt64  `json:"custom_role_id"`
+	Handle            string         `json:"handle"`
+}
+
+type UserDevice struct {
+	ID            int64        `json:"id"`
+	UserID        int64        `json:"user_id"`
+	Fingerprint   string       `json:"fingerprint"`
+	Name          string       `json:"name"`
+	UserAgent     string       `json:"user_agent"`
+	LastIpAddress string       `json:"last_ip_address"`
+	TrustedUntil  sql.NullTime `json:"trusted_until"`
+	CreatedAt     time.Time    `json:"created_at"`
+	LastSeenAt    time.Time    `json:"last_seen_at"`
 }
 
 type UserStatus struct {
@@ -104,25 +609,157 @@ type UserStatus struct {
 	LastActivityAt time.Time      `json:"last_activity_at"`
 	LastSeenAt     time.Time      `json:"last_seen_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
+	StatusEmoji    sql.NullString `json:"status_emoji"`
+	ClearAfter     sql.NullTime   `json:"clear_after"`
+	SetByCalendar  bool           `json:"set_by_calendar"`
 }
 
-type Workspace struct {
-	ID             int64     `json:"id"`
-	OrganizationID int64     `json:"organization_id"`
-	Name           string    `json:"name"`
-	CreatedAt      time.Time `json:"created_at"`
+type WebhookDelivery struct {
+	ID            int64           `json:"id"`
+	WebhookID     int64           `json:"webhook_id"`
+	EventID       int64           `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	WorkspaceID   int64           `json:"workspace_id"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int32           `json:"attempts"`
+	LastError     string          `json:"last_error"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	DeliveredAt   sql.NullTime    `json:"delivered_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
 }
 
-type WorkspaceInvitation struct {
+type Workspace struct {
 	ID             int64         `json:"id"`
-	WorkspaceID    int64         `json:"workspace_id"`
-	InviterID      int64         `json:"inviter_id"`
-	InviteeEmail   string        `json:"invitee_email"`
-	InviteeID      sql.NullInt64 `json:"invitee_id"`
-	InvitationCode string        `json:"invitation_code"`
-	Role           string        `json:"role"`
-	Status         string        `json:"status"`
-	ExpiresAt      time.Time     `json:"expires_at"`
-	AcceptedAt     sql.NullTime  `json:"accepted_at"`
+	OrganizationID int64         `json:"organization_id"`
+	Name           string        `json:"name"`
 	CreatedAt      time.Time     `json:"created_at"`
+	DeletedAt      sql.NullTime  `json:"deleted_at"`
+	DeletedBy      sql.NullInt64 `json:"deleted_by"`
+}
+
+type WorkspaceAutoJoinDomain struct {
+	ID               int64         `json:"id"`
+	WorkspaceID      int64         `json:"workspace_id"`
+	Domain           string        `json:"domain"`
+	RequiresApproval bool          `json:"requires_approval"`
+	CreatedBy        sql.NullInt64 `json:"created_by"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+type WorkspaceBanner struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Text        string        `json:"text"`
+	Severity    string        `json:"severity"`
+	ExpiresAt   sql.NullTime  `json:"expires_at"`
+	CreatedBy   sql.NullInt64 `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type WorkspaceEvent struct {
+	ID          int64           `json:"id"`
+	WorkspaceID int64           `json:"workspace_id"`
+	EventType   string          `json:"event_type"`
+	ActorID     sql.NullInt64   `json:"actor_id"`
+	ChannelID   sql.NullInt64   `json:"channel_id"`
+	Data        json.RawMessage `json:"data"`
+	CreatedAt   time.Time       `json:"created_at"`
+	// Set once the event has been sent to the configured publisher
+	PublishedAt sql.NullTime `json:"published_at"`
+}
+
+type WorkspaceInvitation struct {
+	ID                 int64         `json:"id"`
+	WorkspaceID        int64         `json:"workspace_id"`
+	InviterID          int64         `json:"inviter_id"`
+	InviteeEmail       string        `json:"invitee_email"`
+	InviteeID          sql.NullInt64 `json:"invitee_id"`
+	InvitationCode     string        `json:"invitation_code"`
+	Role               string        `json:"role"`
+	Status             string        `json:"status"`
+	ExpiresAt          time.Time     `json:"expires_at"`
+	AcceptedAt         sql.NullTime  `json:"accepted_at"`
+	CreatedAt          time.Time     `json:"created_at"`
+	EmailStatus        string        `json:"email_status"`
+	EmailAttempts      int32         `json:"email_attempts"`
+	EmailLastAttemptAt sql.NullTime  `json:"email_last_attempt_at"`
+	EmailError         string        `json:"email_error"`
+}
+
+type WorkspaceJoinLink struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Token       string        `json:"token"`
+	CreatedBy   sql.NullInt64 `json:"created_by"`
+	ExpiresAt   sql.NullTime  `json:"expires_at"`
+	MaxUses     sql.NullInt32 `json:"max_uses"`
+	UseCount    int32         `json:"use_count"`
+	RevokedAt   sql.NullTime  `json:"revoked_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type WorkspaceJoinRequest struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	UserID      int64         `json:"user_id"`
+	Status      string        `json:"status"`
+	ReviewedBy  sql.NullInt64 `json:"reviewed_by"`
+	ReviewedAt  sql.NullTime  `json:"reviewed_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type WorkspaceOnboarding struct {
+	WorkspaceID         int64         `json:"workspace_id"`
+	Enabled             bool          `json:"enabled"`
+	WelcomeMessage      string        `json:"welcome_message"`
+	SuggestedChannelIds []int64       `json:"suggested_channel_ids"`
+	Steps               []string      `json:"steps"`
+	BotUserID           sql.NullInt64 `json:"bot_user_id"`
+	UpdatedBy           sql.NullInt64 `json:"updated_by"`
+	UpdatedAt           time.Time     `json:"updated_at"`
+}
+
+type WorkspaceRole struct {
+	ID          int64         `json:"id"`
+	WorkspaceID int64         `json:"workspace_id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Permissions []string      `json:"permissions"`
+	CreatedBy   sql.NullInt64 `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+type WorkspaceSetting struct {
+	WorkspaceID                int64         `json:"workspace_id"`
+	AwayAfterMinutes           sql.NullInt32 `json:"away_after_minutes"`
+	OfflineAfterMinutes        sql.NullInt32 `json:"offline_after_minutes"`
+	UpdatedAt                  time.Time     `json:"updated_at"`
+	MaxReactionsPerUser        sql.NullInt32 `json:"max_reactions_per_user"`
+	MaxDistinctReactions       sql.NullInt32 `json:"max_distinct_reactions"`
+	MessageEditWindowMinutes   sql.NullInt32 `json:"message_edit_window_minutes"`
+	MessageDeleteWindowMinutes sql.NullInt32 `json:"message_delete_window_minutes"`
+	HideDeletedMessages        bool          `json:"hide_deleted_messages"`
+	MaxPinsPerChannel          sql.NullInt32 `json:"max_pins_per_channel"`
+	ImageWatermark             string        `json:"image_watermark"`
+	PublicFiles                bool          `json:"public_files"`
+	PublicFileReferers         []string      `json:"public_file_referers"`
+}
+
+type WorkspaceTeardown struct {
+	WorkspaceID    int64          `json:"workspace_id"`
+	OrganizationID int64          `json:"organization_id"`
+	WorkspaceName  string         `json:"workspace_name"`
+	Status         string         `json:"status"`
+	Step           string         `json:"step"`
+	RowsDeleted    int64          `json:"rows_deleted"`
+	FilesDeleted   int64          `json:"files_deleted"`
+	LastError      sql.NullString `json:"last_error"`
+	StartedAt      sql.NullTime   `json:"started_at"`
+	CompletedAt    sql.NullTime   `json:"completed_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
 }
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerationWords(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	word, err := testQueries.CreateModerationWord(context.Background(), CreateModerationWordParams{
+		WorkspaceID: workspace.ID,
+		Word:        "Heck",
+		Action:      "mask",
+		CreatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Heck", word.Word)
+
+	// Words are unique per workspace regardless of case
+	_, err = testQueries.CreateModerationWord(context.Background(), CreateModerationWordParams{
+		WorkspaceID: workspace.ID,
+		Word:        "heck",
+		Action:      "block",
+	})
+	require.Error(t, err)
+
+	words, err := testQueries.ListModerationWords(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, words, 1)
+
+	rows, err := testQueries.DeleteModerationWord(context.Background(), DeleteModerationWordParams{ID: word.ID, WorkspaceID: workspace.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func TestModerationQueue(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	message := createRandomChannelMessage(t, workspace, channel, user)
+
+	item, err := testQueries.CreateModerationQueueItem(context.Background(), CreateModerationQueueItemParams{
+		WorkspaceID:  workspace.ID,
+		MessageID:    message.ID,
+		MatchedWords: []string{"refund"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", item.Status)
+
+	// Flagging the message again while it is pending updates the same item
+	again, err := testQueries.CreateModerationQueueItem(context.Background(), CreateModerationQueueItemParams{
+		WorkspaceID:  workspace.ID,
+		MessageID:    message.ID,
+		MatchedWords: []string{"refund", "chargeback"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, item.ID, again.ID)
+	require.Equal(t, []string{"refund", "chargeback"}, again.MatchedWords)
+
+	pending, err := testQueries.ListModerationQueue(context.Background(), ListModerationQueueParams{
+		WorkspaceID: workspace.ID,
+		Status:      "pending",
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, message.Content, pending[0].Content)
+
+	resolved, err := testQueries.ResolveModerationQueueItem(context.Background(), ResolveModerationQueueItemParams{
+		ID:          item.ID,
+		WorkspaceID: workspace.ID,
+		Status:      "approved",
+		ReviewedBy:  sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "approved", resolved.Status)
+	require.True(t, resolved.ReviewedAt.Valid)
+
+	_, err = testQueries.ResolveModerationQueueItem(context.Background(), ResolveModerationQueueItemParams{
+		ID:          item.ID,
+		WorkspaceID: workspace.ID,
+		Status:      "removed",
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	entry, err := testQueries.CreateModerationAuditEntry(context.Background(), CreateModerationAuditEntryParams{
+		WorkspaceID:  workspace.ID,
+		MessageID:    sql.NullInt64{Int64: message.ID, Valid: true},
+		AuthorID:     sql.NullInt64{Int64: user.ID, Valid: true},
+		ActorID:      sql.NullInt64{Int64: user.ID, Valid: true},
+		Action:       "approved",
+		MatchedWords: []string{"refund"},
+	})
+	require.NoError(t, err)
+
+	entries, err := testQueries.ListModerationAuditLog(context.Background(), ListModerationAuditLogParams{
+		WorkspaceID: workspace.ID,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, entry.ID, entries[0].ID)
+}
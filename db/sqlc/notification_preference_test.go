@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertWorkspaceNotificationPreference(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	arg := UpsertWorkspaceNotificationPreferenceParams{
+		UserID:       user.ID,
+		WorkspaceID:  workspace.ID,
+		Level:        "all",
+		EmailEnabled: true,
+		PushEnabled:  true,
+	}
+	first, err := testQueries.UpsertWorkspaceNotificationPreference(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, first.ChannelID.Valid)
+
+	// Upserting again updates the row instead of adding a second one
+	arg.Level = "nothing"
+	arg.EmailEnabled = false
+	second, err := testQueries.UpsertWorkspaceNotificationPreference(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID)
+	require.Equal(t, "nothing", second.Level)
+	require.False(t, second.EmailEnabled)
+
+	preferences, err := testQueries.ListNotificationPreferences(context.Background(), ListNotificationPreferencesParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, preferences, 1)
+
+	rows, err := testQueries.DeleteWorkspaceNotificationPreference(context.Background(), DeleteWorkspaceNotificationPreferenceParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func TestChannelNotificationPreference(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	otherChannel := createRandomChannel(t, workspace, user)
+
+	_, err := testQueries.UpsertWorkspaceNotificationPreference(context.Background(), UpsertWorkspaceNotificationPreferenceParams{
+		UserID:       user.ID,
+		WorkspaceID:  workspace.ID,
+		Level:        "mentions",
+		EmailEnabled: true,
+		PushEnabled:  true,
+	})
+	require.NoError(t, err)
+
+	arg := UpsertChannelNotificationPreferenceParams{
+		UserID:       user.ID,
+		WorkspaceID:  workspace.ID,
+		ChannelID:    channel.ID,
+		Level:        "all",
+		EmailEnabled: false,
+		PushEnabled:  true,
+	}
+	first, err := testQueries.UpsertChannelNotificationPreference(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, channel.ID, first.ChannelID.Int64)
+
+	arg.Level = "nothing"
+	second, err := testQueries.UpsertChannelNotificationPreference(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID)
+
+	// The channel's own preference wins over the workspace-wide one
+	effective, err := testQueries.GetEffectiveNotificationPreference(context.Background(), GetEffectiveNotificationPreferenceParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		ChannelID:   channel.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "nothing", effective.Level)
+
+	effective, err = testQueries.GetEffectiveNotificationPreference(context.Background(), GetEffectiveNotificationPreferenceParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		ChannelID:   otherChannel.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "mentions", effective.Level)
+	require.False(t, effective.ChannelID.Valid)
+
+	preferences, err := testQueries.ListNotificationPreferences(context.Background(), ListNotificationPreferencesParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, preferences, 2)
+	require.False(t, preferences[0].ChannelID.Valid)
+
+	rows, err := testQueries.DeleteChannelNotificationPreference(context.Background(), DeleteChannelNotificationPreferenceParams{
+		UserID:    user.ID,
+		ChannelID: channel.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
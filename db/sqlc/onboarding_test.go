@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceOnboarding(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	public, err := testQueries.CreateChannel(context.Background(), CreateChannelParams{
+		WorkspaceID: workspace.ID,
+		Name:        util.RandomString(10),
+		CreatedBy:   user.ID,
+	})
+	require.NoError(t, err)
+	private, err := testQueries.CreateChannel(context.Background(), CreateChannelParams{
+		WorkspaceID: workspace.ID,
+		Name:        util.RandomString(10),
+		IsPrivate:   true,
+		CreatedBy:   user.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetWorkspaceOnboarding(context.Background(), workspace.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg := UpsertWorkspaceOnboardingParams{
+		WorkspaceID:         workspace.ID,
+		Enabled:             true,
+		WelcomeMessage:      "Welcome, {{.FirstName}}!",
+		SuggestedChannelIds: []int64{public.ID},
+		Steps:               []string{"add_photo", "join_channels"},
+		UpdatedBy:           sql.NullInt64{Int64: user.ID, Valid: true},
+	}
+	onboarding, err := testQueries.UpsertWorkspaceOnboarding(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.SuggestedChannelIds, onboarding.SuggestedChannelIds)
+	require.Equal(t, arg.Steps, onboarding.Steps)
+	require.False(t, onboarding.BotUserID.Valid)
+
+	arg.Enabled = false
+	onboarding, err = testQueries.UpsertWorkspaceOnboarding(context.Background(), arg)
+	require.NoError(t, err)
+	require.False(t, onboarding.Enabled)
+
+	// Only public channels are listed
+	channels, err := testQueries.ListOnboardingChannels(context.Background(), ListOnboardingChannelsParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		ChannelIds:  []int64{public.ID, private.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	require.Equal(t, public.ID, channels[0].ID)
+	require.False(t, channels[0].IsMember)
+
+	_, err = testQueries.AddChannelMember(context.Background(), AddChannelMemberParams{
+		ChannelID: public.ID,
+		UserID:    user.ID,
+		AddedBy:   user.ID,
+		Role:      "member",
+	})
+	require.NoError(t, err)
+	channels, err = testQueries.ListOnboardingChannels(context.Background(), ListOnboardingChannelsParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		ChannelIds:  []int64{public.ID},
+	})
+	require.NoError(t, err)
+	require.True(t, channels[0].IsMember)
+
+	// Completing a step twice keeps the first completion
+	for i := 0; i < 2; i++ {
+		err = testQueries.CompleteOnboardingStep(context.Background(), CompleteOnboardingStepParams{
+			UserID:      user.ID,
+			WorkspaceID: workspace.ID,
+			Step:        "add_photo",
+		})
+		require.NoError(t, err)
+	}
+	completions, err := testQueries.ListOnboardingStepCompletions(context.Background(), ListOnboardingStepCompletionsParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, completions, 1)
+	require.Equal(t, "add_photo", completions[0].Step)
+
+	posted, err := testQueries.HasUserPostedInWorkspace(context.Background(), HasUserPostedInWorkspaceParams{
+		WorkspaceID: workspace.ID,
+		SenderID:    user.ID,
+	})
+	require.NoError(t, err)
+	require.False(t, posted)
+}
+
+func TestGetOnboardingBotTx(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	store := NewStore(testDB)
+
+	arg := GetOnboardingBotTxParams{
+		WorkspaceID: workspace.ID,
+		Bot: CreateUserParams{
+			OrganizationID: workspace.OrganizationID,
+			Email:          fmt.Sprintf("onboarding-bot+%d@goslack.invalid", workspace.ID),
+			FirstName:      "Onboarding",
+			LastName:       "Bot",
+			HashedPassword: "!",
+		},
+	}
+
+	// Workspaces without onboarding have no bot
+	_, err := store.GetOnboardingBotTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.UpsertWorkspaceOnboarding(context.Background(), UpsertWorkspaceOnboardingParams{
+		WorkspaceID:         workspace.ID,
+		Enabled:             true,
+		WelcomeMessage:      "Welcome!",
+		SuggestedChannelIds: []int64{},
+		Steps:               []string{},
+		UpdatedBy:           sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	bot, err := store.GetOnboardingBotTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Bot.Email, bot.Email)
+	require.Equal(t, workspace.ID, bot.WorkspaceID.Int64)
+	require.Equal(t, "member", bot.Role)
+
+	// Later welcomes reuse the bot
+	again, err := store.GetOnboardingBotTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, bot.ID, again.ID)
+
+	onboarding, err := testQueries.GetWorkspaceOnboarding(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Equal(t, bot.ID, onboarding.BotUserID.Int64)
+}
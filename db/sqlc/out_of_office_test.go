@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutOfOffice(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	arg := UpsertOutOfOfficeParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		StartsAt:    time.Now().Add(time.Hour),
+		EndsAt:      time.Now().Add(48 * time.Hour),
+		Message:     "On vacation",
+	}
+	outOfOffice, err := testQueries.UpsertOutOfOffice(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Message, outOfOffice.Message)
+
+	// The window has not started yet
+	_, err = testQueries.GetActiveOutOfOffice(context.Background(), GetActiveOutOfOfficeParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	arg.StartsAt = time.Now().Add(-time.Hour)
+	_, err = testQueries.UpsertOutOfOffice(context.Background(), arg)
+	require.NoError(t, err)
+
+	active, err := testQueries.GetActiveOutOfOffice(context.Background(), GetActiveOutOfOfficeParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, active.UserID)
+
+	rows, err := testQueries.DeleteOutOfOffice(context.Background(), DeleteOutOfOfficeParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
+
+func TestRecordOutOfOfficeReply(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	sender := createRandomUserForOrganization(t, workspace.OrganizationID)
+
+	arg := RecordOutOfOfficeReplyParams{
+		UserID:   user.ID,
+		SenderID: sender.ID,
+	}
+	rows, err := testQueries.RecordOutOfOfficeReply(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	// Only one auto-reply per sender per day
+	rows, err = testQueries.RecordOutOfOfficeReply(context.Background(), arg)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+}
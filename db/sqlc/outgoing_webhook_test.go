@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileEventsQueueWebhookDeliveries(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	webhook, err := testQueries.CreateOutgoingWebhook(context.Background(), CreateOutgoingWebhookParams{
+		WorkspaceID: workspace.ID,
+		Url:         "https://hooks.example.com/" + util.RandomString(8),
+		Secret:      util.RandomString(64),
+		EventTypes:  []string{"file.uploaded", "file.deleted"},
+		CreatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"file.uploaded", "file.deleted"}, webhook.EventTypes)
+
+	file := createRandomFileForWorkspace(t, workspace.ID, user.ID)
+	trashed, err := testQueries.TrashFile(context.Background(), TrashFileParams{
+		ID:        file.ID,
+		DeletedBy: sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), trashed)
+
+	// Each subscribed event is queued once
+	deliveries := claimDeliveriesOf(t, webhook.ID)
+	require.Len(t, deliveries, 2)
+	require.Equal(t, "file.uploaded", deliveries[0].EventType)
+	require.Equal(t, "file.deleted", deliveries[1].EventType)
+	for _, delivery := range deliveries {
+		require.Equal(t, workspace.ID, delivery.WorkspaceID)
+		require.Equal(t, "sending", delivery.Status)
+		require.Equal(t, int32(1), delivery.Attempts)
+	}
+
+	var data struct {
+		FileID    int64 `json:"file_id"`
+		Permanent bool  `json:"permanent"`
+	}
+	require.NoError(t, json.Unmarshal(deliveries[1].Payload, &data))
+	require.Equal(t, file.ID, data.FileID)
+	require.False(t, data.Permanent)
+
+	// A failed attempt is taken again once it's due
+	require.NoError(t, testQueries.RetryWebhookDelivery(context.Background(), RetryWebhookDeliveryParams{
+		ID:            deliveries[0].ID,
+		LastError:     "webhook returned 503 Service Unavailable",
+		NextAttemptAt: time.Now().Add(-time.Second),
+	}))
+	require.NoError(t, testQueries.MarkWebhookDelivered(context.Background(), deliveries[1].ID))
+
+	retried := claimDeliveriesOf(t, webhook.ID)
+	require.Len(t, retried, 1)
+	require.Equal(t, deliveries[0].ID, retried[0].ID)
+	require.Equal(t, int32(2), retried[0].Attempts)
+
+	// Deleting the webhook drops its deliveries
+	deleted, err := testQueries.DeleteOutgoingWebhook(context.Background(), DeleteOutgoingWebhookParams{
+		ID:          webhook.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	webhooks, err := testQueries.ListOutgoingWebhooks(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+}
+
+func TestCreateWebhookEventIsNotBroadcast(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	event, err := testQueries.CreateWebhookEvent(context.Background(), CreateWebhookEventParams{
+		EventType:   "file.scanned",
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+		Payload:     json.RawMessage(`{"result":"rejected"}`),
+	})
+	require.NoError(t, err)
+	require.True(t, event.PublishedAt.Valid)
+	require.Empty(t, event.RecipientIds)
+}
+
+// claimDeliveriesOf claims the due deliveries and returns the webhook's
+// in the order they were queued
+func claimDeliveriesOf(t *testing.T, webhookID int64) []WebhookDelivery {
+	claimed, err := testQueries.ClaimWebhookDeliveries(context.Background(), ClaimWebhookDeliveriesParams{
+		StaleBefore: time.Now().Add(-time.Hour),
+		Limit:       1000,
+	})
+	require.NoError(t, err)
+
+	var deliveries []WebhookDelivery
+	for _, delivery := range claimed {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].EventID < deliveries[j].EventID })
+	return deliveries
+}
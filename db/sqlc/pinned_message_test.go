@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinnedMessages(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	message1 := createRandomChannelMessage(t, workspace, channel, user)
+	message2 := createRandomChannelMessage(t, workspace, channel, user)
+
+	for i, message := range []Message{message1, message2} {
+		pin, err := testQueries.PinMessage(context.Background(), PinMessageParams{
+			MessageID: message.ID,
+			ChannelID: channel.ID,
+			PinnedBy:  user.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int32(i+1), pin.Position)
+	}
+
+	// Pinning a message twice returns no rows
+	_, err := testQueries.PinMessage(context.Background(), PinMessageParams{
+		MessageID: message1.ID,
+		ChannelID: channel.ID,
+		PinnedBy:  user.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	count, err := testQueries.CountChannelPins(context.Background(), channel.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	affected, err := testQueries.ReorderPinnedMessages(context.Background(), ReorderPinnedMessagesParams{
+		MessageIds: []int64{message2.ID, message1.ID},
+		ChannelID:  channel.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), affected)
+
+	pins, err := testQueries.ListPinnedMessages(context.Background(), channel.ID)
+	require.NoError(t, err)
+	require.Len(t, pins, 2)
+	require.Equal(t, message2.ID, pins[0].ID)
+	require.Equal(t, user.ID, pins[0].PinnedBy)
+
+	_, err = testQueries.UnpinMessage(context.Background(), message2.ID)
+	require.NoError(t, err)
+
+	ids, err := testQueries.ListChannelPinIDs(context.Background(), channel.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int64{message1.ID}, ids)
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"strconv"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// OpenDB connects to the database through a pgx connection pool sized by the
+// DB_ pool settings, with DB_STATEMENT_TIMEOUT applied to every statement. The queries run on the returned *sql.DB, which borrows
+// its connections from the pool; close the pool after the *sql.DB.
+func OpenDB(ctx context.Context, config util.Config) (*sql.DB, *pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(config.DBSource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+
+	if config.DBMaxConns > 0 {
+		poolConfig.MaxConns = config.DBMaxConns
+	}
+	if config.DBMinConns > 0 {
+		poolConfig.MinConns = config.DBMinConns
+	}
+	if config.DBMaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = config.DBMaxConnLifetime
+	}
+	if config.DBMaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = config.DBMaxConnIdleTime
+	}
+	if config.DBHealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = config.DBHealthCheckPeriod
+	}
+	// The server cancels statements that run too long, including those whose
+	// request context never ends, such as background jobs
+	if config.DBStatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(config.DBStatementTimeout.Milliseconds(), 10)
+	}
+	if poolConfig.MinConns > poolConfig.MaxConns {
+		return nil, nil, fmt.Errorf("invalid database configuration: DB_MIN_CONNS (%d) exceeds DB_MAX_CONNS (%d)", poolConfig.MinConns, poolConfig.MaxConns)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stdlib.OpenDBFromPool(pool), pool, nil
+}
+
+// PublishPoolMetrics adds the pool's statistics to /debug/vars as "db_pool".
+// It must be called at most once.
+func PublishPoolMetrics(pool *pgxpool.Pool) {
+	expvar.Publish("db_pool", expvar.Func(func() any {
+		stat := pool.Stat()
+		return map[string]any{
+			"max_conns":                  stat.MaxConns(),
+			"total_conns":                stat.TotalConns(),
+			"idle_conns":                 stat.IdleConns(),
+			"acquired_conns":             stat.AcquiredConns(),
+			"constructing_conns":         stat.ConstructingConns(),
+			"acquire_count":              stat.AcquireCount(),
+			"acquire_duration_ms":        stat.AcquireDuration().Milliseconds(),
+			"empty_acquire_count":        stat.EmptyAcquireCount(),
+			"canceled_acquire_count":     stat.CanceledAcquireCount(),
+			"new_conns_count":            stat.NewConnsCount(),
+			"max_lifetime_destroy_count": stat.MaxLifetimeDestroyCount(),
+			"max_idle_destroy_count":     stat.MaxIdleDestroyCount(),
+		}
+	}))
+}
@@ -12,92 +12,584 @@ import (
 
 type Querier interface {
 	AcceptWorkspaceInvitation(ctx context.Context, arg AcceptWorkspaceInvitationParams) (WorkspaceInvitation, error)
+	// Acknowledging again keeps the time of the first acknowledgment
+	AcknowledgeMessage(ctx context.Context, arg AcknowledgeMessageParams) (MessageAcknowledgment, error)
 	AddChannelMember(ctx context.Context, arg AddChannelMemberParams) (ChannelMember, error)
+	AddReaction(ctx context.Context, arg AddReactionParams) (MessageReaction, error)
 	AddUserToWorkspace(ctx context.Context, arg AddUserToWorkspaceParams) (User, error)
+	// Sets every member's channel preference to the channel's default,
+	// replacing the ones they picked themselves
+	ApplyChannelNotificationDefault(ctx context.Context, channelID int64) (int64, error)
+	// Only deletions still in their cancellation window can be cancelled
+	CancelOrganizationDeletion(ctx context.Context, arg CancelOrganizationDeletionParams) (OrganizationDeletion, error)
+	// A channel holds content under legal hold if it is held itself or a held user
+	// posted in it
+	ChannelHasActiveLegalHold(ctx context.Context, targetID int64) (bool, error)
 	CheckChannelMembership(ctx context.Context, arg CheckChannelMembershipParams) (string, error)
 	// Check if user has access to file through direct ownership, channel membership, or direct share
 	CheckFileAccess(ctx context.Context, arg CheckFileAccessParams) (bool, error)
 	CheckMessageAuthor(ctx context.Context, id int64) (int64, error)
 	CheckUserInWorkspace(ctx context.Context, arg CheckUserInWorkspaceParams) (bool, error)
+	// Organization owners and admins act as admins of every workspace in their
+	// organization. Nobody has a role in a deleted workspace, or in one whose
+	// organization is being deleted.
 	CheckUserWorkspaceRole(ctx context.Context, arg CheckUserWorkspaceRoleParams) (string, error)
-	CleanupIncompleteUploads(ctx context.Context) error
+	// Marks the requests whose reminder is due as reminded and returns them, so
+	// only one server sends each reminder
+	ClaimDueAcknowledgmentReminders(ctx context.Context, limit int32) ([]MessageAcknowledgmentRequest, error)
+	// Takes due emails for sending. Emails stuck in sending since before
+	// stale_before, because a worker stopped mid-send, are taken again.
+	ClaimEmailDeliveries(ctx context.Context, arg ClaimEmailDeliveriesParams) ([]EmailDelivery, error)
+	// Takes due deliveries for sending. Deliveries stuck in sending since before
+	// stale_before, because a worker stopped mid-send, are taken again.
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	CleanupIncompleteUploads(ctx context.Context) (int64, error)
+	// Statuses set from a calendar also return from busy to online
+	ClearExpiredCustomStatuses(ctx context.Context) ([]UserStatus, error)
+	// Ends Do Not Disturb that was on until a time that has passed, such as a
+	// snooze, and returns the statuses of the users whose presence changes
+	ClearExpiredDoNotDisturb(ctx context.Context) ([]UserStatus, error)
+	// Withdraws a pending request or ends an accepted conversation
+	CloseExternalDMRequest(ctx context.Context, id int64) (ExternalDmRequest, error)
+	CompleteOnboardingStep(ctx context.Context, arg CompleteOnboardingStepParams) error
+	CompleteOrganizationDeletion(ctx context.Context, id int64) error
+	CompleteWorkspaceTeardown(ctx context.Context, workspaceID int64) error
+	CountChannelMessages(ctx context.Context, arg CountChannelMessagesParams) (int64, error)
+	// Pins of deleted messages count towards neither the limit nor the order
+	CountChannelPins(ctx context.Context, channelID int64) (int64, error)
+	CountDirectMessagesBetweenUsers(ctx context.Context, arg CountDirectMessagesBetweenUsersParams) (int64, error)
+	CountOrganizationOwners(ctx context.Context, organizationID int64) (int64, error)
+	// Counts the organization's workspaces that haven't been torn down, deleted ones included
+	CountOrganizationWorkspaces(ctx context.Context, organizationID int64) (int64, error)
+	// Counts the mentions ListUserMentions would list as unread
+	CountUnreadMentions(ctx context.Context, arg CountUnreadMentionsParams) (int64, error)
+	CreateAbuseReport(ctx context.Context, arg CreateAbuseReportParams) (AbuseReport, error)
+	CreateAcknowledgmentRequest(ctx context.Context, arg CreateAcknowledgmentRequestParams) (MessageAcknowledgmentRequest, error)
+	CreateCalendarBusyBlock(ctx context.Context, arg CreateCalendarBusyBlockParams) error
+	CreateCanvas(ctx context.Context, arg CreateCanvasParams) (Canvas, error)
+	CreateCanvasRevision(ctx context.Context, arg CreateCanvasRevisionParams) (CanvasRevision, error)
 	CreateChannel(ctx context.Context, arg CreateChannelParams) (Channel, error)
 	CreateChannelMessage(ctx context.Context, arg CreateChannelMessageParams) (Message, error)
+	CreateCustomEmoji(ctx context.Context, arg CreateCustomEmojiParams) (CustomEmoji, error)
+	// Affects no rows when the message already broke through Do Not Disturb
+	CreateDNDOverride(ctx context.Context, arg CreateDNDOverrideParams) (int64, error)
 	CreateDirectMessage(ctx context.Context, arg CreateDirectMessageParams) (Message, error)
+	CreateEmailDelivery(ctx context.Context, arg CreateEmailDeliveryParams) (EmailDelivery, error)
+	CreateEmailVerification(ctx context.Context, arg CreateEmailVerificationParams) (EmailVerification, error)
+	// Returns no row when the two people already have an open request or
+	// conversation
+	CreateExternalDMRequest(ctx context.Context, arg CreateExternalDMRequestParams) (ExternalDmRequest, error)
 	CreateFile(ctx context.Context, arg CreateFileParams) (File, error)
 	CreateFileShare(ctx context.Context, arg CreateFileShareParams) (FileShare, error)
+	CreateLegalHold(ctx context.Context, arg CreateLegalHoldParams) (LegalHold, error)
+	CreateLoginEvent(ctx context.Context, arg CreateLoginEventParams) (LoginEvent, error)
+	CreateMessageArchive(ctx context.Context, arg CreateMessageArchiveParams) (MessageArchive, error)
+	// Creates a message with its original timestamp, for importing or seeding history
+	CreateMessageAt(ctx context.Context, arg CreateMessageAtParams) (Message, error)
 	CreateMessageFile(ctx context.Context, arg CreateMessageFileParams) (MessageFile, error)
+	CreateMessageMentions(ctx context.Context, arg CreateMessageMentionsParams) error
+	// Creates the partition for the month of the date unless it exists
+	CreateMessagePartition(ctx context.Context, month time.Time) (bool, error)
+	// Adds many reactions in one statement from parallel arrays, skipping any
+	// the user already has on the message
+	CreateMessageReactions(ctx context.Context, arg CreateMessageReactionsParams) (int64, error)
+	CreateModerationAuditEntry(ctx context.Context, arg CreateModerationAuditEntryParams) (ModerationAuditLog, error)
+	CreateModerationQueueItem(ctx context.Context, arg CreateModerationQueueItemParams) (ModerationQueue, error)
+	CreateModerationWord(ctx context.Context, arg CreateModerationWordParams) (ModerationWord, error)
 	CreateOrganization(ctx context.Context, name string) (Organization, error)
+	CreateOrganizationDeletion(ctx context.Context, arg CreateOrganizationDeletionParams) (OrganizationDeletion, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (EventOutbox, error)
+	CreateOutgoingWebhook(ctx context.Context, arg CreateOutgoingWebhookParams) (OutgoingWebhook, error)
+	CreateSavedSearch(ctx context.Context, arg CreateSavedSearchParams) (SavedSearch, error)
+	// Creates a reply in the conversation of the thread's parent message
+	CreateThreadReply(ctx context.Context, arg CreateThreadReplyParams) (Message, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// Writes an event for webhooks only. It's written already published, so the
+	// outbox relay doesn't broadcast it to clients.
+	CreateWebhookEvent(ctx context.Context, arg CreateWebhookEventParams) (EventOutbox, error)
 	CreateWorkspace(ctx context.Context, arg CreateWorkspaceParams) (Workspace, error)
+	CreateWorkspaceAutoJoinDomain(ctx context.Context, arg CreateWorkspaceAutoJoinDomainParams) (WorkspaceAutoJoinDomain, error)
+	CreateWorkspaceBanner(ctx context.Context, arg CreateWorkspaceBannerParams) (WorkspaceBanner, error)
 	CreateWorkspaceInvitation(ctx context.Context, arg CreateWorkspaceInvitationParams) (WorkspaceInvitation, error)
+	CreateWorkspaceJoinLink(ctx context.Context, arg CreateWorkspaceJoinLinkParams) (WorkspaceJoinLink, error)
+	CreateWorkspaceJoinRequest(ctx context.Context, arg CreateWorkspaceJoinRequestParams) (WorkspaceJoinRequest, error)
+	CreateWorkspaceRole(ctx context.Context, arg CreateWorkspaceRoleParams) (WorkspaceRole, error)
+	CreateWorkspaceTeardown(ctx context.Context, arg CreateWorkspaceTeardownParams) error
+	CustomEmojiExists(ctx context.Context, arg CustomEmojiExistsParams) (bool, error)
 	DeclineWorkspaceInvitation(ctx context.Context, invitationCode string) (WorkspaceInvitation, error)
+	// Deletes messages once they are archived; the trigger on messages removes
+	// their reactions and other dependents
+	DeleteArchivedMessages(ctx context.Context, ids []int64) (int64, error)
+	DeleteCalendarBusyBlocks(ctx context.Context, integrationID int64) error
+	DeleteCalendarIntegration(ctx context.Context, arg DeleteCalendarIntegrationParams) (int64, error)
+	DeleteCanvas(ctx context.Context, id int64) error
 	DeleteChannel(ctx context.Context, id int64) error
+	DeleteChannelDailyPosterStats(ctx context.Context, arg DeleteChannelDailyPosterStatsParams) error
+	DeleteChannelDailyStats(ctx context.Context, arg DeleteChannelDailyStatsParams) error
+	DeleteChannelNotificationDefault(ctx context.Context, channelID int64) (int64, error)
+	DeleteChannelNotificationPreference(ctx context.Context, arg DeleteChannelNotificationPreferenceParams) (int64, error)
+	DeleteCustomEmoji(ctx context.Context, id int64) error
+	DeleteEmailSuppression(ctx context.Context, email string) (int64, error)
+	DeleteEmailTemplate(ctx context.Context, arg DeleteEmailTemplateParams) (int64, error)
+	DeleteFeatureFlag(ctx context.Context, arg DeleteFeatureFlagParams) (int64, error)
 	DeleteFile(ctx context.Context, arg DeleteFileParams) error
+	DeleteFilesByIDs(ctx context.Context, ids []int64) (int64, error)
+	DeleteMessageDraft(ctx context.Context, arg DeleteMessageDraftParams) (MessageDraft, error)
+	DeleteMessageDraftForTarget(ctx context.Context, arg DeleteMessageDraftForTargetParams) (MessageDraft, error)
 	DeleteMessageFile(ctx context.Context, arg DeleteMessageFileParams) error
+	DeleteModerationWord(ctx context.Context, arg DeleteModerationWordParams) (int64, error)
+	DeleteOldEmailDeliveries(ctx context.Context, createdAt time.Time) (int64, error)
+	DeleteOldWebhookDeliveries(ctx context.Context, createdAt time.Time) (int64, error)
 	DeleteOrganization(ctx context.Context, id int64) error
+	DeleteOrganizationRole(ctx context.Context, arg DeleteOrganizationRoleParams) (int64, error)
+	DeleteOutOfOffice(ctx context.Context, arg DeleteOutOfOfficeParams) (int64, error)
+	DeleteOutgoingWebhook(ctx context.Context, arg DeleteOutgoingWebhookParams) (int64, error)
+	DeletePublishedOutboxEvents(ctx context.Context, publishedAt sql.NullTime) (int64, error)
+	DeleteReactionsByID(ctx context.Context, ids []int64) (int64, error)
+	DeleteSavedSearch(ctx context.Context, arg DeleteSavedSearchParams) error
 	DeleteUser(ctx context.Context, id int64) error
+	DeleteUserDevice(ctx context.Context, arg DeleteUserDeviceParams) (int64, error)
 	DeleteWorkspace(ctx context.Context, id int64) error
+	DeleteWorkspaceAutoJoinDomain(ctx context.Context, arg DeleteWorkspaceAutoJoinDomainParams) (int64, error)
+	DeleteWorkspaceBanner(ctx context.Context, arg DeleteWorkspaceBannerParams) (int64, error)
+	// Removes events older than the retention
+	DeleteWorkspaceEvents(ctx context.Context, createdAt time.Time) (int64, error)
 	DeleteWorkspaceInvitation(ctx context.Context, id int64) error
+	DeleteWorkspaceInvitationsBatch(ctx context.Context, arg DeleteWorkspaceInvitationsBatchParams) (int64, error)
+	DeleteWorkspaceMessagesBatch(ctx context.Context, arg DeleteWorkspaceMessagesBatchParams) (int64, error)
+	DeleteWorkspaceNotificationPreference(ctx context.Context, arg DeleteWorkspaceNotificationPreferenceParams) (int64, error)
+	// Per-user settings are bounded by the member count, so they go in one statement
+	DeleteWorkspacePreferences(ctx context.Context, workspaceID int64) (int64, error)
+	DeleteWorkspaceRole(ctx context.Context, arg DeleteWorkspaceRoleParams) (int64, error)
+	DeleteWorkspaceStatuses(ctx context.Context, workspaceID int64) (int64, error)
+	// Lifts the statement timeout for the rest of the transaction
+	DisableStatementTimeout(ctx context.Context) error
+	// Drops a monthly partition and what depends on its messages, unless it
+	// holds messages under an active legal hold
+	DropMessagePartition(ctx context.Context, name string) (bool, error)
+	// Makes the user the organization's owner if it has no owner yet
+	EnsureOrganizationOwner(ctx context.Context, arg EnsureOrganizationOwnerParams) error
 	ExpireWorkspaceInvitation(ctx context.Context, id int64) error
+	FailEmailDelivery(ctx context.Context, arg FailEmailDeliveryParams) error
+	FailWebhookDelivery(ctx context.Context, arg FailWebhookDeliveryParams) error
+	GetAbuseReport(ctx context.Context, arg GetAbuseReportParams) (AbuseReport, error)
+	GetAcknowledgmentRequest(ctx context.Context, messageID int64) (MessageAcknowledgmentRequest, error)
+	// Returns the end of the current busy period for every user with an enabled
+	// calendar who is in a meeting right now
+	GetActiveCalendarBusyBlocks(ctx context.Context) ([]GetActiveCalendarBusyBlocksRow, error)
+	GetActiveOutOfOffice(ctx context.Context, arg GetActiveOutOfOfficeParams) (OutOfOffice, error)
+	GetCalendarIntegration(ctx context.Context, arg GetCalendarIntegrationParams) (CalendarIntegration, error)
+	GetCanvas(ctx context.Context, arg GetCanvasParams) (Canvas, error)
+	GetCanvasRevision(ctx context.Context, arg GetCanvasRevisionParams) (CanvasRevision, error)
 	GetChannel(ctx context.Context, id int64) (Channel, error)
 	GetChannelByID(ctx context.Context, id int64) (Channel, error)
+	GetChannelByName(ctx context.Context, arg GetChannelByNameParams) (Channel, error)
+	// When the channel last had a message and when that day was rolled up
+	GetChannelLastActivity(ctx context.Context, channelID int64) (GetChannelLastActivityRow, error)
 	GetChannelMembers(ctx context.Context, arg GetChannelMembersParams) ([]GetChannelMembersRow, error)
 	GetChannelMessages(ctx context.Context, arg GetChannelMessagesParams) ([]GetChannelMessagesRow, error)
+	GetChannelNotificationDefault(ctx context.Context, channelID int64) (ChannelNotificationDefault, error)
+	GetChannelReadState(ctx context.Context, arg GetChannelReadStateParams) (ChannelReadState, error)
 	GetChannelWithCreator(ctx context.Context, id int64) (GetChannelWithCreatorRow, error)
+	GetCustomEmoji(ctx context.Context, arg GetCustomEmojiParams) (CustomEmoji, error)
 	GetDirectMessagesBetweenUsers(ctx context.Context, arg GetDirectMessagesBetweenUsersParams) ([]GetDirectMessagesBetweenUsersRow, error)
+	// Whether Do Not Disturb is on depends on the user's timezone, so it comes along
+	GetDoNotDisturb(ctx context.Context, arg GetDoNotDisturbParams) (GetDoNotDisturbRow, error)
 	GetDuplicateFiles(ctx context.Context, workspaceID int64) ([]GetDuplicateFilesRow, error)
+	// When the first channel message still counted was posted
+	GetEarliestChannelMessageTime(ctx context.Context) (time.Time, error)
+	// The channel's own preference, or the workspace-wide one when it has none
+	GetEffectiveNotificationPreference(ctx context.Context, arg GetEffectiveNotificationPreferenceParams) (NotificationPreference, error)
+	GetEmailBranding(ctx context.Context, organizationID int64) (EmailBranding, error)
+	GetEmailSuppression(ctx context.Context, email string) (EmailSuppression, error)
+	GetEmailTemplate(ctx context.Context, arg GetEmailTemplateParams) (EmailTemplate, error)
+	GetExternalDMPolicy(ctx context.Context, organizationID int64) (ExternalDmPolicy, error)
+	GetExternalDMRequest(ctx context.Context, id int64) (GetExternalDMRequestRow, error)
 	GetFile(ctx context.Context, id int64) (File, error)
 	GetFileByHash(ctx context.Context, arg GetFileByHashParams) (File, error)
 	GetFileMessages(ctx context.Context, fileID int64) ([]GetFileMessagesRow, error)
 	GetFileShares(ctx context.Context, fileID int64) ([]GetFileSharesRow, error)
 	GetFileStats(ctx context.Context, workspaceID int64) (GetFileStatsRow, error)
 	GetFileWithPermissionCheck(ctx context.Context, arg GetFileWithPermissionCheckParams) (GetFileWithPermissionCheckRow, error)
+	// The last day the rollup has stats for
+	GetLatestChannelStatsDay(ctx context.Context) (time.Time, error)
+	GetLegalHold(ctx context.Context, arg GetLegalHoldParams) (LegalHold, error)
 	GetMessageByID(ctx context.Context, id int64) (GetMessageByIDRow, error)
 	GetMessageFiles(ctx context.Context, messageID int64) ([]GetMessageFilesRow, error)
+	GetMessageTranslation(ctx context.Context, arg GetMessageTranslationParams) (MessageTranslation, error)
+	// Messages following an anchor message in the same conversation, oldest first.
+	// Scoping works the same way as GetMessagesBefore.
+	GetMessagesAfter(ctx context.Context, arg GetMessagesAfterParams) ([]GetMessagesAfterRow, error)
+	// Messages preceding an anchor message in the same conversation, newest first.
+	// The conversation is a thread when thread_id is set, otherwise a channel or a direct
+	// message pair. Thread replies are left out of channel and direct message context.
+	// Deleted messages are included as tombstones when include_deleted is set.
+	GetMessagesBefore(ctx context.Context, arg GetMessagesBeforeParams) ([]GetMessagesBeforeRow, error)
+	GetModerationQueueItem(ctx context.Context, arg GetModerationQueueItemParams) (ModerationQueue, error)
 	GetOnlineUsersInWorkspace(ctx context.Context, workspaceID int64) ([]GetOnlineUsersInWorkspaceRow, error)
 	GetOrganization(ctx context.Context, id int64) (Organization, error)
+	// The organization's most recent deletion, under way or not
+	GetOrganizationDeletion(ctx context.Context, organizationID int64) (OrganizationDeletion, error)
+	GetOrganizationRole(ctx context.Context, arg GetOrganizationRoleParams) (string, error)
+	// Sums up the teardowns of the workspaces queued since the deletion was requested
+	GetOrganizationTeardownProgress(ctx context.Context, arg GetOrganizationTeardownProgressParams) (GetOrganizationTeardownProgressRow, error)
+	GetOutOfOffice(ctx context.Context, arg GetOutOfOfficeParams) (OutOfOffice, error)
+	GetOutgoingWebhook(ctx context.Context, id int64) (OutgoingWebhook, error)
 	GetPendingInvitationsForUser(ctx context.Context, inviteeEmail string) ([]GetPendingInvitationsForUserRow, error)
+	// Counts what a new reaction is checked against: the user's reactions to the
+	// message, the distinct emojis on it and whether the emoji is one of them
+	GetReactionCounts(ctx context.Context, arg GetReactionCountsParams) (GetReactionCountsRow, error)
 	GetRecentWorkspaceMessages(ctx context.Context, arg GetRecentWorkspaceMessagesParams) ([]GetRecentWorkspaceMessagesRow, error)
+	GetSavedSearch(ctx context.Context, arg GetSavedSearchParams) (SavedSearch, error)
+	GetTrashedFile(ctx context.Context, arg GetTrashedFileParams) (File, error)
 	GetUser(ctx context.Context, id int64) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserChannels(ctx context.Context, arg GetUserChannelsParams) ([]Channel, error)
+	GetUserDevice(ctx context.Context, arg GetUserDeviceParams) (UserDevice, error)
+	// Permissions granted by a user's custom role in the workspace they belong to
+	GetUserRolePermissions(ctx context.Context, arg GetUserRolePermissionsParams) ([]string, error)
 	GetUserStatus(ctx context.Context, arg GetUserStatusParams) (UserStatus, error)
 	GetUsersByWorkspace(ctx context.Context, arg GetUsersByWorkspaceParams) ([]User, error)
 	GetWorkspace(ctx context.Context, id int64) (Workspace, error)
+	GetWorkspaceAutoJoinDomain(ctx context.Context, arg GetWorkspaceAutoJoinDomainParams) (WorkspaceAutoJoinDomain, error)
+	GetWorkspaceBanner(ctx context.Context, arg GetWorkspaceBannerParams) (WorkspaceBanner, error)
 	GetWorkspaceByID(ctx context.Context, id int64) (Workspace, error)
 	GetWorkspaceInvitation(ctx context.Context, id int64) (WorkspaceInvitation, error)
 	GetWorkspaceInvitationByCode(ctx context.Context, invitationCode string) (WorkspaceInvitation, error)
+	GetWorkspaceJoinLinkByToken(ctx context.Context, token string) (WorkspaceJoinLink, error)
+	GetWorkspaceJoinRequest(ctx context.Context, arg GetWorkspaceJoinRequestParams) (WorkspaceJoinRequest, error)
 	GetWorkspaceMemberCount(ctx context.Context, workspaceID sql.NullInt64) (int64, error)
+	GetWorkspaceOnboarding(ctx context.Context, workspaceID int64) (WorkspaceOnboarding, error)
+	GetWorkspaceOnboardingForUpdate(ctx context.Context, workspaceID int64) (WorkspaceOnboarding, error)
+	GetWorkspaceRole(ctx context.Context, arg GetWorkspaceRoleParams) (WorkspaceRole, error)
+	GetWorkspaceSettings(ctx context.Context, workspaceID int64) (WorkspaceSetting, error)
+	GetWorkspaceTeardown(ctx context.Context, arg GetWorkspaceTeardownParams) (WorkspaceTeardown, error)
 	GetWorkspaceUserStatuses(ctx context.Context, arg GetWorkspaceUserStatusesParams) ([]GetWorkspaceUserStatusesRow, error)
 	GetWorkspaceWithUserCount(ctx context.Context, id int64) (GetWorkspaceWithUserCountRow, error)
+	HasActiveLegalHold(ctx context.Context, arg HasActiveLegalHoldParams) (bool, error)
+	HasUserPostedInWorkspace(ctx context.Context, arg HasUserPostedInWorkspaceParams) (bool, error)
 	IsChannelMember(ctx context.Context, arg IsChannelMemberParams) (bool, error)
+	// Whether the file is attached to a message in a private channel
+	IsFileInPrivateChannel(ctx context.Context, fileID int64) (bool, error)
+	ListAbuseReports(ctx context.Context, arg ListAbuseReportsParams) ([]AbuseReport, error)
+	// The members of the message's channel other than its author, with when they
+	// acknowledged it. Those who haven't come last.
+	ListAcknowledgmentRecipients(ctx context.Context, messageID int64) ([]ListAcknowledgmentRecipientsRow, error)
+	// Banners that have not expired yet, most recent first
+	ListActiveWorkspaceBanners(ctx context.Context, workspaceID int64) ([]WorkspaceBanner, error)
+	// Lists the conversations and UTC months holding messages that can be
+	// archived, oldest month first. A message can be archived once it is older
+	// than the cutoff, unless it is deleted, its thread has replies from after
+	// the cutoff, it still has replies of its own (they are archived first) or
+	// it is under an active legal hold.
+	ListArchivableConversations(ctx context.Context, arg ListArchivableConversationsParams) ([]ListArchivableConversationsRow, error)
+	// Workspaces of an organization that allow an email domain, with whether the
+	// user already asked to join them
+	ListAutoJoinWorkspaces(ctx context.Context, arg ListAutoJoinWorkspacesParams) ([]ListAutoJoinWorkspacesRow, error)
+	ListCanvasRevisions(ctx context.Context, arg ListCanvasRevisionsParams) ([]CanvasRevision, error)
+	ListChannelCanvases(ctx context.Context, arg ListChannelCanvasesParams) ([]Canvas, error)
+	ListChannelDailyStats(ctx context.Context, arg ListChannelDailyStatsParams) ([]ChannelDailyStat, error)
+	// The channel messages posted at an instant, the way Slack ts values name
+	// messages. Two at most are returned, enough to tell whether the instant
+	// names a single message.
+	ListChannelMessageIDsAt(ctx context.Context, arg ListChannelMessageIDsAtParams) ([]int64, error)
+	ListChannelNotificationDefaults(ctx context.Context, workspaceID int64) ([]ChannelNotificationDefault, error)
+	ListChannelPinIDs(ctx context.Context, channelID int64) ([]int64, error)
+	ListChannelTopPosters(ctx context.Context, arg ListChannelTopPostersParams) ([]ListChannelTopPostersRow, error)
+	// Counts messages from others after the user's read position in each channel
+	// they belong to in the workspace. Thread replies are counted per thread.
+	ListChannelUnreadCounts(ctx context.Context, arg ListChannelUnreadCountsParams) ([]ListChannelUnreadCountsRow, error)
 	ListChannelsByWorkspace(ctx context.Context, arg ListChannelsByWorkspaceParams) ([]Channel, error)
+	// Channels of the workspace the user can see, the public ones and the private
+	// ones they're a member of, and whether they have joined them
+	ListChannelsForUser(ctx context.Context, arg ListChannelsForUserParams) ([]ListChannelsForUserRow, error)
+	ListCustomEmojis(ctx context.Context, workspaceID int64) ([]CustomEmoji, error)
+	ListDeletedChannels(ctx context.Context, arg ListDeletedChannelsParams) ([]Channel, error)
+	ListDeletedWorkspaces(ctx context.Context, arg ListDeletedWorkspacesParams) ([]Workspace, error)
+	// The direct messages between two users sent at an instant, as in
+	// ListChannelMessageIDsAt
+	ListDirectMessageIDsAt(ctx context.Context, arg ListDirectMessageIDsAtParams) ([]int64, error)
+	// Counts direct messages received after the user's read position in each
+	// conversation in the workspace, most recently active first
+	ListDirectMessageUnreadCounts(ctx context.Context, arg ListDirectMessageUnreadCountsParams) ([]ListDirectMessageUnreadCountsRow, error)
+	ListDueOrganizationDeletions(ctx context.Context, arg ListDueOrganizationDeletionsParams) ([]OrganizationDeletion, error)
+	ListEmailDeliveriesByAddress(ctx context.Context, arg ListEmailDeliveriesByAddressParams) ([]EmailDelivery, error)
+	ListEmailSuppressionsByEmails(ctx context.Context, emails []string) ([]EmailSuppression, error)
+	ListEmailTemplates(ctx context.Context, organizationID int64) ([]EmailTemplate, error)
+	ListEnabledCalendarIntegrations(ctx context.Context) ([]CalendarIntegration, error)
+	// Requests the user sent or received, newest first, optionally only those
+	// with a status
+	ListExternalDMRequests(ctx context.Context, arg ListExternalDMRequestsParams) ([]ListExternalDMRequestsRow, error)
+	ListFeatureFlags(ctx context.Context, workspaceID int64) ([]FeatureFlag, error)
+	// A file's downloads, most recent first, with who downloaded it
+	ListFileAccessLog(ctx context.Context, arg ListFileAccessLogParams) ([]ListFileAccessLogRow, error)
+	// Pages through every file with the paths it keeps in the file store
+	ListFilesForReconciliation(ctx context.Context, arg ListFilesForReconciliationParams) ([]ListFilesForReconciliationRow, error)
+	// Lists the archives that may hold messages covered by a legal hold, oldest
+	// first: a channel's archives, or for a user the archives of their direct
+	// messages and of channels they posted in
+	ListLegalHoldMessageArchives(ctx context.Context, arg ListLegalHoldMessageArchivesParams) ([]MessageArchive, error)
+	// Exports held messages, including deleted ones, oldest first
+	ListLegalHoldMessages(ctx context.Context, arg ListLegalHoldMessagesParams) ([]ListLegalHoldMessagesRow, error)
+	ListLegalHolds(ctx context.Context, arg ListLegalHoldsParams) ([]LegalHold, error)
+	// A user's login attempts, most recent first, with the name they gave the device
+	ListLoginEvents(ctx context.Context, arg ListLoginEventsParams) ([]ListLoginEventsRow, error)
+	// The messages among the listed ones that ask for acknowledgment, with how
+	// many people acknowledged each and when the user did
+	ListMessageAcknowledgmentStates(ctx context.Context, arg ListMessageAcknowledgmentStatesParams) ([]ListMessageAcknowledgmentStatesRow, error)
+	// Lists a conversation's archives overlapping the optional time range,
+	// newest first
+	ListMessageArchives(ctx context.Context, arg ListMessageArchivesParams) ([]MessageArchive, error)
+	ListMessageDrafts(ctx context.Context, arg ListMessageDraftsParams) ([]MessageDraft, error)
+	ListMessageMentions(ctx context.Context, messageID int64) ([]int64, error)
+	// Lists the monthly message partitions, oldest first. The legacy and default
+	// partitions aren't monthly.
+	ListMessagePartitions(ctx context.Context) ([]string, error)
+	ListMessageThreads(ctx context.Context, threadIds []int64) ([]MessageThread, error)
+	// Lists a conversation's archivable messages created between starts_at and
+	// ends_at, oldest first, with their reactions
+	ListMessagesToArchive(ctx context.Context, arg ListMessagesToArchiveParams) ([]ListMessagesToArchiveRow, error)
+	ListModerationAuditLog(ctx context.Context, arg ListModerationAuditLogParams) ([]ModerationAuditLog, error)
+	ListModerationQueue(ctx context.Context, arg ListModerationQueueParams) ([]ListModerationQueueRow, error)
+	ListModerationWords(ctx context.Context, workspaceID int64) ([]ModerationWord, error)
+	// The workspace-wide preference comes first
+	ListNotificationPreferences(ctx context.Context, arg ListNotificationPreferencesParams) ([]NotificationPreference, error)
+	// Public channels of the workspace among the given ones, and whether the user
+	// has joined them
+	ListOnboardingChannels(ctx context.Context, arg ListOnboardingChannelsParams) ([]ListOnboardingChannelsRow, error)
+	ListOnboardingStepCompletions(ctx context.Context, arg ListOnboardingStepCompletionsParams) ([]OnboardingStepCompletion, error)
+	ListOrganizationAvatarKeys(ctx context.Context, organizationID int64) ([]string, error)
+	ListOrganizationRoles(ctx context.Context, organizationID int64) ([]ListOrganizationRolesRow, error)
 	ListOrganizations(ctx context.Context, arg ListOrganizationsParams) ([]Organization, error)
+	ListOutgoingWebhooks(ctx context.Context, workspaceID int64) ([]OutgoingWebhook, error)
+	ListPendingFilePreviews(ctx context.Context, limit int32) ([]File, error)
+	ListPendingInvitationEmails(ctx context.Context, arg ListPendingInvitationEmailsParams) ([]string, error)
+	ListPendingVideoFiles(ctx context.Context, limit int32) ([]File, error)
+	// Pinned messages of a channel in their arranged order, with who pinned them.
+	// Deleted messages keep their pin but aren't listed.
+	ListPinnedMessages(ctx context.Context, channelID int64) ([]ListPinnedMessagesRow, error)
 	ListPublicChannelsByWorkspace(ctx context.Context, arg ListPublicChannelsByWorkspaceParams) ([]Channel, error)
+	ListPurgeableChannels(ctx context.Context, arg ListPurgeableChannelsParams) ([]Channel, error)
+	ListPurgeableFiles(ctx context.Context, arg ListPurgeableFilesParams) ([]File, error)
+	// Workspaces already handed to the teardown job are skipped
+	ListPurgeableWorkspaces(ctx context.Context, arg ListPurgeableWorkspacesParams) ([]Workspace, error)
+	// Summarizes the reactions to a page of messages: each emoji's count, its
+	// first reactors and whether the viewer is one of them, in the order the
+	// emojis were first used
+	ListReactionSummaries(ctx context.Context, arg ListReactionSummariesParams) ([]ListReactionSummariesRow, error)
+	ListReactors(ctx context.Context, arg ListReactorsParams) ([]ListReactorsRow, error)
+	ListSavedSearches(ctx context.Context, arg ListSavedSearchesParams) ([]SavedSearch, error)
+	// Lists the files whose content is in the file store, for backups
+	ListStoredFiles(ctx context.Context) ([]ListStoredFilesRow, error)
+	// The names and avatars shown for the participants of threads
+	ListThreadParticipants(ctx context.Context, userIds []int64) ([]ListThreadParticipantsRow, error)
+	// Counts replies from others after the user's read position in each thread
+	// they follow in the workspace, most recently active first
+	ListThreadUnreadCounts(ctx context.Context, arg ListThreadUnreadCountsParams) ([]ListThreadUnreadCountsRow, error)
+	// Files in the trash, narrowed to one uploader's when an uploader is given
+	ListTrashedFiles(ctx context.Context, arg ListTrashedFilesParams) ([]ListTrashedFilesRow, error)
+	ListUnfinishedWorkspaceTeardowns(ctx context.Context, limit int32) ([]WorkspaceTeardown, error)
+	ListUnpublishedOutboxEvents(ctx context.Context, arg ListUnpublishedOutboxEventsParams) ([]EventOutbox, error)
+	ListUnpublishedWorkspaceEvents(ctx context.Context, arg ListUnpublishedWorkspaceEventsParams) ([]WorkspaceEvent, error)
+	ListUpcomingCalendarBusyBlocks(ctx context.Context, arg ListUpcomingCalendarBusyBlocksParams) ([]CalendarBusyBlock, error)
+	ListUserDevices(ctx context.Context, userID int64) ([]UserDevice, error)
 	ListUserFiles(ctx context.Context, arg ListUserFilesParams) ([]ListUserFilesRow, error)
+	// Messages in the workspace mentioning the user, newest first. Mentions in
+	// private channels the user is no longer a member of are left out.
+	ListUserMentions(ctx context.Context, arg ListUserMentionsParams) ([]ListUserMentionsRow, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListUsersByEmails(ctx context.Context, emails []string) ([]User, error)
+	ListWorkspaceAdminIDs(ctx context.Context, workspaceID sql.NullInt64) ([]int64, error)
+	ListWorkspaceAutoJoinDomains(ctx context.Context, workspaceID int64) ([]WorkspaceAutoJoinDomain, error)
+	ListWorkspaceBanners(ctx context.Context, workspaceID int64) ([]WorkspaceBanner, error)
+	// Do Not Disturb settings of everyone in a workspace who has them, for presence lists
+	ListWorkspaceDoNotDisturb(ctx context.Context, workspaceID int64) ([]ListWorkspaceDoNotDisturbRow, error)
+	// Events after the cursor. Recent events are held back so events written by
+	// transactions still in flight can't be committed behind the cursor.
+	ListWorkspaceEvents(ctx context.Context, arg ListWorkspaceEventsParams) ([]WorkspaceEvent, error)
 	ListWorkspaceFiles(ctx context.Context, arg ListWorkspaceFilesParams) ([]ListWorkspaceFilesRow, error)
+	ListWorkspaceFilesForTeardown(ctx context.Context, arg ListWorkspaceFilesForTeardownParams) ([]ListWorkspaceFilesForTeardownRow, error)
 	ListWorkspaceInvitations(ctx context.Context, arg ListWorkspaceInvitationsParams) ([]WorkspaceInvitation, error)
+	ListWorkspaceJoinLinks(ctx context.Context, arg ListWorkspaceJoinLinksParams) ([]WorkspaceJoinLink, error)
+	ListWorkspaceJoinRequests(ctx context.Context, arg ListWorkspaceJoinRequestsParams) ([]ListWorkspaceJoinRequestsRow, error)
+	// Keeps the users that belong to the workspace, e.g. to check who a message mentions
+	ListWorkspaceMemberIDs(ctx context.Context, arg ListWorkspaceMemberIDsParams) ([]int64, error)
 	ListWorkspaceMembers(ctx context.Context, arg ListWorkspaceMembersParams) ([]ListWorkspaceMembersRow, error)
+	// Pages through the reactions with an emoji on a workspace's messages
+	ListWorkspaceReactionsByEmoji(ctx context.Context, arg ListWorkspaceReactionsByEmojiParams) ([]MessageReaction, error)
+	ListWorkspaceRoles(ctx context.Context, workspaceID int64) ([]ListWorkspaceRolesRow, error)
+	ListWorkspaceTeardowns(ctx context.Context, arg ListWorkspaceTeardownsParams) ([]WorkspaceTeardown, error)
 	ListWorkspacesByOrganization(ctx context.Context, arg ListWorkspacesByOrganizationParams) ([]Workspace, error)
+	// Marks every channel the user can read in the workspace as read up to its latest message
+	MarkAllChannelsRead(ctx context.Context, arg MarkAllChannelsReadParams) (int64, error)
+	// Marks every direct message conversation of the user in the workspace as read up to its latest message
+	MarkAllDirectMessagesRead(ctx context.Context, arg MarkAllDirectMessagesReadParams) (int64, error)
+	// Marks every thread the user follows in the workspace as read up to its latest reply
+	MarkAllThreadsRead(ctx context.Context, arg MarkAllThreadsReadParams) (int64, error)
+	// The read position only moves forward
+	MarkChannelRead(ctx context.Context, arg MarkChannelReadParams) (ChannelReadState, error)
+	// The read position only moves forward
+	MarkDirectMessagesRead(ctx context.Context, arg MarkDirectMessagesReadParams) (DirectMessageReadState, error)
+	MarkEmailDeliverySent(ctx context.Context, arg MarkEmailDeliverySentParams) error
+	// Keeps the time the mention was first read
+	MarkMentionRead(ctx context.Context, arg MarkMentionReadParams) (MessageMention, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64) error
+	// Follows the thread if the user didn't already. The read position only moves forward.
+	MarkThreadRead(ctx context.Context, arg MarkThreadReadParams) (ThreadReadState, error)
+	// Only verifies the address the verification was sent to
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (User, error)
+	MarkWebhookDelivered(ctx context.Context, id int64) error
+	MarkWorkspaceEventsPublished(ctx context.Context, ids []int64) error
+	OrganizationHasActiveLegalHold(ctx context.Context, organizationID int64) (bool, error)
+	// New pins go to the end of the channel's list. Pinning a message that is
+	// already pinned returns no rows.
+	PinMessage(ctx context.Context, arg PinMessageParams) (PinnedMessage, error)
+	// Permanently removes a batch of messages deleted before the cutoff. Messages
+	// with replies keep their tombstone so the thread stays intact, and messages
+	// of held users or channels are kept while the legal hold is active.
+	PurgeDeletedMessages(ctx context.Context, arg PurgeDeletedMessagesParams) (int64, error)
+	// Only files in the trash are purged
+	PurgeFile(ctx context.Context, id int64) (int64, error)
+	// Hands every workspace of the organization, deleted or not, to the teardown job
+	QueueOrganizationWorkspaceTeardowns(ctx context.Context, organizationID int64) (int64, error)
+	// Logs a download and adds it to the file's download count
+	RecordFileDownload(ctx context.Context, arg RecordFileDownloadParams) error
+	RecordOrganizationDeletionError(ctx context.Context, arg RecordOrganizationDeletionErrorParams) error
+	RecordOrganizationDeletionProgress(ctx context.Context, arg RecordOrganizationDeletionProgressParams) error
+	// Affects no rows when the sender already got an auto-reply today, in the
+	// time zone of the user who is out of office
+	RecordOutOfOfficeReply(ctx context.Context, arg RecordOutOfOfficeReplyParams) (int64, error)
+	// Counts a new reply in its thread's summary and moves its sender to the
+	// front of the thread's five most recent participants
+	RecordThreadReply(ctx context.Context, arg RecordThreadReplyParams) (MessageThread, error)
+	RecordWorkspaceTeardownError(ctx context.Context, arg RecordWorkspaceTeardownErrorParams) error
+	RecordWorkspaceTeardownProgress(ctx context.Context, arg RecordWorkspaceTeardownProgressParams) error
+	// Recounts a thread's summary from its replies, after a reply was deleted
+	RefreshThreadSummary(ctx context.Context, threadID int64) (MessageThread, error)
+	ReleaseLegalHold(ctx context.Context, arg ReleaseLegalHoldParams) (LegalHold, error)
 	RemoveChannelMember(ctx context.Context, arg RemoveChannelMemberParams) error
+	RemoveReaction(ctx context.Context, arg RemoveReactionParams) (int64, error)
 	RemoveUserFromWorkspace(ctx context.Context, arg RemoveUserFromWorkspaceParams) (User, error)
+	// Positions the channel's pins in the order of the given message IDs
+	ReorderPinnedMessages(ctx context.Context, arg ReorderPinnedMessagesParams) (int64, error)
+	// Documents left mid-render by a restart are picked up again
+	RequeueInterruptedFilePreviews(ctx context.Context) error
+	// Files left mid-transcode by a restart are picked up again
+	RequeueInterruptedFileProcessing(ctx context.Context) error
+	ResolveAbuseReport(ctx context.Context, arg ResolveAbuseReportParams) (AbuseReport, error)
+	ResolveModerationQueueItem(ctx context.Context, arg ResolveModerationQueueItemParams) (ModerationQueue, error)
+	// Accepts or declines a request that is still pending
+	RespondExternalDMRequest(ctx context.Context, arg RespondExternalDMRequestParams) (ExternalDmRequest, error)
+	// Only channels still inside the recovery window can be restored
+	RestoreChannel(ctx context.Context, arg RestoreChannelParams) (Channel, error)
+	// Only files still inside the trash retention can be restored
+	RestoreFile(ctx context.Context, arg RestoreFileParams) (File, error)
+	// Only workspaces still inside the recovery window can be restored
+	RestoreWorkspace(ctx context.Context, arg RestoreWorkspaceParams) (Workspace, error)
+	RetryEmailDelivery(ctx context.Context, arg RetryEmailDeliveryParams) error
+	RetryWebhookDelivery(ctx context.Context, arg RetryWebhookDeliveryParams) error
+	ReviewWorkspaceJoinRequest(ctx context.Context, arg ReviewWorkspaceJoinRequestParams) (WorkspaceJoinRequest, error)
+	RevokeWorkspaceJoinLink(ctx context.Context, arg RevokeWorkspaceJoinLinkParams) (WorkspaceJoinLink, error)
+	RollupChannelDailyPosterStats(ctx context.Context, arg RollupChannelDailyPosterStatsParams) (int64, error)
+	RollupChannelDailyStats(ctx context.Context, arg RollupChannelDailyStatsParams) (int64, error)
+	// Private channels only match when the user is a member
+	SearchChannels(ctx context.Context, arg SearchChannelsParams) ([]SearchChannelsRow, error)
+	// Only files the user can access through ownership, public visibility or a share are returned
+	SearchFiles(ctx context.Context, arg SearchFilesParams) ([]SearchFilesRow, error)
+	// Channel messages only match in public channels and private channels the
+	// user is a member of, direct messages only when the user took part
+	SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error)
+	// Lists an organization's people across all its workspaces, with their
+	// workspace and presence. People match on name, handle or title, and on
+	// email when search_email is set; an empty query matches everyone.
+	SearchOrganizationDirectory(ctx context.Context, arg SearchOrganizationDirectoryParams) ([]SearchOrganizationDirectoryRow, error)
+	// Lists the people of a workspace whose name or handle matches the query,
+	// with their public profile only. Emails are matched and returned only when
+	// include_email is set.
+	SearchWorkspaceUsers(ctx context.Context, arg SearchWorkspaceUsersParams) ([]SearchWorkspaceUsersRow, error)
+	// Sets a meeting status until the event ends. Custom statuses the user chose
+	// themselves are left alone.
+	SetCalendarBusyStatus(ctx context.Context, arg SetCalendarBusyStatusParams) (UserStatus, error)
+	// Marks online users away once they have been inactive longer than their
+	// workspace's away threshold. Workspaces without a threshold fall back to the
+	// default, and no one is marked away when neither is set.
+	SetIdleUsersAway(ctx context.Context, defaultAwayMinutes sql.NullInt32) ([]UserStatus, error)
+	// Marks users offline once they have been inactive longer than their
+	// workspace's offline threshold, falling back to the default
+	SetIdleUsersOffline(ctx context.Context, defaultOfflineMinutes int32) ([]UserStatus, error)
+	SetUserCustomRole(ctx context.Context, arg SetUserCustomRoleParams) (User, error)
+	// Takes a user offline while keeping their custom status
+	SetUserPresenceOffline(ctx context.Context, arg SetUserPresenceOfflineParams) (UserStatus, error)
+	// Brings a user online without touching an explicitly chosen away/busy status or custom status
+	SetUserPresenceOnline(ctx context.Context, arg SetUserPresenceOnlineParams) (UserStatus, error)
 	SetUsersOfflineAfterInactivity(ctx context.Context, lastActivityAt time.Time) error
-	SoftDeleteMessage(ctx context.Context, id int64) error
+	// Records the outcome of an invitation email sent by the email queue
+	SetWorkspaceInvitationEmailResult(ctx context.Context, arg SetWorkspaceInvitationEmailResultParams) error
+	SetWorkspaceOnboardingBot(ctx context.Context, arg SetWorkspaceOnboardingBotParams) error
+	SoftDeleteChannel(ctx context.Context, arg SoftDeleteChannelParams) (int64, error)
+	SoftDeleteMessage(ctx context.Context, arg SoftDeleteMessageParams) error
+	SoftDeleteWorkspace(ctx context.Context, arg SoftDeleteWorkspaceParams) (int64, error)
+	// Claims a due deletion, so a cancellation can no longer win against it
+	StartOrganizationDeletion(ctx context.Context, id int64) (OrganizationDeletion, error)
+	// Deletes a message on behalf of a moderator, leaving a notice in its tombstone
+	TakedownMessage(ctx context.Context, arg TakedownMessageParams) (int64, error)
+	TrashFile(ctx context.Context, arg TrashFileParams) (int64, error)
+	UnfollowThread(ctx context.Context, arg UnfollowThreadParams) (int64, error)
+	UnpinMessage(ctx context.Context, messageID int64) (PinnedMessage, error)
+	UpdateCalendarIntegrationSync(ctx context.Context, arg UpdateCalendarIntegrationSyncParams) error
+	// Only applies when the canvas is still at the revision the edit was based on
+	UpdateCanvas(ctx context.Context, arg UpdateCanvasParams) (Canvas, error)
 	UpdateChannel(ctx context.Context, arg UpdateChannelParams) (Channel, error)
+	UpdateFilePreview(ctx context.Context, arg UpdateFilePreviewParams) error
+	UpdateFileProcessing(ctx context.Context, arg UpdateFileProcessingParams) error
 	UpdateFileThumbnail(ctx context.Context, arg UpdateFileThumbnailParams) error
 	UpdateFileUploadStatus(ctx context.Context, arg UpdateFileUploadStatusParams) error
+	UpdateFileVoiceMetadata(ctx context.Context, arg UpdateFileVoiceMetadataParams) error
 	UpdateLastActivity(ctx context.Context, arg UpdateLastActivityParams) error
 	UpdateMessageContent(ctx context.Context, arg UpdateMessageContentParams) (Message, error)
 	UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error)
+	UpdateSavedSearch(ctx context.Context, arg UpdateSavedSearchParams) (SavedSearch, error)
+	UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarParams) (User, error)
+	UpdateUserDevice(ctx context.Context, arg UpdateUserDeviceParams) (UserDevice, error)
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	// Profile details that aren't given keep their current value
 	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error)
 	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
 	UpdateUserWorkspace(ctx context.Context, arg UpdateUserWorkspaceParams) (User, error)
 	UpdateWorkspace(ctx context.Context, arg UpdateWorkspaceParams) (Workspace, error)
+	UpdateWorkspaceBanner(ctx context.Context, arg UpdateWorkspaceBannerParams) (WorkspaceBanner, error)
+	UpdateWorkspaceInvitationEmailStatus(ctx context.Context, arg UpdateWorkspaceInvitationEmailStatusParams) (WorkspaceInvitation, error)
 	UpdateWorkspaceMemberRole(ctx context.Context, arg UpdateWorkspaceMemberRoleParams) (User, error)
+	UpdateWorkspaceRole(ctx context.Context, arg UpdateWorkspaceRoleParams) (WorkspaceRole, error)
+	UpsertCalendarIntegration(ctx context.Context, arg UpsertCalendarIntegrationParams) (CalendarIntegration, error)
+	UpsertChannelNotificationDefault(ctx context.Context, arg UpsertChannelNotificationDefaultParams) (ChannelNotificationDefault, error)
+	UpsertChannelNotificationPreference(ctx context.Context, arg UpsertChannelNotificationPreferenceParams) (NotificationPreference, error)
+	UpsertDoNotDisturb(ctx context.Context, arg UpsertDoNotDisturbParams) (UpsertDoNotDisturbRow, error)
+	UpsertEmailBranding(ctx context.Context, arg UpsertEmailBrandingParams) (EmailBranding, error)
+	UpsertEmailSuppression(ctx context.Context, arg UpsertEmailSuppressionParams) (EmailSuppression, error)
+	UpsertEmailTemplate(ctx context.Context, arg UpsertEmailTemplateParams) (EmailTemplate, error)
+	UpsertExternalDMPolicy(ctx context.Context, arg UpsertExternalDMPolicyParams) (ExternalDmPolicy, error)
+	UpsertFeatureFlag(ctx context.Context, arg UpsertFeatureFlagParams) (FeatureFlag, error)
+	UpsertMessageDraft(ctx context.Context, arg UpsertMessageDraftParams) (MessageDraft, error)
+	UpsertMessageTranslation(ctx context.Context, arg UpsertMessageTranslationParams) (MessageTranslation, error)
+	UpsertOrganizationRole(ctx context.Context, arg UpsertOrganizationRoleParams) (OrganizationRole, error)
+	UpsertOutOfOffice(ctx context.Context, arg UpsertOutOfOfficeParams) (OutOfOffice, error)
+	// Records a login from a device, adding the device the first time it's seen
+	UpsertUserDevice(ctx context.Context, arg UpsertUserDeviceParams) (UserDevice, error)
 	UpsertUserStatus(ctx context.Context, arg UpsertUserStatusParams) (UserStatus, error)
+	UpsertWorkspaceFileSettings(ctx context.Context, arg UpsertWorkspaceFileSettingsParams) (WorkspaceSetting, error)
+	UpsertWorkspaceMessageSettings(ctx context.Context, arg UpsertWorkspaceMessageSettingsParams) (WorkspaceSetting, error)
+	UpsertWorkspaceNotificationPreference(ctx context.Context, arg UpsertWorkspaceNotificationPreferenceParams) (NotificationPreference, error)
+	UpsertWorkspaceOnboarding(ctx context.Context, arg UpsertWorkspaceOnboardingParams) (WorkspaceOnboarding, error)
+	UpsertWorkspacePresenceSettings(ctx context.Context, arg UpsertWorkspacePresenceSettingsParams) (WorkspaceSetting, error)
+	UpsertWorkspaceReactionSettings(ctx context.Context, arg UpsertWorkspaceReactionSettingsParams) (WorkspaceSetting, error)
+	// Marks a verification that is still valid as used, no row is returned otherwise
+	UseEmailVerification(ctx context.Context, token string) (EmailVerification, error)
+	// Counts a use of a link that is still valid, no row is returned otherwise
+	UseWorkspaceJoinLink(ctx context.Context, token string) (WorkspaceJoinLink, error)
+	// A workspace holds content under legal hold if one of its channels is held, or
+	// a held user is a member or has messages or files in it
+	WorkspaceHasActiveLegalHold(ctx context.Context, workspaceID int64) (bool, error)
 }
 
 var _ Querier = (*Queries)(nil)
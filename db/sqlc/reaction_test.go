@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReactionSummaries(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	other := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, user)
+	message := createRandomChannelMessage(t, workspace, channel, user)
+	quiet := createRandomChannelMessage(t, workspace, channel, user)
+
+	for _, reaction := range []AddReactionParams{
+		{MessageID: message.ID, UserID: user.ID, Emoji: "👍"},
+		{MessageID: message.ID, UserID: other.ID, Emoji: "👍"},
+		{MessageID: message.ID, UserID: other.ID, Emoji: "🎉"},
+	} {
+		_, err := testQueries.AddReaction(context.Background(), reaction)
+		require.NoError(t, err)
+	}
+
+	// Each user reacts with an emoji once
+	_, err := testQueries.AddReaction(context.Background(), AddReactionParams{MessageID: message.ID, UserID: user.ID, Emoji: "👍"})
+	require.Error(t, err)
+
+	counts, err := testQueries.GetReactionCounts(context.Background(), GetReactionCountsParams{UserID: other.ID, Emoji: "🎉", MessageID: message.ID})
+	require.NoError(t, err)
+	require.Equal(t, GetReactionCountsRow{UserReactions: 2, DistinctEmojis: 2, EmojiUsed: true}, counts)
+
+	summaries, err := testQueries.ListReactionSummaries(context.Background(), ListReactionSummariesParams{
+		ViewerID:     user.ID,
+		ReactorLimit: 1,
+		MessageIds:   []int64{message.ID, quiet.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+
+	require.Equal(t, "👍", summaries[0].Emoji)
+	require.Equal(t, int64(2), summaries[0].Count)
+	require.True(t, summaries[0].Reacted)
+	require.Equal(t, []int64{user.ID}, summaries[0].UserIds)
+
+	require.Equal(t, "🎉", summaries[1].Emoji)
+	require.False(t, summaries[1].Reacted)
+
+	reactors, err := testQueries.ListReactors(context.Background(), ListReactorsParams{
+		MessageID: message.ID,
+		Emoji:     "👍",
+		Limit:     10,
+		Offset:    1,
+	})
+	require.NoError(t, err)
+	require.Len(t, reactors, 1)
+	require.Equal(t, other.ID, reactors[0].UserID)
+	require.Equal(t, other.FirstName, reactors[0].FirstName)
+
+	removed, err := testQueries.RemoveReaction(context.Background(), RemoveReactionParams{MessageID: message.ID, UserID: other.ID, Emoji: "🎉"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removed)
+
+	removed, err = testQueries.RemoveReaction(context.Background(), RemoveReactionParams{MessageID: message.ID, UserID: other.ID, Emoji: "🎉"})
+	require.NoError(t, err)
+	require.Zero(t, removed)
+}
+
+func TestDeleteCustomEmojiTx(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	other := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, user)
+	message := createRandomChannelMessage(t, workspace, channel, user)
+	file := createRandomFileForWorkspace(t, workspace.ID, user.ID)
+	store := NewStore(testDB)
+
+	emoji, err := testQueries.CreateCustomEmoji(context.Background(), CreateCustomEmojiParams{
+		WorkspaceID: workspace.ID,
+		Name:        "party-parrot",
+		FileID:      file.ID,
+	})
+	require.NoError(t, err)
+
+	for _, reaction := range []AddReactionParams{
+		{MessageID: message.ID, UserID: user.ID, Emoji: ":party-parrot:"},
+		{MessageID: message.ID, UserID: other.ID, Emoji: ":party-parrot:"},
+		{MessageID: message.ID, UserID: other.ID, Emoji: "🦜"},
+	} {
+		_, err := testQueries.AddReaction(context.Background(), reaction)
+		require.NoError(t, err)
+	}
+
+	result, err := store.DeleteCustomEmojiTx(context.Background(), DeleteCustomEmojiTxParams{
+		ID:          emoji.ID,
+		WorkspaceID: workspace.ID,
+		Emoji:       ":party-parrot:",
+		Replacement: "🦜",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.ReactionsMigrated)
+
+	_, err = testQueries.GetCustomEmoji(context.Background(), GetCustomEmojiParams{ID: emoji.ID, WorkspaceID: workspace.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// The user who already reacted with the replacement keeps one reaction
+	summaries, err := testQueries.ListReactionSummaries(context.Background(), ListReactionSummariesParams{
+		ViewerID:     user.ID,
+		ReactorLimit: 10,
+		MessageIds:   []int64{message.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	require.Equal(t, "🦜", summaries[0].Emoji)
+	require.Equal(t, []int64{user.ID, other.ID}, summaries[0].UserIds)
+}
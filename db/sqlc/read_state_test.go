@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkChannelRead(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	message1 := createRandomChannelMessage(t, workspace, channel, user)
+	message2 := createRandomChannelMessage(t, workspace, channel, user)
+
+	readState, err := testQueries.MarkChannelRead(context.Background(), MarkChannelReadParams{
+		UserID:            user.ID,
+		ChannelID:         channel.ID,
+		LastReadMessageID: sql.NullInt64{Int64: message2.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, message2.ID, readState.LastReadMessageID.Int64)
+
+	// Marking an older message does not move the read position back
+	readState, err = testQueries.MarkChannelRead(context.Background(), MarkChannelReadParams{
+		UserID:            user.ID,
+		ChannelID:         channel.ID,
+		LastReadMessageID: sql.NullInt64{Int64: message1.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, message2.ID, readState.LastReadMessageID.Int64)
+}
+
+func TestMarkWorkspaceReadTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	workspace, user := createTestWorkspaceAndUser(t)
+	otherUser := createRandomUserForOrganization(t, workspace.OrganizationID)
+	_, err := testQueries.UpdateUserWorkspace(context.Background(), UpdateUserWorkspaceParams{
+		ID:          otherUser.ID,
+		WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+		Role:        "member",
+	})
+	require.NoError(t, err)
+
+	channel := createRandomChannel(t, workspace, user)
+	createRandomChannelMember(t, channel, user, user)
+	channelMessage := createRandomChannelMessage(t, workspace, channel, otherUser)
+	createRandomDirectMessage(t, workspace, otherUser, user)
+
+	result, err := store.MarkWorkspaceReadTx(context.Background(), MarkWorkspaceReadTxParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.ChannelsMarked)
+	require.Equal(t, int64(1), result.ConversationsMarked)
+
+	readState, err := testQueries.GetChannelReadState(context.Background(), GetChannelReadStateParams{
+		UserID:    user.ID,
+		ChannelID: channel.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, channelMessage.ID, readState.LastReadMessageID.Int64)
+}
+
+func TestThreadUnreadCounts(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	otherUser := createRandomUserForOrganization(t, workspace.OrganizationID)
+	channel := createRandomChannel(t, workspace, user)
+	root := createRandomChannelMessage(t, workspace, channel, user)
+
+	createReply := func() Message {
+		reply, err := testQueries.CreateMessageAt(context.Background(), CreateMessageAtParams{
+			WorkspaceID: workspace.ID,
+			ChannelID:   sql.NullInt64{Int64: channel.ID, Valid: true},
+			SenderID:    otherUser.ID,
+			Content:     util.RandomString(50),
+			ContentType: "text",
+			MessageType: "channel",
+			ThreadID:    sql.NullInt64{Int64: root.ID, Valid: true},
+			CreatedAt:   time.Now(),
+		})
+		require.NoError(t, err)
+		return reply
+	}
+	reply1 := createReply()
+	createReply()
+
+	arg := ListThreadUnreadCountsParams{UserID: user.ID, WorkspaceID: workspace.ID}
+
+	// Threads the user doesn't follow aren't counted
+	counts, err := testQueries.ListThreadUnreadCounts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, counts)
+
+	_, err = testQueries.MarkThreadRead(context.Background(), MarkThreadReadParams{
+		UserID:            user.ID,
+		ThreadID:          root.ID,
+		LastReadMessageID: sql.NullInt64{Int64: reply1.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	counts, err = testQueries.ListThreadUnreadCounts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+	require.Equal(t, root.ID, counts[0].ThreadID)
+	require.Equal(t, int64(1), counts[0].UnreadCount)
+
+	rows, err := testQueries.UnfollowThread(context.Background(), UnfollowThreadParams{UserID: user.ID, ThreadID: root.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	counts, err = testQueries.ListThreadUnreadCounts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, counts)
+}
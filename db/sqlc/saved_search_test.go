@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSavedSearch(t *testing.T, workspace Workspace, user User) SavedSearch {
+	arg := CreateSavedSearchParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		Name:        util.RandomString(10),
+		Query:       "deploy in:#general has:link",
+	}
+
+	savedSearch, err := testQueries.CreateSavedSearch(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, savedSearch)
+
+	require.Equal(t, arg.UserID, savedSearch.UserID)
+	require.Equal(t, arg.WorkspaceID, savedSearch.WorkspaceID)
+	require.Equal(t, arg.Name, savedSearch.Name)
+	require.Equal(t, arg.Query, savedSearch.Query)
+	require.NotZero(t, savedSearch.ID)
+	require.NotZero(t, savedSearch.CreatedAt)
+
+	return savedSearch
+}
+
+func TestCreateSavedSearch(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	createRandomSavedSearch(t, workspace, user)
+}
+
+func TestCreateSavedSearchDuplicateName(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	savedSearch := createRandomSavedSearch(t, workspace, user)
+
+	_, err := testQueries.CreateSavedSearch(context.Background(), CreateSavedSearchParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		Name:        savedSearch.Name,
+		Query:       "other",
+	})
+	require.Error(t, err)
+}
+
+func TestGetSavedSearch(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	savedSearch1 := createRandomSavedSearch(t, workspace, user)
+
+	savedSearch2, err := testQueries.GetSavedSearch(context.Background(), GetSavedSearchParams{
+		ID:          savedSearch1.ID,
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, savedSearch1, savedSearch2)
+
+	// Other users cannot see the saved search
+	_, err = testQueries.GetSavedSearch(context.Background(), GetSavedSearchParams{
+		ID:          savedSearch1.ID,
+		UserID:      user.ID + 1000000,
+		WorkspaceID: workspace.ID,
+	})
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestListSavedSearches(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	for i := 0; i < 3; i++ {
+		createRandomSavedSearch(t, workspace, user)
+	}
+
+	savedSearches, err := testQueries.ListSavedSearches(context.Background(), ListSavedSearchesParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, savedSearches, 3)
+
+	for _, savedSearch := range savedSearches {
+		require.Equal(t, user.ID, savedSearch.UserID)
+		require.Equal(t, workspace.ID, savedSearch.WorkspaceID)
+	}
+}
+
+func TestUpdateSavedSearch(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	savedSearch1 := createRandomSavedSearch(t, workspace, user)
+
+	arg := UpdateSavedSearchParams{
+		ID:          savedSearch1.ID,
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		Name:        util.RandomString(10),
+		Query:       "from:me has:file",
+	}
+
+	savedSearch2, err := testQueries.UpdateSavedSearch(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, savedSearch1.ID, savedSearch2.ID)
+	require.Equal(t, arg.Name, savedSearch2.Name)
+	require.Equal(t, arg.Query, savedSearch2.Query)
+	require.True(t, !savedSearch2.UpdatedAt.Before(savedSearch1.UpdatedAt))
+}
+
+func TestDeleteSavedSearch(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	savedSearch := createRandomSavedSearch(t, workspace, user)
+
+	err := testQueries.DeleteSavedSearch(context.Background(), DeleteSavedSearchParams{
+		ID:          savedSearch.ID,
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetSavedSearch(context.Background(), GetSavedSearchParams{
+		ID:          savedSearch.ID,
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
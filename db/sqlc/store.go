@@ -3,15 +3,36 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 )
 
-// Store defines all functions to execute db queries and transactions
+// Store defines all functions to execute db queries and transactions. It is
+// made of domain stores so code can depend on only the part it uses.
 type Store interface {
-	Querier
-	// Add transaction methods here if needed
+	UserStore
+	OrganizationStore
+	WorkspaceStore
+	ChannelStore
+	MessageStore
+	FileStore
+	CanvasStore
+	ModerationStore
+	EmailStore
+	OutboxStore
+	Transactor
 }
 
+// Transactor runs several queries atomically, for operations no typed Tx
+// method covers
+type Transactor interface {
+	ExecTx(ctx context.Context, fn func(Querier) error) error
+}
+
+// Every query belongs to a domain store
+var _ Querier = Store(nil)
+
 // SQLStore provides all functions to execute SQL queries and transactions
 type SQLStore struct {
 	*Queries
@@ -44,3 +65,764 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 
 	return tx.Commit()
 }
+
+// ExecTx runs fn within a database transaction, which is rolled back when fn
+// returns an error
+func (store *SQLStore) ExecTx(ctx context.Context, fn func(Querier) error) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		return fn(q)
+	})
+}
+
+// MarkWorkspaceReadTxParams contains the input parameters of the mark workspace read transaction
+type MarkWorkspaceReadTxParams struct {
+	UserID      int64 `json:"user_id"`
+	WorkspaceID int64 `json:"workspace_id"`
+}
+
+// MarkWorkspaceReadTxResult is the result of the mark workspace read transaction
+type MarkWorkspaceReadTxResult struct {
+	ChannelsMarked      int64 `json:"channels_marked"`
+	ConversationsMarked int64 `json:"conversations_marked"`
+	ThreadsMarked       int64 `json:"threads_marked"`
+}
+
+// MarkWorkspaceReadTx marks every channel, direct message conversation and followed
+// thread of a user in a workspace as read within a single database transaction
+func (store *SQLStore) MarkWorkspaceReadTx(ctx context.Context, arg MarkWorkspaceReadTxParams) (MarkWorkspaceReadTxResult, error) {
+	var result MarkWorkspaceReadTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.ChannelsMarked, err = q.MarkAllChannelsRead(ctx, MarkAllChannelsReadParams{
+			UserID:      arg.UserID,
+			WorkspaceID: arg.WorkspaceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ConversationsMarked, err = q.MarkAllDirectMessagesRead(ctx, MarkAllDirectMessagesReadParams{
+			UserID:      arg.UserID,
+			WorkspaceID: arg.WorkspaceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ThreadsMarked, err = q.MarkAllThreadsRead(ctx, MarkAllThreadsReadParams{
+			UserID:      arg.UserID,
+			WorkspaceID: arg.WorkspaceID,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// CalendarBusyBlockParams is a single busy block of a calendar feed
+type CalendarBusyBlockParams struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// ReplaceCalendarBusyBlocksTxParams contains the input parameters of the replace calendar busy blocks transaction
+type ReplaceCalendarBusyBlocksTxParams struct {
+	IntegrationID int64                     `json:"integration_id"`
+	Blocks        []CalendarBusyBlockParams `json:"blocks"`
+}
+
+// ReplaceCalendarBusyBlocksTx replaces all busy blocks of a calendar integration
+// with a freshly ingested set and records the sync within a single database transaction
+func (store *SQLStore) ReplaceCalendarBusyBlocksTx(ctx context.Context, arg ReplaceCalendarBusyBlocksTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteCalendarBusyBlocks(ctx, arg.IntegrationID)
+		if err != nil {
+			return err
+		}
+
+		for _, block := range arg.Blocks {
+			err = q.CreateCalendarBusyBlock(ctx, CreateCalendarBusyBlockParams{
+				IntegrationID: arg.IntegrationID,
+				StartsAt:      block.StartsAt,
+				EndsAt:        block.EndsAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return q.UpdateCalendarIntegrationSync(ctx, UpdateCalendarIntegrationSyncParams{
+			ID: arg.IntegrationID,
+		})
+	})
+}
+
+// CreateWorkspaceInvitationsTx creates a batch of invitations within a single
+// database transaction, either all of them are created or none
+func (store *SQLStore) CreateWorkspaceInvitationsTx(ctx context.Context, invitations []CreateWorkspaceInvitationParams) ([]WorkspaceInvitation, error) {
+	created := make([]WorkspaceInvitation, 0, len(invitations))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for _, arg := range invitations {
+			invitation, err := q.CreateWorkspaceInvitation(ctx, arg)
+			if err != nil {
+				return err
+			}
+			created = append(created, invitation)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// JoinWorkspaceByLinkTxParams contains the input parameters of the join workspace by link transaction
+type JoinWorkspaceByLinkTxParams struct {
+	Token  string `json:"token"`
+	UserID int64  `json:"user_id"`
+}
+
+// JoinWorkspaceByLinkTxResult is the result of the join workspace by link transaction
+type JoinWorkspaceByLinkTxResult struct {
+	Link WorkspaceJoinLink `json:"link"`
+	User User              `json:"user"`
+}
+
+// JoinWorkspaceByLinkTx counts a use of a join link and adds the user to its
+// workspace as a member within a single database transaction. It returns
+// sql.ErrNoRows when the link is no longer valid.
+func (store *SQLStore) JoinWorkspaceByLinkTx(ctx context.Context, arg JoinWorkspaceByLinkTxParams) (JoinWorkspaceByLinkTxResult, error) {
+	var result JoinWorkspaceByLinkTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Link, err = q.UseWorkspaceJoinLink(ctx, arg.Token)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.AddUserToWorkspace(ctx, AddUserToWorkspaceParams{
+			ID:          arg.UserID,
+			WorkspaceID: sql.NullInt64{Int64: result.Link.WorkspaceID, Valid: true},
+			Role:        "member",
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// AcceptInvitationTxParams contains the input parameters of the accept invitation transaction
+type AcceptInvitationTxParams struct {
+	InvitationCode string `json:"invitation_code"`
+	UserID         int64  `json:"user_id"`
+}
+
+// AcceptInvitationTxResult is the result of the accept invitation transaction
+type AcceptInvitationTxResult struct {
+	Invitation WorkspaceInvitation `json:"invitation"`
+	User       User                `json:"user"`
+}
+
+// AcceptInvitationTx accepts a pending invitation and adds the user to its
+// workspace with the invited role within a single database transaction. It
+// returns sql.ErrNoRows when the invitation is no longer pending.
+func (store *SQLStore) AcceptInvitationTx(ctx context.Context, arg AcceptInvitationTxParams) (AcceptInvitationTxResult, error) {
+	var result AcceptInvitationTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Invitation, err = q.AcceptWorkspaceInvitation(ctx, AcceptWorkspaceInvitationParams{
+			InvitationCode: arg.InvitationCode,
+			InviteeID:      sql.NullInt64{Int64: arg.UserID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.AddUserToWorkspace(ctx, AddUserToWorkspaceParams{
+			ID:          arg.UserID,
+			WorkspaceID: sql.NullInt64{Int64: result.Invitation.WorkspaceID, Valid: true},
+			Role:        result.Invitation.Role,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ErrWorkspaceUnderLegalHold is returned by DeleteWorkspaceTx when the
+// workspace has content under an active legal hold
+var ErrWorkspaceUnderLegalHold = errors.New("workspace has content under legal hold")
+
+// DeleteWorkspaceTx soft deletes a workspace unless it has content under an
+// active legal hold, checking the holds in the same transaction that deletes
+// it. It returns sql.ErrNoRows when the workspace doesn't exist or is already
+// deleted.
+func (store *SQLStore) DeleteWorkspaceTx(ctx context.Context, arg SoftDeleteWorkspaceParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		rows, err := q.SoftDeleteWorkspace(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return sql.ErrNoRows
+		}
+
+		held, err := q.WorkspaceHasActiveLegalHold(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if held {
+			return ErrWorkspaceUnderLegalHold
+		}
+		return nil
+	})
+}
+
+// VerifyEmailTxResult is the result of the verify email transaction
+type VerifyEmailTxResult struct {
+	Verification EmailVerification `json:"verification"`
+	User         User              `json:"user"`
+}
+
+// VerifyEmailTx uses an email verification and marks the user's email as
+// verified within a single database transaction. It returns sql.ErrNoRows when
+// the verification is no longer valid or the user changed their email since.
+func (store *SQLStore) VerifyEmailTx(ctx context.Context, token string) (VerifyEmailTxResult, error) {
+	var result VerifyEmailTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Verification, err = q.UseEmailVerification(ctx, token)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.MarkUserEmailVerified(ctx, MarkUserEmailVerifiedParams{
+			ID:    result.Verification.UserID,
+			Email: result.Verification.Email,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// ApproveWorkspaceJoinRequestTxParams contains the input parameters of the approve workspace join request transaction
+type ApproveWorkspaceJoinRequestTxParams struct {
+	ID          int64 `json:"id"`
+	WorkspaceID int64 `json:"workspace_id"`
+	ReviewerID  int64 `json:"reviewer_id"`
+}
+
+// ApproveWorkspaceJoinRequestTxResult is the result of the approve workspace join request transaction
+type ApproveWorkspaceJoinRequestTxResult struct {
+	Request WorkspaceJoinRequest `json:"request"`
+	User    User                 `json:"user"`
+}
+
+// ApproveWorkspaceJoinRequestTx approves a pending join request and adds the
+// user to the workspace as a member within a single database transaction. It
+// returns sql.ErrNoRows when the request is no longer pending.
+func (store *SQLStore) ApproveWorkspaceJoinRequestTx(ctx context.Context, arg ApproveWorkspaceJoinRequestTxParams) (ApproveWorkspaceJoinRequestTxResult, error) {
+	var result ApproveWorkspaceJoinRequestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Request, err = q.ReviewWorkspaceJoinRequest(ctx, ReviewWorkspaceJoinRequestParams{
+			ID:          arg.ID,
+			WorkspaceID: arg.WorkspaceID,
+			Status:      "approved",
+			ReviewedBy:  sql.NullInt64{Int64: arg.ReviewerID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.AddUserToWorkspace(ctx, AddUserToWorkspaceParams{
+			ID:          result.Request.UserID,
+			WorkspaceID: sql.NullInt64{Int64: arg.WorkspaceID, Valid: true},
+			Role:        "member",
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// CreateChannelTxParams contains the input parameters of the create channel transaction
+type CreateChannelTxParams struct {
+	WorkspaceID int64  `json:"workspace_id"`
+	Name        string `json:"name"`
+	IsPrivate   bool   `json:"is_private"`
+	CreatedBy   int64  `json:"created_by"`
+	// SystemMessage is posted to the new channel on behalf of its creator
+	SystemMessage string `json:"system_message"`
+}
+
+// CreateChannelTxResult is the result of the create channel transaction
+type CreateChannelTxResult struct {
+	Channel Channel       `json:"channel"`
+	Member  ChannelMember `json:"member"`
+	Message Message       `json:"message"`
+}
+
+// CreateChannelTx creates a channel, adds its creator as a channel admin and
+// posts the "channel created" system message within a single database transaction
+func (store *SQLStore) CreateChannelTx(ctx context.Context, arg CreateChannelTxParams) (CreateChannelTxResult, error) {
+	var result CreateChannelTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Channel, err = q.CreateChannel(ctx, CreateChannelParams{
+			WorkspaceID: arg.WorkspaceID,
+			Name:        arg.Name,
+			IsPrivate:   arg.IsPrivate,
+			CreatedBy:   arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Member, err = q.AddChannelMember(ctx, AddChannelMemberParams{
+			ChannelID: result.Channel.ID,
+			UserID:    arg.CreatedBy,
+			AddedBy:   arg.CreatedBy,
+			Role:      "admin",
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Message, err = q.CreateChannelMessage(ctx, CreateChannelMessageParams{
+			WorkspaceID: result.Channel.WorkspaceID,
+			ChannelID:   sql.NullInt64{Int64: result.Channel.ID, Valid: true},
+			SenderID:    arg.CreatedBy,
+			Content:     arg.SystemMessage,
+			ContentType: "system",
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// CreateCanvasTx creates a canvas and records its content as the first
+// revision within a single database transaction
+func (store *SQLStore) CreateCanvasTx(ctx context.Context, arg CreateCanvasParams) (Canvas, error) {
+	var canvas Canvas
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		canvas, err = q.CreateCanvas(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateCanvasRevision(ctx, CreateCanvasRevisionParams{
+			CanvasID: canvas.ID,
+			Revision: canvas.Revision,
+			Title:    canvas.Title,
+			Content:  canvas.Content,
+			EditedBy: arg.CreatedBy,
+		})
+		return err
+	})
+
+	return canvas, err
+}
+
+// UpdateCanvasTx saves an edit to a canvas based on arg.Revision and records
+// the new revision within a single database transaction. It returns
+// sql.ErrNoRows when the canvas has moved past arg.Revision.
+func (store *SQLStore) UpdateCanvasTx(ctx context.Context, arg UpdateCanvasParams) (Canvas, error) {
+	var canvas Canvas
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		canvas, err = q.UpdateCanvas(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateCanvasRevision(ctx, CreateCanvasRevisionParams{
+			CanvasID: canvas.ID,
+			Revision: canvas.Revision,
+			Title:    canvas.Title,
+			Content:  canvas.Content,
+			EditedBy: arg.UpdatedBy,
+		})
+		return err
+	})
+
+	return canvas, err
+}
+
+// CreateMessageTxParams contains the input parameters of the create message
+// transaction. Exactly one of ChannelMessage, DirectMessage and ThreadReply
+// is set.
+type CreateMessageTxParams struct {
+	ChannelMessage   *CreateChannelMessageParams
+	DirectMessage    *CreateDirectMessageParams
+	ThreadReply      *CreateThreadReplyParams
+	FileIDs          []int64
+	MentionedUserIDs []int64
+	// AcknowledgeBy asks the members of a channel message's channel to
+	// acknowledge it, reminding those who haven't at that time
+	AcknowledgeBy sql.NullTime
+	// AfterCreate builds the event announcing the new message, which is
+	// written to the outbox in the same transaction
+	AfterCreate func(result CreateMessageTxResult) (CreateOutboxEventParams, error)
+}
+
+// CreateMessageTxResult is the result of the create message transaction
+type CreateMessageTxResult struct {
+	Message          Message              `json:"message"`
+	Files            []GetMessageFilesRow `json:"files"`
+	MentionedUserIDs []int64              `json:"mentioned_user_ids"`
+	// The summary of the thread a reply was posted to, counting the reply
+	Thread MessageThread `json:"thread"`
+	// The acknowledgment the message asks for, if it asks for one
+	AcknowledgmentRequest MessageAcknowledgmentRequest `json:"acknowledgment_request"`
+	Event                 EventOutbox                  `json:"event"`
+}
+
+// CreateMessageTx creates a channel or direct message or a thread reply,
+// links its files, records its mentions and the acknowledgment it asks for,
+// counts a reply in its thread's summary and writes the event announcing it
+// within a single database transaction
+func (store *SQLStore) CreateMessageTx(ctx context.Context, arg CreateMessageTxParams) (CreateMessageTxResult, error) {
+	var result CreateMessageTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		switch {
+		case arg.ChannelMessage != nil:
+			result.Message, err = q.CreateChannelMessage(ctx, *arg.ChannelMessage)
+		case arg.DirectMessage != nil:
+			result.Message, err = q.CreateDirectMessage(ctx, *arg.DirectMessage)
+		case arg.ThreadReply != nil:
+			result.Message, err = q.CreateThreadReply(ctx, *arg.ThreadReply)
+			if err != nil {
+				return err
+			}
+			result.Thread, err = q.RecordThreadReply(ctx, RecordThreadReplyParams{
+				ThreadID:  result.Message.ThreadID.Int64,
+				RepliedAt: sql.NullTime{Time: result.Message.CreatedAt, Valid: true},
+				SenderID:  result.Message.SenderID,
+			})
+		default:
+			err = errors.New("a channel message, direct message or thread reply is required")
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, fileID := range arg.FileIDs {
+			_, err = q.CreateMessageFile(ctx, CreateMessageFileParams{
+				MessageID: result.Message.ID,
+				FileID:    fileID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(arg.FileIDs) > 0 {
+			result.Files, err = q.GetMessageFiles(ctx, result.Message.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(arg.MentionedUserIDs) > 0 {
+			err = q.CreateMessageMentions(ctx, CreateMessageMentionsParams{
+				MessageID: result.Message.ID,
+				UserIds:   arg.MentionedUserIDs,
+			})
+			if err != nil {
+				return err
+			}
+			result.MentionedUserIDs = arg.MentionedUserIDs
+		}
+
+		if arg.AcknowledgeBy.Valid {
+			if !result.Message.ChannelID.Valid {
+				return errors.New("only channel messages can ask for acknowledgment")
+			}
+			result.AcknowledgmentRequest, err = q.CreateAcknowledgmentRequest(ctx, CreateAcknowledgmentRequestParams{
+				MessageID:   result.Message.ID,
+				WorkspaceID: result.Message.WorkspaceID,
+				ChannelID:   result.Message.ChannelID.Int64,
+				AuthorID:    result.Message.SenderID,
+				RemindAt:    arg.AcknowledgeBy.Time,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if arg.AfterCreate == nil {
+			return nil
+		}
+
+		event, err := arg.AfterCreate(result)
+		if err != nil {
+			return err
+		}
+		result.Event, err = q.CreateOutboxEvent(ctx, event)
+		return err
+	})
+
+	return result, err
+}
+
+// RollupChannelStatsTxParams contains the input parameters of the channel stats rollup transaction
+type RollupChannelStatsTxParams struct {
+	FromDay time.Time `json:"from_day"`
+	ToDay   time.Time `json:"to_day"`
+}
+
+// RollupChannelStatsTxResult is the result of the channel stats rollup transaction
+type RollupChannelStatsTxResult struct {
+	ChannelDays int64 `json:"channel_days"`
+	PosterDays  int64 `json:"poster_days"`
+}
+
+// RollupChannelStatsTx rebuilds the daily channel and poster stats of the days
+// from FromDay up to, but not including, ToDay within a single database transaction
+func (store *SQLStore) RollupChannelStatsTx(ctx context.Context, arg RollupChannelStatsTxParams) (RollupChannelStatsTxResult, error) {
+	var result RollupChannelStatsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		err = q.DeleteChannelDailyStats(ctx, DeleteChannelDailyStatsParams{
+			FromDay: arg.FromDay,
+			ToDay:   arg.ToDay,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteChannelDailyPosterStats(ctx, DeleteChannelDailyPosterStatsParams{
+			FromDay: arg.FromDay,
+			ToDay:   arg.ToDay,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ChannelDays, err = q.RollupChannelDailyStats(ctx, RollupChannelDailyStatsParams{
+			FromDay: arg.FromDay,
+			ToDay:   arg.ToDay,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.PosterDays, err = q.RollupChannelDailyPosterStats(ctx, RollupChannelDailyPosterStatsParams{
+			FromDay: arg.FromDay,
+			ToDay:   arg.ToDay,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// GetOnboardingBotTxParams contains the input parameters of the get onboarding bot transaction
+type GetOnboardingBotTxParams struct {
+	WorkspaceID int64 `json:"workspace_id"`
+	// Bot is created with these details when the workspace has no bot yet
+	Bot CreateUserParams `json:"bot"`
+}
+
+// GetOnboardingBotTx returns the user a workspace's welcome DMs are sent as,
+// creating it and adding it to the workspace as a member the first time
+// within a single database transaction. It returns sql.ErrNoRows when the
+// workspace has no onboarding.
+func (store *SQLStore) GetOnboardingBotTx(ctx context.Context, arg GetOnboardingBotTxParams) (User, error) {
+	var bot User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// Locking the onboarding keeps concurrent welcomes from creating two bots
+		onboarding, err := q.GetWorkspaceOnboardingForUpdate(ctx, arg.WorkspaceID)
+		if err != nil {
+			return err
+		}
+
+		if onboarding.BotUserID.Valid {
+			bot, err = q.GetUser(ctx, onboarding.BotUserID.Int64)
+			return err
+		}
+
+		bot, err = q.CreateUser(ctx, arg.Bot)
+		if err != nil {
+			return err
+		}
+
+		bot, err = q.AddUserToWorkspace(ctx, AddUserToWorkspaceParams{
+			ID:          bot.ID,
+			WorkspaceID: sql.NullInt64{Int64: arg.WorkspaceID, Valid: true},
+			Role:        "member",
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.SetWorkspaceOnboardingBot(ctx, SetWorkspaceOnboardingBotParams{
+			WorkspaceID: arg.WorkspaceID,
+			BotUserID:   sql.NullInt64{Int64: bot.ID, Valid: true},
+		})
+	})
+
+	return bot, err
+}
+
+// reactionMigrationBatchSize is how many reactions each statement of a
+// reaction migration moves
+const reactionMigrationBatchSize = 1000
+
+// DeleteCustomEmojiTxParams contains the input parameters of the delete custom emoji transaction
+type DeleteCustomEmojiTxParams struct {
+	ID          int64  `json:"id"`
+	WorkspaceID int64  `json:"workspace_id"`
+	Emoji       string `json:"emoji"`
+	// Replacement is the emoji existing reactions move to; they are kept
+	// as they are when it's empty
+	Replacement string `json:"replacement"`
+}
+
+// DeleteCustomEmojiTxResult is the result of the delete custom emoji transaction
+type DeleteCustomEmojiTxResult struct {
+	ReactionsMigrated int64 `json:"reactions_migrated"`
+}
+
+// DeleteCustomEmojiTx deletes a custom emoji and moves the workspace's
+// reactions with it to a replacement emoji within a single database
+// transaction. Reactions move in batches, each inserted with one statement;
+// a user who already reacted with the replacement keeps that reaction.
+func (store *SQLStore) DeleteCustomEmojiTx(ctx context.Context, arg DeleteCustomEmojiTxParams) (DeleteCustomEmojiTxResult, error) {
+	var result DeleteCustomEmojiTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var afterID int64
+		for arg.Replacement != "" {
+			reactions, err := q.ListWorkspaceReactionsByEmoji(ctx, ListWorkspaceReactionsByEmojiParams{
+				WorkspaceID: arg.WorkspaceID,
+				Emoji:       arg.Emoji,
+				AfterID:     afterID,
+				Limit:       reactionMigrationBatchSize,
+			})
+			if err != nil {
+				return err
+			}
+			if len(reactions) == 0 {
+				break
+			}
+
+			batch := CreateMessageReactionsParams{
+				MessageIds: make([]int64, len(reactions)),
+				UserIds:    make([]int64, len(reactions)),
+				Emojis:     make([]string, len(reactions)),
+				CreatedAts: make([]time.Time, len(reactions)),
+			}
+			ids := make([]int64, len(reactions))
+			for i, reaction := range reactions {
+				batch.MessageIds[i] = reaction.MessageID
+				batch.UserIds[i] = reaction.UserID
+				batch.Emojis[i] = arg.Replacement
+				batch.CreatedAts[i] = reaction.CreatedAt
+				ids[i] = reaction.ID
+			}
+
+			if _, err := q.CreateMessageReactions(ctx, batch); err != nil {
+				return err
+			}
+
+			deleted, err := q.DeleteReactionsByID(ctx, ids)
+			if err != nil {
+				return err
+			}
+			result.ReactionsMigrated += deleted
+
+			if len(reactions) < reactionMigrationBatchSize {
+				break
+			}
+			afterID = reactions[len(reactions)-1].ID
+		}
+
+		return q.DeleteCustomEmoji(ctx, arg.ID)
+	})
+
+	return result, err
+}
+
+// ErrArchivedMessagesChanged is returned by ArchiveMessagesTx when messages
+// were deleted after they were read for archiving
+var ErrArchivedMessagesChanged = errors.New("messages changed while being archived")
+
+// ArchiveMessagesTxParams contains the input parameters of the archive messages transaction
+type ArchiveMessagesTxParams struct {
+	Archive    CreateMessageArchiveParams `json:"archive"`
+	MessageIDs []int64                    `json:"message_ids"`
+}
+
+// ArchiveMessagesTx records an archive written to the archive store and
+// deletes the messages it holds within a single database transaction. Nothing
+// is changed when any of the messages was deleted in the meantime.
+func (store *SQLStore) ArchiveMessagesTx(ctx context.Context, arg ArchiveMessagesTxParams) (MessageArchive, error) {
+	var archive MessageArchive
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		archive, err = q.CreateMessageArchive(ctx, arg.Archive)
+		if err != nil {
+			return err
+		}
+
+		deleted, err := q.DeleteArchivedMessages(ctx, arg.MessageIDs)
+		if err != nil {
+			return err
+		}
+		if deleted != int64(len(arg.MessageIDs)) {
+			return ErrArchivedMessagesChanged
+		}
+		return nil
+	})
+
+	return archive, err
+}
+
+// DropMessagePartitionTx drops a monthly message partition within a database
+// transaction that isn't bound by the statement timeout. Deleting what depends
+// on a month of messages can take far longer than a request is allowed to.
+func (store *SQLStore) DropMessagePartitionTx(ctx context.Context, name string) (bool, error) {
+	var dropped bool
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.DisableStatementTimeout(ctx); err != nil {
+			return err
+		}
+
+		var err error
+		dropped, err = q.DropMessagePartition(ctx, name)
+		return err
+	})
+
+	return dropped, err
+}
@@ -0,0 +1,477 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// UserStore holds users with their statuses, availability, preferences,
+// calendars, saved searches and sign-in history
+type UserStore interface {
+	ClearExpiredCustomStatuses(ctx context.Context) ([]UserStatus, error)
+	ClearExpiredDoNotDisturb(ctx context.Context) ([]UserStatus, error)
+	CreateCalendarBusyBlock(ctx context.Context, arg CreateCalendarBusyBlockParams) error
+	CreateDNDOverride(ctx context.Context, arg CreateDNDOverrideParams) (int64, error)
+	CreateEmailVerification(ctx context.Context, arg CreateEmailVerificationParams) (EmailVerification, error)
+	CreateLoginEvent(ctx context.Context, arg CreateLoginEventParams) (LoginEvent, error)
+	CreateSavedSearch(ctx context.Context, arg CreateSavedSearchParams) (SavedSearch, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeleteCalendarBusyBlocks(ctx context.Context, integrationID int64) error
+	DeleteCalendarIntegration(ctx context.Context, arg DeleteCalendarIntegrationParams) (int64, error)
+	DeleteChannelNotificationPreference(ctx context.Context, arg DeleteChannelNotificationPreferenceParams) (int64, error)
+	DeleteOutOfOffice(ctx context.Context, arg DeleteOutOfOfficeParams) (int64, error)
+	DeleteSavedSearch(ctx context.Context, arg DeleteSavedSearchParams) error
+	DeleteUser(ctx context.Context, id int64) error
+	DeleteUserDevice(ctx context.Context, arg DeleteUserDeviceParams) (int64, error)
+	DeleteWorkspaceNotificationPreference(ctx context.Context, arg DeleteWorkspaceNotificationPreferenceParams) (int64, error)
+	GetActiveCalendarBusyBlocks(ctx context.Context) ([]GetActiveCalendarBusyBlocksRow, error)
+	GetActiveOutOfOffice(ctx context.Context, arg GetActiveOutOfOfficeParams) (OutOfOffice, error)
+	GetCalendarIntegration(ctx context.Context, arg GetCalendarIntegrationParams) (CalendarIntegration, error)
+	GetDoNotDisturb(ctx context.Context, arg GetDoNotDisturbParams) (GetDoNotDisturbRow, error)
+	GetEffectiveNotificationPreference(ctx context.Context, arg GetEffectiveNotificationPreferenceParams) (NotificationPreference, error)
+	GetOnlineUsersInWorkspace(ctx context.Context, workspaceID int64) ([]GetOnlineUsersInWorkspaceRow, error)
+	GetOutOfOffice(ctx context.Context, arg GetOutOfOfficeParams) (OutOfOffice, error)
+	GetSavedSearch(ctx context.Context, arg GetSavedSearchParams) (SavedSearch, error)
+	GetUser(ctx context.Context, id int64) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserDevice(ctx context.Context, arg GetUserDeviceParams) (UserDevice, error)
+	GetUserStatus(ctx context.Context, arg GetUserStatusParams) (UserStatus, error)
+	GetUsersByWorkspace(ctx context.Context, arg GetUsersByWorkspaceParams) ([]User, error)
+	GetWorkspaceUserStatuses(ctx context.Context, arg GetWorkspaceUserStatusesParams) ([]GetWorkspaceUserStatusesRow, error)
+	ListEnabledCalendarIntegrations(ctx context.Context) ([]CalendarIntegration, error)
+	ListLoginEvents(ctx context.Context, arg ListLoginEventsParams) ([]ListLoginEventsRow, error)
+	ListNotificationPreferences(ctx context.Context, arg ListNotificationPreferencesParams) ([]NotificationPreference, error)
+	ListSavedSearches(ctx context.Context, arg ListSavedSearchesParams) ([]SavedSearch, error)
+	ListUpcomingCalendarBusyBlocks(ctx context.Context, arg ListUpcomingCalendarBusyBlocksParams) ([]CalendarBusyBlock, error)
+	ListUserDevices(ctx context.Context, userID int64) ([]UserDevice, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListUsersByEmails(ctx context.Context, emails []string) ([]User, error)
+	ListWorkspaceDoNotDisturb(ctx context.Context, workspaceID int64) ([]ListWorkspaceDoNotDisturbRow, error)
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (User, error)
+	RecordOutOfOfficeReply(ctx context.Context, arg RecordOutOfOfficeReplyParams) (int64, error)
+	SearchOrganizationDirectory(ctx context.Context, arg SearchOrganizationDirectoryParams) ([]SearchOrganizationDirectoryRow, error)
+	SearchWorkspaceUsers(ctx context.Context, arg SearchWorkspaceUsersParams) ([]SearchWorkspaceUsersRow, error)
+	SetCalendarBusyStatus(ctx context.Context, arg SetCalendarBusyStatusParams) (UserStatus, error)
+	SetIdleUsersAway(ctx context.Context, defaultAwayMinutes sql.NullInt32) ([]UserStatus, error)
+	SetIdleUsersOffline(ctx context.Context, defaultOfflineMinutes int32) ([]UserStatus, error)
+	SetUserPresenceOffline(ctx context.Context, arg SetUserPresenceOfflineParams) (UserStatus, error)
+	SetUserPresenceOnline(ctx context.Context, arg SetUserPresenceOnlineParams) (UserStatus, error)
+	SetUsersOfflineAfterInactivity(ctx context.Context, lastActivityAt time.Time) error
+	UpdateCalendarIntegrationSync(ctx context.Context, arg UpdateCalendarIntegrationSyncParams) error
+	UpdateLastActivity(ctx context.Context, arg UpdateLastActivityParams) error
+	UpdateSavedSearch(ctx context.Context, arg UpdateSavedSearchParams) (SavedSearch, error)
+	UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarParams) (User, error)
+	UpdateUserDevice(ctx context.Context, arg UpdateUserDeviceParams) (UserDevice, error)
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (User, error)
+	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateUserWorkspace(ctx context.Context, arg UpdateUserWorkspaceParams) (User, error)
+	UpsertCalendarIntegration(ctx context.Context, arg UpsertCalendarIntegrationParams) (CalendarIntegration, error)
+	UpsertChannelNotificationPreference(ctx context.Context, arg UpsertChannelNotificationPreferenceParams) (NotificationPreference, error)
+	UpsertDoNotDisturb(ctx context.Context, arg UpsertDoNotDisturbParams) (UpsertDoNotDisturbRow, error)
+	UpsertOutOfOffice(ctx context.Context, arg UpsertOutOfOfficeParams) (OutOfOffice, error)
+	UpsertUserDevice(ctx context.Context, arg UpsertUserDeviceParams) (UserDevice, error)
+	UpsertUserStatus(ctx context.Context, arg UpsertUserStatusParams) (UserStatus, error)
+	UpsertWorkspaceNotificationPreference(ctx context.Context, arg UpsertWorkspaceNotificationPreferenceParams) (NotificationPreference, error)
+	UseEmailVerification(ctx context.Context, token string) (EmailVerification, error)
+
+	VerifyEmailTx(ctx context.Context, token string) (VerifyEmailTxResult, error)
+	ReplaceCalendarBusyBlocksTx(ctx context.Context, arg ReplaceCalendarBusyBlocksTxParams) error
+}
+
+// OrganizationStore holds organizations, their roles and their legal holds
+type OrganizationStore interface {
+	CancelOrganizationDeletion(ctx context.Context, arg CancelOrganizationDeletionParams) (OrganizationDeletion, error)
+	ChannelHasActiveLegalHold(ctx context.Context, targetID int64) (bool, error)
+	CompleteOrganizationDeletion(ctx context.Context, id int64) error
+	CountOrganizationOwners(ctx context.Context, organizationID int64) (int64, error)
+	CountOrganizationWorkspaces(ctx context.Context, organizationID int64) (int64, error)
+	CreateLegalHold(ctx context.Context, arg CreateLegalHoldParams) (LegalHold, error)
+	CreateOrganization(ctx context.Context, name string) (Organization, error)
+	CreateOrganizationDeletion(ctx context.Context, arg CreateOrganizationDeletionParams) (OrganizationDeletion, error)
+	DeleteOrganization(ctx context.Context, id int64) error
+	DeleteOrganizationRole(ctx context.Context, arg DeleteOrganizationRoleParams) (int64, error)
+	EnsureOrganizationOwner(ctx context.Context, arg EnsureOrganizationOwnerParams) error
+	GetLegalHold(ctx context.Context, arg GetLegalHoldParams) (LegalHold, error)
+	GetOrganization(ctx context.Context, id int64) (Organization, error)
+	GetOrganizationDeletion(ctx context.Context, organizationID int64) (OrganizationDeletion, error)
+	GetOrganizationRole(ctx context.Context, arg GetOrganizationRoleParams) (string, error)
+	GetOrganizationTeardownProgress(ctx context.Context, arg GetOrganizationTeardownProgressParams) (GetOrganizationTeardownProgressRow, error)
+	HasActiveLegalHold(ctx context.Context, arg HasActiveLegalHoldParams) (bool, error)
+	ListDueOrganizationDeletions(ctx context.Context, arg ListDueOrganizationDeletionsParams) ([]OrganizationDeletion, error)
+	ListLegalHoldMessages(ctx context.Context, arg ListLegalHoldMessagesParams) ([]ListLegalHoldMessagesRow, error)
+	ListLegalHolds(ctx context.Context, arg ListLegalHoldsParams) ([]LegalHold, error)
+	ListOrganizationAvatarKeys(ctx context.Context, organizationID int64) ([]string, error)
+	ListOrganizationRoles(ctx context.Context, organizationID int64) ([]ListOrganizationRolesRow, error)
+	ListOrganizations(ctx context.Context, arg ListOrganizationsParams) ([]Organization, error)
+	OrganizationHasActiveLegalHold(ctx context.Context, organizationID int64) (bool, error)
+	QueueOrganizationWorkspaceTeardowns(ctx context.Context, organizationID int64) (int64, error)
+	RecordOrganizationDeletionError(ctx context.Context, arg RecordOrganizationDeletionErrorParams) error
+	RecordOrganizationDeletionProgress(ctx context.Context, arg RecordOrganizationDeletionProgressParams) error
+	ReleaseLegalHold(ctx context.Context, arg ReleaseLegalHoldParams) (LegalHold, error)
+	StartOrganizationDeletion(ctx context.Context, id int64) (OrganizationDeletion, error)
+	UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error)
+	UpsertOrganizationRole(ctx context.Context, arg UpsertOrganizationRoleParams) (OrganizationRole, error)
+	WorkspaceHasActiveLegalHold(ctx context.Context, workspaceID int64) (bool, error)
+}
+
+// WorkspaceStore holds workspaces with their members, roles, settings,
+// invitations, onboarding, feature flags, custom emoji and teardown
+type WorkspaceStore interface {
+	AcceptWorkspaceInvitation(ctx context.Context, arg AcceptWorkspaceInvitationParams) (WorkspaceInvitation, error)
+	AddUserToWorkspace(ctx context.Context, arg AddUserToWorkspaceParams) (User, error)
+	CheckUserInWorkspace(ctx context.Context, arg CheckUserInWorkspaceParams) (bool, error)
+	CheckUserWorkspaceRole(ctx context.Context, arg CheckUserWorkspaceRoleParams) (string, error)
+	CompleteOnboardingStep(ctx context.Context, arg CompleteOnboardingStepParams) error
+	CompleteWorkspaceTeardown(ctx context.Context, workspaceID int64) error
+	CreateCustomEmoji(ctx context.Context, arg CreateCustomEmojiParams) (CustomEmoji, error)
+	CreateWorkspace(ctx context.Context, arg CreateWorkspaceParams) (Workspace, error)
+	CreateWorkspaceAutoJoinDomain(ctx context.Context, arg CreateWorkspaceAutoJoinDomainParams) (WorkspaceAutoJoinDomain, error)
+	CreateWorkspaceBanner(ctx context.Context, arg CreateWorkspaceBannerParams) (WorkspaceBanner, error)
+	CreateWorkspaceInvitation(ctx context.Context, arg CreateWorkspaceInvitationParams) (WorkspaceInvitation, error)
+	CreateWorkspaceJoinLink(ctx context.Context, arg CreateWorkspaceJoinLinkParams) (WorkspaceJoinLink, error)
+	CreateWorkspaceJoinRequest(ctx context.Context, arg CreateWorkspaceJoinRequestParams) (WorkspaceJoinRequest, error)
+	CreateWorkspaceRole(ctx context.Context, arg CreateWorkspaceRoleParams) (WorkspaceRole, error)
+	CreateWorkspaceTeardown(ctx context.Context, arg CreateWorkspaceTeardownParams) error
+	CustomEmojiExists(ctx context.Context, arg CustomEmojiExistsParams) (bool, error)
+	DeclineWorkspaceInvitation(ctx context.Context, invitationCode string) (WorkspaceInvitation, error)
+	DeleteCustomEmoji(ctx context.Context, id int64) error
+	DeleteFeatureFlag(ctx context.Context, arg DeleteFeatureFlagParams) (int64, error)
+	DeleteFilesByIDs(ctx context.Context, ids []int64) (int64, error)
+	DeleteWorkspace(ctx context.Context, id int64) error
+	DeleteWorkspaceAutoJoinDomain(ctx context.Context, arg DeleteWorkspaceAutoJoinDomainParams) (int64, error)
+	DeleteWorkspaceBanner(ctx context.Context, arg DeleteWorkspaceBannerParams) (int64, error)
+	DeleteWorkspaceInvitation(ctx context.Context, id int64) error
+	DeleteWorkspaceInvitationsBatch(ctx context.Context, arg DeleteWorkspaceInvitationsBatchParams) (int64, error)
+	DeleteWorkspaceMessagesBatch(ctx context.Context, arg DeleteWorkspaceMessagesBatchParams) (int64, error)
+	DeleteWorkspacePreferences(ctx context.Context, workspaceID int64) (int64, error)
+	DeleteWorkspaceRole(ctx context.Context, arg DeleteWorkspaceRoleParams) (int64, error)
+	DeleteWorkspaceStatuses(ctx context.Context, workspaceID int64) (int64, error)
+	ExpireWorkspaceInvitation(ctx context.Context, id int64) error
+	GetCustomEmoji(ctx context.Context, arg GetCustomEmojiParams) (CustomEmoji, error)
+	GetPendingInvitationsForUser(ctx context.Context, inviteeEmail string) ([]GetPendingInvitationsForUserRow, error)
+	GetUserRolePermissions(ctx context.Context, arg GetUserRolePermissionsParams) ([]string, error)
+	GetWorkspace(ctx context.Context, id int64) (Workspace, error)
+	GetWorkspaceAutoJoinDomain(ctx context.Context, arg GetWorkspaceAutoJoinDomainParams) (WorkspaceAutoJoinDomain, error)
+	GetWorkspaceBanner(ctx context.Context, arg GetWorkspaceBannerParams) (WorkspaceBanner, error)
+	GetWorkspaceByID(ctx context.Context, id int64) (Workspace, error)
+	GetWorkspaceInvitation(ctx context.Context, id int64) (WorkspaceInvitation, error)
+	GetWorkspaceInvitationByCode(ctx context.Context, invitationCode string) (WorkspaceInvitation, error)
+	GetWorkspaceJoinLinkByToken(ctx context.Context, token string) (WorkspaceJoinLink, error)
+	GetWorkspaceJoinRequest(ctx context.Context, arg GetWorkspaceJoinRequestParams) (WorkspaceJoinRequest, error)
+	GetWorkspaceMemberCount(ctx context.Context, workspaceID sql.NullInt64) (int64, error)
+	GetWorkspaceOnboarding(ctx context.Context, workspaceID int64) (WorkspaceOnboarding, error)
+	GetWorkspaceOnboardingForUpdate(ctx context.Context, workspaceID int64) (WorkspaceOnboarding, error)
+	GetWorkspaceRole(ctx context.Context, arg GetWorkspaceRoleParams) (WorkspaceRole, error)
+	GetWorkspaceSettings(ctx context.Context, workspaceID int64) (WorkspaceSetting, error)
+	GetWorkspaceTeardown(ctx context.Context, arg GetWorkspaceTeardownParams) (WorkspaceTeardown, error)
+	GetWorkspaceWithUserCount(ctx context.Context, id int64) (GetWorkspaceWithUserCountRow, error)
+	HasUserPostedInWorkspace(ctx context.Context, arg HasUserPostedInWorkspaceParams) (bool, error)
+	ListActiveWorkspaceBanners(ctx context.Context, workspaceID int64) ([]WorkspaceBanner, error)
+	ListAutoJoinWorkspaces(ctx context.Context, arg ListAutoJoinWorkspacesParams) ([]ListAutoJoinWorkspacesRow, error)
+	ListCustomEmojis(ctx context.Context, workspaceID int64) ([]CustomEmoji, error)
+	ListDeletedWorkspaces(ctx context.Context, arg ListDeletedWorkspacesParams) ([]Workspace, error)
+	ListFeatureFlags(ctx context.Context, workspaceID int64) ([]FeatureFlag, error)
+	ListOnboardingChannels(ctx context.Context, arg ListOnboardingChannelsParams) ([]ListOnboardingChannelsRow, error)
+	ListOnboardingStepCompletions(ctx context.Context, arg ListOnboardingStepCompletionsParams) ([]OnboardingStepCompletion, error)
+	ListPendingInvitationEmails(ctx context.Context, arg ListPendingInvitationEmailsParams) ([]string, error)
+	ListPurgeableWorkspaces(ctx context.Context, arg ListPurgeableWorkspacesParams) ([]Workspace, error)
+	ListUnfinishedWorkspaceTeardowns(ctx context.Context, limit int32) ([]WorkspaceTeardown, error)
+	ListWorkspaceAdminIDs(ctx context.Context, workspaceID sql.NullInt64) ([]int64, error)
+	ListWorkspaceAutoJoinDomains(ctx context.Context, workspaceID int64) ([]WorkspaceAutoJoinDomain, error)
+	ListWorkspaceBanners(ctx context.Context, workspaceID int64) ([]WorkspaceBanner, error)
+	ListWorkspaceFilesForTeardown(ctx context.Context, arg ListWorkspaceFilesForTeardownParams) ([]ListWorkspaceFilesForTeardownRow, error)
+	ListWorkspaceInvitations(ctx context.Context, arg ListWorkspaceInvitationsParams) ([]WorkspaceInvitation, error)
+	ListWorkspaceJoinLinks(ctx context.Context, arg ListWorkspaceJoinLinksParams) ([]WorkspaceJoinLink, error)
+	ListWorkspaceJoinRequests(ctx context.Context, arg ListWorkspaceJoinRequestsParams) ([]ListWorkspaceJoinRequestsRow, error)
+	ListWorkspaceMemberIDs(ctx context.Context, arg ListWorkspaceMemberIDsParams) ([]int64, error)
+	ListWorkspaceMembers(ctx context.Context, arg ListWorkspaceMembersParams) ([]ListWorkspaceMembersRow, error)
+	ListWorkspaceRoles(ctx context.Context, workspaceID int64) ([]ListWorkspaceRolesRow, error)
+	ListWorkspaceTeardowns(ctx context.Context, arg ListWorkspaceTeardownsParams) ([]WorkspaceTeardown, error)
+	ListWorkspacesByOrganization(ctx context.Context, arg ListWorkspacesByOrganizationParams) ([]Workspace, error)
+	RecordWorkspaceTeardownError(ctx context.Context, arg RecordWorkspaceTeardownErrorParams) error
+	RecordWorkspaceTeardownProgress(ctx context.Context, arg RecordWorkspaceTeardownProgressParams) error
+	RemoveUserFromWorkspace(ctx context.Context, arg RemoveUserFromWorkspaceParams) (User, error)
+	RestoreWorkspace(ctx context.Context, arg RestoreWorkspaceParams) (Workspace, error)
+	ReviewWorkspaceJoinRequest(ctx context.Context, arg ReviewWorkspaceJoinRequestParams) (WorkspaceJoinRequest, error)
+	RevokeWorkspaceJoinLink(ctx context.Context, arg RevokeWorkspaceJoinLinkParams) (WorkspaceJoinLink, error)
+	SetUserCustomRole(ctx context.Context, arg SetUserCustomRoleParams) (User, error)
+	SetWorkspaceInvitationEmailResult(ctx context.Context, arg SetWorkspaceInvitationEmailResultParams) error
+	SetWorkspaceOnboardingBot(ctx context.Context, arg SetWorkspaceOnboardingBotParams) error
+	SoftDeleteWorkspace(ctx context.Context, arg SoftDeleteWorkspaceParams) (int64, error)
+	UpdateWorkspace(ctx context.Context, arg UpdateWorkspaceParams) (Workspace, error)
+	UpdateWorkspaceBanner(ctx context.Context, arg UpdateWorkspaceBannerParams) (WorkspaceBanner, error)
+	UpdateWorkspaceInvitationEmailStatus(ctx context.Context, arg UpdateWorkspaceInvitationEmailStatusParams) (WorkspaceInvitation, error)
+	UpdateWorkspaceMemberRole(ctx context.Context, arg UpdateWorkspaceMemberRoleParams) (User, error)
+	UpdateWorkspaceRole(ctx context.Context, arg UpdateWorkspaceRoleParams) (WorkspaceRole, error)
+	UpsertFeatureFlag(ctx context.Context, arg UpsertFeatureFlagParams) (FeatureFlag, error)
+	UpsertWorkspaceFileSettings(ctx context.Context, arg UpsertWorkspaceFileSettingsParams) (WorkspaceSetting, error)
+	UpsertWorkspaceMessageSettings(ctx context.Context, arg UpsertWorkspaceMessageSettingsParams) (WorkspaceSetting, error)
+	UpsertWorkspaceOnboarding(ctx context.Context, arg UpsertWorkspaceOnboardingParams) (WorkspaceOnboarding, error)
+	UpsertWorkspacePresenceSettings(ctx context.Context, arg UpsertWorkspacePresenceSettingsParams) (WorkspaceSetting, error)
+	UpsertWorkspaceReactionSettings(ctx context.Context, arg UpsertWorkspaceReactionSettingsParams) (WorkspaceSetting, error)
+	UseWorkspaceJoinLink(ctx context.Context, token string) (WorkspaceJoinLink, error)
+
+	AcceptInvitationTx(ctx context.Context, arg AcceptInvitationTxParams) (AcceptInvitationTxResult, error)
+	CreateWorkspaceInvitationsTx(ctx context.Context, invitations []CreateWorkspaceInvitationParams) ([]WorkspaceInvitation, error)
+	JoinWorkspaceByLinkTx(ctx context.Context, arg JoinWorkspaceByLinkTxParams) (JoinWorkspaceByLinkTxResult, error)
+	ApproveWorkspaceJoinRequestTx(ctx context.Context, arg ApproveWorkspaceJoinRequestTxParams) (ApproveWorkspaceJoinRequestTxResult, error)
+	GetOnboardingBotTx(ctx context.Context, arg GetOnboardingBotTxParams) (User, error)
+	DeleteCustomEmojiTx(ctx context.Context, arg DeleteCustomEmojiTxParams) (DeleteCustomEmojiTxResult, error)
+	DeleteWorkspaceTx(ctx context.Context, arg SoftDeleteWorkspaceParams) error
+}
+
+// ChannelStore holds channels, their members and their activity statistics
+type ChannelStore interface {
+	AddChannelMember(ctx context.Context, arg AddChannelMemberParams) (ChannelMember, error)
+	ApplyChannelNotificationDefault(ctx context.Context, channelID int64) (int64, error)
+	CheckChannelMembership(ctx context.Context, arg CheckChannelMembershipParams) (string, error)
+	CreateChannel(ctx context.Context, arg CreateChannelParams) (Channel, error)
+	DeleteChannel(ctx context.Context, id int64) error
+	DeleteChannelDailyPosterStats(ctx context.Context, arg DeleteChannelDailyPosterStatsParams) error
+	DeleteChannelDailyStats(ctx context.Context, arg DeleteChannelDailyStatsParams) error
+	DeleteChannelNotificationDefault(ctx context.Context, channelID int64) (int64, error)
+	GetChannel(ctx context.Context, id int64) (Channel, error)
+	GetChannelByID(ctx context.Context, id int64) (Channel, error)
+	GetChannelByName(ctx context.Context, arg GetChannelByNameParams) (Channel, error)
+	GetChannelLastActivity(ctx context.Context, channelID int64) (GetChannelLastActivityRow, error)
+	GetChannelMembers(ctx context.Context, arg GetChannelMembersParams) ([]GetChannelMembersRow, error)
+	GetChannelNotificationDefault(ctx context.Context, channelID int64) (ChannelNotificationDefault, error)
+	GetChannelWithCreator(ctx context.Context, id int64) (GetChannelWithCreatorRow, error)
+	GetEarliestChannelMessageTime(ctx context.Context) (time.Time, error)
+	GetLatestChannelStatsDay(ctx context.Context) (time.Time, error)
+	GetUserChannels(ctx context.Context, arg GetUserChannelsParams) ([]Channel, error)
+	IsChannelMember(ctx context.Context, arg IsChannelMemberParams) (bool, error)
+	ListChannelDailyStats(ctx context.Context, arg ListChannelDailyStatsParams) ([]ChannelDailyStat, error)
+	ListChannelNotificationDefaults(ctx context.Context, workspaceID int64) ([]ChannelNotificationDefault, error)
+	ListChannelTopPosters(ctx context.Context, arg ListChannelTopPostersParams) ([]ListChannelTopPostersRow, error)
+	ListChannelsByWorkspace(ctx context.Context, arg ListChannelsByWorkspaceParams) ([]Channel, error)
+	ListChannelsForUser(ctx context.Context, arg ListChannelsForUserParams) ([]ListChannelsForUserRow, error)
+	ListDeletedChannels(ctx context.Context, arg ListDeletedChannelsParams) ([]Channel, error)
+	ListPublicChannelsByWorkspace(ctx context.Context, arg ListPublicChannelsByWorkspaceParams) ([]Channel, error)
+	ListPurgeableChannels(ctx context.Context, arg ListPurgeableChannelsParams) ([]Channel, error)
+	RemoveChannelMember(ctx context.Context, arg RemoveChannelMemberParams) error
+	RestoreChannel(ctx context.Context, arg RestoreChannelParams) (Channel, error)
+	RollupChannelDailyPosterStats(ctx context.Context, arg RollupChannelDailyPosterStatsParams) (int64, error)
+	RollupChannelDailyStats(ctx context.Context, arg RollupChannelDailyStatsParams) (int64, error)
+	SearchChannels(ctx context.Context, arg SearchChannelsParams) ([]SearchChannelsRow, error)
+	SoftDeleteChannel(ctx context.Context, arg SoftDeleteChannelParams) (int64, error)
+	UpdateChannel(ctx context.Context, arg UpdateChannelParams) (Channel, error)
+	UpsertChannelNotificationDefault(ctx context.Context, arg UpsertChannelNotificationDefaultParams) (ChannelNotificationDefault, error)
+
+	CreateChannelTx(ctx context.Context, arg CreateChannelTxParams) (CreateChannelTxResult, error)
+	RollupChannelStatsTx(ctx context.Context, arg RollupChannelStatsTxParams) (RollupChannelStatsTxResult, error)
+}
+
+// MessageStore holds messages with their reactions, pins, mentions,
+// translations, drafts, read state, partitions and archives
+type MessageStore interface {
+	AcknowledgeMessage(ctx context.Context, arg AcknowledgeMessageParams) (MessageAcknowledgment, error)
+	AddReaction(ctx context.Context, arg AddReactionParams) (MessageReaction, error)
+	CheckMessageAuthor(ctx context.Context, id int64) (int64, error)
+	ClaimDueAcknowledgmentReminders(ctx context.Context, limit int32) ([]MessageAcknowledgmentRequest, error)
+	CloseExternalDMRequest(ctx context.Context, id int64) (ExternalDmRequest, error)
+	CountChannelMessages(ctx context.Context, arg CountChannelMessagesParams) (int64, error)
+	CountChannelPins(ctx context.Context, channelID int64) (int64, error)
+	CountDirectMessagesBetweenUsers(ctx context.Context, arg CountDirectMessagesBetweenUsersParams) (int64, error)
+	CountUnreadMentions(ctx context.Context, arg CountUnreadMentionsParams) (int64, error)
+	CreateAcknowledgmentRequest(ctx context.Context, arg CreateAcknowledgmentRequestParams) (MessageAcknowledgmentRequest, error)
+	CreateChannelMessage(ctx context.Context, arg CreateChannelMessageParams) (Message, error)
+	CreateDirectMessage(ctx context.Context, arg CreateDirectMessageParams) (Message, error)
+	CreateExternalDMRequest(ctx context.Context, arg CreateExternalDMRequestParams) (ExternalDmRequest, error)
+	CreateMessageArchive(ctx context.Context, arg CreateMessageArchiveParams) (MessageArchive, error)
+	CreateMessageAt(ctx context.Context, arg CreateMessageAtParams) (Message, error)
+	CreateMessageMentions(ctx context.Context, arg CreateMessageMentionsParams) error
+	CreateMessagePartition(ctx context.Context, month time.Time) (bool, error)
+	CreateMessageReactions(ctx context.Context, arg CreateMessageReactionsParams) (int64, error)
+	CreateThreadReply(ctx context.Context, arg CreateThreadReplyParams) (Message, error)
+	DeleteArchivedMessages(ctx context.Context, ids []int64) (int64, error)
+	DeleteMessageDraft(ctx context.Context, arg DeleteMessageDraftParams) (MessageDraft, error)
+	DeleteMessageDraftForTarget(ctx context.Context, arg DeleteMessageDraftForTargetParams) (MessageDraft, error)
+	DeleteReactionsByID(ctx context.Context, ids []int64) (int64, error)
+	DisableStatementTimeout(ctx context.Context) error
+	DropMessagePartition(ctx context.Context, name string) (bool, error)
+	GetAcknowledgmentRequest(ctx context.Context, messageID int64) (MessageAcknowledgmentRequest, error)
+	GetChannelMessages(ctx context.Context, arg GetChannelMessagesParams) ([]GetChannelMessagesRow, error)
+	GetChannelReadState(ctx context.Context, arg GetChannelReadStateParams) (ChannelReadState, error)
+	GetDirectMessagesBetweenUsers(ctx context.Context, arg GetDirectMessagesBetweenUsersParams) ([]GetDirectMessagesBetweenUsersRow, error)
+	GetExternalDMPolicy(ctx context.Context, organizationID int64) (ExternalDmPolicy, error)
+	GetExternalDMRequest(ctx context.Context, id int64) (GetExternalDMRequestRow, error)
+	GetMessageByID(ctx context.Context, id int64) (GetMessageByIDRow, error)
+	GetMessageTranslation(ctx context.Context, arg GetMessageTranslationParams) (MessageTranslation, error)
+	GetMessagesAfter(ctx context.Context, arg GetMessagesAfterParams) ([]GetMessagesAfterRow, error)
+	GetMessagesBefore(ctx context.Context, arg GetMessagesBeforeParams) ([]GetMessagesBeforeRow, error)
+	GetReactionCounts(ctx context.Context, arg GetReactionCountsParams) (GetReactionCountsRow, error)
+	GetRecentWorkspaceMessages(ctx context.Context, arg GetRecentWorkspaceMessagesParams) ([]GetRecentWorkspaceMessagesRow, error)
+	ListAcknowledgmentRecipients(ctx context.Context, messageID int64) ([]ListAcknowledgmentRecipientsRow, error)
+	ListArchivableConversations(ctx context.Context, arg ListArchivableConversationsParams) ([]ListArchivableConversationsRow, error)
+	ListChannelMessageIDsAt(ctx context.Context, arg ListChannelMessageIDsAtParams) ([]int64, error)
+	ListChannelPinIDs(ctx context.Context, channelID int64) ([]int64, error)
+	ListChannelUnreadCounts(ctx context.Context, arg ListChannelUnreadCountsParams) ([]ListChannelUnreadCountsRow, error)
+	ListDirectMessageIDsAt(ctx context.Context, arg ListDirectMessageIDsAtParams) ([]int64, error)
+	ListDirectMessageUnreadCounts(ctx context.Context, arg ListDirectMessageUnreadCountsParams) ([]ListDirectMessageUnreadCountsRow, error)
+	ListExternalDMRequests(ctx context.Context, arg ListExternalDMRequestsParams) ([]ListExternalDMRequestsRow, error)
+	ListLegalHoldMessageArchives(ctx context.Context, arg ListLegalHoldMessageArchivesParams) ([]MessageArchive, error)
+	ListMessageAcknowledgmentStates(ctx context.Context, arg ListMessageAcknowledgmentStatesParams) ([]ListMessageAcknowledgmentStatesRow, error)
+	ListMessageArchives(ctx context.Context, arg ListMessageArchivesParams) ([]MessageArchive, error)
+	ListMessageDrafts(ctx context.Context, arg ListMessageDraftsParams) ([]MessageDraft, error)
+	ListMessageMentions(ctx context.Context, messageID int64) ([]int64, error)
+	ListMessagePartitions(ctx context.Context) ([]string, error)
+	ListMessageThreads(ctx context.Context, threadIds []int64) ([]MessageThread, error)
+	ListMessagesToArchive(ctx context.Context, arg ListMessagesToArchiveParams) ([]ListMessagesToArchiveRow, error)
+	ListPinnedMessages(ctx context.Context, channelID int64) ([]ListPinnedMessagesRow, error)
+	ListReactionSummaries(ctx context.Context, arg ListReactionSummariesParams) ([]ListReactionSummariesRow, error)
+	ListReactors(ctx context.Context, arg ListReactorsParams) ([]ListReactorsRow, error)
+	ListThreadParticipants(ctx context.Context, userIds []int64) ([]ListThreadParticipantsRow, error)
+	ListThreadUnreadCounts(ctx context.Context, arg ListThreadUnreadCountsParams) ([]ListThreadUnreadCountsRow, error)
+	ListUserMentions(ctx context.Context, arg ListUserMentionsParams) ([]ListUserMentionsRow, error)
+	ListWorkspaceReactionsByEmoji(ctx context.Context, arg ListWorkspaceReactionsByEmojiParams) ([]MessageReaction, error)
+	MarkAllChannelsRead(ctx context.Context, arg MarkAllChannelsReadParams) (int64, error)
+	MarkAllDirectMessagesRead(ctx context.Context, arg MarkAllDirectMessagesReadParams) (int64, error)
+	MarkAllThreadsRead(ctx context.Context, arg MarkAllThreadsReadParams) (int64, error)
+	MarkChannelRead(ctx context.Context, arg MarkChannelReadParams) (ChannelReadState, error)
+	MarkDirectMessagesRead(ctx context.Context, arg MarkDirectMessagesReadParams) (DirectMessageReadState, error)
+	MarkMentionRead(ctx context.Context, arg MarkMentionReadParams) (MessageMention, error)
+	MarkThreadRead(ctx context.Context, arg MarkThreadReadParams) (ThreadReadState, error)
+	PinMessage(ctx context.Context, arg PinMessageParams) (PinnedMessage, error)
+	PurgeDeletedMessages(ctx context.Context, arg PurgeDeletedMessagesParams) (int64, error)
+	RecordThreadReply(ctx context.Context, arg RecordThreadReplyParams) (MessageThread, error)
+	RefreshThreadSummary(ctx context.Context, threadID int64) (MessageThread, error)
+	RemoveReaction(ctx context.Context, arg RemoveReactionParams) (int64, error)
+	ReorderPinnedMessages(ctx context.Context, arg ReorderPinnedMessagesParams) (int64, error)
+	RespondExternalDMRequest(ctx context.Context, arg RespondExternalDMRequestParams) (ExternalDmRequest, error)
+	SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error)
+	SoftDeleteMessage(ctx context.Context, arg SoftDeleteMessageParams) error
+	TakedownMessage(ctx context.Context, arg TakedownMessageParams) (int64, error)
+	UnfollowThread(ctx context.Context, arg UnfollowThreadParams) (int64, error)
+	UnpinMessage(ctx context.Context, messageID int64) (PinnedMessage, error)
+	UpdateMessageContent(ctx context.Context, arg UpdateMessageContentParams) (Message, error)
+	UpsertExternalDMPolicy(ctx context.Context, arg UpsertExternalDMPolicyParams) (ExternalDmPolicy, error)
+	UpsertMessageDraft(ctx context.Context, arg UpsertMessageDraftParams) (MessageDraft, error)
+	UpsertMessageTranslation(ctx context.Context, arg UpsertMessageTranslationParams) (MessageTranslation, error)
+
+	CreateMessageTx(ctx context.Context, arg CreateMessageTxParams) (CreateMessageTxResult, error)
+	MarkWorkspaceReadTx(ctx context.Context, arg MarkWorkspaceReadTxParams) (MarkWorkspaceReadTxResult, error)
+	ArchiveMessagesTx(ctx context.Context, arg ArchiveMessagesTxParams) (MessageArchive, error)
+	DropMessagePartitionTx(ctx context.Context, name string) (bool, error)
+}
+
+// FileStore holds uploaded files, their shares, processing state and download log
+type FileStore interface {
+	CheckFileAccess(ctx context.Context, arg CheckFileAccessParams) (bool, error)
+	CleanupIncompleteUploads(ctx context.Context) (int64, error)
+	CreateFile(ctx context.Context, arg CreateFileParams) (File, error)
+	CreateFileShare(ctx context.Context, arg CreateFileShareParams) (FileShare, error)
+	CreateMessageFile(ctx context.Context, arg CreateMessageFileParams) (MessageFile, error)
+	DeleteFile(ctx context.Context, arg DeleteFileParams) error
+	DeleteMessageFile(ctx context.Context, arg DeleteMessageFileParams) error
+	GetDuplicateFiles(ctx context.Context, workspaceID int64) ([]GetDuplicateFilesRow, error)
+	GetFile(ctx context.Context, id int64) (File, error)
+	GetFileByHash(ctx context.Context, arg GetFileByHashParams) (File, error)
+	GetFileMessages(ctx context.Context, fileID int64) ([]GetFileMessagesRow, error)
+	GetFileShares(ctx context.Context, fileID int64) ([]GetFileSharesRow, error)
+	GetFileStats(ctx context.Context, workspaceID int64) (GetFileStatsRow, error)
+	GetFileWithPermissionCheck(ctx context.Context, arg GetFileWithPermissionCheckParams) (GetFileWithPermissionCheckRow, error)
+	GetMessageFiles(ctx context.Context, messageID int64) ([]GetMessageFilesRow, error)
+	GetTrashedFile(ctx context.Context, arg GetTrashedFileParams) (File, error)
+	IsFileInPrivateChannel(ctx context.Context, fileID int64) (bool, error)
+	ListFileAccessLog(ctx context.Context, arg ListFileAccessLogParams) ([]ListFileAccessLogRow, error)
+	ListFilesForReconciliation(ctx context.Context, arg ListFilesForReconciliationParams) ([]ListFilesForReconciliationRow, error)
+	ListPendingFilePreviews(ctx context.Context, limit int32) ([]File, error)
+	ListPendingVideoFiles(ctx context.Context, limit int32) ([]File, error)
+	ListPurgeableFiles(ctx context.Context, arg ListPurgeableFilesParams) ([]File, error)
+	ListStoredFiles(ctx context.Context) ([]ListStoredFilesRow, error)
+	ListTrashedFiles(ctx context.Context, arg ListTrashedFilesParams) ([]ListTrashedFilesRow, error)
+	ListUserFiles(ctx context.Context, arg ListUserFilesParams) ([]ListUserFilesRow, error)
+	ListWorkspaceFiles(ctx context.Context, arg ListWorkspaceFilesParams) ([]ListWorkspaceFilesRow, error)
+	PurgeFile(ctx context.Context, id int64) (int64, error)
+	RecordFileDownload(ctx context.Context, arg RecordFileDownloadParams) error
+	RequeueInterruptedFilePreviews(ctx context.Context) error
+	RequeueInterruptedFileProcessing(ctx context.Context) error
+	RestoreFile(ctx context.Context, arg RestoreFileParams) (File, error)
+	SearchFiles(ctx context.Context, arg SearchFilesParams) ([]SearchFilesRow, error)
+	TrashFile(ctx context.Context, arg TrashFileParams) (int64, error)
+	UpdateFilePreview(ctx context.Context, arg UpdateFilePreviewParams) error
+	UpdateFileProcessing(ctx context.Context, arg UpdateFileProcessingParams) error
+	UpdateFileThumbnail(ctx context.Context, arg UpdateFileThumbnailParams) error
+	UpdateFileUploadStatus(ctx context.Context, arg UpdateFileUploadStatusParams) error
+	UpdateFileVoiceMetadata(ctx context.Context, arg UpdateFileVoiceMetadataParams) error
+}
+
+// CanvasStore holds canvases and their revisions
+type CanvasStore interface {
+	CreateCanvas(ctx context.Context, arg CreateCanvasParams) (Canvas, error)
+	CreateCanvasRevision(ctx context.Context, arg CreateCanvasRevisionParams) (CanvasRevision, error)
+	DeleteCanvas(ctx context.Context, id int64) error
+	GetCanvas(ctx context.Context, arg GetCanvasParams) (Canvas, error)
+	GetCanvasRevision(ctx context.Context, arg GetCanvasRevisionParams) (CanvasRevision, error)
+	ListCanvasRevisions(ctx context.Context, arg ListCanvasRevisionsParams) ([]CanvasRevision, error)
+	ListChannelCanvases(ctx context.Context, arg ListChannelCanvasesParams) ([]Canvas, error)
+	UpdateCanvas(ctx context.Context, arg UpdateCanvasParams) (Canvas, error)
+
+	CreateCanvasTx(ctx context.Context, arg CreateCanvasParams) (Canvas, error)
+	UpdateCanvasTx(ctx context.Context, arg UpdateCanvasParams) (Canvas, error)
+}
+
+// ModerationStore holds moderation rules and actions and abuse reports
+type ModerationStore interface {
+	CreateAbuseReport(ctx context.Context, arg CreateAbuseReportParams) (AbuseReport, error)
+	CreateModerationAuditEntry(ctx context.Context, arg CreateModerationAuditEntryParams) (ModerationAuditLog, error)
+	CreateModerationQueueItem(ctx context.Context, arg CreateModerationQueueItemParams) (ModerationQueue, error)
+	CreateModerationWord(ctx context.Context, arg CreateModerationWordParams) (ModerationWord, error)
+	DeleteModerationWord(ctx context.Context, arg DeleteModerationWordParams) (int64, error)
+	GetAbuseReport(ctx context.Context, arg GetAbuseReportParams) (AbuseReport, error)
+	GetModerationQueueItem(ctx context.Context, arg GetModerationQueueItemParams) (ModerationQueue, error)
+	ListAbuseReports(ctx context.Context, arg ListAbuseReportsParams) ([]AbuseReport, error)
+	ListModerationAuditLog(ctx context.Context, arg ListModerationAuditLogParams) ([]ModerationAuditLog, error)
+	ListModerationQueue(ctx context.Context, arg ListModerationQueueParams) ([]ListModerationQueueRow, error)
+	ListModerationWords(ctx context.Context, workspaceID int64) ([]ModerationWord, error)
+	ResolveAbuseReport(ctx context.Context, arg ResolveAbuseReportParams) (AbuseReport, error)
+	ResolveModerationQueueItem(ctx context.Context, arg ResolveModerationQueueItemParams) (ModerationQueue, error)
+}
+
+// EmailStore holds outgoing emails, suppressed addresses and email templates
+type EmailStore interface {
+	ClaimEmailDeliveries(ctx context.Context, arg ClaimEmailDeliveriesParams) ([]EmailDelivery, error)
+	CreateEmailDelivery(ctx context.Context, arg CreateEmailDeliveryParams) (EmailDelivery, error)
+	DeleteEmailSuppression(ctx context.Context, email string) (int64, error)
+	DeleteEmailTemplate(ctx context.Context, arg DeleteEmailTemplateParams) (int64, error)
+	DeleteOldEmailDeliveries(ctx context.Context, createdAt time.Time) (int64, error)
+	FailEmailDelivery(ctx context.Context, arg FailEmailDeliveryParams) error
+	GetEmailBranding(ctx context.Context, organizationID int64) (EmailBranding, error)
+	GetEmailSuppression(ctx context.Context, email string) (EmailSuppression, error)
+	GetEmailTemplate(ctx context.Context, arg GetEmailTemplateParams) (EmailTemplate, error)
+	ListEmailDeliveriesByAddress(ctx context.Context, arg ListEmailDeliveriesByAddressParams) ([]EmailDelivery, error)
+	ListEmailSuppressionsByEmails(ctx context.Context, emails []string) ([]EmailSuppression, error)
+	ListEmailTemplates(ctx context.Context, organizationID int64) ([]EmailTemplate, error)
+	MarkEmailDeliverySent(ctx context.Context, arg MarkEmailDeliverySentParams) error
+	RetryEmailDelivery(ctx context.Context, arg RetryEmailDeliveryParams) error
+	UpsertEmailBranding(ctx context.Context, arg UpsertEmailBrandingParams) (EmailBranding, error)
+	UpsertEmailSuppression(ctx context.Context, arg UpsertEmailSuppressionParams) (EmailSuppression, error)
+	UpsertEmailTemplate(ctx context.Context, arg UpsertEmailTemplateParams) (EmailTemplate, error)
+}
+
+// OutboxStore holds real-time events waiting to be published, the workspace
+// event stream read by data pipelines and the outgoing webhooks events are
+// delivered to
+type OutboxStore interface {
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (EventOutbox, error)
+	CreateOutgoingWebhook(ctx context.Context, arg CreateOutgoingWebhookParams) (OutgoingWebhook, error)
+	CreateWebhookEvent(ctx context.Context, arg CreateWebhookEventParams) (EventOutbox, error)
+	DeleteOldWebhookDeliveries(ctx context.Context, createdAt time.Time) (int64, error)
+	DeleteOutgoingWebhook(ctx context.Context, arg DeleteOutgoingWebhookParams) (int64, error)
+	DeletePublishedOutboxEvents(ctx context.Context, publishedAt sql.NullTime) (int64, error)
+	DeleteWorkspaceEvents(ctx context.Context, createdAt time.Time) (int64, error)
+	FailWebhookDelivery(ctx context.Context, arg FailWebhookDeliveryParams) error
+	GetOutgoingWebhook(ctx context.Context, id int64) (OutgoingWebhook, error)
+	ListOutgoingWebhooks(ctx context.Context, workspaceID int64) ([]OutgoingWebhook, error)
+	ListUnpublishedOutboxEvents(ctx context.Context, arg ListUnpublishedOutboxEventsParams) ([]EventOutbox, error)
+	ListUnpublishedWorkspaceEvents(ctx context.Context, arg ListUnpublishedWorkspaceEventsParams) ([]WorkspaceEvent, error)
+	ListWorkspaceEvents(ctx context.Context, arg ListWorkspaceEventsParams) ([]WorkspaceEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64) error
+	MarkWebhookDelivered(ctx context.Context, id int64) error
+	MarkWorkspaceEventsPublished(ctx context.Context, ids []int64) error
+	RetryWebhookDelivery(ctx context.Context, arg RetryWebhookDeliveryParams) error
+}
@@ -8,11 +8,23 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 const checkUserWorkspaceRole = `-- name: CheckUserWorkspaceRole :one
-SELECT role FROM users
-WHERE id = $1 AND workspace_id = $2
+SELECT (CASE WHEN o.role IS NULL THEN u.role ELSE 'admin' END)::varchar AS role
+FROM users u
+LEFT JOIN organization_roles o ON o.user_id = u.id
+    AND o.organization_id = (SELECT w.organization_id FROM workspaces w WHERE w.id = $2)
+WHERE u.id = $1 AND (u.workspace_id = $2 OR o.role IS NOT NULL)
+    AND NOT EXISTS (SELECT 1 FROM workspaces dw WHERE dw.id = $2 AND dw.deleted_at IS NOT NULL)
+    AND NOT EXISTS (
+        SELECT 1 FROM workspaces ow
+        JOIN organization_deletions od ON od.organization_id = ow.organization_id
+        WHERE ow.id = $2 AND od.status IN ('pending', 'running')
+    )
 LIMIT 1
 `
 
@@ -21,6 +33,9 @@ type CheckUserWorkspaceRoleParams struct {
 	WorkspaceID sql.NullInt64 `json:"workspace_id"`
 }
 
+// Organization owners and admins act as admins of every workspace in their
+// organization. Nobody has a role in a deleted workspace, or in one whose
+// organization is being deleted.
 func (q *Queries) CheckUserWorkspaceRole(ctx context.Context, arg CheckUserWorkspaceRoleParams) (string, error) {
 	row := q.db.QueryRowContext(ctx, checkUserWorkspaceRole, arg.ID, arg.WorkspaceID)
 	var role string
@@ -38,7 +53,7 @@ INSERT INTO users (
 ) VALUES (
     $1, $2, $3, $4, $5
 )
-RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role
+RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle
 `
 
 type CreateUserParams struct {
@@ -69,6 +84,14 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
 	)
 	return i, err
 }
@@ -84,7 +107,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role FROM users
+SELECT id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -102,12 +125,20 @@ func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role FROM users
+SELECT id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -125,12 +156,20 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
 	)
 	return i, err
 }
 
 const getUsersByWorkspace = `-- name: GetUsersByWorkspace :many
-SELECT id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role FROM users
+SELECT id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle FROM users
 WHERE workspace_id = $1
 ORDER BY created_at ASC
 LIMIT $2
@@ -163,6 +202,14 @@ func (q *Queries) GetUsersByWorkspace(ctx context.Context, arg GetUsersByWorkspa
 			&i.CreatedAt,
 			&i.WorkspaceID,
 			&i.Role,
+			&i.EmailVerifiedAt,
+			&i.Title,
+			&i.Pronouns,
+			&i.Phone,
+			&i.Timezone,
+			&i.AvatarKey,
+			&i.CustomRoleID,
+			&i.Handle,
 		); err != nil {
 			return nil, err
 		}
@@ -178,7 +225,7 @@ func (q *Queries) GetUsersByWorkspace(ctx context.Context, arg GetUsersByWorkspa
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role FROM users
+SELECT id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle FROM users
 WHERE organization_id = $1
 ORDER BY id
 LIMIT $2
@@ -211,6 +258,239 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.CreatedAt,
 			&i.WorkspaceID,
 			&i.Role,
+			&i.EmailVerifiedAt,
+			&i.Title,
+			&i.Pronouns,
+			&i.Phone,
+			&i.Timezone,
+			&i.AvatarKey,
+			&i.CustomRoleID,
+			&i.Handle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersByEmails = `-- name: ListUsersByEmails :many
+SELECT id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle FROM users
+WHERE lower(email) = ANY($1::text[])
+`
+
+func (q *Queries) ListUsersByEmails(ctx context.Context, emails []string) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersByEmails, pq.Array(emails))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Email,
+			&i.FirstName,
+			&i.LastName,
+			&i.HashedPassword,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.WorkspaceID,
+			&i.Role,
+			&i.EmailVerifiedAt,
+			&i.Title,
+			&i.Pronouns,
+			&i.Phone,
+			&i.Timezone,
+			&i.AvatarKey,
+			&i.CustomRoleID,
+			&i.Handle,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkspaceAdminIDs = `-- name: ListWorkspaceAdminIDs :many
+SELECT id FROM users
+WHERE workspace_id = $1 AND role = 'admin'
+ORDER BY id
+`
+
+func (q *Queries) ListWorkspaceAdminIDs(ctx context.Context, workspaceID sql.NullInt64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listWorkspaceAdminIDs, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkspaceMemberIDs = `-- name: ListWorkspaceMemberIDs :many
+SELECT id FROM users
+WHERE workspace_id = $1 AND id = ANY($2::bigint[])
+ORDER BY id
+`
+
+type ListWorkspaceMemberIDsParams struct {
+	WorkspaceID sql.NullInt64 `json:"workspace_id"`
+	UserIds     []int64       `json:"user_ids"`
+}
+
+// Keeps the users that belong to the workspace, e.g. to check who a message mentions
+func (q *Queries) ListWorkspaceMemberIDs(ctx context.Context, arg ListWorkspaceMemberIDsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listWorkspaceMemberIDs, arg.WorkspaceID, pq.Array(arg.UserIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchOrganizationDirectory = `-- name: SearchOrganizationDirectory :many
+SELECT
+    u.id, u.organization_id, u.email, u.first_name, u.last_name, u.hashed_password, u.password_changed_at, u.created_at, u.workspace_id, u.role, u.email_verified_at, u.title, u.pronouns, u.phone, u.timezone, u.avatar_key, u.custom_role_id, u.handle,
+    COALESCE(w.name, '')::text AS workspace_name,
+    COALESCE(us.status, 'offline')::text AS status,
+    us.custom_status,
+    us.status_emoji,
+    COUNT(*) OVER() AS total_count
+FROM users u
+LEFT JOIN workspaces w ON w.id = u.workspace_id
+LEFT JOIN user_status us ON us.user_id = u.id AND us.workspace_id = u.workspace_id
+WHERE u.organization_id = $1
+    AND (
+        $2::text = ''
+        OR (u.first_name || ' ' || u.last_name) ILIKE '%' || $2::text || '%'
+        OR u.handle ILIKE '%' || $2::text || '%'
+        OR u.title ILIKE '%' || $2::text || '%'
+        OR ($3::boolean AND u.email ILIKE '%' || $2::text || '%')
+    )
+ORDER BY u.first_name ASC, u.last_name ASC, u.id ASC
+LIMIT $4
+OFFSET $5
+`
+
+type SearchOrganizationDirectoryParams struct {
+	OrganizationID int64  `json:"organization_id"`
+	Query          string `json:"query"`
+	SearchEmail    bool   `json:"search_email"`
+	Limit          int32  `json:"limit"`
+	Offset         int32  `json:"offset"`
+}
+
+type SearchOrganizationDirectoryRow struct {
+	ID                int64          `json:"id"`
+	OrganizationID    int64          `json:"organization_id"`
+	Email             string         `json:"email"`
+	FirstName         string         `json:"first_name"`
+	LastName          string         `json:"last_name"`
+	HashedPassword    string         `json:"hashed_password"`
+	PasswordChangedAt time.Time      `json:"password_changed_at"`
+	CreatedAt         time.Time      `json:"created_at"`
+	WorkspaceID       sql.NullInt64  `json:"workspace_id"`
+	Role              string         `json:"role"`
+	EmailVerifiedAt   sql.NullTime   `json:"email_verified_at"`
+	Title             string         `json:"title"`
+	Pronouns          string         `json:"pronouns"`
+	Phone             string         `json:"phone"`
+	Timezone          string         `json:"timezone"`
+	AvatarKey         sql.NullString `json:"avatar_key"`
+	CustomRoleID      sql.NullInt64  `json:"custom_role_id"`
+	Handle            string         `json:"handle"`
+	WorkspaceName     string         `json:"workspace_name"`
+	Status            string         `json:"status"`
+	CustomStatus      sql.NullString `json:"custom_status"`
+	StatusEmoji       sql.NullString `json:"status_emoji"`
+	TotalCount        int64          `json:"total_count"`
+}
+
+// Lists an organization's people across all its workspaces, with their
+// workspace and presence. People match on name, handle or title, and on
+// email when search_email is set; an empty query matches everyone.
+func (q *Queries) SearchOrganizationDirectory(ctx context.Context, arg SearchOrganizationDirectoryParams) ([]SearchOrganizationDirectoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchOrganizationDirectory,
+		arg.OrganizationID,
+		arg.Query,
+		arg.SearchEmail,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchOrganizationDirectoryRow{}
+	for rows.Next() {
+		var i SearchOrganizationDirectoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Email,
+			&i.FirstName,
+			&i.LastName,
+			&i.HashedPassword,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.WorkspaceID,
+			&i.Role,
+			&i.EmailVerifiedAt,
+			&i.Title,
+			&i.Pronouns,
+			&i.Phone,
+			&i.Timezone,
+			&i.AvatarKey,
+			&i.CustomRoleID,
+			&i.Handle,
+			&i.WorkspaceName,
+			&i.Status,
+			&i.CustomStatus,
+			&i.StatusEmoji,
+			&i.TotalCount,
 		); err != nil {
 			return nil, err
 		}
@@ -225,13 +505,148 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
+const searchWorkspaceUsers = `-- name: SearchWorkspaceUsers :many
+SELECT
+    u.id,
+    u.organization_id,
+    u.first_name,
+    u.last_name,
+    u.workspace_id,
+    u.role,
+    u.handle,
+    u.title,
+    u.pronouns,
+    u.timezone,
+    u.avatar_key,
+    u.created_at,
+    (CASE WHEN $1::boolean THEN u.email ELSE '' END)::text AS email,
+    COUNT(*) OVER() as total_count
+FROM users u
+WHERE u.workspace_id = $2
+    AND (
+        (u.first_name || ' ' || u.last_name) ILIKE '%' || $3::text || '%'
+        OR u.handle ILIKE '%' || $3::text || '%'
+        OR ($1::boolean AND u.email ILIKE '%' || $3::text || '%')
+    )
+ORDER BY u.first_name ASC, u.last_name ASC
+LIMIT $4
+`
+
+type SearchWorkspaceUsersParams struct {
+	IncludeEmail bool          `json:"include_email"`
+	WorkspaceID  sql.NullInt64 `json:"workspace_id"`
+	Query        string        `json:"query"`
+	Limit        int32         `json:"limit"`
+}
+
+type SearchWorkspaceUsersRow struct {
+	ID             int64          `json:"id"`
+	OrganizationID int64          `json:"organization_id"`
+	FirstName      string         `json:"first_name"`
+	LastName       string         `json:"last_name"`
+	WorkspaceID    sql.NullInt64  `json:"workspace_id"`
+	Role           string         `json:"role"`
+	Handle         string         `json:"handle"`
+	Title          string         `json:"title"`
+	Pronouns       string         `json:"pronouns"`
+	Timezone       string         `json:"timezone"`
+	AvatarKey      sql.NullString `json:"avatar_key"`
+	CreatedAt      time.Time      `json:"created_at"`
+	Email          string         `json:"email"`
+	TotalCount     int64          `json:"total_count"`
+}
+
+// Lists the people of a workspace whose name or handle matches the query,
+// with their public profile only. Emails are matched and returned only when
+// include_email is set.
+func (q *Queries) SearchWorkspaceUsers(ctx context.Context, arg SearchWorkspaceUsersParams) ([]SearchWorkspaceUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchWorkspaceUsers,
+		arg.IncludeEmail,
+		arg.WorkspaceID,
+		arg.Query,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchWorkspaceUsersRow{}
+	for rows.Next() {
+		var i SearchWorkspaceUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.FirstName,
+			&i.LastName,
+			&i.WorkspaceID,
+			&i.Role,
+			&i.Handle,
+			&i.Title,
+			&i.Pronouns,
+			&i.Timezone,
+			&i.AvatarKey,
+			&i.CreatedAt,
+			&i.Email,
+			&i.TotalCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateUserAvatar = `-- name: UpdateUserAvatar :one
+UPDATE users
+SET avatar_key = $2
+WHERE id = $1
+RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle
+`
+
+type UpdateUserAvatarParams struct {
+	ID        int64          `json:"id"`
+	AvatarKey sql.NullString `json:"avatar_key"`
+}
+
+func (q *Queries) UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserAvatar, arg.ID, arg.AvatarKey)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Email,
+		&i.FirstName,
+		&i.LastName,
+		&i.HashedPassword,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.WorkspaceID,
+		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
+	)
+	return i, err
+}
+
 const updateUserPassword = `-- name: UpdateUserPassword :one
 UPDATE users
 SET
     hashed_password = $2,
     password_changed_at = now()
 WHERE id = $1
-RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role
+RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle
 `
 
 type UpdateUserPasswordParams struct {
@@ -253,6 +668,14 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
 	)
 	return i, err
 }
@@ -260,20 +683,40 @@ func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPassword
 const updateUserProfile = `-- name: UpdateUserProfile :one
 UPDATE users
 SET
-    first_name = $2,
-    last_name = $3
-WHERE id = $1
-RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role
+    first_name = $1,
+    last_name = $2,
+    title = COALESCE($3, title),
+    pronouns = COALESCE($4, pronouns),
+    phone = COALESCE($5, phone),
+    timezone = COALESCE($6, timezone),
+    handle = COALESCE($7, handle)
+WHERE id = $8
+RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle
 `
 
 type UpdateUserProfileParams struct {
-	ID        int64  `json:"id"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
+	FirstName string         `json:"first_name"`
+	LastName  string         `json:"last_name"`
+	Title     sql.NullString `json:"title"`
+	Pronouns  sql.NullString `json:"pronouns"`
+	Phone     sql.NullString `json:"phone"`
+	Timezone  sql.NullString `json:"timezone"`
+	Handle    sql.NullString `json:"handle"`
+	ID        int64          `json:"id"`
 }
 
+// Profile details that aren't given keep their current value
 func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, updateUserProfile, arg.ID, arg.FirstName, arg.LastName)
+	row := q.db.QueryRowContext(ctx, updateUserProfile,
+		arg.FirstName,
+		arg.LastName,
+		arg.Title,
+		arg.Pronouns,
+		arg.Phone,
+		arg.Timezone,
+		arg.Handle,
+		arg.ID,
+	)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -286,6 +729,14 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
 	)
 	return i, err
 }
@@ -294,7 +745,7 @@ const updateUserRole = `-- name: UpdateUserRole :one
 UPDATE users
 SET role = $2
 WHERE id = $1
-RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role
+RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle
 `
 
 type UpdateUserRoleParams struct {
@@ -316,6 +767,14 @@ func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams)
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
 	)
 	return i, err
 }
@@ -324,9 +783,10 @@ const updateUserWorkspace = `-- name: UpdateUserWorkspace :one
 UPDATE users
 SET 
     workspace_id = $2,
-    role = $3
+    role = $3,
+    custom_role_id = NULL
 WHERE id = $1
-RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role
+RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle
 `
 
 type UpdateUserWorkspaceParams struct {
@@ -349,6 +809,14 @@ func (q *Queries) UpdateUserWorkspace(ctx context.Context, arg UpdateUserWorkspa
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
 	)
 	return i, err
 }
@@ -11,9 +11,57 @@ import (
 	"time"
 )
 
+const clearExpiredCustomStatuses = `-- name: ClearExpiredCustomStatuses :many
+UPDATE user_status
+SET 
+    status = CASE WHEN set_by_calendar AND status = 'busy' THEN 'online' ELSE status END,
+    custom_status = NULL,
+    status_emoji = NULL,
+    clear_after = NULL,
+    set_by_calendar = false,
+    updated_at = now()
+WHERE clear_after IS NOT NULL AND clear_after <= now()
+RETURNING user_id, workspace_id, status, custom_status, last_activity_at, last_seen_at, updated_at, status_emoji, clear_after, set_by_calendar
+`
+
+// Statuses set from a calendar also return from busy to online
+func (q *Queries) ClearExpiredCustomStatuses(ctx context.Context) ([]UserStatus, error) {
+	rows, err := q.db.QueryContext(ctx, clearExpiredCustomStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserStatus{}
+	for rows.Next() {
+		var i UserStatus
+		if err := rows.Scan(
+			&i.UserID,
+			&i.WorkspaceID,
+			&i.Status,
+			&i.CustomStatus,
+			&i.LastActivityAt,
+			&i.LastSeenAt,
+			&i.UpdatedAt,
+			&i.StatusEmoji,
+			&i.ClearAfter,
+			&i.SetByCalendar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getOnlineUsersInWorkspace = `-- name: GetOnlineUsersInWorkspace :many
 SELECT 
-    us.user_id, us.workspace_id, us.status, us.custom_status, us.last_activity_at, us.last_seen_at, us.updated_at,
+    us.user_id, us.workspace_id, us.status, us.custom_status, us.last_activity_at, us.last_seen_at, us.updated_at, us.status_emoji, us.clear_after, us.set_by_calendar,
     u.first_name,
     u.last_name,
     u.email
@@ -32,6 +80,9 @@ type GetOnlineUsersInWorkspaceRow struct {
 	LastActivityAt time.Time      `json:"last_activity_at"`
 	LastSeenAt     time.Time      `json:"last_seen_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
+	StatusEmoji    sql.NullString `json:"status_emoji"`
+	ClearAfter     sql.NullTime   `json:"clear_after"`
+	SetByCalendar  bool           `json:"set_by_calendar"`
 	FirstName      string         `json:"first_name"`
 	LastName       string         `json:"last_name"`
 	Email          string         `json:"email"`
@@ -54,6 +105,9 @@ func (q *Queries) GetOnlineUsersInWorkspace(ctx context.Context, workspaceID int
 			&i.LastActivityAt,
 			&i.LastSeenAt,
 			&i.UpdatedAt,
+			&i.StatusEmoji,
+			&i.ClearAfter,
+			&i.SetByCalendar,
 			&i.FirstName,
 			&i.LastName,
 			&i.Email,
@@ -72,7 +126,7 @@ func (q *Queries) GetOnlineUsersInWorkspace(ctx context.Context, workspaceID int
 }
 
 const getUserStatus = `-- name: GetUserStatus :one
-SELECT user_id, workspace_id, status, custom_status, last_activity_at, last_seen_at, updated_at FROM user_status
+SELECT user_id, workspace_id, status, custom_status, last_activity_at, last_seen_at, updated_at, status_emoji, clear_after, set_by_calendar FROM user_status
 WHERE user_id = $1 AND workspace_id = $2
 `
 
@@ -92,13 +146,16 @@ func (q *Queries) GetUserStatus(ctx context.Context, arg GetUserStatusParams) (U
 		&i.LastActivityAt,
 		&i.LastSeenAt,
 		&i.UpdatedAt,
+		&i.StatusEmoji,
+		&i.ClearAfter,
+		&i.SetByCalendar,
 	)
 	return i, err
 }
 
 const getWorkspaceUserStatuses = `-- name: GetWorkspaceUserStatuses :many
 SELECT 
-    us.user_id, us.workspace_id, us.status, us.custom_status, us.last_activity_at, us.last_seen_at, us.updated_at,
+    us.user_id, us.workspace_id, us.status, us.custom_status, us.last_activity_at, us.last_seen_at, us.updated_at, us.status_emoji, us.clear_after, us.set_by_calendar,
     u.first_name,
     u.last_name,
     u.email
@@ -124,6 +181,9 @@ type GetWorkspaceUserStatusesRow struct {
 	LastActivityAt time.Time      `json:"last_activity_at"`
 	LastSeenAt     time.Time      `json:"last_seen_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
+	StatusEmoji    sql.NullString `json:"status_emoji"`
+	ClearAfter     sql.NullTime   `json:"clear_after"`
+	SetByCalendar  bool           `json:"set_by_calendar"`
 	FirstName      string         `json:"first_name"`
 	LastName       string         `json:"last_name"`
 	Email          string         `json:"email"`
@@ -146,6 +206,9 @@ func (q *Queries) GetWorkspaceUserStatuses(ctx context.Context, arg GetWorkspace
 			&i.LastActivityAt,
 			&i.LastSeenAt,
 			&i.UpdatedAt,
+			&i.StatusEmoji,
+			&i.ClearAfter,
+			&i.SetByCalendar,
 			&i.FirstName,
 			&i.LastName,
 			&i.Email,
@@ -163,6 +226,234 @@ func (q *Queries) GetWorkspaceUserStatuses(ctx context.Context, arg GetWorkspace
 	return items, nil
 }
 
+const setCalendarBusyStatus = `-- name: SetCalendarBusyStatus :one
+UPDATE user_status
+SET 
+    status = CASE WHEN status = 'offline' THEN status ELSE 'busy' END,
+    custom_status = $3,
+    status_emoji = $4,
+    clear_after = $5,
+    set_by_calendar = true,
+    updated_at = now()
+WHERE user_id = $1 AND workspace_id = $2
+    AND (set_by_calendar OR custom_status IS NULL)
+    AND (clear_after IS DISTINCT FROM $5 OR NOT set_by_calendar)
+RETURNING user_id, workspace_id, status, custom_status, last_activity_at, last_seen_at, updated_at, status_emoji, clear_after, set_by_calendar
+`
+
+type SetCalendarBusyStatusParams struct {
+	UserID       int64          `json:"user_id"`
+	WorkspaceID  int64          `json:"workspace_id"`
+	CustomStatus sql.NullString `json:"custom_status"`
+	StatusEmoji  sql.NullString `json:"status_emoji"`
+	ClearAfter   sql.NullTime   `json:"clear_after"`
+}
+
+// Sets a meeting status until the event ends. Custom statuses the user chose
+// themselves are left alone.
+func (q *Queries) SetCalendarBusyStatus(ctx context.Context, arg SetCalendarBusyStatusParams) (UserStatus, error) {
+	row := q.db.QueryRowContext(ctx, setCalendarBusyStatus,
+		arg.UserID,
+		arg.WorkspaceID,
+		arg.CustomStatus,
+		arg.StatusEmoji,
+		arg.ClearAfter,
+	)
+	var i UserStatus
+	err := row.Scan(
+		&i.UserID,
+		&i.WorkspaceID,
+		&i.Status,
+		&i.CustomStatus,
+		&i.LastActivityAt,
+		&i.LastSeenAt,
+		&i.UpdatedAt,
+		&i.StatusEmoji,
+		&i.ClearAfter,
+		&i.SetByCalendar,
+	)
+	return i, err
+}
+
+const setIdleUsersAway = `-- name: SetIdleUsersAway :many
+UPDATE user_status us
+SET 
+    status = 'away',
+    updated_at = now()
+WHERE us.status = 'online'
+    AND COALESCE(
+        (SELECT ws.away_after_minutes FROM workspace_settings ws WHERE ws.workspace_id = us.workspace_id),
+        $1::int
+    ) IS NOT NULL
+    AND us.last_activity_at < now() - make_interval(mins => COALESCE(
+        (SELECT ws.away_after_minutes FROM workspace_settings ws WHERE ws.workspace_id = us.workspace_id),
+        $1::int
+    ))
+RETURNING us.user_id, us.workspace_id, us.status, us.custom_status, us.last_activity_at, us.last_seen_at, us.updated_at, us.status_emoji, us.clear_after, us.set_by_calendar
+`
+
+// Marks online users away once they have been inactive longer than their
+// workspace's away threshold. Workspaces without a threshold fall back to the
+// default, and no one is marked away when neither is set.
+func (q *Queries) SetIdleUsersAway(ctx context.Context, defaultAwayMinutes sql.NullInt32) ([]UserStatus, error) {
+	rows, err := q.db.QueryContext(ctx, setIdleUsersAway, defaultAwayMinutes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserStatus{}
+	for rows.Next() {
+		var i UserStatus
+		if err := rows.Scan(
+			&i.UserID,
+			&i.WorkspaceID,
+			&i.Status,
+			&i.CustomStatus,
+			&i.LastActivityAt,
+			&i.LastSeenAt,
+			&i.UpdatedAt,
+			&i.StatusEmoji,
+			&i.ClearAfter,
+			&i.SetByCalendar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setIdleUsersOffline = `-- name: SetIdleUsersOffline :many
+UPDATE user_status us
+SET 
+    status = 'offline',
+    updated_at = now()
+WHERE us.status != 'offline'
+    AND us.last_activity_at < now() - make_interval(mins => COALESCE(
+        (SELECT ws.offline_after_minutes FROM workspace_settings ws WHERE ws.workspace_id = us.workspace_id),
+        $1::int
+    ))
+RETURNING us.user_id, us.workspace_id, us.status, us.custom_status, us.last_activity_at, us.last_seen_at, us.updated_at, us.status_emoji, us.clear_after, us.set_by_calendar
+`
+
+// Marks users offline once they have been inactive longer than their
+// workspace's offline threshold, falling back to the default
+func (q *Queries) SetIdleUsersOffline(ctx context.Context, defaultOfflineMinutes int32) ([]UserStatus, error) {
+	rows, err := q.db.QueryContext(ctx, setIdleUsersOffline, defaultOfflineMinutes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserStatus{}
+	for rows.Next() {
+		var i UserStatus
+		if err := rows.Scan(
+			&i.UserID,
+			&i.WorkspaceID,
+			&i.Status,
+			&i.CustomStatus,
+			&i.LastActivityAt,
+			&i.LastSeenAt,
+			&i.UpdatedAt,
+			&i.StatusEmoji,
+			&i.ClearAfter,
+			&i.SetByCalendar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserPresenceOffline = `-- name: SetUserPresenceOffline :one
+UPDATE user_status
+SET 
+    status = 'offline',
+    last_seen_at = now(),
+    updated_at = now()
+WHERE user_id = $1 AND workspace_id = $2
+RETURNING user_id, workspace_id, status, custom_status, last_activity_at, last_seen_at, updated_at, status_emoji, clear_after, set_by_calendar
+`
+
+type SetUserPresenceOfflineParams struct {
+	UserID      int64 `json:"user_id"`
+	WorkspaceID int64 `json:"workspace_id"`
+}
+
+// Takes a user offline while keeping their custom status
+func (q *Queries) SetUserPresenceOffline(ctx context.Context, arg SetUserPresenceOfflineParams) (UserStatus, error) {
+	row := q.db.QueryRowContext(ctx, setUserPresenceOffline, arg.UserID, arg.WorkspaceID)
+	var i UserStatus
+	err := row.Scan(
+		&i.UserID,
+		&i.WorkspaceID,
+		&i.Status,
+		&i.CustomStatus,
+		&i.LastActivityAt,
+		&i.LastSeenAt,
+		&i.UpdatedAt,
+		&i.StatusEmoji,
+		&i.ClearAfter,
+		&i.SetByCalendar,
+	)
+	return i, err
+}
+
+const setUserPresenceOnline = `-- name: SetUserPresenceOnline :one
+INSERT INTO user_status (
+    user_id,
+    workspace_id,
+    status,
+    updated_at
+) VALUES (
+    $1, $2, 'online', now()
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    workspace_id = EXCLUDED.workspace_id,
+    status = CASE WHEN user_status.status = 'offline' THEN 'online' ELSE user_status.status END,
+    last_activity_at = now(),
+    last_seen_at = now(),
+    updated_at = now()
+RETURNING user_id, workspace_id, status, custom_status, last_activity_at, last_seen_at, updated_at, status_emoji, clear_after, set_by_calendar
+`
+
+type SetUserPresenceOnlineParams struct {
+	UserID      int64 `json:"user_id"`
+	WorkspaceID int64 `json:"workspace_id"`
+}
+
+// Brings a user online without touching an explicitly chosen away/busy status or custom status
+func (q *Queries) SetUserPresenceOnline(ctx context.Context, arg SetUserPresenceOnlineParams) (UserStatus, error) {
+	row := q.db.QueryRowContext(ctx, setUserPresenceOnline, arg.UserID, arg.WorkspaceID)
+	var i UserStatus
+	err := row.Scan(
+		&i.UserID,
+		&i.WorkspaceID,
+		&i.Status,
+		&i.CustomStatus,
+		&i.LastActivityAt,
+		&i.LastSeenAt,
+		&i.UpdatedAt,
+		&i.StatusEmoji,
+		&i.ClearAfter,
+		&i.SetByCalendar,
+	)
+	return i, err
+}
+
 const setUsersOfflineAfterInactivity = `-- name: SetUsersOfflineAfterInactivity :exec
 UPDATE user_status
 SET 
@@ -201,15 +492,20 @@ INSERT INTO user_status (
     workspace_id,
     status,
     custom_status,
+    status_emoji,
+    clear_after,
     updated_at
 ) VALUES (
-    $1, $2, $3, $4, now()
+    $1, $2, $3, $4, $5, $6, now()
 )
 ON CONFLICT (user_id) DO UPDATE SET
     status = EXCLUDED.status,
     custom_status = EXCLUDED.custom_status,
+    status_emoji = EXCLUDED.status_emoji,
+    clear_after = EXCLUDED.clear_after,
+    set_by_calendar = false,
     updated_at = now()
-RETURNING user_id, workspace_id, status, custom_status, last_activity_at, last_seen_at, updated_at
+RETURNING user_id, workspace_id, status, custom_status, last_activity_at, last_seen_at, updated_at, status_emoji, clear_after, set_by_calendar
 `
 
 type UpsertUserStatusParams struct {
@@ -217,6 +513,8 @@ type UpsertUserStatusParams struct {
 	WorkspaceID  int64          `json:"workspace_id"`
 	Status       string         `json:"status"`
 	CustomStatus sql.NullString `json:"custom_status"`
+	StatusEmoji  sql.NullString `json:"status_emoji"`
+	ClearAfter   sql.NullTime   `json:"clear_after"`
 }
 
 func (q *Queries) UpsertUserStatus(ctx context.Context, arg UpsertUserStatusParams) (UserStatus, error) {
@@ -225,6 +523,8 @@ func (q *Queries) UpsertUserStatus(ctx context.Context, arg UpsertUserStatusPara
 		arg.WorkspaceID,
 		arg.Status,
 		arg.CustomStatus,
+		arg.StatusEmoji,
+		arg.ClearAfter,
 	)
 	var i UserStatus
 	err := row.Scan(
@@ -235,6 +535,9 @@ func (q *Queries) UpsertUserStatus(ctx context.Context, arg UpsertUserStatusPara
 		&i.LastActivityAt,
 		&i.LastSeenAt,
 		&i.UpdatedAt,
+		&i.StatusEmoji,
+		&i.ClearAfter,
+		&i.SetByCalendar,
 	)
 	return i, err
 }
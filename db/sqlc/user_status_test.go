@@ -477,3 +477,76 @@ func TestUserStatusWorkspaceConstraint(t *testing.T) {
 	_, err = testQueries.UpsertUserStatus(context.Background(), arg)
 	require.NoError(t, err)
 }
+
+func TestClearExpiredCustomStatuses(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	_, err := testQueries.UpsertUserStatus(context.Background(), UpsertUserStatusParams{
+		UserID:       user.ID,
+		WorkspaceID:  workspace.ID,
+		Status:       "busy",
+		CustomStatus: sql.NullString{String: "In a meeting", Valid: true},
+		StatusEmoji:  sql.NullString{String: ":calendar:", Valid: true},
+		ClearAfter:   sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+	})
+	require.NoError(t, err)
+
+	cleared, err := testQueries.ClearExpiredCustomStatuses(context.Background())
+	require.NoError(t, err)
+
+	var found bool
+	for _, userStatus := range cleared {
+		if userStatus.UserID == user.ID {
+			found = true
+			require.False(t, userStatus.CustomStatus.Valid)
+			require.False(t, userStatus.StatusEmoji.Valid)
+			require.False(t, userStatus.ClearAfter.Valid)
+			// Presence is left untouched
+			require.Equal(t, "busy", userStatus.Status)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestSetUserPresence(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	_, err := testQueries.UpsertUserStatus(context.Background(), UpsertUserStatusParams{
+		UserID:       user.ID,
+		WorkspaceID:  workspace.ID,
+		Status:       "offline",
+		CustomStatus: sql.NullString{String: "Commuting", Valid: true},
+	})
+	require.NoError(t, err)
+
+	userStatus, err := testQueries.SetUserPresenceOnline(context.Background(), SetUserPresenceOnlineParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "online", userStatus.Status)
+	require.Equal(t, "Commuting", userStatus.CustomStatus.String)
+
+	userStatus, err = testQueries.SetUserPresenceOffline(context.Background(), SetUserPresenceOfflineParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "offline", userStatus.Status)
+	require.Equal(t, "Commuting", userStatus.CustomStatus.String)
+
+	// An explicitly chosen status is kept when a connection opens
+	_, err = testQueries.UpsertUserStatus(context.Background(), UpsertUserStatusParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		Status:      "busy",
+	})
+	require.NoError(t, err)
+
+	userStatus, err = testQueries.SetUserPresenceOnline(context.Background(), SetUserPresenceOnlineParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "busy", userStatus.Status)
+}
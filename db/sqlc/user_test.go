@@ -115,6 +115,37 @@ func TestUpdateUserProfile(t *testing.T) {
 	require.Equal(t, user1.HashedPassword, user2.HashedPassword)
 	require.WithinDuration(t, user1.PasswordChangedAt, user2.PasswordChangedAt, time.Second)
 	require.WithinDuration(t, user1.CreatedAt, user2.CreatedAt, time.Second)
+	require.Equal(t, "UTC", user2.Timezone)
+}
+
+func TestUpdateUserProfileDetails(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	user2, err := testQueries.UpdateUserProfile(context.Background(), UpdateUserProfileParams{
+		ID:        user1.ID,
+		FirstName: user1.FirstName,
+		LastName:  user1.LastName,
+		Title:     sql.NullString{String: "Engineer", Valid: true},
+		Pronouns:  sql.NullString{String: "they/them", Valid: true},
+		Timezone:  sql.NullString{String: "Asia/Tokyo", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Engineer", user2.Title)
+	require.Equal(t, "they/them", user2.Pronouns)
+	require.Empty(t, user2.Phone)
+	require.Equal(t, "Asia/Tokyo", user2.Timezone)
+
+	// Details that aren't given keep their value
+	user3, err := testQueries.UpdateUserProfile(context.Background(), UpdateUserProfileParams{
+		ID:        user1.ID,
+		FirstName: user1.FirstName,
+		LastName:  user1.LastName,
+		Title:     sql.NullString{String: "", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Empty(t, user3.Title)
+	require.Equal(t, "they/them", user3.Pronouns)
+	require.Equal(t, "Asia/Tokyo", user3.Timezone)
 }
 
 func TestUpdateUserPassword(t *testing.T) {
@@ -268,3 +299,120 @@ func TestCreateUserWithDuplicateEmail(t *testing.T) {
 	require.Error(t, err)
 	require.Empty(t, user2)
 }
+
+func TestSearchWorkspaceUsers(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	users, err := testQueries.SearchWorkspaceUsers(context.Background(), SearchWorkspaceUsersParams{
+		WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+		Query:       user.FirstName,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, user.ID, users[0].ID)
+	require.Equal(t, int64(1), users[0].TotalCount)
+	require.Empty(t, users[0].Email)
+
+	// Emails are only matched and returned when asked for
+	users, err = testQueries.SearchWorkspaceUsers(context.Background(), SearchWorkspaceUsersParams{
+		WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+		Query:       user.Email,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	users, err = testQueries.SearchWorkspaceUsers(context.Background(), SearchWorkspaceUsersParams{
+		IncludeEmail: true,
+		WorkspaceID:  sql.NullInt64{Int64: workspace.ID, Valid: true},
+		Query:        user.Email,
+		Limit:        10,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, user.Email, users[0].Email)
+
+	// People are found by their handle too
+	handle := "h" + util.RandomString(8)
+	_, err = testQueries.UpdateUserProfile(context.Background(), UpdateUserProfileParams{
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Handle:    sql.NullString{String: handle, Valid: true},
+		ID:        user.ID,
+	})
+	require.NoError(t, err)
+
+	users, err = testQueries.SearchWorkspaceUsers(context.Background(), SearchWorkspaceUsersParams{
+		WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+		Query:       handle,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, user.ID, users[0].ID)
+	require.Equal(t, handle, users[0].Handle)
+}
+
+func TestSearchOrganizationDirectory(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	other := createRandomUserForOrganization(t, workspace.OrganizationID)
+
+	user, err := testQueries.UpdateUserProfile(context.Background(), UpdateUserProfileParams{
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Title:     sql.NullString{String: "Staff Engineer", Valid: true},
+		Handle:    sql.NullString{String: "h" + util.RandomString(8), Valid: true},
+		ID:        user.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpsertUserStatus(context.Background(), UpsertUserStatusParams{
+		UserID:       user.ID,
+		WorkspaceID:  workspace.ID,
+		Status:       "online",
+		CustomStatus: sql.NullString{String: "Heads down", Valid: true},
+	})
+	require.NoError(t, err)
+
+	// An empty query lists everyone in the organization
+	people, err := testQueries.SearchOrganizationDirectory(context.Background(), SearchOrganizationDirectoryParams{
+		OrganizationID: workspace.OrganizationID,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	require.Len(t, people, 2)
+	require.Equal(t, int64(2), people[0].TotalCount)
+
+	for _, query := range []string{user.Handle, "staff eng"} {
+		people, err = testQueries.SearchOrganizationDirectory(context.Background(), SearchOrganizationDirectoryParams{
+			OrganizationID: workspace.OrganizationID,
+			Query:          query,
+			Limit:          10,
+		})
+		require.NoError(t, err)
+		require.Len(t, people, 1)
+		require.Equal(t, user.ID, people[0].ID)
+		require.Equal(t, workspace.Name, people[0].WorkspaceName)
+		require.Equal(t, "online", people[0].Status)
+		require.Equal(t, "Heads down", people[0].CustomStatus.String)
+	}
+
+	// Emails only match when asked to
+	arg := SearchOrganizationDirectoryParams{
+		OrganizationID: workspace.OrganizationID,
+		Query:          other.Email,
+		Limit:          10,
+	}
+	people, err = testQueries.SearchOrganizationDirectory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, people)
+
+	arg.SearchEmail = true
+	people, err = testQueries.SearchOrganizationDirectory(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, people, 1)
+	require.Equal(t, other.ID, people[0].ID)
+	require.Equal(t, "offline", people[0].Status)
+	require.Empty(t, people[0].WorkspaceName)
+}
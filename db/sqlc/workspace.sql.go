@@ -15,11 +15,12 @@ const addUserToWorkspace = `-- name: AddUserToWorkspace :one
 UPDATE users
 SET 
     workspace_id = $2,
-    role = $3
+    role = $3,
+    custom_role_id = NULL
 WHERE users.id = $1 AND users.organization_id = (
     SELECT workspaces.organization_id FROM workspaces WHERE workspaces.id = $2
 )
-RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role
+RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle
 `
 
 type AddUserToWorkspaceParams struct {
@@ -42,6 +43,14 @@ func (q *Queries) AddUserToWorkspace(ctx context.Context, arg AddUserToWorkspace
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
 	)
 	return i, err
 }
@@ -72,7 +81,7 @@ INSERT INTO workspaces (
 ) VALUES (
     $1, $2
 )
-RETURNING id, organization_id, name, created_at
+RETURNING id, organization_id, name, created_at, deleted_at, deleted_by
 `
 
 type CreateWorkspaceParams struct {
@@ -88,6 +97,8 @@ func (q *Queries) CreateWorkspace(ctx context.Context, arg CreateWorkspaceParams
 		&i.OrganizationID,
 		&i.Name,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -103,8 +114,8 @@ func (q *Queries) DeleteWorkspace(ctx context.Context, id int64) error {
 }
 
 const getWorkspace = `-- name: GetWorkspace :one
-SELECT id, organization_id, name, created_at FROM workspaces
-WHERE id = $1 LIMIT 1
+SELECT id, organization_id, name, created_at, deleted_at, deleted_by FROM workspaces
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 func (q *Queries) GetWorkspace(ctx context.Context, id int64) (Workspace, error) {
@@ -115,13 +126,15 @@ func (q *Queries) GetWorkspace(ctx context.Context, id int64) (Workspace, error)
 		&i.OrganizationID,
 		&i.Name,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
 const getWorkspaceByID = `-- name: GetWorkspaceByID :one
-SELECT id, organization_id, name, created_at FROM workspaces
-WHERE id = $1 LIMIT 1
+SELECT id, organization_id, name, created_at, deleted_at, deleted_by FROM workspaces
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 func (q *Queries) GetWorkspaceByID(ctx context.Context, id int64) (Workspace, error) {
@@ -132,6 +145,8 @@ func (q *Queries) GetWorkspaceByID(ctx context.Context, id int64) (Workspace, er
 		&i.OrganizationID,
 		&i.Name,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -151,21 +166,23 @@ func (q *Queries) GetWorkspaceMemberCount(ctx context.Context, workspaceID sql.N
 
 const getWorkspaceWithUserCount = `-- name: GetWorkspaceWithUserCount :one
 SELECT 
-    w.id, w.organization_id, w.name, w.created_at,
+    w.id, w.organization_id, w.name, w.created_at, w.deleted_at, w.deleted_by,
     COUNT(u.id) as user_count
 FROM workspaces w
 LEFT JOIN users u ON w.id = u.workspace_id
-WHERE w.id = $1
+WHERE w.id = $1 AND w.deleted_at IS NULL
 GROUP BY w.id, w.organization_id, w.name, w.created_at
 LIMIT 1
 `
 
 type GetWorkspaceWithUserCountRow struct {
-	ID             int64     `json:"id"`
-	OrganizationID int64     `json:"organization_id"`
-	Name           string    `json:"name"`
-	CreatedAt      time.Time `json:"created_at"`
-	UserCount      int64     `json:"user_count"`
+	ID             int64         `json:"id"`
+	OrganizationID int64         `json:"organization_id"`
+	Name           string        `json:"name"`
+	CreatedAt      time.Time     `json:"created_at"`
+	DeletedAt      sql.NullTime  `json:"deleted_at"`
+	DeletedBy      sql.NullInt64 `json:"deleted_by"`
+	UserCount      int64         `json:"user_count"`
 }
 
 func (q *Queries) GetWorkspaceWithUserCount(ctx context.Context, id int64) (GetWorkspaceWithUserCountRow, error) {
@@ -176,13 +193,102 @@ func (q *Queries) GetWorkspaceWithUserCount(ctx context.Context, id int64) (GetW
 		&i.OrganizationID,
 		&i.Name,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 		&i.UserCount,
 	)
 	return i, err
 }
 
+const listDeletedWorkspaces = `-- name: ListDeletedWorkspaces :many
+SELECT id, organization_id, name, created_at, deleted_at, deleted_by FROM workspaces
+WHERE organization_id = $1
+    AND deleted_at > $2::timestamptz
+ORDER BY deleted_at DESC
+`
+
+type ListDeletedWorkspacesParams struct {
+	OrganizationID int64     `json:"organization_id"`
+	DeletedAfter   time.Time `json:"deleted_after"`
+}
+
+func (q *Queries) ListDeletedWorkspaces(ctx context.Context, arg ListDeletedWorkspacesParams) ([]Workspace, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedWorkspaces, arg.OrganizationID, arg.DeletedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Workspace{}
+	for rows.Next() {
+		var i Workspace
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableWorkspaces = `-- name: ListPurgeableWorkspaces :many
+SELECT id, organization_id, name, created_at, deleted_at, deleted_by FROM workspaces
+WHERE deleted_at <= $1::timestamptz
+    AND NOT EXISTS (SELECT 1 FROM workspace_teardowns t WHERE t.workspace_id = workspaces.id)
+ORDER BY deleted_at ASC
+LIMIT $2
+`
+
+type ListPurgeableWorkspacesParams struct {
+	DeletedBefore time.Time `json:"deleted_before"`
+	Limit         int32     `json:"limit"`
+}
+
+// Workspaces already handed to the teardown job are skipped
+func (q *Queries) ListPurgeableWorkspaces(ctx context.Context, arg ListPurgeableWorkspacesParams) ([]Workspace, error) {
+	rows, err := q.db.QueryContext(ctx, listPurgeableWorkspaces, arg.DeletedBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Workspace{}
+	for rows.Next() {
+		var i Workspace
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listWorkspaceMembers = `-- name: ListWorkspaceMembers :many
-SELECT u.id, u.organization_id, u.email, u.first_name, u.last_name, u.role, u.created_at, u.workspace_id
+SELECT u.id, u.organization_id, u.email, u.first_name, u.last_name, u.role, u.created_at, u.workspace_id,
+    u.title, u.pronouns, u.phone, u.timezone, u.avatar_key, u.custom_role_id, u.handle
 FROM users u
 WHERE u.workspace_id = $1
 ORDER BY u.role DESC, u.created_at ASC
@@ -197,14 +303,21 @@ type ListWorkspaceMembersParams struct {
 }
 
 type ListWorkspaceMembersRow struct {
-	ID             int64         `json:"id"`
-	OrganizationID int64         `json:"organization_id"`
-	Email          string        `json:"email"`
-	FirstName      string        `json:"first_name"`
-	LastName       string        `json:"last_name"`
-	Role           string        `json:"role"`
-	CreatedAt      time.Time     `json:"created_at"`
-	WorkspaceID    sql.NullInt64 `json:"workspace_id"`
+	ID             int64          `json:"id"`
+	OrganizationID int64          `json:"organization_id"`
+	Email          string         `json:"email"`
+	FirstName      string         `json:"first_name"`
+	LastName       string         `json:"last_name"`
+	Role           string         `json:"role"`
+	CreatedAt      time.Time      `json:"created_at"`
+	WorkspaceID    sql.NullInt64  `json:"workspace_id"`
+	Title          string         `json:"title"`
+	Pronouns       string         `json:"pronouns"`
+	Phone          string         `json:"phone"`
+	Timezone       string         `json:"timezone"`
+	AvatarKey      sql.NullString `json:"avatar_key"`
+	CustomRoleID   sql.NullInt64  `json:"custom_role_id"`
+	Handle         string         `json:"handle"`
 }
 
 func (q *Queries) ListWorkspaceMembers(ctx context.Context, arg ListWorkspaceMembersParams) ([]ListWorkspaceMembersRow, error) {
@@ -225,6 +338,13 @@ func (q *Queries) ListWorkspaceMembers(ctx context.Context, arg ListWorkspaceMem
 			&i.Role,
 			&i.CreatedAt,
 			&i.WorkspaceID,
+			&i.Title,
+			&i.Pronouns,
+			&i.Phone,
+			&i.Timezone,
+			&i.AvatarKey,
+			&i.CustomRoleID,
+			&i.Handle,
 		); err != nil {
 			return nil, err
 		}
@@ -240,8 +360,8 @@ func (q *Queries) ListWorkspaceMembers(ctx context.Context, arg ListWorkspaceMem
 }
 
 const listWorkspacesByOrganization = `-- name: ListWorkspacesByOrganization :many
-SELECT id, organization_id, name, created_at FROM workspaces
-WHERE organization_id = $1
+SELECT id, organization_id, name, created_at, deleted_at, deleted_by FROM workspaces
+WHERE organization_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT $2
 OFFSET $3
@@ -267,6 +387,8 @@ func (q *Queries) ListWorkspacesByOrganization(ctx context.Context, arg ListWork
 			&i.OrganizationID,
 			&i.Name,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -285,9 +407,10 @@ const removeUserFromWorkspace = `-- name: RemoveUserFromWorkspace :one
 UPDATE users
 SET 
     workspace_id = NULL,
-    role = 'member'
+    role = 'member',
+    custom_role_id = NULL
 WHERE users.id = $1 AND users.workspace_id = $2
-RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role
+RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle
 `
 
 type RemoveUserFromWorkspaceParams struct {
@@ -309,15 +432,76 @@ func (q *Queries) RemoveUserFromWorkspace(ctx context.Context, arg RemoveUserFro
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
+	)
+	return i, err
+}
+
+const restoreWorkspace = `-- name: RestoreWorkspace :one
+UPDATE workspaces
+SET
+    deleted_at = NULL,
+    deleted_by = NULL
+WHERE id = $1
+    AND organization_id = $2
+    AND deleted_at > $3::timestamptz
+RETURNING id, organization_id, name, created_at, deleted_at, deleted_by
+`
+
+type RestoreWorkspaceParams struct {
+	ID             int64     `json:"id"`
+	OrganizationID int64     `json:"organization_id"`
+	DeletedAfter   time.Time `json:"deleted_after"`
+}
+
+// Only workspaces still inside the recovery window can be restored
+func (q *Queries) RestoreWorkspace(ctx context.Context, arg RestoreWorkspaceParams) (Workspace, error) {
+	row := q.db.QueryRowContext(ctx, restoreWorkspace, arg.ID, arg.OrganizationID, arg.DeletedAfter)
+	var i Workspace
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
 
+const softDeleteWorkspace = `-- name: SoftDeleteWorkspace :execrows
+UPDATE workspaces
+SET
+    deleted_at = now(),
+    deleted_by = $2
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type SoftDeleteWorkspaceParams struct {
+	ID        int64         `json:"id"`
+	DeletedBy sql.NullInt64 `json:"deleted_by"`
+}
+
+func (q *Queries) SoftDeleteWorkspace(ctx context.Context, arg SoftDeleteWorkspaceParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, softDeleteWorkspace, arg.ID, arg.DeletedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateWorkspace = `-- name: UpdateWorkspace :one
 UPDATE workspaces
 SET name = $2
-WHERE id = $1
-RETURNING id, organization_id, name, created_at
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, organization_id, name, created_at, deleted_at, deleted_by
 `
 
 type UpdateWorkspaceParams struct {
@@ -333,6 +517,8 @@ func (q *Queries) UpdateWorkspace(ctx context.Context, arg UpdateWorkspaceParams
 		&i.OrganizationID,
 		&i.Name,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.DeletedBy,
 	)
 	return i, err
 }
@@ -341,7 +527,7 @@ const updateWorkspaceMemberRole = `-- name: UpdateWorkspaceMemberRole :one
 UPDATE users
 SET role = $3
 WHERE users.id = $1 AND users.workspace_id = $2
-RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role
+RETURNING id, organization_id, email, first_name, last_name, hashed_password, password_changed_at, created_at, workspace_id, role, email_verified_at, title, pronouns, phone, timezone, avatar_key, custom_role_id, handle
 `
 
 type UpdateWorkspaceMemberRoleParams struct {
@@ -364,6 +550,14 @@ func (q *Queries) UpdateWorkspaceMemberRole(ctx context.Context, arg UpdateWorks
 		&i.CreatedAt,
 		&i.WorkspaceID,
 		&i.Role,
+		&i.EmailVerifiedAt,
+		&i.Title,
+		&i.Pronouns,
+		&i.Phone,
+		&i.Timezone,
+		&i.AvatarKey,
+		&i.CustomRoleID,
+		&i.Handle,
 	)
 	return i, err
 }
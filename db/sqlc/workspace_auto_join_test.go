@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEmailTx(t *testing.T) {
+	_, user := createTestWorkspaceAndUser(t)
+	store := NewStore(testDB)
+	require.False(t, user.EmailVerifiedAt.Valid)
+
+	verification, err := testQueries.CreateEmailVerification(context.Background(), CreateEmailVerificationParams{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Token:     util.RandomString(32),
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	result, err := store.VerifyEmailTx(context.Background(), verification.Token)
+	require.NoError(t, err)
+	require.True(t, result.Verification.UsedAt.Valid)
+	require.True(t, result.User.EmailVerifiedAt.Valid)
+
+	// Tokens only work once
+	_, err = store.VerifyEmailTx(context.Background(), verification.Token)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Expired tokens don't work
+	expired, err := testQueries.CreateEmailVerification(context.Background(), CreateEmailVerificationParams{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Token:     util.RandomString(32),
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	_, err = store.VerifyEmailTx(context.Background(), expired.Token)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Verifications for an old email address don't verify the new one
+	oldAddress, err := testQueries.CreateEmailVerification(context.Background(), CreateEmailVerificationParams{
+		UserID:    user.ID,
+		Email:     util.RandomEmail(),
+		Token:     util.RandomString(32),
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = store.VerifyEmailTx(context.Background(), oldAddress.Token)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// The failed transaction left the token unused
+	_, err = testQueries.UseEmailVerification(context.Background(), oldAddress.Token)
+	require.NoError(t, err)
+}
+
+func TestWorkspaceAutoJoin(t *testing.T) {
+	workspace, admin := createTestWorkspaceAndUser(t)
+	store := NewStore(testDB)
+	domain := strings.ToLower(util.RandomString(8)) + ".com"
+
+	allowed, err := testQueries.CreateWorkspaceAutoJoinDomain(context.Background(), CreateWorkspaceAutoJoinDomainParams{
+		WorkspaceID:      workspace.ID,
+		Domain:           domain,
+		RequiresApproval: true,
+		CreatedBy:        sql.NullInt64{Int64: admin.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.True(t, allowed.RequiresApproval)
+
+	// A domain is allowed once per workspace
+	_, err = testQueries.CreateWorkspaceAutoJoinDomain(context.Background(), CreateWorkspaceAutoJoinDomainParams{
+		WorkspaceID: workspace.ID,
+		Domain:      domain,
+	})
+	require.Error(t, err)
+
+	user := createRandomUserForOrganization(t, workspace.OrganizationID)
+
+	workspaces, err := testQueries.ListAutoJoinWorkspaces(context.Background(), ListAutoJoinWorkspacesParams{
+		UserID:         user.ID,
+		OrganizationID: workspace.OrganizationID,
+		Domain:         domain,
+	})
+	require.NoError(t, err)
+	require.Len(t, workspaces, 1)
+	require.Equal(t, workspace.ID, workspaces[0].ID)
+	require.False(t, workspaces[0].RequestPending)
+
+	request, err := testQueries.CreateWorkspaceJoinRequest(context.Background(), CreateWorkspaceJoinRequestParams{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "pending", request.Status)
+
+	// Only one pending request per user and workspace
+	_, err = testQueries.CreateWorkspaceJoinRequest(context.Background(), CreateWorkspaceJoinRequestParams{
+		WorkspaceID: workspace.ID,
+		UserID:      user.ID,
+	})
+	require.Error(t, err)
+
+	workspaces, err = testQueries.ListAutoJoinWorkspaces(context.Background(), ListAutoJoinWorkspacesParams{
+		UserID:         user.ID,
+		OrganizationID: workspace.OrganizationID,
+		Domain:         domain,
+	})
+	require.NoError(t, err)
+	require.True(t, workspaces[0].RequestPending)
+
+	pending, err := testQueries.ListWorkspaceJoinRequests(context.Background(), ListWorkspaceJoinRequestsParams{
+		WorkspaceID: workspace.ID,
+		Status:      "pending",
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, user.Email, pending[0].UserEmail)
+
+	result, err := store.ApproveWorkspaceJoinRequestTx(context.Background(), ApproveWorkspaceJoinRequestTxParams{
+		ID:          request.ID,
+		WorkspaceID: workspace.ID,
+		ReviewerID:  admin.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "approved", result.Request.Status)
+	require.Equal(t, admin.ID, result.Request.ReviewedBy.Int64)
+	require.Equal(t, workspace.ID, result.User.WorkspaceID.Int64)
+	require.Equal(t, "member", result.User.Role)
+
+	// Reviewed requests can't be reviewed again
+	_, err = store.ApproveWorkspaceJoinRequestTx(context.Background(), ApproveWorkspaceJoinRequestTxParams{
+		ID:          request.ID,
+		WorkspaceID: workspace.ID,
+		ReviewerID:  admin.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rows, err := testQueries.DeleteWorkspaceAutoJoinDomain(context.Background(), DeleteWorkspaceAutoJoinDomainParams{
+		ID:          allowed.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
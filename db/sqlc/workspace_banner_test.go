@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceBanners(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	current, err := testQueries.CreateWorkspaceBanner(context.Background(), CreateWorkspaceBannerParams{
+		WorkspaceID: workspace.ID,
+		Text:        "Welcome to the workspace",
+		Severity:    "info",
+		CreatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.False(t, current.ExpiresAt.Valid)
+
+	expired, err := testQueries.CreateWorkspaceBanner(context.Background(), CreateWorkspaceBannerParams{
+		WorkspaceID: workspace.ID,
+		Text:        "Maintenance is over",
+		Severity:    "warning",
+		ExpiresAt:   sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		CreatedBy:   sql.NullInt64{Int64: user.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// Expired banners are only left out of the active list
+	active, err := testQueries.ListActiveWorkspaceBanners(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	require.Equal(t, current.ID, active[0].ID)
+
+	all, err := testQueries.ListWorkspaceBanners(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+
+	updated, err := testQueries.UpdateWorkspaceBanner(context.Background(), UpdateWorkspaceBannerParams{
+		ID:          expired.ID,
+		WorkspaceID: workspace.ID,
+		Text:        "Maintenance tonight",
+		Severity:    "critical",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "critical", updated.Severity)
+	require.False(t, updated.ExpiresAt.Valid)
+
+	active, err = testQueries.ListActiveWorkspaceBanners(context.Background(), workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+
+	// A banner can only be changed through its own workspace
+	_, err = testQueries.GetWorkspaceBanner(context.Background(), GetWorkspaceBannerParams{ID: current.ID, WorkspaceID: workspace.ID + 1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	rows, err := testQueries.DeleteWorkspaceBanner(context.Background(), DeleteWorkspaceBannerParams{ID: current.ID, WorkspaceID: workspace.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
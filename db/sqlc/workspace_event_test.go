@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceEventsRecordedByTriggers(t *testing.T) {
+	user := createRandomUser(t)
+	workspace := createRandomWorkspaceForUser(t, user.ID)
+	channel := createRandomChannelForWorkspace(t, workspace.ID, user.ID)
+	message := createRandomChannelMessage(t, workspace, channel, user)
+
+	_, err := testQueries.UpdateMessageContent(context.Background(), UpdateMessageContentParams{ID: message.ID, Content: "edited"})
+	require.NoError(t, err)
+
+	events, err := testQueries.ListWorkspaceEvents(context.Background(), ListWorkspaceEventsParams{
+		WorkspaceID:   workspace.ID,
+		CreatedBefore: time.Now().Add(time.Minute),
+		Limit:         10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	require.Equal(t, "member.joined", events[0].EventType)
+	require.Equal(t, user.ID, events[0].ActorID.Int64)
+	require.False(t, events[0].ChannelID.Valid)
+
+	require.Equal(t, "message.created", events[1].EventType)
+	require.Equal(t, channel.ID, events[1].ChannelID.Int64)
+	var data struct {
+		MessageID int64  `json:"message_id"`
+		Content   string `json:"content"`
+	}
+	require.NoError(t, json.Unmarshal(events[1].Data, &data))
+	require.Equal(t, message.ID, data.MessageID)
+	require.Equal(t, message.Content, data.Content)
+
+	require.Equal(t, "message.edited", events[2].EventType)
+	require.NoError(t, json.Unmarshal(events[2].Data, &data))
+	require.Equal(t, "edited", data.Content)
+
+	// Reading from the cursor skips what was already read
+	after, err := testQueries.ListWorkspaceEvents(context.Background(), ListWorkspaceEventsParams{
+		WorkspaceID:   workspace.ID,
+		AfterID:       events[1].ID,
+		CreatedBefore: time.Now().Add(time.Minute),
+		Limit:         10,
+	})
+	require.NoError(t, err)
+	require.Len(t, after, 1)
+	require.Equal(t, events[2].ID, after[0].ID)
+
+	// Events younger than the cutoff are held back
+	held, err := testQueries.ListWorkspaceEvents(context.Background(), ListWorkspaceEventsParams{
+		WorkspaceID:   workspace.ID,
+		CreatedBefore: time.Now().Add(-time.Minute),
+		Limit:         10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, held)
+}
+
+func TestMarkWorkspaceEventsPublished(t *testing.T) {
+	user := createRandomUser(t)
+	workspace := createRandomWorkspaceForUser(t, user.ID)
+
+	events, err := testQueries.ListWorkspaceEvents(context.Background(), ListWorkspaceEventsParams{
+		WorkspaceID:   workspace.ID,
+		CreatedBefore: time.Now().Add(time.Minute),
+		Limit:         10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.False(t, events[0].PublishedAt.Valid)
+
+	err = testQueries.MarkWorkspaceEventsPublished(context.Background(), []int64{events[0].ID})
+	require.NoError(t, err)
+
+	unpublished, err := testQueries.ListUnpublishedWorkspaceEvents(context.Background(), ListUnpublishedWorkspaceEventsParams{
+		CreatedAt: time.Now().Add(time.Minute),
+		Limit:     1000,
+	})
+	require.NoError(t, err)
+	for _, event := range unpublished {
+		require.NotEqual(t, events[0].ID, event.ID)
+	}
+}
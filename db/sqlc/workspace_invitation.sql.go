@@ -9,6 +9,8 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 const acceptWorkspaceInvitation = `-- name: AcceptWorkspaceInvitation :one
@@ -18,7 +20,7 @@ SET
     accepted_at = NOW(),
     invitee_id = $2
 WHERE invitation_code = $1 AND status = 'pending' AND expires_at > NOW()
-RETURNING id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at
+RETURNING id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at, email_status, email_attempts, email_last_attempt_at, email_error
 `
 
 type AcceptWorkspaceInvitationParams struct {
@@ -41,6 +43,10 @@ func (q *Queries) AcceptWorkspaceInvitation(ctx context.Context, arg AcceptWorks
 		&i.ExpiresAt,
 		&i.AcceptedAt,
 		&i.CreatedAt,
+		&i.EmailStatus,
+		&i.EmailAttempts,
+		&i.EmailLastAttemptAt,
+		&i.EmailError,
 	)
 	return i, err
 }
@@ -57,7 +63,7 @@ INSERT INTO workspace_invitations (
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7
 )
-RETURNING id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at
+RETURNING id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at, email_status, email_attempts, email_last_attempt_at, email_error
 `
 
 type CreateWorkspaceInvitationParams struct {
@@ -93,6 +99,10 @@ func (q *Queries) CreateWorkspaceInvitation(ctx context.Context, arg CreateWorks
 		&i.ExpiresAt,
 		&i.AcceptedAt,
 		&i.CreatedAt,
+		&i.EmailStatus,
+		&i.EmailAttempts,
+		&i.EmailLastAttemptAt,
+		&i.EmailError,
 	)
 	return i, err
 }
@@ -101,7 +111,7 @@ const declineWorkspaceInvitation = `-- name: DeclineWorkspaceInvitation :one
 UPDATE workspace_invitations
 SET status = 'declined'
 WHERE invitation_code = $1 AND status = 'pending'
-RETURNING id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at
+RETURNING id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at, email_status, email_attempts, email_last_attempt_at, email_error
 `
 
 func (q *Queries) DeclineWorkspaceInvitation(ctx context.Context, invitationCode string) (WorkspaceInvitation, error) {
@@ -119,6 +129,10 @@ func (q *Queries) DeclineWorkspaceInvitation(ctx context.Context, invitationCode
 		&i.ExpiresAt,
 		&i.AcceptedAt,
 		&i.CreatedAt,
+		&i.EmailStatus,
+		&i.EmailAttempts,
+		&i.EmailLastAttemptAt,
+		&i.EmailError,
 	)
 	return i, err
 }
@@ -145,7 +159,7 @@ func (q *Queries) ExpireWorkspaceInvitation(ctx context.Context, id int64) error
 }
 
 const getPendingInvitationsForUser = `-- name: GetPendingInvitationsForUser :many
-SELECT wi.id, wi.workspace_id, wi.inviter_id, wi.invitee_email, wi.invitee_id, wi.invitation_code, wi.role, wi.status, wi.expires_at, wi.accepted_at, wi.created_at, w.name as workspace_name, u.first_name as inviter_first_name, u.last_name as inviter_last_name
+SELECT wi.id, wi.workspace_id, wi.inviter_id, wi.invitee_email, wi.invitee_id, wi.invitation_code, wi.role, wi.status, wi.expires_at, wi.accepted_at, wi.created_at, wi.email_status, wi.email_attempts, wi.email_last_attempt_at, wi.email_error, w.name as workspace_name, u.first_name as inviter_first_name, u.last_name as inviter_last_name
 FROM workspace_invitations wi
 JOIN workspaces w ON wi.workspace_id = w.id
 JOIN users u ON wi.inviter_id = u.id
@@ -154,20 +168,24 @@ ORDER BY wi.created_at DESC
 `
 
 type GetPendingInvitationsForUserRow struct {
-	ID               int64         `json:"id"`
-	WorkspaceID      int64         `json:"workspace_id"`
-	InviterID        int64         `json:"inviter_id"`
-	InviteeEmail     string        `json:"invitee_email"`
-	InviteeID        sql.NullInt64 `json:"invitee_id"`
-	InvitationCode   string        `json:"invitation_code"`
-	Role             string        `json:"role"`
-	Status           string        `json:"status"`
-	ExpiresAt        time.Time     `json:"expires_at"`
-	AcceptedAt       sql.NullTime  `json:"accepted_at"`
-	CreatedAt        time.Time     `json:"created_at"`
-	WorkspaceName    string        `json:"workspace_name"`
-	InviterFirstName string        `json:"inviter_first_name"`
-	InviterLastName  string        `json:"inviter_last_name"`
+	ID                 int64         `json:"id"`
+	WorkspaceID        int64         `json:"workspace_id"`
+	InviterID          int64         `json:"inviter_id"`
+	InviteeEmail       string        `json:"invitee_email"`
+	InviteeID          sql.NullInt64 `json:"invitee_id"`
+	InvitationCode     string        `json:"invitation_code"`
+	Role               string        `json:"role"`
+	Status             string        `json:"status"`
+	ExpiresAt          time.Time     `json:"expires_at"`
+	AcceptedAt         sql.NullTime  `json:"accepted_at"`
+	CreatedAt          time.Time     `json:"created_at"`
+	EmailStatus        string        `json:"email_status"`
+	EmailAttempts      int32         `json:"email_attempts"`
+	EmailLastAttemptAt sql.NullTime  `json:"email_last_attempt_at"`
+	EmailError         string        `json:"email_error"`
+	WorkspaceName      string        `json:"workspace_name"`
+	InviterFirstName   string        `json:"inviter_first_name"`
+	InviterLastName    string        `json:"inviter_last_name"`
 }
 
 func (q *Queries) GetPendingInvitationsForUser(ctx context.Context, inviteeEmail string) ([]GetPendingInvitationsForUserRow, error) {
@@ -191,6 +209,10 @@ func (q *Queries) GetPendingInvitationsForUser(ctx context.Context, inviteeEmail
 			&i.ExpiresAt,
 			&i.AcceptedAt,
 			&i.CreatedAt,
+			&i.EmailStatus,
+			&i.EmailAttempts,
+			&i.EmailLastAttemptAt,
+			&i.EmailError,
 			&i.WorkspaceName,
 			&i.InviterFirstName,
 			&i.InviterLastName,
@@ -209,7 +231,7 @@ func (q *Queries) GetPendingInvitationsForUser(ctx context.Context, inviteeEmail
 }
 
 const getWorkspaceInvitation = `-- name: GetWorkspaceInvitation :one
-SELECT id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at FROM workspace_invitations
+SELECT id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at, email_status, email_attempts, email_last_attempt_at, email_error FROM workspace_invitations
 WHERE id = $1 LIMIT 1
 `
 
@@ -228,12 +250,16 @@ func (q *Queries) GetWorkspaceInvitation(ctx context.Context, id int64) (Workspa
 		&i.ExpiresAt,
 		&i.AcceptedAt,
 		&i.CreatedAt,
+		&i.EmailStatus,
+		&i.EmailAttempts,
+		&i.EmailLastAttemptAt,
+		&i.EmailError,
 	)
 	return i, err
 }
 
 const getWorkspaceInvitationByCode = `-- name: GetWorkspaceInvitationByCode :one
-SELECT id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at FROM workspace_invitations
+SELECT id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at, email_status, email_attempts, email_last_attempt_at, email_error FROM workspace_invitations
 WHERE invitation_code = $1 AND status = 'pending' AND expires_at > NOW()
 LIMIT 1
 `
@@ -253,12 +279,53 @@ func (q *Queries) GetWorkspaceInvitationByCode(ctx context.Context, invitationCo
 		&i.ExpiresAt,
 		&i.AcceptedAt,
 		&i.CreatedAt,
+		&i.EmailStatus,
+		&i.EmailAttempts,
+		&i.EmailLastAttemptAt,
+		&i.EmailError,
 	)
 	return i, err
 }
 
+const listPendingInvitationEmails = `-- name: ListPendingInvitationEmails :many
+SELECT DISTINCT lower(invitee_email)::text AS email
+FROM workspace_invitations
+WHERE workspace_id = $1
+    AND status = 'pending'
+    AND expires_at > NOW()
+    AND lower(invitee_email) = ANY($2::text[])
+`
+
+type ListPendingInvitationEmailsParams struct {
+	WorkspaceID int64    `json:"workspace_id"`
+	Emails      []string `json:"emails"`
+}
+
+func (q *Queries) ListPendingInvitationEmails(ctx context.Context, arg ListPendingInvitationEmailsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingInvitationEmails, arg.WorkspaceID, pq.Array(arg.Emails))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		items = append(items, email)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listWorkspaceInvitations = `-- name: ListWorkspaceInvitations :many
-SELECT id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at FROM workspace_invitations
+SELECT id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at, email_status, email_attempts, email_last_attempt_at, email_error FROM workspace_invitations
 WHERE workspace_id = $1
 ORDER BY created_at DESC
 LIMIT $2
@@ -292,6 +359,10 @@ func (q *Queries) ListWorkspaceInvitations(ctx context.Context, arg ListWorkspac
 			&i.ExpiresAt,
 			&i.AcceptedAt,
 			&i.CreatedAt,
+			&i.EmailStatus,
+			&i.EmailAttempts,
+			&i.EmailLastAttemptAt,
+			&i.EmailError,
 		); err != nil {
 			return nil, err
 		}
@@ -305,3 +376,63 @@ func (q *Queries) ListWorkspaceInvitations(ctx context.Context, arg ListWorkspac
 	}
 	return items, nil
 }
+
+const setWorkspaceInvitationEmailResult = `-- name: SetWorkspaceInvitationEmailResult :exec
+UPDATE workspace_invitations
+SET
+    email_status = $2,
+    email_error = $3
+WHERE id = $1
+`
+
+type SetWorkspaceInvitationEmailResultParams struct {
+	ID          int64  `json:"id"`
+	EmailStatus string `json:"email_status"`
+	EmailError  string `json:"email_error"`
+}
+
+// Records the outcome of an invitation email sent by the email queue
+func (q *Queries) SetWorkspaceInvitationEmailResult(ctx context.Context, arg SetWorkspaceInvitationEmailResultParams) error {
+	_, err := q.db.ExecContext(ctx, setWorkspaceInvitationEmailResult, arg.ID, arg.EmailStatus, arg.EmailError)
+	return err
+}
+
+const updateWorkspaceInvitationEmailStatus = `-- name: UpdateWorkspaceInvitationEmailStatus :one
+UPDATE workspace_invitations
+SET 
+    email_status = $2,
+    email_error = $3,
+    email_attempts = email_attempts + 1,
+    email_last_attempt_at = NOW()
+WHERE id = $1
+RETURNING id, workspace_id, inviter_id, invitee_email, invitee_id, invitation_code, role, status, expires_at, accepted_at, created_at, email_status, email_attempts, email_last_attempt_at, email_error
+`
+
+type UpdateWorkspaceInvitationEmailStatusParams struct {
+	ID          int64  `json:"id"`
+	EmailStatus string `json:"email_status"`
+	EmailError  string `json:"email_error"`
+}
+
+func (q *Queries) UpdateWorkspaceInvitationEmailStatus(ctx context.Context, arg UpdateWorkspaceInvitationEmailStatusParams) (WorkspaceInvitation, error) {
+	row := q.db.QueryRowContext(ctx, updateWorkspaceInvitationEmailStatus, arg.ID, arg.EmailStatus, arg.EmailError)
+	var i WorkspaceInvitation
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.InviterID,
+		&i.InviteeEmail,
+		&i.InviteeID,
+		&i.InvitationCode,
+		&i.Role,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.AcceptedAt,
+		&i.CreatedAt,
+		&i.EmailStatus,
+		&i.EmailAttempts,
+		&i.EmailLastAttemptAt,
+		&i.EmailError,
+	)
+	return i, err
+}
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWorkspaceInvitationsTx(t *testing.T) {
+	workspace, inviter := createTestWorkspaceAndUser(t)
+	store := NewStore(testDB)
+
+	email := strings.ToUpper(util.RandomString(1)) + util.RandomEmail()
+	arg := []CreateWorkspaceInvitationParams{
+		{
+			WorkspaceID:    workspace.ID,
+			InviterID:      inviter.ID,
+			InviteeEmail:   email,
+			InvitationCode: util.RandomString(12),
+			Role:           "member",
+			ExpiresAt:      time.Now().Add(time.Hour),
+		},
+		{
+			WorkspaceID:    workspace.ID,
+			InviterID:      inviter.ID,
+			InviteeEmail:   util.RandomEmail(),
+			InvitationCode: util.RandomString(12),
+			Role:           "admin",
+			ExpiresAt:      time.Now().Add(time.Hour),
+		},
+	}
+
+	invitations, err := store.CreateWorkspaceInvitationsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, invitations, 2)
+	require.Equal(t, "pending", invitations[0].EmailStatus)
+
+	// Pending invitations are matched regardless of case
+	pending, err := testQueries.ListPendingInvitationEmails(context.Background(), ListPendingInvitationEmailsParams{
+		WorkspaceID: workspace.ID,
+		Emails:      []string{strings.ToLower(email)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{strings.ToLower(email)}, pending)
+
+	// A failing row rolls back the whole batch
+	duplicateCode := arg[1]
+	duplicateCode.InviteeEmail = util.RandomEmail()
+	_, err = store.CreateWorkspaceInvitationsTx(context.Background(), []CreateWorkspaceInvitationParams{
+		{
+			WorkspaceID:    workspace.ID,
+			InviterID:      inviter.ID,
+			InviteeEmail:   util.RandomEmail(),
+			InvitationCode: util.RandomString(12),
+			Role:           "member",
+			ExpiresAt:      time.Now().Add(time.Hour),
+		},
+		duplicateCode,
+	})
+	require.Error(t, err)
+
+	all, err := testQueries.ListWorkspaceInvitations(context.Background(), ListWorkspaceInvitationsParams{
+		WorkspaceID: workspace.ID,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+}
+
+func TestAcceptInvitationTx(t *testing.T) {
+	workspace, inviter := createTestWorkspaceAndUser(t)
+	store := NewStore(testDB)
+
+	user := createRandomUserForOrganization(t, workspace.OrganizationID)
+	invitation, err := testQueries.CreateWorkspaceInvitation(context.Background(), CreateWorkspaceInvitationParams{
+		WorkspaceID:    workspace.ID,
+		InviterID:      inviter.ID,
+		InviteeEmail:   user.Email,
+		InvitationCode: util.RandomString(12),
+		Role:           "admin",
+		ExpiresAt:      time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	result, err := store.AcceptInvitationTx(context.Background(), AcceptInvitationTxParams{
+		InvitationCode: invitation.InvitationCode,
+		UserID:         user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "accepted", result.Invitation.Status)
+	require.Equal(t, user.ID, result.Invitation.InviteeID.Int64)
+	require.Equal(t, workspace.ID, result.User.WorkspaceID.Int64)
+	require.Equal(t, "admin", result.User.Role)
+
+	// An accepted invitation can't be used again
+	other := createRandomUserForOrganization(t, workspace.OrganizationID)
+	_, err = store.AcceptInvitationTx(context.Background(), AcceptInvitationTxParams{
+		InvitationCode: invitation.InvitationCode,
+		UserID:         other.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Failing to add the user leaves the invitation pending
+	pending, err := testQueries.CreateWorkspaceInvitation(context.Background(), CreateWorkspaceInvitationParams{
+		WorkspaceID:    workspace.ID,
+		InviterID:      inviter.ID,
+		InviteeEmail:   util.RandomEmail(),
+		InvitationCode: util.RandomString(12),
+		Role:           "member",
+		ExpiresAt:      time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = store.AcceptInvitationTx(context.Background(), AcceptInvitationTxParams{
+		InvitationCode: pending.InvitationCode,
+		UserID:         -1,
+	})
+	require.Error(t, err)
+
+	stored, err := testQueries.GetWorkspaceInvitationByCode(context.Background(), pending.InvitationCode)
+	require.NoError(t, err)
+	require.Equal(t, "pending", stored.Status)
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinWorkspaceByLinkTx(t *testing.T) {
+	workspace, admin := createTestWorkspaceAndUser(t)
+	store := NewStore(testDB)
+
+	link, err := testQueries.CreateWorkspaceJoinLink(context.Background(), CreateWorkspaceJoinLinkParams{
+		WorkspaceID: workspace.ID,
+		Token:       util.RandomString(32),
+		CreatedBy:   sql.NullInt64{Int64: admin.ID, Valid: true},
+		MaxUses:     sql.NullInt32{Int32: 1, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Zero(t, link.UseCount)
+
+	user := createRandomUserForOrganization(t, workspace.OrganizationID)
+	result, err := store.JoinWorkspaceByLinkTx(context.Background(), JoinWorkspaceByLinkTxParams{
+		Token:  link.Token,
+		UserID: user.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), result.Link.UseCount)
+	require.Equal(t, workspace.ID, result.User.WorkspaceID.Int64)
+	require.Equal(t, "member", result.User.Role)
+
+	// The link is used up
+	other := createRandomUserForOrganization(t, workspace.OrganizationID)
+	_, err = store.JoinWorkspaceByLinkTx(context.Background(), JoinWorkspaceByLinkTxParams{
+		Token:  link.Token,
+		UserID: other.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Revoked links can't be used
+	link2, err := testQueries.CreateWorkspaceJoinLink(context.Background(), CreateWorkspaceJoinLinkParams{
+		WorkspaceID: workspace.ID,
+		Token:       util.RandomString(32),
+	})
+	require.NoError(t, err)
+
+	revoked, err := testQueries.RevokeWorkspaceJoinLink(context.Background(), RevokeWorkspaceJoinLinkParams{
+		ID:          link2.ID,
+		WorkspaceID: workspace.ID,
+	})
+	require.NoError(t, err)
+	require.True(t, revoked.RevokedAt.Valid)
+
+	_, err = store.JoinWorkspaceByLinkTx(context.Background(), JoinWorkspaceByLinkTxParams{
+		Token:  link2.Token,
+		UserID: other.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	links, err := testQueries.ListWorkspaceJoinLinks(context.Background(), ListWorkspaceJoinLinksParams{
+		WorkspaceID: workspace.ID,
+		Limit:       10,
+	})
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertWorkspacePresenceSettings(t *testing.T) {
+	workspace, _ := createTestWorkspaceAndUser(t)
+
+	_, err := testQueries.GetWorkspaceSettings(context.Background(), workspace.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	arg := UpsertWorkspacePresenceSettingsParams{
+		WorkspaceID:         workspace.ID,
+		AwayAfterMinutes:    sql.NullInt32{Int32: 5, Valid: true},
+		OfflineAfterMinutes: sql.NullInt32{Int32: 45, Valid: true},
+	}
+	settings, err := testQueries.UpsertWorkspacePresenceSettings(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.AwayAfterMinutes, settings.AwayAfterMinutes)
+	require.Equal(t, arg.OfflineAfterMinutes, settings.OfflineAfterMinutes)
+
+	// Away must be shorter than offline
+	arg.AwayAfterMinutes = sql.NullInt32{Int32: 60, Valid: true}
+	_, err = testQueries.UpsertWorkspacePresenceSettings(context.Background(), arg)
+	require.Error(t, err)
+}
+
+func TestSetIdleUsersAwayAndOffline(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+
+	_, err := testQueries.UpsertWorkspacePresenceSettings(context.Background(), UpsertWorkspacePresenceSettingsParams{
+		WorkspaceID:         workspace.ID,
+		AwayAfterMinutes:    sql.NullInt32{Int32: 1, Valid: true},
+		OfflineAfterMinutes: sql.NullInt32{Int32: 10, Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpsertUserStatus(context.Background(), UpsertUserStatusParams{
+		UserID:      user.ID,
+		WorkspaceID: workspace.ID,
+		Status:      "online",
+	})
+	require.NoError(t, err)
+
+	// Make the user idle for five minutes
+	_, err = testDB.Exec("UPDATE user_status SET last_activity_at = $1 WHERE user_id = $2", time.Now().Add(-5*time.Minute), user.ID)
+	require.NoError(t, err)
+
+	away, err := testQueries.SetIdleUsersAway(context.Background(), sql.NullInt32{})
+	require.NoError(t, err)
+	require.True(t, containsUserStatus(away, user.ID))
+
+	// The workspace offline threshold has not passed yet, even though the default has
+	offline, err := testQueries.SetIdleUsersOffline(context.Background(), 1)
+	require.NoError(t, err)
+	require.False(t, containsUserStatus(offline, user.ID))
+}
+
+func containsUserStatus(statuses []UserStatus, userID int64) bool {
+	for _, userStatus := range statuses {
+		if userStatus.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
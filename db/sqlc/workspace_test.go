@@ -94,6 +94,80 @@ func TestDeleteWorkspace(t *testing.T) {
 	require.Empty(t, workspace2)
 }
 
+func TestSoftDeleteAndPurgeWorkspace(t *testing.T) {
+	organization := createRandomOrganization(t)
+	workspace := createRandomWorkspace(t, organization)
+
+	rows, err := testQueries.SoftDeleteWorkspace(context.Background(), SoftDeleteWorkspaceParams{ID: workspace.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	// Deleting twice does nothing
+	rows, err = testQueries.SoftDeleteWorkspace(context.Background(), SoftDeleteWorkspaceParams{ID: workspace.ID})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	_, err = testQueries.GetWorkspaceByID(context.Background(), workspace.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	purgeable, err := testQueries.ListPurgeableWorkspaces(context.Background(), ListPurgeableWorkspacesParams{
+		DeletedBefore: time.Now().Add(time.Minute),
+		Limit:         1000,
+	})
+	require.NoError(t, err)
+
+	var found bool
+	for _, w := range purgeable {
+		if w.ID == workspace.ID {
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	require.NoError(t, testQueries.DeleteWorkspace(context.Background(), workspace.ID))
+}
+
+func TestDeleteWorkspaceTx(t *testing.T) {
+	workspace, user := createTestWorkspaceAndUser(t)
+	channel := createRandomChannel(t, workspace, user)
+	store := NewStore(testDB)
+
+	hold, err := testQueries.CreateLegalHold(context.Background(), CreateLegalHoldParams{
+		OrganizationID: workspace.OrganizationID,
+		TargetType:     "channel",
+		TargetID:       channel.ID,
+		Reason:         "Pending litigation",
+	})
+	require.NoError(t, err)
+
+	arg := SoftDeleteWorkspaceParams{
+		ID:        workspace.ID,
+		DeletedBy: sql.NullInt64{Int64: user.ID, Valid: true},
+	}
+
+	// A workspace with a held channel stays
+	err = store.DeleteWorkspaceTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrWorkspaceUnderLegalHold)
+
+	_, err = testQueries.GetWorkspaceByID(context.Background(), workspace.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.ReleaseLegalHold(context.Background(), ReleaseLegalHoldParams{
+		ID:             hold.ID,
+		OrganizationID: workspace.OrganizationID,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.DeleteWorkspaceTx(context.Background(), arg))
+
+	_, err = testQueries.GetWorkspaceByID(context.Background(), workspace.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Deleting twice finds nothing to delete
+	err = store.DeleteWorkspaceTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func TestListWorkspacesByOrganization(t *testing.T) {
 	organization := createRandomOrganization(t)
 
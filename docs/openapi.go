@@ -0,0 +1,9 @@
+package docs
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 document openapi-gen converts from swagger.json,
+// served at /openapi.json
+//
+//go:embed openapi.json
+var OpenAPI []byte
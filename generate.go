@@ -0,0 +1,7 @@
+package main
+
+// Regenerate the API documents and clients after changing handler annotations:
+// swag writes the Swagger document, which openapi-gen converts to OpenAPI 3
+// and generates the Go and TypeScript clients from.
+//go:generate go run github.com/swaggo/swag/cmd/swag init -g main.go -o docs/ --parseInternal --parseDependency
+//go:generate go run ./cmd/openapi-gen -in docs/swagger.json -out docs/openapi.json -go client/client.gen.go -ts client/typescript/src/client.ts
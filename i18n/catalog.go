@@ -0,0 +1,89 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Catalog provides translated messages by language. Implementations can load
+// messages from files, a database or a translation service.
+type Catalog interface {
+	// Languages lists the languages the catalog has messages for
+	Languages() []language.Tag
+	// Lookup returns the message for key in lang
+	Lookup(lang language.Tag, key string) (string, bool)
+}
+
+// MapCatalog is an in-memory catalog
+type MapCatalog struct {
+	messages  map[language.Tag]map[string]string
+	languages []language.Tag
+}
+
+// NewMapCatalog creates an empty in-memory catalog
+func NewMapCatalog() *MapCatalog {
+	return &MapCatalog{
+		messages: make(map[language.Tag]map[string]string),
+	}
+}
+
+// Add adds messages for a language, replacing existing messages with the same key
+func (c *MapCatalog) Add(lang language.Tag, messages map[string]string) {
+	existing, ok := c.messages[lang]
+	if !ok {
+		existing = make(map[string]string, len(messages))
+		c.messages[lang] = existing
+		c.languages = append(c.languages, lang)
+	}
+
+	for key, message := range messages {
+		existing[key] = message
+	}
+}
+
+// LoadDir adds the messages of every <language>.json file in dir, such as
+// es.json or pt-BR.json. Each file is a JSON object of keys to messages.
+func (c *MapCatalog) LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list message catalogs: %w", err)
+	}
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		lang, err := language.Parse(name)
+		if err != nil {
+			return fmt.Errorf("invalid message catalog language %q: %w", name, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read message catalog: %w", err)
+		}
+
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("invalid message catalog %s: %w", filepath.Base(path), err)
+		}
+
+		c.Add(lang, messages)
+	}
+
+	return nil
+}
+
+// Languages lists the languages in the order they were added
+func (c *MapCatalog) Languages() []language.Tag {
+	return c.languages
+}
+
+// Lookup returns the message for key in lang
+func (c *MapCatalog) Lookup(lang language.Tag, key string) (string, bool) {
+	message, ok := c.messages[lang][key]
+	return message, ok
+}
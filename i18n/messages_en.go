@@ -0,0 +1,25 @@
+package i18n
+
+// Message keys for system messages and email templates. API errors are looked
+// up by their English text, so catalogs translate them without a key and errors
+// without a translation are returned unchanged.
+const (
+	SystemChannelJoined   = "system.channel_joined"
+	SystemChannelLeft     = "system.channel_left"
+	SystemWorkspaceJoined = "system.workspace_joined"
+	SystemOutOfOffice     = "system.out_of_office"
+
+	EmailWorkspaceInvitation = "email.workspace_invitation"
+)
+
+// englishMessages is the default catalog. Messages are fmt format strings and
+// translations can reorder arguments with explicit indexes such as %[2]s.
+var englishMessages = map[string]string{
+	SystemChannelJoined:   "%s joined the channel",
+	SystemChannelLeft:     "%s left the channel",
+	SystemWorkspaceJoined: "%s joined the workspace",
+	SystemOutOfOffice:     "%s is out of office until %s",
+
+	EmailWorkspaceInvitation + ".subject": "You're invited to join %[2]s on GoSlack",
+	EmailWorkspaceInvitation + ".body":    "%[1]s invited you to join the %[2]s workspace on GoSlack.\n\nUse the invitation code %[3]s to join. The invitation expires on %[4]s.",
+}
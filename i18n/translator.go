@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage is used when a client accepts none of the catalog's languages
+var DefaultLanguage = language.English
+
+// Translator picks the best language for a client and renders messages from a catalog
+type Translator struct {
+	catalog   Catalog
+	languages []language.Tag
+	matcher   language.Matcher
+}
+
+// NewTranslator creates a translator backed by catalog, falling back to the
+// built-in English messages
+func NewTranslator(catalog Catalog) *Translator {
+	languages := []language.Tag{DefaultLanguage}
+	for _, lang := range catalog.Languages() {
+		if lang != DefaultLanguage {
+			languages = append(languages, lang)
+		}
+	}
+
+	return &Translator{
+		catalog:   catalog,
+		languages: languages,
+		matcher:   language.NewMatcher(languages),
+	}
+}
+
+// Match returns the supported language that best matches an Accept-Language header
+func (t *Translator) Match(acceptLanguage string) language.Tag {
+	_, index := language.MatchStrings(t.matcher, acceptLanguage)
+	return t.languages[index]
+}
+
+// Translate renders the message for key in lang with args. Missing messages
+// fall back to the default language and then to the key itself.
+func (t *Translator) Translate(lang language.Tag, key string, args ...interface{}) string {
+	message, ok := t.catalog.Lookup(lang, key)
+	if !ok {
+		message, ok = t.catalog.Lookup(DefaultLanguage, key)
+	}
+	if !ok {
+		message, ok = englishMessages[key]
+	}
+	if !ok {
+		message = key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Email is a rendered email template
+type Email struct {
+	Subject string
+	Body    string
+}
+
+// Email renders the subject and body of an email template in lang
+func (t *Translator) Email(lang language.Tag, template string, args ...interface{}) Email {
+	return Email{
+		Subject: t.Translate(lang, template+".subject", args...),
+		Body:    t.Translate(lang, template+".body", args...),
+	}
+}
+
+// Localizer translates messages for a single client
+type Localizer struct {
+	translator *Translator
+	Language   language.Tag
+}
+
+// Localizer returns a localizer for lang
+func (t *Translator) Localizer(lang language.Tag) *Localizer {
+	return &Localizer{translator: t, Language: lang}
+}
+
+// T renders the message for key
+func (l *Localizer) T(key string, args ...interface{}) string {
+	return l.translator.Translate(l.Language, key, args...)
+}
+
+// Error translates an error message
+func (l *Localizer) Error(err error) string {
+	return l.translator.Translate(l.Language, err.Error())
+}
+
+// Email renders an email template
+func (l *Localizer) Email(template string, args ...interface{}) Email {
+	return l.translator.Email(l.Language, template, args...)
+}
@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func newTestTranslator() *Translator {
+	catalog := NewMapCatalog()
+	catalog.Add(language.Spanish, map[string]string{
+		SystemChannelJoined:                   "%s se unió al canal",
+		"invalid workspace ID":                "ID de espacio de trabajo no válido",
+		EmailWorkspaceInvitation + ".subject": "Te invitaron a unirte a %[2]s en GoSlack",
+	})
+	return NewTranslator(catalog)
+}
+
+func TestMatch(t *testing.T) {
+	translator := newTestTranslator()
+
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		expected       language.Tag
+	}{
+		{name: "Empty", acceptLanguage: "", expected: language.English},
+		{name: "Exact", acceptLanguage: "es", expected: language.Spanish},
+		{name: "Region", acceptLanguage: "es-MX,es;q=0.9", expected: language.Spanish},
+		{name: "Quality", acceptLanguage: "de;q=0.9,es;q=0.8,en;q=0.1", expected: language.Spanish},
+		{name: "Unsupported", acceptLanguage: "ja", expected: language.English},
+		{name: "Invalid", acceptLanguage: "not a language!", expected: language.English},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, translator.Match(tc.acceptLanguage))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	translator := newTestTranslator()
+
+	require.Equal(t, "Ada se unió al canal", translator.Translate(language.Spanish, SystemChannelJoined, "Ada"))
+	require.Equal(t, "Ada joined the channel", translator.Translate(language.English, SystemChannelJoined, "Ada"))
+
+	// Missing translations fall back to English, then to the key
+	require.Equal(t, "Ada left the channel", translator.Translate(language.Spanish, SystemChannelLeft, "Ada"))
+	require.Equal(t, "channel not found", translator.Translate(language.Spanish, "channel not found"))
+
+	localizer := translator.Localizer(language.Spanish)
+	require.Equal(t, "ID de espacio de trabajo no válido", localizer.Error(errors.New("invalid workspace ID")))
+
+	email := localizer.Email(EmailWorkspaceInvitation, "Ada", "Engineering", "ABC123", "2026-01-02")
+	require.Equal(t, "Te invitaron a unirte a Engineering en GoSlack", email.Subject)
+	require.Contains(t, email.Body, "ABC123")
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pt-BR.json"), []byte(`{"channel not found": "canal não encontrado"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0o644))
+
+	catalog := NewMapCatalog()
+	require.NoError(t, catalog.LoadDir(dir))
+
+	translator := NewTranslator(catalog)
+	lang := translator.Match("pt-BR")
+	require.Equal(t, language.BrazilianPortuguese, lang)
+	require.Equal(t, "canal não encontrado", translator.Translate(lang, "channel not found"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fr.json"), []byte(`not json`), 0o644))
+	require.Error(t, NewMapCatalog().LoadDir(dir))
+}
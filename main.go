@@ -24,13 +24,18 @@ package main
 import (
 	"context"
 	"database/sql"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/heyrmi/goslack/api"
+	"github.com/heyrmi/goslack/backup"
 	db "github.com/heyrmi/goslack/db/sqlc"
-	"github.com/heyrmi/goslack/service"
+	"github.com/heyrmi/goslack/seed"
 	"github.com/heyrmi/goslack/util"
-	_ "github.com/lib/pq"
 
 	_ "github.com/heyrmi/goslack/docs" // Import generated docs
 )
@@ -41,19 +46,38 @@ func main() {
 		log.Fatal("cannot load config:", err)
 	}
 
-	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	// Seeding and backups run long statements
+	if len(os.Args) > 1 && (os.Args[1] == "seed" || os.Args[1] == "backup") {
+		config.DBStatementTimeout = 0
+	}
+
+	conn, pool, err := db.OpenDB(context.Background(), config)
 	if err != nil {
 		log.Fatal("cannot connect to db:", err)
 	}
+	defer pool.Close()
+
+	// `goslack seed` and `goslack backup` run maintenance commands instead of starting the server
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "seed":
+			runSeed(conn, config, os.Args[2:])
+			return
+		case "backup":
+			runBackup(conn, config, os.Args[2:])
+			return
+		}
+	}
 
+	db.PublishPoolMetrics(pool)
 	store := db.NewStore(conn)
 	server, err := api.NewServer(config, store)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
 	}
 
-	// Start background services
-	startBackgroundServices(store)
+	// Apply changed limits without a restart: kill -HUP <pid>
+	go reloadOnSignal(server)
 
 	err = server.Start(config.HTTPServerAddress)
 	if err != nil {
@@ -61,11 +85,107 @@ func main() {
 	}
 }
 
-// startBackgroundServices starts background services like inactivity monitoring
-func startBackgroundServices(store db.Store) {
-	// Background services don't need WebSocket broadcasting, so pass nil
-	statusService := service.NewStatusService(store, nil)
+// reloadOnSignal reloads the configuration on SIGHUP and applies the settings
+// that can change while the server runs. A configuration that fails to load
+// leaves the current settings in place.
+func reloadOnSignal(server *api.Server) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	for range signals {
+		config, err := util.LoadConfig(".")
+		if err != nil {
+			log.Println("cannot reload config:", err)
+			continue
+		}
+		server.ReloadTunables(config)
+		log.Println("reloaded config")
+	}
+}
+
+// runSeed provisions a demo organization and prints how to log in
+func runSeed(conn *sql.DB, config util.Config, args []string) {
+	opts, err := seed.ParseFlags(args, os.Stderr)
+	if err != nil {
+		log.Fatal("invalid seed options:", err)
+	}
+
+	summary, err := seed.Run(context.Background(), conn, config.FileStoragePath, opts)
+	if err != nil {
+		log.Fatal("cannot seed demo data:", err)
+	}
+
+	fmt.Printf("Seeded organization %q (id %d)\n", opts.Organization, summary.OrganizationID)
+	for _, workspace := range summary.Workspaces {
+		fmt.Printf("  workspace %q (id %d), admin %s\n", workspace.Name, workspace.ID, workspace.AdminEmail)
+	}
+	fmt.Printf("%d users, %d channels, %d messages, %d thread replies, %d direct messages, %d files\n",
+		summary.Users, summary.Channels, summary.Messages, summary.ThreadReplies, summary.DirectMessages, summary.Files)
+	fmt.Printf("Every user's password is %q\n", summary.Password)
+}
+
+// runBackup creates, verifies or restores a backup of the database and file store
+func runBackup(conn *sql.DB, config util.Config, args []string) {
+	const usage = "usage: goslack backup create -o <archive> | verify <archive> | restore <archive>"
+	if len(args) == 0 {
+		log.Fatal(usage)
+	}
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("backup create", flag.ExitOnError)
+		output := flags.String("o", "", "path of the backup archive to write")
+		flags.Parse(args[1:])
+		if *output == "" {
+			log.Fatal(usage)
+		}
+
+		file, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			log.Fatal("cannot create backup archive:", err)
+		}
+		manifest, err := backup.Create(context.Background(), conn, file)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(*output)
+			log.Fatal("cannot create backup:", err)
+		}
 
-	// Start inactivity monitor in background
-	go statusService.StartInactivityMonitor(context.Background())
+		fmt.Printf("Backed up %d tables and %d files at schema version %d to %s\n",
+			len(manifest.Tables), len(manifest.Files), manifest.SchemaVersion, *output)
+	case "verify":
+		if len(args) != 2 {
+			log.Fatal(usage)
+		}
+
+		file, err := os.Open(args[1])
+		if err != nil {
+			log.Fatal("cannot open backup archive:", err)
+		}
+		defer file.Close()
+
+		manifest, err := backup.Verify(file)
+		if err != nil {
+			log.Fatal("backup verification failed:", err)
+		}
+
+		fmt.Printf("Backup from %s is intact: %d tables and %d files at schema version %d\n",
+			manifest.CreatedAt.Format("2006-01-02 15:04:05 MST"), len(manifest.Tables), len(manifest.Files), manifest.SchemaVersion)
+	case "restore":
+		if len(args) != 2 {
+			log.Fatal(usage)
+		}
+
+		manifest, err := backup.Restore(context.Background(), conn, config.FileStoragePath, args[1])
+		if err != nil {
+			log.Fatal("cannot restore backup:", err)
+		}
+
+		fmt.Printf("Restored %d tables and %d files from the backup of %s\n",
+			len(manifest.Tables), len(manifest.Files), manifest.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+	default:
+		log.Fatal(usage)
+	}
 }
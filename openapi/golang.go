@@ -0,0 +1,381 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GenerateGo returns the generated part of the Go client: a type for every
+// schema and a Client method for every operation. The package it goes in
+// provides Client and the request helpers the methods call.
+func GenerateGo(doc *Document, packageName string) ([]byte, error) {
+	operations, err := doc.operations()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &goGenerator{names: typeNames(doc), types: map[string]string{}}
+	for component, schema := range doc.Components.Schemas {
+		g.addType(g.names[component], schema.Description, schema)
+	}
+	var methods bytes.Buffer
+	for _, op := range operations {
+		if err := g.writeMethod(&methods, op); err != nil {
+			return nil, err
+		}
+	}
+
+	var code bytes.Buffer
+	for _, name := range sortedKeys(g.types) {
+		code.WriteString(g.types[name])
+	}
+	code.Write(methods.Bytes())
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by openapi-gen from docs/openapi.json. DO NOT EDIT.\n\npackage %s\n\n", packageName)
+	out.WriteString("import (\n")
+	for _, pkg := range []string{"context", "fmt", "io", "net/http", "net/url"} {
+		name := pkg[strings.LastIndex(pkg, "/")+1:]
+		if regexp.MustCompile(`\b` + name + `\.[A-Z]`).Match(code.Bytes()) {
+			fmt.Fprintf(&out, "\t%q\n", pkg)
+		}
+	}
+	out.WriteString(")\n\n")
+	out.Write(code.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated Go client doesn't compile: %w", err)
+	}
+	return formatted, nil
+}
+
+type goGenerator struct {
+	// names maps components to type names
+	names map[string]string
+	// types holds the source of every type declaration by name
+	types map[string]string
+}
+
+// addType declares a named type for a schema
+func (g *goGenerator) addType(name, description string, schema *Schema) {
+	var b strings.Builder
+	if description != "" {
+		b.WriteString(commentLines(name+" "+lowerFirst(description), "//"))
+	} else {
+		fmt.Fprintf(&b, "// %s is a schema of the GoSlack API\n", name)
+	}
+	if schema.Type == "object" && schema.AdditionalProperties == nil {
+		fmt.Fprintf(&b, "type %s %s\n\n", name, g.structType(schema))
+	} else {
+		fmt.Fprintf(&b, "type %s = %s\n\n", name, g.goType(schema))
+	}
+	g.types[name] = b.String()
+}
+
+// structType returns the struct for an object's properties, with optional
+// ones as pointers left out of JSON when unset
+func (g *goGenerator) structType(schema *Schema) string {
+	if len(schema.Properties) == 0 {
+		return "struct{}"
+	}
+
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		if property.Description != "" {
+			b.WriteString(commentLines(property.Description, "//"))
+		}
+		fieldType := g.goType(property)
+		tag := name
+		if !required[name] {
+			fieldType = optionalGoType(fieldType)
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&b, "%s %s `json:%q`\n", exportedName(name), fieldType, tag)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// goType returns the Go type of a schema
+func (g *goGenerator) goType(schema *Schema) string {
+	if schema == nil {
+		return "any"
+	}
+	if schema.Ref != "" {
+		return g.names[schema.RefName()]
+	}
+	if len(schema.AllOf) == 1 {
+		return g.goType(schema.AllOf[0])
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.IsBinary() {
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		if schema.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(schema.Items)
+	case "object":
+		if schema.AdditionalProperties != nil {
+			return "map[string]" + g.goType(schema.AdditionalProperties)
+		}
+		if len(schema.Properties) > 0 {
+			return g.structType(schema)
+		}
+		return "map[string]any"
+	}
+	return "any"
+}
+
+// optionalGoType returns the type of an optional field, a pointer unless the
+// type already has a zero value that means unset
+func optionalGoType(goType string) string {
+	if goType == "any" || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") {
+		return goType
+	}
+	return "*" + goType
+}
+
+// goParameter is a method parameter in a generated method
+type goParameter struct {
+	name     string
+	goType   string
+	in       string
+	wireName string
+	required bool
+}
+
+// writeMethod writes the Client method of an operation, and the types of its
+// parameters and response
+func (g *goGenerator) writeMethod(b *bytes.Buffer, op operation) error {
+	success, ok := op.successResponse()
+	if !ok {
+		// Protocol switches such as the WebSocket connection aren't requests
+		return nil
+	}
+
+	name := exportedName(op.OperationID)
+	args := []string{"ctx context.Context"}
+	used := map[string]bool{"ctx": true, "c": true, "req": true, "out": true, "err": true, "params": true, "body": true}
+
+	// Path parameters are arguments, everything else goes in a params struct
+	path := fmt.Sprintf("%q", op.Path)
+	var options []goParameter
+	for _, parameter := range op.Parameters {
+		if parameter.In == "path" {
+			argName := unexportedName(parameter.Name)
+			if used[argName] || token.IsKeyword(argName) {
+				argName += "Param"
+			}
+			used[argName] = true
+			args = append(args, argName+" "+g.goType(parameter.Schema))
+			path = strings.Replace(path, "{"+parameter.Name+"}", `"+url.PathEscape(fmt.Sprint(`+argName+`))+"`, 1)
+			continue
+		}
+		options = append(options, goParameter{
+			name:     exportedName(parameter.Name),
+			goType:   g.goType(parameter.Schema),
+			in:       parameter.In,
+			wireName: parameter.Name,
+			required: parameter.Required,
+		})
+	}
+	path = strings.TrimSuffix(path, `+""`)
+
+	body := op.jsonBody()
+	if body != nil {
+		args = append(args, "body "+g.goType(body))
+	}
+	if form := op.formBody(); form != nil {
+		required := map[string]bool{}
+		for _, field := range form.Required {
+			required[field] = true
+		}
+		for _, field := range sortedKeys(form.Properties) {
+			fieldType := g.goType(form.Properties[field])
+			if form.Properties[field].IsBinary() {
+				fieldType = "File"
+			}
+			options = append(options, goParameter{
+				name:     exportedName(field),
+				goType:   fieldType,
+				in:       "formData",
+				wireName: field,
+				required: required[field],
+			})
+		}
+	}
+
+	paramsType := ""
+	if len(options) > 0 {
+		paramsType = name + "Params"
+		if _, exists := g.types[paramsType]; exists {
+			return fmt.Errorf("the parameters of %s are named like the schema %s", op.OperationID, paramsType)
+		}
+		g.addParamsType(paramsType, name, options)
+		if hasRequired(options) {
+			args = append(args, "params "+paramsType)
+		} else {
+			args = append(args, "params *"+paramsType)
+		}
+	}
+
+	// The result is the success response's schema, named after the
+	// operation when the annotation describes it inline
+	result := ""
+	switch {
+	case success == nil:
+	case success.IsBinary():
+		result = "io.ReadCloser"
+	case success.Ref != "":
+		result = "*" + g.goType(success)
+	case success.Type == "object" && len(success.Properties) > 0:
+		responseType := name + "Response"
+		if _, exists := g.types[responseType]; exists {
+			return fmt.Errorf("the response of %s is named like the schema %s", op.OperationID, responseType)
+		}
+		g.addType(responseType, "is the response of "+name, success)
+		result = "*" + responseType
+	default:
+		result = g.goType(success)
+	}
+
+	summary := fmt.Sprintf("%s sends %s %s", name, op.Method, op.Path)
+	if op.Summary != "" {
+		summary += ": " + op.Summary
+	}
+	b.WriteString(commentLines(summary, "//"))
+	if op.Description != "" {
+		b.WriteString("//\n")
+		b.WriteString(commentLines(op.Description, "//"))
+	}
+	if op.Deprecated {
+		b.WriteString("//\n// Deprecated: the API marks this operation as deprecated.\n")
+	}
+
+	results := "error"
+	if result != "" {
+		results = "(" + result + ", error)"
+	}
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), results)
+	fmt.Fprintf(b, "req := newRequest(http.Method%s, %s)\n", methodConstant(op.Method), path)
+	if body != nil {
+		b.WriteString("req.body = body\n")
+	}
+	if paramsType != "" {
+		writeParams(b, options, !hasRequired(options))
+	}
+
+	switch {
+	case result == "":
+		b.WriteString("return c.do(ctx, req, nil)\n")
+	case result == "io.ReadCloser":
+		b.WriteString("return c.stream(ctx, req)\n")
+	case strings.HasPrefix(result, "*"):
+		fmt.Fprintf(b, "var out %s\nif err := c.do(ctx, req, &out); err != nil {\nreturn nil, err\n}\nreturn &out, nil\n", result[1:])
+	default:
+		fmt.Fprintf(b, "var out %s\nerr := c.do(ctx, req, &out)\nreturn out, err\n", result)
+	}
+	b.WriteString("}\n\n")
+	return nil
+}
+
+// addParamsType declares the struct of an operation's query, header and form
+// parameters
+func (g *goGenerator) addParamsType(name, method string, options []goParameter) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s holds the parameters of %s\ntype %s struct {\n", name, method, name)
+	for _, option := range options {
+		fieldType := option.goType
+		if !option.required {
+			fieldType = optionalGoType(fieldType)
+		}
+		fmt.Fprintf(&b, "%s %s\n", option.name, fieldType)
+	}
+	b.WriteString("}\n\n")
+	g.types[name] = b.String()
+}
+
+// writeParams writes the code that adds the parameters to the request
+func writeParams(b *bytes.Buffer, options []goParameter, optional bool) {
+	if optional {
+		b.WriteString("if params != nil {\n")
+	}
+	for _, option := range options {
+		value := "params." + option.name
+		pointer := !option.required && !strings.HasPrefix(option.goType, "[]")
+		if pointer {
+			fmt.Fprintf(b, "if %s != nil {\n", value)
+			value = "*" + value
+		}
+
+		switch {
+		case option.in == "formData" && option.goType == "File":
+			fmt.Fprintf(b, "req.addFormFile(%q, %s)\n", option.wireName, value)
+		case strings.HasPrefix(option.goType, "[]"):
+			fmt.Fprintf(b, "for _, value := range %s {\nreq.add(%q, %q, fmt.Sprint(value))\n}\n", value, option.in, option.wireName)
+		default:
+			fmt.Fprintf(b, "req.add(%q, %q, fmt.Sprint(%s))\n", option.in, option.wireName, value)
+		}
+
+		if pointer {
+			b.WriteString("}\n")
+		}
+	}
+	if optional {
+		b.WriteString("}\n")
+	}
+}
+
+func hasRequired(options []goParameter) bool {
+	for _, option := range options {
+		if option.required {
+			return true
+		}
+	}
+	return false
+}
+
+// methodConstant returns the name of a method's net/http constant
+func methodConstant(method string) string {
+	return strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are written in capitals in Go names, as golint expects
+var initialisms = map[string]bool{
+	"API": true, "CSV": true, "DM": true, "DND": true, "HTML": true, "HTTP": true,
+	"ID": true, "IDS": true, "IP": true, "JSON": true, "JWKS": true, "SSO": true,
+	"TLS": true, "TS": true, "UI": true, "URI": true, "URL": true, "URLS": true,
+}
+
+// words splits a name in snake case, kebab case or camel case into words
+func words(name string) []string {
+	var result []string
+	var current []rune
+	runes := []rune(name)
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, string(current))
+			current = nil
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && len(current) > 0 &&
+			(unicode.IsLower(current[len(current)-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return result
+}
+
+// exportedName returns a name as an exported Go identifier, such as
+// WorkspaceID for workspace_id
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, word := range words(name) {
+		upper := strings.ToUpper(word)
+		switch {
+		case upper == "IDS":
+			b.WriteString("IDs")
+		case upper == "URLS":
+			b.WriteString("URLs")
+		case initialisms[upper]:
+			b.WriteString(upper)
+		default:
+			b.WriteString(strings.ToUpper(word[:1]) + strings.ToLower(word[1:]))
+		}
+	}
+	if b.Len() == 0 || unicode.IsDigit(rune(b.String()[0])) {
+		return "X" + b.String()
+	}
+	return b.String()
+}
+
+// unexportedName returns a name as an unexported Go identifier, such as
+// workspaceID for workspace_id
+func unexportedName(name string) string {
+	exported := exportedName(name)
+	// Lower the leading initialism or word: IDs becomes ids, URLPath urlPath
+	first := words(name)[0]
+	if initialisms[strings.ToUpper(first)] {
+		prefix := strings.ToUpper(first)
+		if prefix == "IDS" || prefix == "URLS" {
+			prefix = prefix[:len(prefix)-1] + "s"
+		}
+		return strings.ToLower(prefix) + exported[len(prefix):]
+	}
+	return strings.ToLower(exported[:1]) + exported[1:]
+}
+
+// camelName returns a name in lower camel case for TypeScript, such as
+// workspaceId for workspace_id
+func camelName(name string) string {
+	var b strings.Builder
+	for i, word := range words(name) {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word[:1]) + word[1:])
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// typeNames names the document's schemas in the clients, after their Go type
+// without its package, such as FileResponse for service.FileResponse. Names
+// used in more than one package keep the package as a prefix.
+func typeNames(doc *Document) map[string]string {
+	counts := map[string]int{}
+	for component := range doc.Components.Schemas {
+		counts[baseTypeName(component)]++
+	}
+
+	names := make(map[string]string, len(doc.Components.Schemas))
+	for component := range doc.Components.Schemas {
+		name := baseTypeName(component)
+		if counts[name] > 1 {
+			if pkg, _, found := strings.Cut(component, "."); found {
+				name = exportedName(pkg) + name
+			}
+		}
+		names[component] = name
+	}
+	return names
+}
+
+func baseTypeName(component string) string {
+	if i := strings.LastIndex(component, "."); i >= 0 {
+		component = component[i+1:]
+	}
+	return exportedName(component)
+}
+
+// commentLines returns text as comment lines with a prefix
+func commentLines(text, prefix string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		b.WriteString(strings.TrimRight(prefix+" "+strings.TrimSpace(line), " ") + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,474 @@
+// Package openapi turns the Swagger 2.0 document swag generates from the
+// handler annotations into an OpenAPI 3 document, and generates the typed Go
+// and TypeScript clients from it. The swagger.json in docs stays the source:
+// anything missing from the clients is fixed in the annotations, then
+// everything is regenerated with `go generate`.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Version is the OpenAPI version of converted documents
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3 document, with the parts the handler
+// annotations can describe
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       json.RawMessage     `json:"info"`
+	Servers    []Server            `json:"servers,omitempty"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Server is a base URL the API is served at
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds a path's operations by lowercase HTTP method
+type PathItem map[string]*Operation
+
+// Operation is one method on one path
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+// Parameter is a path, query or header parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is an operation's body, by content type
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// MediaType is the schema of a body in one content type
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Response is one of an operation's responses, by content type
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Components holds the schemas operations refer to and the security schemes
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is a way requests are authenticated
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Name        string `json:"name,omitempty"`
+	In          string `json:"in,omitempty"`
+	Scheme      string `json:"scheme,omitempty"`
+}
+
+// Schema describes a value. Swagger 2.0 and OpenAPI 3 share the keywords
+// swag uses, apart from references and files.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Example              any                `json:"example,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int64             `json:"minLength,omitempty"`
+	MaxLength            *int64             `json:"maxLength,omitempty"`
+	MinItems             *int64             `json:"minItems,omitempty"`
+	MaxItems             *int64             `json:"maxItems,omitempty"`
+}
+
+// UnmarshalJSON reads a schema. A bare true, as swag writes for the values
+// of map[string]interface{}, allows any value.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); bytes.Equal(trimmed, []byte("true")) || bytes.Equal(trimmed, []byte("false")) {
+		*s = Schema{}
+		return nil
+	}
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// RefName returns the component a schema refers to, if it's a reference
+func (s *Schema) RefName() string {
+	return strings.TrimPrefix(s.Ref, componentPrefix)
+}
+
+// IsBinary reports whether a schema stands for raw file content
+func (s *Schema) IsBinary() bool {
+	return s.Type == "string" && s.Format == "binary"
+}
+
+const (
+	definitionPrefix = "#/definitions/"
+	componentPrefix  = "#/components/schemas/"
+)
+
+// swagger is the part of a Swagger 2.0 document swag writes
+type swagger struct {
+	Info                json.RawMessage                        `json:"info"`
+	Host                string                                 `json:"host"`
+	BasePath            string                                 `json:"basePath"`
+	Schemes             []string                               `json:"schemes"`
+	Paths               map[string]map[string]swaggerOperation `json:"paths"`
+	Definitions         map[string]*Schema                     `json:"definitions"`
+	SecurityDefinitions map[string]SecurityScheme              `json:"securityDefinitions"`
+}
+
+type swaggerOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Description string                     `json:"description"`
+	Tags        []string                   `json:"tags"`
+	Consumes    []string                   `json:"consumes"`
+	Produces    []string                   `json:"produces"`
+	Parameters  []swaggerParameter         `json:"parameters"`
+	Responses   map[string]swaggerResponse `json:"responses"`
+	Security    []map[string][]string      `json:"security"`
+	Deprecated  bool                       `json:"deprecated"`
+}
+
+type swaggerParameter struct {
+	Name        string   `json:"name"`
+	In          string   `json:"in"`
+	Description string   `json:"description"`
+	Required    bool     `json:"required"`
+	Schema      *Schema  `json:"schema"`
+	Type        string   `json:"type"`
+	Format      string   `json:"format"`
+	Items       *Schema  `json:"items"`
+	Enum        []any    `json:"enum"`
+	Default     any      `json:"default"`
+	Minimum     *float64 `json:"minimum"`
+	Maximum     *float64 `json:"maximum"`
+}
+
+type swaggerResponse struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Convert turns a Swagger 2.0 document into an OpenAPI 3 document
+func Convert(data []byte) (*Document, error) {
+	var spec swagger
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("invalid Swagger document: %w", err)
+	}
+	if len(spec.Paths) == 0 {
+		return nil, errors.New("invalid Swagger document: no paths")
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    spec.Info,
+		Paths:   make(map[string]PathItem, len(spec.Paths)),
+		Components: Components{
+			Schemas:         make(map[string]*Schema, len(spec.Definitions)),
+			SecuritySchemes: make(map[string]SecurityScheme, len(spec.SecurityDefinitions)),
+		},
+	}
+
+	basePath := strings.TrimSuffix(spec.BasePath, "/")
+	if spec.Host == "" {
+		doc.Servers = []Server{{URL: basePath + "/"}}
+	}
+	for _, scheme := range spec.Schemes {
+		if spec.Host != "" {
+			doc.Servers = append(doc.Servers, Server{URL: scheme + "://" + spec.Host + basePath})
+		}
+	}
+
+	for name, schema := range spec.Definitions {
+		doc.Components.Schemas[name] = convertSchema(schema)
+	}
+	for name, scheme := range spec.SecurityDefinitions {
+		// Swagger's basic authentication is an HTTP scheme in OpenAPI 3
+		if scheme.Type == "basic" {
+			scheme = SecurityScheme{Type: "http", Scheme: "basic", Description: scheme.Description}
+		}
+		doc.Components.SecuritySchemes[name] = scheme
+	}
+
+	for path, operations := range spec.Paths {
+		item := make(PathItem, len(operations))
+		for method, operation := range operations {
+			item[method] = convertOperation(operation)
+		}
+		doc.Paths[path] = item
+	}
+
+	return doc, nil
+}
+
+// convertSchema points a schema's references at the document's components and
+// turns files into binary strings, in place
+func convertSchema(schema *Schema) *Schema {
+	if schema == nil {
+		return nil
+	}
+	if name, ok := strings.CutPrefix(schema.Ref, definitionPrefix); ok {
+		schema.Ref = componentPrefix + name
+	}
+	if schema.Type == "file" {
+		schema.Type, schema.Format = "string", "binary"
+	}
+	for _, property := range schema.Properties {
+		convertSchema(property)
+	}
+	for _, member := range schema.AllOf {
+		convertSchema(member)
+	}
+	convertSchema(schema.Items)
+	convertSchema(schema.AdditionalProperties)
+	return schema
+}
+
+func convertOperation(operation swaggerOperation) *Operation {
+	converted := &Operation{
+		OperationID: operation.OperationID,
+		Summary:     operation.Summary,
+		Description: operation.Description,
+		Tags:        operation.Tags,
+		Responses:   make(map[string]Response, len(operation.Responses)),
+		Security:    operation.Security,
+		Deprecated:  operation.Deprecated,
+	}
+
+	var body *swaggerParameter
+	var form *Schema
+	formRequired := false
+	for i, parameter := range operation.Parameters {
+		switch parameter.In {
+		case "body":
+			body = &operation.Parameters[i]
+		case "formData":
+			if form == nil {
+				form = &Schema{Type: "object", Properties: map[string]*Schema{}}
+			}
+			form.Properties[parameter.Name] = convertSchema(parameterSchema(parameter))
+			if parameter.Description != "" {
+				form.Properties[parameter.Name].Description = parameter.Description
+			}
+			if parameter.Required {
+				form.Required = append(form.Required, parameter.Name)
+				formRequired = true
+			}
+		default:
+			converted.Parameters = append(converted.Parameters, Parameter{
+				Name:        parameter.Name,
+				In:          parameter.In,
+				Description: parameter.Description,
+				Required:    parameter.Required || parameter.In == "path",
+				Schema:      convertSchema(parameterSchema(parameter)),
+			})
+		}
+	}
+
+	if body != nil || form != nil {
+		consumes := operation.Consumes
+		if len(consumes) == 0 {
+			consumes = []string{"application/json"}
+		}
+
+		requestBody := &RequestBody{Content: map[string]MediaType{}, Required: formRequired}
+		if body != nil {
+			requestBody.Description = body.Description
+			requestBody.Required = requestBody.Required || body.Required
+		}
+		for _, contentType := range consumes {
+			switch {
+			case isFormContentType(contentType) && form != nil:
+				requestBody.Content[contentType] = MediaType{Schema: form}
+			case body == nil:
+			case strings.HasPrefix(contentType, "text/"):
+				// Plain text bodies such as CSV are read as they are
+				requestBody.Content[contentType] = MediaType{Schema: &Schema{Type: "string"}}
+			default:
+				requestBody.Content[contentType] = MediaType{Schema: convertSchema(body.Schema)}
+			}
+		}
+		converted.RequestBody = requestBody
+	}
+
+	for code, response := range operation.Responses {
+		converted.Responses[code] = convertResponse(response, operation.Produces)
+	}
+
+	return converted
+}
+
+// parameterSchema returns the schema of a parameter that isn't a body
+func parameterSchema(parameter swaggerParameter) *Schema {
+	return &Schema{
+		Type:    parameter.Type,
+		Format:  parameter.Format,
+		Items:   parameter.Items,
+		Enum:    parameter.Enum,
+		Default: parameter.Default,
+		Minimum: parameter.Minimum,
+		Maximum: parameter.Maximum,
+	}
+}
+
+// convertResponse places a response's schema under the content types it's
+// sent as. Swagger lists one set of content types for every response, so
+// file content goes under the binary ones and everything else, such as
+// errors, under JSON.
+func convertResponse(response swaggerResponse, produces []string) Response {
+	converted := Response{Description: response.Description}
+	if response.Schema == nil {
+		return converted
+	}
+
+	schema := convertSchema(response.Schema)
+	var contentTypes []string
+	for _, contentType := range produces {
+		if schema.IsBinary() != isJSONContentType(contentType) {
+			contentTypes = append(contentTypes, contentType)
+		}
+	}
+	if len(contentTypes) == 0 {
+		if schema.IsBinary() {
+			contentTypes = []string{"application/octet-stream"}
+		} else {
+			contentTypes = []string{"application/json"}
+		}
+	}
+
+	converted.Content = make(map[string]MediaType, len(contentTypes))
+	for _, contentType := range contentTypes {
+		converted.Content[contentType] = MediaType{Schema: schema}
+	}
+	return converted
+}
+
+func isFormContentType(contentType string) bool {
+	return contentType == "multipart/form-data" || contentType == "application/x-www-form-urlencoded"
+}
+
+func isJSONContentType(contentType string) bool {
+	return contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+}
+
+// Marshal returns the document as indented JSON
+func (doc *Document) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// operation is an operation with where it's served
+type operation struct {
+	*Operation
+	Method string
+	Path   string
+}
+
+// operations returns the document's operations sorted by ID. Every operation
+// needs one, since the clients name their methods after them.
+func (doc *Document) operations() ([]operation, error) {
+	var operations []operation
+	seen := map[string]string{}
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			where := strings.ToUpper(method) + " " + path
+			if op.OperationID == "" {
+				return nil, fmt.Errorf("%s has no operation ID; add an @ID annotation", where)
+			}
+			if other, ok := seen[op.OperationID]; ok {
+				return nil, fmt.Errorf("operation ID %s is used by both %s and %s", op.OperationID, other, where)
+			}
+			seen[op.OperationID] = where
+			operations = append(operations, operation{Operation: op, Method: strings.ToUpper(method), Path: path})
+		}
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].OperationID < operations[j].OperationID
+	})
+	return operations, nil
+}
+
+// successResponse returns the schema of an operation's first successful
+// response, and whether it has one at all. Operations that switch protocols,
+// such as the WebSocket connection, have none.
+func (op operation) successResponse() (*Schema, bool) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		for _, media := range op.Responses[code].Content {
+			return media.Schema, true
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// jsonBody returns the schema of an operation's JSON body, if it has one
+func (op operation) jsonBody() *Schema {
+	if op.RequestBody == nil {
+		return nil
+	}
+	for contentType, media := range op.RequestBody.Content {
+		if isJSONContentType(contentType) {
+			return media.Schema
+		}
+	}
+	return nil
+}
+
+// formBody returns the schema of an operation's multipart form, if it has
+// one and no JSON body
+func (op operation) formBody() *Schema {
+	if op.RequestBody == nil || op.jsonBody() != nil {
+		return nil
+	}
+	return op.RequestBody.Content["multipart/form-data"].Schema
+}
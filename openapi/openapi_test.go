@@ -0,0 +1,200 @@
+package openapi
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSwagger = `{
+	"swagger": "2.0",
+	"info": {"title": "Test API", "version": "1.0"},
+	"basePath": "/",
+	"paths": {
+		"/channels/{id}": {
+			"get": {
+				"operationId": "getChannel",
+				"summary": "Get Channel",
+				"produces": ["application/json"],
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "type": "integer"},
+					{"name": "If-None-Match", "in": "header", "type": "string"}
+				],
+				"responses": {
+					"200": {"description": "OK", "schema": {"$ref": "#/definitions/api.channelResponse"}},
+					"404": {"description": "Not Found", "schema": {"type": "object", "additionalProperties": true}}
+				}
+			},
+			"put": {
+				"operationId": "updateChannel",
+				"consumes": ["application/json"],
+				"produces": ["application/json"],
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "type": "integer"},
+					{"name": "channel", "in": "body", "required": true, "schema": {"$ref": "#/definitions/api.updateChannelRequest"}}
+				],
+				"responses": {
+					"200": {"description": "OK", "schema": {"$ref": "#/definitions/api.channelResponse"}}
+				}
+			}
+		},
+		"/files/upload": {
+			"post": {
+				"operationId": "uploadFile",
+				"consumes": ["multipart/form-data"],
+				"produces": ["application/json"],
+				"parameters": [
+					{"name": "workspace_id", "in": "formData", "required": true, "type": "integer"},
+					{"name": "file", "in": "formData", "required": true, "type": "file"}
+				],
+				"responses": {
+					"201": {"description": "Created", "schema": {
+						"type": "object",
+						"properties": {"file": {"$ref": "#/definitions/api.channelResponse"}, "message": {"type": "string"}}
+					}}
+				}
+			}
+		},
+		"/files/{id}/download": {
+			"get": {
+				"operationId": "downloadFile",
+				"produces": ["application/octet-stream"],
+				"parameters": [{"name": "id", "in": "path", "required": true, "type": "integer"}],
+				"responses": {"200": {"description": "OK", "schema": {"type": "file"}}}
+			}
+		}
+	},
+	"definitions": {
+		"api.channelResponse": {
+			"type": "object",
+			"properties": {
+				"id": {"type": "integer"},
+				"name": {"type": "string"},
+				"member_ids": {"type": "array", "items": {"type": "integer"}}
+			}
+		},
+		"api.updateChannelRequest": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {"name": {"type": "string"}, "topic": {"type": "string"}}
+		}
+	},
+	"securityDefinitions": {
+		"BearerAuth": {"type": "apiKey", "name": "Authorization", "in": "header"}
+	}
+}`
+
+func convertTestSwagger(t *testing.T) *Document {
+	doc, err := Convert([]byte(testSwagger))
+	require.NoError(t, err)
+	return doc
+}
+
+func TestConvert(t *testing.T) {
+	doc := convertTestSwagger(t)
+	require.Equal(t, Version, doc.OpenAPI)
+	require.Equal(t, []Server{{URL: "/"}}, doc.Servers)
+	require.Contains(t, doc.Components.Schemas, "api.channelResponse")
+	require.Equal(t, "apiKey", doc.Components.SecuritySchemes["BearerAuth"].Type)
+
+	getChannel := doc.Paths["/channels/{id}"]["get"]
+	require.Len(t, getChannel.Parameters, 2)
+	require.Equal(t, "integer", getChannel.Parameters[0].Schema.Type)
+	require.True(t, getChannel.Parameters[0].Required)
+	require.Equal(t, "header", getChannel.Parameters[1].In)
+	success := getChannel.Responses["200"].Content["application/json"].Schema
+	require.Equal(t, "#/components/schemas/api.channelResponse", success.Ref)
+	require.Equal(t, "api.channelResponse", success.RefName())
+	require.NotNil(t, getChannel.Responses["404"].Content["application/json"].Schema.AdditionalProperties)
+
+	updateChannel := doc.Paths["/channels/{id}"]["put"]
+	require.Len(t, updateChannel.Parameters, 1)
+	require.NotNil(t, updateChannel.RequestBody)
+	require.True(t, updateChannel.RequestBody.Required)
+	body := updateChannel.RequestBody.Content["application/json"].Schema
+	require.Equal(t, "#/components/schemas/api.updateChannelRequest", body.Ref)
+
+	uploadFile := doc.Paths["/files/upload"]["post"]
+	require.Empty(t, uploadFile.Parameters)
+	form := uploadFile.RequestBody.Content["multipart/form-data"].Schema
+	require.Equal(t, "object", form.Type)
+	require.ElementsMatch(t, []string{"workspace_id", "file"}, form.Required)
+	require.True(t, form.Properties["file"].IsBinary())
+	require.Equal(t, "integer", form.Properties["workspace_id"].Type)
+
+	download := doc.Paths["/files/{id}/download"]["get"].Responses["200"]
+	require.NotContains(t, download.Content, "application/json")
+	require.True(t, download.Content["application/octet-stream"].Schema.IsBinary())
+}
+
+func TestConvertInvalid(t *testing.T) {
+	_, err := Convert([]byte(`{"swagger": "2.0"}`))
+	require.Error(t, err)
+
+	_, err = Convert([]byte(`not json`))
+	require.Error(t, err)
+}
+
+func TestOperationsRequireIDs(t *testing.T) {
+	doc := convertTestSwagger(t)
+	operations, err := doc.operations()
+	require.NoError(t, err)
+	require.Len(t, operations, 4)
+	require.Equal(t, "downloadFile", operations[0].OperationID)
+
+	doc.Paths["/files/upload"]["post"].OperationID = "getChannel"
+	_, err = doc.operations()
+	require.ErrorContains(t, err, "getChannel")
+
+	doc.Paths["/files/upload"]["post"].OperationID = ""
+	_, err = doc.operations()
+	require.ErrorContains(t, err, "@ID")
+}
+
+func TestNames(t *testing.T) {
+	require.Equal(t, "WorkspaceID", exportedName("workspace_id"))
+	require.Equal(t, "MemberIDs", exportedName("member_ids"))
+	require.Equal(t, "IfNoneMatch", exportedName("If-None-Match"))
+	require.Equal(t, "GetAPIInfo", exportedName("getAPIInfo"))
+	require.Equal(t, "userID", unexportedName("user_id"))
+	require.Equal(t, "id", unexportedName("id"))
+	require.Equal(t, "uploadFile", camelName("uploadFile"))
+}
+
+func TestGenerateGo(t *testing.T) {
+	source, err := GenerateGo(convertTestSwagger(t), "client")
+	require.NoError(t, err)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "client.gen.go", source, 0)
+	require.NoError(t, err)
+	require.Equal(t, "client", file.Name.Name)
+
+	code := string(source)
+	require.Contains(t, code, "type ChannelResponse struct")
+	require.Contains(t, code, "MemberIDs []int64")
+	require.Contains(t, code, "func (c *Client) GetChannel(ctx context.Context, id int64, params *GetChannelParams) (*ChannelResponse, error)")
+	require.Contains(t, code, "func (c *Client) UpdateChannel(ctx context.Context, id int64, body UpdateChannelRequest) (*ChannelResponse, error)")
+	require.Contains(t, code, "func (c *Client) UploadFile(ctx context.Context, params UploadFileParams) (*UploadFileResponse, error)")
+	require.Contains(t, code, "func (c *Client) DownloadFile(ctx context.Context, id int64) (io.ReadCloser, error)")
+	require.Regexp(t, "Name +string +`json:\"name\"`", code)
+	require.Regexp(t, "Topic +\\*string +`json:\"topic,omitempty\"`", code)
+}
+
+func TestGenerateTypeScript(t *testing.T) {
+	source, err := GenerateTypeScript(convertTestSwagger(t))
+	require.NoError(t, err)
+
+	code := string(source)
+	require.True(t, strings.HasPrefix(code, "// Code generated by openapi-gen"))
+	require.Contains(t, code, "export interface ChannelResponse {")
+	require.Contains(t, code, "member_ids?: number[];")
+	require.Contains(t, code, "async getChannel(id: number, params?: GetChannelParams): Promise<ChannelResponse>")
+	require.Contains(t, code, `headers: { "If-None-Match": params?.["If-None-Match"] }`)
+	require.Contains(t, code, "async uploadFile(params: UploadFileParams): Promise<UploadFileResponse>")
+	require.Contains(t, code, "file: Blob;")
+	require.Contains(t, code, "async downloadFile(id: number): Promise<Blob>")
+	require.Contains(t, code, "export class GoSlackClient extends BaseClient {")
+}
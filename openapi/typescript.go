@@ -0,0 +1,321 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// identifierPattern matches property names TypeScript doesn't need quoted
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// reservedWords can't name method parameters in TypeScript
+var reservedWords = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"default": true, "delete": true, "do": true, "else": true, "enum": true, "export": true,
+	"extends": true, "false": true, "finally": true, "for": true, "function": true, "if": true,
+	"import": true, "in": true, "instanceof": true, "new": true, "null": true, "return": true,
+	"super": true, "switch": true, "this": true, "throw": true, "true": true, "try": true,
+	"typeof": true, "var": true, "void": true, "while": true, "with": true,
+}
+
+// GenerateTypeScript returns the generated part of the TypeScript client: an
+// interface for every schema and a GoSlackClient method for every operation.
+// The request runtime it extends lives next to it in runtime.ts.
+func GenerateTypeScript(doc *Document) ([]byte, error) {
+	operations, err := doc.operations()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &tsGenerator{names: typeNames(doc), types: map[string]string{}}
+	for component, schema := range doc.Components.Schemas {
+		g.addType(g.names[component], schema)
+	}
+	var methods bytes.Buffer
+	for _, op := range operations {
+		if err := g.writeMethod(&methods, op); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by openapi-gen from docs/openapi.json. DO NOT EDIT.\n\n")
+	out.WriteString("import { BaseClient } from \"./runtime\";\n\n")
+	for _, name := range sortedKeys(g.types) {
+		out.WriteString(g.types[name])
+	}
+	out.WriteString("/** GoSlackClient calls the GoSlack API, with a method for every operation */\n")
+	out.WriteString("export class GoSlackClient extends BaseClient {\n")
+	out.Write(methods.Bytes())
+	out.WriteString("}\n")
+	return out.Bytes(), nil
+}
+
+type tsGenerator struct {
+	// names maps components to type names
+	names map[string]string
+	// types holds the source of every type declaration by name
+	types map[string]string
+}
+
+// addType declares a named type for a schema
+func (g *tsGenerator) addType(name string, schema *Schema) {
+	var b strings.Builder
+	if schema.Description != "" {
+		b.WriteString(jsDoc(schema.Description, ""))
+	}
+	if schema.Type == "object" && schema.AdditionalProperties == nil {
+		fmt.Fprintf(&b, "export interface %s %s\n\n", name, g.objectType(schema, ""))
+	} else {
+		fmt.Fprintf(&b, "export type %s = %s;\n\n", name, g.tsType(schema, ""))
+	}
+	g.types[name] = b.String()
+}
+
+// objectType returns the type literal of an object's properties
+func (g *tsGenerator) objectType(schema *Schema, indent string) string {
+	if len(schema.Properties) == 0 {
+		return "{}"
+	}
+
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		if property.Description != "" {
+			b.WriteString(jsDoc(property.Description, indent+"  "))
+		}
+		optional := "?"
+		if required[name] {
+			optional = ""
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, propertyName(name), optional, g.tsType(property, indent+"  "))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// tsType returns the TypeScript type of a schema
+func (g *tsGenerator) tsType(schema *Schema, indent string) string {
+	if schema == nil {
+		return "unknown"
+	}
+	if schema.Ref != "" {
+		return g.names[schema.RefName()]
+	}
+	if len(schema.AllOf) == 1 {
+		return g.tsType(schema.AllOf[0], indent)
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.IsBinary() {
+			return "Blob"
+		}
+		if len(schema.Enum) > 0 {
+			values := make([]string, len(schema.Enum))
+			for i, value := range schema.Enum {
+				values[i] = fmt.Sprintf("%q", fmt.Sprint(value))
+			}
+			return strings.Join(values, " | ")
+		}
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		item := g.tsType(schema.Items, indent)
+		if strings.Contains(item, "|") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object":
+		if schema.AdditionalProperties != nil {
+			return "Record<string, " + g.tsType(schema.AdditionalProperties, indent) + ">"
+		}
+		if len(schema.Properties) > 0 {
+			return g.objectType(schema, indent)
+		}
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+// writeMethod writes the GoSlackClient method of an operation, and the types
+// of its parameters and response
+func (g *tsGenerator) writeMethod(b *bytes.Buffer, op operation) error {
+	success, ok := op.successResponse()
+	if !ok {
+		// Protocol switches such as the WebSocket connection aren't requests
+		return nil
+	}
+
+	name := exportedName(op.OperationID)
+	var args []string
+	path := op.Path
+	type option struct {
+		name     string
+		tsType   string
+		in       string
+		required bool
+	}
+	var options []option
+	for _, parameter := range op.Parameters {
+		if parameter.In == "path" {
+			argName := camelName(parameter.Name)
+			if reservedWords[argName] || argName == "body" || argName == "params" {
+				argName += "Param"
+			}
+			args = append(args, argName+": "+g.tsType(parameter.Schema, ""))
+			path = strings.Replace(path, "{"+parameter.Name+"}", "${encodeURIComponent(String("+argName+"))}", 1)
+			continue
+		}
+		options = append(options, option{
+			name:     parameter.Name,
+			tsType:   g.tsType(parameter.Schema, ""),
+			in:       parameter.In,
+			required: parameter.Required,
+		})
+	}
+
+	body := op.jsonBody()
+	if body != nil {
+		args = append(args, "body: "+g.tsType(body, "  "))
+	}
+	if form := op.formBody(); form != nil {
+		required := map[string]bool{}
+		for _, field := range form.Required {
+			required[field] = true
+		}
+		for _, field := range sortedKeys(form.Properties) {
+			options = append(options, option{
+				name:     field,
+				tsType:   g.tsType(form.Properties[field], ""),
+				in:       "formData",
+				required: required[field],
+			})
+		}
+	}
+
+	paramsRequired := false
+	if len(options) > 0 {
+		paramsType := name + "Params"
+		if _, exists := g.types[paramsType]; exists {
+			return fmt.Errorf("the parameters of %s are named like the schema %s", op.OperationID, paramsType)
+		}
+		var params strings.Builder
+		fmt.Fprintf(&params, "/** %s holds the parameters of %s */\nexport interface %s {\n", paramsType, camelName(op.OperationID), paramsType)
+		for _, option := range options {
+			optional := "?"
+			if option.required {
+				optional = ""
+				paramsRequired = true
+			}
+			fmt.Fprintf(&params, "  %s%s: %s;\n", propertyName(option.name), optional, option.tsType)
+		}
+		params.WriteString("}\n\n")
+		g.types[paramsType] = params.String()
+
+		if paramsRequired {
+			args = append(args, "params: "+paramsType)
+		} else {
+			args = append(args, "params?: "+paramsType)
+		}
+	}
+
+	// The result is the success response's schema, named after the
+	// operation when the annotation describes it inline
+	result := "void"
+	responseType := "none"
+	switch {
+	case success == nil:
+	case success.IsBinary():
+		result, responseType = "Blob", "blob"
+	case success.Type == "object" && len(success.Properties) > 0 && success.Ref == "":
+		result, responseType = name+"Response", "json"
+		if _, exists := g.types[result]; exists {
+			return fmt.Errorf("the response of %s is named like the schema %s", op.OperationID, result)
+		}
+		g.types[result] = fmt.Sprintf("/** %s is the response of %s */\nexport interface %s %s\n\n", result, camelName(op.OperationID), result, g.objectType(success, ""))
+	default:
+		result, responseType = g.tsType(success, "  "), "json"
+	}
+
+	doc := op.Summary
+	if op.Description != "" {
+		doc += "\n\n" + op.Description
+	}
+	doc += "\n\n" + op.Method + " " + op.Path
+	if op.Deprecated {
+		doc += "\n\n@deprecated"
+	}
+	b.WriteString(jsDoc(doc, "  "))
+
+	fmt.Fprintf(b, "  async %s(%s): Promise<%s> {\n", camelName(op.OperationID), strings.Join(args, ", "), result)
+	fmt.Fprintf(b, "    return this.request<%s>({\n", result)
+	fmt.Fprintf(b, "      method: %q,\n", op.Method)
+	fmt.Fprintf(b, "      path: `%s`,\n", path)
+	for _, in := range []string{"query", "header", "formData"} {
+		var fields []string
+		for _, option := range options {
+			if option.in == in {
+				// params?.limit, or params?.["If-None-Match"]
+				access := "params"
+				if !paramsRequired {
+					access += "?."
+				} else if identifierPattern.MatchString(option.name) {
+					access += "."
+				}
+				if identifierPattern.MatchString(option.name) {
+					access += option.name
+				} else {
+					access += fmt.Sprintf("[%q]", option.name)
+				}
+				fields = append(fields, fmt.Sprintf("%s: %s", propertyName(option.name), access))
+			}
+		}
+		if len(fields) > 0 {
+			key := map[string]string{"query": "query", "header": "headers", "formData": "form"}[in]
+			fmt.Fprintf(b, "      %s: { %s },\n", key, strings.Join(fields, ", "))
+		}
+	}
+	if body != nil {
+		b.WriteString("      body,\n")
+	}
+	fmt.Fprintf(b, "      responseType: %q,\n", responseType)
+	b.WriteString("    });\n  }\n\n")
+	return nil
+}
+
+// propertyName returns a property name as TypeScript needs it written
+func propertyName(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// jsDoc returns text as a documentation comment
+func jsDoc(text, indent string) string {
+	text = strings.ReplaceAll(strings.TrimSpace(text), "*/", "*\\/")
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		return indent + "/** " + lines[0] + " */\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		b.WriteString(strings.TrimRight(indent+" * "+strings.TrimSpace(line), " ") + "\n")
+	}
+	b.WriteString(indent + " */\n")
+	return b.String()
+}
@@ -0,0 +1,173 @@
+// Package publicid turns sequential database IDs into opaque, prefixed public
+// IDs such as ch_3NQ8T0XKZ6V1M, so clients can't tell how many rows exist or
+// guess the IDs of other users' records. IDs are encrypted with a keyed
+// permutation rather than stored, so the database keeps its bigint keys and a
+// public ID always maps back to the same row as long as the key is unchanged.
+package publicid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"sync/atomic"
+)
+
+// Kind is the type of record a public ID refers to, used as its prefix
+type Kind string
+
+const (
+	Workspace Kind = "ws"
+	Channel   Kind = "ch"
+	Message   Kind = "msg"
+	User      Kind = "usr"
+	File      Kind = "file"
+)
+
+// kinds lists every prefix Parse accepts
+var kinds = []Kind{Workspace, Channel, Message, User, File}
+
+// ErrInvalid is returned for strings that aren't public IDs
+var ErrInvalid = errors.New("invalid public ID")
+
+const (
+	// Crockford's base32, as used by ULIDs, which leaves out I, L, O and U
+	alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// 13 base32 characters hold the 64 bit ID, the first one only its top 4 bits
+	encodedLength = 13
+	rounds        = 4
+)
+
+// Codec encodes and decodes public IDs with a secret key
+type Codec struct {
+	key []byte
+}
+
+// NewCodec creates a codec. Changing the key changes every public ID, so it
+// has to stay the same for IDs held by clients to keep working.
+func NewCodec(key string) *Codec {
+	return &Codec{key: []byte(key)}
+}
+
+// Encode returns the public ID of a record
+func (c *Codec) Encode(kind Kind, id int64) string {
+	value := c.permute(kind, uint64(id), false)
+
+	var encoded [encodedLength]byte
+	for i := encodedLength - 1; i >= 0; i-- {
+		encoded[i] = alphabet[value&31]
+		value >>= 5
+	}
+
+	return string(kind) + "_" + string(encoded[:])
+}
+
+// Decode returns the database ID of a public ID of the given kind
+func (c *Codec) Decode(kind Kind, publicID string) (int64, error) {
+	encoded, ok := strings.CutPrefix(publicID, string(kind)+"_")
+	if !ok || len(encoded) != encodedLength {
+		return 0, ErrInvalid
+	}
+
+	var value uint64
+	for i := 0; i < encodedLength; i++ {
+		digit := strings.IndexByte(alphabet, upper(encoded[i]))
+		if digit < 0 {
+			return 0, ErrInvalid
+		}
+		// The first character only carries the top 4 bits
+		if i == 0 && digit > 15 {
+			return 0, ErrInvalid
+		}
+		value = value<<5 | uint64(digit)
+	}
+
+	id := int64(c.permute(kind, value, true))
+	if id <= 0 {
+		return 0, ErrInvalid
+	}
+	return id, nil
+}
+
+// Parse decodes a public ID of any kind, returning its kind
+func (c *Codec) Parse(publicID string) (Kind, int64, error) {
+	prefix, _, found := strings.Cut(publicID, "_")
+	if !found {
+		return "", 0, ErrInvalid
+	}
+
+	for _, kind := range kinds {
+		if string(kind) == prefix {
+			id, err := c.Decode(kind, publicID)
+			return kind, id, err
+		}
+	}
+	return "", 0, ErrInvalid
+}
+
+// permute runs a Feistel network over the two 32 bit halves of value, with
+// round keys derived from the codec key and the kind so that the same number
+// has unrelated public IDs for different kinds
+func (c *Codec) permute(kind Kind, value uint64, inverse bool) uint64 {
+	left, right := uint32(value>>32), uint32(value)
+
+	for i := 0; i < rounds; i++ {
+		round := i
+		if inverse {
+			round = rounds - 1 - i
+			left, right = right^c.round(kind, round, left), left
+		} else {
+			left, right = right, left^c.round(kind, round, right)
+		}
+	}
+
+	return uint64(left)<<32 | uint64(right)
+}
+
+// round is the Feistel round function
+func (c *Codec) round(kind Kind, round int, half uint32) uint32 {
+	mac := hmac.New(sha256.New, c.key)
+	var input [5]byte
+	input[0] = byte(round)
+	binary.BigEndian.PutUint32(input[1:], half)
+	mac.Write([]byte(kind))
+	mac.Write(input[:])
+	return binary.BigEndian.Uint32(mac.Sum(nil))
+}
+
+// upper accepts lowercase characters in public IDs typed by hand
+func upper(b byte) byte {
+	if b >= 'a' && b <= 'z' {
+		return b - 'a' + 'A'
+	}
+	return b
+}
+
+// defaultCodec is used by API responses, which are encoded without access to
+// the server configuration
+var defaultCodec atomic.Pointer[Codec]
+
+func init() {
+	defaultCodec.Store(NewCodec(""))
+}
+
+// SetKey sets the key used by Encode, Decode and Parse
+func SetKey(key string) {
+	defaultCodec.Store(NewCodec(key))
+}
+
+// Encode returns the public ID of a record using the configured key
+func Encode(kind Kind, id int64) string {
+	return defaultCodec.Load().Encode(kind, id)
+}
+
+// Decode returns the database ID of a public ID using the configured key
+func Decode(kind Kind, publicID string) (int64, error) {
+	return defaultCodec.Load().Decode(kind, publicID)
+}
+
+// Parse decodes a public ID of any kind using the configured key
+func Parse(publicID string) (Kind, int64, error) {
+	return defaultCodec.Load().Parse(publicID)
+}
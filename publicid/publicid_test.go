@@ -0,0 +1,66 @@
+package publicid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	codec := NewCodec(util.RandomString(32))
+
+	for _, kind := range kinds {
+		for _, id := range []int64{1, 2, util.RandomInt(1, 1000000), 1<<63 - 1} {
+			publicID := codec.Encode(kind, id)
+			require.True(t, strings.HasPrefix(publicID, string(kind)+"_"))
+			require.Len(t, publicID, len(kind)+1+encodedLength)
+
+			decoded, err := codec.Decode(kind, publicID)
+			require.NoError(t, err)
+			require.Equal(t, id, decoded)
+
+			// Prefixes are lowercase already, so this only changes the encoded part
+			parsedKind, parsed, err := codec.Parse(strings.ToLower(publicID))
+			require.NoError(t, err)
+			require.Equal(t, kind, parsedKind)
+			require.Equal(t, id, parsed)
+		}
+	}
+}
+
+func TestCodecHidesSequence(t *testing.T) {
+	codec := NewCodec(util.RandomString(32))
+
+	first := codec.Encode(Message, 1000)
+	second := codec.Encode(Message, 1001)
+	require.NotEqual(t, first[:len(first)-1], second[:len(second)-1])
+
+	// The same number has unrelated IDs for different kinds and keys
+	require.NotEqual(t, first[len("msg_"):], codec.Encode(Channel, 1000)[len("ch_"):])
+	require.NotEqual(t, first, NewCodec(util.RandomString(32)).Encode(Message, 1000))
+}
+
+func TestCodecRejectsInvalidIDs(t *testing.T) {
+	codec := NewCodec(util.RandomString(32))
+	channelID := codec.Encode(Channel, 42)
+
+	invalid := []string{
+		"",
+		"42",
+		"ch_",
+		"ch_123",
+		"ch_" + strings.Repeat("U", encodedLength), // U isn't in the alphabet
+		"ch_" + strings.Repeat("Z", encodedLength), // Overflows 64 bits
+		"xx_" + channelID[len("ch_"):],
+	}
+	for _, publicID := range invalid {
+		_, _, err := codec.Parse(publicID)
+		require.ErrorIs(t, err, ErrInvalid, publicID)
+	}
+
+	// A channel ID isn't accepted as another kind
+	_, err := codec.Decode(Workspace, channelID)
+	require.ErrorIs(t, err, ErrInvalid)
+}
@@ -0,0 +1,103 @@
+package seed
+
+// Name and content pools for the generated data. They are combined at random,
+// so larger demos repeat them in new combinations.
+
+var firstNames = []string{
+	"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken",
+	"Radia", "Guido", "Frances", "Bjarne", "Hedy", "Tim", "Katherine", "Rob",
+	"Sophie", "James", "Anita", "Donald", "Joan", "Edsger", "Shafi", "Niklaus",
+}
+
+var lastNames = []string{
+	"Lovelace", "Turing", "Hopper", "Torvalds", "Hamilton", "Ritchie", "Liskov",
+	"Thompson", "Perlman", "van Rossum", "Allen", "Stroustrup", "Lamarr",
+	"Berners-Lee", "Johnson", "Pike", "Wilson", "Gosling", "Borg", "Knuth",
+	"Clarke", "Dijkstra", "Goldwasser", "Wirth",
+}
+
+var workspaceNames = []string{
+	"Engineering", "Product", "Customer Success", "Marketing", "Operations",
+	"Design", "Sales", "Research",
+}
+
+// channelNames start with the channels every workspace gets
+var channelNames = []string{
+	"general", "random", "announcements", "engineering", "design", "product",
+	"support", "marketing", "incidents", "releases", "hiring", "social",
+	"frontend", "backend", "infrastructure", "data",
+}
+
+// privateChannelNames are used for the private channels of a workspace
+var privateChannelNames = []string{
+	"leadership", "security", "budget-planning", "offsite-planning", "hiring-committee",
+}
+
+var channelMessages = []string{
+	"Good morning everyone!",
+	"Has anyone looked at the latest build failures?",
+	"I just pushed a fix for the login redirect bug.",
+	"Reminder: standup is in 10 minutes.",
+	"Can someone review my PR when they get a chance?",
+	"The staging deploy is done, please give it a spin.",
+	"Great work on the release yesterday, everyone 🎉",
+	"I'll be out this afternoon for a dentist appointment.",
+	"Does anyone know who owns the billing service?",
+	"Heads up: the database migration will run tonight at 22:00 UTC.",
+	"I updated the onboarding doc with the new setup steps.",
+	"Lunch at the usual place?",
+	"The customer reported the export is slow again, looking into it.",
+	"Dashboard numbers look good this week.",
+	"Let's sync on the roadmap tomorrow.",
+	"We hit 99.98% uptime last month!",
+	"Quick poll: tabs or spaces?",
+	"Design mocks for the new settings page are in the shared folder.",
+	"Retro notes are up, thanks for the great discussion.",
+	"Who's up for a coffee break?",
+	"The flaky test is finally fixed.",
+	"Any objections to upgrading to the new Go version?",
+	"I'm seeing elevated error rates on the API, investigating.",
+	"False alarm, it was a misconfigured health check.",
+	"Welcome to the team! Let us know if you need anything.",
+}
+
+var threadReplies = []string{
+	"Thanks, looking now.",
+	"+1",
+	"I can take this one.",
+	"Good catch!",
+	"Agreed, let's do it.",
+	"Could you share more details?",
+	"Done ✅",
+	"I think we discussed this last week, let me find the notes.",
+	"Sounds good to me.",
+	"Let's take this offline.",
+	"Nice!",
+	"I'll follow up after lunch.",
+}
+
+var directMessages = []string{
+	"Hey, do you have a minute?",
+	"Thanks for the help earlier!",
+	"Can you send me the link to the doc?",
+	"Running 5 minutes late to our 1:1.",
+	"Did you get a chance to look at my comments?",
+	"Sure, happy to pair on it this afternoon.",
+	"Let me know when you're free.",
+	"That makes sense, thanks for explaining.",
+	"I moved our meeting to Thursday.",
+	"Congrats on the launch!",
+}
+
+// demoFiles are the text files shared in each workspace
+var demoFiles = []struct {
+	name    string
+	content string
+}{
+	{"meeting-notes.txt", "Weekly sync\n\n- Release is on track for Friday\n- Support backlog is down 20%\n- Next offsite planning starts next week\n"},
+	{"roadmap.txt", "Q1: Search improvements\nQ2: Mobile apps\nQ3: Enterprise SSO\nQ4: Analytics\n"},
+	{"onboarding-checklist.txt", "1. Set up your laptop\n2. Join #general and #random\n3. Read the engineering handbook\n4. Ship your first change\n"},
+	{"incident-report.txt", "Summary: elevated API latency for 12 minutes.\nCause: connection pool exhaustion.\nFix: raised pool size and added alerting.\n"},
+	{"release-checklist.txt", "- Changelog updated\n- Migrations reviewed\n- Staging verified\n- Announce in #releases\n"},
+	{"style-guide.txt", "Write clearly, keep messages short and link to the source of truth.\n"},
+}
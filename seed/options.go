@@ -0,0 +1,134 @@
+package seed
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Options controls the size and shape of the generated demo data
+type Options struct {
+	Organization         string
+	Workspaces           int
+	UsersPerWorkspace    int
+	ChannelsPerWorkspace int
+	MessagesPerChannel   int
+	DirectMessages       int // Per workspace
+	FilesPerWorkspace    int
+	ThreadRatio          float64 // Share of channel messages that get thread replies
+	History              time.Duration
+	Password             string
+	RandomSeed           int64 // 0 picks a random seed
+}
+
+// Presets are the named sizes for the --size flag
+var Presets = map[string]Options{
+	"small": {
+		Workspaces:           1,
+		UsersPerWorkspace:    5,
+		ChannelsPerWorkspace: 3,
+		MessagesPerChannel:   25,
+		DirectMessages:       10,
+		FilesPerWorkspace:    2,
+	},
+	"medium": {
+		Workspaces:           2,
+		UsersPerWorkspace:    12,
+		ChannelsPerWorkspace: 6,
+		MessagesPerChannel:   150,
+		DirectMessages:       60,
+		FilesPerWorkspace:    6,
+	},
+	"large": {
+		Workspaces:           4,
+		UsersPerWorkspace:    40,
+		ChannelsPerWorkspace: 10,
+		MessagesPerChannel:   1000,
+		DirectMessages:       400,
+		FilesPerWorkspace:    20,
+	},
+}
+
+// ParseFlags parses the arguments of the seed command. A size preset sets the
+// defaults and individual flags override it.
+func ParseFlags(args []string, output io.Writer) (Options, error) {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(output)
+
+	size := flags.String("size", "small", "demo size preset: small, medium or large")
+	organization := flags.String("org", "Acme Corp", "name of the demo organization")
+	workspaces := flags.Int("workspaces", 0, "number of workspaces (overrides the preset)")
+	users := flags.Int("users", 0, "users per workspace (overrides the preset)")
+	channels := flags.Int("channels", 0, "channels per workspace (overrides the preset)")
+	messages := flags.Int("messages", 0, "messages per channel (overrides the preset)")
+	directMessages := flags.Int("dms", 0, "direct messages per workspace (overrides the preset)")
+	files := flags.Int("files", 0, "files per workspace (overrides the preset)")
+	threadRatio := flags.Float64("thread-ratio", 0.15, "share of channel messages with thread replies")
+	history := flags.Duration("history", 14*24*time.Hour, "how far back the message history goes")
+	password := flags.String("password", "password123", "password of every demo user")
+	randomSeed := flags.Int64("random-seed", 0, "seed for reproducible data, 0 picks one")
+
+	if err := flags.Parse(args); err != nil {
+		return Options{}, err
+	}
+
+	opts, ok := Presets[*size]
+	if !ok {
+		return Options{}, fmt.Errorf("unknown size %q", *size)
+	}
+
+	opts.Organization = *organization
+	opts.ThreadRatio = *threadRatio
+	opts.History = *history
+	opts.Password = *password
+	opts.RandomSeed = *randomSeed
+
+	overrides := map[string]*int{
+		"workspaces": &opts.Workspaces,
+		"users":      &opts.UsersPerWorkspace,
+		"channels":   &opts.ChannelsPerWorkspace,
+		"messages":   &opts.MessagesPerChannel,
+		"dms":        &opts.DirectMessages,
+		"files":      &opts.FilesPerWorkspace,
+	}
+	values := map[string]int{
+		"workspaces": *workspaces,
+		"users":      *users,
+		"channels":   *channels,
+		"messages":   *messages,
+		"dms":        *directMessages,
+		"files":      *files,
+	}
+	flags.Visit(func(f *flag.Flag) {
+		if target, ok := overrides[f.Name]; ok {
+			*target = values[f.Name]
+		}
+	})
+
+	return opts, opts.Validate()
+}
+
+// Validate checks that the options describe data that can be generated
+func (opts Options) Validate() error {
+	switch {
+	case opts.Organization == "":
+		return errors.New("organization name is required")
+	case opts.Workspaces < 1:
+		return errors.New("at least one workspace is required")
+	case opts.UsersPerWorkspace < 2:
+		return errors.New("at least two users per workspace are required")
+	case opts.ChannelsPerWorkspace < 1:
+		return errors.New("at least one channel per workspace is required")
+	case opts.MessagesPerChannel < 0, opts.DirectMessages < 0, opts.FilesPerWorkspace < 0:
+		return errors.New("message and file counts cannot be negative")
+	case opts.ThreadRatio < 0 || opts.ThreadRatio > 1:
+		return errors.New("thread ratio must be between 0 and 1")
+	case opts.History <= 0:
+		return errors.New("history must be positive")
+	case len(opts.Password) < 6:
+		return errors.New("password must be at least 6 characters")
+	}
+	return nil
+}
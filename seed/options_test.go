@@ -0,0 +1,84 @@
+package seed
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFlags(t *testing.T) {
+	testCases := []struct {
+		name          string
+		args          []string
+		checkOptions  func(t *testing.T, opts Options)
+		expectedError bool
+	}{
+		{
+			name: "Defaults",
+			args: []string{},
+			checkOptions: func(t *testing.T, opts Options) {
+				require.Equal(t, "Acme Corp", opts.Organization)
+				require.Equal(t, Presets["small"].Workspaces, opts.Workspaces)
+				require.Equal(t, Presets["small"].MessagesPerChannel, opts.MessagesPerChannel)
+				require.Equal(t, 14*24*time.Hour, opts.History)
+			},
+		},
+		{
+			name: "PresetWithOverride",
+			args: []string{"-size", "large", "-messages", "10", "-files", "0", "-org", "Globex"},
+			checkOptions: func(t *testing.T, opts Options) {
+				require.Equal(t, "Globex", opts.Organization)
+				require.Equal(t, Presets["large"].Workspaces, opts.Workspaces)
+				require.Equal(t, Presets["large"].UsersPerWorkspace, opts.UsersPerWorkspace)
+				require.Equal(t, 10, opts.MessagesPerChannel)
+				require.Zero(t, opts.FilesPerWorkspace)
+			},
+		},
+		{
+			name:          "UnknownSize",
+			args:          []string{"-size", "huge"},
+			expectedError: true,
+		},
+		{
+			name:          "TooFewUsers",
+			args:          []string{"-users", "1"},
+			expectedError: true,
+		},
+		{
+			name:          "InvalidThreadRatio",
+			args:          []string{"-thread-ratio", "1.5"},
+			expectedError: true,
+		},
+		{
+			name:          "UnknownFlag",
+			args:          []string{"-reactions", "10"},
+			expectedError: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := ParseFlags(tc.args, io.Discard)
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tc.checkOptions(t, opts)
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	require.Equal(t, "acme-corp", slug("Acme Corp"))
+	require.Equal(t, "van-rossum", slug("van Rossum"))
+	require.Equal(t, "berners-lee", slug(" Berners--Lee "))
+	require.Equal(t, "demo", emailLabel("株式会社"))
+
+	require.Equal(t, "general", uniqueName(channelNames, 0))
+	require.Equal(t, "general-2", uniqueName(channelNames, len(channelNames)))
+}
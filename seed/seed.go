@@ -0,0 +1,485 @@
+// Package seed provisions demo data for local development and demo environments.
+package seed
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Summary describes the generated demo data
+type Summary struct {
+	OrganizationID int64
+	Workspaces     []WorkspaceSummary
+	Password       string
+	Users          int
+	Channels       int
+	Messages       int
+	ThreadReplies  int
+	DirectMessages int
+	Files          int
+}
+
+// WorkspaceSummary describes a generated workspace and its admin account
+type WorkspaceSummary struct {
+	ID         int64
+	Name       string
+	AdminEmail string
+}
+
+// seeder generates the demo data of one run within a transaction
+type seeder struct {
+	q           *db.Queries
+	rand        *rand.Rand
+	opts        Options
+	storagePath string
+	now         time.Time
+
+	hashedPassword string
+	emailDomain    string
+
+	summary      *Summary
+	writtenFiles []string
+}
+
+// Run provisions a demo organization in a single transaction. Demo files are
+// written to storagePath and removed again if the transaction fails.
+func Run(ctx context.Context, conn *sql.DB, storagePath string, opts Options) (*Summary, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	randomSeed := opts.RandomSeed
+	if randomSeed == 0 {
+		randomSeed = time.Now().UnixNano()
+	}
+
+	hashedPassword, err := util.HashPassword(opts.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &seeder{
+		q:              db.New(tx),
+		rand:           rand.New(rand.NewSource(randomSeed)),
+		opts:           opts,
+		storagePath:    storagePath,
+		now:            time.Now(),
+		hashedPassword: hashedPassword,
+		// Emails are unique across organizations, so every run gets its own domain
+		emailDomain: fmt.Sprintf("%s-%s.example.com", emailLabel(opts.Organization), util.RandomString(4)),
+		summary:     &Summary{Password: opts.Password},
+	}
+
+	if err := s.seedOrganization(ctx); err != nil {
+		s.removeWrittenFiles()
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return nil, fmt.Errorf("seed err: %v, rb err: %v", err, rbErr)
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		s.removeWrittenFiles()
+		return nil, fmt.Errorf("failed to commit demo data: %w", err)
+	}
+
+	return s.summary, nil
+}
+
+func (s *seeder) seedOrganization(ctx context.Context) error {
+	organization, err := s.q.CreateOrganization(ctx, s.opts.Organization)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+	s.summary.OrganizationID = organization.ID
+
+	for i := 0; i < s.opts.Workspaces; i++ {
+		name := workspaceNames[i%len(workspaceNames)]
+		if i >= len(workspaceNames) {
+			name = fmt.Sprintf("%s %d", name, i/len(workspaceNames)+1)
+		}
+
+		if err := s.seedWorkspace(ctx, organization.ID, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *seeder) seedWorkspace(ctx context.Context, organizationID int64, name string) error {
+	workspace, err := s.q.CreateWorkspace(ctx, db.CreateWorkspaceParams{
+		OrganizationID: organizationID,
+		Name:           name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+
+	users, err := s.createUsers(ctx, organizationID, workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	// The first workspace's admin owns the organization
+	err = s.q.EnsureOrganizationOwner(ctx, db.EnsureOrganizationOwnerParams{
+		OrganizationID: organizationID,
+		UserID:         users[0].ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set organization owner: %w", err)
+	}
+	s.summary.Workspaces = append(s.summary.Workspaces, WorkspaceSummary{
+		ID:         workspace.ID,
+		Name:       workspace.Name,
+		AdminEmail: users[0].Email,
+	})
+
+	channels, err := s.createChannels(ctx, workspace.ID, users)
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range channels {
+		if err := s.createChannelHistory(ctx, workspace.ID, channel); err != nil {
+			return err
+		}
+	}
+
+	if err := s.createDirectMessages(ctx, workspace.ID, users); err != nil {
+		return err
+	}
+
+	// Files are shared in #general, the first channel
+	return s.createFiles(ctx, workspace.ID, channels[0])
+}
+
+// createUsers creates the workspace's users. The first user is the workspace admin.
+func (s *seeder) createUsers(ctx context.Context, organizationID, workspaceID int64) ([]db.User, error) {
+	users := make([]db.User, 0, s.opts.UsersPerWorkspace)
+
+	for i := 0; i < s.opts.UsersPerWorkspace; i++ {
+		n := s.summary.Users
+		firstName := firstNames[n%len(firstNames)]
+		lastName := lastNames[(n/len(firstNames)+n)%len(lastNames)]
+
+		user, err := s.q.CreateUser(ctx, db.CreateUserParams{
+			OrganizationID: organizationID,
+			Email:          fmt.Sprintf("%s.%s%d@%s", slug(firstName), slug(lastName), n+1, s.emailDomain),
+			FirstName:      firstName,
+			LastName:       lastName,
+			HashedPassword: s.hashedPassword,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+
+		role := "member"
+		if i == 0 {
+			role = "admin"
+		}
+		user, err = s.q.AddUserToWorkspace(ctx, db.AddUserToWorkspaceParams{
+			ID:          user.ID,
+			WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true},
+			Role:        role,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add user to workspace: %w", err)
+		}
+
+		users = append(users, user)
+		s.summary.Users++
+	}
+
+	return users, nil
+}
+
+// seededChannel is a generated channel with its members
+type seededChannel struct {
+	channel db.Channel
+	members []db.User
+}
+
+// createChannels creates the workspace's channels. Every fourth channel is
+// private with a random subset of the users as members.
+func (s *seeder) createChannels(ctx context.Context, workspaceID int64, users []db.User) ([]seededChannel, error) {
+	admin := users[0]
+	channels := make([]seededChannel, 0, s.opts.ChannelsPerWorkspace)
+	publicCount, privateCount := 0, 0
+
+	for i := 0; i < s.opts.ChannelsPerWorkspace; i++ {
+		isPrivate := i%4 == 3
+
+		var name string
+		members := users
+		if isPrivate {
+			name = uniqueName(privateChannelNames, privateCount)
+			privateCount++
+			members = s.pickMembers(users)
+		} else {
+			name = uniqueName(channelNames, publicCount)
+			publicCount++
+		}
+
+		channel, err := s.q.CreateChannel(ctx, db.CreateChannelParams{
+			WorkspaceID: workspaceID,
+			Name:        name,
+			IsPrivate:   isPrivate,
+			CreatedBy:   admin.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create channel: %w", err)
+		}
+
+		for _, member := range members {
+			role := "member"
+			if member.ID == admin.ID {
+				role = "admin"
+			}
+			_, err := s.q.AddChannelMember(ctx, db.AddChannelMemberParams{
+				ChannelID: channel.ID,
+				UserID:    member.ID,
+				AddedBy:   admin.ID,
+				Role:      role,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to add channel member: %w", err)
+			}
+		}
+
+		channels = append(channels, seededChannel{channel: channel, members: members})
+		s.summary.Channels++
+	}
+
+	return channels, nil
+}
+
+// pickMembers picks the admin and about half of the other users
+func (s *seeder) pickMembers(users []db.User) []db.User {
+	members := []db.User{users[0]}
+	for _, user := range users[1:] {
+		if s.rand.Intn(2) == 0 {
+			members = append(members, user)
+		}
+	}
+	if len(members) == 1 {
+		members = append(members, users[1])
+	}
+	return members
+}
+
+// createChannelHistory posts the channel's messages spread over the history
+// window, with thread replies on some of them
+func (s *seeder) createChannelHistory(ctx context.Context, workspaceID int64, channel seededChannel) error {
+	for _, createdAt := range s.timestamps(s.opts.MessagesPerChannel) {
+		sender := channel.members[s.rand.Intn(len(channel.members))]
+
+		message, err := s.q.CreateMessageAt(ctx, db.CreateMessageAtParams{
+			WorkspaceID: workspaceID,
+			ChannelID:   sql.NullInt64{Int64: channel.channel.ID, Valid: true},
+			SenderID:    sender.ID,
+			Content:     channelMessages[s.rand.Intn(len(channelMessages))],
+			ContentType: "text",
+			MessageType: "channel",
+			CreatedAt:   createdAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create channel message: %w", err)
+		}
+		s.summary.Messages++
+
+		if s.rand.Float64() >= s.opts.ThreadRatio {
+			continue
+		}
+
+		replyAt := createdAt
+		for r := 1 + s.rand.Intn(4); r > 0; r-- {
+			replyAt = replyAt.Add(time.Duration(1+s.rand.Intn(30)) * time.Minute)
+			if replyAt.After(s.now) {
+				break
+			}
+
+			replier := channel.members[s.rand.Intn(len(channel.members))]
+			_, err := s.q.CreateMessageAt(ctx, db.CreateMessageAtParams{
+				WorkspaceID: workspaceID,
+				ChannelID:   sql.NullInt64{Int64: channel.channel.ID, Valid: true},
+				SenderID:    replier.ID,
+				Content:     threadReplies[s.rand.Intn(len(threadReplies))],
+				ContentType: "text",
+				MessageType: "channel",
+				ThreadID:    sql.NullInt64{Int64: message.ID, Valid: true},
+				CreatedAt:   replyAt,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create thread reply: %w", err)
+			}
+			s.summary.ThreadReplies++
+		}
+	}
+
+	return nil
+}
+
+// createDirectMessages sends direct messages between random pairs of users
+func (s *seeder) createDirectMessages(ctx context.Context, workspaceID int64, users []db.User) error {
+	for _, createdAt := range s.timestamps(s.opts.DirectMessages) {
+		sender := users[s.rand.Intn(len(users))]
+		receiver := users[s.rand.Intn(len(users))]
+		for receiver.ID == sender.ID {
+			receiver = users[s.rand.Intn(len(users))]
+		}
+
+		_, err := s.q.CreateMessageAt(ctx, db.CreateMessageAtParams{
+			WorkspaceID: workspaceID,
+			SenderID:    sender.ID,
+			ReceiverID:  sql.NullInt64{Int64: receiver.ID, Valid: true},
+			Content:     directMessages[s.rand.Intn(len(directMessages))],
+			ContentType: "text",
+			MessageType: "direct",
+			CreatedAt:   createdAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create direct message: %w", err)
+		}
+		s.summary.DirectMessages++
+	}
+
+	return nil
+}
+
+// createFiles stores demo text files and shares each one in a channel message
+func (s *seeder) createFiles(ctx context.Context, workspaceID int64, channel seededChannel) error {
+	if s.opts.FilesPerWorkspace == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.storagePath, 0755); err != nil {
+		return fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	for i, createdAt := range s.timestamps(s.opts.FilesPerWorkspace) {
+		demoFile := demoFiles[i%len(demoFiles)]
+		uploader := channel.members[s.rand.Intn(len(channel.members))]
+
+		// Vary the content so deduplication keeps every file
+		content := fmt.Sprintf("%s\n(%s, copy %d)\n", demoFile.content, s.emailDomain, i+1)
+		hash := sha256.Sum256([]byte(content))
+
+		ext := filepath.Ext(demoFile.name)
+		storedFilename := fmt.Sprintf("%s_%s%s", strings.TrimSuffix(demoFile.name, ext), uuid.New().String(), ext)
+		filePath := filepath.Join(s.storagePath, storedFilename)
+
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write demo file: %w", err)
+		}
+		s.writtenFiles = append(s.writtenFiles, filePath)
+
+		file, err := s.q.CreateFile(ctx, db.CreateFileParams{
+			WorkspaceID:      workspaceID,
+			UploaderID:       uploader.ID,
+			OriginalFilename: demoFile.name,
+			StoredFilename:   storedFilename,
+			FilePath:         filePath,
+			FileSize:         int64(len(content)),
+			MimeType:         "text/plain",
+			FileHash:         hex.EncodeToString(hash[:]),
+			IsPublic:         false,
+			UploadCompleted:  true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create file record: %w", err)
+		}
+
+		message, err := s.q.CreateMessageAt(ctx, db.CreateMessageAtParams{
+			WorkspaceID: workspaceID,
+			ChannelID:   sql.NullInt64{Int64: channel.channel.ID, Valid: true},
+			SenderID:    uploader.ID,
+			Content:     fmt.Sprintf("Sharing %s", demoFile.name),
+			ContentType: "file",
+			MessageType: "channel",
+			CreatedAt:   createdAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create file message: %w", err)
+		}
+
+		_, err = s.q.CreateMessageFile(ctx, db.CreateMessageFileParams{
+			MessageID: message.ID,
+			FileID:    file.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to link file to message: %w", err)
+		}
+		s.summary.Files++
+	}
+
+	return nil
+}
+
+// timestamps returns n sorted random times within the history window
+func (s *seeder) timestamps(n int) []time.Time {
+	start := s.now.Add(-s.opts.History)
+	times := make([]time.Time, n)
+	for i := range times {
+		times[i] = start.Add(time.Duration(s.rand.Int63n(int64(s.opts.History))))
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
+
+func (s *seeder) removeWrittenFiles() {
+	for _, path := range s.writtenFiles {
+		os.Remove(path)
+	}
+}
+
+// uniqueName picks the nth name from a pool, numbering repeats once the pool runs out
+func uniqueName(pool []string, n int) string {
+	name := pool[n%len(pool)]
+	if n >= len(pool) {
+		name = fmt.Sprintf("%s-%d", name, n/len(pool)+1)
+	}
+	return name
+}
+
+// emailLabel turns the organization name into an email domain label
+func emailLabel(organization string) string {
+	if label := slug(organization); label != "" {
+		return label
+	}
+	return "demo"
+}
+
+// slug lowercases a name and keeps only letters, digits and dashes
+func slug(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-':
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+				b.WriteRune('-')
+			}
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Abuse report statuses
+const (
+	AbuseReportStatusOpen      = "open"
+	AbuseReportStatusResolved  = "resolved"
+	AbuseReportStatusDismissed = "dismissed"
+)
+
+// abuseReportServiceStore covers reports, and the messages, files and members they are about
+type abuseReportServiceStore interface {
+	db.FileStore
+	db.MessageStore
+	db.ModerationStore
+	db.WorkspaceStore
+}
+
+// AbuseReportService handles members' reports of abusive messages, files and
+// users and their review by workspace admins
+type AbuseReportService struct {
+	store          abuseReportServiceStore
+	userService    *UserService
+	messageService *MessageService
+	hub            WebSocketHub
+}
+
+// NewAbuseReportService creates a new abuse report service
+func NewAbuseReportService(store abuseReportServiceStore, userService *UserService, messageService *MessageService, hub WebSocketHub) *AbuseReportService {
+	return &AbuseReportService{
+		store:          store,
+		userService:    userService,
+		messageService: messageService,
+		hub:            hub,
+	}
+}
+
+// CreateReport files a report about something the reporter can see in a
+// workspace and notifies the workspace admins
+func (s *AbuseReportService) CreateReport(ctx context.Context, reporterID int64, req CreateAbuseReportRequest) (*AbuseReportResponse, error) {
+	isMember, err := s.userService.IsWorkspaceMember(ctx, reporterID, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check workspace membership: %w", err)
+	}
+	if !isMember {
+		return nil, errors.New("access denied: user is not a member of the workspace")
+	}
+
+	if err := s.checkTarget(ctx, reporterID, req); err != nil {
+		return nil, err
+	}
+
+	report, err := s.store.CreateAbuseReport(ctx, db.CreateAbuseReportParams{
+		WorkspaceID: req.WorkspaceID,
+		ReporterID:  sql.NullInt64{Int64: reporterID, Valid: true},
+		TargetType:  req.TargetType,
+		TargetID:    req.TargetID,
+		Reason:      req.Reason,
+		Details:     req.Details,
+		Anonymous:   req.Anonymous,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, errors.New("report already exists")
+		}
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	s.notifyAdmins(ctx, report)
+
+	return toAbuseReportResponse(report, true), nil
+}
+
+// checkTarget makes sure the reported message, file or user is in the workspace
+// and visible to the reporter
+func (s *AbuseReportService) checkTarget(ctx context.Context, reporterID int64, req CreateAbuseReportRequest) error {
+	switch req.TargetType {
+	case "message":
+		message, err := s.store.GetMessageByID(ctx, req.TargetID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("report target not found")
+			}
+			return fmt.Errorf("failed to get message: %w", err)
+		}
+		if message.WorkspaceID != req.WorkspaceID || message.DeletedAt.Valid {
+			return errors.New("report target not found")
+		}
+		if err := s.messageService.checkMessageAccess(ctx, message, reporterID); err != nil {
+			// Don't reveal messages the reporter cannot read
+			if strings.HasPrefix(err.Error(), "access denied") {
+				return errors.New("report target not found")
+			}
+			return err
+		}
+	case "file":
+		_, err := s.store.GetFileWithPermissionCheck(ctx, db.GetFileWithPermissionCheckParams{
+			ID:          req.TargetID,
+			WorkspaceID: req.WorkspaceID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("report target not found")
+			}
+			return fmt.Errorf("failed to get file: %w", err)
+		}
+		hasAccess, err := s.store.CheckFileAccess(ctx, db.CheckFileAccessParams{
+			FileID:     req.TargetID,
+			UploaderID: reporterID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check file access: %w", err)
+		}
+		if !hasAccess {
+			return errors.New("report target not found")
+		}
+	case "user":
+		if req.TargetID == reporterID {
+			return errors.New("invalid report: users cannot report themselves")
+		}
+		isMember, err := s.userService.IsWorkspaceMember(ctx, req.TargetID, req.WorkspaceID)
+		if err != nil {
+			return fmt.Errorf("failed to check workspace membership: %w", err)
+		}
+		if !isMember {
+			return errors.New("report target not found")
+		}
+	default:
+		return fmt.Errorf("invalid report: unknown target type %q", req.TargetType)
+	}
+
+	return nil
+}
+
+// notifyAdmins sends a new report to the workspace admins over WebSocket
+func (s *AbuseReportService) notifyAdmins(ctx context.Context, report db.AbuseReport) {
+	if s.hub == nil {
+		return
+	}
+
+	adminIDs, err := s.store.ListWorkspaceAdminIDs(ctx, sql.NullInt64{Int64: report.WorkspaceID, Valid: true})
+	if err != nil {
+		fmt.Printf("Error listing admins to notify of report %d: %v\n", report.ID, err)
+		return
+	}
+
+	wsMessage := &WSMessage{
+		Type:        "abuse_report_created",
+		Data:        toAbuseReportResponse(report, false),
+		WorkspaceID: report.WorkspaceID,
+		Timestamp:   time.Now(),
+	}
+	for _, adminID := range adminIDs {
+		s.hub.BroadcastToUser(adminID, wsMessage)
+	}
+}
+
+// ListReports lists a workspace's reports with the given status, oldest first
+func (s *AbuseReportService) ListReports(ctx context.Context, workspaceID int64, status string, limit, offset int32) ([]*AbuseReportResponse, error) {
+	reports, err := s.store.ListAbuseReports(ctx, db.ListAbuseReportsParams{
+		WorkspaceID: workspaceID,
+		Status:      status,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	responses := make([]*AbuseReportResponse, len(reports))
+	for i, report := range reports {
+		responses[i] = toAbuseReportResponse(report, false)
+	}
+	return responses, nil
+}
+
+// CloseReport resolves or dismisses an open report
+func (s *AbuseReportService) CloseReport(ctx context.Context, workspaceID, reportID, adminID int64, status, note string) (*AbuseReportResponse, error) {
+	report, err := s.store.ResolveAbuseReport(ctx, db.ResolveAbuseReportParams{
+		ID:             reportID,
+		WorkspaceID:    workspaceID,
+		Status:         status,
+		ResolvedBy:     sql.NullInt64{Int64: adminID, Valid: true},
+		ResolutionNote: note,
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to close report: %w", err)
+		}
+
+		// Tell a missing report apart from one that was already closed
+		_, err := s.store.GetAbuseReport(ctx, db.GetAbuseReportParams{ID: reportID, WorkspaceID: workspaceID})
+		if err == sql.ErrNoRows {
+			return nil, errors.New("report not found")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get report: %w", err)
+		}
+		return nil, errors.New("report already closed")
+	}
+
+	return toAbuseReportResponse(report, false), nil
+}
+
+// toAbuseReportResponse converts a report, showing the reporter of anonymous
+// reports only when showReporter is set
+func toAbuseReportResponse(report db.AbuseReport, showReporter bool) *AbuseReportResponse {
+	response := &AbuseReportResponse{
+		ID:             report.ID,
+		WorkspaceID:    report.WorkspaceID,
+		TargetType:     report.TargetType,
+		TargetID:       report.TargetID,
+		Reason:         report.Reason,
+		Details:        report.Details,
+		Anonymous:      report.Anonymous,
+		Status:         report.Status,
+		ResolutionNote: report.ResolutionNote,
+		CreatedAt:      report.CreatedAt,
+	}
+	if report.ReporterID.Valid && (showReporter || !report.Anonymous) {
+		response.ReporterID = &report.ReporterID.Int64
+	}
+	if report.ResolvedBy.Valid {
+		response.ResolvedBy = &report.ResolvedBy.Int64
+	}
+	if report.ResolvedAt.Valid {
+		response.ResolvedAt = &report.ResolvedAt.Time
+	}
+	return response
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHub records the messages sent to each user, workspace and channel
+// and the workspaces whose connections were closed
+type recordingHub struct {
+	userMessages           map[int64][]*WSMessage
+	workspaceMessages      map[int64][]*WSMessage
+	channelMessages        map[int64][]*WSMessage
+	allMessages            []*WSMessage
+	disconnectedWorkspaces []int64
+}
+
+func (h *recordingHub) BroadcastToWorkspace(workspaceID int64, message *WSMessage) {
+	if h.workspaceMessages == nil {
+		h.workspaceMessages = make(map[int64][]*WSMessage)
+	}
+	h.workspaceMessages[workspaceID] = append(h.workspaceMessages[workspaceID], message)
+}
+
+func (h *recordingHub) BroadcastToChannel(workspaceID, channelID int64, message *WSMessage) {
+	if h.channelMessages == nil {
+		h.channelMessages = make(map[int64][]*WSMessage)
+	}
+	h.channelMessages[channelID] = append(h.channelMessages[channelID], message)
+}
+
+func (h *recordingHub) BroadcastToUser(userID int64, message *WSMessage) {
+	if h.userMessages == nil {
+		h.userMessages = make(map[int64][]*WSMessage)
+	}
+	h.userMessages[userID] = append(h.userMessages[userID], message)
+}
+
+func (h *recordingHub) BroadcastToAll(message *WSMessage) {
+	h.allMessages = append(h.allMessages, message)
+}
+
+func (h *recordingHub) DisconnectWorkspace(workspaceID int64) {
+	h.disconnectedWorkspaces = append(h.disconnectedWorkspaces, workspaceID)
+}
+
+func TestAbuseReportService_CreateReport(t *testing.T) {
+	const workspaceID, reporterID, targetID = int64(2), int64(5), int64(8)
+	adminIDs := []int64{11, 12}
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	// Reporter and reported user are both members
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+		Times(2).
+		Return("member", nil)
+
+	store.EXPECT().
+		CreateAbuseReport(gomock.Any(), gomock.Eq(db.CreateAbuseReportParams{
+			WorkspaceID: workspaceID,
+			ReporterID:  sql.NullInt64{Int64: reporterID, Valid: true},
+			TargetType:  "user",
+			TargetID:    targetID,
+			Reason:      "harassment",
+			Anonymous:   true,
+		})).
+		Times(1).
+		Return(db.AbuseReport{
+			ID:          1,
+			WorkspaceID: workspaceID,
+			ReporterID:  sql.NullInt64{Int64: reporterID, Valid: true},
+			TargetType:  "user",
+			TargetID:    targetID,
+			Reason:      "harassment",
+			Anonymous:   true,
+			Status:      AbuseReportStatusOpen,
+		}, nil)
+
+	store.EXPECT().
+		ListWorkspaceAdminIDs(gomock.Any(), gomock.Eq(sql.NullInt64{Int64: workspaceID, Valid: true})).
+		Times(1).
+		Return(adminIDs, nil)
+
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	userService := NewUserService(store, nil, util.Config{})
+	abuseReportService := NewAbuseReportService(store, userService, NewMessageService(store, userService, hub, util.Config{}), hub)
+
+	report, err := abuseReportService.CreateReport(ctx, reporterID, CreateAbuseReportRequest{
+		WorkspaceID: workspaceID,
+		TargetType:  "user",
+		TargetID:    targetID,
+		Reason:      "harassment",
+		Anonymous:   true,
+	})
+	require.NoError(t, err)
+
+	// The reporter sees their own report
+	require.NotNil(t, report.ReporterID)
+	require.Equal(t, reporterID, *report.ReporterID)
+
+	// Admins are notified without learning who reported
+	for _, adminID := range adminIDs {
+		require.Len(t, hub.userMessages[adminID], 1)
+		message := hub.userMessages[adminID][0]
+		require.Equal(t, "abuse_report_created", message.Type)
+		require.Nil(t, message.Data.(*AbuseReportResponse).ReporterID)
+	}
+	require.Empty(t, hub.userMessages[reporterID])
+}
+
+func TestAbuseReportService_CannotReportSelf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Times(1).Return("member", nil)
+	store.EXPECT().CreateAbuseReport(gomock.Any(), gomock.Any()).Times(0)
+
+	userService := NewUserService(store, nil, util.Config{})
+	abuseReportService := NewAbuseReportService(store, userService, NewMessageService(store, userService, nil, util.Config{}), nil)
+
+	_, err := abuseReportService.CreateReport(context.Background(), 5, CreateAbuseReportRequest{
+		WorkspaceID: 2,
+		TargetType:  "user",
+		TargetID:    5,
+		Reason:      "spam",
+	})
+	require.EqualError(t, err, "invalid report: users cannot report themselves")
+}
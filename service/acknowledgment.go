@@ -0,0 +1,305 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// acknowledgmentReminderBatch is how many due reminders one run of the
+// reminder job sends
+const acknowledgmentReminderBatch = 100
+
+// SendMustReadMessage sends a message to a channel that asks the channel's
+// members to acknowledge it. Those who haven't after remindAfter, or the
+// default reminder delay when it's zero, are reminded once.
+func (s *MessageService) SendMustReadMessage(ctx context.Context, workspaceID, channelID, senderID int64, content string, remindAfter time.Duration) (*MessageResponse, error) {
+	if remindAfter <= 0 {
+		remindAfter = s.acknowledgmentReminderDelay
+	}
+	remindAt := sql.NullTime{Time: time.Now().Add(remindAfter), Valid: true}
+
+	return s.sendChannelMessage(ctx, workspaceID, channelID, senderID, content, remindAt)
+}
+
+// AcknowledgeMessage records that a user read a message asking for
+// acknowledgment. Acknowledging it again changes nothing.
+func (s *MessageService) AcknowledgeMessage(ctx context.Context, messageID, userID int64) (*MessageAcknowledgmentStatus, error) {
+	message, request, err := s.getAcknowledgmentRequest(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if message.SenderID == userID {
+		return nil, errors.New("authors don't acknowledge their own messages")
+	}
+
+	acknowledgment, err := s.store.AcknowledgeMessage(ctx, db.AcknowledgeMessageParams{
+		MessageID: messageID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acknowledge message: %w", err)
+	}
+
+	response := &MessageResponse{ID: messageID, ChannelID: &request.ChannelID}
+	if err := s.attachAcknowledgments(ctx, []*MessageResponse{response}, userID); err != nil {
+		return nil, err
+	}
+	status := response.Acknowledgment
+	if status == nil {
+		// The message was deleted in the meantime
+		status = &MessageAcknowledgmentStatus{RemindAt: request.RemindAt}
+	}
+	status.Acknowledged = true
+	status.AcknowledgedAt = &acknowledgment.AcknowledgedAt
+
+	if s.hub != nil {
+		s.hub.BroadcastToUser(message.SenderID, &WSMessage{
+			Type: "message_acknowledged",
+			Data: map[string]interface{}{
+				"message_id":         messageID,
+				"user_id":            userID,
+				"acknowledged_at":    acknowledgment.AcknowledgedAt,
+				"acknowledged_count": status.AcknowledgedCount,
+			},
+			WorkspaceID: message.WorkspaceID,
+			ChannelID:   &request.ChannelID,
+			UserID:      userID,
+			Timestamp:   time.Now(),
+		})
+	}
+
+	return status, nil
+}
+
+// GetAcknowledgments returns who has and hasn't acknowledged a message among
+// the current members of its channel. Only the author can see them.
+func (s *MessageService) GetAcknowledgments(ctx context.Context, messageID, userID int64) (*MessageAcknowledgmentsResponse, error) {
+	message, request, err := s.getAcknowledgmentRequest(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if message.SenderID != userID {
+		return nil, errors.New("access denied: only the author can see who acknowledged this message")
+	}
+
+	recipients, err := s.store.ListAcknowledgmentRecipients(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list acknowledgments: %w", err)
+	}
+
+	response := &MessageAcknowledgmentsResponse{
+		MessageID:    messageID,
+		RemindAt:     request.RemindAt,
+		Acknowledged: []AcknowledgmentRecipient{},
+		Pending:      []AcknowledgmentRecipient{},
+	}
+	if request.RemindedAt.Valid {
+		response.RemindedAt = &request.RemindedAt.Time
+	}
+	for _, row := range recipients {
+		recipient := AcknowledgmentRecipient{
+			ID:        row.ID,
+			FirstName: row.FirstName,
+			LastName:  row.LastName,
+			Email:     row.Email,
+		}
+		if row.AcknowledgedAt.Valid {
+			recipient.AcknowledgedAt = &row.AcknowledgedAt.Time
+			response.Acknowledged = append(response.Acknowledged, recipient)
+		} else {
+			response.Pending = append(response.Pending, recipient)
+		}
+	}
+	response.AcknowledgedCount = len(response.Acknowledged)
+	response.PendingCount = len(response.Pending)
+
+	return response, nil
+}
+
+// ExportAcknowledgments returns GetAcknowledgments as CSV, one row per
+// channel member, for authors who track acknowledgments elsewhere
+func (s *MessageService) ExportAcknowledgments(ctx context.Context, messageID, userID int64) ([]byte, error) {
+	acknowledgments, err := s.GetAcknowledgments(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	rows := [][]string{{"user_id", "first_name", "last_name", "email", "acknowledged", "acknowledged_at"}}
+	for _, recipient := range acknowledgments.Acknowledged {
+		rows = append(rows, acknowledgmentRecord(recipient))
+	}
+	for _, recipient := range acknowledgments.Pending {
+		rows = append(rows, acknowledgmentRecord(recipient))
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to write acknowledgments: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func acknowledgmentRecord(recipient AcknowledgmentRecipient) []string {
+	acknowledged, acknowledgedAt := "false", ""
+	if recipient.AcknowledgedAt != nil {
+		acknowledged, acknowledgedAt = "true", recipient.AcknowledgedAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatInt(recipient.ID, 10),
+		recipient.FirstName,
+		recipient.LastName,
+		recipient.Email,
+		acknowledged,
+		acknowledgedAt,
+	}
+}
+
+// getAcknowledgmentRequest returns a message the user can read and the
+// acknowledgment it asks for
+func (s *MessageService) getAcknowledgmentRequest(ctx context.Context, messageID, userID int64) (db.GetMessageByIDRow, db.MessageAcknowledgmentRequest, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return message, db.MessageAcknowledgmentRequest{}, errors.New("message not found")
+		}
+		return message, db.MessageAcknowledgmentRequest{}, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if err := s.checkMessageAccess(ctx, message, userID); err != nil {
+		return message, db.MessageAcknowledgmentRequest{}, err
+	}
+
+	request, err := s.store.GetAcknowledgmentRequest(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return message, request, errors.New("acknowledgment request not found")
+		}
+		return message, request, fmt.Errorf("failed to get acknowledgment request: %w", err)
+	}
+
+	return message, request, nil
+}
+
+// attachAcknowledgments sets the acknowledgment status of the listed messages
+// that ask for one, as seen by the user
+func (s *MessageService) attachAcknowledgments(ctx context.Context, messages []*MessageResponse, userID int64) error {
+	messageIDs := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		if message.ChannelID != nil {
+			messageIDs = append(messageIDs, message.ID)
+		}
+	}
+	if len(messageIDs) == 0 {
+		return nil
+	}
+
+	states, err := s.store.ListMessageAcknowledgmentStates(ctx, db.ListMessageAcknowledgmentStatesParams{
+		UserID:     userID,
+		MessageIds: messageIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list acknowledgments: %w", err)
+	}
+
+	byID := make(map[int64]db.ListMessageAcknowledgmentStatesRow, len(states))
+	for _, state := range states {
+		byID[state.MessageID] = state
+	}
+	for _, message := range messages {
+		state, ok := byID[message.ID]
+		if !ok {
+			continue
+		}
+		message.Acknowledgment = &MessageAcknowledgmentStatus{
+			Acknowledged:      state.AcknowledgedAt.Valid,
+			AcknowledgedCount: state.AcknowledgedCount,
+			RemindAt:          state.RemindAt,
+		}
+		if state.AcknowledgedAt.Valid {
+			message.Acknowledgment.AcknowledgedAt = &state.AcknowledgedAt.Time
+		}
+	}
+
+	return nil
+}
+
+// SendAcknowledgmentReminders reminds the channel members who haven't
+// acknowledged a message once its reminder is due. Each message's reminder is
+// claimed before it's sent, so it's sent once even with several servers.
+func (s *MessageService) SendAcknowledgmentReminders(ctx context.Context) error {
+	requests, err := s.store.ClaimDueAcknowledgmentReminders(ctx, acknowledgmentReminderBatch)
+	if err != nil {
+		return fmt.Errorf("failed to claim acknowledgment reminders: %w", err)
+	}
+
+	for _, request := range requests {
+		message, err := s.store.GetMessageByID(ctx, request.MessageID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				// Deleted messages need no acknowledgment
+				continue
+			}
+			return fmt.Errorf("failed to get message %d: %w", request.MessageID, err)
+		}
+
+		recipients, err := s.store.ListAcknowledgmentRecipients(ctx, request.MessageID)
+		if err != nil {
+			return fmt.Errorf("failed to list acknowledgments of message %d: %w", request.MessageID, err)
+		}
+
+		if s.hub == nil {
+			continue
+		}
+		for _, recipient := range recipients {
+			if recipient.AcknowledgedAt.Valid {
+				continue
+			}
+			s.hub.BroadcastToUser(recipient.ID, &WSMessage{
+				Type: "acknowledgment_reminder",
+				Data: map[string]interface{}{
+					"message_id": message.ID,
+					"sender_id":  message.SenderID,
+					"content":    message.Content,
+					"permalink":  MessagePermalink(message.WorkspaceID, message.ID, message.ThreadID),
+				},
+				WorkspaceID: message.WorkspaceID,
+				ChannelID:   &request.ChannelID,
+				UserID:      message.SenderID,
+				Timestamp:   time.Now(),
+			})
+		}
+	}
+
+	return nil
+}
+
+// StartAcknowledgmentReminderJob sends due acknowledgment reminders on an
+// interval until the context is cancelled
+func (s *MessageService) StartAcknowledgmentReminderJob(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.SendAcknowledgmentReminders(ctx); err != nil {
+				fmt.Printf("Error sending acknowledgment reminders: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageService_Acknowledgments(t *testing.T) {
+	const workspaceID, channelID, authorID, memberID = int64(2), int64(3), int64(5), int64(8)
+	ctx := context.Background()
+	acknowledgedAt := time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC)
+	message := db.GetMessageByIDRow{
+		ID:          40,
+		WorkspaceID: workspaceID,
+		ChannelID:   sql.NullInt64{Int64: channelID, Valid: true},
+		SenderID:    authorID,
+		Content:     "Please read the new travel policy",
+		MessageType: "channel",
+	}
+	request := db.MessageAcknowledgmentRequest{
+		MessageID:   message.ID,
+		WorkspaceID: workspaceID,
+		ChannelID:   channelID,
+		AuthorID:    authorID,
+		RemindAt:    acknowledgedAt.Add(24 * time.Hour),
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetMessageByID(gomock.Any(), message.ID).AnyTimes().Return(message, nil)
+	store.EXPECT().GetAcknowledgmentRequest(gomock.Any(), message.ID).AnyTimes().Return(request, nil)
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return("member", nil)
+	store.EXPECT().GetChannelByID(gomock.Any(), channelID).AnyTimes().Return(db.Channel{ID: channelID, WorkspaceID: workspaceID}, nil)
+	store.EXPECT().
+		ListAcknowledgmentRecipients(gomock.Any(), message.ID).
+		AnyTimes().
+		Return([]db.ListAcknowledgmentRecipientsRow{
+			{ID: memberID, FirstName: "Ada", LastName: "Lovelace", Email: "ada@example.com", AcknowledgedAt: sql.NullTime{Time: acknowledgedAt, Valid: true}},
+			{ID: 9, FirstName: "Alan", LastName: "Turing", Email: "alan@example.com"},
+		}, nil)
+
+	hub := &recordingHub{}
+	messageService := NewMessageService(store, NewUserService(store, nil, util.Config{}), hub, util.Config{})
+
+	// Members acknowledge and the author hears about it
+	store.EXPECT().
+		AcknowledgeMessage(gomock.Any(), db.AcknowledgeMessageParams{MessageID: message.ID, UserID: memberID}).
+		Times(1).
+		Return(db.MessageAcknowledgment{MessageID: message.ID, UserID: memberID, AcknowledgedAt: acknowledgedAt}, nil)
+	store.EXPECT().
+		ListMessageAcknowledgmentStates(gomock.Any(), db.ListMessageAcknowledgmentStatesParams{UserID: memberID, MessageIds: []int64{message.ID}}).
+		Times(1).
+		Return([]db.ListMessageAcknowledgmentStatesRow{
+			{MessageID: message.ID, RemindAt: request.RemindAt, AcknowledgedCount: 1, AcknowledgedAt: sql.NullTime{Time: acknowledgedAt, Valid: true}},
+		}, nil)
+
+	status, err := messageService.AcknowledgeMessage(ctx, message.ID, memberID)
+	require.NoError(t, err)
+	require.True(t, status.Acknowledged)
+	require.Equal(t, acknowledgedAt, *status.AcknowledgedAt)
+	require.Equal(t, int64(1), status.AcknowledgedCount)
+	require.Len(t, hub.userMessages[authorID], 1)
+	require.Equal(t, "message_acknowledged", hub.userMessages[authorID][0].Type)
+
+	_, err = messageService.AcknowledgeMessage(ctx, message.ID, authorID)
+	require.EqualError(t, err, "authors don't acknowledge their own messages")
+
+	// Only the author sees who has and hasn't acknowledged
+	_, err = messageService.GetAcknowledgments(ctx, message.ID, memberID)
+	require.EqualError(t, err, "access denied: only the author can see who acknowledged this message")
+
+	acknowledgments, err := messageService.GetAcknowledgments(ctx, message.ID, authorID)
+	require.NoError(t, err)
+	require.Equal(t, 1, acknowledgments.AcknowledgedCount)
+	require.Equal(t, 1, acknowledgments.PendingCount)
+	require.Equal(t, memberID, acknowledgments.Acknowledged[0].ID)
+	require.Equal(t, int64(9), acknowledgments.Pending[0].ID)
+
+	export, err := messageService.ExportAcknowledgments(ctx, message.ID, authorID)
+	require.NoError(t, err)
+	require.Equal(t, "user_id,first_name,last_name,email,acknowledged,acknowledged_at\n"+
+		"8,Ada,Lovelace,ada@example.com,true,2026-03-04T09:30:00Z\n"+
+		"9,Alan,Turing,alan@example.com,false,\n", string(export))
+}
+
+func TestMessageService_SendAcknowledgmentReminders(t *testing.T) {
+	const workspaceID, channelID, authorID = int64(2), int64(3), int64(5)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ClaimDueAcknowledgmentReminders(gomock.Any(), int32(acknowledgmentReminderBatch)).
+		Times(1).
+		Return([]db.MessageAcknowledgmentRequest{
+			{MessageID: 40, WorkspaceID: workspaceID, ChannelID: channelID, AuthorID: authorID},
+			{MessageID: 41, WorkspaceID: workspaceID, ChannelID: channelID, AuthorID: authorID},
+		}, nil)
+	store.EXPECT().
+		GetMessageByID(gomock.Any(), int64(40)).
+		Times(1).
+		Return(db.GetMessageByIDRow{ID: 40, WorkspaceID: workspaceID, ChannelID: sql.NullInt64{Int64: channelID, Valid: true}, SenderID: authorID}, nil)
+	store.EXPECT().
+		ListAcknowledgmentRecipients(gomock.Any(), int64(40)).
+		Times(1).
+		Return([]db.ListAcknowledgmentRecipientsRow{
+			{ID: 8, AcknowledgedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+			{ID: 9},
+		}, nil)
+
+	// Deleted messages are skipped
+	store.EXPECT().GetMessageByID(gomock.Any(), int64(41)).Times(1).Return(db.GetMessageByIDRow{}, sql.ErrNoRows)
+	store.EXPECT().ListAcknowledgmentRecipients(gomock.Any(), int64(41)).Times(0)
+
+	hub := &recordingHub{}
+	messageService := NewMessageService(store, NewUserService(store, nil, util.Config{}), hub, util.Config{})
+
+	require.NoError(t, messageService.SendAcknowledgmentReminders(ctx))
+	require.Empty(t, hub.userMessages[8])
+	require.Len(t, hub.userMessages[9], 1)
+	require.Equal(t, "acknowledgment_reminder", hub.userMessages[9][0].Type)
+	require.Equal(t, int64(40), hub.userMessages[9][0].Data.(map[string]interface{})["message_id"])
+}
@@ -0,0 +1,524 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+const (
+	// messagesPerArchive caps the messages written to one archive object
+	messagesPerArchive = 5000
+	// archiveConversationsPerRun caps how many conversation months one
+	// archival run works through
+	archiveConversationsPerRun = 100
+)
+
+// archiveMetrics counts archival runs, the archives and messages they wrote
+// and archive fetches, published at /debug/vars
+var archiveMetrics = expvar.NewMap("message_archives")
+
+// ArchivalResult counts what one archival run moved to the archive store
+type ArchivalResult struct {
+	Archives int   `json:"archives"`
+	Messages int64 `json:"messages"`
+}
+
+// archivedMessage is a message as written to an archive, one JSON object
+// per line
+type archivedMessage struct {
+	ID              int64              `json:"id"`
+	WorkspaceID     int64              `json:"workspace_id"`
+	ChannelID       *int64             `json:"channel_id,omitempty"`
+	SenderID        int64              `json:"sender_id"`
+	SenderFirstName string             `json:"sender_first_name"`
+	SenderLastName  string             `json:"sender_last_name"`
+	SenderEmail     string             `json:"sender_email"`
+	ReceiverID      *int64             `json:"receiver_id,omitempty"`
+	Content         string             `json:"content"`
+	ContentType     string             `json:"content_type"`
+	MessageType     string             `json:"message_type"`
+	ThreadID        *int64             `json:"thread_id,omitempty"`
+	EditedAt        *time.Time         `json:"edited_at,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+	Reactions       []archivedReaction `json:"reactions,omitempty"`
+}
+
+// archivedReaction is a reaction to an archived message, in the order they
+// were added
+type archivedReaction struct {
+	Emoji  string `json:"emoji"`
+	UserID int64  `json:"user_id"`
+}
+
+// archiveConversation identifies the channel or direct message pair whose
+// archives are read. A pair is recorded with the lower user ID first.
+type archiveConversation struct {
+	workspaceID int64
+	channelID   sql.NullInt64
+	userID      sql.NullInt64
+	otherUserID sql.NullInt64
+}
+
+func channelArchiveConversation(workspaceID, channelID int64) archiveConversation {
+	return archiveConversation{
+		workspaceID: workspaceID,
+		channelID:   sql.NullInt64{Int64: channelID, Valid: true},
+	}
+}
+
+func directArchiveConversation(workspaceID, userID, otherUserID int64) archiveConversation {
+	if otherUserID < userID {
+		userID, otherUserID = otherUserID, userID
+	}
+	return archiveConversation{
+		workspaceID: workspaceID,
+		userID:      sql.NullInt64{Int64: userID, Valid: true},
+		otherUserID: sql.NullInt64{Int64: otherUserID, Valid: true},
+	}
+}
+
+// ArchiveService moves old messages out of the database into compressed
+// archives in the archive store, and reads them back for history and
+// compliance exports. Reading an archive fetches the whole object, so it is
+// slower than reading messages from the database.
+type ArchiveService struct {
+	store        db.MessageStore
+	archive      ArchiveStore
+	archiveAfter time.Duration
+	now          func() time.Time
+}
+
+// NewArchiveService creates a new archive service
+func NewArchiveService(store db.MessageStore, archive ArchiveStore, config util.Config) *ArchiveService {
+	return &ArchiveService{
+		store:        store,
+		archive:      archive,
+		archiveAfter: config.MessageArchiveAfter,
+		now:          time.Now,
+	}
+}
+
+// Enabled reports whether old messages are archived. Archives written while
+// it was enabled are read either way.
+func (s *ArchiveService) Enabled() bool {
+	return s.archiveAfter > 0
+}
+
+// RunArchival archives the messages older than the archive age, a
+// conversation and month at a time. Each archive is written to the store
+// before its messages are deleted, so an interrupted run loses nothing; the
+// next run writes the same archive again.
+func (s *ArchiveService) RunArchival(ctx context.Context) (ArchivalResult, error) {
+	var result ArchivalResult
+	archiveMetrics.Add("runs", 1)
+
+	cutoff := s.now().Add(-s.archiveAfter)
+	conversations, err := s.store.ListArchivableConversations(ctx, db.ListArchivableConversationsParams{
+		Cutoff: cutoff,
+		Limit:  archiveConversationsPerRun,
+	})
+	if err != nil {
+		archiveMetrics.Add("errors", 1)
+		return result, fmt.Errorf("failed to list archivable conversations: %w", err)
+	}
+
+	for _, conversation := range conversations {
+		for {
+			archived, err := s.archiveBatch(ctx, conversation, cutoff)
+			if err != nil {
+				archiveMetrics.Add("errors", 1)
+				return result, err
+			}
+			if archived == 0 {
+				break
+			}
+			result.Archives++
+			result.Messages += int64(archived)
+			archiveMetrics.Add("archives", 1)
+			archiveMetrics.Add("messages", int64(archived))
+			if archived < messagesPerArchive {
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// StartArchivalJob archives old messages now and then on an interval until
+// the context is cancelled
+func (s *ArchiveService) StartArchivalJob(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.RunArchival(ctx)
+		if err != nil {
+			fmt.Printf("Error archiving messages: %v\n", err)
+		}
+		if result.Archives > 0 {
+			fmt.Printf("Archived %d messages in %d archives\n", result.Messages, result.Archives)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// archiveBatch writes one archive of a conversation's month and deletes its
+// messages. It returns how many messages it archived.
+func (s *ArchiveService) archiveBatch(ctx context.Context, conversation db.ListArchivableConversationsRow, cutoff time.Time) (int, error) {
+	rows, err := s.store.ListMessagesToArchive(ctx, db.ListMessagesToArchiveParams{
+		WorkspaceID: conversation.WorkspaceID,
+		ChannelID:   conversation.ChannelID,
+		UserID:      conversation.UserID,
+		OtherUserID: conversation.OtherUserID,
+		StartsAt:    conversation.Month,
+		EndsAt:      conversation.Month.AddDate(0, 1, 0),
+		Cutoff:      cutoff,
+		Limit:       messagesPerArchive,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list messages to archive: %w", err)
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	data, err := encodeArchive(conversation.WorkspaceID, rows)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode archive: %w", err)
+	}
+
+	// Named after the first message, so writing a batch again replaces the
+	// object instead of leaving a stray copy
+	key := archiveObjectKey(conversation, rows[0].ID)
+	if err := s.archive.Put(ctx, key, data); err != nil {
+		return 0, fmt.Errorf("failed to write archive %s: %w", key, err)
+	}
+
+	messageIDs := make([]int64, len(rows))
+	senders := make(map[int64]bool)
+	senderIDs := []int64{}
+	for i, row := range rows {
+		messageIDs[i] = row.ID
+		if !senders[row.SenderID] {
+			senders[row.SenderID] = true
+			senderIDs = append(senderIDs, row.SenderID)
+		}
+	}
+	sort.Slice(senderIDs, func(i, j int) bool { return senderIDs[i] < senderIDs[j] })
+
+	_, err = s.store.ArchiveMessagesTx(ctx, db.ArchiveMessagesTxParams{
+		Archive: db.CreateMessageArchiveParams{
+			WorkspaceID:    conversation.WorkspaceID,
+			ChannelID:      conversation.ChannelID,
+			UserID:         conversation.UserID,
+			OtherUserID:    conversation.OtherUserID,
+			SenderIds:      senderIDs,
+			ObjectKey:      key,
+			FirstMessageAt: rows[0].CreatedAt,
+			LastMessageAt:  rows[len(rows)-1].CreatedAt,
+			MessageCount:   int32(len(rows)),
+			SizeBytes:      int64(len(data)),
+		},
+		MessageIDs: messageIDs,
+	})
+	if err != nil {
+		// A message deleted while the archive was written leaves the batch
+		// for the next run
+		if errors.Is(err, db.ErrArchivedMessagesChanged) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to record archive %s: %w", key, err)
+	}
+
+	return len(rows), nil
+}
+
+// archiveObjectKey names the object holding a conversation's messages from a
+// month, starting with the given message
+func archiveObjectKey(conversation db.ListArchivableConversationsRow, firstMessageID int64) string {
+	name := fmt.Sprintf("channel-%d", conversation.ChannelID.Int64)
+	if !conversation.ChannelID.Valid {
+		name = fmt.Sprintf("direct-%d-%d", conversation.UserID.Int64, conversation.OtherUserID.Int64)
+	}
+	return fmt.Sprintf("messages/workspace-%d/%s/%s/%d.jsonl.gz",
+		conversation.WorkspaceID, name, conversation.Month.UTC().Format("2006-01"), firstMessageID)
+}
+
+// encodeArchive writes messages as gzipped JSON Lines
+func encodeArchive(workspaceID int64, rows []db.ListMessagesToArchiveRow) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(writer)
+
+	for _, row := range rows {
+		message := archivedMessage{
+			ID:              row.ID,
+			WorkspaceID:     workspaceID,
+			SenderID:        row.SenderID,
+			SenderFirstName: row.SenderFirstName,
+			SenderLastName:  row.SenderLastName,
+			SenderEmail:     row.SenderEmail,
+			Content:         row.Content,
+			ContentType:     row.ContentType,
+			MessageType:     row.MessageType,
+			CreatedAt:       row.CreatedAt,
+		}
+		if row.ChannelID.Valid {
+			message.ChannelID = &row.ChannelID.Int64
+		}
+		if row.ReceiverID.Valid {
+			message.ReceiverID = &row.ReceiverID.Int64
+		}
+		if row.ThreadID.Valid {
+			message.ThreadID = &row.ThreadID.Int64
+		}
+		if row.EditedAt.Valid {
+			message.EditedAt = &row.EditedAt.Time
+		}
+		if len(row.Reactions) > 0 {
+			if err := json.Unmarshal(row.Reactions, &message.Reactions); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := encoder.Encode(message); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeArchive reads the messages of an archive, oldest first
+func decodeArchive(data []byte) ([]archivedMessage, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var messages []archivedMessage
+	decoder := json.NewDecoder(reader)
+	for {
+		var message archivedMessage
+		if err := decoder.Decode(&message); err != nil {
+			if err == io.EOF {
+				return messages, nil
+			}
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+}
+
+// fetchArchive reads an archive's messages from the archive store
+func (s *ArchiveService) fetchArchive(ctx context.Context, archive db.MessageArchive) ([]archivedMessage, error) {
+	archiveMetrics.Add("fetches", 1)
+	data, err := s.archive.Get(ctx, archive.ObjectKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch archive %s: %w", archive.ObjectKey, err)
+	}
+	messages, err := decodeArchive(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", archive.ObjectKey, err)
+	}
+	return messages, nil
+}
+
+// listMessages returns a page of a conversation's archived messages, newest
+// first, optionally only those sent after and before the given times.
+// Archives the offset skips entirely aren't fetched.
+func (s *ArchiveService) listMessages(ctx context.Context, conversation archiveConversation, viewerID int64, before, after *time.Time, limit, offset int32) ([]*MessageResponse, error) {
+	arg := db.ListMessageArchivesParams{
+		WorkspaceID: conversation.workspaceID,
+		ChannelID:   conversation.channelID,
+		UserID:      conversation.userID,
+		OtherUserID: conversation.otherUserID,
+	}
+	if before != nil {
+		arg.Before = sql.NullTime{Time: *before, Valid: true}
+	}
+	if after != nil {
+		arg.After = sql.NullTime{Time: *after, Valid: true}
+	}
+
+	archives, err := s.store.ListMessageArchives(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message archives: %w", err)
+	}
+
+	inRange := func(createdAt time.Time) bool {
+		return (before == nil || createdAt.Before(*before)) && (after == nil || createdAt.After(*after))
+	}
+
+	responses := []*MessageResponse{}
+	for _, archive := range archives {
+		if len(responses) >= int(limit) {
+			break
+		}
+		if offset >= archive.MessageCount && inRange(archive.FirstMessageAt) && inRange(archive.LastMessageAt) {
+			offset -= archive.MessageCount
+			continue
+		}
+
+		messages, err := s.fetchArchive(ctx, archive)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(messages) - 1; i >= 0 && len(responses) < int(limit); i-- {
+			if !inRange(messages[i].CreatedAt) {
+				continue
+			}
+			if offset > 0 {
+				offset--
+				continue
+			}
+			responses = append(responses, messages[i].toMessageResponse(viewerID))
+		}
+	}
+
+	return responses, nil
+}
+
+// listLegalHoldMessages returns a page of the archived messages covered by a
+// legal hold, oldest first
+func (s *ArchiveService) listLegalHoldMessages(ctx context.Context, hold db.LegalHold, limit, offset int32) ([]LegalHoldExportMessage, error) {
+	archives, err := s.store.ListLegalHoldMessageArchives(ctx, db.ListLegalHoldMessageArchivesParams{
+		TargetType: hold.TargetType,
+		TargetID:   hold.TargetID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legal hold archives: %w", err)
+	}
+
+	held := func(message archivedMessage) bool {
+		if hold.TargetType == LegalHoldTargetChannel {
+			return true
+		}
+		return message.SenderID == hold.TargetID || (message.ReceiverID != nil && *message.ReceiverID == hold.TargetID)
+	}
+
+	messages := []LegalHoldExportMessage{}
+	for _, archive := range archives {
+		if len(messages) >= int(limit) {
+			break
+		}
+		// Every message of a held channel's archives or a held user's direct
+		// messages is covered, so these can be skipped without fetching them
+		covered := hold.TargetType == LegalHoldTargetChannel || !archive.ChannelID.Valid
+		if covered && offset >= archive.MessageCount {
+			offset -= archive.MessageCount
+			continue
+		}
+
+		archived, err := s.fetchArchive(ctx, archive)
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range archived {
+			if len(messages) >= int(limit) {
+				break
+			}
+			if !held(message) {
+				continue
+			}
+			if offset > 0 {
+				offset--
+				continue
+			}
+			messages = append(messages, message.toLegalHoldExportMessage())
+		}
+	}
+
+	return messages, nil
+}
+
+// toMessageResponse converts an archived message to a response, summarizing
+// its reactions for the viewer
+func (m archivedMessage) toMessageResponse(viewerID int64) *MessageResponse {
+	response := &MessageResponse{
+		ID:          m.ID,
+		WorkspaceID: m.WorkspaceID,
+		ChannelID:   m.ChannelID,
+		SenderID:    m.SenderID,
+		ReceiverID:  m.ReceiverID,
+		Content:     m.Content,
+		ContentType: m.ContentType,
+		MessageType: m.MessageType,
+		ThreadID:    m.ThreadID,
+		Sender: UserResponse{
+			ID:        m.SenderID,
+			Email:     m.SenderEmail,
+			FirstName: m.SenderFirstName,
+			LastName:  m.SenderLastName,
+		},
+		EditedAt:  m.EditedAt,
+		CreatedAt: m.CreatedAt,
+		Archived:  true,
+	}
+
+	index := make(map[string]int)
+	for _, reaction := range m.Reactions {
+		i, ok := index[reaction.Emoji]
+		if !ok {
+			i = len(response.Reactions)
+			index[reaction.Emoji] = i
+			response.Reactions = append(response.Reactions, ReactionSummary{Emoji: reaction.Emoji, UserIDs: []int64{}})
+		}
+		summary := &response.Reactions[i]
+		summary.Count++
+		if len(summary.UserIDs) < summaryReactors {
+			summary.UserIDs = append(summary.UserIDs, reaction.UserID)
+		}
+		if reaction.UserID == viewerID {
+			summary.Reacted = true
+		}
+	}
+
+	return response
+}
+
+// toLegalHoldExportMessage converts an archived message to a compliance
+// export entry
+func (m archivedMessage) toLegalHoldExportMessage() LegalHoldExportMessage {
+	return LegalHoldExportMessage{
+		ID:          m.ID,
+		WorkspaceID: m.WorkspaceID,
+		ChannelID:   m.ChannelID,
+		SenderID:    m.SenderID,
+		SenderName:  m.SenderFirstName + " " + m.SenderLastName,
+		SenderEmail: m.SenderEmail,
+		ReceiverID:  m.ReceiverID,
+		Content:     m.Content,
+		ContentType: m.ContentType,
+		MessageType: m.MessageType,
+		ThreadID:    m.ThreadID,
+		EditedAt:    m.EditedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
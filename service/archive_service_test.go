@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryArchiveStore keeps archives in memory and counts fetches
+type memoryArchiveStore struct {
+	objects map[string][]byte
+	gets    int
+}
+
+func (s *memoryArchiveStore) Put(ctx context.Context, key string, data []byte) error {
+	s.objects[key] = data
+	return nil
+}
+
+func (s *memoryArchiveStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.gets++
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
+
+func archiveRow(id, senderID int64, createdAt time.Time, reactions string) db.ListMessagesToArchiveRow {
+	return db.ListMessagesToArchiveRow{
+		ID:              id,
+		ChannelID:       sql.NullInt64{Int64: 7, Valid: true},
+		SenderID:        senderID,
+		Content:         "message " + time.Duration(id).String(),
+		ContentType:     "text",
+		MessageType:     "channel",
+		CreatedAt:       createdAt,
+		SenderFirstName: "Ada",
+		SenderLastName:  "Lovelace",
+		SenderEmail:     "ada@example.com",
+		Reactions:       json.RawMessage(reactions),
+	}
+}
+
+func TestArchiveService_RunArchival(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockMessageStore(ctrl)
+	archive := &memoryArchiveStore{objects: map[string][]byte{}}
+	archiveService := NewArchiveService(store, archive, util.Config{MessageArchiveAfter: 365 * 24 * time.Hour})
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	archiveService.now = func() time.Time { return now }
+	cutoff := now.Add(-365 * 24 * time.Hour)
+	month := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	channel := db.ListArchivableConversationsRow{WorkspaceID: 3, ChannelID: sql.NullInt64{Int64: 7, Valid: true}, Month: month}
+	direct := db.ListArchivableConversationsRow{
+		WorkspaceID: 3,
+		UserID:      sql.NullInt64{Int64: 4, Valid: true},
+		OtherUserID: sql.NullInt64{Int64: 9, Valid: true},
+		Month:       month,
+	}
+	store.EXPECT().
+		ListArchivableConversations(gomock.Any(), db.ListArchivableConversationsParams{Cutoff: cutoff, Limit: archiveConversationsPerRun}).
+		Times(1).
+		Return([]db.ListArchivableConversationsRow{channel, direct}, nil)
+
+	rows := []db.ListMessagesToArchiveRow{
+		archiveRow(10, 9, month.Add(time.Hour), `[{"emoji":":tada:","user_id":4}]`),
+		archiveRow(11, 4, month.Add(2*time.Hour), `[]`),
+	}
+	store.EXPECT().
+		ListMessagesToArchive(gomock.Any(), db.ListMessagesToArchiveParams{
+			WorkspaceID: 3,
+			ChannelID:   channel.ChannelID,
+			StartsAt:    month,
+			EndsAt:      time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			Cutoff:      cutoff,
+			Limit:       messagesPerArchive,
+		}).
+		Times(1).
+		Return(rows, nil)
+
+	channelKey := "messages/workspace-3/channel-7/2025-01/10.jsonl.gz"
+	store.EXPECT().
+		ArchiveMessagesTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ArchiveMessagesTxParams) (db.MessageArchive, error) {
+			require.Equal(t, []int64{10, 11}, arg.MessageIDs)
+			require.Equal(t, channelKey, arg.Archive.ObjectKey)
+			require.Equal(t, []int64{4, 9}, arg.Archive.SenderIds)
+			require.Equal(t, int32(2), arg.Archive.MessageCount)
+			require.Equal(t, rows[0].CreatedAt, arg.Archive.FirstMessageAt)
+			require.Equal(t, rows[1].CreatedAt, arg.Archive.LastMessageAt)
+			require.Equal(t, int64(len(archive.objects[channelKey])), arg.Archive.SizeBytes)
+			return db.MessageArchive{ID: 1}, nil
+		})
+
+	// A direct message deleted while it was being archived leaves the batch
+	// for the next run
+	directRow := archiveRow(12, 4, month.Add(3*time.Hour), `[]`)
+	directRow.ChannelID = sql.NullInt64{}
+	directRow.ReceiverID = sql.NullInt64{Int64: 9, Valid: true}
+	directRow.MessageType = "direct"
+	store.EXPECT().
+		ListMessagesToArchive(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ListMessagesToArchiveRow{directRow}, nil)
+	store.EXPECT().
+		ArchiveMessagesTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.MessageArchive{}, db.ErrArchivedMessagesChanged)
+
+	result, err := archiveService.RunArchival(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, ArchivalResult{Archives: 1, Messages: 2}, result)
+
+	messages, err := decodeArchive(archive.objects[channelKey])
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, int64(10), messages[0].ID)
+	require.Equal(t, int64(3), messages[0].WorkspaceID)
+	require.Equal(t, []archivedReaction{{Emoji: ":tada:", UserID: 4}}, messages[0].Reactions)
+	require.Empty(t, messages[1].Reactions)
+	require.Contains(t, archive.objects, "messages/workspace-3/direct-4-9/2025-01/12.jsonl.gz")
+}
+
+func TestArchiveService_ListMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockMessageStore(ctrl)
+	archive := &memoryArchiveStore{objects: map[string][]byte{}}
+	archiveService := NewArchiveService(store, archive, util.Config{})
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	older, err := encodeArchive(3, []db.ListMessagesToArchiveRow{
+		archiveRow(1, 4, base, `[]`),
+		archiveRow(2, 9, base.Add(time.Hour), `[{"emoji":":+1:","user_id":4},{"emoji":":+1:","user_id":9}]`),
+	})
+	require.NoError(t, err)
+	newer, err := encodeArchive(3, []db.ListMessagesToArchiveRow{
+		archiveRow(3, 4, base.AddDate(0, 1, 0), `[]`),
+		archiveRow(4, 4, base.AddDate(0, 1, 1), `[]`),
+	})
+	require.NoError(t, err)
+	archive.objects["older"] = older
+	archive.objects["newer"] = newer
+
+	archives := []db.MessageArchive{
+		{ObjectKey: "newer", FirstMessageAt: base.AddDate(0, 1, 0), LastMessageAt: base.AddDate(0, 1, 1), MessageCount: 2},
+		{ObjectKey: "older", FirstMessageAt: base, LastMessageAt: base.Add(time.Hour), MessageCount: 2},
+	}
+	store.EXPECT().ListMessageArchives(gomock.Any(), gomock.Any()).AnyTimes().Return(archives, nil)
+
+	conversation := channelArchiveConversation(3, 7)
+
+	// History reads newest first across archives
+	messages, err := archiveService.listMessages(context.Background(), conversation, 9, nil, nil, 3, 0)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+	require.Equal(t, int64(4), messages[0].ID)
+	require.Equal(t, int64(3), messages[1].ID)
+	require.Equal(t, int64(2), messages[2].ID)
+	require.True(t, messages[2].Archived)
+	require.Equal(t, []ReactionSummary{{Emoji: ":+1:", Count: 2, UserIDs: []int64{4, 9}, Reacted: true}}, messages[2].Reactions)
+	require.Equal(t, 2, archive.gets)
+
+	// An archive the offset skips entirely isn't fetched
+	archive.gets = 0
+	messages, err = archiveService.listMessages(context.Background(), conversation, 9, nil, nil, 10, 3)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, int64(1), messages[0].ID)
+	require.Equal(t, 1, archive.gets)
+
+	// Ranges filter messages within the archives
+	before := base.AddDate(0, 1, 1)
+	after := base
+	messages, err = archiveService.listMessages(context.Background(), conversation, 9, &before, &after, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, int64(3), messages[0].ID)
+	require.Equal(t, int64(2), messages[1].ID)
+}
+
+func TestArchiveService_ListLegalHoldMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockMessageStore(ctrl)
+	archive := &memoryArchiveStore{objects: map[string][]byte{}}
+	archiveService := NewArchiveService(store, archive, util.Config{})
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	data, err := encodeArchive(3, []db.ListMessagesToArchiveRow{
+		archiveRow(1, 4, base, `[]`),
+		archiveRow(2, 9, base.Add(time.Hour), `[]`),
+		archiveRow(3, 4, base.Add(2*time.Hour), `[]`),
+	})
+	require.NoError(t, err)
+	archive.objects["channel"] = data
+
+	hold := db.LegalHold{TargetType: LegalHoldTargetUser, TargetID: 4}
+	store.EXPECT().
+		ListLegalHoldMessageArchives(gomock.Any(), db.ListLegalHoldMessageArchivesParams{TargetType: LegalHoldTargetUser, TargetID: 4}).
+		Times(2).
+		Return([]db.MessageArchive{{ObjectKey: "channel", ChannelID: sql.NullInt64{Int64: 7, Valid: true}, MessageCount: 3}}, nil)
+
+	// A user hold covers only their messages in a channel's archive, oldest first
+	messages, err := archiveService.listLegalHoldMessages(context.Background(), hold, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, int64(1), messages[0].ID)
+	require.Equal(t, int64(3), messages[1].ID)
+	require.Equal(t, "Ada Lovelace", messages[1].SenderName)
+
+	messages, err = archiveService.listLegalHoldMessages(context.Background(), hold, 10, 1)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, int64(3), messages[0].ID)
+}
+
+func TestMessageService_HistoryContinuesIntoArchive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	archive := &memoryArchiveStore{objects: map[string][]byte{}}
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	data, err := encodeArchive(3, []db.ListMessagesToArchiveRow{
+		archiveRow(1, 4, base, `[]`),
+		archiveRow(2, 4, base.Add(time.Hour), `[]`),
+	})
+	require.NoError(t, err)
+	archive.objects["channel"] = data
+
+	store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).AnyTimes().Return("member", nil)
+	store.EXPECT().GetChannelByID(gomock.Any(), int64(7)).AnyTimes().Return(db.Channel{ID: 7, WorkspaceID: 3}, nil)
+	store.EXPECT().GetWorkspaceSettings(gomock.Any(), int64(3)).AnyTimes().Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+	store.EXPECT().ListReactionSummaries(gomock.Any(), gomock.Any()).AnyTimes().Return([]db.ListReactionSummariesRow{}, nil)
+	store.EXPECT().ListMessageThreads(gomock.Any(), gomock.Any()).AnyTimes().Return([]db.MessageThread{}, nil)
+	store.EXPECT().ListMessageAcknowledgmentStates(gomock.Any(), gomock.Any()).AnyTimes().Return([]db.ListMessageAcknowledgmentStatesRow{}, nil)
+	store.EXPECT().
+		ListMessageArchives(gomock.Any(), db.ListMessageArchivesParams{WorkspaceID: 3, ChannelID: sql.NullInt64{Int64: 7, Valid: true}}).
+		AnyTimes().
+		Return([]db.MessageArchive{{ObjectKey: "channel", FirstMessageAt: base, LastMessageAt: base.Add(time.Hour), MessageCount: 2}}, nil)
+
+	config := util.Config{}
+	messageService := NewMessageService(store, NewUserService(store, nil, config), nil, config)
+	messageService.SetArchive(NewArchiveService(store, archive, config))
+
+	// A page running past the database's messages is filled from the archive
+	store.EXPECT().
+		GetChannelMessages(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.GetChannelMessagesRow{{ID: 5, WorkspaceID: 3, ChannelID: sql.NullInt64{Int64: 7, Valid: true}, CreatedAt: base.AddDate(1, 0, 0)}}, nil)
+
+	messages, err := messageService.GetChannelMessages(context.Background(), 3, 7, 4, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	require.Equal(t, int64(5), messages[0].ID)
+	require.False(t, messages[0].Archived)
+	require.Equal(t, int64(2), messages[1].ID)
+	require.True(t, messages[1].Archived)
+
+	// The next page counts the database's messages to find where it is in
+	// the archive
+	store.EXPECT().GetChannelMessages(gomock.Any(), gomock.Any()).Times(1).Return([]db.GetChannelMessagesRow{}, nil)
+	store.EXPECT().CountChannelMessages(gomock.Any(), gomock.Any()).Times(1).Return(int64(1), nil)
+
+	messages, err = messageService.GetChannelMessages(context.Background(), 3, 7, 4, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	require.Equal(t, int64(1), messages[0].ID)
+}
+
+func TestS3ArchiveStore(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "20261017T090000Z", r.Header.Get("X-Amz-Date"))
+		require.NotEmpty(t, r.Header.Get("X-Amz-Content-Sha256"))
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/20261017/eu-west-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="))
+
+		switch r.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				http.Error(w, "NoSuchKey", http.StatusNotFound)
+				return
+			}
+			w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	archive := &s3ArchiveStore{
+		endpoint:    server.URL,
+		region:      "eu-west-1",
+		credentials: util.AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		client:      server.Client(),
+		now:         func() time.Time { return now },
+	}
+
+	require.NoError(t, archive.Put(context.Background(), "messages/workspace-3/channel-7/2025-01/10.jsonl.gz", []byte("archive")))
+	data, err := archive.Get(context.Background(), "messages/workspace-3/channel-7/2025-01/10.jsonl.gz")
+	require.NoError(t, err)
+	require.Equal(t, "archive", string(data))
+
+	_, err = archive.Get(context.Background(), "missing")
+	require.ErrorContains(t, err, "404")
+}
+
+func TestNewArchiveStore(t *testing.T) {
+	archive, err := NewArchiveStore(util.Config{ArchiveStoragePath: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, archive.Put(context.Background(), "messages/workspace-1/channel-2/2025-01/3.jsonl.gz", []byte("archive")))
+	data, err := archive.Get(context.Background(), "messages/workspace-1/channel-2/2025-01/3.jsonl.gz")
+	require.NoError(t, err)
+	require.Equal(t, "archive", string(data))
+
+	_, err = NewArchiveStore(util.Config{ArchiveStorage: ArchiveStorageS3})
+	require.ErrorContains(t, err, "AWS_S3_BUCKET")
+
+	_, err = NewArchiveStore(util.Config{ArchiveStorage: "tape"})
+	require.ErrorContains(t, err, "unknown archive storage")
+}
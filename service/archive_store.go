@@ -0,0 +1,141 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+)
+
+// Archive storage backends
+const (
+	ArchiveStorageLocal = "local"
+	ArchiveStorageS3    = "s3"
+)
+
+// archiveStoreTimeout bounds a single archive object upload or download
+const archiveStoreTimeout = 2 * time.Minute
+
+// ArchiveStore keeps archived message objects outside the database
+type ArchiveStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// NewArchiveStore creates the archive store chosen in the configuration,
+// archives are kept on the local filesystem unless S3 is chosen
+func NewArchiveStore(config util.Config) (ArchiveStore, error) {
+	switch config.ArchiveStorage {
+	case "", ArchiveStorageLocal:
+		root := config.ArchiveStoragePath
+		if root == "" {
+			root = "./archives"
+		}
+		return &localArchiveStore{root: root}, nil
+	case ArchiveStorageS3:
+		if config.AWSS3Bucket == "" || config.AWSRegion == "" || config.AWSAccessKeyID == "" || config.AWSSecretAccessKey == "" {
+			return nil, errors.New("AWS_S3_BUCKET, AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for s3 archive storage")
+		}
+		return &s3ArchiveStore{
+			endpoint: fmt.Sprintf("https://%s.s3.%s.amazonaws.com", config.AWSS3Bucket, config.AWSRegion),
+			region:   config.AWSRegion,
+			credentials: util.AWSCredentials{
+				AccessKeyID:     config.AWSAccessKeyID,
+				SecretAccessKey: config.AWSSecretAccessKey,
+				SessionToken:    config.AWSSessionToken,
+			},
+			client: &http.Client{Timeout: archiveStoreTimeout},
+			now:    time.Now,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown archive storage %q", config.ArchiveStorage)
+	}
+}
+
+// localArchiveStore keeps archives as files under a directory
+type localArchiveStore struct {
+	root string
+}
+
+func (s *localArchiveStore) Put(ctx context.Context, key string, data []byte) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Written under a temporary name so a partial file is never read
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *localArchiveStore) Get(ctx context.Context, key string) ([]byte, error) {
+	return os.ReadFile(s.path(key))
+}
+
+func (s *localArchiveStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+// s3ArchiveStore keeps archives as objects in an S3 bucket
+type s3ArchiveStore struct {
+	endpoint    string
+	region      string
+	credentials util.AWSCredentials
+	client      *http.Client
+	now         func() time.Time
+}
+
+func (s *s3ArchiveStore) Put(ctx context.Context, key string, data []byte) error {
+	response, err := s.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (s *s3ArchiveStore) Get(ctx context.Context, key string) ([]byte, error) {
+	response, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	return io.ReadAll(response.Body)
+}
+
+// do sends a signed request for an object and returns the response when it
+// succeeded
+func (s *s3ArchiveStore) do(ctx context.Context, method, key string, body []byte) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, method, s.endpoint+"/"+key, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/gzip")
+	sum := sha256.Sum256(body)
+	request.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+	util.SignAWSRequest(request, body, s.credentials, s.region, "s3", s.now())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return nil, fmt.Errorf("S3 returned %s for %s: %s", response.Status, key, strings.TrimSpace(string(message)))
+	}
+	return response, nil
+}
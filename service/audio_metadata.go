@@ -0,0 +1,583 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// waveformPeaks is the most peaks a voice message waveform has
+const waveformPeaks = 64
+
+// AudioMetadata is what's read from an audio file without decoding it
+type AudioMetadata struct {
+	Duration time.Duration
+	Waveform []int32 // Peaks scaled 0-100, only for uncompressed audio
+}
+
+// ReadAudioMetadata reads the duration of an audio file, and its waveform when
+// the samples are uncompressed. WAV, MP3, Ogg (Opus and Vorbis), WebM and
+// MP4 audio are understood.
+func ReadAudioMetadata(r io.ReadSeeker, size int64, mimeType string) (AudioMetadata, error) {
+	var duration time.Duration
+	var err error
+
+	switch mimeType {
+	case "audio/wav", "audio/x-wav", "audio/wave":
+		return readWAVMetadata(r)
+	case "audio/mpeg", "audio/mp3":
+		duration, err = readMP3Duration(r, size)
+	case "audio/ogg", "audio/opus":
+		duration, err = readOggDuration(r, size)
+	case "audio/webm":
+		duration, err = readWebMDuration(r)
+	case "audio/mp4", "audio/x-m4a", "audio/m4a":
+		duration, err = readMP4Duration(r, 0, size)
+	default:
+		return AudioMetadata{}, fmt.Errorf("unsupported audio type '%s'", mimeType)
+	}
+	if err != nil {
+		return AudioMetadata{}, err
+	}
+
+	return AudioMetadata{Duration: duration}, nil
+}
+
+// readWAVMetadata reads the format and data chunks of a WAV file. The
+// waveform is read from 8 and 16-bit PCM samples.
+func readWAVMetadata(r io.ReadSeeker) (AudioMetadata, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil || string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return AudioMetadata{}, errors.New("not a WAV file")
+	}
+
+	var format, channels, bitsPerSample uint16
+	var byteRate uint32
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return AudioMetadata{}, errors.New("WAV file has no audio data")
+		}
+		chunkSize := binary.LittleEndian.Uint32(chunk[4:8])
+
+		switch string(chunk[0:4]) {
+		case "fmt ":
+			if chunkSize < 16 {
+				return AudioMetadata{}, errors.New("invalid WAV format chunk")
+			}
+			fmtChunk := make([]byte, chunkSize+chunkSize%2)
+			if _, err := io.ReadFull(r, fmtChunk); err != nil {
+				return AudioMetadata{}, errors.New("invalid WAV format chunk")
+			}
+			format = binary.LittleEndian.Uint16(fmtChunk[0:2])
+			channels = binary.LittleEndian.Uint16(fmtChunk[2:4])
+			byteRate = binary.LittleEndian.Uint32(fmtChunk[8:12])
+			bitsPerSample = binary.LittleEndian.Uint16(fmtChunk[14:16])
+
+		case "data":
+			if byteRate == 0 {
+				return AudioMetadata{}, errors.New("WAV file has no format chunk")
+			}
+			metadata := AudioMetadata{
+				Duration: time.Duration(float64(chunkSize) / float64(byteRate) * float64(time.Second)),
+			}
+			// Only plain PCM can be read without a decoder
+			if format == 1 && (bitsPerSample == 8 || bitsPerSample == 16) {
+				metadata.Waveform = readPCMWaveform(io.LimitReader(r, int64(chunkSize)), int64(chunkSize), int(channels), int(bitsPerSample))
+			}
+			return metadata, nil
+
+		default:
+			// Chunks are padded to an even size
+			if _, err := r.Seek(int64(chunkSize+chunkSize%2), io.SeekCurrent); err != nil {
+				return AudioMetadata{}, err
+			}
+		}
+	}
+}
+
+// readPCMWaveform splits little-endian PCM samples into evenly sized buckets
+// and returns each bucket's peak, scaled so the loudest one is 100
+func readPCMWaveform(r io.Reader, dataSize int64, channels, bitsPerSample int) []int32 {
+	bytesPerSample := bitsPerSample / 8
+	frameSize := channels * bytesPerSample
+	if frameSize == 0 {
+		return nil
+	}
+	frames := dataSize / int64(frameSize)
+	if frames == 0 {
+		return nil
+	}
+	framesPerPeak := (frames + waveformPeaks - 1) / waveformPeaks
+
+	reader := bufio.NewReader(r)
+	frame := make([]byte, frameSize)
+	peaks := make([]int, 0, waveformPeaks)
+	peak, inBucket := 0, int64(0)
+	for i := int64(0); i < frames; i++ {
+		if _, err := io.ReadFull(reader, frame); err != nil {
+			break
+		}
+		for c := 0; c < channels; c++ {
+			var amplitude int
+			if bytesPerSample == 1 {
+				// 8-bit samples are unsigned around 128
+				amplitude = (int(frame[c]) - 128) * 256
+			} else {
+				amplitude = int(int16(binary.LittleEndian.Uint16(frame[c*2:])))
+			}
+			if amplitude < 0 {
+				amplitude = -amplitude
+			}
+			if amplitude > peak {
+				peak = amplitude
+			}
+		}
+
+		inBucket++
+		if inBucket == framesPerPeak {
+			peaks = append(peaks, peak)
+			peak, inBucket = 0, 0
+		}
+	}
+	if inBucket > 0 {
+		peaks = append(peaks, peak)
+	}
+
+	loudest := 0
+	for _, peak := range peaks {
+		if peak > loudest {
+			loudest = peak
+		}
+	}
+
+	waveform := make([]int32, len(peaks))
+	if loudest > 0 {
+		for i, peak := range peaks {
+			waveform[i] = int32(peak * 100 / loudest)
+		}
+	}
+	return waveform
+}
+
+// mp3Frame is what the duration needs from an MP3 frame header
+type mp3Frame struct {
+	bitrate         int64 // Bits per second
+	sampleRate      int64
+	samplesPerFrame int64
+	sideInfoSize    int
+}
+
+var (
+	mp3BitratesV1 = [3][15]int64{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448}, // Layer I
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},    // Layer II
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},     // Layer III
+	}
+	mp3BitratesV2 = [3][15]int64{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256}, // Layer I
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},      // Layer II
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},      // Layer III
+	}
+	mp3SampleRates = map[byte][3]int64{
+		3: {44100, 48000, 32000}, // MPEG 1
+		2: {22050, 24000, 16000}, // MPEG 2
+		0: {11025, 12000, 8000},  // MPEG 2.5
+	}
+)
+
+// parseMP3FrameHeader parses the 4-byte header at the start of data
+func parseMP3FrameHeader(data []byte) (mp3Frame, bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1]&0xE0 != 0xE0 {
+		return mp3Frame{}, false
+	}
+
+	version := (data[1] >> 3) & 0x03
+	layer := (data[1] >> 1) & 0x03
+	bitrateIndex := data[2] >> 4
+	sampleRateIndex := (data[2] >> 2) & 0x03
+	mono := data[3]>>6 == 3
+
+	sampleRates, ok := mp3SampleRates[version]
+	if !ok || layer == 0 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return mp3Frame{}, false
+	}
+
+	// Layer bits count down from Layer I = 3
+	layerIndex := 3 - int(layer)
+	frame := mp3Frame{sampleRate: sampleRates[sampleRateIndex]}
+	if version == 3 {
+		frame.bitrate = mp3BitratesV1[layerIndex][bitrateIndex] * 1000
+	} else {
+		frame.bitrate = mp3BitratesV2[layerIndex][bitrateIndex] * 1000
+	}
+
+	switch {
+	case layerIndex == 0:
+		frame.samplesPerFrame = 384
+	case layerIndex == 1 || version == 3:
+		frame.samplesPerFrame = 1152
+	default:
+		frame.samplesPerFrame = 576
+	}
+
+	switch {
+	case version == 3 && mono:
+		frame.sideInfoSize = 17
+	case version == 3:
+		frame.sideInfoSize = 32
+	case mono:
+		frame.sideInfoSize = 9
+	default:
+		frame.sideInfoSize = 17
+	}
+
+	return frame, true
+}
+
+// readMP3Duration reads the frame count from a Xing or VBRI header of a
+// variable bitrate file, and otherwise works the duration out from the
+// bitrate of the first frame
+func readMP3Duration(r io.ReadSeeker, size int64) (time.Duration, error) {
+	var tag [10]byte
+	if _, err := io.ReadFull(r, tag[:]); err != nil {
+		return 0, errors.New("not an MP3 file")
+	}
+
+	// Skip an ID3v2 tag, whose size is stored in 7-bit bytes
+	offset := int64(0)
+	if string(tag[0:3]) == "ID3" {
+		offset = 10 + (int64(tag[6]&0x7F)<<21 | int64(tag[7]&0x7F)<<14 | int64(tag[8]&0x7F)<<7 | int64(tag[9]&0x7F))
+		if tag[5]&0x10 != 0 {
+			offset += 10
+		}
+	}
+
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	data := make([]byte, 64*1024)
+	n, err := io.ReadFull(r, data)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return 0, errors.New("not an MP3 file")
+	}
+	data = data[:n]
+
+	for i := 0; i+4 <= len(data); i++ {
+		frame, ok := parseMP3FrameHeader(data[i:])
+		if !ok {
+			continue
+		}
+
+		if frames := mp3FrameCount(data[i:], frame); frames > 0 {
+			return time.Duration(frames * frame.samplesPerFrame * int64(time.Second) / frame.sampleRate), nil
+		}
+
+		audioBytes := size - offset - int64(i)
+		return time.Duration(audioBytes * 8 * int64(time.Second) / frame.bitrate), nil
+	}
+
+	return 0, errors.New("no MP3 frames found")
+}
+
+// mp3FrameCount reads the number of frames from the Xing, Info or VBRI header
+// in the first frame, or returns 0 if there is none
+func mp3FrameCount(data []byte, frame mp3Frame) int64 {
+	xing := 4 + frame.sideInfoSize
+	if len(data) >= xing+12 {
+		id := string(data[xing : xing+4])
+		flags := binary.BigEndian.Uint32(data[xing+4 : xing+8])
+		if (id == "Xing" || id == "Info") && flags&0x01 != 0 {
+			return int64(binary.BigEndian.Uint32(data[xing+8 : xing+12]))
+		}
+	}
+
+	const vbri = 4 + 32
+	if len(data) >= vbri+18 && string(data[vbri:vbri+4]) == "VBRI" {
+		return int64(binary.BigEndian.Uint32(data[vbri+14 : vbri+18]))
+	}
+
+	return 0
+}
+
+// readOggDuration takes the sample rate from the first page's codec header
+// and the number of samples from the granule position of the last page
+func readOggDuration(r io.ReadSeeker, size int64) (time.Duration, error) {
+	first := make([]byte, 512)
+	n, err := io.ReadFull(r, first)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return 0, errors.New("not an Ogg file")
+	}
+	first = first[:n]
+	if n < 27 || string(first[0:4]) != "OggS" || 27+int(first[26])+16 > n {
+		return 0, errors.New("not an Ogg file")
+	}
+	packet := first[27+int(first[26]):]
+
+	var sampleRate, preSkip int64
+	switch {
+	case bytes.HasPrefix(packet, []byte("OpusHead")):
+		// Opus granule positions always count 48 kHz samples
+		sampleRate = 48000
+		preSkip = int64(binary.LittleEndian.Uint16(packet[10:12]))
+	case bytes.HasPrefix(packet, []byte("\x01vorbis")):
+		sampleRate = int64(binary.LittleEndian.Uint32(packet[12:16]))
+	default:
+		return 0, errors.New("unsupported Ogg codec")
+	}
+	if sampleRate == 0 {
+		return 0, errors.New("invalid Ogg codec header")
+	}
+
+	tailSize := int64(64 * 1024)
+	if tailSize > size {
+		tailSize = size
+	}
+	if _, err := r.Seek(size-tailSize, io.SeekStart); err != nil {
+		return 0, err
+	}
+	tail := make([]byte, tailSize)
+	if _, err := io.ReadFull(r, tail); err != nil {
+		return 0, err
+	}
+
+	// Pages that don't end a packet have a granule position of -1
+	for i := bytes.LastIndex(tail, []byte("OggS")); i >= 0; i = bytes.LastIndex(tail[:i], []byte("OggS")) {
+		if i+14 > len(tail) {
+			continue
+		}
+		granule := int64(binary.LittleEndian.Uint64(tail[i+6 : i+14]))
+		if granule <= 0 {
+			continue
+		}
+		samples := granule - preSkip
+		if samples < 0 {
+			samples = 0
+		}
+		return time.Duration(samples * int64(time.Second) / sampleRate), nil
+	}
+
+	return 0, errors.New("Ogg file has no audio pages")
+}
+
+// EBML element IDs used by WebM
+const (
+	ebmlHeaderID        = 0x1A45DFA3
+	ebmlSegmentID       = 0x18538067
+	ebmlInfoID          = 0x1549A966
+	ebmlTimecodeScaleID = 0x2AD7B1
+	ebmlDurationID      = 0x4489
+	ebmlClusterID       = 0x1F43B675
+	ebmlTimecodeID      = 0xE7
+	ebmlBlockGroupID    = 0xA0
+	ebmlBlockID         = 0xA1
+	ebmlSimpleBlockID   = 0xA3
+)
+
+// readEBMLVint reads a variable length integer. IDs keep their length marker
+// bits, sizes don't. A size with every value bit set means unknown.
+func readEBMLVint(r *bufio.Reader, keepMarker bool) (value uint64, length int, unknown bool, err error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	length = bits.LeadingZeros8(first) + 1
+	if length > 8 {
+		return 0, 0, false, errors.New("invalid EBML integer")
+	}
+
+	mask := byte(0xFF >> length)
+	value = uint64(first)
+	if !keepMarker {
+		value = uint64(first & mask)
+	}
+	unknown = first&mask == mask
+	for i := 1; i < length; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, 0, false, err
+		}
+		value = value<<8 | uint64(b)
+		unknown = unknown && b == 0xFF
+	}
+
+	return value, length, unknown, nil
+}
+
+// readWebMDuration reads the duration from the segment info. Browsers
+// recording with MediaRecorder leave it out, so the timestamp of the last
+// block is used instead.
+func readWebMDuration(r io.Reader) (time.Duration, error) {
+	reader := bufio.NewReader(r)
+
+	id, _, _, err := readEBMLVint(reader, true)
+	if err != nil || id != ebmlHeaderID {
+		return 0, errors.New("not a WebM file")
+	}
+	size, _, _, err := readEBMLVint(reader, false)
+	if err != nil {
+		return 0, errors.New("not a WebM file")
+	}
+	if _, err := reader.Discard(int(size)); err != nil {
+		return 0, errors.New("not a WebM file")
+	}
+
+	// Timestamps are in milliseconds unless the segment says otherwise
+	scale := int64(time.Millisecond)
+	var duration float64
+	var clusterTime, lastBlock int64
+
+elements:
+	for {
+		id, _, _, err := readEBMLVint(reader, true)
+		if err != nil {
+			break
+		}
+		size, _, unknown, err := readEBMLVint(reader, false)
+		if err != nil {
+			break
+		}
+
+		// Step into the elements holding what's needed, which may be of unknown size
+		if id == ebmlSegmentID || id == ebmlInfoID || id == ebmlClusterID || id == ebmlBlockGroupID {
+			continue
+		}
+		if unknown || size > math.MaxInt32 {
+			break
+		}
+
+		readSize := int(size)
+		switch id {
+		case ebmlTimecodeScaleID, ebmlDurationID, ebmlTimecodeID:
+		case ebmlBlockID, ebmlSimpleBlockID:
+			// Blocks only need the track number and timestamp at the front
+			if readSize > 16 {
+				readSize = 16
+			}
+		default:
+			if _, err := reader.Discard(readSize); err != nil {
+				break elements
+			}
+			continue
+		}
+
+		data := make([]byte, readSize)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return 0, errors.New("truncated WebM file")
+		}
+		if _, err := reader.Discard(int(size) - readSize); err != nil {
+			return 0, errors.New("truncated WebM file")
+		}
+
+		switch id {
+		case ebmlTimecodeScaleID:
+			if value := int64(ebmlUint(data)); value > 0 {
+				scale = value
+			}
+		case ebmlDurationID:
+			switch len(data) {
+			case 4:
+				duration = float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+			case 8:
+				duration = math.Float64frombits(binary.BigEndian.Uint64(data))
+			}
+		case ebmlTimecodeID:
+			clusterTime = int64(ebmlUint(data))
+		case ebmlBlockID, ebmlSimpleBlockID:
+			// The track number comes first, then a timestamp relative to the cluster
+			if len(data) == 0 {
+				continue
+			}
+			trackLength := bits.LeadingZeros8(data[0]) + 1
+			if len(data) < trackLength+2 {
+				continue
+			}
+			offset := int64(int16(binary.BigEndian.Uint16(data[trackLength : trackLength+2])))
+			if clusterTime+offset > lastBlock {
+				lastBlock = clusterTime + offset
+			}
+		}
+	}
+
+	if duration > 0 {
+		return time.Duration(duration * float64(scale)), nil
+	}
+	if lastBlock > 0 {
+		return time.Duration(lastBlock * scale), nil
+	}
+	return 0, errors.New("WebM file has no duration")
+}
+
+// ebmlUint decodes a big-endian unsigned integer of up to 8 bytes
+func ebmlUint(data []byte) uint64 {
+	var value uint64
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+	return value
+}
+
+// readMP4Duration finds the movie header box inside the movie box and reads
+// its duration
+func readMP4Duration(r io.ReadSeeker, start, end int64) (time.Duration, error) {
+	for offset := start; offset+8 <= end; {
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return 0, err
+		}
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return 0, errors.New("invalid MP4 file")
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(header[0:4]))
+		boxType := string(header[4:8])
+		headerSize := int64(8)
+		switch boxSize {
+		case 0:
+			// The box runs to the end of the file
+			boxSize = end - offset
+		case 1:
+			var largeSize [8]byte
+			if _, err := io.ReadFull(r, largeSize[:]); err != nil {
+				return 0, errors.New("invalid MP4 file")
+			}
+			boxSize = int64(binary.BigEndian.Uint64(largeSize[:]))
+			headerSize = 16
+		}
+		if boxSize < headerSize {
+			return 0, errors.New("invalid MP4 file")
+		}
+
+		switch boxType {
+		case "moov":
+			return readMP4Duration(r, offset+headerSize, offset+boxSize)
+		case "mvhd":
+			var body [32]byte
+			if _, err := io.ReadFull(r, body[:]); err != nil {
+				return 0, errors.New("invalid MP4 movie header")
+			}
+			var timescale, duration uint64
+			if body[0] == 1 {
+				timescale = uint64(binary.BigEndian.Uint32(body[20:24]))
+				duration = binary.BigEndian.Uint64(body[24:32])
+			} else {
+				timescale = uint64(binary.BigEndian.Uint32(body[12:16]))
+				duration = uint64(binary.BigEndian.Uint32(body[16:20]))
+			}
+			if timescale == 0 {
+				return 0, errors.New("invalid MP4 movie header")
+			}
+			return time.Duration(float64(duration) / float64(timescale) * float64(time.Second)), nil
+		}
+
+		offset += boxSize
+	}
+
+	return 0, errors.New("MP4 file has no movie header")
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// wavFile builds a 16-bit mono PCM WAV file from samples
+func wavFile(sampleRate uint32, samples []int16) []byte {
+	var buf bytes.Buffer
+	dataSize := uint32(len(samples) * 2)
+
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, 36+dataSize)
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	binary.Write(&buf, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1)) // mono
+	binary.Write(&buf, binary.LittleEndian, sampleRate)
+	binary.Write(&buf, binary.LittleEndian, sampleRate*2)
+	binary.Write(&buf, binary.LittleEndian, uint16(2))
+	binary.Write(&buf, binary.LittleEndian, uint16(16))
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, dataSize)
+	binary.Write(&buf, binary.LittleEndian, samples)
+
+	return buf.Bytes()
+}
+
+func TestReadAudioMetadata_WAV(t *testing.T) {
+	// One second of silence followed by one second at full volume
+	samples := make([]int16, 16000)
+	for i := 8000; i < len(samples); i++ {
+		samples[i] = 32000
+	}
+	data := wavFile(8000, samples)
+
+	metadata, err := ReadAudioMetadata(bytes.NewReader(data), int64(len(data)), "audio/wav")
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, metadata.Duration)
+	require.Len(t, metadata.Waveform, waveformPeaks)
+	require.Equal(t, int32(0), metadata.Waveform[0])
+	require.Equal(t, int32(100), metadata.Waveform[waveformPeaks-1])
+}
+
+func TestReadAudioMetadata_MP3(t *testing.T) {
+	// MPEG-1 Layer III at 128 kbps and 44.1 kHz, without a Xing header
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+	data := bytes.Repeat(frame, 100)
+
+	metadata, err := ReadAudioMetadata(bytes.NewReader(data), int64(len(data)), "audio/mpeg")
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(len(data)*8)*time.Second/128000, metadata.Duration)
+	require.Empty(t, metadata.Waveform)
+}
+
+func TestReadAudioMetadata_MP4(t *testing.T) {
+	mvhd := make([]byte, 8+100)
+	binary.BigEndian.PutUint32(mvhd[0:4], uint32(len(mvhd)))
+	copy(mvhd[4:8], "mvhd")
+	binary.BigEndian.PutUint32(mvhd[20:24], 1000) // timescale
+	binary.BigEndian.PutUint32(mvhd[24:28], 3500) // duration
+
+	moov := make([]byte, 8, 8+len(mvhd))
+	binary.BigEndian.PutUint32(moov[0:4], uint32(8+len(mvhd)))
+	copy(moov[4:8], "moov")
+	moov = append(moov, mvhd...)
+
+	ftyp := []byte{0, 0, 0, 16, 'f', 't', 'y', 'p', 'M', '4', 'A', ' ', 0, 0, 0, 0}
+	data := append(ftyp, moov...)
+
+	metadata, err := ReadAudioMetadata(bytes.NewReader(data), int64(len(data)), "audio/mp4")
+	require.NoError(t, err)
+	require.Equal(t, 3500*time.Millisecond, metadata.Duration)
+}
+
+func TestReadAudioMetadata_Invalid(t *testing.T) {
+	data := []byte("definitely not audio")
+
+	_, err := ReadAudioMetadata(bytes.NewReader(data), int64(len(data)), "audio/wav")
+	require.EqualError(t, err, "not a WAV file")
+
+	_, err = ReadAudioMetadata(bytes.NewReader(data), int64(len(data)), "audio/flac")
+	require.EqualError(t, err, "unsupported audio type 'audio/flac'")
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// maxAvatarSize is the largest avatar image that can be uploaded
+const maxAvatarSize = 5 << 20 // 5MB
+
+// AvatarSizes are the square sizes in pixels avatars are stored in
+var AvatarSizes = []int{32, 72, 192, 512}
+
+// avatarKeyPattern matches the random keys avatar images are stored under
+var avatarKeyPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// AvatarResponse represents a user's avatar in API responses. Without an
+// avatar image clients show the initials.
+type AvatarResponse struct {
+	AvatarURLs map[string]string `json:"avatar_urls,omitempty"`
+	Initials   string            `json:"initials"`
+}
+
+// UploadAvatar crops an image to a square, stores it in every avatar size and
+// makes it the user's avatar, replacing the previous one
+func (s *FileService) UploadAvatar(ctx context.Context, userID int64, header *multipart.FileHeader) (*AvatarResponse, error) {
+	if header.Size == 0 {
+		return nil, errors.New("invalid avatar: file cannot be empty")
+	}
+	if header.Size > maxAvatarSize {
+		return nil, fmt.Errorf("invalid avatar: image exceeds maximum allowed size of %d bytes", maxAvatarSize)
+	}
+
+	src, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	img, _, err := decodeImage(src)
+	if err != nil {
+		return nil, errors.New("invalid avatar: image must be a JPEG, PNG or GIF")
+	}
+
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := os.MkdirAll(s.avatarDirectory(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create avatar directory: %w", err)
+	}
+
+	// A new key per upload lets clients cache avatar images forever
+	key := strings.ReplaceAll(uuid.New().String(), "-", "")
+	square := cropSquare(img)
+	for _, size := range AvatarSizes {
+		if err := writeImage(s.avatarPath(key, size), resizeImage(square, size, size), "png"); err != nil {
+			s.removeAvatarFiles(key)
+			return nil, fmt.Errorf("failed to save avatar: %w", err)
+		}
+	}
+
+	updated, err := s.store.UpdateUserAvatar(ctx, db.UpdateUserAvatarParams{
+		ID:        userID,
+		AvatarKey: sql.NullString{String: key, Valid: true},
+	})
+	if err != nil {
+		s.removeAvatarFiles(key)
+		return nil, fmt.Errorf("failed to update avatar: %w", err)
+	}
+
+	if user.AvatarKey.Valid {
+		s.removeAvatarFiles(user.AvatarKey.String)
+	}
+
+	return &AvatarResponse{
+		AvatarURLs: avatarURLs(updated.AvatarKey),
+		Initials:   userInitials(updated.FirstName, updated.LastName),
+	}, nil
+}
+
+// DeleteAvatar removes a user's avatar so their initials are shown instead
+func (s *FileService) DeleteAvatar(ctx context.Context, userID int64) (*AvatarResponse, error) {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.AvatarKey.Valid {
+		if _, err := s.store.UpdateUserAvatar(ctx, db.UpdateUserAvatarParams{ID: userID}); err != nil {
+			return nil, fmt.Errorf("failed to delete avatar: %w", err)
+		}
+		s.removeAvatarFiles(user.AvatarKey.String)
+	}
+
+	return &AvatarResponse{Initials: userInitials(user.FirstName, user.LastName)}, nil
+}
+
+// OpenAvatar opens an avatar image in one of the avatar sizes
+func (s *FileService) OpenAvatar(key string, size int) (*os.File, error) {
+	if !avatarKeyPattern.MatchString(key) || !isAvatarSize(size) {
+		return nil, errors.New("avatar not found")
+	}
+
+	file, err := os.Open(s.avatarPath(key, size))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New("avatar not found")
+		}
+		return nil, fmt.Errorf("failed to open avatar: %w", err)
+	}
+
+	return file, nil
+}
+
+func (s *FileService) avatarDirectory() string {
+	return filepath.Join(s.config.FileStoragePath, "avatars")
+}
+
+func (s *FileService) avatarPath(key string, size int) string {
+	return filepath.Join(s.avatarDirectory(), fmt.Sprintf("%s_%d.png", key, size))
+}
+
+// removeAvatarFiles deletes every size of an avatar from disk
+func (s *FileService) removeAvatarFiles(key string) {
+	for _, size := range AvatarSizes {
+		if err := os.Remove(s.avatarPath(key, size)); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("Warning: failed to delete avatar from disk: %v\n", err)
+		}
+	}
+}
+
+func isAvatarSize(size int) bool {
+	for _, avatarSize := range AvatarSizes {
+		if size == avatarSize {
+			return true
+		}
+	}
+	return false
+}
+
+// avatarURLs returns the URLs of each avatar size keyed by size, or nil when
+// the user has no avatar
+func avatarURLs(key sql.NullString) map[string]string {
+	if !key.Valid {
+		return nil
+	}
+
+	urls := make(map[string]string, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		urls[strconv.Itoa(size)] = fmt.Sprintf("/avatars/%s/%d", key.String, size)
+	}
+	return urls
+}
+
+// userInitials returns the uppercase first letters of a user's names
+func userInitials(firstName, lastName string) string {
+	var initials []rune
+	for _, name := range []string{firstName, lastName} {
+		if r, _ := utf8.DecodeRuneInString(strings.TrimSpace(name)); r != utf8.RuneError {
+			initials = append(initials, unicode.ToUpper(r))
+		}
+	}
+	return string(initials)
+}
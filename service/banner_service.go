@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// WSBannerUpdated is sent to the whole workspace when a banner is published,
+// changed or deleted. Banners that expire are not announced; clients hide
+// them once their expiry passes.
+const WSBannerUpdated = "banner_updated"
+
+// Banner severities
+const (
+	BannerSeverityInfo     = "info"
+	BannerSeverityWarning  = "warning"
+	BannerSeverityCritical = "critical"
+)
+
+// BannerService handles the announcement banners workspace admins publish to
+// all members of a workspace
+type BannerService struct {
+	store db.WorkspaceStore
+	hub   WebSocketHub
+	now   func() time.Time
+}
+
+// NewBannerService creates a new banner service
+func NewBannerService(store db.WorkspaceStore, hub WebSocketHub) *BannerService {
+	return &BannerService{
+		store: store,
+		hub:   hub,
+		now:   time.Now,
+	}
+}
+
+// ListActiveBanners lists the banners of a workspace that have not expired,
+// most recent first
+func (s *BannerService) ListActiveBanners(ctx context.Context, workspaceID int64) ([]WorkspaceBannerResponse, error) {
+	banners, err := s.store.ListActiveWorkspaceBanners(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list banners: %w", err)
+	}
+
+	return toWorkspaceBannerResponses(banners), nil
+}
+
+// ListBanners lists the banners of a workspace. Expired banners are only
+// listed for users who can manage the workspace.
+func (s *BannerService) ListBanners(ctx context.Context, workspaceID, userID int64, includeExpired bool) ([]WorkspaceBannerResponse, error) {
+	if !includeExpired {
+		return s.ListActiveBanners(ctx, workspaceID)
+	}
+
+	allowed, err := hasWorkspacePermission(ctx, s.store, userID, workspaceID, PermissionManageWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("access denied: only workspace managers can list expired banners")
+	}
+
+	banners, err := s.store.ListWorkspaceBanners(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list banners: %w", err)
+	}
+
+	return toWorkspaceBannerResponses(banners), nil
+}
+
+// CreateBanner publishes a banner to every member of a workspace
+func (s *BannerService) CreateBanner(ctx context.Context, workspaceID, userID int64, req WorkspaceBannerRequest) (WorkspaceBannerResponse, error) {
+	arg, err := s.bannerParams(req)
+	if err != nil {
+		return WorkspaceBannerResponse{}, err
+	}
+
+	banner, err := s.store.CreateWorkspaceBanner(ctx, db.CreateWorkspaceBannerParams{
+		WorkspaceID: workspaceID,
+		Text:        arg.Text,
+		Severity:    arg.Severity,
+		ExpiresAt:   arg.ExpiresAt,
+		CreatedBy:   sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		return WorkspaceBannerResponse{}, fmt.Errorf("failed to create banner: %w", err)
+	}
+
+	response := toWorkspaceBannerResponse(banner)
+	s.broadcast(workspaceID, BannerEvent{Action: "created", BannerID: banner.ID, Banner: &response, UserID: userID})
+
+	return response, nil
+}
+
+// UpdateBanner replaces the text, severity and expiry of a banner
+func (s *BannerService) UpdateBanner(ctx context.Context, workspaceID, bannerID, userID int64, req WorkspaceBannerRequest) (WorkspaceBannerResponse, error) {
+	arg, err := s.bannerParams(req)
+	if err != nil {
+		return WorkspaceBannerResponse{}, err
+	}
+
+	banner, err := s.store.UpdateWorkspaceBanner(ctx, db.UpdateWorkspaceBannerParams{
+		ID:          bannerID,
+		WorkspaceID: workspaceID,
+		Text:        arg.Text,
+		Severity:    arg.Severity,
+		ExpiresAt:   arg.ExpiresAt,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WorkspaceBannerResponse{}, errors.New("banner not found")
+		}
+		return WorkspaceBannerResponse{}, fmt.Errorf("failed to update banner: %w", err)
+	}
+
+	response := toWorkspaceBannerResponse(banner)
+	s.broadcast(workspaceID, BannerEvent{Action: "updated", BannerID: banner.ID, Banner: &response, UserID: userID})
+
+	return response, nil
+}
+
+// DeleteBanner takes a banner down
+func (s *BannerService) DeleteBanner(ctx context.Context, workspaceID, bannerID, userID int64) error {
+	rows, err := s.store.DeleteWorkspaceBanner(ctx, db.DeleteWorkspaceBannerParams{
+		ID:          bannerID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete banner: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("banner not found")
+	}
+
+	s.broadcast(workspaceID, BannerEvent{Action: "deleted", BannerID: bannerID, UserID: userID})
+
+	return nil
+}
+
+// bannerParams validates a banner request. The workspace and banner IDs of
+// the returned params are left for the caller to fill in.
+func (s *BannerService) bannerParams(req WorkspaceBannerRequest) (db.UpdateWorkspaceBannerParams, error) {
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return db.UpdateWorkspaceBannerParams{}, errors.New("invalid banner: text is required")
+	}
+
+	severity := req.Severity
+	if severity == "" {
+		severity = BannerSeverityInfo
+	}
+
+	var expiresAt sql.NullTime
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(s.now()) {
+			return db.UpdateWorkspaceBannerParams{}, errors.New("invalid banner: expiry must be in the future")
+		}
+		expiresAt = sql.NullTime{Time: *req.ExpiresAt, Valid: true}
+	}
+
+	return db.UpdateWorkspaceBannerParams{
+		Text:      text,
+		Severity:  severity,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *BannerService) broadcast(workspaceID int64, event BannerEvent) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.BroadcastToWorkspace(workspaceID, &WSMessage{
+		Type:        WSBannerUpdated,
+		Data:        event,
+		WorkspaceID: workspaceID,
+		UserID:      event.UserID,
+		Timestamp:   time.Now(),
+	})
+}
+
+func toWorkspaceBannerResponses(banners []db.WorkspaceBanner) []WorkspaceBannerResponse {
+	responses := make([]WorkspaceBannerResponse, len(banners))
+	for i, banner := range banners {
+		responses[i] = toWorkspaceBannerResponse(banner)
+	}
+	return responses
+}
+
+func toWorkspaceBannerResponse(banner db.WorkspaceBanner) WorkspaceBannerResponse {
+	response := WorkspaceBannerResponse{
+		ID:          banner.ID,
+		WorkspaceID: banner.WorkspaceID,
+		Text:        banner.Text,
+		Severity:    banner.Severity,
+		CreatedAt:   banner.CreatedAt,
+		UpdatedAt:   banner.UpdatedAt,
+	}
+	if banner.ExpiresAt.Valid {
+		expiresAt := banner.ExpiresAt.Time
+		response.ExpiresAt = &expiresAt
+	}
+	if banner.CreatedBy.Valid {
+		createdBy := banner.CreatedBy.Int64
+		response.CreatedBy = &createdBy
+	}
+	return response
+}
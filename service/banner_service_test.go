@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBannerService_Broadcasts(t *testing.T) {
+	const workspaceID, userID = int64(3), int64(9)
+	now := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(2 * time.Hour)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	hub := &recordingHub{}
+	bannerService := NewBannerService(store, hub)
+	bannerService.now = func() time.Time { return now }
+
+	banner := db.WorkspaceBanner{
+		ID:          4,
+		WorkspaceID: workspaceID,
+		Text:        "Quarterly all-hands at 3pm",
+		Severity:    BannerSeverityInfo,
+		ExpiresAt:   sql.NullTime{Time: expiresAt, Valid: true},
+		CreatedBy:   sql.NullInt64{Int64: userID, Valid: true},
+	}
+	store.EXPECT().
+		CreateWorkspaceBanner(gomock.Any(), gomock.Eq(db.CreateWorkspaceBannerParams{
+			WorkspaceID: workspaceID,
+			Text:        banner.Text,
+			Severity:    BannerSeverityInfo,
+			ExpiresAt:   banner.ExpiresAt,
+			CreatedBy:   banner.CreatedBy,
+		})).
+		Times(1).
+		Return(banner, nil)
+
+	created, err := bannerService.CreateBanner(ctx, workspaceID, userID, WorkspaceBannerRequest{Text: banner.Text, ExpiresAt: &expiresAt})
+	require.NoError(t, err)
+	require.Equal(t, expiresAt, *created.ExpiresAt)
+
+	updated := banner
+	updated.Severity = BannerSeverityCritical
+	updated.ExpiresAt = sql.NullTime{}
+	store.EXPECT().
+		UpdateWorkspaceBanner(gomock.Any(), gomock.Eq(db.UpdateWorkspaceBannerParams{
+			ID:          banner.ID,
+			WorkspaceID: workspaceID,
+			Text:        banner.Text,
+			Severity:    BannerSeverityCritical,
+		})).
+		Times(1).
+		Return(updated, nil)
+
+	response, err := bannerService.UpdateBanner(ctx, workspaceID, banner.ID, userID, WorkspaceBannerRequest{Text: banner.Text, Severity: BannerSeverityCritical})
+	require.NoError(t, err)
+	require.Nil(t, response.ExpiresAt)
+
+	store.EXPECT().
+		DeleteWorkspaceBanner(gomock.Any(), gomock.Eq(db.DeleteWorkspaceBannerParams{ID: banner.ID, WorkspaceID: workspaceID})).
+		Times(1).
+		Return(int64(1), nil)
+
+	require.NoError(t, bannerService.DeleteBanner(ctx, workspaceID, banner.ID, userID))
+
+	// Every change is announced to the whole workspace
+	messages := hub.workspaceMessages[workspaceID]
+	require.Len(t, messages, 3)
+	for i, action := range []string{"created", "updated", "deleted"} {
+		require.Equal(t, WSBannerUpdated, messages[i].Type)
+		event := messages[i].Data.(BannerEvent)
+		require.Equal(t, action, event.Action)
+		require.Equal(t, banner.ID, event.BannerID)
+		require.Equal(t, action == "deleted", event.Banner == nil)
+	}
+}
+
+func TestBannerService_Validation(t *testing.T) {
+	now := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	bannerService := NewBannerService(store, nil)
+	bannerService.now = func() time.Time { return now }
+
+	_, err := bannerService.UpdateBanner(context.Background(), 1, 2, 3, WorkspaceBannerRequest{Text: " "})
+	require.EqualError(t, err, "invalid banner: text is required")
+
+	_, err = bannerService.UpdateBanner(context.Background(), 1, 2, 3, WorkspaceBannerRequest{Text: "Hi", ExpiresAt: &now})
+	require.EqualError(t, err, "invalid banner: expiry must be in the future")
+
+	store.EXPECT().
+		UpdateWorkspaceBanner(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.WorkspaceBanner{}, sql.ErrNoRows)
+
+	_, err = bannerService.UpdateBanner(context.Background(), 1, 2, 3, WorkspaceBannerRequest{Text: "Hi"})
+	require.EqualError(t, err, "banner not found")
+}
@@ -0,0 +1,547 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+const (
+	// Status shown while a user is in a meeting from their calendar
+	calendarMeetingStatus = "In a meeting"
+	calendarMeetingEmoji  = "📅"
+
+	// How far ahead busy blocks are ingested from a calendar feed
+	calendarSyncWindow = 7 * 24 * time.Hour
+	// Upper bound on the number of busy blocks kept per calendar
+	maxCalendarBusyBlocks = 500
+	// Upper bound on the size of a calendar feed
+	maxCalendarFeedSize = 5 << 20
+
+	icsDateTimeLayout    = "20060102T150405"
+	icsDateTimeUTCLayout = "20060102T150405Z"
+)
+
+// CalendarService handles calendar integrations and the automatic meeting status
+type CalendarService struct {
+	store         db.UserStore
+	statusService *StatusService
+	client        *http.Client
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(store db.UserStore, statusService *StatusService) *CalendarService {
+	return &CalendarService{
+		store:         store,
+		statusService: statusService,
+		client:        &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SetCalendarIntegration connects an ICS calendar feed for a user in a workspace,
+// or updates the feed and opt-in of an existing one
+func (s *CalendarService) SetCalendarIntegration(ctx context.Context, userID, workspaceID int64, req SetCalendarIntegrationRequest) (*CalendarIntegrationResponse, error) {
+	icsURL, err := normalizeCalendarURL(req.ICSURL)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	integration, err := s.store.UpsertCalendarIntegration(ctx, db.UpsertCalendarIntegrationParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		IcsUrl:      icsURL,
+		Enabled:     enabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save calendar integration: %w", err)
+	}
+
+	return toCalendarIntegrationResponse(integration, nil), nil
+}
+
+// GetCalendarIntegration returns a user's calendar integration with its upcoming busy blocks
+func (s *CalendarService) GetCalendarIntegration(ctx context.Context, userID, workspaceID int64) (*CalendarIntegrationResponse, error) {
+	integration, err := s.getCalendarIntegration(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.store.ListUpcomingCalendarBusyBlocks(ctx, db.ListUpcomingCalendarBusyBlocksParams{
+		IntegrationID: integration.ID,
+		Limit:         20,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list busy blocks: %w", err)
+	}
+
+	return toCalendarIntegrationResponse(integration, blocks), nil
+}
+
+// DeleteCalendarIntegration disconnects a user's calendar
+func (s *CalendarService) DeleteCalendarIntegration(ctx context.Context, userID, workspaceID int64) error {
+	rows, err := s.store.DeleteCalendarIntegration(ctx, db.DeleteCalendarIntegrationParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar integration: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("calendar integration not found")
+	}
+
+	return nil
+}
+
+// SyncCalendar fetches a user's calendar right away and applies a meeting in progress
+func (s *CalendarService) SyncCalendar(ctx context.Context, userID, workspaceID int64) (*CalendarIntegrationResponse, error) {
+	integration, err := s.getCalendarIntegration(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.syncIntegration(ctx, integration); err != nil {
+		return nil, err
+	}
+
+	if err := s.ApplyCalendarStatuses(ctx); err != nil {
+		return nil, err
+	}
+
+	return s.GetCalendarIntegration(ctx, userID, workspaceID)
+}
+
+// SyncCalendars ingests the busy blocks of every enabled calendar. A failing
+// feed is recorded on its integration and does not stop the others.
+func (s *CalendarService) SyncCalendars(ctx context.Context) error {
+	integrations, err := s.store.ListEnabledCalendarIntegrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list calendar integrations: %w", err)
+	}
+
+	for _, integration := range integrations {
+		if err := s.syncIntegration(ctx, integration); err != nil {
+			fmt.Printf("Error syncing calendar %d: %v\n", integration.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ApplyCalendarStatuses sets the meeting status for users whose calendar shows
+// them busy right now. The status clears itself when the meeting ends.
+func (s *CalendarService) ApplyCalendarStatuses(ctx context.Context) error {
+	activeBlocks, err := s.store.GetActiveCalendarBusyBlocks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get active busy blocks: %w", err)
+	}
+
+	for _, block := range activeBlocks {
+		userStatus, err := s.store.SetCalendarBusyStatus(ctx, db.SetCalendarBusyStatusParams{
+			UserID:       block.UserID,
+			WorkspaceID:  block.WorkspaceID,
+			CustomStatus: sql.NullString{String: calendarMeetingStatus, Valid: true},
+			StatusEmoji:  sql.NullString{String: calendarMeetingEmoji, Valid: true},
+			ClearAfter:   sql.NullTime{Time: block.EndsAt, Valid: true},
+		})
+		if err != nil {
+			// No rows means the user chose their own status or is already in this meeting
+			if err != sql.ErrNoRows {
+				fmt.Printf("Error setting meeting status for user %d: %v\n", block.UserID, err)
+			}
+			continue
+		}
+
+		s.statusService.broadcastStatus(ctx, userStatus)
+	}
+
+	return nil
+}
+
+// StartCalendarSync starts a background loop that ingests calendar feeds and
+// keeps meeting statuses in step with them
+func (s *CalendarService) StartCalendarSync(ctx context.Context, syncInterval time.Duration) {
+	if syncInterval <= 0 {
+		syncInterval = 15 * time.Minute
+	}
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	var lastSync time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if now.Sub(lastSync) >= syncInterval {
+				if err := s.SyncCalendars(ctx); err != nil {
+					fmt.Printf("Error syncing calendars: %v\n", err)
+				}
+				lastSync = now
+			}
+
+			// Revert meeting statuses that have ended before applying current ones
+			if err := s.statusService.ClearExpiredStatuses(ctx); err != nil {
+				fmt.Printf("Error clearing expired statuses: %v\n", err)
+			}
+			if err := s.ApplyCalendarStatuses(ctx); err != nil {
+				fmt.Printf("Error applying calendar statuses: %v\n", err)
+			}
+		}
+	}
+}
+
+// syncIntegration fetches a calendar feed and replaces the stored busy blocks
+func (s *CalendarService) syncIntegration(ctx context.Context, integration db.CalendarIntegration) error {
+	now := time.Now()
+	blocks, err := s.fetchBusyBlocks(ctx, integration.IcsUrl, now, now.Add(calendarSyncWindow))
+	if err != nil {
+		// Keep the previous blocks and record why the sync failed
+		recordErr := s.store.UpdateCalendarIntegrationSync(ctx, db.UpdateCalendarIntegrationSyncParams{
+			ID:            integration.ID,
+			LastSyncError: sql.NullString{String: err.Error(), Valid: true},
+		})
+		if recordErr != nil {
+			fmt.Printf("Error recording calendar sync failure: %v\n", recordErr)
+		}
+		return err
+	}
+
+	err = s.store.ReplaceCalendarBusyBlocksTx(ctx, db.ReplaceCalendarBusyBlocksTxParams{
+		IntegrationID: integration.ID,
+		Blocks:        blocks,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store busy blocks: %w", err)
+	}
+
+	return nil
+}
+
+// fetchBusyBlocks downloads an ICS feed and parses its busy blocks
+func (s *CalendarService) fetchBusyBlocks(ctx context.Context, icsURL string, from, to time.Time) ([]db.CalendarBusyBlockParams, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, icsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch calendar: %w", err)
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch calendar: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch calendar: unexpected status %d", resp.StatusCode)
+	}
+
+	return ParseICSBusyBlocks(io.LimitReader(resp.Body, maxCalendarFeedSize), from, to)
+}
+
+func (s *CalendarService) getCalendarIntegration(ctx context.Context, userID, workspaceID int64) (db.CalendarIntegration, error) {
+	integration, err := s.store.GetCalendarIntegration(ctx, db.GetCalendarIntegrationParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.CalendarIntegration{}, errors.New("calendar integration not found")
+		}
+		return db.CalendarIntegration{}, fmt.Errorf("failed to get calendar integration: %w", err)
+	}
+
+	return integration, nil
+}
+
+// normalizeCalendarURL validates an ICS feed URL. webcal:// links, as handed out
+// by most calendar apps, are fetched over https.
+func normalizeCalendarURL(raw string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" {
+		return "", errors.New("invalid calendar URL")
+	}
+
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+	case "webcal", "webcals":
+		parsed.Scheme = "https"
+	default:
+		return "", errors.New("invalid calendar URL: scheme must be http, https or webcal")
+	}
+
+	return parsed.String(), nil
+}
+
+// icsProperty is a single unfolded content line of an iCalendar feed
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// ParseICSBusyBlocks extracts the busy blocks between from and to from an
+// iCalendar feed. Both VEVENT entries and VFREEBUSY periods are read. Events
+// marked free (TRANSP:TRANSPARENT) or cancelled are skipped, as are all-day
+// events. Recurring events are expected to be expanded by the feed.
+func ParseICSBusyBlocks(r io.Reader, from, to time.Time) ([]db.CalendarBusyBlockParams, error) {
+	properties, err := readICSProperties(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []db.CalendarBusyBlockParams
+	addBlock := func(start, end time.Time) {
+		if !start.Before(end) || !end.After(from) || !start.Before(to) {
+			return
+		}
+		if len(blocks) < maxCalendarBusyBlocks {
+			blocks = append(blocks, db.CalendarBusyBlockParams{StartsAt: start.UTC(), EndsAt: end.UTC()})
+		}
+	}
+
+	var event map[string]icsProperty
+	for _, property := range properties {
+		switch {
+		case property.name == "BEGIN" && property.value == "VEVENT":
+			event = make(map[string]icsProperty)
+		case property.name == "END" && property.value == "VEVENT":
+			if start, end, ok := icsEventPeriod(event); ok {
+				addBlock(start, end)
+			}
+			event = nil
+		case event != nil:
+			event[property.name] = property
+		case property.name == "FREEBUSY":
+			if fbType := property.params["FBTYPE"]; fbType != "" && !strings.HasPrefix(fbType, "BUSY") {
+				continue
+			}
+			for _, period := range strings.Split(property.value, ",") {
+				if start, end, ok := parseICSPeriod(period); ok {
+					addBlock(start, end)
+				}
+			}
+		}
+	}
+
+	return blocks, nil
+}
+
+// readICSProperties reads an iCalendar feed into its unfolded content lines
+func readICSProperties(r io.Reader) ([]icsProperty, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxCalendarFeedSize)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// Lines starting with whitespace continue the previous line
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	properties := make([]icsProperty, 0, len(lines))
+	for _, line := range lines {
+		if property, ok := parseICSLine(line); ok {
+			properties = append(properties, property)
+		}
+	}
+
+	return properties, nil
+}
+
+// parseICSLine splits a content line into its name, parameters and value
+func parseICSLine(line string) (icsProperty, bool) {
+	// The value starts at the first colon outside a quoted parameter value
+	inQuotes := false
+	colon := -1
+	for i, c := range line {
+		if c == '"' {
+			inQuotes = !inQuotes
+		} else if c == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return icsProperty{}, false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	property := icsProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  line[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		key, value, found := strings.Cut(param, "=")
+		if found {
+			property.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+
+	return property, true
+}
+
+// icsEventPeriod returns the busy period of an event, if it makes the user busy
+func icsEventPeriod(event map[string]icsProperty) (time.Time, time.Time, bool) {
+	if strings.EqualFold(event["TRANSP"].value, "TRANSPARENT") || strings.EqualFold(event["STATUS"].value, "CANCELLED") {
+		return time.Time{}, time.Time{}, false
+	}
+
+	dtStart, ok := event["DTSTART"]
+	if !ok || dtStart.params["VALUE"] == "DATE" {
+		return time.Time{}, time.Time{}, false
+	}
+	start, ok := parseICSDateTime(dtStart)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	if dtEnd, ok := event["DTEND"]; ok {
+		end, ok := parseICSDateTime(dtEnd)
+		return start, end, ok
+	}
+	if duration, ok := event["DURATION"]; ok {
+		d, ok := parseICSDuration(duration.value)
+		return start, start.Add(d), ok
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// parseICSDateTime parses a DATE-TIME value in UTC, in its TZID or as floating UTC time
+func parseICSDateTime(property icsProperty) (time.Time, bool) {
+	if strings.HasSuffix(property.value, "Z") {
+		t, err := time.Parse(icsDateTimeUTCLayout, property.value)
+		return t, err == nil
+	}
+
+	location := time.UTC
+	if tzid := property.params["TZID"]; tzid != "" {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+
+	t, err := time.ParseInLocation(icsDateTimeLayout, property.value, location)
+	return t, err == nil
+}
+
+// parseICSPeriod parses a FREEBUSY period written as start/end or start/duration
+func parseICSPeriod(period string) (time.Time, time.Time, bool) {
+	startValue, endValue, found := strings.Cut(strings.TrimSpace(period), "/")
+	if !found {
+		return time.Time{}, time.Time{}, false
+	}
+
+	start, ok := parseICSDateTime(icsProperty{value: startValue})
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	if strings.HasPrefix(endValue, "P") {
+		d, ok := parseICSDuration(endValue)
+		return start, start.Add(d), ok
+	}
+
+	end, ok := parseICSDateTime(icsProperty{value: endValue})
+	return start, end, ok
+}
+
+// parseICSDuration parses a positive iCalendar duration such as PT1H30M or P1D
+func parseICSDuration(value string) (time.Duration, bool) {
+	value = strings.TrimPrefix(value, "+")
+	if !strings.HasPrefix(value, "P") {
+		return 0, false
+	}
+
+	var total time.Duration
+	number := 0
+	hasNumber := false
+	for _, c := range value[1:] {
+		if c >= '0' && c <= '9' {
+			number = number*10 + int(c-'0')
+			hasNumber = true
+			continue
+		}
+
+		var unit time.Duration
+		switch c {
+		case 'T':
+			continue
+		case 'W':
+			unit = 7 * 24 * time.Hour
+		case 'D':
+			unit = 24 * time.Hour
+		case 'H':
+			unit = time.Hour
+		case 'M':
+			unit = time.Minute
+		case 'S':
+			unit = time.Second
+		default:
+			return 0, false
+		}
+		if !hasNumber {
+			return 0, false
+		}
+
+		total += time.Duration(number) * unit
+		number = 0
+		hasNumber = false
+	}
+
+	return total, total > 0 && !hasNumber
+}
+
+func toCalendarIntegrationResponse(integration db.CalendarIntegration, blocks []db.CalendarBusyBlock) *CalendarIntegrationResponse {
+	response := &CalendarIntegrationResponse{
+		ID:          integration.ID,
+		WorkspaceID: integration.WorkspaceID,
+		Provider:    integration.Provider,
+		ICSURL:      integration.IcsUrl,
+		Enabled:     integration.Enabled,
+		CreatedAt:   integration.CreatedAt,
+		UpdatedAt:   integration.UpdatedAt,
+		BusyBlocks:  make([]CalendarBusyBlockResponse, len(blocks)),
+	}
+
+	if integration.LastSyncedAt.Valid {
+		response.LastSyncedAt = &integration.LastSyncedAt.Time
+	}
+
+	if integration.LastSyncError.Valid {
+		response.LastSyncError = integration.LastSyncError.String
+	}
+
+	for i, block := range blocks {
+		response.BusyBlocks[i] = CalendarBusyBlockResponse{
+			StartsAt: block.StartsAt,
+			EndsAt:   block.EndsAt,
+		}
+	}
+
+	return response
+}
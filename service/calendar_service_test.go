@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+const testICSFeed = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Standup\r\n" +
+	"DTSTART:20240311T090000Z\r\n" +
+	"DTEND:20240311T091500Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Planning with a very long description that has been folded onto\r\n" +
+	"  a second line\r\n" +
+	"DTSTART;TZID=\"Europe/Berlin\":20240311T140000\r\n" +
+	"DURATION:PT1H30M\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Focus time\r\n" +
+	"DTSTART:20240311T100000Z\r\n" +
+	"DTEND:20240311T110000Z\r\n" +
+	"TRANSP:TRANSPARENT\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Cancelled sync\r\n" +
+	"DTSTART:20240311T113000Z\r\n" +
+	"DTEND:20240311T120000Z\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Holiday\r\n" +
+	"DTSTART;VALUE=DATE:20240312\r\n" +
+	"DTEND;VALUE=DATE:20240313\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Last week\r\n" +
+	"DTSTART:20240304T090000Z\r\n" +
+	"DTEND:20240304T100000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VFREEBUSY\r\n" +
+	"FREEBUSY;FBTYPE=BUSY:20240312T080000Z/20240312T083000Z,20240312T150000Z/PT45M\r\n" +
+	"FREEBUSY;FBTYPE=FREE:20240312T090000Z/20240312T100000Z\r\n" +
+	"END:VFREEBUSY\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICSBusyBlocks(t *testing.T) {
+	from := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	to := from.Add(calendarSyncWindow)
+
+	blocks, err := ParseICSBusyBlocks(strings.NewReader(testICSFeed), from, to)
+	require.NoError(t, err)
+
+	expected := []db.CalendarBusyBlockParams{
+		{StartsAt: time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC), EndsAt: time.Date(2024, 3, 11, 9, 15, 0, 0, time.UTC)},
+		// 14:00 in Berlin is 13:00 UTC in March
+		{StartsAt: time.Date(2024, 3, 11, 13, 0, 0, 0, time.UTC), EndsAt: time.Date(2024, 3, 11, 14, 30, 0, 0, time.UTC)},
+		{StartsAt: time.Date(2024, 3, 12, 8, 0, 0, 0, time.UTC), EndsAt: time.Date(2024, 3, 12, 8, 30, 0, 0, time.UTC)},
+		{StartsAt: time.Date(2024, 3, 12, 15, 0, 0, 0, time.UTC), EndsAt: time.Date(2024, 3, 12, 15, 45, 0, 0, time.UTC)},
+	}
+	require.Equal(t, expected, blocks)
+}
+
+func TestParseICSDuration(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"PT15M", 15 * time.Minute, true},
+		{"PT1H30M", 90 * time.Minute, true},
+		{"P1DT2H", 26 * time.Hour, true},
+		{"P1W", 7 * 24 * time.Hour, true},
+		{"PT", 0, false},
+		{"-PT15M", 0, false},
+		{"1H", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			duration, ok := parseICSDuration(tc.value)
+			require.Equal(t, tc.ok, ok)
+			if tc.ok {
+				require.Equal(t, tc.expected, duration)
+			}
+		})
+	}
+}
+
+func TestCalendarService_SyncCalendars(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	feed := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART:" + now.Add(-10*time.Minute).Format(icsDateTimeUTCLayout) + "\r\n" +
+		"DTEND:" + now.Add(20*time.Minute).Format(icsDateTimeUTCLayout) + "\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	feedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/feed.ics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar")
+		_, _ = w.Write([]byte(feed))
+	}))
+	defer feedServer.Close()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	integrations := []db.CalendarIntegration{
+		{ID: 1, UserID: 7, WorkspaceID: 3, IcsUrl: feedServer.URL + "/feed.ics", Enabled: true},
+		{ID: 2, UserID: 8, WorkspaceID: 3, IcsUrl: feedServer.URL + "/missing.ics", Enabled: true},
+	}
+	store.EXPECT().
+		ListEnabledCalendarIntegrations(gomock.Any()).
+		Times(1).
+		Return(integrations, nil)
+
+	store.EXPECT().
+		ReplaceCalendarBusyBlocksTx(gomock.Any(), gomock.Eq(db.ReplaceCalendarBusyBlocksTxParams{
+			IntegrationID: 1,
+			Blocks: []db.CalendarBusyBlockParams{
+				{StartsAt: now.Add(-10 * time.Minute), EndsAt: now.Add(20 * time.Minute)},
+			},
+		})).
+		Times(1).
+		Return(nil)
+
+	// The failing feed keeps its blocks and records the error
+	store.EXPECT().
+		UpdateCalendarIntegrationSync(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateCalendarIntegrationSyncParams) error {
+			require.Equal(t, int64(2), arg.ID)
+			require.True(t, arg.LastSyncError.Valid)
+			require.Contains(t, arg.LastSyncError.String, "unexpected status 404")
+			return nil
+		})
+
+	calendarService := NewCalendarService(store, NewStatusService(store, nil, util.Config{}))
+	err := calendarService.SyncCalendars(context.Background())
+	require.NoError(t, err)
+}
+
+func TestCalendarService_ApplyCalendarStatuses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	endsAt := time.Now().Add(30 * time.Minute)
+	store.EXPECT().
+		GetActiveCalendarBusyBlocks(gomock.Any()).
+		Times(1).
+		Return([]db.GetActiveCalendarBusyBlocksRow{
+			{UserID: 7, WorkspaceID: 3, EndsAt: endsAt},
+			{UserID: 8, WorkspaceID: 3, EndsAt: endsAt},
+		}, nil)
+
+	store.EXPECT().
+		SetCalendarBusyStatus(gomock.Any(), gomock.Eq(db.SetCalendarBusyStatusParams{
+			UserID:       7,
+			WorkspaceID:  3,
+			CustomStatus: sql.NullString{String: calendarMeetingStatus, Valid: true},
+			StatusEmoji:  sql.NullString{String: calendarMeetingEmoji, Valid: true},
+			ClearAfter:   sql.NullTime{Time: endsAt, Valid: true},
+		})).
+		Times(1).
+		Return(db.UserStatus{UserID: 7, WorkspaceID: 3, Status: "busy"}, nil)
+
+	// The second user has chosen their own custom status
+	store.EXPECT().
+		SetCalendarBusyStatus(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.UserStatus{}, sql.ErrNoRows)
+
+	calendarService := NewCalendarService(store, NewStatusService(store, nil, util.Config{}))
+	err := calendarService.ApplyCalendarStatuses(context.Background())
+	require.NoError(t, err)
+}
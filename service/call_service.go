@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Huddle WebSocket message types
+const (
+	WSHuddleStarted            = "huddle_started"
+	WSHuddleEnded              = "huddle_ended"
+	WSHuddleParticipantJoined  = "huddle_participant_joined"
+	WSHuddleParticipantLeft    = "huddle_participant_left"
+	WSHuddleParticipantUpdated = "huddle_participant_updated"
+	WSHuddleOffer              = "huddle_offer"
+	WSHuddleAnswer             = "huddle_answer"
+	WSHuddleICECandidate       = "huddle_ice_candidate"
+)
+
+// huddle is an active call in a channel or direct conversation
+type huddle struct {
+	id           int64
+	workspaceID  int64
+	channelID    int64    // 0 for a direct conversation
+	userIDs      [2]int64 // The direct conversation's users, lowest ID first
+	startedBy    int64
+	startedAt    time.Time
+	participants map[int64]*HuddleParticipantResponse
+}
+
+// CallService tracks huddles and their participants and relays WebRTC
+// signaling between them. Media itself never passes through the server.
+// Huddles only live as long as someone is in them, so they're kept in memory.
+type CallService struct {
+	store       db.ChannelStore
+	userService *UserService
+	hub         WebSocketHub
+	iceServers  []string
+
+	mu            sync.Mutex
+	nextID        int64
+	huddles       map[int64]*huddle
+	conversations map[string]int64 // Conversation key to huddle ID
+	userHuddles   map[int64]int64  // User ID to the huddle they're in
+}
+
+// NewCallService creates a new call service
+func NewCallService(store db.ChannelStore, userService *UserService, hub WebSocketHub, config util.Config) *CallService {
+	iceServers := []string{}
+	for _, server := range strings.Split(config.HuddleICEServers, ",") {
+		if server = strings.TrimSpace(server); server != "" {
+			iceServers = append(iceServers, server)
+		}
+	}
+
+	return &CallService{
+		store:         store,
+		userService:   userService,
+		hub:           hub,
+		iceServers:    iceServers,
+		huddles:       make(map[int64]*huddle),
+		conversations: make(map[string]int64),
+		userHuddles:   make(map[int64]int64),
+	}
+}
+
+// StartHuddle starts a huddle in a channel or direct conversation and puts the
+// user in it. If the conversation already has a huddle the user joins that
+// one instead, which is reported by created being false.
+func (s *CallService) StartHuddle(ctx context.Context, workspaceID, userID int64, req StartHuddleRequest) (resp HuddleResponse, created bool, err error) {
+	if (req.ChannelID == 0) == (req.UserID == 0) {
+		return HuddleResponse{}, false, errors.New("invalid huddle: specify either a channel or a user")
+	}
+
+	var key string
+	var userIDs [2]int64
+	if req.ChannelID != 0 {
+		if err := s.checkChannelAccess(ctx, workspaceID, req.ChannelID, userID); err != nil {
+			return HuddleResponse{}, false, err
+		}
+		key = fmt.Sprintf("channel:%d", req.ChannelID)
+	} else {
+		if req.UserID == userID {
+			return HuddleResponse{}, false, errors.New("invalid huddle: can't start a huddle with yourself")
+		}
+		isMember, err := s.userService.IsWorkspaceMember(ctx, req.UserID, workspaceID)
+		if err != nil {
+			return HuddleResponse{}, false, err
+		}
+		if !isMember {
+			return HuddleResponse{}, false, errors.New("user not found")
+		}
+		userIDs = [2]int64{userID, req.UserID}
+		if userIDs[0] > userIDs[1] {
+			userIDs[0], userIDs[1] = userIDs[1], userIDs[0]
+		}
+		key = fmt.Sprintf("direct:%d:%d:%d", workspaceID, userIDs[0], userIDs[1])
+	}
+
+	s.mu.Lock()
+	h, exists := s.huddles[s.conversations[key]]
+	if !exists {
+		s.nextID++
+		h = &huddle{
+			id:           s.nextID,
+			workspaceID:  workspaceID,
+			channelID:    req.ChannelID,
+			userIDs:      userIDs,
+			startedBy:    userID,
+			startedAt:    time.Now(),
+			participants: make(map[int64]*HuddleParticipantResponse),
+		}
+		s.huddles[h.id] = h
+		s.conversations[key] = h.id
+	} else if s.userHuddles[userID] == h.id {
+		resp = s.toHuddleResponse(h)
+		s.mu.Unlock()
+		return resp, false, nil
+	}
+	left, ended := s.removeParticipant(userID)
+	h.participants[userID] = &HuddleParticipantResponse{HuddleID: h.id, UserID: userID, JoinedAt: time.Now()}
+	s.userHuddles[userID] = h.id
+	resp = s.toHuddleResponse(h)
+	participant := *h.participants[userID]
+	s.mu.Unlock()
+
+	s.announceLeave(left, ended, userID)
+	if !exists {
+		s.broadcast(h, WSHuddleStarted, resp)
+	} else {
+		s.broadcast(h, WSHuddleParticipantJoined, participant)
+	}
+
+	return resp, !exists, nil
+}
+
+// GetHuddle returns an active huddle the user can see
+func (s *CallService) GetHuddle(ctx context.Context, workspaceID, huddleID, userID int64) (HuddleResponse, error) {
+	h, err := s.findHuddle(workspaceID, huddleID)
+	if err != nil {
+		return HuddleResponse{}, err
+	}
+	if err := s.checkHuddleAccess(ctx, h, userID); err != nil {
+		return HuddleResponse{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.toHuddleResponse(h), nil
+}
+
+// JoinHuddle puts the user in a huddle, taking them out of any other huddle
+// they're in
+func (s *CallService) JoinHuddle(ctx context.Context, workspaceID, huddleID, userID int64) (HuddleResponse, error) {
+	h, err := s.findHuddle(workspaceID, huddleID)
+	if err != nil {
+		return HuddleResponse{}, err
+	}
+	if err := s.checkHuddleAccess(ctx, h, userID); err != nil {
+		return HuddleResponse{}, err
+	}
+
+	s.mu.Lock()
+	// The huddle may have ended while access was checked
+	if _, ok := s.huddles[huddleID]; !ok {
+		s.mu.Unlock()
+		return HuddleResponse{}, errors.New("huddle not found")
+	}
+	if s.userHuddles[userID] == huddleID {
+		resp := s.toHuddleResponse(h)
+		s.mu.Unlock()
+		return resp, nil
+	}
+	left, ended := s.removeParticipant(userID)
+	h.participants[userID] = &HuddleParticipantResponse{HuddleID: h.id, UserID: userID, JoinedAt: time.Now()}
+	s.userHuddles[userID] = huddleID
+	resp := s.toHuddleResponse(h)
+	participant := *h.participants[userID]
+	s.mu.Unlock()
+
+	s.announceLeave(left, ended, userID)
+	s.broadcast(h, WSHuddleParticipantJoined, participant)
+
+	return resp, nil
+}
+
+// LeaveHuddle takes the user out of a huddle. The huddle ends when its last
+// participant leaves.
+func (s *CallService) LeaveHuddle(workspaceID, huddleID, userID int64) error {
+	if _, err := s.findHuddle(workspaceID, huddleID); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.userHuddles[userID] != huddleID {
+		s.mu.Unlock()
+		return errors.New("access denied: user is not in this huddle")
+	}
+	left, ended := s.removeParticipant(userID)
+	s.mu.Unlock()
+
+	s.announceLeave(left, ended, userID)
+	return nil
+}
+
+// UpdateMedia records whether a participant's microphone, camera and screen
+// share are on
+func (s *CallService) UpdateMedia(workspaceID, huddleID, userID int64, req UpdateHuddleMediaRequest) (HuddleParticipantResponse, error) {
+	h, err := s.findHuddle(workspaceID, huddleID)
+	if err != nil {
+		return HuddleParticipantResponse{}, err
+	}
+
+	s.mu.Lock()
+	participant, ok := h.participants[userID]
+	if !ok {
+		s.mu.Unlock()
+		return HuddleParticipantResponse{}, errors.New("access denied: user is not in this huddle")
+	}
+	participant.Muted = req.Muted
+	participant.Video = req.Video
+	participant.ScreenSharing = req.ScreenSharing
+	updated := *participant
+	s.mu.Unlock()
+
+	s.broadcast(h, WSHuddleParticipantUpdated, updated)
+	return updated, nil
+}
+
+// RelaySignal forwards a WebRTC offer, answer or ICE candidate to another
+// participant of the sender's huddle
+func (s *CallService) RelaySignal(fromUserID, toUserID int64, signalType string, signal HuddleSignal) error {
+	switch signalType {
+	case WSHuddleOffer, WSHuddleAnswer, WSHuddleICECandidate:
+	default:
+		return fmt.Errorf("invalid signal type: %s", signalType)
+	}
+
+	s.mu.Lock()
+	h, ok := s.huddles[signal.HuddleID]
+	if !ok {
+		s.mu.Unlock()
+		return errors.New("huddle not found")
+	}
+	_, senderIn := h.participants[fromUserID]
+	_, receiverIn := h.participants[toUserID]
+	workspaceID := h.workspaceID
+	s.mu.Unlock()
+
+	if !senderIn {
+		return errors.New("access denied: user is not in this huddle")
+	}
+	if !receiverIn {
+		return errors.New("huddle participant not found")
+	}
+
+	signal.FromUserID = fromUserID
+	s.hub.BroadcastToUser(toUserID, &WSMessage{
+		Type:        signalType,
+		Data:        signal,
+		WorkspaceID: workspaceID,
+		UserID:      fromUserID,
+		Timestamp:   time.Now(),
+	})
+	return nil
+}
+
+// UserDisconnected takes a user out of their huddle once their last
+// connection closes, since they can no longer exchange signaling
+func (s *CallService) UserDisconnected(userID int64) {
+	s.mu.Lock()
+	left, ended := s.removeParticipant(userID)
+	s.mu.Unlock()
+
+	s.announceLeave(left, ended, userID)
+}
+
+// removeParticipant takes a user out of their current huddle, ending it if
+// they were the last one in. Callers must hold the lock.
+func (s *CallService) removeParticipant(userID int64) (left *huddle, ended bool) {
+	huddleID, ok := s.userHuddles[userID]
+	if !ok {
+		return nil, false
+	}
+	delete(s.userHuddles, userID)
+
+	h := s.huddles[huddleID]
+	delete(h.participants, userID)
+	if len(h.participants) > 0 {
+		return h, false
+	}
+
+	delete(s.huddles, huddleID)
+	for key, id := range s.conversations {
+		if id == huddleID {
+			delete(s.conversations, key)
+			break
+		}
+	}
+	return h, true
+}
+
+// announceLeave tells the conversation a user left a huddle, or that it ended
+func (s *CallService) announceLeave(h *huddle, ended bool, userID int64) {
+	if h == nil {
+		return
+	}
+	if ended {
+		s.broadcast(h, WSHuddleEnded, map[string]interface{}{"huddle_id": h.id})
+		return
+	}
+	s.broadcast(h, WSHuddleParticipantLeft, map[string]interface{}{"huddle_id": h.id, "user_id": userID})
+}
+
+// broadcast sends a huddle event to the channel, or to both users of a
+// direct conversation
+func (s *CallService) broadcast(h *huddle, messageType string, data interface{}) {
+	if s.hub == nil {
+		return
+	}
+
+	if h.channelID != 0 {
+		channelID := h.channelID
+		s.hub.BroadcastToChannel(h.workspaceID, channelID, &WSMessage{
+			Type:        messageType,
+			Data:        data,
+			WorkspaceID: h.workspaceID,
+			ChannelID:   &channelID,
+			Timestamp:   time.Now(),
+		})
+		return
+	}
+
+	for _, userID := range h.userIDs {
+		s.hub.BroadcastToUser(userID, &WSMessage{
+			Type:        messageType,
+			Data:        data,
+			WorkspaceID: h.workspaceID,
+			UserID:      userID,
+			Timestamp:   time.Now(),
+		})
+	}
+}
+
+func (s *CallService) findHuddle(workspaceID, huddleID int64) (*huddle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.huddles[huddleID]
+	if !ok || h.workspaceID != workspaceID {
+		return nil, errors.New("huddle not found")
+	}
+	return h, nil
+}
+
+// checkHuddleAccess checks the user can take part in the huddle's conversation
+func (s *CallService) checkHuddleAccess(ctx context.Context, h *huddle, userID int64) error {
+	if h.channelID != 0 {
+		return s.checkChannelAccess(ctx, h.workspaceID, h.channelID, userID)
+	}
+	if h.userIDs[0] != userID && h.userIDs[1] != userID {
+		return errors.New("access denied: user is not part of this conversation")
+	}
+	return nil
+}
+
+// checkChannelAccess checks the channel belongs to the workspace and, if it's
+// private, that the user is a member
+func (s *CallService) checkChannelAccess(ctx context.Context, workspaceID, channelID, userID int64) error {
+	channel, err := s.store.GetChannelByID(ctx, channelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("channel not found")
+		}
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+	if channel.WorkspaceID != workspaceID {
+		return errors.New("channel not found")
+	}
+	if !channel.IsPrivate {
+		return nil
+	}
+
+	isMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+		ChannelID: channelID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check channel membership: %w", err)
+	}
+	if !isMember {
+		return errors.New("access denied: user is not a member of this channel")
+	}
+	return nil
+}
+
+// toHuddleResponse converts a huddle to its response. Callers must hold the lock.
+func (s *CallService) toHuddleResponse(h *huddle) HuddleResponse {
+	resp := HuddleResponse{
+		ID:           h.id,
+		WorkspaceID:  h.workspaceID,
+		StartedBy:    h.startedBy,
+		StartedAt:    h.startedAt,
+		Participants: make([]HuddleParticipantResponse, 0, len(h.participants)),
+		ICEServers:   s.iceServers,
+	}
+	if h.channelID != 0 {
+		channelID := h.channelID
+		resp.ChannelID = &channelID
+	} else {
+		resp.UserIDs = []int64{h.userIDs[0], h.userIDs[1]}
+	}
+	for _, participant := range h.participants {
+		resp.Participants = append(resp.Participants, *participant)
+	}
+	sort.Slice(resp.Participants, func(i, j int) bool {
+		return resp.Participants[i].JoinedAt.Before(resp.Participants[j].JoinedAt)
+	})
+	return resp
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallService_DirectHuddle(t *testing.T) {
+	const workspaceID, callerID, calleeID, outsiderID = int64(2), int64(5), int64(8), int64(9)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		Return("member", nil)
+
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	config := util.Config{HuddleICEServers: "stun:stun.example.com:3478, turn:turn.example.com"}
+	callService := NewCallService(store, NewUserService(store, nil, config), hub, config)
+
+	huddle, created, err := callService.StartHuddle(ctx, workspaceID, callerID, StartHuddleRequest{UserID: calleeID})
+	require.NoError(t, err)
+	require.True(t, created)
+	require.Equal(t, []int64{callerID, calleeID}, huddle.UserIDs)
+	require.Equal(t, []string{"stun:stun.example.com:3478", "turn:turn.example.com"}, huddle.ICEServers)
+	require.Len(t, huddle.Participants, 1)
+
+	// Both users of the conversation hear about the huddle
+	require.Equal(t, WSHuddleStarted, hub.userMessages[calleeID][0].Type)
+	require.Equal(t, WSHuddleStarted, hub.userMessages[callerID][0].Type)
+
+	// Starting a huddle in the same conversation joins the active one
+	joined, created, err := callService.StartHuddle(ctx, workspaceID, calleeID, StartHuddleRequest{UserID: callerID})
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, huddle.ID, joined.ID)
+	require.Len(t, joined.Participants, 2)
+
+	_, err = callService.JoinHuddle(ctx, workspaceID, huddle.ID, outsiderID)
+	require.EqualError(t, err, "access denied: user is not part of this conversation")
+
+	// Signaling is relayed to the addressed participant only
+	err = callService.RelaySignal(callerID, calleeID, WSHuddleOffer, HuddleSignal{HuddleID: huddle.ID, SDP: "v=0"})
+	require.NoError(t, err)
+	offer := hub.userMessages[calleeID][len(hub.userMessages[calleeID])-1]
+	require.Equal(t, WSHuddleOffer, offer.Type)
+	require.Equal(t, HuddleSignal{HuddleID: huddle.ID, FromUserID: callerID, SDP: "v=0"}, offer.Data)
+
+	err = callService.RelaySignal(outsiderID, calleeID, WSHuddleOffer, HuddleSignal{HuddleID: huddle.ID, SDP: "v=0"})
+	require.EqualError(t, err, "access denied: user is not in this huddle")
+
+	// The huddle ends once the last participant is gone
+	require.NoError(t, callService.LeaveHuddle(workspaceID, huddle.ID, callerID))
+	callService.UserDisconnected(calleeID)
+
+	last := hub.userMessages[callerID][len(hub.userMessages[callerID])-1]
+	require.Equal(t, WSHuddleEnded, last.Type)
+
+	_, err = callService.GetHuddle(ctx, workspaceID, huddle.ID, callerID)
+	require.EqualError(t, err, "huddle not found")
+}
+
+func TestCallService_PrivateChannelHuddle(t *testing.T) {
+	const workspaceID, memberID, otherID = int64(2), int64(5), int64(8)
+	ctx := context.Background()
+	channel := db.Channel{ID: 4, WorkspaceID: workspaceID, IsPrivate: true}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetChannelByID(gomock.Any(), channel.ID).AnyTimes().Return(channel, nil)
+	store.EXPECT().
+		IsChannelMember(gomock.Any(), db.IsChannelMemberParams{ChannelID: channel.ID, UserID: memberID}).
+		AnyTimes().
+		Return(true, nil)
+	store.EXPECT().
+		IsChannelMember(gomock.Any(), db.IsChannelMemberParams{ChannelID: channel.ID, UserID: otherID}).
+		AnyTimes().
+		Return(false, nil)
+
+	callService := NewCallService(store, nil, &recordingHub{userMessages: make(map[int64][]*WSMessage)}, util.Config{})
+
+	huddle, created, err := callService.StartHuddle(ctx, workspaceID, memberID, StartHuddleRequest{ChannelID: channel.ID})
+	require.NoError(t, err)
+	require.True(t, created)
+	require.Equal(t, channel.ID, *huddle.ChannelID)
+
+	_, err = callService.JoinHuddle(ctx, workspaceID, huddle.ID, otherID)
+	require.EqualError(t, err, "access denied: user is not a member of this channel")
+
+	// The channel has to belong to the workspace in the URL
+	_, _, err = callService.StartHuddle(ctx, workspaceID+1, memberID, StartHuddleRequest{ChannelID: channel.ID})
+	require.EqualError(t, err, "channel not found")
+}
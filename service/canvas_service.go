@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Canvas WebSocket message types, sent to the canvas's channel
+const (
+	WSCanvasUpdated = "canvas_updated"
+	WSCanvasDeleted = "canvas_deleted"
+)
+
+// canvasServiceStore covers canvases and the channels and workspace roles that grant access to them
+type canvasServiceStore interface {
+	db.CanvasStore
+	db.ChannelStore
+	db.WorkspaceStore
+}
+
+// CanvasService handles the markdown canvases attached to channels. Anyone who
+// can read a channel can read its canvases; editing them requires channel
+// membership.
+type CanvasService struct {
+	store canvasServiceStore
+	hub   WebSocketHub
+}
+
+// NewCanvasService creates a new canvas service
+func NewCanvasService(store canvasServiceStore, hub WebSocketHub) *CanvasService {
+	return &CanvasService{
+		store: store,
+		hub:   hub,
+	}
+}
+
+// CreateCanvas creates a canvas in a channel the user is a member of
+func (s *CanvasService) CreateCanvas(ctx context.Context, workspaceID, channelID, userID int64, req CreateCanvasRequest) (*CanvasResponse, error) {
+	if _, err := s.checkChannelAccess(ctx, workspaceID, channelID, userID, true); err != nil {
+		return nil, err
+	}
+
+	canvas, err := s.store.CreateCanvasTx(ctx, db.CreateCanvasParams{
+		WorkspaceID: workspaceID,
+		ChannelID:   channelID,
+		Title:       req.Title,
+		Content:     req.Content,
+		CreatedBy:   sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create canvas: %w", err)
+	}
+
+	response := toCanvasResponse(canvas)
+	s.broadcast(canvas, WSCanvasUpdated, response, userID)
+
+	return response, nil
+}
+
+// ListChannelCanvases lists a channel's canvases, most recently edited first
+func (s *CanvasService) ListChannelCanvases(ctx context.Context, workspaceID, channelID, userID int64, req ListCanvasesRequest) ([]*CanvasResponse, error) {
+	if _, err := s.checkChannelAccess(ctx, workspaceID, channelID, userID, false); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	canvases, err := s.store.ListChannelCanvases(ctx, db.ListChannelCanvasesParams{
+		ChannelID: channelID,
+		Limit:     limit,
+		Offset:    req.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list canvases: %w", err)
+	}
+
+	responses := make([]*CanvasResponse, len(canvases))
+	for i, canvas := range canvases {
+		responses[i] = toCanvasResponse(canvas)
+	}
+
+	return responses, nil
+}
+
+// GetCanvas gets a canvas the user can read
+func (s *CanvasService) GetCanvas(ctx context.Context, workspaceID, canvasID, userID int64) (*CanvasResponse, error) {
+	canvas, err := s.getCanvas(ctx, workspaceID, canvasID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCanvasResponse(canvas), nil
+}
+
+// UpdateCanvas saves an edit to a canvas. The edit must be based on the
+// canvas's current revision, so concurrent editors can't overwrite each
+// other's changes; the one who saves second has to merge and retry.
+func (s *CanvasService) UpdateCanvas(ctx context.Context, workspaceID, canvasID, userID int64, req UpdateCanvasRequest) (*CanvasResponse, error) {
+	canvas, err := s.getCanvas(ctx, workspaceID, canvasID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.BaseRevision != canvas.Revision {
+		return nil, canvasConflictError(canvas.Revision, req.BaseRevision)
+	}
+
+	// Saving without changes doesn't make a new revision
+	if req.Title == canvas.Title && req.Content == canvas.Content {
+		return toCanvasResponse(canvas), nil
+	}
+
+	updated, err := s.store.UpdateCanvasTx(ctx, db.UpdateCanvasParams{
+		ID:        canvas.ID,
+		Revision:  req.BaseRevision,
+		Title:     req.Title,
+		Content:   req.Content,
+		UpdatedBy: sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Someone else saved between reading the canvas and saving it
+			return nil, canvasConflictError(canvas.Revision+1, req.BaseRevision)
+		}
+		return nil, fmt.Errorf("failed to update canvas: %w", err)
+	}
+
+	response := toCanvasResponse(updated)
+	s.broadcast(updated, WSCanvasUpdated, response, userID)
+
+	return response, nil
+}
+
+// DeleteCanvas deletes a canvas. Its creator, the channel's admins and users
+// who can manage channels may delete it.
+func (s *CanvasService) DeleteCanvas(ctx context.Context, workspaceID, canvasID, userID int64) error {
+	canvas, err := s.getCanvas(ctx, workspaceID, canvasID, userID, true)
+	if err != nil {
+		return err
+	}
+
+	if !canvas.CreatedBy.Valid || canvas.CreatedBy.Int64 != userID {
+		allowed, err := s.canManageChannel(ctx, canvas, userID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return errors.New("access denied: only the canvas creator or a channel admin can delete this canvas")
+		}
+	}
+
+	if err := s.store.DeleteCanvas(ctx, canvas.ID); err != nil {
+		return fmt.Errorf("failed to delete canvas: %w", err)
+	}
+
+	s.broadcast(canvas, WSCanvasDeleted, map[string]int64{"canvas_id": canvas.ID}, userID)
+
+	return nil
+}
+
+// ListRevisions lists a canvas's saved revisions, newest first
+func (s *CanvasService) ListRevisions(ctx context.Context, workspaceID, canvasID, userID int64, req ListCanvasesRequest) ([]*CanvasRevisionResponse, error) {
+	canvas, err := s.getCanvas(ctx, workspaceID, canvasID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	revisions, err := s.store.ListCanvasRevisions(ctx, db.ListCanvasRevisionsParams{
+		CanvasID: canvas.ID,
+		Limit:    limit,
+		Offset:   req.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list canvas revisions: %w", err)
+	}
+
+	responses := make([]*CanvasRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		responses[i] = toCanvasRevisionResponse(revision)
+	}
+
+	return responses, nil
+}
+
+// GetRevision gets one saved revision of a canvas
+func (s *CanvasService) GetRevision(ctx context.Context, workspaceID, canvasID int64, revision int32, userID int64) (*CanvasRevisionResponse, error) {
+	canvas, err := s.getCanvas(ctx, workspaceID, canvasID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := s.store.GetCanvasRevision(ctx, db.GetCanvasRevisionParams{
+		CanvasID: canvas.ID,
+		Revision: revision,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("canvas revision not found")
+		}
+		return nil, fmt.Errorf("failed to get canvas revision: %w", err)
+	}
+
+	return toCanvasRevisionResponse(saved), nil
+}
+
+// getCanvas gets a canvas in the workspace and checks the user's access to its channel
+func (s *CanvasService) getCanvas(ctx context.Context, workspaceID, canvasID, userID int64, write bool) (db.Canvas, error) {
+	canvas, err := s.store.GetCanvas(ctx, db.GetCanvasParams{
+		ID:          canvasID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.Canvas{}, errors.New("canvas not found")
+		}
+		return db.Canvas{}, fmt.Errorf("failed to get canvas: %w", err)
+	}
+
+	if _, err := s.checkChannelAccess(ctx, workspaceID, canvas.ChannelID, userID, write); err != nil {
+		return db.Canvas{}, err
+	}
+
+	return canvas, nil
+}
+
+// checkChannelAccess checks that a channel is in the workspace and that the
+// user can read it or, for write access, is one of its members
+func (s *CanvasService) checkChannelAccess(ctx context.Context, workspaceID, channelID, userID int64, write bool) (db.Channel, error) {
+	channel, err := s.store.GetChannelByID(ctx, channelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.Channel{}, errors.New("channel not found")
+		}
+		return db.Channel{}, fmt.Errorf("failed to get channel: %w", err)
+	}
+	if channel.WorkspaceID != workspaceID {
+		return db.Channel{}, errors.New("channel not found")
+	}
+	if !channel.IsPrivate && !write {
+		return channel, nil
+	}
+
+	isMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+		ChannelID: channelID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return db.Channel{}, fmt.Errorf("failed to check channel membership: %w", err)
+	}
+	if !isMember {
+		return db.Channel{}, errors.New("access denied: user is not a member of this channel")
+	}
+
+	return channel, nil
+}
+
+// canManageChannel reports whether a user is an admin of the canvas's channel
+// or may manage every channel in the workspace
+func (s *CanvasService) canManageChannel(ctx context.Context, canvas db.Canvas, userID int64) (bool, error) {
+	role, err := s.store.CheckChannelMembership(ctx, db.CheckChannelMembershipParams{
+		ChannelID: canvas.ChannelID,
+		UserID:    userID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return false, fmt.Errorf("failed to check channel membership: %w", err)
+	}
+	if role == "admin" {
+		return true, nil
+	}
+
+	return hasWorkspacePermission(ctx, s.store, userID, canvas.WorkspaceID, PermissionManageChannels)
+}
+
+func (s *CanvasService) broadcast(canvas db.Canvas, messageType string, data interface{}, userID int64) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.BroadcastToChannel(canvas.WorkspaceID, canvas.ChannelID, &WSMessage{
+		Type:        messageType,
+		Data:        data,
+		WorkspaceID: canvas.WorkspaceID,
+		ChannelID:   &canvas.ChannelID,
+		UserID:      userID,
+		Timestamp:   time.Now(),
+	})
+}
+
+func canvasConflictError(currentRevision, baseRevision int32) error {
+	return fmt.Errorf("canvas revision conflict: the canvas is at revision %d but the edit was based on revision %d", currentRevision, baseRevision)
+}
+
+func toCanvasResponse(canvas db.Canvas) *CanvasResponse {
+	response := &CanvasResponse{
+		ID:          canvas.ID,
+		WorkspaceID: canvas.WorkspaceID,
+		ChannelID:   canvas.ChannelID,
+		Title:       canvas.Title,
+		Content:     canvas.Content,
+		Revision:    canvas.Revision,
+		CreatedAt:   canvas.CreatedAt,
+		UpdatedAt:   canvas.UpdatedAt,
+	}
+	if canvas.CreatedBy.Valid {
+		response.CreatedBy = &canvas.CreatedBy.Int64
+	}
+	if canvas.UpdatedBy.Valid {
+		response.UpdatedBy = &canvas.UpdatedBy.Int64
+	}
+	return response
+}
+
+func toCanvasRevisionResponse(revision db.CanvasRevision) *CanvasRevisionResponse {
+	response := &CanvasRevisionResponse{
+		CanvasID:  revision.CanvasID,
+		Revision:  revision.Revision,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		CreatedAt: revision.CreatedAt,
+	}
+	if revision.EditedBy.Valid {
+		response.EditedBy = &revision.EditedBy.Int64
+	}
+	return response
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanvasService_UpdateCanvas(t *testing.T) {
+	const workspaceID, editorID, viewerID = int64(2), int64(5), int64(8)
+	ctx := context.Background()
+	channel := db.Channel{ID: 4, WorkspaceID: workspaceID}
+	canvas := db.Canvas{ID: 9, WorkspaceID: workspaceID, ChannelID: channel.ID, Title: "Notes", Content: "v3", Revision: 3}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetCanvas(gomock.Any(), db.GetCanvasParams{ID: canvas.ID, WorkspaceID: workspaceID}).AnyTimes().Return(canvas, nil)
+	store.EXPECT().GetChannelByID(gomock.Any(), channel.ID).AnyTimes().Return(channel, nil)
+	store.EXPECT().
+		IsChannelMember(gomock.Any(), db.IsChannelMemberParams{ChannelID: channel.ID, UserID: editorID}).
+		AnyTimes().
+		Return(true, nil)
+	store.EXPECT().
+		IsChannelMember(gomock.Any(), db.IsChannelMemberParams{ChannelID: channel.ID, UserID: viewerID}).
+		AnyTimes().
+		Return(false, nil)
+
+	hub := &recordingHub{}
+	canvasService := NewCanvasService(store, hub)
+
+	// Workspace members can read a public channel's canvases but only its
+	// members can edit them
+	_, err := canvasService.GetCanvas(ctx, workspaceID, canvas.ID, viewerID)
+	require.NoError(t, err)
+
+	_, err = canvasService.UpdateCanvas(ctx, workspaceID, canvas.ID, viewerID, UpdateCanvasRequest{Title: "Notes", Content: "v4", BaseRevision: 3})
+	require.EqualError(t, err, "access denied: user is not a member of this channel")
+
+	// An edit of an older revision is rejected without saving
+	_, err = canvasService.UpdateCanvas(ctx, workspaceID, canvas.ID, editorID, UpdateCanvasRequest{Title: "Notes", Content: "v4", BaseRevision: 2})
+	require.EqualError(t, err, "canvas revision conflict: the canvas is at revision 3 but the edit was based on revision 2")
+
+	updated := canvas
+	updated.Content, updated.Revision = "v4", 4
+	store.EXPECT().
+		UpdateCanvasTx(gomock.Any(), db.UpdateCanvasParams{
+			ID:        canvas.ID,
+			Revision:  3,
+			Title:     "Notes",
+			Content:   "v4",
+			UpdatedBy: sql.NullInt64{Int64: editorID, Valid: true},
+		}).
+		Times(1).
+		Return(updated, nil)
+
+	response, err := canvasService.UpdateCanvas(ctx, workspaceID, canvas.ID, editorID, UpdateCanvasRequest{Title: "Notes", Content: "v4", BaseRevision: 3})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), response.Revision)
+
+	require.Len(t, hub.channelMessages[channel.ID], 1)
+	require.Equal(t, WSCanvasUpdated, hub.channelMessages[channel.ID][0].Type)
+	require.Equal(t, response, hub.channelMessages[channel.ID][0].Data)
+
+	// Losing a race with another save is a conflict too
+	store.EXPECT().UpdateCanvasTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Canvas{}, sql.ErrNoRows)
+
+	_, err = canvasService.UpdateCanvas(ctx, workspaceID, canvas.ID, editorID, UpdateCanvasRequest{Title: "Notes", Content: "v5", BaseRevision: 3})
+	require.EqualError(t, err, "canvas revision conflict: the canvas is at revision 4 but the edit was based on revision 3")
+}
+
+func TestCanvasService_PrivateChannel(t *testing.T) {
+	const workspaceID, outsiderID = int64(2), int64(8)
+	ctx := context.Background()
+	channel := db.Channel{ID: 4, WorkspaceID: workspaceID, IsPrivate: true}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetChannelByID(gomock.Any(), channel.ID).AnyTimes().Return(channel, nil)
+	store.EXPECT().IsChannelMember(gomock.Any(), gomock.Any()).AnyTimes().Return(false, nil)
+	store.EXPECT().ListChannelCanvases(gomock.Any(), gomock.Any()).Times(0)
+
+	canvasService := NewCanvasService(store, &recordingHub{})
+
+	_, err := canvasService.ListChannelCanvases(ctx, workspaceID, channel.ID, outsiderID, ListCanvasesRequest{})
+	require.EqualError(t, err, "access denied: user is not a member of this channel")
+
+	// The channel has to belong to the workspace in the URL
+	_, err = canvasService.CreateCanvas(ctx, workspaceID+1, channel.ID, outsiderID, CreateCanvasRequest{Title: "Notes"})
+	require.EqualError(t, err, "channel not found")
+}
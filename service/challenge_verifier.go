@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+)
+
+// Challenge providers
+const (
+	ChallengeProviderHCaptcha  = "hcaptcha"
+	ChallengeProviderTurnstile = "turnstile"
+	ChallengeProviderPoW       = "pow" // Proof of work, needs no third party
+
+	hCaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+
+	// Upper bound on the size of a CAPTCHA verification response
+	maxChallengeResponseSize = 64 << 10
+)
+
+// ErrInvalidChallengeResponse is returned when a client didn't solve the challenge
+var ErrInvalidChallengeResponse = errors.New("invalid challenge response")
+
+// ChallengeVerifier checks that a client solved a CAPTCHA or proof-of-work
+// challenge before it may use a public endpoint bots target
+type ChallengeVerifier interface {
+	// Name identifies the provider in API responses
+	Name() string
+	// NewChallenge returns what a client needs to solve a challenge
+	NewChallenge() (ChallengeResponse, error)
+	// Verify checks a client's solution; remoteIP may be empty
+	Verify(ctx context.Context, response, remoteIP string) error
+}
+
+// NewChallengeVerifier creates the challenge verifier set in the configuration.
+// It returns nil when no provider is configured.
+func NewChallengeVerifier(config util.Config) (ChallengeVerifier, error) {
+	switch config.ChallengeProvider {
+	case "":
+		return nil, nil
+	case ChallengeProviderHCaptcha, ChallengeProviderTurnstile:
+		if config.ChallengeSecretKey == "" {
+			return nil, errors.New("invalid challenge configuration: CHALLENGE_SECRET_KEY is required")
+		}
+		verifyURL := hCaptchaVerifyURL
+		if config.ChallengeProvider == ChallengeProviderTurnstile {
+			verifyURL = turnstileVerifyURL
+		}
+		return &siteVerifier{
+			name:      config.ChallengeProvider,
+			verifyURL: verifyURL,
+			siteKey:   config.ChallengeSiteKey,
+			secretKey: config.ChallengeSecretKey,
+			client:    &http.Client{Timeout: 10 * time.Second},
+		}, nil
+	case ChallengeProviderPoW:
+		if config.ChallengePoWDifficulty < 1 || config.ChallengePoWDifficulty > 32 {
+			return nil, errors.New("invalid challenge configuration: CHALLENGE_POW_DIFFICULTY must be between 1 and 32")
+		}
+		// Challenges are signed rather than stored, with the token key unless one is given
+		key := config.ChallengeSecretKey
+		if key == "" {
+			key = config.TokenSymmetricKey
+		}
+		return newPoWVerifier([]byte(key), config.ChallengePoWDifficulty, config.ChallengePoWTTL), nil
+	default:
+		return nil, fmt.Errorf("invalid challenge configuration: unknown provider %q", config.ChallengeProvider)
+	}
+}
+
+// siteVerifier checks CAPTCHA responses with the siteverify API hCaptcha and
+// Cloudflare Turnstile share
+type siteVerifier struct {
+	name      string
+	verifyURL string
+	siteKey   string
+	secretKey string
+	client    *http.Client
+}
+
+func (v *siteVerifier) Name() string {
+	return v.name
+}
+
+func (v *siteVerifier) NewChallenge() (ChallengeResponse, error) {
+	return ChallengeResponse{Provider: v.name, SiteKey: v.siteKey}, nil
+}
+
+func (v *siteVerifier) Verify(ctx context.Context, response, remoteIP string) error {
+	form := url.Values{"secret": {v.secretKey}, "response": {response}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to verify challenge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to verify challenge: %s responded with status %d", v.name, resp.StatusCode)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxChallengeResponseSize)).Decode(&result); err != nil {
+		return fmt.Errorf("failed to verify challenge: %w", err)
+	}
+	if !result.Success {
+		return ErrInvalidChallengeResponse
+	}
+	return nil
+}
+
+// powVerifier issues proof-of-work challenges: the client must find a counter
+// such that the SHA-256 hash of "<challenge>:<counter>" starts with difficulty
+// zero bits. Challenges are signed so the server doesn't store them, and each
+// can be solved once.
+type powVerifier struct {
+	key        []byte
+	difficulty int
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu   sync.Mutex
+	used map[string]time.Time // Challenges solved on this server, until they expire
+}
+
+func newPoWVerifier(key []byte, difficulty int, ttl time.Duration) *powVerifier {
+	return &powVerifier{
+		key:        key,
+		difficulty: difficulty,
+		ttl:        ttl,
+		now:        time.Now,
+		used:       make(map[string]time.Time),
+	}
+}
+
+func (v *powVerifier) Name() string {
+	return ChallengeProviderPoW
+}
+
+func (v *powVerifier) NewChallenge() (ChallengeResponse, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return ChallengeResponse{}, err
+	}
+
+	expiresAt := v.now().Add(v.ttl).Truncate(time.Second)
+	unsigned := hex.EncodeToString(nonce) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return ChallengeResponse{
+		Provider:   ChallengeProviderPoW,
+		Challenge:  unsigned + "." + v.sign(unsigned),
+		Difficulty: v.difficulty,
+		ExpiresAt:  &expiresAt,
+	}, nil
+}
+
+func (v *powVerifier) Verify(ctx context.Context, response, remoteIP string) error {
+	separator := strings.LastIndex(response, ":")
+	if separator < 0 {
+		return ErrInvalidChallengeResponse
+	}
+	challenge := response[:separator]
+
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 3 {
+		return ErrInvalidChallengeResponse
+	}
+	unsigned := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(v.sign(unsigned))) {
+		return ErrInvalidChallengeResponse
+	}
+
+	expiresUnix, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return ErrInvalidChallengeResponse
+	}
+	expiresAt := time.Unix(expiresUnix, 0)
+	now := v.now()
+	if now.After(expiresAt) {
+		return ErrInvalidChallengeResponse
+	}
+
+	hash := sha256.Sum256([]byte(response))
+	if leadingZeroBits(hash[:]) < v.difficulty {
+		return ErrInvalidChallengeResponse
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for solved, expiry := range v.used {
+		if now.After(expiry) {
+			delete(v.used, solved)
+		}
+	}
+	if _, ok := v.used[challenge]; ok {
+		return ErrInvalidChallengeResponse
+	}
+	v.used[challenge] = expiresAt
+
+	return nil
+}
+
+func (v *powVerifier) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte("challenge:" + unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func leadingZeroBits(hash []byte) int {
+	count := 0
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
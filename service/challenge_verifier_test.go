@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+// solvePoW finds the counter for a proof-of-work challenge
+func solvePoW(challenge string, difficulty int) string {
+	for counter := 0; ; counter++ {
+		response := challenge + ":" + strconv.Itoa(counter)
+		hash := sha256.Sum256([]byte(response))
+		if leadingZeroBits(hash[:]) >= difficulty {
+			return response
+		}
+	}
+}
+
+func TestPoWVerifier(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	verifier := newPoWVerifier([]byte(util.RandomString(32)), 8, time.Minute)
+	verifier.now = func() time.Time { return now }
+
+	challenge, err := verifier.NewChallenge()
+	require.NoError(t, err)
+	require.Equal(t, ChallengeProviderPoW, challenge.Provider)
+	require.Equal(t, 8, challenge.Difficulty)
+	require.Equal(t, now.Add(time.Minute), *challenge.ExpiresAt)
+
+	response := solvePoW(challenge.Challenge, 8)
+	require.NoError(t, verifier.Verify(ctx, response, ""))
+
+	// Each challenge can be solved once
+	require.ErrorIs(t, verifier.Verify(ctx, response, ""), ErrInvalidChallengeResponse)
+
+	// Challenges must be signed by the server
+	other := newPoWVerifier([]byte(util.RandomString(32)), 8, time.Minute)
+	forged, err := other.NewChallenge()
+	require.NoError(t, err)
+	require.ErrorIs(t, verifier.Verify(ctx, solvePoW(forged.Challenge, 8), ""), ErrInvalidChallengeResponse)
+
+	// An unsolved challenge is rejected
+	challenge, err = verifier.NewChallenge()
+	require.NoError(t, err)
+	for counter := 0; ; counter++ {
+		response := challenge.Challenge + ":" + strconv.Itoa(counter)
+		hash := sha256.Sum256([]byte(response))
+		if leadingZeroBits(hash[:]) < 8 {
+			require.ErrorIs(t, verifier.Verify(ctx, response, ""), ErrInvalidChallengeResponse)
+			break
+		}
+	}
+
+	// So is an expired one
+	response = solvePoW(challenge.Challenge, 8)
+	now = now.Add(2 * time.Minute)
+	require.ErrorIs(t, verifier.Verify(ctx, response, ""), ErrInvalidChallengeResponse)
+
+	require.ErrorIs(t, verifier.Verify(ctx, "no-separator", ""), ErrInvalidChallengeResponse)
+}
+
+func TestSiteVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret", r.PostForm.Get("secret"))
+		require.Equal(t, "10.0.0.1", r.PostForm.Get("remoteip"))
+
+		success := r.PostForm.Get("response") == "solved"
+		json.NewEncoder(w).Encode(map[string]interface{}{"success": success})
+	}))
+	defer server.Close()
+
+	verifier, err := NewChallengeVerifier(util.Config{
+		ChallengeProvider:  ChallengeProviderTurnstile,
+		ChallengeSiteKey:   "site",
+		ChallengeSecretKey: "secret",
+	})
+	require.NoError(t, err)
+	verifier.(*siteVerifier).verifyURL = server.URL
+
+	challenge, err := verifier.NewChallenge()
+	require.NoError(t, err)
+	require.Equal(t, ChallengeResponse{Provider: ChallengeProviderTurnstile, SiteKey: "site"}, challenge)
+
+	require.NoError(t, verifier.Verify(context.Background(), "solved", "10.0.0.1"))
+	require.ErrorIs(t, verifier.Verify(context.Background(), "guessed", "10.0.0.1"), ErrInvalidChallengeResponse)
+}
+
+func TestNewChallengeVerifier(t *testing.T) {
+	verifier, err := NewChallengeVerifier(util.Config{})
+	require.NoError(t, err)
+	require.Nil(t, verifier)
+
+	_, err = NewChallengeVerifier(util.Config{ChallengeProvider: ChallengeProviderHCaptcha})
+	require.Error(t, err)
+
+	_, err = NewChallengeVerifier(util.Config{ChallengeProvider: ChallengeProviderPoW, ChallengePoWDifficulty: 40})
+	require.Error(t, err)
+
+	_, err = NewChallengeVerifier(util.Config{ChallengeProvider: "recaptcha"})
+	require.Error(t, err)
+}
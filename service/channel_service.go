@@ -5,43 +5,79 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	db "github.com/heyrmi/goslack/db/sqlc"
 )
 
+// channelServiceStore covers channels, their members and the legal holds that keep them
+type channelServiceStore interface {
+	db.ChannelStore
+	db.OrganizationStore
+	db.UserStore
+}
+
 // ChannelService handles channel-related business logic
 type ChannelService struct {
-	store            db.Store
+	store            channelServiceStore
 	userService      *UserService
 	workspaceService *WorkspaceService
+	hub              WebSocketHub
 }
 
 // NewChannelService creates a new channel service
-func NewChannelService(store db.Store, userService *UserService, workspaceService *WorkspaceService) *ChannelService {
+func NewChannelService(store channelServiceStore, userService *UserService, workspaceService *WorkspaceService, hub WebSocketHub) *ChannelService {
 	return &ChannelService{
 		store:            store,
 		userService:      userService,
 		workspaceService: workspaceService,
+		hub:              hub,
 	}
 }
 
-// CreateChannel creates a new channel in a workspace
+// CreateChannel creates a new channel in a workspace with its creator as a
+// channel admin and a "channel created" system message, then announces it
 // Note: This method assumes workspace access has been validated by middleware
 func (s *ChannelService) CreateChannel(ctx context.Context, userID, workspaceID int64, req CreateChannelRequest) (ChannelResponse, error) {
-	// Create the channel
-	arg := db.CreateChannelParams{
-		WorkspaceID: workspaceID,
-		Name:        req.Name,
-		IsPrivate:   req.IsPrivate,
-		CreatedBy:   userID,
+	creator, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ChannelResponse{}, errors.New("user not found")
+		}
+		return ChannelResponse{}, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	channel, err := s.store.CreateChannel(ctx, arg)
+	result, err := s.store.CreateChannelTx(ctx, db.CreateChannelTxParams{
+		WorkspaceID:   workspaceID,
+		Name:          req.Name,
+		IsPrivate:     req.IsPrivate,
+		CreatedBy:     userID,
+		SystemMessage: fmt.Sprintf("%s %s created the channel", creator.FirstName, creator.LastName),
+	})
 	if err != nil {
 		return ChannelResponse{}, fmt.Errorf("failed to create channel: %w", err)
 	}
 
-	return s.toChannelResponse(channel), nil
+	channelResponse := s.toChannelResponse(result.Channel)
+
+	if s.hub != nil {
+		wsMessage := &WSMessage{
+			Type:        "channel_created",
+			Data:        channelResponse,
+			WorkspaceID: workspaceID,
+			ChannelID:   &result.Channel.ID,
+			UserID:      userID,
+			Timestamp:   time.Now(),
+		}
+		// Only the creator knows about a new private channel
+		if result.Channel.IsPrivate {
+			s.hub.BroadcastToUser(userID, wsMessage)
+		} else {
+			s.hub.BroadcastToWorkspace(workspaceID, wsMessage)
+		}
+	}
+
+	return channelResponse, nil
 }
 
 // GetChannel retrieves a channel by ID
@@ -100,6 +136,49 @@ func (s *ChannelService) ListPublicChannelsByWorkspace(ctx context.Context, work
 	return channelResponses, nil
 }
 
+// ListChannelsForUser lists the channels in a workspace a user can see: the
+// public ones and the private ones they're a member of
+func (s *ChannelService) ListChannelsForUser(ctx context.Context, userID, workspaceID int64, limit, offset int32) ([]UserChannelResponse, error) {
+	channels, err := s.store.ListChannelsForUser(ctx, db.ListChannelsForUserParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channels: %w", err)
+	}
+
+	channelResponses := make([]UserChannelResponse, len(channels))
+	for i, channel := range channels {
+		channelResponses[i] = UserChannelResponse{
+			ChannelResponse: s.toChannelResponse(db.Channel{
+				ID:          channel.ID,
+				WorkspaceID: channel.WorkspaceID,
+				Name:        channel.Name,
+				IsPrivate:   channel.IsPrivate,
+				CreatedBy:   channel.CreatedBy,
+				CreatedAt:   channel.CreatedAt,
+			}),
+			IsMember: channel.IsMember,
+		}
+	}
+
+	return channelResponses, nil
+}
+
+// IsChannelMember reports whether a user has joined a channel
+func (s *ChannelService) IsChannelMember(ctx context.Context, userID, channelID int64) (bool, error) {
+	isMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+		ChannelID: channelID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to check channel membership: %w", err)
+	}
+	return isMember, nil
+}
+
 // UpdateChannel updates a channel's information
 func (s *ChannelService) UpdateChannel(ctx context.Context, userID, channelID int64, name string, isPrivate bool) (ChannelResponse, error) {
 	// Get the channel first to check workspace access
@@ -111,8 +190,8 @@ func (s *ChannelService) UpdateChannel(ctx context.Context, userID, channelID in
 		return ChannelResponse{}, fmt.Errorf("failed to get channel: %w", err)
 	}
 
-	// Check if user has access to the workspace (only admins can update channels for now)
-	err = s.workspaceService.CheckUserWorkspaceAdmin(ctx, userID, channel.WorkspaceID)
+	// Only admins and members allowed to manage channels can update them
+	err = s.workspaceService.CheckUserWorkspacePermission(ctx, userID, channel.WorkspaceID, PermissionManageChannels)
 	if err != nil {
 		return ChannelResponse{}, err
 	}
@@ -135,7 +214,8 @@ func (s *ChannelService) UpdateChannel(ctx context.Context, userID, channelID in
 	return s.toChannelResponse(updatedChannel), nil
 }
 
-// DeleteChannel deletes a channel
+// DeleteChannel deletes a channel. Admins can restore it during the recovery
+// window, after which it's purged.
 func (s *ChannelService) DeleteChannel(ctx context.Context, userID, channelID int64) error {
 	// Get the channel first to check workspace access
 	channel, err := s.store.GetChannelByID(ctx, channelID)
@@ -146,16 +226,32 @@ func (s *ChannelService) DeleteChannel(ctx context.Context, userID, channelID in
 		return fmt.Errorf("failed to get channel: %w", err)
 	}
 
-	// Check if user has admin access to the workspace
-	err = s.workspaceService.CheckUserWorkspaceAdmin(ctx, userID, channel.WorkspaceID)
+	// Only admins and members allowed to manage channels can delete them
+	err = s.workspaceService.CheckUserWorkspacePermission(ctx, userID, channel.WorkspaceID, PermissionManageChannels)
 	if err != nil {
 		return err
 	}
 
-	err = s.store.DeleteChannel(ctx, channelID)
+	// Deleting the channel would delete messages under legal hold
+	held, err := s.store.ChannelHasActiveLegalHold(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to check legal holds: %w", err)
+	}
+	if held {
+		return errors.New("channel has content under legal hold")
+	}
+
+	// The channel stays restorable until the purge job removes it
+	rows, err := s.store.SoftDeleteChannel(ctx, db.SoftDeleteChannelParams{
+		ID:        channelID,
+		DeletedBy: sql.NullInt64{Int64: userID, Valid: true},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete channel: %w", err)
 	}
+	if rows == 0 {
+		return errors.New("channel not found")
+	}
 
 	return nil
 }
@@ -189,8 +285,7 @@ func (s *ChannelService) CheckChannelAccess(ctx context.Context, userID, channel
 }
 
 // UserHasChannelAccess checks if a user has access to a channel (returns boolean)
-func (s *ChannelService) UserHasChannelAccess(userID, channelID int64) bool {
-	ctx := context.Background()
+func (s *ChannelService) UserHasChannelAccess(ctx context.Context, userID, channelID int64) bool {
 	err := s.CheckChannelAccess(ctx, userID, channelID)
 	return err == nil
 }
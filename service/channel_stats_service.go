@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+const (
+	// maxChannelStatsDays caps how many days channel stats cover
+	maxChannelStatsDays = 365
+	// channelStatsTopPosters is how many top posters channel stats list
+	channelStatsTopPosters = 10
+	// channelStatsChunkDays caps how many days one rollup transaction rebuilds,
+	// so backfilling a long history doesn't hold one huge transaction
+	channelStatsChunkDays = 31
+)
+
+// channelStatsMetrics counts channel stats rollups and the rows they wrote,
+// published at /debug/vars
+var channelStatsMetrics = expvar.NewMap("channel_stats")
+
+// ChannelDayStats counts a channel's messages on one day
+type ChannelDayStats struct {
+	// Day in UTC, e.g. 2006-01-02
+	Date         string `json:"date"`
+	MessageCount int32  `json:"message_count"`
+	ReplyCount   int32  `json:"reply_count"`
+	ThreadCount  int32  `json:"thread_count"`
+}
+
+// ChannelPosterStats counts the messages a user posted in a channel
+type ChannelPosterStats struct {
+	UserID       int64  `json:"user_id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	MessageCount int64  `json:"message_count"`
+}
+
+// ChannelStatsResponse summarizes a channel's activity over a number of days
+type ChannelStatsResponse struct {
+	ChannelID int64 `json:"channel_id"`
+	// First and last day covered, in UTC
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Messages posted, thread replies included
+	MessageCount int64 `json:"message_count"`
+	ReplyCount   int64 `json:"reply_count"`
+	// Top-level messages that have replies
+	ThreadCount int64 `json:"thread_count"`
+	// Share of messages that are thread replies
+	ReplyRatio float64 `json:"reply_ratio"`
+	// Share of top-level messages that have replies
+	ThreadRatio float64              `json:"thread_ratio"`
+	Days        []ChannelDayStats    `json:"days"`
+	TopPosters  []ChannelPosterStats `json:"top_posters"`
+	// When the last message was posted, as of the last rollup
+	LastActivityAt *time.Time `json:"last_activity_at"`
+	// When the stats were last rolled up; later messages aren't counted yet
+	RolledUpAt *time.Time `json:"rolled_up_at"`
+}
+
+// ChannelStatsService serves per-channel message stats from daily rollups,
+// which a nightly job rebuilds from the messages table
+type ChannelStatsService struct {
+	store        db.ChannelStore
+	rollupHour   int
+	lookbackDays int
+	now          func() time.Time
+}
+
+// NewChannelStatsService creates a new channel stats service
+func NewChannelStatsService(store db.ChannelStore, config util.Config) *ChannelStatsService {
+	rollupHour := config.ChannelStatsRollupHour
+	if rollupHour < 0 || rollupHour > 23 {
+		rollupHour = 2
+	}
+	lookbackDays := config.ChannelStatsLookbackDays
+	if lookbackDays <= 0 {
+		lookbackDays = 7
+	}
+
+	return &ChannelStatsService{
+		store:        store,
+		rollupHour:   rollupHour,
+		lookbackDays: lookbackDays,
+		now:          time.Now,
+	}
+}
+
+// GetChannelStats summarizes a channel's activity over the last days, today
+// included. Note: This method assumes channel access has been validated by the caller.
+func (s *ChannelStatsService) GetChannelStats(ctx context.Context, channelID int64, days int) (ChannelStatsResponse, error) {
+	if days <= 0 || days > maxChannelStatsDays {
+		return ChannelStatsResponse{}, fmt.Errorf("days must be between 1 and %d", maxChannelStatsDays)
+	}
+
+	toDay := startOfUTCDay(s.now()).AddDate(0, 0, 1)
+	fromDay := toDay.AddDate(0, 0, -days)
+
+	dailyStats, err := s.store.ListChannelDailyStats(ctx, db.ListChannelDailyStatsParams{
+		ChannelID: channelID,
+		FromDay:   fromDay,
+		ToDay:     toDay,
+	})
+	if err != nil {
+		return ChannelStatsResponse{}, fmt.Errorf("failed to get channel stats: %w", err)
+	}
+
+	posters, err := s.store.ListChannelTopPosters(ctx, db.ListChannelTopPostersParams{
+		ChannelID: channelID,
+		FromDay:   fromDay,
+		ToDay:     toDay,
+		Limit:     channelStatsTopPosters,
+	})
+	if err != nil {
+		return ChannelStatsResponse{}, fmt.Errorf("failed to get channel top posters: %w", err)
+	}
+
+	response := ChannelStatsResponse{
+		ChannelID:  channelID,
+		From:       fromDay.Format(time.DateOnly),
+		To:         toDay.AddDate(0, 0, -1).Format(time.DateOnly),
+		Days:       make([]ChannelDayStats, 0, days),
+		TopPosters: make([]ChannelPosterStats, len(posters)),
+	}
+
+	// Days without messages have no rollup, so they're filled in with zeros
+	byDay := make(map[string]db.ChannelDailyStat, len(dailyStats))
+	for _, stat := range dailyStats {
+		byDay[stat.Day.Format(time.DateOnly)] = stat
+	}
+	for day := fromDay; day.Before(toDay); day = day.AddDate(0, 0, 1) {
+		date := day.Format(time.DateOnly)
+		stat := byDay[date]
+		response.Days = append(response.Days, ChannelDayStats{
+			Date:         date,
+			MessageCount: stat.MessageCount,
+			ReplyCount:   stat.ReplyCount,
+			ThreadCount:  stat.ThreadCount,
+		})
+		response.MessageCount += int64(stat.MessageCount)
+		response.ReplyCount += int64(stat.ReplyCount)
+		response.ThreadCount += int64(stat.ThreadCount)
+	}
+
+	if response.MessageCount > 0 {
+		response.ReplyRatio = float64(response.ReplyCount) / float64(response.MessageCount)
+	}
+	if topLevel := response.MessageCount - response.ReplyCount; topLevel > 0 {
+		response.ThreadRatio = float64(response.ThreadCount) / float64(topLevel)
+	}
+
+	for i, poster := range posters {
+		response.TopPosters[i] = ChannelPosterStats{
+			UserID:       poster.UserID,
+			FirstName:    poster.FirstName,
+			LastName:     poster.LastName,
+			MessageCount: poster.MessageCount,
+		}
+	}
+
+	// Last activity comes from all rollups, not just the requested days
+	activity, err := s.store.GetChannelLastActivity(ctx, channelID)
+	if err != nil && err != sql.ErrNoRows {
+		return ChannelStatsResponse{}, fmt.Errorf("failed to get channel last activity: %w", err)
+	}
+	if err == nil {
+		response.LastActivityAt = &activity.LastMessageAt
+		response.RolledUpAt = &activity.UpdatedAt
+	}
+
+	return response, nil
+}
+
+// RunRollup rebuilds the daily stats of today and the lookback days before
+// it, so replies and deletions since the last run are counted. The
+// first run backfills from the first channel message, and a run after a gap
+// catches up from the last day rolled up.
+func (s *ChannelStatsService) RunRollup(ctx context.Context) (db.RollupChannelStatsTxResult, error) {
+	var result db.RollupChannelStatsTxResult
+	channelStatsMetrics.Add("runs", 1)
+
+	toDay := startOfUTCDay(s.now()).AddDate(0, 0, 1)
+	fromDay := toDay.AddDate(0, 0, -s.lookbackDays-1)
+
+	latestDay, err := s.store.GetLatestChannelStatsDay(ctx)
+	switch {
+	case err == sql.ErrNoRows:
+		earliest, err := s.store.GetEarliestChannelMessageTime(ctx)
+		if err == sql.ErrNoRows {
+			return result, nil
+		}
+		if err != nil {
+			channelStatsMetrics.Add("errors", 1)
+			return result, fmt.Errorf("failed to get first channel message: %w", err)
+		}
+		if earliestDay := startOfUTCDay(earliest); earliestDay.Before(fromDay) {
+			fromDay = earliestDay
+		}
+	case err != nil:
+		channelStatsMetrics.Add("errors", 1)
+		return result, fmt.Errorf("failed to get last channel stats day: %w", err)
+	default:
+		if latestDay = startOfUTCDay(latestDay); latestDay.Before(fromDay) {
+			fromDay = latestDay
+		}
+	}
+
+	for chunkFrom := fromDay; chunkFrom.Before(toDay); chunkFrom = chunkFrom.AddDate(0, 0, channelStatsChunkDays) {
+		chunkTo := chunkFrom.AddDate(0, 0, channelStatsChunkDays)
+		if chunkTo.After(toDay) {
+			chunkTo = toDay
+		}
+
+		rolledUp, err := s.store.RollupChannelStatsTx(ctx, db.RollupChannelStatsTxParams{
+			FromDay: chunkFrom,
+			ToDay:   chunkTo,
+		})
+		if err != nil {
+			channelStatsMetrics.Add("errors", 1)
+			return result, fmt.Errorf("failed to roll up channel stats: %w", err)
+		}
+		result.ChannelDays += rolledUp.ChannelDays
+		result.PosterDays += rolledUp.PosterDays
+		channelStatsMetrics.Add("channel_days", rolledUp.ChannelDays)
+	}
+
+	return result, nil
+}
+
+// StartRollupJob rolls up channel stats every night at the configured hour
+// (UTC) until the context is cancelled
+func (s *ChannelStatsService) StartRollupJob(ctx context.Context) {
+	for {
+		timer := time.NewTimer(time.Until(nextRollupAt(s.now(), s.rollupHour)))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			result, err := s.RunRollup(ctx)
+			if err != nil {
+				fmt.Printf("Error rolling up channel stats: %v\n", err)
+				continue
+			}
+			fmt.Printf("Rolled up stats for %d channel days\n", result.ChannelDays)
+		}
+	}
+}
+
+// nextRollupAt returns the next time after now that it's the given hour in UTC
+func nextRollupAt(now time.Time, hour int) time.Time {
+	next := startOfUTCDay(now).Add(time.Duration(hour) * time.Hour)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// startOfUTCDay returns midnight UTC of the day t falls on in UTC
+func startOfUTCDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
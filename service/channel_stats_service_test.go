@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelStatsService_GetChannelStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockChannelStore(ctrl)
+	statsService := NewChannelStatsService(store, util.Config{})
+	statsService.now = func() time.Time { return time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC) }
+
+	fromDay := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	lastMessageAt := time.Date(2026, 3, 10, 1, 30, 0, 0, time.UTC)
+	rolledUpAt := time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC)
+
+	store.EXPECT().
+		ListChannelDailyStats(gomock.Any(), gomock.Eq(db.ListChannelDailyStatsParams{ChannelID: 7, FromDay: fromDay, ToDay: toDay})).
+		Times(1).
+		Return([]db.ChannelDailyStat{
+			{ChannelID: 7, Day: fromDay, MessageCount: 6, ReplyCount: 2, ThreadCount: 1},
+			{ChannelID: 7, Day: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), MessageCount: 4, ReplyCount: 2, ThreadCount: 1},
+		}, nil)
+	store.EXPECT().
+		ListChannelTopPosters(gomock.Any(), gomock.Eq(db.ListChannelTopPostersParams{ChannelID: 7, FromDay: fromDay, ToDay: toDay, Limit: channelStatsTopPosters})).
+		Times(1).
+		Return([]db.ListChannelTopPostersRow{{UserID: 3, FirstName: "Ada", LastName: "Lovelace", MessageCount: 8}}, nil)
+	store.EXPECT().
+		GetChannelLastActivity(gomock.Any(), gomock.Eq(int64(7))).
+		Times(1).
+		Return(db.GetChannelLastActivityRow{LastMessageAt: lastMessageAt, UpdatedAt: rolledUpAt}, nil)
+
+	stats, err := statsService.GetChannelStats(context.Background(), 7, 3)
+	require.NoError(t, err)
+	require.Equal(t, "2026-03-08", stats.From)
+	require.Equal(t, "2026-03-10", stats.To)
+
+	// Days without messages are filled in
+	require.Equal(t, []ChannelDayStats{
+		{Date: "2026-03-08", MessageCount: 6, ReplyCount: 2, ThreadCount: 1},
+		{Date: "2026-03-09"},
+		{Date: "2026-03-10", MessageCount: 4, ReplyCount: 2, ThreadCount: 1},
+	}, stats.Days)
+
+	require.Equal(t, int64(10), stats.MessageCount)
+	require.Equal(t, int64(4), stats.ReplyCount)
+	require.Equal(t, int64(2), stats.ThreadCount)
+	require.InDelta(t, 0.4, stats.ReplyRatio, 0.0001)
+	require.InDelta(t, 2.0/6.0, stats.ThreadRatio, 0.0001)
+	require.Equal(t, []ChannelPosterStats{{UserID: 3, FirstName: "Ada", LastName: "Lovelace", MessageCount: 8}}, stats.TopPosters)
+	require.Equal(t, lastMessageAt, *stats.LastActivityAt)
+	require.Equal(t, rolledUpAt, *stats.RolledUpAt)
+
+	_, err = statsService.GetChannelStats(context.Background(), 7, maxChannelStatsDays+1)
+	require.Error(t, err)
+}
+
+func TestChannelStatsService_RunRollup(t *testing.T) {
+	now := time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Lookback", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockChannelStore(ctrl)
+		statsService := NewChannelStatsService(store, util.Config{ChannelStatsLookbackDays: 7})
+		statsService.now = func() time.Time { return now }
+
+		store.EXPECT().GetLatestChannelStatsDay(gomock.Any()).Times(1).Return(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), nil)
+		store.EXPECT().
+			RollupChannelStatsTx(gomock.Any(), gomock.Eq(db.RollupChannelStatsTxParams{
+				FromDay: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+				ToDay:   tomorrow,
+			})).
+			Times(1).
+			Return(db.RollupChannelStatsTxResult{ChannelDays: 12, PosterDays: 30}, nil)
+
+		result, err := statsService.RunRollup(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, db.RollupChannelStatsTxResult{ChannelDays: 12, PosterDays: 30}, result)
+	})
+
+	t.Run("Backfill", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockChannelStore(ctrl)
+		statsService := NewChannelStatsService(store, util.Config{ChannelStatsLookbackDays: 7})
+		statsService.now = func() time.Time { return now }
+
+		// The first rollup covers every message, a month at a time
+		store.EXPECT().GetLatestChannelStatsDay(gomock.Any()).Times(1).Return(time.Time{}, sql.ErrNoRows)
+		store.EXPECT().GetEarliestChannelMessageTime(gomock.Any()).Times(1).Return(time.Date(2026, 1, 1, 18, 0, 0, 0, time.UTC), nil)
+		gomock.InOrder(
+			store.EXPECT().
+				RollupChannelStatsTx(gomock.Any(), gomock.Eq(db.RollupChannelStatsTxParams{
+					FromDay: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+					ToDay:   time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+				})).
+				Times(1).
+				Return(db.RollupChannelStatsTxResult{ChannelDays: 1}, nil),
+			store.EXPECT().
+				RollupChannelStatsTx(gomock.Any(), gomock.Eq(db.RollupChannelStatsTxParams{
+					FromDay: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+					ToDay:   time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+				})).
+				Times(1).
+				Return(db.RollupChannelStatsTxResult{ChannelDays: 2}, nil),
+			store.EXPECT().
+				RollupChannelStatsTx(gomock.Any(), gomock.Eq(db.RollupChannelStatsTxParams{
+					FromDay: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+					ToDay:   tomorrow,
+				})).
+				Times(1).
+				Return(db.RollupChannelStatsTxResult{ChannelDays: 3}, nil),
+		)
+
+		result, err := statsService.RunRollup(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, int64(6), result.ChannelDays)
+	})
+
+	t.Run("NoMessages", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockChannelStore(ctrl)
+		statsService := NewChannelStatsService(store, util.Config{})
+
+		store.EXPECT().GetLatestChannelStatsDay(gomock.Any()).Times(1).Return(time.Time{}, sql.ErrNoRows)
+		store.EXPECT().GetEarliestChannelMessageTime(gomock.Any()).Times(1).Return(time.Time{}, sql.ErrNoRows)
+		store.EXPECT().RollupChannelStatsTx(gomock.Any(), gomock.Any()).Times(0)
+
+		result, err := statsService.RunRollup(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, db.RollupChannelStatsTxResult{}, result)
+	})
+}
+
+func TestNextRollupAt(t *testing.T) {
+	require.Equal(t,
+		time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC),
+		nextRollupAt(time.Date(2026, 3, 10, 1, 59, 0, 0, time.UTC), 2))
+	require.Equal(t,
+		time.Date(2026, 3, 11, 2, 0, 0, 0, time.UTC),
+		nextRollupAt(time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC), 2))
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// cleanupBatchSize caps how many deleted messages one statement purges
+const cleanupBatchSize = 500
+
+// cleanupMetrics counts cleanup runs and the rows they removed, published
+// at /debug/vars
+var cleanupMetrics = expvar.NewMap("cleanup")
+
+// CleanupResult counts the rows removed by one cleanup run
+type CleanupResult struct {
+	DeletedMessages   int64 `json:"deleted_messages"`
+	IncompleteUploads int64 `json:"incomplete_uploads"`
+}
+
+// cleanupServiceStore covers expired files and the messages purged after their recovery window
+type cleanupServiceStore interface {
+	db.FileStore
+	db.MessageStore
+}
+
+// CleanupService permanently removes data nobody can get back: messages
+// deleted longer ago than the retention period and uploads that never
+// completed
+type CleanupService struct {
+	store            cleanupServiceStore
+	messageRetention time.Duration
+}
+
+// NewCleanupService creates a new cleanup service
+func NewCleanupService(store cleanupServiceStore, config util.Config) *CleanupService {
+	messageRetention := config.DeletedMessageRetention
+	if messageRetention <= 0 {
+		messageRetention = 30 * 24 * time.Hour
+	}
+
+	return &CleanupService{
+		store:            store,
+		messageRetention: messageRetention,
+	}
+}
+
+// RunCleanup purges deleted messages past their retention in batches, then
+// removes incomplete uploads. It returns how many rows were removed, including
+// those removed before an error.
+func (s *CleanupService) RunCleanup(ctx context.Context) (CleanupResult, error) {
+	var result CleanupResult
+	cleanupMetrics.Add("runs", 1)
+
+	deletedBefore := sql.NullTime{Time: time.Now().Add(-s.messageRetention), Valid: true}
+	for {
+		purged, err := s.store.PurgeDeletedMessages(ctx, db.PurgeDeletedMessagesParams{
+			DeletedBefore: deletedBefore,
+			Limit:         cleanupBatchSize,
+		})
+		result.DeletedMessages += purged
+		cleanupMetrics.Add("deleted_messages_purged", purged)
+		if err != nil {
+			cleanupMetrics.Add("errors", 1)
+			return result, fmt.Errorf("failed to purge deleted messages: %w", err)
+		}
+		if purged < cleanupBatchSize {
+			break
+		}
+	}
+
+	removed, err := s.store.CleanupIncompleteUploads(ctx)
+	result.IncompleteUploads = removed
+	cleanupMetrics.Add("incomplete_uploads_removed", removed)
+	if err != nil {
+		cleanupMetrics.Add("errors", 1)
+		return result, fmt.Errorf("failed to clean up incomplete uploads: %w", err)
+	}
+
+	return result, nil
+}
+
+// StartCleanupJob runs the cleanup on an interval until the context is cancelled
+func (s *CleanupService) StartCleanupJob(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := s.RunCleanup(ctx)
+			if err != nil {
+				fmt.Printf("Error cleaning up: %v\n", err)
+			}
+			if result.DeletedMessages > 0 || result.IncompleteUploads > 0 {
+				fmt.Printf("Cleaned up %d deleted messages and %d incomplete uploads\n", result.DeletedMessages, result.IncompleteUploads)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanupService_RunCleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	cleanupService := NewCleanupService(store, util.Config{DeletedMessageRetention: 24 * time.Hour})
+
+	purgedBefore := metricValue("deleted_messages_purged")
+
+	// Full batches are followed by another until one comes back short
+	gomock.InOrder(
+		store.EXPECT().
+			PurgeDeletedMessages(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.PurgeDeletedMessagesParams) (int64, error) {
+				require.WithinDuration(t, time.Now().Add(-24*time.Hour), arg.DeletedBefore.Time, time.Minute)
+				require.Equal(t, int32(cleanupBatchSize), arg.Limit)
+				return cleanupBatchSize, nil
+			}),
+		store.EXPECT().PurgeDeletedMessages(gomock.Any(), gomock.Any()).Times(1).Return(int64(20), nil),
+		store.EXPECT().CleanupIncompleteUploads(gomock.Any()).Times(1).Return(int64(3), nil),
+	)
+
+	result, err := cleanupService.RunCleanup(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, CleanupResult{DeletedMessages: cleanupBatchSize + 20, IncompleteUploads: 3}, result)
+	require.Equal(t, purgedBefore+cleanupBatchSize+20, metricValue("deleted_messages_purged"))
+
+	// A failed purge stops the run and still reports what was removed
+	store.EXPECT().PurgeDeletedMessages(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errors.New("connection reset"))
+	store.EXPECT().CleanupIncompleteUploads(gomock.Any()).Times(0)
+
+	result, err = cleanupService.RunCleanup(context.Background())
+	require.Error(t, err)
+	require.Equal(t, CleanupResult{}, result)
+}
+
+func metricValue(key string) int64 {
+	value, ok := cleanupMetrics.Get(key).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return value.Value()
+}
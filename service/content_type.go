@@ -0,0 +1,267 @@
+package service
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Uploads are checked three ways before they're stored: the type the client
+// declares has to be allowed, has to fit the file's extension, and has to
+// fit what the content turns out to be when it's sniffed. Types browsers
+// would run as a page of this site are refused unless active content is
+// allowed.
+
+// sniffLength is how much of a file http.DetectContentType looks at
+const sniffLength = 512
+
+// extensionTypes lists the types a file with a known extension can be
+// declared as, the first being the one assumed when none is declared
+var extensionTypes = map[string][]string{
+	".jpg":  {"image/jpeg"},
+	".jpeg": {"image/jpeg"},
+	".png":  {"image/png"},
+	".gif":  {"image/gif"},
+	".webp": {"image/webp"},
+	".bmp":  {"image/bmp"},
+	".svg":  {"image/svg+xml"},
+	".pdf":  {"application/pdf"},
+	".txt":  {"text/plain"},
+	".md":   {"text/markdown", "text/plain"},
+	".csv":  {"text/csv", "text/plain"},
+	".json": {"application/json", "text/plain"},
+	".xml":  {"application/xml", "text/xml"},
+	".html": {"text/html"},
+	".htm":  {"text/html"},
+	".zip":  {"application/zip"},
+	".doc":  {"application/msword"},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document"},
+	".xls":  {"application/vnd.ms-excel"},
+	".xlsx": {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+	".ppt":  {"application/vnd.ms-powerpoint"},
+	".pptx": {"application/vnd.openxmlformats-officedocument.presentationml.presentation"},
+	".mp3":  {"audio/mpeg"},
+	".m4a":  {"audio/mp4"},
+	".ogg":  {"audio/ogg", "video/ogg"},
+	".opus": {"audio/ogg"},
+	".wav":  {"audio/wav"},
+	".webm": {"audio/webm", "video/webm"},
+	".mp4":  {"video/mp4", "audio/mp4"},
+	".mov":  {"video/quicktime"},
+}
+
+// mimeAliases maps other names clients send for a type to the one used here
+var mimeAliases = map[string]string{
+	"image/jpg":                    "image/jpeg",
+	"image/pjpeg":                  "image/jpeg",
+	"image/x-ms-bmp":               "image/bmp",
+	"application/x-pdf":            "application/pdf",
+	"application/x-zip-compressed": "application/zip",
+	"text/x-csv":                   "text/csv",
+	"audio/mp3":                    "audio/mpeg",
+	"audio/x-m4a":                  "audio/mp4",
+	"audio/x-wav":                  "audio/wav",
+	"audio/wave":                   "audio/wav",
+	"audio/vnd.wave":               "audio/wav",
+}
+
+// sniffedMatches lists the declared types content sniffed as another type
+// can be. Office documents are zip archives, and text formats all sniff as
+// plain text or XML.
+var sniffedMatches = map[string][]string{
+	"application/zip": {
+		"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	},
+	"text/plain":      {"text/csv", "text/markdown", "application/json", "image/svg+xml"},
+	"text/xml":        {"application/xml", "image/svg+xml"},
+	"video/mp4":       {"audio/mp4", "video/quicktime"},
+	"video/webm":      {"audio/webm"},
+	"application/ogg": {"audio/ogg", "video/ogg"},
+	"audio/wave":      {"audio/wav"},
+}
+
+// signedTypes always start with a signature the sniffer knows, or are text,
+// so content it doesn't recognize can't be one of them. Other types, like
+// older office documents and some audio, aren't recognized and are taken
+// as declared.
+var signedTypes = map[string]bool{
+	"image/jpeg":       true,
+	"image/png":        true,
+	"image/gif":        true,
+	"image/webp":       true,
+	"image/bmp":        true,
+	"image/svg+xml":    true,
+	"application/pdf":  true,
+	"application/zip":  true,
+	"application/json": true,
+	"text/plain":       true,
+	"text/csv":         true,
+	"text/markdown":    true,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         true,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": true,
+}
+
+// activeContentTypes are run as a page of this site when they're opened
+var activeContentTypes = map[string]bool{
+	"text/html":             true,
+	"application/xhtml+xml": true,
+}
+
+// svgActiveElements are SVG elements that run script or embed pages
+var svgActiveElements = map[string]bool{
+	"script":        true,
+	"foreignobject": true,
+	"iframe":        true,
+	"embed":         true,
+	"object":        true,
+	"handler":       true,
+}
+
+// getMimeTypeFromExtension returns MIME type based on file extension
+func (s *FileService) getMimeTypeFromExtension(ext string) string {
+	if types, ok := extensionTypes[strings.ToLower(ext)]; ok {
+		return types[0]
+	}
+	return "application/octet-stream"
+}
+
+// declaredContentType returns the type a client declared for an upload,
+// without parameters and under the name used here. Uploads declared without
+// a type get the one their extension stands for.
+func (s *FileService) declaredContentType(header *multipart.FileHeader) string {
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+
+	contentType, _, err := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	if err != nil {
+		return s.getMimeTypeFromExtension(ext)
+	}
+	if alias, ok := mimeAliases[contentType]; ok {
+		contentType = alias
+	}
+	// Windows browsers send CSV files as Excel sheets
+	if ext == ".csv" && contentType == "application/vnd.ms-excel" {
+		contentType = "text/csv"
+	}
+	return contentType
+}
+
+// extensionMatches reports whether a file with the extension can be of the
+// type. Files with extensions that aren't known can be of any type.
+func extensionMatches(ext, contentType string) bool {
+	types, ok := extensionTypes[strings.ToLower(ext)]
+	return !ok || slices.Contains(types, contentType)
+}
+
+// contentMatches reports whether content sniffed as one type can be of the
+// declared type. Content declared as a generic binary can be anything.
+func contentMatches(sniffed, declared string) bool {
+	if sniffed == declared || declared == "application/octet-stream" || slices.Contains(sniffedMatches[sniffed], declared) {
+		return true
+	}
+	return sniffed == "application/octet-stream" && !signedTypes[declared]
+}
+
+// ValidateContent checks that an upload's content is what its declared type
+// says it is, and that it won't run as a page of this site unless active
+// content is allowed. The content is read from the start and left there.
+func (s *FileService) ValidateContent(content io.ReadSeeker, contentType string) error {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to reset file position: %w", err)
+	}
+
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if !contentMatches(sniffed, contentType) {
+		return fmt.Errorf("file is declared as %s but contains %s", contentType, sniffed)
+	}
+
+	if s.config.FileAllowActiveContent {
+		return nil
+	}
+	if activeContentTypes[sniffed] {
+		return errors.New("HTML files are not allowed")
+	}
+	if contentType == "image/svg+xml" {
+		if err := checkSVG(content); err != nil {
+			return err
+		}
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to reset file position: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkSVG rejects SVG images that run script, through script elements,
+// event handler attributes or javascript: links, or that embed other pages.
+// Entity declarations are refused too, as they can hide any of these.
+func checkSVG(content io.Reader) error {
+	errActive := errors.New("SVG images with scripts are not allowed")
+
+	decoder := xml.NewDecoder(content)
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid SVG image: %w", err)
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			if svgActiveElements[strings.ToLower(token.Name.Local)] {
+				return errActive
+			}
+			for _, attr := range token.Attr {
+				if strings.HasPrefix(strings.ToLower(attr.Name.Local), "on") || isScriptURL(attr.Value) {
+					return errActive
+				}
+			}
+		case xml.Directive:
+			if bytes.Contains(bytes.ToUpper(token), []byte("ENTITY")) {
+				return errActive
+			}
+		}
+	}
+}
+
+// isScriptURL reports whether a link runs script when it's followed. Browsers
+// ignore whitespace and control characters inside the scheme.
+func isScriptURL(value string) bool {
+	scheme, _, found := strings.Cut(value, ":")
+	if !found {
+		return false
+	}
+	scheme = strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, scheme)
+
+	switch strings.ToLower(scheme) {
+	case "javascript", "vbscript":
+		return true
+	case "data":
+		return strings.HasPrefix(strings.ToLower(strings.TrimSpace(value)), "data:text/html")
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func testZip(t *testing.T) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	_, err := writer.Create("word/document.xml")
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestFileService_ValidateContent(t *testing.T) {
+	fileService := &FileService{config: util.Config{}}
+
+	testCases := []struct {
+		name        string
+		content     []byte
+		contentType string
+		wantErr     string
+	}{
+		{
+			name:        "PNG",
+			content:     encodeTestPNG(t),
+			contentType: "image/png",
+		},
+		{
+			name:        "TextAsPNG",
+			content:     []byte("definitely not an image"),
+			contentType: "image/png",
+			wantErr:     "file is declared as image/png but contains text/plain",
+		},
+		{
+			name:        "UnknownBinaryAsPDF",
+			content:     []byte{0x00, 0x01, 0x02, 0x03},
+			contentType: "application/pdf",
+			wantErr:     "file is declared as application/pdf but contains application/octet-stream",
+		},
+		{
+			name:        "OfficeDocument",
+			content:     testZip(t),
+			contentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+		},
+		{
+			name:        "CSV",
+			content:     []byte("name,email\nAda,ada@example.com\n"),
+			contentType: "text/csv",
+		},
+		{
+			name:        "OlderOfficeDocument",
+			content:     []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1, 0x00},
+			contentType: "application/msword",
+		},
+		{
+			name:        "HTMLAsText",
+			content:     []byte("<!DOCTYPE html><html><script>alert(1)</script></html>"),
+			contentType: "text/plain",
+			wantErr:     "file is declared as text/plain but contains text/html",
+		},
+		{
+			name:        "HTMLAsBinary",
+			content:     []byte("<html><body onload=alert(1)></body></html>"),
+			contentType: "application/octet-stream",
+			wantErr:     "HTML files are not allowed",
+		},
+		{
+			name:        "SVG",
+			content:     []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"><a href="https://example.com"><circle r="4"/></a></svg>`),
+			contentType: "image/svg+xml",
+		},
+		{
+			name:        "SVGScript",
+			content:     []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+			contentType: "image/svg+xml",
+			wantErr:     "SVG images with scripts are not allowed",
+		},
+		{
+			name:        "SVGEventHandler",
+			content:     []byte(`<svg xmlns="http://www.w3.org/2000/svg"><circle r="4" onclick="alert(1)"/></svg>`),
+			contentType: "image/svg+xml",
+			wantErr:     "SVG images with scripts are not allowed",
+		},
+		{
+			name:        "SVGScriptLink",
+			content:     []byte(`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><a xlink:href=" java&#x09;script:alert(1)"><circle r="4"/></a></svg>`),
+			contentType: "image/svg+xml",
+			wantErr:     "SVG images with scripts are not allowed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := bytes.NewReader(tc.content)
+			err := fileService.ValidateContent(content, tc.contentType)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// The content is left at the start for storing
+			stored, err := io.ReadAll(content)
+			require.NoError(t, err)
+			require.Equal(t, tc.content, stored)
+		})
+	}
+
+	t.Run("ActiveContentAllowed", func(t *testing.T) {
+		fileService := &FileService{config: util.Config{FileAllowActiveContent: true}}
+		svg := `<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`
+		require.NoError(t, fileService.ValidateContent(strings.NewReader(svg), "image/svg+xml"))
+	})
+}
+
+// testUploadHeader builds the header of a file uploaded in a multipart form
+func testUploadHeader(t *testing.T, filename, contentType string, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	partHeader := textproto.MIMEHeader{}
+	partHeader.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	partHeader.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(partHeader)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { form.RemoveAll() })
+	return form.File["file"][0]
+}
+
+func TestFileService_UploadFileRecordsContentScan(t *testing.T) {
+	const workspaceID, uploaderID = int64(5), int64(7)
+	config := util.Config{
+		FileStoragePath:  t.TempDir(),
+		FileMaxSize:      1 << 20,
+		FileAllowedTypes: "image/png",
+	}
+
+	scanOf := func(t *testing.T, arg db.CreateWebhookEventParams) map[string]interface{} {
+		require.Equal(t, WebhookEventFileScanned, arg.EventType)
+		require.Equal(t, workspaceID, arg.WorkspaceID)
+		require.Equal(t, uploaderID, arg.UserID)
+
+		var data map[string]interface{}
+		require.NoError(t, json.Unmarshal(arg.Payload, &data))
+		require.Equal(t, "content_type", data["check"])
+		return data
+	}
+
+	t.Run("Passed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			CreateFile(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.CreateFileParams) (db.File, error) {
+				return db.File{ID: 9, WorkspaceID: arg.WorkspaceID, UploaderID: arg.UploaderID, FilePath: arg.FilePath, MimeType: arg.MimeType}, nil
+			})
+		store.EXPECT().UpdateFileUploadStatus(gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().GetUser(gomock.Any(), uploaderID).Return(db.User{ID: uploaderID}, nil)
+		store.EXPECT().
+			CreateWebhookEvent(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateWebhookEventParams) (db.EventOutbox, error) {
+				data := scanOf(t, arg)
+				require.Equal(t, "passed", data["result"])
+				require.Equal(t, float64(9), data["file_id"])
+				return db.EventOutbox{}, nil
+			})
+
+		fileService := NewFileService(store, config, nil)
+		_, err := fileService.UploadFile(context.Background(), FileUploadRequest{
+			WorkspaceID: workspaceID,
+			File:        testUploadHeader(t, "chart.png", "image/png", encodeTestPNG(t)),
+		}, uploaderID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().CreateFile(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().
+			CreateWebhookEvent(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateWebhookEventParams) (db.EventOutbox, error) {
+				data := scanOf(t, arg)
+				require.Equal(t, "rejected", data["result"])
+				require.Equal(t, "file is declared as image/png but contains text/plain", data["reason"])
+				require.NotContains(t, data, "file_id")
+				return db.EventOutbox{}, nil
+			})
+
+		fileService := NewFileService(store, config, nil)
+		_, err := fileService.UploadFile(context.Background(), FileUploadRequest{
+			WorkspaceID: workspaceID,
+			File:        testUploadHeader(t, "chart.png", "image/png", []byte("definitely not an image")),
+		}, uploaderID)
+		require.Error(t, err)
+	})
+}
+
+func TestFileService_DeclaredContentType(t *testing.T) {
+	fileService := &FileService{}
+	header := func(filename, contentType string) *multipart.FileHeader {
+		header := &multipart.FileHeader{Filename: filename, Header: textproto.MIMEHeader{}}
+		if contentType != "" {
+			header.Header.Set("Content-Type", contentType)
+		}
+		return header
+	}
+
+	require.Equal(t, "text/plain", fileService.declaredContentType(header("notes.txt", "text/plain; charset=utf-8")))
+	require.Equal(t, "image/jpeg", fileService.declaredContentType(header("photo.JPG", "")))
+	require.Equal(t, "application/zip", fileService.declaredContentType(header("archive.zip", "application/x-zip-compressed")))
+	require.Equal(t, "text/csv", fileService.declaredContentType(header("people.csv", "application/vnd.ms-excel")))
+	require.Equal(t, "application/vnd.ms-excel", fileService.declaredContentType(header("budget.xls", "application/vnd.ms-excel")))
+}
+
+func TestIsScriptURL(t *testing.T) {
+	require.True(t, isScriptURL("javascript:alert(1)"))
+	require.True(t, isScriptURL(" JavaScript\t:alert(1)"))
+	require.True(t, isScriptURL("data:text/html;base64,PHNjcmlwdD4="))
+	require.False(t, isScriptURL("javascript"))
+	require.False(t, isScriptURL("https://example.com/javascript:"))
+	require.False(t, isScriptURL("data:image/png;base64,iVBORw0KGgo="))
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// purgeBatchSize caps how many channels or workspaces one purge pass removes
+const purgeBatchSize = 100
+
+// deletionServiceStore covers deleted channels and workspaces and the legal holds that keep them
+type deletionServiceStore interface {
+	db.ChannelStore
+	db.OrganizationStore
+	db.WorkspaceStore
+}
+
+// DeletionService restores deleted channels and workspaces during their
+// recovery window, and purges them for good once it has passed
+type DeletionService struct {
+	store          deletionServiceStore
+	recoveryWindow time.Duration
+}
+
+// NewDeletionService creates a new deletion service
+func NewDeletionService(store deletionServiceStore, config util.Config) *DeletionService {
+	recoveryWindow := config.DeletionRecoveryWindow
+	if recoveryWindow <= 0 {
+		recoveryWindow = 30 * 24 * time.Hour
+	}
+
+	return &DeletionService{
+		store:          store,
+		recoveryWindow: recoveryWindow,
+	}
+}
+
+// ListDeletedChannels lists a workspace's deleted channels that can still be restored
+func (s *DeletionService) ListDeletedChannels(ctx context.Context, workspaceID int64) ([]DeletedChannelResponse, error) {
+	channels, err := s.store.ListDeletedChannels(ctx, db.ListDeletedChannelsParams{
+		WorkspaceID:  workspaceID,
+		DeletedAfter: s.recoveryCutoff(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted channels: %w", err)
+	}
+
+	responses := make([]DeletedChannelResponse, len(channels))
+	for i, channel := range channels {
+		responses[i] = DeletedChannelResponse{
+			ID:          channel.ID,
+			WorkspaceID: channel.WorkspaceID,
+			Name:        channel.Name,
+			IsPrivate:   channel.IsPrivate,
+			CreatedBy:   channel.CreatedBy,
+			CreatedAt:   channel.CreatedAt,
+			DeletedAt:   channel.DeletedAt.Time,
+			PurgeAt:     channel.DeletedAt.Time.Add(s.recoveryWindow),
+		}
+		if channel.DeletedBy.Valid {
+			responses[i].DeletedBy = &channel.DeletedBy.Int64
+		}
+	}
+	return responses, nil
+}
+
+// RestoreChannel brings back a deleted channel with its members and messages
+func (s *DeletionService) RestoreChannel(ctx context.Context, workspaceID, channelID int64) (ChannelResponse, error) {
+	channel, err := s.store.RestoreChannel(ctx, db.RestoreChannelParams{
+		ID:           channelID,
+		WorkspaceID:  workspaceID,
+		DeletedAfter: s.recoveryCutoff(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ChannelResponse{}, errors.New("deleted channel not found")
+		}
+		// Another channel took the name while this one was deleted
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return ChannelResponse{}, errors.New("a channel with this name already exists")
+		}
+		return ChannelResponse{}, fmt.Errorf("failed to restore channel: %w", err)
+	}
+
+	return ChannelResponse{
+		ID:          channel.ID,
+		WorkspaceID: channel.WorkspaceID,
+		Name:        channel.Name,
+		IsPrivate:   channel.IsPrivate,
+		CreatedBy:   channel.CreatedBy,
+		CreatedAt:   channel.CreatedAt,
+	}, nil
+}
+
+// ListDeletedWorkspaces lists an organization's deleted workspaces that can still be restored
+func (s *DeletionService) ListDeletedWorkspaces(ctx context.Context, organizationID int64) ([]DeletedWorkspaceResponse, error) {
+	workspaces, err := s.store.ListDeletedWorkspaces(ctx, db.ListDeletedWorkspacesParams{
+		OrganizationID: organizationID,
+		DeletedAfter:   s.recoveryCutoff(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted workspaces: %w", err)
+	}
+
+	responses := make([]DeletedWorkspaceResponse, len(workspaces))
+	for i, workspace := range workspaces {
+		responses[i] = DeletedWorkspaceResponse{
+			ID:             workspace.ID,
+			OrganizationID: workspace.OrganizationID,
+			Name:           workspace.Name,
+			CreatedAt:      workspace.CreatedAt,
+			DeletedAt:      workspace.DeletedAt.Time,
+			PurgeAt:        workspace.DeletedAt.Time.Add(s.recoveryWindow),
+		}
+		if workspace.DeletedBy.Valid {
+			responses[i].DeletedBy = &workspace.DeletedBy.Int64
+		}
+	}
+	return responses, nil
+}
+
+// RestoreWorkspace brings back a deleted workspace with everything in it
+func (s *DeletionService) RestoreWorkspace(ctx context.Context, organizationID, workspaceID int64) (WorkspaceResponse, error) {
+	workspace, err := s.store.RestoreWorkspace(ctx, db.RestoreWorkspaceParams{
+		ID:             workspaceID,
+		OrganizationID: organizationID,
+		DeletedAfter:   s.recoveryCutoff(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WorkspaceResponse{}, errors.New("deleted workspace not found")
+		}
+		return WorkspaceResponse{}, fmt.Errorf("failed to restore workspace: %w", err)
+	}
+
+	return WorkspaceResponse{
+		ID:             workspace.ID,
+		OrganizationID: workspace.OrganizationID,
+		Name:           workspace.Name,
+		CreatedAt:      workspace.CreatedAt,
+	}, nil
+}
+
+// PurgeExpired permanently removes channels whose recovery window has passed
+// and hands expired workspaces to the teardown job, which clears out their
+// data in batches. Anything under legal hold is kept until the hold is
+// released.
+func (s *DeletionService) PurgeExpired(ctx context.Context) error {
+	cutoff := s.recoveryCutoff()
+
+	channels, err := s.store.ListPurgeableChannels(ctx, db.ListPurgeableChannelsParams{
+		DeletedBefore: cutoff,
+		Limit:         purgeBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list purgeable channels: %w", err)
+	}
+	for _, channel := range channels {
+		held, err := s.store.ChannelHasActiveLegalHold(ctx, channel.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check legal holds: %w", err)
+		}
+		if held {
+			continue
+		}
+		if err := s.store.DeleteChannel(ctx, channel.ID); err != nil {
+			return fmt.Errorf("failed to purge channel %d: %w", channel.ID, err)
+		}
+	}
+
+	workspaces, err := s.store.ListPurgeableWorkspaces(ctx, db.ListPurgeableWorkspacesParams{
+		DeletedBefore: cutoff,
+		Limit:         purgeBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list purgeable workspaces: %w", err)
+	}
+	for _, workspace := range workspaces {
+		held, err := s.store.WorkspaceHasActiveLegalHold(ctx, workspace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check legal holds: %w", err)
+		}
+		if held {
+			continue
+		}
+		err = s.store.CreateWorkspaceTeardown(ctx, db.CreateWorkspaceTeardownParams{
+			WorkspaceID:    workspace.ID,
+			OrganizationID: workspace.OrganizationID,
+			WorkspaceName:  workspace.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to queue teardown for workspace %d: %w", workspace.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// StartPurgeJob purges expired deletions on an interval until the context is cancelled
+func (s *DeletionService) StartPurgeJob(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.PurgeExpired(ctx); err != nil {
+				fmt.Printf("Error purging deleted content: %v\n", err)
+			}
+		}
+	}
+}
+
+// recoveryCutoff is the deletion time before which content can no longer be restored
+func (s *DeletionService) recoveryCutoff() time.Time {
+	return time.Now().Add(-s.recoveryWindow)
+}
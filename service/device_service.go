@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// TrustedDeviceDuration is how long a trusted device skips two-factor
+// authentication before it has to be trusted again
+const TrustedDeviceDuration = 30 * 24 * time.Hour
+
+// Reasons a login attempt failed
+const (
+	LoginFailureUnknownUser       = "unknown_user"
+	LoginFailureIncorrectPassword = "incorrect_password"
+)
+
+// LoginAttempt is a login to record in the login audit
+type LoginAttempt struct {
+	Email         string
+	UserID        int64 // Looked up by email when zero
+	DeviceID      string
+	IPAddress     string
+	UserAgent     string
+	Success       bool
+	FailureReason string
+}
+
+// DeviceService keeps the login audit and the devices users have signed in
+// from, which they can name and trust to skip two-factor authentication
+type DeviceService struct {
+	store db.UserStore
+	now   func() time.Time
+}
+
+// NewDeviceService creates a new device service
+func NewDeviceService(store db.UserStore) *DeviceService {
+	return &DeviceService{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// RecordLogin adds a login attempt to the audit. A successful login also
+// records the device, which is returned; failed attempts don't add devices.
+func (s *DeviceService) RecordLogin(ctx context.Context, attempt LoginAttempt) (*UserDeviceResponse, error) {
+	userID := attempt.UserID
+	if userID == 0 && attempt.FailureReason != LoginFailureUnknownUser {
+		user, err := s.store.GetUserByEmail(ctx, attempt.Email)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		userID = user.ID
+	}
+
+	fingerprint := deviceFingerprint(attempt.DeviceID, attempt.UserAgent)
+
+	var device *db.UserDevice
+	if attempt.Success && userID != 0 {
+		upserted, err := s.store.UpsertUserDevice(ctx, db.UpsertUserDeviceParams{
+			UserID:        userID,
+			Fingerprint:   fingerprint,
+			UserAgent:     attempt.UserAgent,
+			LastIpAddress: attempt.IPAddress,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record device: %w", err)
+		}
+		device = &upserted
+	}
+
+	arg := db.CreateLoginEventParams{
+		UserID:        sql.NullInt64{Int64: userID, Valid: userID != 0},
+		Email:         attempt.Email,
+		Fingerprint:   fingerprint,
+		IpAddress:     attempt.IPAddress,
+		UserAgent:     attempt.UserAgent,
+		Success:       attempt.Success,
+		FailureReason: attempt.FailureReason,
+	}
+	if device != nil {
+		arg.DeviceID = sql.NullInt64{Int64: device.ID, Valid: true}
+	}
+	if _, err := s.store.CreateLoginEvent(ctx, arg); err != nil {
+		return nil, fmt.Errorf("failed to record login: %w", err)
+	}
+
+	if device == nil {
+		return nil, nil
+	}
+	response := s.toUserDeviceResponse(*device)
+	return &response, nil
+}
+
+// ListDevices lists the devices a user has signed in from, most recently seen first
+func (s *DeviceService) ListDevices(ctx context.Context, userID int64) ([]UserDeviceResponse, error) {
+	devices, err := s.store.ListUserDevices(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	responses := make([]UserDeviceResponse, len(devices))
+	for i, device := range devices {
+		responses[i] = s.toUserDeviceResponse(device)
+	}
+	return responses, nil
+}
+
+// UpdateDevice names a device or changes whether it's trusted. Trusting a
+// device again extends its trust by TrustedDeviceDuration.
+func (s *DeviceService) UpdateDevice(ctx context.Context, userID, deviceID int64, req UpdateUserDeviceRequest) (UserDeviceResponse, error) {
+	device, err := s.store.GetUserDevice(ctx, db.GetUserDeviceParams{ID: deviceID, UserID: userID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return UserDeviceResponse{}, errors.New("device not found")
+		}
+		return UserDeviceResponse{}, fmt.Errorf("failed to get device: %w", err)
+	}
+
+	arg := db.UpdateUserDeviceParams{
+		ID:           device.ID,
+		UserID:       userID,
+		Name:         device.Name,
+		TrustedUntil: device.TrustedUntil,
+	}
+	if req.Name != nil {
+		arg.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Trusted != nil {
+		arg.TrustedUntil = sql.NullTime{}
+		if *req.Trusted {
+			arg.TrustedUntil = sql.NullTime{Time: s.now().Add(TrustedDeviceDuration), Valid: true}
+		}
+	}
+
+	device, err = s.store.UpdateUserDevice(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return UserDeviceResponse{}, errors.New("device not found")
+		}
+		return UserDeviceResponse{}, fmt.Errorf("failed to update device: %w", err)
+	}
+
+	return s.toUserDeviceResponse(device), nil
+}
+
+// RevokeDevice forgets a device, so it's no longer trusted and shows up as a
+// new device the next time it's used to sign in
+func (s *DeviceService) RevokeDevice(ctx context.Context, userID, deviceID int64) error {
+	rows, err := s.store.DeleteUserDevice(ctx, db.DeleteUserDeviceParams{ID: deviceID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to revoke device: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("device not found")
+	}
+	return nil
+}
+
+// ListLoginEvents lists a user's login attempts, most recent first
+func (s *DeviceService) ListLoginEvents(ctx context.Context, userID int64, limit, offset int32) ([]LoginEventResponse, error) {
+	if limit == 0 {
+		limit = 50
+	}
+
+	events, err := s.store.ListLoginEvents(ctx, db.ListLoginEventsParams{
+		UserID: sql.NullInt64{Int64: userID, Valid: true},
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login events: %w", err)
+	}
+
+	responses := make([]LoginEventResponse, len(events))
+	for i, event := range events {
+		responses[i] = LoginEventResponse{
+			ID:            event.ID,
+			DeviceName:    event.DeviceName,
+			IPAddress:     event.IpAddress,
+			UserAgent:     event.UserAgent,
+			Success:       event.Success,
+			FailureReason: event.FailureReason,
+			CreatedAt:     event.CreatedAt,
+		}
+		if event.DeviceID.Valid {
+			responses[i].DeviceID = &event.DeviceID.Int64
+		}
+	}
+	return responses, nil
+}
+
+func (s *DeviceService) toUserDeviceResponse(device db.UserDevice) UserDeviceResponse {
+	response := UserDeviceResponse{
+		ID:            device.ID,
+		Name:          device.Name,
+		UserAgent:     device.UserAgent,
+		LastIPAddress: device.LastIpAddress,
+		CreatedAt:     device.CreatedAt,
+		LastSeenAt:    device.LastSeenAt,
+	}
+	if device.TrustedUntil.Valid && device.TrustedUntil.Time.After(s.now()) {
+		response.Trusted = true
+		response.TrustedUntil = &device.TrustedUntil.Time
+	}
+	return response
+}
+
+// deviceFingerprint identifies a device by the ID its client keeps, or by its
+// user agent for clients that don't send one. Only the hash is stored.
+func deviceFingerprint(deviceID, userAgent string) string {
+	source := "ua:" + userAgent
+	if deviceID != "" {
+		source = "id:" + deviceID
+	}
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceService_Trust(t *testing.T) {
+	const userID, deviceID = int64(5), int64(8)
+	now := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	deviceService := NewDeviceService(store)
+	deviceService.now = func() time.Time { return now }
+
+	// Trust that ran out is reported as untrusted
+	device := db.UserDevice{
+		ID:           deviceID,
+		UserID:       userID,
+		Name:         "Phone",
+		TrustedUntil: sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+	}
+	store.EXPECT().ListUserDevices(gomock.Any(), userID).Times(1).Return([]db.UserDevice{device}, nil)
+
+	devices, err := deviceService.ListDevices(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	require.False(t, devices[0].Trusted)
+	require.Nil(t, devices[0].TrustedUntil)
+
+	// Trusting keeps the name and trusts the device for 30 days from now
+	trusted := device
+	trusted.TrustedUntil = sql.NullTime{Time: now.Add(TrustedDeviceDuration), Valid: true}
+	store.EXPECT().GetUserDevice(gomock.Any(), db.GetUserDeviceParams{ID: deviceID, UserID: userID}).Times(1).Return(device, nil)
+	store.EXPECT().
+		UpdateUserDevice(gomock.Any(), gomock.Eq(db.UpdateUserDeviceParams{
+			ID:           deviceID,
+			UserID:       userID,
+			Name:         "Phone",
+			TrustedUntil: trusted.TrustedUntil,
+		})).
+		Times(1).
+		Return(trusted, nil)
+
+	yes := true
+	updated, err := deviceService.UpdateDevice(ctx, userID, deviceID, UpdateUserDeviceRequest{Trusted: &yes})
+	require.NoError(t, err)
+	require.True(t, updated.Trusted)
+	require.Equal(t, now.Add(TrustedDeviceDuration), *updated.TrustedUntil)
+
+	// Renaming keeps the trust, untrusting clears it
+	name, no := "  Work phone ", false
+	store.EXPECT().GetUserDevice(gomock.Any(), gomock.Any()).Times(1).Return(trusted, nil)
+	store.EXPECT().
+		UpdateUserDevice(gomock.Any(), gomock.Eq(db.UpdateUserDeviceParams{
+			ID:     deviceID,
+			UserID: userID,
+			Name:   "Work phone",
+		})).
+		Times(1).
+		Return(db.UserDevice{ID: deviceID, UserID: userID, Name: "Work phone"}, nil)
+
+	updated, err = deviceService.UpdateDevice(ctx, userID, deviceID, UpdateUserDeviceRequest{Name: &name, Trusted: &no})
+	require.NoError(t, err)
+	require.False(t, updated.Trusted)
+	require.Equal(t, "Work phone", updated.Name)
+
+	store.EXPECT().GetUserDevice(gomock.Any(), gomock.Any()).Times(1).Return(db.UserDevice{}, sql.ErrNoRows)
+	_, err = deviceService.UpdateDevice(ctx, userID, deviceID+1, UpdateUserDeviceRequest{Trusted: &yes})
+	require.EqualError(t, err, "device not found")
+
+	store.EXPECT().DeleteUserDevice(gomock.Any(), db.DeleteUserDeviceParams{ID: deviceID, UserID: userID}).Times(1).Return(int64(0), nil)
+	err = deviceService.RevokeDevice(ctx, userID, deviceID)
+	require.EqualError(t, err, "device not found")
+}
+
+func TestDeviceFingerprint(t *testing.T) {
+	// The client's device ID wins over its user agent
+	require.Equal(t, deviceFingerprint("abc", "Firefox"), deviceFingerprint("abc", "Chrome"))
+	require.NotEqual(t, deviceFingerprint("", "Firefox"), deviceFingerprint("", "Chrome"))
+	require.NotEqual(t, deviceFingerprint("Firefox", ""), deviceFingerprint("", "Firefox"))
+}
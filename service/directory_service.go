@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// DirectoryEntryResponse is a person's profile card in the organization
+// directory
+type DirectoryEntryResponse struct {
+	User          UserResponse `json:"user"`
+	WorkspaceName string       `json:"workspace_name,omitempty"`
+	// online, away, busy or offline
+	Presence     string `json:"presence"`
+	CustomStatus string `json:"custom_status,omitempty"`
+	StatusEmoji  string `json:"status_emoji,omitempty"`
+}
+
+// DirectoryResponse is a page of the organization directory
+type DirectoryResponse struct {
+	People []DirectoryEntryResponse `json:"people"`
+	Total  int64                    `json:"total"`
+}
+
+// DirectoryService serves the people directory of an organization, across
+// all of its workspaces
+type DirectoryService struct {
+	store                   db.UserStore
+	organizationRoleService *OrganizationRoleService
+}
+
+// NewDirectoryService creates a new directory service
+func NewDirectoryService(store db.UserStore, organizationRoleService *OrganizationRoleService) *DirectoryService {
+	return &DirectoryService{
+		store:                   store,
+		organizationRoleService: organizationRoleService,
+	}
+}
+
+// SearchDirectory lists the people of an organization whose name, handle or
+// title matches the query. Emails and phone numbers are only shown, and
+// emails only searched, for organization admins; other members see them
+// only for people in their own workspace.
+func (s *DirectoryService) SearchDirectory(ctx context.Context, organizationID int64, viewer UserResponse, query string, limit, offset int32) (DirectoryResponse, error) {
+	if viewer.OrganizationID != organizationID {
+		return DirectoryResponse{}, errors.New("access denied: user is not a member of this organization")
+	}
+
+	isAdmin, err := s.organizationRoleService.IsOrganizationAdmin(ctx, organizationID, viewer.ID)
+	if err != nil {
+		return DirectoryResponse{}, err
+	}
+
+	rows, err := s.store.SearchOrganizationDirectory(ctx, db.SearchOrganizationDirectoryParams{
+		OrganizationID: organizationID,
+		Query:          strings.TrimPrefix(strings.TrimSpace(query), "@"),
+		SearchEmail:    isAdmin,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		return DirectoryResponse{}, fmt.Errorf("failed to search directory: %w", err)
+	}
+
+	response := DirectoryResponse{
+		People: make([]DirectoryEntryResponse, len(rows)),
+	}
+	for i, row := range rows {
+		entry := DirectoryEntryResponse{
+			User: UserResponse{
+				ID:             row.ID,
+				OrganizationID: row.OrganizationID,
+				Email:          row.Email,
+				FirstName:      row.FirstName,
+				LastName:       row.LastName,
+				Role:           row.Role,
+				Handle:         row.Handle,
+				Title:          row.Title,
+				Pronouns:       row.Pronouns,
+				Phone:          row.Phone,
+				Timezone:       row.Timezone,
+				AvatarURLs:     avatarURLs(row.AvatarKey),
+				Initials:       userInitials(row.FirstName, row.LastName),
+				EmailVerified:  row.EmailVerifiedAt.Valid,
+				CreatedAt:      row.CreatedAt,
+			},
+			WorkspaceName: row.WorkspaceName,
+			Presence:      row.Status,
+			CustomStatus:  row.CustomStatus.String,
+			StatusEmoji:   row.StatusEmoji.String,
+		}
+		if row.WorkspaceID.Valid {
+			workspaceID := row.WorkspaceID.Int64
+			entry.User.WorkspaceID = &workspaceID
+		}
+
+		sameWorkspace := row.WorkspaceID.Valid && viewer.WorkspaceID != nil && row.WorkspaceID.Int64 == *viewer.WorkspaceID
+		if !isAdmin && !sameWorkspace && row.ID != viewer.ID {
+			entry.User.Email = ""
+			entry.User.Phone = ""
+		}
+
+		response.People[i] = entry
+	}
+	if len(rows) > 0 {
+		response.Total = rows[0].TotalCount
+	}
+
+	return response, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryService_SearchDirectory(t *testing.T) {
+	workspaceID := int64(10)
+	viewer := UserResponse{ID: 1, OrganizationID: 5, WorkspaceID: &workspaceID}
+	rows := []db.SearchOrganizationDirectoryRow{
+		{
+			ID: 2, OrganizationID: 5, Email: "ada@example.com", Phone: "555-0100", FirstName: "Ada",
+			WorkspaceID: sql.NullInt64{Int64: 10, Valid: true}, WorkspaceName: "Engineering",
+			Status: "online", CustomStatus: sql.NullString{String: "Heads down", Valid: true}, TotalCount: 2,
+		},
+		{
+			ID: 3, OrganizationID: 5, Email: "grace@example.com", Phone: "555-0101", FirstName: "Grace",
+			WorkspaceID: sql.NullInt64{Int64: 20, Valid: true}, WorkspaceName: "Sales",
+			Status: "offline", TotalCount: 2,
+		},
+	}
+
+	t.Run("Member", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		directoryService := NewDirectoryService(store, NewOrganizationRoleService(store))
+
+		store.EXPECT().GetOrganizationRole(gomock.Any(), gomock.Any()).Times(1).Return("", sql.ErrNoRows)
+		store.EXPECT().
+			SearchOrganizationDirectory(gomock.Any(), gomock.Eq(db.SearchOrganizationDirectoryParams{
+				OrganizationID: 5,
+				Query:          "ada",
+				SearchEmail:    false,
+				Limit:          50,
+			})).
+			Times(1).
+			Return(rows, nil)
+
+		directory, err := directoryService.SearchDirectory(context.Background(), 5, viewer, " @ada", 50, 0)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), directory.Total)
+		require.Len(t, directory.People, 2)
+
+		// Contact details show for people in the viewer's workspace only
+		require.Equal(t, "ada@example.com", directory.People[0].User.Email)
+		require.Equal(t, "555-0100", directory.People[0].User.Phone)
+		require.Equal(t, "Engineering", directory.People[0].WorkspaceName)
+		require.Equal(t, "online", directory.People[0].Presence)
+		require.Equal(t, "Heads down", directory.People[0].CustomStatus)
+		require.Empty(t, directory.People[1].User.Email)
+		require.Empty(t, directory.People[1].User.Phone)
+		require.Equal(t, "Sales", directory.People[1].WorkspaceName)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		directoryService := NewDirectoryService(store, NewOrganizationRoleService(store))
+
+		store.EXPECT().GetOrganizationRole(gomock.Any(), gomock.Any()).Times(1).Return(OrganizationRoleAdmin, nil)
+		store.EXPECT().
+			SearchOrganizationDirectory(gomock.Any(), gomock.Eq(db.SearchOrganizationDirectoryParams{
+				OrganizationID: 5,
+				Query:          "grace@example.com",
+				SearchEmail:    true,
+				Limit:          50,
+			})).
+			Times(1).
+			Return(rows[1:], nil)
+
+		directory, err := directoryService.SearchDirectory(context.Background(), 5, viewer, "grace@example.com", 50, 0)
+		require.NoError(t, err)
+		require.Len(t, directory.People, 1)
+		require.Equal(t, "grace@example.com", directory.People[0].User.Email)
+		require.Equal(t, "555-0101", directory.People[0].User.Phone)
+	})
+
+	t.Run("OtherOrganization", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		directoryService := NewDirectoryService(store, NewOrganizationRoleService(store))
+
+		store.EXPECT().SearchOrganizationDirectory(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := directoryService.SearchDirectory(context.Background(), 6, viewer, "", 50, 0)
+		require.EqualError(t, err, "access denied: user is not a member of this organization")
+	})
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// doNotDisturbDays are the day names a Do Not Disturb schedule uses, indexed
+// by time.Weekday as the schedule is stored
+var doNotDisturbDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// DoNotDisturbService handles Do Not Disturb and the urgent override setting
+type DoNotDisturbService struct {
+	store         db.UserStore
+	statusService *StatusService
+	now           func() time.Time
+}
+
+// NewDoNotDisturbService creates a new Do Not Disturb service
+func NewDoNotDisturbService(store db.UserStore, statusService *StatusService) *DoNotDisturbService {
+	return &DoNotDisturbService{
+		store:         store,
+		statusService: statusService,
+		now:           time.Now,
+	}
+}
+
+// SetDoNotDisturb turns Do Not Disturb on until a time, or off when no time is
+// given. The urgent override setting and the schedule are kept unless the
+// request changes them.
+func (s *DoNotDisturbService) SetDoNotDisturb(ctx context.Context, userID, workspaceID int64, req SetDoNotDisturbRequest) (*DoNotDisturbResponse, error) {
+	if req.DNDUntil != nil && !req.DNDUntil.After(s.now()) {
+		return nil, errors.New("dnd_until must be in the future")
+	}
+
+	var start, end int32
+	var days []int32
+	if req.Schedule != nil {
+		var err error
+		start, end, days, err = parseDoNotDisturbSchedule(*req.Schedule)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := s.getDoNotDisturb(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.UpsertDoNotDisturbParams{
+		UserID:              userID,
+		WorkspaceID:         workspaceID,
+		AllowUrgentOverride: current.AllowUrgentOverride,
+		ScheduleStartMinute: current.ScheduleStartMinute,
+		ScheduleEndMinute:   current.ScheduleEndMinute,
+		ScheduleDays:        current.ScheduleDays,
+	}
+	if req.DNDUntil != nil {
+		arg.DndUntil = sql.NullTime{Time: *req.DNDUntil, Valid: true}
+	}
+	if req.AllowUrgentOverride != nil {
+		arg.AllowUrgentOverride = *req.AllowUrgentOverride
+	}
+	if req.ClearSchedule {
+		arg.ScheduleStartMinute = sql.NullInt32{}
+		arg.ScheduleEndMinute = sql.NullInt32{}
+		arg.ScheduleDays = []int32{}
+	}
+	if req.Schedule != nil {
+		arg.ScheduleStartMinute = sql.NullInt32{Int32: start, Valid: true}
+		arg.ScheduleEndMinute = sql.NullInt32{Int32: end, Valid: true}
+		arg.ScheduleDays = days
+	}
+	if arg.ScheduleDays == nil {
+		arg.ScheduleDays = []int32{}
+	}
+
+	dnd, err := s.store.UpsertDoNotDisturb(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set do not disturb: %w", err)
+	}
+
+	// Do Not Disturb shows in the user's presence
+	if s.statusService != nil {
+		s.statusService.PublishUserStatus(ctx, userID, workspaceID)
+	}
+
+	return toDoNotDisturbResponse(db.GetDoNotDisturbRow(dnd), s.now()), nil
+}
+
+// Snooze pauses a user's notifications for a while by turning Do Not Disturb
+// on until then. The schedule and urgent override setting are kept, and the
+// snooze clears itself when it expires.
+func (s *DoNotDisturbService) Snooze(ctx context.Context, userID, workspaceID int64, duration time.Duration) (*DoNotDisturbResponse, error) {
+	until := s.now().Add(duration)
+	return s.SetDoNotDisturb(ctx, userID, workspaceID, SetDoNotDisturbRequest{DNDUntil: &until})
+}
+
+// EndSnooze resumes a user's notifications before their snooze expires. A
+// Do Not Disturb schedule still applies.
+func (s *DoNotDisturbService) EndSnooze(ctx context.Context, userID, workspaceID int64) (*DoNotDisturbResponse, error) {
+	return s.SetDoNotDisturb(ctx, userID, workspaceID, SetDoNotDisturbRequest{})
+}
+
+// GetDoNotDisturb returns a user's Do Not Disturb state and urgent override setting
+func (s *DoNotDisturbService) GetDoNotDisturb(ctx context.Context, userID, workspaceID int64) (*DoNotDisturbResponse, error) {
+	dnd, err := s.getDoNotDisturb(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDoNotDisturbResponse(dnd, s.now()), nil
+}
+
+// GetRecipientNotice tells the sender of an urgent message that the recipient
+// is in Do Not Disturb and whether they can be notified anyway. It returns nil
+// when the recipient is not in Do Not Disturb.
+func (s *DoNotDisturbService) GetRecipientNotice(ctx context.Context, recipientID, workspaceID int64) (*RecipientDoNotDisturbNotice, error) {
+	dnd, until, active, err := activeDoNotDisturb(ctx, s.store, recipientID, workspaceID, s.now())
+	if err != nil || !active {
+		return nil, err
+	}
+
+	return &RecipientDoNotDisturbNotice{
+		DNDUntil:        until,
+		CanNotifyAnyway: dnd.AllowUrgentOverride,
+	}, nil
+}
+
+// getDoNotDisturb returns the stored settings, or the defaults for users who never set them
+func (s *DoNotDisturbService) getDoNotDisturb(ctx context.Context, userID, workspaceID int64) (db.GetDoNotDisturbRow, error) {
+	dnd, err := s.store.GetDoNotDisturb(ctx, db.GetDoNotDisturbParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.GetDoNotDisturbRow{
+				UserID:              userID,
+				WorkspaceID:         workspaceID,
+				AllowUrgentOverride: true,
+			}, nil
+		}
+		return db.GetDoNotDisturbRow{}, fmt.Errorf("failed to get do not disturb: %w", err)
+	}
+
+	return dnd, nil
+}
+
+// activeDoNotDisturb returns a user's Do Not Disturb settings and, when Do Not
+// Disturb is on at now, when it ends
+func activeDoNotDisturb(ctx context.Context, store db.UserStore, userID, workspaceID int64, now time.Time) (db.GetDoNotDisturbRow, time.Time, bool, error) {
+	dnd, err := store.GetDoNotDisturb(ctx, db.GetDoNotDisturbParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.GetDoNotDisturbRow{}, time.Time{}, false, nil
+		}
+		return db.GetDoNotDisturbRow{}, time.Time{}, false, fmt.Errorf("failed to get do not disturb: %w", err)
+	}
+
+	until, active := doNotDisturbUntil(dnd, now)
+	return dnd, until, active, nil
+}
+
+// doNotDisturbUntil reports whether Do Not Disturb is on at now and when it
+// ends. The schedule is read in the user's timezone. When Do Not Disturb was
+// turned on by hand during a scheduled window it ends with the later of the two.
+func doNotDisturbUntil(dnd db.GetDoNotDisturbRow, now time.Time) (time.Time, bool) {
+	var until time.Time
+	if dnd.DndUntil.Valid && dnd.DndUntil.Time.After(now) {
+		until = dnd.DndUntil.Time
+	}
+
+	if dnd.ScheduleStartMinute.Valid && dnd.ScheduleEndMinute.Valid {
+		location, err := LoadTimezone(dnd.Timezone)
+		if err != nil {
+			// Profiles only store valid time zones, but don't fail on old data
+			location = time.UTC
+		}
+
+		end, ok := scheduledDoNotDisturbEnd(int(dnd.ScheduleStartMinute.Int32), int(dnd.ScheduleEndMinute.Int32), dnd.ScheduleDays, location, now)
+		if ok && end.After(until) {
+			until = end
+		}
+	}
+
+	return until, !until.IsZero()
+}
+
+// scheduledDoNotDisturbEnd returns when the scheduled window now falls in
+// ends. Start and end are minutes after midnight in location; a window that
+// ends before it starts runs past midnight and counts as the day it started,
+// so "Friday 22:00 to 08:00" covers early Saturday but not early Friday.
+func scheduledDoNotDisturbEnd(start, end int, days []int32, location *time.Location, now time.Time) (time.Time, bool) {
+	local := now.In(location)
+
+	// The window now falls in started today or, past midnight, yesterday
+	for daysAgo := 0; daysAgo <= 1; daysAgo++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()-daysAgo, 0, 0, 0, 0, location)
+		if len(days) > 0 && !slices.Contains(days, int32(day.Weekday())) {
+			continue
+		}
+
+		endDay := day.Day()
+		if end <= start {
+			endDay++
+		}
+		from := time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, location)
+		to := time.Date(day.Year(), day.Month(), endDay, end/60, end%60, 0, 0, location)
+		if !now.Before(from) && now.Before(to) {
+			return to, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// parseDoNotDisturbSchedule turns a schedule into minutes after midnight and
+// weekday numbers as they are stored
+func parseDoNotDisturbSchedule(schedule DoNotDisturbSchedule) (int32, int32, []int32, error) {
+	start, err := parseClockMinute(schedule.Start)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid schedule start: %w", err)
+	}
+	end, err := parseClockMinute(schedule.End)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid schedule end: %w", err)
+	}
+	if start == end {
+		return 0, 0, nil, errors.New("invalid schedule: start and end must differ")
+	}
+
+	days := []int32{}
+	for _, name := range schedule.Days {
+		day := slices.Index(doNotDisturbDays, strings.ToLower(name))
+		if day < 0 {
+			return 0, 0, nil, fmt.Errorf("invalid schedule day %q", name)
+		}
+		if !slices.Contains(days, int32(day)) {
+			days = append(days, int32(day))
+		}
+	}
+	slices.Sort(days)
+
+	return start, end, days, nil
+}
+
+// parseClockMinute parses a time of day like "22:30" into minutes after midnight
+func parseClockMinute(value string) (int32, error) {
+	hours, minutes, ok := strings.Cut(value, ":")
+	if !ok || len(hours) != 2 || len(minutes) != 2 {
+		return 0, errors.New("time must be HH:MM")
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 23 {
+		return 0, errors.New("time must be HH:MM")
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 {
+		return 0, errors.New("time must be HH:MM")
+	}
+	return int32(h*60 + m), nil
+}
+
+func toDoNotDisturbResponse(dnd db.GetDoNotDisturbRow, now time.Time) *DoNotDisturbResponse {
+	response := &DoNotDisturbResponse{
+		UserID:              dnd.UserID,
+		WorkspaceID:         dnd.WorkspaceID,
+		AllowUrgentOverride: dnd.AllowUrgentOverride,
+		Timezone:            dnd.Timezone,
+	}
+
+	if dnd.DndUntil.Valid {
+		response.DNDUntil = &dnd.DndUntil.Time
+	}
+	if dnd.ScheduleStartMinute.Valid && dnd.ScheduleEndMinute.Valid {
+		start := int(dnd.ScheduleStartMinute.Int32)
+		end := int(dnd.ScheduleEndMinute.Int32)
+		schedule := &DoNotDisturbSchedule{
+			Start: fmt.Sprintf("%02d:%02d", start/60, start%60),
+			End:   fmt.Sprintf("%02d:%02d", end/60, end%60),
+		}
+		for _, day := range dnd.ScheduleDays {
+			schedule.Days = append(schedule.Days, doNotDisturbDays[day])
+		}
+		response.Schedule = schedule
+	}
+	if until, active := doNotDisturbUntil(dnd, now); active {
+		response.Active = true
+		response.ActiveUntil = &until
+	}
+
+	return response
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoNotDisturbUntil(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// 22:00 to 08:00 on Fridays, in Berlin
+	overnight := db.GetDoNotDisturbRow{
+		ScheduleStartMinute: sql.NullInt32{Int32: 22 * 60, Valid: true},
+		ScheduleEndMinute:   sql.NullInt32{Int32: 8 * 60, Valid: true},
+		ScheduleDays:        []int32{int32(time.Friday)},
+		Timezone:            "Europe/Berlin",
+	}
+
+	testCases := []struct {
+		name   string
+		dnd    db.GetDoNotDisturbRow
+		now    time.Time
+		active bool
+		until  time.Time
+	}{
+		{
+			name: "Off",
+			dnd:  db.GetDoNotDisturbRow{Timezone: "UTC"},
+			now:  time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "ManualUntil",
+			dnd: db.GetDoNotDisturbRow{
+				DndUntil: sql.NullTime{Time: time.Date(2026, 3, 13, 14, 0, 0, 0, time.UTC), Valid: true},
+			},
+			now:    time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC),
+			active: true,
+			until:  time.Date(2026, 3, 13, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "ManualExpired",
+			dnd: db.GetDoNotDisturbRow{
+				DndUntil: sql.NullTime{Time: time.Date(2026, 3, 13, 11, 0, 0, 0, time.UTC), Valid: true},
+			},
+			now: time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "BeforeScheduleStarts",
+			dnd:  overnight,
+			// Friday 21:59 in Berlin, 20:59 UTC
+			now: time.Date(2026, 3, 13, 21, 59, 0, 0, berlin),
+		},
+		{
+			name: "ScheduleStarted",
+			dnd:  overnight,
+			// Friday 22:00 in Berlin is still Friday 21:00 in UTC; the
+			// server's clock must not matter
+			now:    time.Date(2026, 3, 13, 21, 0, 0, 0, time.UTC),
+			active: true,
+			until:  time.Date(2026, 3, 14, 8, 0, 0, 0, berlin),
+		},
+		{
+			name:   "PastMidnight",
+			dnd:    overnight,
+			now:    time.Date(2026, 3, 14, 7, 59, 0, 0, berlin),
+			active: true,
+			until:  time.Date(2026, 3, 14, 8, 0, 0, 0, berlin),
+		},
+		{
+			name: "ScheduleEnded",
+			dnd:  overnight,
+			now:  time.Date(2026, 3, 14, 8, 0, 0, 0, berlin),
+		},
+		{
+			name: "PastMidnightOfOtherDay",
+			dnd:  overnight,
+			// Early Friday belongs to Thursday's window, which is not scheduled
+			now: time.Date(2026, 3, 13, 3, 0, 0, 0, berlin),
+		},
+		{
+			name: "EveryDay",
+			dnd: db.GetDoNotDisturbRow{
+				ScheduleStartMinute: sql.NullInt32{Int32: 12 * 60, Valid: true},
+				ScheduleEndMinute:   sql.NullInt32{Int32: 13 * 60, Valid: true},
+				Timezone:            "America/New_York",
+			},
+			now:    time.Date(2026, 3, 10, 16, 30, 0, 0, time.UTC),
+			active: true,
+			until:  time.Date(2026, 3, 10, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "ManualOutlastsSchedule",
+			dnd: db.GetDoNotDisturbRow{
+				DndUntil:            sql.NullTime{Time: time.Date(2026, 3, 14, 10, 0, 0, 0, berlin), Valid: true},
+				ScheduleStartMinute: overnight.ScheduleStartMinute,
+				ScheduleEndMinute:   overnight.ScheduleEndMinute,
+				ScheduleDays:        overnight.ScheduleDays,
+				Timezone:            overnight.Timezone,
+			},
+			now:    time.Date(2026, 3, 14, 1, 0, 0, 0, berlin),
+			active: true,
+			until:  time.Date(2026, 3, 14, 10, 0, 0, 0, berlin),
+		},
+		{
+			name: "InvalidTimezone",
+			dnd: db.GetDoNotDisturbRow{
+				ScheduleStartMinute: sql.NullInt32{Int32: 22 * 60, Valid: true},
+				ScheduleEndMinute:   sql.NullInt32{Int32: 6 * 60, Valid: true},
+				Timezone:            "Mars/Olympus_Mons",
+			},
+			now:    time.Date(2026, 3, 10, 23, 0, 0, 0, time.UTC),
+			active: true,
+			until:  time.Date(2026, 3, 11, 6, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			until, active := doNotDisturbUntil(tc.dnd, tc.now)
+			require.Equal(t, tc.active, active)
+			require.True(t, tc.until.Equal(until), "expected %v, got %v", tc.until, until)
+		})
+	}
+}
+
+func TestDoNotDisturbUntilAcrossDaylightSaving(t *testing.T) {
+	// Clocks in Berlin go forward at 02:00 on 29 March 2026, so the night of
+	// the 28th is an hour shorter
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	dnd := db.GetDoNotDisturbRow{
+		ScheduleStartMinute: sql.NullInt32{Int32: 22 * 60, Valid: true},
+		ScheduleEndMinute:   sql.NullInt32{Int32: 8 * 60, Valid: true},
+		Timezone:            "Europe/Berlin",
+	}
+
+	until, active := doNotDisturbUntil(dnd, time.Date(2026, 3, 28, 22, 0, 0, 0, berlin))
+	require.True(t, active)
+	require.Equal(t, time.Date(2026, 3, 29, 6, 0, 0, 0, time.UTC), until.UTC())
+}
+
+func TestParseDoNotDisturbSchedule(t *testing.T) {
+	start, end, days, err := parseDoNotDisturbSchedule(DoNotDisturbSchedule{Start: "22:30", End: "07:05", Days: []string{"Sat", "mon", "sat"}})
+	require.NoError(t, err)
+	require.Equal(t, int32(22*60+30), start)
+	require.Equal(t, int32(7*60+5), end)
+	require.Equal(t, []int32{1, 6}, days)
+
+	for _, schedule := range []DoNotDisturbSchedule{
+		{Start: "24:00", End: "08:00"},
+		{Start: "7:00", End: "08:00"},
+		{Start: "22:00", End: "22:00"},
+		{Start: "22:00", End: "08:00", Days: []string{"someday"}},
+	} {
+		_, _, _, err := parseDoNotDisturbSchedule(schedule)
+		require.Error(t, err, schedule)
+	}
+}
+
+func TestDoNotDisturbService_GetRecipientNotice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	dndService := NewDoNotDisturbService(store, nil)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	// 23:30 in Tokyo, 14:30 UTC
+	dndService.now = func() time.Time { return time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC) }
+
+	store.EXPECT().
+		GetDoNotDisturb(gomock.Any(), gomock.Eq(db.GetDoNotDisturbParams{UserID: 2, WorkspaceID: 1})).
+		Times(1).
+		Return(db.GetDoNotDisturbRow{
+			UserID:              2,
+			WorkspaceID:         1,
+			AllowUrgentOverride: true,
+			ScheduleStartMinute: sql.NullInt32{Int32: 23 * 60, Valid: true},
+			ScheduleEndMinute:   sql.NullInt32{Int32: 7 * 60, Valid: true},
+			Timezone:            "Asia/Tokyo",
+		}, nil)
+
+	notice, err := dndService.GetRecipientNotice(context.Background(), 2, 1)
+	require.NoError(t, err)
+	require.NotNil(t, notice)
+	require.True(t, notice.CanNotifyAnyway)
+	require.True(t, time.Date(2026, 3, 11, 7, 0, 0, 0, tokyo).Equal(notice.DNDUntil))
+
+	store.EXPECT().
+		GetDoNotDisturb(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return(db.GetDoNotDisturbRow{}, sql.ErrNoRows)
+
+	notice, err = dndService.GetRecipientNotice(context.Background(), 3, 1)
+	require.NoError(t, err)
+	require.Nil(t, notice)
+}
+
+func TestDoNotDisturbService_Snooze(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	dndService := NewDoNotDisturbService(store, nil)
+
+	now := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+	dndService.now = func() time.Time { return now }
+
+	current := db.GetDoNotDisturbRow{
+		UserID:              2,
+		WorkspaceID:         1,
+		AllowUrgentOverride: false,
+		ScheduleStartMinute: sql.NullInt32{Int32: 22 * 60, Valid: true},
+		ScheduleEndMinute:   sql.NullInt32{Int32: 8 * 60, Valid: true},
+		ScheduleDays:        []int32{},
+		Timezone:            "UTC",
+	}
+	store.EXPECT().GetDoNotDisturb(gomock.Any(), gomock.Any()).Times(1).Return(current, nil)
+
+	// The snooze keeps the schedule and the urgent override setting
+	arg := db.UpsertDoNotDisturbParams{
+		UserID:              2,
+		WorkspaceID:         1,
+		DndUntil:            sql.NullTime{Time: now.Add(2 * time.Hour), Valid: true},
+		AllowUrgentOverride: false,
+		ScheduleStartMinute: current.ScheduleStartMinute,
+		ScheduleEndMinute:   current.ScheduleEndMinute,
+		ScheduleDays:        current.ScheduleDays,
+	}
+	upserted := db.UpsertDoNotDisturbRow(current)
+	upserted.DndUntil = arg.DndUntil
+	store.EXPECT().UpsertDoNotDisturb(gomock.Any(), gomock.Eq(arg)).Times(1).Return(upserted, nil)
+
+	dnd, err := dndService.Snooze(context.Background(), 2, 1, 2*time.Hour)
+	require.NoError(t, err)
+	require.True(t, dnd.Active)
+	require.Equal(t, now.Add(2*time.Hour), *dnd.ActiveUntil)
+	require.NotNil(t, dnd.Schedule)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/heyrmi/goslack/util"
+)
+
+// DocumentConverter renders previews of PDFs and office documents
+type DocumentConverter interface {
+	// RenderFirstPage writes a PNG image of the document's first page to outputPath
+	RenderFirstPage(ctx context.Context, inputPath, outputPath string) error
+}
+
+// NewDocumentConverter creates the converter set in the configuration.
+// It returns nil when document previews are disabled.
+func NewDocumentConverter(config util.Config) (DocumentConverter, error) {
+	if !config.EnableDocumentPreviews {
+		return nil, nil
+	}
+
+	path, err := exec.LookPath(config.LibreOfficePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document preview configuration: libreoffice not found at '%s'", config.LibreOfficePath)
+	}
+
+	return &libreOfficeConverter{path: path}, nil
+}
+
+// libreOfficeConverter runs LibreOffice headless, which exports the first
+// page of a document when converting it to an image
+type libreOfficeConverter struct {
+	path string
+}
+
+func (c *libreOfficeConverter) RenderFirstPage(ctx context.Context, inputPath, outputPath string) error {
+	// Work next to the output so the image can be renamed into place
+	workDir, err := os.MkdirTemp(filepath.Dir(outputPath), ".preview-")
+	if err != nil {
+		return fmt.Errorf("failed to create preview directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	// Every run gets its own profile, LibreOffice won't start while another
+	// instance is using one
+	profile := url.URL{Scheme: "file", Path: filepath.Join(workDir, "profile")}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.path,
+		"--headless", "--norestore", "--nolockcheck",
+		"-env:UserInstallation="+profile.String(),
+		"--convert-to", "png",
+		"--outdir", workDir,
+		inputPath,
+	)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return errors.New("libreoffice timed out")
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("libreoffice failed: %s", message)
+		}
+		return fmt.Errorf("libreoffice failed: %w", err)
+	}
+
+	// LibreOffice names the image after the document and exits successfully
+	// even when it couldn't read it
+	rendered := filepath.Join(workDir, strings.TrimSuffix(filepath.Base(inputPath), filepath.Ext(inputPath))+".png")
+	if _, err := os.Stat(rendered); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("libreoffice failed: %s", message)
+		}
+		return errors.New("libreoffice could not render the document")
+	}
+
+	return os.Rename(rendered, outputPath)
+}
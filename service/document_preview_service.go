@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// WSFilePreviewed is sent to the file's workspace when a document's preview
+// has been rendered, or couldn't be
+const WSFilePreviewed = "file_previewed"
+
+// documentsPerRun caps how many queued documents one run of the worker renders
+const documentsPerRun = 10
+
+// documentPreviewSize is the longest side of document previews in pixels.
+// They're larger than image thumbnails so the first lines can be read on a
+// document card.
+const documentPreviewSize = 512
+
+// documentMimeTypes are the document types previews are rendered for
+var documentMimeTypes = map[string]bool{
+	"application/pdf": true,
+	"application/rtf": true,
+
+	"application/msword":            true,
+	"application/vnd.ms-excel":      true,
+	"application/vnd.ms-powerpoint": true,
+
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         true,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": true,
+
+	"application/vnd.oasis.opendocument.text":         true,
+	"application/vnd.oasis.opendocument.spreadsheet":  true,
+	"application/vnd.oasis.opendocument.presentation": true,
+}
+
+// FilePreviewedEvent is the payload of a file_previewed WebSocket message
+type FilePreviewedEvent struct {
+	FileID        int64  `json:"file_id"`
+	PreviewStatus string `json:"preview_status"`
+	ThumbnailURL  string `json:"thumbnail_url,omitempty"`
+}
+
+// DocumentPreviewService renders previews of uploaded documents in the
+// background and stores them as the documents' thumbnails
+type DocumentPreviewService struct {
+	store     db.FileStore
+	hub       WebSocketHub
+	converter DocumentConverter
+	timeout   time.Duration
+	queued    chan struct{}
+}
+
+// NewDocumentPreviewService creates a new document preview service. Without
+// a converter documents get no preview.
+func NewDocumentPreviewService(store db.FileStore, hub WebSocketHub, converter DocumentConverter, config util.Config) *DocumentPreviewService {
+	timeout := config.DocumentPreviewTimeout
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+
+	return &DocumentPreviewService{
+		store:     store,
+		hub:       hub,
+		converter: converter,
+		timeout:   timeout,
+		queued:    make(chan struct{}, 1),
+	}
+}
+
+// Enabled reports whether uploaded documents get previews
+func (s *DocumentPreviewService) Enabled() bool {
+	return s != nil && s.converter != nil
+}
+
+// isDocumentFile checks if a file is a PDF or office document based on MIME type
+func isDocumentFile(mimeType string) bool {
+	return documentMimeTypes[mimeType]
+}
+
+// Enqueue queues an uploaded document for its preview
+func (s *DocumentPreviewService) Enqueue(ctx context.Context, fileID int64) error {
+	if err := s.setStatus(ctx, fileID, ProcessingStatusPending); err != nil {
+		return fmt.Errorf("failed to queue document preview: %w", err)
+	}
+
+	select {
+	case s.queued <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// RenderPending renders previews for the oldest queued documents
+func (s *DocumentPreviewService) RenderPending(ctx context.Context) error {
+	files, err := s.store.ListPendingFilePreviews(ctx, documentsPerRun)
+	if err != nil {
+		return fmt.Errorf("failed to list queued documents: %w", err)
+	}
+
+	for _, file := range files {
+		if err := s.renderPreview(ctx, file); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Printf("Error rendering preview of file %d: %v\n", file.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// StartPreviewWorker renders previews of documents as they are uploaded,
+// checking for any left over at each interval
+func (s *DocumentPreviewService) StartPreviewWorker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	if err := s.store.RequeueInterruptedFilePreviews(ctx); err != nil {
+		fmt.Printf("Error requeueing interrupted document previews: %v\n", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RenderPending(ctx); err != nil {
+			fmt.Printf("Error rendering document previews: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.queued:
+		}
+	}
+}
+
+// renderPreview renders a document's first page, scales it down to the
+// preview size and saves it as the document's thumbnail
+func (s *DocumentPreviewService) renderPreview(ctx context.Context, file db.File) error {
+	if err := s.setStatus(ctx, file.ID, ProcessingStatusProcessing); err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(file.FilePath, filepath.Ext(file.FilePath))
+	pagePath := base + "_page.png"
+	thumbnailPath := base + "_thumb.png"
+	defer removeStoredFile(pagePath)
+
+	renderCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	err := s.converter.RenderFirstPage(renderCtx, file.FilePath, pagePath)
+	if err == nil {
+		err = scalePreview(pagePath, thumbnailPath)
+	}
+	if err != nil {
+		removeStoredFile(thumbnailPath)
+		if ctx.Err() != nil {
+			// Shutting down, the document is requeued on the next start
+			return ctx.Err()
+		}
+		if statusErr := s.setStatus(ctx, file.ID, ProcessingStatusFailed); statusErr != nil {
+			return statusErr
+		}
+		s.notify(file.WorkspaceID, FilePreviewedEvent{FileID: file.ID, PreviewStatus: ProcessingStatusFailed})
+		sendProcessingFinished(s.hub, file, "document preview could not be rendered")
+		return err
+	}
+
+	if err := s.store.UpdateFilePreview(ctx, db.UpdateFilePreviewParams{
+		ID:            file.ID,
+		PreviewStatus: sql.NullString{String: ProcessingStatusCompleted, Valid: true},
+		ThumbnailPath: sql.NullString{String: thumbnailPath, Valid: true},
+	}); err != nil {
+		removeStoredFile(thumbnailPath)
+		return fmt.Errorf("failed to save document preview: %w", err)
+	}
+
+	s.notify(file.WorkspaceID, FilePreviewedEvent{
+		FileID:        file.ID,
+		PreviewStatus: ProcessingStatusCompleted,
+		ThumbnailURL:  thumbnailURL(file.ID),
+	})
+	sendProcessingFinished(s.hub, file, "")
+
+	return nil
+}
+
+func (s *DocumentPreviewService) setStatus(ctx context.Context, fileID int64, status string) error {
+	err := s.store.UpdateFilePreview(ctx, db.UpdateFilePreviewParams{
+		ID:            fileID,
+		PreviewStatus: sql.NullString{String: status, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update document preview status: %w", err)
+	}
+	return nil
+}
+
+func (s *DocumentPreviewService) notify(workspaceID int64, event FilePreviewedEvent) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.BroadcastToWorkspace(workspaceID, &WSMessage{
+		Type:        WSFilePreviewed,
+		Data:        event,
+		WorkspaceID: workspaceID,
+		Timestamp:   time.Now(),
+	})
+}
+
+// scalePreview writes a rendered page, scaled down to the preview size, as a PNG
+func scalePreview(pagePath, previewPath string) error {
+	page, err := os.Open(pagePath)
+	if err != nil {
+		return err
+	}
+	defer page.Close()
+
+	img, _, err := decodeImage(page)
+	if err != nil {
+		return fmt.Errorf("failed to read rendered page: %w", err)
+	}
+	return writeImage(previewPath, fitWithin(img, documentPreviewSize), "png")
+}
+
+func thumbnailURL(fileID int64) string {
+	return fmt.Sprintf("/api/files/%d/thumbnail", fileID)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConverter renders a blank A4-shaped page, or fails with err
+type fakeConverter struct {
+	err error
+}
+
+func (c *fakeConverter) RenderFirstPage(ctx context.Context, inputPath, outputPath string) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	page := image.NewRGBA(image.Rect(0, 0, 794, 1123))
+	for i := range page.Pix {
+		page.Pix[i] = 0xff
+	}
+	page.Set(10, 10, color.Black)
+
+	out, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return png.Encode(out, page)
+}
+
+func TestDocumentPreviewService_RenderPending(t *testing.T) {
+	dir := t.TempDir()
+	file := db.File{
+		ID:          9,
+		WorkspaceID: 3,
+		FilePath:    filepath.Join(dir, "report.pdf"),
+		MimeType:    "application/pdf",
+	}
+	previewPath := filepath.Join(dir, "report_thumb.png")
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockFileStore(ctrl)
+	store.EXPECT().ListPendingFilePreviews(gomock.Any(), int32(documentsPerRun)).Times(1).Return([]db.File{file}, nil)
+	gomock.InOrder(
+		store.EXPECT().
+			UpdateFilePreview(gomock.Any(), db.UpdateFilePreviewParams{
+				ID:            file.ID,
+				PreviewStatus: processingStatus(ProcessingStatusProcessing),
+			}).
+			Times(1).
+			Return(nil),
+		store.EXPECT().
+			UpdateFilePreview(gomock.Any(), db.UpdateFilePreviewParams{
+				ID:            file.ID,
+				PreviewStatus: processingStatus(ProcessingStatusCompleted),
+				ThumbnailPath: sql.NullString{String: previewPath, Valid: true},
+			}).
+			Times(1).
+			Return(nil),
+	)
+
+	hub := &recordingHub{}
+	previewService := NewDocumentPreviewService(store, hub, &fakeConverter{}, util.Config{})
+	require.NoError(t, previewService.RenderPending(context.Background()))
+
+	// The page is scaled down to the preview size and only the preview is kept
+	preview, err := os.Open(previewPath)
+	require.NoError(t, err)
+	defer preview.Close()
+	config, err := png.DecodeConfig(preview)
+	require.NoError(t, err)
+	require.Equal(t, documentPreviewSize, config.Height)
+	require.Equal(t, 794*documentPreviewSize/1123, config.Width)
+	require.NoFileExists(t, filepath.Join(dir, "report_page.png"))
+
+	require.Len(t, hub.workspaceMessages[file.WorkspaceID], 1)
+	message := hub.workspaceMessages[file.WorkspaceID][0]
+	require.Equal(t, WSFilePreviewed, message.Type)
+	require.Equal(t, FilePreviewedEvent{
+		FileID:        file.ID,
+		PreviewStatus: ProcessingStatusCompleted,
+		ThumbnailURL:  "/api/files/9/thumbnail",
+	}, message.Data)
+}
+
+func TestDocumentPreviewService_RenderPendingFailure(t *testing.T) {
+	file := db.File{ID: 9, WorkspaceID: 3, FilePath: filepath.Join(t.TempDir(), "budget.xlsx")}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockFileStore(ctrl)
+	store.EXPECT().ListPendingFilePreviews(gomock.Any(), gomock.Any()).Times(1).Return([]db.File{file}, nil)
+	gomock.InOrder(
+		store.EXPECT().
+			UpdateFilePreview(gomock.Any(), db.UpdateFilePreviewParams{
+				ID:            file.ID,
+				PreviewStatus: processingStatus(ProcessingStatusProcessing),
+			}).
+			Times(1).
+			Return(nil),
+		store.EXPECT().
+			UpdateFilePreview(gomock.Any(), db.UpdateFilePreviewParams{
+				ID:            file.ID,
+				PreviewStatus: processingStatus(ProcessingStatusFailed),
+			}).
+			Times(1).
+			Return(nil),
+	)
+
+	hub := &recordingHub{}
+	converter := &fakeConverter{err: errors.New("libreoffice could not render the document")}
+	previewService := NewDocumentPreviewService(store, hub, converter, util.Config{})
+
+	// A document that can't be rendered is marked failed without stopping the run
+	require.NoError(t, previewService.RenderPending(context.Background()))
+	require.Equal(t, FilePreviewedEvent{FileID: file.ID, PreviewStatus: ProcessingStatusFailed}, hub.workspaceMessages[file.WorkspaceID][0].Data)
+}
+
+func TestIsDocumentFile(t *testing.T) {
+	require.True(t, isDocumentFile("application/pdf"))
+	require.True(t, isDocumentFile("application/vnd.openxmlformats-officedocument.wordprocessingml.document"))
+	require.False(t, isDocumentFile("image/png"))
+	require.False(t, isDocumentFile("text/plain"))
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Draft events sent to the owner's other WebSocket connections
+const (
+	WSDraftUpdated = "draft_updated"
+	WSDraftDeleted = "draft_deleted"
+)
+
+// draftServiceStore covers drafts and the channels and messages they are written in
+type draftServiceStore interface {
+	db.ChannelStore
+	db.MessageStore
+}
+
+// DraftService handles the unsent messages users are writing, keeping them in
+// sync between a user's devices
+type DraftService struct {
+	store       draftServiceStore
+	userService *UserService
+	hub         WebSocketHub
+}
+
+// NewDraftService creates a new draft service
+func NewDraftService(store draftServiceStore, userService *UserService, hub WebSocketHub) *DraftService {
+	return &DraftService{
+		store:       store,
+		userService: userService,
+		hub:         hub,
+	}
+}
+
+// SaveDraft saves a user's draft for a channel or direct conversation and
+// sends it to the user's other connections. Saving empty content deletes the
+// draft, in which case nil is returned.
+func (s *DraftService) SaveDraft(ctx context.Context, userID, workspaceID int64, req SaveDraftRequest) (*DraftResponse, error) {
+	if (req.ChannelID == nil) == (req.ReceiverID == nil) {
+		return nil, errors.New("invalid draft: exactly one of channel_id and receiver_id is required")
+	}
+	if err := s.checkDraftTarget(ctx, userID, workspaceID, req); err != nil {
+		return nil, err
+	}
+
+	channelID, receiverID, threadID := toNullInt64(req.ChannelID), toNullInt64(req.ReceiverID), toNullInt64(req.ThreadID)
+
+	if strings.TrimSpace(req.Content) == "" {
+		draft, err := s.store.DeleteMessageDraftForTarget(ctx, db.DeleteMessageDraftForTargetParams{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			ChannelID:   channelID,
+			ReceiverID:  receiverID,
+			ThreadID:    threadID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to delete draft: %w", err)
+		}
+
+		s.broadcast(WSDraftDeleted, draft, req.ConnectionID)
+		return nil, nil
+	}
+
+	draft, err := s.store.UpsertMessageDraft(ctx, db.UpsertMessageDraftParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		ChannelID:   channelID,
+		ReceiverID:  receiverID,
+		ThreadID:    threadID,
+		Content:     req.Content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save draft: %w", err)
+	}
+
+	s.broadcast(WSDraftUpdated, draft, req.ConnectionID)
+	return toDraftResponse(draft), nil
+}
+
+// ListDrafts lists a user's drafts in a workspace, most recently changed first
+func (s *DraftService) ListDrafts(ctx context.Context, userID, workspaceID int64) ([]DraftResponse, error) {
+	drafts, err := s.store.ListMessageDrafts(ctx, db.ListMessageDraftsParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+
+	responses := make([]DraftResponse, len(drafts))
+	for i, draft := range drafts {
+		responses[i] = *toDraftResponse(draft)
+	}
+	return responses, nil
+}
+
+// DeleteDraft deletes one of a user's drafts and tells the user's other
+// connections, except the one identified by connectionID
+func (s *DraftService) DeleteDraft(ctx context.Context, draftID, userID int64, connectionID string) error {
+	draft, err := s.store.DeleteMessageDraft(ctx, db.DeleteMessageDraftParams{
+		ID:     draftID,
+		UserID: userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("draft not found")
+		}
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+
+	s.broadcast(WSDraftDeleted, draft, connectionID)
+	return nil
+}
+
+// checkDraftTarget checks that the user may write in the draft's channel or to
+// its receiver, and that its thread is in the same workspace
+func (s *DraftService) checkDraftTarget(ctx context.Context, userID, workspaceID int64, req SaveDraftRequest) error {
+	if req.ChannelID != nil {
+		channel, err := s.store.GetChannelByID(ctx, *req.ChannelID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("channel not found")
+			}
+			return fmt.Errorf("failed to get channel: %w", err)
+		}
+		if channel.WorkspaceID != workspaceID {
+			return errors.New("channel not found")
+		}
+		if channel.IsPrivate {
+			isMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+				ChannelID: channel.ID,
+				UserID:    userID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to check channel membership: %w", err)
+			}
+			if !isMember {
+				return errors.New("access denied: user is not a member of this channel")
+			}
+		}
+	} else {
+		isMember, err := s.userService.IsWorkspaceMember(ctx, *req.ReceiverID, workspaceID)
+		if err != nil {
+			return fmt.Errorf("failed to check receiver workspace membership: %w", err)
+		}
+		if !isMember {
+			return errors.New("receiver is not a member of the workspace")
+		}
+	}
+
+	if req.ThreadID != nil {
+		thread, err := s.store.GetMessageByID(ctx, *req.ThreadID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("thread not found")
+			}
+			return fmt.Errorf("failed to get thread: %w", err)
+		}
+		if thread.WorkspaceID != workspaceID {
+			return errors.New("thread not found")
+		}
+	}
+
+	return nil
+}
+
+// broadcast sends a draft event to the draft owner's connections, except the
+// one that made the change
+func (s *DraftService) broadcast(eventType string, draft db.MessageDraft, connectionID string) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.BroadcastToUser(draft.UserID, &WSMessage{
+		Type:                eventType,
+		Data:                toDraftResponse(draft),
+		WorkspaceID:         draft.WorkspaceID,
+		UserID:              draft.UserID,
+		Timestamp:           time.Now(),
+		ExcludeConnectionID: connectionID,
+	})
+}
+
+func toDraftResponse(draft db.MessageDraft) *DraftResponse {
+	response := &DraftResponse{
+		ID:          draft.ID,
+		WorkspaceID: draft.WorkspaceID,
+		Content:     draft.Content,
+		UpdatedAt:   draft.UpdatedAt,
+	}
+	if draft.ChannelID.Valid {
+		response.ChannelID = &draft.ChannelID.Int64
+	}
+	if draft.ReceiverID.Valid {
+		response.ReceiverID = &draft.ReceiverID.Int64
+	}
+	if draft.ThreadID.Valid {
+		response.ThreadID = &draft.ThreadID.Int64
+	}
+	return response
+}
+
+func toNullInt64(id *int64) sql.NullInt64 {
+	if id == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *id, Valid: true}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftService_SaveDraft(t *testing.T) {
+	const workspaceID, userID, channelID = int64(2), int64(5), int64(7)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	draftService := NewDraftService(store, NewUserService(store, nil, util.Config{}), hub)
+
+	store.EXPECT().
+		GetChannelByID(gomock.Any(), channelID).
+		AnyTimes().
+		Return(db.Channel{ID: channelID, WorkspaceID: workspaceID}, nil)
+
+	draft := db.MessageDraft{
+		ID:          11,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		ChannelID:   sql.NullInt64{Int64: channelID, Valid: true},
+		Content:     "Half a thought",
+		UpdatedAt:   time.Now(),
+	}
+	store.EXPECT().
+		UpsertMessageDraft(gomock.Any(), db.UpsertMessageDraftParams{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			ChannelID:   draft.ChannelID,
+			Content:     draft.Content,
+		}).
+		Times(1).
+		Return(draft, nil)
+
+	id := channelID
+	response, err := draftService.SaveDraft(ctx, userID, workspaceID, SaveDraftRequest{ChannelID: &id, Content: draft.Content, ConnectionID: "laptop"})
+	require.NoError(t, err)
+	require.Equal(t, draft.ID, response.ID)
+
+	// The user's other connections are sent the draft
+	require.Len(t, hub.userMessages[userID], 1)
+	require.Equal(t, WSDraftUpdated, hub.userMessages[userID][0].Type)
+	require.Equal(t, "laptop", hub.userMessages[userID][0].ExcludeConnectionID)
+
+	// Clearing the content deletes the draft
+	store.EXPECT().DeleteMessageDraftForTarget(gomock.Any(), gomock.Any()).Times(1).Return(draft, nil)
+
+	response, err = draftService.SaveDraft(ctx, userID, workspaceID, SaveDraftRequest{ChannelID: &id, Content: "  "})
+	require.NoError(t, err)
+	require.Nil(t, response)
+	require.Len(t, hub.userMessages[userID], 2)
+	require.Equal(t, WSDraftDeleted, hub.userMessages[userID][1].Type)
+
+	// A draft is for either a channel or a user
+	receiverID := int64(8)
+	_, err = draftService.SaveDraft(ctx, userID, workspaceID, SaveDraftRequest{ChannelID: &id, ReceiverID: &receiverID, Content: "Hi"})
+	require.EqualError(t, err, "invalid draft: exactly one of channel_id and receiver_id is required")
+
+	// Deleting someone else's draft finds nothing
+	store.EXPECT().
+		DeleteMessageDraft(gomock.Any(), db.DeleteMessageDraftParams{ID: draft.ID, UserID: userID + 1}).
+		Times(1).
+		Return(db.MessageDraft{}, sql.ErrNoRows)
+
+	err = draftService.DeleteDraft(ctx, draft.ID, userID+1, "")
+	require.EqualError(t, err, "draft not found")
+}
@@ -0,0 +1,344 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+)
+
+// Email providers selected with EMAIL_PROVIDER
+const (
+	EmailProviderLog      = "log"
+	EmailProviderSMTP     = "smtp"
+	EmailProviderSendGrid = "sendgrid"
+	EmailProviderSES      = "ses"
+)
+
+// providerTimeout bounds a whole conversation with the provider so a slow
+// mail server can't hold up the worker sending the email
+const providerTimeout = 30 * time.Second
+
+// OutgoingEmail is a rendered email handed to a provider. Raw is the complete
+// MIME message, for providers that send messages as they are.
+type OutgoingEmail struct {
+	From      *mail.Address
+	To        *mail.Address
+	MessageID string
+	Message   EmailMessage
+	Raw       []byte
+}
+
+// EmailProvider delivers emails through a mail server or email API
+type EmailProvider interface {
+	// Name identifies the provider in delivery records
+	Name() string
+	// Deliver sends an email and returns the ID the provider gave it. Errors
+	// wrapped with permanentEmailError aren't worth retrying.
+	Deliver(ctx context.Context, email OutgoingEmail) (string, error)
+}
+
+// permanentEmailError marks a delivery failure that retrying won't fix, such
+// as a rejected API key or recipient
+type permanentEmailError struct {
+	err error
+}
+
+func (e *permanentEmailError) Error() string { return e.err.Error() }
+func (e *permanentEmailError) Unwrap() error { return e.err }
+
+// isPermanentEmailError reports whether err is a permanent delivery failure
+func isPermanentEmailError(err error) bool {
+	var permanent *permanentEmailError
+	return errors.As(err, &permanent)
+}
+
+// newEmailProvider creates the provider chosen in the configuration. Without a
+// choice, SMTP is used when SMTP_HOST is set and emails are logged otherwise.
+func newEmailProvider(config util.Config) (EmailProvider, error) {
+	name := config.EmailProvider
+	if name == "" {
+		name = EmailProviderLog
+		if config.SMTPHost != "" {
+			name = EmailProviderSMTP
+		}
+	}
+
+	switch name {
+	case EmailProviderLog:
+		return logEmailProvider{}, nil
+	case EmailProviderSMTP:
+		if config.SMTPHost == "" {
+			return nil, errors.New("SMTP_HOST is required for the smtp email provider")
+		}
+		port := config.SMTPPort
+		if port == 0 {
+			port = 587
+		}
+		return &smtpEmailProvider{
+			host:     config.SMTPHost,
+			port:     port,
+			username: config.SMTPUsername,
+			password: config.SMTPPassword,
+		}, nil
+	case EmailProviderSendGrid:
+		if config.SendGridAPIKey == "" {
+			return nil, errors.New("SENDGRID_API_KEY is required for the sendgrid email provider")
+		}
+		return &sendGridEmailProvider{
+			apiKey:  config.SendGridAPIKey,
+			baseURL: "https://api.sendgrid.com",
+			client:  &http.Client{Timeout: providerTimeout},
+		}, nil
+	case EmailProviderSES:
+		if config.SESRegion == "" || config.SESAccessKeyID == "" || config.SESSecretAccessKey == "" {
+			return nil, errors.New("SES_REGION, SES_ACCESS_KEY_ID and SES_SECRET_ACCESS_KEY are required for the ses email provider")
+		}
+		return &sesEmailProvider{
+			region:          config.SESRegion,
+			accessKeyID:     config.SESAccessKeyID,
+			secretAccessKey: config.SESSecretAccessKey,
+			endpoint:        fmt.Sprintf("https://email.%s.amazonaws.com", config.SESRegion),
+			client:          &http.Client{Timeout: providerTimeout},
+			now:             time.Now,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown email provider %q", name)
+	}
+}
+
+// logEmailProvider writes emails to the log instead of sending them, which is
+// enough for local development
+type logEmailProvider struct{}
+
+func (logEmailProvider) Name() string { return EmailProviderLog }
+
+func (logEmailProvider) Deliver(ctx context.Context, email OutgoingEmail) (string, error) {
+	fmt.Printf("Email from %s to %s (no email provider is configured):\n%s\n", email.From.Address, email.To.Address, email.Raw)
+	return email.MessageID, nil
+}
+
+// smtpEmailProvider delivers emails to an SMTP server, upgrading to TLS when
+// the server supports STARTTLS
+type smtpEmailProvider struct {
+	host     string
+	port     int
+	username string
+	password string
+}
+
+func (p *smtpEmailProvider) Name() string { return EmailProviderSMTP }
+
+func (p *smtpEmailProvider) Deliver(ctx context.Context, email OutgoingEmail) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, providerTimeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(p.host, strconv.Itoa(p.port)))
+	if err != nil {
+		return "", err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return "", err
+	}
+
+	client, err := smtp.NewClient(conn, p.host)
+	if err != nil {
+		conn.Close()
+		return "", err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: p.host}); err != nil {
+			return "", err
+		}
+	}
+	if p.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", p.username, p.password, p.host)); err != nil {
+			return "", &permanentEmailError{err}
+		}
+	}
+
+	if err := client.Mail(email.From.Address); err != nil {
+		return "", err
+	}
+	if err := client.Rcpt(email.To.Address); err != nil {
+		// 5xx replies reject the recipient for good
+		var reply *textproto.Error
+		if errors.As(err, &reply) && reply.Code >= 500 {
+			return "", &permanentEmailError{err}
+		}
+		return "", err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(email.Raw); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return email.MessageID, client.Quit()
+}
+
+// sendGridEmailProvider delivers emails through the SendGrid v3 mail API
+type sendGridEmailProvider struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+func (p *sendGridEmailProvider) Name() string { return EmailProviderSendGrid }
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+func (p *sendGridEmailProvider) Deliver(ctx context.Context, email OutgoingEmail) (string, error) {
+	body := sendGridRequest{
+		Personalizations: []sendGridPersonalization{
+			{To: []sendGridAddress{{Email: email.To.Address, Name: email.To.Name}}},
+		},
+		From:    sendGridAddress{Email: email.From.Address, Name: email.From.Name},
+		Subject: email.Message.Subject,
+	}
+	// SendGrid requires the plain text part to come first
+	if email.Message.TextBody != "" {
+		body.Content = append(body.Content, sendGridContent{Type: "text/plain", Value: email.Message.TextBody})
+	}
+	if email.Message.HTMLBody != "" {
+		body.Content = append(body.Content, sendGridContent{Type: "text/html", Value: email.Message.HTMLBody})
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", &permanentEmailError{err}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v3/mail/send", bytes.NewReader(data))
+	if err != nil {
+		return "", &permanentEmailError{err}
+	}
+	request.Header.Set("Authorization", "Bearer "+p.apiKey)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if err := providerResponseError(response); err != nil {
+		return "", err
+	}
+	return response.Header.Get("X-Message-Id"), nil
+}
+
+// sesEmailProvider delivers emails through the Amazon SES v2 API, signing
+// requests with AWS Signature Version 4
+type sesEmailProvider struct {
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	endpoint        string
+	client          *http.Client
+	now             func() time.Time
+}
+
+func (p *sesEmailProvider) Name() string { return EmailProviderSES }
+
+func (p *sesEmailProvider) Deliver(ctx context.Context, email OutgoingEmail) (string, error) {
+	data, err := json.Marshal(map[string]interface{}{
+		"FromEmailAddress": email.From.String(),
+		"Destination":      map[string][]string{"ToAddresses": {email.To.String()}},
+		"Content": map[string]interface{}{
+			"Raw": map[string]string{"Data": base64.StdEncoding.EncodeToString(email.Raw)},
+		},
+	})
+	if err != nil {
+		return "", &permanentEmailError{err}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/v2/email/outbound-emails", bytes.NewReader(data))
+	if err != nil {
+		return "", &permanentEmailError{err}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	p.sign(request, data)
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if err := providerResponseError(response); err != nil {
+		return "", err
+	}
+
+	var result struct {
+		MessageID string `json:"MessageId"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode SES response: %w", err)
+	}
+	return result.MessageID, nil
+}
+
+// sign adds an AWS Signature Version 4 authorization header to the request
+func (p *sesEmailProvider) sign(request *http.Request, body []byte) {
+	credentials := util.AWSCredentials{AccessKeyID: p.accessKeyID, SecretAccessKey: p.secretAccessKey}
+	util.SignAWSRequest(request, body, credentials, p.region, "ses", p.now())
+}
+
+// providerResponseError turns an unsuccessful API response into an error.
+// Client errors other than rate limiting won't succeed when retried.
+func providerResponseError(response *http.Response) error {
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	err := fmt.Errorf("provider returned %s: %s", response.Status, strings.TrimSpace(string(body)))
+	if response.StatusCode >= 400 && response.StatusCode < 500 && response.StatusCode != http.StatusTooManyRequests {
+		return &permanentEmailError{err}
+	}
+	return err
+}
@@ -0,0 +1,454 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Email delivery statuses
+const (
+	EmailDeliveryPending = "pending"
+	EmailDeliverySent    = "sent"
+	EmailDeliveryFailed  = "failed"
+)
+
+// emailDeliveriesPerRun caps how many emails one queue pass sends
+const emailDeliveriesPerRun = 50
+
+// emailStaleAfter is how long an email can stay claimed by a worker before
+// another worker takes it over, longer than any provider call can take
+const emailStaleAfter = 10 * time.Minute
+
+// EmailMessage is an email with a plain text and an HTML body. Category and
+// ReferenceID say what the email is about, e.g. "invitation" and the
+// invitation's ID, so the outcome of a queued email can be reported back.
+type EmailMessage struct {
+	To          string
+	Subject     string
+	TextBody    string
+	HTMLBody    string
+	Category    string
+	ReferenceID int64
+}
+
+// EmailResultHandler is told the final outcome of a queued email, once it was
+// sent or failed for good
+type EmailResultHandler func(ctx context.Context, delivery db.EmailDelivery)
+
+// EmailService sends emails through the configured provider. With a store,
+// emails are queued and sent by a background worker that retries failed
+// attempts and records the outcome of each email. Without one they are sent
+// straight away.
+type EmailService struct {
+	store         db.EmailStore
+	from          string
+	provider      EmailProvider
+	maxAttempts   int32
+	retryDelay    time.Duration
+	retention     time.Duration
+	handlers      map[string]EmailResultHandler
+	webhookClient *http.Client
+}
+
+// NewEmailService creates a new email service. A nil store sends emails
+// without queueing them.
+func NewEmailService(store db.EmailStore, config util.Config) (*EmailService, error) {
+	provider, err := newEmailProvider(config)
+	if err != nil {
+		return nil, err
+	}
+
+	service := &EmailService{
+		store:         store,
+		from:          config.EmailFrom,
+		provider:      provider,
+		maxAttempts:   config.EmailMaxAttempts,
+		retryDelay:    config.EmailRetryDelay,
+		retention:     config.EmailDeliveryRetention,
+		handlers:      make(map[string]EmailResultHandler),
+		webhookClient: &http.Client{Timeout: providerTimeout},
+	}
+	if service.from == "" {
+		service.from = "GoSlack <noreply@localhost>"
+	}
+	if service.maxAttempts <= 0 {
+		service.maxAttempts = 5
+	}
+	if service.retryDelay <= 0 {
+		service.retryDelay = time.Minute
+	}
+	if service.retention <= 0 {
+		service.retention = 30 * 24 * time.Hour
+	}
+
+	return service, nil
+}
+
+// Queued reports whether Send queues emails rather than sending them
+func (s *EmailService) Queued() bool {
+	return s.store != nil
+}
+
+// OnResult registers the handler told the outcome of queued emails of a category
+func (s *EmailService) OnResult(category string, handler EmailResultHandler) {
+	s.handlers[category] = handler
+}
+
+// Send queues an email, or sends it straight away without a queue. Addresses
+// that bounced or complained are refused with ErrEmailSuppressed.
+func (s *EmailService) Send(ctx context.Context, message EmailMessage) error {
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	suppressed, err := s.suppressed(ctx, to.Address)
+	if err != nil {
+		return err
+	}
+	if suppressed {
+		return ErrEmailSuppressed
+	}
+
+	if s.store == nil {
+		if _, err := s.deliver(ctx, message); err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	}
+
+	_, err = s.store.CreateEmailDelivery(ctx, db.CreateEmailDeliveryParams{
+		Category:    message.Category,
+		ReferenceID: sql.NullInt64{Int64: message.ReferenceID, Valid: message.ReferenceID != 0},
+		ToAddress:   to.Address,
+		Subject:     message.Subject,
+		TextBody:    message.TextBody,
+		HtmlBody:    message.HTMLBody,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+	return nil
+}
+
+// Render fills in an organization's template for a kind of email, or the
+// built-in template when the organization hasn't customized it, using the
+// organization's branding
+func (s *EmailService) Render(ctx context.Context, organizationID int64, kind, to string, data emailTemplateData) (EmailMessage, error) {
+	emailKind, ok := emailKinds[kind]
+	if !ok {
+		return EmailMessage{}, fmt.Errorf("unknown email kind %q", kind)
+	}
+
+	template := emailKind.builtin
+	if s.store != nil {
+		custom, err := s.store.GetEmailTemplate(ctx, db.GetEmailTemplateParams{
+			OrganizationID: organizationID,
+			Kind:           kind,
+		})
+		switch {
+		case err == nil:
+			template, err = parseEmailTemplate(kind, custom.Subject, custom.TextBody, custom.HtmlBody)
+			if err != nil {
+				return EmailMessage{}, err
+			}
+		case err != sql.ErrNoRows:
+			return EmailMessage{}, fmt.Errorf("failed to get email template: %w", err)
+		}
+	}
+
+	brand, err := s.branding(ctx, organizationID)
+	if err != nil {
+		return EmailMessage{}, err
+	}
+
+	message, err := template.render(to, data.withBrand(brand))
+	if err != nil {
+		return EmailMessage{}, fmt.Errorf("failed to render %s email: %w", kind, err)
+	}
+	message.Category = kind
+	return message, nil
+}
+
+// branding returns an organization's email branding, with defaults for
+// whatever it hasn't set
+func (s *EmailService) branding(ctx context.Context, organizationID int64) (EmailBranding, error) {
+	brand := defaultEmailBranding
+	if s.store == nil {
+		return brand, nil
+	}
+
+	custom, err := s.store.GetEmailBranding(ctx, organizationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return brand, nil
+		}
+		return brand, fmt.Errorf("failed to get email branding: %w", err)
+	}
+
+	if custom.ProductName != "" {
+		brand.ProductName = custom.ProductName
+	}
+	if custom.PrimaryColor != "" {
+		brand.PrimaryColor = custom.PrimaryColor
+	}
+	brand.LogoURL = custom.LogoUrl
+	brand.FooterText = custom.FooterText
+	return brand, nil
+}
+
+// deliver renders an email and hands it to the provider, returning the ID the
+// provider gave it
+func (s *EmailService) deliver(ctx context.Context, message EmailMessage) (string, error) {
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		return "", &permanentEmailError{fmt.Errorf("invalid sender address: %w", err)}
+	}
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return "", &permanentEmailError{fmt.Errorf("invalid recipient address: %w", err)}
+	}
+
+	messageID, err := newMessageID(from.Address)
+	if err != nil {
+		return "", err
+	}
+	data, err := buildEmail(from, to, messageID, message)
+	if err != nil {
+		return "", fmt.Errorf("failed to build email: %w", err)
+	}
+
+	return s.provider.Deliver(ctx, OutgoingEmail{
+		From:      from,
+		To:        to,
+		MessageID: messageID,
+		Message:   message,
+		Raw:       data,
+	})
+}
+
+// ProcessQueue sends the queued emails that are due, then removes finished
+// emails older than the retention period. Failed attempts are retried with a
+// growing delay until the attempt limit. It returns how many emails were sent.
+func (s *EmailService) ProcessQueue(ctx context.Context) (int, error) {
+	sent := 0
+
+	for {
+		deliveries, err := s.store.ClaimEmailDeliveries(ctx, db.ClaimEmailDeliveriesParams{
+			StaleBefore: time.Now().Add(-emailStaleAfter),
+			Limit:       emailDeliveriesPerRun,
+		})
+		if err != nil {
+			return sent, fmt.Errorf("failed to claim queued emails: %w", err)
+		}
+
+		for _, delivery := range deliveries {
+			ok, err := s.processDelivery(ctx, delivery)
+			if err != nil {
+				return sent, err
+			}
+			if ok {
+				sent++
+			}
+		}
+
+		if len(deliveries) < emailDeliveriesPerRun {
+			break
+		}
+	}
+
+	_, err := s.store.DeleteOldEmailDeliveries(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return sent, fmt.Errorf("failed to delete old emails: %w", err)
+	}
+
+	return sent, nil
+}
+
+// processDelivery makes one attempt at sending a queued email and records the
+// outcome, reporting whether the email was sent. Emails to addresses that were
+// suppressed after the email was queued fail without an attempt.
+func (s *EmailService) processDelivery(ctx context.Context, delivery db.EmailDelivery) (bool, error) {
+	suppressed, err := s.suppressed(ctx, delivery.ToAddress)
+	if err != nil {
+		return false, err
+	}
+
+	var providerMessageID string
+	var deliverErr error
+	if suppressed {
+		deliverErr = &permanentEmailError{ErrEmailSuppressed}
+	} else {
+		providerMessageID, deliverErr = s.deliver(ctx, EmailMessage{
+			To:          delivery.ToAddress,
+			Subject:     delivery.Subject,
+			TextBody:    delivery.TextBody,
+			HTMLBody:    delivery.HtmlBody,
+			Category:    delivery.Category,
+			ReferenceID: delivery.ReferenceID.Int64,
+		})
+	}
+
+	switch {
+	case deliverErr == nil:
+		err := s.store.MarkEmailDeliverySent(ctx, db.MarkEmailDeliverySentParams{
+			ID:                delivery.ID,
+			Provider:          s.provider.Name(),
+			ProviderMessageID: providerMessageID,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to record email %d as sent: %w", delivery.ID, err)
+		}
+		delivery.Status = EmailDeliverySent
+		delivery.ProviderMessageID = providerMessageID
+
+	case isPermanentEmailError(deliverErr) || delivery.Attempts >= s.maxAttempts:
+		fmt.Printf("Email %d to %s failed: %v\n", delivery.ID, delivery.ToAddress, deliverErr)
+		err := s.store.FailEmailDelivery(ctx, db.FailEmailDeliveryParams{
+			ID:        delivery.ID,
+			Provider:  s.provider.Name(),
+			LastError: deliverErr.Error(),
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to record email %d as failed: %w", delivery.ID, err)
+		}
+		delivery.Status = EmailDeliveryFailed
+		delivery.LastError = deliverErr.Error()
+
+	default:
+		err := s.store.RetryEmailDelivery(ctx, db.RetryEmailDeliveryParams{
+			ID:            delivery.ID,
+			Provider:      s.provider.Name(),
+			LastError:     deliverErr.Error(),
+			NextAttemptAt: time.Now().Add(s.retryBackoff(delivery.Attempts)),
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to reschedule email %d: %w", delivery.ID, err)
+		}
+		return false, nil
+	}
+
+	delivery.Provider = s.provider.Name()
+	if handler, ok := s.handlers[delivery.Category]; ok {
+		handler(ctx, delivery)
+	}
+	return delivery.Status == EmailDeliverySent, nil
+}
+
+// retryBackoff doubles the retry delay with each failed attempt, up to a day
+func (s *EmailService) retryBackoff(attempts int32) time.Duration {
+	delay := s.retryDelay
+	for i := int32(1); i < attempts && delay < 24*time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > 24*time.Hour {
+		delay = 24 * time.Hour
+	}
+	return delay
+}
+
+// StartQueueWorker sends queued emails on an interval until the context is cancelled
+func (s *EmailService) StartQueueWorker(ctx context.Context, interval time.Duration) {
+	if s.store == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sent, err := s.ProcessQueue(ctx)
+			if err != nil {
+				fmt.Printf("Error sending queued emails: %v\n", err)
+			}
+			if sent > 0 {
+				fmt.Printf("Sent %d queued emails\n", sent)
+			}
+		}
+	}
+}
+
+// buildEmail renders a multipart/alternative message with both bodies
+func buildEmail(from, to *mail.Address, messageID string, message EmailMessage) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", message.TextBody},
+		{"text/html; charset=UTF-8", message.HTMLBody},
+	}
+	for _, part := range parts {
+		if part.content == "" {
+			continue
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", part.contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var data bytes.Buffer
+	fmt.Fprintf(&data, "From: %s\r\n", from.String())
+	fmt.Fprintf(&data, "To: %s\r\n", to.String())
+	fmt.Fprintf(&data, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", message.Subject))
+	fmt.Fprintf(&data, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&data, "Message-ID: %s\r\n", messageID)
+	fmt.Fprintf(&data, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&data, "Content-Type: multipart/alternative; boundary=%q\r\n", writer.Boundary())
+	fmt.Fprintf(&data, "\r\n")
+	data.Write(body.Bytes())
+
+	return data.Bytes(), nil
+}
+
+// newMessageID generates a unique Message-ID in the sender's domain
+func newMessageID(from string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	domain := "localhost"
+	if at := strings.LastIndexByte(from, '@'); at >= 0 {
+		domain = from[at+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(random), domain), nil
+}
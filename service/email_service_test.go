@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+// sentEmail is an email captured instead of being delivered
+type sentEmail struct {
+	from    string
+	to      string
+	message *mail.Message
+}
+
+// capturingEmailProvider records the emails it is given instead of sending them
+type capturingEmailProvider struct {
+	t    *testing.T
+	err  error
+	sent *[]sentEmail
+}
+
+func (p *capturingEmailProvider) Name() string { return "capture" }
+
+func (p *capturingEmailProvider) Deliver(ctx context.Context, email OutgoingEmail) (string, error) {
+	message, err := mail.ReadMessage(strings.NewReader(string(email.Raw)))
+	require.NoError(p.t, err)
+	*p.sent = append(*p.sent, sentEmail{from: email.From.Address, to: email.To.Address, message: message})
+	return email.MessageID, p.err
+}
+
+// newCapturingEmailService returns an email service that sends emails straight
+// away and records them, failing each delivery with err when it is set
+func newCapturingEmailService(t *testing.T, err error) (*EmailService, *[]sentEmail) {
+	return newQueuedCapturingEmailService(t, nil, err)
+}
+
+// newQueuedCapturingEmailService is newCapturingEmailService with emails queued in store
+func newQueuedCapturingEmailService(t *testing.T, store db.Store, err error) (*EmailService, *[]sentEmail) {
+	service, newErr := NewEmailService(store, util.Config{EmailFrom: "GoSlack <noreply@goslack.test>"})
+	require.NoError(t, newErr)
+	sent := &[]sentEmail{}
+	service.provider = &capturingEmailProvider{t: t, err: err, sent: sent}
+	return service, sent
+}
+
+// emailParts returns the bodies of a multipart email by content type
+func emailParts(t *testing.T, message *mail.Message) map[string]string {
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		// The multipart reader decodes quoted-printable parts
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		contentType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		parts[contentType] = string(body)
+	}
+	return parts
+}
+
+func TestEmailService_Send(t *testing.T) {
+	service, sent := newCapturingEmailService(t, nil)
+
+	err := service.Send(context.Background(), EmailMessage{
+		To:       "Ada Lovelace <ada@example.com>",
+		Subject:  "Grüße from GoSlack",
+		TextBody: "Hello Ada, a long line that needs to be wrapped when it is encoded as quoted-printable text for the email body",
+		HTMLBody: "<p>Hello <strong>Ada</strong></p>",
+	})
+	require.NoError(t, err)
+	require.Len(t, *sent, 1)
+
+	email := (*sent)[0]
+	require.Equal(t, "noreply@goslack.test", email.from)
+	require.Equal(t, "ada@example.com", email.to)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(email.message.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, "Grüße from GoSlack", subject)
+	require.True(t, strings.HasSuffix(email.message.Header.Get("Message-ID"), "@goslack.test>"))
+
+	parts := emailParts(t, email.message)
+	require.Equal(t, "Hello Ada, a long line that needs to be wrapped when it is encoded as quoted-printable text for the email body", parts["text/plain"])
+	require.Equal(t, "<p>Hello <strong>Ada</strong></p>", parts["text/html"])
+}
+
+func TestEmailService_SendErrors(t *testing.T) {
+	service, sent := newCapturingEmailService(t, errors.New("connection refused"))
+
+	err := service.Send(context.Background(), EmailMessage{To: "not an address", Subject: "Hi"})
+	require.ErrorContains(t, err, "invalid recipient address")
+	require.Empty(t, *sent)
+
+	err = service.Send(context.Background(), EmailMessage{To: "ada@example.com", Subject: "Hi", TextBody: "Hi"})
+	require.ErrorContains(t, err, "failed to send email: connection refused")
+}
+
+func TestEmailService_QueuesEmails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service, sent := newQueuedCapturingEmailService(t, store, nil)
+	require.True(t, service.Queued())
+
+	store.EXPECT().GetEmailSuppression(gomock.Any(), "ada@example.com").Times(1).Return(db.EmailSuppression{}, sql.ErrNoRows)
+	store.EXPECT().
+		CreateEmailDelivery(gomock.Any(), gomock.Eq(db.CreateEmailDeliveryParams{
+			Category:    "invitation",
+			ReferenceID: sql.NullInt64{Int64: 9, Valid: true},
+			ToAddress:   "ada@example.com",
+			Subject:     "Hi",
+			TextBody:    "Hello",
+		})).
+		Times(1).
+		Return(db.EmailDelivery{ID: 1}, nil)
+
+	err := service.Send(context.Background(), EmailMessage{
+		To:          "ada@example.com",
+		Subject:     "Hi",
+		TextBody:    "Hello",
+		Category:    "invitation",
+		ReferenceID: 9,
+	})
+	require.NoError(t, err)
+	require.Empty(t, *sent)
+}
+
+func TestEmailService_ProcessQueue(t *testing.T) {
+	testCases := []struct {
+		name       string
+		attempts   int32
+		deliverErr error
+		suppressed bool
+		buildStubs func(store *mockdb.MockStore)
+		sent       int
+		result     string
+	}{
+		{
+			name:     "Sent",
+			attempts: 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					MarkEmailDeliverySent(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.MarkEmailDeliverySentParams) error {
+						require.Equal(t, int64(1), arg.ID)
+						require.Equal(t, "capture", arg.Provider)
+						require.NotEmpty(t, arg.ProviderMessageID)
+						return nil
+					})
+			},
+			sent:   1,
+			result: EmailDeliverySent,
+		},
+		{
+			name:       "RetriedAfterTemporaryFailure",
+			attempts:   2,
+			deliverErr: errors.New("connection refused"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RetryEmailDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.RetryEmailDeliveryParams) error {
+						require.Equal(t, "connection refused", arg.LastError)
+						// The second failure waits twice the retry delay
+						require.WithinDuration(t, time.Now().Add(2*time.Minute), arg.NextAttemptAt, 5*time.Second)
+						return nil
+					})
+			},
+		},
+		{
+			name:       "FailedAfterLastAttempt",
+			attempts:   5,
+			deliverErr: errors.New("connection refused"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FailEmailDelivery(gomock.Any(), gomock.Eq(db.FailEmailDeliveryParams{ID: 1, Provider: "capture", LastError: "connection refused"})).
+					Times(1).
+					Return(nil)
+			},
+			result: EmailDeliveryFailed,
+		},
+		{
+			name:       "FailedPermanently",
+			attempts:   1,
+			deliverErr: &permanentEmailError{errors.New("recipient rejected")},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FailEmailDelivery(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil)
+			},
+			result: EmailDeliveryFailed,
+		},
+		{
+			name:       "SuppressedAfterQueueing",
+			attempts:   1,
+			suppressed: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					FailEmailDelivery(gomock.Any(), gomock.Eq(db.FailEmailDeliveryParams{ID: 1, Provider: "capture", LastError: ErrEmailSuppressed.Error()})).
+					Times(1).
+					Return(nil)
+			},
+			result: EmailDeliveryFailed,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			service, sent := newQueuedCapturingEmailService(t, store, tc.deliverErr)
+
+			var results []db.EmailDelivery
+			service.OnResult("invitation", func(ctx context.Context, delivery db.EmailDelivery) {
+				results = append(results, delivery)
+			})
+
+			store.EXPECT().
+				ClaimEmailDeliveries(gomock.Any(), gomock.Any()).
+				Times(1).
+				Return([]db.EmailDelivery{{
+					ID:          1,
+					Category:    "invitation",
+					ReferenceID: sql.NullInt64{Int64: 9, Valid: true},
+					ToAddress:   "ada@example.com",
+					Subject:     "Hi",
+					TextBody:    "Hello",
+					Status:      "sending",
+					Attempts:    tc.attempts,
+				}}, nil)
+			if tc.suppressed {
+				store.EXPECT().GetEmailSuppression(gomock.Any(), "ada@example.com").Times(1).Return(db.EmailSuppression{Email: "ada@example.com"}, nil)
+			} else {
+				store.EXPECT().GetEmailSuppression(gomock.Any(), "ada@example.com").Times(1).Return(db.EmailSuppression{}, sql.ErrNoRows)
+			}
+			tc.buildStubs(store)
+			store.EXPECT().DeleteOldEmailDeliveries(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+
+			count, err := service.ProcessQueue(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.sent, count)
+			if tc.suppressed {
+				require.Empty(t, *sent)
+			} else {
+				require.Len(t, *sent, 1)
+				require.Equal(t, "ada@example.com", (*sent)[0].to)
+			}
+
+			if tc.result == "" {
+				require.Empty(t, results)
+				return
+			}
+			require.Len(t, results, 1)
+			require.Equal(t, tc.result, results[0].Status)
+			require.Equal(t, int64(9), results[0].ReferenceID.Int64)
+		})
+	}
+}
+
+func TestSendGridEmailProvider(t *testing.T) {
+	var request sendGridRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v3/mail/send", r.URL.Path)
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		if request.Personalizations[0].To[0].Email == "bounced@example.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("X-Message-Id", "sg-123")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	provider := &sendGridEmailProvider{apiKey: "secret", baseURL: server.URL, client: server.Client()}
+	email := OutgoingEmail{
+		From:    &mail.Address{Name: "GoSlack", Address: "noreply@goslack.test"},
+		To:      &mail.Address{Address: "ada@example.com"},
+		Message: EmailMessage{Subject: "Hi", TextBody: "Hello", HTMLBody: "<p>Hello</p>"},
+	}
+
+	id, err := provider.Deliver(context.Background(), email)
+	require.NoError(t, err)
+	require.Equal(t, "sg-123", id)
+	require.Equal(t, "Hi", request.Subject)
+	require.Equal(t, "GoSlack", request.From.Name)
+	require.Equal(t, []sendGridContent{{Type: "text/plain", Value: "Hello"}, {Type: "text/html", Value: "<p>Hello</p>"}}, request.Content)
+
+	email.To = &mail.Address{Address: "bounced@example.com"}
+	_, err = provider.Deliver(context.Background(), email)
+	require.Error(t, err)
+	require.True(t, isPermanentEmailError(err))
+}
+
+func TestSESEmailProvider(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/email/outbound-emails", r.URL.Path)
+		require.Equal(t, "20240301T120000Z", r.Header.Get("X-Amz-Date"))
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKID/20240301/eu-west-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature="))
+
+		var body struct {
+			Content struct {
+				Raw struct {
+					Data []byte
+				}
+			}
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "raw message", string(body.Content.Raw.Data))
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider := &sesEmailProvider{
+		region:          "eu-west-1",
+		accessKeyID:     "AKID",
+		secretAccessKey: "secret",
+		endpoint:        server.URL,
+		client:          server.Client(),
+		now:             func() time.Time { return now },
+	}
+	_, err := provider.Deliver(context.Background(), OutgoingEmail{
+		From: &mail.Address{Address: "noreply@goslack.test"},
+		To:   &mail.Address{Address: "ada@example.com"},
+		Raw:  []byte("raw message"),
+	})
+	// Server errors are retried
+	require.Error(t, err)
+	require.False(t, isPermanentEmailError(err))
+}
+
+func TestNewEmailProvider(t *testing.T) {
+	provider, err := newEmailProvider(util.Config{})
+	require.NoError(t, err)
+	require.Equal(t, EmailProviderLog, provider.Name())
+
+	provider, err = newEmailProvider(util.Config{SMTPHost: "smtp.example.com"})
+	require.NoError(t, err)
+	require.Equal(t, EmailProviderSMTP, provider.Name())
+
+	_, err = newEmailProvider(util.Config{EmailProvider: EmailProviderSendGrid})
+	require.ErrorContains(t, err, "SENDGRID_API_KEY")
+
+	_, err = newEmailProvider(util.Config{EmailProvider: "carrier-pigeon"})
+	require.Error(t, err)
+}
+
+func TestEmailService_Render(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service, _ := newQueuedCapturingEmailService(t, store, nil)
+
+	data := invitationEmailData{
+		InviterName:   "Ada <script>",
+		WorkspaceName: "Engineering",
+		Role:          "member",
+		Link:          "https://example.com/join?code=abc",
+		Code:          "abc",
+	}
+
+	// Without customization the built-in template and default branding are used
+	store.EXPECT().GetEmailTemplate(gomock.Any(), gomock.Any()).Times(1).Return(db.EmailTemplate{}, sql.ErrNoRows)
+	store.EXPECT().GetEmailBranding(gomock.Any(), int64(7)).Times(1).Return(db.EmailBranding{}, sql.ErrNoRows)
+
+	message, err := service.Render(context.Background(), 7, EmailKindInvitation, "bob@example.com", data)
+	require.NoError(t, err)
+	require.Equal(t, "bob@example.com", message.To)
+	require.Equal(t, EmailKindInvitation, message.Category)
+	require.Equal(t, "Ada <script> invited you to join Engineering on GoSlack", message.Subject)
+	require.Contains(t, message.HTMLBody, "background: #4a154b")
+	require.NotContains(t, message.HTMLBody, "<script>")
+
+	// The organization's template and branding replace the built-in ones
+	store.EXPECT().
+		GetEmailTemplate(gomock.Any(), gomock.Eq(db.GetEmailTemplateParams{OrganizationID: 7, Kind: EmailKindInvitation})).
+		Times(1).
+		Return(db.EmailTemplate{
+			Subject:  "{{.WorkspaceName}} is waiting for you",
+			TextBody: "Join {{.Brand.ProductName}}: {{.Link}}",
+			HtmlBody: `<img src="{{.Brand.LogoURL}}"><p>{{.InviterName}}</p>`,
+		}, nil)
+	store.EXPECT().
+		GetEmailBranding(gomock.Any(), int64(7)).
+		Times(1).
+		Return(db.EmailBranding{ProductName: "Acme Chat", LogoUrl: "https://acme.example/logo.png"}, nil)
+
+	message, err = service.Render(context.Background(), 7, EmailKindInvitation, "bob@example.com", data)
+	require.NoError(t, err)
+	require.Equal(t, "Engineering is waiting for you", message.Subject)
+	require.Equal(t, "Join Acme Chat: https://example.com/join?code=abc", message.TextBody)
+	require.Equal(t, `<img src="https://acme.example/logo.png"><p>Ada &lt;script&gt;</p>`, message.HTMLBody)
+
+	_, err = service.Render(context.Background(), 7, "newsletter", "bob@example.com", data)
+	require.Error(t, err)
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Reasons an address stops receiving emails
+const (
+	EmailSuppressionBounce    = "bounce"
+	EmailSuppressionComplaint = "complaint"
+)
+
+// ErrEmailSuppressed is returned when sending to an address that bounced or
+// complained about an earlier email
+var ErrEmailSuppressed = errors.New("email address is undeliverable")
+
+// ErrInvalidEmailFeedback is returned for webhook deliveries that can't be read
+var ErrInvalidEmailFeedback = errors.New("invalid email feedback")
+
+// EmailFeedback is a bounce or spam complaint a provider reported for an address
+type EmailFeedback struct {
+	Email  string
+	Reason string
+	Detail string
+}
+
+// EmailSuppressionResponse says why an address is no longer emailed
+type EmailSuppressionResponse struct {
+	Reason       string    `json:"reason"`
+	Provider     string    `json:"provider"`
+	Detail       string    `json:"detail,omitempty"`
+	SuppressedAt time.Time `json:"suppressed_at"`
+}
+
+// suppressed reports whether emails to an address are suppressed. Without a
+// store nothing is.
+func (s *EmailService) suppressed(ctx context.Context, address string) (bool, error) {
+	if s.store == nil {
+		return false, nil
+	}
+
+	_, err := s.store.GetEmailSuppression(ctx, address)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check email suppression: %w", err)
+	}
+	return true, nil
+}
+
+// RecordFeedback suppresses the addresses a provider reported bounces or
+// complaints for, returning how many were recorded
+func (s *EmailService) RecordFeedback(ctx context.Context, provider string, feedback []EmailFeedback) (int, error) {
+	if s.store == nil {
+		return 0, errors.New("email feedback can't be recorded without a store")
+	}
+
+	recorded := 0
+	for _, f := range feedback {
+		address, err := mailAddress(f.Email)
+		if err != nil {
+			fmt.Printf("Ignoring %s feedback for %q: %v\n", provider, f.Email, err)
+			continue
+		}
+
+		_, err = s.store.UpsertEmailSuppression(ctx, db.UpsertEmailSuppressionParams{
+			Email:    address,
+			Reason:   f.Reason,
+			Provider: provider,
+			Detail:   f.Detail,
+		})
+		if err != nil {
+			return recorded, fmt.Errorf("failed to suppress %s: %w", address, err)
+		}
+		recorded++
+	}
+
+	return recorded, nil
+}
+
+// Suppressions returns the suppression state of the given addresses, keyed by
+// lowercased address. Addresses that can still be emailed are left out.
+func (s *EmailService) Suppressions(ctx context.Context, emails []string) (map[string]EmailSuppressionResponse, error) {
+	result := make(map[string]EmailSuppressionResponse)
+	if s.store == nil || len(emails) == 0 {
+		return result, nil
+	}
+
+	lowered := make([]string, len(emails))
+	for i, email := range emails {
+		lowered[i] = strings.ToLower(email)
+	}
+
+	suppressions, err := s.store.ListEmailSuppressionsByEmails(ctx, lowered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email suppressions: %w", err)
+	}
+
+	for _, suppression := range suppressions {
+		result[suppression.Email] = EmailSuppressionResponse{
+			Reason:       suppression.Reason,
+			Provider:     suppression.Provider,
+			Detail:       suppression.Detail,
+			SuppressedAt: suppression.UpdatedAt,
+		}
+	}
+	return result, nil
+}
+
+// LiftSuppression lets emails be sent to an address again, e.g. once the
+// recipient fixed their mailbox
+func (s *EmailService) LiftSuppression(ctx context.Context, email string) error {
+	if s.store == nil {
+		return errors.New("email address is not suppressed")
+	}
+
+	deleted, err := s.store.DeleteEmailSuppression(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to lift email suppression: %w", err)
+	}
+	if deleted == 0 {
+		return errors.New("email address is not suppressed")
+	}
+	return nil
+}
+
+// mailAddress returns the lowercased bare address of an email address
+func mailAddress(email string) (string, error) {
+	parsed, err := mail.ParseAddress(email)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(parsed.Address), nil
+}
+
+// sendGridEvent is the part of a SendGrid event webhook event we read
+type sendGridEvent struct {
+	Email  string `json:"email"`
+	Event  string `json:"event"`
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// HandleSendGridEvents records the bounces and spam reports in a SendGrid
+// event webhook delivery. Blocked messages are temporary failures and other
+// events are ignored.
+func (s *EmailService) HandleSendGridEvents(ctx context.Context, body []byte) (int, error) {
+	var events []sendGridEvent
+	if err := json.Unmarshal(body, &events); err != nil {
+		return 0, fmt.Errorf("%w: invalid SendGrid events: %v", ErrInvalidEmailFeedback, err)
+	}
+
+	var feedback []EmailFeedback
+	for _, event := range events {
+		switch {
+		case event.Event == "bounce" && event.Type != "blocked":
+			feedback = append(feedback, EmailFeedback{Email: event.Email, Reason: EmailSuppressionBounce, Detail: event.Reason})
+		case event.Event == "spamreport":
+			feedback = append(feedback, EmailFeedback{Email: event.Email, Reason: EmailSuppressionComplaint})
+		case event.Event == "dropped" && event.Reason == "Bounced Address":
+			feedback = append(feedback, EmailFeedback{Email: event.Email, Reason: EmailSuppressionBounce, Detail: event.Reason})
+		case event.Event == "dropped" && event.Reason == "Spam Reporting Address":
+			feedback = append(feedback, EmailFeedback{Email: event.Email, Reason: EmailSuppressionComplaint, Detail: event.Reason})
+		}
+	}
+
+	return s.RecordFeedback(ctx, EmailProviderSendGrid, feedback)
+}
+
+// snsMessage is an Amazon SNS HTTP delivery
+type snsMessage struct {
+	Type         string `json:"Type"`
+	Message      string `json:"Message"`
+	SubscribeURL string `json:"SubscribeURL"`
+}
+
+// sesNotification is the part of an SES bounce or complaint notification we
+// read. Notifications from configuration set event publishing name the type
+// eventType instead of notificationType.
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           struct {
+		BounceType        string `json:"bounceType"`
+		BounceSubType     string `json:"bounceSubType"`
+		BouncedRecipients []struct {
+			EmailAddress   string `json:"emailAddress"`
+			DiagnosticCode string `json:"diagnosticCode"`
+		} `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint struct {
+		ComplaintFeedbackType string `json:"complaintFeedbackType"`
+		ComplainedRecipients  []struct {
+			EmailAddress string `json:"emailAddress"`
+		} `json:"complainedRecipients"`
+	} `json:"complaint"`
+}
+
+// HandleSESNotification records the permanent bounces and complaints in an
+// SES notification delivered through SNS. SNS subscription confirmations are
+// confirmed; raw message delivery, without the SNS envelope, also works.
+func (s *EmailService) HandleSESNotification(ctx context.Context, body []byte) (int, error) {
+	var envelope snsMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return 0, fmt.Errorf("%w: invalid SNS message: %v", ErrInvalidEmailFeedback, err)
+	}
+
+	payload := body
+	switch envelope.Type {
+	case "SubscriptionConfirmation":
+		return 0, s.confirmSNSSubscription(ctx, envelope.SubscribeURL)
+	case "UnsubscribeConfirmation":
+		return 0, nil
+	case "Notification":
+		payload = []byte(envelope.Message)
+	}
+
+	var notification sesNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return 0, fmt.Errorf("%w: invalid SES notification: %v", ErrInvalidEmailFeedback, err)
+	}
+
+	notificationType := notification.NotificationType
+	if notificationType == "" {
+		notificationType = notification.EventType
+	}
+
+	var feedback []EmailFeedback
+	switch notificationType {
+	case "Bounce":
+		// Transient bounces, such as a full mailbox, may clear up
+		if notification.Bounce.BounceType != "Permanent" {
+			return 0, nil
+		}
+		for _, recipient := range notification.Bounce.BouncedRecipients {
+			detail := recipient.DiagnosticCode
+			if detail == "" {
+				detail = notification.Bounce.BounceSubType
+			}
+			feedback = append(feedback, EmailFeedback{Email: recipient.EmailAddress, Reason: EmailSuppressionBounce, Detail: detail})
+		}
+	case "Complaint":
+		for _, recipient := range notification.Complaint.ComplainedRecipients {
+			feedback = append(feedback, EmailFeedback{Email: recipient.EmailAddress, Reason: EmailSuppressionComplaint, Detail: notification.Complaint.ComplaintFeedbackType})
+		}
+	}
+
+	return s.RecordFeedback(ctx, EmailProviderSES, feedback)
+}
+
+// confirmSNSSubscription visits the link SNS sends to confirm a new
+// subscription. Only SNS links are followed so the webhook can't be used to
+// make the server fetch arbitrary URLs.
+func (s *EmailService) confirmSNSSubscription(ctx context.Context, subscribeURL string) error {
+	parsed, err := url.Parse(subscribeURL)
+	if err != nil || parsed.Scheme != "https" ||
+		!strings.HasPrefix(parsed.Hostname(), "sns.") || !strings.HasSuffix(parsed.Hostname(), ".amazonaws.com") {
+		return fmt.Errorf("%w: invalid SNS subscribe URL %q", ErrInvalidEmailFeedback, subscribeURL)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return err
+	}
+	response, err := s.webhookClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to confirm SNS subscription: %w", err)
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to confirm SNS subscription: status %d", response.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailService_RefusesSuppressedAddresses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service, sent := newQueuedCapturingEmailService(t, store, nil)
+
+	store.EXPECT().
+		GetEmailSuppression(gomock.Any(), "ada@example.com").
+		Times(1).
+		Return(db.EmailSuppression{Email: "ada@example.com", Reason: EmailSuppressionBounce}, nil)
+	store.EXPECT().CreateEmailDelivery(gomock.Any(), gomock.Any()).Times(0)
+
+	err := service.Send(context.Background(), EmailMessage{To: "Ada <ada@example.com>", Subject: "Hi", TextBody: "Hello"})
+	require.ErrorIs(t, err, ErrEmailSuppressed)
+	require.Empty(t, *sent)
+}
+
+func TestEmailService_HandleSendGridEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service, _ := newQueuedCapturingEmailService(t, store, nil)
+
+	body := `[
+		{"email": "Bounced@Example.com", "event": "bounce", "type": "bounce", "reason": "550 5.1.1 unknown user"},
+		{"email": "blocked@example.com", "event": "bounce", "type": "blocked", "reason": "421 try again later"},
+		{"email": "spam@example.com", "event": "spamreport"},
+		{"email": "ada@example.com", "event": "delivered"},
+		{"email": "not an address", "event": "spamreport"}
+	]`
+
+	var suppressed []db.UpsertEmailSuppressionParams
+	store.EXPECT().
+		UpsertEmailSuppression(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.UpsertEmailSuppressionParams) (db.EmailSuppression, error) {
+			suppressed = append(suppressed, arg)
+			return db.EmailSuppression{Email: arg.Email}, nil
+		})
+
+	count, err := service.HandleSendGridEvents(context.Background(), []byte(body))
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Equal(t, []db.UpsertEmailSuppressionParams{
+		{Email: "bounced@example.com", Reason: EmailSuppressionBounce, Provider: EmailProviderSendGrid, Detail: "550 5.1.1 unknown user"},
+		{Email: "spam@example.com", Reason: EmailSuppressionComplaint, Provider: EmailProviderSendGrid},
+	}, suppressed)
+
+	_, err = service.HandleSendGridEvents(context.Background(), []byte(`{"event": "bounce"}`))
+	require.ErrorIs(t, err, ErrInvalidEmailFeedback)
+}
+
+// snsNotification wraps an SES notification in an SNS delivery
+func snsNotification(t *testing.T, notification string) []byte {
+	body, err := json.Marshal(map[string]string{
+		"Type":    "Notification",
+		"Message": notification,
+	})
+	require.NoError(t, err)
+	return body
+}
+
+func TestEmailService_HandleSESNotification(t *testing.T) {
+	testCases := []struct {
+		name       string
+		body       []byte
+		buildStubs func(store *mockdb.MockStore)
+		count      int
+		checkErr   func(err error)
+	}{
+		{
+			name: "PermanentBounce",
+			body: snsNotification(t, `{"notificationType": "Bounce", "bounce": {"bounceType": "Permanent", "bounceSubType": "General",
+				"bouncedRecipients": [{"emailAddress": "ada@example.com", "diagnosticCode": "smtp; 550 user unknown"}]}}`),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertEmailSuppression(gomock.Any(), gomock.Eq(db.UpsertEmailSuppressionParams{
+						Email:    "ada@example.com",
+						Reason:   EmailSuppressionBounce,
+						Provider: EmailProviderSES,
+						Detail:   "smtp; 550 user unknown",
+					})).
+					Times(1).
+					Return(db.EmailSuppression{}, nil)
+			},
+			count: 1,
+		},
+		{
+			name: "TransientBounce",
+			body: snsNotification(t, `{"notificationType": "Bounce", "bounce": {"bounceType": "Transient",
+				"bouncedRecipients": [{"emailAddress": "ada@example.com"}]}}`),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailSuppression(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name: "RawComplaint",
+			body: []byte(`{"eventType": "Complaint", "complaint": {"complaintFeedbackType": "abuse",
+				"complainedRecipients": [{"emailAddress": "ada@example.com"}]}}`),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertEmailSuppression(gomock.Any(), gomock.Eq(db.UpsertEmailSuppressionParams{
+						Email:    "ada@example.com",
+						Reason:   EmailSuppressionComplaint,
+						Provider: EmailProviderSES,
+						Detail:   "abuse",
+					})).
+					Times(1).
+					Return(db.EmailSuppression{}, nil)
+			},
+			count: 1,
+		},
+		{
+			name: "SubscriptionToOtherHost",
+			body: []byte(`{"Type": "SubscriptionConfirmation", "SubscribeURL": "https://attacker.example.com/confirm"}`),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailSuppression(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkErr: func(err error) {
+				require.ErrorIs(t, err, ErrInvalidEmailFeedback)
+			},
+		},
+		{
+			name: "InvalidJSON",
+			body: []byte(`not json`),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertEmailSuppression(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkErr: func(err error) {
+				require.ErrorIs(t, err, ErrInvalidEmailFeedback)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			service, _ := newQueuedCapturingEmailService(t, store, nil)
+			tc.buildStubs(store)
+
+			count, err := service.HandleSESNotification(context.Background(), tc.body)
+			if tc.checkErr != nil {
+				tc.checkErr(err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.count, count)
+		})
+	}
+}
+
+func TestEmailService_Suppressions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	service, _ := newQueuedCapturingEmailService(t, store, nil)
+
+	store.EXPECT().
+		ListEmailSuppressionsByEmails(gomock.Any(), gomock.Eq([]string{"ada@example.com", "bob@example.com"})).
+		Times(1).
+		Return([]db.EmailSuppression{{Email: "ada@example.com", Reason: EmailSuppressionComplaint, Provider: EmailProviderSES}}, nil)
+
+	suppressions, err := service.Suppressions(context.Background(), []string{"Ada@Example.com", "bob@example.com"})
+	require.NoError(t, err)
+	require.Len(t, suppressions, 1)
+	require.Equal(t, EmailSuppressionComplaint, suppressions["ada@example.com"].Reason)
+
+	store.EXPECT().DeleteEmailSuppression(gomock.Any(), "bob@example.com").Times(1).Return(int64(0), nil)
+	err = service.LiftSuppression(context.Background(), "bob@example.com")
+	require.EqualError(t, err, "email address is not suppressed")
+
+	store.EXPECT().DeleteEmailSuppression(gomock.Any(), "ada@example.com").Times(1).Return(int64(1), sql.ErrConnDone)
+	err = service.LiftSuppression(context.Background(), "ada@example.com")
+	require.ErrorIs(t, err, sql.ErrConnDone)
+}
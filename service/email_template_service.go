@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// EmailTemplateService lets organization admins customize the emails their
+// organization sends and preview them before saving
+type EmailTemplateService struct {
+	store        db.EmailStore
+	emailService *EmailService
+}
+
+// NewEmailTemplateService creates a new email template service
+func NewEmailTemplateService(store db.EmailStore, emailService *EmailService) *EmailTemplateService {
+	return &EmailTemplateService{
+		store:        store,
+		emailService: emailService,
+	}
+}
+
+// EmailTemplateResponse is the template an organization uses for a kind of email
+type EmailTemplateResponse struct {
+	Kind     string `json:"kind"`
+	Subject  string `json:"subject"`
+	TextBody string `json:"text_body"`
+	HTMLBody string `json:"html_body"`
+	// Variables the template can use, e.g. {{.WorkspaceName}}
+	Variables []string `json:"variables"`
+	// Whether the organization replaced the built-in template
+	Custom    bool       `json:"custom"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateEmailTemplateRequest replaces the template of a kind of email
+type UpdateEmailTemplateRequest struct {
+	Subject  string `json:"subject" binding:"required,max=500"`
+	TextBody string `json:"text_body" binding:"required,max=20000"`
+	HTMLBody string `json:"html_body" binding:"required,max=100000"`
+}
+
+// PreviewEmailTemplateRequest previews a template before it is saved. Parts
+// left out are taken from the template the organization uses now.
+type PreviewEmailTemplateRequest struct {
+	Subject  string `json:"subject" binding:"max=500"`
+	TextBody string `json:"text_body" binding:"max=20000"`
+	HTMLBody string `json:"html_body" binding:"max=100000"`
+}
+
+// EmailPreviewResponse is an email rendered with sample data
+type EmailPreviewResponse struct {
+	Subject  string `json:"subject"`
+	TextBody string `json:"text_body"`
+	HTMLBody string `json:"html_body"`
+}
+
+// UpdateEmailBrandingRequest sets how an organization's emails look. Empty
+// fields fall back to the defaults.
+type UpdateEmailBrandingRequest struct {
+	ProductName  string `json:"product_name" binding:"max=100"`
+	LogoURL      string `json:"logo_url" binding:"omitempty,url,startswith=https://,max=2000"`
+	PrimaryColor string `json:"primary_color" binding:"omitempty,hexcolor,len=7"`
+	FooterText   string `json:"footer_text" binding:"max=500"`
+}
+
+// ListEmailTemplates returns the template the organization uses for each kind of email
+func (s *EmailTemplateService) ListEmailTemplates(ctx context.Context, organizationID int64) ([]EmailTemplateResponse, error) {
+	custom, err := s.store.ListEmailTemplates(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email templates: %w", err)
+	}
+
+	byKind := make(map[string]db.EmailTemplate, len(custom))
+	for _, template := range custom {
+		byKind[template.Kind] = template
+	}
+
+	names := emailKindNames()
+	responses := make([]EmailTemplateResponse, 0, len(names))
+	for _, name := range names {
+		if template, ok := byKind[name]; ok {
+			responses = append(responses, toEmailTemplateResponse(template))
+		} else {
+			responses = append(responses, builtinEmailTemplateResponse(emailKinds[name]))
+		}
+	}
+	return responses, nil
+}
+
+// GetEmailTemplate returns the template the organization uses for a kind of email
+func (s *EmailTemplateService) GetEmailTemplate(ctx context.Context, organizationID int64, kind string) (EmailTemplateResponse, error) {
+	emailKind, ok := emailKinds[kind]
+	if !ok {
+		return EmailTemplateResponse{}, errors.New("email kind not found")
+	}
+
+	template, err := s.store.GetEmailTemplate(ctx, db.GetEmailTemplateParams{
+		OrganizationID: organizationID,
+		Kind:           kind,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return builtinEmailTemplateResponse(emailKind), nil
+		}
+		return EmailTemplateResponse{}, fmt.Errorf("failed to get email template: %w", err)
+	}
+	return toEmailTemplateResponse(template), nil
+}
+
+// UpdateEmailTemplate replaces the organization's template for a kind of
+// email. The template is rendered with sample data first so templates that
+// use unknown variables are turned away.
+func (s *EmailTemplateService) UpdateEmailTemplate(ctx context.Context, organizationID, userID int64, kind string, req UpdateEmailTemplateRequest) (EmailTemplateResponse, error) {
+	emailKind, ok := emailKinds[kind]
+	if !ok {
+		return EmailTemplateResponse{}, errors.New("email kind not found")
+	}
+
+	if _, err := renderEmailSample(emailKind, req.Subject, req.TextBody, req.HTMLBody, defaultEmailBranding); err != nil {
+		return EmailTemplateResponse{}, err
+	}
+
+	template, err := s.store.UpsertEmailTemplate(ctx, db.UpsertEmailTemplateParams{
+		OrganizationID: organizationID,
+		Kind:           kind,
+		Subject:        req.Subject,
+		TextBody:       req.TextBody,
+		HtmlBody:       req.HTMLBody,
+		UpdatedBy:      sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		return EmailTemplateResponse{}, fmt.Errorf("failed to save email template: %w", err)
+	}
+	return toEmailTemplateResponse(template), nil
+}
+
+// ResetEmailTemplate goes back to the built-in template for a kind of email
+func (s *EmailTemplateService) ResetEmailTemplate(ctx context.Context, organizationID int64, kind string) (EmailTemplateResponse, error) {
+	emailKind, ok := emailKinds[kind]
+	if !ok {
+		return EmailTemplateResponse{}, errors.New("email kind not found")
+	}
+
+	_, err := s.store.DeleteEmailTemplate(ctx, db.DeleteEmailTemplateParams{
+		OrganizationID: organizationID,
+		Kind:           kind,
+	})
+	if err != nil {
+		return EmailTemplateResponse{}, fmt.Errorf("failed to reset email template: %w", err)
+	}
+	return builtinEmailTemplateResponse(emailKind), nil
+}
+
+// PreviewEmailTemplate renders a kind of email with sample data and the
+// organization's branding, using the parts of the template given in the
+// request in place of the saved ones
+func (s *EmailTemplateService) PreviewEmailTemplate(ctx context.Context, organizationID int64, kind string, req PreviewEmailTemplateRequest) (EmailPreviewResponse, error) {
+	current, err := s.GetEmailTemplate(ctx, organizationID, kind)
+	if err != nil {
+		return EmailPreviewResponse{}, err
+	}
+
+	if req.Subject != "" {
+		current.Subject = req.Subject
+	}
+	if req.TextBody != "" {
+		current.TextBody = req.TextBody
+	}
+	if req.HTMLBody != "" {
+		current.HTMLBody = req.HTMLBody
+	}
+
+	brand, err := s.emailService.branding(ctx, organizationID)
+	if err != nil {
+		return EmailPreviewResponse{}, err
+	}
+
+	message, err := renderEmailSample(emailKinds[kind], current.Subject, current.TextBody, current.HTMLBody, brand)
+	if err != nil {
+		return EmailPreviewResponse{}, err
+	}
+
+	return EmailPreviewResponse{
+		Subject:  message.Subject,
+		TextBody: message.TextBody,
+		HTMLBody: message.HTMLBody,
+	}, nil
+}
+
+// GetEmailBranding returns how the organization's emails look
+func (s *EmailTemplateService) GetEmailBranding(ctx context.Context, organizationID int64) (EmailBranding, error) {
+	return s.emailService.branding(ctx, organizationID)
+}
+
+// UpdateEmailBranding sets how the organization's emails look
+func (s *EmailTemplateService) UpdateEmailBranding(ctx context.Context, organizationID int64, req UpdateEmailBrandingRequest) (EmailBranding, error) {
+	_, err := s.store.UpsertEmailBranding(ctx, db.UpsertEmailBrandingParams{
+		OrganizationID: organizationID,
+		ProductName:    req.ProductName,
+		LogoUrl:        req.LogoURL,
+		PrimaryColor:   req.PrimaryColor,
+		FooterText:     req.FooterText,
+	})
+	if err != nil {
+		return EmailBranding{}, fmt.Errorf("failed to save email branding: %w", err)
+	}
+	return s.emailService.branding(ctx, organizationID)
+}
+
+// renderEmailSample renders a template for a kind of email with the kind's
+// sample data, reporting template mistakes as invalid email template errors
+func renderEmailSample(kind *emailKind, subject, text, html string, brand EmailBranding) (EmailMessage, error) {
+	template, err := parseEmailTemplate(kind.name, subject, text, html)
+	if err != nil {
+		return EmailMessage{}, fmt.Errorf("invalid email template: %w", err)
+	}
+
+	message, err := template.render("", kind.sample.withBrand(brand))
+	if err != nil {
+		return EmailMessage{}, fmt.Errorf("invalid email template: %w", err)
+	}
+	return message, nil
+}
+
+func toEmailTemplateResponse(template db.EmailTemplate) EmailTemplateResponse {
+	return EmailTemplateResponse{
+		Kind:      template.Kind,
+		Subject:   template.Subject,
+		TextBody:  template.TextBody,
+		HTMLBody:  template.HtmlBody,
+		Variables: emailKindVariables(emailKinds[template.Kind]),
+		Custom:    true,
+		UpdatedAt: &template.UpdatedAt,
+	}
+}
+
+func builtinEmailTemplateResponse(kind *emailKind) EmailTemplateResponse {
+	return EmailTemplateResponse{
+		Kind:      kind.name,
+		Subject:   kind.subject,
+		TextBody:  kind.text,
+		HTMLBody:  kind.html,
+		Variables: emailKindVariables(kind),
+	}
+}
+
+// emailKindVariables lists the variables a kind's templates can use
+func emailKindVariables(kind *emailKind) []string {
+	if kind == nil {
+		return brandVariables
+	}
+	variables := make([]string, 0, len(kind.variables)+len(brandVariables))
+	variables = append(variables, kind.variables...)
+	return append(variables, brandVariables...)
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	texttemplate "text/template"
+)
+
+// Kinds of email organizations can customize
+const (
+	EmailKindInvitation           = "invitation"
+	EmailKindVerification         = "verification"
+	EmailKindOrganizationDeletion = "organization_deletion"
+)
+
+// maxRenderedEmailSize caps how large a rendered subject or body can get, so
+// a customized template can't produce runaway output
+const maxRenderedEmailSize = 256 * 1024
+
+// EmailBranding is the product name, logo, colour and footer of an
+// organization's emails. Templates reach it as {{.Brand}}.
+type EmailBranding struct {
+	ProductName  string `json:"product_name"`
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color"`
+	FooterText   string `json:"footer_text,omitempty"`
+}
+
+// defaultEmailBranding is used for whatever an organization hasn't branded
+var defaultEmailBranding = EmailBranding{
+	ProductName:  "GoSlack",
+	PrimaryColor: "#4a154b",
+}
+
+// emailTemplateData is the data of one kind of email, which templates get
+// together with the sending organization's branding
+type emailTemplateData interface {
+	withBrand(brand EmailBranding) any
+}
+
+// emailTemplate renders the subject and bodies of one kind of email
+type emailTemplate struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// parseEmailTemplate parses the subject and bodies of a template
+func parseEmailTemplate(name, subject, text, html string) (*emailTemplate, error) {
+	subjectTemplate, err := texttemplate.New(name + "_subject").Parse(subject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subject template: %w", err)
+	}
+	textTemplate, err := texttemplate.New(name + "_text").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid text body template: %w", err)
+	}
+	htmlTemplate, err := htmltemplate.New(name + "_html").Parse(html)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTML body template: %w", err)
+	}
+
+	return &emailTemplate{subject: subjectTemplate, text: textTemplate, html: htmlTemplate}, nil
+}
+
+func newEmailTemplate(name, subject, text, html string) *emailTemplate {
+	template, err := parseEmailTemplate(name, subject, text, html)
+	if err != nil {
+		panic(err)
+	}
+	return template
+}
+
+// errRenderedEmailTooLarge is returned when a template renders more than
+// maxRenderedEmailSize
+var errRenderedEmailTooLarge = errors.New("rendered email is too large")
+
+// limitedBuffer is a buffer that refuses writes past maxRenderedEmailSize
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > maxRenderedEmailSize {
+		return 0, errRenderedEmailTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+// render fills the template in for a recipient
+func (t *emailTemplate) render(to string, data any) (EmailMessage, error) {
+	var subject, text, html limitedBuffer
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return EmailMessage{}, err
+	}
+	if err := t.text.Execute(&text, data); err != nil {
+		return EmailMessage{}, err
+	}
+	if err := t.html.Execute(&html, data); err != nil {
+		return EmailMessage{}, err
+	}
+
+	return EmailMessage{
+		To:       to,
+		Subject:  subject.String(),
+		TextBody: text.String(),
+		HTMLBody: html.String(),
+	}, nil
+}
+
+// emailKind is a kind of email with its built-in template, the variables its
+// templates can use, and sample data for previews
+type emailKind struct {
+	name      string
+	subject   string
+	text      string
+	html      string
+	variables []string
+	sample    emailTemplateData
+	builtin   *emailTemplate
+}
+
+// emailKinds are the kinds of email that have templates, by name
+var emailKinds = map[string]*emailKind{}
+
+func registerEmailKind(kind *emailKind) *emailKind {
+	kind.builtin = newEmailTemplate(kind.name, kind.subject, kind.text, kind.html)
+	emailKinds[kind.name] = kind
+	return kind
+}
+
+// emailKindNames lists the kinds of email with templates, sorted
+func emailKindNames() []string {
+	names := make([]string, 0, len(emailKinds))
+	for name := range emailKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// brandVariables are available in the templates of every kind of email
+var brandVariables = []string{"Brand.ProductName", "Brand.LogoURL", "Brand.PrimaryColor", "Brand.FooterText"}
+
+// invitationEmailData is passed to the invitation email template
+type invitationEmailData struct {
+	InviterName   string
+	WorkspaceName string
+	Role          string
+	Link          string
+	Code          string
+	ExpiresAt     string
+	Brand         EmailBranding
+}
+
+func (d invitationEmailData) withBrand(brand EmailBranding) any {
+	d.Brand = brand
+	return d
+}
+
+var invitationEmailKind = registerEmailKind(&emailKind{
+	name:    EmailKindInvitation,
+	subject: `{{.InviterName}} invited you to join {{.WorkspaceName}} on {{.Brand.ProductName}}`,
+	text: `Hi,
+
+{{.InviterName}} invited you to join the {{.WorkspaceName}} workspace on {{.Brand.ProductName}} as {{if eq .Role "admin"}}an admin{{else}}a member{{end}}.
+
+Accept the invitation: {{.Link}}
+
+Or join with the invitation code {{.Code}}. The invitation expires on {{.ExpiresAt}}.
+
+If you weren't expecting this invitation you can ignore this email.
+{{with .Brand.FooterText}}
+{{.}}
+{{end}}`,
+	html: `<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; color: #1d1c1d;">
+{{with .Brand.LogoURL}}<p><img src="{{.}}" alt="" style="max-height: 48px;"></p>
+{{end}}<p>Hi,</p>
+<p><strong>{{.InviterName}}</strong> invited you to join the <strong>{{.WorkspaceName}}</strong> workspace on {{.Brand.ProductName}} as {{if eq .Role "admin"}}an admin{{else}}a member{{end}}.</p>
+<p><a href="{{.Link}}" style="display: inline-block; padding: 10px 16px; background: {{.Brand.PrimaryColor}}; color: #ffffff; text-decoration: none; border-radius: 4px;">Accept invitation</a></p>
+<p>Or join with the invitation code <code>{{.Code}}</code>. The invitation expires on {{.ExpiresAt}}.</p>
+<p style="color: #616061;">If you weren't expecting this invitation you can ignore this email.</p>
+{{with .Brand.FooterText}}<p style="color: #616061; font-size: 12px;">{{.}}</p>
+{{end}}</body>
+</html>
+`,
+	variables: []string{"InviterName", "WorkspaceName", "Role", "Link", "Code", "ExpiresAt"},
+	sample: invitationEmailData{
+		InviterName:   "Ada Lovelace",
+		WorkspaceName: "Engineering",
+		Role:          "member",
+		Link:          "https://example.com/join?code=SAMPLECODE",
+		Code:          "SAMPLECODE",
+		ExpiresAt:     "January 2, 2006 at 15:04 UTC",
+	},
+})
+
+// verificationEmailData is passed to the email verification template
+type verificationEmailData struct {
+	FirstName string
+	Link      string
+	ExpiresAt string
+	Brand     EmailBranding
+}
+
+func (d verificationEmailData) withBrand(brand EmailBranding) any {
+	d.Brand = brand
+	return d
+}
+
+var verificationEmailKind = registerEmailKind(&emailKind{
+	name:    EmailKindVerification,
+	subject: `Verify your email address for {{.Brand.ProductName}}`,
+	text: `Hi {{.FirstName}},
+
+Confirm that this is your email address: {{.Link}}
+
+The link expires on {{.ExpiresAt}}.
+
+If you didn't create a {{.Brand.ProductName}} account you can ignore this email.
+{{with .Brand.FooterText}}
+{{.}}
+{{end}}`,
+	html: `<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; color: #1d1c1d;">
+{{with .Brand.LogoURL}}<p><img src="{{.}}" alt="" style="max-height: 48px;"></p>
+{{end}}<p>Hi {{.FirstName}},</p>
+<p>Confirm that this is your email address.</p>
+<p><a href="{{.Link}}" style="display: inline-block; padding: 10px 16px; background: {{.Brand.PrimaryColor}}; color: #ffffff; text-decoration: none; border-radius: 4px;">Verify email address</a></p>
+<p>The link expires on {{.ExpiresAt}}.</p>
+<p style="color: #616061;">If you didn't create a {{.Brand.ProductName}} account you can ignore this email.</p>
+{{with .Brand.FooterText}}<p style="color: #616061; font-size: 12px;">{{.}}</p>
+{{end}}</body>
+</html>
+`,
+	variables: []string{"FirstName", "Link", "ExpiresAt"},
+	sample: verificationEmailData{
+		FirstName: "Ada",
+		Link:      "https://example.com/verify-email?token=SAMPLETOKEN",
+		ExpiresAt: "January 2, 2006 at 15:04 UTC",
+	},
+})
+
+// organizationDeletionEmailData is passed to the template that tells an
+// organization's owners and admins its deletion was requested
+type organizationDeletionEmailData struct {
+	OrganizationName string
+	RequesterName    string
+	ScheduledFor     string
+	Link             string
+	Brand            EmailBranding
+}
+
+func (d organizationDeletionEmailData) withBrand(brand EmailBranding) any {
+	d.Brand = brand
+	return d
+}
+
+var organizationDeletionEmailKind = registerEmailKind(&emailKind{
+	name:    EmailKindOrganizationDeletion,
+	subject: `{{.OrganizationName}} is scheduled for deletion on {{.Brand.ProductName}}`,
+	text: `Hi,
+
+{{.RequesterName}} asked to delete the {{.OrganizationName}} organization on {{.Brand.ProductName}}. Its workspaces can no longer be used.
+
+Everything in the organization will be permanently deleted on {{.ScheduledFor}}. Until then an owner can cancel the deletion: {{.Link}}
+{{with .Brand.FooterText}}
+{{.}}
+{{end}}`,
+	html: `<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; color: #1d1c1d;">
+{{with .Brand.LogoURL}}<p><img src="{{.}}" alt="" style="max-height: 48px;"></p>
+{{end}}<p>Hi,</p>
+<p><strong>{{.RequesterName}}</strong> asked to delete the <strong>{{.OrganizationName}}</strong> organization on {{.Brand.ProductName}}. Its workspaces can no longer be used.</p>
+<p>Everything in the organization will be permanently deleted on {{.ScheduledFor}}. Until then an owner can cancel the deletion.</p>
+<p><a href="{{.Link}}" style="display: inline-block; padding: 10px 16px; background: {{.Brand.PrimaryColor}}; color: #ffffff; text-decoration: none; border-radius: 4px;">Review deletion</a></p>
+{{with .Brand.FooterText}}<p style="color: #616061; font-size: 12px;">{{.}}</p>
+{{end}}</body>
+</html>
+`,
+	variables: []string{"OrganizationName", "RequesterName", "ScheduledFor", "Link"},
+	sample: organizationDeletionEmailData{
+		OrganizationName: "Acme",
+		RequesterName:    "Ada Lovelace",
+		ScheduledFor:     "January 2, 2006 at 15:04 UTC",
+		Link:             "https://example.com/organizations/1/deletion",
+	},
+})
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// emailVerificationTTL is how long an email verification link can be used
+const emailVerificationTTL = 24 * time.Hour
+
+// EmailVerificationService confirms that users own their email address
+type EmailVerificationService struct {
+	store        db.UserStore
+	emailService *EmailService
+	appBaseURL   string
+}
+
+// NewEmailVerificationService creates a new email verification service
+func NewEmailVerificationService(store db.UserStore, emailService *EmailService, config util.Config) *EmailVerificationService {
+	return &EmailVerificationService{
+		store:        store,
+		emailService: emailService,
+		appBaseURL:   config.AppBaseURL,
+	}
+}
+
+// VerifyEmailRequest represents the request to verify an email address
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// SendVerificationEmail emails a user a link that verifies their current email address
+func (s *EmailVerificationService) SendVerificationEmail(ctx context.Context, userID int64) error {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("user not found")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.EmailVerifiedAt.Valid {
+		return errors.New("email is already verified")
+	}
+
+	token, err := generateLinkToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	verification, err := s.store.CreateEmailVerification(ctx, db.CreateEmailVerificationParams{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Token:     token,
+		ExpiresAt: time.Now().Add(emailVerificationTTL),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create email verification: %w", err)
+	}
+
+	message, err := s.emailService.Render(ctx, user.OrganizationID, EmailKindVerification, user.Email, verificationEmailData{
+		FirstName: user.FirstName,
+		Link:      s.appBaseURL + "/verify-email?token=" + url.QueryEscape(verification.Token),
+		ExpiresAt: verification.ExpiresAt.UTC().Format("January 2, 2006 at 15:04 MST"),
+	})
+	if err != nil {
+		return err
+	}
+	message.ReferenceID = verification.ID
+
+	return s.emailService.Send(ctx, message)
+}
+
+// VerifyEmail marks the email address a verification link was sent to as verified
+func (s *EmailVerificationService) VerifyEmail(ctx context.Context, token string) error {
+	_, err := s.store.VerifyEmailTx(ctx, token)
+	if err != nil {
+		// Used, expired, or the user changed their email since
+		if err == sql.ErrNoRows {
+			return errors.New("invalid or expired verification token")
+		}
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	return nil
+}
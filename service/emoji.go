@@ -0,0 +1,149 @@
+package service
+
+import (
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+)
+
+// customEmojiNamePattern matches the names of custom emojis, which reactions
+// use as :name:
+var customEmojiNamePattern = regexp.MustCompile(`^[a-z0-9_+-]{1,62}$`)
+
+// pictographs covers the code points that are emojis by themselves or with
+// an emoji presentation selector, following Unicode's Extended_Pictographic
+// property
+var pictographs = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00a9, Hi: 0x00a9, Stride: 1},
+		{Lo: 0x00ae, Hi: 0x00ae, Stride: 1},
+		{Lo: 0x203c, Hi: 0x203c, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21a9, Hi: 0x21aa, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x23cf, Hi: 0x23cf, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23f3, Stride: 1},
+		{Lo: 0x23f8, Hi: 0x23fa, Stride: 1},
+		{Lo: 0x24c2, Hi: 0x24c2, Stride: 1},
+		{Lo: 0x25aa, Hi: 0x25ab, Stride: 1},
+		{Lo: 0x25b6, Hi: 0x25b6, Stride: 1},
+		{Lo: 0x25c0, Hi: 0x25c0, Stride: 1},
+		{Lo: 0x25fb, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2b05, Hi: 0x2b07, Stride: 1},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b50, Stride: 1},
+		{Lo: 0x2b55, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303d, Hi: 0x303d, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1f1e5, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f3fa, Stride: 1},
+		{Lo: 0x1f400, Hi: 0x1faff, Stride: 1},
+	},
+}
+
+const (
+	zeroWidthJoiner    = '\u200d'
+	emojiPresentation  = '\ufe0f'
+	combiningKeycap    = '\u20e3'
+	tagCancel          = '\U000e007f'
+	regionalIndicatorA = '\U0001f1e6'
+	regionalIndicatorZ = '\U0001f1ff'
+	skinToneLight      = '\U0001f3fb'
+	skinToneDark       = '\U0001f3ff'
+)
+
+// isUnicodeEmoji reports whether s is exactly one emoji: a pictograph with
+// optional presentation selector, skin tone and tags, several of them joined
+// with zero width joiners, a flag or a keycap
+func isUnicodeEmoji(s string) bool {
+	if s == "" || !utf8.ValidString(s) {
+		return false
+	}
+
+	runes := []rune(s)
+
+	// Flags are a pair of regional indicators
+	if len(runes) == 2 && isRegionalIndicator(runes[0]) && isRegionalIndicator(runes[1]) {
+		return true
+	}
+
+	// Keycaps are a digit, # or * followed by the combining keycap
+	if isKeycapBase(runes[0]) {
+		rest := runes[1:]
+		if len(rest) > 0 && rest[0] == emojiPresentation {
+			rest = rest[1:]
+		}
+		return len(rest) == 1 && rest[0] == combiningKeycap
+	}
+
+	for i := 0; i < len(runes); {
+		if !unicode.Is(pictographs, runes[i]) {
+			return false
+		}
+		i++
+
+		if i < len(runes) && runes[i] == emojiPresentation {
+			i++
+		}
+		if i < len(runes) && runes[i] >= skinToneLight && runes[i] <= skinToneDark {
+			i++
+		}
+
+		// Tag sequences spell out subdivision flags such as Scotland's
+		if i < len(runes) && isTag(runes[i]) {
+			for i < len(runes) && isTag(runes[i]) && runes[i] != tagCancel {
+				i++
+			}
+			if i == len(runes) || runes[i] != tagCancel {
+				return false
+			}
+			i++
+		}
+
+		if i == len(runes) {
+			return true
+		}
+		if runes[i] != zeroWidthJoiner || i == len(runes)-1 {
+			return false
+		}
+		i++
+	}
+
+	return false
+}
+
+// customEmojiName returns the name of a custom emoji reference such as
+// :party-parrot:
+func customEmojiName(emoji string) (string, bool) {
+	if len(emoji) < 3 || emoji[0] != ':' || emoji[len(emoji)-1] != ':' {
+		return "", false
+	}
+
+	name := emoji[1 : len(emoji)-1]
+	if !customEmojiNamePattern.MatchString(name) {
+		return "", false
+	}
+	return name, true
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicatorA && r <= regionalIndicatorZ
+}
+
+func isKeycapBase(r rune) bool {
+	return (r >= '0' && r <= '9') || r == '#' || r == '*'
+}
+
+func isTag(r rune) bool {
+	return r >= 0xe0020 && r <= tagCancel
+}
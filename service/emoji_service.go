@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// customEmojiImageTypes are the image types custom emojis can be made from
+var customEmojiImageTypes = map[string]bool{
+	"image/png":  true,
+	"image/gif":  true,
+	"image/jpeg": true,
+	"image/webp": true,
+}
+
+// emojiServiceStore covers custom emoji, their image files and workspace permissions
+type emojiServiceStore interface {
+	db.FileStore
+	db.WorkspaceStore
+}
+
+// EmojiService manages workspace custom emojis. Any workspace member can add
+// one from a public image they uploaded.
+type EmojiService struct {
+	store emojiServiceStore
+}
+
+// NewEmojiService creates a new emoji service
+func NewEmojiService(store emojiServiceStore) *EmojiService {
+	return &EmojiService{
+		store: store,
+	}
+}
+
+// CreateCustomEmoji adds a custom emoji to a workspace
+func (s *EmojiService) CreateCustomEmoji(ctx context.Context, workspaceID, userID int64, req CreateCustomEmojiRequest) (*CustomEmojiResponse, error) {
+	if !customEmojiNamePattern.MatchString(req.Name) {
+		return nil, errors.New("invalid emoji name: use 1-62 lowercase letters, digits, _, + or -")
+	}
+
+	file, err := s.store.GetFile(ctx, req.FileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("file not found")
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	if file.WorkspaceID != workspaceID || file.UploaderID != userID || !file.UploadCompleted {
+		return nil, errors.New("file not found")
+	}
+	if !customEmojiImageTypes[file.MimeType] {
+		return nil, fmt.Errorf("invalid emoji image: %s files can't be used as emojis", file.MimeType)
+	}
+	// Everyone in the workspace has to be able to load the image
+	if !file.IsPublic {
+		return nil, errors.New("invalid emoji image: upload the image as a public file")
+	}
+
+	emoji, err := s.store.CreateCustomEmoji(ctx, db.CreateCustomEmojiParams{
+		WorkspaceID: workspaceID,
+		Name:        req.Name,
+		FileID:      file.ID,
+		CreatedBy:   sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, errors.New("custom emoji already exists")
+		}
+		return nil, fmt.Errorf("failed to create custom emoji: %w", err)
+	}
+
+	return toCustomEmojiResponse(emoji), nil
+}
+
+// ListCustomEmojis lists a workspace's custom emojis by name
+func (s *EmojiService) ListCustomEmojis(ctx context.Context, workspaceID int64) ([]*CustomEmojiResponse, error) {
+	emojis, err := s.store.ListCustomEmojis(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom emojis: %w", err)
+	}
+
+	responses := make([]*CustomEmojiResponse, len(emojis))
+	for i, emoji := range emojis {
+		responses[i] = toCustomEmojiResponse(emoji)
+	}
+
+	return responses, nil
+}
+
+// DeleteCustomEmoji removes a custom emoji. Its creator and users who can
+// manage the workspace may delete it. Existing reactions with it are kept,
+// or move to another emoji when a replacement is given.
+func (s *EmojiService) DeleteCustomEmoji(ctx context.Context, workspaceID, emojiID, userID int64, req DeleteCustomEmojiRequest) (*DeleteCustomEmojiResponse, error) {
+	emoji, err := s.store.GetCustomEmoji(ctx, db.GetCustomEmojiParams{
+		ID:          emojiID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("custom emoji not found")
+		}
+		return nil, fmt.Errorf("failed to get custom emoji: %w", err)
+	}
+
+	if !emoji.CreatedBy.Valid || emoji.CreatedBy.Int64 != userID {
+		allowed, err := hasWorkspacePermission(ctx, s.store, userID, workspaceID, PermissionManageWorkspace)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, errors.New("access denied: only the emoji's creator or a workspace admin can delete it")
+		}
+	}
+
+	reaction := ":" + emoji.Name + ":"
+	if req.ReplaceWith != "" {
+		if req.ReplaceWith == reaction {
+			return nil, errors.New("invalid replacement: an emoji can't replace itself")
+		}
+		if err := s.validateReplacement(ctx, workspaceID, req.ReplaceWith); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := s.store.DeleteCustomEmojiTx(ctx, db.DeleteCustomEmojiTxParams{
+		ID:          emoji.ID,
+		WorkspaceID: workspaceID,
+		Emoji:       reaction,
+		Replacement: req.ReplaceWith,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete custom emoji: %w", err)
+	}
+
+	return &DeleteCustomEmojiResponse{ReactionsMigrated: result.ReactionsMigrated}, nil
+}
+
+// validateReplacement checks that reactions can move to an emoji: a single
+// Unicode emoji or another of the workspace's custom emojis
+func (s *EmojiService) validateReplacement(ctx context.Context, workspaceID int64, emoji string) error {
+	if isUnicodeEmoji(emoji) {
+		return nil
+	}
+
+	name, ok := customEmojiName(emoji)
+	if !ok {
+		return errors.New("invalid replacement: use a single emoji or a custom emoji such as :name:")
+	}
+
+	exists, err := s.store.CustomEmojiExists(ctx, db.CustomEmojiExistsParams{
+		WorkspaceID: workspaceID,
+		Name:        name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check custom emoji: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("invalid replacement: the workspace has no custom emoji named %s", name)
+	}
+
+	return nil
+}
+
+func toCustomEmojiResponse(emoji db.CustomEmoji) *CustomEmojiResponse {
+	response := &CustomEmojiResponse{
+		ID:          emoji.ID,
+		WorkspaceID: emoji.WorkspaceID,
+		Name:        emoji.Name,
+		FileID:      emoji.FileID,
+		ImageURL:    fmt.Sprintf("/api/files/%d/download", emoji.FileID),
+		CreatedAt:   emoji.CreatedAt,
+	}
+	if emoji.CreatedBy.Valid {
+		response.CreatedBy = &emoji.CreatedBy.Int64
+	}
+	return response
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmojiService_DeleteCustomEmojiReplacement(t *testing.T) {
+	const workspaceID, creatorID = int64(3), int64(7)
+	ctx := context.Background()
+	emoji := db.CustomEmoji{
+		ID:          12,
+		WorkspaceID: workspaceID,
+		Name:        "party-parrot",
+		CreatedBy:   sql.NullInt64{Int64: creatorID, Valid: true},
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetCustomEmoji(gomock.Any(), db.GetCustomEmojiParams{ID: emoji.ID, WorkspaceID: workspaceID}).
+		AnyTimes().
+		Return(emoji, nil)
+	emojiService := NewEmojiService(store)
+
+	// Replacements must be emojis members can react with
+	for replacement, message := range map[string]string{
+		":party-parrot:": "invalid replacement: an emoji can't replace itself",
+		"parrot":         "invalid replacement: use a single emoji or a custom emoji such as :name:",
+		":dancing-cat:":  "invalid replacement: the workspace has no custom emoji named dancing-cat",
+	} {
+		store.EXPECT().
+			CustomEmojiExists(gomock.Any(), db.CustomEmojiExistsParams{WorkspaceID: workspaceID, Name: "dancing-cat"}).
+			MaxTimes(1).
+			Return(false, nil)
+
+		_, err := emojiService.DeleteCustomEmoji(ctx, workspaceID, emoji.ID, creatorID, DeleteCustomEmojiRequest{ReplaceWith: replacement})
+		require.EqualError(t, err, message, replacement)
+	}
+
+	// Reactions move to the replacement as the emoji is deleted
+	store.EXPECT().
+		DeleteCustomEmojiTx(gomock.Any(), db.DeleteCustomEmojiTxParams{
+			ID:          emoji.ID,
+			WorkspaceID: workspaceID,
+			Emoji:       ":party-parrot:",
+			Replacement: "🦜",
+		}).
+		Times(1).
+		Return(db.DeleteCustomEmojiTxResult{ReactionsMigrated: 40}, nil)
+
+	response, err := emojiService.DeleteCustomEmoji(ctx, workspaceID, emoji.ID, creatorID, DeleteCustomEmojiRequest{ReplaceWith: "🦜"})
+	require.NoError(t, err)
+	require.Equal(t, int64(40), response.ReactionsMigrated)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUnicodeEmoji(t *testing.T) {
+	for _, emoji := range []string{
+		"👍",
+		"👍🏽",
+		"❤️",
+		"🇩🇪",
+		"1️⃣",
+		"#⃣",
+		"👩‍💻",
+		"👨‍👩‍👧‍👦",
+		"🏴\U000e0067\U000e0062\U000e0073\U000e0063\U000e0074\U000e007f",
+	} {
+		require.True(t, isUnicodeEmoji(emoji), emoji)
+	}
+
+	for _, emoji := range []string{
+		"",
+		"a",
+		"+1",
+		":+1:",
+		"👍👍",
+		"👍 ",
+		"🇩",
+		"12⃣",
+		"👩‍",
+		"\U000e0067\U000e007f",
+		"🏴\U000e0067",
+	} {
+		require.False(t, isUnicodeEmoji(emoji), emoji)
+	}
+}
+
+func TestCustomEmojiName(t *testing.T) {
+	name, ok := customEmojiName(":party-parrot:")
+	require.True(t, ok)
+	require.Equal(t, "party-parrot", name)
+
+	for _, emoji := range []string{"party-parrot", "::", ":Party:", ":a b:", ":party"} {
+		_, ok := customEmojiName(emoji)
+		require.False(t, ok, emoji)
+	}
+}
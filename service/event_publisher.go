@@ -0,0 +1,125 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+)
+
+// Event publishers
+const (
+	EventPublisherKafka = "kafka"
+
+	kafkaJSONContentType = "application/vnd.kafka.json.v2+json"
+
+	// Upper bound on the size of a Kafka REST Proxy response
+	maxKafkaResponseSize = 1 << 20
+)
+
+// EventPublisher sends workspace events to a message broker
+type EventPublisher interface {
+	// Name identifies the publisher in logs
+	Name() string
+	// Publish sends events in order, failing unless all of them were accepted
+	Publish(ctx context.Context, events []WorkspaceEvent) error
+}
+
+// NewEventPublisher creates the event publisher set in the configuration.
+// It returns nil when no publisher is configured.
+func NewEventPublisher(config util.Config) (EventPublisher, error) {
+	switch config.EventPublisher {
+	case "":
+		return nil, nil
+	case EventPublisherKafka:
+		if config.KafkaRESTURL == "" || config.KafkaTopic == "" {
+			return nil, errors.New("invalid event publisher configuration: KAFKA_REST_URL and KAFKA_TOPIC are required")
+		}
+		endpoint, err := url.Parse(config.KafkaRESTURL)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return nil, errors.New("invalid event publisher configuration: KAFKA_REST_URL must be an http(s) URL")
+		}
+		return &kafkaPublisher{
+			topicURL: strings.TrimSuffix(config.KafkaRESTURL, "/") + "/topics/" + url.PathEscape(config.KafkaTopic),
+			client:   &http.Client{Timeout: 30 * time.Second},
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid event publisher configuration: unknown publisher %q", config.EventPublisher)
+	}
+}
+
+// kafkaPublisher produces events to a Kafka topic through a Confluent REST
+// Proxy. Events are keyed by workspace so each workspace's events stay in
+// order on one partition.
+type kafkaPublisher struct {
+	topicURL string
+	client   *http.Client
+}
+
+func (p *kafkaPublisher) Name() string {
+	return EventPublisherKafka
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, events []WorkspaceEvent) error {
+	type record struct {
+		Key   string         `json:"key"`
+		Value WorkspaceEvent `json:"value"`
+	}
+	records := make([]record, len(events))
+	for i, event := range events {
+		records[i] = record{Key: strconv.FormatInt(event.WorkspaceID, 10), Value: event}
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.topicURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", kafkaJSONContentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json, application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		// The URL can carry proxy credentials, so only the underlying error is reported
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kafka rest proxy returned %s", resp.Status)
+	}
+
+	// The proxy answers 200 even when some records fail, with an error per offset
+	var result struct {
+		Offsets []struct {
+			ErrorCode *int   `json:"error_code"`
+			Error     string `json:"error"`
+		} `json:"offsets"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxKafkaResponseSize)).Decode(&result); err != nil {
+		return fmt.Errorf("invalid response from kafka rest proxy: %w", err)
+	}
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return fmt.Errorf("kafka rejected an event: %s", offset.Error)
+		}
+	}
+	return nil
+}
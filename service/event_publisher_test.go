@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventPublisher(t *testing.T) {
+	publisher, err := NewEventPublisher(util.Config{})
+	require.NoError(t, err)
+	require.Nil(t, publisher)
+
+	_, err = NewEventPublisher(util.Config{EventPublisher: EventPublisherKafka, KafkaTopic: "events"})
+	require.Error(t, err)
+
+	_, err = NewEventPublisher(util.Config{EventPublisher: EventPublisherKafka, KafkaRESTURL: "kafka:9092", KafkaTopic: "events"})
+	require.Error(t, err)
+
+	_, err = NewEventPublisher(util.Config{EventPublisher: "kinesis"})
+	require.Error(t, err)
+
+	publisher, err = NewEventPublisher(util.Config{EventPublisher: EventPublisherKafka, KafkaRESTURL: "http://proxy:8082/", KafkaTopic: "goslack.events"})
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy:8082/topics/goslack.events", publisher.(*kafkaPublisher).topicURL)
+}
+
+func TestKafkaEventPublisher(t *testing.T) {
+	events := []WorkspaceEvent{
+		{ID: 1, Type: WorkspaceEventMemberJoined, WorkspaceID: 2, Data: json.RawMessage(`{"user_id":5}`)},
+		{ID: 2, Type: WorkspaceEventFileUploaded, WorkspaceID: 3, Data: json.RawMessage(`{"file_id":9}`)},
+	}
+
+	newPublisher := func(t *testing.T, response string) EventPublisher {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/topics/workspace-events", r.URL.Path)
+			require.Equal(t, kafkaJSONContentType, r.Header.Get("Content-Type"))
+
+			var body struct {
+				Records []struct {
+					Key   string         `json:"key"`
+					Value WorkspaceEvent `json:"value"`
+				} `json:"records"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Len(t, body.Records, 2)
+			require.Equal(t, "2", body.Records[0].Key)
+			require.Equal(t, WorkspaceEventFileUploaded, body.Records[1].Value.Type)
+
+			w.Write([]byte(response))
+		}))
+		t.Cleanup(server.Close)
+
+		publisher, err := NewEventPublisher(util.Config{EventPublisher: EventPublisherKafka, KafkaRESTURL: server.URL, KafkaTopic: "workspace-events"})
+		require.NoError(t, err)
+		return publisher
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		publisher := newPublisher(t, `{"offsets":[{"partition":0,"offset":10},{"partition":1,"offset":4}]}`)
+		require.NoError(t, publisher.Publish(context.Background(), events))
+	})
+
+	t.Run("RecordRejected", func(t *testing.T) {
+		publisher := newPublisher(t, `{"offsets":[{"partition":0,"offset":10},{"error_code":50002,"error":"Kafka error"}]}`)
+		require.EqualError(t, publisher.Publish(context.Background(), events), "kafka rejected an event: Kafka error")
+	})
+}
@@ -0,0 +1,445 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// External DM policies an organization can choose
+const (
+	ExternalDMPolicyOpen       = "open"
+	ExternalDMPolicyRestricted = "restricted"
+	ExternalDMPolicyDisabled   = "disabled"
+)
+
+// External DM request statuses
+const (
+	ExternalDMStatusPending  = "pending"
+	ExternalDMStatusAccepted = "accepted"
+	ExternalDMStatusDeclined = "declined"
+	ExternalDMStatusClosed   = "closed"
+)
+
+// External DM WebSocket message types. New requests are sent to their
+// recipient, changes to both people.
+const (
+	WSExternalDMRequest        = "external_dm_request"
+	WSExternalDMRequestUpdated = "external_dm_request_updated"
+)
+
+// externalDMServiceStore covers external conversations, their messages and the users in them
+type externalDMServiceStore interface {
+	db.MessageStore
+	db.UserStore
+}
+
+// ExternalDMService handles direct conversations between people in different
+// organizations. A user asks someone by email, and once they accept the two
+// can exchange text messages, kept in the workspace the request was sent
+// from. Either organization's policy can disable such conversations or
+// restrict them to some email domains.
+type ExternalDMService struct {
+	store          externalDMServiceStore
+	messageService *MessageService
+	hub            WebSocketHub
+}
+
+// NewExternalDMService creates a new external DM service
+func NewExternalDMService(store externalDMServiceStore, messageService *MessageService, hub WebSocketHub) *ExternalDMService {
+	return &ExternalDMService{
+		store:          store,
+		messageService: messageService,
+		hub:            hub,
+	}
+}
+
+// GetPolicy gets an organization's external DM policy. Organizations that
+// never set one are open.
+func (s *ExternalDMService) GetPolicy(ctx context.Context, organizationID int64) (ExternalDMPolicyResponse, error) {
+	policy, err := s.getPolicy(ctx, organizationID)
+	if err != nil {
+		return ExternalDMPolicyResponse{}, err
+	}
+	return toExternalDMPolicyResponse(policy), nil
+}
+
+// UpdatePolicy sets whether an organization's members can exchange direct
+// messages with people in other organizations
+func (s *ExternalDMService) UpdatePolicy(ctx context.Context, organizationID, userID int64, req UpdateExternalDMPolicyRequest) (ExternalDMPolicyResponse, error) {
+	domains := make([]string, 0, len(req.AllowedDomains))
+	for _, domain := range req.AllowedDomains {
+		domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+		if domain == "" || !strings.Contains(domain, ".") || strings.ContainsAny(domain, " @") {
+			return ExternalDMPolicyResponse{}, fmt.Errorf("invalid domain: %q", domain)
+		}
+		if !slices.Contains(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+	if req.Policy == ExternalDMPolicyRestricted && len(domains) == 0 {
+		return ExternalDMPolicyResponse{}, errors.New("invalid policy: a restricted policy needs at least one allowed domain")
+	}
+
+	policy, err := s.store.UpsertExternalDMPolicy(ctx, db.UpsertExternalDMPolicyParams{
+		OrganizationID: organizationID,
+		Policy:         req.Policy,
+		AllowedDomains: domains,
+		UpdatedBy:      sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		return ExternalDMPolicyResponse{}, fmt.Errorf("failed to save external DM policy: %w", err)
+	}
+
+	return toExternalDMPolicyResponse(policy), nil
+}
+
+// SendRequest asks someone in another organization, found by email, for a
+// direct conversation. The conversation is kept in the sender's workspace.
+func (s *ExternalDMService) SendRequest(ctx context.Context, workspaceID int64, sender UserResponse, req CreateExternalDMRequestRequest) (ExternalDMRequestResponse, error) {
+	recipient, err := s.store.GetUserByEmail(ctx, strings.TrimSpace(req.Email))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ExternalDMRequestResponse{}, errors.New("recipient not found")
+		}
+		return ExternalDMRequestResponse{}, fmt.Errorf("failed to get recipient: %w", err)
+	}
+	if recipient.OrganizationID == sender.OrganizationID {
+		return ExternalDMRequestResponse{}, errors.New("invalid recipient: people in your organization can be sent direct messages")
+	}
+
+	if err := s.checkPolicies(ctx, sender.OrganizationID, sender.Email, recipient.OrganizationID, recipient.Email); err != nil {
+		return ExternalDMRequestResponse{}, err
+	}
+
+	request, err := s.store.CreateExternalDMRequest(ctx, db.CreateExternalDMRequestParams{
+		SenderID:    sender.ID,
+		WorkspaceID: workspaceID,
+		RecipientID: recipient.ID,
+		Message:     strings.TrimSpace(req.Message),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ExternalDMRequestResponse{}, errors.New("external DM request already exists")
+		}
+		return ExternalDMRequestResponse{}, fmt.Errorf("failed to create external DM request: %w", err)
+	}
+
+	response := ExternalDMRequestResponse{
+		ID:          request.ID,
+		WorkspaceID: request.WorkspaceID,
+		Sender: ExternalDMUserResponse{
+			ID:             sender.ID,
+			OrganizationID: sender.OrganizationID,
+			Email:          sender.Email,
+			FirstName:      sender.FirstName,
+			LastName:       sender.LastName,
+		},
+		Recipient: ExternalDMUserResponse{
+			ID:             recipient.ID,
+			OrganizationID: recipient.OrganizationID,
+			Email:          recipient.Email,
+			FirstName:      recipient.FirstName,
+			LastName:       recipient.LastName,
+		},
+		Message:   request.Message,
+		Status:    request.Status,
+		CreatedAt: request.CreatedAt,
+	}
+
+	incoming := response
+	incoming.Incoming = true
+	s.notify(WSExternalDMRequest, workspaceID, sender.ID, recipient.ID, incoming)
+
+	return response, nil
+}
+
+// ListRequests lists the external DM requests a user sent or received,
+// newest first. An empty status lists them all.
+func (s *ExternalDMService) ListRequests(ctx context.Context, userID int64, status string, limit, offset int32) ([]ExternalDMRequestResponse, error) {
+	rows, err := s.store.ListExternalDMRequests(ctx, db.ListExternalDMRequestsParams{
+		UserID: userID,
+		Status: status,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list external DM requests: %w", err)
+	}
+
+	responses := make([]ExternalDMRequestResponse, len(rows))
+	for i, row := range rows {
+		responses[i] = toExternalDMRequestResponse(db.GetExternalDMRequestRow(row), userID)
+	}
+	return responses, nil
+}
+
+// AcceptRequest opens the conversation a request asked for. Only its
+// recipient can accept it, and only while both organizations allow it.
+func (s *ExternalDMService) AcceptRequest(ctx context.Context, requestID, userID int64) (ExternalDMRequestResponse, error) {
+	return s.respond(ctx, requestID, userID, ExternalDMStatusAccepted)
+}
+
+// DeclineRequest turns a request down. Only its recipient can decline it.
+func (s *ExternalDMService) DeclineRequest(ctx context.Context, requestID, userID int64) (ExternalDMRequestResponse, error) {
+	return s.respond(ctx, requestID, userID, ExternalDMStatusDeclined)
+}
+
+func (s *ExternalDMService) respond(ctx context.Context, requestID, userID int64, status string) (ExternalDMRequestResponse, error) {
+	request, err := s.getRequest(ctx, requestID, userID)
+	if err != nil {
+		return ExternalDMRequestResponse{}, err
+	}
+	if request.RecipientID != userID {
+		return ExternalDMRequestResponse{}, errors.New("access denied: only the recipient can respond to an external DM request")
+	}
+
+	if status == ExternalDMStatusAccepted {
+		if err := s.checkRequestPolicies(ctx, request); err != nil {
+			return ExternalDMRequestResponse{}, err
+		}
+	}
+
+	updated, err := s.store.RespondExternalDMRequest(ctx, db.RespondExternalDMRequestParams{
+		ID:     request.ID,
+		Status: status,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ExternalDMRequestResponse{}, errors.New("invalid request: external DM request is no longer pending")
+		}
+		return ExternalDMRequestResponse{}, fmt.Errorf("failed to respond to external DM request: %w", err)
+	}
+
+	return s.updated(request, updated, userID), nil
+}
+
+// CloseRequest withdraws a pending request or ends a conversation. Either
+// person can close it; its messages are kept.
+func (s *ExternalDMService) CloseRequest(ctx context.Context, requestID, userID int64) (ExternalDMRequestResponse, error) {
+	request, err := s.getRequest(ctx, requestID, userID)
+	if err != nil {
+		return ExternalDMRequestResponse{}, err
+	}
+
+	updated, err := s.store.CloseExternalDMRequest(ctx, request.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ExternalDMRequestResponse{}, errors.New("invalid request: external DM request is already closed")
+		}
+		return ExternalDMRequestResponse{}, fmt.Errorf("failed to close external DM request: %w", err)
+	}
+
+	return s.updated(request, updated, userID), nil
+}
+
+// SendMessage sends a text message in an accepted conversation. Conversations
+// between organizations can't share files or mention anyone, and stop taking
+// messages when either organization's policy no longer allows them.
+func (s *ExternalDMService) SendMessage(ctx context.Context, requestID, userID int64, content string) (*MessageResponse, error) {
+	request, err := s.getRequest(ctx, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != ExternalDMStatusAccepted {
+		return nil, fmt.Errorf("invalid request: external DM request is %s", request.Status)
+	}
+	if err := s.checkRequestPolicies(ctx, request); err != nil {
+		return nil, err
+	}
+
+	decision, err := s.messageService.moderate(ctx, request.WorkspaceID, userID, content)
+	if err != nil {
+		return nil, err
+	}
+
+	message, response, err := s.messageService.createExternalMessage(ctx, db.CreateDirectMessageParams{
+		WorkspaceID: request.WorkspaceID,
+		SenderID:    userID,
+		ReceiverID:  sql.NullInt64{Int64: otherExternalDMUser(request, userID), Valid: true},
+		Content:     decision.Content,
+		ContentType: "text",
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.messageService.recordModeration(ctx, decision, message)
+
+	return response, nil
+}
+
+// ListMessages lists the messages of a conversation, newest first. Closed
+// conversations can still be read.
+func (s *ExternalDMService) ListMessages(ctx context.Context, requestID, userID int64, limit, offset int32) ([]*MessageResponse, error) {
+	request, err := s.getRequest(ctx, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Status != ExternalDMStatusAccepted && request.Status != ExternalDMStatusClosed {
+		return nil, fmt.Errorf("invalid request: external DM request is %s", request.Status)
+	}
+
+	messages, err := s.store.GetDirectMessagesBetweenUsers(ctx, db.GetDirectMessagesBetweenUsersParams{
+		WorkspaceID: request.WorkspaceID,
+		SenderID:    userID,
+		ReceiverID:  sql.NullInt64{Int64: otherExternalDMUser(request, userID), Valid: true},
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get direct messages: %w", err)
+	}
+
+	return s.messageService.toDirectMessageResponses(messages), nil
+}
+
+// getRequest gets an external DM request the user is part of. Requests of
+// other people are reported as not found.
+func (s *ExternalDMService) getRequest(ctx context.Context, requestID, userID int64) (db.GetExternalDMRequestRow, error) {
+	request, err := s.store.GetExternalDMRequest(ctx, requestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.GetExternalDMRequestRow{}, errors.New("external DM request not found")
+		}
+		return db.GetExternalDMRequestRow{}, fmt.Errorf("failed to get external DM request: %w", err)
+	}
+	if request.SenderID != userID && request.RecipientID != userID {
+		return db.GetExternalDMRequestRow{}, errors.New("external DM request not found")
+	}
+	return request, nil
+}
+
+// updated announces a changed request to both people and returns it as the
+// user sees it
+func (s *ExternalDMService) updated(request db.GetExternalDMRequestRow, updated db.ExternalDmRequest, userID int64) ExternalDMRequestResponse {
+	request.Status = updated.Status
+	request.RespondedAt = updated.RespondedAt
+
+	s.notify(WSExternalDMRequestUpdated, request.WorkspaceID, userID, request.SenderID, toExternalDMRequestResponse(request, request.SenderID))
+	s.notify(WSExternalDMRequestUpdated, request.WorkspaceID, userID, request.RecipientID, toExternalDMRequestResponse(request, request.RecipientID))
+
+	return toExternalDMRequestResponse(request, userID)
+}
+
+func (s *ExternalDMService) checkRequestPolicies(ctx context.Context, request db.GetExternalDMRequestRow) error {
+	return s.checkPolicies(ctx, request.SenderOrganizationID, request.SenderEmail, request.RecipientOrganizationID, request.RecipientEmail)
+}
+
+// checkPolicies checks that both organizations allow a conversation between
+// the two people
+func (s *ExternalDMService) checkPolicies(ctx context.Context, senderOrganizationID int64, senderEmail string, recipientOrganizationID int64, recipientEmail string) error {
+	senderPolicy, err := s.getPolicy(ctx, senderOrganizationID)
+	if err != nil {
+		return err
+	}
+	if !externalDMAllowed(senderPolicy, recipientEmail) {
+		return errors.New("access denied: the sender's organization does not allow direct messages with this person")
+	}
+
+	recipientPolicy, err := s.getPolicy(ctx, recipientOrganizationID)
+	if err != nil {
+		return err
+	}
+	if !externalDMAllowed(recipientPolicy, senderEmail) {
+		return errors.New("access denied: the recipient's organization does not allow direct messages with this person")
+	}
+
+	return nil
+}
+
+func (s *ExternalDMService) getPolicy(ctx context.Context, organizationID int64) (db.ExternalDmPolicy, error) {
+	policy, err := s.store.GetExternalDMPolicy(ctx, organizationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.ExternalDmPolicy{OrganizationID: organizationID, Policy: ExternalDMPolicyOpen, AllowedDomains: []string{}}, nil
+		}
+		return db.ExternalDmPolicy{}, fmt.Errorf("failed to get external DM policy: %w", err)
+	}
+	return policy, nil
+}
+
+func (s *ExternalDMService) notify(messageType string, workspaceID, actorID, userID int64, request ExternalDMRequestResponse) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.BroadcastToUser(userID, &WSMessage{
+		Type:        messageType,
+		Data:        request,
+		WorkspaceID: workspaceID,
+		UserID:      actorID,
+		Timestamp:   time.Now(),
+	})
+}
+
+// externalDMAllowed reports whether a policy allows a conversation with the
+// person with the email
+func externalDMAllowed(policy db.ExternalDmPolicy, email string) bool {
+	switch policy.Policy {
+	case ExternalDMPolicyOpen:
+		return true
+	case ExternalDMPolicyRestricted:
+		_, domain, ok := strings.Cut(strings.ToLower(email), "@")
+		return ok && slices.Contains(policy.AllowedDomains, domain)
+	default:
+		return false
+	}
+}
+
+func otherExternalDMUser(request db.GetExternalDMRequestRow, userID int64) int64 {
+	if request.SenderID == userID {
+		return request.RecipientID
+	}
+	return request.SenderID
+}
+
+func toExternalDMPolicyResponse(policy db.ExternalDmPolicy) ExternalDMPolicyResponse {
+	response := ExternalDMPolicyResponse{
+		OrganizationID: policy.OrganizationID,
+		Policy:         policy.Policy,
+		AllowedDomains: policy.AllowedDomains,
+	}
+	if response.AllowedDomains == nil {
+		response.AllowedDomains = []string{}
+	}
+	if !policy.UpdatedAt.IsZero() {
+		response.UpdatedAt = &policy.UpdatedAt
+	}
+	return response
+}
+
+func toExternalDMRequestResponse(request db.GetExternalDMRequestRow, viewerID int64) ExternalDMRequestResponse {
+	response := ExternalDMRequestResponse{
+		ID:          request.ID,
+		WorkspaceID: request.WorkspaceID,
+		Sender: ExternalDMUserResponse{
+			ID:             request.SenderID,
+			OrganizationID: request.SenderOrganizationID,
+			Email:          request.SenderEmail,
+			FirstName:      request.SenderFirstName,
+			LastName:       request.SenderLastName,
+		},
+		Recipient: ExternalDMUserResponse{
+			ID:             request.RecipientID,
+			OrganizationID: request.RecipientOrganizationID,
+			Email:          request.RecipientEmail,
+			FirstName:      request.RecipientFirstName,
+			LastName:       request.RecipientLastName,
+		},
+		Message:   request.Message,
+		Status:    request.Status,
+		Incoming:  request.RecipientID == viewerID,
+		CreatedAt: request.CreatedAt,
+	}
+	if request.RespondedAt.Valid {
+		respondedAt := request.RespondedAt.Time
+		response.RespondedAt = &respondedAt
+	}
+	return response
+}
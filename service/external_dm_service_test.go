@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalDMService_UpdatePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	externalDMService := NewExternalDMService(store, nil, nil)
+
+	// Domains are normalized and deduplicated
+	store.EXPECT().
+		UpsertExternalDMPolicy(gomock.Any(), gomock.Eq(db.UpsertExternalDMPolicyParams{
+			OrganizationID: 4,
+			Policy:         ExternalDMPolicyRestricted,
+			AllowedDomains: []string{"partner.com", "vendor.io"},
+			UpdatedBy:      sql.NullInt64{Int64: 9, Valid: true},
+		})).
+		Times(1).
+		Return(db.ExternalDmPolicy{
+			OrganizationID: 4,
+			Policy:         ExternalDMPolicyRestricted,
+			AllowedDomains: []string{"partner.com", "vendor.io"},
+		}, nil)
+
+	policy, err := externalDMService.UpdatePolicy(context.Background(), 4, 9, UpdateExternalDMPolicyRequest{
+		Policy:         ExternalDMPolicyRestricted,
+		AllowedDomains: []string{" Partner.com", "@vendor.io", "partner.com"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"partner.com", "vendor.io"}, policy.AllowedDomains)
+
+	_, err = externalDMService.UpdatePolicy(context.Background(), 4, 9, UpdateExternalDMPolicyRequest{Policy: ExternalDMPolicyRestricted})
+	require.EqualError(t, err, "invalid policy: a restricted policy needs at least one allowed domain")
+
+	_, err = externalDMService.UpdatePolicy(context.Background(), 4, 9, UpdateExternalDMPolicyRequest{
+		Policy:         ExternalDMPolicyOpen,
+		AllowedDomains: []string{"localhost"},
+	})
+	require.EqualError(t, err, `invalid domain: "localhost"`)
+}
+
+func TestExternalDMService_AcceptRequest(t *testing.T) {
+	const senderID, recipientID = int64(3), int64(8)
+	request := db.GetExternalDMRequestRow{
+		ID:                      5,
+		SenderID:                senderID,
+		WorkspaceID:             2,
+		RecipientID:             recipientID,
+		Status:                  ExternalDMStatusPending,
+		SenderEmail:             "ada@acme.com",
+		SenderOrganizationID:    1,
+		RecipientEmail:          "grace@partner.com",
+		RecipientOrganizationID: 6,
+	}
+	respondedAt := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	externalDMService := NewExternalDMService(store, nil, hub)
+
+	store.EXPECT().GetExternalDMRequest(gomock.Any(), gomock.Eq(request.ID)).Times(2).Return(request, nil)
+
+	// Only the recipient can accept
+	_, err := externalDMService.AcceptRequest(context.Background(), request.ID, senderID)
+	require.EqualError(t, err, "access denied: only the recipient can respond to an external DM request")
+
+	store.EXPECT().GetExternalDMPolicy(gomock.Any(), gomock.Any()).Times(2).Return(db.ExternalDmPolicy{}, sql.ErrNoRows)
+	store.EXPECT().
+		RespondExternalDMRequest(gomock.Any(), gomock.Eq(db.RespondExternalDMRequestParams{ID: request.ID, Status: ExternalDMStatusAccepted})).
+		Times(1).
+		Return(db.ExternalDmRequest{
+			ID:          request.ID,
+			Status:      ExternalDMStatusAccepted,
+			RespondedAt: sql.NullTime{Time: respondedAt, Valid: true},
+		}, nil)
+
+	accepted, err := externalDMService.AcceptRequest(context.Background(), request.ID, recipientID)
+	require.NoError(t, err)
+	require.Equal(t, ExternalDMStatusAccepted, accepted.Status)
+	require.Equal(t, respondedAt, *accepted.RespondedAt)
+	require.True(t, accepted.Incoming)
+
+	// Both people hear about it, each from their own side
+	require.Len(t, hub.userMessages[senderID], 1)
+	require.Len(t, hub.userMessages[recipientID], 1)
+	require.Equal(t, WSExternalDMRequestUpdated, hub.userMessages[senderID][0].Type)
+	require.False(t, hub.userMessages[senderID][0].Data.(ExternalDMRequestResponse).Incoming)
+	require.True(t, hub.userMessages[recipientID][0].Data.(ExternalDMRequestResponse).Incoming)
+}
+
+func TestExternalDMAllowed(t *testing.T) {
+	restricted := db.ExternalDmPolicy{Policy: ExternalDMPolicyRestricted, AllowedDomains: []string{"partner.com"}}
+
+	require.True(t, externalDMAllowed(db.ExternalDmPolicy{Policy: ExternalDMPolicyOpen}, "anyone@anywhere.org"))
+	require.False(t, externalDMAllowed(db.ExternalDmPolicy{Policy: ExternalDMPolicyDisabled}, "grace@partner.com"))
+	require.True(t, externalDMAllowed(restricted, "Grace@Partner.com"))
+	require.False(t, externalDMAllowed(restricted, "grace@sub.partner.com"))
+}
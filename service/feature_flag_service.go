@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Feature flags
+const (
+	// FeatureThreads enables thread views and thread events in clients
+	FeatureThreads = "threads"
+	// FeatureHuddles enables audio huddles in channels
+	FeatureHuddles = "huddles"
+	// FeatureNewSearch enables the unified search across messages, files, channels and people
+	FeatureNewSearch = "new_search"
+	// FeatureTranslation enables machine translation of messages
+	FeatureTranslation = "translation"
+)
+
+// FeatureFlag describes a feature that can be rolled out per workspace
+type FeatureFlag struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+// FeatureFlags lists the known feature flags. Features that already shipped
+// default to enabled so workspaces keep them unless an admin turns them off.
+var FeatureFlags = []FeatureFlag{
+	{Name: FeatureThreads, Description: "Thread views and thread events", Default: true},
+	{Name: FeatureHuddles, Description: "Audio huddles in channels", Default: false},
+	{Name: FeatureNewSearch, Description: "Unified search across messages, files, channels and people", Default: true},
+	{Name: FeatureTranslation, Description: "Machine translation of messages", Default: false},
+}
+
+// featureFlagCacheEntry holds a workspace's flag overrides until it expires
+type featureFlagCacheEntry struct {
+	overrides map[string]bool
+	expiresAt time.Time
+}
+
+// FeatureFlagService handles per-workspace feature flags. Overrides are cached
+// so flag checks on hot paths don't hit the database on every request.
+type FeatureFlagService struct {
+	store    db.WorkspaceStore
+	cacheTTL time.Duration
+
+	cache map[int64]featureFlagCacheEntry
+	mutex sync.RWMutex
+}
+
+// NewFeatureFlagService creates a new feature flag service
+func NewFeatureFlagService(store db.WorkspaceStore, config util.Config) *FeatureFlagService {
+	cacheTTL := config.FeatureFlagCacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = 30 * time.Second
+	}
+
+	return &FeatureFlagService{
+		store:    store,
+		cacheTTL: cacheTTL,
+		cache:    make(map[int64]featureFlagCacheEntry),
+	}
+}
+
+// lookupFeatureFlag finds a known feature flag by name
+func lookupFeatureFlag(name string) (FeatureFlag, bool) {
+	for _, flag := range FeatureFlags {
+		if flag.Name == name {
+			return flag, true
+		}
+	}
+	return FeatureFlag{}, false
+}
+
+// IsEnabled reports whether a feature is enabled for a workspace
+func (s *FeatureFlagService) IsEnabled(ctx context.Context, workspaceID int64, flag string) (bool, error) {
+	featureFlag, ok := lookupFeatureFlag(flag)
+	if !ok {
+		return false, fmt.Errorf("unknown feature flag: %s", flag)
+	}
+
+	overrides, err := s.getOverrides(ctx, workspaceID)
+	if err != nil {
+		return false, err
+	}
+
+	if enabled, overridden := overrides[flag]; overridden {
+		return enabled, nil
+	}
+	return featureFlag.Default, nil
+}
+
+// ListFeatureFlags returns the state of every known feature flag for a workspace
+func (s *FeatureFlagService) ListFeatureFlags(ctx context.Context, workspaceID int64) ([]FeatureFlagResponse, error) {
+	overrides, err := s.getOverrides(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]FeatureFlagResponse, len(FeatureFlags))
+	for i, flag := range FeatureFlags {
+		flags[i] = toFeatureFlagResponse(flag, overrides)
+	}
+
+	return flags, nil
+}
+
+// SetFeatureFlag turns a feature on or off for a workspace
+func (s *FeatureFlagService) SetFeatureFlag(ctx context.Context, workspaceID, userID int64, flag string, enabled bool) (FeatureFlagResponse, error) {
+	featureFlag, ok := lookupFeatureFlag(flag)
+	if !ok {
+		return FeatureFlagResponse{}, fmt.Errorf("unknown feature flag: %s", flag)
+	}
+
+	_, err := s.store.UpsertFeatureFlag(ctx, db.UpsertFeatureFlagParams{
+		WorkspaceID: workspaceID,
+		Flag:        flag,
+		Enabled:     enabled,
+		UpdatedBy:   sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		return FeatureFlagResponse{}, fmt.Errorf("failed to set feature flag: %w", err)
+	}
+
+	s.invalidate(workspaceID)
+
+	return toFeatureFlagResponse(featureFlag, map[string]bool{flag: enabled}), nil
+}
+
+// ResetFeatureFlag removes a workspace's override so the flag uses its default again
+func (s *FeatureFlagService) ResetFeatureFlag(ctx context.Context, workspaceID int64, flag string) (FeatureFlagResponse, error) {
+	featureFlag, ok := lookupFeatureFlag(flag)
+	if !ok {
+		return FeatureFlagResponse{}, fmt.Errorf("unknown feature flag: %s", flag)
+	}
+
+	rows, err := s.store.DeleteFeatureFlag(ctx, db.DeleteFeatureFlagParams{
+		WorkspaceID: workspaceID,
+		Flag:        flag,
+	})
+	if err != nil {
+		return FeatureFlagResponse{}, fmt.Errorf("failed to reset feature flag: %w", err)
+	}
+
+	s.invalidate(workspaceID)
+
+	if rows == 0 {
+		return FeatureFlagResponse{}, errors.New("feature flag override not found")
+	}
+
+	return toFeatureFlagResponse(featureFlag, nil), nil
+}
+
+// getOverrides returns a workspace's flag overrides, from the cache when fresh
+func (s *FeatureFlagService) getOverrides(ctx context.Context, workspaceID int64) (map[string]bool, error) {
+	s.mutex.RLock()
+	entry, cached := s.cache[workspaceID]
+	s.mutex.RUnlock()
+
+	if cached && time.Now().Before(entry.expiresAt) {
+		return entry.overrides, nil
+	}
+
+	flags, err := s.store.ListFeatureFlags(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+
+	overrides := make(map[string]bool, len(flags))
+	for _, flag := range flags {
+		overrides[flag.Flag] = flag.Enabled
+	}
+
+	s.mutex.Lock()
+	s.cache[workspaceID] = featureFlagCacheEntry{
+		overrides: overrides,
+		expiresAt: time.Now().Add(s.cacheTTL),
+	}
+	s.mutex.Unlock()
+
+	return overrides, nil
+}
+
+// invalidate drops a workspace's cached overrides after a change
+func (s *FeatureFlagService) invalidate(workspaceID int64) {
+	s.mutex.Lock()
+	delete(s.cache, workspaceID)
+	s.mutex.Unlock()
+}
+
+func toFeatureFlagResponse(flag FeatureFlag, overrides map[string]bool) FeatureFlagResponse {
+	response := FeatureFlagResponse{
+		Flag:        flag.Name,
+		Description: flag.Description,
+		Enabled:     flag.Default,
+		Default:     flag.Default,
+	}
+
+	if enabled, overridden := overrides[flag.Name]; overridden {
+		response.Enabled = enabled
+		response.Overridden = true
+	}
+
+	return response
+}
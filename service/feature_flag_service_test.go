@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagService_IsEnabled(t *testing.T) {
+	const workspaceID = int64(3)
+	ctx := context.Background()
+
+	t.Run("CachesOverrides", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockWorkspaceStore(ctrl)
+
+		store.EXPECT().
+			ListFeatureFlags(gomock.Any(), gomock.Eq(workspaceID)).
+			Times(1).
+			Return([]db.FeatureFlag{{WorkspaceID: workspaceID, Flag: FeatureHuddles, Enabled: true}}, nil)
+
+		featureFlagService := NewFeatureFlagService(store, util.Config{FeatureFlagCacheTTL: time.Minute})
+
+		enabled, err := featureFlagService.IsEnabled(ctx, workspaceID, FeatureHuddles)
+		require.NoError(t, err)
+		require.True(t, enabled)
+
+		// Flags without an override use their default
+		enabled, err = featureFlagService.IsEnabled(ctx, workspaceID, FeatureNewSearch)
+		require.NoError(t, err)
+		require.True(t, enabled)
+	})
+
+	t.Run("CacheExpires", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockWorkspaceStore(ctrl)
+
+		gomock.InOrder(
+			store.EXPECT().
+				ListFeatureFlags(gomock.Any(), gomock.Eq(workspaceID)).
+				Return([]db.FeatureFlag{}, nil),
+			store.EXPECT().
+				ListFeatureFlags(gomock.Any(), gomock.Eq(workspaceID)).
+				Return([]db.FeatureFlag{{WorkspaceID: workspaceID, Flag: FeatureHuddles, Enabled: true}}, nil),
+		)
+
+		featureFlagService := NewFeatureFlagService(store, util.Config{FeatureFlagCacheTTL: 10 * time.Millisecond})
+
+		enabled, err := featureFlagService.IsEnabled(ctx, workspaceID, FeatureHuddles)
+		require.NoError(t, err)
+		require.False(t, enabled)
+
+		time.Sleep(20 * time.Millisecond)
+
+		enabled, err = featureFlagService.IsEnabled(ctx, workspaceID, FeatureHuddles)
+		require.NoError(t, err)
+		require.True(t, enabled)
+	})
+
+	t.Run("SetInvalidatesCache", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockWorkspaceStore(ctrl)
+
+		store.EXPECT().
+			ListFeatureFlags(gomock.Any(), gomock.Eq(workspaceID)).
+			Times(2).
+			Return([]db.FeatureFlag{}, nil)
+		store.EXPECT().
+			UpsertFeatureFlag(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.FeatureFlag{WorkspaceID: workspaceID, Flag: FeatureNewSearch, Enabled: false}, nil)
+
+		featureFlagService := NewFeatureFlagService(store, util.Config{FeatureFlagCacheTTL: time.Minute})
+
+		_, err := featureFlagService.IsEnabled(ctx, workspaceID, FeatureNewSearch)
+		require.NoError(t, err)
+
+		_, err = featureFlagService.SetFeatureFlag(ctx, workspaceID, 7, FeatureNewSearch, false)
+		require.NoError(t, err)
+
+		_, err = featureFlagService.IsEnabled(ctx, workspaceID, FeatureNewSearch)
+		require.NoError(t, err)
+	})
+
+	t.Run("UnknownFlag", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockWorkspaceStore(ctrl)
+
+		featureFlagService := NewFeatureFlagService(store, util.Config{})
+
+		_, err := featureFlagService.IsEnabled(ctx, workspaceID, "time_travel")
+		require.EqualError(t, err, "unknown feature flag: time_travel")
+	})
+}
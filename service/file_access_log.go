@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// FileDownload is a download to record in a file's access log
+type FileDownload struct {
+	FileID    int64
+	UserID    int64
+	IPAddress string
+	UserAgent string
+}
+
+// ListFileAccessLogRequest represents the pagination for a file's access log
+type ListFileAccessLogRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// FileAccessLogEntry represents a download of a file in API responses. The
+// user is left out once their account has been deleted.
+type FileAccessLogEntry struct {
+	ID           int64         `json:"id"`
+	User         *UserResponse `json:"user,omitempty"`
+	IPAddress    string        `json:"ip_address"`
+	UserAgent    string        `json:"user_agent"`
+	DownloadedAt time.Time     `json:"downloaded_at"`
+}
+
+// RecordDownload logs a download of a file's content and counts it against the file
+func (s *FileService) RecordDownload(ctx context.Context, download FileDownload) error {
+	err := s.store.RecordFileDownload(ctx, db.RecordFileDownloadParams{
+		FileID:    download.FileID,
+		UserID:    sql.NullInt64{Int64: download.UserID, Valid: download.UserID != 0},
+		IpAddress: download.IPAddress,
+		UserAgent: download.UserAgent,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record file download: %w", err)
+	}
+	return nil
+}
+
+// ListAccessLog lists who downloaded a file, most recent first. Only the
+// uploader and members allowed to manage the workspace's files can see it.
+func (s *FileService) ListAccessLog(ctx context.Context, fileID, userID int64, limit, offset int32) ([]FileAccessLogEntry, error) {
+	file, err := s.store.GetFile(ctx, fileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("file not found")
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	if file.UploaderID != userID {
+		canManage, err := hasWorkspacePermission(ctx, s.store, userID, file.WorkspaceID, PermissionManageFiles)
+		if err != nil {
+			return nil, err
+		}
+		if !canManage {
+			return nil, errors.New("access denied: only the file uploader or an admin can view its access log")
+		}
+	}
+
+	rows, err := s.store.ListFileAccessLog(ctx, db.ListFileAccessLogParams{
+		FileID: fileID,
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list file access log: %w", err)
+	}
+
+	entries := make([]FileAccessLogEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = FileAccessLogEntry{
+			ID:           row.ID,
+			IPAddress:    row.IpAddress,
+			UserAgent:    row.UserAgent,
+			DownloadedAt: row.CreatedAt,
+		}
+		if row.UserID.Valid {
+			entries[i].User = &UserResponse{
+				ID:        row.UserID.Int64,
+				Email:     row.Email,
+				FirstName: row.FirstName,
+				LastName:  row.LastName,
+			}
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileService_RecordDownload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		RecordFileDownload(gomock.Any(), db.RecordFileDownloadParams{
+			FileID:    3,
+			UserID:    sql.NullInt64{Int64: 7, Valid: true},
+			IpAddress: "203.0.113.9",
+			UserAgent: "curl/8.5.0",
+		}).
+		Return(nil)
+
+	fileService := NewFileService(store, util.Config{}, nil)
+	err := fileService.RecordDownload(context.Background(), FileDownload{
+		FileID:    3,
+		UserID:    7,
+		IPAddress: "203.0.113.9",
+		UserAgent: "curl/8.5.0",
+	})
+	require.NoError(t, err)
+}
+
+func TestFileService_ListAccessLog(t *testing.T) {
+	const fileID, uploaderID, workspaceID = int64(3), int64(7), int64(5)
+	downloadedAt := time.Date(2026, 4, 1, 9, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		userID     int64
+		role       string
+		wantErr    string
+		wantLookup bool
+	}{
+		{name: "Uploader", userID: uploaderID, wantLookup: true},
+		{name: "WorkspaceAdmin", userID: 8, role: "admin", wantLookup: true},
+		{name: "Member", userID: 9, role: "member", wantErr: "access denied"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+
+			store.EXPECT().
+				GetFile(gomock.Any(), fileID).
+				Return(db.File{ID: fileID, UploaderID: uploaderID, WorkspaceID: workspaceID}, nil)
+			if tc.role != "" {
+				store.EXPECT().CheckUserWorkspaceRole(gomock.Any(), gomock.Any()).Return(tc.role, nil)
+				store.EXPECT().GetUserRolePermissions(gomock.Any(), gomock.Any()).AnyTimes().Return([]string{}, nil)
+			}
+
+			if tc.wantLookup {
+				store.EXPECT().
+					ListFileAccessLog(gomock.Any(), db.ListFileAccessLogParams{FileID: fileID, Limit: 50}).
+					Return([]db.ListFileAccessLogRow{
+						{ID: 2, FileID: fileID, UserID: sql.NullInt64{Int64: 9, Valid: true}, IpAddress: "203.0.113.9", CreatedAt: downloadedAt, FirstName: "Ada", Email: "ada@example.com"},
+						{ID: 1, FileID: fileID, IpAddress: "198.51.100.4", CreatedAt: downloadedAt.Add(-time.Hour)},
+					}, nil)
+			} else {
+				store.EXPECT().ListFileAccessLog(gomock.Any(), gomock.Any()).Times(0)
+			}
+
+			fileService := NewFileService(store, util.Config{}, nil)
+			entries, err := fileService.ListAccessLog(context.Background(), fileID, tc.userID, 50, 0)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			require.Equal(t, int64(9), entries[0].User.ID)
+			require.Equal(t, "Ada", entries[0].User.FirstName)
+			require.Equal(t, downloadedAt, entries[0].DownloadedAt)
+			// The second downloader's account has since been deleted
+			require.Nil(t, entries[1].User)
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"expvar"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// reconciliationBatchSize caps how many files one query lists
+const reconciliationBatchSize = 500
+
+// orphanGracePeriod is how old a file nothing refers to has to be before it's
+// quarantined. Uploads are written after their row is created, but renditions
+// and previews are written before their paths are saved.
+const orphanGracePeriod = 6 * time.Hour
+
+// quarantineDirectory is where orphans are moved to in the file store
+const quarantineDirectory = ".quarantine"
+
+// reconciliationMetrics counts reconciliation runs and what they found,
+// published at /debug/vars
+var reconciliationMetrics = expvar.NewMap("file_reconciliation")
+
+// FileReconciliationResult counts what one reconciliation run found and fixed
+type FileReconciliationResult struct {
+	CheckedFiles       int64 `json:"checked_files"`
+	MissingFiles       int64 `json:"missing_files"`
+	OrphansQuarantined int64 `json:"orphans_quarantined"`
+	QuarantinePurged   int64 `json:"quarantine_purged"`
+	ThumbnailsRepaired int64 `json:"thumbnails_repaired"`
+	RenditionsRequeued int64 `json:"renditions_requeued"`
+}
+
+// FileReconciliationService checks the file store against the files table.
+// Stored files no row refers to are quarantined, and thumbnails, previews and
+// video renditions that went missing are made again.
+type FileReconciliationService struct {
+	store       db.FileStore
+	files       *FileService
+	storagePath string
+	retention   time.Duration
+}
+
+// NewFileReconciliationService creates a new file reconciliation service
+func NewFileReconciliationService(store db.FileStore, fileService *FileService, config util.Config) *FileReconciliationService {
+	retention := config.FileQuarantineRetention
+	if retention <= 0 {
+		retention = 30 * 24 * time.Hour
+	}
+
+	return &FileReconciliationService{
+		store:       store,
+		files:       fileService,
+		storagePath: config.FileStoragePath,
+		retention:   retention,
+	}
+}
+
+// RunReconciliation goes through every file to repair what's missing, then
+// quarantines the stored files none of them refer to and purges quarantined
+// files past the retention. Files whose content is missing can't be repaired
+// and are only counted. It returns what was done, including before an error.
+func (s *FileReconciliationService) RunReconciliation(ctx context.Context) (FileReconciliationResult, error) {
+	var result FileReconciliationResult
+	reconciliationMetrics.Add("runs", 1)
+
+	referenced := make(map[string]bool)
+	originals := make(map[string]bool)
+	afterID := int64(0)
+	for {
+		files, err := s.store.ListFilesForReconciliation(ctx, db.ListFilesForReconciliationParams{
+			AfterID: afterID,
+			Limit:   reconciliationBatchSize,
+		})
+		if err != nil {
+			reconciliationMetrics.Add("errors", 1)
+			return result, fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, file := range files {
+			result.CheckedFiles++
+			originals[filepath.Clean(file.FilePath)] = true
+			for _, path := range []string{file.FilePath, file.ThumbnailPath.String, file.TranscodedPath.String, file.PosterPath.String} {
+				if path != "" {
+					referenced[filepath.Clean(path)] = true
+				}
+			}
+
+			if file.UploadCompleted {
+				if err := s.repairFile(ctx, file, referenced, &result); err != nil {
+					reconciliationMetrics.Add("errors", 1)
+					fmt.Printf("Error repairing file %d: %v\n", file.ID, err)
+				}
+			}
+		}
+
+		if len(files) < reconciliationBatchSize {
+			break
+		}
+		afterID = files[len(files)-1].ID
+	}
+	reconciliationMetrics.Add("files_checked", result.CheckedFiles)
+
+	if err := s.quarantineOrphans(referenced, originals, &result); err != nil {
+		reconciliationMetrics.Add("errors", 1)
+		return result, err
+	}
+
+	if err := s.purgeQuarantine(&result); err != nil {
+		reconciliationMetrics.Add("errors", 1)
+		return result, err
+	}
+
+	return result, nil
+}
+
+// StartReconciliationJob runs the reconciliation on an interval until the
+// context is cancelled
+func (s *FileReconciliationService) StartReconciliationJob(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := s.RunReconciliation(ctx)
+			if err != nil {
+				fmt.Printf("Error reconciling files: %v\n", err)
+			}
+			if result.MissingFiles > 0 || result.OrphansQuarantined > 0 || result.ThumbnailsRepaired > 0 || result.RenditionsRequeued > 0 {
+				fmt.Printf("Reconciled %d files: %d missing, %d orphans quarantined, %d thumbnails repaired, %d renditions requeued\n",
+					result.CheckedFiles, result.MissingFiles, result.OrphansQuarantined, result.ThumbnailsRepaired, result.RenditionsRequeued)
+			}
+		}
+	}
+}
+
+// repairFile remakes the thumbnail of an image, and requeues a document's
+// preview or a video's renditions, when they're missing from the file store.
+// New paths are added to referenced.
+func (s *FileReconciliationService) repairFile(ctx context.Context, file db.ListFilesForReconciliationRow, referenced map[string]bool, result *FileReconciliationResult) error {
+	if !storedFileExists(file.FilePath) {
+		result.MissingFiles++
+		reconciliationMetrics.Add("missing_files", 1)
+		fmt.Printf("Warning: file %d is missing from the file store: %s\n", file.ID, file.FilePath)
+		return nil
+	}
+
+	thumbnailMissing := !file.ThumbnailPath.Valid || !storedFileExists(file.ThumbnailPath.String)
+
+	switch {
+	case s.files.config.EnableThumbnails && s.files.isImageFile(file.MimeType) && thumbnailMissing:
+		thumbnailPath, err := s.files.GenerateThumbnail(file.FilePath)
+		if err != nil {
+			return fmt.Errorf("failed to generate thumbnail: %w", err)
+		}
+		referenced[filepath.Clean(thumbnailPath)] = true
+		if err := s.store.UpdateFileThumbnail(ctx, db.UpdateFileThumbnailParams{
+			ID:            file.ID,
+			ThumbnailPath: sql.NullString{String: thumbnailPath, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to save thumbnail: %w", err)
+		}
+		result.ThumbnailsRepaired++
+		reconciliationMetrics.Add("thumbnails_repaired", 1)
+
+	case s.files.previews.Enabled() && file.PreviewStatus.String == ProcessingStatusCompleted && thumbnailMissing:
+		if err := s.files.previews.Enqueue(ctx, file.ID); err != nil {
+			return err
+		}
+		result.RenditionsRequeued++
+		reconciliationMetrics.Add("renditions_requeued", 1)
+
+	case s.files.videoProcessor.Enabled() && file.ProcessingStatus.String == ProcessingStatusCompleted &&
+		(!storedFileExists(file.TranscodedPath.String) || !storedFileExists(file.PosterPath.String)):
+		if err := s.files.videoProcessor.Enqueue(ctx, file.ID); err != nil {
+			return err
+		}
+		result.RenditionsRequeued++
+		reconciliationMetrics.Add("renditions_requeued", 1)
+	}
+
+	return nil
+}
+
+// quarantineOrphans moves the stored files that no row refers to, and that
+// aren't watermarked copies of one, out of the file store. Directories, like
+// the avatars and the quarantine itself, are left alone.
+func (s *FileReconciliationService) quarantineOrphans(referenced, originals map[string]bool, result *FileReconciliationResult) error {
+	entries, err := os.ReadDir(s.storagePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read file store: %w", err)
+	}
+
+	quarantinePath := filepath.Join(s.storagePath, quarantineDirectory)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		path := filepath.Clean(filepath.Join(s.storagePath, entry.Name()))
+		if referenced[path] || isWatermarkedCopy(path, originals) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < orphanGracePeriod {
+			continue
+		}
+
+		if err := os.MkdirAll(quarantinePath, 0755); err != nil {
+			return fmt.Errorf("failed to create quarantine directory: %w", err)
+		}
+		quarantined := filepath.Join(quarantinePath, entry.Name())
+		if err := os.Rename(path, quarantined); err != nil {
+			if !os.IsNotExist(err) {
+				fmt.Printf("Warning: failed to quarantine orphaned file: %v\n", err)
+			}
+			continue
+		}
+		// The retention counts from when the file was quarantined
+		now := time.Now()
+		os.Chtimes(quarantined, now, now)
+
+		result.OrphansQuarantined++
+		reconciliationMetrics.Add("orphans_quarantined", 1)
+	}
+
+	return nil
+}
+
+// purgeQuarantine deletes quarantined files older than the retention
+func (s *FileReconciliationService) purgeQuarantine(result *FileReconciliationResult) error {
+	quarantinePath := filepath.Join(s.storagePath, quarantineDirectory)
+	entries, err := os.ReadDir(quarantinePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read quarantine directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || time.Since(info.ModTime()) < s.retention {
+			continue
+		}
+		if err := os.Remove(filepath.Join(quarantinePath, entry.Name())); err != nil {
+			if !os.IsNotExist(err) {
+				fmt.Printf("Warning: failed to delete quarantined file: %v\n", err)
+			}
+			continue
+		}
+		result.QuarantinePurged++
+		reconciliationMetrics.Add("quarantine_purged", 1)
+	}
+
+	return nil
+}
+
+// isWatermarkedCopy reports whether path is a watermarked copy of one of the
+// stored originals. Temporary files left by an interrupted copy are not.
+func isWatermarkedCopy(path string, originals map[string]bool) bool {
+	if strings.HasSuffix(path, ".tmp") {
+		return false
+	}
+
+	ext := filepath.Ext(path)
+	for i := strings.Index(path, "_wm_"); i >= 0; {
+		if originals[path[:i]+ext] {
+			return true
+		}
+		next := strings.Index(path[i+1:], "_wm_")
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return false
+}
+
+func storedFileExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	// Only a file that's known to be gone counts as missing
+	_, err := os.Stat(path)
+	return !os.IsNotExist(err)
+}
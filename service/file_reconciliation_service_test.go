@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileReconciliationService_RunReconciliation(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-orphanGracePeriod - time.Hour)
+	writeFile := func(name string, modTime time.Time) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(name), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+		return path
+	}
+
+	// An image whose thumbnail went missing
+	imagePath := filepath.Join(dir, "photo.png")
+	out, err := os.Create(imagePath)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(out, image.NewRGBA(image.Rect(0, 0, 600, 300))))
+	require.NoError(t, out.Close())
+
+	writeFile("photo_wm_7_abc.png", old)
+	writeFile("photo_wm_7_abc.png.1234.tmp", old)
+	writeFile("orphan.bin", old)
+	writeFile("uploading.bin", time.Now())
+	writeFile("avatars/key_64.png", old)
+	writeFile(filepath.Join(quarantineDirectory, "expired.bin"), time.Now().Add(-31*24*time.Hour))
+	writeFile(filepath.Join(quarantineDirectory, "recent.bin"), time.Now().Add(-24*time.Hour))
+
+	files := []db.ListFilesForReconciliationRow{
+		{ID: 4, FilePath: imagePath, MimeType: "image/png", UploadCompleted: true, ThumbnailPath: sql.NullString{String: filepath.Join(dir, "photo_thumb.png"), Valid: true}},
+		{ID: 5, FilePath: filepath.Join(dir, "gone.pdf"), MimeType: "application/pdf", UploadCompleted: true},
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListFilesForReconciliation(gomock.Any(), db.ListFilesForReconciliationParams{AfterID: 0, Limit: reconciliationBatchSize}).
+		Times(1).
+		Return(files, nil)
+	store.EXPECT().
+		UpdateFileThumbnail(gomock.Any(), db.UpdateFileThumbnailParams{
+			ID:            4,
+			ThumbnailPath: sql.NullString{String: filepath.Join(dir, "photo_thumb.png"), Valid: true},
+		}).
+		Times(1).
+		Return(nil)
+
+	config := util.Config{FileStoragePath: dir, EnableThumbnails: true}
+	reconciliation := NewFileReconciliationService(store, NewFileService(store, config, nil), config)
+
+	result, err := reconciliation.RunReconciliation(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, FileReconciliationResult{
+		CheckedFiles:       2,
+		MissingFiles:       1,
+		OrphansQuarantined: 2,
+		QuarantinePurged:   1,
+		ThumbnailsRepaired: 1,
+	}, result)
+
+	// The thumbnail is remade, and watermarked copies, new files and
+	// directories stay where they are
+	require.FileExists(t, filepath.Join(dir, "photo_thumb.png"))
+	require.FileExists(t, filepath.Join(dir, "photo_wm_7_abc.png"))
+	require.FileExists(t, filepath.Join(dir, "uploading.bin"))
+	require.FileExists(t, filepath.Join(dir, "avatars", "key_64.png"))
+
+	// Orphans are moved to the quarantine, where the retention starts over
+	for _, name := range []string{"orphan.bin", "photo_wm_7_abc.png.1234.tmp"} {
+		require.NoFileExists(t, filepath.Join(dir, name))
+		info, err := os.Stat(filepath.Join(dir, quarantineDirectory, name))
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now(), info.ModTime(), time.Minute)
+	}
+	require.NoFileExists(t, filepath.Join(dir, quarantineDirectory, "expired.bin"))
+	require.FileExists(t, filepath.Join(dir, quarantineDirectory, "recent.bin"))
+}
+
+func TestIsWatermarkedCopy(t *testing.T) {
+	originals := map[string]bool{"/uploads/report_wm_draft_1f2e.png": true}
+
+	require.True(t, isWatermarkedCopy("/uploads/report_wm_draft_1f2e_wm_7_abc.png", originals))
+	require.False(t, isWatermarkedCopy("/uploads/report_wm_7_abc.png", originals))
+	require.False(t, isWatermarkedCopy("/uploads/report_wm_draft_1f2e_wm_7_abc.png.99.tmp", originals))
+	require.False(t, isWatermarkedCopy("/uploads/other.png", originals))
+}
@@ -5,9 +5,15 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
 	"io"
+	"mime"
 	"mime/multipart"
 	"os"
 	"path/filepath"
@@ -19,27 +25,70 @@ import (
 	"github.com/heyrmi/goslack/util"
 )
 
+// fileServiceStore covers files, their owners and the workspace and organization rules around them,
+// and the events webhooks are sent about them
+type fileServiceStore interface {
+	db.FileStore
+	db.OrganizationStore
+	db.OutboxStore
+	db.UserStore
+	db.WorkspaceStore
+}
+
 // FileService handles file upload, download, and management operations
 type FileService struct {
-	store  db.Store
-	config util.Config
+	store          fileServiceStore
+	config         util.Config
+	tunables       *util.TunablesStore // Size and type limits, which can change while the server runs
+	videoProcessor *VideoProcessingService
+	previews       *DocumentPreviewService
+	hub            WebSocketHub
 }
 
-// NewFileService creates a new file service instance
-func NewFileService(store db.Store, config util.Config) *FileService {
+// NewFileService creates a new file service instance. Uploaded videos are
+// queued with the video processor when it has a transcoder.
+func NewFileService(store fileServiceStore, config util.Config, videoProcessor *VideoProcessingService) *FileService {
 	return &FileService{
-		store:  store,
-		config: config,
+		store:          store,
+		config:         config,
+		tunables:       util.NewTunablesStore(config),
+		videoProcessor: videoProcessor,
+	}
+}
+
+// SetTunables shares the server's tunables with the service, so reloaded
+// limits apply to uploads
+func (s *FileService) SetTunables(tunables *util.TunablesStore) {
+	s.tunables = tunables
+}
+
+// SetHub sets the WebSocket hub uploaders are sent upload progress through
+func (s *FileService) SetHub(hub WebSocketHub) {
+	s.hub = hub
+}
+
+// SetDocumentPreviews sets the service that renders previews of uploaded
+// documents
+func (s *FileService) SetDocumentPreviews(previews *DocumentPreviewService) {
+	s.previews = previews
+}
+
+// currentTunables returns the size and type limits uploads are checked against
+func (s *FileService) currentTunables() util.Tunables {
+	if s.tunables == nil {
+		return util.NewTunables(s.config)
 	}
+	return s.tunables.Load()
 }
 
 // FileUploadRequest represents a file upload request
 type FileUploadRequest struct {
-	WorkspaceID int64                 `form:"workspace_id" binding:"required"`
-	ChannelID   *int64                `form:"channel_id"`
-	ReceiverID  *int64                `form:"receiver_id"`
-	File        *multipart.FileHeader `form:"file" binding:"required"`
-	IsPublic    bool                  `form:"is_public"`
+	WorkspaceID    int64                 `form:"workspace_id" binding:"required"`
+	ChannelID      *int64                `form:"channel_id"`
+	ReceiverID     *int64                `form:"receiver_id"`
+	File           *multipart.FileHeader `form:"file" binding:"required"`
+	IsPublic       bool                  `form:"is_public"`
+	IsVoiceMessage bool                  `form:"is_voice_message"`
 }
 
 // FileResponse represents a file response
@@ -53,6 +102,15 @@ type FileResponse struct {
 	Uploader         UserResponse `json:"uploader"`
 	CreatedAt        time.Time    `json:"created_at"`
 	IsPublic         bool         `json:"is_public"`
+	DownloadCount    int64        `json:"download_count"`
+	IsVoiceMessage   bool         `json:"is_voice_message"`
+	DurationMs       *int32       `json:"duration_ms,omitempty"`
+	Waveform         []int32      `json:"waveform,omitempty"`          // Peaks scaled 0-100, when the audio could be read
+	ProcessingStatus string       `json:"processing_status,omitempty"` // Set for videos queued for transcoding
+	PlaybackURL      string       `json:"playback_url,omitempty"`
+	PosterURL        string       `json:"poster_url,omitempty"`
+	PublicURL        string       `json:"public_url,omitempty"`     // Set for public files, which can be fetched without signing in
+	PreviewStatus    string       `json:"preview_status,omitempty"` // Set for documents queued for a first page preview
 }
 
 // FileUploadProgress represents file upload progress for WebSocket
@@ -84,31 +142,26 @@ var AllowedFileTypes = map[string]bool{
 
 // ValidateFile validates the uploaded file
 func (s *FileService) ValidateFile(header *multipart.FileHeader) error {
+	tunables := s.currentTunables()
+
 	// Check file size
-	if header.Size > s.config.FileMaxSize {
-		return fmt.Errorf("file size %d exceeds maximum allowed size of %d bytes", header.Size, s.config.FileMaxSize)
+	if header.Size > tunables.FileMaxSize {
+		return fmt.Errorf("file size %d exceeds maximum allowed size of %d bytes", header.Size, tunables.FileMaxSize)
 	}
 
 	if header.Size == 0 {
 		return errors.New("file cannot be empty")
 	}
 
-	// Parse allowed types from config
-	allowedTypes := make(map[string]bool)
-	for _, mimeType := range strings.Split(s.config.FileAllowedTypes, ",") {
-		allowedTypes[strings.TrimSpace(mimeType)] = true
-	}
-
-	// Check MIME type from header
-	contentType := header.Header.Get("Content-Type")
-	if contentType == "" {
-		// Try to detect from filename extension
-		ext := strings.ToLower(filepath.Ext(header.Filename))
-		contentType = s.getMimeTypeFromExtension(ext)
+	// Check the declared MIME type, which has to fit the extension. The
+	// content is checked against it once the file is opened.
+	contentType := s.declaredContentType(header)
+	if !s.isAllowedType(contentType) || (activeContentTypes[contentType] && !s.config.FileAllowActiveContent) {
+		return fmt.Errorf("file type '%s' is not allowed", contentType)
 	}
 
-	if !allowedTypes[contentType] {
-		return fmt.Errorf("file type '%s' is not allowed", contentType)
+	if ext := filepath.Ext(header.Filename); !extensionMatches(ext, contentType) {
+		return fmt.Errorf("file extension '%s' does not match file type '%s'", ext, contentType)
 	}
 
 	// Validate filename
@@ -123,31 +176,84 @@ func (s *FileService) ValidateFile(header *multipart.FileHeader) error {
 	return nil
 }
 
-// getMimeTypeFromExtension returns MIME type based on file extension
-func (s *FileService) getMimeTypeFromExtension(ext string) string {
-	switch ext {
-	case ".jpg", ".jpeg":
-		return "image/jpeg"
-	case ".png":
-		return "image/png"
-	case ".gif":
-		return "image/gif"
-	case ".webp":
-		return "image/webp"
-	case ".svg":
-		return "image/svg+xml"
-	case ".pdf":
-		return "application/pdf"
-	case ".txt":
-		return "text/plain"
-	case ".zip":
-		return "application/zip"
-	case ".json":
-		return "application/json"
-	case ".csv":
-		return "text/csv"
-	default:
-		return "application/octet-stream"
+// isAllowedType checks a MIME type against the configured allowed types
+func (s *FileService) isAllowedType(contentType string) bool {
+	for _, mimeType := range strings.Split(s.currentTunables().FileAllowedTypes, ",") {
+		if strings.TrimSpace(mimeType) == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateVoiceMessage validates an audio upload recorded as a voice message
+// and returns its MIME type without parameters such as the codec
+func (s *FileService) ValidateVoiceMessage(header *multipart.FileHeader) (string, error) {
+	maxSize := s.currentTunables().FileMaxSize
+	if header.Size > maxSize {
+		return "", fmt.Errorf("file size %d exceeds maximum allowed size of %d bytes", header.Size, maxSize)
+	}
+
+	if header.Size == 0 {
+		return "", errors.New("file cannot be empty")
+	}
+
+	// Recorders send types such as "audio/webm;codecs=opus"
+	contentType, _, err := mime.ParseMediaType(header.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = s.getMimeTypeFromExtension(strings.ToLower(filepath.Ext(header.Filename)))
+	}
+
+	allowed := false
+	for _, mimeType := range strings.Split(s.config.VoiceMessageAllowedTypes, ",") {
+		if strings.TrimSpace(mimeType) == contentType {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("audio type '%s' is not allowed for voice messages", contentType)
+	}
+
+	if header.Filename == "" {
+		return "", errors.New("filename cannot be empty")
+	}
+
+	if len(header.Filename) > 255 {
+		return "", errors.New("filename too long (maximum 255 characters)")
+	}
+
+	return contentType, nil
+}
+
+// readVoiceMessage reads a voice message's duration and waveform, rejecting
+// audio that can't be read or runs too long
+func (s *FileService) readVoiceMessage(file multipart.File, size int64, mimeType string) (AudioMetadata, error) {
+	metadata, err := ReadAudioMetadata(file, size, mimeType)
+	if err != nil {
+		return AudioMetadata{}, fmt.Errorf("invalid voice message: %w", err)
+	}
+
+	if s.config.VoiceMessageMaxDuration > 0 && metadata.Duration > s.config.VoiceMessageMaxDuration {
+		return AudioMetadata{}, fmt.Errorf("voice message is longer than %s", s.config.VoiceMessageMaxDuration)
+	}
+
+	// Reset file position for subsequent reads
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return AudioMetadata{}, fmt.Errorf("failed to reset file position: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// setVoiceMessage adds a voice message's duration and waveform to its response
+func setVoiceMessage(response *FileResponse, isVoiceMessage bool, durationMs sql.NullInt32, waveform []int32) {
+	response.IsVoiceMessage = isVoiceMessage
+	if durationMs.Valid {
+		response.DurationMs = &durationMs.Int32
+	}
+	if len(waveform) > 0 {
+		response.Waveform = waveform
 	}
 }
 
@@ -187,12 +293,11 @@ func (s *FileService) EnsureUploadDirectory() error {
 }
 
 // CheckDuplicateFile checks if a file with the same hash already exists in the workspace
-func (s *FileService) CheckDuplicateFile(hash string, workspaceID int64) (*db.File, error) {
+func (s *FileService) CheckDuplicateFile(ctx context.Context, hash string, workspaceID int64) (*db.File, error) {
 	if !s.config.EnableFileDeduplication {
 		return nil, nil
 	}
 
-	ctx := context.Background()
 	file, err := s.store.GetFileByHash(ctx, db.GetFileByHashParams{
 		FileHash:    hash,
 		WorkspaceID: workspaceID,
@@ -208,9 +313,16 @@ func (s *FileService) CheckDuplicateFile(hash string, workspaceID int64) (*db.Fi
 }
 
 // UploadFile handles the complete file upload process
-func (s *FileService) UploadFile(req FileUploadRequest, uploaderID int64) (*FileResponse, error) {
+func (s *FileService) UploadFile(ctx context.Context, req FileUploadRequest, uploaderID int64) (*FileResponse, error) {
 	// Validate file
-	if err := s.ValidateFile(req.File); err != nil {
+	var voiceContentType string
+	if req.IsVoiceMessage {
+		contentType, err := s.ValidateVoiceMessage(req.File)
+		if err != nil {
+			return nil, fmt.Errorf("file validation failed: %w", err)
+		}
+		voiceContentType = contentType
+	} else if err := s.ValidateFile(req.File); err != nil {
 		return nil, fmt.Errorf("file validation failed: %w", err)
 	}
 
@@ -221,18 +333,111 @@ func (s *FileService) UploadFile(req FileUploadRequest, uploaderID int64) (*File
 	}
 	defer src.Close()
 
+	upload := fileUpload{
+		workspaceID: req.WorkspaceID,
+		filename:    req.File.Filename,
+		contentType: s.declaredContentType(req.File),
+		size:        req.File.Size,
+		isPublic:    req.IsPublic,
+		content:     src,
+	}
+
+	if req.IsVoiceMessage {
+		voice, err := s.readVoiceMessage(src, req.File.Size, voiceContentType)
+		if err != nil {
+			return nil, err
+		}
+		upload.contentType = voiceContentType
+		upload.voice = &voice
+		return s.saveUpload(ctx, upload, uploaderID)
+	}
+
+	if err := s.ValidateContent(src, upload.contentType); err != nil {
+		s.recordContentScan(ctx, upload, uploaderID, nil, err)
+		return nil, fmt.Errorf("file validation failed: %w", err)
+	}
+
+	response, err := s.saveUpload(ctx, upload, uploaderID)
+	if err != nil {
+		return nil, err
+	}
+	s.recordContentScan(ctx, upload, uploaderID, response, nil)
+	return response, nil
+}
+
+// recordContentScan sends webhooks a file.scanned event with the result of
+// checking an upload's content against its declared type. A rejected upload
+// was never stored, so its event describes the upload rather than a file.
+func (s *FileService) recordContentScan(ctx context.Context, upload fileUpload, uploaderID int64, file *FileResponse, reason error) {
+	data := map[string]interface{}{
+		"uploader_id": uploaderID,
+		"filename":    upload.filename,
+		"mime_type":   upload.contentType,
+		"file_size":   upload.size,
+		"check":       "content_type",
+		"result":      "passed",
+	}
+	if file != nil {
+		data["file_id"] = file.ID
+		data["is_public"] = file.IsPublic
+	}
+	if reason != nil {
+		data["result"] = "rejected"
+		data["reason"] = reason.Error()
+	}
+
+	payload, err := json.Marshal(data)
+	if err == nil {
+		_, err = s.store.CreateWebhookEvent(ctx, db.CreateWebhookEventParams{
+			EventType:   WebhookEventFileScanned,
+			WorkspaceID: upload.workspaceID,
+			UserID:      uploaderID,
+			Payload:     payload,
+		})
+	}
+	if err != nil {
+		fmt.Printf("Error recording content scan of %s: %v\n", upload.filename, err)
+	}
+}
+
+// fileUpload is validated file content on its way to storage
+type fileUpload struct {
+	workspaceID int64
+	filename    string
+	contentType string
+	size        int64
+	isPublic    bool
+	content     multipart.File
+	voice       *AudioMetadata // Set for voice messages
+}
+
+// saveUpload stores validated file content: it's hashed for deduplication,
+// written to disk and recorded, then images get thumbnails, videos are
+// queued for transcoding and documents for previews
+func (s *FileService) saveUpload(ctx context.Context, upload fileUpload, uploaderID int64) (response *FileResponse, err error) {
+	src := upload.content
+	contentType := upload.contentType
+
 	// Calculate file hash for deduplication
 	hash, err := s.CalculateFileHash(src)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate file hash: %w", err)
 	}
 
-	// Check for duplicate files
-	if duplicate, err := s.CheckDuplicateFile(hash, req.WorkspaceID); err != nil {
-		return nil, fmt.Errorf("failed to check for duplicates: %w", err)
-	} else if duplicate != nil {
-		// Return existing file if deduplication is enabled
-		return s.convertToFileResponse(*duplicate)
+	// Check for duplicate files. Every voice message is its own recording, so
+	// they are always stored with their metadata.
+	if upload.voice == nil {
+		if duplicate, err := s.CheckDuplicateFile(ctx, hash, upload.workspaceID); err != nil {
+			return nil, fmt.Errorf("failed to check for duplicates: %w", err)
+		} else if duplicate != nil {
+			// Return existing file if deduplication is enabled
+			sendUploadProgress(s.hub, upload.workspaceID, uploaderID, FileUploadProgress{
+				FileID:   duplicate.ID,
+				Progress: 100,
+				Status:   UploadStatusCompleted,
+			})
+			return s.convertToFileResponse(ctx, *duplicate)
+		}
 	}
 
 	// Ensure upload directory exists
@@ -241,35 +446,37 @@ func (s *FileService) UploadFile(req FileUploadRequest, uploaderID int64) (*File
 	}
 
 	// Generate unique filename
-	storedFilename := s.GenerateUniqueFilename(req.File.Filename)
+	storedFilename := s.GenerateUniqueFilename(upload.filename)
 	filePath := filepath.Join(s.config.FileStoragePath, storedFilename)
 
 	// Create database record first (with upload_completed = false)
-	contentType := req.File.Header.Get("Content-Type")
-	if contentType == "" {
-		contentType = s.getMimeTypeFromExtension(filepath.Ext(req.File.Filename))
-	}
-
 	createFileParams := db.CreateFileParams{
-		WorkspaceID:      req.WorkspaceID,
+		WorkspaceID:      upload.workspaceID,
 		UploaderID:       uploaderID,
-		OriginalFilename: req.File.Filename,
+		OriginalFilename: upload.filename,
 		StoredFilename:   storedFilename,
 		FilePath:         filePath,
-		FileSize:         req.File.Size,
+		FileSize:         upload.size,
 		MimeType:         contentType,
 		FileHash:         hash,
-		IsPublic:         req.IsPublic,
+		IsPublic:         upload.isPublic,
 		UploadCompleted:  false,
 		ThumbnailPath:    sql.NullString{Valid: false},
 	}
 
-	ctx := context.Background()
 	file, err := s.store.CreateFile(ctx, createFileParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create file record: %w", err)
 	}
 
+	// From here on the uploader can follow the file by its ID
+	defer func() {
+		if err != nil {
+			s.reportProgress(file, UploadStatusFailed, 0, err)
+		}
+	}()
+	s.reportProgress(file, UploadStatusUploading, 0, nil)
+
 	// Save file to disk
 	dst, err := os.Create(filePath)
 	if err != nil {
@@ -277,7 +484,14 @@ func (s *FileService) UploadFile(req FileUploadRequest, uploaderID int64) (*File
 	}
 	defer dst.Close()
 
-	if _, err := io.Copy(dst, src); err != nil {
+	stored := &progressReader{
+		Reader: src,
+		size:   upload.size,
+		report: func(percent int) {
+			s.reportProgress(file, UploadStatusUploading, percent, nil)
+		},
+	}
+	if _, err := io.Copy(dst, stored); err != nil {
 		// Clean up file and database record on failure
 		os.Remove(filePath)
 		return nil, fmt.Errorf("failed to save file: %w", err)
@@ -293,8 +507,38 @@ func (s *FileService) UploadFile(req FileUploadRequest, uploaderID int64) (*File
 		return nil, fmt.Errorf("failed to mark file upload as completed: %w", err)
 	}
 
+	if voice := upload.voice; voice != nil {
+		file.IsVoiceMessage = true
+		file.DurationMs = sql.NullInt32{Int32: int32(voice.Duration.Milliseconds()), Valid: true}
+		file.Waveform = voice.Waveform
+		if err := s.store.UpdateFileVoiceMetadata(ctx, db.UpdateFileVoiceMetadataParams{
+			ID:         file.ID,
+			DurationMs: file.DurationMs,
+			Waveform:   file.Waveform,
+		}); err != nil {
+			os.Remove(filePath)
+			return nil, fmt.Errorf("failed to save voice message metadata: %w", err)
+		}
+	}
+
+	// Videos are transcoded in the background
+	if s.videoProcessor.Enabled() && isVideoFile(contentType) {
+		if err := s.videoProcessor.Enqueue(ctx, file.ID); err == nil {
+			file.ProcessingStatus = sql.NullString{String: ProcessingStatusPending, Valid: true}
+		}
+		// Don't fail upload if the video can't be queued, the original still plays
+	}
+
+	// Documents get a preview of their first page in the background
+	if s.previews.Enabled() && isDocumentFile(contentType) {
+		if err := s.previews.Enqueue(ctx, file.ID); err == nil {
+			file.PreviewStatus = sql.NullString{String: ProcessingStatusPending, Valid: true}
+		}
+	}
+
 	// Generate thumbnail for images if enabled
 	if s.config.EnableThumbnails && s.isImageFile(contentType) {
+		s.reportProgress(file, UploadStatusProcessing, 100, nil)
 		if thumbnailPath, err := s.GenerateThumbnail(filePath); err == nil {
 			s.store.UpdateFileThumbnail(ctx, db.UpdateFileThumbnailParams{
 				ID:            file.ID,
@@ -307,7 +551,23 @@ func (s *FileService) UploadFile(req FileUploadRequest, uploaderID int64) (*File
 	// Update file record with completion status
 	file.UploadCompleted = true
 
-	return s.convertToFileResponse(file)
+	// Uploads queued for transcoding or a preview are done when the worker is
+	if file.ProcessingStatus.Valid || file.PreviewStatus.Valid {
+		s.reportProgress(file, UploadStatusProcessing, 100, nil)
+	} else {
+		s.reportProgress(file, UploadStatusCompleted, 100, nil)
+	}
+
+	return s.convertToFileResponse(ctx, file)
+}
+
+// reportProgress tells the uploader of a file how far along its upload is
+func (s *FileService) reportProgress(file db.File, status string, percent int, err error) {
+	progress := FileUploadProgress{FileID: file.ID, Progress: float64(percent), Status: status}
+	if err != nil {
+		progress.Error = err.Error()
+	}
+	sendUploadProgress(s.hub, file.WorkspaceID, file.UploaderID, progress)
 }
 
 // isImageFile checks if the MIME type is an image
@@ -315,23 +575,34 @@ func (s *FileService) isImageFile(mimeType string) bool {
 	return strings.HasPrefix(mimeType, "image/")
 }
 
-// GenerateThumbnail generates a thumbnail for image files
-func (s *FileService) GenerateThumbnail(filePath string) (string, error) {
-	// This is a placeholder - you would implement actual thumbnail generation here
-	// For now, we'll just return the original file path
-	// In a real implementation, you'd use a library like github.com/disintegration/imaging
+// thumbnailSize is the longest side of generated thumbnails in pixels
+const thumbnailSize = 256
 
+// GenerateThumbnail generates a thumbnail for image files. JPEG, PNG and GIF
+// images are scaled down, other images are copied as they are.
+func (s *FileService) GenerateThumbnail(filePath string) (string, error) {
 	ext := filepath.Ext(filePath)
 	thumbnailPath := strings.TrimSuffix(filePath, ext) + "_thumb" + ext
 
-	// For now, just copy the original file as thumbnail
-	// In production, you'd resize it to a smaller dimension
 	src, err := os.Open(filePath)
 	if err != nil {
 		return "", err
 	}
 	defer src.Close()
 
+	img, format, decodeErr := decodeImage(src)
+	if decodeErr == nil {
+		if err := writeImage(thumbnailPath, fitWithin(img, thumbnailSize), format); err != nil {
+			return "", err
+		}
+		return thumbnailPath, nil
+	}
+
+	// Formats without a decoder, like WebP and SVG, are copied
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
 	dst, err := os.Create(thumbnailPath)
 	if err != nil {
 		return "", err
@@ -347,10 +618,35 @@ func (s *FileService) GenerateThumbnail(filePath string) (string, error) {
 	return thumbnailPath, nil
 }
 
+// writeImage encodes an image to a file in the given format, "jpeg", "gif" or "png"
+func writeImage(path string, img image.Image, format string) error {
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(dst, img, &jpeg.Options{Quality: 85})
+	case "gif":
+		err = gif.Encode(dst, img, nil)
+	default:
+		err = png.Encode(dst, img)
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	return nil
+}
+
 // GetFile retrieves a file by ID with permission check
-func (s *FileService) GetFile(fileID, userID, workspaceID int64) (*FileResponse, error) {
+func (s *FileService) GetFile(ctx context.Context, fileID, userID, workspaceID int64) (*FileResponse, error) {
 	// Check file access permissions
-	ctx := context.Background()
 	hasAccess, err := s.store.CheckFileAccess(ctx, db.CheckFileAccessParams{
 		FileID:     fileID,
 		UploaderID: userID,
@@ -379,9 +675,8 @@ func (s *FileService) GetFile(fileID, userID, workspaceID int64) (*FileResponse,
 }
 
 // convertToFileResponse converts a database File to FileResponse
-func (s *FileService) convertToFileResponse(file db.File) (*FileResponse, error) {
+func (s *FileService) convertToFileResponse(ctx context.Context, file db.File) (*FileResponse, error) {
 	// Get uploader info
-	ctx := context.Background()
 	uploader, err := s.store.GetUser(ctx, file.UploaderID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get uploader info: %w", err)
@@ -395,6 +690,8 @@ func (s *FileService) convertToFileResponse(file db.File) (*FileResponse, error)
 		DownloadURL:      fmt.Sprintf("/api/files/%d/download", file.ID),
 		CreatedAt:        file.CreatedAt,
 		IsPublic:         file.IsPublic,
+		DownloadCount:    file.DownloadCount,
+		PreviewStatus:    file.PreviewStatus.String,
 		Uploader: UserResponse{
 			ID:        uploader.ID,
 			Email:     uploader.Email,
@@ -407,6 +704,9 @@ func (s *FileService) convertToFileResponse(file db.File) (*FileResponse, error)
 	if file.ThumbnailPath.Valid {
 		response.ThumbnailURL = fmt.Sprintf("/api/files/%d/thumbnail", file.ID)
 	}
+	setVoiceMessage(response, file.IsVoiceMessage, file.DurationMs, file.Waveform)
+	setVideoProcessing(response, file.ProcessingStatus, file.TranscodedPath, file.PosterPath)
+	setPublicURL(response)
 
 	return response, nil
 }
@@ -421,6 +721,8 @@ func (s *FileService) convertToFileResponseWithUploader(row db.GetFileWithPermis
 		DownloadURL:      fmt.Sprintf("/api/files/%d/download", row.ID),
 		CreatedAt:        row.CreatedAt,
 		IsPublic:         row.IsPublic,
+		DownloadCount:    row.DownloadCount,
+		PreviewStatus:    row.PreviewStatus.String,
 		Uploader: UserResponse{
 			ID:        row.UploaderID,
 			Email:     row.UploaderEmail,
@@ -433,13 +735,15 @@ func (s *FileService) convertToFileResponseWithUploader(row db.GetFileWithPermis
 	if row.ThumbnailPath.Valid {
 		response.ThumbnailURL = fmt.Sprintf("/api/files/%d/thumbnail", row.ID)
 	}
+	setVoiceMessage(response, row.IsVoiceMessage, row.DurationMs, row.Waveform)
+	setVideoProcessing(response, row.ProcessingStatus, row.TranscodedPath, row.PosterPath)
+	setPublicURL(response)
 
 	return response, nil
 }
 
 // ListWorkspaceFiles lists files in a workspace with pagination
-func (s *FileService) ListWorkspaceFiles(workspaceID int64, limit, offset int32) ([]*FileResponse, error) {
-	ctx := context.Background()
+func (s *FileService) ListWorkspaceFiles(ctx context.Context, workspaceID int64, limit, offset int32) ([]*FileResponse, error) {
 	files, err := s.store.ListWorkspaceFiles(ctx, db.ListWorkspaceFilesParams{
 		WorkspaceID: workspaceID,
 		Limit:       limit,
@@ -459,6 +763,8 @@ func (s *FileService) ListWorkspaceFiles(workspaceID int64, limit, offset int32)
 			DownloadURL:      fmt.Sprintf("/api/files/%d/download", file.ID),
 			CreatedAt:        file.CreatedAt,
 			IsPublic:         file.IsPublic,
+			DownloadCount:    file.DownloadCount,
+			PreviewStatus:    file.PreviewStatus.String,
 			Uploader: UserResponse{
 				ID:        file.UploaderID,
 				Email:     file.UploaderEmail,
@@ -470,15 +776,19 @@ func (s *FileService) ListWorkspaceFiles(workspaceID int64, limit, offset int32)
 		if file.ThumbnailPath.Valid {
 			responses[i].ThumbnailURL = fmt.Sprintf("/api/files/%d/thumbnail", file.ID)
 		}
+		setVoiceMessage(responses[i], file.IsVoiceMessage, file.DurationMs, file.Waveform)
+		setVideoProcessing(responses[i], file.ProcessingStatus, file.TranscodedPath, file.PosterPath)
+		setPublicURL(responses[i])
 	}
 
 	return responses, nil
 }
 
-// DeleteFile deletes a file (only by the uploader)
-func (s *FileService) DeleteFile(fileID, userID int64) error {
-	// Get file to check ownership and get file path
-	ctx := context.Background()
+// DeleteFile moves a file to its workspace's trash (only by the uploader or
+// members who can manage files). The content stays on disk until the file is
+// deleted permanently or purged from the trash.
+func (s *FileService) DeleteFile(ctx context.Context, fileID, userID int64) error {
+	// Get file to check ownership
 	file, err := s.store.GetFile(ctx, fileID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -487,86 +797,151 @@ func (s *FileService) DeleteFile(fileID, userID int64) error {
 		return fmt.Errorf("failed to get file: %w", err)
 	}
 
-	// Check if user is the uploader
+	// Check if user is the uploader or allowed to manage the workspace's files
 	if file.UploaderID != userID {
-		return errors.New("access denied: only the file uploader can delete this file")
+		canManage, err := hasWorkspacePermission(ctx, s.store, userID, file.WorkspaceID, PermissionManageFiles)
+		if err != nil {
+			return err
+		}
+		if !canManage {
+			return errors.New("access denied: only the file uploader can delete this file")
+		}
 	}
 
-	// Delete file from database
-	if err := s.store.DeleteFile(ctx, db.DeleteFileParams{
-		ID:         fileID,
-		UploaderID: userID,
-	}); err != nil {
-		return fmt.Errorf("failed to delete file from database: %w", err)
+	trashed, err := s.store.TrashFile(ctx, db.TrashFileParams{
+		ID:        fileID,
+		DeletedBy: sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move file to trash: %w", err)
+	}
+	if trashed == 0 {
+		return errors.New("file not found")
 	}
 
-	// Delete file from disk
-	if err := os.Remove(file.FilePath); err != nil && !os.IsNotExist(err) {
-		// Log error but don't fail the operation
-		// In production, you might want to queue this for cleanup
-		fmt.Printf("Warning: failed to delete file from disk: %v\n", err)
+	return nil
+}
+
+// GetFileContent returns the file content for download
+func (s *FileService) GetFileContent(ctx context.Context, fileID, userID int64) (*os.File, *db.File, error) {
+	file, err := s.getAccessibleFile(ctx, fileID, userID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Delete thumbnail if exists
-	if file.ThumbnailPath.Valid {
-		if err := os.Remove(file.ThumbnailPath.String); err != nil && !os.IsNotExist(err) {
-			fmt.Printf("Warning: failed to delete thumbnail from disk: %v\n", err)
+	// Open file for reading
+	fileContent, err := os.Open(file.FilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, errors.New("file not found on disk")
 		}
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
 	}
 
-	return nil
+	return fileContent, file, nil
 }
 
-// GetFileContent returns the file content for download
-func (s *FileService) GetFileContent(fileID, userID int64) (*os.File, *db.File, error) {
+// GetVideoRendition opens the MP4 rendition ("playback") or poster image
+// ("poster") made for a video, returning it with its MIME type
+func (s *FileService) GetVideoRendition(ctx context.Context, fileID, userID int64, rendition string) (*os.File, string, error) {
+	file, err := s.getAccessibleFile(ctx, fileID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var path sql.NullString
+	var mimeType string
+	switch rendition {
+	case "playback":
+		path, mimeType = file.TranscodedPath, "video/mp4"
+	case "poster":
+		path, mimeType = file.PosterPath, "image/jpeg"
+	default:
+		return nil, "", errors.New("invalid video rendition")
+	}
+
+	if file.ProcessingStatus.String != ProcessingStatusCompleted || !path.Valid {
+		return nil, "", errors.New("video rendition not found")
+	}
+
+	content, err := os.Open(path.String)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", errors.New("video rendition not found")
+		}
+		return nil, "", fmt.Errorf("failed to open video rendition: %w", err)
+	}
+
+	return content, mimeType, nil
+}
+
+// GetThumbnail opens the thumbnail of an image or the first page preview of
+// a document, with its MIME type
+func (s *FileService) GetThumbnail(ctx context.Context, fileID, userID int64) (*os.File, string, error) {
+	file, err := s.getAccessibleFile(ctx, fileID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !file.ThumbnailPath.Valid {
+		return nil, "", errors.New("thumbnail not found")
+	}
+
+	// Image thumbnails keep the image's format, document previews are PNGs
+	mimeType := file.MimeType
+	if isDocumentFile(mimeType) {
+		mimeType = "image/png"
+	}
+
+	content, err := os.Open(file.ThumbnailPath.String)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", errors.New("thumbnail not found")
+		}
+		return nil, "", fmt.Errorf("failed to open thumbnail: %w", err)
+	}
+
+	return content, mimeType, nil
+}
+
+// getAccessibleFile gets a completed upload the user is allowed to download
+func (s *FileService) getAccessibleFile(ctx context.Context, fileID, userID int64) (*db.File, error) {
 	// Check file access permissions
-	ctx := context.Background()
 	hasAccess, err := s.store.CheckFileAccess(ctx, db.CheckFileAccessParams{
 		FileID:     fileID,
 		UploaderID: userID,
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to check file access: %w", err)
+		return nil, fmt.Errorf("failed to check file access: %w", err)
 	}
 
 	if !hasAccess {
-		return nil, nil, errors.New("access denied: you don't have permission to download this file")
+		return nil, errors.New("access denied: you don't have permission to download this file")
 	}
 
 	// Get file info
 	file, err := s.store.GetFile(ctx, fileID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, nil, errors.New("file not found")
+			return nil, errors.New("file not found")
 		}
-		return nil, nil, fmt.Errorf("failed to get file: %w", err)
+		return nil, fmt.Errorf("failed to get file: %w", err)
 	}
 
 	if !file.UploadCompleted {
-		return nil, nil, errors.New("file upload not completed")
+		return nil, errors.New("file upload not completed")
 	}
 
-	// Open file for reading
-	fileContent, err := os.Open(file.FilePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil, errors.New("file not found on disk")
-		}
-		return nil, nil, fmt.Errorf("failed to open file: %w", err)
-	}
-
-	return fileContent, &file, nil
+	return &file, nil
 }
 
-// CleanupIncompleteUploads removes incomplete uploads older than 1 hour
-func (s *FileService) CleanupIncompleteUploads() error {
-	ctx := context.Background()
+// CleanupIncompleteUploads removes incomplete uploads older than 1 hour and
+// returns how many were removed
+func (s *FileService) CleanupIncompleteUploads(ctx context.Context) (int64, error) {
 	return s.store.CleanupIncompleteUploads(ctx)
 }
 
 // GetFileStats returns file statistics for a workspace
-func (s *FileService) GetFileStats(workspaceID int64) (*db.GetFileStatsRow, error) {
-	ctx := context.Background()
+func (s *FileService) GetFileStats(ctx context.Context, workspaceID int64) (*db.GetFileStatsRow, error) {
 	stats, err := s.store.GetFileStats(ctx, workspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file stats: %w", err)
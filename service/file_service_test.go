@@ -2,6 +2,9 @@ package service
 
 import (
 	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
 	"mime/multipart"
 	"net/textproto"
 	"os"
@@ -75,6 +78,33 @@ func TestFileService_ValidateFile(t *testing.T) {
 		require.Contains(t, err.Error(), "not allowed")
 	})
 
+	t.Run("ExtensionMismatch", func(t *testing.T) {
+		header := &multipart.FileHeader{
+			Filename: "invoice.pdf",
+			Size:     1024,
+			Header:   textproto.MIMEHeader{},
+		}
+		header.Header.Set("Content-Type", "image/png")
+
+		err := fileService.ValidateFile(header)
+		require.EqualError(t, err, "file extension '.pdf' does not match file type 'image/png'")
+	})
+
+	t.Run("HTMLNotAllowed", func(t *testing.T) {
+		fileService := &FileService{
+			config: util.Config{FileMaxSize: 10485760, FileAllowedTypes: "text/html"},
+		}
+		header := &multipart.FileHeader{
+			Filename: "page.html",
+			Size:     1024,
+			Header:   textproto.MIMEHeader{},
+		}
+		header.Header.Set("Content-Type", "text/html")
+
+		err := fileService.ValidateFile(header)
+		require.EqualError(t, err, "file type 'text/html' is not allowed")
+	})
+
 	t.Run("EmptyFile", func(t *testing.T) {
 		header := &multipart.FileHeader{
 			Filename: "empty.txt",
@@ -89,6 +119,53 @@ func TestFileService_ValidateFile(t *testing.T) {
 	})
 }
 
+func TestFileService_ValidateVoiceMessage(t *testing.T) {
+	fileService := &FileService{
+		config: util.Config{
+			FileMaxSize:              10485760,
+			VoiceMessageAllowedTypes: "audio/webm,audio/ogg,audio/mpeg",
+		},
+	}
+
+	t.Run("CodecParameter", func(t *testing.T) {
+		header := &multipart.FileHeader{
+			Filename: "voice.webm",
+			Size:     1024,
+			Header:   textproto.MIMEHeader{},
+		}
+		header.Header.Set("Content-Type", "audio/webm;codecs=opus")
+
+		contentType, err := fileService.ValidateVoiceMessage(header)
+		require.NoError(t, err)
+		require.Equal(t, "audio/webm", contentType)
+	})
+
+	t.Run("TypeFromExtension", func(t *testing.T) {
+		header := &multipart.FileHeader{
+			Filename: "voice.MP3",
+			Size:     1024,
+			Header:   textproto.MIMEHeader{},
+		}
+
+		contentType, err := fileService.ValidateVoiceMessage(header)
+		require.NoError(t, err)
+		require.Equal(t, "audio/mpeg", contentType)
+	})
+
+	t.Run("NotAudio", func(t *testing.T) {
+		header := &multipart.FileHeader{
+			Filename: "voice.jpg",
+			Size:     1024,
+			Header:   textproto.MIMEHeader{},
+		}
+		header.Header.Set("Content-Type", "image/jpeg")
+
+		_, err := fileService.ValidateVoiceMessage(header)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not allowed for voice messages")
+	})
+}
+
 func TestFileService_GenerateUniqueFilename(t *testing.T) {
 	fileService := &FileService{}
 
@@ -171,3 +248,36 @@ func TestFileService_GetMimeTypeFromExtension(t *testing.T) {
 		})
 	}
 }
+
+func TestResizeImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	draw.Draw(src, src.Bounds(), image.NewUniform(color.RGBA{R: 200, A: 255}), image.Point{}, draw.Src)
+
+	t.Run("FitWithin", func(t *testing.T) {
+		fitted := fitWithin(src, 100)
+		require.Equal(t, 100, fitted.Bounds().Dx())
+		require.Equal(t, 50, fitted.Bounds().Dy())
+		require.Equal(t, color.RGBA{R: 200, A: 255}, fitted.RGBAAt(10, 10))
+	})
+
+	t.Run("SmallImageKeepsSize", func(t *testing.T) {
+		fitted := fitWithin(src, 1000)
+		require.Equal(t, src.Bounds().Size(), fitted.Bounds().Size())
+	})
+
+	t.Run("CropSquare", func(t *testing.T) {
+		square := cropSquare(src)
+		require.Equal(t, 200, square.Bounds().Dx())
+		require.Equal(t, 200, square.Bounds().Dy())
+
+		resized := resizeImage(square, 32, 32)
+		require.Equal(t, image.Rect(0, 0, 32, 32), resized.Bounds())
+	})
+}
+
+func TestUserInitials(t *testing.T) {
+	require.Equal(t, "JD", userInitials("jane", "doe"))
+	require.Equal(t, "ÉÖ", userInitials("élodie", " östberg"))
+	require.Equal(t, "A", userInitials("Ada", ""))
+	require.Equal(t, "", userInitials("", ""))
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Deleted files wait in their workspace's trash, hidden everywhere else, until
+// they're restored, deleted permanently or purged once the trash retention
+// has passed. Their content stays on disk until then.
+
+// trashPurgeBatchSize caps how many files one trash purge pass removes
+const trashPurgeBatchSize = 100
+
+// ListTrashRequest pages through a workspace's trash, most recently deleted first
+type ListTrashRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// TrashedFileResponse represents a deleted file that can still be restored
+type TrashedFileResponse struct {
+	FileResponse
+	DeletedBy *int64    `json:"deleted_by,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+// ListTrash lists a workspace's deleted files that can still be restored.
+// Members see the files they uploaded, and members who can manage files see
+// all of them.
+func (s *FileService) ListTrash(ctx context.Context, workspaceID, userID int64, limit, offset int32) ([]*TrashedFileResponse, error) {
+	canManage, err := hasWorkspacePermission(ctx, s.store, userID, workspaceID, PermissionManageFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	var uploaderID sql.NullInt64
+	if !canManage {
+		uploaderID = sql.NullInt64{Int64: userID, Valid: true}
+	}
+
+	files, err := s.store.ListTrashedFiles(ctx, db.ListTrashedFilesParams{
+		WorkspaceID:  workspaceID,
+		DeletedAfter: s.trashCutoff(),
+		UploaderID:   uploaderID,
+		Limit:        limit,
+		Offset:       offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trashed files: %w", err)
+	}
+
+	responses := make([]*TrashedFileResponse, len(files))
+	for i, file := range files {
+		responses[i] = &TrashedFileResponse{
+			FileResponse: FileResponse{
+				ID:               file.ID,
+				OriginalFilename: file.OriginalFilename,
+				FileSize:         file.FileSize,
+				MimeType:         file.MimeType,
+				CreatedAt:        file.CreatedAt,
+				IsPublic:         file.IsPublic,
+				DownloadCount:    file.DownloadCount,
+				IsVoiceMessage:   file.IsVoiceMessage,
+				Uploader: UserResponse{
+					ID:        file.UploaderID,
+					Email:     file.UploaderEmail,
+					FirstName: file.UploaderFirstName,
+					LastName:  file.UploaderLastName,
+				},
+			},
+			DeletedAt: file.DeletedAt.Time,
+			PurgeAt:   file.DeletedAt.Time.Add(s.trashRetention()),
+		}
+		if file.DeletedBy.Valid {
+			responses[i].DeletedBy = &file.DeletedBy.Int64
+		}
+	}
+
+	return responses, nil
+}
+
+// RestoreFile takes a file out of the trash, back where it was before it was
+// deleted
+func (s *FileService) RestoreFile(ctx context.Context, workspaceID, fileID, userID int64) (*FileResponse, error) {
+	if _, err := s.getTrashedFile(ctx, workspaceID, fileID, userID); err != nil {
+		return nil, err
+	}
+
+	file, err := s.store.RestoreFile(ctx, db.RestoreFileParams{
+		ID:           fileID,
+		WorkspaceID:  workspaceID,
+		DeletedAfter: s.trashCutoff(),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("trashed file not found")
+		}
+		return nil, fmt.Errorf("failed to restore file: %w", err)
+	}
+
+	return s.convertToFileResponse(ctx, file)
+}
+
+// DeleteFilePermanently removes a file in the trash and its content for
+// good, without waiting for the purge. Files uploaded by a user under legal
+// hold are kept.
+func (s *FileService) DeleteFilePermanently(ctx context.Context, workspaceID, fileID, userID int64) error {
+	file, err := s.getTrashedFile(ctx, workspaceID, fileID, userID)
+	if err != nil {
+		return err
+	}
+
+	held, err := s.store.HasActiveLegalHold(ctx, db.HasActiveLegalHoldParams{
+		TargetType: LegalHoldTargetUser,
+		TargetID:   file.UploaderID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check legal holds: %w", err)
+	}
+	if held {
+		return errors.New("file is under legal hold")
+	}
+
+	purged, err := s.purgeFile(ctx, file)
+	if err != nil {
+		return err
+	}
+	// Restored since it was looked up
+	if !purged {
+		return errors.New("trashed file not found")
+	}
+	return nil
+}
+
+// PurgeTrash permanently removes files that have been in the trash longer
+// than the retention, with their content. Files uploaded by a user under
+// legal hold are kept until the hold is released.
+func (s *FileService) PurgeTrash(ctx context.Context) error {
+	files, err := s.store.ListPurgeableFiles(ctx, db.ListPurgeableFilesParams{
+		DeletedBefore: s.trashCutoff(),
+		Limit:         trashPurgeBatchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list purgeable files: %w", err)
+	}
+
+	for _, file := range files {
+		held, err := s.store.HasActiveLegalHold(ctx, db.HasActiveLegalHoldParams{
+			TargetType: LegalHoldTargetUser,
+			TargetID:   file.UploaderID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check legal holds: %w", err)
+		}
+		if held {
+			continue
+		}
+		if _, err := s.purgeFile(ctx, file); err != nil {
+			return fmt.Errorf("failed to purge file %d: %w", file.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// StartTrashPurgeJob purges expired files from the trash on an interval until
+// the context is cancelled
+func (s *FileService) StartTrashPurgeJob(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.PurgeTrash(ctx); err != nil {
+				fmt.Printf("Error purging file trash: %v\n", err)
+			}
+		}
+	}
+}
+
+// getTrashedFile returns a file in a workspace's trash, if the user uploaded
+// it or can manage the workspace's files
+func (s *FileService) getTrashedFile(ctx context.Context, workspaceID, fileID, userID int64) (db.File, error) {
+	file, err := s.store.GetTrashedFile(ctx, db.GetTrashedFileParams{
+		ID:          fileID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.File{}, errors.New("trashed file not found")
+		}
+		return db.File{}, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	if file.UploaderID != userID {
+		canManage, err := hasWorkspacePermission(ctx, s.store, userID, workspaceID, PermissionManageFiles)
+		if err != nil {
+			return db.File{}, err
+		}
+		if !canManage {
+			return db.File{}, errors.New("access denied: only the file uploader can manage this file in the trash")
+		}
+	}
+
+	return file, nil
+}
+
+// purgeFile deletes a trashed file and then its content, along with its
+// thumbnail, renditions and watermarked copies. It reports whether the file
+// was still in the trash.
+func (s *FileService) purgeFile(ctx context.Context, file db.File) (bool, error) {
+	purged, err := s.store.PurgeFile(ctx, file.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete file from database: %w", err)
+	}
+	if purged == 0 {
+		return false, nil
+	}
+
+	removeStoredFile(file.FilePath)
+	for _, path := range []sql.NullString{file.ThumbnailPath, file.TranscodedPath, file.PosterPath} {
+		if path.Valid {
+			removeStoredFile(path.String)
+		}
+	}
+	removeWatermarkedImages(file.FilePath)
+
+	return true, nil
+}
+
+// trashRetention is how long deleted files can be restored from the trash
+func (s *FileService) trashRetention() time.Duration {
+	if s.config.FileTrashRetention <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return s.config.FileTrashRetention
+}
+
+// trashCutoff is the deletion time before which files can no longer be
+// restored from the trash
+func (s *FileService) trashCutoff() time.Time {
+	return time.Now().Add(-s.trashRetention())
+}
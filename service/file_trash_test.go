@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func expectWorkspaceRole(store *mockdb.MockStore, userID, workspaceID int64, role string) {
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), db.CheckUserWorkspaceRoleParams{
+			ID:          userID,
+			WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true},
+		}).
+		Return(role, nil)
+	if role != "admin" {
+		store.EXPECT().GetUserRolePermissions(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+	}
+}
+
+func TestFileService_DeleteFileMovesToTrash(t *testing.T) {
+	const uploaderID, workspaceID = int64(4), int64(2)
+	filePath := filepath.Join(t.TempDir(), "report.pdf")
+	require.NoError(t, os.WriteFile(filePath, []byte("%PDF-1.7"), 0644))
+	file := db.File{ID: 9, WorkspaceID: workspaceID, UploaderID: uploaderID, FilePath: filePath}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetFile(gomock.Any(), file.ID).Times(1).Return(file, nil)
+	store.EXPECT().
+		TrashFile(gomock.Any(), db.TrashFileParams{ID: file.ID, DeletedBy: sql.NullInt64{Int64: uploaderID, Valid: true}}).
+		Times(1).
+		Return(int64(1), nil)
+	store.EXPECT().DeleteFile(gomock.Any(), gomock.Any()).Times(0)
+
+	err := NewFileService(store, util.Config{}, nil).DeleteFile(context.Background(), file.ID, uploaderID)
+	require.NoError(t, err)
+
+	// The content is kept so the file can be restored
+	require.FileExists(t, filePath)
+}
+
+func TestFileService_ListTrash(t *testing.T) {
+	const workspaceID = int64(2)
+	deletedAt := time.Now().Add(-time.Hour)
+	config := util.Config{FileTrashRetention: 24 * time.Hour}
+
+	testCases := []struct {
+		name     string
+		role     string
+		uploader sql.NullInt64
+	}{
+		{
+			name:     "Member",
+			role:     "member",
+			uploader: sql.NullInt64{Int64: 4, Valid: true},
+		},
+		{
+			name: "Admin",
+			role: "admin",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			expectWorkspaceRole(store, 4, workspaceID, tc.role)
+			store.EXPECT().
+				ListTrashedFiles(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, arg db.ListTrashedFilesParams) ([]db.ListTrashedFilesRow, error) {
+					require.Equal(t, tc.uploader, arg.UploaderID)
+					require.WithinDuration(t, time.Now().Add(-config.FileTrashRetention), arg.DeletedAfter, time.Minute)
+					return []db.ListTrashedFilesRow{{
+						ID:          9,
+						WorkspaceID: workspaceID,
+						UploaderID:  4,
+						DeletedAt:   sql.NullTime{Time: deletedAt, Valid: true},
+						DeletedBy:   sql.NullInt64{Int64: 4, Valid: true},
+					}}, nil
+				})
+
+			files, err := NewFileService(store, config, nil).ListTrash(context.Background(), workspaceID, 4, 20, 0)
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+			require.Equal(t, deletedAt.Add(24*time.Hour), files[0].PurgeAt)
+			require.Equal(t, int64(4), *files[0].DeletedBy)
+			require.Empty(t, files[0].DownloadURL)
+		})
+	}
+}
+
+func TestFileService_RestoreFile(t *testing.T) {
+	const workspaceID = int64(2)
+	file := db.File{ID: 9, WorkspaceID: workspaceID, UploaderID: 4}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetTrashedFile(gomock.Any(), db.GetTrashedFileParams{ID: file.ID, WorkspaceID: workspaceID}).Times(1).Return(file, nil)
+		store.EXPECT().RestoreFile(gomock.Any(), gomock.Any()).Times(1).Return(file, nil)
+		store.EXPECT().GetUser(gomock.Any(), file.UploaderID).Times(1).Return(db.User{ID: file.UploaderID}, nil)
+
+		restored, err := NewFileService(store, util.Config{}, nil).RestoreFile(context.Background(), workspaceID, file.ID, file.UploaderID)
+		require.NoError(t, err)
+		require.Equal(t, file.ID, restored.ID)
+	})
+
+	t.Run("NotUploader", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetTrashedFile(gomock.Any(), gomock.Any()).Times(1).Return(file, nil)
+		expectWorkspaceRole(store, 7, workspaceID, "member")
+		store.EXPECT().RestoreFile(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := NewFileService(store, util.Config{}, nil).RestoreFile(context.Background(), workspaceID, file.ID, 7)
+		require.EqualError(t, err, "access denied: only the file uploader can manage this file in the trash")
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetTrashedFile(gomock.Any(), gomock.Any()).Times(1).Return(file, nil)
+		store.EXPECT().RestoreFile(gomock.Any(), gomock.Any()).Times(1).Return(db.File{}, sql.ErrNoRows)
+
+		_, err := NewFileService(store, util.Config{}, nil).RestoreFile(context.Background(), workspaceID, file.ID, file.UploaderID)
+		require.EqualError(t, err, "trashed file not found")
+	})
+}
+
+func TestFileService_DeleteFilePermanently(t *testing.T) {
+	const workspaceID = int64(2)
+
+	newFile := func(t *testing.T) db.File {
+		dir := t.TempDir()
+		file := db.File{
+			ID:            9,
+			WorkspaceID:   workspaceID,
+			UploaderID:    4,
+			FilePath:      filepath.Join(dir, "photo.png"),
+			ThumbnailPath: sql.NullString{String: filepath.Join(dir, "photo_thumb.png"), Valid: true},
+		}
+		require.NoError(t, os.WriteFile(file.FilePath, []byte("png"), 0644))
+		require.NoError(t, os.WriteFile(file.ThumbnailPath.String, []byte("png"), 0644))
+		return file
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		file := newFile(t)
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetTrashedFile(gomock.Any(), gomock.Any()).Times(1).Return(file, nil)
+		store.EXPECT().HasActiveLegalHold(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+		store.EXPECT().PurgeFile(gomock.Any(), file.ID).Times(1).Return(int64(1), nil)
+
+		err := NewFileService(store, util.Config{}, nil).DeleteFilePermanently(context.Background(), workspaceID, file.ID, file.UploaderID)
+		require.NoError(t, err)
+		require.NoFileExists(t, file.FilePath)
+		require.NoFileExists(t, file.ThumbnailPath.String)
+	})
+
+	t.Run("LegalHold", func(t *testing.T) {
+		file := newFile(t)
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetTrashedFile(gomock.Any(), gomock.Any()).Times(1).Return(file, nil)
+		store.EXPECT().
+			HasActiveLegalHold(gomock.Any(), db.HasActiveLegalHoldParams{TargetType: LegalHoldTargetUser, TargetID: file.UploaderID}).
+			Times(1).
+			Return(true, nil)
+		store.EXPECT().PurgeFile(gomock.Any(), gomock.Any()).Times(0)
+
+		err := NewFileService(store, util.Config{}, nil).DeleteFilePermanently(context.Background(), workspaceID, file.ID, file.UploaderID)
+		require.EqualError(t, err, "file is under legal hold")
+		require.FileExists(t, file.FilePath)
+	})
+
+	t.Run("Restored", func(t *testing.T) {
+		file := newFile(t)
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetTrashedFile(gomock.Any(), gomock.Any()).Times(1).Return(file, nil)
+		store.EXPECT().HasActiveLegalHold(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+		store.EXPECT().PurgeFile(gomock.Any(), file.ID).Times(1).Return(int64(0), nil)
+
+		err := NewFileService(store, util.Config{}, nil).DeleteFilePermanently(context.Background(), workspaceID, file.ID, file.UploaderID)
+		require.EqualError(t, err, "trashed file not found")
+		require.FileExists(t, file.FilePath)
+	})
+}
+
+func TestFileService_PurgeTrash(t *testing.T) {
+	dir := t.TempDir()
+	expired := db.File{ID: 1, UploaderID: 4, FilePath: filepath.Join(dir, "old.txt")}
+	held := db.File{ID: 2, UploaderID: 5, FilePath: filepath.Join(dir, "held.txt")}
+	for _, file := range []db.File{expired, held} {
+		require.NoError(t, os.WriteFile(file.FilePath, []byte("text"), 0644))
+	}
+	config := util.Config{FileTrashRetention: 7 * 24 * time.Hour}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListPurgeableFiles(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ListPurgeableFilesParams) ([]db.File, error) {
+			require.WithinDuration(t, time.Now().Add(-config.FileTrashRetention), arg.DeletedBefore, time.Minute)
+			return []db.File{expired, held}, nil
+		})
+	store.EXPECT().HasActiveLegalHold(gomock.Any(), db.HasActiveLegalHoldParams{TargetType: LegalHoldTargetUser, TargetID: expired.UploaderID}).Return(false, nil)
+	store.EXPECT().HasActiveLegalHold(gomock.Any(), db.HasActiveLegalHoldParams{TargetType: LegalHoldTargetUser, TargetID: held.UploaderID}).Return(true, nil)
+	store.EXPECT().PurgeFile(gomock.Any(), expired.ID).Times(1).Return(int64(1), nil)
+	store.EXPECT().PurgeFile(gomock.Any(), held.ID).Times(0)
+
+	require.NoError(t, NewFileService(store, config, nil).PurgeTrash(context.Background()))
+	require.NoFileExists(t, expired.FilePath)
+	require.FileExists(t, held.FilePath)
+}
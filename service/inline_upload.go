@@ -0,0 +1,117 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// inlineImageExtensions are the image types that can be pasted inline, with
+// the extension given to pasted images that arrive without a filename
+var inlineImageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// InlineFileUploadRequest represents an image pasted into the client, sent
+// as base64 or as a data URL such as data:image/png;base64,...
+type InlineFileUploadRequest struct {
+	WorkspaceID int64  `json:"workspace_id" binding:"required"`
+	ChannelID   *int64 `json:"channel_id"`
+	ReceiverID  *int64 `json:"receiver_id"`
+	Filename    string `json:"filename" binding:"omitempty,max=255"`
+	Data        string `json:"data" binding:"required"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// inlineFile serves decoded inline content as an uploaded file
+type inlineFile struct {
+	*bytes.Reader
+}
+
+func (f inlineFile) Close() error {
+	return nil
+}
+
+// UploadInlineImage stores a pasted image the same way as a multipart
+// upload. The image type is sniffed from the content and has to agree with
+// the type a data URL declares.
+func (s *FileService) UploadInlineImage(ctx context.Context, req InlineFileUploadRequest, uploaderID int64) (*FileResponse, error) {
+	declaredType, encoded := parseDataURL(req.Data)
+
+	content, err := decodeBase64(encoded)
+	if err != nil {
+		return nil, errors.New("file validation failed: image data is not valid base64")
+	}
+	if len(content) == 0 {
+		return nil, errors.New("file validation failed: file cannot be empty")
+	}
+	if maxSize := s.currentTunables().FileMaxSize; int64(len(content)) > maxSize {
+		return nil, fmt.Errorf("file size %d exceeds maximum allowed size of %d bytes", len(content), maxSize)
+	}
+
+	contentType := http.DetectContentType(content)
+	ext, ok := inlineImageExtensions[contentType]
+	if !ok {
+		return nil, errors.New("file validation failed: only PNG, JPEG, GIF and WebP images can be pasted")
+	}
+	if declaredType != "" && declaredType != contentType {
+		return nil, fmt.Errorf("file validation failed: data is declared as %s but contains %s", declaredType, contentType)
+	}
+	if !s.isAllowedType(contentType) {
+		return nil, fmt.Errorf("file validation failed: file type '%s' is not allowed", contentType)
+	}
+
+	filename := req.Filename
+	if filename == "" {
+		filename = "pasted-image-" + time.Now().UTC().Format("20060102-150405") + ext
+	}
+
+	return s.saveUpload(ctx, fileUpload{
+		workspaceID: req.WorkspaceID,
+		filename:    filename,
+		contentType: contentType,
+		size:        int64(len(content)),
+		isPublic:    req.IsPublic,
+		content:     inlineFile{bytes.NewReader(content)},
+	}, uploaderID)
+}
+
+// parseDataURL splits a base64 data URL into its declared MIME type and its
+// data. Anything else is taken to be plain base64 without a declared type.
+func parseDataURL(data string) (mimeType, encoded string) {
+	data = strings.TrimSpace(data)
+	if !strings.HasPrefix(data, "data:") {
+		return "", data
+	}
+
+	header, encoded, found := strings.Cut(strings.TrimPrefix(data, "data:"), ",")
+	if !found {
+		return "", data
+	}
+	mimeType, _, _ = strings.Cut(header, ";")
+	return strings.ToLower(strings.TrimSpace(mimeType)), encoded
+}
+
+// decodeBase64 decodes standard or URL-safe base64, padded or not
+func decodeBase64(encoded string) ([]byte, error) {
+	encoded = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == ' ' {
+			return -1
+		}
+		return r
+	}, encoded)
+	encoded = strings.TrimRight(encoded, "=")
+
+	if strings.ContainsAny(encoded, "-_") {
+		return base64.RawURLEncoding.DecodeString(encoded)
+	}
+	return base64.RawStdEncoding.DecodeString(encoded)
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDataURL(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        string
+		wantType    string
+		wantEncoded string
+	}{
+		{name: "DataURL", data: "data:image/png;base64,iVBORw0K", wantType: "image/png", wantEncoded: "iVBORw0K"},
+		{name: "UppercaseType", data: "data:Image/JPEG;base64,/9j/", wantType: "image/jpeg", wantEncoded: "/9j/"},
+		{name: "PlainBase64", data: " iVBORw0K\n", wantEncoded: "iVBORw0K"},
+		{name: "NoComma", data: "data:image/png;base64", wantEncoded: "data:image/png;base64"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mimeType, encoded := parseDataURL(tc.data)
+			require.Equal(t, tc.wantType, mimeType)
+			require.Equal(t, tc.wantEncoded, encoded)
+		})
+	}
+}
+
+func TestDecodeBase64(t *testing.T) {
+	content := []byte{0xfb, 0xff, 0x00, 0x10}
+
+	for _, encoded := range []string{
+		base64.StdEncoding.EncodeToString(content),
+		base64.RawStdEncoding.EncodeToString(content),
+		base64.URLEncoding.EncodeToString(content),
+		base64.StdEncoding.EncodeToString(content)[:4] + "\n" + base64.StdEncoding.EncodeToString(content)[4:],
+	} {
+		decoded, err := decodeBase64(encoded)
+		require.NoError(t, err, encoded)
+		require.Equal(t, content, decoded)
+	}
+
+	_, err := decodeBase64("not base64!")
+	require.Error(t, err)
+}
+
+func encodeTestPNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	return buf.Bytes()
+}
+
+func TestFileService_UploadInlineImage(t *testing.T) {
+	const workspaceID, uploaderID = int64(5), int64(7)
+	ctx := context.Background()
+	content := encodeTestPNG(t)
+	dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString(content)
+
+	config := util.Config{
+		FileStoragePath:  t.TempDir(),
+		FileMaxSize:      1 << 20,
+		FileAllowedTypes: "image/png,image/jpeg",
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+
+		store.EXPECT().
+			CreateFile(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.CreateFileParams) (db.File, error) {
+				require.Equal(t, workspaceID, arg.WorkspaceID)
+				require.Equal(t, "screenshot.png", arg.OriginalFilename)
+				require.Equal(t, "image/png", arg.MimeType)
+				require.Equal(t, int64(len(content)), arg.FileSize)
+				return db.File{
+					ID:               9,
+					WorkspaceID:      arg.WorkspaceID,
+					UploaderID:       arg.UploaderID,
+					OriginalFilename: arg.OriginalFilename,
+					FilePath:         arg.FilePath,
+					FileSize:         arg.FileSize,
+					MimeType:         arg.MimeType,
+					FileHash:         arg.FileHash,
+				}, nil
+			})
+		store.EXPECT().UpdateFileUploadStatus(gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().GetUser(gomock.Any(), uploaderID).Return(db.User{ID: uploaderID}, nil)
+
+		fileService := NewFileService(store, config, nil)
+		file, err := fileService.UploadInlineImage(ctx, InlineFileUploadRequest{
+			WorkspaceID: workspaceID,
+			Filename:    "screenshot.png",
+			Data:        dataURL,
+		}, uploaderID)
+		require.NoError(t, err)
+		require.Equal(t, int64(9), file.ID)
+		require.Equal(t, "image/png", file.MimeType)
+
+		files, err := filepath.Glob(filepath.Join(config.FileStoragePath, "screenshot_*.png"))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		stored, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		require.Equal(t, content, stored)
+	})
+
+	testCases := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "InvalidBase64", data: "data:image/png;base64,%%%", wantErr: "not valid base64"},
+		{name: "Empty", data: "data:image/png;base64,", wantErr: "cannot be empty"},
+		{name: "NotAnImage", data: base64.StdEncoding.EncodeToString([]byte("just some text")), wantErr: "only PNG, JPEG, GIF and WebP"},
+		{name: "DeclaredTypeMismatch", data: "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(content), wantErr: "declared as image/jpeg"},
+		{name: "TypeNotAllowed", data: base64.StdEncoding.EncodeToString([]byte("GIF89a\x01\x00\x01\x00")), wantErr: "'image/gif' is not allowed"},
+		{name: "TooLarge", data: base64.StdEncoding.EncodeToString(append(content, make([]byte, 1<<20)...)), wantErr: "exceeds maximum allowed size"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().CreateFile(gomock.Any(), gomock.Any()).Times(0)
+
+			fileService := NewFileService(store, config, nil)
+			_, err := fileService.UploadInlineImage(ctx, InlineFileUploadRequest{
+				WorkspaceID: workspaceID,
+				Data:        tc.data,
+			}, uploaderID)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
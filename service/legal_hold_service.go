@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Legal hold target types
+const (
+	LegalHoldTargetUser    = "user"
+	LegalHoldTargetChannel = "channel"
+)
+
+// legalHoldServiceStore covers legal holds and the users, channels and workspaces they target
+type legalHoldServiceStore interface {
+	db.ChannelStore
+	db.OrganizationStore
+	db.UserStore
+	db.WorkspaceStore
+}
+
+// LegalHoldService handles placing users and channels under legal hold and
+// exporting the held content for compliance. Deleting held content is refused
+// by the services that own it.
+type LegalHoldService struct {
+	store   legalHoldServiceStore
+	archive *ArchiveService
+}
+
+// NewLegalHoldService creates a new legal hold service
+func NewLegalHoldService(store legalHoldServiceStore) *LegalHoldService {
+	return &LegalHoldService{
+		store: store,
+	}
+}
+
+// SetArchive sets the archive that exports read archived messages from
+func (s *LegalHoldService) SetArchive(archive *ArchiveService) {
+	s.archive = archive
+}
+
+// CreateLegalHold places a user or channel of the organization under legal hold
+func (s *LegalHoldService) CreateLegalHold(ctx context.Context, organizationID, adminID int64, req CreateLegalHoldRequest) (*LegalHoldResponse, error) {
+	if err := s.checkTarget(ctx, organizationID, req.TargetType, req.TargetID); err != nil {
+		return nil, err
+	}
+
+	hold, err := s.store.CreateLegalHold(ctx, db.CreateLegalHoldParams{
+		OrganizationID: organizationID,
+		TargetType:     req.TargetType,
+		TargetID:       req.TargetID,
+		Reason:         req.Reason,
+		CreatedBy:      sql.NullInt64{Int64: adminID, Valid: true},
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, errors.New("legal hold already exists")
+		}
+		return nil, fmt.Errorf("failed to create legal hold: %w", err)
+	}
+
+	return toLegalHoldResponse(hold), nil
+}
+
+// checkTarget makes sure the held user or channel belongs to the organization
+func (s *LegalHoldService) checkTarget(ctx context.Context, organizationID int64, targetType string, targetID int64) error {
+	switch targetType {
+	case LegalHoldTargetUser:
+		user, err := s.store.GetUser(ctx, targetID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("legal hold target not found")
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.OrganizationID != organizationID {
+			return errors.New("legal hold target not found")
+		}
+	case LegalHoldTargetChannel:
+		channel, err := s.store.GetChannelByID(ctx, targetID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errors.New("legal hold target not found")
+			}
+			return fmt.Errorf("failed to get channel: %w", err)
+		}
+		workspace, err := s.store.GetWorkspace(ctx, channel.WorkspaceID)
+		if err != nil {
+			return fmt.Errorf("failed to get workspace: %w", err)
+		}
+		if workspace.OrganizationID != organizationID {
+			return errors.New("legal hold target not found")
+		}
+	default:
+		return fmt.Errorf("invalid legal hold: unknown target type %q", targetType)
+	}
+
+	return nil
+}
+
+// ListLegalHolds lists an organization's legal holds, newest first
+func (s *LegalHoldService) ListLegalHolds(ctx context.Context, organizationID int64, includeReleased bool, limit, offset int32) ([]*LegalHoldResponse, error) {
+	holds, err := s.store.ListLegalHolds(ctx, db.ListLegalHoldsParams{
+		OrganizationID: organizationID,
+		ActiveOnly:     !includeReleased,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legal holds: %w", err)
+	}
+
+	responses := make([]*LegalHoldResponse, len(holds))
+	for i, hold := range holds {
+		responses[i] = toLegalHoldResponse(hold)
+	}
+	return responses, nil
+}
+
+// ReleaseLegalHold releases an active legal hold. The held content can be
+// deleted again unless another hold covers it.
+func (s *LegalHoldService) ReleaseLegalHold(ctx context.Context, organizationID, holdID, adminID int64) (*LegalHoldResponse, error) {
+	hold, err := s.store.ReleaseLegalHold(ctx, db.ReleaseLegalHoldParams{
+		ID:             holdID,
+		OrganizationID: organizationID,
+		ReleasedBy:     sql.NullInt64{Int64: adminID, Valid: true},
+	})
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to release legal hold: %w", err)
+		}
+
+		// Tell a missing hold apart from one that was already released
+		_, err := s.store.GetLegalHold(ctx, db.GetLegalHoldParams{ID: holdID, OrganizationID: organizationID})
+		if err == sql.ErrNoRows {
+			return nil, errors.New("legal hold not found")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get legal hold: %w", err)
+		}
+		return nil, errors.New("legal hold already released")
+	}
+
+	return toLegalHoldResponse(hold), nil
+}
+
+// ExportLegalHold returns a page of the messages covered by a legal hold,
+// oldest first. For a user these are the messages they sent or received, for
+// a channel every message posted in it. Deleted messages are included.
+func (s *LegalHoldService) ExportLegalHold(ctx context.Context, organizationID, holdID int64, limit, offset int32) (*LegalHoldExportResponse, error) {
+	hold, err := s.store.GetLegalHold(ctx, db.GetLegalHoldParams{ID: holdID, OrganizationID: organizationID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("legal hold not found")
+		}
+		return nil, fmt.Errorf("failed to get legal hold: %w", err)
+	}
+
+	rows, err := s.store.ListLegalHoldMessages(ctx, db.ListLegalHoldMessagesParams{
+		TargetType: hold.TargetType,
+		TargetID:   hold.TargetID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export legal hold messages: %w", err)
+	}
+
+	messages := make([]LegalHoldExportMessage, len(rows))
+	for i, row := range rows {
+		message := LegalHoldExportMessage{
+			ID:          row.ID,
+			WorkspaceID: row.WorkspaceID,
+			SenderID:    row.SenderID,
+			SenderName:  row.SenderFirstName + " " + row.SenderLastName,
+			SenderEmail: row.SenderEmail,
+			Content:     row.Content,
+			ContentType: row.ContentType,
+			MessageType: row.MessageType,
+			CreatedAt:   row.CreatedAt,
+		}
+		if row.ChannelID.Valid {
+			message.ChannelID = &row.ChannelID.Int64
+		}
+		if row.ReceiverID.Valid {
+			message.ReceiverID = &row.ReceiverID.Int64
+		}
+		if row.ThreadID.Valid {
+			message.ThreadID = &row.ThreadID.Int64
+		}
+		if row.EditedAt.Valid {
+			message.EditedAt = &row.EditedAt.Time
+		}
+		if row.DeletedAt.Valid {
+			message.DeletedAt = &row.DeletedAt.Time
+		}
+		messages[i] = message
+	}
+
+	return &LegalHoldExportResponse{
+		Hold:     *toLegalHoldResponse(hold),
+		Messages: messages,
+	}, nil
+}
+
+// ExportArchivedLegalHold returns a page of the archived messages covered by
+// a legal hold, oldest first. Messages under an active hold aren't archived,
+// so these were archived before the hold was placed. Reading archives is
+// slower than exporting messages from the database.
+func (s *LegalHoldService) ExportArchivedLegalHold(ctx context.Context, organizationID, holdID int64, limit, offset int32) (*LegalHoldExportResponse, error) {
+	hold, err := s.store.GetLegalHold(ctx, db.GetLegalHoldParams{ID: holdID, OrganizationID: organizationID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("legal hold not found")
+		}
+		return nil, fmt.Errorf("failed to get legal hold: %w", err)
+	}
+
+	messages := []LegalHoldExportMessage{}
+	if s.archive != nil {
+		messages, err = s.archive.listLegalHoldMessages(ctx, hold, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &LegalHoldExportResponse{
+		Hold:     *toLegalHoldResponse(hold),
+		Messages: messages,
+	}, nil
+}
+
+// toLegalHoldResponse converts a db.LegalHold to a LegalHoldResponse
+func toLegalHoldResponse(hold db.LegalHold) *LegalHoldResponse {
+	response := &LegalHoldResponse{
+		ID:             hold.ID,
+		OrganizationID: hold.OrganizationID,
+		TargetType:     hold.TargetType,
+		TargetID:       hold.TargetID,
+		Reason:         hold.Reason,
+		Active:         !hold.ReleasedAt.Valid,
+		CreatedAt:      hold.CreatedAt,
+	}
+	if hold.CreatedBy.Valid {
+		response.CreatedBy = &hold.CreatedBy.Int64
+	}
+	if hold.ReleasedBy.Valid {
+		response.ReleasedBy = &hold.ReleasedBy.Int64
+	}
+	if hold.ReleasedAt.Valid {
+		response.ReleasedAt = &hold.ReleasedAt.Time
+	}
+	return response
+}
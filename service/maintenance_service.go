@@ -0,0 +1,88 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+)
+
+// WSMaintenance is sent to every WebSocket connection when maintenance starts
+// or ends, and to new connections while it lasts
+const WSMaintenance = "maintenance"
+
+// defaultMaintenanceRetryAfter is how long clients are told to wait when no
+// retry time is configured
+const defaultMaintenanceRetryAfter = 5 * time.Minute
+
+// MaintenanceBroadcaster sends an event to every WebSocket connection
+type MaintenanceBroadcaster interface {
+	BroadcastToAll(message *WSMessage)
+}
+
+// MaintenanceService holds the operator's maintenance toggle. While it is on
+// the API serves reads and rejects writes. The toggle is kept per server, so
+// each server of a deployment must be switched.
+type MaintenanceService struct {
+	hub MaintenanceBroadcaster
+	now func() time.Time
+
+	status MaintenanceResponse
+	mutex  sync.RWMutex
+}
+
+// NewMaintenanceService creates a new maintenance service, in maintenance
+// when MAINTENANCE_MODE is set
+func NewMaintenanceService(hub MaintenanceBroadcaster, config util.Config) *MaintenanceService {
+	s := &MaintenanceService{hub: hub, now: time.Now}
+
+	retryAfter := config.MaintenanceRetryAfter
+	if retryAfter <= 0 {
+		retryAfter = defaultMaintenanceRetryAfter
+	}
+	s.status = MaintenanceResponse{
+		Enabled:           config.MaintenanceMode,
+		Message:           config.MaintenanceMessage,
+		RetryAfterSeconds: int32(retryAfter / time.Second),
+	}
+	if config.MaintenanceMode {
+		startedAt := s.now()
+		s.status.StartedAt = &startedAt
+	}
+	return s
+}
+
+// Status returns whether the server is in maintenance
+func (s *MaintenanceService) Status() MaintenanceResponse {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.status
+}
+
+// UpdateMaintenance turns maintenance on or off and notifies connected clients
+// when it changes. Fields left out of the request keep their values.
+func (s *MaintenanceService) UpdateMaintenance(req UpdateMaintenanceRequest) MaintenanceResponse {
+	s.mutex.Lock()
+	previous := s.status.Enabled
+	s.status.Enabled = *req.Enabled
+	if req.Message != nil {
+		s.status.Message = *req.Message
+	}
+	if req.RetryAfterSeconds != nil {
+		s.status.RetryAfterSeconds = *req.RetryAfterSeconds
+	}
+	switch {
+	case s.status.Enabled && !previous:
+		startedAt := s.now()
+		s.status.StartedAt = &startedAt
+	case !s.status.Enabled:
+		s.status.StartedAt = nil
+	}
+	status := s.status
+	s.mutex.Unlock()
+
+	if status.Enabled != previous && s.hub != nil {
+		s.hub.BroadcastToAll(&WSMessage{Type: WSMaintenance, Data: status})
+	}
+	return status
+}
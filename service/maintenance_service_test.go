@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceService(t *testing.T) {
+	now := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+	hub := &recordingHub{}
+	s := NewMaintenanceService(hub, util.Config{MaintenanceRetryAfter: 2 * time.Minute})
+	s.now = func() time.Time { return now }
+
+	status := s.Status()
+	require.False(t, status.Enabled)
+	require.Equal(t, int32(120), status.RetryAfterSeconds)
+
+	enabled, disabled := true, false
+	message := "Upgrading the database"
+	status = s.UpdateMaintenance(UpdateMaintenanceRequest{Enabled: &enabled, Message: &message})
+	require.True(t, status.Enabled)
+	require.Equal(t, message, status.Message)
+	require.Equal(t, now, *status.StartedAt)
+	require.Len(t, hub.allMessages, 1)
+	require.Equal(t, WSMaintenance, hub.allMessages[0].Type)
+	require.Equal(t, status, hub.allMessages[0].Data)
+
+	// Changing the retry time keeps maintenance going without notifying clients again
+	retryAfter := int32(600)
+	now = now.Add(time.Minute)
+	status = s.UpdateMaintenance(UpdateMaintenanceRequest{Enabled: &enabled, RetryAfterSeconds: &retryAfter})
+	require.Equal(t, int32(600), status.RetryAfterSeconds)
+	require.Equal(t, message, status.Message)
+	require.Equal(t, now.Add(-time.Minute), *status.StartedAt)
+	require.Len(t, hub.allMessages, 1)
+
+	status = s.UpdateMaintenance(UpdateMaintenanceRequest{Enabled: &disabled})
+	require.False(t, status.Enabled)
+	require.Nil(t, status.StartedAt)
+	require.Len(t, hub.allMessages, 2)
+	require.Equal(t, s.Status(), status)
+}
+
+func TestMaintenanceServiceFromConfig(t *testing.T) {
+	s := NewMaintenanceService(nil, util.Config{MaintenanceMode: true, MaintenanceMessage: "Back soon"})
+
+	status := s.Status()
+	require.True(t, status.Enabled)
+	require.Equal(t, "Back soon", status.Message)
+	require.Equal(t, int32(300), status.RetryAfterSeconds)
+	require.NotNil(t, status.StartedAt)
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// mentionPattern matches a mention of a user in message content
+var mentionPattern = regexp.MustCompile(`<@(\d+)>`)
+
+// maxMentionsPerMessage caps the users one message can mention
+const maxMentionsPerMessage = 50
+
+// parseMentions returns the IDs of the users mentioned in content as
+// <@user_id>, in the order they're first mentioned
+func parseMentions(content string) []int64 {
+	var userIDs []int64
+	seen := make(map[int64]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		userID, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		userIDs = append(userIDs, userID)
+
+		if len(userIDs) == maxMentionsPerMessage {
+			break
+		}
+	}
+	return userIDs
+}
+
+// resolveMentions returns the workspace members mentioned in content. Mentions
+// of anyone else are left as plain text.
+func (s *MessageService) resolveMentions(ctx context.Context, workspaceID int64, content string) ([]int64, error) {
+	userIDs := parseMentions(content)
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	members, err := s.store.ListWorkspaceMemberIDs(ctx, db.ListWorkspaceMemberIDsParams{
+		WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true},
+		UserIds:     userIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check mentioned users: %w", err)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members, nil
+}
+
+// mentionContextSize is how many preceding messages of its conversation are
+// shown with each mention
+const mentionContextSize = 2
+
+// GetUserMentions lists the messages in a workspace mentioning the user, newest
+// first, each with the messages leading up to it
+func (s *MessageService) GetUserMentions(ctx context.Context, workspaceID, userID int64, req ListMentionsRequest) (*MentionsResponse, error) {
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	rows, err := s.store.ListUserMentions(ctx, db.ListUserMentionsParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		UnreadOnly:  req.UnreadOnly,
+		Limit:       req.Limit,
+		Offset:      req.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mentions: %w", err)
+	}
+
+	unreadCount, err := s.store.CountUnreadMentions(ctx, db.CountUnreadMentionsParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread mentions: %w", err)
+	}
+
+	settings, err := s.getSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MentionsResponse{
+		Mentions:    make([]*MentionResponse, 0, len(rows)),
+		UnreadCount: unreadCount,
+	}
+	var page []*MessageResponse
+	for _, row := range rows {
+		message := db.GetMessageByIDRow{
+			ID:              row.ID,
+			WorkspaceID:     row.WorkspaceID,
+			ChannelID:       row.ChannelID,
+			SenderID:        row.SenderID,
+			ReceiverID:      row.ReceiverID,
+			Content:         row.Content,
+			MessageType:     row.MessageType,
+			ThreadID:        row.ThreadID,
+			EditedAt:        row.EditedAt,
+			DeletedAt:       row.DeletedAt,
+			CreatedAt:       row.CreatedAt,
+			ContentType:     row.ContentType,
+			DeletedBy:       row.DeletedBy,
+			DeletionNotice:  row.DeletionNotice,
+			SenderFirstName: row.SenderFirstName,
+			SenderLastName:  row.SenderLastName,
+			SenderEmail:     row.SenderEmail,
+		}
+
+		mention := &MentionResponse{
+			Message:     s.toMessageByIDResponse(message),
+			ChannelName: row.ChannelName.String,
+			Permalink:   MessagePermalink(message.WorkspaceID, message.ID, message.ThreadID),
+			Context:     []*MessageResponse{},
+			IsRead:      row.ReadAt.Valid,
+		}
+		if row.ReadAt.Valid {
+			mention.ReadAt = &row.ReadAt.Time
+		}
+
+		preceding, err := s.store.GetMessagesBefore(ctx, messagesBeforeParams(message, !settings.HideDeletedMessages, mentionContextSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get messages before mention: %w", err)
+		}
+		for i := len(preceding) - 1; i >= 0; i-- {
+			mention.Context = append(mention.Context, s.toMessageByIDResponse(db.GetMessageByIDRow(preceding[i])))
+		}
+
+		response.Mentions = append(response.Mentions, mention)
+		page = append(page, mention.Message)
+		page = append(page, mention.Context...)
+	}
+
+	if err := s.attachReactions(ctx, page, userID); err != nil {
+		return nil, err
+	}
+	windows := s.toMessageWindows(settings)
+	for _, message := range page {
+		windows.apply(message)
+	}
+
+	return response, nil
+}
+
+// MarkMentionAsRead clears a mention of the user from their unread mentions
+func (s *MessageService) MarkMentionAsRead(ctx context.Context, messageID, userID int64) (*MentionReadResponse, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("mention not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	mention, err := s.store.MarkMentionRead(ctx, db.MarkMentionReadParams{
+		MessageID: messageID,
+		UserID:    userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("mention not found")
+		}
+		return nil, fmt.Errorf("failed to mark mention as read: %w", err)
+	}
+
+	unreadCount, err := s.store.CountUnreadMentions(ctx, db.CountUnreadMentionsParams{
+		UserID:      userID,
+		WorkspaceID: message.WorkspaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread mentions: %w", err)
+	}
+
+	return &MentionReadResponse{
+		MessageID:   mention.MessageID,
+		ReadAt:      mention.ReadAt.Time,
+		UnreadCount: unreadCount,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMentions(t *testing.T) {
+	require.Nil(t, parseMentions("no mentions here, email me @ work"))
+	require.Equal(t, []int64{12, 7}, parseMentions("<@12> can you check with <@7>? cc <@12>"))
+	require.Nil(t, parseMentions("<@abc> <@> <@99999999999999999999>"))
+
+	var content strings.Builder
+	for i := 1; i <= maxMentionsPerMessage+10; i++ {
+		fmt.Fprintf(&content, "<@%d> ", i)
+	}
+	require.Len(t, parseMentions(content.String()), maxMentionsPerMessage)
+}
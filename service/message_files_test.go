@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageService_MultipleFiles(t *testing.T) {
+	const workspaceID, channelID, senderID = int64(3), int64(7), int64(5)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), db.CheckUserWorkspaceRoleParams{ID: senderID, WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true}}).
+		AnyTimes().
+		Return("member", nil)
+	store.EXPECT().GetUser(gomock.Any(), senderID).AnyTimes().Return(db.User{ID: senderID, FirstName: "Ada"}, nil)
+	store.EXPECT().GetWorkspaceSettings(gomock.Any(), workspaceID).AnyTimes().Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+
+	// Files 11 and 12 are the sender's, 13 belongs to someone else and 14 is
+	// in another workspace
+	for _, fileID := range []int64{11, 12, 13, 14} {
+		store.EXPECT().
+			CheckFileAccess(gomock.Any(), db.CheckFileAccessParams{FileID: fileID, UploaderID: senderID}).
+			AnyTimes().
+			Return(fileID != 13, nil)
+	}
+	for _, fileID := range []int64{11, 12} {
+		store.EXPECT().
+			GetFileWithPermissionCheck(gomock.Any(), db.GetFileWithPermissionCheckParams{ID: fileID, WorkspaceID: workspaceID}).
+			AnyTimes().
+			Return(db.GetFileWithPermissionCheckRow{ID: fileID, WorkspaceID: workspaceID}, nil)
+	}
+	store.EXPECT().
+		GetFileWithPermissionCheck(gomock.Any(), db.GetFileWithPermissionCheckParams{ID: 14, WorkspaceID: workspaceID}).
+		AnyTimes().
+		Return(db.GetFileWithPermissionCheckRow{}, sql.ErrNoRows)
+
+	config := util.Config{MessageMaxFiles: 2}
+	messageService := NewMessageService(store, NewUserService(store, nil, config), nil, config)
+
+	request := func(fileID *int64, fileIDs ...int64) CreateChannelMessageRequest {
+		return CreateChannelMessageRequest{
+			WorkspaceID: workspaceID,
+			ChannelID:   channelID,
+			Content:     "Both reports",
+			ContentType: "file",
+			FileID:      fileID,
+			FileIDs:     fileIDs,
+		}
+	}
+
+	// The single file comes first and repeats are attached once, all in the
+	// transaction that creates the message
+	store.EXPECT().
+		CreateMessageTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg db.CreateMessageTxParams) (db.CreateMessageTxResult, error) {
+			require.Equal(t, []int64{12, 11}, arg.FileIDs)
+
+			result := db.CreateMessageTxResult{
+				Message: db.Message{ID: 40, WorkspaceID: workspaceID, ChannelID: sql.NullInt64{Int64: channelID, Valid: true}, SenderID: senderID},
+				Files: []db.GetMessageFilesRow{
+					{ID: 12, WorkspaceID: workspaceID, UploaderID: senderID},
+					{ID: 11, WorkspaceID: workspaceID, UploaderID: senderID},
+				},
+			}
+			_, err := arg.AfterCreate(result)
+			return result, err
+		})
+
+	fileID := int64(12)
+	message, err := messageService.CreateChannelMessage(ctx, request(&fileID, 11, 12), senderID)
+	require.NoError(t, err)
+	require.Len(t, message.Files, 2)
+	require.Equal(t, int64(12), message.Files[0].ID)
+	require.Equal(t, int64(11), message.Files[1].ID)
+
+	// Every file is checked and nothing is stored if one fails
+	_, err = messageService.CreateChannelMessage(ctx, request(nil, 11, 13), senderID)
+	require.EqualError(t, err, "access denied: you don't have permission to attach file 13")
+
+	_, err = messageService.CreateChannelMessage(ctx, request(nil, 11, 14), senderID)
+	require.EqualError(t, err, "file 14 not found")
+
+	_, err = messageService.CreateChannelMessage(ctx, request(&fileID, 11, 14), senderID)
+	require.EqualError(t, err, "a message can have at most 2 attachments")
+}
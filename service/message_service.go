@@ -8,26 +8,97 @@ import (
 	"time"
 
 	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
 )
 
+// messageServiceStore covers messages, their channels and senders, workspace message settings and
+// the outbox their events are published from
+type messageServiceStore interface {
+	db.ChannelStore
+	db.FileStore
+	db.MessageStore
+	db.OutboxStore
+	db.UserStore
+	db.WorkspaceStore
+}
+
 // MessageService handles message-related business logic
 type MessageService struct {
-	store       db.Store
-	userService *UserService
-	hub         WebSocketHub // Interface for WebSocket hub
+	store               messageServiceStore
+	userService         *UserService
+	hub                 WebSocketHub // Interface for WebSocket hub
+	moderator           MessageModerator
+	archive             *ArchiveService
+	defaultEditWindow   time.Duration
+	defaultDeleteWindow time.Duration
+	defaultMaxPins      int32
+	maxFiles            int
+	// Default time before members who haven't acknowledged a message are reminded
+	acknowledgmentReminderDelay time.Duration
 }
 
 // NewMessageService creates a new message service
-func NewMessageService(store db.Store, userService *UserService, hub WebSocketHub) *MessageService {
+func NewMessageService(store messageServiceStore, userService *UserService, hub WebSocketHub, config util.Config) *MessageService {
+	defaultMaxPins := config.PinMaxPerChannel
+	if defaultMaxPins <= 0 {
+		defaultMaxPins = 100
+	}
+	maxFiles := config.MessageMaxFiles
+	if maxFiles <= 0 {
+		maxFiles = 10
+	}
+	acknowledgmentReminderDelay := config.AcknowledgmentReminderDelay
+	if acknowledgmentReminderDelay <= 0 {
+		acknowledgmentReminderDelay = 24 * time.Hour
+	}
+
 	return &MessageService{
-		store:       store,
-		userService: userService,
-		hub:         hub,
+		store:               store,
+		userService:         userService,
+		hub:                 hub,
+		defaultEditWindow:   max(config.MessageEditWindow, 0),
+		defaultDeleteWindow: max(config.MessageDeleteWindow, 0),
+		defaultMaxPins:      defaultMaxPins,
+		maxFiles:            maxFiles,
+
+		acknowledgmentReminderDelay: acknowledgmentReminderDelay,
+	}
+}
+
+// SetModerator sets the moderator that screens new and edited messages
+func (s *MessageService) SetModerator(moderator MessageModerator) {
+	s.moderator = moderator
+}
+
+// SetArchive sets the archive that history continues into once the messages
+// in the database run out
+func (s *MessageService) SetArchive(archive *ArchiveService) {
+	s.archive = archive
+}
+
+// moderate screens message content with the moderator, if one is set
+func (s *MessageService) moderate(ctx context.Context, workspaceID, authorID int64, content string) (*ModerationDecision, error) {
+	if s.moderator == nil {
+		return &ModerationDecision{Content: content}, nil
+	}
+	return s.moderator.ModerateMessage(ctx, workspaceID, authorID, content)
+}
+
+// recordModeration records the moderation actions taken on a stored message
+func (s *MessageService) recordModeration(ctx context.Context, decision *ModerationDecision, message db.Message) {
+	if s.moderator != nil {
+		s.moderator.RecordModeration(ctx, decision, message.WorkspaceID, message.SenderID, message.ID)
 	}
 }
 
 // SendChannelMessage sends a message to a channel
 func (s *MessageService) SendChannelMessage(ctx context.Context, workspaceID, channelID, senderID int64, content string) (*MessageResponse, error) {
+	return s.sendChannelMessage(ctx, workspaceID, channelID, senderID, content, sql.NullTime{})
+}
+
+// sendChannelMessage sends a message to a channel, asking its members to
+// acknowledge it when acknowledgeBy is set
+func (s *MessageService) sendChannelMessage(ctx context.Context, workspaceID, channelID, senderID int64, content string, acknowledgeBy sql.NullTime) (*MessageResponse, error) {
 	// Verify sender is a workspace member
 	isMember, err := s.userService.IsWorkspaceMember(ctx, senderID, workspaceID)
 	if err != nil {
@@ -37,37 +108,28 @@ func (s *MessageService) SendChannelMessage(ctx context.Context, workspaceID, ch
 		return nil, errors.New("sender is not a member of the workspace")
 	}
 
+	decision, err := s.moderate(ctx, workspaceID, senderID, content)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the message
 	arg := db.CreateChannelMessageParams{
 		WorkspaceID: workspaceID,
 		ChannelID:   sql.NullInt64{Int64: channelID, Valid: true},
 		SenderID:    senderID,
-		Content:     content,
+		Content:     decision.Content,
 		ContentType: "text", // Default to text content type
 	}
 
-	message, err := s.store.CreateChannelMessage(ctx, arg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create channel message: %w", err)
-	}
-
-	messageResponse, err := s.toMessageResponse(ctx, message)
+	message, messageResponse, err := s.createMessage(ctx, db.CreateMessageTxParams{
+		ChannelMessage: &arg,
+		AcknowledgeBy:  acknowledgeBy,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	// Broadcast to WebSocket clients if hub is available
-	if s.hub != nil {
-		wsMessage := &WSMessage{
-			Type:        "message_sent",
-			Data:        messageResponse,
-			WorkspaceID: workspaceID,
-			ChannelID:   &channelID,
-			UserID:      senderID,
-			Timestamp:   time.Now(),
-		}
-		s.hub.BroadcastToChannel(workspaceID, channelID, wsMessage)
-	}
+	s.recordModeration(ctx, decision, message)
 
 	return messageResponse, nil
 }
@@ -91,58 +153,258 @@ func (s *MessageService) SendDirectMessage(ctx context.Context, workspaceID, sen
 		return nil, errors.New("receiver is not a member of the workspace")
 	}
 
+	decision, err := s.moderate(ctx, workspaceID, senderID, content)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the message
 	arg := db.CreateDirectMessageParams{
 		WorkspaceID: workspaceID,
 		SenderID:    senderID,
 		ReceiverID:  sql.NullInt64{Int64: receiverID, Valid: true},
-		Content:     content,
+		Content:     decision.Content,
 		ContentType: "text", // Default to text content type
 	}
 
-	message, err := s.store.CreateDirectMessage(ctx, arg)
+	message, messageResponse, err := s.createMessage(ctx, db.CreateMessageTxParams{DirectMessage: &arg})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create direct message: %w", err)
+		return nil, err
 	}
+	s.recordModeration(ctx, decision, message)
 
-	messageResponse, err := s.toMessageResponse(ctx, message)
+	// Answer on behalf of a receiver who is out of office
+	if senderID != receiverID {
+		if err := s.sendOutOfOfficeReply(ctx, workspaceID, senderID, receiverID); err != nil {
+			// The message itself was delivered, so only log the failed auto-reply
+			fmt.Printf("Error sending out of office reply from user %d: %v\n", receiverID, err)
+		}
+	}
+
+	return messageResponse, nil
+}
+
+// sendOutOfOfficeReply sends the receiver's out-of-office message back to the
+// sender when the receiver is away, at most once per sender per day
+func (s *MessageService) sendOutOfOfficeReply(ctx context.Context, workspaceID, senderID, receiverID int64) error {
+	outOfOffice, err := s.store.GetActiveOutOfOffice(ctx, db.GetActiveOutOfOfficeParams{
+		UserID:      receiverID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("failed to get out of office: %w", err)
+	}
+
+	rows, err := s.store.RecordOutOfOfficeReply(ctx, db.RecordOutOfOfficeReplyParams{
+		UserID:   receiverID,
+		SenderID: senderID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record out of office reply: %w", err)
+	}
+	if rows == 0 {
+		// The sender already got an auto-reply today
+		return nil
+	}
+
+	_, _, err = s.createMessage(ctx, db.CreateMessageTxParams{
+		DirectMessage: &db.CreateDirectMessageParams{
+			WorkspaceID: workspaceID,
+			SenderID:    receiverID,
+			ReceiverID:  sql.NullInt64{Int64: senderID, Valid: true},
+			Content:     outOfOffice.Message,
+			ContentType: "text",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create out of office reply: %w", err)
+	}
+
+	return nil
+}
+
+// createMessage stores a message together with its file links, the
+// workspace members it mentions and the event announcing it, then publishes
+// the event. Channel messages are announced to the channel, direct messages
+// to both participants.
+func (s *MessageService) createMessage(ctx context.Context, arg db.CreateMessageTxParams) (db.Message, *MessageResponse, error) {
+	return s.storeMessage(ctx, arg, true)
+}
+
+// createExternalMessage stores a direct message between people in different
+// organizations like createMessage, except that it mentions nobody: the
+// workspace keeping the conversation is only one participant's.
+func (s *MessageService) createExternalMessage(ctx context.Context, arg db.CreateDirectMessageParams) (db.Message, *MessageResponse, error) {
+	return s.storeMessage(ctx, db.CreateMessageTxParams{DirectMessage: &arg}, false)
+}
+
+func (s *MessageService) storeMessage(ctx context.Context, arg db.CreateMessageTxParams, withMentions bool) (db.Message, *MessageResponse, error) {
+	messageType := "channel"
+	workspaceID, senderID, content := int64(0), int64(0), ""
+	if arg.ChannelMessage != nil {
+		workspaceID, senderID, content = arg.ChannelMessage.WorkspaceID, arg.ChannelMessage.SenderID, arg.ChannelMessage.Content
+	} else if arg.DirectMessage != nil {
+		messageType = "direct"
+		workspaceID, senderID, content = arg.DirectMessage.WorkspaceID, arg.DirectMessage.SenderID, arg.DirectMessage.Content
+	} else if arg.ThreadReply != nil {
+		messageType = arg.ThreadReply.MessageType
+		workspaceID, senderID, content = arg.ThreadReply.WorkspaceID, arg.ThreadReply.SenderID, arg.ThreadReply.Content
+	}
+
+	// The sender is looked up first so the message's event can be written
+	// in the transaction that stores it
+	sender, err := s.userService.GetUser(ctx, senderID)
+	if err != nil {
+		return db.Message{}, nil, fmt.Errorf("failed to get sender info: %w", err)
+	}
+
+	if withMentions {
+		arg.MentionedUserIDs, err = s.resolveMentions(ctx, workspaceID, content)
+		if err != nil {
+			return db.Message{}, nil, err
+		}
+	}
+
+	windows, err := s.getMessageWindows(ctx, workspaceID)
+	if err != nil {
+		return db.Message{}, nil, err
+	}
+
+	var messageResponse *MessageResponse
+	var wsMessage *WSMessage
+	arg.AfterCreate = func(result db.CreateMessageTxResult) (db.CreateOutboxEventParams, error) {
+		message := result.Message
+
+		messageResponse = newMessageResponse(message, sender)
+		messageResponse.Files = toMessageFileResponses(result.Files)
+		messageResponse.Mentions = result.MentionedUserIDs
+		if arg.AcknowledgeBy.Valid {
+			messageResponse.Acknowledgment = &MessageAcknowledgmentStatus{RemindAt: result.AcknowledgmentRequest.RemindAt}
+		}
+		windows.apply(messageResponse)
+
+		wsMessage = &WSMessage{
+			Type:        "message_sent",
+			Data:        messageResponse,
+			WorkspaceID: message.WorkspaceID,
+			UserID:      message.SenderID,
+			Timestamp:   time.Now(),
+		}
+		if message.ChannelID.Valid {
+			wsMessage.ChannelID = &message.ChannelID.Int64
+			return newOutboxEvent(wsMessage)
+		}
+		return newOutboxEvent(wsMessage, message.SenderID, message.ReceiverID.Int64)
+	}
+
+	result, err := s.store.CreateMessageTx(ctx, arg)
+	if err != nil {
+		return db.Message{}, nil, fmt.Errorf("failed to create %s message: %w", messageType, err)
+	}
+
+	s.publishEvent(ctx, result.Event, wsMessage)
+	if arg.ThreadReply != nil {
+		message := result.Message
+		s.broadcastThreadSummary(ctx, result.Thread, message.WorkspaceID, message.ChannelID, message.SenderID, message.ReceiverID, message.SenderID)
+	}
+
+	return result.Message, messageResponse, nil
+}
+
+// publishEvent broadcasts an event written to the outbox with a message. If
+// that fails the outbox relay publishes it later.
+func (s *MessageService) publishEvent(ctx context.Context, event db.EventOutbox, message *WSMessage) {
+	if s.hub == nil || message == nil {
+		return
+	}
+	if err := publishOutboxEvent(ctx, s.store, s.hub, event, message); err != nil {
+		fmt.Printf("Error publishing %s event: %v\n", message.Type, err)
+	}
+}
+
+// NotifyAnyway sends an urgent notification for a direct message to a recipient
+// in Do Not Disturb after the sender confirmed it. Each message can break
+// through once, and only if the recipient allows urgent overrides.
+func (s *MessageService) NotifyAnyway(ctx context.Context, messageID, senderID int64) (*MessageResponse, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if message.SenderID != senderID {
+		return nil, errors.New("access denied: only the sender can notify anyway")
+	}
+	if message.MessageType != "direct" || !message.ReceiverID.Valid {
+		return nil, errors.New("only direct messages can notify anyway")
+	}
+	receiverID := message.ReceiverID.Int64
+
+	dnd, _, active, err := activeDoNotDisturb(ctx, s.store, receiverID, message.WorkspaceID, time.Now())
 	if err != nil {
 		return nil, err
 	}
+	if !active {
+		return nil, errors.New("recipient is not in do not disturb")
+	}
+	if !dnd.AllowUrgentOverride {
+		return nil, errors.New("access denied: recipient does not allow urgent notifications")
+	}
 
-	// Broadcast to WebSocket clients (send to both sender and receiver)
+	rows, err := s.store.CreateDNDOverride(ctx, db.CreateDNDOverrideParams{
+		MessageID:  messageID,
+		SenderID:   senderID,
+		ReceiverID: receiverID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record urgent notification: %w", err)
+	}
+	if rows == 0 {
+		return nil, errors.New("urgent notification already sent")
+	}
+
+	messageResponse := s.toMessageByIDResponse(message)
+
+	// Only the recipient is notified, the sender already has the message
 	if s.hub != nil {
 		wsMessage := &WSMessage{
-			Type:        "message_sent",
+			Type:        "urgent_message",
 			Data:        messageResponse,
-			WorkspaceID: workspaceID,
+			WorkspaceID: message.WorkspaceID,
 			UserID:      senderID,
 			Timestamp:   time.Now(),
 		}
-		s.hub.BroadcastToUser(senderID, wsMessage)
 		s.hub.BroadcastToUser(receiverID, wsMessage)
 	}
 
 	return messageResponse, nil
 }
 
-// GetChannelMessages retrieves messages from a channel with pagination
+// GetChannelMessages retrieves messages from a channel with pagination. Once
+// the channel's messages in the database run out, history continues with its
+// archived messages.
 func (s *MessageService) GetChannelMessages(ctx context.Context, workspaceID, channelID, userID int64, limit, offset int32) ([]*MessageResponse, error) {
-	// Verify user is a workspace member
-	isMember, err := s.userService.IsWorkspaceMember(ctx, userID, workspaceID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check workspace membership: %w", err)
+	if err := s.checkChannelHistoryAccess(ctx, workspaceID, channelID, userID); err != nil {
+		return nil, err
 	}
-	if !isMember {
-		return nil, errors.New("user is not a member of the workspace")
+
+	settings, err := s.getSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get messages
+	// Get messages, with tombstones for deleted ones unless the workspace hides them
 	arg := db.GetChannelMessagesParams{
-		ChannelID:   sql.NullInt64{Int64: channelID, Valid: true},
-		WorkspaceID: workspaceID,
-		Limit:       limit,
-		Offset:      offset,
+		ChannelID:      sql.NullInt64{Int64: channelID, Valid: true},
+		WorkspaceID:    workspaceID,
+		IncludeDeleted: !settings.HideDeletedMessages,
+		Limit:          limit,
+		Offset:         offset,
 	}
 
 	messages, err := s.store.GetChannelMessages(ctx, arg)
@@ -150,26 +412,114 @@ func (s *MessageService) GetChannelMessages(ctx context.Context, workspaceID, ch
 		return nil, fmt.Errorf("failed to get channel messages: %w", err)
 	}
 
-	return s.toChannelMessageResponses(messages), nil
+	responses := s.toChannelMessageResponses(messages)
+	if err := s.attachReactions(ctx, responses, userID); err != nil {
+		return nil, err
+	}
+	if err := s.attachThreadSummaries(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachAcknowledgments(ctx, responses, userID); err != nil {
+		return nil, err
+	}
+	windows := s.toMessageWindows(settings)
+	for _, response := range responses {
+		windows.apply(response)
+	}
+
+	if s.archive != nil && len(responses) < int(limit) {
+		archiveOffset, err := s.archiveOffset(len(responses), offset, func() (int64, error) {
+			return s.store.CountChannelMessages(ctx, db.CountChannelMessagesParams{
+				ChannelID:      arg.ChannelID,
+				WorkspaceID:    workspaceID,
+				IncludeDeleted: arg.IncludeDeleted,
+			})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count channel messages: %w", err)
+		}
+		archived, err := s.archive.listMessages(ctx, channelArchiveConversation(workspaceID, channelID), userID, nil, nil, limit-int32(len(responses)), archiveOffset)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, archived...)
+	}
+
+	return responses, nil
 }
 
-// GetDirectMessages retrieves direct messages between two users
-func (s *MessageService) GetDirectMessages(ctx context.Context, workspaceID, userID, otherUserID int64, limit, offset int32) ([]*MessageResponse, error) {
-	// Verify both users are workspace members
+// GetArchivedChannelMessages retrieves a page of a channel's archived
+// messages, newest first, optionally within a time range
+func (s *MessageService) GetArchivedChannelMessages(ctx context.Context, workspaceID, channelID, userID int64, req GetArchivedMessagesRequest) ([]*MessageResponse, error) {
+	if err := s.checkChannelHistoryAccess(ctx, workspaceID, channelID, userID); err != nil {
+		return nil, err
+	}
+	if s.archive == nil {
+		return []*MessageResponse{}, nil
+	}
+
+	return s.archive.listMessages(ctx, channelArchiveConversation(workspaceID, channelID), userID, req.Before, req.After, req.Limit, req.Offset)
+}
+
+// checkChannelHistoryAccess checks that a user can read a channel's history:
+// they must be a workspace member, and a member of the channel if it is private
+func (s *MessageService) checkChannelHistoryAccess(ctx context.Context, workspaceID, channelID, userID int64) error {
+	// Verify user is a workspace member
 	isMember, err := s.userService.IsWorkspaceMember(ctx, userID, workspaceID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check user workspace membership: %w", err)
+		return fmt.Errorf("failed to check workspace membership: %w", err)
 	}
 	if !isMember {
-		return nil, errors.New("user is not a member of the workspace")
+		return errors.New("user is not a member of the workspace")
 	}
 
-	isOtherMember, err := s.userService.IsWorkspaceMember(ctx, otherUserID, workspaceID)
+	channel, err := s.store.GetChannelByID(ctx, channelID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check other user workspace membership: %w", err)
+		if err == sql.ErrNoRows {
+			return errors.New("channel not found")
+		}
+		return fmt.Errorf("failed to get channel: %w", err)
 	}
-	if !isOtherMember {
-		return nil, errors.New("other user is not a member of the workspace")
+	if channel.WorkspaceID != workspaceID {
+		return errors.New("channel not found")
+	}
+
+	if channel.IsPrivate {
+		isChannelMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+			ChannelID: channel.ID,
+			UserID:    userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check channel membership: %w", err)
+		}
+		if !isChannelMember {
+			return errors.New("access denied: user is not a member of the channel")
+		}
+	}
+
+	return nil
+}
+
+// archiveOffset works out how many archived messages a history page skips,
+// given how many messages it found in the database. Only a page past the end
+// of the database needs the database messages counted.
+func (s *MessageService) archiveOffset(found int, offset int32, count func() (int64, error)) (int32, error) {
+	if found > 0 {
+		return 0, nil
+	}
+	total, err := count()
+	if err != nil {
+		return 0, err
+	}
+	return int32(max(int64(offset)-total, 0)), nil
+}
+
+// GetDirectMessages retrieves direct messages between two users. Once their
+// messages in the database run out, history continues with their archived
+// messages.
+func (s *MessageService) GetDirectMessages(ctx context.Context, workspaceID, userID, otherUserID int64, limit, offset int32) ([]*MessageResponse, error) {
+	if err := s.checkDirectHistoryAccess(ctx, workspaceID, userID, otherUserID); err != nil {
+		return nil, err
 	}
 
 	// Get messages
@@ -186,10 +536,71 @@ func (s *MessageService) GetDirectMessages(ctx context.Context, workspaceID, use
 		return nil, fmt.Errorf("failed to get direct messages: %w", err)
 	}
 
-	return s.toDirectMessageResponses(messages), nil
+	responses := s.toDirectMessageResponses(messages)
+	if err := s.attachReactions(ctx, responses, userID); err != nil {
+		return nil, err
+	}
+	if err := s.attachMessageWindows(ctx, workspaceID, responses); err != nil {
+		return nil, err
+	}
+
+	if s.archive != nil && len(responses) < int(limit) {
+		archiveOffset, err := s.archiveOffset(len(responses), offset, func() (int64, error) {
+			return s.store.CountDirectMessagesBetweenUsers(ctx, db.CountDirectMessagesBetweenUsersParams{
+				WorkspaceID: workspaceID,
+				SenderID:    userID,
+				ReceiverID:  arg.ReceiverID,
+			})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count direct messages: %w", err)
+		}
+		archived, err := s.archive.listMessages(ctx, directArchiveConversation(workspaceID, userID, otherUserID), userID, nil, nil, limit-int32(len(responses)), archiveOffset)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, archived...)
+	}
+
+	return responses, nil
+}
+
+// GetArchivedDirectMessages retrieves a page of the archived direct messages
+// between two users, newest first, optionally within a time range
+func (s *MessageService) GetArchivedDirectMessages(ctx context.Context, workspaceID, userID, otherUserID int64, req GetArchivedMessagesRequest) ([]*MessageResponse, error) {
+	if err := s.checkDirectHistoryAccess(ctx, workspaceID, userID, otherUserID); err != nil {
+		return nil, err
+	}
+	if s.archive == nil {
+		return []*MessageResponse{}, nil
+	}
+
+	return s.archive.listMessages(ctx, directArchiveConversation(workspaceID, userID, otherUserID), userID, req.Before, req.After, req.Limit, req.Offset)
 }
 
-// EditMessage edits a message (only by the author)
+// checkDirectHistoryAccess checks that both users are workspace members
+func (s *MessageService) checkDirectHistoryAccess(ctx context.Context, workspaceID, userID, otherUserID int64) error {
+	isMember, err := s.userService.IsWorkspaceMember(ctx, userID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to check user workspace membership: %w", err)
+	}
+	if !isMember {
+		return errors.New("user is not a member of the workspace")
+	}
+
+	isOtherMember, err := s.userService.IsWorkspaceMember(ctx, otherUserID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to check other user workspace membership: %w", err)
+	}
+	if !isOtherMember {
+		return errors.New("other user is not a member of the workspace")
+	}
+
+	return nil
+}
+
+// EditMessage edits a message (only by the author, within the workspace's
+// edit window unless they're a workspace admin)
 func (s *MessageService) EditMessage(ctx context.Context, messageID, userID int64, newContent string) (*MessageResponse, error) {
 	// Check if user is the author
 	authorID, err := s.store.CheckMessageAuthor(ctx, messageID)
@@ -204,21 +615,42 @@ func (s *MessageService) EditMessage(ctx context.Context, messageID, userID int6
 		return nil, errors.New("only the message author can edit the message")
 	}
 
+	existing, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	windows, err := s.getMessageWindows(ctx, existing.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMessageWindow(ctx, existing, userID, "edited", windows.edit, PermissionManageWorkspace); err != nil {
+		return nil, err
+	}
+
+	// Edits go through moderation like new messages
+	decision, err := s.moderate(ctx, existing.WorkspaceID, userID, newContent)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update the message
 	arg := db.UpdateMessageContentParams{
 		ID:      messageID,
-		Content: newContent,
+		Content: decision.Content,
 	}
 
 	message, err := s.store.UpdateMessageContent(ctx, arg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update message: %w", err)
 	}
+	s.recordModeration(ctx, decision, message)
 
 	messageResponse, err := s.toMessageResponse(ctx, message)
 	if err != nil {
 		return nil, err
 	}
+	windows.apply(messageResponse)
 
 	// Broadcast edit to WebSocket clients
 	if s.hub != nil {
@@ -244,7 +676,8 @@ func (s *MessageService) EditMessage(ctx context.Context, messageID, userID int6
 	return messageResponse, nil
 }
 
-// DeleteMessage soft deletes a message (by author or workspace admin)
+// DeleteMessage soft deletes a message (by the author within the workspace's
+// delete window, or by a workspace admin)
 func (s *MessageService) DeleteMessage(ctx context.Context, messageID, userID int64) error {
 	// Get the message to check author and workspace
 	message, err := s.store.GetMessageByID(ctx, messageID)
@@ -255,51 +688,71 @@ func (s *MessageService) DeleteMessage(ctx context.Context, messageID, userID in
 		return fmt.Errorf("failed to get message: %w", err)
 	}
 
-	// Check if user is the author or workspace admin
+	// Check if user is the author or allowed to delete anyone's messages
 	isAuthor := message.SenderID == userID
-	isAdmin := false
+	canDeleteAny := false
 
 	if !isAuthor {
-		// Check if user is workspace admin
-		var workspaceAdminErr error
-		isAdmin, workspaceAdminErr = s.userService.IsWorkspaceAdmin(ctx, userID, message.WorkspaceID)
-		if workspaceAdminErr != nil {
-			return fmt.Errorf("failed to check workspace admin status: %w", workspaceAdminErr)
+		var permissionErr error
+		canDeleteAny, permissionErr = s.userService.HasWorkspacePermission(ctx, userID, message.WorkspaceID, PermissionDeleteAnyMessage)
+		if permissionErr != nil {
+			return fmt.Errorf("failed to check workspace permissions: %w", permissionErr)
 		}
 	}
 
-	if !isAuthor && !isAdmin {
+	if !isAuthor && !canDeleteAny {
 		return errors.New("only the message author or workspace admin can delete the message")
 	}
 
-	// Soft delete the message
-	err = s.store.SoftDeleteMessage(ctx, messageID)
+	if isAuthor {
+		windows, err := s.getMessageWindows(ctx, message.WorkspaceID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkMessageWindow(ctx, message, userID, "deleted", windows.delete, PermissionDeleteAnyMessage); err != nil {
+			return err
+		}
+	}
+
+	// Soft delete the message, leaving a tombstone in history
+	err = s.store.SoftDeleteMessage(ctx, db.SoftDeleteMessageParams{
+		ID:        messageID,
+		DeletedBy: sql.NullInt64{Int64: userID, Valid: true},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete message: %w", err)
 	}
 
 	// Broadcast deletion to WebSocket clients
-	if s.hub != nil {
-		wsMessage := &WSMessage{
-			Type:        "message_deleted",
-			Data:        map[string]interface{}{"message_id": messageID},
-			WorkspaceID: message.WorkspaceID,
-			UserID:      userID,
-			Timestamp:   time.Now(),
-		}
+	s.broadcastDeletion(message, userID)
+	s.refreshThread(ctx, message, userID)
 
-		if message.ChannelID.Valid {
-			channelID := message.ChannelID.Int64
-			wsMessage.ChannelID = &channelID
-			s.hub.BroadcastToChannel(message.WorkspaceID, channelID, wsMessage)
-		} else if message.ReceiverID.Valid {
-			// Direct message - broadcast to both sender and receiver
-			s.hub.BroadcastToUser(message.SenderID, wsMessage)
-			s.hub.BroadcastToUser(message.ReceiverID.Int64, wsMessage)
-		}
+	return nil
+}
+
+// broadcastDeletion tells WebSocket clients that a message was deleted
+func (s *MessageService) broadcastDeletion(message db.GetMessageByIDRow, deletedBy int64) {
+	if s.hub == nil {
+		return
 	}
 
-	return nil
+	wsMessage := &WSMessage{
+		Type:        "message_deleted",
+		Data:        map[string]interface{}{"message_id": message.ID, "deleted_by": deletedBy},
+		WorkspaceID: message.WorkspaceID,
+		UserID:      deletedBy,
+		Timestamp:   time.Now(),
+	}
+
+	if message.ChannelID.Valid {
+		channelID := message.ChannelID.Int64
+		wsMessage.ChannelID = &channelID
+		s.hub.BroadcastToChannel(message.WorkspaceID, channelID, wsMessage)
+	} else if message.ReceiverID.Valid {
+		// Direct message - broadcast to both sender and receiver
+		s.hub.BroadcastToUser(message.SenderID, wsMessage)
+		s.hub.BroadcastToUser(message.ReceiverID.Int64, wsMessage)
+	}
 }
 
 // GetMessage retrieves a single message
@@ -312,7 +765,170 @@ func (s *MessageService) GetMessage(ctx context.Context, messageID, userID int64
 		return nil, fmt.Errorf("failed to get message: %w", err)
 	}
 
-	// Check if user has access to this message
+	if err := s.checkMessageAccess(ctx, message, userID); err != nil {
+		return nil, err
+	}
+
+	response := s.toMessageByIDResponse(message)
+	response.Permalink = MessagePermalink(message.WorkspaceID, message.ID, message.ThreadID)
+	if err := s.attachMessageWindows(ctx, message.WorkspaceID, []*MessageResponse{response}); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ErrAmbiguousMessageTime is returned when a conversation has more than one
+// message at the instant looked up, so the instant doesn't name a message
+var ErrAmbiguousMessageTime = errors.New("more than one message was sent at that time")
+
+// GetChannelMessageAt retrieves the channel message posted at an instant, for
+// APIs that name messages by their time, such as Slack's ts values
+func (s *MessageService) GetChannelMessageAt(ctx context.Context, workspaceID, channelID, userID int64, createdAt time.Time) (*MessageResponse, error) {
+	messageIDs, err := s.store.ListChannelMessageIDsAt(ctx, db.ListChannelMessageIDsAtParams{
+		ChannelID:   sql.NullInt64{Int64: channelID, Valid: true},
+		WorkspaceID: workspaceID,
+		CreatedAt:   createdAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return s.getMessageAt(ctx, messageIDs, userID)
+}
+
+// GetDirectMessageAt retrieves the direct message between two users sent at an
+// instant, as GetChannelMessageAt does for channels
+func (s *MessageService) GetDirectMessageAt(ctx context.Context, workspaceID, userID, otherUserID int64, createdAt time.Time) (*MessageResponse, error) {
+	messageIDs, err := s.store.ListDirectMessageIDsAt(ctx, db.ListDirectMessageIDsAtParams{
+		WorkspaceID: workspaceID,
+		SenderID:    userID,
+		ReceiverID:  sql.NullInt64{Int64: otherUserID, Valid: true},
+		CreatedAt:   createdAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return s.getMessageAt(ctx, messageIDs, userID)
+}
+
+// getMessageAt retrieves the message sent at an instant, refusing to pick one
+// when several were sent at the same instant
+func (s *MessageService) getMessageAt(ctx context.Context, messageIDs []int64, userID int64) (*MessageResponse, error) {
+	switch len(messageIDs) {
+	case 0:
+		return nil, errors.New("message not found")
+	case 1:
+		return s.GetMessage(ctx, messageIDs[0], userID)
+	default:
+		return nil, ErrAmbiguousMessageTime
+	}
+}
+
+// GetMessageContext retrieves a message together with the messages around it in the
+// same conversation, so a permalink can be opened in place. Thread replies are shown
+// within their thread; other messages within their channel or direct conversation.
+func (s *MessageService) GetMessageContext(ctx context.Context, messageID, userID int64, before, after int32) (*MessageContextResponse, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if err := s.checkMessageAccess(ctx, message, userID); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSettings(ctx, message.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deleted messages show up as tombstones so threads keep their shape
+	beforeArg := messagesBeforeParams(message, !settings.HideDeletedMessages, before)
+
+	response := &MessageContextResponse{
+		Message:   s.toMessageByIDResponse(message),
+		Permalink: MessagePermalink(message.WorkspaceID, message.ID, message.ThreadID),
+		Before:    []*MessageResponse{},
+		After:     []*MessageResponse{},
+	}
+
+	if before > 0 {
+		messages, err := s.store.GetMessagesBefore(ctx, beforeArg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get messages before: %w", err)
+		}
+		response.HasMoreBefore = int32(len(messages)) == before
+		// Return context in chronological order
+		for i := len(messages) - 1; i >= 0; i-- {
+			response.Before = append(response.Before, s.toMessageByIDResponse(db.GetMessageByIDRow(messages[i])))
+		}
+	}
+
+	if after > 0 {
+		messages, err := s.store.GetMessagesAfter(ctx, db.GetMessagesAfterParams(beforeArg))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get messages after: %w", err)
+		}
+		response.HasMoreAfter = int32(len(messages)) == after
+		for _, message := range messages {
+			response.After = append(response.After, s.toMessageByIDResponse(db.GetMessageByIDRow(message)))
+		}
+	}
+
+	page := append([]*MessageResponse{response.Message}, response.Before...)
+	page = append(page, response.After...)
+	if err := s.attachReactions(ctx, page, userID); err != nil {
+		return nil, err
+	}
+	windows := s.toMessageWindows(settings)
+	for _, response := range page {
+		windows.apply(response)
+	}
+
+	return response, nil
+}
+
+// messagesBeforeParams scopes GetMessagesBefore, and GetMessagesAfter, to the
+// conversation of an anchor message
+func messagesBeforeParams(message db.GetMessageByIDRow, includeDeleted bool, limit int32) db.GetMessagesBeforeParams {
+	arg := db.GetMessagesBeforeParams{
+		WorkspaceID:    message.WorkspaceID,
+		CreatedAt:      message.CreatedAt,
+		MessageID:      message.ID,
+		ThreadID:       message.ThreadID,
+		IncludeDeleted: includeDeleted,
+		Limit:          limit,
+	}
+	if !message.ThreadID.Valid {
+		if message.MessageType == "direct" {
+			arg.UserA = message.SenderID
+			arg.UserB = message.ReceiverID.Int64
+		} else {
+			arg.ChannelID = message.ChannelID
+		}
+	}
+	return arg
+}
+
+// MessagePermalink builds the stable permalink of a message. Thread replies carry
+// their thread so clients can open the thread view directly.
+func MessagePermalink(workspaceID, messageID int64, threadID sql.NullInt64) string {
+	permalink := fmt.Sprintf("/workspace/%d/messages/%d", workspaceID, messageID)
+	if threadID.Valid {
+		permalink += fmt.Sprintf("?thread=%d", threadID.Int64)
+	}
+	return permalink
+}
+
+// checkMessageAccess checks whether a user can read a message. Direct messages are
+// limited to their participants, private channel messages to channel members and
+// other channel messages to workspace members.
+func (s *MessageService) checkMessageAccess(ctx context.Context, message db.GetMessageByIDRow, userID int64) error {
 	if message.MessageType == "direct" {
 		// For direct messages, user must be sender or receiver
 		receiverID := int64(0)
@@ -321,20 +937,43 @@ func (s *MessageService) GetMessage(ctx context.Context, messageID, userID int64
 		}
 
 		if message.SenderID != userID && receiverID != userID {
-			return nil, errors.New("access denied: user is not part of this conversation")
+			return errors.New("access denied: user is not part of this conversation")
 		}
-	} else {
-		// For channel messages, user must be workspace member
-		isMember, err := s.userService.IsWorkspaceMember(ctx, userID, message.WorkspaceID)
+		return nil
+	}
+
+	// For channel messages, user must be workspace member
+	isMember, err := s.userService.IsWorkspaceMember(ctx, userID, message.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to check workspace membership: %w", err)
+	}
+	if !isMember {
+		return errors.New("access denied: user is not a member of the workspace")
+	}
+
+	if !message.ChannelID.Valid {
+		return nil
+	}
+
+	channel, err := s.store.GetChannelByID(ctx, message.ChannelID.Int64)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel.IsPrivate {
+		isChannelMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+			ChannelID: channel.ID,
+			UserID:    userID,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to check workspace membership: %w", err)
+			return fmt.Errorf("failed to check channel membership: %w", err)
 		}
-		if !isMember {
-			return nil, errors.New("access denied: user is not a member of the workspace")
+		if !isChannelMember {
+			return errors.New("access denied: user is not a member of the channel")
 		}
 	}
 
-	return s.toMessageByIDResponse(message), nil
+	return nil
 }
 
 // Helper function to convert db message to response with sender info
@@ -345,6 +984,11 @@ func (s *MessageService) toMessageResponse(ctx context.Context, message db.Messa
 		return nil, fmt.Errorf("failed to get sender info: %w", err)
 	}
 
+	return newMessageResponse(message, sender), nil
+}
+
+// newMessageResponse converts a db message to a response with the given sender
+func newMessageResponse(message db.Message, sender UserResponse) *MessageResponse {
 	response := &MessageResponse{
 		ID:          message.ID,
 		WorkspaceID: message.WorkspaceID,
@@ -371,7 +1015,43 @@ func (s *MessageService) toMessageResponse(ctx context.Context, message db.Messa
 		response.EditedAt = &message.EditedAt.Time
 	}
 
-	return response, nil
+	return response
+}
+
+// toMessageFileResponses converts the files attached to a message to responses
+func toMessageFileResponses(files []db.GetMessageFilesRow) []*FileResponse {
+	if len(files) == 0 {
+		return nil
+	}
+
+	fileResponses := make([]*FileResponse, len(files))
+	for i, file := range files {
+		fileResponses[i] = &FileResponse{
+			ID:               file.ID,
+			OriginalFilename: file.OriginalFilename,
+			FileSize:         file.FileSize,
+			MimeType:         file.MimeType,
+			DownloadURL:      fmt.Sprintf("/api/files/%d/download", file.ID),
+			CreatedAt:        file.CreatedAt,
+			IsPublic:         file.IsPublic,
+			DownloadCount:    file.DownloadCount,
+			PreviewStatus:    file.PreviewStatus.String,
+			Uploader: UserResponse{
+				ID:        file.UploaderID,
+				Email:     file.UploaderEmail,
+				FirstName: file.UploaderFirstName,
+				LastName:  file.UploaderLastName,
+			},
+		}
+
+		if file.ThumbnailPath.Valid {
+			fileResponses[i].ThumbnailURL = fmt.Sprintf("/api/files/%d/thumbnail", file.ID)
+		}
+		setVoiceMessage(fileResponses[i], file.IsVoiceMessage, file.DurationMs, file.Waveform)
+		setVideoProcessing(fileResponses[i], file.ProcessingStatus, file.TranscodedPath, file.PosterPath)
+		setPublicURL(fileResponses[i])
+	}
+	return fileResponses
 }
 
 // Helper function to convert channel message rows to responses
@@ -408,6 +1088,7 @@ func (s *MessageService) toChannelMessageResponses(messages []db.GetChannelMessa
 		if message.EditedAt.Valid {
 			response.EditedAt = &message.EditedAt.Time
 		}
+		setTombstone(response, message.DeletedAt, message.DeletedBy, message.DeletionNotice)
 
 		responses[i] = response
 	}
@@ -448,6 +1129,7 @@ func (s *MessageService) toDirectMessageResponses(messages []db.GetDirectMessage
 		if message.EditedAt.Valid {
 			response.EditedAt = &message.EditedAt.Time
 		}
+		setTombstone(response, message.DeletedAt, message.DeletedBy, message.DeletionNotice)
 
 		responses[i] = response
 	}
@@ -486,14 +1168,80 @@ func (s *MessageService) toMessageByIDResponse(message db.GetMessageByIDRow) *Me
 	if message.EditedAt.Valid {
 		response.EditedAt = &message.EditedAt.Time
 	}
+	setTombstone(response, message.DeletedAt, message.DeletedBy, message.DeletionNotice)
 
 	return response
 }
 
-// CreateChannelMessage creates a new channel message (with optional file attachment)
-func (s *MessageService) CreateChannelMessage(req CreateChannelMessageRequest, senderID int64) (*MessageResponse, error) {
+// setTombstone turns the response for a deleted message into a tombstone,
+// keeping its place in history but none of its content
+func setTombstone(response *MessageResponse, deletedAt sql.NullTime, deletedBy sql.NullInt64, deletionNotice sql.NullString) {
+	if !deletedAt.Valid {
+		return
+	}
+
+	response.Content = ""
+	response.DeletedAt = &deletedAt.Time
+	if deletedBy.Valid {
+		response.DeletedBy = &deletedBy.Int64
+	}
+	response.DeletionNotice = deletionNotice.String
+}
+
+// attachmentIDs lists the files attached to a new message, the single file
+// first, without duplicates. Each file has to be in the message's workspace
+// and accessible to the sender.
+func (s *MessageService) attachmentIDs(ctx context.Context, workspaceID, senderID int64, fileID *int64, fileIDs []int64) ([]int64, error) {
+	requested := fileIDs
+	if fileID != nil {
+		requested = append([]int64{*fileID}, fileIDs...)
+	}
+
+	var attached []int64
+	seen := make(map[int64]bool, len(requested))
+	for _, id := range requested {
+		if !seen[id] {
+			seen[id] = true
+			attached = append(attached, id)
+		}
+	}
+	if len(attached) > s.maxFiles {
+		return nil, fmt.Errorf("a message can have at most %d attachments", s.maxFiles)
+	}
+
+	for _, id := range attached {
+		hasAccess, err := s.store.CheckFileAccess(ctx, db.CheckFileAccessParams{
+			FileID:     id,
+			UploaderID: senderID,
+		})
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("file %d not found", id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check file access: %w", err)
+		}
+		if !hasAccess {
+			return nil, fmt.Errorf("access denied: you don't have permission to attach file %d", id)
+		}
+
+		_, err = s.store.GetFileWithPermissionCheck(ctx, db.GetFileWithPermissionCheckParams{
+			ID:          id,
+			WorkspaceID: workspaceID,
+		})
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("file %d not found", id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file: %w", err)
+		}
+	}
+
+	return attached, nil
+}
+
+// CreateChannelMessage creates a new channel message with optional file attachments
+func (s *MessageService) CreateChannelMessage(ctx context.Context, req CreateChannelMessageRequest, senderID int64) (*MessageResponse, error) {
 	// Verify sender is a workspace member
-	ctx := context.Background()
 	isMember, err := s.userService.IsWorkspaceMember(ctx, senderID, req.WorkspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check workspace membership: %w", err)
@@ -502,87 +1250,40 @@ func (s *MessageService) CreateChannelMessage(req CreateChannelMessageRequest, s
 		return nil, errors.New("sender is not a member of the workspace")
 	}
 
+	decision, err := s.moderate(ctx, req.WorkspaceID, senderID, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the message
 	createMessageParams := db.CreateChannelMessageParams{
 		WorkspaceID: req.WorkspaceID,
 		ChannelID:   sql.NullInt64{Int64: req.ChannelID, Valid: true},
 		SenderID:    senderID,
-		Content:     req.Content,
+		Content:     decision.Content,
 		ContentType: req.ContentType,
 	}
 
-	message, err := s.store.CreateChannelMessage(ctx, createMessageParams)
+	fileIDs, err := s.attachmentIDs(ctx, req.WorkspaceID, senderID, req.FileID, req.FileIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create channel message: %w", err)
-	}
-
-	// If file is attached, create message-file relationship
-	if req.FileID != nil {
-		_, err = s.store.CreateMessageFile(ctx, db.CreateMessageFileParams{
-			MessageID: message.ID,
-			FileID:    *req.FileID,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to link file to message: %w", err)
-		}
+		return nil, err
 	}
 
-	// Build response
-	messageResponse, err := s.toMessageResponse(ctx, message)
+	message, messageResponse, err := s.createMessage(ctx, db.CreateMessageTxParams{
+		ChannelMessage: &createMessageParams,
+		FileIDs:        fileIDs,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	// Add file information if present
-	if req.FileID != nil {
-		files, err := s.store.GetMessageFiles(ctx, message.ID)
-		if err == nil && len(files) > 0 {
-			fileResponses := make([]*FileResponse, len(files))
-			for i, file := range files {
-				fileResponses[i] = &FileResponse{
-					ID:               file.ID,
-					OriginalFilename: file.OriginalFilename,
-					FileSize:         file.FileSize,
-					MimeType:         file.MimeType,
-					DownloadURL:      fmt.Sprintf("/api/files/%d/download", file.ID),
-					CreatedAt:        file.CreatedAt,
-					IsPublic:         file.IsPublic,
-					Uploader: UserResponse{
-						ID:        file.UploaderID,
-						Email:     file.UploaderEmail,
-						FirstName: file.UploaderFirstName,
-						LastName:  file.UploaderLastName,
-					},
-				}
-
-				if file.ThumbnailPath.Valid {
-					fileResponses[i].ThumbnailURL = fmt.Sprintf("/api/files/%d/thumbnail", file.ID)
-				}
-			}
-			messageResponse.Files = fileResponses
-		}
-	}
-
-	// Broadcast to WebSocket clients if hub is available
-	if s.hub != nil {
-		wsMessage := &WSMessage{
-			Type:        "message_sent",
-			Data:        messageResponse,
-			WorkspaceID: req.WorkspaceID,
-			ChannelID:   &req.ChannelID,
-			UserID:      senderID,
-			Timestamp:   time.Now(),
-		}
-		s.hub.BroadcastToChannel(req.WorkspaceID, req.ChannelID, wsMessage)
-	}
+	s.recordModeration(ctx, decision, message)
 
 	return messageResponse, nil
 }
 
-// CreateDirectMessage creates a new direct message (with optional file attachment)
-func (s *MessageService) CreateDirectMessage(req CreateDirectMessageRequest, senderID int64) (*MessageResponse, error) {
+// CreateDirectMessage creates a new direct message with optional file attachments
+func (s *MessageService) CreateDirectMessage(ctx context.Context, req CreateDirectMessageRequest, senderID int64) (*MessageResponse, error) {
 	// Verify sender is a workspace member
-	ctx := context.Background()
 	isMember, err := s.userService.IsWorkspaceMember(ctx, senderID, req.WorkspaceID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check sender workspace membership: %w", err)
@@ -600,79 +1301,33 @@ func (s *MessageService) CreateDirectMessage(req CreateDirectMessageRequest, sen
 		return nil, errors.New("receiver is not a member of the workspace")
 	}
 
+	decision, err := s.moderate(ctx, req.WorkspaceID, senderID, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the message
 	createMessageParams := db.CreateDirectMessageParams{
 		WorkspaceID: req.WorkspaceID,
 		SenderID:    senderID,
 		ReceiverID:  sql.NullInt64{Int64: req.ReceiverID, Valid: true},
-		Content:     req.Content,
+		Content:     decision.Content,
 		ContentType: req.ContentType,
 	}
 
-	message, err := s.store.CreateDirectMessage(ctx, createMessageParams)
+	fileIDs, err := s.attachmentIDs(ctx, req.WorkspaceID, senderID, req.FileID, req.FileIDs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create direct message: %w", err)
-	}
-
-	// If file is attached, create message-file relationship
-	if req.FileID != nil {
-		_, err = s.store.CreateMessageFile(ctx, db.CreateMessageFileParams{
-			MessageID: message.ID,
-			FileID:    *req.FileID,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to link file to message: %w", err)
-		}
+		return nil, err
 	}
 
-	// Build response
-	messageResponse, err := s.toMessageResponse(ctx, message)
+	message, messageResponse, err := s.createMessage(ctx, db.CreateMessageTxParams{
+		DirectMessage: &createMessageParams,
+		FileIDs:       fileIDs,
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	// Add file information if present
-	if req.FileID != nil {
-		files, err := s.store.GetMessageFiles(ctx, message.ID)
-		if err == nil && len(files) > 0 {
-			fileResponses := make([]*FileResponse, len(files))
-			for i, file := range files {
-				fileResponses[i] = &FileResponse{
-					ID:               file.ID,
-					OriginalFilename: file.OriginalFilename,
-					FileSize:         file.FileSize,
-					MimeType:         file.MimeType,
-					DownloadURL:      fmt.Sprintf("/api/files/%d/download", file.ID),
-					CreatedAt:        file.CreatedAt,
-					IsPublic:         file.IsPublic,
-					Uploader: UserResponse{
-						ID:        file.UploaderID,
-						Email:     file.UploaderEmail,
-						FirstName: file.UploaderFirstName,
-						LastName:  file.UploaderLastName,
-					},
-				}
-
-				if file.ThumbnailPath.Valid {
-					fileResponses[i].ThumbnailURL = fmt.Sprintf("/api/files/%d/thumbnail", file.ID)
-				}
-			}
-			messageResponse.Files = fileResponses
-		}
-	}
-
-	// Broadcast to WebSocket clients (send to both sender and receiver)
-	if s.hub != nil {
-		wsMessage := &WSMessage{
-			Type:        "message_sent",
-			Data:        messageResponse,
-			WorkspaceID: req.WorkspaceID,
-			UserID:      senderID,
-			Timestamp:   time.Now(),
-		}
-		s.hub.BroadcastToUser(senderID, wsMessage)
-		s.hub.BroadcastToUser(req.ReceiverID, wsMessage)
-	}
+	s.recordModeration(ctx, decision, message)
 
 	return messageResponse, nil
 }
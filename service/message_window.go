@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// messageWindows are how long after sending members may edit and delete
+// their messages in a workspace, 0 meaning there is no limit
+type messageWindows struct {
+	edit   time.Duration
+	delete time.Duration
+}
+
+// apply sets when the message stops being editable and deletable by its
+// author, so clients can disable the actions once the window has passed
+func (w messageWindows) apply(response *MessageResponse) {
+	if response.DeletedAt != nil {
+		return
+	}
+	if w.edit > 0 {
+		editableUntil := response.CreatedAt.Add(w.edit)
+		response.EditableUntil = &editableUntil
+	}
+	if w.delete > 0 {
+		deletableUntil := response.CreatedAt.Add(w.delete)
+		response.DeletableUntil = &deletableUntil
+	}
+}
+
+// GetMessageSettings returns the edit and delete windows of a workspace,
+// whether it hides deleted messages and its pin limit
+func (s *MessageService) GetMessageSettings(ctx context.Context, workspaceID int64) (*MessageSettingsResponse, error) {
+	settings, err := s.getSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toMessageSettingsResponse(settings), nil
+}
+
+// UpdateMessageSettings sets the edit and delete windows of a workspace,
+// whether deleted messages leave tombstones in history and its pin limit.
+// Leaving a window or the limit empty restores the server default.
+func (s *MessageService) UpdateMessageSettings(ctx context.Context, workspaceID int64, req UpdateMessageSettingsRequest) (*MessageSettingsResponse, error) {
+	arg := db.UpsertWorkspaceMessageSettingsParams{
+		WorkspaceID:         workspaceID,
+		HideDeletedMessages: req.HideDeletedMessages,
+	}
+	if req.MessageEditWindowMinutes != nil {
+		arg.MessageEditWindowMinutes = sql.NullInt32{Int32: *req.MessageEditWindowMinutes, Valid: true}
+	}
+	if req.MessageDeleteWindowMinutes != nil {
+		arg.MessageDeleteWindowMinutes = sql.NullInt32{Int32: *req.MessageDeleteWindowMinutes, Valid: true}
+	}
+	if req.MaxPinsPerChannel != nil {
+		arg.MaxPinsPerChannel = sql.NullInt32{Int32: *req.MaxPinsPerChannel, Valid: true}
+	}
+
+	settings, err := s.store.UpsertWorkspaceMessageSettings(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update message settings: %w", err)
+	}
+
+	return s.toMessageSettingsResponse(settings), nil
+}
+
+// checkMessageWindow checks that a message is still within the window in
+// which its author may change it. Users with the exempting permission, such
+// as workspace admins, may change their messages at any time.
+func (s *MessageService) checkMessageWindow(ctx context.Context, message db.GetMessageByIDRow, userID int64, action string, window time.Duration, exemptPermission string) error {
+	if window <= 0 || time.Since(message.CreatedAt) <= window {
+		return nil
+	}
+
+	exempt, err := s.userService.HasWorkspacePermission(ctx, userID, message.WorkspaceID, exemptPermission)
+	if err != nil {
+		return fmt.Errorf("failed to check workspace permissions: %w", err)
+	}
+	if exempt {
+		return nil
+	}
+
+	return fmt.Errorf("access denied: messages can only be %s within %d minutes of being sent", action, int64(window/time.Minute))
+}
+
+// attachMessageWindows sets the edit and delete deadlines of messages from
+// one workspace
+func (s *MessageService) attachMessageWindows(ctx context.Context, workspaceID int64, messages []*MessageResponse) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	windows, err := s.getMessageWindows(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		windows.apply(message)
+	}
+
+	return nil
+}
+
+// getMessageWindows resolves the edit and delete windows in effect in a workspace
+func (s *MessageService) getMessageWindows(ctx context.Context, workspaceID int64) (messageWindows, error) {
+	settings, err := s.getSettings(ctx, workspaceID)
+	if err != nil {
+		return messageWindows{}, err
+	}
+
+	return s.toMessageWindows(settings), nil
+}
+
+// toMessageWindows resolves the edit and delete windows set in workspace
+// settings, falling back to the server defaults
+func (s *MessageService) toMessageWindows(settings db.WorkspaceSetting) messageWindows {
+	windows := messageWindows{edit: s.defaultEditWindow, delete: s.defaultDeleteWindow}
+	if settings.MessageEditWindowMinutes.Valid {
+		windows.edit = time.Duration(settings.MessageEditWindowMinutes.Int32) * time.Minute
+	}
+	if settings.MessageDeleteWindowMinutes.Valid {
+		windows.delete = time.Duration(settings.MessageDeleteWindowMinutes.Int32) * time.Minute
+	}
+
+	return windows
+}
+
+// maxPins resolves how many messages a channel can pin under workspace
+// settings, falling back to the server default
+func (s *MessageService) maxPins(settings db.WorkspaceSetting) int32 {
+	if settings.MaxPinsPerChannel.Valid {
+		return settings.MaxPinsPerChannel.Int32
+	}
+	return s.defaultMaxPins
+}
+
+func (s *MessageService) getSettings(ctx context.Context, workspaceID int64) (db.WorkspaceSetting, error) {
+	settings, err := s.store.GetWorkspaceSettings(ctx, workspaceID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return db.WorkspaceSetting{}, fmt.Errorf("failed to get workspace settings: %w", err)
+		}
+		// No settings yet means the defaults apply
+		settings = db.WorkspaceSetting{WorkspaceID: workspaceID}
+	}
+
+	return settings, nil
+}
+
+// toMessageSettingsResponse converts workspace settings to a message settings
+// response, resolving the windows that are actually in effect
+func (s *MessageService) toMessageSettingsResponse(settings db.WorkspaceSetting) *MessageSettingsResponse {
+	response := &MessageSettingsResponse{
+		WorkspaceID:                         settings.WorkspaceID,
+		EffectiveMessageEditWindowMinutes:   int32(s.defaultEditWindow / time.Minute),
+		EffectiveMessageDeleteWindowMinutes: int32(s.defaultDeleteWindow / time.Minute),
+		HideDeletedMessages:                 settings.HideDeletedMessages,
+		EffectiveMaxPinsPerChannel:          s.maxPins(settings),
+	}
+
+	if settings.MessageEditWindowMinutes.Valid {
+		response.MessageEditWindowMinutes = &settings.MessageEditWindowMinutes.Int32
+		response.EffectiveMessageEditWindowMinutes = settings.MessageEditWindowMinutes.Int32
+	}
+
+	if settings.MessageDeleteWindowMinutes.Valid {
+		response.MessageDeleteWindowMinutes = &settings.MessageDeleteWindowMinutes.Int32
+		response.EffectiveMessageDeleteWindowMinutes = settings.MessageDeleteWindowMinutes.Int32
+	}
+
+	if settings.MaxPinsPerChannel.Valid {
+		response.MaxPinsPerChannel = &settings.MaxPinsPerChannel.Int32
+	}
+
+	return response
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageService_MessageWindows(t *testing.T) {
+	const workspaceID, memberID, adminID = int64(2), int64(5), int64(8)
+	ctx := context.Background()
+	recent := db.GetMessageByIDRow{ID: 20, WorkspaceID: workspaceID, SenderID: memberID, CreatedAt: time.Now().Add(-30 * time.Minute)}
+	old := db.GetMessageByIDRow{ID: 21, WorkspaceID: workspaceID, SenderID: memberID, CreatedAt: time.Now().Add(-2 * time.Hour)}
+	adminOld := db.GetMessageByIDRow{ID: 22, WorkspaceID: workspaceID, SenderID: adminID, CreatedAt: time.Now().Add(-2 * time.Hour)}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	for _, message := range []db.GetMessageByIDRow{recent, old, adminOld} {
+		store.EXPECT().GetMessageByID(gomock.Any(), message.ID).AnyTimes().Return(message, nil)
+		store.EXPECT().CheckMessageAuthor(gomock.Any(), message.ID).AnyTimes().Return(message.SenderID, nil)
+	}
+	// The workspace sets the edit window, the delete window is the server default
+	store.EXPECT().
+		GetWorkspaceSettings(gomock.Any(), workspaceID).
+		AnyTimes().
+		Return(db.WorkspaceSetting{WorkspaceID: workspaceID, MessageEditWindowMinutes: sql.NullInt32{Int32: 15, Valid: true}}, nil)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), db.CheckUserWorkspaceRoleParams{ID: memberID, WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true}}).
+		AnyTimes().
+		Return("member", nil)
+	store.EXPECT().GetUserRolePermissions(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, sql.ErrNoRows)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), db.CheckUserWorkspaceRoleParams{ID: adminID, WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true}}).
+		AnyTimes().
+		Return("admin", nil)
+
+	config := util.Config{MessageDeleteWindow: time.Hour}
+	messageService := NewMessageService(store, NewUserService(store, nil, config), nil, config)
+
+	// Members can't edit after the edit window
+	store.EXPECT().UpdateMessageContent(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := messageService.EditMessage(ctx, recent.ID, memberID, "edited")
+	require.EqualError(t, err, "access denied: messages can only be edited within 15 minutes of being sent")
+
+	// but can still delete within the delete window
+	store.EXPECT().
+		SoftDeleteMessage(gomock.Any(), db.SoftDeleteMessageParams{ID: recent.ID, DeletedBy: sql.NullInt64{Int64: memberID, Valid: true}}).
+		Times(1).
+		Return(nil)
+
+	require.NoError(t, messageService.DeleteMessage(ctx, recent.ID, memberID))
+
+	store.EXPECT().SoftDeleteMessage(gomock.Any(), gomock.Any()).Times(0)
+
+	err = messageService.DeleteMessage(ctx, old.ID, memberID)
+	require.EqualError(t, err, "access denied: messages can only be deleted within 60 minutes of being sent")
+
+	// Admins aren't limited, and responses carry the windows for clients
+	store.EXPECT().
+		UpdateMessageContent(gomock.Any(), db.UpdateMessageContentParams{ID: adminOld.ID, Content: "edited"}).
+		Times(1).
+		Return(db.Message{ID: adminOld.ID, WorkspaceID: workspaceID, SenderID: adminID, Content: "edited", CreatedAt: adminOld.CreatedAt}, nil)
+	store.EXPECT().GetUser(gomock.Any(), adminID).Times(1).Return(db.User{ID: adminID}, nil)
+
+	response, err := messageService.EditMessage(ctx, adminOld.ID, adminID, "edited")
+	require.NoError(t, err)
+	require.Equal(t, adminOld.CreatedAt.Add(15*time.Minute), *response.EditableUntil)
+	require.Equal(t, adminOld.CreatedAt.Add(time.Hour), *response.DeletableUntil)
+
+	store.EXPECT().
+		SoftDeleteMessage(gomock.Any(), db.SoftDeleteMessageParams{ID: adminOld.ID, DeletedBy: sql.NullInt64{Int64: adminID, Valid: true}}).
+		Times(1).
+		Return(nil)
+
+	require.NoError(t, messageService.DeleteMessage(ctx, adminOld.ID, adminID))
+}
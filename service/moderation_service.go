@@ -0,0 +1,516 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Moderation actions for words in a workspace's moderation list
+const (
+	// ModerationActionBlock rejects messages containing the word
+	ModerationActionBlock = "block"
+	// ModerationActionMask replaces the word with asterisks
+	ModerationActionMask = "mask"
+	// ModerationActionFlag delivers the message and queues it for admin review
+	ModerationActionFlag = "flag"
+)
+
+// Moderation queue statuses
+const (
+	ModerationStatusPending  = "pending"
+	ModerationStatusApproved = "approved"
+	ModerationStatusRemoved  = "removed"
+)
+
+// ModerationDecision is the outcome of screening a message. Content is what
+// should be stored, with masked words already replaced.
+type ModerationDecision struct {
+	Content string
+	Blocked []string
+	Masked  []string
+	Flagged []string
+}
+
+// MessageModerator screens message content before it is stored and records the
+// actions taken once the message exists
+type MessageModerator interface {
+	ModerateMessage(ctx context.Context, workspaceID, authorID int64, content string) (*ModerationDecision, error)
+	RecordModeration(ctx context.Context, decision *ModerationDecision, workspaceID, authorID, messageID int64)
+}
+
+// moderationServiceStore covers moderation rules and the messages they act on
+type moderationServiceStore interface {
+	db.MessageStore
+	db.ModerationStore
+}
+
+// ModerationService handles per-workspace moderation lists, the review queue
+// for flagged messages and the moderation audit log
+type ModerationService struct {
+	store          moderationServiceStore
+	messageService *MessageService
+}
+
+// NewModerationService creates a new moderation service
+func NewModerationService(store moderationServiceStore, messageService *MessageService) *ModerationService {
+	return &ModerationService{
+		store:          store,
+		messageService: messageService,
+	}
+}
+
+// ModerateMessage checks a message against its workspace's moderation list.
+// Blocked messages are recorded in the audit log and rejected with an error.
+func (s *ModerationService) ModerateMessage(ctx context.Context, workspaceID, authorID int64, content string) (*ModerationDecision, error) {
+	words, err := s.store.ListModerationWords(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation words: %w", err)
+	}
+
+	decision := moderateContent(content, words)
+	if len(decision.Blocked) > 0 {
+		s.audit(ctx, db.CreateModerationAuditEntryParams{
+			WorkspaceID:  workspaceID,
+			AuthorID:     sql.NullInt64{Int64: authorID, Valid: true},
+			Action:       "blocked",
+			MatchedWords: decision.Blocked,
+		})
+		return nil, errors.New("message blocked by content moderation")
+	}
+
+	return decision, nil
+}
+
+// RecordModeration queues a flagged message for review and records masked and
+// flagged words in the audit log. The message was already delivered, so
+// failures are only logged.
+func (s *ModerationService) RecordModeration(ctx context.Context, decision *ModerationDecision, workspaceID, authorID, messageID int64) {
+	if decision == nil {
+		return
+	}
+
+	entry := db.CreateModerationAuditEntryParams{
+		WorkspaceID: workspaceID,
+		MessageID:   sql.NullInt64{Int64: messageID, Valid: true},
+		AuthorID:    sql.NullInt64{Int64: authorID, Valid: true},
+	}
+
+	if len(decision.Masked) > 0 {
+		entry.Action = "masked"
+		entry.MatchedWords = decision.Masked
+		s.audit(ctx, entry)
+	}
+
+	if len(decision.Flagged) > 0 {
+		_, err := s.store.CreateModerationQueueItem(ctx, db.CreateModerationQueueItemParams{
+			WorkspaceID:  workspaceID,
+			MessageID:    messageID,
+			MatchedWords: decision.Flagged,
+		})
+		if err != nil {
+			fmt.Printf("Error queueing message %d for moderation: %v\n", messageID, err)
+		}
+
+		entry.Action = "flagged"
+		entry.MatchedWords = decision.Flagged
+		s.audit(ctx, entry)
+	}
+}
+
+// audit writes a moderation audit log entry, logging failures
+func (s *ModerationService) audit(ctx context.Context, entry db.CreateModerationAuditEntryParams) {
+	if _, err := s.store.CreateModerationAuditEntry(ctx, entry); err != nil {
+		fmt.Printf("Error writing moderation audit entry for workspace %d: %v\n", entry.WorkspaceID, err)
+	}
+}
+
+// ListModerationWords lists a workspace's moderated words
+func (s *ModerationService) ListModerationWords(ctx context.Context, workspaceID int64) ([]ModerationWordResponse, error) {
+	words, err := s.store.ListModerationWords(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation words: %w", err)
+	}
+
+	responses := make([]ModerationWordResponse, len(words))
+	for i, word := range words {
+		responses[i] = toModerationWordResponse(word)
+	}
+	return responses, nil
+}
+
+// AddModerationWord adds a word or phrase to a workspace's moderation list
+func (s *ModerationService) AddModerationWord(ctx context.Context, workspaceID, userID int64, req CreateModerationWordRequest) (*ModerationWordResponse, error) {
+	word := strings.Join(strings.Fields(req.Word), " ")
+	if word == "" {
+		return nil, errors.New("invalid moderation word: word is empty")
+	}
+
+	created, err := s.store.CreateModerationWord(ctx, db.CreateModerationWordParams{
+		WorkspaceID: workspaceID,
+		Word:        word,
+		Action:      req.Action,
+		CreatedBy:   sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, errors.New("moderation word already exists")
+		}
+		return nil, fmt.Errorf("failed to add moderation word: %w", err)
+	}
+
+	response := toModerationWordResponse(created)
+	return &response, nil
+}
+
+// DeleteModerationWord removes a word from a workspace's moderation list
+func (s *ModerationService) DeleteModerationWord(ctx context.Context, workspaceID, wordID int64) error {
+	rows, err := s.store.DeleteModerationWord(ctx, db.DeleteModerationWordParams{
+		ID:          wordID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete moderation word: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("moderation word not found")
+	}
+	return nil
+}
+
+// ListModerationQueue lists a workspace's flagged messages with the given
+// review status, oldest first
+func (s *ModerationService) ListModerationQueue(ctx context.Context, workspaceID int64, status string, limit, offset int32) ([]ModerationQueueItemResponse, error) {
+	items, err := s.store.ListModerationQueue(ctx, db.ListModerationQueueParams{
+		WorkspaceID: workspaceID,
+		Status:      status,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation queue: %w", err)
+	}
+
+	responses := make([]ModerationQueueItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = ModerationQueueItemResponse{
+			ID:           item.ID,
+			MessageID:    item.MessageID,
+			MatchedWords: item.MatchedWords,
+			Status:       item.Status,
+			CreatedAt:    item.CreatedAt,
+			Sender: UserResponse{
+				ID:        item.SenderID,
+				Email:     item.SenderEmail,
+				FirstName: item.SenderFirstName,
+				LastName:  item.SenderLastName,
+			},
+		}
+		// Content of removed messages is no longer shown
+		if !item.MessageDeletedAt.Valid {
+			responses[i].Content = item.Content
+		}
+		if item.ChannelID.Valid {
+			responses[i].ChannelID = &item.ChannelID.Int64
+		}
+		if item.ReviewedBy.Valid {
+			responses[i].ReviewedBy = &item.ReviewedBy.Int64
+		}
+		if item.ReviewedAt.Valid {
+			responses[i].ReviewedAt = &item.ReviewedAt.Time
+		}
+	}
+	return responses, nil
+}
+
+// ReviewModerationItem approves a flagged message or removes it
+func (s *ModerationService) ReviewModerationItem(ctx context.Context, workspaceID, itemID, reviewerID int64, decision string) error {
+	item, err := s.store.GetModerationQueueItem(ctx, db.GetModerationQueueItemParams{
+		ID:          itemID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("moderation item not found")
+		}
+		return fmt.Errorf("failed to get moderation item: %w", err)
+	}
+	if item.Status != ModerationStatusPending {
+		return errors.New("moderation item already reviewed")
+	}
+
+	message, err := s.store.GetMessageByID(ctx, item.MessageID)
+	if err != nil {
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+
+	status, action := ModerationStatusApproved, "approved"
+	if decision == "remove" {
+		status, action = ModerationStatusRemoved, "removed"
+		if !message.DeletedAt.Valid {
+			if err := s.messageService.DeleteMessage(ctx, item.MessageID, reviewerID); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = s.store.ResolveModerationQueueItem(ctx, db.ResolveModerationQueueItemParams{
+		ID:          itemID,
+		WorkspaceID: workspaceID,
+		Status:      status,
+		ReviewedBy:  sql.NullInt64{Int64: reviewerID, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("moderation item already reviewed")
+		}
+		return fmt.Errorf("failed to review moderation item: %w", err)
+	}
+
+	s.audit(ctx, db.CreateModerationAuditEntryParams{
+		WorkspaceID:  workspaceID,
+		MessageID:    sql.NullInt64{Int64: item.MessageID, Valid: true},
+		AuthorID:     sql.NullInt64{Int64: message.SenderID, Valid: true},
+		ActorID:      sql.NullInt64{Int64: reviewerID, Valid: true},
+		Action:       action,
+		MatchedWords: item.MatchedWords,
+	})
+
+	return nil
+}
+
+// TakedownMessage deletes a message on behalf of a moderator of its workspace.
+// The takedown is recorded in the audit log with the moderator's reason and
+// the author is told why in a direct message from the moderator. A policy, when
+// given, is shown in place of the message in history.
+func (s *ModerationService) TakedownMessage(ctx context.Context, messageID, moderatorID int64, req TakedownMessageRequest) (*TakedownMessageResponse, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	canModerate, err := s.messageService.userService.HasWorkspacePermission(ctx, moderatorID, message.WorkspaceID, PermissionModerateContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check workspace permissions: %w", err)
+	}
+	if !canModerate {
+		return nil, errors.New("access denied: only moderators can take down messages")
+	}
+
+	var notice sql.NullString
+	if policy := strings.TrimSpace(req.Policy); policy != "" {
+		notice = sql.NullString{String: "Removed by a moderator for violating " + policy, Valid: true}
+	}
+
+	rows, err := s.store.TakedownMessage(ctx, db.TakedownMessageParams{
+		ID:             messageID,
+		DeletedBy:      sql.NullInt64{Int64: moderatorID, Valid: true},
+		DeletionNotice: notice,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to take down message: %w", err)
+	}
+	if rows == 0 {
+		return nil, errors.New("message already deleted")
+	}
+	takenDownAt := time.Now()
+
+	s.messageService.broadcastDeletion(message, moderatorID)
+	s.messageService.refreshThread(ctx, message, moderatorID)
+
+	s.audit(ctx, db.CreateModerationAuditEntryParams{
+		WorkspaceID: message.WorkspaceID,
+		MessageID:   sql.NullInt64{Int64: messageID, Valid: true},
+		AuthorID:    sql.NullInt64{Int64: message.SenderID, Valid: true},
+		ActorID:     sql.NullInt64{Int64: moderatorID, Valid: true},
+		Action:      "taken_down",
+		Reason:      sql.NullString{String: req.Reason, Valid: true},
+	})
+
+	response := &TakedownMessageResponse{
+		MessageID:      messageID,
+		WorkspaceID:    message.WorkspaceID,
+		AuthorID:       message.SenderID,
+		TakenDownBy:    moderatorID,
+		Reason:         req.Reason,
+		DeletionNotice: notice.String,
+		TakenDownAt:    takenDownAt,
+	}
+
+	// The message is already gone, so a failed notification is only logged
+	if message.SenderID != moderatorID {
+		if err := s.notifyTakedown(ctx, message, moderatorID, req.Reason, notice.String); err != nil {
+			fmt.Printf("Error notifying user %d of takedown of message %d: %v\n", message.SenderID, messageID, err)
+		} else {
+			response.AuthorNotified = true
+		}
+	}
+
+	return response, nil
+}
+
+// notifyTakedown tells the author of a taken down message why it was removed
+func (s *ModerationService) notifyTakedown(ctx context.Context, message db.GetMessageByIDRow, moderatorID int64, reason, notice string) error {
+	content := "A message you sent was removed by a moderator. Reason: " + reason
+	if notice != "" {
+		content += "\n" + notice + "."
+	}
+
+	_, _, err := s.messageService.createMessage(ctx, db.CreateMessageTxParams{
+		DirectMessage: &db.CreateDirectMessageParams{
+			WorkspaceID: message.WorkspaceID,
+			SenderID:    moderatorID,
+			ReceiverID:  sql.NullInt64{Int64: message.SenderID, Valid: true},
+			Content:     content,
+			ContentType: "system",
+		},
+	})
+	return err
+}
+
+// ListModerationAuditLog lists a workspace's moderation actions, newest first
+func (s *ModerationService) ListModerationAuditLog(ctx context.Context, workspaceID int64, limit, offset int32) ([]ModerationAuditEntryResponse, error) {
+	entries, err := s.store.ListModerationAuditLog(ctx, db.ListModerationAuditLogParams{
+		WorkspaceID: workspaceID,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list moderation audit log: %w", err)
+	}
+
+	responses := make([]ModerationAuditEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = ModerationAuditEntryResponse{
+			ID:           entry.ID,
+			Action:       entry.Action,
+			MatchedWords: entry.MatchedWords,
+			Reason:       entry.Reason.String,
+			CreatedAt:    entry.CreatedAt,
+		}
+		if entry.MessageID.Valid {
+			responses[i].MessageID = &entry.MessageID.Int64
+		}
+		if entry.AuthorID.Valid {
+			responses[i].AuthorID = &entry.AuthorID.Int64
+		}
+		if entry.ActorID.Valid {
+			responses[i].ActorID = &entry.ActorID.Int64
+		}
+	}
+	return responses, nil
+}
+
+func toModerationWordResponse(word db.ModerationWord) ModerationWordResponse {
+	response := ModerationWordResponse{
+		ID:        word.ID,
+		Word:      word.Word,
+		Action:    word.Action,
+		CreatedAt: word.CreatedAt,
+	}
+	if word.CreatedBy.Valid {
+		response.CreatedBy = &word.CreatedBy.Int64
+	}
+	return response
+}
+
+// moderateContent finds the moderated words in content, ignoring case and
+// matching whole words only, and masks the words whose action is mask
+func moderateContent(content string, words []db.ModerationWord) *ModerationDecision {
+	decision := &ModerationDecision{Content: content}
+
+	var maskRanges [][]int
+	for _, word := range words {
+		matches := findModeratedWord(content, word.Word)
+		if len(matches) == 0 {
+			continue
+		}
+
+		switch word.Action {
+		case ModerationActionBlock:
+			decision.Blocked = append(decision.Blocked, word.Word)
+		case ModerationActionMask:
+			decision.Masked = append(decision.Masked, word.Word)
+			maskRanges = append(maskRanges, matches...)
+		case ModerationActionFlag:
+			decision.Flagged = append(decision.Flagged, word.Word)
+		}
+	}
+
+	if len(maskRanges) > 0 {
+		decision.Content = maskContent(content, maskRanges)
+	}
+	return decision
+}
+
+// findModeratedWord returns the byte ranges where word appears in content as a
+// whole word, ignoring case
+func findModeratedWord(content, word string) [][]int {
+	pattern, err := regexp.Compile("(?i)" + regexp.QuoteMeta(word))
+	if err != nil {
+		return nil
+	}
+
+	var matches [][]int
+	for _, match := range pattern.FindAllStringIndex(content, -1) {
+		if match[0] > 0 {
+			if r, _ := utf8.DecodeLastRuneInString(content[:match[0]]); isWordRune(r) {
+				continue
+			}
+		}
+		if match[1] < len(content) {
+			if r, _ := utf8.DecodeRuneInString(content[match[1]:]); isWordRune(r) {
+				continue
+			}
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// maskContent replaces every rune in the given byte ranges with an asterisk,
+// keeping whitespace so masked phrases keep their shape
+func maskContent(content string, ranges [][]int) string {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	var builder strings.Builder
+	position := 0
+	for _, r := range ranges {
+		start, end := r[0], r[1]
+		if end <= position {
+			continue
+		}
+		if start < position {
+			start = position
+		}
+
+		builder.WriteString(content[position:start])
+		for _, char := range content[start:end] {
+			if unicode.IsSpace(char) {
+				builder.WriteRune(char)
+			} else {
+				builder.WriteByte('*')
+			}
+		}
+		position = end
+	}
+	builder.WriteString(content[position:])
+
+	return builder.String()
+}
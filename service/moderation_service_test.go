@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerateContent(t *testing.T) {
+	words := []db.ModerationWord{
+		{Word: "darn", Action: ModerationActionMask},
+		{Word: "heck no", Action: ModerationActionMask},
+		{Word: "crème", Action: ModerationActionMask},
+		{Word: "refund", Action: ModerationActionFlag},
+		{Word: "password", Action: ModerationActionBlock},
+	}
+
+	testCases := []struct {
+		name            string
+		content         string
+		expectedContent string
+		blocked         []string
+		masked          []string
+		flagged         []string
+	}{
+		{
+			name:            "NoMatch",
+			content:         "Good morning everyone",
+			expectedContent: "Good morning everyone",
+		},
+		{
+			name:            "MaskIgnoresCase",
+			content:         "Darn it, DARN printer",
+			expectedContent: "**** it, **** printer",
+			masked:          []string{"darn"},
+		},
+		{
+			name:            "WholeWordsOnly",
+			content:         "darned darnit undarn",
+			expectedContent: "darned darnit undarn",
+		},
+		{
+			name:            "MaskPhraseKeepsSpaces",
+			content:         "Heck no!",
+			expectedContent: "**** **!",
+			masked:          []string{"heck no"},
+		},
+		{
+			name:            "MaskUnicode",
+			content:         "la Crème brûlée",
+			expectedContent: "la ***** brûlée",
+			masked:          []string{"crème"},
+		},
+		{
+			name:            "Flag",
+			content:         "Can I get a refund?",
+			expectedContent: "Can I get a refund?",
+			flagged:         []string{"refund"},
+		},
+		{
+			name:            "Block",
+			content:         "my password is hunter2, darn",
+			expectedContent: "my password is hunter2, ****",
+			blocked:         []string{"password"},
+			masked:          []string{"darn"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decision := moderateContent(tc.content, words)
+			require.Equal(t, tc.expectedContent, decision.Content)
+			require.Equal(t, tc.blocked, decision.Blocked)
+			require.Equal(t, tc.masked, decision.Masked)
+			require.Equal(t, tc.flagged, decision.Flagged)
+		})
+	}
+}
+
+func TestModerationService_ModerateMessage(t *testing.T) {
+	const workspaceID, authorID = int64(4), int64(9)
+	ctx := context.Background()
+
+	words := []db.ModerationWord{
+		{WorkspaceID: workspaceID, Word: "secret", Action: ModerationActionBlock},
+		{WorkspaceID: workspaceID, Word: "refund", Action: ModerationActionFlag},
+	}
+
+	t.Run("BlockedIsAudited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+
+		store.EXPECT().ListModerationWords(gomock.Any(), gomock.Eq(workspaceID)).Times(1).Return(words, nil)
+		store.EXPECT().
+			CreateModerationAuditEntry(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateModerationAuditEntryParams) (db.ModerationAuditLog, error) {
+				require.Equal(t, "blocked", arg.Action)
+				require.False(t, arg.MessageID.Valid)
+				require.Equal(t, authorID, arg.AuthorID.Int64)
+				require.Equal(t, []string{"secret"}, arg.MatchedWords)
+				return db.ModerationAuditLog{}, nil
+			})
+
+		moderationService := NewModerationService(store, nil)
+		_, err := moderationService.ModerateMessage(ctx, workspaceID, authorID, "the secret plan")
+		require.EqualError(t, err, "message blocked by content moderation")
+	})
+
+	t.Run("FlaggedIsQueued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+
+		store.EXPECT().ListModerationWords(gomock.Any(), gomock.Eq(workspaceID)).Times(1).Return(words, nil)
+		store.EXPECT().
+			CreateModerationQueueItem(gomock.Any(), gomock.Eq(db.CreateModerationQueueItemParams{
+				WorkspaceID:  workspaceID,
+				MessageID:    12,
+				MatchedWords: []string{"refund"},
+			})).
+			Times(1).
+			Return(db.ModerationQueue{}, nil)
+		store.EXPECT().
+			CreateModerationAuditEntry(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.CreateModerationAuditEntryParams) (db.ModerationAuditLog, error) {
+				require.Equal(t, "flagged", arg.Action)
+				require.Equal(t, int64(12), arg.MessageID.Int64)
+				return db.ModerationAuditLog{}, nil
+			})
+
+		moderationService := NewModerationService(store, nil)
+		decision, err := moderationService.ModerateMessage(ctx, workspaceID, authorID, "I want a refund")
+		require.NoError(t, err)
+		require.Equal(t, "I want a refund", decision.Content)
+
+		moderationService.RecordModeration(ctx, decision, workspaceID, authorID, 12)
+	})
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// ListChannelDefaults returns the default notification preferences admins
+// picked for the workspace's channels
+func (s *NotificationPreferenceService) ListChannelDefaults(ctx context.Context, workspaceID int64) ([]ChannelNotificationDefaultResponse, error) {
+	defaults, err := s.store.ListChannelNotificationDefaults(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channel notification defaults: %w", err)
+	}
+
+	response := make([]ChannelNotificationDefaultResponse, len(defaults))
+	for i, channelDefault := range defaults {
+		response[i] = toChannelNotificationDefaultResponse(channelDefault)
+	}
+	return response, nil
+}
+
+// SetChannelDefault picks the notification preference members get in a
+// channel when they join it. Members already in the channel keep their
+// preference until the default is applied to them.
+func (s *NotificationPreferenceService) SetChannelDefault(ctx context.Context, userID, workspaceID, channelID int64, req SetChannelNotificationDefaultRequest) (*ChannelNotificationDefaultResponse, error) {
+	if err := s.checkChannelAccess(ctx, userID, workspaceID, channelID); err != nil {
+		return nil, err
+	}
+
+	arg := db.UpsertChannelNotificationDefaultParams{
+		ChannelID:    channelID,
+		WorkspaceID:  workspaceID,
+		Level:        req.Level,
+		EmailEnabled: true,
+		PushEnabled:  true,
+		UpdatedBy:    sql.NullInt64{Int64: userID, Valid: true},
+	}
+	if req.EmailEnabled != nil {
+		arg.EmailEnabled = *req.EmailEnabled
+	}
+	if req.PushEnabled != nil {
+		arg.PushEnabled = *req.PushEnabled
+	}
+
+	channelDefault, err := s.store.UpsertChannelNotificationDefault(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set channel notification default: %w", err)
+	}
+
+	response := toChannelNotificationDefaultResponse(channelDefault)
+	return &response, nil
+}
+
+// DeleteChannelDefault removes a channel's default. The preferences it
+// seeded are the members' own now and are kept.
+func (s *NotificationPreferenceService) DeleteChannelDefault(ctx context.Context, userID, workspaceID, channelID int64) error {
+	if err := s.checkChannelAccess(ctx, userID, workspaceID, channelID); err != nil {
+		return err
+	}
+
+	rows, err := s.store.DeleteChannelNotificationDefault(ctx, channelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete channel notification default: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("channel notification default not found")
+	}
+	return nil
+}
+
+// ApplyChannelDefault sets every member's preference in a channel to the
+// channel's default, replacing the overrides they picked themselves
+func (s *NotificationPreferenceService) ApplyChannelDefault(ctx context.Context, userID, workspaceID, channelID int64) (*ApplyChannelNotificationDefaultResponse, error) {
+	if err := s.checkChannelAccess(ctx, userID, workspaceID, channelID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getChannelDefault(ctx, channelID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("channel notification default not found")
+		}
+		return nil, err
+	}
+
+	applied, err := s.store.ApplyChannelNotificationDefault(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply channel notification default: %w", err)
+	}
+
+	return &ApplyChannelNotificationDefaultResponse{
+		ChannelID: channelID,
+		Applied:   applied,
+	}, nil
+}
+
+// getChannelDefault returns a channel's default, or sql.ErrNoRows when
+// admins didn't pick one
+func (s *NotificationPreferenceService) getChannelDefault(ctx context.Context, channelID int64) (db.ChannelNotificationDefault, error) {
+	channelDefault, err := s.store.GetChannelNotificationDefault(ctx, channelID)
+	if err != nil && err != sql.ErrNoRows {
+		return channelDefault, fmt.Errorf("failed to get channel notification default: %w", err)
+	}
+	return channelDefault, err
+}
+
+// markChannelDefaultOverride flags a channel preference that differs from the
+// default admins picked for the channel
+func (s *NotificationPreferenceService) markChannelDefaultOverride(ctx context.Context, preference *NotificationPreferenceResponse) error {
+	channelDefault, err := s.getChannelDefault(ctx, *preference.ChannelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	preference.OverridesChannelDefault = overridesChannelDefault(*preference, channelDefault)
+	return nil
+}
+
+func overridesChannelDefault(preference NotificationPreferenceResponse, channelDefault db.ChannelNotificationDefault) bool {
+	return preference.Level != channelDefault.Level ||
+		preference.EmailEnabled != channelDefault.EmailEnabled ||
+		preference.PushEnabled != channelDefault.PushEnabled
+}
+
+func toChannelNotificationDefaultResponse(channelDefault db.ChannelNotificationDefault) ChannelNotificationDefaultResponse {
+	response := ChannelNotificationDefaultResponse{
+		ChannelID:    channelDefault.ChannelID,
+		WorkspaceID:  channelDefault.WorkspaceID,
+		Level:        channelDefault.Level,
+		EmailEnabled: channelDefault.EmailEnabled,
+		PushEnabled:  channelDefault.PushEnabled,
+		UpdatedAt:    channelDefault.UpdatedAt,
+	}
+	if channelDefault.UpdatedBy.Valid {
+		updatedBy := channelDefault.UpdatedBy.Int64
+		response.UpdatedBy = &updatedBy
+	}
+	return response
+}
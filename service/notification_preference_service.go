@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Notification levels, from most to least notifications
+const (
+	NotificationLevelAll      = "all"
+	NotificationLevelMentions = "mentions"
+	NotificationLevelNothing  = "nothing"
+)
+
+// notificationPreferenceServiceStore covers notification preferences and the channels they are set on
+type notificationPreferenceServiceStore interface {
+	db.ChannelStore
+	db.UserStore
+}
+
+// NotificationPreferenceService manages how users want to be notified: a
+// workspace-wide preference and overrides for single channels
+type NotificationPreferenceService struct {
+	store notificationPreferenceServiceStore
+}
+
+// NewNotificationPreferenceService creates a new notification preference service
+func NewNotificationPreferenceService(store notificationPreferenceServiceStore) *NotificationPreferenceService {
+	return &NotificationPreferenceService{
+		store: store,
+	}
+}
+
+// ListPreferences returns the user's workspace-wide preference, or the
+// defaults if they never set it, and their channel overrides, flagging the
+// ones that differ from a channel's default
+func (s *NotificationPreferenceService) ListPreferences(ctx context.Context, userID, workspaceID int64) (*NotificationPreferencesResponse, error) {
+	preferences, err := s.store.ListNotificationPreferences(ctx, db.ListNotificationPreferencesParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	defaults, err := s.store.ListChannelNotificationDefaults(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list channel notification defaults: %w", err)
+	}
+	channelDefaults := make(map[int64]db.ChannelNotificationDefault, len(defaults))
+	for _, channelDefault := range defaults {
+		channelDefaults[channelDefault.ChannelID] = channelDefault
+	}
+
+	response := &NotificationPreferencesResponse{
+		Workspace: defaultNotificationPreference(workspaceID),
+		Channels:  []NotificationPreferenceResponse{},
+	}
+	for _, preference := range preferences {
+		if !preference.ChannelID.Valid {
+			response.Workspace = toNotificationPreferenceResponse(preference)
+			continue
+		}
+		channelPreference := toNotificationPreferenceResponse(preference)
+		if channelDefault, ok := channelDefaults[preference.ChannelID.Int64]; ok {
+			channelPreference.OverridesChannelDefault = overridesChannelDefault(channelPreference, channelDefault)
+		}
+		response.Channels = append(response.Channels, channelPreference)
+	}
+
+	return response, nil
+}
+
+// UpdateWorkspacePreference changes the user's workspace-wide preference
+func (s *NotificationPreferenceService) UpdateWorkspacePreference(ctx context.Context, userID, workspaceID int64, req UpdateNotificationPreferenceRequest) (*NotificationPreferenceResponse, error) {
+	current, err := s.getEffectivePreference(ctx, userID, workspaceID, 0)
+	if err != nil {
+		return nil, err
+	}
+	current = applyNotificationPreferenceRequest(current, req)
+
+	preference, err := s.store.UpsertWorkspaceNotificationPreference(ctx, db.UpsertWorkspaceNotificationPreferenceParams{
+		UserID:       userID,
+		WorkspaceID:  workspaceID,
+		Level:        current.Level,
+		EmailEnabled: current.EmailEnabled,
+		PushEnabled:  current.PushEnabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification preference: %w", err)
+	}
+
+	response := toNotificationPreferenceResponse(preference)
+	return &response, nil
+}
+
+// ResetWorkspacePreference removes the user's workspace-wide preference so
+// the defaults apply again. Channel overrides are kept.
+func (s *NotificationPreferenceService) ResetWorkspacePreference(ctx context.Context, userID, workspaceID int64) error {
+	rows, err := s.store.DeleteWorkspaceNotificationPreference(ctx, db.DeleteWorkspaceNotificationPreferenceParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset notification preference: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("notification preference not found")
+	}
+	return nil
+}
+
+// GetChannelPreference returns the preference that applies in a channel: the
+// channel's own, or else the workspace-wide one. It is flagged when it
+// differs from the default admins picked for the channel.
+func (s *NotificationPreferenceService) GetChannelPreference(ctx context.Context, userID, workspaceID, channelID int64) (*NotificationPreferenceResponse, error) {
+	if err := s.checkChannelAccess(ctx, userID, workspaceID, channelID); err != nil {
+		return nil, err
+	}
+
+	preference, err := s.getEffectivePreference(ctx, userID, workspaceID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.markChannelDefaultOverride(ctx, &preference); err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// UpdateChannelPreference overrides the workspace-wide preference in a
+// channel. Fields the request leaves out start from the preference that
+// currently applies in the channel.
+func (s *NotificationPreferenceService) UpdateChannelPreference(ctx context.Context, userID, workspaceID, channelID int64, req UpdateNotificationPreferenceRequest) (*NotificationPreferenceResponse, error) {
+	if err := s.checkChannelAccess(ctx, userID, workspaceID, channelID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.getEffectivePreference(ctx, userID, workspaceID, channelID)
+	if err != nil {
+		return nil, err
+	}
+	current = applyNotificationPreferenceRequest(current, req)
+
+	preference, err := s.store.UpsertChannelNotificationPreference(ctx, db.UpsertChannelNotificationPreferenceParams{
+		UserID:       userID,
+		WorkspaceID:  workspaceID,
+		ChannelID:    channelID,
+		Level:        current.Level,
+		EmailEnabled: current.EmailEnabled,
+		PushEnabled:  current.PushEnabled,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification preference: %w", err)
+	}
+
+	response := toNotificationPreferenceResponse(preference)
+	if err := s.markChannelDefaultOverride(ctx, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// DeleteChannelPreference removes a channel override so the workspace-wide
+// preference applies in the channel again
+func (s *NotificationPreferenceService) DeleteChannelPreference(ctx context.Context, userID, workspaceID, channelID int64) error {
+	if err := s.checkChannelAccess(ctx, userID, workspaceID, channelID); err != nil {
+		return err
+	}
+
+	rows, err := s.store.DeleteChannelNotificationPreference(ctx, db.DeleteChannelNotificationPreferenceParams{
+		UserID:    userID,
+		ChannelID: channelID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete notification preference: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("notification preference not found")
+	}
+	return nil
+}
+
+// getEffectivePreference returns the channel's preference, the workspace-wide
+// one when the channel has none, or the defaults. A channel ID of 0 asks for
+// the workspace-wide preference.
+func (s *NotificationPreferenceService) getEffectivePreference(ctx context.Context, userID, workspaceID, channelID int64) (NotificationPreferenceResponse, error) {
+	preference, err := s.store.GetEffectiveNotificationPreference(ctx, db.GetEffectiveNotificationPreferenceParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		ChannelID:   channelID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			response := defaultNotificationPreference(workspaceID)
+			if channelID != 0 {
+				response.ChannelID = &channelID
+			}
+			return response, nil
+		}
+		return NotificationPreferenceResponse{}, fmt.Errorf("failed to get notification preference: %w", err)
+	}
+
+	response := toNotificationPreferenceResponse(preference)
+	if channelID != 0 && !preference.ChannelID.Valid {
+		response.ChannelID = &channelID
+		response.Inherited = true
+		response.UpdatedAt = nil
+	}
+	return response, nil
+}
+
+// checkChannelAccess makes sure the channel is in the workspace and, if it is
+// private, that the user is a member
+func (s *NotificationPreferenceService) checkChannelAccess(ctx context.Context, userID, workspaceID, channelID int64) error {
+	channel, err := s.store.GetChannelByID(ctx, channelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("channel not found")
+		}
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel.WorkspaceID != workspaceID {
+		return errors.New("channel not found")
+	}
+
+	if channel.IsPrivate {
+		isMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+			ChannelID: channelID,
+			UserID:    userID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check channel membership: %w", err)
+		}
+		if !isMember {
+			return errors.New("access denied: user is not a member of the channel")
+		}
+	}
+
+	return nil
+}
+
+// defaultNotificationPreference is what applies when the user never set a preference
+func defaultNotificationPreference(workspaceID int64) NotificationPreferenceResponse {
+	return NotificationPreferenceResponse{
+		WorkspaceID:  workspaceID,
+		Level:        NotificationLevelMentions,
+		EmailEnabled: true,
+		PushEnabled:  true,
+		Inherited:    true,
+	}
+}
+
+func applyNotificationPreferenceRequest(preference NotificationPreferenceResponse, req UpdateNotificationPreferenceRequest) NotificationPreferenceResponse {
+	if req.Level != nil {
+		preference.Level = *req.Level
+	}
+	if req.EmailEnabled != nil {
+		preference.EmailEnabled = *req.EmailEnabled
+	}
+	if req.PushEnabled != nil {
+		preference.PushEnabled = *req.PushEnabled
+	}
+	return preference
+}
+
+func toNotificationPreferenceResponse(preference db.NotificationPreference) NotificationPreferenceResponse {
+	response := NotificationPreferenceResponse{
+		WorkspaceID:  preference.WorkspaceID,
+		Level:        preference.Level,
+		EmailEnabled: preference.EmailEnabled,
+		PushEnabled:  preference.PushEnabled,
+		UpdatedAt:    &preference.UpdatedAt,
+	}
+	if preference.ChannelID.Valid {
+		channelID := preference.ChannelID.Int64
+		response.ChannelID = &channelID
+	}
+	return response
+}
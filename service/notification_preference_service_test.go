@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationPreferenceService_ListPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	preferenceService := NewNotificationPreferenceService(store)
+
+	store.EXPECT().
+		ListNotificationPreferences(gomock.Any(), gomock.Eq(db.ListNotificationPreferencesParams{UserID: 1, WorkspaceID: 2})).
+		Times(1).
+		Return([]db.NotificationPreference{
+			{UserID: 1, WorkspaceID: 2, ChannelID: sql.NullInt64{Int64: 5, Valid: true}, Level: NotificationLevelAll, PushEnabled: true},
+		}, nil)
+
+	// Admins picked email too for the channel
+	store.EXPECT().
+		ListChannelNotificationDefaults(gomock.Any(), gomock.Eq(int64(2))).
+		Times(1).
+		Return([]db.ChannelNotificationDefault{
+			{ChannelID: 5, WorkspaceID: 2, Level: NotificationLevelAll, EmailEnabled: true, PushEnabled: true},
+		}, nil)
+
+	preferences, err := preferenceService.ListPreferences(context.Background(), 1, 2)
+	require.NoError(t, err)
+
+	// Without a workspace-wide preference the defaults apply
+	require.Equal(t, defaultNotificationPreference(2), preferences.Workspace)
+	require.Len(t, preferences.Channels, 1)
+	require.Equal(t, int64(5), *preferences.Channels[0].ChannelID)
+	require.Equal(t, NotificationLevelAll, preferences.Channels[0].Level)
+	require.False(t, preferences.Channels[0].Inherited)
+	require.True(t, preferences.Channels[0].OverridesChannelDefault)
+}
+
+func TestNotificationPreferenceService_UpdateWorkspacePreference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	preferenceService := NewNotificationPreferenceService(store)
+
+	store.EXPECT().
+		GetEffectiveNotificationPreference(gomock.Any(), gomock.Eq(db.GetEffectiveNotificationPreferenceParams{UserID: 1, WorkspaceID: 2})).
+		Times(1).
+		Return(db.NotificationPreference{}, sql.ErrNoRows)
+
+	// Fields left out keep the defaults
+	store.EXPECT().
+		UpsertWorkspaceNotificationPreference(gomock.Any(), gomock.Eq(db.UpsertWorkspaceNotificationPreferenceParams{
+			UserID:       1,
+			WorkspaceID:  2,
+			Level:        NotificationLevelMentions,
+			EmailEnabled: false,
+			PushEnabled:  true,
+		})).
+		Times(1).
+		Return(db.NotificationPreference{UserID: 1, WorkspaceID: 2, Level: NotificationLevelMentions, PushEnabled: true, UpdatedAt: time.Now()}, nil)
+
+	emailEnabled := false
+	preference, err := preferenceService.UpdateWorkspacePreference(context.Background(), 1, 2, UpdateNotificationPreferenceRequest{EmailEnabled: &emailEnabled})
+	require.NoError(t, err)
+	require.Nil(t, preference.ChannelID)
+	require.False(t, preference.EmailEnabled)
+	require.False(t, preference.Inherited)
+}
+
+func TestNotificationPreferenceService_ChannelPreference(t *testing.T) {
+	channel := db.Channel{ID: 5, WorkspaceID: 2}
+
+	t.Run("Inherited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		preferenceService := NewNotificationPreferenceService(store)
+
+		store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+		store.EXPECT().
+			GetEffectiveNotificationPreference(gomock.Any(), gomock.Eq(db.GetEffectiveNotificationPreferenceParams{UserID: 1, WorkspaceID: 2, ChannelID: 5})).
+			Times(1).
+			Return(db.NotificationPreference{UserID: 1, WorkspaceID: 2, Level: NotificationLevelNothing, UpdatedAt: time.Now()}, nil)
+		store.EXPECT().
+			GetChannelNotificationDefault(gomock.Any(), gomock.Eq(channel.ID)).
+			Times(1).
+			Return(db.ChannelNotificationDefault{}, sql.ErrNoRows)
+
+		preference, err := preferenceService.GetChannelPreference(context.Background(), 1, 2, 5)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), *preference.ChannelID)
+		require.Equal(t, NotificationLevelNothing, preference.Level)
+		require.True(t, preference.Inherited)
+		require.False(t, preference.OverridesChannelDefault)
+		require.Nil(t, preference.UpdatedAt)
+	})
+
+	t.Run("UpdateStartsFromWorkspacePreference", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		preferenceService := NewNotificationPreferenceService(store)
+
+		store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+		store.EXPECT().
+			GetEffectiveNotificationPreference(gomock.Any(), gomock.Any()).
+			Times(1).
+			Return(db.NotificationPreference{UserID: 1, WorkspaceID: 2, Level: NotificationLevelMentions, EmailEnabled: false, PushEnabled: true}, nil)
+		store.EXPECT().
+			UpsertChannelNotificationPreference(gomock.Any(), gomock.Eq(db.UpsertChannelNotificationPreferenceParams{
+				UserID:       1,
+				WorkspaceID:  2,
+				ChannelID:    5,
+				Level:        NotificationLevelAll,
+				EmailEnabled: false,
+				PushEnabled:  true,
+			})).
+			Times(1).
+			Return(db.NotificationPreference{UserID: 1, WorkspaceID: 2, ChannelID: sql.NullInt64{Int64: 5, Valid: true}, Level: NotificationLevelAll, PushEnabled: true}, nil)
+		store.EXPECT().
+			GetChannelNotificationDefault(gomock.Any(), gomock.Eq(channel.ID)).
+			Times(1).
+			Return(db.ChannelNotificationDefault{ChannelID: 5, WorkspaceID: 2, Level: NotificationLevelAll, PushEnabled: true}, nil)
+
+		level := NotificationLevelAll
+		preference, err := preferenceService.UpdateChannelPreference(context.Background(), 1, 2, 5, UpdateNotificationPreferenceRequest{Level: &level})
+		require.NoError(t, err)
+		require.Equal(t, NotificationLevelAll, preference.Level)
+		require.False(t, preference.Inherited)
+		require.False(t, preference.OverridesChannelDefault)
+	})
+
+	t.Run("OtherWorkspace", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		preferenceService := NewNotificationPreferenceService(store)
+
+		store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+		store.EXPECT().UpsertChannelNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := preferenceService.UpdateChannelPreference(context.Background(), 1, 3, 5, UpdateNotificationPreferenceRequest{})
+		require.EqualError(t, err, "channel not found")
+	})
+
+	t.Run("PrivateChannelNotMember", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		preferenceService := NewNotificationPreferenceService(store)
+
+		private := channel
+		private.IsPrivate = true
+		store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(private, nil)
+		store.EXPECT().IsChannelMember(gomock.Any(), gomock.Eq(db.IsChannelMemberParams{ChannelID: 5, UserID: 1})).Times(1).Return(false, nil)
+		store.EXPECT().DeleteChannelNotificationPreference(gomock.Any(), gomock.Any()).Times(0)
+
+		err := preferenceService.DeleteChannelPreference(context.Background(), 1, 2, 5)
+		require.ErrorContains(t, err, "access denied")
+	})
+}
+
+func TestNotificationPreferenceService_ChannelDefault(t *testing.T) {
+	channel := db.Channel{ID: 5, WorkspaceID: 2}
+
+	t.Run("Set", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		preferenceService := NewNotificationPreferenceService(store)
+
+		// Email and push stay on unless turned off
+		store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+		store.EXPECT().
+			UpsertChannelNotificationDefault(gomock.Any(), gomock.Eq(db.UpsertChannelNotificationDefaultParams{
+				ChannelID:    5,
+				WorkspaceID:  2,
+				Level:        NotificationLevelAll,
+				EmailEnabled: true,
+				PushEnabled:  false,
+				UpdatedBy:    sql.NullInt64{Int64: 1, Valid: true},
+			})).
+			Times(1).
+			Return(db.ChannelNotificationDefault{
+				ChannelID:    5,
+				WorkspaceID:  2,
+				Level:        NotificationLevelAll,
+				EmailEnabled: true,
+				UpdatedBy:    sql.NullInt64{Int64: 1, Valid: true},
+				UpdatedAt:    time.Now(),
+			}, nil)
+
+		pushEnabled := false
+		channelDefault, err := preferenceService.SetChannelDefault(context.Background(), 1, 2, 5, SetChannelNotificationDefaultRequest{
+			Level:       NotificationLevelAll,
+			PushEnabled: &pushEnabled,
+		})
+		require.NoError(t, err)
+		require.Equal(t, NotificationLevelAll, channelDefault.Level)
+		require.Equal(t, int64(1), *channelDefault.UpdatedBy)
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		preferenceService := NewNotificationPreferenceService(store)
+
+		store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+		store.EXPECT().
+			GetChannelNotificationDefault(gomock.Any(), gomock.Eq(channel.ID)).
+			Times(1).
+			Return(db.ChannelNotificationDefault{ChannelID: 5, WorkspaceID: 2, Level: NotificationLevelMentions}, nil)
+		store.EXPECT().ApplyChannelNotificationDefault(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(int64(12), nil)
+
+		applied, err := preferenceService.ApplyChannelDefault(context.Background(), 1, 2, 5)
+		require.NoError(t, err)
+		require.Equal(t, int64(12), applied.Applied)
+	})
+
+	t.Run("ApplyWithoutDefault", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		preferenceService := NewNotificationPreferenceService(store)
+
+		store.EXPECT().GetChannelByID(gomock.Any(), gomock.Eq(channel.ID)).Times(1).Return(channel, nil)
+		store.EXPECT().
+			GetChannelNotificationDefault(gomock.Any(), gomock.Eq(channel.ID)).
+			Times(1).
+			Return(db.ChannelNotificationDefault{}, sql.ErrNoRows)
+		store.EXPECT().ApplyChannelNotificationDefault(gomock.Any(), gomock.Any()).Times(0)
+
+		_, err := preferenceService.ApplyChannelDefault(context.Background(), 1, 2, 5)
+		require.EqualError(t, err, "channel notification default not found")
+	})
+}
@@ -0,0 +1,465 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Steps of the onboarding checklist. Each is completed by doing it, and stays
+// completed once done.
+const (
+	OnboardingStepAddPhoto     = "add_photo"
+	OnboardingStepAddTitle     = "add_title"
+	OnboardingStepChooseHandle = "choose_handle"
+	OnboardingStepJoinChannels = "join_channels"
+	OnboardingStepSendMessage  = "send_message"
+)
+
+// onboardingSteps are the checklist steps with their titles, in their default order
+var onboardingSteps = []OnboardingStepResponse{
+	{Step: OnboardingStepAddPhoto, Title: "Add a profile photo"},
+	{Step: OnboardingStepAddTitle, Title: "Say what you do"},
+	{Step: OnboardingStepChooseHandle, Title: "Choose a handle"},
+	{Step: OnboardingStepJoinChannels, Title: "Join a suggested channel"},
+	{Step: OnboardingStepSendMessage, Title: "Send your first message"},
+}
+
+// defaultWelcomeMessage is the welcome DM of workspaces that haven't written their own
+const defaultWelcomeMessage = "Welcome to {{.WorkspaceName}}, {{.FirstName}}! " +
+	"Finish setting up your profile and join a few channels to get started."
+
+// Details of the user each workspace's welcome DMs are sent as
+const (
+	onboardingBotFirstName = "Onboarding"
+	onboardingBotLastName  = "Bot"
+	// onboardingBotPassword is no bcrypt hash, so no password matches it and
+	// the bot can't log in
+	onboardingBotPassword = "!"
+)
+
+// welcomeMessageData is what welcome message templates can use
+type welcomeMessageData struct {
+	FirstName     string
+	LastName      string
+	WorkspaceName string
+}
+
+// onboardingServiceStore covers onboarding progress, the onboarding bot and the channels new members join
+type onboardingServiceStore interface {
+	db.ChannelStore
+	db.UserStore
+	db.WorkspaceStore
+}
+
+// OnboardingService onboards new workspace members: it sends them a welcome
+// DM from the workspace's onboarding bot, suggests channels to join and
+// tracks their profile checklist
+type OnboardingService struct {
+	store          onboardingServiceStore
+	messageService *MessageService
+}
+
+// NewOnboardingService creates a new onboarding service
+func NewOnboardingService(store onboardingServiceStore, messageService *MessageService) *OnboardingService {
+	return &OnboardingService{
+		store:          store,
+		messageService: messageService,
+	}
+}
+
+// GetSettings returns how a workspace onboards new members
+func (s *OnboardingService) GetSettings(ctx context.Context, workspaceID int64) (OnboardingSettingsResponse, error) {
+	onboarding, err := s.store.GetWorkspaceOnboarding(ctx, workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return toOnboardingSettingsResponse(db.WorkspaceOnboarding{
+				WorkspaceID:    workspaceID,
+				WelcomeMessage: defaultWelcomeMessage,
+				Steps:          defaultOnboardingSteps(),
+			}), nil
+		}
+		return OnboardingSettingsResponse{}, fmt.Errorf("failed to get onboarding: %w", err)
+	}
+
+	return toOnboardingSettingsResponse(onboarding), nil
+}
+
+// UpdateSettings customizes how a workspace onboards new members. Onboarding
+// is enabled unless the request says otherwise.
+func (s *OnboardingService) UpdateSettings(ctx context.Context, workspaceID, userID int64, req UpdateOnboardingRequest) (OnboardingSettingsResponse, error) {
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	steps := make([]string, 0, len(req.Steps))
+	for _, step := range req.Steps {
+		if onboardingStepTitle(step) == "" {
+			return OnboardingSettingsResponse{}, fmt.Errorf("invalid onboarding step '%s'", step)
+		}
+		if slices.Contains(steps, step) {
+			return OnboardingSettingsResponse{}, fmt.Errorf("invalid onboarding step '%s': listed twice", step)
+		}
+		steps = append(steps, step)
+	}
+
+	channelIDs := make([]int64, 0, len(req.SuggestedChannelIDs))
+	for _, channelID := range req.SuggestedChannelIDs {
+		if !slices.Contains(channelIDs, channelID) {
+			channelIDs = append(channelIDs, channelID)
+		}
+	}
+	if len(channelIDs) > 0 {
+		channels, err := s.store.ListOnboardingChannels(ctx, db.ListOnboardingChannelsParams{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			ChannelIds:  channelIDs,
+		})
+		if err != nil {
+			return OnboardingSettingsResponse{}, fmt.Errorf("failed to get suggested channels: %w", err)
+		}
+		if len(channels) != len(channelIDs) {
+			return OnboardingSettingsResponse{}, errors.New("invalid suggested channels: only public channels of the workspace can be suggested")
+		}
+	}
+
+	if _, err := renderWelcomeMessage(req.WelcomeMessage, welcomeMessageData{
+		FirstName:     "Ada",
+		LastName:      "Lovelace",
+		WorkspaceName: "Engineering",
+	}); err != nil {
+		return OnboardingSettingsResponse{}, fmt.Errorf("invalid welcome message: %w", err)
+	}
+
+	onboarding, err := s.store.UpsertWorkspaceOnboarding(ctx, db.UpsertWorkspaceOnboardingParams{
+		WorkspaceID:         workspaceID,
+		Enabled:             enabled,
+		WelcomeMessage:      req.WelcomeMessage,
+		SuggestedChannelIds: channelIDs,
+		Steps:               steps,
+		UpdatedBy:           sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		return OnboardingSettingsResponse{}, fmt.Errorf("failed to update onboarding: %w", err)
+	}
+
+	return toOnboardingSettingsResponse(onboarding), nil
+}
+
+// WelcomeMember sends a new member of a workspace its welcome DM. Nothing
+// is sent when the workspace has no onboarding or it is disabled.
+func (s *OnboardingService) WelcomeMember(ctx context.Context, workspaceID, userID int64) error {
+	onboarding, err := s.store.GetWorkspaceOnboarding(ctx, workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("failed to get onboarding: %w", err)
+	}
+	if !onboarding.Enabled {
+		return nil
+	}
+
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	workspace, err := s.store.GetWorkspace(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	content, err := renderWelcomeMessage(onboarding.WelcomeMessage, welcomeMessageData{
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		WorkspaceName: workspace.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render welcome message: %w", err)
+	}
+
+	bot, err := s.store.GetOnboardingBotTx(ctx, db.GetOnboardingBotTxParams{
+		WorkspaceID: workspaceID,
+		Bot: db.CreateUserParams{
+			OrganizationID: workspace.OrganizationID,
+			Email:          onboardingBotEmail(workspaceID),
+			FirstName:      onboardingBotFirstName,
+			LastName:       onboardingBotLastName,
+			HashedPassword: onboardingBotPassword,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get onboarding bot: %w", err)
+	}
+	if bot.ID == userID {
+		return nil
+	}
+
+	if _, err := s.messageService.SendDirectMessage(ctx, workspaceID, bot.ID, userID, content); err != nil {
+		return fmt.Errorf("failed to send welcome message: %w", err)
+	}
+
+	return nil
+}
+
+// GetOnboarding returns a member's onboarding checklist and the channels
+// suggested to them. Steps the member has done since they last looked are
+// recorded as completed.
+func (s *OnboardingService) GetOnboarding(ctx context.Context, workspaceID int64, user UserResponse) (OnboardingResponse, error) {
+	response := OnboardingResponse{
+		Checklist:         []OnboardingStepResponse{},
+		SuggestedChannels: []SuggestedChannelResponse{},
+	}
+
+	onboarding, err := s.store.GetWorkspaceOnboarding(ctx, workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return response, nil
+		}
+		return OnboardingResponse{}, fmt.Errorf("failed to get onboarding: %w", err)
+	}
+	if !onboarding.Enabled {
+		return response, nil
+	}
+	response.Enabled = true
+
+	joinedChannel := false
+	if len(onboarding.SuggestedChannelIds) > 0 {
+		channels, err := s.store.ListOnboardingChannels(ctx, db.ListOnboardingChannelsParams{
+			UserID:      user.ID,
+			WorkspaceID: workspaceID,
+			ChannelIds:  onboarding.SuggestedChannelIds,
+		})
+		if err != nil {
+			return OnboardingResponse{}, fmt.Errorf("failed to get suggested channels: %w", err)
+		}
+
+		// Channels are suggested in the order the admin listed them
+		byID := make(map[int64]db.ListOnboardingChannelsRow, len(channels))
+		for _, channel := range channels {
+			byID[channel.ID] = channel
+		}
+		for _, channelID := range onboarding.SuggestedChannelIds {
+			channel, ok := byID[channelID]
+			if !ok {
+				continue
+			}
+			response.SuggestedChannels = append(response.SuggestedChannels, SuggestedChannelResponse{
+				Channel: ChannelResponse{
+					ID:          channel.ID,
+					WorkspaceID: channel.WorkspaceID,
+					Name:        channel.Name,
+					IsPrivate:   channel.IsPrivate,
+					CreatedBy:   channel.CreatedBy,
+					CreatedAt:   channel.CreatedAt,
+				},
+				Joined: channel.IsMember,
+			})
+			joinedChannel = joinedChannel || channel.IsMember
+		}
+	}
+
+	completions, err := s.store.ListOnboardingStepCompletions(ctx, db.ListOnboardingStepCompletionsParams{
+		UserID:      user.ID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return OnboardingResponse{}, fmt.Errorf("failed to get onboarding progress: %w", err)
+	}
+	completed := make(map[string]db.OnboardingStepCompletion, len(completions))
+	for _, completion := range completions {
+		completed[completion.Step] = completion
+	}
+
+	response.Completed = true
+	for _, step := range onboarding.Steps {
+		title := onboardingStepTitle(step)
+		if title == "" {
+			continue
+		}
+		// Without suggestions there is nothing to join
+		if step == OnboardingStepJoinChannels && len(response.SuggestedChannels) == 0 {
+			continue
+		}
+
+		item := OnboardingStepResponse{Step: step, Title: title}
+		if completion, ok := completed[step]; ok {
+			item.Completed = true
+			item.CompletedAt = &completion.CompletedAt
+		} else {
+			done, err := s.isStepDone(ctx, workspaceID, user, step, joinedChannel)
+			if err != nil {
+				return OnboardingResponse{}, err
+			}
+			if done {
+				if err := s.store.CompleteOnboardingStep(ctx, db.CompleteOnboardingStepParams{
+					UserID:      user.ID,
+					WorkspaceID: workspaceID,
+					Step:        step,
+				}); err != nil {
+					return OnboardingResponse{}, fmt.Errorf("failed to complete onboarding step: %w", err)
+				}
+				item.Completed = true
+			}
+		}
+
+		response.Checklist = append(response.Checklist, item)
+		response.Completed = response.Completed && item.Completed
+	}
+
+	return response, nil
+}
+
+// JoinSuggestedChannels adds a member to channels onboarding suggests, or to
+// all of them when no channel IDs are given
+func (s *OnboardingService) JoinSuggestedChannels(ctx context.Context, workspaceID int64, user UserResponse, channelIDs []int64) (OnboardingResponse, error) {
+	onboarding, err := s.store.GetWorkspaceOnboarding(ctx, workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return OnboardingResponse{}, errors.New("onboarding not found")
+		}
+		return OnboardingResponse{}, fmt.Errorf("failed to get onboarding: %w", err)
+	}
+	if !onboarding.Enabled {
+		return OnboardingResponse{}, errors.New("onboarding not found")
+	}
+
+	if len(channelIDs) == 0 {
+		channelIDs = onboarding.SuggestedChannelIds
+	}
+	for _, channelID := range channelIDs {
+		if !slices.Contains(onboarding.SuggestedChannelIds, channelID) {
+			return OnboardingResponse{}, fmt.Errorf("channel %d is not suggested", channelID)
+		}
+	}
+
+	if len(channelIDs) > 0 {
+		channels, err := s.store.ListOnboardingChannels(ctx, db.ListOnboardingChannelsParams{
+			UserID:      user.ID,
+			WorkspaceID: workspaceID,
+			ChannelIds:  channelIDs,
+		})
+		if err != nil {
+			return OnboardingResponse{}, fmt.Errorf("failed to get suggested channels: %w", err)
+		}
+
+		for _, channel := range channels {
+			if channel.IsMember {
+				continue
+			}
+			if _, err := s.store.AddChannelMember(ctx, db.AddChannelMemberParams{
+				ChannelID: channel.ID,
+				UserID:    user.ID,
+				AddedBy:   user.ID,
+				Role:      "member",
+			}); err != nil {
+				return OnboardingResponse{}, fmt.Errorf("failed to join channel: %w", err)
+			}
+		}
+	}
+
+	return s.GetOnboarding(ctx, workspaceID, user)
+}
+
+// isStepDone checks whether a member has done a checklist step
+func (s *OnboardingService) isStepDone(ctx context.Context, workspaceID int64, user UserResponse, step string, joinedChannel bool) (bool, error) {
+	switch step {
+	case OnboardingStepAddPhoto:
+		return len(user.AvatarURLs) > 0, nil
+	case OnboardingStepAddTitle:
+		return user.Title != "", nil
+	case OnboardingStepChooseHandle:
+		return user.Handle != "", nil
+	case OnboardingStepJoinChannels:
+		return joinedChannel, nil
+	case OnboardingStepSendMessage:
+		posted, err := s.store.HasUserPostedInWorkspace(ctx, db.HasUserPostedInWorkspaceParams{
+			WorkspaceID: workspaceID,
+			SenderID:    user.ID,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to check messages: %w", err)
+		}
+		return posted, nil
+	}
+	return false, nil
+}
+
+// renderWelcomeMessage fills a welcome message template in for a member
+func renderWelcomeMessage(message string, data welcomeMessageData) (string, error) {
+	template, err := texttemplate.New("welcome_message").Parse(message)
+	if err != nil {
+		return "", err
+	}
+
+	var content strings.Builder
+	if err := template.Execute(&content, data); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(content.String()) == "" {
+		return "", errors.New("message is empty")
+	}
+	if content.Len() > 4000 {
+		return "", errors.New("message is longer than 4000 characters")
+	}
+
+	return content.String(), nil
+}
+
+// onboardingBotEmail is the email address of a workspace's onboarding bot,
+// on a domain that can't receive email
+func onboardingBotEmail(workspaceID int64) string {
+	return fmt.Sprintf("onboarding-bot+%d@goslack.invalid", workspaceID)
+}
+
+// onboardingStepTitle returns the title of a checklist step, or an empty
+// string for unknown steps
+func onboardingStepTitle(step string) string {
+	for _, known := range onboardingSteps {
+		if known.Step == step {
+			return known.Title
+		}
+	}
+	return ""
+}
+
+// defaultOnboardingSteps returns every checklist step in its default order
+func defaultOnboardingSteps() []string {
+	steps := make([]string, len(onboardingSteps))
+	for i, step := range onboardingSteps {
+		steps[i] = step.Step
+	}
+	return steps
+}
+
+func toOnboardingSettingsResponse(onboarding db.WorkspaceOnboarding) OnboardingSettingsResponse {
+	response := OnboardingSettingsResponse{
+		WorkspaceID:         onboarding.WorkspaceID,
+		Enabled:             onboarding.Enabled,
+		WelcomeMessage:      onboarding.WelcomeMessage,
+		SuggestedChannelIDs: onboarding.SuggestedChannelIds,
+		Steps:               onboarding.Steps,
+		AvailableSteps:      onboardingSteps,
+	}
+	if response.SuggestedChannelIDs == nil {
+		response.SuggestedChannelIDs = []int64{}
+	}
+	if response.Steps == nil {
+		response.Steps = []string{}
+	}
+	if onboarding.BotUserID.Valid {
+		response.BotUserID = &onboarding.BotUserID.Int64
+	}
+	if !onboarding.UpdatedAt.IsZero() {
+		response.UpdatedAt = &onboarding.UpdatedAt
+	}
+	return response
+}
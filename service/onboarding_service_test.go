@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnboardingService_UpdateSettings(t *testing.T) {
+	const workspaceID, adminID = int64(3), int64(7)
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		onboardingService := NewOnboardingService(store, nil)
+
+		store.EXPECT().
+			ListOnboardingChannels(gomock.Any(), gomock.Eq(db.ListOnboardingChannelsParams{UserID: adminID, WorkspaceID: workspaceID, ChannelIds: []int64{20, 21}})).
+			Times(1).
+			Return([]db.ListOnboardingChannelsRow{{ID: 20}, {ID: 21}}, nil)
+		store.EXPECT().
+			UpsertWorkspaceOnboarding(gomock.Any(), gomock.Eq(db.UpsertWorkspaceOnboardingParams{
+				WorkspaceID:         workspaceID,
+				Enabled:             true,
+				WelcomeMessage:      "Hi {{.FirstName}}, welcome to {{.WorkspaceName}}!",
+				SuggestedChannelIds: []int64{20, 21},
+				Steps:               []string{OnboardingStepAddPhoto, OnboardingStepJoinChannels},
+				UpdatedBy:           sql.NullInt64{Int64: adminID, Valid: true},
+			})).
+			Times(1).
+			Return(db.WorkspaceOnboarding{
+				WorkspaceID:         workspaceID,
+				Enabled:             true,
+				WelcomeMessage:      "Hi {{.FirstName}}, welcome to {{.WorkspaceName}}!",
+				SuggestedChannelIds: []int64{20, 21},
+				Steps:               []string{OnboardingStepAddPhoto, OnboardingStepJoinChannels},
+			}, nil)
+
+		settings, err := onboardingService.UpdateSettings(context.Background(), workspaceID, adminID, UpdateOnboardingRequest{
+			WelcomeMessage:      "Hi {{.FirstName}}, welcome to {{.WorkspaceName}}!",
+			SuggestedChannelIDs: []int64{20, 21, 20},
+			Steps:               []string{OnboardingStepAddPhoto, OnboardingStepJoinChannels},
+		})
+		require.NoError(t, err)
+		require.True(t, settings.Enabled)
+		require.Equal(t, []int64{20, 21}, settings.SuggestedChannelIDs)
+		require.Len(t, settings.AvailableSteps, len(onboardingSteps))
+	})
+
+	testCases := []struct {
+		name string
+		req  UpdateOnboardingRequest
+		// Channels ListOnboardingChannels finds, nil when it isn't called
+		channels []db.ListOnboardingChannelsRow
+		err      string
+	}{
+		{
+			name: "UnknownStep",
+			req:  UpdateOnboardingRequest{WelcomeMessage: "Hi", Steps: []string{"read_handbook"}},
+			err:  "invalid onboarding step 'read_handbook'",
+		},
+		{
+			name: "DuplicateStep",
+			req:  UpdateOnboardingRequest{WelcomeMessage: "Hi", Steps: []string{OnboardingStepAddTitle, OnboardingStepAddTitle}},
+			err:  "invalid onboarding step 'add_title': listed twice",
+		},
+		{
+			name:     "PrivateChannel",
+			req:      UpdateOnboardingRequest{WelcomeMessage: "Hi", SuggestedChannelIDs: []int64{20, 22}},
+			channels: []db.ListOnboardingChannelsRow{{ID: 20}},
+			err:      "invalid suggested channels: only public channels of the workspace can be suggested",
+		},
+		{
+			name: "UnknownVariable",
+			req:  UpdateOnboardingRequest{WelcomeMessage: "Hi {{.Nickname}}"},
+			err:  "invalid welcome message",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			onboardingService := NewOnboardingService(store, nil)
+
+			if tc.channels != nil {
+				store.EXPECT().ListOnboardingChannels(gomock.Any(), gomock.Any()).Times(1).Return(tc.channels, nil)
+			}
+			store.EXPECT().UpsertWorkspaceOnboarding(gomock.Any(), gomock.Any()).Times(0)
+
+			_, err := onboardingService.UpdateSettings(context.Background(), workspaceID, adminID, tc.req)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestOnboardingService_GetOnboarding(t *testing.T) {
+	const workspaceID = int64(3)
+	user := UserResponse{ID: 9, Title: "Engineer"}
+	photoAddedAt := time.Now().Add(-time.Hour)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	onboardingService := NewOnboardingService(store, nil)
+
+	store.EXPECT().
+		GetWorkspaceOnboarding(gomock.Any(), gomock.Eq(workspaceID)).
+		Times(1).
+		Return(db.WorkspaceOnboarding{
+			WorkspaceID:         workspaceID,
+			Enabled:             true,
+			SuggestedChannelIds: []int64{21, 20},
+			Steps:               defaultOnboardingSteps(),
+		}, nil)
+	store.EXPECT().
+		ListOnboardingChannels(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.ListOnboardingChannelsRow{
+			{ID: 20, WorkspaceID: workspaceID, Name: "general", IsMember: true},
+			{ID: 21, WorkspaceID: workspaceID, Name: "random"},
+		}, nil)
+
+	// The photo was added and later removed; it stays completed
+	store.EXPECT().
+		ListOnboardingStepCompletions(gomock.Any(), gomock.Eq(db.ListOnboardingStepCompletionsParams{UserID: user.ID, WorkspaceID: workspaceID})).
+		Times(1).
+		Return([]db.OnboardingStepCompletion{{UserID: user.ID, WorkspaceID: workspaceID, Step: OnboardingStepAddPhoto, CompletedAt: photoAddedAt}}, nil)
+
+	// Steps done since the last look are recorded
+	for _, step := range []string{OnboardingStepAddTitle, OnboardingStepJoinChannels} {
+		store.EXPECT().
+			CompleteOnboardingStep(gomock.Any(), gomock.Eq(db.CompleteOnboardingStepParams{UserID: user.ID, WorkspaceID: workspaceID, Step: step})).
+			Times(1).
+			Return(nil)
+	}
+	store.EXPECT().
+		HasUserPostedInWorkspace(gomock.Any(), gomock.Eq(db.HasUserPostedInWorkspaceParams{WorkspaceID: workspaceID, SenderID: user.ID})).
+		Times(1).
+		Return(false, nil)
+
+	onboarding, err := onboardingService.GetOnboarding(context.Background(), workspaceID, user)
+	require.NoError(t, err)
+	require.True(t, onboarding.Enabled)
+	require.False(t, onboarding.Completed)
+
+	// Channels keep the order the admin suggested them in
+	require.Len(t, onboarding.SuggestedChannels, 2)
+	require.Equal(t, "random", onboarding.SuggestedChannels[0].Channel.Name)
+	require.False(t, onboarding.SuggestedChannels[0].Joined)
+	require.True(t, onboarding.SuggestedChannels[1].Joined)
+
+	completed := map[string]bool{}
+	for _, step := range onboarding.Checklist {
+		completed[step.Step] = step.Completed
+	}
+	require.Equal(t, map[string]bool{
+		OnboardingStepAddPhoto:     true,
+		OnboardingStepAddTitle:     true,
+		OnboardingStepChooseHandle: false,
+		OnboardingStepJoinChannels: true,
+		OnboardingStepSendMessage:  false,
+	}, completed)
+	require.Equal(t, photoAddedAt, *onboarding.Checklist[0].CompletedAt)
+}
+
+func TestOnboardingService_WelcomeMemberWithoutOnboarding(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	onboardingService := NewOnboardingService(store, nil)
+
+	store.EXPECT().GetWorkspaceOnboarding(gomock.Any(), gomock.Eq(int64(3))).Times(1).Return(db.WorkspaceOnboarding{}, sql.ErrNoRows)
+	store.EXPECT().GetWorkspaceOnboarding(gomock.Any(), gomock.Eq(int64(4))).Times(1).Return(db.WorkspaceOnboarding{WorkspaceID: 4}, nil)
+	store.EXPECT().GetOnboardingBotTx(gomock.Any(), gomock.Any()).Times(0)
+
+	require.NoError(t, onboardingService.WelcomeMember(context.Background(), 3, 9))
+	// Disabled onboarding sends nothing either
+	require.NoError(t, onboardingService.WelcomeMember(context.Background(), 4, 9))
+}
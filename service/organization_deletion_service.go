@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Organization deletion statuses
+const (
+	OrganizationDeletionStatusPending   = "pending"
+	OrganizationDeletionStatusRunning   = "running"
+	OrganizationDeletionStatusCompleted = "completed"
+	OrganizationDeletionStatusCancelled = "cancelled"
+)
+
+// Organization deletion steps, in the order they run
+const (
+	OrganizationDeletionStepWorkspaces   = "workspaces"
+	OrganizationDeletionStepAvatars      = "avatars"
+	OrganizationDeletionStepOrganization = "organization"
+)
+
+// organizationDeletionsPerRun caps how many organizations one worker pass works through
+const organizationDeletionsPerRun = 10
+
+// organizationDeletionServiceStore covers the organization being deleted, its
+// owners and members, and the workspaces handed to the teardown job
+type organizationDeletionServiceStore interface {
+	db.OrganizationStore
+	db.UserStore
+	db.WorkspaceStore
+}
+
+// OrganizationDeletionService deletes organizations in stages. A requested
+// deletion locks members out of the organization's workspaces at once and can
+// be cancelled until its window passes. After that the workspaces are handed
+// to the workspace teardown job, and once they're gone the organization
+// itself is deleted.
+type OrganizationDeletionService struct {
+	store        organizationDeletionServiceStore
+	emailService *EmailService
+	fileService  *FileService
+	hub          WebSocketHub
+	window       time.Duration
+	appBaseURL   string
+	now          func() time.Time
+}
+
+// NewOrganizationDeletionService creates a new organization deletion service
+func NewOrganizationDeletionService(store organizationDeletionServiceStore, emailService *EmailService, fileService *FileService, hub WebSocketHub, config util.Config) *OrganizationDeletionService {
+	window := config.OrganizationDeletionWindow
+	if window <= 0 {
+		window = 72 * time.Hour
+	}
+
+	return &OrganizationDeletionService{
+		store:        store,
+		emailService: emailService,
+		fileService:  fileService,
+		hub:          hub,
+		window:       window,
+		appBaseURL:   strings.TrimRight(config.AppBaseURL, "/"),
+		now:          time.Now,
+	}
+}
+
+// RequestDeletion schedules an organization's deletion at the end of the
+// cancellation window. Members lose access to its workspaces straight away
+// and the owners and admins are told by email.
+func (s *OrganizationDeletionService) RequestDeletion(ctx context.Context, organizationID, requesterID int64) (OrganizationDeletionResponse, error) {
+	if err := s.checkOwner(ctx, organizationID, requesterID); err != nil {
+		return OrganizationDeletionResponse{}, err
+	}
+
+	organization, err := s.store.GetOrganization(ctx, organizationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return OrganizationDeletionResponse{}, errors.New("organization not found")
+		}
+		return OrganizationDeletionResponse{}, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	held, err := s.store.OrganizationHasActiveLegalHold(ctx, organizationID)
+	if err != nil {
+		return OrganizationDeletionResponse{}, fmt.Errorf("failed to check legal holds: %w", err)
+	}
+	if held {
+		return OrganizationDeletionResponse{}, errors.New("organization has content under legal hold")
+	}
+
+	deletion, err := s.store.CreateOrganizationDeletion(ctx, db.CreateOrganizationDeletionParams{
+		OrganizationID:   organizationID,
+		OrganizationName: organization.Name,
+		RequestedBy:      sql.NullInt64{Int64: requesterID, Valid: true},
+		ScheduledFor:     s.now().Add(s.window),
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return OrganizationDeletionResponse{}, errors.New("organization deletion is already in progress")
+		}
+		return OrganizationDeletionResponse{}, fmt.Errorf("failed to schedule organization deletion: %w", err)
+	}
+
+	// Workspace roles already exclude the organization, so only live
+	// connections still need closing
+	if err := s.disconnectWorkspaces(ctx, organizationID); err != nil {
+		fmt.Printf("Warning: failed to disconnect workspaces of organization %d: %v\n", organizationID, err)
+	}
+	s.notifyAdmins(ctx, deletion, requesterID)
+
+	return toOrganizationDeletionResponse(deletion), nil
+}
+
+// GetDeletion reports the organization's most recent deletion
+func (s *OrganizationDeletionService) GetDeletion(ctx context.Context, organizationID int64) (OrganizationDeletionResponse, error) {
+	deletion, err := s.store.GetOrganizationDeletion(ctx, organizationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return OrganizationDeletionResponse{}, errors.New("organization deletion not found")
+		}
+		return OrganizationDeletionResponse{}, fmt.Errorf("failed to get organization deletion: %w", err)
+	}
+	return toOrganizationDeletionResponse(deletion), nil
+}
+
+// CancelDeletion cancels a deletion that's still in its cancellation window,
+// which gives members their access back
+func (s *OrganizationDeletionService) CancelDeletion(ctx context.Context, organizationID, userID int64) (OrganizationDeletionResponse, error) {
+	if err := s.checkOwner(ctx, organizationID, userID); err != nil {
+		return OrganizationDeletionResponse{}, err
+	}
+
+	deletion, err := s.store.CancelOrganizationDeletion(ctx, db.CancelOrganizationDeletionParams{
+		OrganizationID: organizationID,
+		CancelledBy:    sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err == nil {
+		return toOrganizationDeletionResponse(deletion), nil
+	}
+	if err != sql.ErrNoRows {
+		return OrganizationDeletionResponse{}, fmt.Errorf("failed to cancel organization deletion: %w", err)
+	}
+
+	latest, err := s.store.GetOrganizationDeletion(ctx, organizationID)
+	if err == nil && latest.Status == OrganizationDeletionStatusRunning {
+		return OrganizationDeletionResponse{}, errors.New("organization deletion has already started and can no longer be cancelled")
+	}
+	return OrganizationDeletionResponse{}, errors.New("organization deletion not found")
+}
+
+// RunDeletions works through deletions whose cancellation window has passed.
+// A failing deletion records its error and is retried on the next pass
+// without holding up the others.
+func (s *OrganizationDeletionService) RunDeletions(ctx context.Context) error {
+	deletions, err := s.store.ListDueOrganizationDeletions(ctx, db.ListDueOrganizationDeletionsParams{
+		ScheduledFor: s.now(),
+		Limit:        organizationDeletionsPerRun,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list organization deletions: %w", err)
+	}
+
+	for _, deletion := range deletions {
+		if err := s.deleteOrganization(ctx, deletion); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Printf("Error deleting organization %d: %v\n", deletion.OrganizationID, err)
+			recordErr := s.store.RecordOrganizationDeletionError(ctx, db.RecordOrganizationDeletionErrorParams{
+				ID:        deletion.ID,
+				LastError: sql.NullString{String: err.Error(), Valid: true},
+			})
+			if recordErr != nil {
+				return fmt.Errorf("failed to record organization deletion error: %w", recordErr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// StartDeletionWorker runs due deletions on an interval until the context is cancelled
+func (s *OrganizationDeletionService) StartDeletionWorker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RunDeletions(ctx); err != nil {
+				fmt.Printf("Error running organization deletions: %v\n", err)
+			}
+		}
+	}
+}
+
+// deleteOrganization moves a due deletion along. It queues teardowns for the
+// organization's workspaces and returns until the teardown job has removed
+// them all, then deletes the members' avatars and the organization, which
+// cascades to its remaining rows.
+func (s *OrganizationDeletionService) deleteOrganization(ctx context.Context, deletion db.OrganizationDeletion) error {
+	organizationID := deletion.OrganizationID
+
+	// A hold placed during the window keeps the organization until it's released
+	held, err := s.store.OrganizationHasActiveLegalHold(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to check legal holds: %w", err)
+	}
+	if held {
+		return errors.New("organization has content under legal hold")
+	}
+
+	deletion, err = s.store.StartOrganizationDeletion(ctx, deletion.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Cancelled since it was listed
+			return nil
+		}
+		return fmt.Errorf("failed to start organization deletion: %w", err)
+	}
+
+	// Queued again on every pass to pick up workspaces created since
+	if _, err := s.store.QueueOrganizationWorkspaceTeardowns(ctx, organizationID); err != nil {
+		return fmt.Errorf("failed to queue workspace teardowns: %w", err)
+	}
+
+	progress, err := s.store.GetOrganizationTeardownProgress(ctx, db.GetOrganizationTeardownProgressParams{
+		OrganizationID: organizationID,
+		Since:          deletion.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get workspace teardown progress: %w", err)
+	}
+	if err := s.recordProgress(ctx, deletion.ID, OrganizationDeletionStepWorkspaces, progress, 0); err != nil {
+		return err
+	}
+
+	remaining, err := s.store.CountOrganizationWorkspaces(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to count workspaces: %w", err)
+	}
+	if remaining > 0 {
+		return nil
+	}
+
+	avatarKeys, err := s.store.ListOrganizationAvatarKeys(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to list avatars: %w", err)
+	}
+	if s.fileService != nil {
+		for _, key := range avatarKeys {
+			s.fileService.removeAvatarFiles(key)
+		}
+	}
+	if err := s.recordProgress(ctx, deletion.ID, OrganizationDeletionStepAvatars, progress, int64(len(avatarKeys))); err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteOrganization(ctx, organizationID); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	if err := s.recordProgress(ctx, deletion.ID, OrganizationDeletionStepOrganization, progress, int64(len(avatarKeys))); err != nil {
+		return err
+	}
+
+	if err := s.store.CompleteOrganizationDeletion(ctx, deletion.ID); err != nil {
+		return fmt.Errorf("failed to complete organization deletion: %w", err)
+	}
+
+	fmt.Printf("Deleted organization %d (%s)\n", organizationID, deletion.OrganizationName)
+	return nil
+}
+
+func (s *OrganizationDeletionService) recordProgress(ctx context.Context, deletionID int64, step string, progress db.GetOrganizationTeardownProgressRow, avatarsDeleted int64) error {
+	err := s.store.RecordOrganizationDeletionProgress(ctx, db.RecordOrganizationDeletionProgressParams{
+		Step:              step,
+		WorkspacesTotal:   progress.WorkspacesTotal,
+		WorkspacesDeleted: progress.WorkspacesDeleted,
+		RowsDeleted:       progress.RowsDeleted,
+		FilesDeleted:      progress.FilesDeleted + avatarsDeleted,
+		ID:                deletionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record organization deletion progress: %w", err)
+	}
+	return nil
+}
+
+// checkOwner allows only the organization's owners to delete it or cancel its deletion
+func (s *OrganizationDeletionService) checkOwner(ctx context.Context, organizationID, userID int64) error {
+	role, err := s.store.GetOrganizationRole(ctx, db.GetOrganizationRoleParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to get organization role: %w", err)
+	}
+	if role != OrganizationRoleOwner {
+		return errors.New("access denied: only organization owners can delete the organization")
+	}
+	return nil
+}
+
+// disconnectWorkspaces closes the live connections to the organization's workspaces
+func (s *OrganizationDeletionService) disconnectWorkspaces(ctx context.Context, organizationID int64) error {
+	if s.hub == nil {
+		return nil
+	}
+
+	const pageSize = 100
+	for offset := int32(0); ; offset += pageSize {
+		workspaces, err := s.store.ListWorkspacesByOrganization(ctx, db.ListWorkspacesByOrganizationParams{
+			OrganizationID: organizationID,
+			Limit:          pageSize,
+			Offset:         offset,
+		})
+		if err != nil {
+			return err
+		}
+		for _, workspace := range workspaces {
+			s.hub.DisconnectWorkspace(workspace.ID)
+		}
+		if len(workspaces) < pageSize {
+			return nil
+		}
+	}
+}
+
+// notifyAdmins emails the organization's owners and admins that its deletion
+// was requested. Failures are logged, the deletion stands either way.
+func (s *OrganizationDeletionService) notifyAdmins(ctx context.Context, deletion db.OrganizationDeletion, requesterID int64) {
+	if s.emailService == nil {
+		return
+	}
+
+	admins, err := s.store.ListOrganizationRoles(ctx, deletion.OrganizationID)
+	if err != nil {
+		fmt.Printf("Warning: failed to list organization admins: %v\n", err)
+		return
+	}
+
+	requesterName := ""
+	for _, admin := range admins {
+		if admin.UserID == requesterID {
+			requesterName = strings.TrimSpace(admin.FirstName + " " + admin.LastName)
+		}
+	}
+	if requesterName == "" {
+		requesterName = "An owner"
+	}
+
+	for _, admin := range admins {
+		message, err := s.emailService.Render(ctx, deletion.OrganizationID, EmailKindOrganizationDeletion, admin.Email, organizationDeletionEmailData{
+			OrganizationName: deletion.OrganizationName,
+			RequesterName:    requesterName,
+			ScheduledFor:     deletion.ScheduledFor.UTC().Format("January 2, 2006 at 15:04 MST"),
+			Link:             s.appBaseURL + "/organizations/" + strconv.FormatInt(deletion.OrganizationID, 10) + "/deletion",
+		})
+		if err == nil {
+			message.ReferenceID = deletion.ID
+			err = s.emailService.Send(ctx, message)
+		}
+		if err != nil {
+			fmt.Printf("Warning: failed to email organization deletion notice to %s: %v\n", admin.Email, err)
+		}
+	}
+}
+
+func toOrganizationDeletionResponse(deletion db.OrganizationDeletion) OrganizationDeletionResponse {
+	resp := OrganizationDeletionResponse{
+		ID:                deletion.ID,
+		OrganizationID:    deletion.OrganizationID,
+		OrganizationName:  deletion.OrganizationName,
+		Status:            deletion.Status,
+		Step:              deletion.Step,
+		WorkspacesTotal:   deletion.WorkspacesTotal,
+		WorkspacesDeleted: deletion.WorkspacesDeleted,
+		RowsDeleted:       deletion.RowsDeleted,
+		FilesDeleted:      deletion.FilesDeleted,
+		ScheduledFor:      deletion.ScheduledFor,
+		CreatedAt:         deletion.CreatedAt,
+		UpdatedAt:         deletion.UpdatedAt,
+	}
+	if deletion.RequestedBy.Valid {
+		resp.RequestedBy = &deletion.RequestedBy.Int64
+	}
+	if deletion.LastError.Valid {
+		resp.LastError = &deletion.LastError.String
+	}
+	if deletion.StartedAt.Valid {
+		resp.StartedAt = &deletion.StartedAt.Time
+	}
+	if deletion.CompletedAt.Valid {
+		resp.CompletedAt = &deletion.CompletedAt.Time
+	}
+	if deletion.CancelledAt.Valid {
+		resp.CancelledAt = &deletion.CancelledAt.Time
+	}
+	return resp
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationDeletionService_RequestDeletion(t *testing.T) {
+	const organizationID, ownerID = int64(4), int64(9)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		GetOrganizationRole(gomock.Any(), db.GetOrganizationRoleParams{OrganizationID: organizationID, UserID: ownerID}).
+		Return(OrganizationRoleOwner, nil)
+	store.EXPECT().GetOrganization(gomock.Any(), organizationID).Return(db.Organization{ID: organizationID, Name: "Acme"}, nil)
+	store.EXPECT().OrganizationHasActiveLegalHold(gomock.Any(), organizationID).Return(false, nil)
+	store.EXPECT().
+		CreateOrganizationDeletion(gomock.Any(), db.CreateOrganizationDeletionParams{
+			OrganizationID:   organizationID,
+			OrganizationName: "Acme",
+			RequestedBy:      sql.NullInt64{Int64: ownerID, Valid: true},
+			ScheduledFor:     now.Add(24 * time.Hour),
+		}).
+		Return(db.OrganizationDeletion{
+			ID:               1,
+			OrganizationID:   organizationID,
+			OrganizationName: "Acme",
+			Status:           OrganizationDeletionStatusPending,
+			ScheduledFor:     now.Add(24 * time.Hour),
+		}, nil)
+	store.EXPECT().
+		ListWorkspacesByOrganization(gomock.Any(), gomock.Any()).
+		Return([]db.Workspace{{ID: 11}, {ID: 12}}, nil)
+
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	deletionService := NewOrganizationDeletionService(store, nil, nil, hub, util.Config{OrganizationDeletionWindow: 24 * time.Hour})
+	deletionService.now = func() time.Time { return now }
+
+	deletion, err := deletionService.RequestDeletion(ctx, organizationID, ownerID)
+	require.NoError(t, err)
+	require.Equal(t, OrganizationDeletionStatusPending, deletion.Status)
+	require.Equal(t, now.Add(24*time.Hour), deletion.ScheduledFor)
+	require.Equal(t, []int64{11, 12}, hub.disconnectedWorkspaces)
+}
+
+func TestOrganizationDeletionService_RequestDeletionByAdmin(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetOrganizationRole(gomock.Any(), gomock.Any()).Return(OrganizationRoleAdmin, nil)
+	store.EXPECT().CreateOrganizationDeletion(gomock.Any(), gomock.Any()).Times(0)
+
+	deletionService := NewOrganizationDeletionService(store, nil, nil, nil, util.Config{})
+	_, err := deletionService.RequestDeletion(ctx, 4, 9)
+	require.ErrorContains(t, err, "access denied")
+}
+
+func TestOrganizationDeletionService_RunDeletionsWaitsForWorkspaces(t *testing.T) {
+	const organizationID = int64(4)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	deletion := db.OrganizationDeletion{ID: 1, OrganizationID: organizationID, Status: OrganizationDeletionStatusPending}
+	store.EXPECT().ListDueOrganizationDeletions(gomock.Any(), gomock.Any()).Return([]db.OrganizationDeletion{deletion}, nil)
+	store.EXPECT().OrganizationHasActiveLegalHold(gomock.Any(), organizationID).Return(false, nil)
+	store.EXPECT().StartOrganizationDeletion(gomock.Any(), deletion.ID).Return(deletion, nil)
+	store.EXPECT().QueueOrganizationWorkspaceTeardowns(gomock.Any(), organizationID).Return(int64(3), nil)
+	store.EXPECT().
+		GetOrganizationTeardownProgress(gomock.Any(), gomock.Any()).
+		Return(db.GetOrganizationTeardownProgressRow{WorkspacesTotal: 3, WorkspacesDeleted: 1, RowsDeleted: 40, FilesDeleted: 2}, nil)
+	store.EXPECT().
+		RecordOrganizationDeletionProgress(gomock.Any(), db.RecordOrganizationDeletionProgressParams{
+			Step:              OrganizationDeletionStepWorkspaces,
+			WorkspacesTotal:   3,
+			WorkspacesDeleted: 1,
+			RowsDeleted:       40,
+			FilesDeleted:      2,
+			ID:                deletion.ID,
+		}).
+		Return(nil)
+	store.EXPECT().CountOrganizationWorkspaces(gomock.Any(), organizationID).Return(int64(2), nil)
+
+	// The organization stays until the teardown job has removed its workspaces
+	store.EXPECT().DeleteOrganization(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().CompleteOrganizationDeletion(gomock.Any(), gomock.Any()).Times(0)
+
+	deletionService := NewOrganizationDeletionService(store, nil, nil, nil, util.Config{})
+	require.NoError(t, deletionService.RunDeletions(ctx))
+}
+
+func TestOrganizationDeletionService_RunDeletionsDeletesOrganization(t *testing.T) {
+	const organizationID = int64(4)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	deletion := db.OrganizationDeletion{ID: 1, OrganizationID: organizationID, Status: OrganizationDeletionStatusRunning}
+	store.EXPECT().ListDueOrganizationDeletions(gomock.Any(), gomock.Any()).Return([]db.OrganizationDeletion{deletion}, nil)
+	store.EXPECT().OrganizationHasActiveLegalHold(gomock.Any(), organizationID).Return(false, nil)
+	store.EXPECT().StartOrganizationDeletion(gomock.Any(), deletion.ID).Return(deletion, nil)
+	store.EXPECT().QueueOrganizationWorkspaceTeardowns(gomock.Any(), organizationID).Return(int64(0), nil)
+	store.EXPECT().
+		GetOrganizationTeardownProgress(gomock.Any(), gomock.Any()).
+		Return(db.GetOrganizationTeardownProgressRow{WorkspacesTotal: 2, WorkspacesDeleted: 2, RowsDeleted: 80, FilesDeleted: 5}, nil)
+	store.EXPECT().CountOrganizationWorkspaces(gomock.Any(), organizationID).Return(int64(0), nil)
+	store.EXPECT().ListOrganizationAvatarKeys(gomock.Any(), organizationID).Return([]string{"a", "b"}, nil)
+
+	var steps []string
+	var filesDeleted int64
+	store.EXPECT().
+		RecordOrganizationDeletionProgress(gomock.Any(), gomock.Any()).
+		Times(3).
+		DoAndReturn(func(_ context.Context, arg db.RecordOrganizationDeletionProgressParams) error {
+			steps = append(steps, arg.Step)
+			filesDeleted = arg.FilesDeleted
+			return nil
+		})
+	gomock.InOrder(
+		store.EXPECT().DeleteOrganization(gomock.Any(), organizationID).Return(nil),
+		store.EXPECT().CompleteOrganizationDeletion(gomock.Any(), deletion.ID).Return(nil),
+	)
+	store.EXPECT().RecordOrganizationDeletionError(gomock.Any(), gomock.Any()).Times(0)
+
+	deletionService := NewOrganizationDeletionService(store, nil, nil, nil, util.Config{})
+	require.NoError(t, deletionService.RunDeletions(ctx))
+
+	require.Equal(t, []string{
+		OrganizationDeletionStepWorkspaces,
+		OrganizationDeletionStepAvatars,
+		OrganizationDeletionStepOrganization,
+	}, steps)
+	// 5 workspace files and 2 avatars
+	require.Equal(t, int64(7), filesDeleted)
+}
+
+func TestOrganizationDeletionService_RunDeletionsWaitsForLegalHold(t *testing.T) {
+	const organizationID = int64(4)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	deletion := db.OrganizationDeletion{ID: 1, OrganizationID: organizationID, Status: OrganizationDeletionStatusPending}
+	store.EXPECT().ListDueOrganizationDeletions(gomock.Any(), gomock.Any()).Return([]db.OrganizationDeletion{deletion}, nil)
+	store.EXPECT().OrganizationHasActiveLegalHold(gomock.Any(), organizationID).Return(true, nil)
+	store.EXPECT().StartOrganizationDeletion(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().
+		RecordOrganizationDeletionError(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.RecordOrganizationDeletionErrorParams) error {
+			require.Equal(t, deletion.ID, arg.ID)
+			require.Contains(t, arg.LastError.String, "under legal hold")
+			return nil
+		})
+
+	deletionService := NewOrganizationDeletionService(store, nil, nil, nil, util.Config{})
+	require.NoError(t, deletionService.RunDeletions(ctx))
+}
+
+func TestOrganizationDeletionService_CancelDeletionAfterStart(t *testing.T) {
+	const organizationID, ownerID = int64(4), int64(9)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetOrganizationRole(gomock.Any(), gomock.Any()).Return(OrganizationRoleOwner, nil)
+	store.EXPECT().CancelOrganizationDeletion(gomock.Any(), gomock.Any()).Return(db.OrganizationDeletion{}, sql.ErrNoRows)
+	store.EXPECT().
+		GetOrganizationDeletion(gomock.Any(), organizationID).
+		Return(db.OrganizationDeletion{ID: 1, OrganizationID: organizationID, Status: OrganizationDeletionStatusRunning}, nil)
+
+	deletionService := NewOrganizationDeletionService(store, nil, nil, nil, util.Config{})
+	_, err := deletionService.CancelDeletion(ctx, organizationID, ownerID)
+	require.ErrorContains(t, err, "can no longer be cancelled")
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Organization roles
+const (
+	OrganizationRoleOwner = "owner"
+	OrganizationRoleAdmin = "admin"
+)
+
+// organizationRoleServiceStore covers organization roles and the users who hold them
+type organizationRoleServiceStore interface {
+	db.OrganizationStore
+	db.UserStore
+}
+
+// OrganizationRoleService manages organization owners and admins. Both
+// administer every workspace in the organization; only owners can grant or
+// take away ownership, and an organization always keeps at least one owner.
+type OrganizationRoleService struct {
+	store organizationRoleServiceStore
+}
+
+// NewOrganizationRoleService creates a new organization role service
+func NewOrganizationRoleService(store organizationRoleServiceStore) *OrganizationRoleService {
+	return &OrganizationRoleService{
+		store: store,
+	}
+}
+
+// GetRole returns a user's organization role, or an empty string when they
+// don't hold one
+func (s *OrganizationRoleService) GetRole(ctx context.Context, organizationID, userID int64) (string, error) {
+	role, err := s.store.GetOrganizationRole(ctx, db.GetOrganizationRoleParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get organization role: %w", err)
+	}
+	return role, nil
+}
+
+// IsOrganizationAdmin checks if a user is an owner or admin of an organization
+func (s *OrganizationRoleService) IsOrganizationAdmin(ctx context.Context, organizationID, userID int64) (bool, error) {
+	role, err := s.GetRole(ctx, organizationID, userID)
+	if err != nil {
+		return false, err
+	}
+	return role == OrganizationRoleOwner || role == OrganizationRoleAdmin, nil
+}
+
+// ListRoles lists an organization's owners and admins
+func (s *OrganizationRoleService) ListRoles(ctx context.Context, organizationID int64) ([]OrganizationRoleResponse, error) {
+	roles, err := s.store.ListOrganizationRoles(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization roles: %w", err)
+	}
+
+	responses := make([]OrganizationRoleResponse, len(roles))
+	for i, role := range roles {
+		responses[i] = OrganizationRoleResponse{
+			OrganizationID: role.OrganizationID,
+			UserID:         role.UserID,
+			Email:          role.Email,
+			FirstName:      role.FirstName,
+			LastName:       role.LastName,
+			Role:           role.Role,
+			CreatedAt:      role.CreatedAt,
+			UpdatedAt:      role.UpdatedAt,
+		}
+		if role.GrantedBy.Valid {
+			responses[i].GrantedBy = &role.GrantedBy.Int64
+		}
+	}
+	return responses, nil
+}
+
+// AssignRole makes a user of the organization an owner or admin of it
+func (s *OrganizationRoleService) AssignRole(ctx context.Context, organizationID, actorID, userID int64, role string) (OrganizationRoleResponse, error) {
+	if role != OrganizationRoleOwner && role != OrganizationRoleAdmin {
+		return OrganizationRoleResponse{}, fmt.Errorf("invalid organization role: %s", role)
+	}
+
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return OrganizationRoleResponse{}, errors.New("user not found in organization")
+		}
+		return OrganizationRoleResponse{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.OrganizationID != organizationID {
+		return OrganizationRoleResponse{}, errors.New("user not found in organization")
+	}
+
+	currentRole, err := s.checkRoleChange(ctx, organizationID, actorID, userID, role == OrganizationRoleOwner)
+	if err != nil {
+		return OrganizationRoleResponse{}, err
+	}
+	if currentRole == OrganizationRoleOwner && role != OrganizationRoleOwner {
+		if err := s.checkRemainingOwners(ctx, organizationID); err != nil {
+			return OrganizationRoleResponse{}, err
+		}
+	}
+
+	assigned, err := s.store.UpsertOrganizationRole(ctx, db.UpsertOrganizationRoleParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		GrantedBy:      sql.NullInt64{Int64: actorID, Valid: true},
+	})
+	if err != nil {
+		return OrganizationRoleResponse{}, fmt.Errorf("failed to assign organization role: %w", err)
+	}
+
+	resp := OrganizationRoleResponse{
+		OrganizationID: assigned.OrganizationID,
+		UserID:         assigned.UserID,
+		Email:          user.Email,
+		FirstName:      user.FirstName,
+		LastName:       user.LastName,
+		Role:           assigned.Role,
+		GrantedBy:      &actorID,
+		CreatedAt:      assigned.CreatedAt,
+		UpdatedAt:      assigned.UpdatedAt,
+	}
+	return resp, nil
+}
+
+// RemoveRole takes away a user's organization role. They keep their role in
+// their own workspace.
+func (s *OrganizationRoleService) RemoveRole(ctx context.Context, organizationID, actorID, userID int64) error {
+	currentRole, err := s.checkRoleChange(ctx, organizationID, actorID, userID, false)
+	if err != nil {
+		return err
+	}
+	if currentRole == "" {
+		return errors.New("organization role not found")
+	}
+	if currentRole == OrganizationRoleOwner {
+		if err := s.checkRemainingOwners(ctx, organizationID); err != nil {
+			return err
+		}
+	}
+
+	rows, err := s.store.DeleteOrganizationRole(ctx, db.DeleteOrganizationRoleParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove organization role: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("organization role not found")
+	}
+
+	return nil
+}
+
+// checkRoleChange stops admins from granting ownership or changing an owner,
+// and returns the target's current organization role
+func (s *OrganizationRoleService) checkRoleChange(ctx context.Context, organizationID, actorID, userID int64, grantsOwner bool) (string, error) {
+	actorRole, err := s.GetRole(ctx, organizationID, actorID)
+	if err != nil {
+		return "", err
+	}
+
+	currentRole, err := s.GetRole(ctx, organizationID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	if actorRole != OrganizationRoleOwner {
+		if grantsOwner {
+			return "", errors.New("access denied: only owners can grant ownership")
+		}
+		if currentRole == OrganizationRoleOwner {
+			return "", errors.New("access denied: only owners can change or remove an owner")
+		}
+	}
+
+	return currentRole, nil
+}
+
+// checkRemainingOwners refuses to take ownership from the organization's last owner
+func (s *OrganizationRoleService) checkRemainingOwners(ctx context.Context, organizationID int64) error {
+	owners, err := s.store.CountOrganizationOwners(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to count organization owners: %w", err)
+	}
+	if owners <= 1 {
+		return errors.New("organization must keep at least one owner")
+	}
+	return nil
+}
@@ -11,11 +11,11 @@ import (
 
 // OrganizationService handles organization-related business logic
 type OrganizationService struct {
-	store db.Store
+	store db.OrganizationStore
 }
 
 // NewOrganizationService creates a new organization service
-func NewOrganizationService(store db.Store) *OrganizationService {
+func NewOrganizationService(store db.OrganizationStore) *OrganizationService {
 	return &OrganizationService{
 		store: store,
 	}
@@ -76,13 +76,3 @@ func (s *OrganizationService) ListOrganizations(ctx context.Context, limit, offs
 
 	return organizations, nil
 }
-
-// DeleteOrganization deletes an organization
-func (s *OrganizationService) DeleteOrganization(ctx context.Context, organizationID int64) error {
-	err := s.store.DeleteOrganization(ctx, organizationID)
-	if err != nil {
-		return fmt.Errorf("failed to delete organization: %w", err)
-	}
-
-	return nil
-}
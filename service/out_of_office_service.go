@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// OutOfOfficeService handles out-of-office windows and their auto-reply messages
+type OutOfOfficeService struct {
+	store db.UserStore
+}
+
+// NewOutOfOfficeService creates a new out-of-office service
+func NewOutOfOfficeService(store db.UserStore) *OutOfOfficeService {
+	return &OutOfOfficeService{
+		store: store,
+	}
+}
+
+// SetOutOfOffice sets a user's out-of-office window and auto-reply message.
+// The window starts right away when no start time is given.
+func (s *OutOfOfficeService) SetOutOfOffice(ctx context.Context, userID, workspaceID int64, req SetOutOfOfficeRequest) (*OutOfOfficeResponse, error) {
+	startsAt := time.Now()
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+
+	if !req.EndsAt.After(startsAt) {
+		return nil, errors.New("out of office must end after it starts")
+	}
+	if !req.EndsAt.After(time.Now()) {
+		return nil, errors.New("out of office must end in the future")
+	}
+
+	outOfOffice, err := s.store.UpsertOutOfOffice(ctx, db.UpsertOutOfOfficeParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		StartsAt:    startsAt,
+		EndsAt:      req.EndsAt,
+		Message:     req.Message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set out of office: %w", err)
+	}
+
+	return toOutOfOfficeResponse(outOfOffice), nil
+}
+
+// GetOutOfOffice returns a user's out-of-office window, whether or not it is active
+func (s *OutOfOfficeService) GetOutOfOffice(ctx context.Context, userID, workspaceID int64) (*OutOfOfficeResponse, error) {
+	outOfOffice, err := s.store.GetOutOfOffice(ctx, db.GetOutOfOfficeParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("out of office not found")
+		}
+		return nil, fmt.Errorf("failed to get out of office: %w", err)
+	}
+
+	return toOutOfOfficeResponse(outOfOffice), nil
+}
+
+// GetActiveOutOfOffice returns a user's out-of-office window if they are away
+// right now, or nil otherwise
+func (s *OutOfOfficeService) GetActiveOutOfOffice(ctx context.Context, userID, workspaceID int64) (*OutOfOfficeResponse, error) {
+	outOfOffice, err := s.store.GetActiveOutOfOffice(ctx, db.GetActiveOutOfOfficeParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get out of office: %w", err)
+	}
+
+	return toOutOfOfficeResponse(outOfOffice), nil
+}
+
+// ClearOutOfOffice removes a user's out-of-office window
+func (s *OutOfOfficeService) ClearOutOfOffice(ctx context.Context, userID, workspaceID int64) error {
+	rows, err := s.store.DeleteOutOfOffice(ctx, db.DeleteOutOfOfficeParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear out of office: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("out of office not found")
+	}
+
+	return nil
+}
+
+func toOutOfOfficeResponse(outOfOffice db.OutOfOffice) *OutOfOfficeResponse {
+	now := time.Now()
+	return &OutOfOfficeResponse{
+		UserID:      outOfOffice.UserID,
+		WorkspaceID: outOfOffice.WorkspaceID,
+		StartsAt:    outOfOffice.StartsAt,
+		EndsAt:      outOfOffice.EndsAt,
+		Message:     outOfOffice.Message,
+		Active:      !outOfOffice.StartsAt.After(now) && outOfOffice.EndsAt.After(now),
+	}
+}
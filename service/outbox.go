@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// outboxEventsPerRun caps how many events one relay pass publishes
+const outboxEventsPerRun = 100
+
+// newOutboxEvent prepares a WebSocket event for the outbox. Events with
+// recipients go to those users, others to the event's channel, or the whole
+// workspace without a channel.
+func newOutboxEvent(message *WSMessage, recipientIDs ...int64) (db.CreateOutboxEventParams, error) {
+	payload, err := json.Marshal(message.Data)
+	if err != nil {
+		return db.CreateOutboxEventParams{}, fmt.Errorf("failed to encode %s event: %w", message.Type, err)
+	}
+
+	event := db.CreateOutboxEventParams{
+		EventType:    message.Type,
+		WorkspaceID:  message.WorkspaceID,
+		UserID:       message.UserID,
+		RecipientIds: recipientIDs,
+		Payload:      payload,
+	}
+	if event.RecipientIds == nil {
+		event.RecipientIds = []int64{}
+	}
+	if message.ChannelID != nil {
+		event.ChannelID = sql.NullInt64{Int64: *message.ChannelID, Valid: true}
+	}
+	return event, nil
+}
+
+// publishOutboxEvent broadcasts an outbox event and marks it published. An
+// event that can't be marked stays in the outbox and is sent again by the
+// relay, so clients may see it twice but never miss it.
+func publishOutboxEvent(ctx context.Context, store db.OutboxStore, hub WebSocketHub, event db.EventOutbox, message *WSMessage) error {
+	message.EventID = event.ID
+
+	switch {
+	case len(event.RecipientIds) > 0:
+		for _, userID := range event.RecipientIds {
+			hub.BroadcastToUser(userID, message)
+		}
+	case event.ChannelID.Valid:
+		hub.BroadcastToChannel(event.WorkspaceID, event.ChannelID.Int64, message)
+	default:
+		hub.BroadcastToWorkspace(event.WorkspaceID, message)
+	}
+
+	if err := store.MarkOutboxEventPublished(ctx, event.ID); err != nil {
+		return fmt.Errorf("failed to mark event %d published: %w", event.ID, err)
+	}
+	return nil
+}
+
+// OutboxRelay publishes outbox events that weren't broadcast after their
+// transaction committed, because the process stopped or marking them failed.
+// Requests publish their own events straight away; the relay only picks up
+// events that are still unpublished after a delay.
+type OutboxRelay struct {
+	store     db.OutboxStore
+	hub       WebSocketHub
+	delay     time.Duration
+	retention time.Duration
+}
+
+// NewOutboxRelay creates a new outbox relay
+func NewOutboxRelay(store db.OutboxStore, hub WebSocketHub, config util.Config) *OutboxRelay {
+	delay := config.OutboxRelayDelay
+	if delay <= 0 {
+		delay = 10 * time.Second
+	}
+
+	retention := config.OutboxRetention
+	if retention <= 0 {
+		retention = 24 * time.Hour
+	}
+
+	return &OutboxRelay{
+		store:     store,
+		hub:       hub,
+		delay:     delay,
+		retention: retention,
+	}
+}
+
+// RelayEvents publishes the unpublished events older than the relay delay in
+// the order they were written, then removes events published longer ago than
+// the retention period. It returns how many events were published.
+func (r *OutboxRelay) RelayEvents(ctx context.Context) (int, error) {
+	published := 0
+
+	for {
+		events, err := r.store.ListUnpublishedOutboxEvents(ctx, db.ListUnpublishedOutboxEventsParams{
+			CreatedAt: time.Now().Add(-r.delay),
+			Limit:     outboxEventsPerRun,
+		})
+		if err != nil {
+			return published, fmt.Errorf("failed to list outbox events: %w", err)
+		}
+
+		for _, event := range events {
+			message := &WSMessage{
+				Type:        event.EventType,
+				Data:        event.Payload,
+				WorkspaceID: event.WorkspaceID,
+				UserID:      event.UserID,
+				Timestamp:   event.CreatedAt,
+			}
+			if event.ChannelID.Valid {
+				message.ChannelID = &event.ChannelID.Int64
+			}
+
+			if err := publishOutboxEvent(ctx, r.store, r.hub, event, message); err != nil {
+				return published, err
+			}
+			published++
+		}
+
+		if len(events) < outboxEventsPerRun {
+			break
+		}
+	}
+
+	_, err := r.store.DeletePublishedOutboxEvents(ctx, sql.NullTime{Time: time.Now().Add(-r.retention), Valid: true})
+	if err != nil {
+		return published, fmt.Errorf("failed to delete published outbox events: %w", err)
+	}
+
+	return published, nil
+}
+
+// StartRelay relays outbox events on an interval until the context is cancelled
+func (r *OutboxRelay) StartRelay(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := r.RelayEvents(ctx)
+			if err != nil {
+				fmt.Printf("Error relaying outbox events: %v\n", err)
+			}
+			if published > 0 {
+				fmt.Printf("Relayed %d outbox events\n", published)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRelay_RelayEvents(t *testing.T) {
+	const workspaceID, channelID, senderID, receiverID = int64(2), int64(4), int64(5), int64(8)
+	ctx := context.Background()
+	createdAt := time.Now().Add(-time.Minute)
+	channel := channelID
+
+	channelEvent, err := newOutboxEvent(&WSMessage{
+		Type:        "message_sent",
+		Data:        MessageResponse{ID: 20, Content: "Hello"},
+		WorkspaceID: workspaceID,
+		ChannelID:   &channel,
+		UserID:      senderID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullInt64{Int64: channelID, Valid: true}, channelEvent.ChannelID)
+	require.Empty(t, channelEvent.RecipientIds)
+
+	directEvent, err := newOutboxEvent(&WSMessage{
+		Type:        "message_sent",
+		Data:        MessageResponse{ID: 21, Content: "Hi"},
+		WorkspaceID: workspaceID,
+		UserID:      senderID,
+	}, senderID, receiverID)
+	require.NoError(t, err)
+
+	events := []db.EventOutbox{
+		{ID: 1, EventType: channelEvent.EventType, WorkspaceID: workspaceID, ChannelID: channelEvent.ChannelID, UserID: senderID, RecipientIds: []int64{}, Payload: channelEvent.Payload, CreatedAt: createdAt},
+		{ID: 2, EventType: directEvent.EventType, WorkspaceID: workspaceID, UserID: senderID, RecipientIds: directEvent.RecipientIds, Payload: directEvent.Payload, CreatedAt: createdAt},
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockOutboxStore(ctrl)
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	relay := NewOutboxRelay(store, hub, util.Config{OutboxRelayDelay: 30 * time.Second})
+
+	store.EXPECT().ListUnpublishedOutboxEvents(gomock.Any(), gomock.Any()).Times(1).Return(events, nil)
+	store.EXPECT().MarkOutboxEventPublished(gomock.Any(), int64(1)).Times(1).Return(nil)
+	store.EXPECT().MarkOutboxEventPublished(gomock.Any(), int64(2)).Times(1).Return(errors.New("connection reset"))
+
+	// An event that can't be marked stops the pass, so it's sent again
+	// before anything written after it
+	published, err := relay.RelayEvents(ctx)
+	require.Error(t, err)
+	require.Equal(t, 1, published)
+
+	require.Len(t, hub.channelMessages[channelID], 1)
+	message := hub.channelMessages[channelID][0]
+	require.Equal(t, "message_sent", message.Type)
+	require.Equal(t, int64(1), message.EventID)
+	require.Equal(t, createdAt, message.Timestamp)
+
+	var data MessageResponse
+	payload, err := json.Marshal(message.Data)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(payload, &data))
+	require.Equal(t, "Hello", data.Content)
+
+	store.EXPECT().
+		ListUnpublishedOutboxEvents(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.ListUnpublishedOutboxEventsParams) ([]db.EventOutbox, error) {
+			// Only events older than the relay delay are picked up
+			require.WithinDuration(t, time.Now().Add(-30*time.Second), arg.CreatedAt, time.Second)
+			return events[1:], nil
+		})
+	store.EXPECT().MarkOutboxEventPublished(gomock.Any(), int64(2)).Times(1).Return(nil)
+	store.EXPECT().DeletePublishedOutboxEvents(gomock.Any(), gomock.Any()).Times(1).Return(int64(3), nil)
+
+	published, err = relay.RelayEvents(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, published)
+
+	// Direct message events go to both participants
+	for _, userID := range []int64{senderID, receiverID} {
+		require.Len(t, hub.userMessages[userID], 2)
+		require.Equal(t, int64(2), hub.userMessages[userID][1].EventID)
+	}
+}
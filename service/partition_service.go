@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// messagePartitionLayout is how monthly message partitions are named
+const messagePartitionLayout = "messages_p200601"
+
+// partitionMetrics counts maintenance runs and the partitions they created
+// and dropped, published at /debug/vars
+var partitionMetrics = expvar.NewMap("message_partitions")
+
+// PartitionMaintenanceResult lists the message partitions one maintenance
+// run created, dropped and kept past the retention because of legal holds
+type PartitionMaintenanceResult struct {
+	Created []string `json:"created"`
+	Dropped []string `json:"dropped"`
+	Held    []string `json:"held"`
+}
+
+// PartitionService maintains the monthly message partitions: it creates them
+// ahead of time and drops the months older than the message retention
+type PartitionService struct {
+	store            db.MessageStore
+	monthsAhead      int
+	messageRetention time.Duration
+	now              func() time.Time
+}
+
+// NewPartitionService creates a new partition service
+func NewPartitionService(store db.MessageStore, config util.Config) *PartitionService {
+	monthsAhead := config.MessagePartitionsAhead
+	if monthsAhead <= 0 {
+		monthsAhead = 3
+	}
+
+	return &PartitionService{
+		store:            store,
+		monthsAhead:      monthsAhead,
+		messageRetention: config.MessageRetention,
+		now:              time.Now,
+	}
+}
+
+// RunMaintenance creates the partitions for the coming months, then drops the
+// partitions of months that ended before the retention cutoff. It returns the
+// partitions it changed, including those changed before an error.
+func (s *PartitionService) RunMaintenance(ctx context.Context) (PartitionMaintenanceResult, error) {
+	var result PartitionMaintenanceResult
+	partitionMetrics.Add("runs", 1)
+
+	now := s.now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	// This month's partition was made ahead of time, or by the migration
+	for i := 1; i <= s.monthsAhead; i++ {
+		month := thisMonth.AddDate(0, i, 0)
+		created, err := s.store.CreateMessagePartition(ctx, month)
+		if err != nil {
+			partitionMetrics.Add("errors", 1)
+			return result, fmt.Errorf("failed to create message partition for %s: %w", month.Format("2006-01"), err)
+		}
+		if created {
+			result.Created = append(result.Created, month.Format(messagePartitionLayout))
+			partitionMetrics.Add("created", 1)
+		}
+	}
+
+	if s.messageRetention <= 0 {
+		return result, nil
+	}
+
+	partitions, err := s.store.ListMessagePartitions(ctx)
+	if err != nil {
+		partitionMetrics.Add("errors", 1)
+		return result, fmt.Errorf("failed to list message partitions: %w", err)
+	}
+
+	cutoff := now.Add(-s.messageRetention)
+	for _, name := range partitions {
+		month, err := time.Parse(messagePartitionLayout, name)
+		if err != nil {
+			continue
+		}
+		if month.AddDate(0, 1, 0).After(cutoff) {
+			break
+		}
+
+		dropped, err := s.store.DropMessagePartitionTx(ctx, name)
+		if err != nil {
+			partitionMetrics.Add("errors", 1)
+			return result, fmt.Errorf("failed to drop message partition %s: %w", name, err)
+		}
+		if dropped {
+			result.Dropped = append(result.Dropped, name)
+			partitionMetrics.Add("dropped", 1)
+		} else {
+			result.Held = append(result.Held, name)
+		}
+	}
+
+	return result, nil
+}
+
+// StartMaintenanceJob maintains the partitions now and then on an interval
+// until the context is cancelled
+func (s *PartitionService) StartMaintenanceJob(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.RunMaintenance(ctx)
+		if err != nil {
+			fmt.Printf("Error maintaining message partitions: %v\n", err)
+		}
+		if len(result.Created) > 0 || len(result.Dropped) > 0 {
+			fmt.Printf("Created message partitions %v and dropped %v\n", result.Created, result.Dropped)
+		}
+		if len(result.Held) > 0 {
+			fmt.Printf("Kept message partitions %v past retention for legal holds\n", result.Held)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionService_RunMaintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockMessageStore(ctrl)
+	partitionService := NewPartitionService(store, util.Config{
+		MessagePartitionsAhead: 2,
+		MessageRetention:       90 * 24 * time.Hour,
+	})
+	partitionService.now = func() time.Time { return time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC) }
+
+	// Partitions are created for the coming months; existing ones are left alone
+	store.EXPECT().CreateMessagePartition(gomock.Any(), time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)).Times(1).Return(false, nil)
+	store.EXPECT().CreateMessagePartition(gomock.Any(), time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)).Times(1).Return(true, nil)
+
+	// Months that ended before the cutoff of July 19 are dropped unless held
+	store.EXPECT().
+		ListMessagePartitions(gomock.Any()).
+		Times(1).
+		Return([]string{"messages_p202605", "messages_p202606", "messages_p202607", "messages_p202608"}, nil)
+	store.EXPECT().DropMessagePartitionTx(gomock.Any(), "messages_p202605").Times(1).Return(true, nil)
+	store.EXPECT().DropMessagePartitionTx(gomock.Any(), "messages_p202606").Times(1).Return(false, nil)
+
+	result, err := partitionService.RunMaintenance(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, PartitionMaintenanceResult{
+		Created: []string{"messages_p202612"},
+		Dropped: []string{"messages_p202605"},
+		Held:    []string{"messages_p202606"},
+	}, result)
+}
+
+func TestPartitionService_RunMaintenanceWithoutRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockMessageStore(ctrl)
+	partitionService := NewPartitionService(store, util.Config{})
+
+	// Messages are kept forever, so nothing is listed or dropped
+	store.EXPECT().CreateMessagePartition(gomock.Any(), gomock.Any()).Times(3).Return(true, nil)
+
+	result, err := partitionService.RunMaintenance(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result.Created, 3)
+	require.Empty(t, result.Dropped)
+
+	// A failed creation stops the run
+	store.EXPECT().CreateMessagePartition(gomock.Any(), gomock.Any()).Times(1).Return(false, errors.New("default partition contains rows"))
+
+	_, err = partitionService.RunMaintenance(context.Background())
+	require.ErrorContains(t, err, "failed to create message partition")
+}
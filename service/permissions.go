@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Workspace permissions. Admins hold all of them, members only those granted
+// by their custom role.
+const (
+	PermissionManageWorkspace  = "manage_workspace"
+	PermissionInviteMembers    = "invite_members"
+	PermissionManageMembers    = "manage_members"
+	PermissionManageRoles      = "manage_roles"
+	PermissionManageChannels   = "manage_channels"
+	PermissionDeleteAnyMessage = "delete_any_message"
+	PermissionManageFiles      = "manage_files"
+	PermissionModerateContent  = "moderate_content"
+	PermissionReadEvents       = "read_events"
+)
+
+// PermissionResponse describes a permission in the catalog
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// permissionCatalog lists every permission a custom role can grant
+var permissionCatalog = []PermissionResponse{
+	{PermissionManageWorkspace, "Change workspace settings, presence settings and feature flags"},
+	{PermissionInviteMembers, "Invite people, manage join links and auto-join domains, and review join requests"},
+	{PermissionManageMembers, "Remove members and change their role between member and admin"},
+	{PermissionManageRoles, "Create, edit and assign custom roles"},
+	{PermissionManageChannels, "Edit and delete any channel"},
+	{PermissionDeleteAnyMessage, "Delete messages sent by anyone"},
+	{PermissionManageFiles, "Delete files uploaded by anyone"},
+	{PermissionModerateContent, "Manage blocked words, review the moderation queue and resolve abuse reports"},
+	{PermissionReadEvents, "Read the workspace event stream for data pipelines"},
+}
+
+// Permissions returns the permission catalog
+func Permissions() []PermissionResponse {
+	return slices.Clone(permissionCatalog)
+}
+
+// IsPermission reports whether name is in the permission catalog
+func IsPermission(name string) bool {
+	for _, permission := range permissionCatalog {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// workspacePermissions returns the permissions a user holds in a workspace,
+// or nil when they aren't a member of it
+func workspacePermissions(ctx context.Context, store db.WorkspaceStore, userID, workspaceID int64) ([]string, error) {
+	role, err := store.CheckUserWorkspaceRole(ctx, db.CheckUserWorkspaceRoleParams{
+		ID:          userID,
+		WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to check user role: %w", err)
+	}
+
+	if role == "admin" {
+		all := make([]string, len(permissionCatalog))
+		for i, permission := range permissionCatalog {
+			all[i] = permission.Name
+		}
+		return all, nil
+	}
+
+	permissions, err := store.GetUserRolePermissions(ctx, db.GetUserRolePermissionsParams{
+		ID:          userID,
+		WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Members without a custom role have no extra permissions
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to get role permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// hasWorkspacePermission checks whether a user holds a permission in a workspace
+func hasWorkspacePermission(ctx context.Context, store db.WorkspaceStore, userID, workspaceID int64, permission string) (bool, error) {
+	permissions, err := workspacePermissions(ctx, store, userID, workspaceID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(permissions, permission), nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Pin WebSocket message types, sent to the pinned message's channel
+const (
+	WSMessagePinned   = "message_pinned"
+	WSMessageUnpinned = "message_unpinned"
+	WSPinsReordered   = "pins_reordered"
+)
+
+// pinServiceStore covers pins and the channels and messages they belong to
+type pinServiceStore interface {
+	db.ChannelStore
+	db.MessageStore
+}
+
+// PinService handles messages pinned to the top of their channel. Only
+// channel members can pin, unpin and arrange pins, and each channel can pin
+// up to the workspace's limit.
+type PinService struct {
+	store          pinServiceStore
+	messageService *MessageService
+	hub            WebSocketHub
+}
+
+// NewPinService creates a new pin service
+func NewPinService(store pinServiceStore, messageService *MessageService, hub WebSocketHub) *PinService {
+	return &PinService{
+		store:          store,
+		messageService: messageService,
+		hub:            hub,
+	}
+}
+
+// PinMessage pins a channel message to the end of its channel's pins
+func (s *PinService) PinMessage(ctx context.Context, messageID, userID int64) (*PinnedMessageResponse, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if !message.ChannelID.Valid {
+		return nil, errors.New("invalid message: only channel messages can be pinned")
+	}
+
+	channel, err := s.getChannel(ctx, message.ChannelID.Int64, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.messageService.getSettings(ctx, channel.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	limit := s.messageService.maxPins(settings)
+
+	count, err := s.store.CountChannelPins(ctx, channel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pinned messages: %w", err)
+	}
+	if count >= int64(limit) {
+		return nil, fmt.Errorf("pin limit reached: a channel can pin at most %d messages", limit)
+	}
+
+	pin, err := s.store.PinMessage(ctx, db.PinMessageParams{
+		MessageID: message.ID,
+		ChannelID: channel.ID,
+		PinnedBy:  userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("message already pinned")
+		}
+		return nil, fmt.Errorf("failed to pin message: %w", err)
+	}
+
+	s.broadcast(channel, WSMessagePinned, PinEvent{ChannelID: channel.ID, MessageID: message.ID, UserID: userID})
+
+	return &PinnedMessageResponse{
+		Message:  s.messageService.toMessageByIDResponse(message),
+		PinnedBy: pin.PinnedBy,
+		PinnedAt: pin.PinnedAt,
+		Position: pin.Position,
+	}, nil
+}
+
+// UnpinMessage removes a message from its channel's pins
+func (s *PinService) UnpinMessage(ctx context.Context, messageID, userID int64) error {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("message not found")
+		}
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+	if !message.ChannelID.Valid {
+		return errors.New("pin not found")
+	}
+
+	channel, err := s.getChannel(ctx, message.ChannelID.Int64, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.store.UnpinMessage(ctx, message.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("pin not found")
+		}
+		return fmt.Errorf("failed to unpin message: %w", err)
+	}
+
+	s.broadcast(channel, WSMessageUnpinned, PinEvent{ChannelID: channel.ID, MessageID: message.ID, UserID: userID})
+
+	return nil
+}
+
+// GetPinnedMessages lists a channel's pinned messages in their arranged order
+func (s *PinService) GetPinnedMessages(ctx context.Context, channelID, userID int64) ([]*PinnedMessageResponse, error) {
+	channel, err := s.store.GetChannelByID(ctx, channelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("channel not found")
+		}
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	// Anyone who can read the channel can see its pins
+	isMember, err := s.messageService.userService.IsWorkspaceMember(ctx, userID, channel.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check workspace membership: %w", err)
+	}
+	if !isMember {
+		return nil, errors.New("access denied: user is not a member of the workspace")
+	}
+	if channel.IsPrivate {
+		if err := s.checkChannelMember(ctx, channel.ID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.listPins(ctx, channel.ID, userID)
+}
+
+// ReorderPins arranges a channel's pinned messages in the given order
+func (s *PinService) ReorderPins(ctx context.Context, channelID, userID int64, messageIDs []int64) ([]*PinnedMessageResponse, error) {
+	channel, err := s.getChannel(ctx, channelID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned, err := s.store.ListChannelPinIDs(ctx, channel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned messages: %w", err)
+	}
+
+	if !samePins(pinned, messageIDs) {
+		return nil, errors.New("invalid order: message_ids must list every pinned message of the channel once")
+	}
+
+	if _, err := s.store.ReorderPinnedMessages(ctx, db.ReorderPinnedMessagesParams{
+		MessageIds: messageIDs,
+		ChannelID:  channel.ID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to reorder pinned messages: %w", err)
+	}
+
+	s.broadcast(channel, WSPinsReordered, PinEvent{ChannelID: channel.ID, UserID: userID, MessageIDs: messageIDs})
+
+	return s.listPins(ctx, channel.ID, userID)
+}
+
+func (s *PinService) listPins(ctx context.Context, channelID, userID int64) ([]*PinnedMessageResponse, error) {
+	rows, err := s.store.ListPinnedMessages(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned messages: %w", err)
+	}
+
+	pins := make([]*PinnedMessageResponse, 0, len(rows))
+	messages := make([]*MessageResponse, 0, len(rows))
+	for _, row := range rows {
+		message := s.messageService.toMessageByIDResponse(db.GetMessageByIDRow{
+			ID:              row.ID,
+			WorkspaceID:     row.WorkspaceID,
+			ChannelID:       row.ChannelID,
+			SenderID:        row.SenderID,
+			ReceiverID:      row.ReceiverID,
+			Content:         row.Content,
+			MessageType:     row.MessageType,
+			ThreadID:        row.ThreadID,
+			EditedAt:        row.EditedAt,
+			DeletedAt:       row.DeletedAt,
+			CreatedAt:       row.CreatedAt,
+			ContentType:     row.ContentType,
+			DeletedBy:       row.DeletedBy,
+			DeletionNotice:  row.DeletionNotice,
+			SenderFirstName: row.SenderFirstName,
+			SenderLastName:  row.SenderLastName,
+			SenderEmail:     row.SenderEmail,
+		})
+		messages = append(messages, message)
+		pins = append(pins, &PinnedMessageResponse{
+			Message:  message,
+			PinnedBy: row.PinnedBy,
+			PinnedAt: row.PinnedAt,
+			Position: row.Position,
+		})
+	}
+
+	if err := s.messageService.attachReactions(ctx, messages, userID); err != nil {
+		return nil, err
+	}
+
+	return pins, nil
+}
+
+// getChannel returns a channel whose pins the user may change, which takes
+// being a member of it
+func (s *PinService) getChannel(ctx context.Context, channelID, userID int64) (db.Channel, error) {
+	channel, err := s.store.GetChannelByID(ctx, channelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.Channel{}, errors.New("channel not found")
+		}
+		return db.Channel{}, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if err := s.checkChannelMember(ctx, channel.ID, userID); err != nil {
+		return db.Channel{}, err
+	}
+
+	return channel, nil
+}
+
+func (s *PinService) checkChannelMember(ctx context.Context, channelID, userID int64) error {
+	isMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+		ChannelID: channelID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check channel membership: %w", err)
+	}
+	if !isMember {
+		return errors.New("access denied: user is not a member of the channel")
+	}
+	return nil
+}
+
+func (s *PinService) broadcast(channel db.Channel, messageType string, event PinEvent) {
+	if s.hub == nil {
+		return
+	}
+
+	channelID := channel.ID
+	s.hub.BroadcastToChannel(channel.WorkspaceID, channel.ID, &WSMessage{
+		Type:        messageType,
+		Data:        event,
+		WorkspaceID: channel.WorkspaceID,
+		ChannelID:   &channelID,
+		UserID:      event.UserID,
+		Timestamp:   time.Now(),
+	})
+}
+
+// samePins reports whether order lists exactly the pinned message IDs, each once
+func samePins(pinned, order []int64) bool {
+	if len(pinned) != len(order) {
+		return false
+	}
+
+	remaining := make(map[int64]bool, len(pinned))
+	for _, id := range pinned {
+		remaining[id] = true
+	}
+	for _, id := range order {
+		if !remaining[id] {
+			return false
+		}
+		delete(remaining, id)
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinService_PinMessage(t *testing.T) {
+	const workspaceID, channelID, memberID, outsiderID = int64(2), int64(7), int64(5), int64(11)
+	ctx := context.Background()
+	message := db.GetMessageByIDRow{
+		ID:          20,
+		WorkspaceID: workspaceID,
+		ChannelID:   sql.NullInt64{Int64: channelID, Valid: true},
+		SenderID:    memberID,
+		MessageType: "channel",
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetMessageByID(gomock.Any(), message.ID).AnyTimes().Return(message, nil)
+	store.EXPECT().GetChannelByID(gomock.Any(), channelID).AnyTimes().Return(db.Channel{ID: channelID, WorkspaceID: workspaceID}, nil)
+	store.EXPECT().IsChannelMember(gomock.Any(), db.IsChannelMemberParams{ChannelID: channelID, UserID: memberID}).AnyTimes().Return(true, nil)
+	store.EXPECT().IsChannelMember(gomock.Any(), db.IsChannelMemberParams{ChannelID: channelID, UserID: outsiderID}).AnyTimes().Return(false, nil)
+	store.EXPECT().
+		GetWorkspaceSettings(gomock.Any(), workspaceID).
+		AnyTimes().
+		Return(db.WorkspaceSetting{WorkspaceID: workspaceID, MaxPinsPerChannel: sql.NullInt32{Int32: 2, Valid: true}}, nil)
+
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	messageService := NewMessageService(store, NewUserService(store, nil, util.Config{}), hub, util.Config{})
+	pinService := NewPinService(store, messageService, hub)
+
+	// Only channel members can pin, even in public channels
+	_, err := pinService.PinMessage(ctx, message.ID, outsiderID)
+	require.EqualError(t, err, "access denied: user is not a member of the channel")
+
+	store.EXPECT().CountChannelPins(gomock.Any(), channelID).Times(1).Return(int64(1), nil)
+	store.EXPECT().
+		PinMessage(gomock.Any(), db.PinMessageParams{MessageID: message.ID, ChannelID: channelID, PinnedBy: memberID}).
+		Times(1).
+		Return(db.PinnedMessage{MessageID: message.ID, ChannelID: channelID, PinnedBy: memberID, Position: 2, PinnedAt: time.Now()}, nil)
+
+	pin, err := pinService.PinMessage(ctx, message.ID, memberID)
+	require.NoError(t, err)
+	require.Equal(t, memberID, pin.PinnedBy)
+	require.Equal(t, int32(2), pin.Position)
+	require.Len(t, hub.channelMessages[channelID], 1)
+	require.Equal(t, WSMessagePinned, hub.channelMessages[channelID][0].Type)
+
+	// The workspace's limit caps the channel's pins
+	store.EXPECT().CountChannelPins(gomock.Any(), channelID).Times(1).Return(int64(2), nil)
+
+	_, err = pinService.PinMessage(ctx, message.ID, memberID)
+	require.EqualError(t, err, "pin limit reached: a channel can pin at most 2 messages")
+}
+
+func TestPinService_ReorderPins(t *testing.T) {
+	const workspaceID, channelID, memberID = int64(2), int64(7), int64(5)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetChannelByID(gomock.Any(), channelID).AnyTimes().Return(db.Channel{ID: channelID, WorkspaceID: workspaceID}, nil)
+	store.EXPECT().IsChannelMember(gomock.Any(), gomock.Any()).AnyTimes().Return(true, nil)
+	store.EXPECT().ListChannelPinIDs(gomock.Any(), channelID).AnyTimes().Return([]int64{20, 21, 22}, nil)
+
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	messageService := NewMessageService(store, NewUserService(store, nil, util.Config{}), hub, util.Config{})
+	pinService := NewPinService(store, messageService, hub)
+
+	// The order must list every pin once
+	for _, order := range [][]int64{{22, 20}, {22, 20, 20}, {22, 20, 23}} {
+		_, err := pinService.ReorderPins(ctx, channelID, memberID, order)
+		require.EqualError(t, err, "invalid order: message_ids must list every pinned message of the channel once")
+	}
+
+	order := []int64{22, 20, 21}
+	store.EXPECT().
+		ReorderPinnedMessages(gomock.Any(), db.ReorderPinnedMessagesParams{MessageIds: order, ChannelID: channelID}).
+		Times(1).
+		Return(int64(3), nil)
+	store.EXPECT().ListPinnedMessages(gomock.Any(), channelID).Times(1).Return([]db.ListPinnedMessagesRow{}, nil)
+
+	pins, err := pinService.ReorderPins(ctx, channelID, memberID, order)
+	require.NoError(t, err)
+	require.Empty(t, pins)
+	require.Equal(t, WSPinsReordered, hub.channelMessages[channelID][0].Type)
+	require.Equal(t, order, hub.channelMessages[channelID][0].Data.(PinEvent).MessageIDs)
+}
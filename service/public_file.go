@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/publicid"
+)
+
+// PublicFile is a public file opened for someone who isn't signed in
+type PublicFile struct {
+	Content *os.File
+	File    *db.File
+	// Whether the workspace only lets some sites embed its files, in which
+	// case the response depends on the Referer
+	RefererRestricted bool
+}
+
+// OpenPublicFile opens a file uploaded as public for someone who isn't signed
+// in. Only completed uploads are served, and only while their workspace exists
+// and allows public files. Images that members download watermarked aren't
+// served, since there is no one to stamp them with. refererHost is the site
+// embedding the file, empty when it's opened directly.
+func (s *FileService) OpenPublicFile(ctx context.Context, fileID int64, refererHost string) (*PublicFile, error) {
+	file, err := s.store.GetFile(ctx, fileID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("file not found")
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	if !file.IsPublic || !file.UploadCompleted {
+		return nil, errors.New("file not found")
+	}
+
+	if _, err := s.store.GetWorkspace(ctx, file.WorkspaceID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("file not found")
+		}
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	settings, err := s.fileSettings(ctx, file.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.PublicFiles {
+		return nil, errors.New("file not found")
+	}
+
+	watermarked, err := s.isWatermarked(ctx, &file, settings)
+	if err != nil {
+		return nil, err
+	}
+	if watermarked {
+		return nil, errors.New("file not found")
+	}
+
+	if !refererAllowed(refererHost, settings.PublicFileReferers) {
+		return nil, errors.New("access denied: files from this workspace can't be embedded on this site")
+	}
+
+	content, err := os.Open(file.FilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New("file not found")
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	return &PublicFile{
+		Content:           content,
+		File:              &file,
+		RefererRestricted: len(settings.PublicFileReferers) > 0,
+	}, nil
+}
+
+// refererAllowed reports whether a site can embed a workspace's public files.
+// Listing a host allows its subdomains too. Files opened directly, without a
+// referer, are always allowed.
+func refererAllowed(refererHost string, allowed []string) bool {
+	if refererHost == "" || len(allowed) == 0 {
+		return true
+	}
+
+	refererHost = strings.TrimSuffix(strings.ToLower(refererHost), ".")
+	for _, host := range allowed {
+		if refererHost == host || strings.HasSuffix(refererHost, "."+host) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeRefererHosts lowercases the listed hosts and drops duplicates
+func normalizeRefererHosts(hosts []string) []string {
+	normalized := make([]string, 0, len(hosts))
+	seen := make(map[string]bool, len(hosts))
+	for _, host := range hosts {
+		host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		normalized = append(normalized, host)
+	}
+	return normalized
+}
+
+// publicFileURL returns where a public file can be fetched without signing in.
+// It uses the file's public ID so the addresses of other files can't be guessed.
+func publicFileURL(fileID int64) string {
+	return "/public/files/" + publicid.Encode(publicid.File, fileID)
+}
+
+// setPublicURL adds the address of a public file to its response
+func setPublicURL(response *FileResponse) {
+	if response.IsPublic {
+		response.PublicURL = publicFileURL(response.ID)
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileService_OpenPublicFile(t *testing.T) {
+	const workspaceID = int64(5)
+	ctx := context.Background()
+
+	filePath := filepath.Join(t.TempDir(), "diagram.png")
+	writeTestPNG(t, filePath)
+	file := db.File{
+		ID:              3,
+		WorkspaceID:     workspaceID,
+		FilePath:        filePath,
+		MimeType:        "image/png",
+		IsPublic:        true,
+		UploadCompleted: true,
+	}
+
+	testCases := []struct {
+		name        string
+		file        db.File
+		settings    *db.WorkspaceSetting
+		refererHost string
+		wantErr     string
+		restricted  bool
+	}{
+		{
+			name: "NoSettings",
+		},
+		{
+			name:        "AllowedSubdomain",
+			settings:    &db.WorkspaceSetting{ImageWatermark: ImageWatermarkOff, PublicFiles: true, PublicFileReferers: []string{"example.com"}},
+			refererHost: "Blog.Example.com",
+			restricted:  true,
+		},
+		{
+			name:     "NotPublic",
+			file:     db.File{ID: file.ID, WorkspaceID: workspaceID, UploadCompleted: true},
+			settings: &db.WorkspaceSetting{},
+			wantErr:  "file not found",
+		},
+		{
+			name:     "TurnedOff",
+			settings: &db.WorkspaceSetting{ImageWatermark: ImageWatermarkOff, PublicFiles: false},
+			wantErr:  "file not found",
+		},
+		{
+			name:     "Watermarked",
+			settings: &db.WorkspaceSetting{ImageWatermark: ImageWatermarkAll, PublicFiles: true},
+			wantErr:  "file not found",
+		},
+		{
+			name:        "Hotlinked",
+			settings:    &db.WorkspaceSetting{ImageWatermark: ImageWatermarkOff, PublicFiles: true, PublicFileReferers: []string{"example.com"}},
+			refererHost: "notexample.com",
+			wantErr:     "access denied: files from this workspace can't be embedded on this site",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.file.ID == 0 {
+				tc.file = file
+			}
+
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetFile(gomock.Any(), file.ID).Times(1).Return(tc.file, nil)
+			if tc.file.IsPublic {
+				store.EXPECT().GetWorkspace(gomock.Any(), workspaceID).Times(1).Return(db.Workspace{ID: workspaceID}, nil)
+				if tc.settings == nil {
+					store.EXPECT().GetWorkspaceSettings(gomock.Any(), workspaceID).Times(1).Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+				} else {
+					store.EXPECT().GetWorkspaceSettings(gomock.Any(), workspaceID).Times(1).Return(*tc.settings, nil)
+				}
+			}
+
+			public, err := NewFileService(store, util.Config{}, nil).OpenPublicFile(ctx, file.ID, tc.refererHost)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer public.Content.Close()
+			require.Equal(t, file.ID, public.File.ID)
+			require.Equal(t, tc.restricted, public.RefererRestricted)
+		})
+	}
+}
+
+func TestFileService_UpdateFileSettings(t *testing.T) {
+	const workspaceID = int64(5)
+	current := db.WorkspaceSetting{
+		WorkspaceID:        workspaceID,
+		ImageWatermark:     ImageWatermarkOff,
+		PublicFiles:        false,
+		PublicFileReferers: []string{"example.com"},
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetWorkspaceSettings(gomock.Any(), workspaceID).Times(2).Return(current, nil)
+	gomock.InOrder(
+		// Public file options left out of the request keep their value
+		store.EXPECT().
+			UpsertWorkspaceFileSettings(gomock.Any(), db.UpsertWorkspaceFileSettingsParams{
+				WorkspaceID:        workspaceID,
+				ImageWatermark:     ImageWatermarkAll,
+				PublicFiles:        false,
+				PublicFileReferers: []string{"example.com"},
+			}).
+			Times(1).
+			Return(db.WorkspaceSetting{WorkspaceID: workspaceID, ImageWatermark: ImageWatermarkAll, PublicFileReferers: []string{"example.com"}}, nil),
+		store.EXPECT().
+			UpsertWorkspaceFileSettings(gomock.Any(), db.UpsertWorkspaceFileSettingsParams{
+				WorkspaceID:        workspaceID,
+				ImageWatermark:     ImageWatermarkOff,
+				PublicFiles:        true,
+				PublicFileReferers: []string{"example.com", "cdn.example.org"},
+			}).
+			Times(1).
+			Return(db.WorkspaceSetting{WorkspaceID: workspaceID, ImageWatermark: ImageWatermarkOff, PublicFiles: true}, nil),
+	)
+
+	fileService := NewFileService(store, util.Config{}, nil)
+	settings, err := fileService.UpdateFileSettings(context.Background(), workspaceID, UpdateFileSettingsRequest{ImageWatermark: ImageWatermarkAll})
+	require.NoError(t, err)
+	require.Equal(t, []string{"example.com"}, settings.PublicFileReferers)
+
+	publicFiles := true
+	settings, err = fileService.UpdateFileSettings(context.Background(), workspaceID, UpdateFileSettingsRequest{
+		ImageWatermark:     ImageWatermarkOff,
+		PublicFiles:        &publicFiles,
+		PublicFileReferers: []string{"Example.com", "cdn.example.org.", "example.com"},
+	})
+	require.NoError(t, err)
+	require.True(t, settings.PublicFiles)
+	require.Equal(t, []string{}, settings.PublicFileReferers)
+}
+
+func TestRefererAllowed(t *testing.T) {
+	allowed := []string{"example.com"}
+
+	require.True(t, refererAllowed("", allowed))
+	require.True(t, refererAllowed("anything.net", nil))
+	require.True(t, refererAllowed("example.com", allowed))
+	require.True(t, refererAllowed("www.example.com.", allowed))
+	require.False(t, refererAllowed("badexample.com", allowed))
+	require.False(t, refererAllowed("example.com.evil.net", allowed))
+}
+
+func TestSetPublicURL(t *testing.T) {
+	response := &FileResponse{ID: 3}
+	setPublicURL(response)
+	require.Empty(t, response.PublicURL)
+
+	response.IsPublic = true
+	setPublicURL(response)
+	require.Regexp(t, `^/public/files/file_[0-9A-Z]{13}$`, response.PublicURL)
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/heyrmi/goslack/publicid"
+)
+
+// Workspaces, channels, messages and users are returned with a public_id
+// alongside their numeric id. Routes accept either one, so clients can stop
+// relying on sequential IDs. The public ID is added while encoding so every
+// response and WebSocket event carrying these types includes it.
+
+// MarshalJSON adds the workspace's public ID
+func (r WorkspaceResponse) MarshalJSON() ([]byte, error) {
+	type plain WorkspaceResponse
+	return json.Marshal(struct {
+		plain
+		PublicID string `json:"public_id"`
+	}{plain(r), publicid.Encode(publicid.Workspace, r.ID)})
+}
+
+// MarshalJSON adds the channel's public ID
+func (r ChannelResponse) MarshalJSON() ([]byte, error) {
+	type plain ChannelResponse
+	return json.Marshal(struct {
+		plain
+		PublicID string `json:"public_id"`
+	}{plain(r), publicid.Encode(publicid.Channel, r.ID)})
+}
+
+// MarshalJSON adds the message's public ID
+func (r MessageResponse) MarshalJSON() ([]byte, error) {
+	type plain MessageResponse
+	return json.Marshal(struct {
+		plain
+		PublicID string `json:"public_id"`
+	}{plain(r), publicid.Encode(publicid.Message, r.ID)})
+}
+
+// MarshalJSON adds the user's public ID
+func (r UserResponse) MarshalJSON() ([]byte, error) {
+	type plain UserResponse
+	return json.Marshal(struct {
+		plain
+		PublicID string `json:"public_id"`
+	}{plain(r), publicid.Encode(publicid.User, r.ID)})
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Reaction WebSocket message types, sent to the message's conversation
+const (
+	WSReactionAdded   = "reaction_added"
+	WSReactionRemoved = "reaction_removed"
+)
+
+// summaryReactors is how many reactors each reaction summary lists; the
+// rest are paged through with ListReactors
+const summaryReactors = 5
+
+// Reaction validation error codes
+const (
+	ReactionErrorInvalidEmoji       = "invalid_emoji"
+	ReactionErrorUnknownCustomEmoji = "unknown_custom_emoji"
+	ReactionErrorUserLimit          = "reaction_limit_reached"
+	ReactionErrorDistinctLimit      = "distinct_reaction_limit_reached"
+)
+
+// ReactionValidationError explains why a reaction was rejected, with a code
+// clients can act on and the limit that was reached, if any
+type ReactionValidationError struct {
+	Code    string `json:"code"`
+	Message string `json:"error"`
+	Limit   int32  `json:"limit,omitempty"`
+}
+
+func (e *ReactionValidationError) Error() string {
+	return e.Message
+}
+
+// reactionServiceStore covers reactions, the messages they are on and custom emoji
+type reactionServiceStore interface {
+	db.MessageStore
+	db.WorkspaceStore
+}
+
+// ReactionService handles emoji reactions to messages. Anyone who can read
+// a message can react to it with a Unicode emoji or one of the workspace's
+// custom emojis, within the workspace's reaction limits.
+type ReactionService struct {
+	store              reactionServiceStore
+	messageService     *MessageService
+	hub                WebSocketHub
+	defaultMaxPerUser  int32
+	defaultMaxDistinct int32
+}
+
+// NewReactionService creates a new reaction service
+func NewReactionService(store reactionServiceStore, messageService *MessageService, hub WebSocketHub, config util.Config) *ReactionService {
+	defaultMaxPerUser := config.ReactionMaxPerUser
+	if defaultMaxPerUser <= 0 {
+		defaultMaxPerUser = 23
+	}
+	defaultMaxDistinct := config.ReactionMaxDistinct
+	if defaultMaxDistinct <= 0 {
+		defaultMaxDistinct = 50
+	}
+
+	return &ReactionService{
+		store:              store,
+		messageService:     messageService,
+		hub:                hub,
+		defaultMaxPerUser:  defaultMaxPerUser,
+		defaultMaxDistinct: defaultMaxDistinct,
+	}
+}
+
+// AddReaction reacts to a message and returns its updated reaction summaries
+func (s *ReactionService) AddReaction(ctx context.Context, messageID, userID int64, emoji string) ([]ReactionSummary, error) {
+	message, err := s.getMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateEmoji(ctx, message.WorkspaceID, emoji); err != nil {
+		return nil, err
+	}
+	if err := s.checkLimits(ctx, message, userID, emoji); err != nil {
+		return nil, err
+	}
+
+	_, err = s.store.AddReaction(ctx, db.AddReactionParams{
+		MessageID: messageID,
+		UserID:    userID,
+		Emoji:     emoji,
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return nil, errors.New("reaction already exists")
+		}
+		return nil, fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	s.broadcast(message, WSReactionAdded, ReactionEvent{MessageID: messageID, UserID: userID, Emoji: emoji})
+
+	return s.summaries(ctx, messageID, userID)
+}
+
+// RemoveReaction removes the user's reaction to a message and returns its
+// updated reaction summaries
+func (s *ReactionService) RemoveReaction(ctx context.Context, messageID, userID int64, emoji string) ([]ReactionSummary, error) {
+	message, err := s.getMessage(ctx, messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.store.RemoveReaction(ctx, db.RemoveReactionParams{
+		MessageID: messageID,
+		UserID:    userID,
+		Emoji:     emoji,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove reaction: %w", err)
+	}
+	if removed == 0 {
+		return nil, errors.New("reaction not found")
+	}
+
+	s.broadcast(message, WSReactionRemoved, ReactionEvent{MessageID: messageID, UserID: userID, Emoji: emoji})
+
+	return s.summaries(ctx, messageID, userID)
+}
+
+// GetReactions returns a message's reactions grouped by emoji
+func (s *ReactionService) GetReactions(ctx context.Context, messageID, userID int64) ([]ReactionSummary, error) {
+	if _, err := s.getMessage(ctx, messageID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.summaries(ctx, messageID, userID)
+}
+
+// ListReactors pages through the users who reacted to a message with an
+// emoji, in the order they reacted
+func (s *ReactionService) ListReactors(ctx context.Context, messageID, userID int64, emoji string, req ListReactorsRequest) ([]ReactorResponse, error) {
+	if _, err := s.getMessage(ctx, messageID, userID); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	rows, err := s.store.ListReactors(ctx, db.ListReactorsParams{
+		MessageID: messageID,
+		Emoji:     emoji,
+		Limit:     limit,
+		Offset:    req.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactors: %w", err)
+	}
+
+	reactors := make([]ReactorResponse, len(rows))
+	for i, row := range rows {
+		reactors[i] = ReactorResponse{
+			UserID:    row.UserID,
+			FirstName: row.FirstName,
+			LastName:  row.LastName,
+			ReactedAt: row.CreatedAt,
+		}
+	}
+
+	return reactors, nil
+}
+
+// GetReactionSettings returns the reaction limits of a workspace
+func (s *ReactionService) GetReactionSettings(ctx context.Context, workspaceID int64) (*ReactionSettingsResponse, error) {
+	settings, err := s.getSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toReactionSettingsResponse(settings), nil
+}
+
+// UpdateReactionSettings sets the reaction limits of a workspace. Leaving a
+// limit empty restores the server default.
+func (s *ReactionService) UpdateReactionSettings(ctx context.Context, workspaceID int64, req UpdateReactionSettingsRequest) (*ReactionSettingsResponse, error) {
+	arg := db.UpsertWorkspaceReactionSettingsParams{
+		WorkspaceID: workspaceID,
+	}
+	if req.MaxReactionsPerUser != nil {
+		arg.MaxReactionsPerUser = sql.NullInt32{Int32: *req.MaxReactionsPerUser, Valid: true}
+	}
+	if req.MaxDistinctReactions != nil {
+		arg.MaxDistinctReactions = sql.NullInt32{Int32: *req.MaxDistinctReactions, Valid: true}
+	}
+
+	settings, err := s.store.UpsertWorkspaceReactionSettings(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reaction settings: %w", err)
+	}
+
+	return s.toReactionSettingsResponse(settings), nil
+}
+
+// validateEmoji checks that an emoji is a single Unicode emoji or one of the
+// workspace's custom emojis written as :name:
+func (s *ReactionService) validateEmoji(ctx context.Context, workspaceID int64, emoji string) error {
+	if isUnicodeEmoji(emoji) {
+		return nil
+	}
+
+	name, ok := customEmojiName(emoji)
+	if !ok {
+		return &ReactionValidationError{
+			Code:    ReactionErrorInvalidEmoji,
+			Message: "invalid emoji: react with a single emoji or a custom emoji such as :name:",
+		}
+	}
+
+	exists, err := s.store.CustomEmojiExists(ctx, db.CustomEmojiExistsParams{
+		WorkspaceID: workspaceID,
+		Name:        name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check custom emoji: %w", err)
+	}
+	if !exists {
+		return &ReactionValidationError{
+			Code:    ReactionErrorUnknownCustomEmoji,
+			Message: fmt.Sprintf("invalid emoji: the workspace has no custom emoji named %s", name),
+		}
+	}
+
+	return nil
+}
+
+// checkLimits checks a new reaction against the workspace's limits on
+// reactions per user and distinct emojis per message
+func (s *ReactionService) checkLimits(ctx context.Context, message db.GetMessageByIDRow, userID int64, emoji string) error {
+	settings, err := s.getSettings(ctx, message.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	limits := s.toReactionSettingsResponse(settings)
+
+	counts, err := s.store.GetReactionCounts(ctx, db.GetReactionCountsParams{
+		UserID:    userID,
+		Emoji:     emoji,
+		MessageID: message.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count reactions: %w", err)
+	}
+
+	if counts.UserReactions >= int64(limits.EffectiveMaxReactionsPerUser) {
+		return &ReactionValidationError{
+			Code:    ReactionErrorUserLimit,
+			Message: fmt.Sprintf("reaction limit reached: you can add up to %d reactions to a message", limits.EffectiveMaxReactionsPerUser),
+			Limit:   limits.EffectiveMaxReactionsPerUser,
+		}
+	}
+	// Reacting with an emoji that's already on the message is always allowed
+	if !counts.EmojiUsed && counts.DistinctEmojis >= int64(limits.EffectiveMaxDistinctReactions) {
+		return &ReactionValidationError{
+			Code:    ReactionErrorDistinctLimit,
+			Message: fmt.Sprintf("reaction limit reached: a message can have up to %d different emojis", limits.EffectiveMaxDistinctReactions),
+			Limit:   limits.EffectiveMaxDistinctReactions,
+		}
+	}
+
+	return nil
+}
+
+func (s *ReactionService) getSettings(ctx context.Context, workspaceID int64) (db.WorkspaceSetting, error) {
+	settings, err := s.store.GetWorkspaceSettings(ctx, workspaceID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return db.WorkspaceSetting{}, fmt.Errorf("failed to get workspace settings: %w", err)
+		}
+		// No settings yet means the defaults apply
+		settings = db.WorkspaceSetting{WorkspaceID: workspaceID}
+	}
+
+	return settings, nil
+}
+
+// toReactionSettingsResponse converts workspace settings to a reaction settings
+// response, resolving the limits that are actually in effect
+func (s *ReactionService) toReactionSettingsResponse(settings db.WorkspaceSetting) *ReactionSettingsResponse {
+	response := &ReactionSettingsResponse{
+		WorkspaceID:                   settings.WorkspaceID,
+		EffectiveMaxReactionsPerUser:  s.defaultMaxPerUser,
+		EffectiveMaxDistinctReactions: s.defaultMaxDistinct,
+	}
+
+	if settings.MaxReactionsPerUser.Valid {
+		response.MaxReactionsPerUser = &settings.MaxReactionsPerUser.Int32
+		response.EffectiveMaxReactionsPerUser = settings.MaxReactionsPerUser.Int32
+	}
+
+	if settings.MaxDistinctReactions.Valid {
+		response.MaxDistinctReactions = &settings.MaxDistinctReactions.Int32
+		response.EffectiveMaxDistinctReactions = settings.MaxDistinctReactions.Int32
+	}
+
+	return response
+}
+
+// getMessage gets a message the user can read
+func (s *ReactionService) getMessage(ctx context.Context, messageID, userID int64) (db.GetMessageByIDRow, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.GetMessageByIDRow{}, errors.New("message not found")
+		}
+		return db.GetMessageByIDRow{}, fmt.Errorf("failed to get message: %w", err)
+	}
+	if message.DeletedAt.Valid {
+		return db.GetMessageByIDRow{}, errors.New("message not found")
+	}
+
+	if err := s.messageService.checkMessageAccess(ctx, message, userID); err != nil {
+		return db.GetMessageByIDRow{}, err
+	}
+
+	return message, nil
+}
+
+func (s *ReactionService) summaries(ctx context.Context, messageID, userID int64) ([]ReactionSummary, error) {
+	summaries, err := loadReactionSummaries(ctx, s.store, []int64{messageID}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if summaries[messageID] == nil {
+		return []ReactionSummary{}, nil
+	}
+	return summaries[messageID], nil
+}
+
+func (s *ReactionService) broadcast(message db.GetMessageByIDRow, messageType string, event ReactionEvent) {
+	if s.hub == nil {
+		return
+	}
+
+	wsMessage := &WSMessage{
+		Type:        messageType,
+		Data:        event,
+		WorkspaceID: message.WorkspaceID,
+		UserID:      event.UserID,
+		Timestamp:   time.Now(),
+	}
+
+	if message.ChannelID.Valid {
+		channelID := message.ChannelID.Int64
+		wsMessage.ChannelID = &channelID
+		s.hub.BroadcastToChannel(message.WorkspaceID, channelID, wsMessage)
+	} else if message.ReceiverID.Valid {
+		// Direct message - broadcast to both sender and receiver
+		s.hub.BroadcastToUser(message.SenderID, wsMessage)
+		s.hub.BroadcastToUser(message.ReceiverID.Int64, wsMessage)
+	}
+}
+
+// loadReactionSummaries summarizes the reactions to a page of messages in one
+// query, keyed by message ID
+func loadReactionSummaries(ctx context.Context, store db.MessageStore, messageIDs []int64, viewerID int64) (map[int64][]ReactionSummary, error) {
+	summaries := make(map[int64][]ReactionSummary)
+	if len(messageIDs) == 0 {
+		return summaries, nil
+	}
+
+	rows, err := store.ListReactionSummaries(ctx, db.ListReactionSummariesParams{
+		ViewerID:     viewerID,
+		ReactorLimit: summaryReactors,
+		MessageIds:   messageIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reactions: %w", err)
+	}
+
+	for _, row := range rows {
+		summaries[row.MessageID] = append(summaries[row.MessageID], ReactionSummary{
+			Emoji:   row.Emoji,
+			Count:   row.Count,
+			UserIDs: row.UserIds,
+			Reacted: row.Reacted,
+		})
+	}
+
+	return summaries, nil
+}
+
+// attachReactions adds reaction summaries to a page of messages. Tombstones
+// of deleted messages are left without reactions.
+func (s *MessageService) attachReactions(ctx context.Context, messages []*MessageResponse, viewerID int64) error {
+	messageIDs := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		if message.DeletedAt == nil {
+			messageIDs = append(messageIDs, message.ID)
+		}
+	}
+
+	summaries, err := loadReactionSummaries(ctx, s.store, messageIDs, viewerID)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		message.Reactions = summaries[message.ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReactionService_DirectMessage(t *testing.T) {
+	const workspaceID, senderID, receiverID, outsiderID = int64(2), int64(5), int64(8), int64(11)
+	ctx := context.Background()
+	message := db.GetMessageByIDRow{
+		ID:          20,
+		WorkspaceID: workspaceID,
+		SenderID:    senderID,
+		ReceiverID:  sql.NullInt64{Int64: receiverID, Valid: true},
+		MessageType: "direct",
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetMessageByID(gomock.Any(), message.ID).AnyTimes().Return(message, nil)
+
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	reactionService := NewReactionService(store, NewMessageService(store, NewUserService(store, nil, util.Config{}), hub, util.Config{}), hub, util.Config{})
+
+	// Only the conversation's participants can react
+	_, err := reactionService.AddReaction(ctx, message.ID, outsiderID, "👍")
+	require.EqualError(t, err, "access denied: user is not part of this conversation")
+
+	store.EXPECT().GetWorkspaceSettings(gomock.Any(), workspaceID).AnyTimes().Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+	store.EXPECT().GetReactionCounts(gomock.Any(), gomock.Any()).AnyTimes().Return(db.GetReactionCountsRow{}, nil)
+
+	reaction := db.AddReactionParams{MessageID: message.ID, UserID: receiverID, Emoji: "👍"}
+	store.EXPECT().AddReaction(gomock.Any(), reaction).Times(1).Return(db.MessageReaction{}, nil)
+	store.EXPECT().
+		ListReactionSummaries(gomock.Any(), db.ListReactionSummariesParams{
+			ViewerID:     receiverID,
+			ReactorLimit: summaryReactors,
+			MessageIds:   []int64{message.ID},
+		}).
+		Times(1).
+		Return([]db.ListReactionSummariesRow{
+			{MessageID: message.ID, Emoji: "👍", Count: 1, Reacted: true, UserIds: []int64{receiverID}},
+		}, nil)
+
+	summaries, err := reactionService.AddReaction(ctx, message.ID, receiverID, "👍")
+	require.NoError(t, err)
+	require.Equal(t, []ReactionSummary{{Emoji: "👍", Count: 1, UserIDs: []int64{receiverID}, Reacted: true}}, summaries)
+
+	// Both participants hear about the reaction
+	event := ReactionEvent{MessageID: message.ID, UserID: receiverID, Emoji: "👍"}
+	for _, userID := range []int64{senderID, receiverID} {
+		require.Len(t, hub.userMessages[userID], 1)
+		require.Equal(t, WSReactionAdded, hub.userMessages[userID][0].Type)
+		require.Equal(t, event, hub.userMessages[userID][0].Data)
+	}
+
+	// Reacting twice with the same emoji is a conflict
+	store.EXPECT().AddReaction(gomock.Any(), reaction).Times(1).Return(db.MessageReaction{}, &pgconn.PgError{Code: db.UniqueViolation})
+
+	_, err = reactionService.AddReaction(ctx, message.ID, receiverID, "👍")
+	require.EqualError(t, err, "reaction already exists")
+
+	store.EXPECT().RemoveReaction(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+
+	_, err = reactionService.RemoveReaction(ctx, message.ID, senderID, "🎉")
+	require.EqualError(t, err, "reaction not found")
+}
+
+func TestReactionService_ListReactors(t *testing.T) {
+	const workspaceID, userID = int64(2), int64(5)
+	ctx := context.Background()
+	message := db.GetMessageByIDRow{
+		ID:          20,
+		WorkspaceID: workspaceID,
+		SenderID:    userID,
+		ReceiverID:  sql.NullInt64{Int64: 8, Valid: true},
+		MessageType: "direct",
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetMessageByID(gomock.Any(), message.ID).Times(1).Return(message, nil)
+	store.EXPECT().
+		ListReactors(gomock.Any(), db.ListReactorsParams{MessageID: message.ID, Emoji: "🎉", Limit: 50}).
+		Times(1).
+		Return([]db.ListReactorsRow{{UserID: 8, FirstName: "Ada", LastName: "Lovelace"}}, nil)
+
+	reactionService := NewReactionService(store, NewMessageService(store, NewUserService(store, nil, util.Config{}), nil, util.Config{}), nil, util.Config{})
+
+	reactors, err := reactionService.ListReactors(ctx, message.ID, userID, "🎉", ListReactorsRequest{})
+	require.NoError(t, err)
+	require.Len(t, reactors, 1)
+	require.Equal(t, "Ada", reactors[0].FirstName)
+
+	// Deleted messages can't be reacted to or inspected
+	message.DeletedAt = sql.NullTime{Valid: true}
+	store.EXPECT().GetMessageByID(gomock.Any(), message.ID).Times(1).Return(message, nil)
+
+	_, err = reactionService.GetReactions(ctx, message.ID, userID)
+	require.EqualError(t, err, "message not found")
+}
+
+func TestReactionService_Validation(t *testing.T) {
+	const workspaceID, userID = int64(2), int64(5)
+	ctx := context.Background()
+	message := db.GetMessageByIDRow{
+		ID:          20,
+		WorkspaceID: workspaceID,
+		SenderID:    userID,
+		ReceiverID:  sql.NullInt64{Int64: 8, Valid: true},
+		MessageType: "direct",
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetMessageByID(gomock.Any(), message.ID).AnyTimes().Return(message, nil)
+	store.EXPECT().AddReaction(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().
+		GetWorkspaceSettings(gomock.Any(), workspaceID).
+		AnyTimes().
+		Return(db.WorkspaceSetting{WorkspaceID: workspaceID, MaxDistinctReactions: sql.NullInt32{Int32: 3, Valid: true}}, nil)
+
+	config := util.Config{ReactionMaxPerUser: 2}
+	reactionService := NewReactionService(store, NewMessageService(store, NewUserService(store, nil, config), nil, config), nil, config)
+
+	var validationErr *ReactionValidationError
+
+	_, err := reactionService.AddReaction(ctx, message.ID, userID, "thumbsup")
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, ReactionErrorInvalidEmoji, validationErr.Code)
+
+	store.EXPECT().
+		CustomEmojiExists(gomock.Any(), db.CustomEmojiExistsParams{WorkspaceID: workspaceID, Name: "party-parrot"}).
+		Times(1).
+		Return(false, nil)
+
+	_, err = reactionService.AddReaction(ctx, message.ID, userID, ":party-parrot:")
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, ReactionErrorUnknownCustomEmoji, validationErr.Code)
+
+	// The server default limits reactions per user
+	store.EXPECT().GetReactionCounts(gomock.Any(), gomock.Any()).Times(1).Return(db.GetReactionCountsRow{UserReactions: 2}, nil)
+
+	_, err = reactionService.AddReaction(ctx, message.ID, userID, "👍")
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, ReactionErrorUserLimit, validationErr.Code)
+	require.Equal(t, int32(2), validationErr.Limit)
+
+	// The workspace limits different emojis, but emojis already on the
+	// message can still be added
+	store.EXPECT().GetReactionCounts(gomock.Any(), gomock.Any()).Times(1).Return(db.GetReactionCountsRow{DistinctEmojis: 3}, nil)
+
+	_, err = reactionService.AddReaction(ctx, message.ID, userID, "🎉")
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, ReactionErrorDistinctLimit, validationErr.Code)
+	require.Equal(t, int32(3), validationErr.Limit)
+
+	store.EXPECT().GetReactionCounts(gomock.Any(), gomock.Any()).Times(1).Return(db.GetReactionCountsRow{DistinctEmojis: 3, EmojiUsed: true}, nil)
+	store.EXPECT().AddReaction(gomock.Any(), gomock.Any()).Times(1).Return(db.MessageReaction{}, nil)
+	store.EXPECT().ListReactionSummaries(gomock.Any(), gomock.Any()).Times(1).Return([]db.ListReactionSummariesRow{}, nil)
+
+	_, err = reactionService.AddReaction(ctx, message.ID, userID, "🎉")
+	require.NoError(t, err)
+}
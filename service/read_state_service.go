@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// WSReadStateUpdated is sent to a user's connections when they mark a channel,
+// direct message conversation or thread as read
+const WSReadStateUpdated = "read_state_updated"
+
+// readStateServiceStore covers read positions in channels, conversations and threads
+type readStateServiceStore interface {
+	db.ChannelStore
+	db.MessageStore
+}
+
+// ReadStateService tracks how far users have read in channels, direct message
+// conversations and the threads they follow
+type ReadStateService struct {
+	store          readStateServiceStore
+	userService    *UserService
+	messageService *MessageService
+	hub            WebSocketHub
+}
+
+// NewReadStateService creates a new read state service
+func NewReadStateService(store readStateServiceStore, userService *UserService, messageService *MessageService, hub WebSocketHub) *ReadStateService {
+	return &ReadStateService{
+		store:          store,
+		userService:    userService,
+		messageService: messageService,
+		hub:            hub,
+	}
+}
+
+// MarkChannelAsRead moves the user's read position in a channel up to the given message
+func (s *ReadStateService) MarkChannelAsRead(ctx context.Context, workspaceID, channelID, userID, messageID int64) (*ChannelReadStateResponse, error) {
+	channel, err := s.store.GetChannelByID(ctx, channelID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("channel not found")
+		}
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel.WorkspaceID != workspaceID {
+		return nil, errors.New("channel not found")
+	}
+
+	if channel.IsPrivate {
+		isMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+			ChannelID: channelID,
+			UserID:    userID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check channel membership: %w", err)
+		}
+		if !isMember {
+			return nil, errors.New("access denied: user is not a member of the channel")
+		}
+	}
+
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if !message.ChannelID.Valid || message.ChannelID.Int64 != channelID {
+		return nil, errors.New("message not found")
+	}
+
+	readState, err := s.store.MarkChannelRead(ctx, db.MarkChannelReadParams{
+		UserID:            userID,
+		ChannelID:         channelID,
+		LastReadMessageID: sql.NullInt64{Int64: messageID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark channel as read: %w", err)
+	}
+
+	response := &ChannelReadStateResponse{
+		ChannelID:         readState.ChannelID,
+		LastReadMessageID: lastReadMessageID(readState.LastReadMessageID),
+		LastReadAt:        readState.LastReadAt,
+	}
+
+	s.broadcastReadState(userID, workspaceID, ReadStateEvent{
+		Kind:              "channel",
+		ChannelID:         &response.ChannelID,
+		LastReadMessageID: response.LastReadMessageID,
+		LastReadAt:        response.LastReadAt,
+	})
+
+	return response, nil
+}
+
+// MarkDirectMessagesAsRead moves the user's read position in a direct message
+// conversation up to the given message
+func (s *ReadStateService) MarkDirectMessagesAsRead(ctx context.Context, workspaceID, otherUserID, userID, messageID int64) (*DirectMessageReadStateResponse, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	// The message has to belong to the conversation between the two users
+	if message.MessageType != "direct" || message.WorkspaceID != workspaceID || !message.ReceiverID.Valid {
+		return nil, errors.New("message not found")
+	}
+	betweenUsers := (message.SenderID == userID && message.ReceiverID.Int64 == otherUserID) ||
+		(message.SenderID == otherUserID && message.ReceiverID.Int64 == userID)
+	if !betweenUsers {
+		return nil, errors.New("message not found")
+	}
+
+	readState, err := s.store.MarkDirectMessagesRead(ctx, db.MarkDirectMessagesReadParams{
+		UserID:            userID,
+		WorkspaceID:       workspaceID,
+		OtherUserID:       otherUserID,
+		LastReadMessageID: sql.NullInt64{Int64: messageID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark conversation as read: %w", err)
+	}
+
+	response := &DirectMessageReadStateResponse{
+		OtherUserID:       readState.OtherUserID,
+		LastReadMessageID: lastReadMessageID(readState.LastReadMessageID),
+		LastReadAt:        readState.LastReadAt,
+	}
+
+	s.broadcastReadState(userID, workspaceID, ReadStateEvent{
+		Kind:              "direct",
+		OtherUserID:       &response.OtherUserID,
+		LastReadMessageID: response.LastReadMessageID,
+		LastReadAt:        response.LastReadAt,
+	})
+
+	return response, nil
+}
+
+// MarkThreadAsRead moves the user's read position in a thread up to the given
+// message, which may be the thread's root or one of its replies. Marking a
+// thread as read also follows it, so its unread replies are counted from then on.
+func (s *ReadStateService) MarkThreadAsRead(ctx context.Context, threadID, userID, messageID int64) (*ThreadReadStateResponse, error) {
+	root, err := s.getThread(ctx, threadID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if messageID != threadID {
+		message, err := s.store.GetMessageByID(ctx, messageID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, errors.New("message not found")
+			}
+			return nil, fmt.Errorf("failed to get message: %w", err)
+		}
+
+		if !message.ThreadID.Valid || message.ThreadID.Int64 != threadID {
+			return nil, errors.New("message not found")
+		}
+	}
+
+	readState, err := s.store.MarkThreadRead(ctx, db.MarkThreadReadParams{
+		UserID:            userID,
+		ThreadID:          threadID,
+		LastReadMessageID: sql.NullInt64{Int64: messageID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark thread as read: %w", err)
+	}
+
+	response := &ThreadReadStateResponse{
+		ThreadID:          readState.ThreadID,
+		LastReadMessageID: lastReadMessageID(readState.LastReadMessageID),
+		LastReadAt:        readState.LastReadAt,
+	}
+
+	s.broadcastReadState(userID, root.WorkspaceID, ReadStateEvent{
+		Kind:              "thread",
+		ThreadID:          &response.ThreadID,
+		LastReadMessageID: response.LastReadMessageID,
+		LastReadAt:        response.LastReadAt,
+	})
+
+	return response, nil
+}
+
+// UnfollowThread stops counting a thread's unread replies for the user
+func (s *ReadStateService) UnfollowThread(ctx context.Context, threadID, userID int64) error {
+	rows, err := s.store.UnfollowThread(ctx, db.UnfollowThreadParams{
+		UserID:   userID,
+		ThreadID: threadID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unfollow thread: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("followed thread not found")
+	}
+
+	return nil
+}
+
+// GetUnreadCounts returns how many unread messages the user has in each channel,
+// direct message conversation and followed thread of a workspace. Conversations
+// and threads without unread messages are left out.
+func (s *ReadStateService) GetUnreadCounts(ctx context.Context, workspaceID, userID int64) (*UnreadCountsResponse, error) {
+	channels, err := s.store.ListChannelUnreadCounts(ctx, db.ListChannelUnreadCountsParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread channel messages: %w", err)
+	}
+
+	conversations, err := s.store.ListDirectMessageUnreadCounts(ctx, db.ListDirectMessageUnreadCountsParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread direct messages: %w", err)
+	}
+
+	threads, err := s.store.ListThreadUnreadCounts(ctx, db.ListThreadUnreadCountsParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread thread replies: %w", err)
+	}
+
+	response := &UnreadCountsResponse{
+		WorkspaceID:    workspaceID,
+		Channels:       make([]ChannelUnreadCount, len(channels)),
+		DirectMessages: make([]DirectMessageUnreadCount, len(conversations)),
+		Threads:        make([]ThreadUnreadCount, len(threads)),
+	}
+
+	for i, channel := range channels {
+		response.Channels[i] = ChannelUnreadCount{
+			ChannelID:         channel.ChannelID,
+			LastReadMessageID: lastReadMessageID(channel.LastReadMessageID),
+			UnreadCount:       channel.UnreadCount,
+		}
+		response.TotalUnread += channel.UnreadCount
+	}
+
+	for i, conversation := range conversations {
+		response.DirectMessages[i] = DirectMessageUnreadCount{
+			OtherUserID:       conversation.OtherUserID,
+			LastReadMessageID: lastReadMessageID(conversation.LastReadMessageID),
+			UnreadCount:       conversation.UnreadCount,
+		}
+		response.TotalUnread += conversation.UnreadCount
+	}
+
+	for i, thread := range threads {
+		response.Threads[i] = ThreadUnreadCount{
+			ThreadID:          thread.ThreadID,
+			LastReadMessageID: lastReadMessageID(thread.LastReadMessageID),
+			UnreadCount:       thread.UnreadCount,
+		}
+		response.TotalUnread += thread.UnreadCount
+	}
+
+	return response, nil
+}
+
+// getThread returns the root message of a thread the user can see
+func (s *ReadStateService) getThread(ctx context.Context, threadID, userID int64) (db.GetMessageByIDRow, error) {
+	root, err := s.store.GetMessageByID(ctx, threadID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.GetMessageByIDRow{}, errors.New("thread not found")
+		}
+		return db.GetMessageByIDRow{}, fmt.Errorf("failed to get thread: %w", err)
+	}
+
+	if root.ThreadID.Valid {
+		return db.GetMessageByIDRow{}, errors.New("thread not found")
+	}
+
+	if err := s.messageService.checkMessageAccess(ctx, root, userID); err != nil {
+		return db.GetMessageByIDRow{}, err
+	}
+
+	return root, nil
+}
+
+// MarkWorkspaceAsRead marks every channel, direct message conversation and followed
+// thread of the user in a workspace as read, and notifies the user's other connections
+func (s *ReadStateService) MarkWorkspaceAsRead(ctx context.Context, workspaceID, userID int64) (*WorkspaceReadResponse, error) {
+	result, err := s.store.MarkWorkspaceReadTx(ctx, db.MarkWorkspaceReadTxParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark workspace as read: %w", err)
+	}
+
+	response := &WorkspaceReadResponse{
+		WorkspaceID:         workspaceID,
+		ChannelsMarked:      result.ChannelsMarked,
+		ConversationsMarked: result.ConversationsMarked,
+		ThreadsMarked:       result.ThreadsMarked,
+		ReadAt:              time.Now(),
+	}
+
+	// Let the user's other devices clear their badges
+	if s.hub != nil {
+		wsMessage := &WSMessage{
+			Type:        "workspace_read",
+			Data:        response,
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+			Timestamp:   response.ReadAt,
+		}
+		s.hub.BroadcastToUser(userID, wsMessage)
+	}
+
+	return response, nil
+}
+
+// broadcastReadState tells the user's connections, including the one that made the
+// change, where they have read up to so every device can clear its badges
+func (s *ReadStateService) broadcastReadState(userID, workspaceID int64, event ReadStateEvent) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.BroadcastToUser(userID, &WSMessage{
+		Type:        WSReadStateUpdated,
+		Data:        event,
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Timestamp:   time.Now(),
+	})
+}
+
+// lastReadMessageID returns the message a read position points at, if any
+func lastReadMessageID(position sql.NullInt64) *int64 {
+	if !position.Valid {
+		return nil
+	}
+	return &position.Int64
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadStateService_MarkChannelAsReadBroadcasts(t *testing.T) {
+	const workspaceID, userID, channelID, messageID = int64(2), int64(5), int64(7), int64(40)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	readStateService := NewReadStateService(store, nil, nil, hub)
+
+	store.EXPECT().
+		GetChannelByID(gomock.Any(), channelID).
+		Times(1).
+		Return(db.Channel{ID: channelID, WorkspaceID: workspaceID}, nil)
+	store.EXPECT().
+		GetMessageByID(gomock.Any(), messageID).
+		Times(1).
+		Return(db.GetMessageByIDRow{ID: messageID, ChannelID: sql.NullInt64{Int64: channelID, Valid: true}}, nil)
+	store.EXPECT().
+		MarkChannelRead(gomock.Any(), db.MarkChannelReadParams{
+			UserID:            userID,
+			ChannelID:         channelID,
+			LastReadMessageID: sql.NullInt64{Int64: messageID, Valid: true},
+		}).
+		Times(1).
+		Return(db.ChannelReadState{
+			UserID:            userID,
+			ChannelID:         channelID,
+			LastReadMessageID: sql.NullInt64{Int64: messageID, Valid: true},
+			LastReadAt:        time.Now(),
+		}, nil)
+
+	response, err := readStateService.MarkChannelAsRead(ctx, workspaceID, channelID, userID, messageID)
+	require.NoError(t, err)
+	require.Equal(t, messageID, *response.LastReadMessageID)
+
+	// Every one of the user's connections is told where they have read up to
+	require.Len(t, hub.userMessages[userID], 1)
+	sent := hub.userMessages[userID][0]
+	require.Equal(t, WSReadStateUpdated, sent.Type)
+	require.Empty(t, sent.ExcludeConnectionID)
+
+	event, ok := sent.Data.(ReadStateEvent)
+	require.True(t, ok)
+	require.Equal(t, "channel", event.Kind)
+	require.Equal(t, channelID, *event.ChannelID)
+	require.Equal(t, messageID, *event.LastReadMessageID)
+}
@@ -0,0 +1,593 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// searchDateLayout is the date format accepted by the before:, after: and on: filters
+const searchDateLayout = "2006-01-02"
+
+// SearchQuery represents a parsed search query with its filters
+type SearchQuery struct {
+	Text     string     `json:"text,omitempty"`
+	From     string     `json:"from,omitempty"`
+	In       string     `json:"in,omitempty"`
+	After    *time.Time `json:"after,omitempty"`
+	Before   *time.Time `json:"before,omitempty"`
+	HasFile  bool       `json:"has_file,omitempty"`
+	HasLink  bool       `json:"has_link,omitempty"`
+	IsThread bool       `json:"is_thread,omitempty"`
+}
+
+// SearchQueryError is returned for search queries that can't be parsed
+type SearchQueryError struct {
+	err error
+}
+
+func (e *SearchQueryError) Error() string { return e.err.Error() }
+func (e *SearchQueryError) Unwrap() error { return e.err }
+
+// SearchTargetError is returned when a from: or in: filter names a sender or
+// channel the user can't search
+type SearchTargetError struct {
+	Kind string // sender or channel
+	Name string
+}
+
+func (e *SearchTargetError) Error() string { return fmt.Sprintf("%s '%s' not found", e.Kind, e.Name) }
+
+// ErrSavedSearchNotFound is returned for saved searches that don't exist or
+// belong to someone else
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+// parseSearchQuery parses a query sent to the service, reporting a bad one as a
+// SearchQueryError
+func parseSearchQuery(raw string) (SearchQuery, error) {
+	query, err := ParseSearchQuery(raw)
+	if err != nil {
+		return SearchQuery{}, &SearchQueryError{err: err}
+	}
+	return query, nil
+}
+
+// ParseSearchQuery splits a raw search string into free text and filters.
+// Tokens with an unknown "key:" prefix are kept as free text so that
+// things like URLs can still be searched for.
+func ParseSearchQuery(raw string) (SearchQuery, error) {
+	var query SearchQuery
+	var text []string
+
+	for _, token := range strings.Fields(raw) {
+		key, value, found := strings.Cut(token, ":")
+		if !found {
+			text = append(text, token)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "from":
+			if value == "" {
+				return SearchQuery{}, errors.New("from: filter requires a value")
+			}
+			query.From = strings.TrimPrefix(value, "@")
+		case "in":
+			if value == "" {
+				return SearchQuery{}, errors.New("in: filter requires a value")
+			}
+			query.In = strings.TrimPrefix(value, "#")
+		case "before":
+			date, err := parseSearchDate(key, value)
+			if err != nil {
+				return SearchQuery{}, err
+			}
+			query.Before = &date
+		case "after":
+			date, err := parseSearchDate(key, value)
+			if err != nil {
+				return SearchQuery{}, err
+			}
+			// after: excludes the given day itself
+			date = date.AddDate(0, 0, 1)
+			query.After = &date
+		case "on":
+			date, err := parseSearchDate(key, value)
+			if err != nil {
+				return SearchQuery{}, err
+			}
+			nextDay := date.AddDate(0, 0, 1)
+			query.After = &date
+			query.Before = &nextDay
+		case "has":
+			switch strings.ToLower(value) {
+			case "file":
+				query.HasFile = true
+			case "link":
+				query.HasLink = true
+			default:
+				return SearchQuery{}, fmt.Errorf("unsupported has: filter value '%s'", value)
+			}
+		case "is":
+			switch strings.ToLower(value) {
+			case "thread":
+				query.IsThread = true
+			default:
+				return SearchQuery{}, fmt.Errorf("unsupported is: filter value '%s'", value)
+			}
+		default:
+			text = append(text, token)
+		}
+	}
+
+	query.Text = strings.Join(text, " ")
+
+	if query.After != nil && query.Before != nil && !query.After.Before(*query.Before) {
+		return SearchQuery{}, errors.New("after: date must be earlier than before: date")
+	}
+
+	if query.Text == "" && !query.hasFilters() {
+		return SearchQuery{}, errors.New("search query cannot be empty")
+	}
+
+	return query, nil
+}
+
+// hasFilters reports whether any filter besides free text is set
+func (q SearchQuery) hasFilters() bool {
+	return q.From != "" || q.In != "" || q.After != nil || q.Before != nil ||
+		q.HasFile || q.HasLink || q.IsThread
+}
+
+// parseSearchDate parses a YYYY-MM-DD date used by date filters
+func parseSearchDate(key, value string) (time.Time, error) {
+	date, err := time.Parse(searchDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: filter expects a date in YYYY-MM-DD format", key)
+	}
+	return date, nil
+}
+
+// searchServiceStore covers messages, files, channels and users to search
+type searchServiceStore interface {
+	db.ChannelStore
+	db.FileStore
+	db.MessageStore
+	db.UserStore
+}
+
+// SearchService handles message search
+type SearchService struct {
+	store       searchServiceStore
+	userService *UserService
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(store searchServiceStore, userService *UserService) *SearchService {
+	return &SearchService{
+		store:       store,
+		userService: userService,
+	}
+}
+
+// SearchMessages parses a raw query and searches messages visible to the user
+// in a workspace, returning the matches and the parsed query. Channel messages
+// are searched in public channels and the private channels the user is a
+// member of, direct messages only when the user took part.
+func (s *SearchService) SearchMessages(ctx context.Context, workspaceID, userID int64, rawQuery string, limit, offset int32) ([]*MessageResponse, SearchQuery, error) {
+	query, err := parseSearchQuery(rawQuery)
+	if err != nil {
+		return nil, SearchQuery{}, err
+	}
+
+	messages, err := s.searchMessageRows(ctx, workspaceID, userID, query, limit, offset)
+	if err != nil {
+		return nil, SearchQuery{}, err
+	}
+
+	return s.toSearchMessageResponses(messages), query, nil
+}
+
+// searchMessageRows resolves the query filters and runs the message search
+func (s *SearchService) searchMessageRows(ctx context.Context, workspaceID, userID int64, query SearchQuery, limit, offset int32) ([]db.SearchMessagesRow, error) {
+	arg := db.SearchMessagesParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Query:       sql.NullString{String: query.Text, Valid: query.Text != ""},
+		HasFile:     query.HasFile,
+		HasLink:     query.HasLink,
+		IsThread:    query.IsThread,
+		Limit:       limit,
+		Offset:      offset,
+	}
+
+	if query.From != "" {
+		senderID, err := s.resolveSender(ctx, workspaceID, userID, query.From)
+		if err != nil {
+			return nil, err
+		}
+		arg.SenderID = sql.NullInt64{Int64: senderID, Valid: true}
+	}
+
+	if query.In != "" {
+		channel, err := s.store.GetChannelByName(ctx, db.GetChannelByNameParams{
+			WorkspaceID: workspaceID,
+			Name:        query.In,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, &SearchTargetError{Kind: "channel", Name: query.In}
+			}
+			return nil, fmt.Errorf("failed to resolve channel: %w", err)
+		}
+		// Private channels the user isn't in are treated as missing, so
+		// their names can't be probed
+		if channel.IsPrivate {
+			isMember, err := s.store.IsChannelMember(ctx, db.IsChannelMemberParams{
+				ChannelID: channel.ID,
+				UserID:    userID,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to check channel membership: %w", err)
+			}
+			if !isMember {
+				return nil, &SearchTargetError{Kind: "channel", Name: query.In}
+			}
+		}
+		arg.ChannelID = sql.NullInt64{Int64: channel.ID, Valid: true}
+	}
+
+	if query.After != nil {
+		arg.After = sql.NullTime{Time: *query.After, Valid: true}
+	}
+
+	if query.Before != nil {
+		arg.Before = sql.NullTime{Time: *query.Before, Valid: true}
+	}
+
+	messages, err := s.store.SearchMessages(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// Search parses a raw query and runs it across messages, files, channels and
+// people. Filters only apply to messages; the other groups match on the free
+// text and are left empty when the query has none.
+func (s *SearchService) Search(ctx context.Context, workspaceID, userID int64, rawQuery string, limit int32) (*UnifiedSearchResponse, error) {
+	query, err := parseSearchQuery(rawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnifiedSearchResponse{
+		Query:    query,
+		Messages: MessageSearchResults{Results: []*MessageResponse{}},
+		Files:    FileSearchResults{Results: []*FileResponse{}},
+		Channels: ChannelSearchResults{Results: []ChannelResponse{}},
+		People:   PeopleSearchResults{Results: []UserResponse{}},
+	}
+
+	messages, err := s.searchMessageRows(ctx, workspaceID, userID, query, limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	response.Messages.Results = s.toSearchMessageResponses(messages)
+	if len(messages) > 0 {
+		response.Messages.Count = messages[0].TotalCount
+	}
+
+	if query.Text == "" {
+		return response, nil
+	}
+
+	files, err := s.store.SearchFiles(ctx, db.SearchFilesParams{
+		WorkspaceID: workspaceID,
+		Query:       query.Text,
+		UserID:      userID,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search files: %w", err)
+	}
+	for _, file := range files {
+		response.Files.Results = append(response.Files.Results, toSearchFileResponse(file))
+	}
+	if len(files) > 0 {
+		response.Files.Count = files[0].TotalCount
+	}
+
+	channels, err := s.store.SearchChannels(ctx, db.SearchChannelsParams{
+		WorkspaceID: workspaceID,
+		Query:       query.Text,
+		UserID:      userID,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search channels: %w", err)
+	}
+	for _, channel := range channels {
+		response.Channels.Results = append(response.Channels.Results, ChannelResponse{
+			ID:          channel.ID,
+			WorkspaceID: channel.WorkspaceID,
+			Name:        channel.Name,
+			IsPrivate:   channel.IsPrivate,
+			CreatedBy:   channel.CreatedBy,
+			CreatedAt:   channel.CreatedAt,
+		})
+	}
+	if len(channels) > 0 {
+		response.Channels.Count = channels[0].TotalCount
+	}
+
+	// People search shows public profiles; emails are only matched and shown
+	// to those who manage the workspace's members
+	includeEmail, err := s.userService.HasWorkspacePermission(ctx, userID, workspaceID, PermissionManageMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	people, err := s.store.SearchWorkspaceUsers(ctx, db.SearchWorkspaceUsersParams{
+		IncludeEmail: includeEmail,
+		WorkspaceID:  sql.NullInt64{Int64: workspaceID, Valid: true},
+		Query:        query.Text,
+		Limit:        limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search people: %w", err)
+	}
+	for _, person := range people {
+		response.People.Results = append(response.People.Results, UserResponse{
+			ID:             person.ID,
+			OrganizationID: person.OrganizationID,
+			Email:          person.Email,
+			FirstName:      person.FirstName,
+			LastName:       person.LastName,
+			WorkspaceID:    &workspaceID,
+			Role:           person.Role,
+			Handle:         person.Handle,
+			Title:          person.Title,
+			Pronouns:       person.Pronouns,
+			Timezone:       person.Timezone,
+			AvatarURLs:     avatarURLs(person.AvatarKey),
+			Initials:       userInitials(person.FirstName, person.LastName),
+			CreatedAt:      person.CreatedAt,
+		})
+	}
+	if len(people) > 0 {
+		response.People.Count = people[0].TotalCount
+	}
+
+	return response, nil
+}
+
+// resolveSender resolves the value of a from: filter ("me", a user ID, or an email) to a user ID
+func (s *SearchService) resolveSender(ctx context.Context, workspaceID, userID int64, from string) (int64, error) {
+	if strings.EqualFold(from, "me") {
+		return userID, nil
+	}
+
+	if id, err := strconv.ParseInt(from, 10, 64); err == nil {
+		// As with emails, only members of the workspace can be searched by
+		isMember, err := s.userService.IsWorkspaceMember(ctx, id, workspaceID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check workspace membership: %w", err)
+		}
+		if !isMember {
+			return 0, &SearchTargetError{Kind: "sender", Name: from}
+		}
+		return id, nil
+	}
+
+	sender, err := s.userService.GetUserByEmail(ctx, from)
+	if err != nil {
+		return 0, &SearchTargetError{Kind: "sender", Name: from}
+	}
+
+	if sender.WorkspaceID == nil || *sender.WorkspaceID != workspaceID {
+		return 0, &SearchTargetError{Kind: "sender", Name: from}
+	}
+
+	return sender.ID, nil
+}
+
+// Helper function to convert search result rows to responses
+func (s *SearchService) toSearchMessageResponses(messages []db.SearchMessagesRow) []*MessageResponse {
+	responses := make([]*MessageResponse, len(messages))
+	for i, message := range messages {
+		response := &MessageResponse{
+			ID:          message.ID,
+			WorkspaceID: message.WorkspaceID,
+			SenderID:    message.SenderID,
+			Content:     message.Content,
+			ContentType: message.ContentType,
+			MessageType: message.MessageType,
+			Sender: UserResponse{
+				ID:        message.SenderID,
+				Email:     message.SenderEmail,
+				FirstName: message.SenderFirstName,
+				LastName:  message.SenderLastName,
+			},
+			CreatedAt: message.CreatedAt,
+		}
+
+		if message.ChannelID.Valid {
+			response.ChannelID = &message.ChannelID.Int64
+		}
+
+		if message.ReceiverID.Valid {
+			response.ReceiverID = &message.ReceiverID.Int64
+		}
+
+		if message.ThreadID.Valid {
+			response.ThreadID = &message.ThreadID.Int64
+		}
+
+		if message.EditedAt.Valid {
+			response.EditedAt = &message.EditedAt.Time
+		}
+
+		responses[i] = response
+	}
+	return responses
+}
+
+// CreateSavedSearch saves a named search query for the user in a workspace
+func (s *SearchService) CreateSavedSearch(ctx context.Context, workspaceID, userID int64, req SavedSearchRequest) (*SavedSearchResponse, error) {
+	// Reject queries that could never be executed
+	if _, err := parseSearchQuery(req.Query); err != nil {
+		return nil, err
+	}
+
+	savedSearch, err := s.store.CreateSavedSearch(ctx, db.CreateSavedSearchParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Name:        strings.TrimSpace(req.Name),
+		Query:       req.Query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSavedSearchResponse(savedSearch), nil
+}
+
+// ListSavedSearches lists the user's saved searches in a workspace
+func (s *SearchService) ListSavedSearches(ctx context.Context, workspaceID, userID int64) ([]*SavedSearchResponse, error) {
+	savedSearches, err := s.store.ListSavedSearches(ctx, db.ListSavedSearchesParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+
+	responses := make([]*SavedSearchResponse, len(savedSearches))
+	for i, savedSearch := range savedSearches {
+		responses[i] = toSavedSearchResponse(savedSearch)
+	}
+	return responses, nil
+}
+
+// UpdateSavedSearch renames a saved search or changes its query
+func (s *SearchService) UpdateSavedSearch(ctx context.Context, workspaceID, userID, searchID int64, req SavedSearchRequest) (*SavedSearchResponse, error) {
+	if _, err := parseSearchQuery(req.Query); err != nil {
+		return nil, err
+	}
+
+	savedSearch, err := s.store.UpdateSavedSearch(ctx, db.UpdateSavedSearchParams{
+		ID:          searchID,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Name:        strings.TrimSpace(req.Name),
+		Query:       req.Query,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSavedSearchNotFound
+		}
+		return nil, err
+	}
+
+	return toSavedSearchResponse(savedSearch), nil
+}
+
+// DeleteSavedSearch deletes one of the user's saved searches
+func (s *SearchService) DeleteSavedSearch(ctx context.Context, workspaceID, userID, searchID int64) error {
+	_, err := s.getSavedSearch(ctx, workspaceID, userID, searchID)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.DeleteSavedSearch(ctx, db.DeleteSavedSearchParams{
+		ID:          searchID,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+
+	return nil
+}
+
+// RunSavedSearch executes a saved search and returns the matching messages
+func (s *SearchService) RunSavedSearch(ctx context.Context, workspaceID, userID, searchID int64, limit, offset int32) (*SavedSearchResponse, []*MessageResponse, error) {
+	savedSearch, err := s.getSavedSearch(ctx, workspaceID, userID, searchID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages, _, err := s.SearchMessages(ctx, workspaceID, userID, savedSearch.Query, limit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return toSavedSearchResponse(savedSearch), messages, nil
+}
+
+// getSavedSearch fetches a saved search owned by the user
+func (s *SearchService) getSavedSearch(ctx context.Context, workspaceID, userID, searchID int64) (db.SavedSearch, error) {
+	savedSearch, err := s.store.GetSavedSearch(ctx, db.GetSavedSearchParams{
+		ID:          searchID,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.SavedSearch{}, ErrSavedSearchNotFound
+		}
+		return db.SavedSearch{}, fmt.Errorf("failed to get saved search: %w", err)
+	}
+	return savedSearch, nil
+}
+
+// Helper function to convert a db saved search to a response
+func toSavedSearchResponse(savedSearch db.SavedSearch) *SavedSearchResponse {
+	return &SavedSearchResponse{
+		ID:          savedSearch.ID,
+		WorkspaceID: savedSearch.WorkspaceID,
+		Name:        savedSearch.Name,
+		Query:       savedSearch.Query,
+		CreatedAt:   savedSearch.CreatedAt,
+		UpdatedAt:   savedSearch.UpdatedAt,
+	}
+}
+
+// Helper function to convert a file search row to a response
+func toSearchFileResponse(file db.SearchFilesRow) *FileResponse {
+	response := &FileResponse{
+		ID:               file.ID,
+		OriginalFilename: file.OriginalFilename,
+		FileSize:         file.FileSize,
+		MimeType:         file.MimeType,
+		DownloadURL:      fmt.Sprintf("/api/files/%d/download", file.ID),
+		CreatedAt:        file.CreatedAt,
+		IsPublic:         file.IsPublic,
+		DownloadCount:    file.DownloadCount,
+		PreviewStatus:    file.PreviewStatus.String,
+		Uploader: UserResponse{
+			ID:        file.UploaderID,
+			Email:     file.UploaderEmail,
+			FirstName: file.UploaderFirstName,
+			LastName:  file.UploaderLastName,
+		},
+	}
+
+	if file.ThumbnailPath.Valid {
+		response.ThumbnailURL = fmt.Sprintf("/api/files/%d/thumbnail", file.ID)
+	}
+	setVoiceMessage(response, file.IsVoiceMessage, file.DurationMs, file.Waveform)
+	setVideoProcessing(response, file.ProcessingStatus, file.TranscodedPath, file.PosterPath)
+	setPublicURL(response)
+
+	return response
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	t.Run("FreeTextOnly", func(t *testing.T) {
+		query, err := ParseSearchQuery("deploy failed")
+		require.NoError(t, err)
+		require.Equal(t, "deploy failed", query.Text)
+		require.False(t, query.hasFilters())
+	})
+
+	t.Run("AllFilters", func(t *testing.T) {
+		query, err := ParseSearchQuery("release from:@alice@example.com in:#general after:2024-01-01 before:2024-02-01 has:file has:link is:thread notes")
+		require.NoError(t, err)
+		require.Equal(t, "release notes", query.Text)
+		require.Equal(t, "alice@example.com", query.From)
+		require.Equal(t, "general", query.In)
+		require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), *query.After)
+		require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), *query.Before)
+		require.True(t, query.HasFile)
+		require.True(t, query.HasLink)
+		require.True(t, query.IsThread)
+	})
+
+	t.Run("OnDate", func(t *testing.T) {
+		query, err := ParseSearchQuery("on:2024-03-10")
+		require.NoError(t, err)
+		require.Empty(t, query.Text)
+		require.Equal(t, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), *query.After)
+		require.Equal(t, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), *query.Before)
+	})
+
+	t.Run("UnknownPrefixIsText", func(t *testing.T) {
+		query, err := ParseSearchQuery("https://example.com")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com", query.Text)
+	})
+
+	t.Run("InvalidDate", func(t *testing.T) {
+		_, err := ParseSearchQuery("before:yesterday")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "YYYY-MM-DD")
+	})
+
+	t.Run("UnsupportedHasValue", func(t *testing.T) {
+		_, err := ParseSearchQuery("has:emoji")
+		require.Error(t, err)
+	})
+
+	t.Run("EmptyFilterValue", func(t *testing.T) {
+		_, err := ParseSearchQuery("from:")
+		require.Error(t, err)
+	})
+
+	t.Run("InvertedDateRange", func(t *testing.T) {
+		_, err := ParseSearchQuery("after:2024-02-01 before:2024-01-01")
+		require.Error(t, err)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := ParseSearchQuery("   ")
+		require.Error(t, err)
+	})
+}
+
+func TestSearchService_SearchMessagesFrom(t *testing.T) {
+	const workspaceID, userID, memberID, outsiderID = int64(2), int64(5), int64(7), int64(9)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), db.CheckUserWorkspaceRoleParams{ID: memberID, WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true}}).
+		Times(1).
+		Return("member", nil)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), db.CheckUserWorkspaceRoleParams{ID: outsiderID, WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true}}).
+		Times(1).
+		Return("", sql.ErrNoRows)
+	store.EXPECT().
+		SearchMessages(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.SearchMessagesParams) ([]db.SearchMessagesRow, error) {
+			require.Equal(t, sql.NullInt64{Int64: memberID, Valid: true}, arg.SenderID)
+			return []db.SearchMessagesRow{}, nil
+		})
+
+	searchService := NewSearchService(store, NewUserService(store, nil, util.Config{}))
+
+	_, query, err := searchService.SearchMessages(ctx, workspaceID, userID, "from:7 deploy", 20, 0)
+	require.NoError(t, err)
+	require.Equal(t, "deploy", query.Text)
+
+	// Users outside the workspace can't be searched by ID
+	_, _, err = searchService.SearchMessages(ctx, workspaceID, userID, "from:9 deploy", 20, 0)
+	require.EqualError(t, err, "sender '9' not found")
+	var targetErr *SearchTargetError
+	require.ErrorAs(t, err, &targetErr)
+	require.Equal(t, "sender", targetErr.Kind)
+
+	// Bad queries are reported as such
+	_, _, err = searchService.SearchMessages(ctx, workspaceID, userID, "before:yesterday", 20, 0)
+	var queryErr *SearchQueryError
+	require.ErrorAs(t, err, &queryErr)
+}
+
+func TestSearchService_SearchPeople(t *testing.T) {
+	const workspaceID, memberID, adminID = int64(2), int64(5), int64(6)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), db.CheckUserWorkspaceRoleParams{ID: memberID, WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true}}).
+		Times(1).
+		Return("member", nil)
+	store.EXPECT().GetUserRolePermissions(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrNoRows)
+	store.EXPECT().
+		CheckUserWorkspaceRole(gomock.Any(), db.CheckUserWorkspaceRoleParams{ID: adminID, WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true}}).
+		Times(1).
+		Return("admin", nil)
+	store.EXPECT().SearchMessages(gomock.Any(), gomock.Any()).Times(2).Return([]db.SearchMessagesRow{}, nil)
+	store.EXPECT().SearchFiles(gomock.Any(), gomock.Any()).Times(2).Return([]db.SearchFilesRow{}, nil)
+	store.EXPECT().SearchChannels(gomock.Any(), gomock.Any()).Times(2).Return([]db.SearchChannelsRow{}, nil)
+
+	// Members see public profiles; admins can also match and see emails
+	var includeEmail []bool
+	store.EXPECT().
+		SearchWorkspaceUsers(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.SearchWorkspaceUsersParams) ([]db.SearchWorkspaceUsersRow, error) {
+			includeEmail = append(includeEmail, arg.IncludeEmail)
+			return []db.SearchWorkspaceUsersRow{{
+				ID:         7,
+				FirstName:  "Ada",
+				LastName:   "Lovelace",
+				Role:       "member",
+				Handle:     "ada",
+				TotalCount: 1,
+			}}, nil
+		})
+
+	searchService := NewSearchService(store, NewUserService(store, nil, util.Config{}))
+
+	response, err := searchService.Search(ctx, workspaceID, memberID, "ada", 5)
+	require.NoError(t, err)
+	require.Len(t, response.People.Results, 1)
+	require.Equal(t, "ada", response.People.Results[0].Handle)
+	require.Equal(t, "AL", response.People.Results[0].Initials)
+	require.Empty(t, response.People.Results[0].Email)
+
+	_, err = searchService.Search(ctx, workspaceID, adminID, "ada", 5)
+	require.NoError(t, err)
+	require.Equal(t, []bool{false, true}, includeEmail)
+}
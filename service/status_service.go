@@ -5,23 +5,182 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
 )
 
+// Presence modes
+const (
+	// PresenceModeActivity derives presence from activity updates and the inactivity monitor only
+	PresenceModeActivity = "activity"
+	// PresenceModeConnection derives presence from WebSocket connections only
+	PresenceModeConnection = "connection"
+	// PresenceModeHybrid combines WebSocket connections with the inactivity monitor
+	PresenceModeHybrid = "hybrid"
+)
+
+// statusServiceStore covers user statuses and the workspace members they are broadcast to
+type statusServiceStore interface {
+	db.UserStore
+	db.WorkspaceStore
+}
+
 // StatusService handles user status-related business logic
 type StatusService struct {
-	store db.Store
+	store statusServiceStore
 	hub   WebSocketHub // Interface for WebSocket hub
+
+	presenceMode       string
+	offlineGracePeriod time.Duration
+
+	// Default inactivity thresholds for workspaces without their own settings
+	defaultAwayAfter    time.Duration
+	defaultOfflineAfter time.Duration
+
+	// Pending offline transitions for users whose last connection closed
+	pendingOffline map[int64]*time.Timer
+	mutex          sync.Mutex
 }
 
 // NewStatusService creates a new status service
-func NewStatusService(store db.Store, hub WebSocketHub) *StatusService {
+func NewStatusService(store statusServiceStore, hub WebSocketHub, config util.Config) *StatusService {
+	// Without explicit configuration presence keeps following activity only
+	presenceMode := config.PresenceMode
+	if presenceMode == "" {
+		presenceMode = PresenceModeActivity
+	}
+
+	defaultOfflineAfter := config.PresenceOfflineAfter
+	if defaultOfflineAfter <= 0 {
+		defaultOfflineAfter = 30 * time.Minute
+	}
+
 	return &StatusService{
-		store: store,
-		hub:   hub,
+		store:               store,
+		hub:                 hub,
+		presenceMode:        presenceMode,
+		offlineGracePeriod:  config.PresenceOfflineGracePeriod,
+		defaultAwayAfter:    config.PresenceAwayAfter,
+		defaultOfflineAfter: defaultOfflineAfter,
+		pendingOffline:      make(map[int64]*time.Timer),
+	}
+}
+
+// tracksConnections reports whether WebSocket connections drive presence
+func (s *StatusService) tracksConnections() bool {
+	return s.presenceMode == PresenceModeConnection || s.presenceMode == PresenceModeHybrid
+}
+
+// UserConnected is called when a user opens their first WebSocket connection.
+// It cancels a pending offline transition and brings the user online, keeping
+// an explicitly chosen away/busy status.
+func (s *StatusService) UserConnected(userID, workspaceID int64) {
+	if !s.tracksConnections() {
+		return
+	}
+
+	s.mutex.Lock()
+	if timer, exists := s.pendingOffline[userID]; exists {
+		timer.Stop()
+		delete(s.pendingOffline, userID)
+	}
+	s.mutex.Unlock()
+
+	ctx := context.Background()
+	userStatus, err := s.store.SetUserPresenceOnline(ctx, db.SetUserPresenceOnlineParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		fmt.Printf("Error setting user %d online: %v\n", userID, err)
+		return
+	}
+
+	s.broadcastStatus(ctx, userStatus)
+}
+
+// UserDisconnected is called when a user's last WebSocket connection closes.
+// The user goes offline after the grace period unless they reconnect first.
+func (s *StatusService) UserDisconnected(userID, workspaceID int64) {
+	if !s.tracksConnections() {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if timer, exists := s.pendingOffline[userID]; exists {
+		timer.Stop()
 	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(s.offlineGracePeriod, func() {
+		s.mutex.Lock()
+		// A reconnect or a newer disconnect replaced this transition
+		if s.pendingOffline[userID] != timer {
+			s.mutex.Unlock()
+			return
+		}
+		delete(s.pendingOffline, userID)
+		s.mutex.Unlock()
+
+		ctx := context.Background()
+		userStatus, err := s.store.SetUserPresenceOffline(ctx, db.SetUserPresenceOfflineParams{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+		})
+		if err != nil {
+			if err != sql.ErrNoRows {
+				fmt.Printf("Error setting user %d offline: %v\n", userID, err)
+			}
+			return
+		}
+
+		s.broadcastStatus(ctx, userStatus)
+	})
+	s.pendingOffline[userID] = timer
+}
+
+// broadcastStatus sends a status_changed event for a user status to their workspace
+func (s *StatusService) broadcastStatus(ctx context.Context, userStatus db.UserStatus) {
+	if s.hub == nil {
+		return
+	}
+
+	statusResponse, err := s.toUserStatusResponse(ctx, userStatus)
+	if err != nil {
+		return
+	}
+
+	wsMessage := &WSMessage{
+		Type:        "status_changed",
+		Data:        statusResponse,
+		WorkspaceID: userStatus.WorkspaceID,
+		UserID:      userStatus.UserID,
+		Timestamp:   time.Now(),
+	}
+	s.hub.BroadcastToWorkspace(userStatus.WorkspaceID, wsMessage)
+}
+
+// PublishUserStatus sends a user's current status to their workspace, for
+// changes that affect the status without updating it, like Do Not Disturb
+func (s *StatusService) PublishUserStatus(ctx context.Context, userID, workspaceID int64) {
+	if s.hub == nil {
+		return
+	}
+
+	userStatus, err := s.store.GetUserStatus(ctx, db.GetUserStatusParams{
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return
+	}
+
+	s.broadcastStatus(ctx, userStatus)
 }
 
 // SetUserOnline sets a user as online in a workspace
@@ -88,18 +247,36 @@ func (s *StatusService) SetUserOffline(ctx context.Context, userID, workspaceID
 	return nil
 }
 
-// SetUserStatus sets a user's status and custom status
-func (s *StatusService) SetUserStatus(ctx context.Context, userID, workspaceID int64, status, customStatus string) (*UserStatusResponse, error) {
+// SetUserStatus sets a user's status, custom status and status emoji.
+// When ClearAfter is set the custom status and emoji are cleared at that time.
+func (s *StatusService) SetUserStatus(ctx context.Context, userID, workspaceID int64, req UpdateUserStatusRequest) (*UserStatusResponse, error) {
+	if req.ClearAfter != nil {
+		if req.CustomStatus == "" && req.StatusEmoji == "" {
+			return nil, errors.New("clear_after requires a custom status or status emoji")
+		}
+		if !req.ClearAfter.After(time.Now()) {
+			return nil, errors.New("clear_after must be in the future")
+		}
+	}
+
 	arg := db.UpsertUserStatusParams{
 		UserID:      userID,
 		WorkspaceID: workspaceID,
-		Status:      status,
+		Status:      req.Status,
 		CustomStatus: sql.NullString{
-			String: customStatus,
-			Valid:  customStatus != "",
+			String: req.CustomStatus,
+			Valid:  req.CustomStatus != "",
+		},
+		StatusEmoji: sql.NullString{
+			String: req.StatusEmoji,
+			Valid:  req.StatusEmoji != "",
 		},
 	}
 
+	if req.ClearAfter != nil {
+		arg.ClearAfter = sql.NullTime{Time: *req.ClearAfter, Valid: true}
+	}
+
 	userStatus, err := s.store.UpsertUserStatus(ctx, arg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to set user status: %w", err)
@@ -156,7 +333,7 @@ func (s *StatusService) GetWorkspaceUserStatuses(ctx context.Context, workspaceI
 		return nil, fmt.Errorf("failed to get workspace user statuses: %w", err)
 	}
 
-	return s.toUserStatusResponses(ctx, statuses), nil
+	return s.toUserStatusResponses(ctx, statuses, s.workspaceDoNotDisturb(ctx, workspaceID)), nil
 }
 
 // UpdateUserActivity updates a user's last activity timestamp
@@ -186,6 +363,137 @@ func (s *StatusService) SetInactiveUsersOffline(ctx context.Context, inactivityD
 	return nil
 }
 
+// SweepInactiveUsers marks idle users away and then offline according to the
+// thresholds of their workspace, falling back to the server defaults
+func (s *StatusService) SweepInactiveUsers(ctx context.Context) error {
+	var defaultAwayMinutes sql.NullInt32
+	if s.defaultAwayAfter > 0 {
+		defaultAwayMinutes = sql.NullInt32{Int32: int32(s.defaultAwayAfter / time.Minute), Valid: true}
+	}
+
+	awayStatuses, err := s.store.SetIdleUsersAway(ctx, defaultAwayMinutes)
+	if err != nil {
+		return fmt.Errorf("failed to set idle users away: %w", err)
+	}
+
+	offlineStatuses, err := s.store.SetIdleUsersOffline(ctx, int32(s.defaultOfflineAfter/time.Minute))
+	if err != nil {
+		return fmt.Errorf("failed to set idle users offline: %w", err)
+	}
+
+	for _, userStatus := range awayStatuses {
+		s.broadcastStatus(ctx, userStatus)
+	}
+	for _, userStatus := range offlineStatuses {
+		s.broadcastStatus(ctx, userStatus)
+	}
+
+	return nil
+}
+
+// GetPresenceSettings returns the inactivity thresholds of a workspace
+func (s *StatusService) GetPresenceSettings(ctx context.Context, workspaceID int64) (*PresenceSettingsResponse, error) {
+	settings, err := s.store.GetWorkspaceSettings(ctx, workspaceID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to get workspace settings: %w", err)
+		}
+		// No settings yet means the defaults apply
+		settings = db.WorkspaceSetting{WorkspaceID: workspaceID}
+	}
+
+	return s.toPresenceSettingsResponse(settings), nil
+}
+
+// UpdatePresenceSettings sets the inactivity thresholds of a workspace.
+// Leaving a threshold empty restores the server default.
+func (s *StatusService) UpdatePresenceSettings(ctx context.Context, workspaceID int64, req UpdatePresenceSettingsRequest) (*PresenceSettingsResponse, error) {
+	awayAfter := s.defaultAwayAfter
+	if req.AwayAfterMinutes != nil {
+		awayAfter = time.Duration(*req.AwayAfterMinutes) * time.Minute
+	}
+	offlineAfter := s.defaultOfflineAfter
+	if req.OfflineAfterMinutes != nil {
+		offlineAfter = time.Duration(*req.OfflineAfterMinutes) * time.Minute
+	}
+	if awayAfter > 0 && awayAfter >= offlineAfter {
+		return nil, errors.New("away threshold must be shorter than offline threshold")
+	}
+
+	arg := db.UpsertWorkspacePresenceSettingsParams{
+		WorkspaceID: workspaceID,
+	}
+	if req.AwayAfterMinutes != nil {
+		arg.AwayAfterMinutes = sql.NullInt32{Int32: *req.AwayAfterMinutes, Valid: true}
+	}
+	if req.OfflineAfterMinutes != nil {
+		arg.OfflineAfterMinutes = sql.NullInt32{Int32: *req.OfflineAfterMinutes, Valid: true}
+	}
+
+	settings, err := s.store.UpsertWorkspacePresenceSettings(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update presence settings: %w", err)
+	}
+
+	return s.toPresenceSettingsResponse(settings), nil
+}
+
+// toPresenceSettingsResponse converts workspace settings to a presence settings
+// response, resolving the thresholds that are actually in effect
+func (s *StatusService) toPresenceSettingsResponse(settings db.WorkspaceSetting) *PresenceSettingsResponse {
+	response := &PresenceSettingsResponse{
+		WorkspaceID:                  settings.WorkspaceID,
+		EffectiveOfflineAfterMinutes: int32(s.defaultOfflineAfter / time.Minute),
+	}
+
+	if s.defaultAwayAfter > 0 {
+		defaultAway := int32(s.defaultAwayAfter / time.Minute)
+		response.EffectiveAwayAfterMinutes = &defaultAway
+	}
+
+	if settings.AwayAfterMinutes.Valid {
+		response.AwayAfterMinutes = &settings.AwayAfterMinutes.Int32
+		response.EffectiveAwayAfterMinutes = &settings.AwayAfterMinutes.Int32
+	}
+
+	if settings.OfflineAfterMinutes.Valid {
+		response.OfflineAfterMinutes = &settings.OfflineAfterMinutes.Int32
+		response.EffectiveOfflineAfterMinutes = settings.OfflineAfterMinutes.Int32
+	}
+
+	return response
+}
+
+// ClearExpiredStatuses clears custom statuses whose clear_after time has passed
+// and broadcasts the change to each user's workspace
+func (s *StatusService) ClearExpiredStatuses(ctx context.Context) error {
+	statuses, err := s.store.ClearExpiredCustomStatuses(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to clear expired statuses: %w", err)
+	}
+
+	for _, userStatus := range statuses {
+		s.broadcastStatus(ctx, userStatus)
+	}
+
+	return nil
+}
+
+// ClearExpiredDoNotDisturb ends snoozes and other Do Not Disturb that has
+// expired and broadcasts the change to each user's workspace
+func (s *StatusService) ClearExpiredDoNotDisturb(ctx context.Context) error {
+	statuses, err := s.store.ClearExpiredDoNotDisturb(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to clear expired do not disturb: %w", err)
+	}
+
+	for _, userStatus := range statuses {
+		s.broadcastStatus(ctx, userStatus)
+	}
+
+	return nil
+}
+
 // GetOnlineUsersInWorkspace retrieves all online users in a workspace
 func (s *StatusService) GetOnlineUsersInWorkspace(ctx context.Context, workspaceID int64) ([]*UserStatusResponse, error) {
 	statuses, err := s.store.GetOnlineUsersInWorkspace(ctx, workspaceID)
@@ -193,7 +501,7 @@ func (s *StatusService) GetOnlineUsersInWorkspace(ctx context.Context, workspace
 		return nil, fmt.Errorf("failed to get online users: %w", err)
 	}
 
-	return s.toOnlineUserStatusResponses(statuses), nil
+	return s.toOnlineUserStatusResponses(statuses, s.workspaceDoNotDisturb(ctx, workspaceID)), nil
 }
 
 // StartInactivityMonitor starts a background goroutine to monitor user inactivity
@@ -206,11 +514,24 @@ func (s *StatusService) StartInactivityMonitor(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			// Set users offline who have been inactive for 30 minutes
-			err := s.SetInactiveUsersOffline(ctx, 30*time.Minute)
-			if err != nil {
-				// Log error but don't stop the monitor
-				fmt.Printf("Error setting inactive users offline: %v\n", err)
+			// Mark idle users away or offline using each workspace's thresholds,
+			// unless presence follows WebSocket connections only
+			if s.presenceMode != PresenceModeConnection {
+				err := s.SweepInactiveUsers(ctx)
+				if err != nil {
+					// Log error but don't stop the monitor
+					fmt.Printf("Error sweeping inactive users: %v\n", err)
+				}
+			}
+
+			// Clear custom statuses that have expired
+			if err := s.ClearExpiredStatuses(ctx); err != nil {
+				fmt.Printf("Error clearing expired statuses: %v\n", err)
+			}
+
+			// End snoozes that have expired
+			if err := s.ClearExpiredDoNotDisturb(ctx); err != nil {
+				fmt.Printf("Error clearing expired do not disturb: %v\n", err)
 			}
 		}
 	}
@@ -253,11 +574,47 @@ func (s *StatusService) toUserStatusResponse(ctx context.Context, userStatus db.
 		response.CustomStatus = userStatus.CustomStatus.String
 	}
 
+	if userStatus.StatusEmoji.Valid {
+		response.StatusEmoji = userStatus.StatusEmoji.String
+	}
+
+	if userStatus.ClearAfter.Valid {
+		response.ClearAfter = &userStatus.ClearAfter.Time
+	}
+
+	// Presence still shows without the z-z icon if Do Not Disturb can't be read
+	_, until, active, err := activeDoNotDisturb(ctx, s.store, userStatus.UserID, userStatus.WorkspaceID, time.Now())
+	if err == nil && active {
+		response.DNDUntil = &until
+	}
+
 	return response, nil
 }
 
+// workspaceDoNotDisturb returns when Do Not Disturb ends for everyone in a
+// workspace who is in it now
+func (s *StatusService) workspaceDoNotDisturb(ctx context.Context, workspaceID int64) map[int64]time.Time {
+	dndUntil := make(map[int64]time.Time)
+
+	rows, err := s.store.ListWorkspaceDoNotDisturb(ctx, workspaceID)
+	if err != nil {
+		// Presence still shows without the z-z icons
+		fmt.Printf("Error listing do not disturb in workspace %d: %v\n", workspaceID, err)
+		return dndUntil
+	}
+
+	now := time.Now()
+	for _, row := range rows {
+		if until, active := doNotDisturbUntil(db.GetDoNotDisturbRow(row), now); active {
+			dndUntil[row.UserID] = until
+		}
+	}
+
+	return dndUntil
+}
+
 // Helper function to convert multiple user statuses to responses
-func (s *StatusService) toUserStatusResponses(ctx context.Context, statuses []db.GetWorkspaceUserStatusesRow) []*UserStatusResponse {
+func (s *StatusService) toUserStatusResponses(ctx context.Context, statuses []db.GetWorkspaceUserStatusesRow, dndUntil map[int64]time.Time) []*UserStatusResponse {
 	responses := make([]*UserStatusResponse, len(statuses))
 	for i, status := range statuses {
 		userResponse := UserResponse{
@@ -279,13 +636,25 @@ func (s *StatusService) toUserStatusResponses(ctx context.Context, statuses []db
 			response.CustomStatus = status.CustomStatus.String
 		}
 
+		if status.StatusEmoji.Valid {
+			response.StatusEmoji = status.StatusEmoji.String
+		}
+
+		if status.ClearAfter.Valid {
+			response.ClearAfter = &status.ClearAfter.Time
+		}
+
+		if until, ok := dndUntil[status.UserID]; ok {
+			response.DNDUntil = &until
+		}
+
 		responses[i] = response
 	}
 	return responses
 }
 
 // Helper function to convert online user statuses to responses
-func (s *StatusService) toOnlineUserStatusResponses(statuses []db.GetOnlineUsersInWorkspaceRow) []*UserStatusResponse {
+func (s *StatusService) toOnlineUserStatusResponses(statuses []db.GetOnlineUsersInWorkspaceRow, dndUntil map[int64]time.Time) []*UserStatusResponse {
 	responses := make([]*UserStatusResponse, len(statuses))
 	for i, status := range statuses {
 		userResponse := UserResponse{
@@ -307,6 +676,18 @@ func (s *StatusService) toOnlineUserStatusResponses(statuses []db.GetOnlineUsers
 			response.CustomStatus = status.CustomStatus.String
 		}
 
+		if status.StatusEmoji.Valid {
+			response.StatusEmoji = status.StatusEmoji.String
+		}
+
+		if status.ClearAfter.Valid {
+			response.ClearAfter = &status.ClearAfter.Time
+		}
+
+		if until, ok := dndUntil[status.UserID]; ok {
+			response.DNDUntil = &until
+		}
+
 		responses[i] = response
 	}
 	return responses
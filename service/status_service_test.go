@@ -0,0 +1,92 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusService_ConnectionPresence(t *testing.T) {
+	const userID, workspaceID = int64(7), int64(3)
+	gracePeriod := 50 * time.Millisecond
+
+	newStatusService := func(store db.Store, mode string) *StatusService {
+		return NewStatusService(store, nil, util.Config{
+			PresenceMode:               mode,
+			PresenceOfflineGracePeriod: gracePeriod,
+		})
+	}
+
+	t.Run("OfflineAfterGracePeriod", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+
+		store.EXPECT().
+			SetUserPresenceOnline(gomock.Any(), gomock.Eq(db.SetUserPresenceOnlineParams{UserID: userID, WorkspaceID: workspaceID})).
+			Times(1).
+			Return(db.UserStatus{UserID: userID, WorkspaceID: workspaceID, Status: "online"}, nil)
+
+		offline := make(chan struct{})
+		store.EXPECT().
+			SetUserPresenceOffline(gomock.Any(), gomock.Eq(db.SetUserPresenceOfflineParams{UserID: userID, WorkspaceID: workspaceID})).
+			Times(1).
+			DoAndReturn(func(_ interface{}, _ db.SetUserPresenceOfflineParams) (db.UserStatus, error) {
+				close(offline)
+				return db.UserStatus{UserID: userID, WorkspaceID: workspaceID, Status: "offline"}, nil
+			})
+
+		statusService := newStatusService(store, PresenceModeHybrid)
+		statusService.UserConnected(userID, workspaceID)
+		statusService.UserDisconnected(userID, workspaceID)
+
+		select {
+		case <-offline:
+		case <-time.After(time.Second):
+			t.Fatal("user was not set offline after the grace period")
+		}
+	})
+
+	t.Run("ReconnectWithinGracePeriod", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+
+		store.EXPECT().
+			SetUserPresenceOnline(gomock.Any(), gomock.Any()).
+			Times(2).
+			Return(db.UserStatus{UserID: userID, WorkspaceID: workspaceID, Status: "online"}, nil)
+
+		store.EXPECT().
+			SetUserPresenceOffline(gomock.Any(), gomock.Any()).
+			Times(0)
+
+		statusService := newStatusService(store, PresenceModeConnection)
+		statusService.UserConnected(userID, workspaceID)
+		statusService.UserDisconnected(userID, workspaceID)
+		statusService.UserConnected(userID, workspaceID)
+
+		time.Sleep(2 * gracePeriod)
+		statusService.mutex.Lock()
+		require.Empty(t, statusService.pendingOffline)
+		statusService.mutex.Unlock()
+	})
+
+	t.Run("ActivityModeIgnoresConnections", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+
+		store.EXPECT().SetUserPresenceOnline(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().SetUserPresenceOffline(gomock.Any(), gomock.Any()).Times(0)
+
+		statusService := newStatusService(store, "")
+		require.Equal(t, PresenceModeActivity, statusService.presenceMode)
+
+		statusService.UserConnected(userID, workspaceID)
+		statusService.UserDisconnected(userID, workspaceID)
+		time.Sleep(2 * gracePeriod)
+	})
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// CreateThreadReply replies in the thread of a message, in the message's
+// channel or direct conversation. A reply to a reply joins the thread the
+// replied-to message is in. The thread's summary on its parent message is
+// updated with the reply and sent to the conversation.
+func (s *MessageService) CreateThreadReply(ctx context.Context, messageID, senderID int64, req CreateThreadReplyRequest) (*MessageResponse, error) {
+	parent, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if err := s.checkMessageAccess(ctx, parent, senderID); err != nil {
+		return nil, err
+	}
+
+	threadID := parent.ID
+	if parent.ThreadID.Valid {
+		threadID = parent.ThreadID.Int64
+	}
+
+	// A direct reply goes to the other person in the conversation
+	var receiverID sql.NullInt64
+	if parent.MessageType == "direct" {
+		receiverID = parent.ReceiverID
+		if parent.ReceiverID.Int64 == senderID {
+			receiverID = sql.NullInt64{Int64: parent.SenderID, Valid: true}
+		}
+	}
+
+	decision, err := s.moderate(ctx, parent.WorkspaceID, senderID, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	message, response, err := s.createMessage(ctx, db.CreateMessageTxParams{
+		ThreadReply: &db.CreateThreadReplyParams{
+			WorkspaceID: parent.WorkspaceID,
+			ChannelID:   parent.ChannelID,
+			SenderID:    senderID,
+			ReceiverID:  receiverID,
+			Content:     decision.Content,
+			ContentType: "text",
+			MessageType: parent.MessageType,
+			ThreadID:    sql.NullInt64{Int64: threadID, Valid: true},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.recordModeration(ctx, decision, message)
+
+	return response, nil
+}
+
+// attachThreadSummaries sets the reply count, last reply time and latest
+// participants of the threads started by the listed messages
+func (s *MessageService) attachThreadSummaries(ctx context.Context, messages []*MessageResponse) error {
+	threadIDs := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		if message.ThreadID == nil {
+			threadIDs = append(threadIDs, message.ID)
+		}
+	}
+	if len(threadIDs) == 0 {
+		return nil
+	}
+
+	threads, err := s.store.ListMessageThreads(ctx, threadIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list threads: %w", err)
+	}
+	if len(threads) == 0 {
+		return nil
+	}
+
+	participants, err := s.threadParticipants(ctx, threads)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int64]db.MessageThread, len(threads))
+	for _, thread := range threads {
+		byID[thread.ThreadID] = thread
+	}
+	for _, message := range messages {
+		if thread, ok := byID[message.ID]; ok && message.ThreadID == nil {
+			setThreadSummary(message, thread, participants)
+		}
+	}
+
+	return nil
+}
+
+// threadParticipants looks up the names and avatars of the people in the
+// threads' summaries, by user ID
+func (s *MessageService) threadParticipants(ctx context.Context, threads []db.MessageThread) (map[int64]ThreadParticipant, error) {
+	var userIDs []int64
+	seen := make(map[int64]bool)
+	for _, thread := range threads {
+		for _, id := range thread.ParticipantIds {
+			if !seen[id] {
+				seen[id] = true
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.store.ListThreadParticipants(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list thread participants: %w", err)
+	}
+
+	participants := make(map[int64]ThreadParticipant, len(rows))
+	for _, row := range rows {
+		participants[row.ID] = ThreadParticipant{
+			ID:         row.ID,
+			FirstName:  row.FirstName,
+			LastName:   row.LastName,
+			AvatarURLs: avatarURLs(row.AvatarKey),
+			Initials:   userInitials(row.FirstName, row.LastName),
+		}
+	}
+	return participants, nil
+}
+
+// setThreadSummary sets a thread's summary on its parent message. Deleted
+// users drop out of the participants.
+func setThreadSummary(message *MessageResponse, thread db.MessageThread, participants map[int64]ThreadParticipant) {
+	message.ReplyCount = thread.ReplyCount
+	message.LastReplyAt = nil
+	if thread.LastReplyAt.Valid {
+		message.LastReplyAt = &thread.LastReplyAt.Time
+	}
+
+	message.ReplyParticipants = nil
+	for _, id := range thread.ParticipantIds {
+		if participant, ok := participants[id]; ok {
+			message.ReplyParticipants = append(message.ReplyParticipants, participant)
+		}
+	}
+}
+
+// refreshThread recounts the summary of the thread a deleted reply was in and
+// sends it to the conversation. The reply is already gone, so failures are
+// only logged.
+func (s *MessageService) refreshThread(ctx context.Context, reply db.GetMessageByIDRow, deletedBy int64) {
+	if !reply.ThreadID.Valid {
+		return
+	}
+
+	thread, err := s.store.RefreshThreadSummary(ctx, reply.ThreadID.Int64)
+	if err != nil {
+		fmt.Printf("Error refreshing summary of thread %d: %v\n", reply.ThreadID.Int64, err)
+		return
+	}
+
+	s.broadcastThreadSummary(ctx, thread, reply.WorkspaceID, reply.ChannelID, reply.SenderID, reply.ReceiverID, deletedBy)
+}
+
+// broadcastThreadSummary tells WebSocket clients in a thread's conversation
+// about the thread's new summary, so they can update its parent message
+func (s *MessageService) broadcastThreadSummary(ctx context.Context, thread db.MessageThread, workspaceID int64, channelID sql.NullInt64, senderID int64, receiverID sql.NullInt64, actorID int64) {
+	if s.hub == nil {
+		return
+	}
+
+	participants, err := s.threadParticipants(ctx, []db.MessageThread{thread})
+	if err != nil {
+		fmt.Printf("Error sending summary of thread %d: %v\n", thread.ThreadID, err)
+		return
+	}
+	summary := &MessageResponse{ID: thread.ThreadID}
+	setThreadSummary(summary, thread, participants)
+
+	wsMessage := &WSMessage{
+		Type: "thread_updated",
+		Data: map[string]interface{}{
+			"message_id":         thread.ThreadID,
+			"reply_count":        summary.ReplyCount,
+			"last_reply_at":      summary.LastReplyAt,
+			"reply_participants": summary.ReplyParticipants,
+		},
+		WorkspaceID: workspaceID,
+		UserID:      actorID,
+		Timestamp:   time.Now(),
+	}
+
+	if channelID.Valid {
+		wsMessage.ChannelID = &channelID.Int64
+		s.hub.BroadcastToChannel(workspaceID, channelID.Int64, wsMessage)
+	} else if receiverID.Valid {
+		s.hub.BroadcastToUser(senderID, wsMessage)
+		s.hub.BroadcastToUser(receiverID.Int64, wsMessage)
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"golang.org/x/text/language"
+)
+
+// Translation providers
+const (
+	TranslationProviderDeepL  = "deepl"
+	TranslationProviderGoogle = "google"
+
+	deepLAPIURL     = "https://api.deepl.com/v2/translate"
+	deepLFreeAPIURL = "https://api-free.deepl.com/v2/translate"
+	googleAPIURL    = "https://translation.googleapis.com/language/translate/v2"
+
+	// Upper bound on the size of a translation API response
+	maxTranslationResponseSize = 1 << 20
+)
+
+// Translation is a machine translation of a text
+type Translation struct {
+	Text           string
+	SourceLanguage string // Detected by the provider, as a BCP 47 tag
+}
+
+// TranslationProvider translates text with an external machine translation API
+type TranslationProvider interface {
+	// Name identifies the provider in API responses
+	Name() string
+	// Translate translates text into the target language and detects its source language
+	Translate(ctx context.Context, text string, target language.Tag) (*Translation, error)
+}
+
+// NewTranslationProvider creates the translation provider set in the configuration.
+// It returns nil when no provider is configured.
+func NewTranslationProvider(config util.Config) (TranslationProvider, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	switch config.TranslationProvider {
+	case "":
+		return nil, nil
+	case TranslationProviderDeepL:
+		if config.TranslationAPIKey == "" {
+			return nil, errors.New("invalid translation configuration: TRANSLATION_API_KEY is required")
+		}
+		apiURL := config.TranslationAPIURL
+		if apiURL == "" {
+			apiURL = deepLAPIURL
+			// DeepL API Free keys end with ":fx" and use their own endpoint
+			if strings.HasSuffix(config.TranslationAPIKey, ":fx") {
+				apiURL = deepLFreeAPIURL
+			}
+		}
+		return &deepLProvider{apiURL: apiURL, apiKey: config.TranslationAPIKey, client: client}, nil
+	case TranslationProviderGoogle:
+		if config.TranslationAPIKey == "" {
+			return nil, errors.New("invalid translation configuration: TRANSLATION_API_KEY is required")
+		}
+		apiURL := config.TranslationAPIURL
+		if apiURL == "" {
+			apiURL = googleAPIURL
+		}
+		return &googleProvider{apiURL: apiURL, apiKey: config.TranslationAPIKey, client: client}, nil
+	default:
+		return nil, fmt.Errorf("invalid translation configuration: unknown provider %q", config.TranslationProvider)
+	}
+}
+
+// deepLProvider translates with the DeepL API
+type deepLProvider struct {
+	apiURL string
+	apiKey string
+	client *http.Client
+}
+
+func (p *deepLProvider) Name() string {
+	return TranslationProviderDeepL
+}
+
+func (p *deepLProvider) Translate(ctx context.Context, text string, target language.Tag) (*Translation, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"text":        []string{text},
+		"target_lang": strings.ToUpper(target.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		Translations []struct {
+			DetectedSourceLanguage string `json:"detected_source_language"`
+			Text                   string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := doTranslationRequest(p.client, req, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Translations) == 0 {
+		return nil, errors.New("failed to translate message: empty response from translation provider")
+	}
+
+	return &Translation{
+		Text:           result.Translations[0].Text,
+		SourceLanguage: normalizeLanguage(result.Translations[0].DetectedSourceLanguage),
+	}, nil
+}
+
+// googleProvider translates with the Google Cloud Translation API
+type googleProvider struct {
+	apiURL string
+	apiKey string
+	client *http.Client
+}
+
+func (p *googleProvider) Name() string {
+	return TranslationProviderGoogle
+}
+
+func (p *googleProvider) Translate(ctx context.Context, text string, target language.Tag) (*Translation, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"q":      []string{text},
+		"target": target.String(),
+		"format": "text",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiURL+"?key="+url.QueryEscape(p.apiKey), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		Data struct {
+			Translations []struct {
+				TranslatedText         string `json:"translatedText"`
+				DetectedSourceLanguage string `json:"detectedSourceLanguage"`
+			} `json:"translations"`
+		} `json:"data"`
+	}
+	if err := doTranslationRequest(p.client, req, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Data.Translations) == 0 {
+		return nil, errors.New("failed to translate message: empty response from translation provider")
+	}
+
+	return &Translation{
+		Text:           result.Data.Translations[0].TranslatedText,
+		SourceLanguage: normalizeLanguage(result.Data.Translations[0].DetectedSourceLanguage),
+	}, nil
+}
+
+// doTranslationRequest sends a request to a translation API and decodes its JSON response
+func doTranslationRequest(client *http.Client, req *http.Request, result interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		// The request URL can carry the API key, so only the underlying error is reported
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to translate message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to translate message: translation provider returned %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxTranslationResponseSize)).Decode(result); err != nil {
+		return fmt.Errorf("failed to translate message: invalid response from translation provider: %w", err)
+	}
+	return nil
+}
+
+// normalizeLanguage returns the canonical BCP 47 form of a language code such as
+// "EN" or "pt-br", or the lowercased code when it cannot be parsed
+func normalizeLanguage(code string) string {
+	tag, err := language.Parse(code)
+	if err != nil {
+		return strings.ToLower(code)
+	}
+	return tag.String()
+}
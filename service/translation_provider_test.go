@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestNewTranslationProvider(t *testing.T) {
+	provider, err := NewTranslationProvider(util.Config{})
+	require.NoError(t, err)
+	require.Nil(t, provider)
+
+	_, err = NewTranslationProvider(util.Config{TranslationProvider: TranslationProviderDeepL})
+	require.Error(t, err)
+
+	_, err = NewTranslationProvider(util.Config{TranslationProvider: "babelfish", TranslationAPIKey: "key"})
+	require.Error(t, err)
+
+	provider, err = NewTranslationProvider(util.Config{TranslationProvider: TranslationProviderDeepL, TranslationAPIKey: "key:fx"})
+	require.NoError(t, err)
+	require.Equal(t, deepLFreeAPIURL, provider.(*deepLProvider).apiURL)
+
+	provider, err = NewTranslationProvider(util.Config{TranslationProvider: TranslationProviderGoogle, TranslationAPIKey: "key"})
+	require.NoError(t, err)
+	require.Equal(t, TranslationProviderGoogle, provider.Name())
+}
+
+func TestGoogleTranslationProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secret", r.URL.Query().Get("key"))
+
+		var body struct {
+			Q      []string `json:"q"`
+			Target string   `json:"target"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, []string{"Hola"}, body.Q)
+		require.Equal(t, "pt-BR", body.Target)
+
+		w.Write([]byte(`{"data":{"translations":[{"translatedText":"Olá","detectedSourceLanguage":"es"}]}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewTranslationProvider(util.Config{
+		TranslationProvider: TranslationProviderGoogle,
+		TranslationAPIKey:   "secret",
+		TranslationAPIURL:   server.URL,
+	})
+	require.NoError(t, err)
+
+	translation, err := provider.Translate(context.Background(), "Hola", language.MustParse("pt-br"))
+	require.NoError(t, err)
+	require.Equal(t, "Olá", translation.Text)
+	require.Equal(t, "es", translation.SourceLanguage)
+}
+
+func TestTranslationProviderErrorHidesAPIKey(t *testing.T) {
+	provider, err := NewTranslationProvider(util.Config{
+		TranslationProvider: TranslationProviderGoogle,
+		TranslationAPIKey:   "secret",
+		TranslationAPIURL:   "http://127.0.0.1:1",
+	})
+	require.NoError(t, err)
+
+	_, err = provider.Translate(context.Background(), "Hola", language.German)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "secret")
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"golang.org/x/text/language"
+)
+
+// TranslationService translates messages with the configured translation provider.
+// Translations are cached per message and language until the message is edited.
+type TranslationService struct {
+	store              db.MessageStore
+	messageService     *MessageService
+	featureFlagService *FeatureFlagService
+	provider           TranslationProvider
+}
+
+// NewTranslationService creates a new translation service. provider may be nil
+// when translation is not configured.
+func NewTranslationService(store db.MessageStore, messageService *MessageService, featureFlagService *FeatureFlagService, provider TranslationProvider) *TranslationService {
+	return &TranslationService{
+		store:              store,
+		messageService:     messageService,
+		featureFlagService: featureFlagService,
+		provider:           provider,
+	}
+}
+
+// TranslateMessage translates a message the user can read into the target language
+func (s *TranslationService) TranslateMessage(ctx context.Context, messageID, userID int64, targetLanguage string) (*MessageTranslationResponse, error) {
+	if s.provider == nil {
+		return nil, errors.New("translation is not configured")
+	}
+
+	target, err := language.Parse(targetLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("invalid language %q", targetLanguage)
+	}
+
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("message not found")
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if message.DeletedAt.Valid {
+		return nil, errors.New("message not found")
+	}
+
+	if err := s.messageService.checkMessageAccess(ctx, message, userID); err != nil {
+		return nil, err
+	}
+
+	enabled, err := s.featureFlagService.IsEnabled(ctx, message.WorkspaceID, FeatureTranslation)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, errors.New("access denied: translation is not enabled for this workspace")
+	}
+
+	contentHash := sha256.Sum256([]byte(message.Content))
+	hash := hex.EncodeToString(contentHash[:])
+
+	cached, err := s.store.GetMessageTranslation(ctx, db.GetMessageTranslationParams{
+		MessageID:      message.ID,
+		TargetLanguage: target.String(),
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get message translation: %w", err)
+	}
+	if err == nil && cached.ContentHash == hash {
+		response := toMessageTranslationResponse(cached)
+		response.Cached = true
+		return response, nil
+	}
+
+	translation, err := s.provider.Translate(ctx, message.Content, target)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.store.UpsertMessageTranslation(ctx, db.UpsertMessageTranslationParams{
+		MessageID:      message.ID,
+		TargetLanguage: target.String(),
+		SourceLanguage: translation.SourceLanguage,
+		ContentHash:    hash,
+		TranslatedText: translation.Text,
+		Provider:       s.provider.Name(),
+	})
+	if err != nil {
+		// The translation is still returned, it is just not cached
+		fmt.Printf("Error caching translation of message %d: %v\n", message.ID, err)
+		return &MessageTranslationResponse{
+			MessageID:      message.ID,
+			SourceLanguage: translation.SourceLanguage,
+			TargetLanguage: target.String(),
+			TranslatedText: translation.Text,
+			Provider:       s.provider.Name(),
+		}, nil
+	}
+
+	return toMessageTranslationResponse(stored), nil
+}
+
+func toMessageTranslationResponse(translation db.MessageTranslation) *MessageTranslationResponse {
+	return &MessageTranslationResponse{
+		MessageID:      translation.MessageID,
+		SourceLanguage: translation.SourceLanguage,
+		TargetLanguage: translation.TargetLanguage,
+		TranslatedText: translation.TranslatedText,
+		Provider:       translation.Provider,
+	}
+}
@@ -15,24 +15,90 @@ type CreateUserRequest struct {
 type LoginUserRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
+	// DeviceID is a random ID the client keeps, so the device is recognized
+	// even when its user agent changes
+	DeviceID string `json:"device_id" binding:"omitempty,max=200"`
 }
 
 // LoginUserResponse represents the response after successful login
 type LoginUserResponse struct {
-	AccessToken string       `json:"access_token"`
-	User        UserResponse `json:"user"`
+	AccessToken   string       `json:"access_token"`
+	User          UserResponse `json:"user"`
+	DeviceID      int64        `json:"device_id,omitempty"`
+	DeviceTrusted bool         `json:"device_trusted"` // Trusted devices skip two-factor authentication
+}
+
+// UserDeviceResponse represents a device a user has signed in from
+type UserDeviceResponse struct {
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	UserAgent     string     `json:"user_agent"`
+	LastIPAddress string     `json:"last_ip_address"`
+	Trusted       bool       `json:"trusted"`
+	TrustedUntil  *time.Time `json:"trusted_until,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastSeenAt    time.Time  `json:"last_seen_at"`
+}
+
+// UpdateUserDeviceRequest represents the request to name or trust a device.
+// Fields that aren't given keep their current value.
+type UpdateUserDeviceRequest struct {
+	Name    *string `json:"name" binding:"omitempty,max=100"`
+	Trusted *bool   `json:"trusted"`
+}
+
+// ChallengeResponse tells a client what to solve before using an endpoint
+// that requires a challenge: a CAPTCHA widget for the site key, or a
+// proof-of-work challenge
+type ChallengeResponse struct {
+	Provider   string     `json:"provider"`
+	SiteKey    string     `json:"site_key,omitempty"`
+	Challenge  string     `json:"challenge,omitempty"`
+	Difficulty int        `json:"difficulty,omitempty"` // Leading zero bits SHA-256("<challenge>:<counter>") needs
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Endpoints  []string   `json:"endpoints"`
+}
+
+// ListLoginEventsRequest represents the request to list login attempts with pagination
+type ListLoginEventsRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// LoginEventResponse represents a login attempt in API responses
+type LoginEventResponse struct {
+	ID            int64     `json:"id"`
+	DeviceID      *int64    `json:"device_id,omitempty"`
+	DeviceName    string    `json:"device_name,omitempty"`
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent"`
+	Success       bool      `json:"success"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // UserResponse represents a user in API responses (without sensitive data)
 type UserResponse struct {
-	ID             int64     `json:"id"`
-	OrganizationID int64     `json:"organization_id"`
-	Email          string    `json:"email"`
-	FirstName      string    `json:"first_name"`
-	LastName       string    `json:"last_name"`
-	WorkspaceID    *int64    `json:"workspace_id,omitempty"`
-	Role           string    `json:"role"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             int64             `json:"id"`
+	OrganizationID int64             `json:"organization_id"`
+	Email          string            `json:"email"`
+	FirstName      string            `json:"first_name"`
+	LastName       string            `json:"last_name"`
+	WorkspaceID    *int64            `json:"workspace_id,omitempty"`
+	Role           string            `json:"role"`
+	CustomRoleID   *int64            `json:"custom_role_id,omitempty"`
+	Handle         string            `json:"handle,omitempty"`
+	Title          string            `json:"title,omitempty"`
+	Pronouns       string            `json:"pronouns,omitempty"`
+	Phone          string            `json:"phone,omitempty"`
+	Timezone       string            `json:"timezone,omitempty"`
+	AvatarURLs     map[string]string `json:"avatar_urls,omitempty"` // Keyed by size in pixels
+	Initials       string            `json:"initials,omitempty"`    // Shown when there's no avatar
+	EmailVerified  bool              `json:"email_verified"`
+	CreatedAt      time.Time         `json:"created_at"`
+	// Set when emails to the user stopped after a bounce or spam complaint,
+	// only shown to workspace admins
+	EmailSuppression *EmailSuppressionResponse `json:"email_suppression,omitempty"`
 }
 
 // CreateOrganizationRequest represents the request to create a new organization
@@ -40,10 +106,17 @@ type CreateOrganizationRequest struct {
 	Name string `json:"name" binding:"required"`
 }
 
-// UpdateUserProfileRequest represents the request to update user profile
+// UpdateUserProfileRequest represents the request to update user profile.
+// Profile details that are left out keep their current value, an empty
+// string clears them.
 type UpdateUserProfileRequest struct {
-	FirstName string `json:"first_name" binding:"required"`
-	LastName  string `json:"last_name" binding:"required"`
+	FirstName string  `json:"first_name" binding:"required"`
+	LastName  string  `json:"last_name" binding:"required"`
+	Handle    *string `json:"handle" binding:"omitempty,max=50"` // Unique in the organization, e.g. "ada.lovelace"
+	Title     *string `json:"title" binding:"omitempty,max=100"`
+	Pronouns  *string `json:"pronouns" binding:"omitempty,max=50"`
+	Phone     *string `json:"phone" binding:"omitempty,max=32"`
+	Timezone  *string `json:"timezone" binding:"omitempty,max=64"` // IANA name, e.g. "Europe/Berlin"
 }
 
 // ChangePasswordRequest represents the request to change user password
@@ -63,6 +136,8 @@ type WorkspaceResponse struct {
 	OrganizationID int64     `json:"organization_id"`
 	Name           string    `json:"name"`
 	CreatedAt      time.Time `json:"created_at"`
+	// Banners are the workspace's active announcements, only shown to its members
+	Banners []WorkspaceBannerResponse `json:"banners,omitempty"`
 }
 
 // CreateChannelRequest represents the request to create a new channel
@@ -79,6 +154,22 @@ type ChannelResponse struct {
 	IsPrivate   bool      `json:"is_private"`
 	CreatedBy   int64     `json:"created_by"`
 	CreatedAt   time.Time `json:"created_at"`
+	// Users typing in the channel when it was opened
+	Typing []TypingUserResponse `json:"typing,omitempty"`
+}
+
+// UserChannelResponse is a channel a user can see and whether they joined it
+type UserChannelResponse struct {
+	ChannelResponse
+	IsMember bool `json:"is_member"`
+}
+
+// TypingUserResponse is a user who is typing in a channel
+type TypingUserResponse struct {
+	UserID    int64        `json:"user_id"`
+	User      UserResponse `json:"user"`
+	StartedAt time.Time    `json:"started_at"`
+	ExpiresAt time.Time    `json:"expires_at"`
 }
 
 // UpdateUserRoleRequest represents the request to update a user's role
@@ -95,12 +186,18 @@ type ListChannelsRequest struct {
 // SendChannelMessageRequest represents the request to send a channel message
 type SendChannelMessageRequest struct {
 	Content string `json:"content" binding:"required,max=4000"`
+	// Ask the channel's members to acknowledge the message, reminding those
+	// who haven't after the reminder delay
+	RequireAcknowledgment bool `json:"require_acknowledgment"`
+	// Minutes before the reminder, defaulting to the server's reminder delay
+	AcknowledgmentReminderMinutes int32 `json:"acknowledgment_reminder_minutes" binding:"omitempty,min=5,max=43200"`
 }
 
 // SendDirectMessageRequest represents the request to send a direct message
 type SendDirectMessageRequest struct {
 	ReceiverID int64  `json:"receiver_id" binding:"required,min=1"`
 	Content    string `json:"content" binding:"required,max=4000"`
+	Urgent     bool   `json:"urgent"` // Ask whether to notify a recipient in Do Not Disturb anyway
 }
 
 // EditMessageRequest represents the request to edit a message
@@ -108,47 +205,122 @@ type EditMessageRequest struct {
 	Content string `json:"content" binding:"required,max=4000"`
 }
 
+// CreateThreadReplyRequest represents the request to reply in a message's thread
+type CreateThreadReplyRequest struct {
+	Content string `json:"content" binding:"required,max=4000"`
+}
+
+// ThreadParticipant represents someone who replied in a thread, as shown on
+// the thread's parent message
+type ThreadParticipant struct {
+	ID         int64             `json:"id"`
+	FirstName  string            `json:"first_name"`
+	LastName   string            `json:"last_name"`
+	AvatarURLs map[string]string `json:"avatar_urls,omitempty"`
+	Initials   string            `json:"initials,omitempty"`
+}
+
 // CreateChannelMessageRequest represents the request to create a channel message
 type CreateChannelMessageRequest struct {
-	WorkspaceID int64  `json:"workspace_id" binding:"required"`
-	ChannelID   int64  `json:"channel_id" binding:"required"`
-	Content     string `json:"content" binding:"max=4000"`
-	ContentType string `json:"content_type" binding:"required,oneof=text file image system"`
-	FileID      *int64 `json:"file_id,omitempty"`
+	WorkspaceID int64   `json:"workspace_id" binding:"required"`
+	ChannelID   int64   `json:"channel_id" binding:"required"`
+	Content     string  `json:"content" binding:"max=4000"`
+	ContentType string  `json:"content_type" binding:"required,oneof=text file image system"`
+	FileID      *int64  `json:"file_id,omitempty"`
+	FileIDs     []int64 `json:"file_ids,omitempty"`
 }
 
 // CreateDirectMessageRequest represents the request to create a direct message
 type CreateDirectMessageRequest struct {
-	WorkspaceID int64  `json:"workspace_id" binding:"required"`
-	ReceiverID  int64  `json:"receiver_id" binding:"required"`
-	Content     string `json:"content" binding:"max=4000"`
-	ContentType string `json:"content_type" binding:"required,oneof=text file image system"`
-	FileID      *int64 `json:"file_id,omitempty"`
+	WorkspaceID int64   `json:"workspace_id" binding:"required"`
+	ReceiverID  int64   `json:"receiver_id" binding:"required"`
+	Content     string  `json:"content" binding:"max=4000"`
+	ContentType string  `json:"content_type" binding:"required,oneof=text file image system"`
+	FileID      *int64  `json:"file_id,omitempty"`
+	FileIDs     []int64 `json:"file_ids,omitempty"`
 }
 
 // MessageResponse represents a message in API responses
 type MessageResponse struct {
-	ID          int64           `json:"id"`
-	WorkspaceID int64           `json:"workspace_id"`
-	ChannelID   *int64          `json:"channel_id,omitempty"`
-	SenderID    int64           `json:"sender_id"`
-	ReceiverID  *int64          `json:"receiver_id,omitempty"`
-	Content     string          `json:"content"`
-	ContentType string          `json:"content_type"` // "text", "file", "image", "system"
-	MessageType string          `json:"message_type"`
-	ThreadID    *int64          `json:"thread_id,omitempty"`
-	Sender      UserResponse    `json:"sender"`
-	Files       []*FileResponse `json:"files,omitempty"` // Attached files
-	EditedAt    *time.Time      `json:"edited_at,omitempty"`
-	CreatedAt   time.Time       `json:"created_at"`
+	ID          int64             `json:"id"`
+	WorkspaceID int64             `json:"workspace_id"`
+	ChannelID   *int64            `json:"channel_id,omitempty"`
+	SenderID    int64             `json:"sender_id"`
+	ReceiverID  *int64            `json:"receiver_id,omitempty"`
+	Content     string            `json:"content"`
+	ContentType string            `json:"content_type"` // "text", "file", "image", "system"
+	MessageType string            `json:"message_type"`
+	ThreadID    *int64            `json:"thread_id,omitempty"`
+	Sender      UserResponse      `json:"sender"`
+	Files       []*FileResponse   `json:"files,omitempty"`    // Attached files
+	Mentions    []int64           `json:"mentions,omitempty"` // Workspace members mentioned as <@user_id>
+	Reactions   []ReactionSummary `json:"reactions,omitempty"`
+	EditedAt    *time.Time        `json:"edited_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Permalink   string            `json:"permalink,omitempty"`
+	// Until when the author may edit or delete the message, unset when the
+	// workspace has no limit. Workspace admins aren't limited.
+	EditableUntil  *time.Time `json:"editable_until,omitempty"`
+	DeletableUntil *time.Time `json:"deletable_until,omitempty"`
+	// Set on tombstones left in history by deleted messages, which have no content
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy *int64     `json:"deleted_by,omitempty"`
+	// Set on tombstones of messages taken down by a moderator, stating the
+	// policy the message violated
+	DeletionNotice string `json:"deletion_notice,omitempty"`
+	// Set on urgent direct messages to a recipient in Do Not Disturb
+	RecipientDoNotDisturb *RecipientDoNotDisturbNotice `json:"recipient_do_not_disturb,omitempty"`
+	// Set on messages read from the message archive, which can no longer be
+	// edited, deleted or reacted to
+	Archived bool `json:"archived,omitempty"`
+	// Set on messages with replies in channel listings, summarizing the
+	// thread without reading it
+	ReplyCount        int32               `json:"reply_count,omitempty"`
+	LastReplyAt       *time.Time          `json:"last_reply_at,omitempty"`
+	ReplyParticipants []ThreadParticipant `json:"reply_participants,omitempty"` // Latest repliers first, at most five
+	// Set on messages asking their channel's members to acknowledge them
+	Acknowledgment *MessageAcknowledgmentStatus `json:"acknowledgment,omitempty"`
 	// WebSocket metadata (for Phase 5)
 	EventType string `json:"event_type,omitempty"` // "message_sent", "message_edited", etc.
 }
 
+// MessageAcknowledgmentStatus represents the acknowledgment a message asks
+// for, as seen by the user reading it
+type MessageAcknowledgmentStatus struct {
+	Acknowledged      bool       `json:"acknowledged"`
+	AcknowledgedAt    *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedCount int64      `json:"acknowledged_count"`
+	RemindAt          time.Time  `json:"remind_at"` // When members who haven't acknowledged are reminded
+}
+
+// AcknowledgmentRecipient represents a channel member asked to acknowledge a
+// message
+type AcknowledgmentRecipient struct {
+	ID             int64      `json:"id"`
+	FirstName      string     `json:"first_name"`
+	LastName       string     `json:"last_name"`
+	Email          string     `json:"email"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+}
+
+// MessageAcknowledgmentsResponse represents who has and hasn't acknowledged
+// a message, as shown to its author
+type MessageAcknowledgmentsResponse struct {
+	MessageID         int64                     `json:"message_id"`
+	RemindAt          time.Time                 `json:"remind_at"`
+	RemindedAt        *time.Time                `json:"reminded_at,omitempty"`
+	AcknowledgedCount int                       `json:"acknowledged_count"`
+	PendingCount      int                       `json:"pending_count"`
+	Acknowledged      []AcknowledgmentRecipient `json:"acknowledged"`
+	Pending           []AcknowledgmentRecipient `json:"pending"`
+}
+
 // UpdateUserStatusRequest represents the request to update user status
 type UpdateUserStatusRequest struct {
-	Status       string `json:"status" binding:"required,oneof=online away busy offline"`
-	CustomStatus string `json:"custom_status" binding:"max=100"`
+	Status       string     `json:"status" binding:"required,oneof=online away busy offline"`
+	CustomStatus string     `json:"custom_status" binding:"max=100"`
+	StatusEmoji  string     `json:"status_emoji" binding:"max=64"`
+	ClearAfter   *time.Time `json:"clear_after"` // Custom status and emoji are cleared at this time
 }
 
 // UserStatusResponse represents user status in API responses
@@ -157,6 +329,9 @@ type UserStatusResponse struct {
 	WorkspaceID  int64        `json:"workspace_id"`
 	Status       string       `json:"status"`
 	CustomStatus string       `json:"custom_status,omitempty"`
+	StatusEmoji  string       `json:"status_emoji,omitempty"`
+	ClearAfter   *time.Time   `json:"clear_after,omitempty"`
+	DNDUntil     *time.Time   `json:"dnd_until,omitempty"` // Set while notifications are paused, e.g. snoozed; clients show a z-z icon
 	LastSeenAt   time.Time    `json:"last_seen_at"`
 	User         UserResponse `json:"user"`
 	// WebSocket metadata
@@ -169,6 +344,15 @@ type GetMessagesRequest struct {
 	Offset int32 `form:"offset" binding:"omitempty,min=0"`
 }
 
+// GetArchivedMessagesRequest represents a page of archived history, optionally
+// limited to messages sent after and before the given times
+type GetArchivedMessagesRequest struct {
+	Before *time.Time `form:"before"`
+	After  *time.Time `form:"after"`
+	Limit  int32      `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32      `form:"offset" binding:"omitempty,min=0"`
+}
+
 // AddChannelMemberRequest represents the request to add a member to a channel
 type AddChannelMemberRequest struct {
 	UserID int64  `json:"user_id" binding:"required,min=1"`
@@ -191,6 +375,7 @@ type WebSocketHub interface {
 	BroadcastToWorkspace(workspaceID int64, message *WSMessage)
 	BroadcastToChannel(workspaceID, channelID int64, message *WSMessage)
 	BroadcastToUser(userID int64, message *WSMessage)
+	DisconnectWorkspace(workspaceID int64)
 }
 
 // WSMessage represents a WebSocket message
@@ -201,4 +386,1153 @@ type WSMessage struct {
 	ChannelID   *int64      `json:"channel_id,omitempty"`
 	UserID      int64       `json:"user_id"`
 	Timestamp   time.Time   `json:"timestamp"`
+	// EventID identifies events delivered through the outbox, which can
+	// arrive more than once
+	EventID int64 `json:"event_id,omitempty"`
+	// ExcludeConnectionID keeps an event sent to a user from echoing back to
+	// the connection that caused it
+	ExcludeConnectionID string `json:"-"`
+}
+
+// SearchMessagesRequest represents the request to search messages in a workspace.
+// The query supports free text combined with filters such as from:, in:, before:,
+// after:, on:, has:file, has:link and is:thread.
+type SearchMessagesRequest struct {
+	Query  string `form:"q" binding:"required,max=500"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32  `form:"offset" binding:"omitempty,min=0"`
+}
+
+// SavedSearchRequest represents the request to create or update a saved search
+type SavedSearchRequest struct {
+	Name  string `json:"name" binding:"required,min=1,max=100"`
+	Query string `json:"query" binding:"required,max=500"`
+}
+
+// SavedSearchResponse represents a saved search in API responses
+type SavedSearchResponse struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Query       string    `json:"query"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UnifiedSearchRequest represents the request to search a workspace from a single search box
+type UnifiedSearchRequest struct {
+	Query string `form:"q" binding:"required,max=500"`
+	Limit int32  `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// MessageSearchResults is the messages group of a unified search
+type MessageSearchResults struct {
+	Count   int64              `json:"count"`
+	Results []*MessageResponse `json:"results"`
+}
+
+// FileSearchResults is the files group of a unified search
+type FileSearchResults struct {
+	Count   int64           `json:"count"`
+	Results []*FileResponse `json:"results"`
+}
+
+// ChannelSearchResults is the channels group of a unified search
+type ChannelSearchResults struct {
+	Count   int64             `json:"count"`
+	Results []ChannelResponse `json:"results"`
+}
+
+// PeopleSearchResults is the people group of a unified search
+type PeopleSearchResults struct {
+	Count   int64          `json:"count"`
+	Results []UserResponse `json:"results"`
+}
+
+// UnifiedSearchResponse groups search results by kind. Each count is the total
+// number of matches, which may exceed the number of results returned.
+type UnifiedSearchResponse struct {
+	Query    SearchQuery          `json:"query"`
+	Messages MessageSearchResults `json:"messages"`
+	Files    FileSearchResults    `json:"files"`
+	Channels ChannelSearchResults `json:"channels"`
+	People   PeopleSearchResults  `json:"people"`
+}
+
+// MessageContextRequest represents the request to fetch the messages around a message
+type MessageContextRequest struct {
+	Before *int32 `form:"before" binding:"omitempty,min=0,max=100"`
+	After  *int32 `form:"after" binding:"omitempty,min=0,max=100"`
+}
+
+// MessageContextResponse represents a message with the surrounding messages of its
+// conversation, both in chronological order
+type MessageContextResponse struct {
+	Message       *MessageResponse   `json:"message"`
+	Permalink     string             `json:"permalink"`
+	Before        []*MessageResponse `json:"before"`
+	After         []*MessageResponse `json:"after"`
+	HasMoreBefore bool               `json:"has_more_before"`
+	HasMoreAfter  bool               `json:"has_more_after"`
+}
+
+// ListMentionsRequest represents the request to list the current user's mentions
+type ListMentionsRequest struct {
+	Limit      int32 `form:"limit" binding:"omitempty,min=1,max=50"`
+	Offset     int32 `form:"offset" binding:"omitempty,min=0"`
+	UnreadOnly bool  `form:"unread_only"`
+}
+
+// MentionResponse represents a message mentioning the user, with the messages
+// leading up to it in chronological order
+type MentionResponse struct {
+	Message     *MessageResponse   `json:"message"`
+	ChannelName string             `json:"channel_name,omitempty"`
+	Permalink   string             `json:"permalink"`
+	Context     []*MessageResponse `json:"context"`
+	IsRead      bool               `json:"is_read"`
+	ReadAt      *time.Time         `json:"read_at,omitempty"`
+}
+
+// MentionsResponse represents a page of the mentions feed
+type MentionsResponse struct {
+	Mentions    []*MentionResponse `json:"mentions"`
+	UnreadCount int64              `json:"unread_count"`
+}
+
+// MentionReadResponse represents the result of marking a mention as read
+type MentionReadResponse struct {
+	MessageID   int64     `json:"message_id"`
+	ReadAt      time.Time `json:"read_at"`
+	UnreadCount int64     `json:"unread_count"`
+}
+
+// MarkChannelReadRequest represents the request to mark a channel as read
+type MarkChannelReadRequest struct {
+	MessageID int64 `json:"message_id" binding:"required,min=1"`
+}
+
+// ChannelReadStateResponse represents the user's read position in a channel
+type ChannelReadStateResponse struct {
+	ChannelID         int64     `json:"channel_id"`
+	LastReadMessageID *int64    `json:"last_read_message_id,omitempty"`
+	LastReadAt        time.Time `json:"last_read_at"`
+}
+
+// DirectMessageReadStateResponse represents the user's read position in a direct message conversation
+type DirectMessageReadStateResponse struct {
+	OtherUserID       int64     `json:"other_user_id"`
+	LastReadMessageID *int64    `json:"last_read_message_id,omitempty"`
+	LastReadAt        time.Time `json:"last_read_at"`
+}
+
+// ThreadReadStateResponse represents the user's read position in a followed thread
+type ThreadReadStateResponse struct {
+	ThreadID          int64     `json:"thread_id"`
+	LastReadMessageID *int64    `json:"last_read_message_id,omitempty"`
+	LastReadAt        time.Time `json:"last_read_at"`
+}
+
+// ReadStateEvent is the payload of a read_state_updated event. Kind is "channel",
+// "direct" or "thread" and says which of the IDs is set.
+type ReadStateEvent struct {
+	Kind              string    `json:"kind"`
+	ChannelID         *int64    `json:"channel_id,omitempty"`
+	OtherUserID       *int64    `json:"other_user_id,omitempty"`
+	ThreadID          *int64    `json:"thread_id,omitempty"`
+	LastReadMessageID *int64    `json:"last_read_message_id,omitempty"`
+	LastReadAt        time.Time `json:"last_read_at"`
+}
+
+// WorkspaceReadResponse represents the result of marking a whole workspace as read
+type WorkspaceReadResponse struct {
+	WorkspaceID         int64     `json:"workspace_id"`
+	ChannelsMarked      int64     `json:"channels_marked"`
+	ConversationsMarked int64     `json:"conversations_marked"`
+	ThreadsMarked       int64     `json:"threads_marked"`
+	ReadAt              time.Time `json:"read_at"`
+}
+
+// ChannelUnreadCount represents the unread messages in a channel
+type ChannelUnreadCount struct {
+	ChannelID         int64  `json:"channel_id"`
+	LastReadMessageID *int64 `json:"last_read_message_id,omitempty"`
+	UnreadCount       int64  `json:"unread_count"`
+}
+
+// DirectMessageUnreadCount represents the unread messages in a direct message conversation
+type DirectMessageUnreadCount struct {
+	OtherUserID       int64  `json:"other_user_id"`
+	LastReadMessageID *int64 `json:"last_read_message_id,omitempty"`
+	UnreadCount       int64  `json:"unread_count"`
+}
+
+// ThreadUnreadCount represents the unread replies in a followed thread
+type ThreadUnreadCount struct {
+	ThreadID          int64  `json:"thread_id"`
+	LastReadMessageID *int64 `json:"last_read_message_id,omitempty"`
+	UnreadCount       int64  `json:"unread_count"`
+}
+
+// UnreadCountsResponse represents the user's unread messages across a workspace.
+// Only conversations and threads with unread messages are listed.
+type UnreadCountsResponse struct {
+	WorkspaceID    int64                      `json:"workspace_id"`
+	TotalUnread    int64                      `json:"total_unread"`
+	Channels       []ChannelUnreadCount       `json:"channels"`
+	DirectMessages []DirectMessageUnreadCount `json:"direct_messages"`
+	Threads        []ThreadUnreadCount        `json:"threads"`
+}
+
+// UpdatePresenceSettingsRequest represents the request to update a workspace's inactivity thresholds.
+// A missing threshold falls back to the server default.
+type UpdatePresenceSettingsRequest struct {
+	AwayAfterMinutes    *int32 `json:"away_after_minutes" binding:"omitempty,min=1,max=1440"`
+	OfflineAfterMinutes *int32 `json:"offline_after_minutes" binding:"omitempty,min=1,max=10080"`
+}
+
+// PresenceSettingsResponse represents a workspace's inactivity thresholds.
+// The effective values include server defaults for thresholds the workspace has not set.
+type PresenceSettingsResponse struct {
+	WorkspaceID                  int64  `json:"workspace_id"`
+	AwayAfterMinutes             *int32 `json:"away_after_minutes"`
+	OfflineAfterMinutes          *int32 `json:"offline_after_minutes"`
+	EffectiveAwayAfterMinutes    *int32 `json:"effective_away_after_minutes"`
+	EffectiveOfflineAfterMinutes int32  `json:"effective_offline_after_minutes"`
+}
+
+// SetCalendarIntegrationRequest represents the request to connect an ICS calendar feed.
+// Enabled defaults to true and turns the automatic meeting status on or off.
+type SetCalendarIntegrationRequest struct {
+	ICSURL  string `json:"ics_url" binding:"required,max=2048"`
+	Enabled *bool  `json:"enabled"`
+}
+
+// CalendarBusyBlockResponse represents a busy block ingested from a calendar
+type CalendarBusyBlockResponse struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+// CalendarIntegrationResponse represents a user's calendar integration in API responses
+type CalendarIntegrationResponse struct {
+	ID            int64                       `json:"id"`
+	WorkspaceID   int64                       `json:"workspace_id"`
+	Provider      string                      `json:"provider"`
+	ICSURL        string                      `json:"ics_url"`
+	Enabled       bool                        `json:"enabled"`
+	LastSyncedAt  *time.Time                  `json:"last_synced_at,omitempty"`
+	LastSyncError string                      `json:"last_sync_error,omitempty"`
+	BusyBlocks    []CalendarBusyBlockResponse `json:"busy_blocks"`
+	CreatedAt     time.Time                   `json:"created_at"`
+	UpdatedAt     time.Time                   `json:"updated_at"`
+}
+
+// SetOutOfOfficeRequest represents the request to set an out-of-office window.
+// StartsAt defaults to now.
+type SetOutOfOfficeRequest struct {
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   time.Time  `json:"ends_at" binding:"required"`
+	Message  string     `json:"message" binding:"required,min=1,max=1000"`
+}
+
+// OutOfOfficeResponse represents a user's out-of-office window in API responses
+type OutOfOfficeResponse struct {
+	UserID      int64     `json:"user_id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Message     string    `json:"message"`
+	Active      bool      `json:"active"`
+}
+
+// SaveDraftRequest represents the request to save the unsent message a user is
+// writing in a channel or to another user, optionally in a thread. Saving
+// empty content deletes the draft. ConnectionID is the ID of the user's
+// WebSocket connection making the change, which isn't sent the update.
+type SaveDraftRequest struct {
+	ChannelID    *int64 `json:"channel_id" binding:"omitempty,min=1"`
+	ReceiverID   *int64 `json:"receiver_id" binding:"omitempty,min=1"`
+	ThreadID     *int64 `json:"thread_id" binding:"omitempty,min=1"`
+	Content      string `json:"content" binding:"max=4000"`
+	ConnectionID string `json:"connection_id"`
+}
+
+// DraftResponse represents a message draft in API responses and draft events
+type DraftResponse struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	ChannelID   *int64    `json:"channel_id,omitempty"`
+	ReceiverID  *int64    `json:"receiver_id,omitempty"`
+	ThreadID    *int64    `json:"thread_id,omitempty"`
+	Content     string    `json:"content"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DoNotDisturbSchedule turns Do Not Disturb on at the same time every day, or
+// on the given days, in the user's timezone. A schedule that ends before it
+// starts runs past midnight.
+type DoNotDisturbSchedule struct {
+	Start string   `json:"start" binding:"required"` // "22:00"
+	End   string   `json:"end" binding:"required"`   // "08:00"
+	Days  []string `json:"days,omitempty"`           // "mon" to "sun", the days the schedule starts on; empty means every day
+}
+
+// SetDoNotDisturbRequest represents the request to change Do Not Disturb.
+// A missing dnd_until turns Do Not Disturb off; a missing schedule keeps the
+// current one unless clear_schedule is set.
+type SetDoNotDisturbRequest struct {
+	DNDUntil            *time.Time            `json:"dnd_until"`
+	AllowUrgentOverride *bool                 `json:"allow_urgent_override"`
+	Schedule            *DoNotDisturbSchedule `json:"schedule"`
+	ClearSchedule       bool                  `json:"clear_schedule"`
+}
+
+// DoNotDisturbResponse represents a user's Do Not Disturb state in API responses
+type DoNotDisturbResponse struct {
+	UserID              int64                 `json:"user_id"`
+	WorkspaceID         int64                 `json:"workspace_id"`
+	DNDUntil            *time.Time            `json:"dnd_until,omitempty"`
+	Schedule            *DoNotDisturbSchedule `json:"schedule,omitempty"`
+	Timezone            string                `json:"timezone,omitempty"`
+	Active              bool                  `json:"active"`
+	ActiveUntil         *time.Time            `json:"active_until,omitempty"`
+	AllowUrgentOverride bool                  `json:"allow_urgent_override"`
+}
+
+// RecipientDoNotDisturbNotice tells the sender of an urgent message that the recipient
+// is in Do Not Disturb. When CanNotifyAnyway is set the sender may confirm the escalation.
+type RecipientDoNotDisturbNotice struct {
+	DNDUntil        time.Time `json:"dnd_until"`
+	CanNotifyAnyway bool      `json:"can_notify_anyway"`
+}
+
+// UpdateNotificationPreferenceRequest represents the request to change a
+// notification preference. Fields that are left out keep their current value.
+type UpdateNotificationPreferenceRequest struct {
+	Level        *string `json:"level" binding:"omitempty,oneof=all mentions nothing"`
+	EmailEnabled *bool   `json:"email_enabled"`
+	PushEnabled  *bool   `json:"push_enabled"`
+}
+
+// NotificationPreferenceResponse represents a workspace-wide or channel
+// notification preference in API responses
+type NotificationPreferenceResponse struct {
+	WorkspaceID  int64  `json:"workspace_id"`
+	ChannelID    *int64 `json:"channel_id,omitempty"`
+	Level        string `json:"level"`
+	EmailEnabled bool   `json:"email_enabled"`
+	PushEnabled  bool   `json:"push_enabled"`
+	// Inherited is set when there is no preference of its own, so the
+	// workspace-wide preference or the defaults apply
+	Inherited bool `json:"inherited"`
+	// OverridesChannelDefault is set when an admin picked a default for the
+	// channel and the preference differs from it
+	OverridesChannelDefault bool       `json:"overrides_channel_default"`
+	UpdatedAt               *time.Time `json:"updated_at,omitempty"`
+}
+
+// NotificationPreferencesResponse lists a user's notification preferences in a workspace
+type NotificationPreferencesResponse struct {
+	Workspace NotificationPreferenceResponse   `json:"workspace"`
+	Channels  []NotificationPreferenceResponse `json:"channels"`
+}
+
+// SetChannelNotificationDefaultRequest represents the request to pick the
+// notification preference a channel's members start with. Email and push
+// are on unless turned off.
+type SetChannelNotificationDefaultRequest struct {
+	Level        string `json:"level" binding:"required,oneof=all mentions nothing"`
+	EmailEnabled *bool  `json:"email_enabled"`
+	PushEnabled  *bool  `json:"push_enabled"`
+}
+
+// ChannelNotificationDefaultResponse represents a channel's default
+// notification preference in API responses
+type ChannelNotificationDefaultResponse struct {
+	ChannelID    int64     `json:"channel_id"`
+	WorkspaceID  int64     `json:"workspace_id"`
+	Level        string    `json:"level"`
+	EmailEnabled bool      `json:"email_enabled"`
+	PushEnabled  bool      `json:"push_enabled"`
+	UpdatedBy    *int64    `json:"updated_by,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ApplyChannelNotificationDefaultResponse reports how many members' channel
+// preferences were set to the channel's default
+type ApplyChannelNotificationDefaultResponse struct {
+	ChannelID int64 `json:"channel_id"`
+	Applied   int64 `json:"applied"`
+}
+
+// SnoozeNotificationsRequest represents the request to pause notifications for a while
+type SnoozeNotificationsRequest struct {
+	DurationMinutes int32 `json:"duration_minutes" binding:"required,min=1,max=10080"` // e.g. 120 for two hours, at most a week
+}
+
+// WorkspaceBannerRequest represents the request to publish or change a
+// workspace announcement banner. Without an expiry the banner stays up until
+// it is deleted.
+type WorkspaceBannerRequest struct {
+	Text      string     `json:"text" binding:"required,max=500"`
+	Severity  string     `json:"severity" binding:"omitempty,oneof=info warning critical"` // defaults to info
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// WorkspaceBannerResponse represents a workspace announcement banner in API responses
+type WorkspaceBannerResponse struct {
+	ID          int64      `json:"id"`
+	WorkspaceID int64      `json:"workspace_id"`
+	Text        string     `json:"text"`
+	Severity    string     `json:"severity"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	CreatedBy   *int64     `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// BannerEvent is the payload of banner_updated WebSocket messages. Action is
+// created, updated or deleted; Banner is left out when the banner was deleted.
+type BannerEvent struct {
+	Action   string                   `json:"action"`
+	BannerID int64                    `json:"banner_id"`
+	Banner   *WorkspaceBannerResponse `json:"banner,omitempty"`
+	UserID   int64                    `json:"user_id"`
+}
+
+// ExternalDMPolicyResponse represents whether an organization's members can
+// exchange direct messages with people in other organizations
+type ExternalDMPolicyResponse struct {
+	OrganizationID int64 `json:"organization_id"`
+	// open, restricted to AllowedDomains, or disabled
+	Policy         string     `json:"policy"`
+	AllowedDomains []string   `json:"allowed_domains"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateExternalDMPolicyRequest represents the request to change an
+// organization's external DM policy. AllowedDomains are email domains and are
+// only used by the restricted policy.
+type UpdateExternalDMPolicyRequest struct {
+	Policy         string   `json:"policy" binding:"required,oneof=open restricted disabled"`
+	AllowedDomains []string `json:"allowed_domains" binding:"max=100,dive,max=253"`
+}
+
+// CreateExternalDMRequestRequest represents the request to start a direct
+// conversation with someone in another organization
+type CreateExternalDMRequestRequest struct {
+	Email   string `json:"email" binding:"required,email"`
+	Message string `json:"message" binding:"max=500"` // introduces the sender to the recipient
+}
+
+// ExternalDMUserResponse is what people in another organization see of a user
+type ExternalDMUserResponse struct {
+	ID             int64  `json:"id"`
+	OrganizationID int64  `json:"organization_id"`
+	Email          string `json:"email"`
+	FirstName      string `json:"first_name"`
+	LastName       string `json:"last_name"`
+}
+
+// ExternalDMRequestResponse represents a request for a direct conversation
+// between organizations in API responses. An accepted request stands for the
+// conversation itself. Incoming is set when the viewer is the recipient.
+type ExternalDMRequestResponse struct {
+	ID          int64                  `json:"id"`
+	WorkspaceID int64                  `json:"workspace_id"`
+	Sender      ExternalDMUserResponse `json:"sender"`
+	Recipient   ExternalDMUserResponse `json:"recipient"`
+	Message     string                 `json:"message,omitempty"`
+	Status      string                 `json:"status"`
+	Incoming    bool                   `json:"incoming"`
+	CreatedAt   time.Time              `json:"created_at"`
+	RespondedAt *time.Time             `json:"responded_at,omitempty"`
+}
+
+// SendExternalDMRequest represents a message in a conversation with someone
+// in another organization. Only text can be sent.
+type SendExternalDMRequest struct {
+	Content string `json:"content" binding:"required,max=4000"`
+}
+
+// SetFeatureFlagRequest represents the request to turn a feature on or off for a workspace
+type SetFeatureFlagRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// FeatureFlagResponse represents a feature flag's state for a workspace in API responses.
+// Overridden is set when the workspace does not use the flag's default.
+type FeatureFlagResponse struct {
+	Flag        string `json:"flag"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Default     bool   `json:"default"`
+	Overridden  bool   `json:"overridden"`
+}
+
+// TranslateMessageRequest represents the request to translate a message
+type TranslateMessageRequest struct {
+	Lang string `form:"lang" binding:"required"` // BCP 47 language tag, e.g. "de" or "pt-BR"
+}
+
+// MessageTranslationResponse represents a machine translation of a message in API
+// responses. SourceLanguage is detected by the translation provider.
+type MessageTranslationResponse struct {
+	MessageID      int64  `json:"message_id"`
+	SourceLanguage string `json:"source_language"`
+	TargetLanguage string `json:"target_language"`
+	TranslatedText string `json:"translated_text"`
+	Provider       string `json:"provider"`
+	Cached         bool   `json:"cached"`
+}
+
+// CreateModerationWordRequest represents the request to add a word or phrase to a
+// workspace's moderation list
+type CreateModerationWordRequest struct {
+	Word   string `json:"word" binding:"required,max=100"`
+	Action string `json:"action" binding:"required,oneof=block mask flag"`
+}
+
+// ModerationWordResponse represents a moderated word in API responses
+type ModerationWordResponse struct {
+	ID        int64     `json:"id"`
+	Word      string    `json:"word"`
+	Action    string    `json:"action"`
+	CreatedBy *int64    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListModerationQueueRequest represents the request to list flagged messages
+type ListModerationQueueRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending approved removed"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32  `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ReviewModerationItemRequest represents an admin's decision on a flagged message
+type ReviewModerationItemRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=approve remove"`
+}
+
+// ModerationQueueItemResponse represents a flagged message in API responses
+type ModerationQueueItemResponse struct {
+	ID           int64        `json:"id"`
+	MessageID    int64        `json:"message_id"`
+	ChannelID    *int64       `json:"channel_id,omitempty"`
+	Content      string       `json:"content,omitempty"`
+	Sender       UserResponse `json:"sender"`
+	MatchedWords []string     `json:"matched_words"`
+	Status       string       `json:"status"`
+	ReviewedBy   *int64       `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time   `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// ModerationAuditEntryResponse represents a moderation action in API responses.
+// ActorID is empty for actions taken automatically on new and edited messages.
+type ModerationAuditEntryResponse struct {
+	ID           int64     `json:"id"`
+	MessageID    *int64    `json:"message_id,omitempty"`
+	AuthorID     *int64    `json:"author_id,omitempty"`
+	ActorID      *int64    `json:"actor_id,omitempty"`
+	Action       string    `json:"action"`
+	MatchedWords []string  `json:"matched_words"`
+	Reason       string    `json:"reason,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TakedownMessageRequest represents a moderator's takedown of a message. The
+// reason is sent to the author; the policy, when given, replaces the message
+// in history.
+type TakedownMessageRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+	Policy string `json:"policy" binding:"omitempty,max=200"`
+}
+
+// TakedownMessageResponse represents a message taken down by a moderator
+type TakedownMessageResponse struct {
+	MessageID      int64     `json:"message_id"`
+	WorkspaceID    int64     `json:"workspace_id"`
+	AuthorID       int64     `json:"author_id"`
+	TakenDownBy    int64     `json:"taken_down_by"`
+	Reason         string    `json:"reason"`
+	DeletionNotice string    `json:"deletion_notice,omitempty"`
+	AuthorNotified bool      `json:"author_notified"`
+	TakenDownAt    time.Time `json:"taken_down_at"`
+}
+
+// CreateAbuseReportRequest represents a member's report of a message, file or user
+type CreateAbuseReportRequest struct {
+	WorkspaceID int64  `json:"workspace_id" binding:"required,min=1"`
+	TargetType  string `json:"target_type" binding:"required,oneof=message file user"`
+	TargetID    int64  `json:"target_id" binding:"required,min=1"`
+	Reason      string `json:"reason" binding:"required,oneof=spam harassment hate_speech inappropriate other"`
+	Details     string `json:"details" binding:"max=2000"`
+	Anonymous   bool   `json:"anonymous"` // Hide the reporter from admins
+}
+
+// ListAbuseReportsRequest represents the request to list a workspace's reports
+type ListAbuseReportsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=open resolved dismissed"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32  `form:"offset" binding:"omitempty,min=0"`
+}
+
+// CloseAbuseReportRequest represents an admin resolving or dismissing a report
+type CloseAbuseReportRequest struct {
+	Note string `json:"note" binding:"max=2000"`
+}
+
+// AbuseReportResponse represents an abuse report in API responses. ReporterID is
+// omitted from admin views of anonymous reports.
+type AbuseReportResponse struct {
+	ID             int64      `json:"id"`
+	WorkspaceID    int64      `json:"workspace_id"`
+	ReporterID     *int64     `json:"reporter_id,omitempty"`
+	TargetType     string     `json:"target_type"`
+	TargetID       int64      `json:"target_id"`
+	Reason         string     `json:"reason"`
+	Details        string     `json:"details,omitempty"`
+	Anonymous      bool       `json:"anonymous"`
+	Status         string     `json:"status"`
+	ResolvedBy     *int64     `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	ResolutionNote string     `json:"resolution_note,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// CreateLegalHoldRequest represents placing a user or channel under legal hold
+type CreateLegalHoldRequest struct {
+	TargetType string `json:"target_type" binding:"required,oneof=user channel"`
+	TargetID   int64  `json:"target_id" binding:"required,min=1"`
+	Reason     string `json:"reason" binding:"required,max=2000"`
+}
+
+// ListLegalHoldsRequest represents the request to list an organization's legal holds
+type ListLegalHoldsRequest struct {
+	IncludeReleased bool  `form:"include_released"`
+	Limit           int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset          int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// LegalHoldResponse represents a legal hold in API responses
+type LegalHoldResponse struct {
+	ID             int64      `json:"id"`
+	OrganizationID int64      `json:"organization_id"`
+	TargetType     string     `json:"target_type"`
+	TargetID       int64      `json:"target_id"`
+	Reason         string     `json:"reason"`
+	Active         bool       `json:"active"`
+	CreatedBy      *int64     `json:"created_by,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ReleasedBy     *int64     `json:"released_by,omitempty"`
+	ReleasedAt     *time.Time `json:"released_at,omitempty"`
+}
+
+// LegalHoldExportMessage represents a held message in a compliance export.
+// Deleted messages are included with their deletion time.
+type LegalHoldExportMessage struct {
+	ID          int64      `json:"id"`
+	WorkspaceID int64      `json:"workspace_id"`
+	ChannelID   *int64     `json:"channel_id,omitempty"`
+	SenderID    int64      `json:"sender_id"`
+	SenderName  string     `json:"sender_name"`
+	SenderEmail string     `json:"sender_email"`
+	ReceiverID  *int64     `json:"receiver_id,omitempty"`
+	Content     string     `json:"content"`
+	ContentType string     `json:"content_type"`
+	MessageType string     `json:"message_type"`
+	ThreadID    *int64     `json:"thread_id,omitempty"`
+	EditedAt    *time.Time `json:"edited_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// LegalHoldExportResponse represents a page of a legal hold's compliance export
+type LegalHoldExportResponse struct {
+	Hold     LegalHoldResponse        `json:"hold"`
+	Messages []LegalHoldExportMessage `json:"messages"`
+}
+
+// CreateAutoJoinDomainRequest represents allowing an email domain to join a workspace
+type CreateAutoJoinDomainRequest struct {
+	Domain           string `json:"domain" binding:"required,max=255"`
+	RequiresApproval bool   `json:"requires_approval"`
+}
+
+// AutoJoinDomainResponse represents an allowed email domain in API responses
+type AutoJoinDomainResponse struct {
+	ID               int64     `json:"id"`
+	WorkspaceID      int64     `json:"workspace_id"`
+	Domain           string    `json:"domain"`
+	RequiresApproval bool      `json:"requires_approval"`
+	CreatedBy        *int64    `json:"created_by,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// DiscoverableWorkspaceResponse represents a workspace a user can join through their email domain
+type DiscoverableWorkspaceResponse struct {
+	ID               int64     `json:"id"`
+	Name             string    `json:"name"`
+	RequiresApproval bool      `json:"requires_approval"`
+	RequestPending   bool      `json:"request_pending"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// AutoJoinResponse represents the outcome of joining a workspace through an
+// email domain: either the user joined, or their request waits for approval
+type AutoJoinResponse struct {
+	Status  string               `json:"status"` // "joined" or "pending"
+	User    *UserResponse        `json:"user,omitempty"`
+	Request *JoinRequestResponse `json:"request,omitempty"`
+}
+
+// ListJoinRequestsRequest represents the request to list a workspace's join requests
+type ListJoinRequestsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	Limit  int32  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32  `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ReviewJoinRequestRequest represents an admin's decision on a join request
+type ReviewJoinRequestRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=approve reject"`
+}
+
+// JoinRequestResponse represents a request to join a workspace in API responses
+type JoinRequestResponse struct {
+	ID            int64      `json:"id"`
+	WorkspaceID   int64      `json:"workspace_id"`
+	UserID        int64      `json:"user_id"`
+	UserEmail     string     `json:"user_email,omitempty"`
+	UserFirstName string     `json:"user_first_name,omitempty"`
+	UserLastName  string     `json:"user_last_name,omitempty"`
+	Status        string     `json:"status"`
+	ReviewedBy    *int64     `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// WorkspaceRoleRequest represents creating or replacing a custom workspace role
+type WorkspaceRoleRequest struct {
+	Name        string   `json:"name" binding:"required,max=50"`
+	Description string   `json:"description" binding:"max=500"`
+	Permissions []string `json:"permissions"`
+}
+
+// WorkspaceRoleResponse represents a custom workspace role in API responses
+type WorkspaceRoleResponse struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	MemberCount *int64    `json:"member_count,omitempty"`
+	CreatedBy   *int64    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// AssignWorkspaceRoleRequest represents giving a member a custom role, or
+// removing it when RoleID is null
+type AssignWorkspaceRoleRequest struct {
+	RoleID *int64 `json:"role_id" binding:"omitempty,min=1"`
+}
+
+// SetOrganizationRoleRequest represents making a user an organization owner or admin
+type SetOrganizationRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner admin"`
+}
+
+// OrganizationRoleResponse represents an organization role assignment in API responses
+type OrganizationRoleResponse struct {
+	OrganizationID int64     `json:"organization_id"`
+	UserID         int64     `json:"user_id"`
+	Email          string    `json:"email"`
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
+	Role           string    `json:"role"`
+	GrantedBy      *int64    `json:"granted_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// DeletedChannelResponse represents a deleted channel that can still be restored
+type DeletedChannelResponse struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	Name        string    `json:"name"`
+	IsPrivate   bool      `json:"is_private"`
+	CreatedBy   int64     `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	DeletedBy   *int64    `json:"deleted_by,omitempty"`
+	DeletedAt   time.Time `json:"deleted_at"`
+	PurgeAt     time.Time `json:"purge_at"`
+}
+
+// DeletedWorkspaceResponse represents a deleted workspace that can still be restored
+type DeletedWorkspaceResponse struct {
+	ID             int64     `json:"id"`
+	OrganizationID int64     `json:"organization_id"`
+	Name           string    `json:"name"`
+	CreatedAt      time.Time `json:"created_at"`
+	DeletedBy      *int64    `json:"deleted_by,omitempty"`
+	DeletedAt      time.Time `json:"deleted_at"`
+	PurgeAt        time.Time `json:"purge_at"`
+}
+
+// ListWorkspaceTeardownsRequest represents paging through an organization's workspace teardowns
+type ListWorkspaceTeardownsRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// WorkspaceTeardownResponse reports how far the cleanup of a purged workspace has got
+type WorkspaceTeardownResponse struct {
+	WorkspaceID    int64      `json:"workspace_id"`
+	OrganizationID int64      `json:"organization_id"`
+	WorkspaceName  string     `json:"workspace_name"`
+	Status         string     `json:"status"`
+	Step           string     `json:"step,omitempty"`
+	RowsDeleted    int64      `json:"rows_deleted"`
+	FilesDeleted   int64      `json:"files_deleted"`
+	LastError      *string    `json:"last_error,omitempty"`
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// OrganizationDeletionResponse reports a staged organization deletion: when
+// it's due and how far the teardown of its workspaces has got
+type OrganizationDeletionResponse struct {
+	ID                int64      `json:"id"`
+	OrganizationID    int64      `json:"organization_id"`
+	OrganizationName  string     `json:"organization_name"`
+	RequestedBy       *int64     `json:"requested_by,omitempty"`
+	Status            string     `json:"status"`
+	Step              string     `json:"step,omitempty"`
+	WorkspacesTotal   int32      `json:"workspaces_total"`
+	WorkspacesDeleted int32      `json:"workspaces_deleted"`
+	RowsDeleted       int64      `json:"rows_deleted"`
+	FilesDeleted      int64      `json:"files_deleted"`
+	LastError         *string    `json:"last_error,omitempty"`
+	ScheduledFor      time.Time  `json:"scheduled_for"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+	CancelledAt       *time.Time `json:"cancelled_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// StartHuddleRequest represents starting a huddle in a channel or in a direct
+// conversation with another user. Exactly one of the two is set.
+type StartHuddleRequest struct {
+	ChannelID int64 `json:"channel_id" binding:"omitempty,min=1"`
+	UserID    int64 `json:"user_id" binding:"omitempty,min=1"`
+}
+
+// UpdateHuddleMediaRequest represents a participant turning their microphone,
+// camera or screen share on or off
+type UpdateHuddleMediaRequest struct {
+	Muted         bool `json:"muted"`
+	Video         bool `json:"video"`
+	ScreenSharing bool `json:"screen_sharing"`
+}
+
+// HuddleParticipantResponse represents a user in a huddle
+type HuddleParticipantResponse struct {
+	HuddleID      int64     `json:"huddle_id"`
+	UserID        int64     `json:"user_id"`
+	JoinedAt      time.Time `json:"joined_at"`
+	Muted         bool      `json:"muted"`
+	Video         bool      `json:"video"`
+	ScreenSharing bool      `json:"screen_sharing"`
+}
+
+// HuddleResponse represents an active huddle. Media flows between the
+// participants' clients, the server only relays signaling.
+type HuddleResponse struct {
+	ID           int64                       `json:"id"`
+	WorkspaceID  int64                       `json:"workspace_id"`
+	ChannelID    *int64                      `json:"channel_id,omitempty"`
+	UserIDs      []int64                     `json:"user_ids,omitempty"` // The two users of a direct conversation huddle
+	StartedBy    int64                       `json:"started_by"`
+	StartedAt    time.Time                   `json:"started_at"`
+	Participants []HuddleParticipantResponse `json:"participants"`
+	ICEServers   []string                    `json:"ice_servers"`
+}
+
+// HuddleSignal is a WebRTC offer, answer or ICE candidate relayed from one
+// huddle participant to another
+type HuddleSignal struct {
+	HuddleID   int64       `json:"huddle_id"`
+	FromUserID int64       `json:"from_user_id"`
+	SDP        string      `json:"sdp,omitempty"`
+	Candidate  interface{} `json:"candidate,omitempty"`
+}
+
+// CreateCanvasRequest represents creating a canvas in a channel
+type CreateCanvasRequest struct {
+	Title   string `json:"title" binding:"required,max=255"`
+	Content string `json:"content" binding:"max=200000"` // Markdown
+}
+
+// UpdateCanvasRequest represents saving an edit to a canvas. BaseRevision is
+// the revision the edit was made against; the save is rejected if someone
+// else saved in the meantime.
+type UpdateCanvasRequest struct {
+	Title        string `json:"title" binding:"required,max=255"`
+	Content      string `json:"content" binding:"max=200000"`
+	BaseRevision int32  `json:"base_revision" binding:"required,min=1"`
+}
+
+// ListCanvasesRequest represents the request to list canvases or their revisions
+type ListCanvasesRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// CanvasResponse represents a canvas in API responses
+type CanvasResponse struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	ChannelID   int64     `json:"channel_id"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content"`
+	Revision    int32     `json:"revision"`
+	CreatedBy   *int64    `json:"created_by,omitempty"`
+	UpdatedBy   *int64    `json:"updated_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CanvasRevisionResponse represents a saved revision of a canvas
+type CanvasRevisionResponse struct {
+	CanvasID  int64     `json:"canvas_id"`
+	Revision  int32     `json:"revision"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	EditedBy  *int64    `json:"edited_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddReactionRequest represents reacting to a message with an emoji
+type AddReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required,max=64"`
+}
+
+// ListReactorsRequest represents the request to page through the users who
+// reacted to a message with an emoji
+type ListReactorsRequest struct {
+	Limit  int32 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int32 `form:"offset" binding:"omitempty,min=0"`
+}
+
+// ReactionSummary represents the reactions to a message with one emoji
+type ReactionSummary struct {
+	Emoji   string  `json:"emoji"`
+	Count   int64   `json:"count"`
+	UserIDs []int64 `json:"user_ids"` // The first users to react, in the order they reacted
+	Reacted bool    `json:"reacted"`  // Whether the current user reacted with this emoji
+}
+
+// ReactorResponse represents a user who reacted to a message
+type ReactorResponse struct {
+	UserID    int64     `json:"user_id"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	ReactedAt time.Time `json:"reacted_at"`
+}
+
+// ReactionEvent is the payload of reaction_added and reaction_removed
+// WebSocket messages
+type ReactionEvent struct {
+	MessageID int64  `json:"message_id"`
+	UserID    int64  `json:"user_id"`
+	Emoji     string `json:"emoji"`
+}
+
+// PinnedMessageResponse represents a message pinned to its channel, with who
+// pinned it and when
+type PinnedMessageResponse struct {
+	Message  *MessageResponse `json:"message"`
+	PinnedBy int64            `json:"pinned_by"`
+	PinnedAt time.Time        `json:"pinned_at"`
+	Position int32            `json:"position"`
+}
+
+// ReorderPinsRequest represents the request to arrange a channel's pinned
+// messages. It must list every pinned message of the channel once.
+type ReorderPinsRequest struct {
+	MessageIDs []int64 `json:"message_ids" binding:"required,min=1,dive,min=1"`
+}
+
+// PinEvent is the payload of message_pinned, message_unpinned and
+// pins_reordered WebSocket messages. MessageIDs is the new order of the
+// channel's pins and is only set on pins_reordered.
+type PinEvent struct {
+	ChannelID  int64   `json:"channel_id"`
+	MessageID  int64   `json:"message_id,omitempty"`
+	UserID     int64   `json:"user_id"`
+	MessageIDs []int64 `json:"message_ids,omitempty"`
+}
+
+// CreateCustomEmojiRequest represents adding a custom emoji to a workspace
+// from an image the user uploaded
+type CreateCustomEmojiRequest struct {
+	Name   string `json:"name" binding:"required,max=62"`
+	FileID int64  `json:"file_id" binding:"required,min=1"`
+}
+
+// CustomEmojiResponse represents a workspace custom emoji
+type CustomEmojiResponse struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	Name        string    `json:"name"`
+	FileID      int64     `json:"file_id"`
+	ImageURL    string    `json:"image_url"`
+	CreatedBy   *int64    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DeleteCustomEmojiRequest represents deleting a custom emoji, optionally
+// moving its reactions to another emoji
+type DeleteCustomEmojiRequest struct {
+	ReplaceWith string `form:"replace_with" binding:"max=64"`
+}
+
+// DeleteCustomEmojiResponse represents the result of deleting a custom emoji
+type DeleteCustomEmojiResponse struct {
+	ReactionsMigrated int64 `json:"reactions_migrated"`
+}
+
+// UpdateReactionSettingsRequest represents the request to update a workspace's
+// reaction limits. A missing limit falls back to the server default.
+type UpdateReactionSettingsRequest struct {
+	MaxReactionsPerUser  *int32 `json:"max_reactions_per_user" binding:"omitempty,min=1,max=100"`
+	MaxDistinctReactions *int32 `json:"max_distinct_reactions" binding:"omitempty,min=1,max=500"`
+}
+
+// ReactionSettingsResponse represents a workspace's reaction limits.
+// The effective values include server defaults for limits the workspace has not set.
+type ReactionSettingsResponse struct {
+	WorkspaceID                   int64  `json:"workspace_id"`
+	MaxReactionsPerUser           *int32 `json:"max_reactions_per_user"`
+	MaxDistinctReactions          *int32 `json:"max_distinct_reactions"`
+	EffectiveMaxReactionsPerUser  int32  `json:"effective_max_reactions_per_user"`
+	EffectiveMaxDistinctReactions int32  `json:"effective_max_distinct_reactions"`
+}
+
+// UpdateMessageSettingsRequest represents the request to update how long
+// members may edit and delete their messages, whether deleted messages
+// leave tombstones in history and how many messages a channel can pin.
+// A missing window or limit falls back to the server default, a window of
+// 0 allows it at any time.
+type UpdateMessageSettingsRequest struct {
+	MessageEditWindowMinutes   *int32 `json:"message_edit_window_minutes" binding:"omitempty,min=0,max=525600"`
+	MessageDeleteWindowMinutes *int32 `json:"message_delete_window_minutes" binding:"omitempty,min=0,max=525600"`
+	HideDeletedMessages        bool   `json:"hide_deleted_messages"`
+	MaxPinsPerChannel          *int32 `json:"max_pins_per_channel" binding:"omitempty,min=1,max=1000"`
+}
+
+// MessageSettingsResponse represents a workspace's message edit and delete windows,
+// whether deleted messages are hidden from history and its pin limit.
+// The effective values include server defaults for those the workspace has not set.
+type MessageSettingsResponse struct {
+	WorkspaceID                         int64  `json:"workspace_id"`
+	MessageEditWindowMinutes            *int32 `json:"message_edit_window_minutes"`
+	MessageDeleteWindowMinutes          *int32 `json:"message_delete_window_minutes"`
+	EffectiveMessageEditWindowMinutes   int32  `json:"effective_message_edit_window_minutes"`
+	EffectiveMessageDeleteWindowMinutes int32  `json:"effective_message_delete_window_minutes"`
+	HideDeletedMessages                 bool   `json:"hide_deleted_messages"`
+	MaxPinsPerChannel                   *int32 `json:"max_pins_per_channel"`
+	EffectiveMaxPinsPerChannel          int32  `json:"effective_max_pins_per_channel"`
+}
+
+// UpdateOnboardingRequest represents the request to customize how a
+// workspace onboards new members. The welcome message is a Go template that
+// can use {{.FirstName}}, {{.LastName}} and {{.WorkspaceName}}.
+type UpdateOnboardingRequest struct {
+	Enabled             *bool    `json:"enabled"`
+	WelcomeMessage      string   `json:"welcome_message" binding:"required,max=4000"`
+	SuggestedChannelIDs []int64  `json:"suggested_channel_ids" binding:"max=20"`
+	Steps               []string `json:"steps" binding:"max=10"`
+}
+
+// OnboardingSettingsResponse represents how a workspace onboards new members.
+// Workspaces that haven't set up onboarding get the defaults, disabled.
+type OnboardingSettingsResponse struct {
+	WorkspaceID         int64      `json:"workspace_id"`
+	Enabled             bool       `json:"enabled"`
+	WelcomeMessage      string     `json:"welcome_message"`
+	SuggestedChannelIDs []int64    `json:"suggested_channel_ids"`
+	Steps               []string   `json:"steps"`
+	BotUserID           *int64     `json:"bot_user_id,omitempty"`
+	UpdatedAt           *time.Time `json:"updated_at,omitempty"`
+	// Every step the checklist can use, with its title
+	AvailableSteps []OnboardingStepResponse `json:"available_steps"`
+}
+
+// OnboardingStepResponse represents a step of the onboarding checklist
+type OnboardingStepResponse struct {
+	Step        string     `json:"step"`
+	Title       string     `json:"title"`
+	Completed   bool       `json:"completed"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// SuggestedChannelResponse represents a channel onboarding suggests joining
+type SuggestedChannelResponse struct {
+	Channel ChannelResponse `json:"channel"`
+	Joined  bool            `json:"joined"`
+}
+
+// OnboardingResponse represents a member's onboarding checklist and the
+// channels suggested to them
+type OnboardingResponse struct {
+	Enabled           bool                       `json:"enabled"`
+	Checklist         []OnboardingStepResponse   `json:"checklist"`
+	SuggestedChannels []SuggestedChannelResponse `json:"suggested_channels"`
+	// Whether every checklist step is done
+	Completed bool `json:"completed"`
+}
+
+// JoinSuggestedChannelsRequest represents the request to join channels
+// onboarding suggests. No channel IDs joins all of them.
+type JoinSuggestedChannelsRequest struct {
+	ChannelIDs []int64 `json:"channel_ids" binding:"max=20"`
+}
+
+// EffectiveConfigResponse represents the settings the server currently runs
+// with that can be reloaded without a restart
+type EffectiveConfigResponse struct {
+	HTTPMaxBodySize         int64     `json:"http_max_body_size"`
+	FileMaxSize             int64     `json:"file_max_size"`
+	FileAllowedTypes        []string  `json:"file_allowed_types"`
+	WSReadBufferSize        int       `json:"ws_read_buffer_size"`
+	WSWriteBufferSize       int       `json:"ws_write_buffer_size"`
+	WSMaxConnectionsPerUser int       `json:"ws_max_connections_per_user"`
+	WSMaxMessageSize        int64     `json:"ws_max_message_size"`
+	WSPingInterval          string    `json:"ws_ping_interval"`
+	WSPongTimeout           string    `json:"ws_pong_timeout"`
+	WSDraftDebounce         string    `json:"ws_draft_debounce"`
+	LoadedAt                time.Time `json:"loaded_at"`
+}
+
+// MaintenanceResponse represents whether the API is in maintenance. While it
+// is, writes are rejected with 503 and a Retry-After header.
+type MaintenanceResponse struct {
+	Enabled           bool       `json:"enabled"`
+	Message           string     `json:"message,omitempty"`
+	RetryAfterSeconds int32      `json:"retry_after_seconds"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+}
+
+// UpdateMaintenanceRequest represents the request to turn maintenance on or off
+type UpdateMaintenanceRequest struct {
+	Enabled           *bool   `json:"enabled" binding:"required"`
+	Message           *string `json:"message" binding:"omitempty,max=500"`
+	RetryAfterSeconds *int32  `json:"retry_after_seconds" binding:"omitempty,min=1,max=86400"`
 }
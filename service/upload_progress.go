@@ -0,0 +1,77 @@
+package service
+
+import (
+	"io"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// WSFileUploadProgress is sent to the uploader's connections as an upload is
+// stored and processed
+const WSFileUploadProgress = "file_upload_progress"
+
+// Upload progress statuses. Uploads that are transcoded or previewed in the
+// background stay processing until the worker finishes with them.
+const (
+	UploadStatusUploading  = "uploading"
+	UploadStatusProcessing = "processing"
+	UploadStatusCompleted  = "completed"
+	UploadStatusFailed     = "failed"
+)
+
+// uploadProgressStep is how many percent of an upload are stored between
+// progress events
+const uploadProgressStep = 10
+
+// sendUploadProgress tells the uploader how far along their upload is
+func sendUploadProgress(hub WebSocketHub, workspaceID, uploaderID int64, progress FileUploadProgress) {
+	if hub == nil {
+		return
+	}
+
+	hub.BroadcastToUser(uploaderID, &WSMessage{
+		Type:        WSFileUploadProgress,
+		Data:        progress,
+		WorkspaceID: workspaceID,
+		UserID:      uploaderID,
+		Timestamp:   time.Now(),
+	})
+}
+
+// sendProcessingFinished tells the uploader that background processing of
+// their upload is over. The upload can be used even if processing failed, in
+// which case failure says what's missing.
+func sendProcessingFinished(hub WebSocketHub, file db.File, failure string) {
+	sendUploadProgress(hub, file.WorkspaceID, file.UploaderID, FileUploadProgress{
+		FileID:   file.ID,
+		Progress: 100,
+		Status:   UploadStatusCompleted,
+		Error:    failure,
+	})
+}
+
+// progressReader reports the share of an upload read so far each time it
+// passes another uploadProgressStep percent
+type progressReader struct {
+	io.Reader
+	size     int64
+	read     int64
+	reported int
+	report   func(percent int)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += int64(n)
+
+	if r.size > 0 {
+		percent := int(min(r.read*100/r.size, 100))
+		if step := percent - percent%uploadProgressStep; step > r.reported {
+			r.reported = step
+			r.report(step)
+		}
+	}
+
+	return n, err
+}
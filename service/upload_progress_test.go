@@ -0,0 +1,107 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressReader(t *testing.T) {
+	var reported []int
+	reader := &progressReader{
+		Reader: bytes.NewReader(make([]byte, 1000)),
+		size:   1000,
+		report: func(percent int) { reported = append(reported, percent) },
+	}
+
+	// A read that passes two steps only reports the second
+	buf := make([]byte, 150)
+	for {
+		if _, err := reader.Read(buf); err == io.EOF {
+			break
+		}
+	}
+	require.Equal(t, []int{10, 30, 40, 60, 70, 90, 100}, reported)
+}
+
+func uploadStatuses(messages []*WSMessage) []string {
+	statuses := make([]string, len(messages))
+	for i, message := range messages {
+		statuses[i] = message.Data.(FileUploadProgress).Status
+	}
+	return statuses
+}
+
+func TestFileService_UploadProgress(t *testing.T) {
+	const workspaceID, uploaderID = int64(5), int64(7)
+	ctx := context.Background()
+	request := InlineFileUploadRequest{
+		WorkspaceID: workspaceID,
+		Filename:    "screenshot.png",
+		Data:        base64.StdEncoding.EncodeToString(encodeTestPNG(t)),
+	}
+	config := util.Config{
+		FileStoragePath:  t.TempDir(),
+		FileMaxSize:      1 << 20,
+		FileAllowedTypes: "image/png",
+		EnableThumbnails: true,
+	}
+	createFile := func(_ context.Context, arg db.CreateFileParams) (db.File, error) {
+		return db.File{ID: 9, WorkspaceID: arg.WorkspaceID, UploaderID: arg.UploaderID, FilePath: arg.FilePath, MimeType: arg.MimeType}, nil
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().CreateFile(gomock.Any(), gomock.Any()).DoAndReturn(createFile)
+		store.EXPECT().UpdateFileUploadStatus(gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().UpdateFileThumbnail(gomock.Any(), gomock.Any()).Return(nil)
+		store.EXPECT().GetUser(gomock.Any(), uploaderID).Return(db.User{ID: uploaderID}, nil)
+
+		hub := &recordingHub{}
+		fileService := NewFileService(store, config, nil)
+		fileService.SetHub(hub)
+
+		_, err := fileService.UploadInlineImage(ctx, request, uploaderID)
+		require.NoError(t, err)
+
+		// Only the uploader hears about it, the image is small enough to be
+		// stored in one read
+		messages := hub.userMessages[uploaderID]
+		require.Len(t, hub.userMessages, 1)
+		require.Equal(t, []string{UploadStatusUploading, UploadStatusUploading, UploadStatusProcessing, UploadStatusCompleted}, uploadStatuses(messages))
+		require.Equal(t, FileUploadProgress{FileID: 9, Progress: 100, Status: UploadStatusUploading}, messages[1].Data)
+		require.Equal(t, FileUploadProgress{FileID: 9, Progress: 100, Status: UploadStatusCompleted}, messages[3].Data)
+		for _, message := range messages {
+			require.Equal(t, WSFileUploadProgress, message.Type)
+			require.Equal(t, workspaceID, message.WorkspaceID)
+		}
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().CreateFile(gomock.Any(), gomock.Any()).DoAndReturn(createFile)
+		store.EXPECT().UpdateFileUploadStatus(gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+		hub := &recordingHub{}
+		fileService := NewFileService(store, config, nil)
+		fileService.SetHub(hub)
+
+		_, err := fileService.UploadInlineImage(ctx, request, uploaderID)
+		require.Error(t, err)
+
+		messages := hub.userMessages[uploaderID]
+		require.Equal(t, UploadStatusFailed, uploadStatuses(messages)[len(messages)-1])
+		require.Equal(t, "failed to mark file upload as completed: connection reset", messages[len(messages)-1].Data.(FileUploadProgress).Error)
+	})
+}
@@ -5,21 +5,38 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	_ "time/tzdata" // Time zones must load on hosts without a zoneinfo database
 
 	db "github.com/heyrmi/goslack/db/sqlc"
 	"github.com/heyrmi/goslack/token"
 	"github.com/heyrmi/goslack/util"
 )
 
+// handlePattern matches handles made of lowercase letters, digits, dots,
+// dashes and underscores
+var handlePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,49}$`)
+
+// phonePattern matches phone numbers written with digits, spaces and the usual punctuation
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 ().-]{2,30}$`)
+
+// userServiceStore covers users and their workspace memberships and roles
+type userServiceStore interface {
+	db.UserStore
+	db.WorkspaceStore
+}
+
 // UserService handles user-related business logic
 type UserService struct {
-	store      db.Store
+	store      userServiceStore
 	tokenMaker token.Maker
 	config     util.Config
 }
 
 // NewUserService creates a new user service
-func NewUserService(store db.Store, tokenMaker token.Maker, config util.Config) *UserService {
+func NewUserService(store userServiceStore, tokenMaker token.Maker, config util.Config) *UserService {
 	return &UserService{
 		store:      store,
 		tokenMaker: tokenMaker,
@@ -70,10 +87,12 @@ func (s *UserService) LoginUser(ctx context.Context, req LoginUserRequest) (Logi
 	}
 
 	// Create access token
-	accessToken, _, err := s.tokenMaker.CreateToken(
-		user.Email,
-		s.config.AccessTokenDuration,
-	)
+	accessToken, _, err := s.tokenMaker.CreateTokenWithClaims(token.Claims{
+		Username:  user.Email,
+		SubjectID: user.ID,
+		Type:      token.TokenTypeUser,
+		Scopes:    []string{token.ScopeAll},
+	}, s.config.AccessTokenDuration)
 	if err != nil {
 		return LoginUserResponse{}, fmt.Errorf("failed to create access token: %w", err)
 	}
@@ -119,18 +138,79 @@ func (s *UserService) UpdateUserProfile(ctx context.Context, userID int64, req U
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 	}
+	if req.Handle != nil {
+		handle := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(*req.Handle), "@"))
+		if handle != "" && !handlePattern.MatchString(handle) {
+			return UserResponse{}, errors.New("invalid handle")
+		}
+		arg.Handle = sql.NullString{String: handle, Valid: true}
+	}
+	if req.Title != nil {
+		arg.Title = sql.NullString{String: strings.TrimSpace(*req.Title), Valid: true}
+	}
+	if req.Pronouns != nil {
+		arg.Pronouns = sql.NullString{String: strings.TrimSpace(*req.Pronouns), Valid: true}
+	}
+	if req.Phone != nil {
+		phone := strings.TrimSpace(*req.Phone)
+		if phone != "" && !phonePattern.MatchString(phone) {
+			return UserResponse{}, errors.New("invalid phone number")
+		}
+		arg.Phone = sql.NullString{String: phone, Valid: true}
+	}
+	if req.Timezone != nil {
+		if _, err := LoadTimezone(*req.Timezone); err != nil {
+			return UserResponse{}, err
+		}
+		arg.Timezone = sql.NullString{String: *req.Timezone, Valid: true}
+	}
 
 	user, err := s.store.UpdateUserProfile(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return UserResponse{}, errors.New("user not found")
 		}
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return UserResponse{}, errors.New("handle is already taken")
+		}
 		return UserResponse{}, fmt.Errorf("failed to update user profile: %w", err)
 	}
 
 	return s.toUserResponse(user), nil
 }
 
+// GetUserLocation returns the time zone of a user's profile for features that
+// work with the user's local time
+func (s *UserService) GetUserLocation(ctx context.Context, userID int64) (*time.Location, error) {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	location, err := LoadTimezone(user.Timezone)
+	if err != nil {
+		// Profiles only store valid time zones, but don't fail on old data
+		return time.UTC, nil
+	}
+	return location, nil
+}
+
+// LoadTimezone loads an IANA time zone such as "America/New_York"
+func LoadTimezone(name string) (*time.Location, error) {
+	// LoadLocation treats "" as UTC and "Local" as the server's zone
+	if name == "" || name == "Local" {
+		return nil, errors.New("invalid timezone")
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("invalid timezone")
+	}
+	return location, nil
+}
+
 // ChangePassword changes a user's password
 func (s *UserService) ChangePassword(ctx context.Context, userID int64, req ChangePasswordRequest) error {
 	// Get current user
@@ -257,6 +337,24 @@ func (s *UserService) IsWorkspaceAdmin(ctx context.Context, userID, workspaceID
 	return role == "admin", nil
 }
 
+// HasWorkspacePermission checks if a user holds a permission in a workspace,
+// either as an admin or through their custom role
+func (s *UserService) HasWorkspacePermission(ctx context.Context, userID, workspaceID int64, permission string) (bool, error) {
+	return hasWorkspacePermission(ctx, s.store, userID, workspaceID, permission)
+}
+
+// GetWorkspacePermissions lists the permissions a user holds in a workspace
+func (s *UserService) GetWorkspacePermissions(ctx context.Context, userID, workspaceID int64) ([]string, error) {
+	permissions, err := workspacePermissions(ctx, s.store, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if permissions == nil {
+		return nil, errors.New("user not found in workspace")
+	}
+	return permissions, nil
+}
+
 // IsWorkspaceMember checks if a user is a member (admin or member) in a workspace
 func (s *UserService) IsWorkspaceMember(ctx context.Context, userID, workspaceID int64) (bool, error) {
 	role, err := s.CheckUserWorkspaceRole(ctx, userID, workspaceID)
@@ -271,8 +369,7 @@ func (s *UserService) IsWorkspaceMember(ctx context.Context, userID, workspaceID
 }
 
 // UserBelongsToWorkspace checks if a user belongs to a workspace (for backward compatibility)
-func (s *UserService) UserBelongsToWorkspace(userID, workspaceID int64) bool {
-	ctx := context.Background()
+func (s *UserService) UserBelongsToWorkspace(ctx context.Context, userID, workspaceID int64) bool {
 	isMember, err := s.IsWorkspaceMember(ctx, userID, workspaceID)
 	if err != nil {
 		return false
@@ -287,6 +384,11 @@ func (s *UserService) toUserResponse(user db.User) UserResponse {
 		workspaceID = &user.WorkspaceID.Int64
 	}
 
+	var customRoleID *int64
+	if user.CustomRoleID.Valid {
+		customRoleID = &user.CustomRoleID.Int64
+	}
+
 	return UserResponse{
 		ID:             user.ID,
 		OrganizationID: user.OrganizationID,
@@ -295,6 +397,15 @@ func (s *UserService) toUserResponse(user db.User) UserResponse {
 		LastName:       user.LastName,
 		WorkspaceID:    workspaceID,
 		Role:           user.Role,
+		CustomRoleID:   customRoleID,
+		Handle:         user.Handle,
+		Title:          user.Title,
+		Pronouns:       user.Pronouns,
+		Phone:          user.Phone,
+		Timezone:       user.Timezone,
+		AvatarURLs:     avatarURLs(user.AvatarKey),
+		Initials:       userInitials(user.FirstName, user.LastName),
+		EmailVerified:  user.EmailVerifiedAt.Valid,
 		CreatedAt:      user.CreatedAt,
 	}
 }
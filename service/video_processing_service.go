@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Video processing statuses
+const (
+	ProcessingStatusPending    = "pending"
+	ProcessingStatusProcessing = "processing"
+	ProcessingStatusCompleted  = "completed"
+	ProcessingStatusFailed     = "failed"
+)
+
+// WSFileProcessed is sent to the file's workspace when a video finishes processing
+const WSFileProcessed = "file_processed"
+
+// videosPerRun caps how many queued videos one run of the worker transcodes
+const videosPerRun = 5
+
+// FileProcessedEvent is the payload of a file_processed WebSocket message.
+// Clients that show the file fetch it again to pick up the renditions.
+type FileProcessedEvent struct {
+	FileID           int64  `json:"file_id"`
+	ProcessingStatus string `json:"processing_status"`
+	PlaybackURL      string `json:"playback_url,omitempty"`
+	PosterURL        string `json:"poster_url,omitempty"`
+}
+
+// VideoProcessingService transcodes uploaded videos in the background
+type VideoProcessingService struct {
+	store      db.FileStore
+	hub        WebSocketHub
+	transcoder VideoTranscoder
+	timeout    time.Duration
+	queued     chan struct{}
+}
+
+// NewVideoProcessingService creates a new video processing service. Without
+// a transcoder videos are stored as uploaded.
+func NewVideoProcessingService(store db.FileStore, hub WebSocketHub, transcoder VideoTranscoder, config util.Config) *VideoProcessingService {
+	timeout := config.VideoTranscodeTimeout
+	if timeout <= 0 {
+		timeout = 10 * time.Minute
+	}
+
+	return &VideoProcessingService{
+		store:      store,
+		hub:        hub,
+		transcoder: transcoder,
+		timeout:    timeout,
+		queued:     make(chan struct{}, 1),
+	}
+}
+
+// Enabled reports whether uploaded videos are transcoded
+func (s *VideoProcessingService) Enabled() bool {
+	return s != nil && s.transcoder != nil
+}
+
+// isVideoFile checks if a file is a video based on MIME type
+func isVideoFile(mimeType string) bool {
+	return strings.HasPrefix(mimeType, "video/")
+}
+
+// Enqueue queues an uploaded video for transcoding
+func (s *VideoProcessingService) Enqueue(ctx context.Context, fileID int64) error {
+	if err := s.setStatus(ctx, fileID, ProcessingStatusPending); err != nil {
+		return fmt.Errorf("failed to queue video for processing: %w", err)
+	}
+
+	// Wake the worker without waiting for it
+	select {
+	case s.queued <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// ProcessPending transcodes the oldest queued videos
+func (s *VideoProcessingService) ProcessPending(ctx context.Context) error {
+	files, err := s.store.ListPendingVideoFiles(ctx, videosPerRun)
+	if err != nil {
+		return fmt.Errorf("failed to list queued videos: %w", err)
+	}
+
+	for _, file := range files {
+		if err := s.processVideo(ctx, file); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Printf("Error processing video %d: %v\n", file.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// StartProcessingWorker transcodes queued videos as they are uploaded,
+// checking for any left over at each interval
+func (s *VideoProcessingService) StartProcessingWorker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	// Videos that were being transcoded when the server stopped start over
+	if err := s.store.RequeueInterruptedFileProcessing(ctx); err != nil {
+		fmt.Printf("Error requeueing interrupted video processing: %v\n", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessPending(ctx); err != nil {
+			fmt.Printf("Error processing videos: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.queued:
+		}
+	}
+}
+
+// processVideo makes the MP4 rendition and poster for a video and tells the
+// workspace once it's done
+func (s *VideoProcessingService) processVideo(ctx context.Context, file db.File) error {
+	if err := s.setStatus(ctx, file.ID, ProcessingStatusProcessing); err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(file.FilePath, filepath.Ext(file.FilePath))
+	transcodedPath := base + "_web.mp4"
+	posterPath := base + "_poster.jpg"
+
+	transcodeCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	err := s.transcoder.Transcode(transcodeCtx, file.FilePath, transcodedPath)
+	if err == nil {
+		err = s.transcoder.ExtractPoster(transcodeCtx, file.FilePath, posterPath)
+	}
+	if err != nil {
+		removeStoredFile(transcodedPath)
+		removeStoredFile(posterPath)
+		if ctx.Err() != nil {
+			// Shutting down, the video is requeued on the next start
+			return ctx.Err()
+		}
+		if statusErr := s.setStatus(ctx, file.ID, ProcessingStatusFailed); statusErr != nil {
+			return statusErr
+		}
+		s.notify(file.WorkspaceID, FileProcessedEvent{FileID: file.ID, ProcessingStatus: ProcessingStatusFailed})
+		sendProcessingFinished(s.hub, file, "video could not be transcoded, the original is available")
+		return err
+	}
+
+	if err := s.store.UpdateFileProcessing(ctx, db.UpdateFileProcessingParams{
+		ID:               file.ID,
+		ProcessingStatus: sql.NullString{String: ProcessingStatusCompleted, Valid: true},
+		TranscodedPath:   sql.NullString{String: transcodedPath, Valid: true},
+		PosterPath:       sql.NullString{String: posterPath, Valid: true},
+	}); err != nil {
+		removeStoredFile(transcodedPath)
+		removeStoredFile(posterPath)
+		return fmt.Errorf("failed to save video renditions: %w", err)
+	}
+
+	s.notify(file.WorkspaceID, FileProcessedEvent{
+		FileID:           file.ID,
+		ProcessingStatus: ProcessingStatusCompleted,
+		PlaybackURL:      playbackURL(file.ID),
+		PosterURL:        posterURL(file.ID),
+	})
+	sendProcessingFinished(s.hub, file, "")
+
+	return nil
+}
+
+func (s *VideoProcessingService) setStatus(ctx context.Context, fileID int64, status string) error {
+	err := s.store.UpdateFileProcessing(ctx, db.UpdateFileProcessingParams{
+		ID:               fileID,
+		ProcessingStatus: sql.NullString{String: status, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update video processing status: %w", err)
+	}
+	return nil
+}
+
+func (s *VideoProcessingService) notify(workspaceID int64, event FileProcessedEvent) {
+	if s.hub == nil {
+		return
+	}
+
+	s.hub.BroadcastToWorkspace(workspaceID, &WSMessage{
+		Type:        WSFileProcessed,
+		Data:        event,
+		WorkspaceID: workspaceID,
+		Timestamp:   time.Now(),
+	})
+}
+
+func playbackURL(fileID int64) string {
+	return fmt.Sprintf("/api/files/%d/playback", fileID)
+}
+
+func posterURL(fileID int64) string {
+	return fmt.Sprintf("/api/files/%d/poster", fileID)
+}
+
+// setVideoProcessing adds a video's processing status and renditions to its response
+func setVideoProcessing(response *FileResponse, status, transcodedPath, posterPath sql.NullString) {
+	if !status.Valid {
+		return
+	}
+
+	response.ProcessingStatus = status.String
+	if status.String != ProcessingStatusCompleted {
+		return
+	}
+	if transcodedPath.Valid {
+		response.PlaybackURL = playbackURL(response.ID)
+	}
+	if posterPath.Valid {
+		response.PosterURL = posterURL(response.ID)
+	}
+}
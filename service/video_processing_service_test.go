@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTranscoder writes placeholder renditions, or fails with err
+type fakeTranscoder struct {
+	err error
+}
+
+func (t *fakeTranscoder) Transcode(ctx context.Context, inputPath, outputPath string) error {
+	if t.err != nil {
+		return t.err
+	}
+	return os.WriteFile(outputPath, []byte("mp4"), 0644)
+}
+
+func (t *fakeTranscoder) ExtractPoster(ctx context.Context, inputPath, posterPath string) error {
+	return os.WriteFile(posterPath, []byte("jpeg"), 0644)
+}
+
+func processingStatus(status string) sql.NullString {
+	return sql.NullString{String: status, Valid: true}
+}
+
+func TestVideoProcessingService_ProcessPending(t *testing.T) {
+	dir := t.TempDir()
+	file := db.File{
+		ID:          7,
+		WorkspaceID: 3,
+		FilePath:    filepath.Join(dir, "clip.mov"),
+		MimeType:    "video/quicktime",
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockFileStore(ctrl)
+	store.EXPECT().ListPendingVideoFiles(gomock.Any(), int32(videosPerRun)).Times(1).Return([]db.File{file}, nil)
+	gomock.InOrder(
+		store.EXPECT().
+			UpdateFileProcessing(gomock.Any(), db.UpdateFileProcessingParams{
+				ID:               file.ID,
+				ProcessingStatus: processingStatus(ProcessingStatusProcessing),
+			}).
+			Times(1).
+			Return(nil),
+		store.EXPECT().
+			UpdateFileProcessing(gomock.Any(), db.UpdateFileProcessingParams{
+				ID:               file.ID,
+				ProcessingStatus: processingStatus(ProcessingStatusCompleted),
+				TranscodedPath:   sql.NullString{String: filepath.Join(dir, "clip_web.mp4"), Valid: true},
+				PosterPath:       sql.NullString{String: filepath.Join(dir, "clip_poster.jpg"), Valid: true},
+			}).
+			Times(1).
+			Return(nil),
+	)
+
+	hub := &recordingHub{}
+	videoService := NewVideoProcessingService(store, hub, &fakeTranscoder{}, util.Config{})
+	require.NoError(t, videoService.ProcessPending(context.Background()))
+
+	require.FileExists(t, filepath.Join(dir, "clip_web.mp4"))
+	require.FileExists(t, filepath.Join(dir, "clip_poster.jpg"))
+
+	require.Len(t, hub.workspaceMessages[file.WorkspaceID], 1)
+	message := hub.workspaceMessages[file.WorkspaceID][0]
+	require.Equal(t, WSFileProcessed, message.Type)
+	require.Equal(t, FileProcessedEvent{
+		FileID:           file.ID,
+		ProcessingStatus: ProcessingStatusCompleted,
+		PlaybackURL:      "/api/files/7/playback",
+		PosterURL:        "/api/files/7/poster",
+	}, message.Data)
+}
+
+func TestVideoProcessingService_ProcessPendingFailure(t *testing.T) {
+	file := db.File{ID: 7, WorkspaceID: 3, FilePath: filepath.Join(t.TempDir(), "clip.mov")}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockFileStore(ctrl)
+	store.EXPECT().ListPendingVideoFiles(gomock.Any(), gomock.Any()).Times(1).Return([]db.File{file}, nil)
+	gomock.InOrder(
+		store.EXPECT().
+			UpdateFileProcessing(gomock.Any(), db.UpdateFileProcessingParams{
+				ID:               file.ID,
+				ProcessingStatus: processingStatus(ProcessingStatusProcessing),
+			}).
+			Times(1).
+			Return(nil),
+		store.EXPECT().
+			UpdateFileProcessing(gomock.Any(), db.UpdateFileProcessingParams{
+				ID:               file.ID,
+				ProcessingStatus: processingStatus(ProcessingStatusFailed),
+			}).
+			Times(1).
+			Return(nil),
+	)
+
+	hub := &recordingHub{}
+	transcoder := &fakeTranscoder{err: errors.New("ffmpeg failed: invalid data found")}
+	videoService := NewVideoProcessingService(store, hub, transcoder, util.Config{})
+
+	// A video that can't be transcoded is marked failed without stopping the run
+	require.NoError(t, videoService.ProcessPending(context.Background()))
+	require.Equal(t, FileProcessedEvent{FileID: file.ID, ProcessingStatus: ProcessingStatusFailed}, hub.workspaceMessages[file.WorkspaceID][0].Data)
+}
+
+func TestSetVideoProcessing(t *testing.T) {
+	response := &FileResponse{ID: 4}
+	setVideoProcessing(response, sql.NullString{}, sql.NullString{}, sql.NullString{})
+	require.Empty(t, response.ProcessingStatus)
+
+	setVideoProcessing(response, processingStatus(ProcessingStatusPending), sql.NullString{}, sql.NullString{})
+	require.Equal(t, ProcessingStatusPending, response.ProcessingStatus)
+	require.Empty(t, response.PlaybackURL)
+
+	setVideoProcessing(response, processingStatus(ProcessingStatusCompleted), processingStatus("a.mp4"), processingStatus("a.jpg"))
+	require.Equal(t, "/api/files/4/playback", response.PlaybackURL)
+	require.Equal(t, "/api/files/4/poster", response.PosterURL)
+}
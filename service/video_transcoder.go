@@ -0,0 +1,83 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/heyrmi/goslack/util"
+)
+
+// VideoTranscoder converts uploaded videos into renditions browsers can play
+type VideoTranscoder interface {
+	// Transcode writes an H.264/AAC MP4 rendition of the input to outputPath
+	Transcode(ctx context.Context, inputPath, outputPath string) error
+	// ExtractPoster writes a JPEG frame from near the start of the video to posterPath
+	ExtractPoster(ctx context.Context, inputPath, posterPath string) error
+}
+
+// NewVideoTranscoder creates the transcoder set in the configuration.
+// It returns nil when video transcoding is disabled.
+func NewVideoTranscoder(config util.Config) (VideoTranscoder, error) {
+	if !config.EnableVideoTranscoding {
+		return nil, nil
+	}
+
+	path, err := exec.LookPath(config.FFmpegPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid video transcoding configuration: ffmpeg not found at '%s'", config.FFmpegPath)
+	}
+
+	return &ffmpegTranscoder{path: path}, nil
+}
+
+// ffmpegTranscoder runs the ffmpeg command line tool
+type ffmpegTranscoder struct {
+	path string
+}
+
+func (t *ffmpegTranscoder) Transcode(ctx context.Context, inputPath, outputPath string) error {
+	return t.run(ctx,
+		"-i", inputPath,
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "23",
+		// Browsers only play 4:2:0 H.264, whose dimensions must be even
+		"-pix_fmt", "yuv420p", "-vf", "scale=trunc(iw/2)*2:trunc(ih/2)*2",
+		"-c:a", "aac", "-b:a", "128k",
+		// Put the index first so playback can start before the download finishes
+		"-movflags", "+faststart",
+		"-f", "mp4", outputPath,
+	)
+}
+
+func (t *ffmpegTranscoder) ExtractPoster(ctx context.Context, inputPath, posterPath string) error {
+	// The thumbnail filter picks a representative frame rather than a black first frame
+	return t.run(ctx,
+		"-i", inputPath,
+		"-vf", "thumbnail,scale='min(1280,iw)':-2",
+		"-frames:v", "1",
+		"-f", "image2", posterPath,
+	)
+}
+
+func (t *ffmpegTranscoder) run(ctx context.Context, args ...string) error {
+	args = append([]string{"-hide_banner", "-loglevel", "error", "-nostdin", "-y"}, args...)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.path, args...)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return errors.New("ffmpeg timed out")
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("ffmpeg failed: %s", message)
+		}
+		return fmt.Errorf("ffmpeg failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Image watermark modes, set per workspace
+const (
+	ImageWatermarkOff             = "off"
+	ImageWatermarkPrivateChannels = "private_channels"
+	ImageWatermarkAll             = "all"
+)
+
+// UpdateFileSettingsRequest represents the request to set which images
+// downloaded from a workspace are stamped with a watermark, and whether its
+// public files can be fetched without signing in. Leaving out public_files or
+// public_file_referers keeps their current value.
+type UpdateFileSettingsRequest struct {
+	ImageWatermark     string   `json:"image_watermark" binding:"required,oneof=off private_channels all"`
+	PublicFiles        *bool    `json:"public_files"`
+	PublicFileReferers []string `json:"public_file_referers" binding:"omitempty,max=50,dive,hostname_rfc1123"` // Sites public files can be embedded on, empty allows any
+}
+
+// FileSettingsResponse represents a workspace's file settings
+type FileSettingsResponse struct {
+	WorkspaceID        int64    `json:"workspace_id"`
+	ImageWatermark     string   `json:"image_watermark"`
+	PublicFiles        bool     `json:"public_files"`
+	PublicFileReferers []string `json:"public_file_referers"`
+}
+
+// GetFileSettings returns which images downloaded from a workspace are
+// watermarked and how its public files can be fetched
+func (s *FileService) GetFileSettings(ctx context.Context, workspaceID int64) (*FileSettingsResponse, error) {
+	settings, err := s.fileSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newFileSettingsResponse(settings), nil
+}
+
+// UpdateFileSettings sets which images downloaded from a workspace are
+// watermarked and how its public files can be fetched
+func (s *FileService) UpdateFileSettings(ctx context.Context, workspaceID int64, req UpdateFileSettingsRequest) (*FileSettingsResponse, error) {
+	current, err := s.fileSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := db.UpsertWorkspaceFileSettingsParams{
+		WorkspaceID:        workspaceID,
+		ImageWatermark:     req.ImageWatermark,
+		PublicFiles:        current.PublicFiles,
+		PublicFileReferers: current.PublicFileReferers,
+	}
+	if req.PublicFiles != nil {
+		params.PublicFiles = *req.PublicFiles
+	}
+	if req.PublicFileReferers != nil {
+		params.PublicFileReferers = normalizeRefererHosts(req.PublicFileReferers)
+	}
+
+	settings, err := s.store.UpsertWorkspaceFileSettings(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update file settings: %w", err)
+	}
+
+	return newFileSettingsResponse(settings), nil
+}
+
+// fileSettings returns a workspace's settings, or the defaults when it has none
+func (s *FileService) fileSettings(ctx context.Context, workspaceID int64) (db.WorkspaceSetting, error) {
+	settings, err := s.store.GetWorkspaceSettings(ctx, workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.WorkspaceSetting{
+				WorkspaceID:    workspaceID,
+				ImageWatermark: ImageWatermarkOff,
+				PublicFiles:    true,
+			}, nil
+		}
+		return db.WorkspaceSetting{}, fmt.Errorf("failed to get workspace settings: %w", err)
+	}
+	return settings, nil
+}
+
+func newFileSettingsResponse(settings db.WorkspaceSetting) *FileSettingsResponse {
+	referers := settings.PublicFileReferers
+	if referers == nil {
+		referers = []string{}
+	}
+
+	return &FileSettingsResponse{
+		WorkspaceID:        settings.WorkspaceID,
+		ImageWatermark:     settings.ImageWatermark,
+		PublicFiles:        settings.PublicFiles,
+		PublicFileReferers: referers,
+	}
+}
+
+// OpenWatermarkedImage opens a copy of an image stamped with the workspace
+// name and the downloader's email, when the workspace watermarks it. Copies
+// are made on the first download and kept next to the original, one per
+// user, so later downloads are served from disk. It returns nil when the
+// image is served as it is, which includes formats that can't be decoded.
+// The key identifies the copy's content for caching.
+func (s *FileService) OpenWatermarkedImage(ctx context.Context, file *db.File, user UserResponse) (content *os.File, key string, err error) {
+	if !s.isImageFile(file.MimeType) {
+		return nil, "", nil
+	}
+
+	settings, err := s.fileSettings(ctx, file.WorkspaceID)
+	if err != nil {
+		return nil, "", err
+	}
+	watermarked, err := s.isWatermarked(ctx, file, settings)
+	if err != nil || !watermarked {
+		return nil, "", err
+	}
+
+	workspace, err := s.store.GetWorkspace(ctx, file.WorkspaceID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	// The key changes with the text, so renaming the workspace or changing
+	// the email makes a new copy
+	text := workspace.Name + " - " + user.Email
+	sum := sha256.Sum256([]byte(file.FileHash + "\x00" + text))
+	key = hex.EncodeToString(sum[:8])
+	path := watermarkedImagePath(file.FilePath, user.ID, key)
+
+	content, err = os.Open(path)
+	if err == nil {
+		return content, key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, "", fmt.Errorf("failed to open watermarked image: %w", err)
+	}
+
+	created, err := createWatermarkedImage(file.FilePath, path, text)
+	if err != nil {
+		return nil, "", err
+	}
+	if !created {
+		return nil, "", nil
+	}
+
+	content, err = os.Open(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open watermarked image: %w", err)
+	}
+	return content, key, nil
+}
+
+// isWatermarked reports whether downloads of a file are stamped with a watermark
+func (s *FileService) isWatermarked(ctx context.Context, file *db.File, settings db.WorkspaceSetting) (bool, error) {
+	if !s.isImageFile(file.MimeType) {
+		return false, nil
+	}
+
+	switch settings.ImageWatermark {
+	case ImageWatermarkAll:
+		return true, nil
+	case ImageWatermarkPrivateChannels:
+		private, err := s.store.IsFileInPrivateChannel(ctx, file.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to check file channels: %w", err)
+		}
+		return private, nil
+	default:
+		return false, nil
+	}
+}
+
+// watermarkedImagePath is where a user's watermarked copy of an image is kept
+func watermarkedImagePath(filePath string, userID int64, key string) string {
+	ext := filepath.Ext(filePath)
+	return fmt.Sprintf("%s_wm_%d_%s%s", strings.TrimSuffix(filePath, ext), userID, key, ext)
+}
+
+// removeWatermarkedImages deletes the watermarked copies made of an image
+func removeWatermarkedImages(filePath string) {
+	ext := filepath.Ext(filePath)
+	paths, err := filepath.Glob(strings.TrimSuffix(filePath, ext) + "_wm_*" + ext)
+	if err != nil {
+		return
+	}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("Warning: failed to delete watermarked image from disk: %v\n", err)
+		}
+	}
+}
+
+// createWatermarkedImage writes a watermarked copy of an image. It reports
+// false without an error when the image can't be decoded. The copy is
+// written under a temporary name first so concurrent downloads never read
+// a partial file.
+func createWatermarkedImage(srcPath, dstPath, text string) (bool, error) {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer src.Close()
+
+	img, format, err := decodeImage(src)
+	if err != nil {
+		return false, nil
+	}
+
+	tmpPath := dstPath + "." + uuid.New().String() + ".tmp"
+	if err := writeImage(tmpPath, watermarkImage(img, text), format); err != nil {
+		return false, fmt.Errorf("failed to write watermarked image: %w", err)
+	}
+	if err := os.Rename(tmpPath, dstPath); err != nil {
+		os.Remove(tmpPath)
+		return false, fmt.Errorf("failed to save watermarked image: %w", err)
+	}
+	return true, nil
+}
+
+// watermarkColor is mid gray so the watermark shows on light and dark images
+var watermarkColor = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+
+// watermarkOpacity is how strongly the watermark covers the image, out of 255
+const watermarkOpacity = 90
+
+// watermarkImage stamps text across an image in staggered rows, scaled to
+// the image so it stays legible without hiding the content
+func watermarkImage(img image.Image, text string) *image.RGBA {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+
+	width, height := dst.Bounds().Dx(), dst.Bounds().Dy()
+	scale := max(1, min(width, height)/300)
+	glyphs := []rune(strings.ToUpper(text) + "   ")
+	textWidth := len(glyphs) * (glyphWidth + 1) * scale
+	lineHeight := (glyphHeight + 2) * scale
+	rowSpacing := lineHeight * 5
+
+	for row, y := 0, lineHeight; y < height; row, y = row+1, y+rowSpacing {
+		// Every other row shifts by half the text so the rows are staggered
+		x := -(row % 2) * textWidth / 2
+		for ; x < width; x += textWidth {
+			drawText(dst, glyphs, x, y, scale)
+		}
+	}
+
+	return dst
+}
+
+// drawText blends text onto an image with the top left corner of the first
+// glyph at x, y, each font pixel becoming a scale by scale block
+func drawText(dst *image.RGBA, glyphs []rune, x, y, scale int) {
+	for i, r := range glyphs {
+		glyph, ok := watermarkFont[r]
+		if !ok {
+			glyph = watermarkFont['?']
+		}
+		left := x + i*(glyphWidth+1)*scale
+		for gy, bits := range glyph {
+			for gx := 0; gx < glyphWidth; gx++ {
+				if bits&(1<<(glyphWidth-1-gx)) == 0 {
+					continue
+				}
+				blendBlock(dst, left+gx*scale, y+gy*scale, scale)
+			}
+		}
+	}
+}
+
+// blendBlock blends the watermark color into a square of pixels
+func blendBlock(dst *image.RGBA, x, y, size int) {
+	block := image.Rect(x, y, x+size, y+size).Intersect(dst.Bounds())
+	for py := block.Min.Y; py < block.Max.Y; py++ {
+		for px := block.Min.X; px < block.Max.X; px++ {
+			i := dst.PixOffset(px, py)
+			dst.Pix[i] = blendChannel(dst.Pix[i], watermarkColor.R)
+			dst.Pix[i+1] = blendChannel(dst.Pix[i+1], watermarkColor.G)
+			dst.Pix[i+2] = blendChannel(dst.Pix[i+2], watermarkColor.B)
+		}
+	}
+}
+
+func blendChannel(under, over uint8) uint8 {
+	return uint8((int(under)*(255-watermarkOpacity) + int(over)*watermarkOpacity) / 255)
+}
+
+// Size of the watermark font's glyphs in font pixels
+const (
+	glyphWidth  = 5
+	glyphHeight = 7
+)
+
+// watermarkFont is a 5x7 bitmap font covering what workspace names and
+// email addresses usually contain. Each row's low five bits are its pixels,
+// left to right. Other characters are drawn as a question mark.
+var watermarkFont = map[rune][glyphHeight]uint8{
+	' ':  {},
+	'.':  {0x00, 0x00, 0x00, 0x00, 0x00, 0x0C, 0x0C},
+	'@':  {0x0E, 0x11, 0x17, 0x15, 0x17, 0x10, 0x0F},
+	'-':  {0x00, 0x00, 0x00, 0x1F, 0x00, 0x00, 0x00},
+	'_':  {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1F},
+	'+':  {0x00, 0x04, 0x04, 0x1F, 0x04, 0x04, 0x00},
+	'\'': {0x04, 0x04, 0x08, 0x00, 0x00, 0x00, 0x00},
+	'&':  {0x0C, 0x12, 0x14, 0x08, 0x15, 0x12, 0x0D},
+	'?':  {0x0E, 0x11, 0x01, 0x02, 0x04, 0x00, 0x04},
+	'0':  {0x0E, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0E},
+	'1':  {0x04, 0x0C, 0x04, 0x04, 0x04, 0x04, 0x0E},
+	'2':  {0x0E, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1F},
+	'3':  {0x1F, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0E},
+	'4':  {0x02, 0x06, 0x0A, 0x12, 0x1F, 0x02, 0x02},
+	'5':  {0x1F, 0x10, 0x1E, 0x01, 0x01, 0x11, 0x0E},
+	'6':  {0x06, 0x08, 0x10, 0x1E, 0x11, 0x11, 0x0E},
+	'7':  {0x1F, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08},
+	'8':  {0x0E, 0x11, 0x11, 0x0E, 0x11, 0x11, 0x0E},
+	'9':  {0x0E, 0x11, 0x11, 0x0F, 0x01, 0x02, 0x0C},
+	'A':  {0x0E, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x11},
+	'B':  {0x1E, 0x11, 0x11, 0x1E, 0x11, 0x11, 0x1E},
+	'C':  {0x0E, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0E},
+	'D':  {0x1E, 0x11, 0x11, 0x11, 0x11, 0x11, 0x1E},
+	'E':  {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x1F},
+	'F':  {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x10},
+	'G':  {0x0E, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0F},
+	'H':  {0x11, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x11},
+	'I':  {0x0E, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0E},
+	'J':  {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0C},
+	'K':  {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11},
+	'L':  {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1F},
+	'M':  {0x11, 0x1B, 0x15, 0x15, 0x11, 0x11, 0x11},
+	'N':  {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11},
+	'O':  {0x0E, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E},
+	'P':  {0x1E, 0x11, 0x11, 0x1E, 0x10, 0x10, 0x10},
+	'Q':  {0x0E, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0D},
+	'R':  {0x1E, 0x11, 0x11, 0x1E, 0x14, 0x12, 0x11},
+	'S':  {0x0F, 0x10, 0x10, 0x0E, 0x01, 0x01, 0x1E},
+	'T':  {0x1F, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+	'U':  {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E},
+	'V':  {0x11, 0x11, 0x11, 0x11, 0x11, 0x0A, 0x04},
+	'W':  {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0A},
+	'X':  {0x11, 0x11, 0x0A, 0x04, 0x0A, 0x11, 0x11},
+	'Y':  {0x11, 0x11, 0x11, 0x0A, 0x04, 0x04, 0x04},
+	'Z':  {0x1F, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1F},
+}
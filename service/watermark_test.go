@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatermarkImage(t *testing.T) {
+	white := image.NewRGBA(image.Rect(10, 10, 410, 310))
+	draw.Draw(white, white.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	marked := watermarkImage(white, "Acme - ada@example.com")
+	require.Equal(t, image.Rect(0, 0, 400, 300), marked.Bounds())
+
+	stamped := 0
+	for y := 0; y < 300; y++ {
+		for x := 0; x < 400; x++ {
+			r, g, b, _ := marked.At(x, y).RGBA()
+			if r != 0xffff || g != 0xffff || b != 0xffff {
+				stamped++
+				// Blended, not painted over
+				require.Greater(t, r, uint32(0x8000))
+			}
+		}
+	}
+	require.Greater(t, stamped, 0)
+	require.Less(t, stamped, 400*300/4)
+}
+
+func writeTestPNG(t *testing.T, path string) {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(f, img))
+	require.NoError(t, f.Close())
+}
+
+func TestFileService_OpenWatermarkedImage(t *testing.T) {
+	const workspaceID = int64(5)
+	ctx := context.Background()
+	user := UserResponse{ID: 7, Email: "ada@example.com"}
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "diagram.png")
+	writeTestPNG(t, filePath)
+	file := &db.File{ID: 3, WorkspaceID: workspaceID, FilePath: filePath, MimeType: "image/png", FileHash: "abc"}
+
+	t.Run("Off", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().GetWorkspaceSettings(gomock.Any(), workspaceID).Return(db.WorkspaceSetting{}, sql.ErrNoRows)
+
+		content, _, err := NewFileService(store, util.Config{}, nil).OpenWatermarkedImage(ctx, file, user)
+		require.NoError(t, err)
+		require.Nil(t, content)
+	})
+
+	t.Run("NotInPrivateChannel", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetWorkspaceSettings(gomock.Any(), workspaceID).
+			Return(db.WorkspaceSetting{ImageWatermark: ImageWatermarkPrivateChannels}, nil)
+		store.EXPECT().IsFileInPrivateChannel(gomock.Any(), file.ID).Return(false, nil)
+
+		content, _, err := NewFileService(store, util.Config{}, nil).OpenWatermarkedImage(ctx, file, user)
+		require.NoError(t, err)
+		require.Nil(t, content)
+	})
+
+	t.Run("CachedPerUser", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			GetWorkspaceSettings(gomock.Any(), workspaceID).
+			Times(2).
+			Return(db.WorkspaceSetting{ImageWatermark: ImageWatermarkAll}, nil)
+		store.EXPECT().
+			GetWorkspace(gomock.Any(), workspaceID).
+			Times(2).
+			Return(db.Workspace{ID: workspaceID, Name: "Acme"}, nil)
+
+		fileService := NewFileService(store, util.Config{}, nil)
+		content, key, err := fileService.OpenWatermarkedImage(ctx, file, user)
+		require.NoError(t, err)
+		require.NotNil(t, content)
+		require.NotEmpty(t, key)
+		first := content.Name()
+		require.NoError(t, content.Close())
+		require.Equal(t, watermarkedImagePath(filePath, user.ID, key), first)
+
+		_, format, err := image.DecodeConfig(mustOpen(t, first))
+		require.NoError(t, err)
+		require.Equal(t, "png", format)
+
+		// The second download is served from the copy
+		info, err := os.Stat(first)
+		require.NoError(t, err)
+		content, secondKey, err := fileService.OpenWatermarkedImage(ctx, file, user)
+		require.NoError(t, err)
+		require.Equal(t, key, secondKey)
+		require.Equal(t, first, content.Name())
+		require.NoError(t, content.Close())
+		again, err := os.Stat(first)
+		require.NoError(t, err)
+		require.Equal(t, info.ModTime(), again.ModTime())
+
+		removeWatermarkedImages(filePath)
+		require.NoFileExists(t, first)
+		require.FileExists(t, filePath)
+	})
+}
+
+func mustOpen(t *testing.T, path string) *os.File {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
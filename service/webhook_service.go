@@ -0,0 +1,435 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Database triggers write file lifecycle events to the event outbox and queue
+// a delivery for every webhook of the workspace subscribed to the event. A
+// worker POSTs the deliveries, signed with the webhook's secret, and retries
+// failed ones with a growing delay. Webhooks only reach public addresses: the
+// URL's host is checked when the webhook is created and every connection is
+// checked again when it's dialed, and redirects aren't followed, so a webhook
+// can't be pointed at the server's own network.
+
+// Webhook event types
+const (
+	WebhookEventFileUploaded = "file.uploaded"
+	WebhookEventFileDeleted  = "file.deleted"
+	WebhookEventFileShared   = "file.shared"
+	WebhookEventFileScanned  = "file.scanned"
+)
+
+const (
+	// webhookDeliveriesPerRun caps how many deliveries one claim takes
+	webhookDeliveriesPerRun = 50
+
+	// webhookStaleAfter is how long a delivery can stay in sending before
+	// another worker takes it again
+	webhookStaleAfter = 5 * time.Minute
+
+	// maxWebhookErrorSize caps how much of a failed response is kept as the
+	// delivery's last error
+	maxWebhookErrorSize = 512
+)
+
+// errWebhookAddress is returned when a webhook URL leads to an address that
+// isn't public
+var errWebhookAddress = errors.New("webhook URL must not point to a private, loopback or link-local address")
+
+// CreateWebhookRequest subscribes a URL to file events
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,url,max=2048"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,oneof=file.uploaded file.deleted file.shared file.scanned"`
+}
+
+// WebhookResponse is an outgoing webhook. The secret is only returned when
+// the webhook is created.
+type WebhookResponse struct {
+	ID          int64     `json:"id"`
+	WorkspaceID int64     `json:"workspace_id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"event_types"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedBy   *int64    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook
+type WebhookPayload struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	WorkspaceID int64           `json:"workspace_id"`
+	Data        json.RawMessage `json:"data"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+// WebhookService manages a workspace's outgoing webhooks and delivers the
+// events they subscribe to
+type WebhookService struct {
+	store       db.OutboxStore
+	client      *http.Client
+	maxAttempts int32
+	retryDelay  time.Duration
+	retention   time.Duration
+
+	// lookupIPAddr resolves webhook hosts and allowAddress decides which
+	// addresses webhooks may reach
+	lookupIPAddr func(ctx context.Context, host string) ([]net.IPAddr, error)
+	allowAddress func(ip net.IP) bool
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(store db.OutboxStore, config util.Config) *WebhookService {
+	service := &WebhookService{
+		store:        store,
+		maxAttempts:  config.WebhookMaxAttempts,
+		retryDelay:   config.WebhookRetryDelay,
+		retention:    config.WebhookRetention,
+		lookupIPAddr: net.DefaultResolver.LookupIPAddr,
+		allowAddress: isPublicAddress,
+	}
+
+	// The address is checked once it's resolved, right before connecting, so
+	// a host that resolves differently after the webhook was created still
+	// can't reach a private address. Deliveries never go through a proxy,
+	// which would hide the address.
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !service.allowAddress(ip) {
+				return errWebhookAddress
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	service.client = &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: transport,
+		// A redirect could lead anywhere, so the redirect response itself is
+		// the result and counts as a failed delivery
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	if service.maxAttempts <= 0 {
+		service.maxAttempts = 8
+	}
+	if service.retryDelay <= 0 {
+		service.retryDelay = 30 * time.Second
+	}
+	if service.retention <= 0 {
+		service.retention = 7 * 24 * time.Hour
+	}
+	return service
+}
+
+// CreateWebhook subscribes a URL to a workspace's file events. The response
+// holds the secret deliveries are signed with; it isn't shown again.
+func (s *WebhookService) CreateWebhook(ctx context.Context, workspaceID, userID int64, req CreateWebhookRequest) (*WebhookResponse, error) {
+	endpoint, err := url.Parse(req.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, errors.New("webhook URL must be an http(s) URL")
+	}
+	if err := s.checkHost(ctx, endpoint.Hostname()); err != nil {
+		return nil, err
+	}
+
+	eventTypes := make([]string, 0, len(req.EventTypes))
+	subscribed := make(map[string]bool, len(req.EventTypes))
+	for _, eventType := range req.EventTypes {
+		if !subscribed[eventType] {
+			subscribed[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	webhook, err := s.store.CreateOutgoingWebhook(ctx, db.CreateOutgoingWebhookParams{
+		WorkspaceID: workspaceID,
+		Url:         req.URL,
+		Secret:      hex.EncodeToString(secret),
+		EventTypes:  eventTypes,
+		CreatedBy:   sql.NullInt64{Int64: userID, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	response := newWebhookResponse(webhook)
+	response.Secret = webhook.Secret
+	return response, nil
+}
+
+// checkHost resolves a webhook URL's host and refuses it unless every
+// address it resolves to is public
+func (s *WebhookService) checkHost(ctx context.Context, host string) error {
+	addrs, err := s.lookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("webhook URL host %q could not be resolved", host)
+	}
+	for _, addr := range addrs {
+		if !s.allowAddress(addr.IP) {
+			return errWebhookAddress
+		}
+	}
+	return nil
+}
+
+// isPublicAddress reports whether an IP is reachable on the public internet,
+// as opposed to loopback, private, link-local (which includes cloud metadata
+// endpoints), unspecified or multicast addresses
+func isPublicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// ListWebhooks returns a workspace's outgoing webhooks
+func (s *WebhookService) ListWebhooks(ctx context.Context, workspaceID int64) ([]*WebhookResponse, error) {
+	webhooks, err := s.store.ListOutgoingWebhooks(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	response := make([]*WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		response[i] = newWebhookResponse(webhook)
+	}
+	return response, nil
+}
+
+// DeleteWebhook removes a webhook along with its pending deliveries
+func (s *WebhookService) DeleteWebhook(ctx context.Context, workspaceID, webhookID int64) error {
+	deleted, err := s.store.DeleteOutgoingWebhook(ctx, db.DeleteOutgoingWebhookParams{
+		ID:          webhookID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if deleted == 0 {
+		return errors.New("webhook not found")
+	}
+	return nil
+}
+
+// DeliverWebhooks sends the deliveries that are due, then removes finished
+// deliveries older than the retention period. Failed attempts are retried
+// with a growing delay until the attempt limit. It returns how many
+// deliveries succeeded.
+func (s *WebhookService) DeliverWebhooks(ctx context.Context) (int, error) {
+	delivered := 0
+
+	for {
+		deliveries, err := s.store.ClaimWebhookDeliveries(ctx, db.ClaimWebhookDeliveriesParams{
+			StaleBefore: time.Now().Add(-webhookStaleAfter),
+			Limit:       webhookDeliveriesPerRun,
+		})
+		if err != nil {
+			return delivered, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+		}
+
+		for _, delivery := range deliveries {
+			ok, err := s.processDelivery(ctx, delivery)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+		}
+
+		if len(deliveries) < webhookDeliveriesPerRun {
+			break
+		}
+	}
+
+	if _, err := s.store.DeleteOldWebhookDeliveries(ctx, time.Now().Add(-s.retention)); err != nil {
+		return delivered, fmt.Errorf("failed to delete old webhook deliveries: %w", err)
+	}
+
+	return delivered, nil
+}
+
+// processDelivery makes one attempt at a delivery and records the outcome,
+// reporting whether it succeeded
+func (s *WebhookService) processDelivery(ctx context.Context, delivery db.WebhookDelivery) (bool, error) {
+	webhook, err := s.store.GetOutgoingWebhook(ctx, delivery.WebhookID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// The webhook was deleted along with its deliveries
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get webhook %d: %w", delivery.WebhookID, err)
+	}
+
+	deliverErr := s.deliver(ctx, webhook, delivery)
+	switch {
+	case deliverErr == nil:
+		if err := s.store.MarkWebhookDelivered(ctx, delivery.ID); err != nil {
+			return false, fmt.Errorf("failed to record webhook delivery %d as delivered: %w", delivery.ID, err)
+		}
+		return true, nil
+
+	case delivery.Attempts >= s.maxAttempts:
+		fmt.Printf("Webhook delivery %d to webhook %d failed: %v\n", delivery.ID, webhook.ID, deliverErr)
+		if err := s.store.FailWebhookDelivery(ctx, db.FailWebhookDeliveryParams{
+			ID:        delivery.ID,
+			LastError: deliverErr.Error(),
+		}); err != nil {
+			return false, fmt.Errorf("failed to record webhook delivery %d as failed: %w", delivery.ID, err)
+		}
+		return false, nil
+
+	default:
+		if err := s.store.RetryWebhookDelivery(ctx, db.RetryWebhookDeliveryParams{
+			ID:            delivery.ID,
+			LastError:     deliverErr.Error(),
+			NextAttemptAt: time.Now().Add(s.retryBackoff(delivery.Attempts)),
+		}); err != nil {
+			return false, fmt.Errorf("failed to reschedule webhook delivery %d: %w", delivery.ID, err)
+		}
+		return false, nil
+	}
+}
+
+// deliver POSTs an event to a webhook. The body is signed with an HMAC-SHA256
+// of the webhook's secret, sent as X-Goslack-Signature: sha256=<hex>, so the
+// receiver can tell the event came from this server. Any 2xx response counts
+// as delivered.
+func (s *WebhookService) deliver(ctx context.Context, webhook db.OutgoingWebhook, delivery db.WebhookDelivery) error {
+	body, err := json.Marshal(WebhookPayload{
+		ID:          delivery.EventID,
+		Type:        delivery.EventType,
+		WorkspaceID: delivery.WorkspaceID,
+		Data:        delivery.Payload,
+		OccurredAt:  delivery.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "goslack-webhooks")
+	req.Header.Set("X-Goslack-Event", delivery.EventType)
+	req.Header.Set("X-Goslack-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Goslack-Signature", "sha256="+SignWebhookPayload(webhook.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// The URL can carry credentials, so only the underlying error is kept
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorSize))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of a webhook body, which
+// receivers compare with the X-Goslack-Signature header
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryBackoff doubles the retry delay with each failed attempt, up to a day
+func (s *WebhookService) retryBackoff(attempts int32) time.Duration {
+	delay := s.retryDelay
+	for i := int32(1); i < attempts && delay < 24*time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > 24*time.Hour {
+		delay = 24 * time.Hour
+	}
+	return delay
+}
+
+// StartDeliveryWorker sends due webhook deliveries on an interval until the
+// context is cancelled
+func (s *WebhookService) StartDeliveryWorker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			delivered, err := s.DeliverWebhooks(ctx)
+			if err != nil {
+				fmt.Printf("Error delivering webhooks: %v\n", err)
+			}
+			if delivered > 0 {
+				fmt.Printf("Delivered %d webhook events\n", delivered)
+			}
+		}
+	}
+}
+
+// newWebhookResponse converts a webhook to its API form, without its secret
+func newWebhookResponse(webhook db.OutgoingWebhook) *WebhookResponse {
+	response := &WebhookResponse{
+		ID:          webhook.ID,
+		WorkspaceID: webhook.WorkspaceID,
+		URL:         webhook.Url,
+		EventTypes:  webhook.EventTypes,
+		CreatedAt:   webhook.CreatedAt,
+	}
+	if webhook.CreatedBy.Valid {
+		response.CreatedBy = &webhook.CreatedBy.Int64
+	}
+	return response
+}
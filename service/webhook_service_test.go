@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookService_CreateWebhook(t *testing.T) {
+	const workspaceID, userID = int64(2), int64(5)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockOutboxStore(ctrl)
+	webhookService := NewWebhookService(store, util.Config{})
+	webhookService.lookupIPAddr = fakeLookupIPAddr
+
+	store.EXPECT().
+		CreateOutgoingWebhook(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateOutgoingWebhookParams) (db.OutgoingWebhook, error) {
+			require.Equal(t, workspaceID, arg.WorkspaceID)
+			require.Equal(t, []string{WebhookEventFileUploaded, WebhookEventFileScanned}, arg.EventTypes)
+			require.Len(t, arg.Secret, 64)
+			return db.OutgoingWebhook{
+				ID:          7,
+				WorkspaceID: arg.WorkspaceID,
+				Url:         arg.Url,
+				Secret:      arg.Secret,
+				EventTypes:  arg.EventTypes,
+				CreatedBy:   arg.CreatedBy,
+			}, nil
+		})
+
+	webhook, err := webhookService.CreateWebhook(context.Background(), workspaceID, userID, CreateWebhookRequest{
+		URL:        "https://hooks.example.com/files",
+		EventTypes: []string{WebhookEventFileUploaded, WebhookEventFileScanned, WebhookEventFileUploaded},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(7), webhook.ID)
+	require.Len(t, webhook.Secret, 64)
+	require.Equal(t, userID, *webhook.CreatedBy)
+
+	_, err = webhookService.CreateWebhook(context.Background(), workspaceID, userID, CreateWebhookRequest{
+		URL:        "ftp://hooks.example.com/files",
+		EventTypes: []string{WebhookEventFileUploaded},
+	})
+	require.EqualError(t, err, "webhook URL must be an http(s) URL")
+
+	for _, endpoint := range []string{
+		"http://127.0.0.1:8080/files",
+		"http://[::1]/files",
+		"http://10.0.0.5/files",
+		"http://169.254.169.254/latest/meta-data",
+		"http://0.0.0.0/files",
+		// A public name that resolves to a private address
+		"https://internal.example.com/files",
+	} {
+		_, err = webhookService.CreateWebhook(context.Background(), workspaceID, userID, CreateWebhookRequest{
+			URL:        endpoint,
+			EventTypes: []string{WebhookEventFileUploaded},
+		})
+		require.ErrorIs(t, err, errWebhookAddress, endpoint)
+	}
+
+	_, err = webhookService.CreateWebhook(context.Background(), workspaceID, userID, CreateWebhookRequest{
+		URL:        "https://unknown.example.com/files",
+		EventTypes: []string{WebhookEventFileUploaded},
+	})
+	require.EqualError(t, err, `webhook URL host "unknown.example.com" could not be resolved`)
+}
+
+// fakeLookupIPAddr resolves the hosts the tests use without DNS
+func fakeLookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	switch host {
+	case "hooks.example.com":
+		return []net.IPAddr{{IP: net.ParseIP("93.184.215.14")}}, nil
+	case "internal.example.com":
+		return []net.IPAddr{{IP: net.ParseIP("93.184.215.14")}, {IP: net.ParseIP("192.168.1.20")}}, nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IPAddr{{IP: ip}}, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestWebhookService_DeliverWebhooks(t *testing.T) {
+	const secret = "0123456789abcdef"
+
+	var mu sync.Mutex
+	var received []*http.Request
+	var bodies [][]byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, r)
+		bodies = append(bodies, body)
+		mu.Unlock()
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/up", http.StatusFound)
+			return
+		}
+		if r.URL.Path == "/down" {
+			http.Error(w, "try later", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	webhooks := map[int64]db.OutgoingWebhook{
+		1: {ID: 1, WorkspaceID: 2, Url: receiver.URL + "/up", Secret: secret},
+		2: {ID: 2, WorkspaceID: 2, Url: receiver.URL + "/down", Secret: secret},
+		4: {ID: 4, WorkspaceID: 2, Url: receiver.URL + "/moved", Secret: secret},
+	}
+	occurredAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	deliveries := []db.WebhookDelivery{
+		{ID: 10, WebhookID: 1, EventID: 100, EventType: WebhookEventFileUploaded, WorkspaceID: 2, Payload: json.RawMessage(`{"file_id":30}`), Attempts: 1, CreatedAt: occurredAt},
+		{ID: 11, WebhookID: 2, EventID: 100, EventType: WebhookEventFileUploaded, WorkspaceID: 2, Payload: json.RawMessage(`{"file_id":30}`), Attempts: 2, CreatedAt: occurredAt},
+		{ID: 12, WebhookID: 2, EventID: 101, EventType: WebhookEventFileDeleted, WorkspaceID: 2, Payload: json.RawMessage(`{"file_id":31}`), Attempts: 3, CreatedAt: occurredAt},
+		// The webhook was deleted after the delivery was claimed
+		{ID: 13, WebhookID: 3, EventID: 102, EventType: WebhookEventFileShared, WorkspaceID: 2, Payload: json.RawMessage(`{"file_id":32}`), Attempts: 1},
+		// Redirects aren't followed
+		{ID: 14, WebhookID: 4, EventID: 103, EventType: WebhookEventFileShared, WorkspaceID: 2, Payload: json.RawMessage(`{"file_id":33}`), Attempts: 1},
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockOutboxStore(ctrl)
+	store.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any()).Times(1).Return(deliveries, nil)
+	store.EXPECT().
+		GetOutgoingWebhook(gomock.Any(), gomock.Any()).
+		Times(5).
+		DoAndReturn(func(_ context.Context, id int64) (db.OutgoingWebhook, error) {
+			webhook, ok := webhooks[id]
+			if !ok {
+				return db.OutgoingWebhook{}, sql.ErrNoRows
+			}
+			return webhook, nil
+		})
+
+	store.EXPECT().MarkWebhookDelivered(gomock.Any(), int64(10)).Times(1).Return(nil)
+	store.EXPECT().
+		RetryWebhookDelivery(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.RetryWebhookDeliveryParams) error {
+			switch arg.ID {
+			case 11:
+				require.Contains(t, arg.LastError, "503")
+				// The second failure waits twice the retry delay
+				require.WithinDuration(t, time.Now().Add(2*time.Minute), arg.NextAttemptAt, 5*time.Second)
+			case 14:
+				require.Contains(t, arg.LastError, "302")
+			default:
+				t.Fatalf("unexpected retry of delivery %d", arg.ID)
+			}
+			return nil
+		})
+	store.EXPECT().
+		FailWebhookDelivery(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.FailWebhookDeliveryParams) error {
+			require.Equal(t, int64(12), arg.ID)
+			require.Contains(t, arg.LastError, "try later")
+			return nil
+		})
+	store.EXPECT().DeleteOldWebhookDeliveries(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+
+	webhookService := NewWebhookService(store, util.Config{WebhookMaxAttempts: 3, WebhookRetryDelay: time.Minute})
+	// The test receiver listens on loopback
+	webhookService.allowAddress = func(net.IP) bool { return true }
+	delivered, err := webhookService.DeliverWebhooks(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, delivered)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 4)
+
+	request := received[0]
+	require.Equal(t, http.MethodPost, request.Method)
+	require.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	require.Equal(t, WebhookEventFileUploaded, request.Header.Get("X-Goslack-Event"))
+	require.Equal(t, "10", request.Header.Get("X-Goslack-Delivery"))
+	require.Equal(t, "sha256="+SignWebhookPayload(secret, bodies[0]), request.Header.Get("X-Goslack-Signature"))
+
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal(bodies[0], &payload))
+	require.Equal(t, int64(100), payload.ID)
+	require.Equal(t, WebhookEventFileUploaded, payload.Type)
+	require.Equal(t, int64(2), payload.WorkspaceID)
+	require.JSONEq(t, `{"file_id":30}`, string(payload.Data))
+	require.Equal(t, occurredAt, payload.OccurredAt)
+}
+
+func TestWebhookService_DeliverToPrivateAddress(t *testing.T) {
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockOutboxStore(ctrl)
+	store.EXPECT().
+		ClaimWebhookDeliveries(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.WebhookDelivery{{ID: 10, WebhookID: 1, EventID: 100, EventType: WebhookEventFileUploaded, WorkspaceID: 2, Payload: json.RawMessage(`{}`), Attempts: 1}}, nil)
+	// The webhook's host resolved to a public address when it was created,
+	// and to loopback now
+	store.EXPECT().
+		GetOutgoingWebhook(gomock.Any(), int64(1)).
+		Times(1).
+		Return(db.OutgoingWebhook{ID: 1, WorkspaceID: 2, Url: receiver.URL, Secret: "secret"}, nil)
+	store.EXPECT().MarkWebhookDelivered(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().
+		RetryWebhookDelivery(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.RetryWebhookDeliveryParams) error {
+			require.Contains(t, arg.LastError, errWebhookAddress.Error())
+			return nil
+		})
+	store.EXPECT().DeleteOldWebhookDeliveries(gomock.Any(), gomock.Any()).Times(1).Return(int64(0), nil)
+
+	webhookService := NewWebhookService(store, util.Config{})
+	delivered, err := webhookService.DeliverWebhooks(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, delivered)
+	require.Zero(t, requests)
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// HMAC-SHA256 of "hello" keyed with "secret"
+	require.Equal(t, "88aab3ede8d3adf94d26ab90d3bafd4a2083070c3bcce9c014ee04a443847c0b", SignWebhookPayload("secret", []byte("hello")))
+}
@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// Outcomes of joining a workspace through an email domain
+const (
+	AutoJoinStatusJoined  = "joined"
+	AutoJoinStatusPending = "pending"
+)
+
+// Join request statuses
+const (
+	JoinRequestStatusPending  = "pending"
+	JoinRequestStatusApproved = "approved"
+	JoinRequestStatusRejected = "rejected"
+)
+
+// domainPattern matches a lowercase DNS name with at least two labels
+var domainPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+
+// workspaceAutoJoinServiceStore covers auto-join rules and the users and workspaces they match
+type workspaceAutoJoinServiceStore interface {
+	db.UserStore
+	db.WorkspaceStore
+}
+
+// WorkspaceAutoJoinService lets users with a verified email address in an
+// allowed domain find and join workspaces of their organization without an
+// invitation. Domains can require an admin to approve each request.
+type WorkspaceAutoJoinService struct {
+	store workspaceAutoJoinServiceStore
+}
+
+// NewWorkspaceAutoJoinService creates a new workspace auto-join service
+func NewWorkspaceAutoJoinService(store workspaceAutoJoinServiceStore) *WorkspaceAutoJoinService {
+	return &WorkspaceAutoJoinService{
+		store: store,
+	}
+}
+
+// AddAutoJoinDomain allows users of an email domain to join a workspace
+func (s *WorkspaceAutoJoinService) AddAutoJoinDomain(ctx context.Context, workspaceID, creatorID int64, req CreateAutoJoinDomainRequest) (AutoJoinDomainResponse, error) {
+	domain := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(req.Domain), "@"))
+	if !domainPattern.MatchString(domain) {
+		return AutoJoinDomainResponse{}, errors.New("invalid domain")
+	}
+
+	allowed, err := s.store.CreateWorkspaceAutoJoinDomain(ctx, db.CreateWorkspaceAutoJoinDomainParams{
+		WorkspaceID:      workspaceID,
+		Domain:           domain,
+		RequiresApproval: req.RequiresApproval,
+		CreatedBy:        sql.NullInt64{Int64: creatorID, Valid: true},
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return AutoJoinDomainResponse{}, errors.New("auto-join domain already exists")
+		}
+		return AutoJoinDomainResponse{}, fmt.Errorf("failed to add auto-join domain: %w", err)
+	}
+
+	return toAutoJoinDomainResponse(allowed), nil
+}
+
+// ListAutoJoinDomains lists the email domains allowed to join a workspace
+func (s *WorkspaceAutoJoinService) ListAutoJoinDomains(ctx context.Context, workspaceID int64) ([]AutoJoinDomainResponse, error) {
+	domains, err := s.store.ListWorkspaceAutoJoinDomains(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auto-join domains: %w", err)
+	}
+
+	responses := make([]AutoJoinDomainResponse, len(domains))
+	for i, domain := range domains {
+		responses[i] = toAutoJoinDomainResponse(domain)
+	}
+	return responses, nil
+}
+
+// RemoveAutoJoinDomain stops an email domain from joining a workspace. Pending
+// requests from the domain stay in the queue for admins to review.
+func (s *WorkspaceAutoJoinService) RemoveAutoJoinDomain(ctx context.Context, workspaceID, domainID int64) error {
+	rows, err := s.store.DeleteWorkspaceAutoJoinDomain(ctx, db.DeleteWorkspaceAutoJoinDomainParams{
+		ID:          domainID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove auto-join domain: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("auto-join domain not found")
+	}
+
+	return nil
+}
+
+// ListDiscoverableWorkspaces lists the workspaces of the user's organization
+// that allow the domain of their verified email address
+func (s *WorkspaceAutoJoinService) ListDiscoverableWorkspaces(ctx context.Context, userID int64) ([]DiscoverableWorkspaceResponse, error) {
+	user, err := s.getVerifiedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := s.store.ListAutoJoinWorkspaces(ctx, db.ListAutoJoinWorkspacesParams{
+		UserID:         user.ID,
+		OrganizationID: user.OrganizationID,
+		Domain:         emailDomain(user.Email),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list discoverable workspaces: %w", err)
+	}
+
+	responses := make([]DiscoverableWorkspaceResponse, len(workspaces))
+	for i, workspace := range workspaces {
+		responses[i] = DiscoverableWorkspaceResponse{
+			ID:               workspace.ID,
+			Name:             workspace.Name,
+			RequiresApproval: workspace.RequiresApproval,
+			RequestPending:   workspace.RequestPending,
+			CreatedAt:        workspace.CreatedAt,
+		}
+	}
+	return responses, nil
+}
+
+// AutoJoinWorkspace adds a user whose verified email domain is allowed to the
+// workspace as a member, or queues a join request when the domain requires approval
+func (s *WorkspaceAutoJoinService) AutoJoinWorkspace(ctx context.Context, userID, workspaceID int64) (AutoJoinResponse, error) {
+	user, err := s.getVerifiedUser(ctx, userID)
+	if err != nil {
+		return AutoJoinResponse{}, err
+	}
+
+	if user.WorkspaceID.Valid {
+		if user.WorkspaceID.Int64 == workspaceID {
+			return AutoJoinResponse{}, errors.New("user is already a member of this workspace")
+		}
+		return AutoJoinResponse{}, errors.New("user is already a member of another workspace")
+	}
+
+	workspace, err := s.store.GetWorkspace(ctx, workspaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return AutoJoinResponse{}, errors.New("workspace not found")
+		}
+		return AutoJoinResponse{}, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if workspace.OrganizationID != user.OrganizationID {
+		// Don't reveal workspaces of other organizations
+		return AutoJoinResponse{}, errors.New("workspace not found")
+	}
+
+	allowed, err := s.store.GetWorkspaceAutoJoinDomain(ctx, db.GetWorkspaceAutoJoinDomainParams{
+		WorkspaceID: workspaceID,
+		Domain:      emailDomain(user.Email),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return AutoJoinResponse{}, errors.New("access denied: email domain is not allowed to join this workspace")
+		}
+		return AutoJoinResponse{}, fmt.Errorf("failed to get auto-join domain: %w", err)
+	}
+
+	if allowed.RequiresApproval {
+		request, err := s.store.CreateWorkspaceJoinRequest(ctx, db.CreateWorkspaceJoinRequestParams{
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+		})
+		if err != nil {
+			if db.ErrorCode(err) == db.UniqueViolation {
+				return AutoJoinResponse{}, errors.New("join request already exists")
+			}
+			return AutoJoinResponse{}, fmt.Errorf("failed to create join request: %w", err)
+		}
+
+		resp := toJoinRequestResponse(request)
+		return AutoJoinResponse{Status: AutoJoinStatusPending, Request: &resp}, nil
+	}
+
+	joined, err := s.store.AddUserToWorkspace(ctx, db.AddUserToWorkspaceParams{
+		ID:          userID,
+		WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true},
+		Role:        "member",
+	})
+	if err != nil {
+		return AutoJoinResponse{}, fmt.Errorf("failed to join workspace: %w", err)
+	}
+
+	resp := s.toUserResponse(joined)
+	return AutoJoinResponse{Status: AutoJoinStatusJoined, User: &resp}, nil
+}
+
+// ListJoinRequests lists a workspace's join requests with a status, oldest first
+func (s *WorkspaceAutoJoinService) ListJoinRequests(ctx context.Context, workspaceID int64, status string, limit, offset int32) ([]JoinRequestResponse, error) {
+	requests, err := s.store.ListWorkspaceJoinRequests(ctx, db.ListWorkspaceJoinRequestsParams{
+		WorkspaceID: workspaceID,
+		Status:      status,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list join requests: %w", err)
+	}
+
+	responses := make([]JoinRequestResponse, len(requests))
+	for i, request := range requests {
+		responses[i] = toJoinRequestResponse(db.WorkspaceJoinRequest{
+			ID:          request.ID,
+			WorkspaceID: request.WorkspaceID,
+			UserID:      request.UserID,
+			Status:      request.Status,
+			ReviewedBy:  request.ReviewedBy,
+			ReviewedAt:  request.ReviewedAt,
+			CreatedAt:   request.CreatedAt,
+		})
+		responses[i].UserEmail = request.UserEmail
+		responses[i].UserFirstName = request.UserFirstName
+		responses[i].UserLastName = request.UserLastName
+	}
+	return responses, nil
+}
+
+// ReviewJoinRequest approves a pending join request, adding the user to the
+// workspace as a member, or rejects it
+func (s *WorkspaceAutoJoinService) ReviewJoinRequest(ctx context.Context, workspaceID, requestID, reviewerID int64, decision string) (JoinRequestResponse, error) {
+	request, err := s.store.GetWorkspaceJoinRequest(ctx, db.GetWorkspaceJoinRequestParams{
+		ID:          requestID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return JoinRequestResponse{}, errors.New("join request not found")
+		}
+		return JoinRequestResponse{}, fmt.Errorf("failed to get join request: %w", err)
+	}
+	if request.Status != JoinRequestStatusPending {
+		return JoinRequestResponse{}, errors.New("join request already reviewed")
+	}
+
+	if decision == "reject" {
+		request, err = s.store.ReviewWorkspaceJoinRequest(ctx, db.ReviewWorkspaceJoinRequestParams{
+			ID:          requestID,
+			WorkspaceID: workspaceID,
+			Status:      JoinRequestStatusRejected,
+			ReviewedBy:  sql.NullInt64{Int64: reviewerID, Valid: true},
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return JoinRequestResponse{}, errors.New("join request already reviewed")
+			}
+			return JoinRequestResponse{}, fmt.Errorf("failed to reject join request: %w", err)
+		}
+		return toJoinRequestResponse(request), nil
+	}
+
+	// The user may have joined a workspace since they asked
+	user, err := s.store.GetUser(ctx, request.UserID)
+	if err != nil {
+		return JoinRequestResponse{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.WorkspaceID.Valid {
+		return JoinRequestResponse{}, errors.New("user is already a member of another workspace")
+	}
+
+	result, err := s.store.ApproveWorkspaceJoinRequestTx(ctx, db.ApproveWorkspaceJoinRequestTxParams{
+		ID:          requestID,
+		WorkspaceID: workspaceID,
+		ReviewerID:  reviewerID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return JoinRequestResponse{}, errors.New("join request already reviewed")
+		}
+		return JoinRequestResponse{}, fmt.Errorf("failed to approve join request: %w", err)
+	}
+
+	return toJoinRequestResponse(result.Request), nil
+}
+
+// getVerifiedUser gets a user who has verified their email address
+func (s *WorkspaceAutoJoinService) getVerifiedUser(ctx context.Context, userID int64) (db.User, error) {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		return db.User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.EmailVerifiedAt.Valid {
+		return db.User{}, errors.New("access denied: email address is not verified")
+	}
+	return user, nil
+}
+
+// emailDomain returns the lowercase domain of an email address
+func emailDomain(email string) string {
+	if at := strings.LastIndexByte(email, '@'); at >= 0 {
+		return strings.ToLower(email[at+1:])
+	}
+	return ""
+}
+
+func toAutoJoinDomainResponse(domain db.WorkspaceAutoJoinDomain) AutoJoinDomainResponse {
+	resp := AutoJoinDomainResponse{
+		ID:               domain.ID,
+		WorkspaceID:      domain.WorkspaceID,
+		Domain:           domain.Domain,
+		RequiresApproval: domain.RequiresApproval,
+		CreatedAt:        domain.CreatedAt,
+	}
+
+	if domain.CreatedBy.Valid {
+		resp.CreatedBy = &domain.CreatedBy.Int64
+	}
+
+	return resp
+}
+
+func toJoinRequestResponse(request db.WorkspaceJoinRequest) JoinRequestResponse {
+	resp := JoinRequestResponse{
+		ID:          request.ID,
+		WorkspaceID: request.WorkspaceID,
+		UserID:      request.UserID,
+		Status:      request.Status,
+		CreatedAt:   request.CreatedAt,
+	}
+
+	if request.ReviewedBy.Valid {
+		resp.ReviewedBy = &request.ReviewedBy.Int64
+	}
+
+	if request.ReviewedAt.Valid {
+		resp.ReviewedAt = &request.ReviewedAt.Time
+	}
+
+	return resp
+}
+
+func (s *WorkspaceAutoJoinService) toUserResponse(user db.User) UserResponse {
+	resp := UserResponse{
+		ID:             user.ID,
+		OrganizationID: user.OrganizationID,
+		Email:          user.Email,
+		FirstName:      user.FirstName,
+		LastName:       user.LastName,
+		Role:           user.Role,
+		Handle:         user.Handle,
+		Title:          user.Title,
+		Pronouns:       user.Pronouns,
+		Phone:          user.Phone,
+		Timezone:       user.Timezone,
+		AvatarURLs:     avatarURLs(user.AvatarKey),
+		Initials:       userInitials(user.FirstName, user.LastName),
+		EmailVerified:  user.EmailVerifiedAt.Valid,
+		CreatedAt:      user.CreatedAt,
+	}
+
+	if user.WorkspaceID.Valid {
+		resp.WorkspaceID = &user.WorkspaceID.Int64
+	}
+
+	if user.CustomRoleID.Valid {
+		resp.CustomRoleID = &user.CustomRoleID.Int64
+	}
+
+	return resp
+}
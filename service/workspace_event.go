@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Database triggers record a normalized event whenever a message is created
+// or edited, a member joins or an upload completes. Data pipelines read the
+// events per workspace with a cursor, and a publisher can push them to a
+// message broker as well.
+
+// Workspace event types
+const (
+	WorkspaceEventMessageCreated = "message.created"
+	WorkspaceEventMessageEdited  = "message.edited"
+	WorkspaceEventMemberJoined   = "member.joined"
+	WorkspaceEventFileUploaded   = "file.uploaded"
+)
+
+// workspaceEventsPerPublish caps how many events one publish batch sends
+const workspaceEventsPerPublish = 500
+
+// WorkspaceEvent is a domain event in a workspace's event stream
+type WorkspaceEvent struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	WorkspaceID int64           `json:"workspace_id"`
+	ActorID     *int64          `json:"actor_id,omitempty"`
+	ChannelID   *int64          `json:"channel_id,omitempty"`
+	Data        json.RawMessage `json:"data"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+}
+
+// ListWorkspaceEventsRequest reads a workspace's events after a cursor
+type ListWorkspaceEventsRequest struct {
+	Since int64 `form:"since" binding:"omitempty,min=0"`
+	Limit int32 `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
+
+// WorkspaceEventsResponse is a page of a workspace's event stream. Pass
+// NextCursor as since to read the events that follow.
+type WorkspaceEventsResponse struct {
+	Events     []WorkspaceEvent `json:"events"`
+	NextCursor int64            `json:"next_cursor"`
+	HasMore    bool             `json:"has_more"`
+}
+
+// WorkspaceEventService serves workspace event streams and publishes them to
+// the configured publisher
+type WorkspaceEventService struct {
+	store     db.OutboxStore
+	publisher EventPublisher
+	delay     time.Duration
+	retention time.Duration
+}
+
+// NewWorkspaceEventService creates a new workspace event service. The
+// publisher can be nil, in which case events are only read through the API.
+func NewWorkspaceEventService(store db.OutboxStore, publisher EventPublisher, config util.Config) *WorkspaceEventService {
+	delay := config.EventStreamDelay
+	if delay <= 0 {
+		delay = 5 * time.Second
+	}
+
+	retention := config.EventStreamRetention
+	if retention <= 0 {
+		retention = 7 * 24 * time.Hour
+	}
+
+	return &WorkspaceEventService{
+		store:     store,
+		publisher: publisher,
+		delay:     delay,
+		retention: retention,
+	}
+}
+
+// ListEvents returns a workspace's events after the cursor, oldest first.
+// Events younger than the stream delay are held back, as transactions still
+// in flight may commit events with lower IDs, so a reader that follows the
+// cursor sees every event exactly once.
+func (s *WorkspaceEventService) ListEvents(ctx context.Context, workspaceID int64, req ListWorkspaceEventsRequest) (*WorkspaceEventsResponse, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = 100
+	}
+
+	// One extra event tells whether there are more
+	events, err := s.store.ListWorkspaceEvents(ctx, db.ListWorkspaceEventsParams{
+		WorkspaceID:   workspaceID,
+		AfterID:       req.Since,
+		CreatedBefore: time.Now().Add(-s.delay),
+		Limit:         limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace events: %w", err)
+	}
+
+	response := &WorkspaceEventsResponse{
+		Events:     []WorkspaceEvent{},
+		NextCursor: req.Since,
+	}
+	if len(events) > int(limit) {
+		events = events[:limit]
+		response.HasMore = true
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, newWorkspaceEvent(event))
+		response.NextCursor = event.ID
+	}
+
+	return response, nil
+}
+
+// PublishEvents sends the unpublished events older than the stream delay to
+// the publisher in the order they were recorded, then removes events older
+// than the retention. A batch that fails is sent again on the next run, so
+// consumers may see an event twice. It returns how many events were published.
+func (s *WorkspaceEventService) PublishEvents(ctx context.Context) (int, error) {
+	published := 0
+
+	for s.publisher != nil {
+		events, err := s.store.ListUnpublishedWorkspaceEvents(ctx, db.ListUnpublishedWorkspaceEventsParams{
+			CreatedAt: time.Now().Add(-s.delay),
+			Limit:     workspaceEventsPerPublish,
+		})
+		if err != nil {
+			return published, fmt.Errorf("failed to list unpublished workspace events: %w", err)
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		batch := make([]WorkspaceEvent, len(events))
+		ids := make([]int64, len(events))
+		for i, event := range events {
+			batch[i] = newWorkspaceEvent(event)
+			ids[i] = event.ID
+		}
+
+		if err := s.publisher.Publish(ctx, batch); err != nil {
+			return published, fmt.Errorf("failed to publish workspace events to %s: %w", s.publisher.Name(), err)
+		}
+		if err := s.store.MarkWorkspaceEventsPublished(ctx, ids); err != nil {
+			return published, fmt.Errorf("failed to mark workspace events published: %w", err)
+		}
+		published += len(events)
+
+		if len(events) < workspaceEventsPerPublish {
+			break
+		}
+	}
+
+	if _, err := s.store.DeleteWorkspaceEvents(ctx, time.Now().Add(-s.retention)); err != nil {
+		return published, fmt.Errorf("failed to delete old workspace events: %w", err)
+	}
+
+	return published, nil
+}
+
+// StartPublisher publishes workspace events on an interval until the context
+// is cancelled
+func (s *WorkspaceEventService) StartPublisher(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := s.PublishEvents(ctx)
+			if err != nil {
+				fmt.Printf("Error publishing workspace events: %v\n", err)
+			}
+			if published > 0 {
+				fmt.Printf("Published %d workspace events\n", published)
+			}
+		}
+	}
+}
+
+// newWorkspaceEvent converts a recorded event to its API form
+func newWorkspaceEvent(event db.WorkspaceEvent) WorkspaceEvent {
+	response := WorkspaceEvent{
+		ID:          event.ID,
+		Type:        event.EventType,
+		WorkspaceID: event.WorkspaceID,
+		Data:        event.Data,
+		OccurredAt:  event.CreatedAt,
+	}
+	if event.ActorID.Valid {
+		response.ActorID = &event.ActorID.Int64
+	}
+	if event.ChannelID.Valid {
+		response.ChannelID = &event.ChannelID.Int64
+	}
+	return response
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher keeps the events it's given, failing when err is set
+type recordingPublisher struct {
+	events []WorkspaceEvent
+	err    error
+}
+
+func (p *recordingPublisher) Name() string {
+	return "recording"
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, events []WorkspaceEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func testWorkspaceEvents(workspaceID int64, ids ...int64) []db.WorkspaceEvent {
+	events := make([]db.WorkspaceEvent, len(ids))
+	for i, id := range ids {
+		events[i] = db.WorkspaceEvent{
+			ID:          id,
+			WorkspaceID: workspaceID,
+			EventType:   WorkspaceEventMessageCreated,
+			ActorID:     sql.NullInt64{Int64: 5, Valid: true},
+			ChannelID:   sql.NullInt64{Int64: 4, Valid: true},
+			Data:        json.RawMessage(`{"message_id":20}`),
+			CreatedAt:   time.Now().Add(-time.Minute),
+		}
+	}
+	return events
+}
+
+func TestWorkspaceEventService_ListEvents(t *testing.T) {
+	const workspaceID = int64(2)
+	config := util.Config{EventStreamDelay: 30 * time.Second}
+
+	t.Run("HasMore", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockOutboxStore(ctrl)
+		store.EXPECT().
+			ListWorkspaceEvents(gomock.Any(), gomock.Any()).
+			Times(1).
+			DoAndReturn(func(_ context.Context, arg db.ListWorkspaceEventsParams) ([]db.WorkspaceEvent, error) {
+				require.Equal(t, int64(10), arg.AfterID)
+				require.Equal(t, int32(3), arg.Limit)
+				require.WithinDuration(t, time.Now().Add(-config.EventStreamDelay), arg.CreatedBefore, time.Second)
+				return testWorkspaceEvents(workspaceID, 11, 14, 15), nil
+			})
+
+		page, err := NewWorkspaceEventService(store, nil, config).ListEvents(context.Background(), workspaceID, ListWorkspaceEventsRequest{Since: 10, Limit: 2})
+		require.NoError(t, err)
+		require.True(t, page.HasMore)
+		require.Len(t, page.Events, 2)
+		require.Equal(t, int64(14), page.NextCursor)
+		require.Equal(t, WorkspaceEventMessageCreated, page.Events[0].Type)
+		require.Equal(t, int64(5), *page.Events[0].ActorID)
+		require.JSONEq(t, `{"message_id":20}`, string(page.Events[0].Data))
+	})
+
+	t.Run("CaughtUp", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockOutboxStore(ctrl)
+		store.EXPECT().ListWorkspaceEvents(gomock.Any(), gomock.Any()).Times(1).Return([]db.WorkspaceEvent{}, nil)
+
+		// The cursor stays put so the reader asks from the same place again
+		page, err := NewWorkspaceEventService(store, nil, config).ListEvents(context.Background(), workspaceID, ListWorkspaceEventsRequest{Since: 15})
+		require.NoError(t, err)
+		require.False(t, page.HasMore)
+		require.Empty(t, page.Events)
+		require.Equal(t, int64(15), page.NextCursor)
+	})
+}
+
+func TestWorkspaceEventService_PublishEvents(t *testing.T) {
+	const workspaceID = int64(2)
+	config := util.Config{EventStreamRetention: 24 * time.Hour}
+
+	t.Run("OK", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockOutboxStore(ctrl)
+		publisher := &recordingPublisher{}
+
+		gomock.InOrder(
+			store.EXPECT().ListUnpublishedWorkspaceEvents(gomock.Any(), gomock.Any()).Times(1).Return(testWorkspaceEvents(workspaceID, 1, 2), nil),
+			store.EXPECT().MarkWorkspaceEventsPublished(gomock.Any(), []int64{1, 2}).Times(1).Return(nil),
+			store.EXPECT().
+				DeleteWorkspaceEvents(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, createdAt time.Time) (int64, error) {
+					require.WithinDuration(t, time.Now().Add(-config.EventStreamRetention), createdAt, time.Second)
+					return 0, nil
+				}),
+		)
+
+		published, err := NewWorkspaceEventService(store, publisher, config).PublishEvents(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, published)
+		require.Len(t, publisher.events, 2)
+		require.Equal(t, int64(1), publisher.events[0].ID)
+	})
+
+	t.Run("PublishFails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockOutboxStore(ctrl)
+		publisher := &recordingPublisher{err: errors.New("broker unavailable")}
+
+		// Unmarked events are sent again on the next run
+		store.EXPECT().ListUnpublishedWorkspaceEvents(gomock.Any(), gomock.Any()).Times(1).Return(testWorkspaceEvents(workspaceID, 1), nil)
+		store.EXPECT().MarkWorkspaceEventsPublished(gomock.Any(), gomock.Any()).Times(0)
+
+		published, err := NewWorkspaceEventService(store, publisher, config).PublishEvents(context.Background())
+		require.Error(t, err)
+		require.Zero(t, published)
+	})
+
+	t.Run("NoPublisher", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		store := mockdb.NewMockOutboxStore(ctrl)
+		store.EXPECT().ListUnpublishedWorkspaceEvents(gomock.Any(), gomock.Any()).Times(0)
+		store.EXPECT().DeleteWorkspaceEvents(gomock.Any(), gomock.Any()).Times(1).Return(int64(3), nil)
+
+		published, err := NewWorkspaceEventService(store, nil, config).PublishEvents(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, published)
+	})
+}
@@ -4,24 +4,52 @@ import (
 	"context"
 	"crypto/rand"
 	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
+	"net/mail"
+	"net/url"
 	"strings"
 	"time"
 
 	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
 )
 
+// Invitation email delivery statuses
+const (
+	InvitationEmailStatusPending = "pending"
+	InvitationEmailStatusSent    = "sent"
+	InvitationEmailStatusFailed  = "failed"
+)
+
+// workspaceInvitationServiceStore covers invitations, join links and join requests and the users they admit
+type workspaceInvitationServiceStore interface {
+	db.UserStore
+	db.WorkspaceStore
+}
+
 // WorkspaceInvitationService handles workspace invitation logic
 type WorkspaceInvitationService struct {
-	store db.Store
+	store          workspaceInvitationServiceStore
+	emailService   *EmailService
+	appBaseURL     string
+	resendCooldown time.Duration
 }
 
 // NewWorkspaceInvitationService creates a new workspace invitation service
-func NewWorkspaceInvitationService(store db.Store) *WorkspaceInvitationService {
-	return &WorkspaceInvitationService{
-		store: store,
-	}
+func NewWorkspaceInvitationService(store workspaceInvitationServiceStore, emailService *EmailService, config util.Config) *WorkspaceInvitationService {
+	service := &WorkspaceInvitationService{
+		store:          store,
+		emailService:   emailService,
+		appBaseURL:     strings.TrimRight(config.AppBaseURL, "/"),
+		resendCooldown: config.InvitationResendCooldown,
+	}
+	// Queued invitation emails are categorized by their kind
+	emailService.OnResult(EmailKindInvitation, service.recordInvitationEmailResult)
+	return service
 }
 
 // InviteUserRequest represents the request to invite a user to workspace
@@ -45,6 +73,73 @@ type WorkspaceInvitationResponse struct {
 	CreatedAt      time.Time          `json:"created_at"`
 	Inviter        *UserResponse      `json:"inviter,omitempty"`
 	Workspace      *WorkspaceResponse `json:"workspace,omitempty"`
+	// Delivery of the invitation email: "pending", "sent" or "failed"
+	EmailStatus        string     `json:"email_status"`
+	EmailAttempts      int32      `json:"email_attempts"`
+	EmailLastAttemptAt *time.Time `json:"email_last_attempt_at,omitempty"`
+	EmailError         string     `json:"email_error,omitempty"`
+}
+
+// MaxBulkInvitations is the most invitations one bulk request can create
+const MaxBulkInvitations = 500
+
+// Bulk invitation row results
+const (
+	BulkInviteStatusInvited        = "invited"
+	BulkInviteStatusAlreadyMember  = "already_member"
+	BulkInviteStatusAlreadyInvited = "already_invited"
+	BulkInviteStatusDuplicate      = "duplicate"
+	BulkInviteStatusInvalidEmail   = "invalid_email"
+	BulkInviteStatusInvalidRole    = "invalid_role"
+)
+
+// BulkInviteEntry is one row of a bulk invitation. Rows are validated one by
+// one so a bad row doesn't reject the whole request.
+type BulkInviteEntry struct {
+	Email string `json:"email"`
+	Role  string `json:"role"` // "admin" or "member", defaults to "member"
+}
+
+// BulkInviteRequest represents the request to invite several users at once
+type BulkInviteRequest struct {
+	Invitations []BulkInviteEntry `json:"invitations" binding:"required,min=1,max=500"`
+}
+
+// BulkInviteResult reports what happened to one row of a bulk invitation
+type BulkInviteResult struct {
+	Row        int                          `json:"row"` // 1-based position in the request or CSV data rows
+	Email      string                       `json:"email"`
+	Role       string                       `json:"role,omitempty"`
+	Status     string                       `json:"status"`
+	Invitation *WorkspaceInvitationResponse `json:"invitation,omitempty"`
+}
+
+// BulkInviteResponse represents the per-row report of a bulk invitation
+type BulkInviteResponse struct {
+	Invited int                `json:"invited"`
+	Skipped int                `json:"skipped"`
+	Results []BulkInviteResult `json:"results"`
+}
+
+// CreateJoinLinkRequest represents the request to create a shareable join link
+type CreateJoinLinkRequest struct {
+	ExpiresAt *time.Time `json:"expires_at"`                                    // No expiry when omitted
+	MaxUses   *int32     `json:"max_uses" binding:"omitempty,min=1,max=100000"` // Unlimited when omitted
+}
+
+// JoinLinkResponse represents a workspace join link in API responses
+type JoinLinkResponse struct {
+	ID          int64      `json:"id"`
+	WorkspaceID int64      `json:"workspace_id"`
+	Token       string     `json:"token"`
+	URL         string     `json:"url"`
+	CreatedBy   *int64     `json:"created_by,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	MaxUses     *int32     `json:"max_uses,omitempty"`
+	UseCount    int32      `json:"use_count"`
+	Active      bool       `json:"active"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
 }
 
 // JoinWorkspaceRequest represents the request to join a workspace
@@ -110,9 +205,119 @@ func (s *WorkspaceInvitationService) InviteUser(ctx context.Context, workspaceID
 		return WorkspaceInvitationResponse{}, fmt.Errorf("failed to create invitation: %w", err)
 	}
 
+	// The invitation stands even if the email can't be sent, it can be resent
+	invitation = s.sendInvitationEmail(ctx, invitation)
+
 	return s.toInvitationResponse(invitation), nil
 }
 
+// ResendInvitation sends the email of a pending invitation again. Emails for
+// the same invitation are at least the resend cooldown apart.
+func (s *WorkspaceInvitationService) ResendInvitation(ctx context.Context, workspaceID, invitationID int64) (WorkspaceInvitationResponse, error) {
+	invitation, err := s.store.GetWorkspaceInvitation(ctx, invitationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WorkspaceInvitationResponse{}, errors.New("invitation not found")
+		}
+		return WorkspaceInvitationResponse{}, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if invitation.WorkspaceID != workspaceID {
+		return WorkspaceInvitationResponse{}, errors.New("invitation not found")
+	}
+
+	if invitation.Status != "pending" || !invitation.ExpiresAt.After(time.Now()) {
+		return WorkspaceInvitationResponse{}, errors.New("invitation is no longer pending")
+	}
+
+	if invitation.EmailLastAttemptAt.Valid {
+		retryAt := invitation.EmailLastAttemptAt.Time.Add(s.resendCooldown)
+		if wait := time.Until(retryAt); wait > 0 {
+			return WorkspaceInvitationResponse{}, fmt.Errorf("invitation email was sent recently, try again in %s", wait.Round(time.Second))
+		}
+	}
+
+	invitation = s.sendInvitationEmail(ctx, invitation)
+
+	return s.toInvitationResponse(invitation), nil
+}
+
+// sendInvitationEmail emails the invitation link to the invitee and records
+// whether the email went out. A queued email stays pending until the email
+// queue reports the outcome.
+func (s *WorkspaceInvitationService) sendInvitationEmail(ctx context.Context, invitation db.WorkspaceInvitation) db.WorkspaceInvitation {
+	status := InvitationEmailStatusSent
+	if s.emailService.Queued() {
+		status = InvitationEmailStatusPending
+	}
+	var emailError string
+	if err := s.deliverInvitationEmail(ctx, invitation); err != nil {
+		fmt.Printf("Error sending email for invitation %d: %v\n", invitation.ID, err)
+		status = InvitationEmailStatusFailed
+		emailError = err.Error()
+	}
+
+	updated, err := s.store.UpdateWorkspaceInvitationEmailStatus(ctx, db.UpdateWorkspaceInvitationEmailStatusParams{
+		ID:          invitation.ID,
+		EmailStatus: status,
+		EmailError:  emailError,
+	})
+	if err != nil {
+		fmt.Printf("Error recording email status for invitation %d: %v\n", invitation.ID, err)
+		return invitation
+	}
+
+	return updated
+}
+
+// deliverInvitationEmail renders the invitation email and sends it
+func (s *WorkspaceInvitationService) deliverInvitationEmail(ctx context.Context, invitation db.WorkspaceInvitation) error {
+	workspace, err := s.store.GetWorkspace(ctx, invitation.WorkspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+	inviter, err := s.store.GetUser(ctx, invitation.InviterID)
+	if err != nil {
+		return fmt.Errorf("failed to get inviter: %w", err)
+	}
+
+	message, err := s.emailService.Render(ctx, workspace.OrganizationID, EmailKindInvitation, invitation.InviteeEmail, invitationEmailData{
+		InviterName:   strings.TrimSpace(inviter.FirstName + " " + inviter.LastName),
+		WorkspaceName: workspace.Name,
+		Role:          invitation.Role,
+		Link:          s.appBaseURL + "/join?code=" + url.QueryEscape(invitation.InvitationCode),
+		Code:          invitation.InvitationCode,
+		ExpiresAt:     invitation.ExpiresAt.UTC().Format("January 2, 2006 at 15:04 MST"),
+	})
+	if err != nil {
+		return err
+	}
+	message.ReferenceID = invitation.ID
+
+	return s.emailService.Send(ctx, message)
+}
+
+// recordInvitationEmailResult records on the invitation whether its queued
+// email was sent
+func (s *WorkspaceInvitationService) recordInvitationEmailResult(ctx context.Context, delivery db.EmailDelivery) {
+	if !delivery.ReferenceID.Valid {
+		return
+	}
+
+	status := InvitationEmailStatusSent
+	if delivery.Status != EmailDeliverySent {
+		status = InvitationEmailStatusFailed
+	}
+
+	err := s.store.SetWorkspaceInvitationEmailResult(ctx, db.SetWorkspaceInvitationEmailResultParams{
+		ID:          delivery.ReferenceID.Int64,
+		EmailStatus: status,
+		EmailError:  delivery.LastError,
+	})
+	if err != nil {
+		fmt.Printf("Error recording email status for invitation %d: %v\n", delivery.ReferenceID.Int64, err)
+	}
+}
+
 // JoinWorkspace allows a user to join a workspace using invitation code
 func (s *WorkspaceInvitationService) JoinWorkspace(ctx context.Context, userID int64, req JoinWorkspaceRequest) (UserResponse, error) {
 	// Get the invitation
@@ -140,29 +345,19 @@ func (s *WorkspaceInvitationService) JoinWorkspace(ctx context.Context, userID i
 		return UserResponse{}, errors.New("user is already a member of another workspace")
 	}
 
-	// Add user to workspace
-	addUserArg := db.AddUserToWorkspaceParams{
-		ID:          userID,
-		WorkspaceID: sql.NullInt64{Int64: invitation.WorkspaceID, Valid: true},
-		Role:        invitation.Role,
-	}
-
-	updatedUser, err := s.store.AddUserToWorkspace(ctx, addUserArg)
-	if err != nil {
-		return UserResponse{}, fmt.Errorf("failed to add user to workspace: %w", err)
-	}
-
-	// Accept the invitation
-	_, err = s.store.AcceptWorkspaceInvitation(ctx, db.AcceptWorkspaceInvitationParams{
+	// Accept the invitation and add the user to its workspace
+	result, err := s.store.AcceptInvitationTx(ctx, db.AcceptInvitationTxParams{
 		InvitationCode: req.InvitationCode,
-		InviteeID:      sql.NullInt64{Int64: userID, Valid: true},
+		UserID:         userID,
 	})
 	if err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Warning: failed to update invitation status: %v\n", err)
+		if err == sql.ErrNoRows {
+			return UserResponse{}, errors.New("invalid or expired invitation code")
+		}
+		return UserResponse{}, fmt.Errorf("failed to join workspace: %w", err)
 	}
 
-	return s.toUserResponse(updatedUser), nil
+	return s.toUserResponse(result.User), nil
 }
 
 // ListWorkspaceInvitations lists invitations for a workspace
@@ -186,6 +381,349 @@ func (s *WorkspaceInvitationService) ListWorkspaceInvitations(ctx context.Contex
 	return responses, nil
 }
 
+// BulkInviteUsers validates each row, creates the invitations for the valid
+// ones in a single transaction and reports the outcome of every row. The
+// invitation emails are sent in the background after the invitations exist.
+func (s *WorkspaceInvitationService) BulkInviteUsers(ctx context.Context, workspaceID, inviterID int64, entries []BulkInviteEntry) (BulkInviteResponse, error) {
+	if len(entries) > MaxBulkInvitations {
+		return BulkInviteResponse{}, fmt.Errorf("invalid bulk invitation: at most %d invitations per request", MaxBulkInvitations)
+	}
+
+	results := make([]BulkInviteResult, len(entries))
+	seen := make(map[string]bool, len(entries))
+	var emails []string
+
+	for i, entry := range entries {
+		email := strings.TrimSpace(entry.Email)
+		role := strings.ToLower(strings.TrimSpace(entry.Role))
+		if role == "" {
+			role = "member"
+		}
+		results[i] = BulkInviteResult{Row: i + 1, Email: email, Role: role}
+
+		switch {
+		case !isPlainEmailAddress(email):
+			results[i].Status = BulkInviteStatusInvalidEmail
+		case role != "admin" && role != "member":
+			results[i].Status = BulkInviteStatusInvalidRole
+		case seen[strings.ToLower(email)]:
+			results[i].Status = BulkInviteStatusDuplicate
+		default:
+			seen[strings.ToLower(email)] = true
+			emails = append(emails, strings.ToLower(email))
+		}
+	}
+
+	// Look up existing users and pending invitations for all rows at once
+	existingUsers := make(map[string]db.User)
+	pendingInvitations := make(map[string]bool)
+	if len(emails) > 0 {
+		users, err := s.store.ListUsersByEmails(ctx, emails)
+		if err != nil {
+			return BulkInviteResponse{}, fmt.Errorf("failed to look up users: %w", err)
+		}
+		for _, user := range users {
+			existingUsers[strings.ToLower(user.Email)] = user
+		}
+
+		pending, err := s.store.ListPendingInvitationEmails(ctx, db.ListPendingInvitationEmailsParams{
+			WorkspaceID: workspaceID,
+			Emails:      emails,
+		})
+		if err != nil {
+			return BulkInviteResponse{}, fmt.Errorf("failed to look up pending invitations: %w", err)
+		}
+		for _, email := range pending {
+			pendingInvitations[email] = true
+		}
+	}
+
+	var toCreate []db.CreateWorkspaceInvitationParams
+	var createdRows []int
+	expiresAt := time.Now().Add(7 * 24 * time.Hour)
+
+	for i := range results {
+		if results[i].Status != "" {
+			continue
+		}
+
+		email := strings.ToLower(results[i].Email)
+		user, exists := existingUsers[email]
+		if exists && user.WorkspaceID.Valid && user.WorkspaceID.Int64 == workspaceID {
+			results[i].Status = BulkInviteStatusAlreadyMember
+			continue
+		}
+		if pendingInvitations[email] {
+			results[i].Status = BulkInviteStatusAlreadyInvited
+			continue
+		}
+
+		invitationCode, err := s.generateInvitationCode()
+		if err != nil {
+			return BulkInviteResponse{}, fmt.Errorf("failed to generate invitation code: %w", err)
+		}
+
+		var inviteeID sql.NullInt64
+		if exists {
+			inviteeID = sql.NullInt64{Int64: user.ID, Valid: true}
+		}
+
+		toCreate = append(toCreate, db.CreateWorkspaceInvitationParams{
+			WorkspaceID:    workspaceID,
+			InviterID:      inviterID,
+			InviteeEmail:   results[i].Email,
+			InviteeID:      inviteeID,
+			InvitationCode: invitationCode,
+			Role:           results[i].Role,
+			ExpiresAt:      expiresAt,
+		})
+		createdRows = append(createdRows, i)
+	}
+
+	var invitations []db.WorkspaceInvitation
+	if len(toCreate) > 0 {
+		var err error
+		invitations, err = s.store.CreateWorkspaceInvitationsTx(ctx, toCreate)
+		if err != nil {
+			return BulkInviteResponse{}, fmt.Errorf("failed to create invitations: %w", err)
+		}
+	}
+
+	response := BulkInviteResponse{Results: results}
+	for j, invitation := range invitations {
+		i := createdRows[j]
+		invitationResponse := s.toInvitationResponse(invitation)
+		results[i].Status = BulkInviteStatusInvited
+		results[i].Invitation = &invitationResponse
+	}
+	for _, result := range results {
+		if result.Status == BulkInviteStatusInvited {
+			response.Invited++
+		} else {
+			response.Skipped++
+		}
+	}
+
+	// Sending hundreds of emails would hold the request for too long
+	if len(invitations) > 0 {
+		go func() {
+			for _, invitation := range invitations {
+				s.sendInvitationEmail(context.Background(), invitation)
+			}
+		}()
+	}
+
+	return response, nil
+}
+
+// ParseInvitationCSV reads bulk invitation rows from CSV. The first column is
+// the email and the optional second column the role, unless a header row
+// names the "email" and "role" columns.
+func ParseInvitationCSV(r io.Reader) ([]BulkInviteEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	emailColumn, roleColumn := 0, 1
+	var entries []BulkInviteEntry
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		if first && isInvitationCSVHeader(record) {
+			roleColumn = -1
+			for i, name := range record {
+				switch strings.ToLower(strings.TrimSpace(name)) {
+				case "email":
+					emailColumn = i
+				case "role":
+					roleColumn = i
+				}
+			}
+			continue
+		}
+
+		var entry BulkInviteEntry
+		if emailColumn < len(record) {
+			entry.Email = record[emailColumn]
+		}
+		if roleColumn >= 0 && roleColumn < len(record) {
+			entry.Role = record[roleColumn]
+		}
+		entries = append(entries, entry)
+
+		if len(entries) > MaxBulkInvitations {
+			return nil, fmt.Errorf("invalid CSV: at most %d invitations per request", MaxBulkInvitations)
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("invalid CSV: no invitations")
+	}
+	return entries, nil
+}
+
+// isInvitationCSVHeader reports whether a CSV row is a header naming an email column
+func isInvitationCSVHeader(record []string) bool {
+	for _, name := range record {
+		if strings.EqualFold(strings.TrimSpace(name), "email") {
+			return true
+		}
+	}
+	return false
+}
+
+// isPlainEmailAddress reports whether s is a bare email address, without a
+// display name or angle brackets
+func isPlainEmailAddress(s string) bool {
+	address, err := mail.ParseAddress(s)
+	return err == nil && address.Name == "" && address.Address == s
+}
+
+// CreateJoinLink creates a link that adds anyone in the organization who opens
+// it to the workspace as a member
+func (s *WorkspaceInvitationService) CreateJoinLink(ctx context.Context, workspaceID, creatorID int64, req CreateJoinLinkRequest) (JoinLinkResponse, error) {
+	arg := db.CreateWorkspaceJoinLinkParams{
+		WorkspaceID: workspaceID,
+		CreatedBy:   sql.NullInt64{Int64: creatorID, Valid: true},
+	}
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			return JoinLinkResponse{}, errors.New("invalid join link: expiry must be in the future")
+		}
+		arg.ExpiresAt = sql.NullTime{Time: *req.ExpiresAt, Valid: true}
+	}
+	if req.MaxUses != nil {
+		arg.MaxUses = sql.NullInt32{Int32: *req.MaxUses, Valid: true}
+	}
+
+	token, err := generateLinkToken()
+	if err != nil {
+		return JoinLinkResponse{}, fmt.Errorf("failed to generate join link token: %w", err)
+	}
+	arg.Token = token
+
+	link, err := s.store.CreateWorkspaceJoinLink(ctx, arg)
+	if err != nil {
+		return JoinLinkResponse{}, fmt.Errorf("failed to create join link: %w", err)
+	}
+
+	return s.toJoinLinkResponse(link), nil
+}
+
+// ListJoinLinks lists a workspace's join links, newest first
+func (s *WorkspaceInvitationService) ListJoinLinks(ctx context.Context, workspaceID int64, limit, offset int32) ([]JoinLinkResponse, error) {
+	links, err := s.store.ListWorkspaceJoinLinks(ctx, db.ListWorkspaceJoinLinksParams{
+		WorkspaceID: workspaceID,
+		Limit:       limit,
+		Offset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list join links: %w", err)
+	}
+
+	responses := make([]JoinLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = s.toJoinLinkResponse(link)
+	}
+	return responses, nil
+}
+
+// RevokeJoinLink stops a join link from being used
+func (s *WorkspaceInvitationService) RevokeJoinLink(ctx context.Context, workspaceID, linkID int64) (JoinLinkResponse, error) {
+	link, err := s.store.RevokeWorkspaceJoinLink(ctx, db.RevokeWorkspaceJoinLinkParams{
+		ID:          linkID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return JoinLinkResponse{}, errors.New("join link not found")
+		}
+		return JoinLinkResponse{}, fmt.Errorf("failed to revoke join link: %w", err)
+	}
+
+	return s.toJoinLinkResponse(link), nil
+}
+
+// JoinWorkspaceByLink adds a user to the workspace of a join link as a member.
+// Links only work for users of the workspace's organization.
+func (s *WorkspaceInvitationService) JoinWorkspaceByLink(ctx context.Context, userID int64, token string) (UserResponse, error) {
+	link, err := s.store.GetWorkspaceJoinLinkByToken(ctx, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return UserResponse{}, errors.New("invalid or expired join link")
+		}
+		return UserResponse{}, fmt.Errorf("failed to get join link: %w", err)
+	}
+	if !joinLinkActive(link) {
+		return UserResponse{}, errors.New("invalid or expired join link")
+	}
+
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		return UserResponse{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.WorkspaceID.Valid {
+		if user.WorkspaceID.Int64 == link.WorkspaceID {
+			return UserResponse{}, errors.New("user is already a member of this workspace")
+		}
+		return UserResponse{}, errors.New("user is already a member of another workspace")
+	}
+
+	workspace, err := s.store.GetWorkspace(ctx, link.WorkspaceID)
+	if err != nil {
+		return UserResponse{}, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if workspace.OrganizationID != user.OrganizationID {
+		// Don't reveal workspaces of other organizations
+		return UserResponse{}, errors.New("invalid or expired join link")
+	}
+
+	result, err := s.store.JoinWorkspaceByLinkTx(ctx, db.JoinWorkspaceByLinkTxParams{
+		Token:  token,
+		UserID: userID,
+	})
+	if err != nil {
+		// The link was revoked or used up since it was checked
+		if err == sql.ErrNoRows {
+			return UserResponse{}, errors.New("invalid or expired join link")
+		}
+		return UserResponse{}, fmt.Errorf("failed to join workspace: %w", err)
+	}
+
+	return s.toUserResponse(result.User), nil
+}
+
+// joinLinkActive reports whether a join link can still be used
+func joinLinkActive(link db.WorkspaceJoinLink) bool {
+	if link.RevokedAt.Valid {
+		return false
+	}
+	if link.ExpiresAt.Valid && !link.ExpiresAt.Time.After(time.Now()) {
+		return false
+	}
+	if link.MaxUses.Valid && link.UseCount >= link.MaxUses.Int32 {
+		return false
+	}
+	return true
+}
+
+// generateLinkToken generates an unguessable URL-safe token for links
+func generateLinkToken() (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
 // RemoveUserFromWorkspace removes a user from workspace
 func (s *WorkspaceInvitationService) RemoveUserFromWorkspace(ctx context.Context, userID, workspaceID int64) (UserResponse, error) {
 	arg := db.RemoveUserFromWorkspaceParams{
@@ -244,6 +782,44 @@ func (s *WorkspaceInvitationService) ListWorkspaceMembers(ctx context.Context, w
 	return responses, nil
 }
 
+// AddEmailSuppressions marks the members that no longer get emails because
+// their address bounced or complained
+func (s *WorkspaceInvitationService) AddEmailSuppressions(ctx context.Context, members []UserResponse) error {
+	emails := make([]string, len(members))
+	for i, member := range members {
+		emails[i] = member.Email
+	}
+
+	suppressions, err := s.emailService.Suppressions(ctx, emails)
+	if err != nil {
+		return err
+	}
+
+	for i := range members {
+		if suppression, ok := suppressions[strings.ToLower(members[i].Email)]; ok {
+			members[i].EmailSuppression = &suppression
+		}
+	}
+	return nil
+}
+
+// LiftMemberEmailSuppression lets emails be sent to a workspace member again
+// after their address bounced or complained
+func (s *WorkspaceInvitationService) LiftMemberEmailSuppression(ctx context.Context, workspaceID, userID int64) error {
+	user, err := s.store.GetUser(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("user not found in workspace")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.WorkspaceID.Valid || user.WorkspaceID.Int64 != workspaceID {
+		return errors.New("user not found in workspace")
+	}
+
+	return s.emailService.LiftSuppression(ctx, user.Email)
+}
+
 func (s *WorkspaceInvitationService) toInvitationResponse(invitation db.WorkspaceInvitation) WorkspaceInvitationResponse {
 	resp := WorkspaceInvitationResponse{
 		ID:             invitation.ID,
@@ -255,6 +831,9 @@ func (s *WorkspaceInvitationService) toInvitationResponse(invitation db.Workspac
 		Status:         invitation.Status,
 		ExpiresAt:      invitation.ExpiresAt,
 		CreatedAt:      invitation.CreatedAt,
+		EmailStatus:    invitation.EmailStatus,
+		EmailAttempts:  invitation.EmailAttempts,
+		EmailError:     invitation.EmailError,
 	}
 
 	if invitation.InviteeID.Valid {
@@ -265,6 +844,40 @@ func (s *WorkspaceInvitationService) toInvitationResponse(invitation db.Workspac
 		resp.AcceptedAt = &invitation.AcceptedAt.Time
 	}
 
+	if invitation.EmailLastAttemptAt.Valid {
+		resp.EmailLastAttemptAt = &invitation.EmailLastAttemptAt.Time
+	}
+
+	return resp
+}
+
+func (s *WorkspaceInvitationService) toJoinLinkResponse(link db.WorkspaceJoinLink) JoinLinkResponse {
+	resp := JoinLinkResponse{
+		ID:          link.ID,
+		WorkspaceID: link.WorkspaceID,
+		Token:       link.Token,
+		URL:         s.appBaseURL + "/join-link/" + link.Token,
+		UseCount:    link.UseCount,
+		Active:      joinLinkActive(link),
+		CreatedAt:   link.CreatedAt,
+	}
+
+	if link.CreatedBy.Valid {
+		resp.CreatedBy = &link.CreatedBy.Int64
+	}
+
+	if link.ExpiresAt.Valid {
+		resp.ExpiresAt = &link.ExpiresAt.Time
+	}
+
+	if link.MaxUses.Valid {
+		resp.MaxUses = &link.MaxUses.Int32
+	}
+
+	if link.RevokedAt.Valid {
+		resp.RevokedAt = &link.RevokedAt.Time
+	}
+
 	return resp
 }
 
@@ -276,6 +889,14 @@ func (s *WorkspaceInvitationService) toUserResponse(user db.User) UserResponse {
 		FirstName:      user.FirstName,
 		LastName:       user.LastName,
 		Role:           user.Role,
+		Handle:         user.Handle,
+		Title:          user.Title,
+		Pronouns:       user.Pronouns,
+		Phone:          user.Phone,
+		Timezone:       user.Timezone,
+		AvatarURLs:     avatarURLs(user.AvatarKey),
+		Initials:       userInitials(user.FirstName, user.LastName),
+		EmailVerified:  user.EmailVerifiedAt.Valid,
 		CreatedAt:      user.CreatedAt,
 	}
 
@@ -283,6 +904,10 @@ func (s *WorkspaceInvitationService) toUserResponse(user db.User) UserResponse {
 		resp.WorkspaceID = &user.WorkspaceID.Int64
 	}
 
+	if user.CustomRoleID.Valid {
+		resp.CustomRoleID = &user.CustomRoleID.Int64
+	}
+
 	return resp
 }
 
@@ -294,6 +919,13 @@ func (s *WorkspaceInvitationService) toUserResponseFromMemberRow(user db.ListWor
 		FirstName:      user.FirstName,
 		LastName:       user.LastName,
 		Role:           user.Role,
+		Handle:         user.Handle,
+		Title:          user.Title,
+		Pronouns:       user.Pronouns,
+		Phone:          user.Phone,
+		Timezone:       user.Timezone,
+		AvatarURLs:     avatarURLs(user.AvatarKey),
+		Initials:       userInitials(user.FirstName, user.LastName),
 		CreatedAt:      user.CreatedAt,
 	}
 
@@ -301,5 +933,9 @@ func (s *WorkspaceInvitationService) toUserResponseFromMemberRow(user db.ListWor
 		resp.WorkspaceID = &user.WorkspaceID.Int64
 	}
 
+	if user.CustomRoleID.Valid {
+		resp.CustomRoleID = &user.CustomRoleID.Int64
+	}
+
 	return resp
 }
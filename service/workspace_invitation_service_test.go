@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceInvitationService_InviteUserSendsEmail(t *testing.T) {
+	ctx := context.Background()
+	workspace := db.Workspace{ID: 3, OrganizationID: 1, Name: "Acme"}
+	inviter := db.User{ID: 4, OrganizationID: 1, FirstName: "Grace", LastName: "Hopper"}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetUserByEmail(gomock.Any(), "ada@example.com").Times(1).Return(db.User{}, sql.ErrNoRows)
+
+	var invitation db.WorkspaceInvitation
+	store.EXPECT().
+		CreateWorkspaceInvitation(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateWorkspaceInvitationParams) (db.WorkspaceInvitation, error) {
+			invitation = db.WorkspaceInvitation{
+				ID:             9,
+				WorkspaceID:    arg.WorkspaceID,
+				InviterID:      arg.InviterID,
+				InviteeEmail:   arg.InviteeEmail,
+				InvitationCode: arg.InvitationCode,
+				Role:           arg.Role,
+				Status:         "pending",
+				ExpiresAt:      arg.ExpiresAt,
+				EmailStatus:    InvitationEmailStatusPending,
+			}
+			return invitation, nil
+		})
+	store.EXPECT().GetWorkspace(gomock.Any(), workspace.ID).Times(1).Return(workspace, nil)
+	store.EXPECT().GetUser(gomock.Any(), inviter.ID).Times(1).Return(inviter, nil)
+	store.EXPECT().
+		UpdateWorkspaceInvitationEmailStatus(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateWorkspaceInvitationEmailStatusParams) (db.WorkspaceInvitation, error) {
+			require.Equal(t, invitation.ID, arg.ID)
+			require.Equal(t, InvitationEmailStatusSent, arg.EmailStatus)
+			updated := invitation
+			updated.EmailStatus = arg.EmailStatus
+			updated.EmailAttempts = 1
+			updated.EmailLastAttemptAt = sql.NullTime{Time: time.Now(), Valid: true}
+			return updated, nil
+		})
+
+	emailService, sent := newCapturingEmailService(t, nil)
+	service := NewWorkspaceInvitationService(store, emailService, util.Config{AppBaseURL: "https://chat.example.com/"})
+
+	response, err := service.InviteUser(ctx, workspace.ID, inviter.ID, InviteUserRequest{Email: "ada@example.com", Role: "member"})
+	require.NoError(t, err)
+	require.Equal(t, InvitationEmailStatusSent, response.EmailStatus)
+	require.Equal(t, int32(1), response.EmailAttempts)
+
+	require.Len(t, *sent, 1)
+	email := (*sent)[0]
+	require.Equal(t, "ada@example.com", email.to)
+	require.Equal(t, "Grace Hopper invited you to join Acme on GoSlack", email.message.Header.Get("Subject"))
+
+	parts := emailParts(t, email.message)
+	link := "https://chat.example.com/join?code=" + invitation.InvitationCode
+	require.Contains(t, parts["text/plain"], link)
+	require.Contains(t, parts["text/html"], `href="`+link+`"`)
+}
+
+func TestWorkspaceInvitationService_InviteUserEmailFails(t *testing.T) {
+	ctx := context.Background()
+	invitation := db.WorkspaceInvitation{ID: 9, WorkspaceID: 3, InviterID: 4, InviteeEmail: "ada@example.com", InvitationCode: "ABCD1234", Role: "member"}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any()).Times(1).Return(db.User{}, sql.ErrNoRows)
+	store.EXPECT().CreateWorkspaceInvitation(gomock.Any(), gomock.Any()).Times(1).Return(invitation, nil)
+	store.EXPECT().GetWorkspace(gomock.Any(), gomock.Any()).Times(1).Return(db.Workspace{ID: 3, Name: "Acme"}, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{ID: 4}, nil)
+	store.EXPECT().
+		UpdateWorkspaceInvitationEmailStatus(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.UpdateWorkspaceInvitationEmailStatusParams) (db.WorkspaceInvitation, error) {
+			require.Equal(t, InvitationEmailStatusFailed, arg.EmailStatus)
+			require.Contains(t, arg.EmailError, "connection refused")
+			updated := invitation
+			updated.EmailStatus = arg.EmailStatus
+			updated.EmailError = arg.EmailError
+			return updated, nil
+		})
+
+	emailService, _ := newCapturingEmailService(t, errors.New("connection refused"))
+	service := NewWorkspaceInvitationService(store, emailService, util.Config{})
+
+	// The invitation is still created when the email fails
+	response, err := service.InviteUser(ctx, 3, 4, InviteUserRequest{Email: "ada@example.com", Role: "member"})
+	require.NoError(t, err)
+	require.Equal(t, InvitationEmailStatusFailed, response.EmailStatus)
+	require.NotEmpty(t, response.EmailError)
+}
+
+func TestWorkspaceInvitationService_ResendInvitation(t *testing.T) {
+	ctx := context.Background()
+	invitation := db.WorkspaceInvitation{
+		ID:             9,
+		WorkspaceID:    3,
+		InviterID:      4,
+		InviteeEmail:   "ada@example.com",
+		InvitationCode: "ABCD1234",
+		Role:           "member",
+		Status:         "pending",
+		ExpiresAt:      time.Now().Add(24 * time.Hour),
+		EmailStatus:    InvitationEmailStatusFailed,
+	}
+
+	testCases := []struct {
+		name       string
+		invitation func() db.WorkspaceInvitation
+		sends      bool
+		wantErr    string
+	}{
+		{
+			name: "OK",
+			invitation: func() db.WorkspaceInvitation {
+				resent := invitation
+				resent.EmailLastAttemptAt = sql.NullTime{Time: time.Now().Add(-10 * time.Minute), Valid: true}
+				return resent
+			},
+			sends: true,
+		},
+		{
+			name: "Cooldown",
+			invitation: func() db.WorkspaceInvitation {
+				resent := invitation
+				resent.EmailLastAttemptAt = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+				return resent
+			},
+			wantErr: "invitation email was sent recently",
+		},
+		{
+			name: "Accepted",
+			invitation: func() db.WorkspaceInvitation {
+				accepted := invitation
+				accepted.Status = "accepted"
+				return accepted
+			},
+			wantErr: "invitation is no longer pending",
+		},
+		{
+			name: "Expired",
+			invitation: func() db.WorkspaceInvitation {
+				expired := invitation
+				expired.ExpiresAt = time.Now().Add(-time.Hour)
+				return expired
+			},
+			wantErr: "invitation is no longer pending",
+		},
+		{
+			name: "OtherWorkspace",
+			invitation: func() db.WorkspaceInvitation {
+				other := invitation
+				other.WorkspaceID = 8
+				return other
+			},
+			wantErr: "invitation not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+
+			current := tc.invitation()
+			store.EXPECT().GetWorkspaceInvitation(gomock.Any(), invitation.ID).Times(1).Return(current, nil)
+			if tc.sends {
+				store.EXPECT().GetWorkspace(gomock.Any(), gomock.Any()).Times(1).Return(db.Workspace{ID: 3, Name: "Acme"}, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(db.User{ID: 4, FirstName: "Grace"}, nil)
+				store.EXPECT().UpdateWorkspaceInvitationEmailStatus(gomock.Any(), gomock.Any()).Times(1).Return(current, nil)
+			}
+
+			emailService, sent := newCapturingEmailService(t, nil)
+			service := NewWorkspaceInvitationService(store, emailService, util.Config{InvitationResendCooldown: 5 * time.Minute})
+
+			_, err := service.ResendInvitation(ctx, invitation.WorkspaceID, invitation.ID)
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				require.Empty(t, *sent)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, *sent, 1)
+		})
+	}
+}
+
+func TestWorkspaceInvitationService_BulkInviteUsers(t *testing.T) {
+	ctx := context.Background()
+	const workspaceID, inviterID = int64(3), int64(4)
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		ListUsersByEmails(gomock.Any(), gomock.Eq([]string{"ada@example.com", "member@example.com", "invited@example.com", "other@example.com"})).
+		Times(1).
+		Return([]db.User{
+			{ID: 20, Email: "Member@example.com", WorkspaceID: sql.NullInt64{Int64: workspaceID, Valid: true}},
+			{ID: 21, Email: "other@example.com", WorkspaceID: sql.NullInt64{Int64: workspaceID + 1, Valid: true}},
+		}, nil)
+	store.EXPECT().
+		ListPendingInvitationEmails(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]string{"invited@example.com"}, nil)
+	store.EXPECT().
+		CreateWorkspaceInvitationsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, invitations []db.CreateWorkspaceInvitationParams) ([]db.WorkspaceInvitation, error) {
+			require.Len(t, invitations, 2)
+			require.Equal(t, "Ada@example.com", invitations[0].InviteeEmail)
+			require.Equal(t, "admin", invitations[0].Role)
+			require.False(t, invitations[0].InviteeID.Valid)
+			// Existing users in other workspaces are linked to their invitation
+			require.Equal(t, sql.NullInt64{Int64: 21, Valid: true}, invitations[1].InviteeID)
+
+			created := make([]db.WorkspaceInvitation, len(invitations))
+			for i, arg := range invitations {
+				created[i] = db.WorkspaceInvitation{ID: int64(i + 1), WorkspaceID: arg.WorkspaceID, InviteeEmail: arg.InviteeEmail, Role: arg.Role, Status: "pending"}
+			}
+			return created, nil
+		})
+
+	// Emails are sent in the background
+	store.EXPECT().GetWorkspace(gomock.Any(), gomock.Any()).AnyTimes().Return(db.Workspace{ID: workspaceID, Name: "Acme"}, nil)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).AnyTimes().Return(db.User{ID: inviterID}, nil)
+	store.EXPECT().UpdateWorkspaceInvitationEmailStatus(gomock.Any(), gomock.Any()).AnyTimes().Return(db.WorkspaceInvitation{}, nil)
+
+	emailService, _ := newCapturingEmailService(t, nil)
+	service := NewWorkspaceInvitationService(store, emailService, util.Config{})
+
+	report, err := service.BulkInviteUsers(ctx, workspaceID, inviterID, []BulkInviteEntry{
+		{Email: " Ada@example.com ", Role: "Admin"},
+		{Email: "member@example.com"},
+		{Email: "invited@example.com"},
+		{Email: "ada@EXAMPLE.com"},
+		{Email: "Bob <bob@example.com>"},
+		{Email: "carol@example.com", Role: "owner"},
+		{Email: "other@example.com"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Invited)
+	require.Equal(t, 5, report.Skipped)
+
+	statuses := make([]string, len(report.Results))
+	for i, result := range report.Results {
+		require.Equal(t, i+1, result.Row)
+		statuses[i] = result.Status
+	}
+	require.Equal(t, []string{
+		BulkInviteStatusInvited,
+		BulkInviteStatusAlreadyMember,
+		BulkInviteStatusAlreadyInvited,
+		BulkInviteStatusDuplicate,
+		BulkInviteStatusInvalidEmail,
+		BulkInviteStatusInvalidRole,
+		BulkInviteStatusInvited,
+	}, statuses)
+	require.NotNil(t, report.Results[0].Invitation)
+	require.Nil(t, report.Results[1].Invitation)
+}
+
+func TestParseInvitationCSV(t *testing.T) {
+	testCases := []struct {
+		name    string
+		csv     string
+		want    []BulkInviteEntry
+		wantErr string
+	}{
+		{
+			name: "NoHeader",
+			csv:  "ada@example.com,admin\nbob@example.com\n",
+			want: []BulkInviteEntry{{Email: "ada@example.com", Role: "admin"}, {Email: "bob@example.com"}},
+		},
+		{
+			name: "HeaderWithReorderedColumns",
+			csv:  "Name,Role,Email\nAda,member,ada@example.com\n",
+			want: []BulkInviteEntry{{Email: "ada@example.com", Role: "member"}},
+		},
+		{
+			name: "InvalidEmailsAreKeptForTheReport",
+			csv:  "not an email,member\n",
+			want: []BulkInviteEntry{{Email: "not an email", Role: "member"}},
+		},
+		{
+			name:    "Empty",
+			csv:     "email,role\n",
+			wantErr: "invalid CSV: no invitations",
+		},
+		{
+			name:    "Malformed",
+			csv:     "\"ada@example.com,admin\n",
+			wantErr: "invalid CSV",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entries, err := ParseInvitationCSV(strings.NewReader(tc.csv))
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, entries)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+)
+
+// WorkspaceRoleService manages the custom roles a workspace defines on top of
+// the built-in admin and member roles. Anyone who can manage roles can grant
+// any permission, so manage_roles should only be given to trusted members.
+type WorkspaceRoleService struct {
+	store       db.WorkspaceStore
+	userService *UserService
+}
+
+// NewWorkspaceRoleService creates a new workspace role service
+func NewWorkspaceRoleService(store db.WorkspaceStore, userService *UserService) *WorkspaceRoleService {
+	return &WorkspaceRoleService{
+		store:       store,
+		userService: userService,
+	}
+}
+
+// CreateRole adds a custom role to a workspace
+func (s *WorkspaceRoleService) CreateRole(ctx context.Context, workspaceID, creatorID int64, req WorkspaceRoleRequest) (WorkspaceRoleResponse, error) {
+	name, permissions, err := validateWorkspaceRole(req)
+	if err != nil {
+		return WorkspaceRoleResponse{}, err
+	}
+
+	role, err := s.store.CreateWorkspaceRole(ctx, db.CreateWorkspaceRoleParams{
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+		CreatedBy:   sql.NullInt64{Int64: creatorID, Valid: true},
+	})
+	if err != nil {
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return WorkspaceRoleResponse{}, errors.New("role already exists")
+		}
+		return WorkspaceRoleResponse{}, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	return toWorkspaceRoleResponse(role), nil
+}
+
+// ListRoles lists a workspace's custom roles with how many members hold each
+func (s *WorkspaceRoleService) ListRoles(ctx context.Context, workspaceID int64) ([]WorkspaceRoleResponse, error) {
+	roles, err := s.store.ListWorkspaceRoles(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	responses := make([]WorkspaceRoleResponse, len(roles))
+	for i, role := range roles {
+		responses[i] = toWorkspaceRoleResponse(db.WorkspaceRole{
+			ID:          role.ID,
+			WorkspaceID: role.WorkspaceID,
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: role.Permissions,
+			CreatedBy:   role.CreatedBy,
+			CreatedAt:   role.CreatedAt,
+			UpdatedAt:   role.UpdatedAt,
+		})
+		responses[i].MemberCount = &role.MemberCount
+	}
+	return responses, nil
+}
+
+// UpdateRole replaces a custom role's name, description and permissions.
+// Members holding the role get the new permissions immediately.
+func (s *WorkspaceRoleService) UpdateRole(ctx context.Context, workspaceID, roleID int64, req WorkspaceRoleRequest) (WorkspaceRoleResponse, error) {
+	name, permissions, err := validateWorkspaceRole(req)
+	if err != nil {
+		return WorkspaceRoleResponse{}, err
+	}
+
+	role, err := s.store.UpdateWorkspaceRole(ctx, db.UpdateWorkspaceRoleParams{
+		ID:          roleID,
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WorkspaceRoleResponse{}, errors.New("role not found")
+		}
+		if db.ErrorCode(err) == db.UniqueViolation {
+			return WorkspaceRoleResponse{}, errors.New("role already exists")
+		}
+		return WorkspaceRoleResponse{}, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return toWorkspaceRoleResponse(role), nil
+}
+
+// DeleteRole removes a custom role. Members who held it keep only the
+// permissions of their built-in role.
+func (s *WorkspaceRoleService) DeleteRole(ctx context.Context, workspaceID, roleID int64) error {
+	rows, err := s.store.DeleteWorkspaceRole(ctx, db.DeleteWorkspaceRoleParams{
+		ID:          roleID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("role not found")
+	}
+
+	return nil
+}
+
+// AssignRole gives a workspace member a custom role, or removes their custom
+// role when roleID is nil
+func (s *WorkspaceRoleService) AssignRole(ctx context.Context, workspaceID, userID int64, roleID *int64) (UserResponse, error) {
+	var customRoleID sql.NullInt64
+	if roleID != nil {
+		role, err := s.store.GetWorkspaceRole(ctx, db.GetWorkspaceRoleParams{
+			ID:          *roleID,
+			WorkspaceID: workspaceID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return UserResponse{}, errors.New("role not found")
+			}
+			return UserResponse{}, fmt.Errorf("failed to get role: %w", err)
+		}
+		customRoleID = sql.NullInt64{Int64: role.ID, Valid: true}
+	}
+
+	user, err := s.store.SetUserCustomRole(ctx, db.SetUserCustomRoleParams{
+		ID:           userID,
+		WorkspaceID:  sql.NullInt64{Int64: workspaceID, Valid: true},
+		CustomRoleID: customRoleID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return UserResponse{}, errors.New("user not found in workspace")
+		}
+		return UserResponse{}, fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	return s.userService.toUserResponse(user), nil
+}
+
+// validateWorkspaceRole returns the trimmed role name and the sorted, unique
+// permissions of a role request
+func validateWorkspaceRole(req WorkspaceRoleRequest) (string, []string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return "", nil, errors.New("invalid role name: name cannot be empty")
+	}
+	if strings.EqualFold(name, "admin") || strings.EqualFold(name, "member") {
+		return "", nil, fmt.Errorf("invalid role name: %q is a built-in role", name)
+	}
+
+	permissions := make([]string, 0, len(req.Permissions))
+	for _, permission := range req.Permissions {
+		if !IsPermission(permission) {
+			return "", nil, fmt.Errorf("invalid permission: %s", permission)
+		}
+		permissions = append(permissions, permission)
+	}
+	slices.Sort(permissions)
+
+	return name, slices.Compact(permissions), nil
+}
+
+func toWorkspaceRoleResponse(role db.WorkspaceRole) WorkspaceRoleResponse {
+	resp := WorkspaceRoleResponse{
+		ID:          role.ID,
+		WorkspaceID: role.WorkspaceID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+
+	if role.CreatedBy.Valid {
+		resp.CreatedBy = &role.CreatedBy.Int64
+	}
+
+	return resp
+}
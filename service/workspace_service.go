@@ -9,14 +9,22 @@ import (
 	db "github.com/heyrmi/goslack/db/sqlc"
 )
 
+// workspaceServiceStore covers workspaces, their members and their organization
+type workspaceServiceStore interface {
+	db.OrganizationStore
+	db.UserStore
+	db.WorkspaceStore
+	db.Transactor
+}
+
 // WorkspaceService handles workspace-related business logic
 type WorkspaceService struct {
-	store       db.Store
+	store       workspaceServiceStore
 	userService *UserService
 }
 
 // NewWorkspaceService creates a new workspace service
-func NewWorkspaceService(store db.Store, userService *UserService) *WorkspaceService {
+func NewWorkspaceService(store workspaceServiceStore, userService *UserService) *WorkspaceService {
 	return &WorkspaceService{
 		store:       store,
 		userService: userService,
@@ -34,24 +42,41 @@ func (s *WorkspaceService) CreateWorkspace(ctx context.Context, userID int64, re
 		return WorkspaceResponse{}, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	// Create the workspace
-	arg := db.CreateWorkspaceParams{
-		OrganizationID: user.OrganizationID,
-		Name:           req.Name,
-	}
+	// The workspace, its owner and its first admin are set up together so a
+	// failure doesn't leave a workspace nobody can administer
+	var workspace db.Workspace
+	err = s.store.ExecTx(ctx, func(q db.Querier) error {
+		// The first user to create a workspace in an organization without an
+		// owner becomes its owner
+		err := q.EnsureOrganizationOwner(ctx, db.EnsureOrganizationOwnerParams{
+			OrganizationID: user.OrganizationID,
+			UserID:         user.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to ensure organization owner: %w", err)
+		}
 
-	workspace, err := s.store.CreateWorkspace(ctx, arg)
-	if err != nil {
-		return WorkspaceResponse{}, fmt.Errorf("failed to create workspace: %w", err)
-	}
+		workspace, err = q.CreateWorkspace(ctx, db.CreateWorkspaceParams{
+			OrganizationID: user.OrganizationID,
+			Name:           req.Name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create workspace: %w", err)
+		}
 
-	// Assign the creating user as admin of the workspace
-	_, err = s.userService.AssignUserToWorkspace(ctx, userID, workspace.ID, "admin")
+		// Assign the creating user as admin of the workspace
+		_, err = q.UpdateUserWorkspace(ctx, db.UpdateUserWorkspaceParams{
+			ID:          userID,
+			WorkspaceID: sql.NullInt64{Int64: workspace.ID, Valid: true},
+			Role:        "admin",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to assign user as admin: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		// If assigning user fails, we should clean up the workspace
-		// In a real implementation, this should be done in a transaction
-		s.store.DeleteWorkspace(ctx, workspace.ID)
-		return WorkspaceResponse{}, fmt.Errorf("failed to assign user as admin: %w", err)
+		return WorkspaceResponse{}, err
 	}
 
 	return s.toWorkspaceResponse(workspace), nil
@@ -109,10 +134,20 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, workspaceID int6
 	return s.toWorkspaceResponse(workspace), nil
 }
 
-// DeleteWorkspace deletes a workspace
-func (s *WorkspaceService) DeleteWorkspace(ctx context.Context, workspaceID int64) error {
-	err := s.store.DeleteWorkspace(ctx, workspaceID)
+// DeleteWorkspace deletes a workspace. Organization admins can restore it
+// during the recovery window, after which it's purged.
+func (s *WorkspaceService) DeleteWorkspace(ctx context.Context, userID, workspaceID int64) error {
+	err := s.store.DeleteWorkspaceTx(ctx, db.SoftDeleteWorkspaceParams{
+		ID:        workspaceID,
+		DeletedBy: sql.NullInt64{Int64: userID, Valid: true},
+	})
 	if err != nil {
+		if errors.Is(err, db.ErrWorkspaceUnderLegalHold) {
+			return err
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("workspace not found")
+		}
 		return fmt.Errorf("failed to delete workspace: %w", err)
 	}
 	return nil
@@ -130,14 +165,14 @@ func (s *WorkspaceService) CheckUserWorkspaceAccess(ctx context.Context, userID,
 	return nil
 }
 
-// CheckUserWorkspaceAdmin checks if a user is an admin of a workspace
-func (s *WorkspaceService) CheckUserWorkspaceAdmin(ctx context.Context, userID, workspaceID int64) error {
-	isAdmin, err := s.userService.IsWorkspaceAdmin(ctx, userID, workspaceID)
+// CheckUserWorkspacePermission checks if a user holds a permission in a workspace
+func (s *WorkspaceService) CheckUserWorkspacePermission(ctx context.Context, userID, workspaceID int64, permission string) error {
+	allowed, err := s.userService.HasWorkspacePermission(ctx, userID, workspaceID, permission)
 	if err != nil {
 		return err
 	}
-	if !isAdmin {
-		return errors.New("user is not an admin of this workspace")
+	if !allowed {
+		return fmt.Errorf("access denied: %s permission required", permission)
 	}
 	return nil
 }
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+)
+
+// Workspace teardown statuses
+const (
+	TeardownStatusPending   = "pending"
+	TeardownStatusRunning   = "running"
+	TeardownStatusCompleted = "completed"
+)
+
+// Workspace teardown steps, in the order they run
+const (
+	TeardownStepConnections = "connections"
+	TeardownStepFiles       = "files"
+	TeardownStepMessages    = "messages"
+	TeardownStepInvitations = "invitations"
+	TeardownStepStatuses    = "statuses"
+	TeardownStepPreferences = "preferences"
+	TeardownStepWorkspace   = "workspace"
+)
+
+// teardownsPerRun caps how many workspaces one worker pass works through
+const teardownsPerRun = 10
+
+// WorkspaceTeardownService removes everything a purged workspace leaves
+// behind. Large tables are deleted in batches so a big workspace doesn't hold
+// locks for long, and progress is recorded after every batch. Every step can
+// safely run again, so an interrupted teardown resumes on the next pass.
+type WorkspaceTeardownService struct {
+	store     db.WorkspaceStore
+	hub       WebSocketHub
+	batchSize int32
+}
+
+// NewWorkspaceTeardownService creates a new workspace teardown service
+func NewWorkspaceTeardownService(store db.WorkspaceStore, hub WebSocketHub, config util.Config) *WorkspaceTeardownService {
+	batchSize := config.TeardownBatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	return &WorkspaceTeardownService{
+		store:     store,
+		hub:       hub,
+		batchSize: batchSize,
+	}
+}
+
+// ListTeardowns lists an organization's workspace teardowns, newest first
+func (s *WorkspaceTeardownService) ListTeardowns(ctx context.Context, organizationID int64, limit, offset int32) ([]WorkspaceTeardownResponse, error) {
+	teardowns, err := s.store.ListWorkspaceTeardowns(ctx, db.ListWorkspaceTeardownsParams{
+		OrganizationID: organizationID,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace teardowns: %w", err)
+	}
+
+	responses := make([]WorkspaceTeardownResponse, len(teardowns))
+	for i, teardown := range teardowns {
+		responses[i] = toWorkspaceTeardownResponse(teardown)
+	}
+	return responses, nil
+}
+
+// GetTeardown reports the progress of a workspace's teardown
+func (s *WorkspaceTeardownService) GetTeardown(ctx context.Context, organizationID, workspaceID int64) (WorkspaceTeardownResponse, error) {
+	teardown, err := s.store.GetWorkspaceTeardown(ctx, db.GetWorkspaceTeardownParams{
+		WorkspaceID:    workspaceID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WorkspaceTeardownResponse{}, errors.New("workspace teardown not found")
+		}
+		return WorkspaceTeardownResponse{}, fmt.Errorf("failed to get workspace teardown: %w", err)
+	}
+	return toWorkspaceTeardownResponse(teardown), nil
+}
+
+// RunTeardowns works through unfinished teardowns. A failing teardown records
+// its error and is retried on the next pass without holding up the others.
+func (s *WorkspaceTeardownService) RunTeardowns(ctx context.Context) error {
+	teardowns, err := s.store.ListUnfinishedWorkspaceTeardowns(ctx, teardownsPerRun)
+	if err != nil {
+		return fmt.Errorf("failed to list workspace teardowns: %w", err)
+	}
+
+	for _, teardown := range teardowns {
+		if err := s.teardownWorkspace(ctx, teardown); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			fmt.Printf("Error tearing down workspace %d: %v\n", teardown.WorkspaceID, err)
+			recordErr := s.store.RecordWorkspaceTeardownError(ctx, db.RecordWorkspaceTeardownErrorParams{
+				WorkspaceID: teardown.WorkspaceID,
+				LastError:   sql.NullString{String: err.Error(), Valid: true},
+			})
+			if recordErr != nil {
+				return fmt.Errorf("failed to record teardown error: %w", recordErr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// StartTeardownWorker runs teardowns on an interval until the context is cancelled
+func (s *WorkspaceTeardownService) StartTeardownWorker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RunTeardowns(ctx); err != nil {
+				fmt.Printf("Error running workspace teardowns: %v\n", err)
+			}
+		}
+	}
+}
+
+// teardownWorkspace runs every teardown step for a workspace and finally
+// deletes the workspace row, which cascades to the remaining small tables
+func (s *WorkspaceTeardownService) teardownWorkspace(ctx context.Context, teardown db.WorkspaceTeardown) error {
+	workspaceID := teardown.WorkspaceID
+
+	if s.hub != nil {
+		s.hub.DisconnectWorkspace(workspaceID)
+	}
+	if err := s.recordProgress(ctx, workspaceID, TeardownStepConnections, 0, 0); err != nil {
+		return err
+	}
+
+	if err := s.deleteFiles(ctx, workspaceID); err != nil {
+		return err
+	}
+
+	err := s.deleteInBatches(ctx, workspaceID, TeardownStepMessages, func() (int64, error) {
+		return s.store.DeleteWorkspaceMessagesBatch(ctx, db.DeleteWorkspaceMessagesBatchParams{
+			WorkspaceID: workspaceID,
+			Limit:       s.batchSize,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete messages: %w", err)
+	}
+
+	err = s.deleteInBatches(ctx, workspaceID, TeardownStepInvitations, func() (int64, error) {
+		return s.store.DeleteWorkspaceInvitationsBatch(ctx, db.DeleteWorkspaceInvitationsBatchParams{
+			WorkspaceID: workspaceID,
+			Limit:       s.batchSize,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete invitations: %w", err)
+	}
+
+	statuses, err := s.store.DeleteWorkspaceStatuses(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete statuses: %w", err)
+	}
+	if err := s.recordProgress(ctx, workspaceID, TeardownStepStatuses, statuses, 0); err != nil {
+		return err
+	}
+
+	preferences, err := s.store.DeleteWorkspacePreferences(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete preferences: %w", err)
+	}
+	if err := s.recordProgress(ctx, workspaceID, TeardownStepPreferences, preferences, 0); err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteWorkspace(ctx, workspaceID); err != nil {
+		return fmt.Errorf("failed to delete workspace: %w", err)
+	}
+	if err := s.recordProgress(ctx, workspaceID, TeardownStepWorkspace, 1, 0); err != nil {
+		return err
+	}
+
+	if err := s.store.CompleteWorkspaceTeardown(ctx, workspaceID); err != nil {
+		return fmt.Errorf("failed to complete workspace teardown: %w", err)
+	}
+
+	fmt.Printf("Tore down workspace %d (%s)\n", workspaceID, teardown.WorkspaceName)
+	return nil
+}
+
+// deleteFiles removes the workspace's stored files, thumbnails and
+// watermarked copies, then their rows
+func (s *WorkspaceTeardownService) deleteFiles(ctx context.Context, workspaceID int64) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		files, err := s.store.ListWorkspaceFilesForTeardown(ctx, db.ListWorkspaceFilesForTeardownParams{
+			WorkspaceID: workspaceID,
+			Limit:       s.batchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+		if len(files) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(files))
+		for i, file := range files {
+			ids[i] = file.ID
+			removeStoredFile(file.FilePath)
+			removeWatermarkedImages(file.FilePath)
+			for _, path := range []sql.NullString{file.ThumbnailPath, file.TranscodedPath, file.PosterPath} {
+				if path.Valid {
+					removeStoredFile(path.String)
+				}
+			}
+		}
+
+		deleted, err := s.store.DeleteFilesByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to delete files: %w", err)
+		}
+		if err := s.recordProgress(ctx, workspaceID, TeardownStepFiles, deleted, int64(len(files))); err != nil {
+			return err
+		}
+	}
+}
+
+// deleteInBatches runs a batched delete until nothing is left, recording
+// progress after every batch
+func (s *WorkspaceTeardownService) deleteInBatches(ctx context.Context, workspaceID int64, step string, deleteBatch func() (int64, error)) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		deleted, err := deleteBatch()
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return nil
+		}
+		if err := s.recordProgress(ctx, workspaceID, step, deleted, 0); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *WorkspaceTeardownService) recordProgress(ctx context.Context, workspaceID int64, step string, rowsDeleted, filesDeleted int64) error {
+	err := s.store.RecordWorkspaceTeardownProgress(ctx, db.RecordWorkspaceTeardownProgressParams{
+		Step:         step,
+		RowsDeleted:  rowsDeleted,
+		FilesDeleted: filesDeleted,
+		WorkspaceID:  workspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record teardown progress: %w", err)
+	}
+	return nil
+}
+
+// removeStoredFile deletes a file from disk. A file that's already gone is
+// fine, and a file that can't be removed doesn't stop the teardown.
+func removeStoredFile(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		fmt.Printf("Warning: failed to delete file from disk: %v\n", err)
+	}
+}
+
+func toWorkspaceTeardownResponse(teardown db.WorkspaceTeardown) WorkspaceTeardownResponse {
+	resp := WorkspaceTeardownResponse{
+		WorkspaceID:    teardown.WorkspaceID,
+		OrganizationID: teardown.OrganizationID,
+		WorkspaceName:  teardown.WorkspaceName,
+		Status:         teardown.Status,
+		Step:           teardown.Step,
+		RowsDeleted:    teardown.RowsDeleted,
+		FilesDeleted:   teardown.FilesDeleted,
+		CreatedAt:      teardown.CreatedAt,
+		UpdatedAt:      teardown.UpdatedAt,
+	}
+	if teardown.LastError.Valid {
+		resp.LastError = &teardown.LastError.String
+	}
+	if teardown.StartedAt.Valid {
+		resp.StartedAt = &teardown.StartedAt.Time
+	}
+	if teardown.CompletedAt.Valid {
+		resp.CompletedAt = &teardown.CompletedAt.Time
+	}
+	return resp
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mockdb "github.com/heyrmi/goslack/db/mock"
+	db "github.com/heyrmi/goslack/db/sqlc"
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceTeardownService_RunTeardowns(t *testing.T) {
+	const workspaceID = int64(7)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "report.png")
+	thumbnailPath := filepath.Join(dir, "report_thumb.png")
+	require.NoError(t, os.WriteFile(filePath, []byte("file"), 0644))
+	require.NoError(t, os.WriteFile(thumbnailPath, []byte("thumb"), 0644))
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		ListUnfinishedWorkspaceTeardowns(gomock.Any(), gomock.Any()).
+		Return([]db.WorkspaceTeardown{{WorkspaceID: workspaceID, WorkspaceName: "Engineering", Status: TeardownStatusPending}}, nil)
+
+	gomock.InOrder(
+		store.EXPECT().
+			ListWorkspaceFilesForTeardown(gomock.Any(), db.ListWorkspaceFilesForTeardownParams{WorkspaceID: workspaceID, Limit: 2}).
+			Return([]db.ListWorkspaceFilesForTeardownRow{{
+				ID:            3,
+				FilePath:      filePath,
+				ThumbnailPath: sql.NullString{String: thumbnailPath, Valid: true},
+			}}, nil),
+		store.EXPECT().DeleteFilesByIDs(gomock.Any(), []int64{3}).Return(int64(1), nil),
+		store.EXPECT().
+			ListWorkspaceFilesForTeardown(gomock.Any(), gomock.Any()).
+			Return([]db.ListWorkspaceFilesForTeardownRow{}, nil),
+	)
+
+	// Messages take two full batches before none are left
+	gomock.InOrder(
+		store.EXPECT().DeleteWorkspaceMessagesBatch(gomock.Any(), db.DeleteWorkspaceMessagesBatchParams{WorkspaceID: workspaceID, Limit: 2}).Return(int64(2), nil),
+		store.EXPECT().DeleteWorkspaceMessagesBatch(gomock.Any(), gomock.Any()).Return(int64(2), nil),
+		store.EXPECT().DeleteWorkspaceMessagesBatch(gomock.Any(), gomock.Any()).Return(int64(0), nil),
+	)
+	store.EXPECT().DeleteWorkspaceInvitationsBatch(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	store.EXPECT().DeleteWorkspaceStatuses(gomock.Any(), workspaceID).Return(int64(3), nil)
+	store.EXPECT().DeleteWorkspacePreferences(gomock.Any(), workspaceID).Return(int64(1), nil)
+	store.EXPECT().DeleteWorkspace(gomock.Any(), workspaceID).Return(nil)
+	store.EXPECT().CompleteWorkspaceTeardown(gomock.Any(), workspaceID).Return(nil)
+
+	var rowsDeleted, filesDeleted int64
+	store.EXPECT().
+		RecordWorkspaceTeardownProgress(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, arg db.RecordWorkspaceTeardownProgressParams) error {
+			require.Equal(t, workspaceID, arg.WorkspaceID)
+			rowsDeleted += arg.RowsDeleted
+			filesDeleted += arg.FilesDeleted
+			return nil
+		})
+	store.EXPECT().RecordWorkspaceTeardownError(gomock.Any(), gomock.Any()).Times(0)
+
+	hub := &recordingHub{userMessages: make(map[int64][]*WSMessage)}
+	teardownService := NewWorkspaceTeardownService(store, hub, util.Config{TeardownBatchSize: 2})
+
+	require.NoError(t, teardownService.RunTeardowns(ctx))
+
+	require.Equal(t, []int64{workspaceID}, hub.disconnectedWorkspaces)
+	require.NoFileExists(t, filePath)
+	require.NoFileExists(t, thumbnailPath)
+	// 1 file, 4 messages, 3 statuses, 1 preference and the workspace itself
+	require.Equal(t, int64(10), rowsDeleted)
+	require.Equal(t, int64(1), filesDeleted)
+}
+
+func TestWorkspaceTeardownService_RunTeardownsRecordsError(t *testing.T) {
+	const workspaceID = int64(7)
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().
+		ListUnfinishedWorkspaceTeardowns(gomock.Any(), gomock.Any()).
+		Return([]db.WorkspaceTeardown{{WorkspaceID: workspaceID}}, nil)
+	store.EXPECT().RecordWorkspaceTeardownProgress(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	store.EXPECT().
+		ListWorkspaceFilesForTeardown(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("connection reset"))
+	store.EXPECT().DeleteWorkspace(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().CompleteWorkspaceTeardown(gomock.Any(), gomock.Any()).Times(0)
+
+	// The failure is kept on the teardown so the next pass retries it
+	store.EXPECT().
+		RecordWorkspaceTeardownError(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.RecordWorkspaceTeardownErrorParams) error {
+			require.Equal(t, workspaceID, arg.WorkspaceID)
+			require.Contains(t, arg.LastError.String, "connection reset")
+			return nil
+		})
+
+	teardownService := NewWorkspaceTeardownService(store, nil, util.Config{})
+	require.NoError(t, teardownService.RunTeardowns(ctx))
+}
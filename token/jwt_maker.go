@@ -0,0 +1,358 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JWT signing algorithms
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+const minRSAKeyBits = 2048
+
+// KeySetProvider is implemented by makers whose tokens others can verify with public keys
+type KeySetProvider interface {
+	// JWKS returns the public keys tokens are verified with
+	JWKS() JSONWebKeySet
+}
+
+// JSONWebKeySet is a set of public keys as served by a JWKS endpoint (RFC 7517)
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey is an RSA or Ed25519 public key in JWK format
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"` // Ed25519 keys
+	X         string `json:"x,omitempty"`   // Ed25519 keys
+	N         string `json:"n,omitempty"`   // RSA keys
+	E         string `json:"e,omitempty"`   // RSA keys
+}
+
+// jwtKey is a key tokens are verified with, and signed with when it has a private key
+type jwtKey struct {
+	id         string
+	algorithm  string
+	publicKey  crypto.PublicKey
+	privateKey crypto.Signer
+}
+
+// JWTMaker is a JSON Web Token maker signing with RS256 or EdDSA keys
+type JWTMaker struct {
+	keys     []jwtKey // The first key signs, all of them verify
+	audience string
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// jwtClaims are the registered JWT claims a payload maps to, plus ours
+type jwtClaims struct {
+	ID        string `json:"jti"`
+	Subject   string `json:"sub,omitempty"`
+	Audience  string `json:"aud,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	Username  string `json:"username"`
+	Type      string `json:"token_type"`
+	Scope     string `json:"scope,omitempty"` // Space-separated, as in RFC 8693
+}
+
+// NewJWTMaker creates a new JWTMaker from PEM encoded keys. The first key must be an RSA
+// or Ed25519 private key and signs new tokens; the others may be public keys and are
+// only used to verify tokens, so keys can be rotated without logging everyone out.
+// Tokens it creates are issued for the audience, and tokens issued for another audience
+// are rejected; an empty audience accepts any.
+func NewJWTMaker(keyPEMs [][]byte, audience string) (Maker, error) {
+	if len(keyPEMs) == 0 {
+		return nil, errors.New("invalid JWT keys: at least one key is required")
+	}
+
+	maker := &JWTMaker{audience: audience}
+	seen := make(map[string]bool)
+	for i, keyPEM := range keyPEMs {
+		key, err := parseJWTKey(keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT key %d: %w", i+1, err)
+		}
+		if i == 0 && key.privateKey == nil {
+			return nil, errors.New("invalid JWT keys: the first key signs tokens and must be a private key")
+		}
+		if seen[key.id] {
+			continue
+		}
+		seen[key.id] = true
+		maker.keys = append(maker.keys, key)
+	}
+
+	return maker, nil
+}
+
+// CreateToken creates a new user token with full access for a specific username and duration
+func (maker *JWTMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
+	return maker.CreateTokenWithClaims(Claims{
+		Username: username,
+		Type:     TokenTypeUser,
+		Scopes:   []string{ScopeAll},
+	}, duration)
+}
+
+// CreateTokenWithClaims creates a new token with specific claims and duration
+func (maker *JWTMaker) CreateTokenWithClaims(claims Claims, duration time.Duration) (string, *Payload, error) {
+	if claims.Audience == "" {
+		claims.Audience = maker.audience
+	}
+
+	payload, err := NewPayloadWithClaims(claims, duration)
+	if err != nil {
+		return "", payload, err
+	}
+
+	key := maker.keys[0]
+	header, err := json.Marshal(jwtHeader{Algorithm: key.algorithm, Type: "JWT", KeyID: key.id})
+	if err != nil {
+		return "", payload, err
+	}
+
+	registered := jwtClaims{
+		ID:        payload.ID.String(),
+		Audience:  payload.Audience,
+		IssuedAt:  payload.IssuedAt.Unix(),
+		ExpiresAt: payload.ExpiredAt.Unix(),
+		Username:  payload.Username,
+		Type:      payload.Type,
+		Scope:     strings.Join(payload.Scopes, " "),
+	}
+	if payload.SubjectID != 0 {
+		registered.Subject = strconv.FormatInt(payload.SubjectID, 10)
+	}
+	body, err := json.Marshal(registered)
+	if err != nil {
+		return "", payload, err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	signature, err := key.sign([]byte(signingInput))
+	if err != nil {
+		return "", payload, err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), payload, nil
+}
+
+// VerifyToken checks if the token is valid or not
+func (maker *JWTMaker) VerifyToken(token string) (*Payload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	// The algorithm must be the key's own, so a token can't pick a weaker one
+	signingInput := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range maker.keys {
+		if (header.KeyID == "" || key.id == header.KeyID) && key.algorithm == header.Algorithm && key.verify(signingInput, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, ErrInvalidToken
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{
+		ID:        tokenID,
+		Username:  claims.Username,
+		Type:      claims.Type,
+		Scopes:    strings.Fields(claims.Scope),
+		Audience:  claims.Audience,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		ExpiredAt: time.Unix(claims.ExpiresAt, 0),
+	}
+	if claims.Subject != "" {
+		payload.SubjectID, err = strconv.ParseInt(claims.Subject, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidToken
+		}
+	}
+
+	err = payload.Valid()
+	if err != nil {
+		return nil, err
+	}
+
+	if maker.audience != "" && payload.Audience != maker.audience {
+		return nil, ErrInvalidAudience
+	}
+
+	return payload, nil
+}
+
+// JWKS returns the public keys tokens are verified with, including keys being rotated in or out
+func (maker *JWTMaker) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(maker.keys))}
+	for _, key := range maker.keys {
+		jwk := publicJWK(key.publicKey)
+		jwk.KeyID = key.id
+		jwk.Use = "sig"
+		jwk.Algorithm = key.algorithm
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+func (key jwtKey) sign(signingInput []byte) ([]byte, error) {
+	switch key.algorithm {
+	case AlgorithmRS256:
+		digest := sha256.Sum256(signingInput)
+		return key.privateKey.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return key.privateKey.Sign(rand.Reader, signingInput, crypto.Hash(0))
+	}
+}
+
+func (key jwtKey) verify(signingInput, signature []byte) bool {
+	switch publicKey := key.publicKey.(type) {
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(publicKey, signingInput, signature)
+	default:
+		return false
+	}
+}
+
+// parseJWTKey parses a PEM encoded RSA or Ed25519 private or public key
+func parseJWTKey(keyPEM []byte) (jwtKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return jwtKey{}, errors.New("no PEM data found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return jwtKey{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return jwtKey{}, err
+	}
+
+	var key jwtKey
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key = jwtKey{algorithm: AlgorithmRS256, publicKey: &k.PublicKey, privateKey: k}
+	case *rsa.PublicKey:
+		key = jwtKey{algorithm: AlgorithmRS256, publicKey: k}
+	case ed25519.PrivateKey:
+		key = jwtKey{algorithm: AlgorithmEdDSA, publicKey: k.Public(), privateKey: k}
+	case ed25519.PublicKey:
+		key = jwtKey{algorithm: AlgorithmEdDSA, publicKey: k}
+	default:
+		return jwtKey{}, errors.New("only RSA and Ed25519 keys are supported")
+	}
+
+	if rsaKey, ok := key.publicKey.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < minRSAKeyBits {
+		return jwtKey{}, fmt.Errorf("RSA keys must have at least %d bits", minRSAKeyBits)
+	}
+
+	key.id = jwkThumbprint(key.publicKey)
+	return key, nil
+}
+
+// publicJWK returns the key type and public key members of a JWK
+func publicJWK(publicKey crypto.PublicKey) JSONWebKey {
+	switch k := publicKey.(type) {
+	case *rsa.PublicKey:
+		return JSONWebKey{
+			KeyType: "RSA",
+			N:       base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return JSONWebKey{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       base64.RawURLEncoding.EncodeToString(k),
+		}
+	default:
+		return JSONWebKey{}
+	}
+}
+
+// jwkThumbprint returns the RFC 7638 thumbprint of a public key, used as its key ID
+func jwkThumbprint(publicKey crypto.PublicKey) string {
+	jwk := publicJWK(publicKey)
+
+	// The required members in lexicographic order, with no whitespace
+	var members string
+	if jwk.KeyType == "RSA" {
+		members = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, jwk.E, jwk.KeyType, jwk.N)
+	} else {
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Curve, jwk.KeyType, jwk.X)
+	}
+
+	digest := sha256.Sum256([]byte(members))
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
+
+func decodeJWTPart(part string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,185 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heyrmi/goslack/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomRSAKeyPEM(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return privateKeyPEM(t, key)
+}
+
+func randomEd25519KeyPEM(t *testing.T) []byte {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return privateKeyPEM(t, key)
+}
+
+func privateKeyPEM(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func publicKeyPEM(t *testing.T, keyPEM []byte) []byte {
+	block, _ := pem.Decode(keyPEM)
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.(crypto.Signer).Public())
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestJWTMaker(t *testing.T) {
+	for name, keyPEM := range map[string][]byte{
+		AlgorithmRS256: randomRSAKeyPEM(t),
+		AlgorithmEdDSA: randomEd25519KeyPEM(t),
+	} {
+		t.Run(name, func(t *testing.T) {
+			maker, err := NewJWTMaker([][]byte{keyPEM}, "goslack")
+			require.NoError(t, err)
+
+			claims := Claims{
+				Username:  util.RandomEmail(),
+				SubjectID: util.RandomInt(1, 1000),
+				Type:      TokenTypePersonal,
+				Scopes:    []string{"messages:read", "files:*"},
+			}
+			duration := time.Minute
+
+			issuedAt := time.Now()
+			expiredAt := issuedAt.Add(duration)
+
+			token, _, err := maker.CreateTokenWithClaims(claims, duration)
+			require.NoError(t, err)
+			require.NotEmpty(t, token)
+
+			var header jwtHeader
+			require.NoError(t, decodeJWTPart(strings.Split(token, ".")[0], &header))
+			require.Equal(t, name, header.Algorithm)
+
+			payload, err := maker.VerifyToken(token)
+			require.NoError(t, err)
+			require.NotZero(t, payload.ID)
+			require.Equal(t, claims.Username, payload.Username)
+			require.Equal(t, claims.SubjectID, payload.SubjectID)
+			require.Equal(t, TokenTypePersonal, payload.Type)
+			require.Equal(t, claims.Scopes, payload.Scopes)
+			require.Equal(t, "goslack", payload.Audience)
+			require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
+			require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
+
+			keySet := maker.(KeySetProvider).JWKS()
+			require.Len(t, keySet.Keys, 1)
+			require.Equal(t, header.KeyID, keySet.Keys[0].KeyID)
+			require.Equal(t, name, keySet.Keys[0].Algorithm)
+		})
+	}
+}
+
+func TestExpiredJWTToken(t *testing.T) {
+	maker, err := NewJWTMaker([][]byte{randomEd25519KeyPEM(t)}, "")
+	require.NoError(t, err)
+
+	token, payload, err := maker.CreateToken(util.RandomOwner(), -time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEmpty(t, payload)
+
+	payload, err = maker.VerifyToken(token)
+	require.EqualError(t, err, ErrExpiredToken.Error())
+	require.Nil(t, payload)
+}
+
+func TestInvalidJWTToken(t *testing.T) {
+	keyPEM := randomEd25519KeyPEM(t)
+	maker, err := NewJWTMaker([][]byte{keyPEM}, "goslack")
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), time.Minute)
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	// Unsigned token
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	payload, err := maker.VerifyToken(none + "." + parts[1] + ".")
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	// Signed by a key the maker doesn't accept
+	otherMaker, err := NewJWTMaker([][]byte{randomEd25519KeyPEM(t)}, "goslack")
+	require.NoError(t, err)
+	otherToken, _, err := otherMaker.CreateToken(util.RandomOwner(), time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(otherToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	// Issued for another audience
+	audienceMaker, err := NewJWTMaker([][]byte{keyPEM}, "other")
+	require.NoError(t, err)
+
+	payload, err = audienceMaker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidAudience.Error())
+	require.Nil(t, payload)
+
+	// Issued without an audience
+	noAudienceMaker, err := NewJWTMaker([][]byte{keyPEM}, "")
+	require.NoError(t, err)
+	noAudienceToken, _, err := noAudienceMaker.CreateToken(util.RandomOwner(), time.Minute)
+	require.NoError(t, err)
+
+	payload, err = audienceMaker.VerifyToken(noAudienceToken)
+	require.EqualError(t, err, ErrInvalidAudience.Error())
+	require.Nil(t, payload)
+}
+
+func TestJWTKeyRotation(t *testing.T) {
+	oldKey := randomRSAKeyPEM(t)
+	newKey := randomEd25519KeyPEM(t)
+
+	oldMaker, err := NewJWTMaker([][]byte{oldKey}, "")
+	require.NoError(t, err)
+	oldToken, _, err := oldMaker.CreateToken(util.RandomOwner(), time.Minute)
+	require.NoError(t, err)
+
+	// The public half of a new key is published before it signs anything
+	_, err = NewJWTMaker([][]byte{publicKeyPEM(t, newKey)}, "")
+	require.Error(t, err)
+	publishingMaker, err := NewJWTMaker([][]byte{oldKey, publicKeyPEM(t, newKey)}, "")
+	require.NoError(t, err)
+	require.Len(t, publishingMaker.(KeySetProvider).JWKS().Keys, 2)
+
+	// The new key signs, while tokens signed with the old key are still accepted
+	rotatedMaker, err := NewJWTMaker([][]byte{newKey, publicKeyPEM(t, oldKey)}, "")
+	require.NoError(t, err)
+
+	_, err = rotatedMaker.VerifyToken(oldToken)
+	require.NoError(t, err)
+
+	newToken, _, err := rotatedMaker.CreateToken(util.RandomOwner(), time.Minute)
+	require.NoError(t, err)
+	_, err = publishingMaker.VerifyToken(newToken)
+	require.NoError(t, err)
+
+	// Once the old key is removed, its tokens are rejected
+	retiredMaker, err := NewJWTMaker([][]byte{newKey}, "")
+	require.NoError(t, err)
+
+	_, err = retiredMaker.VerifyToken(oldToken)
+	require.EqualError(t, err, ErrInvalidToken.Error())
+}
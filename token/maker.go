@@ -2,11 +2,21 @@ package token
 
 import "time"
 
+// Token formats a server can issue
+const (
+	FormatPASETO = "paseto" // Encrypted with TOKEN_SYMMETRIC_KEY
+	FormatJWT    = "jwt"    // Signed with RS256 or EdDSA keys, for gateways that validate standard JWTs
+)
+
 // Maker is an interface for managing tokens
 type Maker interface {
-	// CreateToken creates a new token for a specific username and duration
+	// CreateToken creates a new user token with full access for a specific username and duration
 	CreateToken(username string, duration time.Duration) (string, *Payload, error)
 
+	// CreateTokenWithClaims creates a new token with specific claims and duration,
+	// the maker's audience is used when the claims don't set one
+	CreateTokenWithClaims(claims Claims, duration time.Duration) (string, *Payload, error)
+
 	// VerifyToken checks if the token is valid or not
 	VerifyToken(token string) (*Payload, error)
 }
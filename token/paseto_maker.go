@@ -12,10 +12,14 @@ import (
 type PasetoMaker struct {
 	paseto       *paseto.V2
 	symmetricKey []byte
+	audience     string
+	legacy       LegacyTokens
 }
 
-// NewPasetoMaker creates a new PasetoMaker
-func NewPasetoMaker(symmetricKey string) (Maker, error) {
+// NewPasetoMaker creates a new PasetoMaker. Tokens it creates are issued for the audience,
+// and tokens issued for another audience are rejected; an empty audience accepts any.
+// Tokens without an audience or scopes are only accepted as legacy tokens.
+func NewPasetoMaker(symmetricKey string, audience string, legacy LegacyTokens) (Maker, error) {
 	if len(symmetricKey) != chacha20poly1305.KeySize {
 		return nil, fmt.Errorf("invalid key size: must be exactly %d characters", chacha20poly1305.KeySize)
 	}
@@ -23,14 +27,29 @@ func NewPasetoMaker(symmetricKey string) (Maker, error) {
 	maker := &PasetoMaker{
 		paseto:       paseto.NewV2(),
 		symmetricKey: []byte(symmetricKey),
+		audience:     audience,
+		legacy:       legacy,
 	}
 
 	return maker, nil
 }
 
-// CreateToken creates a new token for a specific username and duration
+// CreateToken creates a new user token with full access for a specific username and duration
 func (maker *PasetoMaker) CreateToken(username string, duration time.Duration) (string, *Payload, error) {
-	payload, err := NewPayload(username, duration)
+	return maker.CreateTokenWithClaims(Claims{
+		Username: username,
+		Type:     TokenTypeUser,
+		Scopes:   []string{ScopeAll},
+	}, duration)
+}
+
+// CreateTokenWithClaims creates a new token with specific claims and duration
+func (maker *PasetoMaker) CreateTokenWithClaims(claims Claims, duration time.Duration) (string, *Payload, error) {
+	if claims.Audience == "" {
+		claims.Audience = maker.audience
+	}
+
+	payload, err := NewPayloadWithClaims(claims, duration)
 	if err != nil {
 		return "", payload, err
 	}
@@ -53,5 +72,17 @@ func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
 		return nil, err
 	}
 
+	// Tokens issued before audiences and scopes existed carry neither
+	legacy := maker.legacy.accepts(payload)
+	if maker.audience != "" && payload.Audience != maker.audience && (payload.Audience != "" || !legacy) {
+		return nil, ErrInvalidAudience
+	}
+	if payload.Scopes == nil {
+		if !legacy {
+			return nil, ErrInvalidToken
+		}
+		payload.Scopes = []string{ScopeAll}
+	}
+
 	return payload, nil
 }
@@ -4,12 +4,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/heyrmi/goslack/util"
+	"github.com/o1egl/paseto"
 	"github.com/stretchr/testify/require"
 )
 
 func TestPasetoMaker(t *testing.T) {
-	maker, err := NewPasetoMaker(util.RandomString(32))
+	maker, err := NewPasetoMaker(util.RandomString(32), "goslack", LegacyTokens{})
 	require.NoError(t, err)
 
 	username := util.RandomOwner()
@@ -29,12 +31,15 @@ func TestPasetoMaker(t *testing.T) {
 
 	require.NotZero(t, payload.ID)
 	require.Equal(t, username, payload.Username)
+	require.Equal(t, TokenTypeUser, payload.Type)
+	require.Equal(t, []string{ScopeAll}, payload.Scopes)
+	require.Equal(t, "goslack", payload.Audience)
 	require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 	require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 }
 
 func TestExpiredPasetoToken(t *testing.T) {
-	maker, err := NewPasetoMaker(util.RandomString(32))
+	maker, err := NewPasetoMaker(util.RandomString(32), "goslack", LegacyTokens{})
 	require.NoError(t, err)
 
 	token, payload, err := maker.CreateToken(util.RandomOwner(), -time.Minute)
@@ -47,3 +52,107 @@ func TestExpiredPasetoToken(t *testing.T) {
 	require.EqualError(t, err, ErrExpiredToken.Error())
 	require.Nil(t, payload)
 }
+
+func TestPasetoTokenWithClaims(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32), "goslack", LegacyTokens{})
+	require.NoError(t, err)
+
+	claims := Claims{
+		Username:  util.RandomOwner(),
+		SubjectID: util.RandomInt(1, 1000),
+		Type:      TokenTypeBot,
+		Scopes:    []string{"messages:write", "channels:*"},
+	}
+
+	token, _, err := maker.CreateTokenWithClaims(claims, time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, claims.Username, payload.Username)
+	require.Equal(t, claims.SubjectID, payload.SubjectID)
+	require.Equal(t, TokenTypeBot, payload.Type)
+	require.Equal(t, claims.Scopes, payload.Scopes)
+	require.Equal(t, "goslack", payload.Audience)
+
+	require.True(t, payload.HasScope("messages:write"))
+	require.False(t, payload.HasScope("messages:read"))
+	require.True(t, payload.HasScope("channels:read"))
+	require.True(t, payload.HasScope("channels:write"))
+	require.False(t, payload.HasScope("files:read"))
+}
+
+func TestPasetoTokenAudience(t *testing.T) {
+	key := util.RandomString(32)
+
+	maker, err := NewPasetoMaker(key, "goslack", LegacyTokens{})
+	require.NoError(t, err)
+	otherMaker, err := NewPasetoMaker(key, "other", LegacyTokens{})
+	require.NoError(t, err)
+	anyMaker, err := NewPasetoMaker(key, "", LegacyTokens{})
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(util.RandomOwner(), time.Minute)
+	require.NoError(t, err)
+
+	payload, err := otherMaker.VerifyToken(token)
+	require.EqualError(t, err, ErrInvalidAudience.Error())
+	require.Nil(t, payload)
+
+	payload, err = anyMaker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, "goslack", payload.Audience)
+}
+
+func TestPasetoLegacyToken(t *testing.T) {
+	key := util.RandomString(32)
+	cutover := time.Now().Add(-5 * time.Minute)
+	maker, err := NewPasetoMaker(key, "goslack", LegacyTokens{Cutover: cutover, MaxAge: 15 * time.Minute})
+	require.NoError(t, err)
+
+	// A payload as issued before tokens carried a subject, type, scopes or audience
+	legacyToken := func(issuedAt time.Time) string {
+		token, err := paseto.NewV2().Encrypt([]byte(key), map[string]interface{}{
+			"id":         uuid.New(),
+			"username":   "legacy",
+			"issued_at":  issuedAt,
+			"expired_at": time.Now().Add(time.Hour),
+		}, nil)
+		require.NoError(t, err)
+		return token
+	}
+
+	// Issued before the cutover and still within an access token's lifetime
+	payload, err := maker.VerifyToken(legacyToken(time.Now().Add(-10 * time.Minute)))
+	require.NoError(t, err)
+	require.Equal(t, "legacy", payload.Username)
+	require.Empty(t, payload.Audience)
+	require.Equal(t, []string{ScopeAll}, payload.Scopes)
+	require.True(t, payload.HasScope("messages:write"))
+
+	// Issued before the cutover, but an access token's lifetime has passed since
+	payload, err = maker.VerifyToken(legacyToken(time.Now().Add(-30 * time.Minute)))
+	require.EqualError(t, err, ErrInvalidAudience.Error())
+	require.Nil(t, payload)
+
+	// Issued after the cutover
+	payload, err = maker.VerifyToken(legacyToken(time.Now()))
+	require.EqualError(t, err, ErrInvalidAudience.Error())
+	require.Nil(t, payload)
+
+	// Without a cutover no token may lack an audience or scopes
+	anyMaker, err := NewPasetoMaker(key, "", LegacyTokens{MaxAge: 15 * time.Minute})
+	require.NoError(t, err)
+	payload, err = anyMaker.VerifyToken(legacyToken(time.Now().Add(-10 * time.Minute)))
+	require.EqualError(t, err, ErrInvalidToken.Error())
+	require.Nil(t, payload)
+
+	// Tokens issued without scopes since don't get full access
+	token, _, err := maker.CreateTokenWithClaims(Claims{Username: util.RandomOwner(), Type: TokenTypeBot}, time.Minute)
+	require.NoError(t, err)
+
+	payload, err = maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Empty(t, payload.Scopes)
+	require.False(t, payload.HasScope("messages:read"))
+}
@@ -2,6 +2,7 @@ package token
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,28 +10,92 @@ import (
 
 // Different types of error returned by the VerifyToken function
 var (
-	ErrInvalidToken = errors.New("token is invalid")
-	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken    = errors.New("token is invalid")
+	ErrExpiredToken    = errors.New("token has expired")
+	ErrInvalidAudience = errors.New("token audience is invalid")
 )
 
+// Token types say who a token was issued to
+const (
+	TokenTypeUser          = "user"          // Issued on login
+	TokenTypeBot           = "bot"           // Issued to a bot or integration
+	TokenTypePersonal      = "personal"      // Created by a user for their own scripts
+	TokenTypeImpersonation = "impersonation" // Issued to an admin acting as another user
+)
+
+// ScopeAll grants every scope. Scopes are "<area>:<access>", e.g. "messages:write",
+// and "<area>:*" grants every access to an area.
+const ScopeAll = "*"
+
+// Claims are what a token is issued for, besides its lifetime
+type Claims struct {
+	Username  string
+	SubjectID int64
+	Type      string
+	Scopes    []string
+	Audience  string
+}
+
 // Payload contains the payload data of the token
 type Payload struct {
 	ID        uuid.UUID `json:"id"`
 	Username  string    `json:"username"`
+	SubjectID int64     `json:"sub,omitempty"`
+	Type      string    `json:"type"`
+	Scopes    []string  `json:"scopes"`
+	Audience  string    `json:"aud,omitempty"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-// NewPayload creates a new token payload with a specific username and duration
+// LegacyTokens says which tokens issued before tokens carried an audience and
+// scopes are still accepted: those issued before the cutover, for at most
+// MaxAge after they were issued. They keep the full access they were issued
+// with. A zero cutover accepts none.
+type LegacyTokens struct {
+	Cutover time.Time
+	MaxAge  time.Duration
+}
+
+// accepts reports whether a token without an audience or scopes is still accepted
+func (legacy LegacyTokens) accepts(payload *Payload) bool {
+	return payload.IssuedAt.Before(legacy.Cutover) && time.Since(payload.IssuedAt) <= legacy.MaxAge
+}
+
+// NewPayload creates a new user token payload with full access for a specific username and duration
 func NewPayload(username string, duration time.Duration) (*Payload, error) {
+	return NewPayloadWithClaims(Claims{
+		Username: username,
+		Type:     TokenTypeUser,
+		Scopes:   []string{ScopeAll},
+	}, duration)
+}
+
+// NewPayloadWithClaims creates a new token payload with specific claims and duration
+func NewPayloadWithClaims(claims Claims, duration time.Duration) (*Payload, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, err
 	}
 
+	tokenType := claims.Type
+	if tokenType == "" {
+		tokenType = TokenTypeUser
+	}
+
+	// Only tokens issued before scopes existed are left without any
+	scopes := claims.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
 	payload := &Payload{
 		ID:        tokenID,
-		Username:  username,
+		Username:  claims.Username,
+		SubjectID: claims.SubjectID,
+		Type:      tokenType,
+		Scopes:    scopes,
+		Audience:  claims.Audience,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}
@@ -44,3 +109,14 @@ func (payload *Payload) Valid() error {
 	}
 	return nil
 }
+
+// HasScope reports whether the token grants a scope such as "messages:read"
+func (payload *Payload) HasScope(scope string) bool {
+	area, _, _ := strings.Cut(scope, ":")
+	for _, granted := range payload.Scopes {
+		if granted == ScopeAll || granted == scope || granted == area+":*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AWSCredentials are the credentials AWS API requests are signed with
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // Set for temporary credentials
+}
+
+// SignAWSRequest adds an AWS Signature Version 4 authorization header to a
+// request for a service in a region. The request must have its Content-Type
+// set, and body must be what it sends. An X-Amz-Content-Sha256 header, which
+// S3 requires, is signed when the request has one.
+func SignAWSRequest(request *http.Request, body []byte, credentials AWSCredentials, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	canonicalHeaders := []string{
+		"content-type:" + request.Header.Get("Content-Type"),
+		"host:" + request.URL.Host,
+	}
+	signedHeaders := "content-type;host"
+	if contentSHA256 := request.Header.Get("X-Amz-Content-Sha256"); contentSHA256 != "" {
+		canonicalHeaders = append(canonicalHeaders, "x-amz-content-sha256:"+contentSHA256)
+		signedHeaders += ";x-amz-content-sha256"
+	}
+	canonicalHeaders = append(canonicalHeaders, "x-amz-date:"+amzDate)
+	signedHeaders += ";x-amz-date"
+	if credentials.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+		canonicalHeaders = append(canonicalHeaders, "x-amz-security-token:"+credentials.SessionToken)
+		signedHeaders += ";x-amz-security-token"
+	}
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		strings.Join(canonicalHeaders, "\n"),
+		"",
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -1,65 +1,487 @@
 package util
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
+// secretsLoadTimeout bounds how long startup waits for the secrets backend
+const secretsLoadTimeout = 30 * time.Second
+
 // Config stores all configuration of the application.
 // The values are read by viper from a config file or environment variables.
 type Config struct {
-	DBDriver                string        `mapstructure:"DB_DRIVER"`
 	DBSource                string        `mapstructure:"DB_SOURCE"`
+	DBMaxConns              int32         `mapstructure:"DB_MAX_CONNS"` // Largest number of connections in the pool
+	DBMinConns              int32         `mapstructure:"DB_MIN_CONNS"` // Connections kept open even when idle
+	DBMaxConnLifetime       time.Duration `mapstructure:"DB_MAX_CONN_LIFETIME"`
+	DBMaxConnIdleTime       time.Duration `mapstructure:"DB_MAX_CONN_IDLE_TIME"`
+	DBHealthCheckPeriod     time.Duration `mapstructure:"DB_HEALTH_CHECK_PERIOD"` // How often idle connections are checked
+	DBStatementTimeout      time.Duration `mapstructure:"DB_STATEMENT_TIMEOUT"`   // Longest a single query may run, 0 is unlimited
 	HTTPServerAddress       string        `mapstructure:"HTTP_SERVER_ADDRESS"`
 	TokenSymmetricKey       string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	PublicIDKey             string        `mapstructure:"PUBLIC_ID_KEY"` // Encrypts public IDs, TOKEN_SYMMETRIC_KEY when empty
 	AccessTokenDuration     time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration    time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	TokenAudience           string        `mapstructure:"TOKEN_AUDIENCE"`       // Tokens issued for other audiences are rejected
+	TokenFormat             string        `mapstructure:"TOKEN_FORMAT"`         // "paseto" or "jwt"
+	TokenLegacyCutover      string        `mapstructure:"TOKEN_LEGACY_CUTOVER"` // RFC 3339 time before which PASETO tokens may lack an audience and scopes
+	JWTKeyFiles             string        `mapstructure:"JWT_KEY_FILES"`        // Comma-separated PEM key files, the first signs tokens
 	WSReadBufferSize        int           `mapstructure:"WS_READ_BUFFER_SIZE"`
 	WSWriteBufferSize       int           `mapstructure:"WS_WRITE_BUFFER_SIZE"`
 	WSMaxConnectionsPerUser int           `mapstructure:"WS_MAX_CONNECTIONS_PER_USER"`
 	WSPingInterval          time.Duration `mapstructure:"WS_PING_INTERVAL"`
 	WSPongTimeout           time.Duration `mapstructure:"WS_PONG_TIMEOUT"`
+	WSMaxMessageSize        int64         `mapstructure:"WS_MAX_MESSAGE_SIZE"` // Largest message a client may send, in bytes
+	WSBatchEvents           bool          `mapstructure:"WS_BATCH_EVENTS"`     // Send events queued within WS_BATCH_WINDOW as one JSON array frame
+	WSBatchWindow           time.Duration `mapstructure:"WS_BATCH_WINDOW"`
+	WSDraftDebounce         time.Duration `mapstructure:"WS_DRAFT_DEBOUNCE"` // How long draft_update messages must pause before the draft is saved
+	WSTypingTTL             time.Duration `mapstructure:"WS_TYPING_TTL"`     // How long a typing indicator lasts unless it is renewed
+	// HTTP configuration
+	CORSAllowedOrigins string `mapstructure:"CORS_ALLOWED_ORIGINS"` // Comma-separated, empty disallows cross-origin requests
+	CORSAllowedHeaders string `mapstructure:"CORS_ALLOWED_HEADERS"` // Comma-separated
+	TrustedProxies     string `mapstructure:"TRUSTED_PROXIES"`      // Comma-separated IPs or CIDRs, empty trusts none
+	HTTPMaxBodySize    int64  `mapstructure:"HTTP_MAX_BODY_SIZE"`   // Largest request body in bytes, file uploads excepted
+	AdminAPIToken      string `mapstructure:"ADMIN_API_TOKEN"`      // Token operator routes such as /admin/config require, empty disables them
+	// Maintenance configuration
+	MaintenanceMode          bool          `mapstructure:"MAINTENANCE_MODE"` // Start in maintenance, operators toggle it at /admin/maintenance
+	MaintenanceMessage       string        `mapstructure:"MAINTENANCE_MESSAGE"`
+	MaintenanceRetryAfter    time.Duration `mapstructure:"MAINTENANCE_RETRY_AFTER"`    // Sent in Retry-After with rejected writes
+	MaintenanceAllowedRoutes string        `mapstructure:"MAINTENANCE_ALLOWED_ROUTES"` // Comma-separated routes that accept writes during maintenance, e.g. /users/login
+	// Challenge configuration (optional)
+	ChallengeProvider      string        `mapstructure:"CHALLENGE_PROVIDER"`  // "hcaptcha", "turnstile" or "pow", empty disables challenges
+	ChallengeEndpoints     string        `mapstructure:"CHALLENGE_ENDPOINTS"` // Comma-separated endpoints that require a solved challenge
+	ChallengeSiteKey       string        `mapstructure:"CHALLENGE_SITE_KEY"`
+	ChallengeSecretKey     string        `mapstructure:"CHALLENGE_SECRET_KEY"`     // Signs proof-of-work challenges, TOKEN_SYMMETRIC_KEY when empty
+	ChallengePoWDifficulty int           `mapstructure:"CHALLENGE_POW_DIFFICULTY"` // Leading zero bits a proof-of-work hash needs
+	ChallengePoWTTL        time.Duration `mapstructure:"CHALLENGE_POW_TTL"`
+	// TLS configuration (optional, use a certificate or ACME hostnames)
+	TLSCertFile         string        `mapstructure:"TLS_CERT_FILE"`
+	TLSKeyFile          string        `mapstructure:"TLS_KEY_FILE"`
+	TLSAutocertHosts    string        `mapstructure:"TLS_AUTOCERT_HOSTS"` // Comma-separated hostnames for Let's Encrypt certificates
+	TLSAutocertEmail    string        `mapstructure:"TLS_AUTOCERT_EMAIL"`
+	TLSAutocertCacheDir string        `mapstructure:"TLS_AUTOCERT_CACHE_DIR"`
+	HTTPRedirectAddress string        `mapstructure:"HTTP_REDIRECT_ADDRESS"` // Plain HTTP listener redirecting to HTTPS, empty disables
+	HSTSMaxAge          time.Duration `mapstructure:"HSTS_MAX_AGE"`          // 0 disables the HSTS header
+	// Presence configuration
+	PresenceMode               string        `mapstructure:"PRESENCE_MODE"` // "activity", "connection" or "hybrid"
+	PresenceOfflineGracePeriod time.Duration `mapstructure:"PRESENCE_OFFLINE_GRACE_PERIOD"`
+	PresenceAwayAfter          time.Duration `mapstructure:"PRESENCE_AWAY_AFTER"`    // Default inactivity before away, 0 disables
+	PresenceOfflineAfter       time.Duration `mapstructure:"PRESENCE_OFFLINE_AFTER"` // Default inactivity before offline
+	CalendarSyncInterval       time.Duration `mapstructure:"CALENDAR_SYNC_INTERVAL"` // How often calendar feeds are fetched
+	// Reaction configuration
+	ReactionMaxPerUser  int32 `mapstructure:"REACTION_MAX_PER_USER"` // Default reactions one user can add to a message
+	ReactionMaxDistinct int32 `mapstructure:"REACTION_MAX_DISTINCT"` // Default different emojis a message can have
+	// Message configuration
+	MessageEditWindow   time.Duration `mapstructure:"MESSAGE_EDIT_WINDOW"`   // Default time members can edit their messages, 0 is unlimited
+	MessageDeleteWindow time.Duration `mapstructure:"MESSAGE_DELETE_WINDOW"` // Default time members can delete their messages, 0 is unlimited
+	PinMaxPerChannel    int32         `mapstructure:"PIN_MAX_PER_CHANNEL"`   // Default messages that can be pinned in one channel
+	MessageMaxFiles     int           `mapstructure:"MESSAGE_MAX_FILES"`     // Files that can be attached to one message
+	// Acknowledgment configuration
+	AcknowledgmentReminderDelay    time.Duration `mapstructure:"ACKNOWLEDGMENT_REMINDER_DELAY"`    // Default time before members who haven't acknowledged a message are reminded
+	AcknowledgmentReminderInterval time.Duration `mapstructure:"ACKNOWLEDGMENT_REMINDER_INTERVAL"` // How often due acknowledgment reminders are sent
+	// Outbox configuration
+	OutboxRelayInterval time.Duration `mapstructure:"OUTBOX_RELAY_INTERVAL"` // How often unpublished real-time events are relayed
+	OutboxRelayDelay    time.Duration `mapstructure:"OUTBOX_RELAY_DELAY"`    // How old an unpublished event is before the relay sends it
+	OutboxRetention     time.Duration `mapstructure:"OUTBOX_RETENTION"`      // How long published events are kept
+	// Workspace event stream configuration
+	EventStreamDelay     time.Duration `mapstructure:"EVENT_STREAM_DELAY"`     // How old an event is before it's read or published
+	EventStreamRetention time.Duration `mapstructure:"EVENT_STREAM_RETENTION"` // How long workspace events are kept
+	EventPublisher       string        `mapstructure:"EVENT_PUBLISHER"`        // Broker workspace events are pushed to: "kafka" or empty for none
+	EventPublishInterval time.Duration `mapstructure:"EVENT_PUBLISH_INTERVAL"` // How often new workspace events are published
+	KafkaRESTURL         string        `mapstructure:"KAFKA_REST_URL"`         // Kafka REST Proxy base URL
+	KafkaTopic           string        `mapstructure:"KAFKA_TOPIC"`            // Topic workspace events are produced to
+	// Outgoing webhook configuration
+	WebhookDeliveryInterval time.Duration `mapstructure:"WEBHOOK_DELIVERY_INTERVAL"` // How often due webhook deliveries are sent
+	WebhookMaxAttempts      int32         `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`      // Attempts at a delivery before it is marked failed
+	WebhookRetryDelay       time.Duration `mapstructure:"WEBHOOK_RETRY_DELAY"`       // Wait after the first failed attempt, doubled after each one
+	WebhookRetention        time.Duration `mapstructure:"WEBHOOK_RETENTION"`         // How long finished deliveries are kept
+	// Huddle configuration
+	HuddleICEServers string `mapstructure:"HUDDLE_ICE_SERVERS"` // Comma-separated STUN/TURN URLs handed to huddle clients
+	// Deletion configuration
+	DeletionRecoveryWindow       time.Duration `mapstructure:"DELETION_RECOVERY_WINDOW"`       // How long deleted channels and workspaces can be restored
+	DeletionPurgeInterval        time.Duration `mapstructure:"DELETION_PURGE_INTERVAL"`        // How often expired deletions are purged
+	TeardownInterval             time.Duration `mapstructure:"TEARDOWN_INTERVAL"`              // How often purged workspaces are torn down
+	TeardownBatchSize            int32         `mapstructure:"TEARDOWN_BATCH_SIZE"`            // Rows deleted per statement during teardown
+	OrganizationDeletionWindow   time.Duration `mapstructure:"ORGANIZATION_DELETION_WINDOW"`   // How long a requested organization deletion can be cancelled
+	OrganizationDeletionInterval time.Duration `mapstructure:"ORGANIZATION_DELETION_INTERVAL"` // How often due organization deletions are worked on
+	DeletedMessageRetention      time.Duration `mapstructure:"DELETED_MESSAGE_RETENTION"`      // How long deleted messages are kept as tombstones
+	CleanupInterval              time.Duration `mapstructure:"CLEANUP_INTERVAL"`               // How often deleted messages and incomplete uploads are purged
+	FileReconciliationInterval   time.Duration `mapstructure:"FILE_RECONCILIATION_INTERVAL"`   // How often the file store is checked against the files table
+	FileQuarantineRetention      time.Duration `mapstructure:"FILE_QUARANTINE_RETENTION"`      // How long orphaned files are kept in quarantine
+	// Message partition configuration
+	MessageRetention             time.Duration `mapstructure:"MESSAGE_RETENTION"`              // How long messages are kept before their month is dropped, 0 keeps them
+	MessagePartitionsAhead       int           `mapstructure:"MESSAGE_PARTITIONS_AHEAD"`       // Months of message partitions created ahead of time
+	PartitionMaintenanceInterval time.Duration `mapstructure:"PARTITION_MAINTENANCE_INTERVAL"` // How often message partitions are created and dropped
+	// Message archive configuration
+	MessageArchiveAfter    time.Duration `mapstructure:"MESSAGE_ARCHIVE_AFTER"`    // How old messages are before they move to the archive store, 0 disables archiving
+	MessageArchiveInterval time.Duration `mapstructure:"MESSAGE_ARCHIVE_INTERVAL"` // How often old messages are archived
+	ArchiveStorage         string        `mapstructure:"ARCHIVE_STORAGE"`          // "local" or "s3" (AWS_S3_BUCKET in AWS_REGION)
+	ArchiveStoragePath     string        `mapstructure:"ARCHIVE_STORAGE_PATH"`     // Directory archives are written to with local storage
+	// Channel stats configuration
+	ChannelStatsRollupHour   int `mapstructure:"CHANNEL_STATS_ROLLUP_HOUR"`   // Hour of the day (UTC) the channel stats rollup runs
+	ChannelStatsLookbackDays int `mapstructure:"CHANNEL_STATS_LOOKBACK_DAYS"` // Days the rollup recomputes, so late replies and deletions are counted
+	// Feature flag configuration
+	FeatureFlagCacheTTL time.Duration `mapstructure:"FEATURE_FLAG_CACHE_TTL"` // How long workspace flags are cached per server
+	// Localization configuration
+	I18NCatalogPath string `mapstructure:"I18N_CATALOG_PATH"` // Directory of <language>.json message catalogs, empty uses English only
+	// Translation configuration (optional)
+	TranslationProvider string `mapstructure:"TRANSLATION_PROVIDER"` // "deepl" or "google", empty disables translation
+	TranslationAPIKey   string `mapstructure:"TRANSLATION_API_KEY"`
+	TranslationAPIURL   string `mapstructure:"TRANSLATION_API_URL"` // Overrides the provider's endpoint
+	// Email configuration (emails are logged instead of sent when SMTP_HOST is empty)
+	EmailProvider            string        `mapstructure:"EMAIL_PROVIDER"` // "smtp", "sendgrid", "ses" or "log", empty picks smtp or log
+	SMTPHost                 string        `mapstructure:"SMTP_HOST"`
+	SMTPPort                 int           `mapstructure:"SMTP_PORT"`
+	SMTPUsername             string        `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword             string        `mapstructure:"SMTP_PASSWORD"`
+	SendGridAPIKey           string        `mapstructure:"SENDGRID_API_KEY"`
+	SESRegion                string        `mapstructure:"SES_REGION"`
+	SESAccessKeyID           string        `mapstructure:"SES_ACCESS_KEY_ID"`
+	SESSecretAccessKey       string        `mapstructure:"SES_SECRET_ACCESS_KEY"`
+	EmailFrom                string        `mapstructure:"EMAIL_FROM"`
+	EmailQueueInterval       time.Duration `mapstructure:"EMAIL_QUEUE_INTERVAL"`       // How often queued emails are sent
+	EmailMaxAttempts         int32         `mapstructure:"EMAIL_MAX_ATTEMPTS"`         // Attempts at sending an email before it is marked failed
+	EmailRetryDelay          time.Duration `mapstructure:"EMAIL_RETRY_DELAY"`          // Wait after the first failed attempt, doubled after each one
+	EmailDeliveryRetention   time.Duration `mapstructure:"EMAIL_DELIVERY_RETENTION"`   // How long sent and failed emails are kept
+	EmailWebhookToken        string        `mapstructure:"EMAIL_WEBHOOK_TOKEN"`        // Token bounce and complaint webhooks must carry, empty disables them
+	AppBaseURL               string        `mapstructure:"APP_BASE_URL"`               // Web app address used in links sent by email
+	InvitationResendCooldown time.Duration `mapstructure:"INVITATION_RESEND_COOLDOWN"` // Minimum time between invitation emails
 	// File storage configuration
-	FileStoragePath         string `mapstructure:"FILE_STORAGE_PATH"`
-	FileMaxSize             int64  `mapstructure:"FILE_MAX_SIZE"`
-	FileAllowedTypes        string `mapstructure:"FILE_ALLOWED_TYPES"`
-	EnableFileDeduplication bool   `mapstructure:"ENABLE_FILE_DEDUPLICATION"`
-	EnableThumbnails        bool   `mapstructure:"ENABLE_THUMBNAILS"`
+	FileStoragePath         string        `mapstructure:"FILE_STORAGE_PATH"`
+	FileMaxSize             int64         `mapstructure:"FILE_MAX_SIZE"`
+	FileAllowedTypes        string        `mapstructure:"FILE_ALLOWED_TYPES"`
+	EnableFileDeduplication bool          `mapstructure:"ENABLE_FILE_DEDUPLICATION"`
+	EnableThumbnails        bool          `mapstructure:"ENABLE_THUMBNAILS"`
+	PublicFileCacheMaxAge   time.Duration `mapstructure:"PUBLIC_FILE_CACHE_MAX_AGE"` // How long public files may be cached by browsers and CDNs
+	FileAllowActiveContent  bool          `mapstructure:"FILE_ALLOW_ACTIVE_CONTENT"` // Accept HTML files and SVG images with scripts
+	FileTrashRetention      time.Duration `mapstructure:"FILE_TRASH_RETENTION"`      // How long deleted files can be restored from the trash
+	FileTrashPurgeInterval  time.Duration `mapstructure:"FILE_TRASH_PURGE_INTERVAL"` // How often expired files are purged from the trash
+	// Voice message configuration
+	VoiceMessageAllowedTypes string        `mapstructure:"VOICE_MESSAGE_ALLOWED_TYPES"` // Comma-separated audio MIME types
+	VoiceMessageMaxDuration  time.Duration `mapstructure:"VOICE_MESSAGE_MAX_DURATION"`
+	// Video transcoding configuration
+	EnableVideoTranscoding  bool          `mapstructure:"ENABLE_VIDEO_TRANSCODING"`
+	FFmpegPath              string        `mapstructure:"FFMPEG_PATH"`
+	VideoTranscodeTimeout   time.Duration `mapstructure:"VIDEO_TRANSCODE_TIMEOUT"`   // Longest a single video may take to transcode
+	VideoProcessingInterval time.Duration `mapstructure:"VIDEO_PROCESSING_INTERVAL"` // How often queued videos are checked for
+	// Document preview configuration
+	EnableDocumentPreviews  bool          `mapstructure:"ENABLE_DOCUMENT_PREVIEWS"`
+	LibreOfficePath         string        `mapstructure:"LIBREOFFICE_PATH"`
+	DocumentPreviewTimeout  time.Duration `mapstructure:"DOCUMENT_PREVIEW_TIMEOUT"`  // Longest a single document may take to render
+	DocumentPreviewInterval time.Duration `mapstructure:"DOCUMENT_PREVIEW_INTERVAL"` // How often queued documents are checked for
 	// AWS S3 configuration (optional)
 	AWSS3Bucket  string `mapstructure:"AWS_S3_BUCKET"`
 	AWSRegion    string `mapstructure:"AWS_REGION"`
 	UseS3Storage bool   `mapstructure:"USE_S3_STORAGE"`
+	// Secrets configuration (optional, secrets override the config file and environment)
+	SecretsProvider    string `mapstructure:"SECRETS_PROVIDER"` // "vault" or "aws", empty reads secrets from the config file and environment only
+	VaultAddr          string `mapstructure:"VAULT_ADDR"`
+	VaultToken         string `mapstructure:"VAULT_TOKEN"`
+	VaultSecretPath    string `mapstructure:"VAULT_SECRET_PATH"` // e.g. secret/data/goslack for a KV version 2 engine mounted at secret
+	AWSSecretID        string `mapstructure:"AWS_SECRET_ID"`     // Secrets Manager secret holding a JSON object, in AWS_REGION
+	AWSAccessKeyID     string `mapstructure:"AWS_ACCESS_KEY_ID"`
+	AWSSecretAccessKey string `mapstructure:"AWS_SECRET_ACCESS_KEY"`
+	AWSSessionToken    string `mapstructure:"AWS_SESSION_TOKEN"`
 }
 
-// LoadConfig reads configuration from file or environment variables.
+// LoadConfig reads configuration in layers: defaults, then the app.env file in
+// path if there is one, then environment variables, then the secrets backend
+// set in SECRETS_PROVIDER. Each layer overrides the ones before it. An error is
+// returned when a required secret is missing.
 func LoadConfig(path string) (config Config, err error) {
-	viper.AddConfigPath(path)
-	viper.SetConfigName("app")
-	viper.SetConfigType("env")
+	v := viper.New()
+	v.AddConfigPath(path)
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+
+	v.AutomaticEnv()
+	// AutomaticEnv only covers keys viper already knows, bind the rest so
+	// settings missing from the config file can still come from the environment
+	for _, key := range configKeys() {
+		if err = v.BindEnv(key); err != nil {
+			return
+		}
+	}
+
+	// Set default values for database pool configuration
+	v.SetDefault("DB_MAX_CONNS", 25)
+	v.SetDefault("DB_MIN_CONNS", 2)
+	v.SetDefault("DB_MAX_CONN_LIFETIME", "1h")
+	v.SetDefault("DB_MAX_CONN_IDLE_TIME", "30m")
+	v.SetDefault("DB_HEALTH_CHECK_PERIOD", "1m")
+	v.SetDefault("DB_STATEMENT_TIMEOUT", "30s")
 
-	viper.AutomaticEnv()
+	v.SetDefault("TOKEN_AUDIENCE", "goslack")
+	v.SetDefault("TOKEN_FORMAT", "paseto")
+	v.SetDefault("TOKEN_LEGACY_CUTOVER", "")
+	v.SetDefault("JWT_KEY_FILES", "")
 
 	// Set default values for WebSocket configuration
-	viper.SetDefault("WS_READ_BUFFER_SIZE", 1024)
-	viper.SetDefault("WS_WRITE_BUFFER_SIZE", 1024)
-	viper.SetDefault("WS_MAX_CONNECTIONS_PER_USER", 5)
-	viper.SetDefault("WS_PING_INTERVAL", "54s")
-	viper.SetDefault("WS_PONG_TIMEOUT", "60s")
+	v.SetDefault("WS_READ_BUFFER_SIZE", 1024)
+	v.SetDefault("WS_WRITE_BUFFER_SIZE", 1024)
+	v.SetDefault("WS_MAX_CONNECTIONS_PER_USER", 5)
+	v.SetDefault("WS_PING_INTERVAL", "54s")
+	v.SetDefault("WS_PONG_TIMEOUT", "60s")
+	v.SetDefault("WS_MAX_MESSAGE_SIZE", 16384) // Fits WebRTC session descriptions
+	v.SetDefault("WS_BATCH_EVENTS", false)
+	v.SetDefault("WS_BATCH_WINDOW", "50ms")
+	v.SetDefault("WS_DRAFT_DEBOUNCE", "1s")
+	v.SetDefault("WS_TYPING_TTL", "6s")
+
+	// Set default values for HTTP configuration
+	v.SetDefault("CORS_ALLOWED_ORIGINS", "")
+	v.SetDefault("CORS_ALLOWED_HEADERS", "Origin,Content-Type,Accept,Authorization,X-Requested-With,If-None-Match,Accept-Language,X-Challenge-Response")
+	v.SetDefault("TRUSTED_PROXIES", "")
+	v.SetDefault("HTTP_MAX_BODY_SIZE", 1048576) // 1MB
+	v.SetDefault("ADMIN_API_TOKEN", "")
+
+	// Set default values for maintenance configuration
+	v.SetDefault("MAINTENANCE_MODE", false)
+	v.SetDefault("MAINTENANCE_MESSAGE", "")
+	v.SetDefault("MAINTENANCE_RETRY_AFTER", "5m")
+	v.SetDefault("MAINTENANCE_ALLOWED_ROUTES", "/users/login")
+
+	// Set default values for challenge configuration
+	v.SetDefault("CHALLENGE_PROVIDER", "")
+	v.SetDefault("CHALLENGE_ENDPOINTS", "register")
+	v.SetDefault("CHALLENGE_POW_DIFFICULTY", 20)
+	v.SetDefault("CHALLENGE_POW_TTL", "5m")
+
+	// Set default values for TLS configuration
+	v.SetDefault("TLS_AUTOCERT_CACHE_DIR", "./certs")
+	v.SetDefault("HSTS_MAX_AGE", "8760h") // 1 year
+
+	// Set default values for presence configuration
+	v.SetDefault("PRESENCE_MODE", "hybrid")
+	v.SetDefault("PRESENCE_OFFLINE_GRACE_PERIOD", "30s")
+	v.SetDefault("PRESENCE_AWAY_AFTER", "0s")
+	v.SetDefault("PRESENCE_OFFLINE_AFTER", "30m")
+	v.SetDefault("CALENDAR_SYNC_INTERVAL", "15m")
+
+	// Set default values for reaction configuration
+	v.SetDefault("REACTION_MAX_PER_USER", 23)
+	v.SetDefault("REACTION_MAX_DISTINCT", 50)
+
+	// Set default values for message configuration
+	v.SetDefault("MESSAGE_EDIT_WINDOW", "0s")
+	v.SetDefault("MESSAGE_DELETE_WINDOW", "0s")
+	v.SetDefault("PIN_MAX_PER_CHANNEL", 100)
+	v.SetDefault("MESSAGE_MAX_FILES", 10)
+
+	// Set default values for acknowledgment configuration
+	v.SetDefault("ACKNOWLEDGMENT_REMINDER_DELAY", "24h")
+	v.SetDefault("ACKNOWLEDGMENT_REMINDER_INTERVAL", "5m")
+
+	// Set default values for outbox configuration
+	v.SetDefault("OUTBOX_RELAY_INTERVAL", "5s")
+	v.SetDefault("OUTBOX_RELAY_DELAY", "10s")
+	v.SetDefault("OUTBOX_RETENTION", "24h")
+
+	// Set default values for workspace event stream configuration
+	v.SetDefault("EVENT_STREAM_DELAY", "5s")
+	v.SetDefault("EVENT_STREAM_RETENTION", "168h") // 7 days
+	v.SetDefault("EVENT_PUBLISHER", "")
+	v.SetDefault("EVENT_PUBLISH_INTERVAL", "5s")
+	v.SetDefault("KAFKA_REST_URL", "")
+	v.SetDefault("KAFKA_TOPIC", "goslack.workspace-events")
+
+	// Set default values for outgoing webhook configuration
+	v.SetDefault("WEBHOOK_DELIVERY_INTERVAL", "5s")
+	v.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	v.SetDefault("WEBHOOK_RETRY_DELAY", "30s")
+	v.SetDefault("WEBHOOK_RETENTION", "168h") // 7 days
+
+	// Set default values for huddle configuration
+	v.SetDefault("HUDDLE_ICE_SERVERS", "stun:stun.l.google.com:19302")
+
+	// Set default values for deletion configuration
+	v.SetDefault("DELETION_RECOVERY_WINDOW", "720h") // 30 days
+	v.SetDefault("DELETION_PURGE_INTERVAL", "1h")
+	v.SetDefault("TEARDOWN_INTERVAL", "1m")
+	v.SetDefault("TEARDOWN_BATCH_SIZE", 500)
+	v.SetDefault("ORGANIZATION_DELETION_WINDOW", "72h")
+	v.SetDefault("ORGANIZATION_DELETION_INTERVAL", "1m")
+	v.SetDefault("DELETED_MESSAGE_RETENTION", "720h")
+	v.SetDefault("CLEANUP_INTERVAL", "1h")
+	v.SetDefault("FILE_RECONCILIATION_INTERVAL", "24h")
+	v.SetDefault("FILE_QUARANTINE_RETENTION", "720h")
+
+	// Set default values for message partition configuration
+	v.SetDefault("MESSAGE_RETENTION", "0s")
+	v.SetDefault("MESSAGE_PARTITIONS_AHEAD", 3)
+	v.SetDefault("PARTITION_MAINTENANCE_INTERVAL", "24h")
+
+	// Set default values for message archive configuration
+	v.SetDefault("MESSAGE_ARCHIVE_AFTER", "0s")
+	v.SetDefault("MESSAGE_ARCHIVE_INTERVAL", "1h")
+	v.SetDefault("ARCHIVE_STORAGE", "local")
+	v.SetDefault("ARCHIVE_STORAGE_PATH", "./archives")
+
+	// Set default values for channel stats configuration
+	v.SetDefault("CHANNEL_STATS_ROLLUP_HOUR", 2)
+	v.SetDefault("CHANNEL_STATS_LOOKBACK_DAYS", 7)
+
+	// Set default values for feature flag configuration
+	v.SetDefault("FEATURE_FLAG_CACHE_TTL", "30s")
+
+	// Set default values for localization configuration
+	v.SetDefault("I18N_CATALOG_PATH", "")
+
+	// Set default values for translation configuration
+	v.SetDefault("TRANSLATION_PROVIDER", "")
+	v.SetDefault("TRANSLATION_API_KEY", "")
+	v.SetDefault("TRANSLATION_API_URL", "")
+
+	// Set default values for email configuration
+	v.SetDefault("EMAIL_PROVIDER", "")
+	v.SetDefault("SMTP_HOST", "")
+	v.SetDefault("SMTP_PORT", 587)
+	v.SetDefault("SMTP_USERNAME", "")
+	v.SetDefault("SMTP_PASSWORD", "")
+	v.SetDefault("SENDGRID_API_KEY", "")
+	v.SetDefault("SES_REGION", "")
+	v.SetDefault("SES_ACCESS_KEY_ID", "")
+	v.SetDefault("SES_SECRET_ACCESS_KEY", "")
+	v.SetDefault("EMAIL_FROM", "GoSlack <noreply@localhost>")
+	v.SetDefault("EMAIL_QUEUE_INTERVAL", "10s")
+	v.SetDefault("EMAIL_MAX_ATTEMPTS", 5)
+	v.SetDefault("EMAIL_RETRY_DELAY", "1m")
+	v.SetDefault("EMAIL_DELIVERY_RETENTION", "720h") // 30 days
+	v.SetDefault("EMAIL_WEBHOOK_TOKEN", "")
+	v.SetDefault("APP_BASE_URL", "http://localhost:3000")
+	v.SetDefault("INVITATION_RESEND_COOLDOWN", "5m")
 
 	// Set default values for file storage configuration
-	viper.SetDefault("FILE_STORAGE_PATH", "./uploads")
-	viper.SetDefault("FILE_MAX_SIZE", 10485760) // 10MB
-	viper.SetDefault("FILE_ALLOWED_TYPES", "image/jpeg,image/png,image/gif,image/webp,application/pdf,text/plain,application/zip")
-	viper.SetDefault("ENABLE_FILE_DEDUPLICATION", true)
-	viper.SetDefault("ENABLE_THUMBNAILS", true)
-	viper.SetDefault("USE_S3_STORAGE", false)
-
-	err = viper.ReadInConfig()
+	v.SetDefault("FILE_STORAGE_PATH", "./uploads")
+	v.SetDefault("FILE_MAX_SIZE", 10485760) // 10MB
+	v.SetDefault("FILE_ALLOWED_TYPES", "image/jpeg,image/png,image/gif,image/webp,application/pdf,text/plain,application/zip")
+	v.SetDefault("ENABLE_FILE_DEDUPLICATION", true)
+	v.SetDefault("ENABLE_THUMBNAILS", true)
+	v.SetDefault("PUBLIC_FILE_CACHE_MAX_AGE", "1h")
+	v.SetDefault("FILE_ALLOW_ACTIVE_CONTENT", false)
+	v.SetDefault("USE_S3_STORAGE", false)
+
+	// Set default values for voice message configuration
+	v.SetDefault("VOICE_MESSAGE_ALLOWED_TYPES", "audio/webm,audio/ogg,audio/mpeg,audio/mp4,audio/wav")
+	v.SetDefault("VOICE_MESSAGE_MAX_DURATION", "5m")
+
+	// Set default values for video transcoding configuration
+	v.SetDefault("ENABLE_VIDEO_TRANSCODING", false)
+	v.SetDefault("FFMPEG_PATH", "ffmpeg")
+	v.SetDefault("VIDEO_TRANSCODE_TIMEOUT", "10m")
+	v.SetDefault("VIDEO_PROCESSING_INTERVAL", "1m")
+
+	// Set default values for document preview configuration
+	v.SetDefault("ENABLE_DOCUMENT_PREVIEWS", false)
+	v.SetDefault("LIBREOFFICE_PATH", "soffice")
+	v.SetDefault("DOCUMENT_PREVIEW_TIMEOUT", "2m")
+	v.SetDefault("DOCUMENT_PREVIEW_INTERVAL", "1m")
+
+	// The config file is optional, e.g. in containers configured through the environment
+	if err = v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			return
+		}
+	}
+
+	if err = v.Unmarshal(&config); err != nil {
+		return
+	}
+
+	provider, err := NewSecretsProvider(config)
 	if err != nil {
 		return
 	}
+	if provider != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), secretsLoadTimeout)
+		defer cancel()
+
+		var secrets map[string]string
+		secrets, err = provider.LoadSecrets(ctx)
+		if err != nil {
+			err = fmt.Errorf("cannot load secrets from %s: %w", provider.Name(), err)
+			return
+		}
+		for key, value := range secrets {
+			v.Set(key, value)
+		}
+		if err = v.Unmarshal(&config); err != nil {
+			return
+		}
+	}
 
-	err = viper.Unmarshal(&config)
+	err = config.ValidateSecrets()
 	return
 }
+
+// configKeys returns the setting names of every Config field
+func configKeys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("mapstructure"); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ValidateSecrets checks that the secrets the configuration needs are set,
+// so a misconfigured server fails at startup rather than on first use.
+func (config Config) ValidateSecrets() error {
+	var missing []string
+	require := func(key, value string) {
+		if value == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	require("DB_SOURCE", config.DBSource)
+
+	switch config.TokenFormat {
+	case "", "paseto":
+		if len(config.TokenSymmetricKey) != 32 {
+			return errors.New("invalid configuration: TOKEN_SYMMETRIC_KEY must be exactly 32 characters")
+		}
+	case "jwt":
+		require("JWT_KEY_FILES", config.JWTKeyFiles)
+	}
+
+	switch config.EmailProvider {
+	case "sendgrid":
+		require("SENDGRID_API_KEY", config.SendGridAPIKey)
+	case "ses":
+		require("SES_ACCESS_KEY_ID", config.SESAccessKeyID)
+		require("SES_SECRET_ACCESS_KEY", config.SESSecretAccessKey)
+	}
+	if config.SMTPUsername != "" {
+		require("SMTP_PASSWORD", config.SMTPPassword)
+	}
+
+	if config.TranslationProvider != "" {
+		require("TRANSLATION_API_KEY", config.TranslationAPIKey)
+	}
+
+	if config.MessageArchiveAfter > 0 && config.ArchiveStorage == "s3" {
+		require("AWS_ACCESS_KEY_ID", config.AWSAccessKeyID)
+		require("AWS_SECRET_ACCESS_KEY", config.AWSSecretAccessKey)
+	}
+
+	switch config.ChallengeProvider {
+	case "hcaptcha", "turnstile":
+		require("CHALLENGE_SECRET_KEY", config.ChallengeSecretKey)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("invalid configuration: missing required secrets %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Secrets providers
+const (
+	SecretsProviderVault = "vault"
+	SecretsProviderAWS   = "aws" // AWS Secrets Manager
+
+	// Upper bound on the size of a secrets response
+	maxSecretsResponseSize = 1 << 20
+)
+
+// SecretsProvider loads secrets from a secret manager. Secrets are named like
+// the settings they override, e.g. DB_SOURCE or TOKEN_SYMMETRIC_KEY.
+type SecretsProvider interface {
+	// Name identifies the provider in errors
+	Name() string
+	// LoadSecrets returns every secret the provider holds for the server
+	LoadSecrets(ctx context.Context) (map[string]string, error)
+}
+
+// NewSecretsProvider creates the secrets provider set in the configuration.
+// It returns nil when no provider is configured.
+func NewSecretsProvider(config Config) (SecretsProvider, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	switch config.SecretsProvider {
+	case "":
+		return nil, nil
+	case SecretsProviderVault:
+		if config.VaultAddr == "" || config.VaultToken == "" || config.VaultSecretPath == "" {
+			return nil, errors.New("invalid secrets configuration: VAULT_ADDR, VAULT_TOKEN and VAULT_SECRET_PATH are required")
+		}
+		return &vaultSecretsProvider{
+			addr:       strings.TrimSuffix(config.VaultAddr, "/"),
+			token:      config.VaultToken,
+			secretPath: strings.Trim(config.VaultSecretPath, "/"),
+			client:     client,
+		}, nil
+	case SecretsProviderAWS:
+		if config.AWSSecretID == "" || config.AWSRegion == "" || config.AWSAccessKeyID == "" || config.AWSSecretAccessKey == "" {
+			return nil, errors.New("invalid secrets configuration: AWS_SECRET_ID, AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required")
+		}
+		return &awsSecretsProvider{
+			secretID: config.AWSSecretID,
+			region:   config.AWSRegion,
+			credentials: AWSCredentials{
+				AccessKeyID:     config.AWSAccessKeyID,
+				SecretAccessKey: config.AWSSecretAccessKey,
+				SessionToken:    config.AWSSessionToken,
+			},
+			endpoint: fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", config.AWSRegion),
+			client:   client,
+			now:      time.Now,
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid secrets configuration: unknown provider %q", config.SecretsProvider)
+	}
+}
+
+// vaultSecretsProvider reads a secret from HashiCorp Vault's KV secrets engine
+type vaultSecretsProvider struct {
+	addr       string
+	token      string
+	secretPath string // API path of the secret, e.g. secret/data/goslack for KV version 2
+	client     *http.Client
+}
+
+func (p *vaultSecretsProvider) Name() string {
+	return SecretsProviderVault
+}
+
+func (p *vaultSecretsProvider) LoadSecrets(ctx context.Context) (map[string]string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+p.secretPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("X-Vault-Token", p.token)
+
+	var result struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := doSecretsRequest(p.client, request, &result); err != nil {
+		return nil, err
+	}
+
+	// KV version 2 nests the secret and its metadata in data
+	var versioned struct {
+		Data     map[string]interface{} `json:"data"`
+		Metadata json.RawMessage        `json:"metadata"`
+	}
+	if err := json.Unmarshal(result.Data, &versioned); err == nil && versioned.Metadata != nil {
+		return secretStrings(versioned.Data), nil
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(result.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode vault secret: %w", err)
+	}
+	return secretStrings(data), nil
+}
+
+// awsSecretsProvider reads a secret holding a JSON object from AWS Secrets Manager
+type awsSecretsProvider struct {
+	secretID    string
+	region      string
+	credentials AWSCredentials
+	endpoint    string
+	client      *http.Client
+	now         func() time.Time
+}
+
+func (p *awsSecretsProvider) Name() string {
+	return SecretsProviderAWS
+}
+
+func (p *awsSecretsProvider) LoadSecrets(ctx context.Context) (map[string]string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": p.secretID})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	request.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	SignAWSRequest(request, body, p.credentials, p.region, "secretsmanager", p.now())
+
+	var result struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := doSecretsRequest(p.client, request, &result); err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(result.SecretString), &data); err != nil {
+		return nil, errors.New("failed to decode AWS secret: it must hold a JSON object")
+	}
+	return secretStrings(data), nil
+}
+
+// doSecretsRequest sends a request to a secret manager and decodes its JSON response
+func doSecretsRequest(client *http.Client, request *http.Request, result interface{}) error {
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to load secrets: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxSecretsResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to load secrets: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		// Error responses don't carry secret values, but keep them short in logs
+		if len(body) > 200 {
+			body = body[:200]
+		}
+		return fmt.Errorf("failed to load secrets: provider returned %s: %s", response.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to decode secrets: %w", err)
+	}
+	return nil
+}
+
+// secretStrings turns secret values into strings, so numbers and booleans
+// can be stored as they would be written in app.env
+func secretStrings(data map[string]interface{}) map[string]string {
+	secrets := make(map[string]string, len(data))
+	for key, value := range data {
+		switch v := value.(type) {
+		case string:
+			secrets[strings.ToUpper(key)] = v
+		case nil:
+		default:
+			secrets[strings.ToUpper(key)] = fmt.Sprint(v)
+		}
+	}
+	return secrets
+}
@@ -0,0 +1,183 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testConfigFile = `DB_SOURCE=postgresql://file
+TOKEN_SYMMETRIC_KEY=12345678901234567890123456789012
+HTTP_SERVER_ADDRESS=0.0.0.0:8080
+`
+
+func writeTestConfig(t *testing.T, content string) string {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "app.env"), []byte(content), 0o600)
+	require.NoError(t, err)
+	return dir
+}
+
+func newTestVault(t *testing.T, token string, response string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		require.Equal(t, "/v1/secret/data/goslack", r.URL.Path)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	dir := writeTestConfig(t, testConfigFile)
+
+	// Defaults and the config file
+	config, err := LoadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, "postgresql://file", config.DBSource)
+	require.Equal(t, 5*time.Minute, config.ChallengePoWTTL)
+
+	// Environment variables override the file, including settings it doesn't have
+	t.Setenv("DB_SOURCE", "postgresql://env")
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	config, err = LoadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, "postgresql://env", config.DBSource)
+	require.Equal(t, "smtp.example.com", config.SMTPHost)
+
+	// The secrets backend overrides the environment
+	vault := newTestVault(t, "root-token", `{"data":{"data":{"DB_SOURCE":"postgresql://vault","smtp_port":2525},"metadata":{"version":3}}}`)
+	t.Setenv("SECRETS_PROVIDER", SecretsProviderVault)
+	t.Setenv("VAULT_ADDR", vault.URL)
+	t.Setenv("VAULT_TOKEN", "root-token")
+	t.Setenv("VAULT_SECRET_PATH", "/secret/data/goslack")
+	config, err = LoadConfig(dir)
+	require.NoError(t, err)
+	require.Equal(t, "postgresql://vault", config.DBSource)
+	require.Equal(t, 2525, config.SMTPPort)
+	require.Equal(t, "smtp.example.com", config.SMTPHost)
+
+	// Failing to load secrets stops startup
+	t.Setenv("VAULT_TOKEN", "wrong-token")
+	_, err = LoadConfig(dir)
+	require.ErrorContains(t, err, "cannot load secrets from vault")
+}
+
+func TestLoadConfigWithoutFile(t *testing.T) {
+	t.Setenv("DB_SOURCE", "postgresql://env")
+	t.Setenv("TOKEN_SYMMETRIC_KEY", "12345678901234567890123456789012")
+
+	config, err := LoadConfig(t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, "postgresql://env", config.DBSource)
+	require.Equal(t, "goslack", config.TokenAudience)
+}
+
+func TestLoadConfigMissingSecrets(t *testing.T) {
+	dir := writeTestConfig(t, "TOKEN_SYMMETRIC_KEY=12345678901234567890123456789012\nEMAIL_PROVIDER=sendgrid\n")
+
+	_, err := LoadConfig(dir)
+	require.EqualError(t, err, "invalid configuration: missing required secrets DB_SOURCE, SENDGRID_API_KEY")
+}
+
+func TestValidateSecrets(t *testing.T) {
+	config := Config{DBSource: "postgresql://db", TokenSymmetricKey: RandomString(32)}
+	require.NoError(t, config.ValidateSecrets())
+
+	short := config
+	short.TokenSymmetricKey = RandomString(16)
+	require.EqualError(t, short.ValidateSecrets(), "invalid configuration: TOKEN_SYMMETRIC_KEY must be exactly 32 characters")
+
+	jwt := config
+	jwt.TokenFormat = "jwt"
+	jwt.TokenSymmetricKey = ""
+	require.EqualError(t, jwt.ValidateSecrets(), "invalid configuration: missing required secrets JWT_KEY_FILES")
+
+	providers := config
+	providers.EmailProvider = "ses"
+	providers.TranslationProvider = "deepl"
+	providers.ChallengeProvider = "turnstile"
+	require.EqualError(t, providers.ValidateSecrets(),
+		"invalid configuration: missing required secrets SES_ACCESS_KEY_ID, SES_SECRET_ACCESS_KEY, TRANSLATION_API_KEY, CHALLENGE_SECRET_KEY")
+}
+
+func TestNewSecretsProvider(t *testing.T) {
+	provider, err := NewSecretsProvider(Config{})
+	require.NoError(t, err)
+	require.Nil(t, provider)
+
+	_, err = NewSecretsProvider(Config{SecretsProvider: SecretsProviderVault, VaultAddr: "https://vault"})
+	require.ErrorContains(t, err, "invalid secrets configuration")
+
+	_, err = NewSecretsProvider(Config{SecretsProvider: SecretsProviderAWS, AWSSecretID: "goslack"})
+	require.ErrorContains(t, err, "invalid secrets configuration")
+
+	_, err = NewSecretsProvider(Config{SecretsProvider: "consul"})
+	require.EqualError(t, err, `invalid secrets configuration: unknown provider "consul"`)
+}
+
+func TestVaultSecretsProviderKVVersion1(t *testing.T) {
+	vault := newTestVault(t, "root-token", `{"data":{"DB_SOURCE":"postgresql://vault","ENABLE_THUMBNAILS":false}}`)
+
+	provider, err := NewSecretsProvider(Config{
+		SecretsProvider: SecretsProviderVault,
+		VaultAddr:       vault.URL + "/",
+		VaultToken:      "root-token",
+		VaultSecretPath: "secret/data/goslack",
+	})
+	require.NoError(t, err)
+
+	secrets, err := provider.LoadSecrets(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"DB_SOURCE": "postgresql://vault", "ENABLE_THUMBNAILS": "false"}, secrets)
+}
+
+func TestAWSSecretsProvider(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		require.Equal(t, "20260301T120000Z", r.Header.Get("X-Amz-Date"))
+		require.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		authorization := r.Header.Get("Authorization")
+		require.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKID/20260301/eu-west-1/secretsmanager/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token, Signature="))
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "goslack/production", body["SecretId"])
+
+		w.Write([]byte(`{"Name":"goslack/production","SecretString":"{\"TOKEN_SYMMETRIC_KEY\":\"from-aws\"}"}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewSecretsProvider(Config{
+		SecretsProvider:    SecretsProviderAWS,
+		AWSSecretID:        "goslack/production",
+		AWSRegion:          "eu-west-1",
+		AWSAccessKeyID:     "AKID",
+		AWSSecretAccessKey: "secret",
+		AWSSessionToken:    "session",
+	})
+	require.NoError(t, err)
+
+	aws := provider.(*awsSecretsProvider)
+	aws.endpoint = server.URL
+	aws.now = func() time.Time { return now }
+
+	secrets, err := provider.LoadSecrets(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"TOKEN_SYMMETRIC_KEY": "from-aws"}, secrets)
+}
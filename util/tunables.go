@@ -0,0 +1,74 @@
+package util
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Tunables are the settings that can change while the server runs. They are
+// reloaded from the configuration on SIGHUP; every other setting still needs
+// a restart.
+type Tunables struct {
+	HTTPMaxBodySize         int64
+	FileMaxSize             int64
+	FileAllowedTypes        string
+	WSReadBufferSize        int // Applies to new connections
+	WSWriteBufferSize       int // Applies to new connections
+	WSMaxConnectionsPerUser int
+	WSMaxMessageSize        int64         // Applies to new connections
+	WSPingInterval          time.Duration // Applies to new connections
+	WSPongTimeout           time.Duration
+	WSDraftDebounce         time.Duration
+}
+
+// NewTunables takes the tunable settings from a configuration
+func NewTunables(config Config) Tunables {
+	return Tunables{
+		HTTPMaxBodySize:         config.HTTPMaxBodySize,
+		FileMaxSize:             config.FileMaxSize,
+		FileAllowedTypes:        config.FileAllowedTypes,
+		WSReadBufferSize:        config.WSReadBufferSize,
+		WSWriteBufferSize:       config.WSWriteBufferSize,
+		WSMaxConnectionsPerUser: config.WSMaxConnectionsPerUser,
+		WSMaxMessageSize:        config.WSMaxMessageSize,
+		WSPingInterval:          config.WSPingInterval,
+		WSPongTimeout:           config.WSPongTimeout,
+		WSDraftDebounce:         config.WSDraftDebounce,
+	}
+}
+
+// TunablesSnapshot is a set of tunables and when it was loaded
+type TunablesSnapshot struct {
+	Tunables
+	LoadedAt time.Time
+}
+
+// TunablesStore holds the current tunables. Readers always see a complete
+// snapshot, even while a reload swaps it.
+type TunablesStore struct {
+	current atomic.Pointer[TunablesSnapshot]
+}
+
+// NewTunablesStore creates a store holding the tunables of a configuration
+func NewTunablesStore(config Config) *TunablesStore {
+	store := &TunablesStore{}
+	store.Update(config)
+	return store
+}
+
+// Load returns the current tunables
+func (s *TunablesStore) Load() Tunables {
+	return s.current.Load().Tunables
+}
+
+// Snapshot returns the current tunables with when they were loaded
+func (s *TunablesStore) Snapshot() TunablesSnapshot {
+	return *s.current.Load()
+}
+
+// Update replaces the tunables with those of a newly loaded configuration
+func (s *TunablesStore) Update(config Config) TunablesSnapshot {
+	snapshot := &TunablesSnapshot{Tunables: NewTunables(config), LoadedAt: time.Now()}
+	s.current.Store(snapshot)
+	return *snapshot
+}
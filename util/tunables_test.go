@@ -0,0 +1,38 @@
+package util
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTunablesStore(t *testing.T) {
+	config := Config{FileMaxSize: 1024, WSPongTimeout: time.Minute, DBSource: "postgresql://db"}
+	store := NewTunablesStore(config)
+	loaded := store.Snapshot()
+	require.Equal(t, int64(1024), loaded.FileMaxSize)
+	require.Equal(t, time.Minute, loaded.WSPongTimeout)
+
+	// Readers see either the old or the new snapshot while it is swapped
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				size := store.Load().FileMaxSize
+				require.Contains(t, []int64{1024, 2048}, size)
+			}
+		}()
+	}
+
+	config.FileMaxSize = 2048
+	reloaded := store.Update(config)
+	wg.Wait()
+
+	require.Equal(t, int64(2048), store.Load().FileMaxSize)
+	require.Equal(t, reloaded, store.Snapshot())
+	require.False(t, reloaded.LoadedAt.Before(loaded.LoadedAt))
+}